@@ -186,4 +186,111 @@ func TestAlarm(t *testing.T) {
 		}).
 			ExpectCalls("PutMetricAlarm", "DescribeAlarms").Run(t)
 	})
+	t.Run("attach on instance and scalinggroup", func(t *testing.T) {
+		Template("attach alarm name=my-alarm instance=i-1234 scalinggroup=my-asg").Mock(&cloudwatchMock{
+			DescribeAlarmsFunc: func(param0 *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+				return &cloudwatch.DescribeAlarmsOutput{
+					MetricAlarms: []*cloudwatch.MetricAlarm{{
+						AlarmActions:       []*string{String("action_1")},
+						AlarmName:          String("my-alarm"),
+						ComparisonOperator: String("GreaterThanThreshold"),
+						Dimensions:         []*cloudwatch.Dimension{{Name: String("InstanceId"), Value: String("i-5678")}, {Name: String("Dim"), Value: String("val")}},
+						EvaluationPeriods:  Int64(2),
+						MetricName:         String("CPUUtilization"),
+						Namespace:          String("AWS/EC2"),
+						Period:             Int64(60),
+						Statistic:          String("Average"),
+						Threshold:          Float64(80),
+					}},
+				}, nil
+			},
+			PutMetricAlarmFunc: func(param0 *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+				return nil, nil
+			},
+		}).ExpectInput("PutMetricAlarm", &cloudwatch.PutMetricAlarmInput{
+			AlarmActions:       []*string{String("action_1")},
+			AlarmName:          String("my-alarm"),
+			ComparisonOperator: String("GreaterThanThreshold"),
+			Dimensions:         []*cloudwatch.Dimension{{Name: String("InstanceId"), Value: String("i-1234")}, {Name: String("AutoScalingGroupName"), Value: String("my-asg")}},
+			EvaluationPeriods:  Int64(2),
+			MetricName:         String("CPUUtilization"),
+			Namespace:          String("AWS/EC2"),
+			Period:             Int64(60),
+			Statistic:          String("Average"),
+			Threshold:          Float64(80),
+		}).ExpectInput("DescribeAlarms", &cloudwatch.DescribeAlarmsInput{
+			AlarmNames: []*string{String("my-alarm")},
+		}).
+			ExpectCommandResult("InstanceId:i-5678,Dim:val").ExpectCalls("PutMetricAlarm", "DescribeAlarms").Run(t)
+	})
+	t.Run("detach from instance", func(t *testing.T) {
+		Template("detach alarm name=my-alarm instance=i-1234").Mock(&cloudwatchMock{
+			DescribeAlarmsFunc: func(param0 *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+				return &cloudwatch.DescribeAlarmsOutput{
+					MetricAlarms: []*cloudwatch.MetricAlarm{{
+						AlarmName:          String("my-alarm"),
+						ComparisonOperator: String("GreaterThanThreshold"),
+						Dimensions:         []*cloudwatch.Dimension{{Name: String("InstanceId"), Value: String("i-1234")}, {Name: String("AutoScalingGroupName"), Value: String("my-asg")}},
+						EvaluationPeriods:  Int64(2),
+						MetricName:         String("CPUUtilization"),
+						Namespace:          String("AWS/EC2"),
+						Period:             Int64(60),
+						Statistic:          String("Average"),
+						Threshold:          Float64(80),
+					}},
+				}, nil
+			},
+			PutMetricAlarmFunc: func(param0 *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+				return nil, nil
+			},
+		}).ExpectInput("PutMetricAlarm", &cloudwatch.PutMetricAlarmInput{
+			AlarmName:          String("my-alarm"),
+			ComparisonOperator: String("GreaterThanThreshold"),
+			Dimensions:         []*cloudwatch.Dimension{{Name: String("AutoScalingGroupName"), Value: String("my-asg")}},
+			EvaluationPeriods:  Int64(2),
+			MetricName:         String("CPUUtilization"),
+			Namespace:          String("AWS/EC2"),
+			Period:             Int64(60),
+			Statistic:          String("Average"),
+			Threshold:          Float64(80),
+		}).ExpectInput("DescribeAlarms", &cloudwatch.DescribeAlarmsInput{
+			AlarmNames: []*string{String("my-alarm")},
+		}).
+			ExpectCommandResult("InstanceId:i-1234,AutoScalingGroupName:my-asg").ExpectCalls("PutMetricAlarm", "DescribeAlarms").Run(t)
+	})
+	t.Run("detach from instance restoring dimensions", func(t *testing.T) {
+		Template("detach alarm name=my-alarm instance=i-1234 dimensions=InstanceId:i-5678,Dim:val").Mock(&cloudwatchMock{
+			DescribeAlarmsFunc: func(param0 *cloudwatch.DescribeAlarmsInput) (*cloudwatch.DescribeAlarmsOutput, error) {
+				return &cloudwatch.DescribeAlarmsOutput{
+					MetricAlarms: []*cloudwatch.MetricAlarm{{
+						AlarmName:          String("my-alarm"),
+						ComparisonOperator: String("GreaterThanThreshold"),
+						Dimensions:         []*cloudwatch.Dimension{{Name: String("InstanceId"), Value: String("i-1234")}},
+						EvaluationPeriods:  Int64(2),
+						MetricName:         String("CPUUtilization"),
+						Namespace:          String("AWS/EC2"),
+						Period:             Int64(60),
+						Statistic:          String("Average"),
+						Threshold:          Float64(80),
+					}},
+				}, nil
+			},
+			PutMetricAlarmFunc: func(param0 *cloudwatch.PutMetricAlarmInput) (*cloudwatch.PutMetricAlarmOutput, error) {
+				return nil, nil
+			},
+		}).ExpectInput("PutMetricAlarm", &cloudwatch.PutMetricAlarmInput{
+			AlarmName:          String("my-alarm"),
+			ComparisonOperator: String("GreaterThanThreshold"),
+			Dimensions:         []*cloudwatch.Dimension{{Name: String("InstanceId"), Value: String("i-5678")}, {Name: String("Dim"), Value: String("val")}},
+			EvaluationPeriods:  Int64(2),
+			MetricName:         String("CPUUtilization"),
+			Namespace:          String("AWS/EC2"),
+			Period:             Int64(60),
+			Statistic:          String("Average"),
+			Threshold:          Float64(80),
+		}).ExpectInput("DescribeAlarms", &cloudwatch.DescribeAlarmsInput{
+			AlarmNames: []*string{String("my-alarm")},
+		}).
+			ExpectCommandResult("InstanceId:i-1234").ExpectCalls("PutMetricAlarm", "DescribeAlarms").Run(t)
+	})
 }
@@ -0,0 +1,87 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elasticache"
+)
+
+func TestCachecluster(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create cachecluster id=my-cache engine=Redis type=cache.T2.micro count=1 port=6379 "+
+			"securitygroups=sg-1234,sg-5678 subnetgroup=my-cache-subnets availabilityzone=eu-west-1a autoupgrade=true").
+			Mock(&elasticacheMock{
+				CreateCacheClusterFunc: func(param0 *elasticache.CreateCacheClusterInput) (*elasticache.CreateCacheClusterOutput, error) {
+					return &elasticache.CreateCacheClusterOutput{CacheCluster: &elasticache.CacheCluster{CacheClusterId: String("my-cache")}}, nil
+				},
+			}).ExpectInput("CreateCacheCluster", &elasticache.CreateCacheClusterInput{
+			CacheClusterId:            String("my-cache"),
+			Engine:                    String("redis"),
+			CacheNodeType:             String("cache.t2.micro"),
+			NumCacheNodes:             Int64(1),
+			Port:                      Int64(6379),
+			SecurityGroupIds:          []*string{String("sg-1234"), String("sg-5678")},
+			CacheSubnetGroupName:      String("my-cache-subnets"),
+			PreferredAvailabilityZone: String("eu-west-1a"),
+			AutoMinorVersionUpgrade:   Bool(true),
+		}).ExpectCommandResult("my-cache").ExpectCalls("CreateCacheCluster").
+			ExpectRevert("delete cachecluster id=my-cache").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete cachecluster id=my-cache snapshot=my-final-snapshot").
+			Mock(&elasticacheMock{
+				DeleteCacheClusterFunc: func(param0 *elasticache.DeleteCacheClusterInput) (*elasticache.DeleteCacheClusterOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteCacheCluster", &elasticache.DeleteCacheClusterInput{
+			CacheClusterId:          String("my-cache"),
+			FinalSnapshotIdentifier: String("my-final-snapshot"),
+		}).ExpectCalls("DeleteCacheCluster").Run(t)
+	})
+}
+
+func TestReplicationgroup(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create replicationgroup id=my-sessions description='user sessions' engine=redis type=cache.r6g.large count=2 failover=true encrypted=true").
+			Mock(&elasticacheMock{
+				CreateReplicationGroupFunc: func(param0 *elasticache.CreateReplicationGroupInput) (*elasticache.CreateReplicationGroupOutput, error) {
+					return &elasticache.CreateReplicationGroupOutput{ReplicationGroup: &elasticache.ReplicationGroup{ReplicationGroupId: String("my-sessions")}}, nil
+				},
+			}).ExpectInput("CreateReplicationGroup", &elasticache.CreateReplicationGroupInput{
+			ReplicationGroupId:          String("my-sessions"),
+			ReplicationGroupDescription: String("user sessions"),
+			Engine:                      String("redis"),
+			CacheNodeType:               String("cache.r6g.large"),
+			NumCacheClusters:            Int64(2),
+			AutomaticFailoverEnabled:    Bool(true),
+			AtRestEncryptionEnabled:     Bool(true),
+		}).ExpectCommandResult("my-sessions").ExpectCalls("CreateReplicationGroup").
+			ExpectRevert("delete replicationgroup id=my-sessions").Run(t)
+	})
+
+	t.Run("create from primary cluster", func(t *testing.T) {
+		Template("create replicationgroup id=my-sessions description=sessions primary-cluster=my-cache").
+			Mock(&elasticacheMock{
+				CreateReplicationGroupFunc: func(param0 *elasticache.CreateReplicationGroupInput) (*elasticache.CreateReplicationGroupOutput, error) {
+					return &elasticache.CreateReplicationGroupOutput{ReplicationGroup: &elasticache.ReplicationGroup{ReplicationGroupId: String("my-sessions")}}, nil
+				},
+			}).ExpectInput("CreateReplicationGroup", &elasticache.CreateReplicationGroupInput{
+			ReplicationGroupId:          String("my-sessions"),
+			ReplicationGroupDescription: String("sessions"),
+			PrimaryClusterId:            String("my-cache"),
+		}).ExpectCommandResult("my-sessions").ExpectCalls("CreateReplicationGroup").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete replicationgroup id=my-sessions retain-primary=true").
+			Mock(&elasticacheMock{
+				DeleteReplicationGroupFunc: func(param0 *elasticache.DeleteReplicationGroupInput) (*elasticache.DeleteReplicationGroupOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteReplicationGroup", &elasticache.DeleteReplicationGroupInput{
+			ReplicationGroupId:   String("my-sessions"),
+			RetainPrimaryCluster: Bool(true),
+		}).ExpectCalls("DeleteReplicationGroup").Run(t)
+	})
+}
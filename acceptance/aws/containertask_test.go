@@ -51,6 +51,48 @@ func TestContainerTask(t *testing.T) {
 			}).
 				ExpectCommandResult("arn:of:new:task").ExpectCalls("RunTask").Run(t)
 		})
+		t.Run("fargate service", func(t *testing.T) {
+			Template("start containertask name=my-new-service cluster=my-cluster-name desired-count=2 type=service "+
+				"deployment-name=prod launch-type=fargate subnets=sub-1234,sub-2345 securitygroups=sg-1234 public=true").
+				Mock(&ecsMock{
+					CreateServiceFunc: func(param0 *ecs.CreateServiceInput) (*ecs.CreateServiceOutput, error) {
+						return &ecs.CreateServiceOutput{
+							Service: &ecs.Service{ServiceArn: String("arn:of:my:new:service")},
+						}, nil
+					},
+				}).ExpectInput("CreateService", &ecs.CreateServiceInput{
+				TaskDefinition: String("my-new-service"),
+				Cluster:        String("my-cluster-name"),
+				DesiredCount:   Int64(2),
+				ServiceName:    String("prod"),
+				LaunchType:     String("FARGATE"),
+				NetworkConfiguration: &ecs.NetworkConfiguration{AwsvpcConfiguration: &ecs.AwsVpcConfiguration{
+					Subnets:        []*string{String("sub-1234"), String("sub-2345")},
+					SecurityGroups: []*string{String("sg-1234")},
+					AssignPublicIp: String("ENABLED"),
+				}},
+			}).
+				ExpectCommandResult("arn:of:my:new:service").ExpectCalls("CreateService").Run(t)
+		})
+		t.Run("fargate task", func(t *testing.T) {
+			Template("start containertask name=my-new-task cluster=my-cluster-name desired-count=1 type=task launch-type=fargate subnets=sub-1234").
+				Mock(&ecsMock{
+					RunTaskFunc: func(param0 *ecs.RunTaskInput) (*ecs.RunTaskOutput, error) {
+						return &ecs.RunTaskOutput{
+							Tasks: []*ecs.Task{{TaskArn: String("arn:of:new:task")}},
+						}, nil
+					},
+				}).ExpectInput("RunTask", &ecs.RunTaskInput{
+				TaskDefinition: String("my-new-task"),
+				Cluster:        String("my-cluster-name"),
+				Count:          Int64(1),
+				LaunchType:     String("FARGATE"),
+				NetworkConfiguration: &ecs.NetworkConfiguration{AwsvpcConfiguration: &ecs.AwsVpcConfiguration{
+					Subnets: []*string{String("sub-1234")},
+				}},
+			}).
+				ExpectCommandResult("arn:of:new:task").ExpectCalls("RunTask").Run(t)
+		})
 	})
 
 	t.Run("stop", func(t *testing.T) {
@@ -165,6 +207,30 @@ func TestContainerTask(t *testing.T) {
 				NetworkMode: String("bridge"),
 			}).ExpectCommandResult("arn:of:my:updated:definition").ExpectCalls("DescribeTaskDefinition", "RegisterTaskDefinition").Run(t)
 		})
+
+		t.Run("fargate task", func(t *testing.T) {
+			Template("attach containertask name=my-task container-name=nginx image=nginx memory-hard-limit=512 launch-type=fargate cpu=256 memory=512 execution-role=arn:of:execution:role").
+				Mock(&ecsMock{
+					DescribeTaskDefinitionFunc: func(param0 *ecs.DescribeTaskDefinitionInput) (*ecs.DescribeTaskDefinitionOutput, error) {
+						return nil, awserr.New("ClientException", "unable to describe task definition", errors.New("task does not exist"))
+					},
+					RegisterTaskDefinitionFunc: func(param0 *ecs.RegisterTaskDefinitionInput) (*ecs.RegisterTaskDefinitionOutput, error) {
+						return &ecs.RegisterTaskDefinitionOutput{TaskDefinition: &ecs.TaskDefinition{TaskDefinitionArn: String("arn:of:my:new:definition")}}, nil
+					},
+				}).ExpectInput("DescribeTaskDefinition", &ecs.DescribeTaskDefinitionInput{
+				TaskDefinition: String("my-task"),
+			}).ExpectInput("RegisterTaskDefinition", &ecs.RegisterTaskDefinitionInput{
+				Family:                  String("my-task"),
+				RequiresCompatibilities: []*string{String("FARGATE")},
+				NetworkMode:             String("awsvpc"),
+				Cpu:                     String("256"),
+				Memory:                  String("512"),
+				ExecutionRoleArn:        String("arn:of:execution:role"),
+				ContainerDefinitions: []*ecs.ContainerDefinition{
+					{Name: String("nginx"), Image: String("nginx"), Memory: Int64(512)},
+				},
+			}).ExpectCommandResult("arn:of:my:new:definition").ExpectCalls("DescribeTaskDefinition", "RegisterTaskDefinition").Run(t)
+		})
 	})
 
 	t.Run("detach", func(t *testing.T) {
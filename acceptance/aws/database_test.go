@@ -16,10 +16,17 @@ func TestDatabase(t *testing.T) {
 				"optiongroup=my-db-optiongroup port=3306 backupwindow=my-db-backupwindow maintenancewindow=my-db-maintenancewindow "+
 				"public=true encrypted=true storagetype=my-db-storagetype timezone=my-db-timezone vpcsecuritygroups=my-db-vpcsecuritygroup-1,my-db-vpcsecuritygroup-2").
 				Mock(&rdsMock{
+					DescribeDBEngineVersionsFunc: func(param0 *rds.DescribeDBEngineVersionsInput) (*rds.DescribeDBEngineVersionsOutput, error) {
+						return &rds.DescribeDBEngineVersionsOutput{DBEngineVersions: []*rds.DBEngineVersion{
+							{EngineVersion: String("my-db-previous-version")}, {EngineVersion: String("my-db-version")},
+						}}, nil
+					},
 					CreateDBInstanceFunc: func(param0 *rds.CreateDBInstanceInput) (*rds.CreateDBInstanceOutput, error) {
 						return &rds.CreateDBInstanceOutput{DBInstance: &rds.DBInstance{DBInstanceIdentifier: String("new-database-id")}}, nil
 					},
-				}).ExpectInput("CreateDBInstance", &rds.CreateDBInstanceInput{
+				}).ExpectInput("DescribeDBEngineVersions", &rds.DescribeDBEngineVersionsInput{
+				Engine: String("my-db-engine"),
+			}).ExpectInput("CreateDBInstance", &rds.CreateDBInstanceInput{
 				DBInstanceClass:         String("my-db-type"),
 				DBInstanceIdentifier:    String("my-db-id"),
 				Engine:                  String("my-db-engine"),
@@ -49,7 +56,7 @@ func TestDatabase(t *testing.T) {
 				StorageType:                String("my-db-storagetype"),
 				Timezone:                   String("my-db-timezone"),
 				VpcSecurityGroupIds:        []*string{String("my-db-vpcsecuritygroup-1"), String("my-db-vpcsecuritygroup-2")},
-			}).ExpectCommandResult("new-database-id").ExpectCalls("CreateDBInstance").Run(t)
+			}).ExpectCommandResult("new-database-id").ExpectCalls("DescribeDBEngineVersions", "CreateDBInstance").Run(t)
 		})
 		t.Run("read replica db", func(t *testing.T) {
 			Template("create database replica=my-replica-id replica-source=my-source-id").
@@ -102,6 +102,44 @@ func TestDistribution(t *testing.T) {
 			}).
 				ExpectCommandResult("new-distribution-id").ExpectCalls("CreateDistribution").Run(t)
 		})
+		t.Run("with origin access identity", func(t *testing.T) {
+			Template("create distribution origin-domain=mybucket.s3.amazonaws.com origin-access-identity=E2QWRUHAPOMQZL").
+				Mock(&cloudfrontMock{
+					CreateDistributionFunc: func(param0 *cloudfront.CreateDistributionInput) (*cloudfront.CreateDistributionOutput, error) {
+						return &cloudfront.CreateDistributionOutput{Distribution: &cloudfront.Distribution{Id: String("new-distribution-id")}}, nil
+					},
+				}).ExpectInput("CreateDistribution", &cloudfront.CreateDistributionInput{
+				DistributionConfig: &cloudfront.DistributionConfig{
+					Comment: aws.String("mybucket.s3.amazonaws.com"),
+					DefaultCacheBehavior: &cloudfront.DefaultCacheBehavior{
+						MinTTL: aws.Int64(0),
+						ForwardedValues: &cloudfront.ForwardedValues{
+							Cookies:     &cloudfront.CookiePreference{Forward: aws.String("all")},
+							QueryString: aws.Bool(true),
+						},
+						TrustedSigners: &cloudfront.TrustedSigners{
+							Enabled:  aws.Bool(false),
+							Quantity: aws.Int64(0),
+						},
+						TargetOriginId:       aws.String("orig_1"),
+						ViewerProtocolPolicy: aws.String("allow-all"),
+					},
+					Enabled:         aws.Bool(true),
+					CallerReference: String("callerReference"),
+					Origins: &cloudfront.Origins{
+						Items: []*cloudfront.Origin{
+							{
+								DomainName:     String("mybucket.s3.amazonaws.com"),
+								Id:             String("orig_1"),
+								S3OriginConfig: &cloudfront.S3OriginConfig{OriginAccessIdentity: String("origin-access-identity/cloudfront/E2QWRUHAPOMQZL")},
+							},
+						},
+						Quantity: Int64(1),
+					},
+				},
+			}).
+				ExpectCommandResult("new-distribution-id").ExpectCalls("CreateDistribution").Run(t)
+		})
 	})
 
 	t.Run("update", func(t *testing.T) {
@@ -4,19 +4,57 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
 )
 
 func TestElasticip(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create elasticip domain=vpc").
 			Mock(&ec2Mock{
+				DescribeAccountAttributesFunc: func(param0 *ec2.DescribeAccountAttributesInput) (*ec2.DescribeAccountAttributesOutput, error) {
+					return &ec2.DescribeAccountAttributesOutput{AccountAttributes: []*ec2.AccountAttribute{
+						{AttributeName: String("vpc-max-elastic-ips"), AttributeValues: []*ec2.AccountAttributeValue{{AttributeValue: String("5")}}},
+					}}, nil
+				},
+				DescribeAddressesFunc: func(param0 *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+					return &ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{{AllocationId: String("eipalloc-1")}}}, nil
+				},
 				AllocateAddressFunc: func(param0 *ec2.AllocateAddressInput) (*ec2.AllocateAddressOutput, error) {
 					return &ec2.AllocateAddressOutput{AllocationId: String("new-elasticip-allocation-id")}, nil
 				},
 			}).ExpectInput("AllocateAddress", &ec2.AllocateAddressInput{
 			Domain: String("vpc"),
+		}).ExpectInput("DescribeAccountAttributes", &ec2.DescribeAccountAttributesInput{
+			AttributeNames: []*string{String("vpc-max-elastic-ips")},
+		}).ExpectInput("DescribeAddresses", &ec2.DescribeAddressesInput{
+			Filters: []*ec2.Filter{{Name: String("domain"), Values: []*string{String("vpc")}}},
 		}).
-			ExpectCommandResult("new-elasticip-allocation-id").ExpectCalls("AllocateAddress").Run(t)
+			ExpectCommandResult("new-elasticip-allocation-id").ExpectCalls("DescribeAccountAttributes", "DescribeAddresses", "AllocateAddress").Run(t)
+	})
+
+	t.Run("create reusing unassociated", func(t *testing.T) {
+		g := graph.NewGraph()
+		g.AddResource(
+			resourcetest.ElasticIP("eipalloc-1").Prop(properties.Association, "eipassoc-1").Build(),
+			resourcetest.ElasticIP("eipalloc-2").Build(),
+		)
+		Template("create elasticip domain=vpc reuse=true").Graph(g).
+			Mock(&ec2Mock{
+				DescribeAccountAttributesFunc: func(param0 *ec2.DescribeAccountAttributesInput) (*ec2.DescribeAccountAttributesOutput, error) {
+					return &ec2.DescribeAccountAttributesOutput{AccountAttributes: []*ec2.AccountAttribute{
+						{AttributeName: String("vpc-max-elastic-ips"), AttributeValues: []*ec2.AccountAttributeValue{{AttributeValue: String("2")}}},
+					}}, nil
+				},
+				DescribeAddressesFunc: func(param0 *ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+					return &ec2.DescribeAddressesOutput{Addresses: []*ec2.Address{
+						{AllocationId: String("eipalloc-1"), AssociationId: String("eipassoc-1")},
+						{AllocationId: String("eipalloc-2"), PublicIp: String("1.2.3.4")},
+					}}, nil
+				},
+			}).IgnoreInput("DescribeAccountAttributes", "DescribeAddresses").
+			ExpectCommandResult("eipalloc-2").ExpectCalls("DescribeAccountAttributes", "DescribeAddresses").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
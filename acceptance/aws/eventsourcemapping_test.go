@@ -0,0 +1,47 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/lambda"
+)
+
+func TestEventsourcemapping(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create eventsourcemapping function=my-function source=arn:of:my:stream starting-position=LATEST batch-size=50 enabled=true").
+			Mock(&lambdaMock{
+				CreateEventSourceMappingFunc: func(param0 *lambda.CreateEventSourceMappingInput) (*lambda.EventSourceMappingConfiguration, error) {
+					return &lambda.EventSourceMappingConfiguration{UUID: String("new-mapping-id")}, nil
+				},
+			}).ExpectInput("CreateEventSourceMapping", &lambda.CreateEventSourceMappingInput{
+			FunctionName:     String("my-function"),
+			EventSourceArn:   String("arn:of:my:stream"),
+			StartingPosition: String("LATEST"),
+			BatchSize:        Int64(50),
+			Enabled:          Bool(true),
+		}).ExpectCommandResult("new-mapping-id").ExpectCalls("CreateEventSourceMapping").Run(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		Template("update eventsourcemapping id=mapping-id enabled=false").
+			Mock(&lambdaMock{
+				UpdateEventSourceMappingFunc: func(param0 *lambda.UpdateEventSourceMappingInput) (*lambda.EventSourceMappingConfiguration, error) {
+					return &lambda.EventSourceMappingConfiguration{UUID: String("mapping-id")}, nil
+				},
+			}).ExpectInput("UpdateEventSourceMapping", &lambda.UpdateEventSourceMappingInput{
+			UUID:    String("mapping-id"),
+			Enabled: Bool(false),
+		}).ExpectCalls("UpdateEventSourceMapping").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete eventsourcemapping id=mapping-id").
+			Mock(&lambdaMock{
+				DeleteEventSourceMappingFunc: func(param0 *lambda.DeleteEventSourceMappingInput) (*lambda.EventSourceMappingConfiguration, error) {
+					return &lambda.EventSourceMappingConfiguration{UUID: String("mapping-id")}, nil
+				},
+			}).ExpectInput("DeleteEventSourceMapping", &lambda.DeleteEventSourceMappingInput{
+			UUID: String("mapping-id"),
+		}).ExpectCalls("DeleteEventSourceMapping").Run(t)
+	})
+}
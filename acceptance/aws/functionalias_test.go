@@ -0,0 +1,46 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/lambda"
+)
+
+func TestFunctionalias(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create functionalias function=my-function name=prod version=4 description='production traffic'").
+			Mock(&lambdaMock{
+				CreateAliasFunc: func(param0 *lambda.CreateAliasInput) (*lambda.AliasConfiguration, error) {
+					return &lambda.AliasConfiguration{AliasArn: String("arn:of:my-function:prod")}, nil
+				},
+			}).ExpectInput("CreateAlias", &lambda.CreateAliasInput{
+			FunctionName:    String("my-function"),
+			Name:            String("prod"),
+			FunctionVersion: String("4"),
+			Description:     String("production traffic"),
+		}).ExpectCommandResult("arn:of:my-function:prod").ExpectCalls("CreateAlias").Run(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		Template("update functionalias function=my-function name=prod version=5").
+			Mock(&lambdaMock{
+				UpdateAliasFunc: func(param0 *lambda.UpdateAliasInput) (*lambda.AliasConfiguration, error) {
+					return &lambda.AliasConfiguration{AliasArn: String("arn:of:my-function:prod")}, nil
+				},
+			}).ExpectInput("UpdateAlias", &lambda.UpdateAliasInput{
+			FunctionName:    String("my-function"),
+			Name:            String("prod"),
+			FunctionVersion: String("5"),
+		}).ExpectCalls("UpdateAlias").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete functionalias function=my-function name=prod").
+			Mock(&lambdaMock{
+				DeleteAliasFunc: func(param0 *lambda.DeleteAliasInput) (*lambda.DeleteAliasOutput, error) { return nil, nil },
+			}).ExpectInput("DeleteAlias", &lambda.DeleteAliasInput{
+			FunctionName: String("my-function"),
+			Name:         String("prod"),
+		}).ExpectCalls("DeleteAlias").Run(t)
+	})
+}
@@ -0,0 +1,31 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/lambda"
+)
+
+func TestFunctionversion(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create functionversion function=my-function description='first release'").
+			Mock(&lambdaMock{
+				PublishVersionFunc: func(param0 *lambda.PublishVersionInput) (*lambda.FunctionConfiguration, error) {
+					return &lambda.FunctionConfiguration{FunctionArn: String("arn:of:my-function:4"), Version: String("4")}, nil
+				},
+			}).ExpectInput("PublishVersion", &lambda.PublishVersionInput{
+			FunctionName: String("my-function"),
+			Description:  String("first release"),
+		}).ExpectCommandResult("4").ExpectCalls("PublishVersion").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete functionversion function=my-function version=4").
+			Mock(&lambdaMock{
+				DeleteFunctionFunc: func(param0 *lambda.DeleteFunctionInput) (*lambda.DeleteFunctionOutput, error) { return nil, nil },
+			}).ExpectInput("DeleteFunction", &lambda.DeleteFunctionInput{
+			FunctionName: String("my-function"),
+			Qualifier:    String("4"),
+		}).ExpectCalls("DeleteFunction").Run(t)
+	})
+}
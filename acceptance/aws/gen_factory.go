@@ -19,20 +19,25 @@ package awsat
 
 import (
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/wallix/awless/aws/spec"
@@ -86,12 +91,24 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "attachintegration":
+		return func() interface{} {
+			cmd := awsspec.NewAttachIntegration(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
 	case "attachinternetgateway":
 		return func() interface{} {
 			cmd := awsspec.NewAttachInternetgateway(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "attachkeygrant":
+		return func() interface{} {
+			cmd := awsspec.NewAttachKeygrant(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(kmsiface.KMSAPI))
+			return cmd
+		}
 	case "attachmfadevice":
 		return func() interface{} {
 			cmd := awsspec.NewAttachMfadevice(nil, f.Graph, f.Logger)
@@ -230,6 +247,30 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(cloudwatchiface.CloudWatchAPI))
 			return cmd
 		}
+	case "createalias":
+		return func() interface{} {
+			cmd := awsspec.NewCreateAlias(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(kmsiface.KMSAPI))
+			return cmd
+		}
+	case "createapideployment":
+		return func() interface{} {
+			cmd := awsspec.NewCreateApideployment(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
+	case "createapimethod":
+		return func() interface{} {
+			cmd := awsspec.NewCreateApimethod(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
+	case "createapiresource":
+		return func() interface{} {
+			cmd := awsspec.NewCreateApiresource(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
 	case "createappscalingpolicy":
 		return func() interface{} {
 			cmd := awsspec.NewCreateAppscalingpolicy(nil, f.Graph, f.Logger)
@@ -248,6 +289,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(s3iface.S3API))
 			return cmd
 		}
+	case "createcachecluster":
+		return func() interface{} {
+			cmd := awsspec.NewCreateCachecluster(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(elasticacheiface.ElastiCacheAPI))
+			return cmd
+		}
 	case "createcertificate":
 		return func() interface{} {
 			cmd := awsspec.NewCreateCertificate(nil, f.Graph, f.Logger)
@@ -284,18 +331,42 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "createeventsourcemapping":
+		return func() interface{} {
+			cmd := awsspec.NewCreateEventsourcemapping(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
+			return cmd
+		}
 	case "createfunction":
 		return func() interface{} {
 			cmd := awsspec.NewCreateFunction(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
 			return cmd
 		}
+	case "createfunctionalias":
+		return func() interface{} {
+			cmd := awsspec.NewCreateFunctionalias(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
+			return cmd
+		}
+	case "createfunctionversion":
+		return func() interface{} {
+			cmd := awsspec.NewCreateFunctionversion(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
+			return cmd
+		}
 	case "creategroup":
 		return func() interface{} {
 			cmd := awsspec.NewCreateGroup(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(iamiface.IAMAPI))
 			return cmd
 		}
+	case "createhealthcheck":
+		return func() interface{} {
+			cmd := awsspec.NewCreateHealthcheck(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(route53iface.Route53API))
+			return cmd
+		}
 	case "createimage":
 		return func() interface{} {
 			cmd := awsspec.NewCreateImage(nil, f.Graph, f.Logger)
@@ -320,6 +391,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "createkey":
+		return func() interface{} {
+			cmd := awsspec.NewCreateKey(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(kmsiface.KMSAPI))
+			return cmd
+		}
 	case "createkeypair":
 		return func() interface{} {
 			cmd := awsspec.NewCreateKeypair(nil, f.Graph, f.Logger)
@@ -332,6 +409,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(autoscalingiface.AutoScalingAPI))
 			return cmd
 		}
+	case "createlaunchtemplate":
+		return func() interface{} {
+			cmd := awsspec.NewCreateLaunchtemplate(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(ec2iface.EC2API))
+			return cmd
+		}
 	case "createlistener":
 		return func() interface{} {
 			cmd := awsspec.NewCreateListener(nil, f.Graph, f.Logger)
@@ -368,12 +451,24 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "createoriginaccessidentity":
+		return func() interface{} {
+			cmd := awsspec.NewCreateOriginaccessidentity(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(cloudfrontiface.CloudFrontAPI))
+			return cmd
+		}
 	case "createpolicy":
 		return func() interface{} {
 			cmd := awsspec.NewCreatePolicy(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(iamiface.IAMAPI))
 			return cmd
 		}
+	case "createpresignedurl":
+		return func() interface{} {
+			cmd := awsspec.NewCreatePresignedurl(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(s3iface.S3API))
+			return cmd
+		}
 	case "createqueue":
 		return func() interface{} {
 			cmd := awsspec.NewCreateQueue(nil, f.Graph, f.Logger)
@@ -386,12 +481,24 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(route53iface.Route53API))
 			return cmd
 		}
+	case "createreplicationgroup":
+		return func() interface{} {
+			cmd := awsspec.NewCreateReplicationgroup(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(elasticacheiface.ElastiCacheAPI))
+			return cmd
+		}
 	case "createrepository":
 		return func() interface{} {
 			cmd := awsspec.NewCreateRepository(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(ecriface.ECRAPI))
 			return cmd
 		}
+	case "createrestapi":
+		return func() interface{} {
+			cmd := awsspec.NewCreateRestapi(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
 	case "createrole":
 		return func() interface{} {
 			cmd := awsspec.NewCreateRole(nil, f.Graph, f.Logger)
@@ -440,12 +547,24 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "createspotfleet":
+		return func() interface{} {
+			cmd := awsspec.NewCreateSpotfleet(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(ec2iface.EC2API))
+			return cmd
+		}
 	case "createstack":
 		return func() interface{} {
 			cmd := awsspec.NewCreateStack(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(cloudformationiface.CloudFormationAPI))
 			return cmd
 		}
+	case "createstatemachine":
+		return func() interface{} {
+			cmd := awsspec.NewCreateStatemachine(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(sfniface.SFNAPI))
+			return cmd
+		}
 	case "createsubnet":
 		return func() interface{} {
 			cmd := awsspec.NewCreateSubnet(nil, f.Graph, f.Logger)
@@ -458,6 +577,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(snsiface.SNSAPI))
 			return cmd
 		}
+	case "createtable":
+		return func() interface{} {
+			cmd := awsspec.NewCreateTable(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(dynamodbiface.DynamoDBAPI))
+			return cmd
+		}
 	case "createtag":
 		return func() interface{} {
 			cmd := awsspec.NewCreateTag(nil, f.Graph, f.Logger)
@@ -476,6 +601,18 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(snsiface.SNSAPI))
 			return cmd
 		}
+	case "createtrafficpolicy":
+		return func() interface{} {
+			cmd := awsspec.NewCreateTrafficpolicy(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(route53iface.Route53API))
+			return cmd
+		}
+	case "createtrafficpolicyinstance":
+		return func() interface{} {
+			cmd := awsspec.NewCreateTrafficpolicyinstance(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(route53iface.Route53API))
+			return cmd
+		}
 	case "createuser":
 		return func() interface{} {
 			cmd := awsspec.NewCreateUser(nil, f.Graph, f.Logger)
@@ -512,6 +649,36 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(cloudwatchiface.CloudWatchAPI))
 			return cmd
 		}
+	case "deletealias":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteAlias(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(kmsiface.KMSAPI))
+			return cmd
+		}
+	case "deleteapideployment":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteApideployment(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
+	case "deleteapimethod":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteApimethod(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
+	case "deleteapiresource":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteApiresource(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
+	case "deleteapistage":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteApistage(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
 	case "deleteappscalingpolicy":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteAppscalingpolicy(nil, f.Graph, f.Logger)
@@ -530,6 +697,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(s3iface.S3API))
 			return cmd
 		}
+	case "deletecachecluster":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteCachecluster(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(elasticacheiface.ElastiCacheAPI))
+			return cmd
+		}
 	case "deletecertificate":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteCertificate(nil, f.Graph, f.Logger)
@@ -572,18 +745,42 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "deleteeventsourcemapping":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteEventsourcemapping(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
+			return cmd
+		}
 	case "deletefunction":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteFunction(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
 			return cmd
 		}
+	case "deletefunctionalias":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteFunctionalias(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
+			return cmd
+		}
+	case "deletefunctionversion":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteFunctionversion(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
+			return cmd
+		}
 	case "deletegroup":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteGroup(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(iamiface.IAMAPI))
 			return cmd
 		}
+	case "deletehealthcheck":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteHealthcheck(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(route53iface.Route53API))
+			return cmd
+		}
 	case "deleteimage":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteImage(nil, f.Graph, f.Logger)
@@ -608,6 +805,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "deletekey":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteKey(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(kmsiface.KMSAPI))
+			return cmd
+		}
 	case "deletekeypair":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteKeypair(nil, f.Graph, f.Logger)
@@ -620,6 +823,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(autoscalingiface.AutoScalingAPI))
 			return cmd
 		}
+	case "deletelaunchtemplate":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteLaunchtemplate(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(ec2iface.EC2API))
+			return cmd
+		}
 	case "deletelistener":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteListener(nil, f.Graph, f.Logger)
@@ -656,6 +865,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "deleteoriginaccessidentity":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteOriginaccessidentity(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(cloudfrontiface.CloudFrontAPI))
+			return cmd
+		}
 	case "deletepolicy":
 		return func() interface{} {
 			cmd := awsspec.NewDeletePolicy(nil, f.Graph, f.Logger)
@@ -674,12 +889,24 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(route53iface.Route53API))
 			return cmd
 		}
+	case "deletereplicationgroup":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteReplicationgroup(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(elasticacheiface.ElastiCacheAPI))
+			return cmd
+		}
 	case "deleterepository":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteRepository(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(ecriface.ECRAPI))
 			return cmd
 		}
+	case "deleterestapi":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteRestapi(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
 	case "deleterole":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteRole(nil, f.Graph, f.Logger)
@@ -728,12 +955,24 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "deletespotfleet":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteSpotfleet(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(ec2iface.EC2API))
+			return cmd
+		}
 	case "deletestack":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteStack(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(cloudformationiface.CloudFormationAPI))
 			return cmd
 		}
+	case "deletestatemachine":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteStatemachine(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(sfniface.SFNAPI))
+			return cmd
+		}
 	case "deletesubnet":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteSubnet(nil, f.Graph, f.Logger)
@@ -746,6 +985,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(snsiface.SNSAPI))
 			return cmd
 		}
+	case "deletetable":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteTable(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(dynamodbiface.DynamoDBAPI))
+			return cmd
+		}
 	case "deletetag":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteTag(nil, f.Graph, f.Logger)
@@ -764,6 +1009,18 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(snsiface.SNSAPI))
 			return cmd
 		}
+	case "deletetrafficpolicy":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteTrafficpolicy(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(route53iface.Route53API))
+			return cmd
+		}
+	case "deletetrafficpolicyinstance":
+		return func() interface{} {
+			cmd := awsspec.NewDeleteTrafficpolicyinstance(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(route53iface.Route53API))
+			return cmd
+		}
 	case "deleteuser":
 		return func() interface{} {
 			cmd := awsspec.NewDeleteUser(nil, f.Graph, f.Logger)
@@ -818,12 +1075,24 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "detachintegration":
+		return func() interface{} {
+			cmd := awsspec.NewDetachIntegration(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(apigatewayiface.APIGatewayAPI))
+			return cmd
+		}
 	case "detachinternetgateway":
 		return func() interface{} {
 			cmd := awsspec.NewDetachInternetgateway(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "detachkeygrant":
+		return func() interface{} {
+			cmd := awsspec.NewDetachKeygrant(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(kmsiface.KMSAPI))
+			return cmd
+		}
 	case "detachmfadevice":
 		return func() interface{} {
 			cmd := awsspec.NewDetachMfadevice(nil, f.Graph, f.Logger)
@@ -914,6 +1183,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "startstatemachine":
+		return func() interface{} {
+			cmd := awsspec.NewStartStatemachine(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(sfniface.SFNAPI))
+			return cmd
+		}
 	case "stopalarm":
 		return func() interface{} {
 			cmd := awsspec.NewStopAlarm(nil, f.Graph, f.Logger)
@@ -938,6 +1213,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "syncs3object":
+		return func() interface{} {
+			cmd := awsspec.NewSyncS3object(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(s3iface.S3API))
+			return cmd
+		}
 	case "updatebucket":
 		return func() interface{} {
 			cmd := awsspec.NewUpdateBucket(nil, f.Graph, f.Logger)
@@ -956,6 +1237,18 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(cloudfrontiface.CloudFrontAPI))
 			return cmd
 		}
+	case "updateeventsourcemapping":
+		return func() interface{} {
+			cmd := awsspec.NewUpdateEventsourcemapping(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
+			return cmd
+		}
+	case "updatefunctionalias":
+		return func() interface{} {
+			cmd := awsspec.NewUpdateFunctionalias(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(lambdaiface.LambdaAPI))
+			return cmd
+		}
 	case "updateimage":
 		return func() interface{} {
 			cmd := awsspec.NewUpdateImage(nil, f.Graph, f.Logger)
@@ -968,12 +1261,24 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "updatelaunchtemplate":
+		return func() interface{} {
+			cmd := awsspec.NewUpdateLaunchtemplate(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(ec2iface.EC2API))
+			return cmd
+		}
 	case "updateloginprofile":
 		return func() interface{} {
 			cmd := awsspec.NewUpdateLoginprofile(nil, f.Graph, f.Logger)
 			cmd.SetApi(f.Mock.(iamiface.IAMAPI))
 			return cmd
 		}
+	case "updateoriginaccessidentity":
+		return func() interface{} {
+			cmd := awsspec.NewUpdateOriginaccessidentity(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(cloudfrontiface.CloudFrontAPI))
+			return cmd
+		}
 	case "updatepolicy":
 		return func() interface{} {
 			cmd := awsspec.NewUpdatePolicy(nil, f.Graph, f.Logger)
@@ -1004,6 +1309,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "updatesnapshot":
+		return func() interface{} {
+			cmd := awsspec.NewUpdateSnapshot(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(ec2iface.EC2API))
+			return cmd
+		}
 	case "updatestack":
 		return func() interface{} {
 			cmd := awsspec.NewUpdateStack(nil, f.Graph, f.Logger)
@@ -1016,6 +1327,12 @@ func (f *AcceptanceFactory) Build(key string) func() interface{} {
 			cmd.SetApi(f.Mock.(ec2iface.EC2API))
 			return cmd
 		}
+	case "updatetable":
+		return func() interface{} {
+			cmd := awsspec.NewUpdateTable(nil, f.Graph, f.Logger)
+			cmd.SetApi(f.Mock.(dynamodbiface.DynamoDBAPI))
+			return cmd
+		}
 	case "updatetargetgroup":
 		return func() interface{} {
 			cmd := awsspec.NewUpdateTargetgroup(nil, f.Graph, f.Logger)
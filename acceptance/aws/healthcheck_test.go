@@ -0,0 +1,38 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+func TestHealthcheck(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create healthcheck callerreference=web-check type=https domain=www.example.com port=443 path=/health interval=10 failure-threshold=3").Mock(&route53Mock{
+			CreateHealthCheckFunc: func(input *route53.CreateHealthCheckInput) (*route53.CreateHealthCheckOutput, error) {
+				return &route53.CreateHealthCheckOutput{HealthCheck: &route53.HealthCheck{Id: String("new-healthcheck-id")}}, nil
+			},
+		}).ExpectInput("CreateHealthCheck", &route53.CreateHealthCheckInput{
+			CallerReference: String("web-check"),
+			HealthCheckConfig: &route53.HealthCheckConfig{
+				Type:                     String("HTTPS"),
+				FullyQualifiedDomainName: String("www.example.com"),
+				Port:                     Int64(443),
+				ResourcePath:             String("/health"),
+				RequestInterval:          Int64(10),
+				FailureThreshold:         Int64(3),
+			},
+		}).ExpectCommandResult("new-healthcheck-id").ExpectCalls("CreateHealthCheck").
+			ExpectRevert("delete healthcheck id=new-healthcheck-id").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete healthcheck id=any-healthcheck-id").Mock(&route53Mock{
+			DeleteHealthCheckFunc: func(input *route53.DeleteHealthCheckInput) (*route53.DeleteHealthCheckOutput, error) {
+				return nil, nil
+			},
+		}).ExpectInput("DeleteHealthCheck", &route53.DeleteHealthCheckInput{
+			HealthCheckId: String("any-healthcheck-id"),
+		}).ExpectCalls("DeleteHealthCheck").Run(t)
+	})
+}
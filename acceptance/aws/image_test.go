@@ -1,8 +1,11 @@
 package awsat
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 )
 
@@ -34,6 +37,29 @@ func TestImage(t *testing.T) {
 				NoReboot:    Bool(true),
 			}).ExpectCommandResult("new-image-id").ExpectCalls("CreateImage").Run(t)
 		})
+
+		t.Run("and wait", func(t *testing.T) {
+			Template("create image name=my-image-name instance=my-instance-id wait=true wait-timeout=1800").
+				Mock(&ec2Mock{
+					CreateImageFunc: func(param0 *ec2.CreateImageInput) (*ec2.CreateImageOutput, error) {
+						return &ec2.CreateImageOutput{ImageId: String("new-image-id")}, nil
+					},
+					WaitUntilImageAvailableWithContextFunc: func(param0 aws.Context, param1 *ec2.DescribeImagesInput, param2 ...request.WaiterOption) error {
+						if got, want := param1, (&ec2.DescribeImagesInput{ImageIds: []*string{String("new-image-id")}}); !reflect.DeepEqual(got, want) {
+							t.Fatalf("got %#v, want %#v", got, want)
+						}
+						if _, hasDeadline := param0.Deadline(); !hasDeadline {
+							t.Fatal("expected wait with a deadline")
+						}
+						return nil
+					},
+				}).ExpectInput("CreateImage", &ec2.CreateImageInput{
+				Name:       String("my-image-name"),
+				InstanceId: String("my-instance-id"),
+				NoReboot:   Bool(true),
+			}).IgnoreInput("WaitUntilImageAvailableWithContext").
+				ExpectCommandResult("new-image-id").ExpectCalls("CreateImage", "WaitUntilImageAvailableWithContext").Run(t)
+		})
 	})
 
 	t.Run("copy", func(t *testing.T) {
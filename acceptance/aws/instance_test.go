@@ -21,6 +21,7 @@ func TestInstance(t *testing.T) {
 
 			Template("create instance distro=canonical name=myinstance subnet=sub_1 type=t2.nano count=1").
 				Mock(&ec2Mock{
+					DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
 					RunInstancesFunc: func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
 						return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: String("new-instance-id")}}}, nil
 					},
@@ -40,7 +41,13 @@ func TestInstance(t *testing.T) {
 				Tags: []*ec2.Tag{
 					{Key: String("Name"), Value: String("myinstance")},
 				},
-			}).ExpectCommandResult("new-instance-id").ExpectCalls("RunInstances", "CreateTagsRequest").
+			}).ExpectInput("DescribeReservedInstancesOfferings", &ec2.DescribeReservedInstancesOfferingsInput{
+				IncludeMarketplace: Bool(false),
+				InstanceTenancy:    String("default"),
+				OfferingClass:      String("standard"),
+				OfferingType:       String("No Upfront"),
+				ProductDescription: String("Linux/UNIX"),
+			}).ExpectCommandResult("new-instance-id").ExpectCalls("DescribeReservedInstancesOfferings", "RunInstances", "CreateTagsRequest").
 				ExpectRevert("delete instance id=new-instance-id").Run(t)
 		})
 
@@ -53,6 +60,7 @@ func TestInstance(t *testing.T) {
 				"name=myinstance subnet=sub_1 type=t2.nano keypair=mykp ip=10.2.3.4 "+
 				"userdata="+userdataFile+" securitygroup=sg-1234 lock=true role=myrole").
 				Mock(&ec2Mock{
+					DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
 					RunInstancesFunc: func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
 						return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: String("new-instance-id")}}}, nil
 					},
@@ -78,12 +86,48 @@ func TestInstance(t *testing.T) {
 				Tags: []*ec2.Tag{
 					{Key: String("Name"), Value: String("myinstance")},
 				},
-			}).ExpectCommandResult("new-instance-id").ExpectCalls("RunInstances", "CreateTagsRequest").Run(t)
+			}).ExpectCommandResult("new-instance-id").IgnoreInput("DescribeReservedInstancesOfferings").ExpectCalls("DescribeReservedInstancesOfferings", "RunInstances", "CreateTagsRequest").Run(t)
+		})
+
+		t.Run("spot", func(t *testing.T) {
+			Template("create instance image=ami-1234 name=myinstance subnet=sub_1 type=m4.large count=1 spot=true spotprice=0.05 spotinterruption=Stop").
+				Mock(&ec2Mock{
+					DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
+					RunInstancesFunc: func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+						return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: String("new-instance-id")}}}, nil
+					},
+					CreateTagsRequestFunc: func(input *ec2.CreateTagsInput) (req *request.Request, output *ec2.CreateTagsOutput) {
+						output = &ec2.CreateTagsOutput{}
+						req = request.New(aws.Config{}, metadata.ClientInfo{}, request.Handlers{}, nil, &request.Operation{}, input, output)
+						return
+					},
+				}).ExpectInput("RunInstances", &ec2.RunInstancesInput{
+				SubnetId:     String("sub_1"),
+				ImageId:      String("ami-1234"),
+				InstanceType: String("m4.large"),
+				MinCount:     Int64(1),
+				MaxCount:     Int64(1),
+				InstanceMarketOptions: &ec2.InstanceMarketOptionsRequest{
+					MarketType: String("spot"),
+					SpotOptions: &ec2.SpotMarketOptions{
+						MaxPrice:                     String("0.05"),
+						InstanceInterruptionBehavior: String("stop"),
+						SpotInstanceType:             String("persistent"),
+					},
+				},
+			}).ExpectInput("CreateTagsRequest", &ec2.CreateTagsInput{
+				Resources: []*string{String("new-instance-id")},
+				Tags: []*ec2.Tag{
+					{Key: String("Name"), Value: String("myinstance")},
+				},
+			}).ExpectCommandResult("new-instance-id").IgnoreInput("DescribeReservedInstancesOfferings").ExpectCalls("DescribeReservedInstancesOfferings", "RunInstances", "CreateTagsRequest").
+				ExpectRevert("delete instance cancel-spot-requests=true id=new-instance-id").Run(t)
 		})
 	})
 
 	t.Run("update", func(t *testing.T) {
 		Template("update instance id=id-1234 type=t2.micro lock=true").Mock(&ec2Mock{
+			DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
 			ModifyInstanceAttributeFunc: func(param0 *ec2.ModifyInstanceAttributeInput) (*ec2.ModifyInstanceAttributeOutput, error) {
 				return nil, nil
 			},
@@ -92,7 +136,7 @@ func TestInstance(t *testing.T) {
 			InstanceType:          &ec2.AttributeValue{Value: String("t2.micro")},
 			DisableApiTermination: &ec2.AttributeBooleanValue{Value: Bool(true)},
 		}).
-			ExpectCalls("ModifyInstanceAttribute").Run(t)
+			IgnoreInput("DescribeReservedInstancesOfferings").ExpectCalls("DescribeReservedInstancesOfferings", "ModifyInstanceAttribute").Run(t)
 	})
 
 	t.Run("delete", func(t *testing.T) {
@@ -109,6 +153,24 @@ func TestInstance(t *testing.T) {
 			}).ExpectInput("TerminateInstances", &ec2.TerminateInstancesInput{InstanceIds: []*string{String("id-1234"), String("id-2345")}}).
 				ExpectCalls("TerminateInstances").Run(t)
 		})
+
+		t.Run("cancel spot requests", func(t *testing.T) {
+			Template("delete instance ids=id-1234,id-2345 cancel-spot-requests=true").Mock(&ec2Mock{
+				DescribeInstancesFunc: func(param0 *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+					return &ec2.DescribeInstancesOutput{Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{
+						{InstanceId: String("id-1234"), SpotInstanceRequestId: String("sir-1234")},
+						{InstanceId: String("id-2345")},
+					}}}}, nil
+				},
+				CancelSpotInstanceRequestsFunc: func(param0 *ec2.CancelSpotInstanceRequestsInput) (*ec2.CancelSpotInstanceRequestsOutput, error) {
+					return nil, nil
+				},
+				TerminateInstancesFunc: func(param0 *ec2.TerminateInstancesInput) (*ec2.TerminateInstancesOutput, error) { return nil, nil },
+			}).ExpectInput("DescribeInstances", &ec2.DescribeInstancesInput{InstanceIds: []*string{String("id-1234"), String("id-2345")}}).
+				ExpectInput("CancelSpotInstanceRequests", &ec2.CancelSpotInstanceRequestsInput{SpotInstanceRequestIds: []*string{String("sir-1234")}}).
+				ExpectInput("TerminateInstances", &ec2.TerminateInstancesInput{InstanceIds: []*string{String("id-1234"), String("id-2345")}}).
+				ExpectCalls("DescribeInstances", "CancelSpotInstanceRequests", "TerminateInstances").Run(t)
+		})
 	})
 
 	t.Run("start", func(t *testing.T) {
@@ -231,3 +293,15 @@ func generateTmpFile(content string) (*os.File, string, func()) {
 	}
 	return file, file.Name(), cleanup
 }
+
+// instanceTypesOfferings returns reserved instances offerings of the given instance types,
+// the instance types offered in the region being validated against them
+func instanceTypesOfferings(types ...string) func(*ec2.DescribeReservedInstancesOfferingsInput) (*ec2.DescribeReservedInstancesOfferingsOutput, error) {
+	return func(input *ec2.DescribeReservedInstancesOfferingsInput) (*ec2.DescribeReservedInstancesOfferingsOutput, error) {
+		out := &ec2.DescribeReservedInstancesOfferingsOutput{}
+		for _, typ := range types {
+			out.ReservedInstancesOfferings = append(out.ReservedInstancesOfferings, &ec2.ReservedInstancesOffering{InstanceType: String(typ)})
+		}
+		return out, nil
+	}
+}
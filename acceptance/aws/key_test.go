@@ -0,0 +1,94 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+func TestKey(t *testing.T) {
+	policy := `{"Statement": [{"Sid": "root", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "kms:*", "Resource": "*"}]}`
+	_, policyFilePath, policyClean := generateTmpFile(policy)
+	defer policyClean()
+
+	t.Run("create", func(t *testing.T) {
+		Template("create key description=backups usage=encrypt_decrypt policy-file="+policyFilePath).
+			Mock(&kmsMock{
+				CreateKeyFunc: func(param0 *kms.CreateKeyInput) (*kms.CreateKeyOutput, error) {
+					return &kms.CreateKeyOutput{KeyMetadata: &kms.KeyMetadata{KeyId: String("new-key-id")}}, nil
+				},
+			}).ExpectInput("CreateKey", &kms.CreateKeyInput{
+			Description: String("backups"),
+			KeyUsage:    String("ENCRYPT_DECRYPT"),
+			Policy:      String(policy),
+		}).ExpectCommandResult("new-key-id").ExpectCalls("CreateKey").
+			ExpectRevert("delete key id=new-key-id").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete key id=key-id pending-days=7").
+			Mock(&kmsMock{
+				ScheduleKeyDeletionFunc: func(param0 *kms.ScheduleKeyDeletionInput) (*kms.ScheduleKeyDeletionOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("ScheduleKeyDeletion", &kms.ScheduleKeyDeletionInput{
+			KeyId:               String("key-id"),
+			PendingWindowInDays: Int64(7),
+		}).ExpectCalls("ScheduleKeyDeletion").Run(t)
+	})
+}
+
+func TestAlias(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create alias key=key-id name=alias/backups").
+			Mock(&kmsMock{
+				CreateAliasFunc: func(param0 *kms.CreateAliasInput) (*kms.CreateAliasOutput, error) {
+					return &kms.CreateAliasOutput{}, nil
+				},
+			}).ExpectInput("CreateAlias", &kms.CreateAliasInput{
+			AliasName:   String("alias/backups"),
+			TargetKeyId: String("key-id"),
+		}).ExpectCommandResult("alias/backups").ExpectCalls("CreateAlias").
+			ExpectRevert("delete alias name=alias/backups").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete alias name=alias/backups").
+			Mock(&kmsMock{
+				DeleteAliasFunc: func(param0 *kms.DeleteAliasInput) (*kms.DeleteAliasOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteAlias", &kms.DeleteAliasInput{
+			AliasName: String("alias/backups"),
+		}).ExpectCalls("DeleteAlias").Run(t)
+	})
+}
+
+func TestKeygrant(t *testing.T) {
+	t.Run("attach", func(t *testing.T) {
+		Template("attach keygrant key=key-id grantee=arn:aws:iam::123456789012:role/backup operations=Encrypt,Decrypt name=backup-grant").
+			Mock(&kmsMock{
+				CreateGrantFunc: func(param0 *kms.CreateGrantInput) (*kms.CreateGrantOutput, error) {
+					return &kms.CreateGrantOutput{GrantId: String("new-grant-id")}, nil
+				},
+			}).ExpectInput("CreateGrant", &kms.CreateGrantInput{
+			KeyId:            String("key-id"),
+			GranteePrincipal: String("arn:aws:iam::123456789012:role/backup"),
+			Operations:       []*string{String("Encrypt"), String("Decrypt")},
+			Name:             String("backup-grant"),
+		}).ExpectCommandResult("new-grant-id").ExpectCalls("CreateGrant").
+			ExpectRevert("detach keygrant id=new-grant-id key=key-id").Run(t)
+	})
+
+	t.Run("detach", func(t *testing.T) {
+		Template("detach keygrant id=grant-id key=key-id").
+			Mock(&kmsMock{
+				RevokeGrantFunc: func(param0 *kms.RevokeGrantInput) (*kms.RevokeGrantOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("RevokeGrant", &kms.RevokeGrantInput{
+			GrantId: String("grant-id"),
+			KeyId:   String("key-id"),
+		}).ExpectCalls("RevokeGrant").Run(t)
+	})
+}
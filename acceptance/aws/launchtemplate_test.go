@@ -0,0 +1,81 @@
+package awsat
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestLaunchtemplate(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		_, userdataFile, cleanup := generateTmpFile("#!/bin/bash")
+		defer cleanup()
+
+		Template("create launchtemplate name=web-template image=ami-1234 type=t2.micro keypair=mykp securitygroups=sg-1234,sg-5678 "+
+			"role=myrole lock=true description=first userdata="+userdataFile).
+			Mock(&ec2Mock{
+				DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
+				CreateLaunchTemplateFunc: func(param0 *ec2.CreateLaunchTemplateInput) (*ec2.CreateLaunchTemplateOutput, error) {
+					return &ec2.CreateLaunchTemplateOutput{LaunchTemplate: &ec2.LaunchTemplate{LaunchTemplateId: String("lt-1234")}}, nil
+				},
+			}).ExpectInput("CreateLaunchTemplate", &ec2.CreateLaunchTemplateInput{
+			LaunchTemplateName: String("web-template"),
+			VersionDescription: String("first"),
+			LaunchTemplateData: &ec2.RequestLaunchTemplateData{
+				ImageId:               String("ami-1234"),
+				InstanceType:          String("t2.micro"),
+				KeyName:               String("mykp"),
+				SecurityGroupIds:      []*string{String("sg-1234"), String("sg-5678")},
+				IamInstanceProfile:    &ec2.LaunchTemplateIamInstanceProfileSpecificationRequest{Name: String("myrole")},
+				DisableApiTermination: Bool(true),
+				UserData:              String(base64.StdEncoding.EncodeToString([]byte("#!/bin/bash"))),
+			},
+		}).ExpectCommandResult("lt-1234").IgnoreInput("DescribeReservedInstancesOfferings").ExpectCalls("DescribeReservedInstancesOfferings", "CreateLaunchTemplate").
+			ExpectRevert("delete launchtemplate id=lt-1234").Run(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		t.Run("new version", func(t *testing.T) {
+			Template("update launchtemplate id=lt-1234 source-version=2 type=m4.large").
+				Mock(&ec2Mock{
+					DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
+					CreateLaunchTemplateVersionFunc: func(param0 *ec2.CreateLaunchTemplateVersionInput) (*ec2.CreateLaunchTemplateVersionOutput, error) {
+						return &ec2.CreateLaunchTemplateVersionOutput{LaunchTemplateVersion: &ec2.LaunchTemplateVersion{VersionNumber: Int64(3)}}, nil
+					},
+				}).ExpectInput("CreateLaunchTemplateVersion", &ec2.CreateLaunchTemplateVersionInput{
+				LaunchTemplateId:   String("lt-1234"),
+				SourceVersion:      String("2"),
+				LaunchTemplateData: &ec2.RequestLaunchTemplateData{InstanceType: String("m4.large")},
+			}).ExpectCommandResult("3").IgnoreInput("DescribeReservedInstancesOfferings").ExpectCalls("DescribeReservedInstancesOfferings", "CreateLaunchTemplateVersion").Run(t)
+		})
+
+		t.Run("new default version", func(t *testing.T) {
+			Template("update launchtemplate id=lt-1234 image=ami-5678 default=true").
+				Mock(&ec2Mock{
+					CreateLaunchTemplateVersionFunc: func(param0 *ec2.CreateLaunchTemplateVersionInput) (*ec2.CreateLaunchTemplateVersionOutput, error) {
+						return &ec2.CreateLaunchTemplateVersionOutput{LaunchTemplateVersion: &ec2.LaunchTemplateVersion{VersionNumber: Int64(3)}}, nil
+					},
+					ModifyLaunchTemplateFunc: func(param0 *ec2.ModifyLaunchTemplateInput) (*ec2.ModifyLaunchTemplateOutput, error) {
+						return nil, nil
+					},
+				}).ExpectInput("CreateLaunchTemplateVersion", &ec2.CreateLaunchTemplateVersionInput{
+				LaunchTemplateId:   String("lt-1234"),
+				LaunchTemplateData: &ec2.RequestLaunchTemplateData{ImageId: String("ami-5678")},
+			}).ExpectInput("ModifyLaunchTemplate", &ec2.ModifyLaunchTemplateInput{
+				LaunchTemplateId: String("lt-1234"),
+				DefaultVersion:   String("3"),
+			}).ExpectCommandResult("3").ExpectCalls("CreateLaunchTemplateVersion", "ModifyLaunchTemplate").Run(t)
+		})
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete launchtemplate id=lt-1234").
+			Mock(&ec2Mock{
+				DeleteLaunchTemplateFunc: func(param0 *ec2.DeleteLaunchTemplateInput) (*ec2.DeleteLaunchTemplateOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteLaunchTemplate", &ec2.DeleteLaunchTemplateInput{LaunchTemplateId: String("lt-1234")}).
+			ExpectCalls("DeleteLaunchTemplate").Run(t)
+	})
+}
@@ -37,6 +37,7 @@ delete instance id=new-instance-id`,
 		}
 		for _, tcase := range tcases {
 			Template(tcase.template).Mock(&ec2Mock{
+				DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
 				RunInstancesFunc: func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
 					return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: String("new-instance-id")}}}, nil
 				},
@@ -48,9 +49,9 @@ delete instance id=new-instance-id`,
 				StopInstancesFunc: func(input *ec2.StopInstancesInput) (*ec2.StopInstancesOutput, error) {
 					return &ec2.StopInstancesOutput{StoppingInstances: []*ec2.InstanceStateChange{{InstanceId: String("new-instance-id")}}}, nil
 				},
-			}).IgnoreInput("RunInstances", "CreateTagsRequest").ExpectInput("StopInstances", &ec2.StopInstancesInput{
+			}).IgnoreInput("DescribeReservedInstancesOfferings", "RunInstances", "CreateTagsRequest").ExpectInput("StopInstances", &ec2.StopInstancesInput{
 				InstanceIds: tcase.expStoppedIds,
-			}).ExpectCalls("RunInstances", "CreateTagsRequest", "StopInstances").ExpectRevert(tcase.expRevert).Run(t)
+			}).ExpectCalls("DescribeReservedInstancesOfferings", "RunInstances", "CreateTagsRequest", "StopInstances").ExpectRevert(tcase.expRevert).Run(t)
 		}
 	})
 	t.Run("reference from inlined variable", func(t *testing.T) {
@@ -66,6 +67,7 @@ delete instance id=new-instance-id`,
 	t.Run("reverting with multiple ids", func(t *testing.T) {
 		Template(`inst = create instance name=myinstance count=1 image=ami-12345 subnet=sub-1234 type=t2.nano
 start instance id=[id-1234,$inst,id-2345]`).Mock(&ec2Mock{
+			DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
 			RunInstancesFunc: func(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
 				return &ec2.Reservation{Instances: []*ec2.Instance{{InstanceId: String("new-instance-id")}}}, nil
 			},
@@ -77,9 +79,9 @@ start instance id=[id-1234,$inst,id-2345]`).Mock(&ec2Mock{
 			StartInstancesFunc: func(input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
 				return &ec2.StartInstancesOutput{StartingInstances: []*ec2.InstanceStateChange{{InstanceId: String("new-instance-id")}}}, nil
 			},
-		}).IgnoreInput("RunInstances", "CreateTagsRequest").ExpectInput("StartInstances", &ec2.StartInstancesInput{
+		}).IgnoreInput("DescribeReservedInstancesOfferings", "RunInstances", "CreateTagsRequest").ExpectInput("StartInstances", &ec2.StartInstancesInput{
 			InstanceIds: []*string{String("id-1234"), String("new-instance-id"), String("id-2345")},
-		}).ExpectCalls("RunInstances", "CreateTagsRequest", "StartInstances").
+		}).ExpectCalls("DescribeReservedInstancesOfferings", "RunInstances", "CreateTagsRequest", "StartInstances").
 			ExpectRevert(`check instance id=id-1234 state=running timeout=180
 check instance id=new-instance-id state=running timeout=180
 check instance id=id-2345 state=running timeout=180
@@ -2,7 +2,10 @@ package awsat
 
 import (
 	"reflect"
+	"sync"
 	"testing"
+
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
 )
 
 type mock interface {
@@ -14,12 +17,15 @@ type mock interface {
 
 type basicMock struct {
 	t             *testing.T
+	mu            sync.Mutex
 	calls         map[string]int
 	expInputs     map[string]interface{}
 	ignoredInputs map[string]struct{}
 }
 
 func (m *basicMock) addCall(call string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.calls == nil {
 		m.calls = make(map[string]int)
 	}
@@ -27,6 +33,8 @@ func (m *basicMock) addCall(call string) {
 }
 
 func (m *basicMock) Calls() map[string]int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.calls
 }
 
@@ -54,3 +62,11 @@ func (m *basicMock) verifyInput(call string, got interface{}) {
 		m.t.Fatalf("got %#v, want %#v", got, want)
 	}
 }
+
+// TaggingAPI gives the tag commands the mock when it also mocks the resource groups tagging API
+func (f *AcceptanceFactory) TaggingAPI() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+	if api, ok := f.Mock.(resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI); ok {
+		return api
+	}
+	return nil
+}
@@ -1,8 +1,11 @@
 package awsat
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 )
 
@@ -20,6 +23,28 @@ func TestNATGateway(t *testing.T) {
 			ExpectCommandResult("new-natgateway-id").ExpectCalls("CreateNatGateway").Run(t)
 	})
 
+	t.Run("create and wait", func(t *testing.T) {
+		Template("create natgateway elasticip-id=eip-12345 subnet=sub-23456 wait=true wait-timeout=60").
+			Mock(&ec2Mock{
+				CreateNatGatewayFunc: func(param0 *ec2.CreateNatGatewayInput) (*ec2.CreateNatGatewayOutput, error) {
+					return &ec2.CreateNatGatewayOutput{NatGateway: &ec2.NatGateway{NatGatewayId: String("new-natgateway-id")}}, nil
+				},
+				WaitUntilNatGatewayAvailableWithContextFunc: func(param0 aws.Context, param1 *ec2.DescribeNatGatewaysInput, param2 ...request.WaiterOption) error {
+					if got, want := param1, (&ec2.DescribeNatGatewaysInput{NatGatewayIds: []*string{String("new-natgateway-id")}}); !reflect.DeepEqual(got, want) {
+						t.Fatalf("got %#v, want %#v", got, want)
+					}
+					if _, hasDeadline := param0.Deadline(); !hasDeadline {
+						t.Fatal("expected wait with a deadline")
+					}
+					return nil
+				},
+			}).ExpectInput("CreateNatGateway", &ec2.CreateNatGatewayInput{
+			AllocationId: String("eip-12345"),
+			SubnetId:     String("sub-23456"),
+		}).IgnoreInput("WaitUntilNatGatewayAvailableWithContext").
+			ExpectCommandResult("new-natgateway-id").ExpectCalls("CreateNatGateway", "WaitUntilNatGatewayAvailableWithContext").Run(t)
+	})
+
 	t.Run("delete", func(t *testing.T) {
 		Template("delete natgateway id=ngw-1234").
 			Mock(&ec2Mock{
@@ -0,0 +1,71 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/wallix/awless/aws/spec"
+)
+
+func TestOriginAccessIdentity(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		awsspec.CallerReferenceFunc = func() string {
+			return "callerReference"
+		}
+		Template("create originaccessidentity comment='access to my bucket'").
+			Mock(&cloudfrontMock{
+				CreateCloudFrontOriginAccessIdentityFunc: func(param0 *cloudfront.CreateCloudFrontOriginAccessIdentityInput) (*cloudfront.CreateCloudFrontOriginAccessIdentityOutput, error) {
+					return &cloudfront.CreateCloudFrontOriginAccessIdentityOutput{CloudFrontOriginAccessIdentity: &cloudfront.OriginAccessIdentity{Id: String("new-identity-id")}}, nil
+				},
+			}).ExpectInput("CreateCloudFrontOriginAccessIdentity", &cloudfront.CreateCloudFrontOriginAccessIdentityInput{
+			CloudFrontOriginAccessIdentityConfig: &cloudfront.OriginAccessIdentityConfig{
+				CallerReference: String("callerReference"),
+				Comment:         String("access to my bucket"),
+			},
+		}).ExpectCommandResult("new-identity-id").ExpectCalls("CreateCloudFrontOriginAccessIdentity").Run(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		Template("update originaccessidentity id=my-identity comment='new comment'").
+			Mock(&cloudfrontMock{
+				GetCloudFrontOriginAccessIdentityConfigFunc: func(param0 *cloudfront.GetCloudFrontOriginAccessIdentityConfigInput) (*cloudfront.GetCloudFrontOriginAccessIdentityConfigOutput, error) {
+					return &cloudfront.GetCloudFrontOriginAccessIdentityConfigOutput{
+						ETag: String("etag-id"),
+						CloudFrontOriginAccessIdentityConfig: &cloudfront.OriginAccessIdentityConfig{
+							CallerReference: String("callerReference"),
+							Comment:         String("old comment"),
+						},
+					}, nil
+				},
+				UpdateCloudFrontOriginAccessIdentityFunc: func(param0 *cloudfront.UpdateCloudFrontOriginAccessIdentityInput) (*cloudfront.UpdateCloudFrontOriginAccessIdentityOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("GetCloudFrontOriginAccessIdentityConfig", &cloudfront.GetCloudFrontOriginAccessIdentityConfigInput{
+			Id: String("my-identity"),
+		}).ExpectInput("UpdateCloudFrontOriginAccessIdentity", &cloudfront.UpdateCloudFrontOriginAccessIdentityInput{
+			Id:      String("my-identity"),
+			IfMatch: String("etag-id"),
+			CloudFrontOriginAccessIdentityConfig: &cloudfront.OriginAccessIdentityConfig{
+				CallerReference: String("callerReference"),
+				Comment:         String("new comment"),
+			},
+		}).ExpectCalls("GetCloudFrontOriginAccessIdentityConfig", "UpdateCloudFrontOriginAccessIdentity").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete originaccessidentity id=my-identity").
+			Mock(&cloudfrontMock{
+				GetCloudFrontOriginAccessIdentityFunc: func(param0 *cloudfront.GetCloudFrontOriginAccessIdentityInput) (*cloudfront.GetCloudFrontOriginAccessIdentityOutput, error) {
+					return &cloudfront.GetCloudFrontOriginAccessIdentityOutput{ETag: String("etag-id")}, nil
+				},
+				DeleteCloudFrontOriginAccessIdentityFunc: func(param0 *cloudfront.DeleteCloudFrontOriginAccessIdentityInput) (*cloudfront.DeleteCloudFrontOriginAccessIdentityOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("GetCloudFrontOriginAccessIdentity", &cloudfront.GetCloudFrontOriginAccessIdentityInput{
+			Id: String("my-identity"),
+		}).ExpectInput("DeleteCloudFrontOriginAccessIdentity", &cloudfront.DeleteCloudFrontOriginAccessIdentityInput{
+			Id:      String("my-identity"),
+			IfMatch: String("etag-id"),
+		}).ExpectCalls("GetCloudFrontOriginAccessIdentity", "DeleteCloudFrontOriginAccessIdentity").Run(t)
+	})
+}
@@ -0,0 +1,36 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestPresignedurl(t *testing.T) {
+	client := s3.New(session.Must(session.NewSession(&aws.Config{
+		Region:      String("eu-west-1"),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	})))
+
+	t.Run("get", func(t *testing.T) {
+		Template("create presignedurl bucket=my-bucket key=reports/2017.pdf expires=3600").Mock(&s3Mock{
+			GetObjectRequestFunc: func(input *s3.GetObjectInput) (*request.Request, *s3.GetObjectOutput) {
+				return client.GetObjectRequest(input)
+			}}).
+			ExpectInput("GetObjectRequest", &s3.GetObjectInput{Bucket: String("my-bucket"), Key: String("reports/2017.pdf")}).
+			ExpectCalls("GetObjectRequest").Run(t)
+	})
+
+	t.Run("put", func(t *testing.T) {
+		Template("create presignedurl bucket=my-bucket key=uploads/data.csv method=PUT").Mock(&s3Mock{
+			PutObjectRequestFunc: func(input *s3.PutObjectInput) (*request.Request, *s3.PutObjectOutput) {
+				return client.PutObjectRequest(input)
+			}}).
+			ExpectInput("PutObjectRequest", &s3.PutObjectInput{Bucket: String("my-bucket"), Key: String("uploads/data.csv")}).
+			ExpectCalls("PutObjectRequest").Run(t)
+	})
+}
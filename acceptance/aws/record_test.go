@@ -65,6 +65,35 @@ func TestRecord(t *testing.T) {
 				},
 			}).ExpectCommandResult("change-id").ExpectCalls("ChangeResourceRecordSets").Run(t)
 		})
+
+		t.Run("with routing policy", func(t *testing.T) {
+			Template("create record zone=/hostedzone/1234ABCD name=www.domain.com type=A value=1.2.3.4 ttl=60 set-identifier=main failover=primary healthcheck=hc-1234").
+				Mock(&route53Mock{
+					ChangeResourceRecordSetsFunc: func(param0 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+						return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &route53.ChangeInfo{Id: String("change-id")}}, nil
+					},
+				}).ExpectInput("ChangeResourceRecordSets", &route53.ChangeResourceRecordSetsInput{
+				HostedZoneId: String("/hostedzone/1234ABCD"),
+				ChangeBatch: &route53.ChangeBatch{
+					Changes: []*route53.Change{
+						{
+							ResourceRecordSet: &route53.ResourceRecordSet{
+								ResourceRecords: []*route53.ResourceRecord{
+									{Value: String("1.2.3.4")},
+								},
+								Name:          String("www.domain.com"),
+								Type:          String("A"),
+								TTL:           Int64(60),
+								SetIdentifier: String("main"),
+								Failover:      String("PRIMARY"),
+								HealthCheckId: String("hc-1234"),
+							},
+							Action: String("CREATE"),
+						},
+					},
+				},
+			}).ExpectCommandResult("change-id").ExpectCalls("ChangeResourceRecordSets").Run(t)
+		})
 	})
 
 	t.Run("update", func(t *testing.T) {
@@ -125,6 +154,40 @@ func TestRecord(t *testing.T) {
 			}).Graph(g).ExpectCommandResult("deleted-id").ExpectCalls("ChangeResourceRecordSets").Run(t)
 		})
 
+		t.Run("from awless-id with routing policy", func(t *testing.T) {
+			g := graph.NewGraph()
+			zone := resourcetest.Zone("/hostedzone/1234ABCD").Build()
+			record := resourcetest.Record("awls-rec").Prop(properties.Name, "www.domain.com").Prop(properties.Type, "A").Prop(properties.Records, []string{"1.2.3.4"}).Prop(properties.TTL, 60).
+				Prop(properties.Set, "eu").Prop(properties.Weight, 80).Build()
+			g.AddResource(zone, record)
+			g.AddParentRelation(zone, record)
+			Template("delete record id=awls-rec").
+				Mock(&route53Mock{
+					ChangeResourceRecordSetsFunc: func(param0 *route53.ChangeResourceRecordSetsInput) (*route53.ChangeResourceRecordSetsOutput, error) {
+						return &route53.ChangeResourceRecordSetsOutput{ChangeInfo: &route53.ChangeInfo{Id: String("deleted-id")}}, nil
+					},
+				}).ExpectInput("ChangeResourceRecordSets", &route53.ChangeResourceRecordSetsInput{
+				HostedZoneId: String("/hostedzone/1234ABCD"),
+				ChangeBatch: &route53.ChangeBatch{
+					Changes: []*route53.Change{
+						{
+							ResourceRecordSet: &route53.ResourceRecordSet{
+								ResourceRecords: []*route53.ResourceRecord{
+									{Value: String("1.2.3.4")},
+								},
+								Name:          String("www.domain.com"),
+								Type:          String("A"),
+								TTL:           Int64(60),
+								SetIdentifier: String("eu"),
+								Weight:        Int64(80),
+							},
+							Action: String("DELETE"),
+						},
+					},
+				},
+			}).Graph(g).ExpectCommandResult("deleted-id").ExpectCalls("ChangeResourceRecordSets").Run(t)
+		})
+
 		t.Run("with all params", func(t *testing.T) {
 			Template("delete record zone=/hostedzone/1234ABCD name=mydeleted.domain.com type=A value=127.0.0.1 ttl=60").
 				Mock(&route53Mock{
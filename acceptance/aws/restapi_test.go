@@ -0,0 +1,187 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/apigateway"
+)
+
+func TestRestapi(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create restapi name=petstore description='pets shop' version=v1").
+			Mock(&apigatewayMock{
+				CreateRestApiFunc: func(param0 *apigateway.CreateRestApiInput) (*apigateway.RestApi, error) {
+					return &apigateway.RestApi{Id: String("api-12345")}, nil
+				},
+			}).ExpectInput("CreateRestApi", &apigateway.CreateRestApiInput{
+			Name:        String("petstore"),
+			Description: String("pets shop"),
+			Version:     String("v1"),
+		}).ExpectCommandResult("api-12345").ExpectCalls("CreateRestApi").
+			ExpectRevert("delete restapi id=api-12345").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete restapi id=api-12345").
+			Mock(&apigatewayMock{
+				DeleteRestApiFunc: func(param0 *apigateway.DeleteRestApiInput) (*apigateway.DeleteRestApiOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteRestApi", &apigateway.DeleteRestApiInput{
+			RestApiId: String("api-12345"),
+		}).ExpectCalls("DeleteRestApi").Run(t)
+	})
+}
+
+func TestApideployment(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create apideployment restapi=api-12345 stage=prod description='first release'").
+			Mock(&apigatewayMock{
+				CreateDeploymentFunc: func(param0 *apigateway.CreateDeploymentInput) (*apigateway.Deployment, error) {
+					return &apigateway.Deployment{Id: String("dep-12345")}, nil
+				},
+			}).ExpectInput("CreateDeployment", &apigateway.CreateDeploymentInput{
+			RestApiId:   String("api-12345"),
+			StageName:   String("prod"),
+			Description: String("first release"),
+		}).ExpectCommandResult("dep-12345").ExpectCalls("CreateDeployment").
+			ExpectRevert("delete apistage name=prod restapi=api-12345\ndelete apideployment id=dep-12345 restapi=api-12345").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete apideployment id=dep-12345 restapi=api-12345").
+			Mock(&apigatewayMock{
+				DeleteDeploymentFunc: func(param0 *apigateway.DeleteDeploymentInput) (*apigateway.DeleteDeploymentOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteDeployment", &apigateway.DeleteDeploymentInput{
+			DeploymentId: String("dep-12345"),
+			RestApiId:    String("api-12345"),
+		}).ExpectCalls("DeleteDeployment").Run(t)
+	})
+}
+
+func TestApistage(t *testing.T) {
+	t.Run("delete", func(t *testing.T) {
+		Template("delete apistage name=prod restapi=api-12345").
+			Mock(&apigatewayMock{
+				DeleteStageFunc: func(param0 *apigateway.DeleteStageInput) (*apigateway.DeleteStageOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteStage", &apigateway.DeleteStageInput{
+			StageName: String("prod"),
+			RestApiId: String("api-12345"),
+		}).ExpectCalls("DeleteStage").Run(t)
+	})
+}
+
+func TestApiresource(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create apiresource restapi=api-12345 parent=root-12345 path=pets").
+			Mock(&apigatewayMock{
+				CreateResourceFunc: func(param0 *apigateway.CreateResourceInput) (*apigateway.Resource, error) {
+					return &apigateway.Resource{Id: String("res-12345")}, nil
+				},
+			}).ExpectInput("CreateResource", &apigateway.CreateResourceInput{
+			RestApiId: String("api-12345"),
+			ParentId:  String("root-12345"),
+			PathPart:  String("pets"),
+		}).ExpectCommandResult("res-12345").ExpectCalls("CreateResource").
+			ExpectRevert("delete apiresource id=res-12345 restapi=api-12345").Run(t)
+	})
+
+	t.Run("create under root", func(t *testing.T) {
+		Template("create apiresource restapi=api-12345 path=pets").
+			Mock(&apigatewayMock{
+				GetResourcesFunc: func(param0 *apigateway.GetResourcesInput) (*apigateway.GetResourcesOutput, error) {
+					return &apigateway.GetResourcesOutput{Items: []*apigateway.Resource{
+						{Id: String("res-67890"), Path: String("/users")},
+						{Id: String("root-12345"), Path: String("/")},
+					}}, nil
+				},
+				CreateResourceFunc: func(param0 *apigateway.CreateResourceInput) (*apigateway.Resource, error) {
+					return &apigateway.Resource{Id: String("res-12345")}, nil
+				},
+			}).ExpectInput("GetResources", &apigateway.GetResourcesInput{
+			RestApiId: String("api-12345"),
+		}).ExpectInput("CreateResource", &apigateway.CreateResourceInput{
+			RestApiId: String("api-12345"),
+			ParentId:  String("root-12345"),
+			PathPart:  String("pets"),
+		}).ExpectCommandResult("res-12345").ExpectCalls("GetResources", "CreateResource").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete apiresource id=res-12345 restapi=api-12345").
+			Mock(&apigatewayMock{
+				DeleteResourceFunc: func(param0 *apigateway.DeleteResourceInput) (*apigateway.DeleteResourceOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteResource", &apigateway.DeleteResourceInput{
+			ResourceId: String("res-12345"),
+			RestApiId:  String("api-12345"),
+		}).ExpectCalls("DeleteResource").Run(t)
+	})
+}
+
+func TestApimethod(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create apimethod restapi=api-12345 resource=res-12345 method=get").
+			Mock(&apigatewayMock{
+				PutMethodFunc: func(param0 *apigateway.PutMethodInput) (*apigateway.Method, error) {
+					return &apigateway.Method{HttpMethod: String("GET")}, nil
+				},
+			}).ExpectInput("PutMethod", &apigateway.PutMethodInput{
+			RestApiId:         String("api-12345"),
+			ResourceId:        String("res-12345"),
+			HttpMethod:        String("GET"),
+			AuthorizationType: String("NONE"),
+		}).ExpectCommandResult("GET").ExpectCalls("PutMethod").
+			ExpectRevert("delete apimethod method=GET resource=res-12345 restapi=api-12345").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete apimethod restapi=api-12345 resource=res-12345 method=post").
+			Mock(&apigatewayMock{
+				DeleteMethodFunc: func(param0 *apigateway.DeleteMethodInput) (*apigateway.DeleteMethodOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteMethod", &apigateway.DeleteMethodInput{
+			RestApiId:  String("api-12345"),
+			ResourceId: String("res-12345"),
+			HttpMethod: String("POST"),
+		}).ExpectCalls("DeleteMethod").Run(t)
+	})
+}
+
+func TestIntegration(t *testing.T) {
+	t.Run("attach", func(t *testing.T) {
+		Template("attach integration restapi=api-12345 resource=res-12345 method=get function=arn:aws:lambda:eu-west-1:123456789012:function:pets").
+			Mock(&apigatewayMock{
+				PutIntegrationFunc: func(param0 *apigateway.PutIntegrationInput) (*apigateway.Integration, error) {
+					return &apigateway.Integration{}, nil
+				},
+			}).ExpectInput("PutIntegration", &apigateway.PutIntegrationInput{
+			RestApiId:             String("api-12345"),
+			ResourceId:            String("res-12345"),
+			HttpMethod:            String("GET"),
+			Type:                  String("AWS_PROXY"),
+			IntegrationHttpMethod: String("POST"),
+			Uri:                   String("arn:aws:apigateway:eu-west-1:lambda:path/2015-03-31/functions/arn:aws:lambda:eu-west-1:123456789012:function:pets/invocations"),
+		}).ExpectCalls("PutIntegration").
+			ExpectRevert("detach integration method=GET resource=res-12345 restapi=api-12345").Run(t)
+	})
+
+	t.Run("detach", func(t *testing.T) {
+		Template("detach integration restapi=api-12345 resource=res-12345 method=post").
+			Mock(&apigatewayMock{
+				DeleteIntegrationFunc: func(param0 *apigateway.DeleteIntegrationInput) (*apigateway.DeleteIntegrationOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteIntegration", &apigateway.DeleteIntegrationInput{
+			RestApiId:  String("api-12345"),
+			ResourceId: String("res-12345"),
+			HttpMethod: String("POST"),
+		}).ExpectCalls("DeleteIntegration").Run(t)
+	})
+}
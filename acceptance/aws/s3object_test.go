@@ -1,6 +1,13 @@
 package awsat
 
 import (
+	"crypto/md5"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 
 	"os"
@@ -75,4 +82,92 @@ func TestS3object(t *testing.T) {
 				Bucket: String("any-bucket"),
 			}).ExpectCalls("DeleteObject").Run(t)
 	})
+
+	t.Run("sync", func(t *testing.T) {
+		t.Run("upload", func(t *testing.T) {
+			dir := generateTmpDir(map[string]string{
+				"index.html":   "<html></html>",
+				"css/site.css": "body {}",
+				"js/app.js":    "main()",
+				"notes.tmp":    "todo",
+			})
+			defer os.RemoveAll(dir)
+
+			var mu sync.Mutex
+			var uploaded []string
+			Template("sync s3object dir="+dir+" bucket=my-website prefix=www exclude='*.tmp' acl=public-read").Mock(&s3Mock{
+				ListObjectsV2Func: func(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+					return &s3.ListObjectsV2Output{Contents: []*s3.Object{
+						{Key: String("www/index.html"), ETag: String(etag("<html></html>")), Size: Int64(13)},
+						{Key: String("www/js/app.js"), ETag: String(etag("old()")), Size: Int64(5)},
+					}}, nil
+				},
+				PutObjectFunc: func(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					if got, want := StringValue(input.ACL), "public-read"; got != want {
+						t.Errorf("got %s, want %s", got, want)
+					}
+					uploaded = append(uploaded, StringValue(input.Key))
+					return &s3.PutObjectOutput{}, nil
+				}}).
+				ExpectInput("ListObjectsV2", &s3.ListObjectsV2Input{Bucket: String("my-website"), Prefix: String("www/")}).
+				IgnoreInput("PutObject").
+				ExpectCommandResult("my-website").ExpectCalls("ListObjectsV2", "PutObject", "PutObject").Run(t)
+
+			sort.Strings(uploaded)
+			if got, want := uploaded, []string{"www/css/site.css", "www/js/app.js"}; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		})
+
+		t.Run("download", func(t *testing.T) {
+			dir := generateTmpDir(map[string]string{"a.txt": "hello"})
+			defer os.RemoveAll(dir)
+
+			Template("sync s3object dir="+dir+" bucket=my-backups direction=download include=['*.txt'] concurrency=1").Mock(&s3Mock{
+				ListObjectsV2Func: func(input *s3.ListObjectsV2Input) (*s3.ListObjectsV2Output, error) {
+					return &s3.ListObjectsV2Output{Contents: []*s3.Object{
+						{Key: String("a.txt"), ETag: String(etag("hello")), Size: Int64(5)},
+						{Key: String("sub/b.txt"), ETag: String(etag("world")), Size: Int64(5)},
+						{Key: String("sub/c.log"), ETag: String(etag("log")), Size: Int64(3)},
+					}}, nil
+				},
+				GetObjectFunc: func(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+					return &s3.GetObjectOutput{Body: ioutil.NopCloser(strings.NewReader("world"))}, nil
+				}}).
+				ExpectInput("ListObjectsV2", &s3.ListObjectsV2Input{Bucket: String("my-backups")}).
+				ExpectInput("GetObject", &s3.GetObjectInput{Bucket: String("my-backups"), Key: String("sub/b.txt")}).
+				ExpectCalls("ListObjectsV2", "GetObject").Run(t)
+
+			content, err := ioutil.ReadFile(filepath.Join(dir, "sub", "b.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(content), "world"; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+		})
+	})
+}
+
+func generateTmpDir(files map[string]string) string {
+	dir, err := ioutil.TempDir("", "awless-at-tmpdir")
+	if err != nil {
+		panic(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			panic(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			panic(err)
+		}
+	}
+	return dir
+}
+
+func etag(content string) string {
+	return fmt.Sprintf("\"%x\"", md5.Sum([]byte(content)))
 }
@@ -27,6 +27,20 @@ func TestScalingGroup(t *testing.T) {
 			}).ExpectCommandResult("new-autoscaling").ExpectCalls("CreateAutoScalingGroup").Run(t)
 	})
 
+	t.Run("create with launch template", func(t *testing.T) {
+		Template("create scalinggroup name=new-autoscaling launchtemplate=lt-1234 launchtemplate-version=Latest max-size=3 min-size=1 subnets=sub_1,sub_2").Mock(&autoscalingMock{
+			CreateAutoScalingGroupFunc: func(input *autoscaling.CreateAutoScalingGroupInput) (*autoscaling.CreateAutoScalingGroupOutput, error) {
+				return &autoscaling.CreateAutoScalingGroupOutput{}, nil
+			}}).
+			ExpectInput("CreateAutoScalingGroup", &autoscaling.CreateAutoScalingGroupInput{
+				AutoScalingGroupName: String("new-autoscaling"),
+				LaunchTemplate:       &autoscaling.LaunchTemplateSpecification{LaunchTemplateId: String("lt-1234"), Version: String("$Latest")},
+				MaxSize:              Int64(3),
+				MinSize:              Int64(1),
+				VPCZoneIdentifier:    String("sub_1,sub_2"),
+			}).ExpectCommandResult("new-autoscaling").ExpectCalls("CreateAutoScalingGroup").Run(t)
+	})
+
 	t.Run("update", func(t *testing.T) {
 		Template("update scalinggroup name=new-autoscaling launchconfiguration=config max-size=12 min-size=10 subnets=sub_1,sub_2 cooldown=3 desired-capacity=12 healthcheck-grace-period=4 healthcheck-type=healthy new-instances-protected=true").Mock(&autoscalingMock{
 			UpdateAutoScalingGroupFunc: func(input *autoscaling.UpdateAutoScalingGroupInput) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
@@ -46,6 +60,17 @@ func TestScalingGroup(t *testing.T) {
 			}).ExpectCalls("UpdateAutoScalingGroup").Run(t)
 	})
 
+	t.Run("update launch template", func(t *testing.T) {
+		Template("update scalinggroup name=new-autoscaling launchtemplate=lt-1234 launchtemplate-version=4").Mock(&autoscalingMock{
+			UpdateAutoScalingGroupFunc: func(input *autoscaling.UpdateAutoScalingGroupInput) (*autoscaling.UpdateAutoScalingGroupOutput, error) {
+				return nil, nil
+			}}).
+			ExpectInput("UpdateAutoScalingGroup", &autoscaling.UpdateAutoScalingGroupInput{
+				AutoScalingGroupName: String("new-autoscaling"),
+				LaunchTemplate:       &autoscaling.LaunchTemplateSpecification{LaunchTemplateId: String("lt-1234"), Version: String("4")},
+			}).ExpectCalls("UpdateAutoScalingGroup").Run(t)
+	})
+
 	t.Run("delete", func(t *testing.T) {
 		Template("delete scalinggroup name=any-sg force=true").Mock(&autoscalingMock{
 			DeleteAutoScalingGroupFunc: func(input *autoscaling.DeleteAutoScalingGroupInput) (*autoscaling.DeleteAutoScalingGroupOutput, error) {
@@ -106,6 +106,51 @@ func TestSecuritygroup(t *testing.T) {
 					},
 				}).ExpectCalls("RevokeSecurityGroupEgress").Run(t)
 		})
+		t.Run("replace inbound and outbound rules", func(t *testing.T) {
+			Template("update securitygroup id=my-secgroup-id inbound-rules=['tcp:443:0.0.0.0/0','tcp:22:10.0.0.0/16','tcp:8080:sg-12345'] outbound-rules=none").Mock(&ec2Mock{
+				DescribeSecurityGroupsFunc: func(input *ec2.DescribeSecurityGroupsInput) (*ec2.DescribeSecurityGroupsOutput, error) {
+					return &ec2.DescribeSecurityGroupsOutput{SecurityGroups: []*ec2.SecurityGroup{
+						{
+							GroupId: String("my-secgroup-id"),
+							IpPermissions: []*ec2.IpPermission{
+								{IpProtocol: String("tcp"), FromPort: Int64(22), ToPort: Int64(22), IpRanges: []*ec2.IpRange{{CidrIp: String("0.0.0.0/0")}, {CidrIp: String("10.0.0.0/16")}}},
+								{IpProtocol: String("tcp"), FromPort: Int64(443), ToPort: Int64(443), IpRanges: []*ec2.IpRange{{CidrIp: String("0.0.0.0/0")}}},
+							},
+							IpPermissionsEgress: []*ec2.IpPermission{
+								{IpProtocol: String("-1"), IpRanges: []*ec2.IpRange{{CidrIp: String("0.0.0.0/0")}}},
+							},
+						},
+					}}, nil
+				},
+				AuthorizeSecurityGroupIngressFunc: func(input *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+					return nil, nil
+				},
+				RevokeSecurityGroupIngressFunc: func(input *ec2.RevokeSecurityGroupIngressInput) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+					return nil, nil
+				},
+				RevokeSecurityGroupEgressFunc: func(input *ec2.RevokeSecurityGroupEgressInput) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+					return nil, nil
+				}}).
+				ExpectInput("DescribeSecurityGroups", &ec2.DescribeSecurityGroupsInput{GroupIds: []*string{String("my-secgroup-id")}}).
+				ExpectInput("AuthorizeSecurityGroupIngress", &ec2.AuthorizeSecurityGroupIngressInput{
+					GroupId: String("my-secgroup-id"),
+					IpPermissions: []*ec2.IpPermission{
+						{IpProtocol: String("tcp"), FromPort: Int64(8080), ToPort: Int64(8080), UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: String("sg-12345")}}},
+					},
+				}).
+				ExpectInput("RevokeSecurityGroupIngress", &ec2.RevokeSecurityGroupIngressInput{
+					GroupId: String("my-secgroup-id"),
+					IpPermissions: []*ec2.IpPermission{
+						{IpProtocol: String("tcp"), FromPort: Int64(22), ToPort: Int64(22), IpRanges: []*ec2.IpRange{{CidrIp: String("0.0.0.0/0")}}},
+					},
+				}).
+				ExpectInput("RevokeSecurityGroupEgress", &ec2.RevokeSecurityGroupEgressInput{
+					GroupId: String("my-secgroup-id"),
+					IpPermissions: []*ec2.IpPermission{
+						{IpProtocol: String("-1"), FromPort: Int64(-1), ToPort: Int64(-1), IpRanges: []*ec2.IpRange{{CidrIp: String("0.0.0.0/0")}}},
+					},
+				}).ExpectCalls("DescribeSecurityGroups", "AuthorizeSecurityGroupIngress", "RevokeSecurityGroupIngress", "RevokeSecurityGroupEgress").Run(t)
+		})
 	})
 
 	t.Run("delete", func(t *testing.T) {
@@ -1,8 +1,11 @@
 package awsat
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 )
 
@@ -20,6 +23,23 @@ func TestSnapshot(t *testing.T) {
 			ExpectCommandResult("new-snapshot-id").ExpectCalls("CreateSnapshot").Run(t)
 	})
 
+	t.Run("create and wait", func(t *testing.T) {
+		Template("create snapshot volume=my-volume-id wait=true").
+			Mock(&ec2Mock{
+				CreateSnapshotFunc: func(param0 *ec2.CreateSnapshotInput) (*ec2.Snapshot, error) {
+					return &ec2.Snapshot{SnapshotId: String("new-snapshot-id")}, nil
+				},
+				WaitUntilSnapshotCompletedWithContextFunc: func(param0 aws.Context, param1 *ec2.DescribeSnapshotsInput, param2 ...request.WaiterOption) error {
+					if got, want := param1, (&ec2.DescribeSnapshotsInput{SnapshotIds: []*string{String("new-snapshot-id")}}); !reflect.DeepEqual(got, want) {
+						t.Fatalf("got %#v, want %#v", got, want)
+					}
+					return nil
+				},
+			}).ExpectInput("CreateSnapshot", &ec2.CreateSnapshotInput{VolumeId: String("my-volume-id")}).
+			IgnoreInput("WaitUntilSnapshotCompletedWithContext").
+			ExpectCommandResult("new-snapshot-id").ExpectCalls("CreateSnapshot", "WaitUntilSnapshotCompletedWithContext").Run(t)
+	})
+
 	t.Run("delete", func(t *testing.T) {
 		Template("delete snapshot id=snap-1234").
 			Mock(&ec2Mock{
@@ -44,4 +64,33 @@ func TestSnapshot(t *testing.T) {
 		}).ExpectCommandResult("my-snapshotcopy-id").ExpectCalls("CopySnapshot").Run(t)
 	})
 
+	t.Run("update", func(t *testing.T) {
+		t.Run("share with accounts", func(t *testing.T) {
+			Template("update snapshot id=snap-1234 accounts=[123456789012,210987654321] operation=add").
+				Mock(&ec2Mock{
+					ModifySnapshotAttributeFunc: func(param0 *ec2.ModifySnapshotAttributeInput) (*ec2.ModifySnapshotAttributeOutput, error) {
+						return nil, nil
+					},
+				}).ExpectInput("ModifySnapshotAttribute", &ec2.ModifySnapshotAttributeInput{
+				SnapshotId:    String("snap-1234"),
+				Attribute:     String("createVolumePermission"),
+				OperationType: String("add"),
+				UserIds:       []*string{String("123456789012"), String("210987654321")},
+			}).ExpectCalls("ModifySnapshotAttribute").Run(t)
+		})
+
+		t.Run("make private", func(t *testing.T) {
+			Template("update snapshot id=snap-1234 groups=all operation=Remove").
+				Mock(&ec2Mock{
+					ModifySnapshotAttributeFunc: func(param0 *ec2.ModifySnapshotAttributeInput) (*ec2.ModifySnapshotAttributeOutput, error) {
+						return nil, nil
+					},
+				}).ExpectInput("ModifySnapshotAttribute", &ec2.ModifySnapshotAttributeInput{
+				SnapshotId:    String("snap-1234"),
+				Attribute:     String("createVolumePermission"),
+				OperationType: String("remove"),
+				GroupNames:    []*string{String("all")},
+			}).ExpectCalls("ModifySnapshotAttribute").Run(t)
+		})
+	})
 }
@@ -0,0 +1,64 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestSpotfleet(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create spotfleet role=arn:aws:iam::123456789012:role/fleet-role capacity=3 image=ami-1234 type=m4.large "+
+			"subnet=sub-1234 keypair=mykp securitygroups=sg-1234,sg-5678 price=0.05 interruption=Hibernate allocation=Diversified").
+			Mock(&ec2Mock{
+				DescribeReservedInstancesOfferingsFunc: instanceTypesOfferings("t2.nano", "t2.micro", "m4.large"),
+				RequestSpotFleetFunc: func(param0 *ec2.RequestSpotFleetInput) (*ec2.RequestSpotFleetOutput, error) {
+					return &ec2.RequestSpotFleetOutput{SpotFleetRequestId: String("sfr-1234")}, nil
+				},
+			}).ExpectInput("RequestSpotFleet", &ec2.RequestSpotFleetInput{
+			SpotFleetRequestConfig: &ec2.SpotFleetRequestConfigData{
+				IamFleetRole:                 String("arn:aws:iam::123456789012:role/fleet-role"),
+				TargetCapacity:               Int64(3),
+				SpotPrice:                    String("0.05"),
+				InstanceInterruptionBehavior: String("hibernate"),
+				AllocationStrategy:           String("diversified"),
+				LaunchSpecifications: []*ec2.SpotFleetLaunchSpecification{
+					{
+						ImageId:        String("ami-1234"),
+						InstanceType:   String("m4.large"),
+						KeyName:        String("mykp"),
+						SubnetId:       String("sub-1234"),
+						SecurityGroups: []*ec2.GroupIdentifier{{GroupId: String("sg-1234")}, {GroupId: String("sg-5678")}},
+					},
+				},
+			},
+		}).ExpectCommandResult("sfr-1234").IgnoreInput("DescribeReservedInstancesOfferings").ExpectCalls("DescribeReservedInstancesOfferings", "RequestSpotFleet").
+			ExpectRevert("delete spotfleet id=sfr-1234").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		t.Run("terminating instances", func(t *testing.T) {
+			Template("delete spotfleet id=sfr-1234").
+				Mock(&ec2Mock{
+					CancelSpotFleetRequestsFunc: func(param0 *ec2.CancelSpotFleetRequestsInput) (*ec2.CancelSpotFleetRequestsOutput, error) {
+						return nil, nil
+					},
+				}).ExpectInput("CancelSpotFleetRequests", &ec2.CancelSpotFleetRequestsInput{
+				SpotFleetRequestIds: []*string{String("sfr-1234")},
+				TerminateInstances:  Bool(true),
+			}).ExpectCalls("CancelSpotFleetRequests").Run(t)
+		})
+
+		t.Run("keeping instances", func(t *testing.T) {
+			Template("delete spotfleet id=sfr-1234 terminate-instances=false").
+				Mock(&ec2Mock{
+					CancelSpotFleetRequestsFunc: func(param0 *ec2.CancelSpotFleetRequestsInput) (*ec2.CancelSpotFleetRequestsOutput, error) {
+						return nil, nil
+					},
+				}).ExpectInput("CancelSpotFleetRequests", &ec2.CancelSpotFleetRequestsInput{
+				SpotFleetRequestIds: []*string{String("sfr-1234")},
+				TerminateInstances:  Bool(false),
+			}).ExpectCalls("CancelSpotFleetRequests").Run(t)
+		})
+	})
+}
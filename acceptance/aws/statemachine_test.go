@@ -0,0 +1,50 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+)
+
+func TestStateMachine(t *testing.T) {
+	_, definitionFilePath, definitionClean := generateTmpFile(`{"StartAt": "Hello", "States": {"Hello": {"Type": "Pass", "End": true}}}`)
+	defer definitionClean()
+
+	t.Run("create", func(t *testing.T) {
+		Template("create statemachine name=order-workflow definition-file="+definitionFilePath+" role=arn:aws:iam::123456789012:role/states").
+			Mock(&sfnMock{
+				CreateStateMachineFunc: func(param0 *sfn.CreateStateMachineInput) (*sfn.CreateStateMachineOutput, error) {
+					return &sfn.CreateStateMachineOutput{StateMachineArn: String("new-machine-arn")}, nil
+				},
+			}).ExpectInput("CreateStateMachine", &sfn.CreateStateMachineInput{
+			Name:       String("order-workflow"),
+			Definition: String(`{"StartAt": "Hello", "States": {"Hello": {"Type": "Pass", "End": true}}}`),
+			RoleArn:    String("arn:aws:iam::123456789012:role/states"),
+		}).ExpectCommandResult("new-machine-arn").ExpectCalls("CreateStateMachine").
+			ExpectRevert("delete statemachine id=new-machine-arn").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete statemachine id=machine-arn").
+			Mock(&sfnMock{
+				DeleteStateMachineFunc: func(param0 *sfn.DeleteStateMachineInput) (*sfn.DeleteStateMachineOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteStateMachine", &sfn.DeleteStateMachineInput{
+			StateMachineArn: String("machine-arn"),
+		}).ExpectCalls("DeleteStateMachine").Run(t)
+	})
+
+	t.Run("start", func(t *testing.T) {
+		Template("start statemachine id=machine-arn name=order-42 input='{\"order\": 42}'").
+			Mock(&sfnMock{
+				StartExecutionFunc: func(param0 *sfn.StartExecutionInput) (*sfn.StartExecutionOutput, error) {
+					return &sfn.StartExecutionOutput{ExecutionArn: String("new-execution-arn")}, nil
+				},
+			}).ExpectInput("StartExecution", &sfn.StartExecutionInput{
+			StateMachineArn: String("machine-arn"),
+			Name:            String("order-42"),
+			Input:           String(`{"order": 42}`),
+		}).ExpectCommandResult("new-execution-arn").ExpectCalls("StartExecution").Run(t)
+	})
+}
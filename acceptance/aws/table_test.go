@@ -0,0 +1,89 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+func TestTable(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create table name=my-table hash-key=id read-capacity=5 write-capacity=10").
+			Mock(&dynamodbMock{
+				CreateTableFunc: func(param0 *dynamodb.CreateTableInput) (*dynamodb.CreateTableOutput, error) {
+					return &dynamodb.CreateTableOutput{TableDescription: &dynamodb.TableDescription{TableName: String("my-table")}}, nil
+				},
+			}).ExpectInput("CreateTable", &dynamodb.CreateTableInput{
+			TableName:             String("my-table"),
+			KeySchema:             []*dynamodb.KeySchemaElement{{AttributeName: String("id"), KeyType: String("HASH")}},
+			AttributeDefinitions:  []*dynamodb.AttributeDefinition{{AttributeName: String("id"), AttributeType: String("S")}},
+			ProvisionedThroughput: &dynamodb.ProvisionedThroughput{ReadCapacityUnits: Int64(5), WriteCapacityUnits: Int64(10)},
+		}).ExpectCommandResult("my-table").ExpectCalls("CreateTable").ExpectRevert("delete table name=my-table").Run(t)
+	})
+
+	t.Run("create with range key", func(t *testing.T) {
+		Template("create table name=my-table hash-key=id hash-key-type=n range-key=date range-key-type=S read-capacity=1 write-capacity=1").
+			Mock(&dynamodbMock{
+				CreateTableFunc: func(param0 *dynamodb.CreateTableInput) (*dynamodb.CreateTableOutput, error) {
+					return &dynamodb.CreateTableOutput{TableDescription: &dynamodb.TableDescription{TableName: String("my-table")}}, nil
+				},
+			}).ExpectInput("CreateTable", &dynamodb.CreateTableInput{
+			TableName: String("my-table"),
+			KeySchema: []*dynamodb.KeySchemaElement{
+				{AttributeName: String("id"), KeyType: String("HASH")},
+				{AttributeName: String("date"), KeyType: String("RANGE")},
+			},
+			AttributeDefinitions: []*dynamodb.AttributeDefinition{
+				{AttributeName: String("id"), AttributeType: String("N")},
+				{AttributeName: String("date"), AttributeType: String("S")},
+			},
+			ProvisionedThroughput: &dynamodb.ProvisionedThroughput{ReadCapacityUnits: Int64(1), WriteCapacityUnits: Int64(1)},
+		}).ExpectCommandResult("my-table").ExpectCalls("CreateTable").Run(t)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		Template("update table name=my-table read-capacity=20 write-capacity=30").
+			Mock(&dynamodbMock{
+				UpdateTableFunc: func(param0 *dynamodb.UpdateTableInput) (*dynamodb.UpdateTableOutput, error) {
+					return &dynamodb.UpdateTableOutput{TableDescription: &dynamodb.TableDescription{TableName: String("my-table")}}, nil
+				},
+			}).ExpectInput("UpdateTable", &dynamodb.UpdateTableInput{
+			TableName:             String("my-table"),
+			ProvisionedThroughput: &dynamodb.ProvisionedThroughput{ReadCapacityUnits: Int64(20), WriteCapacityUnits: Int64(30)},
+		}).ExpectCalls("UpdateTable").Run(t)
+	})
+
+	t.Run("update index keeping current write capacity", func(t *testing.T) {
+		Template("update table name=my-table index=by-email read-capacity=8").
+			Mock(&dynamodbMock{
+				DescribeTableFunc: func(param0 *dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error) {
+					return &dynamodb.DescribeTableOutput{Table: &dynamodb.TableDescription{
+						TableName:             String("my-table"),
+						ProvisionedThroughput: &dynamodb.ProvisionedThroughputDescription{ReadCapacityUnits: Int64(5), WriteCapacityUnits: Int64(5)},
+						GlobalSecondaryIndexes: []*dynamodb.GlobalSecondaryIndexDescription{
+							{IndexName: String("by-email"), ProvisionedThroughput: &dynamodb.ProvisionedThroughputDescription{ReadCapacityUnits: Int64(2), WriteCapacityUnits: Int64(3)}},
+						},
+					}}, nil
+				},
+				UpdateTableFunc: func(param0 *dynamodb.UpdateTableInput) (*dynamodb.UpdateTableOutput, error) {
+					return &dynamodb.UpdateTableOutput{TableDescription: &dynamodb.TableDescription{TableName: String("my-table")}}, nil
+				},
+			}).ExpectInput("DescribeTable", &dynamodb.DescribeTableInput{TableName: String("my-table")}).
+			ExpectInput("UpdateTable", &dynamodb.UpdateTableInput{
+				TableName: String("my-table"),
+				GlobalSecondaryIndexUpdates: []*dynamodb.GlobalSecondaryIndexUpdate{
+					{Update: &dynamodb.UpdateGlobalSecondaryIndexAction{IndexName: String("by-email"), ProvisionedThroughput: &dynamodb.ProvisionedThroughput{ReadCapacityUnits: Int64(8), WriteCapacityUnits: Int64(3)}}},
+				},
+			}).ExpectCalls("DescribeTable", "UpdateTable").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete table name=my-table").
+			Mock(&dynamodbMock{
+				DeleteTableFunc: func(param0 *dynamodb.DeleteTableInput) (*dynamodb.DeleteTableOutput, error) {
+					return nil, nil
+				},
+			}).ExpectInput("DeleteTable", &dynamodb.DeleteTableInput{TableName: String("my-table")}).
+			ExpectCalls("DeleteTable").Run(t)
+	})
+}
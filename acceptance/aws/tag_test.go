@@ -1,14 +1,24 @@
 package awsat
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/client/metadata"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 )
 
+// taggingMock mocks the resource groups tagging API while satisfying
+// the EC2 API of the tag commands
+type taggingMock struct {
+	*resourcegroupstaggingapiMock
+	ec2iface.EC2API
+}
+
 func TestTag(t *testing.T) {
 	t.Run("create", func(t *testing.T) {
 		Template("create tag key=MyKey resource=any-resource-id value=Value").Mock(&ec2Mock{
@@ -33,4 +43,62 @@ func TestTag(t *testing.T) {
 				Tags:      []*ec2.Tag{{Key: String("MyKey")}},
 			}).ExpectCalls("DeleteTags").Run(t)
 	})
+
+	t.Run("create by arn", func(t *testing.T) {
+		Template("create tag key=Env resource=arn:aws:s3:::my-bucket value=prod").Mock(&taggingMock{resourcegroupstaggingapiMock: &resourcegroupstaggingapiMock{
+			TagResourcesFunc: func(input *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+				return &resourcegroupstaggingapi.TagResourcesOutput{}, nil
+			}}}).
+			ExpectInput("TagResources", &resourcegroupstaggingapi.TagResourcesInput{
+				ResourceARNList: []*string{String("arn:aws:s3:::my-bucket")},
+				Tags:            map[string]*string{"Env": String("prod")},
+			}).ExpectCalls("TagResources").
+			ExpectRevert("delete tag key=Env resource=arn:aws:s3:::my-bucket value=prod").Run(t)
+	})
+
+	t.Run("create by query", func(t *testing.T) {
+		var arns []*string
+		for i := 0; i < 25; i++ {
+			arns = append(arns, String(fmt.Sprintf("arn:aws:lambda:eu-west-1:123456789012:function:func-%d", i)))
+		}
+		var tagged []*string
+		Template("create tag key=Owner query=['Env=staging',lambda:function] value=ops").Mock(&taggingMock{resourcegroupstaggingapiMock: &resourcegroupstaggingapiMock{
+			GetResourcesFunc: func(input *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+				var mappings []*resourcegroupstaggingapi.ResourceTagMapping
+				for _, arn := range arns {
+					mappings = append(mappings, &resourcegroupstaggingapi.ResourceTagMapping{ResourceARN: arn})
+				}
+				return &resourcegroupstaggingapi.GetResourcesOutput{ResourceTagMappingList: mappings}, nil
+			},
+			TagResourcesFunc: func(input *resourcegroupstaggingapi.TagResourcesInput) (*resourcegroupstaggingapi.TagResourcesOutput, error) {
+				if len(input.ResourceARNList) > 20 {
+					t.Fatalf("got %d resources, want at most 20 per call", len(input.ResourceARNList))
+				}
+				tagged = append(tagged, input.ResourceARNList...)
+				return &resourcegroupstaggingapi.TagResourcesOutput{}, nil
+			}}}).
+			ExpectInput("GetResources", &resourcegroupstaggingapi.GetResourcesInput{
+				TagFilters:          []*resourcegroupstaggingapi.TagFilter{{Key: String("Env"), Values: []*string{String("staging")}}},
+				ResourceTypeFilters: []*string{String("lambda:function")},
+			}).IgnoreInput("TagResources").ExpectCalls("GetResources", "TagResources", "TagResources").Run(t)
+		if got, want := len(tagged), len(arns); got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("delete by query", func(t *testing.T) {
+		Template("delete tag key=Owner query='Env=staging'").Mock(&taggingMock{resourcegroupstaggingapiMock: &resourcegroupstaggingapiMock{
+			GetResourcesFunc: func(input *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+				return &resourcegroupstaggingapi.GetResourcesOutput{ResourceTagMappingList: []*resourcegroupstaggingapi.ResourceTagMapping{
+					{ResourceARN: String("arn:aws:s3:::my-bucket")},
+				}}, nil
+			},
+			UntagResourcesFunc: func(input *resourcegroupstaggingapi.UntagResourcesInput) (*resourcegroupstaggingapi.UntagResourcesOutput, error) {
+				return &resourcegroupstaggingapi.UntagResourcesOutput{}, nil
+			}}}).
+			ExpectInput("UntagResources", &resourcegroupstaggingapi.UntagResourcesInput{
+				ResourceARNList: []*string{String("arn:aws:s3:::my-bucket")},
+				TagKeys:         []*string{String("Owner")},
+			}).IgnoreInput("GetResources").ExpectCalls("GetResources", "UntagResources").Run(t)
+	})
 }
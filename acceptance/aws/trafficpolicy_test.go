@@ -0,0 +1,64 @@
+package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/route53"
+)
+
+func TestTrafficpolicy(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		document := `{"AWSPolicyFormatVersion":"2015-10-01","RecordType":"A","StartRule":"geo","Endpoints":{}}`
+		_, documentFile, cleanup := generateTmpFile(document)
+		defer cleanup()
+
+		Template("create trafficpolicy name=geo-web document-file="+documentFile+" comment=geolocation").Mock(&route53Mock{
+			CreateTrafficPolicyFunc: func(input *route53.CreateTrafficPolicyInput) (*route53.CreateTrafficPolicyOutput, error) {
+				return &route53.CreateTrafficPolicyOutput{TrafficPolicy: &route53.TrafficPolicy{Id: String("new-trafficpolicy-id"), Version: Int64(1)}}, nil
+			},
+		}).ExpectInput("CreateTrafficPolicy", &route53.CreateTrafficPolicyInput{
+			Name:     String("geo-web"),
+			Document: String(document),
+			Comment:  String("geolocation"),
+		}).ExpectCommandResult("new-trafficpolicy-id").ExpectCalls("CreateTrafficPolicy").
+			ExpectRevert("delete trafficpolicy id=new-trafficpolicy-id version=1").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete trafficpolicy id=any-trafficpolicy-id version=2").Mock(&route53Mock{
+			DeleteTrafficPolicyFunc: func(input *route53.DeleteTrafficPolicyInput) (*route53.DeleteTrafficPolicyOutput, error) {
+				return nil, nil
+			},
+		}).ExpectInput("DeleteTrafficPolicy", &route53.DeleteTrafficPolicyInput{
+			Id:      String("any-trafficpolicy-id"),
+			Version: Int64(2),
+		}).ExpectCalls("DeleteTrafficPolicy").Run(t)
+	})
+}
+
+func TestTrafficpolicyinstance(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		Template("create trafficpolicyinstance zone=Z1234 name=www.example.com ttl=60 policy=any-trafficpolicy-id version=1").Mock(&route53Mock{
+			CreateTrafficPolicyInstanceFunc: func(input *route53.CreateTrafficPolicyInstanceInput) (*route53.CreateTrafficPolicyInstanceOutput, error) {
+				return &route53.CreateTrafficPolicyInstanceOutput{TrafficPolicyInstance: &route53.TrafficPolicyInstance{Id: String("new-trafficpolicyinstance-id")}}, nil
+			},
+		}).ExpectInput("CreateTrafficPolicyInstance", &route53.CreateTrafficPolicyInstanceInput{
+			HostedZoneId:         String("Z1234"),
+			Name:                 String("www.example.com"),
+			TTL:                  Int64(60),
+			TrafficPolicyId:      String("any-trafficpolicy-id"),
+			TrafficPolicyVersion: Int64(1),
+		}).ExpectCommandResult("new-trafficpolicyinstance-id").ExpectCalls("CreateTrafficPolicyInstance").
+			ExpectRevert("delete trafficpolicyinstance id=new-trafficpolicyinstance-id").Run(t)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		Template("delete trafficpolicyinstance id=any-trafficpolicyinstance-id").Mock(&route53Mock{
+			DeleteTrafficPolicyInstanceFunc: func(input *route53.DeleteTrafficPolicyInstanceInput) (*route53.DeleteTrafficPolicyInstanceOutput, error) {
+				return nil, nil
+			},
+		}).ExpectInput("DeleteTrafficPolicyInstance", &route53.DeleteTrafficPolicyInstanceInput{
+			Id: String("any-trafficpolicyinstance-id"),
+		}).ExpectCalls("DeleteTrafficPolicyInstance").Run(t)
+	})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit checks the security posture of the resources of a graph
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+)
+
+type Severity int
+
+const (
+	Low Severity = iota + 1
+	Medium
+	High
+)
+
+var severityNames = map[Severity]string{Low: "low", Medium: "medium", High: "high"}
+
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+func ParseSeverity(s string) (Severity, error) {
+	for sev, name := range severityNames {
+		if strings.EqualFold(name, s) {
+			return sev, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid severity '%s', expecting one of low, medium, high", s)
+}
+
+// Finding is a weakness of a resource reported by a check
+type Finding struct {
+	Check        string   `json:"check"`
+	Severity     Severity `json:"severity"`
+	ResourceID   string   `json:"resourceId"`
+	ResourceType string   `json:"resourceType"`
+	ResourceName string   `json:"resourceName,omitempty"`
+	Message      string   `json:"message"`
+}
+
+func newFinding(check string, sev Severity, res cloud.Resource, format string, a ...interface{}) *Finding {
+	name, _ := res.Properties()[properties.Name].(string)
+	return &Finding{Check: check, Severity: sev, ResourceID: res.Id(), ResourceType: res.Type(), ResourceName: name, Message: fmt.Sprintf(format, a...)}
+}
+
+// Check inspects the resources of a graph, as synced locally
+type Check struct {
+	Name, Description string
+	Run               func(g cloud.GraphAPI, now time.Time) ([]*Finding, error)
+}
+
+// Checks are the built-in checks, run by default
+var Checks = []*Check{
+	{Name: "public_buckets", Description: "S3 buckets whose ACL grants access to anybody or to any AWS account", Run: publicBuckets},
+	{Name: "open_securitygroups", Description: "Security groups allowing inbound traffic from anywhere on other ports than HTTP(S)", Run: openSecurityGroups},
+	{Name: "unused_accesskeys", Description: "Inactive access keys left behind and active access keys not used for 90 days", Run: unusedAccessKeys},
+	{Name: "unencrypted_volumes", Description: "EBS volumes not encrypted at rest", Run: unencryptedVolumes},
+	{Name: "users_without_mfa", Description: "IAM users signing in with a password without MFA device", Run: usersWithoutMFA},
+}
+
+func LookupCheck(name string) (*Check, bool) {
+	for _, c := range Checks {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// Run runs the checks on the graph and returns their findings, the most severe first
+func Run(g cloud.GraphAPI, now time.Time, checks ...*Check) ([]*Finding, error) {
+	var all []*Finding
+	for _, c := range checks {
+		findings, err := c.Run(g, now)
+		if err != nil {
+			return all, fmt.Errorf("%s: %s", c.Name, err)
+		}
+		all = append(all, findings...)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Severity != all[j].Severity {
+			return all[i].Severity > all[j].Severity
+		}
+		if all[i].Check != all[j].Check {
+			return all[i].Check < all[j].Check
+		}
+		return all[i].ResourceID < all[j].ResourceID
+	})
+	return all, nil
+}
@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestRunChecks(t *testing.T) {
+	now := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	_, anywhere, _ := net.ParseCIDR("0.0.0.0/0")
+	_, private, _ := net.ParseCIDR("10.0.0.0/16")
+	openRule := func(from, to int64, ranges ...*net.IPNet) *graph.FirewallRule {
+		return &graph.FirewallRule{Protocol: "tcp", PortRange: graph.PortRange{FromPort: from, ToPort: to}, IPRanges: ranges}
+	}
+
+	g := graph.NewGraph()
+	withMFA := resourcetest.User("user_mfa").Prop(properties.PasswordLastUsed, now).Build()
+	mfa := resourcetest.MfaDevice("mfa_1").Build()
+	g.AddResource(withMFA, mfa,
+		resourcetest.User("user_nomfa").Prop(properties.Name, "bob").Prop(properties.PasswordLastUsed, now).Build(),
+		resourcetest.User("user_hardwaremfa").Prop(properties.PasswordLastUsed, now).Prop(properties.MFAActive, true).Build(),
+		resourcetest.User("user_mfa_removed").Prop(properties.PasswordLastUsed, now).Prop(properties.MFAActive, false).Build(),
+		resourcetest.User("user_api").Build(),
+		resourcetest.Bucket("bucket_public").Prop(properties.Grants, []*graph.Grant{
+			{Permission: "READ", Grantee: graph.Grantee{GranteeType: "Group", GranteeID: "http://acs.amazonaws.com/groups/global/AllUsers"}},
+		}).Build(),
+		resourcetest.Bucket("bucket_private").Prop(properties.Grants, []*graph.Grant{
+			{Permission: "FULL_CONTROL", Grantee: graph.Grantee{GranteeType: "CanonicalUser", GranteeID: "1234"}},
+		}).Build(),
+		resourcetest.SecurityGroup("sg_ssh").Prop(properties.InboundRules, []*graph.FirewallRule{openRule(22, 22, anywhere), openRule(443, 443, anywhere)}).Build(),
+		resourcetest.SecurityGroup("sg_app").Prop(properties.InboundRules, []*graph.FirewallRule{openRule(8000, 8080, anywhere), openRule(22, 22, private)}).Build(),
+		resourcetest.SecurityGroup("sg_all").Prop(properties.InboundRules, []*graph.FirewallRule{
+			{Protocol: "any", PortRange: graph.PortRange{Any: true}, IPRanges: []*net.IPNet{anywhere}},
+		}).Build(),
+		resourcetest.AccessKey("key_inactive").Prop(properties.Username, "alice").Prop(properties.State, "Inactive").Build(),
+		resourcetest.AccessKey("key_never").Prop(properties.Username, "alice").Prop(properties.State, "Active").Prop(properties.Created, now.AddDate(0, 0, -120)).Build(),
+		resourcetest.AccessKey("key_idle").Prop(properties.Username, "alice").Prop(properties.State, "Active").Prop(properties.Created, now.AddDate(0, 0, -400)).Prop(properties.LastUsed, now.AddDate(0, 0, -100)).Build(),
+		resourcetest.AccessKey("key_daily").Prop(properties.Username, "alice").Prop(properties.State, "Active").Prop(properties.Created, now.AddDate(0, 0, -400)).Prop(properties.LastUsed, now.AddDate(0, 0, -1)).Build(),
+		resourcetest.AccessKey("key_new").Prop(properties.Username, "alice").Prop(properties.State, "Active").Prop(properties.Created, now.AddDate(0, 0, -10)).Build(),
+		resourcetest.Volume("vol_clear").Prop(properties.Encrypted, false).Build(),
+		resourcetest.Volume("vol_encrypted").Prop(properties.Encrypted, true).Build(),
+	)
+	g.AddAppliesOnRelation(withMFA, mfa)
+
+	findings, err := Run(g, now, Checks...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		got = append(got, fmt.Sprintf("%s %s %s: %s", f.Severity, f.Check, f.ResourceID, f.Message))
+	}
+	exp := []string{
+		"high open_securitygroups sg_all: any traffic on all ports open to 0.0.0.0/0",
+		"high open_securitygroups sg_ssh: tcp port 22 (SSH) open to 0.0.0.0/0",
+		"high public_buckets bucket_public: READ granted to anybody",
+		"high users_without_mfa user_mfa_removed: user signs in with a password without MFA device",
+		"high users_without_mfa user_nomfa: user signs in with a password without MFA device",
+		"medium open_securitygroups sg_app: tcp port 8000-8080 open to 0.0.0.0/0",
+		"medium unencrypted_volumes vol_clear: volume not encrypted at rest",
+		"medium unused_accesskeys key_idle: active access key of user alice not used for 100 days",
+		"medium unused_accesskeys key_never: active access key of user alice never used since its creation 120 days ago",
+		"low unused_accesskeys key_inactive: inactive access key of user alice left behind",
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got\n%q\nwant\n%q", got, exp)
+	}
+
+	b, err := json.Marshal(findings[4])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"check":"users_without_mfa","severity":"high","resourceId":"user_nomfa","resourceType":"user","resourceName":"bob","message":"user signs in with a password without MFA device"}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if sev, err := ParseSeverity("Medium"); err != nil || sev != Medium {
+		t.Fatalf("got %s, %v", sev, err)
+	}
+	if _, err := ParseSeverity("critical"); err == nil {
+		t.Fatal("expected error")
+	}
+}
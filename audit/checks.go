@@ -0,0 +1,194 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/cloud/rdf"
+	"github.com/wallix/awless/graph"
+)
+
+const accessKeyMaxUnused = 90 * 24 * time.Hour
+
+// sensitivePorts are the ports of administration and databases that should never be open to anywhere
+var sensitivePorts = []struct {
+	port int64
+	name string
+}{
+	{22, "SSH"}, {3389, "RDP"}, {1433, "MSSQL"}, {3306, "MySQL"}, {5432, "PostgreSQL"},
+	{6379, "Redis"}, {9200, "Elasticsearch"}, {11211, "Memcached"}, {27017, "MongoDB"},
+}
+
+func publicBuckets(g cloud.GraphAPI, now time.Time) ([]*Finding, error) {
+	buckets, err := g.Find(cloud.NewQuery(cloud.Bucket))
+	if err != nil {
+		return nil, err
+	}
+	var findings []*Finding
+	for _, b := range buckets {
+		grants, _ := b.Properties()[properties.Grants].([]*graph.Grant)
+		var toAll, toAuthenticated []string
+		for _, grant := range grants {
+			switch {
+			case strings.Contains(grant.Grantee.GranteeID, "AllUsers"):
+				toAll = append(toAll, grant.Permission)
+			case strings.Contains(grant.Grantee.GranteeID, "AuthenticatedUsers"):
+				toAuthenticated = append(toAuthenticated, grant.Permission)
+			}
+		}
+		if len(toAll) > 0 {
+			findings = append(findings, newFinding("public_buckets", High, b, "%s granted to anybody", strings.Join(toAll, ", ")))
+		}
+		if len(toAuthenticated) > 0 {
+			findings = append(findings, newFinding("public_buckets", High, b, "%s granted to any AWS account", strings.Join(toAuthenticated, ", ")))
+		}
+	}
+	return findings, nil
+}
+
+func openSecurityGroups(g cloud.GraphAPI, now time.Time) ([]*Finding, error) {
+	groups, err := g.Find(cloud.NewQuery(cloud.SecurityGroup))
+	if err != nil {
+		return nil, err
+	}
+	var findings []*Finding
+	for _, sg := range groups {
+		rules, _ := sg.Properties()[properties.InboundRules].([]*graph.FirewallRule)
+		for _, r := range rules {
+			anywhere := openToAnywhere(r)
+			if anywhere == "" {
+				continue
+			}
+			switch {
+			case r.PortRange.Any:
+				findings = append(findings, newFinding("open_securitygroups", High, sg, "%s traffic on all ports open to %s", r.Protocol, anywhere))
+			case isHTTPPortRange(r.PortRange):
+				continue
+			default:
+				sev, exposed := Medium, portRangeText(r.PortRange)
+				for _, sensitive := range sensitivePorts {
+					if r.PortRange.Contains(sensitive.port) {
+						sev, exposed = High, fmt.Sprintf("%s (%s)", exposed, sensitive.name)
+						break
+					}
+				}
+				findings = append(findings, newFinding("open_securitygroups", sev, sg, "%s port %s open to %s", r.Protocol, exposed, anywhere))
+			}
+		}
+	}
+	return findings, nil
+}
+
+// unusedAccessKeys reports the inactive access keys and the active ones not used for 90 days,
+// or never used since their creation 90 days ago
+func unusedAccessKeys(g cloud.GraphAPI, now time.Time) ([]*Finding, error) {
+	keys, err := g.Find(cloud.NewQuery(cloud.AccessKey))
+	if err != nil {
+		return nil, err
+	}
+	var findings []*Finding
+	for _, k := range keys {
+		user, _ := k.Properties()[properties.Username].(string)
+		state, _ := k.Properties()[properties.State].(string)
+		if !strings.EqualFold(state, "active") {
+			findings = append(findings, newFinding("unused_accesskeys", Low, k, "inactive access key of user %s left behind", user))
+			continue
+		}
+		if lastUsed, ok := k.Properties()[properties.LastUsed].(time.Time); ok {
+			if now.Sub(lastUsed) > accessKeyMaxUnused {
+				findings = append(findings, newFinding("unused_accesskeys", Medium, k, "active access key of user %s not used for %d days", user, int(now.Sub(lastUsed).Hours()/24)))
+			}
+			continue
+		}
+		if created, ok := k.Properties()[properties.Created].(time.Time); ok && now.Sub(created) > accessKeyMaxUnused {
+			findings = append(findings, newFinding("unused_accesskeys", Medium, k, "active access key of user %s never used since its creation %d days ago", user, int(now.Sub(created).Hours()/24)))
+		}
+	}
+	return findings, nil
+}
+
+func unencryptedVolumes(g cloud.GraphAPI, now time.Time) ([]*Finding, error) {
+	volumes, err := g.Find(cloud.NewQuery(cloud.Volume))
+	if err != nil {
+		return nil, err
+	}
+	var findings []*Finding
+	for _, v := range volumes {
+		if encrypted, ok := v.Properties()[properties.Encrypted].(bool); ok && !encrypted {
+			findings = append(findings, newFinding("unencrypted_volumes", Medium, v, "volume not encrypted at rest"))
+		}
+	}
+	return findings, nil
+}
+
+// usersWithoutMFA reports the users who signed in with a password without any kind of MFA device.
+// Without the MFA status of a user (synced by former versions), only its virtual MFA devices
+// depending on it (i.e. the user applies on its devices) are considered
+func usersWithoutMFA(g cloud.GraphAPI, now time.Time) ([]*Finding, error) {
+	users, err := g.Find(cloud.NewQuery(cloud.User))
+	if err != nil {
+		return nil, err
+	}
+	var findings []*Finding
+	for _, u := range users {
+		if _, ok := u.Properties()[properties.PasswordLastUsed].(time.Time); !ok {
+			continue
+		}
+		hasMFA, known := u.Properties()[properties.MFAActive].(bool)
+		if !known {
+			applied, err := g.ResourceRelations(u, rdf.ApplyOn, false)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range applied {
+				if r.Type() == cloud.MFADevice {
+					hasMFA = true
+				}
+			}
+		}
+		if !hasMFA {
+			findings = append(findings, newFinding("users_without_mfa", High, u, "user signs in with a password without MFA device"))
+		}
+	}
+	return findings, nil
+}
+
+// openToAnywhere returns the range of the rule covering the whole internet, if any
+func openToAnywhere(r *graph.FirewallRule) string {
+	for _, n := range r.IPRanges {
+		if ones, _ := n.Mask.Size(); ones == 0 {
+			return n.String()
+		}
+	}
+	return ""
+}
+
+func isHTTPPortRange(p graph.PortRange) bool {
+	return (p.FromPort == 80 || p.FromPort == 443) && p.FromPort == p.ToPort
+}
+
+func portRangeText(p graph.PortRange) string {
+	if p.FromPort == p.ToPort {
+		return fmt.Sprint(p.FromPort)
+	}
+	return fmt.Sprintf("%d-%d", p.FromPort, p.ToPort)
+}
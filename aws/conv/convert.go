@@ -31,19 +31,24 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/apigateway"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sfn"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/cloud/properties"
@@ -88,6 +93,10 @@ func InitResource(source interface{}) (*graph.Resource, error) {
 		res = graph.InitResource(cloud.Snapshot, awssdk.StringValue(ss.SnapshotId))
 	case *ec2.NetworkInterface:
 		res = graph.InitResource(cloud.NetworkInterface, awssdk.StringValue(ss.NetworkInterfaceId))
+	case *ec2.SpotFleetRequestConfig:
+		res = graph.InitResource(cloud.SpotFleet, awssdk.StringValue(ss.SpotFleetRequestId))
+	case *ec2.SpotInstanceRequest:
+		res = graph.InitResource(cloud.SpotRequest, awssdk.StringValue(ss.SpotInstanceRequestId))
 	// Loadbalancer
 	case *elbv2.LoadBalancer:
 		res = graph.InitResource(cloud.LoadBalancer, awssdk.StringValue(ss.LoadBalancerArn))
@@ -100,6 +109,14 @@ func InitResource(source interface{}) (*graph.Resource, error) {
 		res = graph.InitResource(cloud.Database, awssdk.StringValue(ss.DBInstanceIdentifier))
 	case *rds.DBSubnetGroup:
 		res = graph.InitResource(cloud.DbSubnetGroup, awssdk.StringValue(ss.DBSubnetGroupArn))
+	case *elasticache.CacheCluster:
+		res = graph.InitResource(cloud.CacheCluster, awssdk.StringValue(ss.CacheClusterId))
+	case *elasticache.ReplicationGroup:
+		res = graph.InitResource(cloud.ReplicationGroup, awssdk.StringValue(ss.ReplicationGroupId))
+	case *dynamodb.TableDescription:
+		res = graph.InitResource(cloud.Table, awssdk.StringValue(ss.TableName))
+	case *dynamodb.GlobalSecondaryIndexDescription:
+		res = graph.InitResource(cloud.TableIndex, awssdk.StringValue(ss.IndexArn))
 		// Autoscaling
 	case *autoscaling.LaunchConfiguration:
 		res = graph.InitResource(cloud.LaunchConfiguration, awssdk.StringValue(ss.LaunchConfigurationARN))
@@ -112,6 +129,8 @@ func InitResource(source interface{}) (*graph.Resource, error) {
 		res = graph.InitResource(cloud.Repository, awssdk.StringValue(ss.RepositoryArn))
 	case *ecs.Cluster:
 		res = graph.InitResource(cloud.ContainerCluster, awssdk.StringValue(ss.ClusterArn))
+	case *ecs.Service:
+		res = graph.InitResource(cloud.ContainerService, awssdk.StringValue(ss.ServiceArn))
 	case *ecs.TaskDefinition:
 		res = graph.InitResource(cloud.ContainerTask, awssdk.StringValue(ss.TaskDefinitionArn))
 	case *ecs.Container:
@@ -121,6 +140,13 @@ func InitResource(source interface{}) (*graph.Resource, error) {
 		// ACM
 	case *acm.CertificateSummary:
 		res = graph.InitResource(cloud.Certificate, awssdk.StringValue(ss.CertificateArn))
+	// KMS
+	case *kms.KeyMetadata:
+		res = graph.InitResource(cloud.Key, awssdk.StringValue(ss.KeyId))
+	case *kms.AliasListEntry:
+		res = graph.InitResource(cloud.Alias, awssdk.StringValue(ss.AliasName))
+	case *kms.GrantListEntry:
+		res = graph.InitResource(cloud.KeyGrant, awssdk.StringValue(ss.GrantId))
 	// IAM
 	case *iam.User:
 		res = graph.InitResource(cloud.User, awssdk.StringValue(ss.UserId))
@@ -155,10 +181,32 @@ func InitResource(source interface{}) (*graph.Resource, error) {
 		res = graph.InitResource(cloud.Zone, awssdk.StringValue(ss.Id))
 	case *route53.ResourceRecordSet:
 		id := HashFields(awssdk.StringValue(ss.Name), awssdk.StringValue(ss.Type))
+		if ss.SetIdentifier != nil {
+			// record sets of a routing policy share their name and type
+			id = HashFields(awssdk.StringValue(ss.Name), awssdk.StringValue(ss.Type), awssdk.StringValue(ss.SetIdentifier))
+		}
 		res = graph.InitResource(cloud.Record, id)
+	case *route53.HealthCheck:
+		res = graph.InitResource(cloud.HealthCheck, awssdk.StringValue(ss.Id))
 		// Lambda
 	case *lambda.FunctionConfiguration:
 		res = graph.InitResource(cloud.Function, awssdk.StringValue(ss.FunctionArn))
+	case *lambda.AliasConfiguration:
+		res = graph.InitResource(cloud.FunctionAlias, awssdk.StringValue(ss.AliasArn))
+	case *lambda.EventSourceMappingConfiguration:
+		res = graph.InitResource(cloud.EventSourceMapping, awssdk.StringValue(ss.UUID))
+	case *sfn.StateMachineListItem:
+		res = graph.InitResource(cloud.StateMachine, awssdk.StringValue(ss.StateMachineArn))
+	case *sfn.ExecutionListItem:
+		res = graph.InitResource(cloud.Execution, awssdk.StringValue(ss.ExecutionArn))
+		// API Gateway
+	case *apigateway.RestApi:
+		res = graph.InitResource(cloud.RestApi, awssdk.StringValue(ss.Id))
+	case *apigateway.Stage:
+		// stages are only named uniquely within their rest api, whose deployments have unique ids
+		res = graph.InitResource(cloud.ApiStage, HashFields(awssdk.StringValue(ss.DeploymentId), awssdk.StringValue(ss.StageName)))
+	case *apigateway.Deployment:
+		res = graph.InitResource(cloud.ApiDeployment, awssdk.StringValue(ss.Id))
 		// Monitoring
 	case *cloudwatch.Metric:
 		id := HashFields(awssdk.StringValue(ss.Namespace), awssdk.StringValue(ss.MetricName))
@@ -168,6 +216,8 @@ func InitResource(source interface{}) (*graph.Resource, error) {
 		// cdn
 	case *cloudfront.DistributionSummary:
 		res = graph.InitResource(cloud.Distribution, awssdk.StringValue(ss.Id))
+	case *cloudfront.OriginAccessIdentitySummary:
+		res = graph.InitResource(cloud.OriginAccessIdentity, awssdk.StringValue(ss.Id))
 		// cloudformation
 	case *cloudformation.Stack:
 		res = graph.InitResource(cloud.Stack, awssdk.StringValue(ss.StackId))
@@ -221,6 +271,9 @@ func NewResource(source interface{}) (*graph.Resource, error) {
 				if err != nil {
 					errc <- fmt.Errorf("type [%s]: prop '%v': %s", res.Type(), p, err)
 				}
+				if t.extra && val == nil {
+					return
+				}
 				resultc <- keyValResult{p, val}
 			}
 		}(prop, trans)
@@ -254,6 +307,7 @@ type propertyTransform struct {
 	name      string
 	transform transformFn
 	fetch     fetchFn
+	extra     bool
 }
 
 type transformFn func(i interface{}) (interface{}, error)
@@ -625,6 +679,21 @@ var extractContainersImagesFn = func(i interface{}) (interface{}, error) {
 	return keyVals, nil
 }
 
+var extractKeySchemaFn = func(keyType string) transformFn {
+	return func(i interface{}) (interface{}, error) {
+		keys, ok := i.([]*dynamodb.KeySchemaElement)
+		if !ok {
+			return nil, fmt.Errorf("extract key schema: not a key schema element slice but a %T", i)
+		}
+		for _, k := range keys {
+			if awssdk.StringValue(k.KeyType) == keyType {
+				return awssdk.StringValue(k.AttributeName), nil
+			}
+		}
+		return nil, nil
+	}
+}
+
 func extractDocumentDefaultVersion(i interface{}) (interface{}, error) {
 	if _, ok := i.([]*iam.PolicyVersion); !ok {
 		return nil, fmt.Errorf("extract default version of document, not a policy version slice but a %T", i)
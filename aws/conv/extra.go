@@ -0,0 +1,305 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsconv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/cloud/rdf"
+)
+
+// ExtraProperty describes a property extracted from the raw AWS SDK object
+// of a resource type, in addition to the properties built in awless.
+//
+// Path is a dot separated list of the SDK struct field names (i.e. the keys of the
+// object in its JSON form), where slices are indexed with [n] or flattened with [*]:
+//
+//	Placement.Tenancy
+//	NetworkInterfaces[0].SubnetId
+//	BlockDeviceMappings[*].Ebs.VolumeId  (with "list" data type)
+type ExtraProperty struct {
+	ResourceType string `json:"type"`
+	Path         string `json:"path"`
+	Property     string `json:"property"`
+	DataType     string `json:"datatype,omitempty"`
+}
+
+var extraDataTypes = map[string]string{
+	"":       rdf.XsdString,
+	"string": rdf.XsdString,
+	"list":   rdf.XsdString,
+	"int":    rdf.XsdInt,
+	"bool":   rdf.XsdBoolean,
+	"time":   rdf.XsdDateTime,
+}
+
+var (
+	propertyNameRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+	pathSegmentRegex  = regexp.MustCompile(`^([A-Z][A-Za-z0-9]*)(?:\[(\*|[0-9]+)\])?$`)
+)
+
+// RegisterExtraProperty adds a property extracted from the SDK objects of the given resource type.
+// It has to be called before fetching or loading graphs.
+func RegisterExtraProperty(p ExtraProperty) error {
+	defs, ok := awsResourcesDef[p.ResourceType]
+	if !ok {
+		return fmt.Errorf("extra property '%s': unknown resource type '%s'", p.Property, p.ResourceType)
+	}
+	if !propertyNameRegex.MatchString(p.Property) {
+		return fmt.Errorf("extra property '%s': expecting a capitalized alphanumeric name (ex: Tenancy)", p.Property)
+	}
+	if _, exists := defs[p.Property]; exists {
+		return fmt.Errorf("extra property '%s': already extracted for %s", p.Property, p.ResourceType)
+	}
+	dataType, ok := extraDataTypes[p.DataType]
+	if !ok {
+		return fmt.Errorf("extra property '%s': unsupported data type '%s' (expecting string, int, bool, time or list)", p.Property, p.DataType)
+	}
+	fetch, err := extractPathFn(p.Path, p.DataType)
+	if err != nil {
+		return fmt.Errorf("extra property '%s': %s", p.Property, err)
+	}
+	if _, err = rdf.RegisterLiteralProperty(p.Property, dataType, p.DataType == "list"); err != nil {
+		return err
+	}
+
+	defs[p.Property] = &propertyTransform{name: p.Path, fetch: fetch, extra: true}
+	return nil
+}
+
+// LoadExtraProperties registers the extra properties listed in JSON in the given reader
+func LoadExtraProperties(r io.Reader) error {
+	var props []ExtraProperty
+	if err := json.NewDecoder(r).Decode(&props); err != nil {
+		return fmt.Errorf("decoding extra properties: %s", err)
+	}
+	for _, p := range props {
+		if err := RegisterExtraProperty(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadExtraPropertiesFile registers the extra properties of the given JSON file, if it exists
+func LoadExtraPropertiesFile(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err = LoadExtraProperties(f); err != nil {
+		return fmt.Errorf("%s: %s", path, err)
+	}
+	return nil
+}
+
+// CatalogEntry describes a property extracted for a resource type
+type CatalogEntry struct {
+	ResourceType string `json:"type"`
+	Property     string `json:"property"`
+	Field        string `json:"field,omitempty"`
+	DataType     string `json:"datatype,omitempty"`
+	List         bool   `json:"list,omitempty"`
+	Extra        bool   `json:"extra,omitempty"`
+}
+
+// Catalog returns all the properties extracted from the AWS SDK objects,
+// sorted by resource type and property
+func Catalog() []CatalogEntry {
+	var entries []CatalogEntry
+	for resType, defs := range awsResourcesDef {
+		for prop, def := range defs {
+			entry := CatalogEntry{ResourceType: resType, Property: prop, Field: def.name, Extra: def.extra}
+			if id, ok := rdf.Labels[prop]; ok {
+				if p, err := rdf.Properties.Get(id); err == nil {
+					entry.DataType = p.RdfsDataType
+					entry.List = p.RdfsDefinedBy == rdf.RdfsList
+				}
+			}
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].ResourceType == entries[j].ResourceType {
+			return entries[i].Property < entries[j].Property
+		}
+		return entries[i].ResourceType < entries[j].ResourceType
+	})
+	return entries
+}
+
+type pathSegment struct {
+	field string
+	index int // -1 when not indexed
+	all   bool
+}
+
+func parsePath(path string) ([]pathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	var segments []pathSegment
+	for _, s := range strings.Split(path, ".") {
+		matches := pathSegmentRegex.FindStringSubmatch(s)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid path '%s': unexpected '%s'", path, s)
+		}
+		seg := pathSegment{field: matches[1], index: -1}
+		switch matches[2] {
+		case "":
+		case "*":
+			seg.all = true
+		default:
+			seg.index, _ = strconv.Atoi(matches[2])
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+func extractPathFn(path, dataType string) (fetchFn, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	flatten := false
+	for _, s := range segments {
+		flatten = flatten || s.all
+	}
+	if flatten && dataType != "list" {
+		return nil, fmt.Errorf("path '%s' with [*] requires the list data type", path)
+	}
+
+	return func(i interface{}) (interface{}, error) {
+		values, err := walkPath(reflect.ValueOf(i), segments)
+		if err != nil {
+			return nil, fmt.Errorf("path '%s': %s", path, err)
+		}
+		if dataType == "list" {
+			var list []string
+			for _, v := range values {
+				if v.Kind() == reflect.Slice {
+					for j := 0; j < v.Len(); j++ {
+						if e := indirect(v.Index(j)); e.IsValid() {
+							list = append(list, fmt.Sprint(e.Interface()))
+						}
+					}
+				} else {
+					list = append(list, fmt.Sprint(v.Interface()))
+				}
+			}
+			if len(list) == 0 {
+				return nil, nil
+			}
+			return list, nil
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return convertExtraValue(values[0].Interface(), dataType)
+	}, nil
+}
+
+func walkPath(v reflect.Value, segments []pathSegment) ([]reflect.Value, error) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if len(segments) == 0 {
+		return []reflect.Value{v}, nil
+	}
+	seg := segments[0]
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot get '%s' in %s", seg.field, v.Type())
+	}
+	field := v.FieldByName(seg.field)
+	if !field.IsValid() {
+		return nil, fmt.Errorf("no field '%s' in %s", seg.field, v.Type())
+	}
+	if seg.index < 0 && !seg.all {
+		return walkPath(field, segments[1:])
+	}
+	if field.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("'%s' in %s is not a list", seg.field, v.Type())
+	}
+	if !seg.all {
+		if seg.index >= field.Len() {
+			return nil, nil
+		}
+		return walkPath(field.Index(seg.index), segments[1:])
+	}
+	var all []reflect.Value
+	for j := 0; j < field.Len(); j++ {
+		values, err := walkPath(field.Index(j), segments[1:])
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, values...)
+	}
+	return all, nil
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func convertExtraValue(i interface{}, dataType string) (interface{}, error) {
+	switch dataType {
+	case "int":
+		v := reflect.ValueOf(i)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return int(v.Int()), nil
+		}
+		return nil, fmt.Errorf("expected an integer, got a %T", i)
+	case "bool":
+		if b, ok := i.(bool); ok {
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected a boolean, got a %T", i)
+	case "time":
+		if t, ok := i.(time.Time); ok {
+			return t.UTC(), nil
+		}
+		return nil, fmt.Errorf("expected a time, got a %T", i)
+	default:
+		if s, ok := i.(string); ok {
+			return s, nil
+		}
+		return fmt.Sprint(i), nil
+	}
+}
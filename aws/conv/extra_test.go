@@ -0,0 +1,111 @@
+package awsconv
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+)
+
+func TestExtraProperties(t *testing.T) {
+	config := `[
+	{"type": "instance", "path": "Placement.Tenancy", "property": "ExtraTenancy"},
+	{"type": "instance", "path": "AmiLaunchIndex", "property": "ExtraLaunchIndex", "datatype": "int"},
+	{"type": "instance", "path": "NetworkInterfaces[0].SubnetId", "property": "ExtraFirstSubnet"},
+	{"type": "instance", "path": "NetworkInterfaces[*].PrivateIpAddresses[*].PrivateIpAddress", "property": "ExtraPrivateIPs", "datatype": "list"},
+	{"type": "instance", "path": "EbsOptimized", "property": "ExtraEbsOptimized", "datatype": "bool"}
+]`
+	if err := LoadExtraProperties(strings.NewReader(config)); err != nil {
+		t.Fatal(err)
+	}
+
+	instance := &ec2.Instance{
+		InstanceId:     awssdk.String("inst_1"),
+		AmiLaunchIndex: awssdk.Int64(2),
+		Placement:      &ec2.Placement{Tenancy: awssdk.String("dedicated")},
+		NetworkInterfaces: []*ec2.InstanceNetworkInterface{
+			{NetworkInterfaceId: awssdk.String("eni_1"), SubnetId: awssdk.String("sub_1"), PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{
+				{PrivateIpAddress: awssdk.String("10.0.0.1")}, {PrivateIpAddress: awssdk.String("10.0.0.2")},
+			}},
+			{NetworkInterfaceId: awssdk.String("eni_2"), SubnetId: awssdk.String("sub_2"), PrivateIpAddresses: []*ec2.InstancePrivateIpAddress{
+				{PrivateIpAddress: awssdk.String("10.0.1.1")},
+			}},
+		},
+	}
+	res, err := NewResource(instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"ExtraTenancy":     "dedicated",
+		"ExtraLaunchIndex": 2,
+		"ExtraFirstSubnet": "sub_1",
+		"ExtraPrivateIPs":  []string{"10.0.0.1", "10.0.0.2", "10.0.1.1"},
+	}
+	for k, want := range expected {
+		if got := res.Properties()[k]; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: got %#v, want %#v", k, got, want)
+		}
+	}
+	if _, ok := res.Properties()["ExtraEbsOptimized"]; ok {
+		t.Fatal("expected nil extra property to be absent")
+	}
+
+	g := graph.NewGraph()
+	g.AddResource(res)
+	var buff bytes.Buffer
+	if err = g.MarshalTo(&buff); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := graph.NewGraph()
+	if err = reloaded.Unmarshal(buff.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	inst, err := reloaded.GetResource(cloud.Instance, "inst_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := inst.Properties()["ExtraTenancy"], "dedicated"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	var found bool
+	for _, entry := range Catalog() {
+		if entry.ResourceType == cloud.Instance && entry.Property == "ExtraPrivateIPs" {
+			found = true
+			if !entry.Extra || !entry.List || entry.DataType != "xsd:string" {
+				t.Fatalf("unexpected catalog entry %#v", entry)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("extra property not in catalog")
+	}
+}
+
+func TestRegisterExtraPropertyErrors(t *testing.T) {
+	tcases := []struct {
+		prop        ExtraProperty
+		expectedErr string
+	}{
+		{ExtraProperty{ResourceType: "unknown", Path: "Name", Property: "Any"}, "unknown resource type"},
+		{ExtraProperty{ResourceType: cloud.Instance, Path: "Placement.Tenancy", Property: "lowercase"}, "capitalized"},
+		{ExtraProperty{ResourceType: cloud.Instance, Path: "InstanceType", Property: "Type"}, "already extracted"},
+		{ExtraProperty{ResourceType: cloud.Instance, Path: "Placement..Tenancy", Property: "BadPath"}, "invalid path"},
+		{ExtraProperty{ResourceType: cloud.Instance, Path: "Tags[*].Key", Property: "TagKeys"}, "requires the list data type"},
+		{ExtraProperty{ResourceType: cloud.Instance, Path: "Hypervisor", Property: "Hyper", DataType: "float"}, "unsupported data type"},
+		{ExtraProperty{ResourceType: cloud.Subnet, Path: "SubnetId", Property: "PublicIP", DataType: "int"}, "already exists"},
+	}
+	for i, tcase := range tcases {
+		err := RegisterExtraProperty(tcase.prop)
+		if err == nil || !strings.Contains(err.Error(), tcase.expectedErr) {
+			t.Fatalf("%d: got %v, want error containing '%s'", i+1, err, tcase.expectedErr)
+		}
+	}
+}
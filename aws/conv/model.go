@@ -32,6 +32,7 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Image:             {name: "ImageId", transform: extractValueFn},
 		properties.Launched:          {name: "LaunchTime", transform: extractValueFn},
 		properties.State:             {name: "State", transform: extractFieldFn("Name")},
+		properties.StateMessage:      {name: "StateTransitionReason", transform: extractValueFn},
 		properties.KeyPair:           {name: "KeyName", transform: extractValueFn},
 		properties.SecurityGroups:    {name: "SecurityGroups", transform: extractStringSliceValues("GroupId")},
 		properties.Affinity:          {name: "Placement", transform: extractFieldFn("Affinity")},
@@ -40,6 +41,7 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Host:              {name: "Placement", transform: extractFieldFn("HostId")},
 		properties.Architecture:      {name: "Architecture", transform: extractValueFn},
 		properties.Hypervisor:        {name: "Hypervisor", transform: extractValueFn},
+		properties.Platform:          {name: "Platform", transform: extractValueFn},
 		properties.Profile:           {name: "IamInstanceProfile", transform: extractFieldFn("Arn")},
 		properties.Lifecycle:         {name: "InstanceLifecycle", transform: extractValueFn},
 		properties.NetworkInterfaces: {name: "NetworkInterfaces", transform: extractStringSliceValues("NetworkInterfaceId")},
@@ -179,6 +181,24 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Vpc:              {name: "VpcId", transform: extractValueFn},
 		properties.Tags:             {name: "TagSet", transform: extractTagsFn},
 	},
+	cloud.SpotFleet: {
+		properties.State:           {name: "SpotFleetRequestState", transform: extractValueFn},
+		properties.DesiredCapacity: {name: "SpotFleetRequestConfig", transform: extractFieldFn("TargetCapacity")},
+		properties.SpotPrice:       {name: "SpotFleetRequestConfig", transform: extractFieldFn("SpotPrice")},
+		properties.Role:            {name: "SpotFleetRequestConfig", transform: extractFieldFn("IamFleetRole")},
+		properties.Type:            {name: "SpotFleetRequestConfig", transform: extractFieldFn("Type")},
+		properties.Created:         {name: "CreateTime", transform: extractTimeFn},
+	},
+	cloud.SpotRequest: {
+		properties.State:            {name: "State", transform: extractValueFn},
+		properties.Instance:         {name: "InstanceId", transform: extractValueFn},
+		properties.SpotPrice:        {name: "SpotPrice", transform: extractValueFn},
+		properties.Type:             {name: "Type", transform: extractValueFn},
+		properties.Image:            {name: "LaunchSpecification", transform: extractFieldFn("ImageId")},
+		properties.AvailabilityZone: {name: "LaunchedAvailabilityZone", transform: extractValueFn},
+		properties.Created:          {name: "CreateTime", transform: extractTimeFn},
+		properties.Tags:             {name: "Tags", transform: extractTagsFn},
+	},
 	// LoadBalancer
 	cloud.LoadBalancer: {
 		properties.Name:              {name: "LoadBalancerName", transform: extractValueFn},
@@ -268,6 +288,56 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Subnets:     {name: "Subnets", transform: extractStringSliceValues("SubnetIdentifier")},
 		properties.Vpc:         {name: "VpcId", transform: extractValueFn},
 	},
+	cloud.CacheCluster: {
+		properties.Name:                     {name: "CacheClusterId", transform: extractValueFn},
+		properties.State:                    {name: "CacheClusterStatus", transform: extractValueFn},
+		properties.Class:                    {name: "CacheNodeType", transform: extractValueFn},
+		properties.Engine:                   {name: "Engine", transform: extractValueFn},
+		properties.EngineVersion:            {name: "EngineVersion", transform: extractValueFn},
+		properties.Size:                     {name: "NumCacheNodes", transform: extractValueFn},
+		properties.AvailabilityZone:         {name: "PreferredAvailabilityZone", transform: extractValueFn},
+		properties.PreferredMaintenanceDate: {name: "PreferredMaintenanceWindow", transform: extractValueFn},
+		properties.AutoUpgrade:              {name: "AutoMinorVersionUpgrade", transform: extractValueFn},
+		properties.Encrypted:                {name: "AtRestEncryptionEnabled", transform: extractValueFn},
+		properties.Endpoint:                 {name: "ConfigurationEndpoint", transform: extractFieldFn("Address")},
+		properties.Port:                     {name: "ConfigurationEndpoint", transform: extractFieldFn("Port")},
+		properties.SecurityGroups:           {name: "SecurityGroups", transform: extractStringSliceValues("SecurityGroupId")},
+		properties.Created:                  {name: "CacheClusterCreateTime", transform: extractTimeFn},
+	},
+	cloud.ReplicationGroup: {
+		properties.Name:        {name: "ReplicationGroupId", transform: extractValueFn},
+		properties.Description: {name: "Description", transform: extractValueFn},
+		properties.State:       {name: "Status", transform: extractValueFn},
+		properties.Class:       {name: "CacheNodeType", transform: extractValueFn},
+		properties.Failover:    {name: "AutomaticFailover", transform: extractValueFn},
+		properties.Encrypted:   {name: "AtRestEncryptionEnabled", transform: extractValueFn},
+		properties.Endpoint:    {name: "ConfigurationEndpoint", transform: extractFieldFn("Address")},
+		properties.Port:        {name: "ConfigurationEndpoint", transform: extractFieldFn("Port")},
+	},
+	cloud.Table: {
+		properties.Name:          {name: "TableName", transform: extractValueFn},
+		properties.Arn:           {name: "TableArn", transform: extractValueFn},
+		properties.State:         {name: "TableStatus", transform: extractValueFn},
+		properties.Created:       {name: "CreationDateTime", transform: extractTimeFn},
+		properties.Size:          {name: "TableSizeBytes", transform: extractValueFn},
+		properties.ItemCount:     {name: "ItemCount", transform: extractValueFn},
+		properties.ReadCapacity:  {name: "ProvisionedThroughput", transform: extractFieldFn("ReadCapacityUnits")},
+		properties.WriteCapacity: {name: "ProvisionedThroughput", transform: extractFieldFn("WriteCapacityUnits")},
+		properties.HashKey:       {name: "KeySchema", transform: extractKeySchemaFn("HASH")},
+		properties.RangeKey:      {name: "KeySchema", transform: extractKeySchemaFn("RANGE")},
+	},
+	cloud.TableIndex: {
+		properties.Name:          {name: "IndexName", transform: extractValueFn},
+		properties.Arn:           {name: "IndexArn", transform: extractValueFn},
+		properties.State:         {name: "IndexStatus", transform: extractValueFn},
+		properties.Size:          {name: "IndexSizeBytes", transform: extractValueFn},
+		properties.ItemCount:     {name: "ItemCount", transform: extractValueFn},
+		properties.ReadCapacity:  {name: "ProvisionedThroughput", transform: extractFieldFn("ReadCapacityUnits")},
+		properties.WriteCapacity: {name: "ProvisionedThroughput", transform: extractFieldFn("WriteCapacityUnits")},
+		properties.HashKey:       {name: "KeySchema", transform: extractKeySchemaFn("HASH")},
+		properties.RangeKey:      {name: "KeySchema", transform: extractKeySchemaFn("RANGE")},
+		properties.Projection:    {name: "Projection", transform: extractFieldFn("ProjectionType")},
+	},
 	//Autoscaling
 	cloud.LaunchConfiguration: {
 		properties.Name:           {name: "LaunchConfigurationName", transform: extractValueFn},
@@ -323,6 +393,19 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.RunningTasksCount:                 {name: "RunningTasksCount", transform: extractValueFn},
 		properties.State:                             {name: "Status", transform: extractValueFn},
 	},
+	cloud.ContainerService: {
+		properties.Name:              {name: "ServiceName", transform: extractValueFn},
+		properties.Arn:               {name: "ServiceArn", transform: extractValueFn},
+		properties.Cluster:           {name: "ClusterArn", transform: extractValueFn},
+		properties.ContainerTask:     {name: "TaskDefinition", transform: extractValueFn},
+		properties.DesiredCount:      {name: "DesiredCount", transform: extractValueFn},
+		properties.RunningTasksCount: {name: "RunningCount", transform: extractValueFn},
+		properties.PendingTasksCount: {name: "PendingCount", transform: extractValueFn},
+		properties.LaunchType:        {name: "LaunchType", transform: extractValueFn},
+		properties.Role:              {name: "RoleArn", transform: extractValueFn},
+		properties.Created:           {name: "CreatedAt", transform: extractValueFn},
+		properties.State:             {name: "Status", transform: extractValueFn},
+	},
 	cloud.ContainerTask: {
 		properties.Name:             {name: "Family", transform: extractValueFn},
 		properties.Arn:              {name: "TaskDefinitionArn", transform: extractValueFn},
@@ -356,6 +439,29 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Arn:  {name: "CertificateArn", transform: extractValueFn},
 		properties.Name: {name: "DomainName", transform: extractValueFn},
 	},
+	//KMS
+	cloud.Key: {
+		properties.Arn:         {name: "Arn", transform: extractValueFn},
+		properties.Description: {name: "Description", transform: extractValueFn},
+		properties.State:       {name: "KeyState", transform: extractValueFn},
+		properties.Enabled:     {name: "Enabled", transform: extractValueFn},
+		properties.Type:        {name: "KeyManager", transform: extractValueFn},
+		properties.Owner:       {name: "AWSAccountId", transform: extractValueFn},
+		properties.Created:     {name: "CreationDate", transform: extractTimeFn},
+	},
+	cloud.Alias: {
+		properties.Name: {name: "AliasName", transform: extractValueFn},
+		properties.Arn:  {name: "AliasArn", transform: extractValueFn},
+		properties.Key:  {name: "TargetKeyId", transform: extractValueFn},
+	},
+	cloud.KeyGrant: {
+		properties.Name:             {name: "Name", transform: extractValueFn},
+		properties.Key:              {name: "KeyId", transform: extractValueFn},
+		properties.GranteePrincipal: {name: "GranteePrincipal", transform: extractValueFn},
+		properties.Operations:       {name: "Operations", transform: extractValueFn},
+		properties.Account:          {name: "IssuingAccount", transform: extractValueFn},
+		properties.Created:          {name: "CreationDate", transform: extractTimeFn},
+	},
 	//IAM
 	cloud.User: {
 		properties.Name:             {name: "UserName", transform: extractValueFn},
@@ -443,6 +549,16 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Type:                  {name: "Type", transform: extractValueFn},
 		properties.Weight:                {name: "Weight", transform: extractValueFn},
 	},
+	cloud.HealthCheck: {
+		properties.CallerReference:         {name: "CallerReference", transform: extractValueFn},
+		properties.Type:                    {name: "HealthCheckConfig", transform: extractFieldFn("Type")},
+		properties.PublicDNS:               {name: "HealthCheckConfig", transform: extractFieldFn("FullyQualifiedDomainName")},
+		properties.PublicIP:                {name: "HealthCheckConfig", transform: extractFieldFn("IPAddress")},
+		properties.Port:                    {name: "HealthCheckConfig", transform: extractFieldFn("Port")},
+		properties.CheckPath:               {name: "HealthCheckConfig", transform: extractFieldFn("ResourcePath")},
+		properties.CheckInterval:           {name: "HealthCheckConfig", transform: extractFieldFn("RequestInterval")},
+		properties.UnhealthyThresholdCount: {name: "HealthCheckConfig", transform: extractFieldFn("FailureThreshold")},
+	},
 	// Lambda
 	cloud.Function: {
 		properties.Arn:         {name: "FunctionArn", transform: extractValueFn},
@@ -458,6 +574,49 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Timeout:     {name: "Timeout", transform: extractValueFn},
 		properties.Version:     {name: "Version", transform: extractValueFn},
 	},
+	cloud.FunctionAlias: {
+		properties.Arn:         {name: "AliasArn", transform: extractValueFn},
+		properties.Name:        {name: "Name", transform: extractValueFn},
+		properties.Version:     {name: "FunctionVersion", transform: extractValueFn},
+		properties.Description: {name: "Description", transform: extractValueFn},
+	},
+	cloud.EventSourceMapping: {
+		properties.Source:       {name: "EventSourceArn", transform: extractValueFn},
+		properties.State:        {name: "State", transform: extractValueFn},
+		properties.StateMessage: {name: "StateTransitionReason", transform: extractValueFn},
+		properties.Modified:     {name: "LastModified", transform: extractTimeFn},
+	},
+	// Step Functions
+	cloud.StateMachine: {
+		properties.Arn:     {name: "StateMachineArn", transform: extractValueFn},
+		properties.Name:    {name: "Name", transform: extractValueFn},
+		properties.Created: {name: "CreationDate", transform: extractValueFn},
+	},
+	cloud.Execution: {
+		properties.Arn:      {name: "ExecutionArn", transform: extractValueFn},
+		properties.Name:     {name: "Name", transform: extractValueFn},
+		properties.State:    {name: "Status", transform: extractValueFn},
+		properties.Launched: {name: "StartDate", transform: extractValueFn},
+		properties.Stopped:  {name: "StopDate", transform: extractValueFn},
+	},
+	// API Gateway
+	cloud.RestApi: {
+		properties.Name:        {name: "Name", transform: extractValueFn},
+		properties.Description: {name: "Description", transform: extractValueFn},
+		properties.Version:     {name: "Version", transform: extractValueFn},
+		properties.Created:     {name: "CreatedDate", transform: extractTimeFn},
+	},
+	cloud.ApiStage: {
+		properties.Name:        {name: "StageName", transform: extractValueFn},
+		properties.Description: {name: "Description", transform: extractValueFn},
+		properties.Deployment:  {name: "DeploymentId", transform: extractValueFn},
+		properties.Created:     {name: "CreatedDate", transform: extractTimeFn},
+		properties.Modified:    {name: "LastUpdatedDate", transform: extractTimeFn},
+	},
+	cloud.ApiDeployment: {
+		properties.Description: {name: "Description", transform: extractValueFn},
+		properties.Created:     {name: "CreatedDate", transform: extractTimeFn},
+	},
 	// Monitoring
 	cloud.Metric: {
 		properties.Name:       {name: "MetricName", transform: extractValueFn},
@@ -497,6 +656,10 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.State:              {name: "Status", transform: extractValueFn},
 		properties.WebACL:             {name: "WebACLId", transform: extractValueFn},
 	},
+	cloud.OriginAccessIdentity: {
+		properties.Comment:       {name: "Comment", transform: extractValueFn},
+		properties.CanonicalUser: {name: "S3CanonicalUserId", transform: extractValueFn},
+	},
 	// Cloudformation
 	cloud.Stack: {
 		properties.Name:            {name: "StackName", transform: extractValueFn},
@@ -13,7 +13,8 @@ func AwlessCommandDefinitionsDoc(action, entity, fallbackDef string) string {
 }
 
 var commandDefinitionsDoc = map[string]string{
-	"copy.image": "Copy an EC2 image from given source region to current awless region",
+	"copy.image":    "Copy an EC2 image from given source region to current awless region",
+	"sync.s3object": "Upload a local directory to a bucket, or download it from the bucket, transferring only the changed files",
 }
 
 func AwlessExamplesDoc(action, entity string) string {
@@ -45,9 +46,15 @@ var cliExamplesDoc = map[string][]string{
 	"attach.instanceprofile": {
 		"awless attach instanceprofile instance=@redis name=MyProfile replace=true",
 	},
+	"attach.integration": {
+		"awless attach integration restapi=@petstore resource=a1b2c3 method=GET function=arn:aws:lambda:us-east-1:123456789012:function:pets",
+	},
 	"attach.internetgateway": {
 		"awless attach internetgateway id=igw-636c0504 vpc=vpc-1aba387c",
 	},
+	"attach.keygrant": {
+		"awless attach keygrant key=1234abcd-12ab-34cd-56ef-1234567890ab grantee=arn:aws:iam::123456789012:role/backup operations=Encrypt,Decrypt,GenerateDataKey",
+	},
 	"attach.policy": {
 		"awless attach policy role=MyNewRole service=ec2 access=readonly",
 		"awless attach policy user=jsmith service=s3 access=readonly",
@@ -99,6 +106,7 @@ var cliExamplesDoc = map[string][]string{
 	},
 	"copy.snapshot": {
 		"awless copy snapshot source-id=efwqwdr2or source-region=us-west-2",
+		"awless copy snapshot source-id=snap-0123456789abcdef0 source-region=eu-west-1 encrypted=true wait=true",
 	},
 	"create.accesskey": {
 		"awless create accesskey user=jsmith no-prompt=true",
@@ -106,6 +114,21 @@ var cliExamplesDoc = map[string][]string{
 	"create.alarm": {
 		" awless create alarm namespace=AWS/EC2 dimensions=AutoScalingGroupName:instancesScalingGroup evaluation-periods=2 metric=CPUUtilization name=scaleinAlarm operator=GreaterThanOrEqualToThreshold period=300 statistic-function=Average threshold=75",
 	},
+	"create.alias": {
+		"awless create alias name=alias/backups key=1234abcd-12ab-34cd-56ef-1234567890ab",
+	},
+	"create.apideployment": {
+		"awless create apideployment restapi=@petstore stage=prod",
+		"awless create apideployment restapi=@petstore stage=staging description=\"new pets endpoints\"",
+	},
+	"create.apimethod": {
+		"awless create apimethod restapi=@petstore resource=a1b2c3 method=GET",
+		"awless create apimethod restapi=@petstore resource=a1b2c3 method=POST authorization=AWS_IAM",
+	},
+	"create.apiresource": {
+		"awless create apiresource restapi=@petstore path=pets",
+		"awless create apiresource restapi=@petstore parent=a1b2c3 path={petId}",
+	},
 	"create.appscalingpolicy": {
 		" awless create appscalingpolicy dimension=ecs:service:DesiredCount name=ScaleOutPolicy resource=service/my-ecs-cluster/my-service-deployment-name service-namespace=ecs stepscaling-adjustment-type=ChangeInCapacity stepscaling-adjustments=0::+1 type=StepScaling stepscaling-aggregation-type=Average stepscaling-cooldown=60",
 	},
@@ -115,6 +138,10 @@ var cliExamplesDoc = map[string][]string{
 	"create.bucket": {
 		"awless create bucket name=my-bucket-name acl=public-read",
 	},
+	"create.cachecluster": {
+		"awless create cachecluster id=sessions-cache engine=redis type=cache.t2.micro subnetgroup=my-cache-subnets securitygroups=@redis_sg",
+		"awless create cachecluster id=pages-cache engine=memcached type=cache.m4.large count=3",
+	},
 	"create.containercluster": {
 		"awless create containercluster name=mycluster",
 	},
@@ -126,18 +153,24 @@ var cliExamplesDoc = map[string][]string{
 	},
 	"create.distribution": {
 		"awless create distribution origin-domain=mybucket.s3.amazonaws.com",
+		"awless create distribution origin-domain=mybucket.s3.amazonaws.com origin-access-identity=E2QWRUHAPOMQZL",
 	},
 	"create.elasticip": {
 		"awless create elasticip domain=vpc",
+		"awless create elasticip domain=vpc reuse=true",
 	},
 	"create.function": {},
 	"create.group": {
 		"awless create name=admins",
 	},
+	"create.healthcheck": {
+		"awless create healthcheck callerreference=web-check type=HTTPS domain=www.example.com path=/health",
+	},
 	"create.image": {
 		"awless create image instance=@my-instance-name name=redis-image description='redis prod image'",
 		"awless create image instance=i-0ee436a45561c04df name=redis-image reboot=true",
 		"awless create image instance=@redis-prod name=redis-prod-image",
+		"awless create image instance=@redis-prod name=redis-prod-image wait=true wait-timeout=1800",
 	},
 	"create.instance": {
 		"awless create instance keypair=jsmith type=t2.micro subnet=@my-subnet",
@@ -150,59 +183,119 @@ var cliExamplesDoc = map[string][]string{
 		"awless create instance distro=debian:debian:jessie lock=true",
 		"awless create instance distro=amazonlinux securitygroup=@my-ssh-secgroup",
 		"awless create instance distro=amazonlinux:::::instance-store",
+		"awless create instance distro=amazonlinux subnet=@my-subnet wait=true wait-timeout=300",
+		"awless create instance distro=amazonlinux type=m4.large spot=true spotprice=0.05 spotinterruption=stop",
+	},
+	"create.instanceprofile": {},
+	"create.internetgateway": {},
+	"create.key": {
+		"awless create key description=\"backups encryption\"",
+		"awless create key description=\"backups encryption\" policy-file=./key-policy.json",
 	},
-	"create.instanceprofile":     {},
-	"create.internetgateway":     {},
 	"create.keypair":             {},
 	"create.launchconfiguration": {},
-	"create.listener":            {},
-	"create.loadbalancer":        {},
-	"create.loginprofile":        {},
-	"create.natgateway":          {},
-	"create.policy":              {},
-	"create.queue":               {},
-	"create.record":              {},
-	"create.repository":          {},
-	"create.role":                {},
-	"create.route":               {},
-	"create.routetable":          {},
-	"create.s3object":            {},
-	"create.scalinggroup":        {},
-	"create.scalingpolicy":       {},
+	"create.launchtemplate": {
+		"awless create launchtemplate name=web-template distro=amazonlinux type=t2.micro keypair=jsmith securitygroups=@web-secgroup",
+		"awless create launchtemplate name=web-template image=ami-123456 type=m4.large userdata=/home/jsmith/data.sh role=MyInstanceRole",
+	},
+	"create.listener":     {},
+	"create.loadbalancer": {},
+	"create.loginprofile": {},
+	"create.natgateway":   {},
+	"create.originaccessidentity": {
+		"awless create originaccessidentity comment=my-bucket-access",
+	},
+	"create.policy": {},
+	"create.presignedurl": {
+		"awless create presignedurl bucket=my-bucket key=reports/2017.pdf expires=3600",
+		"awless create presignedurl bucket=my-bucket key=uploads/data.csv method=put",
+	},
+	"create.queue": {},
+	"create.record": {
+		"awless create record zone=Z1KDFJUGTHTBCB name=www.example.com type=A ttl=60 value=52.95.110.1 set-identifier=eu weight=80",
+		"awless create record zone=Z1KDFJUGTHTBCB name=www.example.com type=A ttl=60 value=52.95.110.1 set-identifier=main failover=primary healthcheck=abcdef11-2222-3333-4444-555555fedcba",
+	},
+	"create.replicationgroup": {
+		"awless create replicationgroup id=sessions description=\"sessions store\" engine=redis type=cache.t2.micro count=2 failover=true",
+		"awless create replicationgroup id=sessions description=\"sessions store\" primary-cluster=sessions-cache",
+	},
+	"create.repository": {},
+	"create.restapi": {
+		"awless create restapi name=petstore description=\"pets shop api\"",
+	},
+	"create.role":       {},
+	"create.route":      {},
+	"create.routetable": {},
+	"create.s3object":   {},
+	"create.scalinggroup": {
+		"awless create scalinggroup name=web-group launchconfiguration=web-config min-size=1 max-size=3 subnets=@sub1,@sub2",
+		"awless create scalinggroup name=web-group launchtemplate=lt-0123456789abcdef0 min-size=1 max-size=3 subnets=@sub1,@sub2",
+	},
+	"create.scalingpolicy": {},
 	"create.securitygroup": {
 		"awless create securitygroup vpc=@myvpc name=ssh-only description=ssh-access",
 		"(... see more params at `awless update securitygroup -h`)",
 	},
-	"create.snapshot":            {},
-	"create.stack":               {},
-	"create.subnet":              {},
-	"create.subscription":        {},
-	"create.tag":                 {},
-	"create.targetgroup":         {},
-	"create.topic":               {},
-	"create.user":                {},
-	"create.volume":              {},
-	"create.vpc":                 {},
-	"create.zone":                {},
-	"delete.accesskey":           {},
-	"delete.alarm":               {},
-	"delete.appscalingpolicy":    {},
-	"delete.appscalingtarget":    {},
-	"delete.bucket":              {},
-	"delete.containercluster":    {},
-	"delete.containertask":       {},
-	"delete.database":            {},
-	"delete.dbsubnetgroup":       {},
-	"delete.distribution":        {},
-	"delete.elasticip":           {},
-	"delete.function":            {},
-	"delete.group":               {},
-	"delete.image":               {},
-	"delete.instance":            {},
-	"delete.instanceprofile":     {},
-	"delete.internetgateway":     {},
+	"create.snapshot": {
+		"awless create snapshot volume=vol-0123456789abcdef0 description='before upgrade'",
+		"awless create snapshot volume=@my-volume wait=true",
+	},
+	"create.spotfleet": {
+		"awless create spotfleet role=arn:aws:iam::123456789012:role/aws-ec2-spot-fleet-tagging-role capacity=3 image=ami-123456 type=m4.large",
+		"awless create spotfleet role=arn:aws:iam::123456789012:role/aws-ec2-spot-fleet-tagging-role capacity=10 image=@my-ami type=c4.large price=0.05 allocation=diversified subnet=@my-subnet",
+	},
+	"create.stack": {},
+	"create.statemachine": {
+		"awless create statemachine name=order-workflow definition-file=./order.json role=arn:aws:iam::123456789012:role/StatesExecutionRole",
+	},
+	"create.subnet":       {},
+	"create.subscription": {},
+	"create.tag": {
+		"awless create tag resource=i-54318a4e key=Env value=prod",
+		"awless create tag resource=arn:aws:s3:::my-bucket key=Env value=prod",
+		"awless create tag query=['Env=staging',ec2:instance] key=Owner value=ops",
+	},
+	"create.targetgroup": {},
+	"create.topic":       {},
+	"create.trafficpolicy": {
+		"awless create trafficpolicy name=geo-web document-file=./geo-web.json comment='Route web users to the closest region'",
+	},
+	"create.trafficpolicyinstance": {
+		"awless create trafficpolicyinstance zone=Z1KDFJUGTHTBCB name=www.example.com ttl=60 policy=12345678-abcd-9876-fedc-1a2b3c4de5f6 version=1",
+	},
+	"create.user":             {},
+	"create.volume":           {},
+	"create.vpc":              {},
+	"create.zone":             {},
+	"delete.accesskey":        {},
+	"delete.alarm":            {},
+	"delete.alias":            {},
+	"delete.apideployment":    {},
+	"delete.apimethod":        {},
+	"delete.apiresource":      {},
+	"delete.apistage":         {},
+	"delete.appscalingpolicy": {},
+	"delete.appscalingtarget": {},
+	"delete.bucket":           {},
+	"delete.cachecluster":     {},
+	"delete.containercluster": {},
+	"delete.containertask":    {},
+	"delete.database":         {},
+	"delete.dbsubnetgroup":    {},
+	"delete.distribution":     {},
+	"delete.elasticip":        {},
+	"delete.function":         {},
+	"delete.group":            {},
+	"delete.image":            {},
+	"delete.instance":         {},
+	"delete.instanceprofile":  {},
+	"delete.internetgateway":  {},
+	"delete.key": {
+		"awless delete key id=1234abcd-12ab-34cd-56ef-1234567890ab pending-days=7",
+	},
 	"delete.keypair":             {},
 	"delete.launchconfiguration": {},
+	"delete.launchtemplate":      {"awless delete launchtemplate id=lt-0123456789abcdef0"},
 	"delete.listener":            {},
 	"delete.loadbalancer":        {},
 	"delete.loginprofile":        {},
@@ -210,7 +303,9 @@ var cliExamplesDoc = map[string][]string{
 	"delete.policy":              {},
 	"delete.queue":               {},
 	"delete.record":              {},
+	"delete.replicationgroup":    {},
 	"delete.repository":          {},
+	"delete.restapi":             {},
 	"delete.role":                {},
 	"delete.route":               {},
 	"delete.routetable":          {},
@@ -219,12 +314,20 @@ var cliExamplesDoc = map[string][]string{
 	"delete.scalingpolicy":       {},
 	"delete.securitygroup":       {},
 	"delete.snapshot":            {},
+	"delete.spotfleet":           {"awless delete spotfleet id=sfr-12345678-90ab-cdef-1234-567890abcdef terminate-instances=false"},
 	"delete.stack":               {},
+	"delete.statemachine":        {},
 	"delete.subnet":              {},
 	"delete.subscription":        {},
-	"delete.tag":                 {},
-	"delete.targetgroup":         {},
-	"delete.topic":               {},
+	"delete.tag": {
+		"awless delete tag query=['Team=web',lambda:function] key=Owner",
+	},
+	"delete.targetgroup": {},
+	"delete.topic":       {},
+	"delete.trafficpolicy": {
+		"awless delete trafficpolicy id=12345678-abcd-9876-fedc-1a2b3c4de5f6 version=1",
+	},
+	"delete.trafficpolicyinstance": {},
 	"delete.user": {
 		"awless delete user name=john",
 	},
@@ -236,7 +339,9 @@ var cliExamplesDoc = map[string][]string{
 	"detach.elasticip":       {},
 	"detach.instance":        {},
 	"detach.instanceprofile": {},
+	"detach.integration":     {},
 	"detach.internetgateway": {},
+	"detach.keygrant":        {},
 	"detach.policy":          {},
 	"detach.role":            {},
 	"detach.routetable":      {},
@@ -247,13 +352,25 @@ var cliExamplesDoc = map[string][]string{
 	"start.alarm":            {},
 	"start.containertask":    {},
 	"start.instance":         {},
-	"stop.alarm":             {},
-	"stop.containertask":     {},
-	"stop.instance":          {},
-	"update.bucket":          {},
-	"update.containertask":   {},
-	"update.distribution":    {},
-	"update.instance":        {},
+	"start.statemachine": {
+		"awless start statemachine id=@order-workflow input='{\"order\": 42}'",
+	},
+	"stop.alarm":         {},
+	"stop.containertask": {},
+	"stop.instance":      {},
+	"sync.s3object": {
+		"awless sync s3object dir=./public bucket=my-website acl=public-read",
+		"awless sync s3object dir=./public bucket=my-website prefix=v2 include=['*.html','*.css'] exclude=drafts/*",
+		"awless sync s3object dir=./backup bucket=my-backups direction=download concurrency=20",
+	},
+	"update.bucket":        {},
+	"update.containertask": {},
+	"update.distribution":  {},
+	"update.instance":      {},
+	"update.launchtemplate": {
+		"awless update launchtemplate id=lt-0123456789abcdef0 image=ami-654321 default=true",
+		"awless update launchtemplate id=lt-0123456789abcdef0 source-version=2 type=m4.xlarge description=\"bigger instances\"",
+	},
 	"update.image": {
 		"awless update image id=@my-image description=new-description # Make an AMI public",
 		"awless update image id=ami-bd6bb2c5 groups=all operation=add # Make an AMI private",
@@ -265,10 +382,17 @@ var cliExamplesDoc = map[string][]string{
 	"update.policy":       {},
 	"update.record":       {},
 	"update.s3object":     {},
-	"update.scalinggroup": {},
+	"update.scalinggroup": {
+		"awless update scalinggroup name=web-group launchtemplate=lt-0123456789abcdef0 launchtemplate-version=latest",
+	},
 	"update.securitygroup": {
 		"awless update securitygroup id=@ssh-only inbound=authorize protocol=tcp cidr=0.0.0.0/0 portrange=26257",
 		"awless update securitygroup id=@ssh-only inbound=authorize protocol=tcp securitygroup=sg-123457 portrange=8080",
+		"awless update securitygroup id=@web inbound-rules=['tcp:443:0.0.0.0/0','tcp:22:10.0.0.0/16'] outbound-rules=['any:any:0.0.0.0/0']",
+	},
+	"update.snapshot": {
+		"awless update snapshot id=snap-0123456789abcdef0 accounts=3456728198326 operation=add # Share a snapshot with another AWS account",
+		"awless update snapshot id=snap-0123456789abcdef0 groups=all operation=remove # Make a public snapshot private",
 	},
 	"update.stack":       {},
 	"update.subnet":      {},
@@ -6,16 +6,24 @@ import (
 )
 
 var (
-	timeouts      = []string{"10", "60", "180", "300", "600", "900"}
-	boolean       = []string{"true", "false"}
-	services      = []string{"iam", "ec2", "s3", "route53", "elbv2", "rds", "autoscaling", "lambda", "sns", "sqs", "cloudwatch", "cloudfront", "ecr", "ecs", "applicationautoscaling", "acm", "sts", "cloudformation"}
-	instanceTypes = []string{"t2.nano", "t2.micro", "t2.small", "t2.medium", "t2.large", "t2.xlarge", "t2.2xlarge", "m4.large", "m4.xlarge", "c4.large", "c4.xlarge"}
-	s3ACLs        = []string{"private", "public-read", "public-read-write", "aws-exec-read", "authenticated-read", "bucket-owner-read", "bucket-owner-full-control", "log-delivery-write"}
-	distros       = []string{"amazonlinux", "canonical", "redhat", "debian", "suselinux", "windows"}
+	timeouts       = []string{"10", "60", "180", "300", "600", "900"}
+	boolean        = []string{"true", "false"}
+	services       = []string{"iam", "ec2", "s3", "route53", "elbv2", "rds", "dynamodb", "autoscaling", "lambda", "sns", "sqs", "cloudwatch", "cloudfront", "ecr", "ecs", "applicationautoscaling", "acm", "sts", "cloudformation"}
+	instanceTypes  = []string{"t2.nano", "t2.micro", "t2.small", "t2.medium", "t2.large", "t2.xlarge", "t2.2xlarge", "m4.large", "m4.xlarge", "c4.large", "c4.xlarge"}
+	s3ACLs         = []string{"private", "public-read", "public-read-write", "aws-exec-read", "authenticated-read", "bucket-owner-read", "bucket-owner-full-control", "log-delivery-write"}
+	cacheNodeTypes = []string{"cache.t3.micro", "cache.t3.small", "cache.t3.medium", "cache.t4g.micro", "cache.t4g.small", "cache.t4g.medium", "cache.m5.large", "cache.m5.xlarge", "cache.m5.2xlarge", "cache.m5.4xlarge", "cache.m5.12xlarge", "cache.m5.24xlarge", "cache.m6g.large", "cache.m6g.xlarge", "cache.m6g.2xlarge", "cache.m6g.4xlarge", "cache.r5.large", "cache.r5.xlarge", "cache.r5.2xlarge", "cache.r5.4xlarge", "cache.r5.12xlarge", "cache.r5.24xlarge", "cache.r6g.large", "cache.r6g.xlarge", "cache.r6g.2xlarge", "cache.r6g.4xlarge", "cache.t2.micro", "cache.t2.small", "cache.t2.medium", "cache.m3.medium", "cache.m3.large", "cache.m3.xlarge", "cache.m3.2xlarge", "cache.m4.large", "cache.m4.xlarge", "cache.m4.2xlarge", "cache.m4.4xlarge", "cache.m4.10xlarge", "cache.r3.large", "cache.r3.xlarge", "cache.r3.2xlarge", "cache.r3.4xlarge", "cache.r3.8xlarge", "cache.r4.large", "cache.r4.xlarge", "cache.r4.2xlarge", "cache.r4.4xlarge", "cache.r4.8xlarge", "cache.r4.16xlarge"}
+	httpMethods    = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS", "ANY"}
+	distros        = []string{"amazonlinux", "canonical", "redhat", "debian", "suselinux", "windows"}
 )
 
 var EnumDoc = map[string][]string{
 
+	"attach.containertask.launch-type": {"ec2", "fargate"},
+
+	"attach.integration.method": httpMethods,
+
+	"attach.keygrant.operations": {"Decrypt", "Encrypt", "GenerateDataKey", "GenerateDataKeyWithoutPlaintext", "ReEncryptFrom", "ReEncryptTo", "CreateGrant", "RetireGrant", "DescribeKey"},
+
 	"attach.policy.access":  {"readonly", "full"},
 	"attach.policy.service": services,
 
@@ -55,6 +63,10 @@ var EnumDoc = map[string][]string{
 	"create.alarm.statistic-function": {"Minimum", "Maximum", "Sum", "Average", "SampleCount", "pNN.NN"},
 	"create.alarm.unit":               {"Seconds", "Microseconds", "Milliseconds", "Bytes", "Kilobytes", "Megabytes", "Gigabytes", "Terabytes", "Bits", "Kilobits", "Megabits", "Gigabits", "Terabits", "Percent", "Count", "Bytes/Second", "Kilobytes/Second", "Megabytes/Second", "Gigabytes/Second", "Terabytes/Second", "Bits/Second", "Kilobits/Second", "Megabits/Second", "Gigabits/Second", "Terabits/Second", "Count/Second", "None"},
 
+	"create.apimethod.authorization":   {"NONE", "AWS_IAM", "CUSTOM", "COGNITO_USER_POOLS"},
+	"create.apimethod.method":          httpMethods,
+	"create.apimethod.apikey-required": boolean,
+
 	"create.appscalingtarget.dimension":         {"ecs:service:DesiredCount", "ec2:spot-fleet-request:TargetCapacity", "elasticmapreduce:instancegroup:InstanceCount", "appstream:fleet:DesiredCapacity", "dynamodb:table:ReadCapacityUnits", "dynamodb:table:WriteCapacityUnits", "dynamodb:index:ReadCapacityUnits", "dynamodb:index:WriteCapacityUnits"},
 	"create.appscalingtarget.service-namespace": {"ecs", "ec2", "elasticmapreduce", "appstream", "dynamodb"},
 
@@ -67,6 +79,10 @@ var EnumDoc = map[string][]string{
 
 	"create.bucket.acl": s3ACLs,
 
+	"create.cachecluster.engine":      {"memcached", "redis"},
+	"create.cachecluster.type":        cacheNodeTypes,
+	"create.cachecluster.autoupgrade": boolean,
+
 	"create.database.engine":             {"mysql", "mariadb", "postgres", "aurora", "oracle-se1", "oracle-se2", "oracle-se", "oracle-ee", "sqlserver-ee", "sqlserver-se", "sqlserver-ex", "sqlserver-web"},
 	"create.database.copytagstosnapshot": boolean,
 	"create.database.encrypted":          boolean,
@@ -85,15 +101,26 @@ var EnumDoc = map[string][]string{
 
 	"create.elasticip.domain": {"vpc", "ec2-classic"},
 
+	"create.eventsourcemapping.enabled":           boolean,
+	"create.eventsourcemapping.starting-position": {"TRIM_HORIZON", "LATEST"},
+
 	"create.function.runtime": {"nodejs", "nodejs4.3", "nodejs6.10", "java8", "python2.7", "python3.6", "dotnetcore1.0", "nodejs4.3-edge"},
 
-	"create.instance.distro":   distros,
-	"create.instance.type":     instanceTypes,
-	"create.instance.lock":     boolean,
-	"create.instance.userdata": {""},
+	"create.instance.distro":           distros,
+	"create.instance.type":             instanceTypes,
+	"create.instance.lock":             boolean,
+	"create.instance.userdata":         {""},
+	"create.instance.spot":             boolean,
+	"create.instance.spotinterruption": {"terminate", "stop", "hibernate"},
+
+	"create.healthcheck.type": {"HTTP", "HTTPS", "HTTP_STR_MATCH", "HTTPS_STR_MATCH", "TCP"},
 
 	"create.image.reboot": boolean,
 
+	"create.key.usage":          {"ENCRYPT_DECRYPT"},
+	"create.key.origin":         {"AWS_KMS", "EXTERNAL"},
+	"create.key.bypass-lockout": boolean,
+
 	"create.keypair.encrypted": boolean,
 
 	"create.launchconfiguration.distro":   distros,
@@ -101,6 +128,11 @@ var EnumDoc = map[string][]string{
 	"create.launchconfiguration.userdata": {""},
 	"create.launchconfiguration.public":   boolean,
 
+	"create.launchtemplate.distro":   distros,
+	"create.launchtemplate.type":     instanceTypes,
+	"create.launchtemplate.userdata": {""},
+	"create.launchtemplate.lock":     boolean,
+
 	"create.listener.actiontype": {"forward"},
 	"create.listener.protocol":   {"HTTP", "HTTPS"},
 	"create.listener.sslpolicy":  {"ELBSecurityPolicy-2016-08", "ELBSecurityPolicy-TLS-1-2-2017-01", "ELBSecurityPolicy-TLS-1-1-2017-01", "ELBSecurityPolicy-2015-05", "ELBSecurityPolicy-TLS-1-0-2015-04"},
@@ -109,14 +141,26 @@ var EnumDoc = map[string][]string{
 	"create.policy.effect":   {"Allow", "Deny"},
 	"create.policy.resource": {"*"},
 
-	"create.record.type": {"A", "AAAA", "CNAME", "MX", "NAPTR", "NS", "PTR", "SOA", "SPF", "SRV", "TXT"},
+	"create.presignedurl.method": {"get", "put"},
+
+	"create.record.type":     {"A", "AAAA", "CNAME", "MX", "NAPTR", "NS", "PTR", "SOA", "SPF", "SRV", "TXT"},
+	"create.record.failover": {"PRIMARY", "SECONDARY"},
 
 	"create.s3object.acl": s3ACLs,
 
+	"create.replicationgroup.engine":    {"redis"},
+	"create.replicationgroup.type":      cacheNodeTypes,
+	"create.replicationgroup.failover":  boolean,
+	"create.replicationgroup.encrypted": boolean,
+
 	"create.scalinggroup.healthcheck-type": {"EC2", "ELB"},
 
 	"create.scalingpolicy.adjustment-type": {"ChangeInCapacity", "ExactCapacity", "PercentChangeInCapacity"},
 
+	"create.spotfleet.type":         instanceTypes,
+	"create.spotfleet.interruption": {"terminate", "stop", "hibernate"},
+	"create.spotfleet.allocation":   {"lowestPrice", "diversified"},
+
 	"create.stack.capabilities": {"CAPABILITY_IAM", "CAPABILITY_NAMED_IAM"},
 	"create.stack.on-failure":   {"DO_NOTHING", "ROLLBACK", "DELETE"},
 
@@ -124,18 +168,31 @@ var EnumDoc = map[string][]string{
 
 	"create.subscription.protocol": {"http", "https", "email", "email-json", "sms", "sqs", "lambda"},
 
+	"create.table.hash-key-type":  {"S", "N", "B"},
+	"create.table.range-key-type": {"S", "N", "B"},
+
 	"create.zone.isprivate": boolean,
 
+	"delete.apimethod.method": httpMethods,
+
 	"delete.containertask.all-versions": boolean,
 
 	"delete.database.skip-snapshot": boolean,
 
 	"delete.image.delete-snapshots": boolean,
 
+	"delete.instance.cancel-spot-requests": boolean,
+
 	"delete.policy.all-versions": boolean,
 
 	"delete.record.type": {"A", "AAAA", "CNAME", "MX", "NAPTR", "NS", "PTR", "SOA", "SPF", "SRV", "TXT"},
 
+	"delete.replicationgroup.retain-primary": boolean,
+
+	"delete.spotfleet.terminate-instances": boolean,
+
+	"detach.integration.method": httpMethods,
+
 	"detach.networkinterface.force": boolean,
 
 	"detach.policy.access":  {"readonly", "full"},
@@ -147,10 +204,15 @@ var EnumDoc = map[string][]string{
 
 	"restart.database.with-failover": boolean,
 
-	"start.containertask.type": {"task", "service"},
+	"start.containertask.type":        {"task", "service"},
+	"start.containertask.launch-type": {"ec2", "fargate"},
+	"start.containertask.public":      boolean,
 
 	"stop.containertask.type": {"task", "service"},
 
+	"sync.s3object.acl":       s3ACLs,
+	"sync.s3object.direction": {"upload", "download"},
+
 	"update.bucket.acl":            {"private", "public-read", "public-read-write", "aws-exec-read", "authenticated-read", "bucket-owner-read", "bucket-owner-full-control", "log-delivery-write"},
 	"update.bucket.public-website": boolean,
 	"update.bucket.index-suffix":   {"index.html"},
@@ -162,9 +224,12 @@ var EnumDoc = map[string][]string{
 	"update.distribution.price-class":     {"PriceClass_All", "PriceClass_100", "PriceClass_200"},
 	"update.distribution.enable":          boolean,
 
+	"update.eventsourcemapping.enabled": boolean,
+
 	"update.image.operation": {"add", "remove"},
 
-	"update.instance.type": instanceTypes,
+	"update.instance.metadata-tokens": {"required", "optional"},
+	"update.instance.type":            instanceTypes,
 
 	"update.policy.effect": {"Allow", "Deny"},
 
@@ -189,6 +254,12 @@ type ParamType struct {
 }
 
 var ParamTypeDoc = map[string]*ParamType{
+	"attach.integration.function": {ResourceType: cloud.Function, PropertyName: properties.Arn},
+	"attach.integration.restapi":  {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+	"detach.integration.restapi":  {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+
+	"attach.keygrant.key": {ResourceType: cloud.Key, PropertyName: properties.ID},
+
 	"attach.policy.group": {ResourceType: cloud.Group, PropertyName: properties.Name},
 	"attach.policy.role":  {ResourceType: cloud.Role, PropertyName: properties.Name},
 	"attach.policy.user":  {ResourceType: cloud.User, PropertyName: properties.Name},
@@ -198,9 +269,33 @@ var ParamTypeDoc = map[string]*ParamType{
 
 	"create.accesskey.user": {ResourceType: cloud.User, PropertyName: properties.Name},
 
+	"create.alias.key": {ResourceType: cloud.Key, PropertyName: properties.ID},
+
+	"create.apideployment.restapi": {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+	"delete.apideployment.id":      {ResourceType: cloud.ApiDeployment, PropertyName: properties.ID},
+	"delete.apideployment.restapi": {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+	"create.apimethod.restapi":     {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+	"delete.apimethod.restapi":     {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+	"create.apiresource.restapi":   {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+	"delete.apiresource.restapi":   {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+	"delete.apistage.restapi":      {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+	"delete.restapi.id":            {ResourceType: cloud.RestApi, PropertyName: properties.ID},
+
+	"create.cachecluster.replication-group": {ResourceType: cloud.ReplicationGroup, PropertyName: properties.ID},
+
+	"create.distribution.origin-access-identity": {ResourceType: cloud.OriginAccessIdentity, PropertyName: properties.ID},
+	"update.distribution.origin-access-identity": {ResourceType: cloud.OriginAccessIdentity, PropertyName: properties.ID},
+
 	"create.instance.role": {ResourceType: cloud.Role, PropertyName: properties.Name},
 
-	"create.record.values": {ResourceType: cloud.Record, PropertyName: properties.Records},
+	"create.record.values":      {ResourceType: cloud.Record, PropertyName: properties.Records},
+	"create.record.healthcheck": {ResourceType: cloud.HealthCheck, PropertyName: properties.ID},
+
+	"create.replicationgroup.primary-cluster": {ResourceType: cloud.CacheCluster, PropertyName: properties.ID},
+
+	"delete.cachecluster.id":     {ResourceType: cloud.CacheCluster, PropertyName: properties.ID},
+	"delete.replicationgroup.id": {ResourceType: cloud.ReplicationGroup, PropertyName: properties.ID},
+	"delete.spotfleet.id":        {ResourceType: cloud.SpotFleet, PropertyName: properties.ID},
 
 	"delete.policy.arn":   {ResourceType: cloud.Policy, PropertyName: properties.Arn},
 	"detach.policy.arn":   {ResourceType: cloud.Policy, PropertyName: properties.Arn},
@@ -22,19 +22,21 @@ var generatedParamsDoc = map[string]map[string]string{
 	"attach.containertask": {},
 	"attach.elasticip": {
 		"allow-reassociation": "For a VPC in an EC2-Classic account, specify true to allow an Elastic IP address that is already associated with an instance or network interface to be reassociated with the specified instance or network interface",
-		"id":               "The allocation ID",
-		"instance":         "The ID of the instance",
-		"networkinterface": "The ID of the network interface",
-		"privateip":        "The primary or secondary private IP address to associate with the Elastic IP address",
+		"id":                  "The allocation ID",
+		"instance":            "The ID of the instance",
+		"networkinterface":    "The ID of the network interface",
+		"privateip":           "The primary or secondary private IP address to associate with the Elastic IP address",
 	},
 	"attach.instance": {
 		"targetgroup": "The Amazon Resource Name (ARN) of the target group",
 	},
 	"attach.instanceprofile": {},
+	"attach.integration":     {},
 	"attach.internetgateway": {
 		"id":  "The ID of the Internet gateway",
 		"vpc": "The ID of the VPC",
 	},
+	"attach.keygrant": {},
 	"attach.mfadevice": {
 		"id":         "The serial number that uniquely identifies the MFA device",
 		"mfa-code-1": "An authentication code emitted by the device",
@@ -82,12 +84,16 @@ var generatedParamsDoc = map[string]map[string]string{
 		"name":          "The name of the new AMI in the destination region",
 		"source-id":     "The ID of the AMI to copy",
 		"source-region": "The name of the region that contains the AMI to copy",
+		"wait":          "Set to true to wait for the resource to be available before proceeding to the next statement",
+		"wait-timeout":  "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
 	"copy.snapshot": {
 		"description":   "A description for the EBS snapshot",
 		"encrypted":     "Specifies whether the destination snapshot should be encrypted",
 		"source-id":     "The ID of the EBS snapshot to copy",
 		"source-region": "The ID of the region that contains the snapshot to be copied",
+		"wait":          "Set to true to wait for the resource to be completed before proceeding to the next statement",
+		"wait-timeout":  "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
 	"create.accesskey": {
 		"user": "The name of the IAM user that the new key will belong to",
@@ -109,6 +115,10 @@ var generatedParamsDoc = map[string]map[string]string{
 		"threshold":                "The value against which the specified statistic is compared",
 		"unit":                     "The unit of measure for the statistic",
 	},
+	"create.alias":         {},
+	"create.apideployment": {},
+	"create.apimethod":     {},
+	"create.apiresource":   {},
 	"create.appscalingpolicy": {
 		"dimension":         "The scalable dimension",
 		"name":              "The name of the scaling policy",
@@ -128,15 +138,21 @@ var generatedParamsDoc = map[string]map[string]string{
 		"acl":  "The canned ACL to apply to the bucket",
 		"name": "",
 	},
-	"create.certificate": {},
+	"create.cachecluster": {},
+	"create.certificate":  {},
 	"create.containercluster": {
 		"name": "The name of your cluster",
 	},
 	"create.database":      {},
 	"create.dbsubnetgroup": {},
 	"create.distribution":  {},
-	"create.elasticip": {
-		"domain": "Set to vpc to allocate the address for use with instances in a VPC",
+	"create.elasticip":     {},
+	"create.eventsourcemapping": {
+		"batch-size":        "The largest number of records that AWS Lambda will retrieve from your event source at the time of invoking your function",
+		"enabled":           "Indicates whether AWS Lambda should begin polling the event source",
+		"function":          "The Lambda function to invoke when AWS Lambda detects an event on the stream",
+		"source":            "The Amazon Resource Name (ARN) of the Amazon Kinesis or the Amazon DynamoDB stream that is the event source",
+		"starting-position": "The position in the stream where AWS Lambda should start reading",
 	},
 	"create.function": {
 		"description": "A short, user-defined function description",
@@ -148,14 +164,29 @@ var generatedParamsDoc = map[string]map[string]string{
 		"runtime":     "The runtime environment for the Lambda function you are uploading",
 		"timeout":     "The function execution time at which Lambda should terminate the function",
 	},
+	"create.functionalias": {
+		"description": "Description of the alias",
+		"function":    "Name of the Lambda function for which you want to create an alias",
+		"name":        "Name for the alias you are creating",
+		"version":     "Lambda function version for which you are creating the alias",
+	},
+	"create.functionversion": {
+		"description": "The description for the version you are publishing",
+		"function":    "The Lambda function name",
+	},
 	"create.group": {
 		"name": "The name of the group to create",
 	},
+	"create.healthcheck": {
+		"callerreference": "A unique string that identifies the request and that allows you to retry a failed CreateHealthCheck request without the risk of creating two identical health checks:   If you send a CreateHealthCheck request with the same CallerReference and settings as a previous request, and if the health check doesn't exist, Amazon Route 53 creates the health check",
+	},
 	"create.image": {
-		"description": "A description for the new image",
-		"instance":    "The ID of the instance",
-		"name":        "A name for the new image",
-		"reboot":      "By default, Amazon EC2 attempts to shut down and reboot the instance before creating the image",
+		"description":  "A description for the new image",
+		"instance":     "The ID of the instance",
+		"name":         "A name for the new image",
+		"reboot":       "By default, Amazon EC2 attempts to shut down and reboot the instance before creating the image",
+		"wait":         "Set to true to wait for the resource to be available before proceeding to the next statement",
+		"wait-timeout": "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
 	"create.instance": {
 		"image":         "The ID of the AMI, which you can get by calling DescribeImages",
@@ -171,6 +202,7 @@ var generatedParamsDoc = map[string]map[string]string{
 		"name": "The name of the instance profile to create",
 	},
 	"create.internetgateway": {},
+	"create.key":             {},
 	"create.keypair": {
 		"name": "A unique name for the key pair",
 	},
@@ -209,6 +241,8 @@ var generatedParamsDoc = map[string]map[string]string{
 	"create.natgateway": {
 		"elasticip-id": "The allocation ID of an Elastic IP address to associate with the NAT gateway",
 		"subnet":       "The subnet in which to create the NAT gateway",
+		"wait":         "Set to true to wait for the resource to be available before proceeding to the next statement",
+		"wait-timeout": "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
 	"create.networkinterface": {
 		"description":    "A description for the network interface",
@@ -216,18 +250,22 @@ var generatedParamsDoc = map[string]map[string]string{
 		"securitygroups": "The IDs of one or more security groups",
 		"subnet":         "The ID of the subnet to associate with the network interface",
 	},
+	"create.originaccessidentity": {},
 	"create.policy": {
 		"description": "A friendly description of the policy",
 		"name":        "The friendly name of the policy",
 	},
+	"create.presignedurl": {},
 	"create.queue": {
 		"name": "The name of the new queue",
 	},
-	"create.record": {},
+	"create.record":           {},
+	"create.replicationgroup": {},
 	"create.repository": {
 		"name": "The name to use for the repository",
 	},
-	"create.role": {},
+	"create.restapi": {},
+	"create.role":    {},
 	"create.route": {
 		"cidr":    "The IPv4 CIDR address block used for the destination match",
 		"gateway": "The ID of an Internet gateway or virtual private gateway attached to your VPC",
@@ -246,9 +284,9 @@ var generatedParamsDoc = map[string]map[string]string{
 		"max-size":                 "The maximum size of the group",
 		"min-size":                 "The minimum size of the group",
 		"name":                     "The name of the Auto Scaling group",
-		"new-instances-protected": "Indicates whether newly launched instances are protected from termination by Auto Scaling when scaling in",
-		"subnets":                 "A comma-separated list of subnet identifiers for your virtual private cloud (VPC)",
-		"targetgroups":            "The Amazon Resource Names (ARN) of the target groups",
+		"new-instances-protected":  "Indicates whether newly launched instances are protected from termination by Auto Scaling when scaling in",
+		"subnets":                  "A comma-separated list of subnet identifiers for your virtual private cloud (VPC)",
+		"targetgroups":             "The Amazon Resource Names (ARN) of the target groups",
 	},
 	"create.scalingpolicy": {
 		"adjustment-magnitude": "The minimum number of instances to scale",
@@ -264,8 +302,10 @@ var generatedParamsDoc = map[string]map[string]string{
 		"vpc":         "The ID of the VPC",
 	},
 	"create.snapshot": {
-		"description": "A description for the snapshot",
-		"volume":      "The ID of the EBS volume",
+		"description":  "A description for the snapshot",
+		"volume":       "The ID of the EBS volume",
+		"wait":         "Set to true to wait for the resource to be completed before proceeding to the next statement",
+		"wait-timeout": "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
 	"create.stack": {
 		"capabilities":     "A list of values that you must specify before AWS CloudFormation can create certain stacks",
@@ -281,6 +321,7 @@ var generatedParamsDoc = map[string]map[string]string{
 		"template-file":    "Structure containing the template body with a minimum length of 1 byte and a maximum length of 51,200 bytes",
 		"timeout":          "The amount of time that can pass before the stack status becomes CREATE_FAILED; if DisableRollback is not set or is set to false, the stack will be rolled back",
 	},
+	"create.statemachine": {},
 	"create.subnet": {
 		"availabilityzone": "The Availability Zone for the subnet",
 		"cidr":             "The IPv4 network range for the subnet, in CIDR notation",
@@ -330,6 +371,11 @@ var generatedParamsDoc = map[string]map[string]string{
 	"delete.alarm": {
 		"name": "The alarms to be deleted",
 	},
+	"delete.alias":         {},
+	"delete.apideployment": {},
+	"delete.apimethod":     {},
+	"delete.apiresource":   {},
+	"delete.apistage":      {},
 	"delete.appscalingpolicy": {
 		"dimension":         "The scalable dimension",
 		"name":              "The name of the scaling policy",
@@ -347,6 +393,7 @@ var generatedParamsDoc = map[string]map[string]string{
 	"delete.certificate": {
 		"arn": "String that contains the ARN of the ACM Certificate to be deleted",
 	},
+	"delete.cachecluster": {},
 	"delete.containercluster": {
 		"id": "The short name or full Amazon Resource Name (ARN) of the cluster to delete",
 	},
@@ -360,13 +407,27 @@ var generatedParamsDoc = map[string]map[string]string{
 		"id": "The allocation ID",
 		"ip": "The Elastic IP address",
 	},
+	"delete.eventsourcemapping": {
+		"id": "The event source mapping ID",
+	},
 	"delete.function": {
 		"id":      "The Lambda function to delete",
 		"version": "Using this optional parameter you can specify a function version (but not the $LATEST version) to direct AWS Lambda to delete a specific function version",
 	},
+	"delete.functionalias": {
+		"function": "The Lambda function name for which the alias is created",
+		"name":     "Name of the alias to delete",
+	},
+	"delete.functionversion": {
+		"function": "The Lambda function to delete",
+		"version":  "Using this optional parameter you can specify a function version (but not the $LATEST version) to direct AWS Lambda to delete a specific function version",
+	},
 	"delete.group": {
 		"name": "The name of the IAM group to delete",
 	},
+	"delete.healthcheck": {
+		"id": "The ID of the health check that you want to delete",
+	},
 	"delete.image": {},
 	"delete.instance": {
 		"ids": "One or more instance IDs",
@@ -377,6 +438,7 @@ var generatedParamsDoc = map[string]map[string]string{
 	"delete.internetgateway": {
 		"id": "The ID of the Internet gateway",
 	},
+	"delete.key": {},
 	"delete.keypair": {
 		"name": "The name of the key pair",
 	},
@@ -399,19 +461,22 @@ var generatedParamsDoc = map[string]map[string]string{
 	"delete.networkinterface": {
 		"id": "The ID of the network interface",
 	},
+	"delete.originaccessidentity": {},
 	"delete.policy": {
 		"arn": "The Amazon Resource Name (ARN) of the IAM policy you want to delete",
 	},
 	"delete.queue": {
 		"url": "The URL of the Amazon SQS queue to delete",
 	},
-	"delete.record": {},
+	"delete.record":           {},
+	"delete.replicationgroup": {},
 	"delete.repository": {
 		"account": "The AWS account ID associated with the registry that contains the repository to delete",
 		"force":   "If a repository contains images, forces the deletion",
 		"name":    "The name of the repository to delete",
 	},
-	"delete.role": {},
+	"delete.restapi": {},
+	"delete.role":    {},
 	"delete.route": {
 		"cidr":  "The IPv4 CIDR range for the route",
 		"table": "The ID of the route table",
@@ -439,7 +504,10 @@ var generatedParamsDoc = map[string]map[string]string{
 	"delete.stack": {
 		"name":             "The name or the unique stack ID that is associated with the stack",
 		"retain-resources": "For stacks in the DELETE_FAILED state, a list of resource logical IDs that are associated with the resources you want to retain",
+		"wait":             "Set to true to wait for the resource to be deleted before proceeding to the next statement",
+		"wait-timeout":     "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
+	"delete.statemachine": {},
 	"delete.subnet": {
 		"id": "The ID of the subnet",
 	},
@@ -474,10 +542,12 @@ var generatedParamsDoc = map[string]map[string]string{
 		"targetgroup": "The Amazon Resource Name (ARN) of the target group",
 	},
 	"detach.instanceprofile": {},
+	"detach.integration":     {},
 	"detach.internetgateway": {
 		"id":  "The ID of the Internet gateway",
 		"vpc": "The ID of the VPC",
 	},
+	"detach.keygrant": {},
 	"detach.mfadevice": {
 		"id":   "The serial number that uniquely identifies the MFA device",
 		"user": "The name of the user whose MFA device you want to deactivate",
@@ -525,6 +595,7 @@ var generatedParamsDoc = map[string]map[string]string{
 	"start.instance": {
 		"ids": "One or more instance IDs",
 	},
+	"start.statemachine": {},
 	"stop.alarm": {
 		"names": "The names of the alarms",
 	},
@@ -535,6 +606,7 @@ var generatedParamsDoc = map[string]map[string]string{
 	"stop.instance": {
 		"ids": "One or more instance IDs",
 	},
+	"sync.s3object": {},
 	"update.bucket": {},
 	"update.containertask": {
 		"cluster":         "The short name or full Amazon Resource Name (ARN) of the cluster that your service is running on",
@@ -543,16 +615,26 @@ var generatedParamsDoc = map[string]map[string]string{
 		"name":            "The family and revision (family:revision) or full ARN of the task definition to run in your service",
 	},
 	"update.distribution": {},
-	"update.image":        {},
-	"update.instance": {
-		"id":   "The ID of the instance",
-		"lock": "If the value is true, you can't terminate the instance using the Amazon EC2 console, CLI, or API; otherwise, you can",
-	},
+	"update.eventsourcemapping": {
+		"batch-size": "The maximum number of stream records that can be sent to your Lambda function for a single invocation",
+		"enabled":    "Specifies whether AWS Lambda should actively poll the stream or not",
+		"function":   "The Lambda function to which you want the stream records sent",
+		"id":         "The event source mapping identifier",
+	},
+	"update.functionalias": {
+		"description": "You can change the description of the alias using this parameter",
+		"function":    "The function name for which the alias is created",
+		"name":        "The alias name",
+		"version":     "Using this parameter you can change the Lambda function version to which the alias points",
+	},
+	"update.image":    {},
+	"update.instance": {},
 	"update.loginprofile": {
 		"password":       "The new password for the specified IAM user",
 		"password-reset": "Allows this new password to be used only once by requiring the specified IAM user to set a new password on next sign-in",
 		"username":       "The name of the user whose password you want to update",
 	},
+	"update.originaccessidentity": {},
 	"update.policy": {
 		"arn": "The Amazon Resource Name (ARN) of the IAM policy to which you want to add a new version",
 	},
@@ -572,10 +654,11 @@ var generatedParamsDoc = map[string]map[string]string{
 		"max-size":                 "The maximum size of the Auto Scaling group",
 		"min-size":                 "The minimum size of the Auto Scaling group",
 		"name":                     "The name of the Auto Scaling group",
-		"new-instances-protected": "Indicates whether newly launched instances are protected from termination by Auto Scaling when scaling in",
-		"subnets":                 "The ID of the subnet, if you are launching into a VPC",
+		"new-instances-protected":  "Indicates whether newly launched instances are protected from termination by Auto Scaling when scaling in",
+		"subnets":                  "The ID of the subnet, if you are launching into a VPC",
 	},
 	"update.securitygroup": {},
+	"update.snapshot":      {},
 	"update.stack": {
 		"capabilities":          "A list of values that you must specify before AWS CloudFormation can update certain stacks",
 		"name":                  "The name or unique stack ID of the stack to update",
@@ -21,8 +21,11 @@ func TemplateParamsDocWithEnums(action, entity, param string) (string, bool) {
 
 var manualParamsDoc = map[string]map[string]string{
 	"attach.alarm": {
-		"name":       "The Name of the Alarm to update",
-		"action-arn": "The Amazon Resource Name (ARN) of the action to execute when this alarm transitions to the ALARM state from any other state",
+		"name":         "The Name of the Alarm to update",
+		"action-arn":   "The Amazon Resource Name (ARN) of the action to execute when this alarm transitions to the ALARM state from any other state",
+		"instance":     "The ID of the instance to monitor with the alarm (sets the InstanceId dimension)",
+		"scalinggroup": "The name of the scaling group to monitor with the alarm (sets the AutoScalingGroupName dimension)",
+		"dimensions":   "The dimensions of the alarm as 'Name:Value' (the given instance, scalinggroup and dimensions replace the current dimensions of the alarm)",
 	},
 	"attach.containertask": {
 		"container-name":    "The name of a container",
@@ -34,6 +37,10 @@ var manualParamsDoc = map[string]map[string]string{
 		"privileged":        "When this parameter is true, the container is given elevated privileges on the host container instance",
 		"workdir":           "The working directory in which to run commands inside the container",
 		"ports":             "The list of port mappings for the container. Port mappings allow containers to access ports on the host container instance to send or receive traffic (format [host-port:]container-port[/protocol][,[host-port:]container-port[/protocol]])",
+		"launch-type":       "The launch type the task is compatible with. Use 'fargate' to run the task without managing container instances (the task then uses the awsvpc network mode)",
+		"cpu":               "The number of cpu units used by the task, required for the fargate launch type (ex: 256, 512, 1024...)",
+		"memory":            "The amount (in MiB) of memory used by the task, required for the fargate launch type (ex: 512, 1024, 2048...)",
+		"execution-role":    "The Amazon Resource Name (ARN) of the role allowing the ECS agent to pull images and send logs on your behalf, required for images from private repositories with the fargate launch type",
 	},
 	"attach.elasticip": {
 		"allow-reassociation": "Specify false to ensure the operation fails if the Elastic IP address is already associated with another resource",
@@ -47,6 +54,19 @@ var manualParamsDoc = map[string]map[string]string{
 		"name":     "The name of the InstanceProfile to associate to the Instance",
 		"replace":  "If 'true' will replace existing instance profile with provided one",
 	},
+	"attach.integration": {
+		"function": "The Amazon Resource Name (ARN) of the lambda function invoked by the API method. The function must grant API Gateway the permission to invoke it",
+		"method":   "The HTTP method of the API method (GET, POST, ANY...)",
+		"resource": "The ID of the rest api resource",
+		"restapi":  "The ID of the rest api",
+	},
+	"attach.keygrant": {
+		"key":                "The ID or ARN of the KMS key the grant applies to",
+		"grantee":            "The ARN of the principal (user, role, account) receiving the permissions",
+		"operations":         "The list of operations the grant permits (e.g. Encrypt,Decrypt,GenerateDataKey)",
+		"name":               "A friendly name identifying the grant",
+		"retiring-principal": "The ARN of the principal allowed to retire the grant",
+	},
 	"attach.mfadevice": {
 		"no-prompt": "Use 'true' to disable the prompt that asks to append the mfadevice to ~/.aws/config file",
 	},
@@ -127,16 +147,39 @@ var manualParamsDoc = map[string]map[string]string{
 		"statistic-function": "The statistic for the metric associated with the alarm, other than percentile",
 		"unit":               "The unit of measure for the statistic",
 	},
+	"create.alias": {
+		"name": "The name of the alias, prefixed with 'alias/' (e.g. alias/my-key)",
+		"key":  "The ID or ARN of the KMS key the alias points to",
+	},
+	"create.apideployment": {
+		"restapi":           "The ID of the rest api to deploy",
+		"description":       "The description of the deployment",
+		"stage":             "The name of the stage the deployment is published to. The stage is created if it does not exist",
+		"stage-description": "The description of the stage",
+	},
+	"create.apimethod": {
+		"restapi":         "The ID of the rest api",
+		"resource":        "The ID of the rest api resource",
+		"method":          "The HTTP method of the API method (GET, POST, ANY...)",
+		"authorization":   "The type of authorization of the method (NONE, AWS_IAM, CUSTOM or COGNITO_USER_POOLS). Defaults to NONE",
+		"authorizer":      "The ID of the authorizer of a CUSTOM or COGNITO_USER_POOLS authorization",
+		"apikey-required": "Set to true to require an API key to call the method",
+	},
+	"create.apiresource": {
+		"restapi": "The ID of the rest api",
+		"path":    "The last path segment of the resource (e.g. pets or {petId})",
+		"parent":  "The ID of the parent resource. Defaults to the root resource ('/') of the rest api",
+	},
 	"create.appscalingtarget": {
 		"dimension":         "The scalable dimension associated with the scalable target",
 		"resource":          "The identifier of the resource associated with the scalable target (eg. for ECS: service/cluster-name/service-deployment-name, for EC2 spot-fleet: spot-fleet-request/sfr-73fbd2ce-aa30-494c-8788-1cee4EXAMPLE, for EMR cluster: instancegroup/j-2EEZNYKUA1NTV/ig-1791Y4E1L8YI0, for AppStream 2.0 fleet: fleet/sample-fleet, for DynamoDB table: table/my-table, for DynamoDB global secondary index: table/my-table/index/my-table-index)",
 		"service-namespace": "The namespace of the AWS service",
 	},
 	"create.appscalingpolicy": {
-		"dimension":         "The scalable dimension associated with the scalable target",
-		"resource":          "The identifier of the resource associated with the scalable target (eg. for ECS: service/cluster-name/service-deployment-name, for EC2 spot-fleet: spot-fleet-request/sfr-73fbd2ce-aa30-494c-8788-1cee4EXAMPLE, for EMR cluster: instancegroup/j-2EEZNYKUA1NTV/ig-1791Y4E1L8YI0, for AppStream 2.0 fleet: fleet/sample-fleet, for DynamoDB table: table/my-table, for DynamoDB global secondary index: table/my-table/index/my-table-index)",
-		"service-namespace": "The namespace of the AWS service",
-		"type":              "The policy type",
+		"dimension":                            "The scalable dimension associated with the scalable target",
+		"resource":                             "The identifier of the resource associated with the scalable target (eg. for ECS: service/cluster-name/service-deployment-name, for EC2 spot-fleet: spot-fleet-request/sfr-73fbd2ce-aa30-494c-8788-1cee4EXAMPLE, for EMR cluster: instancegroup/j-2EEZNYKUA1NTV/ig-1791Y4E1L8YI0, for AppStream 2.0 fleet: fleet/sample-fleet, for DynamoDB table: table/my-table, for DynamoDB global secondary index: table/my-table/index/my-table-index)",
+		"service-namespace":                    "The namespace of the AWS service",
+		"type":                                 "The policy type",
 		"stepscaling-adjustment-type":          "The scalable dimension",
 		"stepscaling-adjustments":              "A set of adjustments that enable you to scale based on the size of the alarm breach using this format: [[from]:[to]:scaling-adjustment[,[from]:[to]:scaling-adjustment[,...]]]",
 		"stepscaling-cooldown":                 "The amount of time, in seconds, after a scaling activity completes where previous trigger-related scaling activities can influence future scaling events",
@@ -147,6 +190,21 @@ var manualParamsDoc = map[string]map[string]string{
 		"acl":  "The canned ACL to apply to the bucket",
 		"name": "The name of bucket to create",
 	},
+	"create.cachecluster": {
+		"id":                "The identifier of the cache cluster (lowercase, 1 to 20 alphanumeric characters or hyphens, starting with a letter)",
+		"engine":            "The cache engine of the cluster: memcached or redis",
+		"type":              "The compute and memory capacity of the cache nodes (e.g. cache.t2.micro)",
+		"count":             "The number of cache nodes in the cluster (must be 1 for redis)",
+		"replication-group": "The ID of the replication group the cluster joins as a read replica (redis only)",
+		"version":           "The version of the cache engine",
+		"port":              "The port number on which each of the cache nodes accepts connections",
+		"subnetgroup":       "The name of the cache subnet group to use for the cluster in a VPC",
+		"securitygroups":    "The VPC security groups IDs associated with the cluster",
+		"parametergroup":    "The name of the parameter group to associate with the cluster",
+		"availabilityzone":  "The Availability Zone in which the cluster is created",
+		"maintenancewindow": "The weekly time range during which maintenance is performed (format ddd:hh24:mi-ddd:hh24:mi)",
+		"autoupgrade":       "Set to true to indicate that minor engine upgrades are applied automatically",
+	},
 	"create.certificate": {
 		"domains":            "Main and Additional Fully qualified domain names (FQDNs) to be included in the Certificate name and Subject Alternative Name of the ACM Certificate",
 		"validation-domains": "The domain name that you want ACM to use to send you validation emails. This domain name is the suffix of the email addresses that you want ACM to use. This must be the same as the DomainName value or a superdomain of the domain value",
@@ -182,8 +240,10 @@ var manualParamsDoc = map[string]map[string]string{
 		"timezone":           "The time zone of the DB instance",
 		"type":               "Contains the name of the compute and memory capacity class of the DB instance",
 		"username":           "Contains the master username for the DB instance",
-		"version":            "Indicates the database engine version",
+		"version":            "Indicates the database engine version (checked against the versions RDS offers for the engine)",
 		"vpcsecuritygroups":  "A list of EC2 VPC security groups to associate with this DB instance",
+		"wait":               "Set to true to wait for the resource to be available before proceeding to the next statement",
+		"wait-timeout":       "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
 	"create.dbsubnetgroup": {
 		"description": "The description for the DB subnet group",
@@ -191,21 +251,23 @@ var manualParamsDoc = map[string]map[string]string{
 		"subnets":     "The EC2 Subnet IDs for the DB subnet group",
 	},
 	"create.distribution": {
-		"origin-domain":   "The DNS name of the Amazon S3 bucket from which you want CloudFront to get objects for this origin, for example, myawsbucket.s3.amazonaws.com",
-		"certificate":     "The Amazon Resource Name (ARN) of the AWS Certificate Manager (ACM) certificate you want to use for TSL connection",
-		"comment":         "Any comments you want to include about the distribution",
-		"default-file":    "The object that you want CloudFront to request from your origin when a viewer requests the root URL for your distribution (http://www.example.com)",
-		"domain-aliases":  "A list of CNAMEs (alternate domain names), if any, for this distribution",
-		"enable":          "From this field, you can enable or disable the selected distribution",
-		"forward-cookies": "Specifies which cookies to forward to the origin for this cache behavior",
-		"forward-queries": "Indicates whether you want CloudFront to forward query strings to the origin that is associated with this cache behavior and cache based on the query string parameters",
-		"https-behaviour": "The protocol (HTTP or HTTPS) that viewers can use to access the files",
-		"origin-path":     "An optional element that causes CloudFront to request your content from a directory in your Amazon S3 bucket or your custom origin. When you include this element, specify the directory name, beginning with a /",
-		"price-class":     "The price class that corresponds with the maximum price that you want to pay for CloudFront service. If you specify PriceClass_All, CloudFront responds to requests for your objects from all CloudFront edge locations",
-		"min-ttl":         "The minimum amount of time that you want objects to stay in CloudFront caches before CloudFront forwards another request to your origin to determine whether the object has been updated",
+		"origin-domain":          "The DNS name of the Amazon S3 bucket from which you want CloudFront to get objects for this origin, for example, myawsbucket.s3.amazonaws.com",
+		"certificate":            "The Amazon Resource Name (ARN) of the AWS Certificate Manager (ACM) certificate you want to use for TSL connection",
+		"comment":                "Any comments you want to include about the distribution",
+		"default-file":           "The object that you want CloudFront to request from your origin when a viewer requests the root URL for your distribution (http://www.example.com)",
+		"domain-aliases":         "A list of CNAMEs (alternate domain names), if any, for this distribution",
+		"enable":                 "From this field, you can enable or disable the selected distribution",
+		"forward-cookies":        "Specifies which cookies to forward to the origin for this cache behavior",
+		"forward-queries":        "Indicates whether you want CloudFront to forward query strings to the origin that is associated with this cache behavior and cache based on the query string parameters",
+		"https-behaviour":        "The protocol (HTTP or HTTPS) that viewers can use to access the files",
+		"origin-path":            "An optional element that causes CloudFront to request your content from a directory in your Amazon S3 bucket or your custom origin. When you include this element, specify the directory name, beginning with a /",
+		"price-class":            "The price class that corresponds with the maximum price that you want to pay for CloudFront service. If you specify PriceClass_All, CloudFront responds to requests for your objects from all CloudFront edge locations",
+		"min-ttl":                "The minimum amount of time that you want objects to stay in CloudFront caches before CloudFront forwards another request to your origin to determine whether the object has been updated",
+		"origin-access-identity": "The ID of the origin access identity with which CloudFront gets the objects of the S3 bucket origin, so that the bucket can deny any other public access",
 	},
 	"create.elasticip": {
 		"domain": "Set to vpc to allocate the address for use with instances in a VPC else the address is for use with instances in EC2-Classic",
+		"reuse":  "Reuse an unassociated elastic IP of the local graph instead of allocating a new one (the creation is then not revertible)",
 	},
 	"create.function": {
 		"bucket":        "Amazon S3 bucket name where the .zip file containing your deployment package is stored. This bucket must reside in the same AWS region where you are creating the Lambda function",
@@ -217,16 +279,39 @@ var manualParamsDoc = map[string]map[string]string{
 	"create.group": {
 		"name": "The name of the group to create",
 	},
+	"create.healthcheck": {
+		"type":              "The type of health check: HTTP, HTTPS, HTTP_STR_MATCH, HTTPS_STR_MATCH or TCP",
+		"ip":                "The IPv4 or IPv6 address of the endpoint to check",
+		"domain":            "The fully qualified domain name of the endpoint to check",
+		"port":              "The port on the endpoint to check (required for TCP health checks)",
+		"path":              "The path requested by HTTP and HTTPS health checks (e.g. /health)",
+		"search-string":     "The string searched in the response body by string matching health checks",
+		"interval":          "The number of seconds between health check requests: 10 or 30",
+		"failure-threshold": "The number of consecutive failed checks for the endpoint to be considered unhealthy",
+	},
 	"create.instance": {
-		"count":  "The number of instances to launch",
-		"name":   "The name of the instance to launch",
-		"role":   "The name of the instance profile (role) to launch the instance with",
-		"image":  "The ID of an AMI for the instance to be launched",
-		"distro": "The distro query to resolve official community bare distro AMI from current region. See `awless search images -h`",
+		"count":            "The number of instances to launch",
+		"name":             "The name of the instance to launch",
+		"role":             "The name of the instance profile (role) to launch the instance with",
+		"image":            "The ID of an AMI for the instance to be launched",
+		"distro":           "The distro query to resolve official community bare distro AMI from current region. See `awless search images -h`",
+		"wait":             "Set to true to wait for the resource to be running before proceeding to the next statement",
+		"wait-timeout":     "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
+		"spot":             "Set to true to launch the instance as a spot instance",
+		"spotprice":        "The maximum hourly price (in USD) to pay for the spot instance (defaults to the on-demand price)",
+		"spotinterruption": "The behavior when the spot instance is interrupted: terminate (default), stop or hibernate",
+		"type":             "The instance type (checked against the instance types EC2 offers in the region)",
 	},
 	"create.image": {
 		"reboot": "True to shut down and reboot the instance before creating the image, otherwise no reboot and file system integrity on the created image cannot be guaranteed",
 	},
+	"create.key": {
+		"description":    "The description of the KMS key",
+		"usage":          "The intended use of the key: ENCRYPT_DECRYPT",
+		"origin":         "The source of the key material: AWS_KMS or EXTERNAL",
+		"policy-file":    "The path to a JSON key policy document (defaults to a policy giving the account full access to the key)",
+		"bypass-lockout": "Set to 'true' to skip the check preventing a key policy from locking out the current principal",
+	},
 	"create.keypair": {
 		"name":      "The name of the keypair to create (it will also be the name of the file stored in ~/.awless/keys)",
 		"encrypted": "Set to 'true' if you want to encrypt the keypair"},
@@ -234,6 +319,18 @@ var manualParamsDoc = map[string]map[string]string{
 		"distro": "The distro query to resolve official community bare distro AMI from current region. See `awless search images -h`",
 		"public": "Used for groups that launch instances into a virtual private cloud (VPC). Specifies whether to assign a public IP address to each instance",
 	},
+	"create.launchtemplate": {
+		"name":           "The name of the launch template",
+		"description":    "The description of the first version of the launch template",
+		"image":          "The ID of the AMI of the instances launched from the template",
+		"distro":         "The distro query to resolve official community bare distro AMI from current region. See `awless search images -h`",
+		"type":           "The instance type of the instances launched from the template",
+		"keypair":        "The name of the keypair of the instances",
+		"securitygroups": "The IDs of the security groups of the instances",
+		"userdata":       "The path to the file containing the user data of the instances",
+		"role":           "The name of the instance profile (role) of the instances",
+		"lock":           "Set to true to protect the instances from termination",
+	},
 	"create.listener": {
 		"actiontype":  "The type of action",
 		"targetgroup": "The Amazon Resource Name (ARN) of the target group",
@@ -244,6 +341,9 @@ var manualParamsDoc = map[string]map[string]string{
 	"create.mfadevice": {
 		"name": "The name of the virtual MFA device",
 	},
+	"create.originaccessidentity": {
+		"comment": "Any comments you want to include about the origin access identity",
+	},
 	"create.policy": {
 		"name":        "The friendly name of the policy",
 		"description": "A friendly description of the policy",
@@ -252,6 +352,12 @@ var manualParamsDoc = map[string]map[string]string{
 		"resource":    "The Amazon Resource Name (ARN) of the Resource element which specifies the object or objects that the policy covers",
 		"conditions":  "List of conditions necessary for the policy to be in effect (e.g. [aws:UserAgent!=My user agent,s3:prefix=~home/,aws:CurrentTime>=2013-06-30T00:00:00Z,aws:SourceIp!=203.0.113.0/24,aws:SourceArn==arn:aws:sns:eu-west-1:*:*])",
 	},
+	"create.presignedurl": {
+		"bucket":  "The name of the bucket of the object",
+		"key":     "The key of the object to download, or to upload with method=put",
+		"method":  "The HTTP method allowed with the URL: get or put (defaults to get)",
+		"expires": "The number of seconds the URL is valid for, up to 7 days (defaults to 900)",
+	},
 	"create.queue": {
 		"delay":              "The length of time, in seconds, for which the delivery of all messages in the queue is delayed. Valid values: An integer from 0 to 900 seconds (15 minutes). The default is 0",
 		"max-msg-size":       "The limit of how many bytes a message can contain before Amazon SQS rejects it. Valid values: An integer from 1024 bytes (1 KiB) to 262144 bytes (256 KiB). The default is 262144 (256 KiB)",
@@ -262,13 +368,39 @@ var manualParamsDoc = map[string]map[string]string{
 		"visibility-timeout": "The visibility timeout for the queue. Valid values: An integer from 0 to 43200 (12 hours). The default is 30",
 	},
 	"create.record": {
-		"zone":    "The ID of the hosted zone that contains the resource record sets that you want to change",
-		"name":    "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
-		"type":    "The DNS record type",
-		"value":   "The new DNS record value",
-		"values":  "The new DNS record value(s)",
-		"ttl":     "The resource record cache time to live (TTL), in seconds",
-		"comment": "Any comments you want to include about a change batch request",
+		"zone":           "The ID of the hosted zone that contains the resource record sets that you want to change",
+		"name":           "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
+		"type":           "The DNS record type",
+		"value":          "The new DNS record value",
+		"values":         "The new DNS record value(s)",
+		"ttl":            "The resource record cache time to live (TTL), in seconds",
+		"comment":        "Any comments you want to include about a change batch request",
+		"set-identifier": "An identifier that differentiates among multiple records with the same name and type (required with weighted, latency and failover routing)",
+		"weight":         "Weighted routing: a value between 0 and 255 determining the proportion of DNS queries answered with this record",
+		"region":         "Latency routing: the AWS region of the resource this record refers to",
+		"failover":       "Failover routing: whether this record is the PRIMARY or SECONDARY record",
+		"healthcheck":    "The ID of the health check whose status determines whether this record is returned",
+	},
+	"create.replicationgroup": {
+		"id":              "The identifier of the replication group (lowercase, 1 to 20 alphanumeric characters or hyphens, starting with a letter)",
+		"description":     "The description of the replication group",
+		"engine":          "The cache engine of the clusters in the group: redis",
+		"type":            "The compute and memory capacity of the cache nodes (e.g. cache.t2.micro)",
+		"count":           "The number of clusters in the group, the primary included",
+		"primary-cluster": "The ID of an existing redis cluster to use as the primary of the group, engine and type are then inherited",
+		"failover":        "Set to true to promote automatically a read replica when the primary fails",
+		"version":         "The version of the cache engine",
+		"port":            "The port number on which each of the cache nodes accepts connections",
+		"subnetgroup":     "The name of the cache subnet group to use for the group in a VPC",
+		"securitygroups":  "The VPC security groups IDs associated with the clusters of the group",
+		"parametergroup":  "The name of the parameter group to associate with the clusters of the group",
+		"encrypted":       "Set to true to enable encryption at rest",
+	},
+	"create.restapi": {
+		"name":        "The name of the rest api",
+		"description": "The description of the rest api",
+		"version":     "A version identifier for the rest api",
+		"clone":       "The ID of the rest api to clone from",
 	},
 	"create.role": {
 		"conditions":        "List of conditions necessary for the policy to be in effect (e.g. [aws:UserAgent!=My user agent,s3:prefix=~home/,aws:CurrentTime>=2013-06-30T00:00:00Z,aws:SourceIp!=203.0.113.0/24,aws:SourceArn==arn:aws:sns:eu-west-1:*:*])",
@@ -285,12 +417,26 @@ var manualParamsDoc = map[string]map[string]string{
 		"acl":    "The canned ACL to apply to the object",
 	},
 	"create.scalinggroup": {
-		"healthcheck-type": "The service to use for the health checks",
+		"healthcheck-type":       "The service to use for the health checks",
+		"launchtemplate":         "The ID of the launch template, replacing the launch configuration",
+		"launchtemplate-version": "The version of the launch template: a version number, latest or default (defaults to default)",
 	},
 	"create.scalingpolicy": {
 		"adjustment-type":    "The adjustment type",
 		"adjustment-scaling": "The amount by which to scale, based on the specified adjustment type (e.g. '-2', '3')",
 	},
+	"create.spotfleet": {
+		"role":           "The ARN of the IAM role granting the Spot Fleet the permission to launch and terminate instances",
+		"capacity":       "The number of instances the fleet maintains",
+		"image":          "The ID of the AMI of the instances launched by the fleet",
+		"type":           "The instance type of the instances launched by the fleet",
+		"subnet":         "The ID of the subnet in which to launch the instances",
+		"keypair":        "The name of the keypair of the instances",
+		"securitygroups": "The IDs of the security groups of the instances",
+		"price":          "The maximum hourly price (in USD) to pay per instance (defaults to the on-demand price)",
+		"interruption":   "The behavior when a spot instance is interrupted: terminate (default), stop or hibernate",
+		"allocation":     "How the instances are allocated across the spot pools: lowestPrice (default) or diversified",
+	},
 	"create.stack": {
 		"capabilities":  "A list of values that you must specify before AWS CloudFormation can create certain stacks",
 		"on-failure":    "Determines what action will be taken if stack creation fails",
@@ -298,6 +444,13 @@ var manualParamsDoc = map[string]map[string]string{
 		"policy-file":   "The path to the file containing the stack policy body",
 		"template-file": "The path to the file containing the template body with a minimum size of 1 byte and a maximum size of 51,200 bytes",
 		"stack-file":    "The path to the file containing Parameters/Tags/StackPolices definition (http://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/continuous-delivery-codepipeline-cfn-artifacts.html#w2ab2c13c15c15). Values passed via CLI has higher priority than ones defined in StackFile",
+		"wait":          "Set to true to wait for the resource to be created before proceeding to the next statement",
+		"wait-timeout":  "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
+	},
+	"create.statemachine": {
+		"name":            "The name of the state machine",
+		"definition-file": "The path to the file containing the Amazon States Language definition of the state machine",
+		"role":            "The Amazon Resource Name (ARN) of the IAM role used by the state machine to run its states",
 	},
 	"create.subnet": {
 		"name":   "The 'Name' Tag for the subnet to create",
@@ -308,14 +461,36 @@ var manualParamsDoc = map[string]map[string]string{
 		"protocol": "The protocol you want to use",
 		"topic":    "The ARN of the topic you want to subscribe to",
 	},
+	"create.table": {
+		"name":           "The name of the DynamoDB table to create",
+		"hash-key":       "The name of the attribute used as partition key",
+		"hash-key-type":  "The type of the partition key attribute: S (string), N (number) or B (binary). Default to S",
+		"range-key":      "The name of the attribute used as sort key",
+		"range-key-type": "The type of the sort key attribute: S (string), N (number) or B (binary). Default to S",
+		"read-capacity":  "The maximum number of strongly consistent reads consumed per second (1 to 40000)",
+		"write-capacity": "The maximum number of writes consumed per second (1 to 40000)",
+	},
 	"create.tag": {
-		"resource": "The ID of the resource on which you want to add a tag",
+		"resource": "The ID of the EC2 resource or the Amazon Resource Name (ARN) of any other taggable resource on which you want to add a tag",
+		"query":    "Bulk mode: the resources to tag given as a list of tags 'Key=Value' ('Key=*' for any value) and/or resource types (ec2:instance, s3, lambda:function...)",
 		"key":      "The Tag key",
 		"value":    "The Tag value",
 	},
 	"create.targetgroup": {
 		"matcher": "The HTTP codes to use when checking for a successful response from a target",
 	},
+	"create.trafficpolicy": {
+		"name":          "The name of the traffic policy",
+		"document-file": "The path to the JSON document describing the traffic policy (routing rules, endpoints and health checks)",
+		"comment":       "Any comments that you want to include about the traffic policy",
+	},
+	"create.trafficpolicyinstance": {
+		"zone":    "The ID of the hosted zone in which to create the records",
+		"name":    "The domain name (e.g. www.example.com) for which to create the records from the traffic policy",
+		"ttl":     "The TTL (in seconds) of the records created from the traffic policy",
+		"policy":  "The ID of the traffic policy to create the records from",
+		"version": "The version of the traffic policy to create the records from",
+	},
 	"create.vpc": {
 		"name": "The 'Name' Tag for the VPC to create",
 	},
@@ -331,9 +506,33 @@ var manualParamsDoc = map[string]map[string]string{
 	"delete.alarm": {
 		"name": "The name of the alarm(s) to be deleted",
 	},
+	"delete.alias": {
+		"name": "The name of the alias to delete (e.g. alias/my-key)",
+	},
+	"delete.apideployment": {
+		"id":      "The ID of the deployment to delete",
+		"restapi": "The ID of the rest api of the deployment",
+	},
+	"delete.apimethod": {
+		"restapi":  "The ID of the rest api",
+		"resource": "The ID of the rest api resource",
+		"method":   "The HTTP method of the API method",
+	},
+	"delete.apiresource": {
+		"id":      "The ID of the resource to delete. Its child resources and methods are deleted too",
+		"restapi": "The ID of the rest api of the resource",
+	},
+	"delete.apistage": {
+		"name":    "The name of the stage to delete",
+		"restapi": "The ID of the rest api of the stage",
+	},
 	"delete.bucket": {
 		"name": "The name of the bucket to be deleted",
 	},
+	"delete.cachecluster": {
+		"id":       "The ID of the cache cluster to be deleted",
+		"snapshot": "The name of a final snapshot of the redis cluster taken before deletion",
+	},
 	"delete.containertask": {
 		"name":         "The name of the containertask to be deleted",
 		"all-versions": "Set to 'true' to delete all existing versions of the containertask to be deleted",
@@ -342,6 +541,8 @@ var manualParamsDoc = map[string]map[string]string{
 		"id":            "The ID of the database to be deleted",
 		"skip-snapshot": "Determines whether a final DB snapshot is created before the DB instance is deleted. If true is specified, no DBSnapshot is created. If false is specified, a DB snapshot is created before the DB instance is deleted",
 		"snapshot":      "The ID of the new DBSnapshot created when skip-snapshot=false",
+		"wait":          "Set to true to wait for the resource to be deleted before proceeding to the next statement",
+		"wait-timeout":  "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
 	"delete.dbsubnetgroup": {
 		"name": "The name of the database subnet group to be deleted",
@@ -357,29 +558,55 @@ var manualParamsDoc = map[string]map[string]string{
 		"delete-snapshots": "Set to 'true' to also delete the snapshots created from this image",
 	},
 	"delete.instance": {
-		"ids": "The ID(s) of the instance(s) to be deleted",
-		"id":  "The ID of the instance(s) to be deleted",
+		"ids":                  "The ID(s) of the instance(s) to be deleted",
+		"id":                   "The ID of the instance(s) to be deleted",
+		"cancel-spot-requests": "Set to true to cancel the spot requests of spot instances first, so that persistent requests do not launch new instances",
+		"wait":                 "Set to true to wait for the resource to be terminated before proceeding to the next statement",
+		"wait-timeout":         "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
 	},
 	"delete.internetgateway": {
 		"id": "The ID of the Internet gateway to be deleted",
 	},
+	"delete.key": {
+		"id":           "The ID or ARN of the KMS key to schedule for deletion",
+		"pending-days": "The waiting period in days before the key is deleted: between 7 and 30 (defaults to 30)",
+	},
 	"delete.keypair": {
 		"name": "The name of the key pair to be deleted",
 	},
 	"delete.launchconfiguration": {
 		"name": "The name of the launch configuration to be deleted",
 	},
+	"delete.launchtemplate": {
+		"id": "The ID of the launch template to be deleted, with all its versions",
+	},
+	"delete.originaccessidentity": {
+		"id": "The ID of the origin access identity to be deleted",
+	},
 	"delete.policy": {
 		"all-versions": "Set to 'true' to delete all existing versions of the policy to be deleted",
 	},
 	"delete.record": {
-		"id":     "The awless id (cf `awless list records`) of the record to delete",
-		"zone":   "The ID of the hosted zone that contains the resource record sets that you want to delete",
-		"name":   "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
-		"type":   "The DNS record type",
-		"value":  "The DNS record value to delete",
-		"values": "The DNS record value(s) to delete",
-		"ttl":    "The resource record cache time to live (TTL), in seconds",
+		"id":             "The awless id (cf `awless list records`) of the record to delete",
+		"zone":           "The ID of the hosted zone that contains the resource record sets that you want to delete",
+		"name":           "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
+		"type":           "The DNS record type",
+		"value":          "The DNS record value to delete",
+		"values":         "The DNS record value(s) to delete",
+		"ttl":            "The resource record cache time to live (TTL), in seconds",
+		"set-identifier": "An identifier that differentiates among multiple records with the same name and type (required with weighted, latency and failover routing)",
+		"weight":         "Weighted routing: a value between 0 and 255 determining the proportion of DNS queries answered with this record",
+		"region":         "Latency routing: the AWS region of the resource this record refers to",
+		"failover":       "Failover routing: whether this record is the PRIMARY or SECONDARY record",
+		"healthcheck":    "The ID of the health check whose status determines whether this record is returned",
+	},
+	"delete.replicationgroup": {
+		"id":             "The ID of the replication group to be deleted",
+		"retain-primary": "Set to true to delete only the read replicas and keep the primary cluster",
+		"snapshot":       "The name of a final snapshot of the primary cluster taken before deletion",
+	},
+	"delete.restapi": {
+		"id": "The ID of the rest api to delete",
 	},
 	"delete.role": {
 		"name": "The name of the role to be deleted",
@@ -388,14 +615,35 @@ var manualParamsDoc = map[string]map[string]string{
 		"bucket": "The name of the bucket containing the object to be deleted",
 		"name":   "The name (i.e. key) of the object to be deleted",
 	},
+	"delete.spotfleet": {
+		"id":                  "The ID of the spot fleet request to cancel",
+		"terminate-instances": "Set to false to keep running the instances of the fleet (defaults to true)",
+	},
+	"delete.statemachine": {
+		"id": "The Amazon Resource Name (ARN) of the state machine to delete",
+	},
+	"delete.table": {
+		"name": "The name of the DynamoDB table to delete",
+	},
 	"delete.tag": {
-		"resource": "The ID of the resource on which you want to remove a tag",
+		"resource": "The ID of the EC2 resource or the Amazon Resource Name (ARN) of any other taggable resource on which you want to remove a tag",
+		"query":    "Bulk mode: the resources to untag given as a list of tags 'Key=Value' ('Key=*' for any value) and/or resource types (ec2:instance, s3, lambda:function...)",
 		"key":      "The Tag key",
-		"value":    "The Tag value",
+		"value":    "The Tag value (only applies to EC2 resources given by ID)",
+	},
+	"delete.trafficpolicy": {
+		"id":      "The ID of the traffic policy to delete",
+		"version": "The version of the traffic policy to delete",
+	},
+	"delete.trafficpolicyinstance": {
+		"id": "The ID of the traffic policy instance to delete, deleting the records it created",
 	},
 	"detach.alarm": {
-		"name":       "The name of the alarm",
-		"action-arn": "The Amazon Resource Name (ARN) to be detached of the ALARM actions",
+		"name":         "The name of the alarm",
+		"action-arn":   "The Amazon Resource Name (ARN) to be detached of the ALARM actions",
+		"instance":     "The ID of the instance no longer monitored by the alarm",
+		"scalinggroup": "The name of the scaling group no longer monitored by the alarm",
+		"dimensions":   "The dimensions as 'Name:Value' to set on the alarm once detached (used to restore the dimensions when reverting an attach)",
 	},
 	"detach.containertask": {
 		"container-name": "The name of the container to detach",
@@ -408,6 +656,15 @@ var manualParamsDoc = map[string]map[string]string{
 		"instance": "The ID of the Instance",
 		"name":     "The name of the InstanceProfile to detach from the Instance",
 	},
+	"detach.integration": {
+		"method":   "The HTTP method of the API method",
+		"resource": "The ID of the rest api resource",
+		"restapi":  "The ID of the rest api",
+	},
+	"detach.keygrant": {
+		"id":  "The ID of the grant to revoke",
+		"key": "The ID or ARN of the KMS key the grant applies to",
+	},
 	"detach.networkinterface": {
 		"attachment": "The ID of the attachment",
 		"force":      "Specifies whether to force a detachment",
@@ -449,13 +706,22 @@ var manualParamsDoc = map[string]map[string]string{
 		"loadbalancer.container-name": "The name of the container (as it appears in a container definition) to associate with the load balancer",
 		"loadbalancer.container-port": "The port on the container to associate with the load balancer",
 		"loadbalancer.targetgroup":    "The full Amazon Resource Name (ARN) of the Elastic Load Balancing target group associated with a service",
-		"name":            "The name of the container task to start",
-		"deployment-name": "The deployment name of the service (e.g. prod, staging...)",
-		"role":            "The name or full Amazon Resource Name (ARN) of the IAM role that allows Amazon ECS to make calls to your load balancer on your behalf",
+		"name":                        "The name of the container task to start",
+		"deployment-name":             "The deployment name of the service (e.g. prod, staging...)",
+		"role":                        "The name or full Amazon Resource Name (ARN) of the IAM role that allows Amazon ECS to make calls to your load balancer on your behalf",
+		"launch-type":                 "The launch type on which to run the service or task",
+		"subnets":                     "The subnets of the tasks, required for the fargate launch type",
+		"securitygroups":              "The security groups of the tasks, with the fargate launch type",
+		"public":                      "Set to 'true' to assign a public IP to the tasks, with the fargate launch type",
 	},
 	"start.instance": {
 		"id": "The ID of the instance to be started",
 	},
+	"start.statemachine": {
+		"id":    "The Amazon Resource Name (ARN) of the state machine to execute",
+		"input": "The JSON input data for the execution",
+		"name":  "The name of the execution, unique for the account, region and state machine for 90 days",
+	},
 	"stop.containertask": {
 		"cluster":         "The short name or full Amazon Resource Name (ARN) of the cluster on which to run your task",
 		"type":            "The type of task to launch",
@@ -465,6 +731,16 @@ var manualParamsDoc = map[string]map[string]string{
 	"stop.instance": {
 		"id": "The ID of the instance to be stopped",
 	},
+	"sync.s3object": {
+		"bucket":      "The name of the bucket to sync with",
+		"dir":         "The local directory to upload, or to download the objects into with direction=download",
+		"direction":   "Either upload the directory to the bucket or download the bucket into the directory (defaults to upload)",
+		"prefix":      "The key prefix under which the objects are synced in the bucket (defaults to the bucket root)",
+		"include":     "List (one or more) patterns of the files to sync (ex: '*.html' or 'assets/*'), matched on the base name when without '/'",
+		"exclude":     "List (one or more) patterns of the files not to sync (ex: '*.tmp')",
+		"concurrency": "The number of files transferred at once (defaults to 5)",
+		"acl":         "The canned ACL to apply to the uploaded objects",
+	},
 	"update.bucket": {
 		"name":              "The name of the bucket to update",
 		"acl":               "The canned ACL to apply to the bucket",
@@ -474,19 +750,20 @@ var manualParamsDoc = map[string]map[string]string{
 		"enforce-https":     "Use HTTPS rather than HTTP when redirecting requests",
 	},
 	"update.distribution": {
-		"id":              "The ID of the distribution to update",
-		"origin-domain":   "The DNS name of the Amazon S3 bucket from which you want CloudFront to get objects for this origin, for example, myawsbucket.s3.amazonaws.com",
-		"certificate":     "The Amazon Resource Name (ARN) of the AWS Certificate Manager (ACM) certificate you want to use for TSL connection",
-		"comment":         "Any comments you want to include about the distribution",
-		"default-file":    "The object that you want CloudFront to request from your origin when a viewer requests the root URL for your distribution (http://www.example.com)",
-		"domain-aliases":  "A list of CNAMEs (alternate domain names), if any, for this distribution",
-		"forward-cookies": "Specifies which cookies to forward to the origin for this cache behavior",
-		"forward-queries": "Indicates whether you want CloudFront to forward query strings to the origin that is associated with this cache behavior and cache based on the query string parameters (true | false)",
-		"https-behaviour": "The protocol (HTTP or HTTPS) that viewers can use to access the files",
-		"origin-path":     "An optional element that causes CloudFront to request your content from a directory in your Amazon S3 bucket or your custom origin. When you include this element, specify the directory name, beginning with a /",
-		"price-class":     "The price class that corresponds with the maximum price that you want to pay for CloudFront service. If you specify PriceClass_All, CloudFront responds to requests for your objects from all CloudFront edge locations",
-		"min-ttl":         "The minimum amount of time that you want objects to stay in CloudFront caches before CloudFront forwards another request to your origin to determine whether the object has been updated",
-		"enable":          "Enable/Disable the distribution",
+		"id":                     "The ID of the distribution to update",
+		"origin-domain":          "The DNS name of the Amazon S3 bucket from which you want CloudFront to get objects for this origin, for example, myawsbucket.s3.amazonaws.com",
+		"certificate":            "The Amazon Resource Name (ARN) of the AWS Certificate Manager (ACM) certificate you want to use for TSL connection",
+		"comment":                "Any comments you want to include about the distribution",
+		"default-file":           "The object that you want CloudFront to request from your origin when a viewer requests the root URL for your distribution (http://www.example.com)",
+		"domain-aliases":         "A list of CNAMEs (alternate domain names), if any, for this distribution",
+		"forward-cookies":        "Specifies which cookies to forward to the origin for this cache behavior",
+		"forward-queries":        "Indicates whether you want CloudFront to forward query strings to the origin that is associated with this cache behavior and cache based on the query string parameters (true | false)",
+		"https-behaviour":        "The protocol (HTTP or HTTPS) that viewers can use to access the files",
+		"origin-path":            "An optional element that causes CloudFront to request your content from a directory in your Amazon S3 bucket or your custom origin. When you include this element, specify the directory name, beginning with a /",
+		"price-class":            "The price class that corresponds with the maximum price that you want to pay for CloudFront service. If you specify PriceClass_All, CloudFront responds to requests for your objects from all CloudFront edge locations",
+		"min-ttl":                "The minimum amount of time that you want objects to stay in CloudFront caches before CloudFront forwards another request to your origin to determine whether the object has been updated",
+		"enable":                 "Enable/Disable the distribution",
+		"origin-access-identity": "The ID of the origin access identity with which CloudFront gets the objects of the S3 bucket origin, so that the bucket can deny any other public access",
 	},
 	"update.image": {
 		"accounts":      "List (one or more) AWS account IDs",
@@ -497,7 +774,28 @@ var manualParamsDoc = map[string]map[string]string{
 		"product-codes": "One or more DevPay product codes. After adding a product code, it cannot be removed",
 	},
 	"update.instance": {
-		"type": "Changes the instance type to the specified value",
+		"id":              "The ID of the instance",
+		"lock":            "If the value is true, you can't terminate the instance using the Amazon EC2 console, CLI, or API; otherwise, you can",
+		"metadata-tokens": "Set to 'required' to enforce the use of session tokens (IMDSv2) when querying the instance metadata service, or 'optional' to allow IMDSv1",
+		"type":            "Changes the instance type to the specified value (checked against the instance types EC2 offers in the region)",
+	},
+	"update.launchtemplate": {
+		"id":             "The ID of the launch template to create a new version of",
+		"source-version": "The version the new version is based on, its params being overridden by the given ones (defaults to a version without other params)",
+		"description":    "The description of the new version",
+		"default":        "Set to true to make the new version the default version of the launch template",
+		"image":          "The ID of the AMI of the instances launched from the template",
+		"distro":         "The distro query to resolve official community bare distro AMI from current region. See `awless search images -h`",
+		"type":           "The instance type of the instances launched from the template",
+		"keypair":        "The name of the keypair of the instances",
+		"securitygroups": "The IDs of the security groups of the instances",
+		"userdata":       "The path to the file containing the user data of the instances",
+		"role":           "The name of the instance profile (role) of the instances",
+		"lock":           "Set to true to protect the instances from termination",
+	},
+	"update.originaccessidentity": {
+		"id":      "The ID of the origin access identity to update",
+		"comment": "Any comments you want to include about the origin access identity",
 	},
 	"update.policy": {
 		"arn":        "The Amazon Resource Name (ARN) of the IAM policy you want to attach",
@@ -507,13 +805,18 @@ var manualParamsDoc = map[string]map[string]string{
 		"conditions": "List of conditions necessary for the policy to be in effect (e.g. [aws:UserAgent!=My user agent,s3:prefix=~home/,aws:CurrentTime>=2013-06-30T00:00:00Z,aws:SourceIp!=203.0.113.0/24,aws:SourceArn==arn:aws:sns:eu-west-1:*:*])",
 	},
 	"update.record": {
-		"zone":    "The ID of the hosted zone that contains the resource record sets that you want to change",
-		"name":    "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
-		"type":    "The DNS record type",
-		"value":   "The current or new DNS record value",
-		"values":  "The current or new DNS record value(s)",
-		"ttl":     "The resource record cache time to live (TTL), in seconds",
-		"comment": "Any comments you want to include about a change batch request",
+		"zone":           "The ID of the hosted zone that contains the resource record sets that you want to change",
+		"name":           "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
+		"type":           "The DNS record type",
+		"value":          "The current or new DNS record value",
+		"values":         "The current or new DNS record value(s)",
+		"ttl":            "The resource record cache time to live (TTL), in seconds",
+		"comment":        "Any comments you want to include about a change batch request",
+		"set-identifier": "An identifier that differentiates among multiple records with the same name and type (required with weighted, latency and failover routing)",
+		"weight":         "Weighted routing: a value between 0 and 255 determining the proportion of DNS queries answered with this record",
+		"region":         "Latency routing: the AWS region of the resource this record refers to",
+		"failover":       "Failover routing: whether this record is the PRIMARY or SECONDARY record",
+		"healthcheck":    "The ID of the health check whose status determines whether this record is returned",
 	},
 	"update.s3object": {
 		"acl":     "The canned ACL to apply to the bucket",
@@ -521,14 +824,26 @@ var manualParamsDoc = map[string]map[string]string{
 		"name":    "The name of the object to be updated",
 		"version": "Used to reference a specific version of the object",
 	},
+	"update.scalinggroup": {
+		"launchtemplate":         "The ID of the launch template, replacing the launch configuration",
+		"launchtemplate-version": "The version of the launch template: a version number, latest or default (defaults to default)",
+	},
 	"update.securitygroup": {
-		"id":            "The ID of the security group to be updated",
-		"cidr":          "The CIDR IPv4 address range",
-		"securitygroup": "The ID of the source security group. Cannot be used when using cidr param",
-		"protocol":      "The IP protocol name or number",
-		"inbound":       "Set inbound to either authorize or revoke, to update the security group ingress rules",
-		"outbound":      "Set outbound to either authorize or revoke, to update the security group egress rules",
-		"portrange":     "The portrange for the rule to update: any, 80, 22-23...",
+		"id":             "The ID of the security group to be updated",
+		"cidr":           "The CIDR IPv4 address range",
+		"securitygroup":  "The ID of the source security group. Cannot be used when using cidr param",
+		"protocol":       "The IP protocol name or number",
+		"inbound":        "Set inbound to either authorize or revoke, to update the security group ingress rules",
+		"outbound":       "Set outbound to either authorize or revoke, to update the security group egress rules",
+		"portrange":      "The portrange for the rule to update: any, 80, 22-23...",
+		"inbound-rules":  "The full list of ingress rules replacing the current ones, each given as 'protocol:portrange:source' with source a CIDR or a security group ID (ex: tcp:443:0.0.0.0/0). Use 'none' to remove all the rules",
+		"outbound-rules": "The full list of egress rules replacing the current ones, each given as 'protocol:portrange:source' with source a CIDR or a security group ID (ex: any:any:0.0.0.0/0). Use 'none' to remove all the rules",
+	},
+	"update.snapshot": {
+		"id":        "The ID of the EBS snapshot",
+		"accounts":  "List (one or more) AWS account IDs to share the snapshot with",
+		"groups":    "List (one or more) user groups, use 'all' to make the snapshot public",
+		"operation": "The operation type for create volume permissions: add or remove",
 	},
 	"update.stack": {
 		"capabilities":       "A list of values that you must specify before AWS CloudFormation can update certain stacks",
@@ -537,9 +852,17 @@ var manualParamsDoc = map[string]map[string]string{
 		"policy-update-file": "The path to the file containing the temporary overriding stack policy",
 		"template-file":      "The path to the file containing the template body with a minimum size of 1 byte and a maximum size of 51,200 bytes",
 		"stack-file":         "The path to the file containing Parameters/Tags/StackPolices definition (http://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/continuous-delivery-codepipeline-cfn-artifacts.html#w2ab2c13c15c15). Values passed via CLI has higher priority than ones defined in StackFile",
+		"wait":               "Set to true to wait for the resource to be updated before proceeding to the next statement",
+		"wait-timeout":       "The maximum time (in seconds) to wait for when wait=true (defaults to 600)",
+	},
+	"update.table": {
+		"name":           "The name of the DynamoDB table to update",
+		"index":          "The name of a global secondary index of the table to update instead of the table itself",
+		"read-capacity":  "The new maximum number of strongly consistent reads consumed per second (1 to 40000)",
+		"write-capacity": "The new maximum number of writes consumed per second (1 to 40000)",
 	},
 	"update.targetgroup": {
-		"id": "The Amazon Resource Name (ARN) of the target group",
+		"id":                  "The Amazon Resource Name (ARN) of the target group",
 		"deregistrationdelay": "The amount time for Elastic Load Balancing to wait before changing the state of a deregistering target from draining to unused. The range is 0-3600 seconds. The default value is 300 seconds",
 		"healthcheckinterval": "The approximate amount of time, in seconds, between health checks of an individual target",
 		"healthcheckpath":     "The ping path that is the destination on the targets for health checks",
@@ -0,0 +1,67 @@
+package awsfetch
+
+import (
+	"context"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+)
+
+func getAllRestApis(ctx context.Context, api apigatewayiface.APIGatewayAPI) (res []*apigateway.RestApi, err error) {
+	err = api.GetRestApisPages(&apigateway.GetRestApisInput{}, func(out *apigateway.GetRestApisOutput, lastPage bool) (shouldContinue bool) {
+		res = append(res, out.Items...)
+		return out.Position != nil && ctx.Err() == nil
+	})
+	return
+}
+
+func listRestApiDeployments(ctx context.Context, api apigatewayiface.APIGatewayAPI, restApiId *string) (res []*apigateway.Deployment, err error) {
+	err = api.GetDeploymentsPages(&apigateway.GetDeploymentsInput{RestApiId: restApiId}, func(out *apigateway.GetDeploymentsOutput, lastPage bool) (shouldContinue bool) {
+		res = append(res, out.Items...)
+		return out.Position != nil && ctx.Err() == nil
+	})
+	return
+}
+
+// listRestApiFunctions returns the ARNs of the lambda functions integrated with the methods of a rest api
+func listRestApiFunctions(ctx context.Context, api apigatewayiface.APIGatewayAPI, restApiId *string) (res []string, err error) {
+	unique := make(map[string]struct{})
+	input := &apigateway.GetResourcesInput{RestApiId: restApiId, Embed: []*string{awssdk.String("methods")}}
+	err = api.GetResourcesPages(input, func(out *apigateway.GetResourcesOutput, lastPage bool) (shouldContinue bool) {
+		for _, resource := range out.Items {
+			for _, method := range resource.ResourceMethods {
+				if method == nil || method.MethodIntegration == nil {
+					continue
+				}
+				if arn, ok := functionArnFromIntegrationUri(awssdk.StringValue(method.MethodIntegration.Uri)); ok {
+					if _, done := unique[arn]; !done {
+						unique[arn] = struct{}{}
+						res = append(res, arn)
+					}
+				}
+			}
+		}
+		return out.Position != nil && ctx.Err() == nil
+	})
+	return
+}
+
+// functionArnFromIntegrationUri extracts the unqualified function ARN from a lambda integration URI,
+// ex: arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/arn:aws:lambda:us-east-1:123456789012:function:pets:prod/invocations
+func functionArnFromIntegrationUri(uri string) (string, bool) {
+	if !strings.Contains(uri, ":lambda:path/") {
+		return "", false
+	}
+	i := strings.Index(uri, "/functions/")
+	if i < 0 {
+		return "", false
+	}
+	path := strings.TrimSuffix(uri[i+len("/functions/"):], "/invocations")
+	splits := strings.Split(path, ":")
+	if len(splits) < 7 || splits[2] != "lambda" || splits[5] != "function" {
+		return "", false
+	}
+	return strings.Join(splits[:7], ":"), true
+}
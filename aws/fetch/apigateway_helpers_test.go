@@ -0,0 +1,27 @@
+package awsfetch
+
+import "testing"
+
+func TestFunctionArnFromIntegrationUri(t *testing.T) {
+	tcases := []struct {
+		uri    string
+		expArn string
+		expOk  bool
+	}{
+		{uri: "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/arn:aws:lambda:us-east-1:123456789012:function:pets/invocations", expArn: "arn:aws:lambda:us-east-1:123456789012:function:pets", expOk: true},
+		{uri: "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/arn:aws:lambda:us-east-1:123456789012:function:pets:prod/invocations", expArn: "arn:aws:lambda:us-east-1:123456789012:function:pets", expOk: true},
+		{uri: "arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/arn:aws:lambda:us-east-1:123456789012:function:pets:${stageVariables.alias}/invocations", expArn: "arn:aws:lambda:us-east-1:123456789012:function:pets", expOk: true},
+		{uri: "arn:aws:apigateway:us-east-1:s3:path/my-bucket/{key}"},
+		{uri: "https://petstore.example.com/pets"},
+		{uri: ""},
+	}
+	for i, tcase := range tcases {
+		arn, ok := functionArnFromIntegrationUri(tcase.uri)
+		if got, want := ok, tcase.expOk; got != want {
+			t.Fatalf("%d: got %t, want %t", i+1, got, want)
+		}
+		if got, want := arn, tcase.expArn; got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+	}
+}
@@ -4,20 +4,25 @@ import (
 	"reflect"
 
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
@@ -30,6 +35,7 @@ type AWSAPI struct {
 	Ec2                    ec2iface.EC2API
 	Elbv2                  elbv2iface.ELBV2API
 	Rds                    rdsiface.RDSAPI
+	Dynamodb               dynamodbiface.DynamoDBAPI
 	Autoscaling            autoscalingiface.AutoScalingAPI
 	Ecr                    ecriface.ECRAPI
 	Ecs                    ecsiface.ECSAPI
@@ -40,10 +46,14 @@ type AWSAPI struct {
 	Sqs                    sqsiface.SQSAPI
 	Route53                route53iface.Route53API
 	Lambda                 lambdaiface.LambdaAPI
+	Sfn                    sfniface.SFNAPI
+	Apigateway             apigatewayiface.APIGatewayAPI
 	Cloudwatch             cloudwatchiface.CloudWatchAPI
 	Cloudfront             cloudfrontiface.CloudFrontAPI
 	Cloudformation         cloudformationiface.CloudFormationAPI
 	Acm                    acmiface.ACMAPI
+	Kms                    kmsiface.KMSAPI
+	Elasticache            elasticacheiface.ElastiCacheAPI
 }
 
 type Config struct {
@@ -0,0 +1,28 @@
+package awsfetch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+func getAllTables(ctx context.Context, api dynamodbiface.DynamoDBAPI) (res []*dynamodb.TableDescription, err error) {
+	var names []*string
+	err = api.ListTablesPages(&dynamodb.ListTablesInput{}, func(out *dynamodb.ListTablesOutput, lastPage bool) (shouldContinue bool) {
+		names = append(names, out.TableNames...)
+		return out.LastEvaluatedTableName != nil && ctx.Err() == nil
+	})
+	if err != nil {
+		return
+	}
+
+	for _, name := range names {
+		var out *dynamodb.DescribeTableOutput
+		if out, err = api.DescribeTable(&dynamodb.DescribeTableInput{TableName: name}); err != nil {
+			return
+		}
+		res = append(res, out.Table)
+	}
+	return
+}
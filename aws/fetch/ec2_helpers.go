@@ -0,0 +1,144 @@
+package awsfetch
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/fetch"
+)
+
+// ec2IDFilterPerResourceType is the name of the EC2 API filter on the ID of each resource type
+var ec2IDFilterPerResourceType = map[string]string{
+	cloud.Instance:         "instance-id",
+	cloud.Subnet:           "subnet-id",
+	cloud.Vpc:              "vpc-id",
+	cloud.SecurityGroup:    "group-id",
+	cloud.Volume:           "volume-id",
+	cloud.InternetGateway:  "internet-gateway-id",
+	cloud.NatGateway:       "nat-gateway-id",
+	cloud.RouteTable:       "route-table-id",
+	cloud.Image:            "image-id",
+	cloud.ElasticIP:        "allocation-id",
+	cloud.Snapshot:         "snapshot-id",
+	cloud.NetworkInterface: "network-interface-id",
+}
+
+// ec2FiltersFromContext translates the tag matchers and the exact ID matcher of the query
+// carried by the context into EC2 API filters. Only matchers with the exact same semantic on the AWS side are
+// translated, the full query being applied anyway on the fetched resources when displayed.
+func ec2FiltersFromContext(ctx context.Context, resourceType string) (filters []*ec2.Filter) {
+	q, ok := fetch.QueryForType(ctx, resourceType)
+	if !ok {
+		return nil
+	}
+
+	matchers := []cloud.Matcher{q.Matcher}
+	if and, isAnd := q.Matcher.(interface {
+		Matchers() []cloud.Matcher
+	}); isAnd {
+		matchers = and.Matchers()
+	}
+
+	names := make(map[string]bool)
+	addFilter := func(name, value string) {
+		// EC2 ORs the values of a filter: only the first filter of each name can be pushed
+		if !names[name] {
+			names[name] = true
+			filters = append(filters, &ec2.Filter{Name: awssdk.String(name), Values: []*string{awssdk.String(value)}})
+		}
+	}
+
+	for _, m := range matchers {
+		switch mm := m.(type) {
+		case interface {
+			Tag() (string, string)
+		}:
+			k, v := mm.Tag()
+			addFilter(fmt.Sprintf("tag:%s", k), v)
+		case interface {
+			TagKey() string
+		}:
+			addFilter("tag-key", mm.TagKey())
+		case interface {
+			TagValue() string
+		}:
+			addFilter("tag-value", mm.TagValue())
+		case interface {
+			IndexedProperty() (string, string, bool)
+		}:
+			filterName, ok := ec2IDFilterPerResourceType[resourceType]
+			if name, value, exact := mm.IndexedProperty(); ok && exact && name == properties.ID {
+				addFilter(filterName, value)
+			}
+		}
+	}
+	return filters
+}
+
+// The vendored SDK does not know about the instance metadata options (IMDSv2)
+// so we read them directly from the raw DescribeInstances responses
+type instancesMetadataTokens struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func newInstancesMetadataTokens() *instancesMetadataTokens {
+	return &instancesMetadataTokens{tokens: make(map[string]string)}
+}
+
+func (m *instancesMetadataTokens) get(id string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tok, ok := m.tokens[id]
+	return tok, ok
+}
+
+func (m *instancesMetadataTokens) captureOption() request.Option {
+	return func(r *request.Request) {
+		r.Handlers.Unmarshal.PushFront(func(r *request.Request) {
+			if r.HTTPResponse == nil || r.HTTPResponse.Body == nil {
+				return
+			}
+			body, err := ioutil.ReadAll(r.HTTPResponse.Body)
+			r.HTTPResponse.Body.Close()
+			r.HTTPResponse.Body = ioutil.NopCloser(bytes.NewReader(body))
+			if err != nil {
+				return
+			}
+			m.parse(body)
+		})
+	}
+}
+
+func (m *instancesMetadataTokens) parse(body []byte) {
+	var out struct {
+		Reservations []struct {
+			Instances []struct {
+				InstanceId string `xml:"instanceId"`
+				HttpTokens string `xml:"metadataOptions>httpTokens"`
+			} `xml:"instancesSet>item"`
+		} `xml:"reservationSet>item"`
+	}
+	if err := xml.Unmarshal(body, &out); err != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, reservation := range out.Reservations {
+		for _, inst := range reservation.Instances {
+			if inst.InstanceId != "" && inst.HttpTokens != "" {
+				m.tokens[inst.InstanceId] = inst.HttpTokens
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package awsfetch
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/fetch"
+)
+
+func TestEC2FiltersFromContext(t *testing.T) {
+	tcases := []struct {
+		query  cloud.Query
+		expect []*ec2.Filter
+	}{
+		{query: cloud.NewQuery(cloud.Instance), expect: nil},
+		{query: cloud.NewQuery(cloud.Subnet).Match(match.Tag("Env", "prod")), expect: nil},
+		{query: cloud.NewQuery(cloud.Instance).Match(match.Tag("Env", "prod")), expect: []*ec2.Filter{
+			{Name: awssdk.String("tag:Env"), Values: []*string{awssdk.String("prod")}},
+		}},
+		{query: cloud.NewQuery(cloud.Instance).Match(match.And(match.Property("State", "running"), match.Tag("Env", "prod"), match.TagKey("Dept"), match.TagKey("Team"), match.TagValue("Blue"))), expect: []*ec2.Filter{
+			{Name: awssdk.String("tag:Env"), Values: []*string{awssdk.String("prod")}},
+			{Name: awssdk.String("tag-key"), Values: []*string{awssdk.String("Dept")}},
+			{Name: awssdk.String("tag-value"), Values: []*string{awssdk.String("Blue")}},
+		}},
+		{query: cloud.NewQuery(cloud.Instance).Match(match.Property("ID", "i-1234")), expect: []*ec2.Filter{
+			{Name: awssdk.String("instance-id"), Values: []*string{awssdk.String("i-1234")}},
+		}},
+		{query: cloud.NewQuery(cloud.Instance).Match(match.Property("ID", "i-12").Contains()), expect: nil},
+		{query: cloud.NewQuery(cloud.Instance).Match(match.Or(match.Tag("Env", "prod"), match.Tag("Env", "dev"))), expect: nil},
+	}
+
+	for i, tcase := range tcases {
+		ctx := fetch.WithQuery(context.Background(), tcase.query)
+		if got, want := ec2FiltersFromContext(ctx, cloud.Instance), tcase.expect; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+
+	if got := ec2FiltersFromContext(context.Background(), cloud.Instance); got != nil {
+		t.Fatalf("expected no filters without query, got %v", got)
+	}
+
+	idcases := []struct {
+		resourceType, id string
+		expect           []*ec2.Filter
+	}{
+		{resourceType: cloud.Vpc, id: "vpc-1234", expect: []*ec2.Filter{{Name: awssdk.String("vpc-id"), Values: []*string{awssdk.String("vpc-1234")}}}},
+		{resourceType: cloud.SecurityGroup, id: "sg-1234", expect: []*ec2.Filter{{Name: awssdk.String("group-id"), Values: []*string{awssdk.String("sg-1234")}}}},
+		{resourceType: cloud.ElasticIP, id: "eipalloc-1234", expect: []*ec2.Filter{{Name: awssdk.String("allocation-id"), Values: []*string{awssdk.String("eipalloc-1234")}}}},
+		{resourceType: cloud.ImportImageTask, id: "import-ami-1234", expect: nil},
+	}
+	for _, tcase := range idcases {
+		ctx := fetch.WithQuery(context.Background(), cloud.NewQuery(tcase.resourceType).Match(match.Property("ID", tcase.id)))
+		if got, want := ec2FiltersFromContext(ctx, tcase.resourceType), tcase.expect; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: got %v, want %v", tcase.resourceType, got, want)
+		}
+	}
+}
+
+func TestCaptureInstancesMetadataTokens(t *testing.T) {
+	body := `<DescribeInstancesResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <reservationSet>
+    <item>
+      <instancesSet>
+        <item>
+          <instanceId>inst_1</instanceId>
+          <networkInterfaceSet><item><networkInterfaceId>eni_1</networkInterfaceId></item></networkInterfaceSet>
+          <metadataOptions><httpTokens>required</httpTokens><httpEndpoint>enabled</httpEndpoint></metadataOptions>
+        </item>
+        <item>
+          <instanceId>inst_2</instanceId>
+          <metadataOptions><httpTokens>optional</httpTokens></metadataOptions>
+        </item>
+      </instancesSet>
+    </item>
+    <item>
+      <instancesSet>
+        <item><instanceId>inst_3</instanceId></item>
+      </instancesSet>
+    </item>
+  </reservationSet>
+</DescribeInstancesResponse>`
+
+	tokens := newInstancesMetadataTokens()
+	req := &request.Request{HTTPResponse: &http.Response{Body: ioutil.NopCloser(bytes.NewBufferString(body))}}
+	tokens.captureOption()(req)
+	req.Handlers.Unmarshal.Run(req)
+
+	if got, want := tokens.tokens, map[string]string{"inst_1": "required", "inst_2": "optional"}; len(got) != len(want) || got["inst_1"] != want["inst_1"] || got["inst_2"] != want["inst_2"] {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, ok := tokens.get("inst_3"); ok {
+		t.Fatal("expected no metadata tokens for inst_3")
+	}
+
+	restored, err := ioutil.ReadAll(req.HTTPResponse.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(restored), body; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
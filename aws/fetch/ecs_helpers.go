@@ -13,7 +13,7 @@ import (
 func getClustersNames(ctx context.Context, api ecsiface.ECSAPI) (res []*string, err error) {
 	err = api.ListClustersPages(&ecs.ListClustersInput{}, func(out *ecs.ListClustersOutput, lastPage bool) (shouldContinue bool) {
 		res = append(res, out.ClusterArns...)
-		return out.NextToken != nil
+		return out.NextToken != nil && ctx.Err() == nil
 	})
 	return
 }
@@ -40,7 +40,7 @@ func getAllTasks(ctx context.Context, cache fetch.Cache, api ecsiface.ECSAPI) (r
 	addTaskContainersFunc := func(cl *string) func(*ecs.ListTasksOutput, bool) bool {
 		return func(out *ecs.ListTasksOutput, lastPage bool) (shouldContinue bool) {
 			tasksNamesc <- listTasksOutput{output: out, cluster: cl}
-			return out.NextToken != nil
+			return out.NextToken != nil && ctx.Err() == nil
 		}
 	}
 
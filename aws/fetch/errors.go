@@ -0,0 +1,100 @@
+package awsfetch
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+var (
+	accessDeniedCodes = map[string]bool{
+		"AccessDenied":          true,
+		"AccessDeniedException": true,
+		"AuthorizationError":    true,
+		"UnauthorizedOperation": true,
+	}
+	notSupportedCodes = map[string]bool{
+		"InvalidAction":             true,
+		"UnknownOperationException": true,
+		"UnsupportedOperation":      true,
+	}
+	deniedActionRegex = regexp.MustCompile(`perform: ([a-z0-9-]+:[A-Za-z0-9]+)`)
+)
+
+const accessDeniedMessage = "Access Denied"
+
+// ClassifyErrorsMiddleware turns the AWS errors denying, throttling or not supporting
+// the fetching of a resource type into typed errors
+func ClassifyErrorsMiddleware(resType string, next fetch.Func) fetch.Func {
+	return func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		resources, objects, err := next(ctx, cache)
+		return resources, objects, classifyError(ctx, resType, err)
+	}
+}
+
+// Middlewares returns the middlewares wrapping the fetch funcs of the AWS services:
+// logging, retry of throttled fetches and classification of errors
+func Middlewares(conf *Config) []fetch.Middleware {
+	return []fetch.Middleware{
+		fetch.LoggingMiddleware(conf.Log),
+		fetch.RetryMiddleware(throttledFetchAttempts, throttledFetchBackoff, isThrottled),
+		ClassifyErrorsMiddleware,
+	}
+}
+
+const (
+	throttledFetchAttempts = 3
+	throttledFetchBackoff  = time.Second
+)
+
+func isThrottled(err error) bool {
+	_, ok := err.(*fetch.ThrottledError)
+	return ok
+}
+
+func classifyError(ctx context.Context, resType string, err error) error {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+	switch {
+	case accessDeniedCodes[aerr.Code()] || aerr.Message() == accessDeniedMessage:
+		action := fetchActionPerResourceType[resType]
+		if matches := deniedActionRegex.FindStringSubmatch(aerr.Message()); len(matches) > 1 {
+			action = matches[1]
+		}
+		return &fetch.AccessDeniedError{ResourceType: resType, Action: action, Err: err}
+	case request.IsErrorThrottle(err):
+		return &fetch.ThrottledError{ResourceType: resType, Err: err}
+	case notSupportedCodes[aerr.Code()] || isUnknownEndpoint(aerr):
+		region, _ := ctx.Value("region").(string)
+		return &fetch.NotSupportedInRegionError{ResourceType: resType, Region: region, Err: err}
+	}
+	return err
+}
+
+// isUnknownEndpoint returns true when the request failed because the service has no endpoint in the region
+func isUnknownEndpoint(aerr awserr.Error) bool {
+	if aerr.Code() != "RequestError" {
+		return false
+	}
+	urlErr, ok := aerr.OrigErr().(*url.Error)
+	if !ok {
+		return false
+	}
+	switch e := urlErr.Err.(type) {
+	case *net.DNSError:
+		return true
+	case *net.OpError:
+		_, ok := e.Err.(*net.DNSError)
+		return ok
+	}
+	return false
+}
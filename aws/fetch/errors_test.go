@@ -0,0 +1,54 @@
+package awsfetch
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+func TestClassifyErrorsMiddleware(t *testing.T) {
+	denied := awserr.NewRequestFailure(awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation.", nil), 403, "")
+	deniedWithAction := awserr.NewRequestFailure(awserr.New("AccessDenied", "User: arn:aws:iam::123:user/toto is not authorized to perform: iam:ListInstanceProfiles", nil), 403, "")
+	s3Denied := awserr.NewRequestFailure(awserr.New("Forbidden", "Access Denied", nil), 403, "")
+	throttled := awserr.NewRequestFailure(awserr.New("RequestLimitExceeded", "Request limit exceeded.", nil), 503, "")
+	unknownEndpoint := awserr.New("RequestError", "send request failed", &url.Error{Op: "Post", URL: "https://ecs.eu-north-9.amazonaws.com/", Err: &net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "ecs.eu-north-9.amazonaws.com"}}})
+	unsupported := awserr.NewRequestFailure(awserr.New("InvalidAction", "The action DescribeNatGateways is not valid for this web service.", nil), 400, "")
+	other := errors.New("unexpected")
+
+	ctx := context.WithValue(context.Background(), "region", "eu-north-9")
+	tcases := []struct {
+		resType string
+		err     error
+		exp     error
+	}{
+		{"volume", denied, &fetch.AccessDeniedError{ResourceType: "volume", Action: "ec2:DescribeVolumes", Err: denied}},
+		{"instanceprofile", deniedWithAction, &fetch.AccessDeniedError{ResourceType: "instanceprofile", Action: "iam:ListInstanceProfiles", Err: deniedWithAction}},
+		{"bucket", s3Denied, &fetch.AccessDeniedError{ResourceType: "bucket", Err: s3Denied}},
+		{"instance", throttled, &fetch.ThrottledError{ResourceType: "instance", Err: throttled}},
+		{"containercluster", unknownEndpoint, &fetch.NotSupportedInRegionError{ResourceType: "containercluster", Region: "eu-north-9", Err: unknownEndpoint}},
+		{"natgateway", unsupported, &fetch.NotSupportedInRegionError{ResourceType: "natgateway", Region: "eu-north-9", Err: unsupported}},
+		{"vpc", other, other},
+		{"vpc", nil, nil},
+	}
+
+	for i, tcase := range tcases {
+		fn := ClassifyErrorsMiddleware(tcase.resType, func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+			return nil, nil, tcase.err
+		})
+		_, _, err := fn(ctx, nil)
+		if got, want := err, tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+
+	if got, want := (&fetch.AccessDeniedError{ResourceType: "volume", Action: "ec2:DescribeVolumes"}).Error(), "access denied to volumes: missing ec2:DescribeVolumes permission"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
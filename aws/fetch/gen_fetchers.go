@@ -31,11 +31,14 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/sfn"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/wallix/awless/aws/conv"
 	"github.com/wallix/awless/fetch"
@@ -47,39 +50,6 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 
 	addManualInfraFetchFuncs(conf, funcs)
 
-	funcs["instance"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
-		var resources []*graph.Resource
-		var objects []*ec2.Instance
-
-		if !conf.getBoolDefaultTrue("aws.infra.instance.sync") && !getBoolFromContext(ctx, "force") {
-			conf.Log.Verbose("sync: *disabled* for resource infra[instance]")
-			return resources, objects, nil
-		}
-		var badResErr error
-		err := conf.APIs.Ec2.DescribeInstancesPages(&ec2.DescribeInstancesInput{},
-			func(out *ec2.DescribeInstancesOutput, lastPage bool) (shouldContinue bool) {
-				for _, all := range out.Reservations {
-					for _, output := range all.Instances {
-						if badResErr != nil {
-							return false
-						}
-						objects = append(objects, output)
-						var res *graph.Resource
-						if res, badResErr = awsconv.NewResource(output); badResErr != nil {
-							return false
-						}
-						resources = append(resources, res)
-					}
-				}
-				return out.NextToken != nil
-			})
-		if err != nil {
-			return resources, objects, err
-		}
-
-		return resources, objects, badResErr
-	}
-
 	funcs["subnet"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
 		var resources []*graph.Resource
 		var objects []*ec2.Subnet
@@ -89,7 +59,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeSubnets(&ec2.DescribeSubnetsInput{})
+		out, err := conf.APIs.Ec2.DescribeSubnets(&ec2.DescribeSubnetsInput{Filters: ec2FiltersFromContext(ctx, "subnet")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -115,7 +85,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeVpcs(&ec2.DescribeVpcsInput{})
+		out, err := conf.APIs.Ec2.DescribeVpcs(&ec2.DescribeVpcsInput{Filters: ec2FiltersFromContext(ctx, "vpc")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -167,7 +137,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{})
+		out, err := conf.APIs.Ec2.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: ec2FiltersFromContext(ctx, "securitygroup")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -193,7 +163,8 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
-		err := conf.APIs.Ec2.DescribeVolumesPages(&ec2.DescribeVolumesInput{},
+		var pages int
+		err := conf.APIs.Ec2.DescribeVolumesPages(&ec2.DescribeVolumesInput{Filters: ec2FiltersFromContext(ctx, "volume")},
 			func(out *ec2.DescribeVolumesOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.Volumes {
 					if badResErr != nil {
@@ -206,7 +177,9 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "volume", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -224,7 +197,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{})
+		out, err := conf.APIs.Ec2.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{Filters: ec2FiltersFromContext(ctx, "internetgateway")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -250,7 +223,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{})
+		out, err := conf.APIs.Ec2.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{Filter: ec2FiltersFromContext(ctx, "natgateway")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -276,7 +249,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeRouteTables(&ec2.DescribeRouteTablesInput{})
+		out, err := conf.APIs.Ec2.DescribeRouteTables(&ec2.DescribeRouteTablesInput{Filters: ec2FiltersFromContext(ctx, "routetable")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -328,7 +301,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeImages(&ec2.DescribeImagesInput{Owners: []*string{awssdk.String("self")}})
+		out, err := conf.APIs.Ec2.DescribeImages(&ec2.DescribeImagesInput{Owners: []*string{awssdk.String("self")}, Filters: ec2FiltersFromContext(ctx, "image")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -380,7 +353,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeAddresses(&ec2.DescribeAddressesInput{})
+		out, err := conf.APIs.Ec2.DescribeAddresses(&ec2.DescribeAddressesInput{Filters: ec2FiltersFromContext(ctx, "elasticip")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -406,7 +379,8 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
-		err := conf.APIs.Ec2.DescribeSnapshotsPages(&ec2.DescribeSnapshotsInput{OwnerIds: []*string{awssdk.String("self")}},
+		var pages int
+		err := conf.APIs.Ec2.DescribeSnapshotsPages(&ec2.DescribeSnapshotsInput{OwnerIds: []*string{awssdk.String("self")}, Filters: ec2FiltersFromContext(ctx, "snapshot")},
 			func(out *ec2.DescribeSnapshotsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.Snapshots {
 					if badResErr != nil {
@@ -419,7 +393,9 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "snapshot", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -437,7 +413,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 
-		out, err := conf.APIs.Ec2.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{})
+		out, err := conf.APIs.Ec2.DescribeNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{Filters: ec2FiltersFromContext(ctx, "networkinterface")})
 		if err != nil {
 			return resources, objects, err
 		}
@@ -454,6 +430,66 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 		return resources, objects, nil
 	}
 
+	funcs["spotfleet"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*ec2.SpotFleetRequestConfig
+
+		if !conf.getBoolDefaultTrue("aws.infra.spotfleet.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[spotfleet]")
+			return resources, objects, nil
+		}
+		var badResErr error
+		var pages int
+		err := conf.APIs.Ec2.DescribeSpotFleetRequestsPages(&ec2.DescribeSpotFleetRequestsInput{},
+			func(out *ec2.DescribeSpotFleetRequestsOutput, lastPage bool) (shouldContinue bool) {
+				for _, output := range out.SpotFleetRequestConfigs {
+					if badResErr != nil {
+						return false
+					}
+					objects = append(objects, output)
+					var res *graph.Resource
+					if res, badResErr = awsconv.NewResource(output); badResErr != nil {
+						return false
+					}
+					resources = append(resources, res)
+				}
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "spotfleet", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
+			})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		return resources, objects, badResErr
+	}
+
+	funcs["spotrequest"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*ec2.SpotInstanceRequest
+
+		if !conf.getBoolDefaultTrue("aws.infra.spotrequest.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[spotrequest]")
+			return resources, objects, nil
+		}
+
+		out, err := conf.APIs.Ec2.DescribeSpotInstanceRequests(&ec2.DescribeSpotInstanceRequestsInput{})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		for _, output := range out.SpotInstanceRequests {
+			objects = append(objects, output)
+			res, err := awsconv.NewResource(output)
+			if err != nil {
+				return resources, objects, err
+			}
+			resources = append(resources, res)
+		}
+
+		return resources, objects, nil
+	}
+
 	funcs["loadbalancer"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
 		var resources []*graph.Resource
 		var objects []*elbv2.LoadBalancer
@@ -463,6 +499,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Elbv2.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
 			func(out *elbv2.DescribeLoadBalancersOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.LoadBalancers {
@@ -476,7 +513,9 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextMarker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "loadbalancer", Pages: pages, Resources: len(resources)})
+				return out.NextMarker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -520,6 +559,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Rds.DescribeDBInstancesPages(&rds.DescribeDBInstancesInput{},
 			func(out *rds.DescribeDBInstancesOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.DBInstances {
@@ -533,7 +573,9 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.Marker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "database", Pages: pages, Resources: len(resources)})
+				return out.Marker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -551,6 +593,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Rds.DescribeDBSubnetGroupsPages(&rds.DescribeDBSubnetGroupsInput{},
 			func(out *rds.DescribeDBSubnetGroupsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.DBSubnetGroups {
@@ -564,7 +607,77 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.Marker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "dbsubnetgroup", Pages: pages, Resources: len(resources)})
+				return out.Marker != nil && ctx.Err() == nil
+			})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		return resources, objects, badResErr
+	}
+
+	funcs["cachecluster"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*elasticache.CacheCluster
+
+		if !conf.getBoolDefaultTrue("aws.infra.cachecluster.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[cachecluster]")
+			return resources, objects, nil
+		}
+		var badResErr error
+		var pages int
+		err := conf.APIs.Elasticache.DescribeCacheClustersPages(&elasticache.DescribeCacheClustersInput{},
+			func(out *elasticache.DescribeCacheClustersOutput, lastPage bool) (shouldContinue bool) {
+				for _, output := range out.CacheClusters {
+					if badResErr != nil {
+						return false
+					}
+					objects = append(objects, output)
+					var res *graph.Resource
+					if res, badResErr = awsconv.NewResource(output); badResErr != nil {
+						return false
+					}
+					resources = append(resources, res)
+				}
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "cachecluster", Pages: pages, Resources: len(resources)})
+				return out.Marker != nil && ctx.Err() == nil
+			})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		return resources, objects, badResErr
+	}
+
+	funcs["replicationgroup"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*elasticache.ReplicationGroup
+
+		if !conf.getBoolDefaultTrue("aws.infra.replicationgroup.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[replicationgroup]")
+			return resources, objects, nil
+		}
+		var badResErr error
+		var pages int
+		err := conf.APIs.Elasticache.DescribeReplicationGroupsPages(&elasticache.DescribeReplicationGroupsInput{},
+			func(out *elasticache.DescribeReplicationGroupsOutput, lastPage bool) (shouldContinue bool) {
+				for _, output := range out.ReplicationGroups {
+					if badResErr != nil {
+						return false
+					}
+					objects = append(objects, output)
+					var res *graph.Resource
+					if res, badResErr = awsconv.NewResource(output); badResErr != nil {
+						return false
+					}
+					resources = append(resources, res)
+				}
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "replicationgroup", Pages: pages, Resources: len(resources)})
+				return out.Marker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -582,6 +695,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Autoscaling.DescribeLaunchConfigurationsPages(&autoscaling.DescribeLaunchConfigurationsInput{},
 			func(out *autoscaling.DescribeLaunchConfigurationsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.LaunchConfigurations {
@@ -595,7 +709,9 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "launchconfiguration", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -613,6 +729,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Autoscaling.DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{},
 			func(out *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.AutoScalingGroups {
@@ -626,7 +743,9 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "scalinggroup", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -644,6 +763,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Autoscaling.DescribePoliciesPages(&autoscaling.DescribePoliciesInput{},
 			func(out *autoscaling.DescribePoliciesOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.ScalingPolicies {
@@ -657,7 +777,9 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "scalingpolicy", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -675,6 +797,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Ecr.DescribeRepositoriesPages(&ecr.DescribeRepositoriesInput{},
 			func(out *ecr.DescribeRepositoriesOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.Repositories {
@@ -688,7 +811,9 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "repository", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -706,6 +831,7 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Acm.ListCertificatesPages(&acm.ListCertificatesInput{},
 			func(out *acm.ListCertificatesOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.CertificateSummaryList {
@@ -719,7 +845,43 @@ func BuildInfraFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "certificate", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
+			})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		return resources, objects, badResErr
+	}
+
+	funcs["alias"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*kms.AliasListEntry
+
+		if !conf.getBoolDefaultTrue("aws.infra.alias.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[alias]")
+			return resources, objects, nil
+		}
+		var badResErr error
+		var pages int
+		err := conf.APIs.Kms.ListAliasesPages(&kms.ListAliasesInput{},
+			func(out *kms.ListAliasesOutput, lastPage bool) (shouldContinue bool) {
+				for _, output := range out.Aliases {
+					if badResErr != nil {
+						return false
+					}
+					objects = append(objects, output)
+					var res *graph.Resource
+					if res, badResErr = awsconv.NewResource(output); badResErr != nil {
+						return false
+					}
+					resources = append(resources, res)
+				}
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "alias", Pages: pages, Resources: len(resources)})
+				return out.NextMarker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -743,6 +905,7 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Iam.GetAccountAuthorizationDetailsPages(&iam.GetAccountAuthorizationDetailsInput{Filter: []*string{awssdk.String(iam.EntityTypeGroup)}},
 			func(out *iam.GetAccountAuthorizationDetailsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.GroupDetailList {
@@ -756,7 +919,9 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.Marker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "group", Pages: pages, Resources: len(resources)})
+				return out.Marker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -774,6 +939,7 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Iam.GetAccountAuthorizationDetailsPages(&iam.GetAccountAuthorizationDetailsInput{Filter: []*string{awssdk.String(iam.EntityTypeRole)}},
 			func(out *iam.GetAccountAuthorizationDetailsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.RoleDetailList {
@@ -787,7 +953,9 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.Marker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "role", Pages: pages, Resources: len(resources)})
+				return out.Marker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -805,6 +973,7 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Iam.ListInstanceProfilesPages(&iam.ListInstanceProfilesInput{},
 			func(out *iam.ListInstanceProfilesOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.InstanceProfiles {
@@ -818,7 +987,9 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.Marker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "instanceprofile", Pages: pages, Resources: len(resources)})
+				return out.Marker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -836,6 +1007,7 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Iam.ListVirtualMFADevicesPages(&iam.ListVirtualMFADevicesInput{},
 			func(out *iam.ListVirtualMFADevicesOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.VirtualMFADevices {
@@ -849,7 +1021,9 @@ func BuildAccessFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.Marker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "mfadevice", Pages: pages, Resources: len(resources)})
+				return out.Marker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -879,6 +1053,7 @@ func BuildMessagingFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Sns.ListSubscriptionsPages(&sns.ListSubscriptionsInput{},
 			func(out *sns.ListSubscriptionsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.Subscriptions {
@@ -892,7 +1067,9 @@ func BuildMessagingFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "subscription", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -910,6 +1087,7 @@ func BuildMessagingFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Sns.ListTopicsPages(&sns.ListTopicsInput{},
 			func(out *sns.ListTopicsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.Topics {
@@ -923,7 +1101,9 @@ func BuildMessagingFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "topic", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -947,6 +1127,7 @@ func BuildDnsFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Route53.ListHostedZonesPages(&route53.ListHostedZonesInput{},
 			func(out *route53.ListHostedZonesOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.HostedZones {
@@ -960,7 +1141,43 @@ func BuildDnsFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextMarker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "zone", Pages: pages, Resources: len(resources)})
+				return out.NextMarker != nil && ctx.Err() == nil
+			})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		return resources, objects, badResErr
+	}
+
+	funcs["healthcheck"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*route53.HealthCheck
+
+		if !conf.getBoolDefaultTrue("aws.dns.healthcheck.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource dns[healthcheck]")
+			return resources, objects, nil
+		}
+		var badResErr error
+		var pages int
+		err := conf.APIs.Route53.ListHealthChecksPages(&route53.ListHealthChecksInput{},
+			func(out *route53.ListHealthChecksOutput, lastPage bool) (shouldContinue bool) {
+				for _, output := range out.HealthChecks {
+					if badResErr != nil {
+						return false
+					}
+					objects = append(objects, output)
+					var res *graph.Resource
+					if res, badResErr = awsconv.NewResource(output); badResErr != nil {
+						return false
+					}
+					resources = append(resources, res)
+				}
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "healthcheck", Pages: pages, Resources: len(resources)})
+				return out.NextMarker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -984,6 +1201,7 @@ func BuildLambdaFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Lambda.ListFunctionsPages(&lambda.ListFunctionsInput{},
 			func(out *lambda.ListFunctionsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.Functions {
@@ -997,7 +1215,43 @@ func BuildLambdaFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextMarker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "function", Pages: pages, Resources: len(resources)})
+				return out.NextMarker != nil && ctx.Err() == nil
+			})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		return resources, objects, badResErr
+	}
+
+	funcs["statemachine"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*sfn.StateMachineListItem
+
+		if !conf.getBoolDefaultTrue("aws.lambda.statemachine.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource lambda[statemachine]")
+			return resources, objects, nil
+		}
+		var badResErr error
+		var pages int
+		err := conf.APIs.Sfn.ListStateMachinesPages(&sfn.ListStateMachinesInput{},
+			func(out *sfn.ListStateMachinesOutput, lastPage bool) (shouldContinue bool) {
+				for _, output := range out.StateMachines {
+					if badResErr != nil {
+						return false
+					}
+					objects = append(objects, output)
+					var res *graph.Resource
+					if res, badResErr = awsconv.NewResource(output); badResErr != nil {
+						return false
+					}
+					resources = append(resources, res)
+				}
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "statemachine", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -1021,6 +1275,7 @@ func BuildMonitoringFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Cloudwatch.ListMetricsPages(&cloudwatch.ListMetricsInput{},
 			func(out *cloudwatch.ListMetricsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.Metrics {
@@ -1034,7 +1289,9 @@ func BuildMonitoringFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "metric", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -1052,6 +1309,7 @@ func BuildMonitoringFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Cloudwatch.DescribeAlarmsPages(&cloudwatch.DescribeAlarmsInput{},
 			func(out *cloudwatch.DescribeAlarmsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.MetricAlarms {
@@ -1065,7 +1323,9 @@ func BuildMonitoringFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "alarm", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -1089,6 +1349,7 @@ func BuildCdnFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Cloudfront.ListDistributionsPages(&cloudfront.ListDistributionsInput{},
 			func(out *cloudfront.ListDistributionsOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.DistributionList.Items {
@@ -1102,7 +1363,43 @@ func BuildCdnFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.DistributionList.NextMarker != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "distribution", Pages: pages, Resources: len(resources)})
+				return out.DistributionList.NextMarker != nil && ctx.Err() == nil
+			})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		return resources, objects, badResErr
+	}
+
+	funcs["originaccessidentity"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*cloudfront.OriginAccessIdentitySummary
+
+		if !conf.getBoolDefaultTrue("aws.cdn.originaccessidentity.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource cdn[originaccessidentity]")
+			return resources, objects, nil
+		}
+		var badResErr error
+		var pages int
+		err := conf.APIs.Cloudfront.ListCloudFrontOriginAccessIdentitiesPages(&cloudfront.ListCloudFrontOriginAccessIdentitiesInput{},
+			func(out *cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, lastPage bool) (shouldContinue bool) {
+				for _, output := range out.CloudFrontOriginAccessIdentityList.Items {
+					if badResErr != nil {
+						return false
+					}
+					objects = append(objects, output)
+					var res *graph.Resource
+					if res, badResErr = awsconv.NewResource(output); badResErr != nil {
+						return false
+					}
+					resources = append(resources, res)
+				}
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "originaccessidentity", Pages: pages, Resources: len(resources)})
+				return out.CloudFrontOriginAccessIdentityList.NextMarker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -1126,6 +1423,7 @@ func BuildCloudformationFetchFuncs(conf *Config) fetch.Funcs {
 			return resources, objects, nil
 		}
 		var badResErr error
+		var pages int
 		err := conf.APIs.Cloudformation.DescribeStacksPages(&cloudformation.DescribeStacksInput{},
 			func(out *cloudformation.DescribeStacksOutput, lastPage bool) (shouldContinue bool) {
 				for _, output := range out.Stacks {
@@ -1139,7 +1437,9 @@ func BuildCloudformationFetchFuncs(conf *Config) fetch.Funcs {
 					}
 					resources = append(resources, res)
 				}
-				return out.NextToken != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "stack", Pages: pages, Resources: len(resources)})
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -1149,3 +1449,95 @@ func BuildCloudformationFetchFuncs(conf *Config) fetch.Funcs {
 	}
 	return funcs
 }
+
+var fetchActionPerResourceType = map[string]string{
+	"subnet":               "ec2:DescribeSubnets",
+	"vpc":                  "ec2:DescribeVpcs",
+	"keypair":              "ec2:DescribeKeyPairs",
+	"securitygroup":        "ec2:DescribeSecurityGroups",
+	"volume":               "ec2:DescribeVolumes",
+	"internetgateway":      "ec2:DescribeInternetGateways",
+	"natgateway":           "ec2:DescribeNatGateways",
+	"routetable":           "ec2:DescribeRouteTables",
+	"availabilityzone":     "ec2:DescribeAvailabilityZones",
+	"image":                "ec2:DescribeImages",
+	"importimagetask":      "ec2:DescribeImportImageTasks",
+	"elasticip":            "ec2:DescribeAddresses",
+	"snapshot":             "ec2:DescribeSnapshots",
+	"networkinterface":     "ec2:DescribeNetworkInterfaces",
+	"spotfleet":            "ec2:DescribeSpotFleetRequests",
+	"spotrequest":          "ec2:DescribeSpotInstanceRequests",
+	"loadbalancer":         "elasticloadbalancing:DescribeLoadBalancers",
+	"targetgroup":          "elasticloadbalancing:DescribeTargetGroups",
+	"database":             "rds:DescribeDBInstances",
+	"dbsubnetgroup":        "rds:DescribeDBSubnetGroups",
+	"cachecluster":         "elasticache:DescribeCacheClusters",
+	"replicationgroup":     "elasticache:DescribeReplicationGroups",
+	"launchconfiguration":  "autoscaling:DescribeLaunchConfigurations",
+	"scalinggroup":         "autoscaling:DescribeAutoScalingGroups",
+	"scalingpolicy":        "autoscaling:DescribePolicies",
+	"repository":           "ecr:DescribeRepositories",
+	"certificate":          "acm:ListCertificates",
+	"alias":                "kms:ListAliases",
+	"group":                "iam:GetAccountAuthorizationDetails",
+	"role":                 "iam:GetAccountAuthorizationDetails",
+	"instanceprofile":      "iam:ListInstanceProfiles",
+	"mfadevice":            "iam:ListVirtualMFADevices",
+	"subscription":         "sns:ListSubscriptions",
+	"topic":                "sns:ListTopics",
+	"zone":                 "route53:ListHostedZones",
+	"healthcheck":          "route53:ListHealthChecks",
+	"function":             "lambda:ListFunctions",
+	"statemachine":         "states:ListStateMachines",
+	"metric":               "cloudwatch:ListMetrics",
+	"alarm":                "cloudwatch:DescribeAlarms",
+	"distribution":         "cloudfront:ListDistributions",
+	"originaccessidentity": "cloudfront:ListCloudFrontOriginAccessIdentities",
+	"stack":                "cloudformation:DescribeStacks",
+}
+
+var generatedCacheTypes = []interface{}{
+	[]*ec2.Subnet{},
+	[]*ec2.Vpc{},
+	[]*ec2.KeyPairInfo{},
+	[]*ec2.SecurityGroup{},
+	[]*ec2.Volume{},
+	[]*ec2.InternetGateway{},
+	[]*ec2.NatGateway{},
+	[]*ec2.RouteTable{},
+	[]*ec2.AvailabilityZone{},
+	[]*ec2.Image{},
+	[]*ec2.ImportImageTask{},
+	[]*ec2.Address{},
+	[]*ec2.Snapshot{},
+	[]*ec2.NetworkInterface{},
+	[]*ec2.SpotFleetRequestConfig{},
+	[]*ec2.SpotInstanceRequest{},
+	[]*elbv2.LoadBalancer{},
+	[]*elbv2.TargetGroup{},
+	[]*rds.DBInstance{},
+	[]*rds.DBSubnetGroup{},
+	[]*elasticache.CacheCluster{},
+	[]*elasticache.ReplicationGroup{},
+	[]*autoscaling.LaunchConfiguration{},
+	[]*autoscaling.Group{},
+	[]*autoscaling.ScalingPolicy{},
+	[]*ecr.Repository{},
+	[]*acm.CertificateSummary{},
+	[]*kms.AliasListEntry{},
+	[]*iam.GroupDetail{},
+	[]*iam.RoleDetail{},
+	[]*iam.InstanceProfile{},
+	[]*iam.VirtualMFADevice{},
+	[]*sns.Subscription{},
+	[]*sns.Topic{},
+	[]*route53.HostedZone{},
+	[]*route53.HealthCheck{},
+	[]*lambda.FunctionConfiguration{},
+	[]*sfn.StateMachineListItem{},
+	[]*cloudwatch.Metric{},
+	[]*cloudwatch.MetricAlarm{},
+	[]*cloudfront.DistributionSummary{},
+	[]*cloudfront.OriginAccessIdentitySummary{},
+	[]*cloudformation.Stack{},
+}
@@ -0,0 +1,38 @@
+package awsfetch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+)
+
+func getAllKeys(ctx context.Context, api kmsiface.KMSAPI) (res []*kms.KeyMetadata, err error) {
+	var ids []*string
+	err = api.ListKeysPages(&kms.ListKeysInput{}, func(out *kms.ListKeysOutput, lastPage bool) (shouldContinue bool) {
+		for _, key := range out.Keys {
+			ids = append(ids, key.KeyId)
+		}
+		return out.NextMarker != nil && ctx.Err() == nil
+	})
+	if err != nil {
+		return
+	}
+
+	for _, id := range ids {
+		var out *kms.DescribeKeyOutput
+		if out, err = api.DescribeKey(&kms.DescribeKeyInput{KeyId: id}); err != nil {
+			return
+		}
+		res = append(res, out.KeyMetadata)
+	}
+	return
+}
+
+func listKeyGrants(ctx context.Context, api kmsiface.KMSAPI, keyId *string) (res []*kms.GrantListEntry, err error) {
+	err = api.ListGrantsPages(&kms.ListGrantsInput{KeyId: keyId}, func(out *kms.ListGrantsResponse, lastPage bool) (shouldContinue bool) {
+		res = append(res, out.Grants...)
+		return out.NextMarker != nil && ctx.Err() == nil
+	})
+	return
+}
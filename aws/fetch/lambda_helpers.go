@@ -0,0 +1,23 @@
+package awsfetch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+func listFunctionAliases(ctx context.Context, api lambdaiface.LambdaAPI, functionArn *string) (res []*lambda.AliasConfiguration, err error) {
+	input := &lambda.ListAliasesInput{FunctionName: functionArn}
+	for {
+		var out *lambda.ListAliasesOutput
+		if out, err = api.ListAliasesWithContext(ctx, input); err != nil {
+			return
+		}
+		res = append(res, out.Aliases...)
+		if out.NextMarker == nil || ctx.Err() != nil {
+			return
+		}
+		input.Marker = out.NextMarker
+	}
+}
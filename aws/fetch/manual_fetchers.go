@@ -10,11 +10,17 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sfn"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/wallix/awless/aws/conv"
 	"github.com/wallix/awless/cloud"
@@ -25,6 +31,56 @@ import (
 )
 
 func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
+	funcs["instance"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*ec2.Instance
+
+		if !conf.getBoolDefaultTrue("aws.infra.instance.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[instance]")
+			return resources, objects, nil
+		}
+
+		metadataTokens := newInstancesMetadataTokens()
+
+		var badResErr error
+		var pages int
+		pageFn := func(out *ec2.DescribeInstancesOutput, lastPage bool) (shouldContinue bool) {
+			for _, all := range out.Reservations {
+				for _, output := range all.Instances {
+					if badResErr != nil {
+						return false
+					}
+					objects = append(objects, output)
+					var res *graph.Resource
+					if res, badResErr = awsconv.NewResource(output); badResErr != nil {
+						return false
+					}
+					if tokens, ok := metadataTokens.get(awssdk.StringValue(output.InstanceId)); ok {
+						res.Properties()[properties.MetadataTokens] = tokens
+					}
+					resources = append(resources, res)
+				}
+			}
+			pages++
+			fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "instance", Pages: pages, Resources: len(resources)})
+			return out.NextToken != nil && ctx.Err() == nil
+		}
+
+		input := &ec2.DescribeInstancesInput{Filters: ec2FiltersFromContext(ctx, cloud.Instance)}
+
+		var err error
+		if client, ok := conf.APIs.Ec2.(*ec2.EC2); ok {
+			err = client.DescribeInstancesPagesWithContext(ctx, input, pageFn, metadataTokens.captureOption())
+		} else {
+			err = conf.APIs.Ec2.DescribeInstancesPages(input, pageFn)
+		}
+		if err != nil {
+			return resources, objects, err
+		}
+
+		return resources, objects, badResErr
+	}
+
 	funcs["containerinstance"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
 		var objects []*ecs.ContainerInstance
 		var resources []*graph.Resource
@@ -49,7 +105,7 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 			err := conf.APIs.Ecs.ListContainerInstancesPages(&ecs.ListContainerInstancesInput{Cluster: cluster}, func(out *ecs.ListContainerInstancesOutput, lastPage bool) (shouldContinue bool) {
 				var containerInstancesOut *ecs.DescribeContainerInstancesOutput
 				if len(out.ContainerInstanceArns) == 0 {
-					return out.NextToken != nil
+					return out.NextToken != nil && ctx.Err() == nil
 				}
 
 				if containerInstancesOut, badResErr = conf.APIs.Ecs.DescribeContainerInstances(&ecs.DescribeContainerInstancesInput{Cluster: cluster, ContainerInstances: out.ContainerInstanceArns}); badResErr != nil {
@@ -67,7 +123,7 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 					parent := graph.InitResource(cloud.ContainerCluster, awssdk.StringValue(cluster))
 					res.AddRelation(rdf.ChildrenOfRel, parent)
 				}
-				return out.NextToken != nil
+				return out.NextToken != nil && ctx.Err() == nil
 			})
 			if err != nil {
 				return resources, objects, err
@@ -168,7 +224,7 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 					resc <- resStruct{res: tasksOut.TaskDefinition}
 				}(arn)
 			}
-			return out.NextToken != nil
+			return out.NextToken != nil && ctx.Err() == nil
 		})
 		if err != nil {
 			return resources, objects, err
@@ -306,6 +362,190 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 		return resources, objects, nil
 	}
 
+	funcs["containerservice"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*ecs.Service
+
+		if !conf.getBoolDefaultTrue("aws.infra.containerservice.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[containerservice]")
+			return resources, objects, nil
+		}
+
+		var clusterArns []*string
+
+		if val, e := cache.Get("getClustersNames", func() (interface{}, error) {
+			return getClustersNames(ctx, conf.APIs.Ecs)
+		}); e != nil {
+			return resources, objects, e
+		} else if v, ok := val.([]*string); ok {
+			clusterArns = v
+		}
+
+		for _, cluster := range clusterArns {
+			var serviceArns []*string
+			err := conf.APIs.Ecs.ListServicesPages(&ecs.ListServicesInput{Cluster: cluster}, func(out *ecs.ListServicesOutput, lastPage bool) (shouldContinue bool) {
+				serviceArns = append(serviceArns, out.ServiceArns...)
+				return out.NextToken != nil && ctx.Err() == nil
+			})
+			if err != nil {
+				return resources, objects, err
+			}
+
+			for _, arns := range sliceOfSlice(serviceArns, 10) {
+				servicesOut, err := conf.APIs.Ecs.DescribeServices(&ecs.DescribeServicesInput{Cluster: cluster, Services: arns})
+				if err != nil {
+					return resources, objects, err
+				}
+				for _, service := range servicesOut.Services {
+					objects = append(objects, service)
+					res, err := awsconv.NewResource(service)
+					if err != nil {
+						return resources, objects, err
+					}
+					if net := service.NetworkConfiguration; net != nil && net.AwsvpcConfiguration != nil {
+						if subnets := awssdk.StringValueSlice(net.AwsvpcConfiguration.Subnets); len(subnets) > 0 {
+							res.Properties()[properties.Subnets] = subnets
+						}
+						if groups := awssdk.StringValueSlice(net.AwsvpcConfiguration.SecurityGroups); len(groups) > 0 {
+							res.Properties()[properties.SecurityGroups] = groups
+						}
+					}
+					res.AddRelation(rdf.ChildrenOfRel, graph.InitResource(cloud.ContainerCluster, awssdk.StringValue(service.ClusterArn)))
+					res.AddRelation(rdf.DependingOnRel, graph.InitResource(cloud.ContainerTask, awssdk.StringValue(service.TaskDefinition)))
+					for _, lb := range service.LoadBalancers {
+						if lb.TargetGroupArn != nil {
+							res.AddRelation(rdf.DependingOnRel, graph.InitResource(cloud.TargetGroup, awssdk.StringValue(lb.TargetGroupArn)))
+						}
+					}
+					resources = append(resources, res)
+				}
+			}
+		}
+		return resources, objects, nil
+	}
+
+	funcs["table"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*dynamodb.TableDescription
+
+		if !conf.getBoolDefaultTrue("aws.infra.table.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[table]")
+			return resources, objects, nil
+		}
+
+		if val, e := cache.Get("getAllTables", func() (interface{}, error) {
+			return getAllTables(ctx, conf.APIs.Dynamodb)
+		}); e != nil {
+			return resources, objects, e
+		} else if v, ok := val.([]*dynamodb.TableDescription); ok {
+			objects = v
+		}
+
+		for _, table := range objects {
+			res, err := awsconv.NewResource(table)
+			if err != nil {
+				return resources, objects, err
+			}
+			resources = append(resources, res)
+		}
+		return resources, objects, nil
+	}
+
+	funcs["tableindex"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*dynamodb.GlobalSecondaryIndexDescription
+
+		if !conf.getBoolDefaultTrue("aws.infra.tableindex.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[tableindex]")
+			return resources, objects, nil
+		}
+
+		var tables []*dynamodb.TableDescription
+		if val, e := cache.Get("getAllTables", func() (interface{}, error) {
+			return getAllTables(ctx, conf.APIs.Dynamodb)
+		}); e != nil {
+			return resources, objects, e
+		} else if v, ok := val.([]*dynamodb.TableDescription); ok {
+			tables = v
+		}
+
+		for _, table := range tables {
+			for _, index := range table.GlobalSecondaryIndexes {
+				objects = append(objects, index)
+				res, err := awsconv.NewResource(index)
+				if err != nil {
+					return resources, objects, err
+				}
+				res.AddRelation(rdf.ChildrenOfRel, graph.InitResource(cloud.Table, awssdk.StringValue(table.TableName)))
+				resources = append(resources, res)
+			}
+		}
+		return resources, objects, nil
+	}
+
+	funcs["key"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*kms.KeyMetadata
+
+		if !conf.getBoolDefaultTrue("aws.infra.key.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[key]")
+			return resources, objects, nil
+		}
+
+		if val, e := cache.Get("getAllKeys", func() (interface{}, error) {
+			return getAllKeys(ctx, conf.APIs.Kms)
+		}); e != nil {
+			return resources, objects, e
+		} else if v, ok := val.([]*kms.KeyMetadata); ok {
+			objects = v
+		}
+
+		for _, key := range objects {
+			res, err := awsconv.NewResource(key)
+			if err != nil {
+				return resources, objects, err
+			}
+			resources = append(resources, res)
+		}
+		return resources, objects, nil
+	}
+
+	funcs["keygrant"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*kms.GrantListEntry
+
+		if !conf.getBoolDefaultTrue("aws.infra.keygrant.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource infra[keygrant]")
+			return resources, objects, nil
+		}
+
+		var keys []*kms.KeyMetadata
+		if val, e := cache.Get("getAllKeys", func() (interface{}, error) {
+			return getAllKeys(ctx, conf.APIs.Kms)
+		}); e != nil {
+			return resources, objects, e
+		} else if v, ok := val.([]*kms.KeyMetadata); ok {
+			keys = v
+		}
+
+		for _, key := range keys {
+			grants, err := listKeyGrants(ctx, conf.APIs.Kms, key.KeyId)
+			if err != nil {
+				return resources, objects, err
+			}
+			for _, grant := range grants {
+				objects = append(objects, grant)
+				res, err := awsconv.NewResource(grant)
+				if err != nil {
+					return resources, objects, err
+				}
+				res.AddRelation(rdf.ChildrenOfRel, graph.InitResource(cloud.Key, awssdk.StringValue(key.KeyId)))
+				resources = append(resources, res)
+			}
+		}
+		return resources, objects, nil
+	}
+
 	funcs["listener"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
 		var objects []*elbv2.Listener
 		var resources []*graph.Resource
@@ -330,14 +570,14 @@ func addManualInfraFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 								for _, listen := range out.Listeners {
 									resultc <- listen
 								}
-								return out.NextMarker != nil
+								return out.NextMarker != nil && ctx.Err() == nil
 							})
 						if err != nil {
 							errc <- err
 						}
 					}(lb)
 				}
-				return out.NextMarker != nil
+				return out.NextMarker != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -401,7 +641,7 @@ func addManualAccessFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 					}
 					resourcesC <- res
 				}
-				return out.Marker != nil
+				return out.Marker != nil && ctx.Err() == nil
 			})
 			if err != nil {
 				errC <- err
@@ -419,7 +659,22 @@ func addManualAccessFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 						errC <- e
 						return false
 					}
-					resourcesC <- res
+					if user.PasswordLastUsed == nil {
+						resourcesC <- res
+						continue
+					}
+					// all kinds of MFA devices (virtual, hardware, U2F) of the users signing in with a password
+					wg.Add(1)
+					go func(user *iam.User, res *graph.Resource) {
+						defer wg.Done()
+						out, err := conf.APIs.Iam.ListMFADevices(&iam.ListMFADevicesInput{UserName: user.UserName})
+						if err != nil {
+							errC <- err
+							return
+						}
+						res.Properties()[properties.MFAActive] = len(out.MFADevices) > 0
+						resourcesC <- res
+					}(user, res)
 				}
 				return page.Marker != nil
 			})
@@ -492,7 +747,7 @@ func addManualAccessFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 						res.Properties()[properties.Attached] = awssdk.Int64Value(p.AttachmentCount) > 0
 						resourcesC <- res
 					}
-					return out.Marker != nil
+					return out.Marker != nil && ctx.Err() == nil
 				})
 			if err != nil {
 				errC <- err
@@ -565,9 +820,15 @@ func addManualAccessFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 									return false
 								}
 								res.AddRelation(rdf.ChildrenOfRel, userRes)
+								lastUsed, e := conf.APIs.Iam.GetAccessKeyLastUsed(&iam.GetAccessKeyLastUsedInput{AccessKeyId: output.AccessKeyId})
+								if e != nil {
+									conf.Log.Verbosef("sync: cannot get last use of access key %s: %s", awssdk.StringValue(output.AccessKeyId), e)
+								} else if lastUsed.AccessKeyLastUsed != nil && lastUsed.AccessKeyLastUsed.LastUsedDate != nil {
+									res.Properties()[properties.LastUsed] = awssdk.TimeValue(lastUsed.AccessKeyLastUsed.LastUsedDate)
+								}
 								resourcesC <- res
 							}
-							return out.Marker != nil
+							return out.Marker != nil && ctx.Err() == nil
 						})
 					if err != nil {
 						hasError = true
@@ -798,7 +1059,7 @@ func addManualDnsFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 					for _, output := range out.HostedZones {
 						zoneC <- output
 					}
-					return out.NextMarker != nil
+					return out.NextMarker != nil && ctx.Err() == nil
 				})
 			if err != nil {
 				errC <- err
@@ -828,7 +1089,7 @@ func addManualDnsFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 								res.AddRelation(rdf.ChildrenOfRel, parent)
 								resourcesC <- res
 							}
-							return out.NextRecordName != nil
+							return out.NextRecordName != nil && ctx.Err() == nil
 						})
 					if err != nil {
 						errC <- err
@@ -864,6 +1125,217 @@ func addManualDnsFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 	}
 }
 func addManualLambdaFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
+	funcs["functionalias"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*lambda.AliasConfiguration
+
+		if !conf.getBoolDefaultTrue("aws.lambda.functionalias.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource lambda[functionalias]")
+			return resources, objects, nil
+		}
+
+		var functionArns []*string
+		err := conf.APIs.Lambda.ListFunctionsPages(&lambda.ListFunctionsInput{}, func(out *lambda.ListFunctionsOutput, lastPage bool) (shouldContinue bool) {
+			for _, fn := range out.Functions {
+				functionArns = append(functionArns, fn.FunctionArn)
+			}
+			return out.NextMarker != nil && ctx.Err() == nil
+		})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		for _, arn := range functionArns {
+			aliases, err := listFunctionAliases(ctx, conf.APIs.Lambda, arn)
+			if err != nil {
+				return resources, objects, err
+			}
+			for _, alias := range aliases {
+				objects = append(objects, alias)
+				res, err := awsconv.NewResource(alias)
+				if err != nil {
+					return resources, objects, err
+				}
+				res.AddRelation(rdf.ChildrenOfRel, graph.InitResource(cloud.Function, awssdk.StringValue(arn)))
+				resources = append(resources, res)
+			}
+		}
+		return resources, objects, nil
+	}
+
+	funcs["eventsourcemapping"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*lambda.EventSourceMappingConfiguration
+
+		if !conf.getBoolDefaultTrue("aws.lambda.eventsourcemapping.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource lambda[eventsourcemapping]")
+			return resources, objects, nil
+		}
+
+		var badResErr error
+		err := conf.APIs.Lambda.ListEventSourceMappingsPages(&lambda.ListEventSourceMappingsInput{}, func(out *lambda.ListEventSourceMappingsOutput, lastPage bool) (shouldContinue bool) {
+			for _, mapping := range out.EventSourceMappings {
+				objects = append(objects, mapping)
+				var res *graph.Resource
+				if res, badResErr = awsconv.NewResource(mapping); badResErr != nil {
+					return false
+				}
+				resources = append(resources, res)
+			}
+			return out.NextMarker != nil && ctx.Err() == nil
+		})
+		if err != nil {
+			return resources, objects, err
+		}
+		return resources, objects, badResErr
+	}
+
+	funcs["execution"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*sfn.ExecutionListItem
+
+		if !conf.getBoolDefaultTrue("aws.lambda.execution.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource lambda[execution]")
+			return resources, objects, nil
+		}
+
+		var stateMachineArns []*string
+		err := conf.APIs.Sfn.ListStateMachinesPages(&sfn.ListStateMachinesInput{}, func(out *sfn.ListStateMachinesOutput, lastPage bool) (shouldContinue bool) {
+			for _, machine := range out.StateMachines {
+				stateMachineArns = append(stateMachineArns, machine.StateMachineArn)
+			}
+			return out.NextToken != nil && ctx.Err() == nil
+		})
+		if err != nil {
+			return resources, objects, err
+		}
+
+		for _, arn := range stateMachineArns {
+			executions, err := listStateMachineExecutions(ctx, conf.APIs.Sfn, arn)
+			if err != nil {
+				return resources, objects, err
+			}
+			for _, execution := range executions {
+				objects = append(objects, execution)
+				res, err := awsconv.NewResource(execution)
+				if err != nil {
+					return resources, objects, err
+				}
+				res.AddRelation(rdf.ChildrenOfRel, graph.InitResource(cloud.StateMachine, awssdk.StringValue(arn)))
+				resources = append(resources, res)
+			}
+		}
+		return resources, objects, nil
+	}
+
+	funcs["restapi"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*apigateway.RestApi
+
+		if !conf.getBoolDefaultTrue("aws.lambda.restapi.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource lambda[restapi]")
+			return resources, objects, nil
+		}
+
+		if val, e := cache.Get("getAllRestApis", func() (interface{}, error) {
+			return getAllRestApis(ctx, conf.APIs.Apigateway)
+		}); e != nil {
+			return resources, objects, e
+		} else if v, ok := val.([]*apigateway.RestApi); ok {
+			objects = v
+		}
+
+		for _, restApi := range objects {
+			res, err := awsconv.NewResource(restApi)
+			if err != nil {
+				return resources, objects, err
+			}
+			functions, err := listRestApiFunctions(ctx, conf.APIs.Apigateway, restApi.Id)
+			if err != nil {
+				return resources, objects, err
+			}
+			for _, arn := range functions {
+				res.AddRelation(rdf.DependingOnRel, graph.InitResource(cloud.Function, arn))
+			}
+			resources = append(resources, res)
+		}
+		return resources, objects, nil
+	}
+
+	funcs["apistage"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*apigateway.Stage
+
+		if !conf.getBoolDefaultTrue("aws.lambda.apistage.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource lambda[apistage]")
+			return resources, objects, nil
+		}
+
+		var restApis []*apigateway.RestApi
+		if val, e := cache.Get("getAllRestApis", func() (interface{}, error) {
+			return getAllRestApis(ctx, conf.APIs.Apigateway)
+		}); e != nil {
+			return resources, objects, e
+		} else if v, ok := val.([]*apigateway.RestApi); ok {
+			restApis = v
+		}
+
+		for _, restApi := range restApis {
+			out, err := conf.APIs.Apigateway.GetStagesWithContext(ctx, &apigateway.GetStagesInput{RestApiId: restApi.Id})
+			if err != nil {
+				return resources, objects, err
+			}
+			for _, stage := range out.Item {
+				objects = append(objects, stage)
+				res, err := awsconv.NewResource(stage)
+				if err != nil {
+					return resources, objects, err
+				}
+				res.AddRelation(rdf.ChildrenOfRel, graph.InitResource(cloud.RestApi, awssdk.StringValue(restApi.Id)))
+				if stage.DeploymentId != nil {
+					res.AddRelation(rdf.DependingOnRel, graph.InitResource(cloud.ApiDeployment, awssdk.StringValue(stage.DeploymentId)))
+				}
+				resources = append(resources, res)
+			}
+		}
+		return resources, objects, nil
+	}
+
+	funcs["apideployment"] = func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		var resources []*graph.Resource
+		var objects []*apigateway.Deployment
+
+		if !conf.getBoolDefaultTrue("aws.lambda.apideployment.sync") && !getBoolFromContext(ctx, "force") {
+			conf.Log.Verbose("sync: *disabled* for resource lambda[apideployment]")
+			return resources, objects, nil
+		}
+
+		var restApis []*apigateway.RestApi
+		if val, e := cache.Get("getAllRestApis", func() (interface{}, error) {
+			return getAllRestApis(ctx, conf.APIs.Apigateway)
+		}); e != nil {
+			return resources, objects, e
+		} else if v, ok := val.([]*apigateway.RestApi); ok {
+			restApis = v
+		}
+
+		for _, restApi := range restApis {
+			deployments, err := listRestApiDeployments(ctx, conf.APIs.Apigateway, restApi.Id)
+			if err != nil {
+				return resources, objects, err
+			}
+			for _, deployment := range deployments {
+				objects = append(objects, deployment)
+				res, err := awsconv.NewResource(deployment)
+				if err != nil {
+					return resources, objects, err
+				}
+				res.AddRelation(rdf.ChildrenOfRel, graph.InitResource(cloud.RestApi, awssdk.StringValue(restApi.Id)))
+				resources = append(resources, res)
+			}
+		}
+		return resources, objects, nil
+	}
 }
 func addManualMonitoringFetchFuncs(conf *Config, funcs map[string]fetch.Func) {
 }
@@ -0,0 +1,23 @@
+package awsfetch
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
+)
+
+func listStateMachineExecutions(ctx context.Context, api sfniface.SFNAPI, stateMachineArn *string) (res []*sfn.ExecutionListItem, err error) {
+	input := &sfn.ListExecutionsInput{StateMachineArn: stateMachineArn}
+	for {
+		var out *sfn.ListExecutionsOutput
+		if out, err = api.ListExecutionsWithContext(ctx, input); err != nil {
+			return
+		}
+		res = append(res, out.Executions...)
+		if out.NextToken == nil || ctx.Err() != nil {
+			return
+		}
+		input.NextToken = out.NextToken
+	}
+}
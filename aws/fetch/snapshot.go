@@ -0,0 +1,73 @@
+package awsfetch
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/wallix/awless/aws/conv"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+func init() {
+	fetch.RegisterCacheTypes(generatedCacheTypes...)
+	fetch.RegisterCacheTypes(
+		[]*string{},
+		[]*apigateway.Deployment{},
+		[]*apigateway.RestApi{},
+		[]*apigateway.Stage{},
+		[]*dynamodb.GlobalSecondaryIndexDescription{},
+		[]*dynamodb.TableDescription{},
+		[]*ec2.Instance{},
+		[]*ecs.Cluster{},
+		[]*ecs.Container{},
+		[]*ecs.ContainerInstance{},
+		[]*ecs.Service{},
+		[]*ecs.Task{},
+		[]*ecs.TaskDefinition{},
+		[]*elbv2.Listener{},
+		[]*iam.AccessKeyMetadata{},
+		[]*iam.Policy{},
+		[]*iam.UserDetail{},
+		[]*kms.GrantListEntry{},
+		[]*kms.KeyMetadata{},
+		[]*lambda.AliasConfiguration{},
+		[]*lambda.EventSourceMappingConfiguration{},
+		[]*route53.ResourceRecordSet{},
+		[]*s3.Bucket{},
+		[]*s3.Object{},
+		[]*sfn.ExecutionListItem{},
+	)
+}
+
+// ReplayMiddleware builds the resources from the AWS objects recorded in the snapshot
+// (see fetch.Snapshotter) instead of calling the AWS APIs
+func ReplayMiddleware(snapshot fetch.Cache) fetch.Middleware {
+	return fetch.ReplayMiddleware(snapshot, objectsToResources)
+}
+
+func objectsToResources(resourceType string, objects interface{}) (resources []*graph.Resource, err error) {
+	v := reflect.ValueOf(objects)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("replay %s: unexpected objects of type %T", resourceType, objects)
+	}
+	for i := 0; i < v.Len(); i++ {
+		res, err := awsconv.NewResource(v.Index(i).Interface())
+		if err != nil {
+			return resources, fmt.Errorf("replay %s: %s", resourceType, err)
+		}
+		resources = append(resources, res)
+	}
+	return
+}
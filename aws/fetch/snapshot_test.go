@@ -0,0 +1,49 @@
+package awsfetch
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+func TestReplayMiddleware(t *testing.T) {
+	recorder := fetch.NewFetcher(fetch.Funcs{"subnet": func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		objects := []*ec2.Subnet{
+			{SubnetId: awssdk.String("sub_1"), VpcId: awssdk.String("vpc_1"), CidrBlock: awssdk.String("10.0.0.0/24")},
+		}
+		return nil, objects, nil
+	}})
+	if _, err := recorder.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var buff bytes.Buffer
+	if err := recorder.Snapshot(&buff); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := fetch.LoadSnapshot(&buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := fetch.NewFetcher(fetch.Funcs{"subnet": func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		t.Fatal("unexpected fetch")
+		return nil, nil, nil
+	}}, ReplayMiddleware(snapshot))
+	g, err := f.FetchByType(context.Background(), "subnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := g.GetResource("subnet", "sub_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.Properties()[properties.CIDR], "10.0.0.0/24"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awspool
+
+import (
+	"errors"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// Default is the pool shared by the AWS services and commands
+var Default = New(nil)
+
+// Pool holds the AWS sessions per (profile, region) and the API clients per (profile, region, service).
+// Sessions and clients are created lazily on first use, then reused across fetchers and drivers.
+// The sessions of a profile (or of a role assumed from a profile) share the same credentials
+// whatever the region: credentials are retrieved and refreshed once for all regions.
+type Pool struct {
+	resolve func(profile string) (*session.Session, error)
+
+	mu       sync.Mutex
+	sessions map[sessionKey]*sessionEntry
+	resolved map[sessionKey]*session.Session
+	clients  map[clientKey]*clientEntry
+}
+
+type sessionKey struct {
+	profile, region, role string
+}
+
+type sessionEntry struct {
+	once sync.Once
+	sess *session.Session
+	err  error
+}
+
+type clientKey struct {
+	sess    *session.Session
+	service string
+}
+
+type clientEntry struct {
+	once   sync.Once
+	client interface{}
+}
+
+// New returns a pool resolving the session of a profile with the given func.
+// The region of the resolved sessions is the default one of the pool.
+func New(resolve func(profile string) (*session.Session, error)) *Pool {
+	if resolve == nil {
+		resolve = func(string) (*session.Session, error) {
+			return nil, errors.New("awspool: no session resolver")
+		}
+	}
+	return &Pool{
+		resolve:  resolve,
+		sessions: make(map[sessionKey]*sessionEntry),
+		resolved: make(map[sessionKey]*session.Session),
+		clients:  make(map[clientKey]*clientEntry),
+	}
+}
+
+// Session returns the session of the profile in the region (the default region when empty)
+func (p *Pool) Session(profile, region string) (*session.Session, error) {
+	return p.session(sessionKey{profile: profile, region: region}, func() (*session.Session, error) {
+		if region == "" {
+			return p.resolve(profile)
+		}
+		base, err := p.Session(profile, "")
+		if err != nil {
+			return nil, err
+		}
+		if awssdk.StringValue(base.Config.Region) == region {
+			return base, nil
+		}
+		return base.Copy(&awssdk.Config{Region: awssdk.String(region)}), nil
+	})
+}
+
+// RoleSession returns the session assuming the role from the profile in the region (the default region when empty)
+func (p *Pool) RoleSession(profile, region, roleArn string) (*session.Session, error) {
+	return p.session(sessionKey{profile: profile, region: region, role: roleArn}, func() (*session.Session, error) {
+		if region == "" {
+			base, err := p.Session(profile, "")
+			if err != nil {
+				return nil, err
+			}
+			return base.Copy(&awssdk.Config{Credentials: stscreds.NewCredentials(base, roleArn)}), nil
+		}
+		base, err := p.RoleSession(profile, "", roleArn)
+		if err != nil {
+			return nil, err
+		}
+		return base.Copy(&awssdk.Config{Region: awssdk.String(region)}), nil
+	})
+}
+
+func (p *Pool) session(key sessionKey, create func() (*session.Session, error)) (*session.Session, error) {
+	p.mu.Lock()
+	entry, ok := p.sessions[key]
+	if !ok {
+		entry = &sessionEntry{}
+		p.sessions[key] = entry
+	}
+	p.mu.Unlock()
+
+	entry.once.Do(func() {
+		if entry.sess, entry.err = create(); entry.err == nil {
+			p.mu.Lock()
+			p.resolved[key] = entry.sess
+			p.mu.Unlock()
+		}
+	})
+	if entry.err != nil { // not kept so that the session is resolved again on next call
+		p.mu.Lock()
+		if p.sessions[key] == entry {
+			delete(p.sessions, key)
+		}
+		p.mu.Unlock()
+	}
+	return entry.sess, entry.err
+}
+
+// Client returns the API client of the service for the session, created with the given func on first call
+func (p *Pool) Client(sess *session.Session, service string, create func() interface{}) interface{} {
+	key := clientKey{sess: sess, service: service}
+	p.mu.Lock()
+	entry, ok := p.clients[key]
+	if !ok {
+		entry = &clientEntry{}
+		p.clients[key] = entry
+	}
+	p.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.client = create()
+	})
+	return entry.client
+}
+
+// Refresh expires the credentials of the sessions of the profile, forcing
+// their retrieval on next request (ex: after a MFA or a role session expiration)
+func (p *Pool) Refresh(profile string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, sess := range p.resolved {
+		if key.profile == profile && sess.Config.Credentials != nil {
+			sess.Config.Credentials.Expire()
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package awspool
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/awstesting/mock"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestPool(t *testing.T) {
+	var resolved int
+	failing := true
+	pool := New(func(profile string) (*session.Session, error) {
+		if profile == "failing" && failing {
+			return nil, errors.New("no credentials")
+		}
+		resolved++
+		return mock.Session.Copy(&awssdk.Config{Region: awssdk.String("us-east-1")}), nil
+	})
+
+	var wg sync.WaitGroup
+	sessions := make([]*session.Session, 10)
+	for i := range sessions {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			region := "eu-west-1"
+			if i%2 == 0 {
+				region = "us-east-1"
+			}
+			sess, err := pool.Session("default", region)
+			if err != nil {
+				t.Error(err)
+			}
+			sessions[i] = sess
+		}(i)
+	}
+	wg.Wait()
+
+	if got, want := resolved, 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	base, _ := pool.Session("default", "")
+	if sessions[0] != base || sessions[2] != base {
+		t.Fatal("expected session of default region to be the base session")
+	}
+	if sessions[1] != sessions[3] || sessions[1] == base {
+		t.Fatal("expected same session per region")
+	}
+	if got, want := awssdk.StringValue(sessions[1].Config.Region), "eu-west-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if sessions[1].Config.Credentials != base.Config.Credentials {
+		t.Fatal("expected credentials shared across regions")
+	}
+
+	role, err := pool.RoleSession("default", "eu-west-1", "arn:aws:iam::123456789012:role/Audit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role.Config.Credentials == base.Config.Credentials {
+		t.Fatal("expected role credentials")
+	}
+	if other, _ := pool.RoleSession("default", "us-west-2", "arn:aws:iam::123456789012:role/Audit"); other.Config.Credentials != role.Config.Credentials {
+		t.Fatal("expected role credentials shared across regions")
+	}
+
+	newEC2 := func() interface{} { return ec2.New(sessions[1]) }
+	if pool.Client(sessions[1], "ec2", newEC2) != pool.Client(sessions[1], "ec2", newEC2) {
+		t.Fatal("expected same client")
+	}
+	if pool.Client(sessions[1], "ec2", newEC2) == pool.Client(base, "ec2", func() interface{} { return ec2.New(base) }) {
+		t.Fatal("expected client per region")
+	}
+
+	if _, err := pool.Session("failing", ""); err == nil {
+		t.Fatal("expected error")
+	}
+	failing = false
+	if _, err := pool.Session("failing", ""); err != nil {
+		t.Fatalf("expected session resolved again after failure, got %s", err)
+	}
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/wallix/awless/aws/pool"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+)
+
+const (
+	AccountsRolesConfigKey            = "aws.accounts.roles"
+	AccountsOrganizationRoleConfigKey = "aws.accounts.organization.role"
+
+	defaultOrganizationRole = "OrganizationAccountAccessRole"
+)
+
+// Account is an AWS account fetched when listing across accounts.
+// The role is empty for the account of the current session.
+type Account struct {
+	ID, RoleArn string
+}
+
+func (a *Account) String() string {
+	if a.RoleArn == "" {
+		return a.ID
+	}
+	return fmt.Sprintf("%s (%s)", a.ID, a.RoleArn)
+}
+
+// Session and settings resolved at Init, needed to instantiate the services in other accounts
+var current struct {
+	sess      *session.Session
+	profile   string
+	extraConf map[string]interface{}
+	log       *logger.Logger
+}
+
+// ResolveAccounts returns the current account followed by the accounts reachable through
+// the roles configured in `aws.accounts.roles` (comma separated role ARNs). Without configured
+// roles, the active accounts of the AWS Organization are listed and the role named after
+// `aws.accounts.organization.role` (default: OrganizationAccountAccessRole) is assumed in each of them.
+func ResolveAccounts() ([]*Account, error) {
+	if current.sess == nil {
+		return nil, errors.New("resolve accounts: AWS session not initialized")
+	}
+	var roles []string
+	if s, ok := current.extraConf[AccountsRolesConfigKey].(string); ok {
+		for _, r := range strings.Split(s, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				roles = append(roles, r)
+			}
+		}
+	}
+	orgRole, _ := current.extraConf[AccountsOrganizationRoleConfigKey].(string)
+	return resolveAccounts(sts.New(current.sess), organizations.New(current.sess), roles, orgRole)
+}
+
+func resolveAccounts(stsAPI stsiface.STSAPI, orgAPI organizationsiface.OrganizationsAPI, roles []string, orgRole string) ([]*Account, error) {
+	identity, err := stsAPI.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, fmt.Errorf("resolve current account: %s", err)
+	}
+	currentID := awssdk.StringValue(identity.Account)
+	accounts := []*Account{{ID: currentID}}
+
+	if len(roles) > 0 {
+		for _, role := range roles {
+			splits := strings.Split(role, ":")
+			if len(splits) != 6 || !strings.HasPrefix(splits[5], "role/") {
+				return nil, fmt.Errorf("invalid role ARN '%s' in %s", role, AccountsRolesConfigKey)
+			}
+			if splits[4] != currentID {
+				accounts = append(accounts, &Account{ID: splits[4], RoleArn: role})
+			}
+		}
+		return accounts, nil
+	}
+
+	if orgRole == "" {
+		orgRole = defaultOrganizationRole
+	}
+	partition := "aws"
+	if splits := strings.Split(awssdk.StringValue(identity.Arn), ":"); len(splits) > 1 {
+		partition = splits[1]
+	}
+	err = orgAPI.ListAccountsPages(&organizations.ListAccountsInput{}, func(out *organizations.ListAccountsOutput, lastPage bool) bool {
+		for _, acc := range out.Accounts {
+			id := awssdk.StringValue(acc.Id)
+			if id == currentID || awssdk.StringValue(acc.Status) != organizations.AccountStatusActive {
+				continue
+			}
+			accounts = append(accounts, &Account{ID: id, RoleArn: fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, id, orgRole)})
+		}
+		return out.NextToken != nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list organization accounts (or set roles with `awless config set %s`): %s", AccountsRolesConfigKey, err)
+	}
+	return accounts, nil
+}
+
+var newServiceFuncs = map[string]func(*session.Session, string, map[string]interface{}, *logger.Logger) cloud.Service{
+	"infra":          NewInfra,
+	"access":         NewAccess,
+	"storage":        NewStorage,
+	"messaging":      NewMessaging,
+	"dns":            NewDns,
+	"lambda":         NewLambda,
+	"monitoring":     NewMonitoring,
+	"cdn":            NewCdn,
+	"cloudformation": NewCloudformation,
+}
+
+// FetchByTypeInAccounts fetches in parallel the resources of the given type in each account,
+// assuming the role of the account if any. Fetched resources are tagged with their account id.
+// The graph of the accounts successfully fetched is returned along with the errors of the others.
+func FetchByTypeInAccounts(ctx context.Context, resourceType string, accounts []*Account) (cloud.GraphAPI, error) {
+	srvName, ok := ServicePerResourceType[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("cannot find service for resource type %s", resourceType)
+	}
+	newService := newServiceFuncs[srvName]
+
+	return fetchByTypeInAccounts(ctx, resourceType, accounts, func(acc *Account) (cloud.Service, error) {
+		sess := current.sess
+		if acc.RoleArn != "" {
+			var err error
+			if sess, err = awspool.Default.RoleSession(current.profile, "", acc.RoleArn); err != nil {
+				return nil, err
+			}
+		}
+		return newService(sess, current.profile, current.extraConf, current.log), nil
+	})
+}
+
+func fetchByTypeInAccounts(ctx context.Context, resourceType string, accounts []*Account, serviceFor func(*Account) (cloud.Service, error)) (cloud.GraphAPI, error) {
+	type accountResult struct {
+		account *Account
+		gph     cloud.GraphAPI
+		err     error
+	}
+	results := make(chan accountResult, len(accounts))
+
+	var wg sync.WaitGroup
+	for _, acc := range accounts {
+		wg.Add(1)
+		go func(a *Account) {
+			defer wg.Done()
+			srv, err := serviceFor(a)
+			if err != nil {
+				results <- accountResult{account: a, err: err}
+				return
+			}
+			g, err := srv.FetchByType(ctx, resourceType)
+			results <- accountResult{account: a, gph: g, err: err}
+		}(acc)
+	}
+	wg.Wait()
+	close(results)
+
+	merged := graph.NewGraph()
+	ferr := new(fetch.Error)
+	for res := range results {
+		if res.err != nil {
+			ferr.Add(fmt.Errorf("account %s: %s", res.account, res.err))
+			continue
+		}
+		if err := addAccountResources(merged, res.gph, resourceType, res.account.ID); err != nil {
+			ferr.Add(fmt.Errorf("account %s: %s", res.account, err))
+		}
+	}
+
+	if ferr.Any() {
+		return merged, ferr
+	}
+	return merged, nil
+}
+
+func addAccountResources(merged *graph.Graph, gph cloud.GraphAPI, resourceType, accountID string) error {
+	g, ok := gph.(*graph.Graph)
+	if !ok {
+		return fmt.Errorf("unexpected graph type %T", gph)
+	}
+	resources, err := g.Find(cloud.NewQuery(resourceType))
+	if err != nil {
+		return err
+	}
+	merged.AddGraph(g)
+	for _, r := range resources {
+		res := r.(*graph.Resource)
+		res.SetProperty(properties.Account, accountID)
+		if err := merged.AddResource(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package awsservices
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/organizations"
+	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/wallix/awless/aws/fetch"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+type mockAccountsSts struct {
+	stsiface.STSAPI
+}
+
+func (m *mockAccountsSts) GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Account: awssdk.String("111111111111"), Arn: awssdk.String("arn:aws:iam::111111111111:user/toto")}, nil
+}
+
+type mockOrganizations struct {
+	organizationsiface.OrganizationsAPI
+	accounts []*organizations.Account
+}
+
+func (m *mockOrganizations) ListAccountsPages(input *organizations.ListAccountsInput, fn func(*organizations.ListAccountsOutput, bool) bool) error {
+	fn(&organizations.ListAccountsOutput{Accounts: m.accounts}, true)
+	return nil
+}
+
+func TestResolveAccounts(t *testing.T) {
+	orgs := &mockOrganizations{accounts: []*organizations.Account{
+		{Id: awssdk.String("111111111111"), Status: awssdk.String("ACTIVE")},
+		{Id: awssdk.String("222222222222"), Status: awssdk.String("ACTIVE")},
+		{Id: awssdk.String("333333333333"), Status: awssdk.String("SUSPENDED")},
+	}}
+
+	t.Run("from organization", func(t *testing.T) {
+		accounts, err := resolveAccounts(&mockAccountsSts{}, orgs, nil, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []*Account{
+			{ID: "111111111111"},
+			{ID: "222222222222", RoleArn: "arn:aws:iam::222222222222:role/OrganizationAccountAccessRole"},
+		}
+		if got, want := accounts, expected; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("from roles", func(t *testing.T) {
+		accounts, err := resolveAccounts(&mockAccountsSts{}, orgs, []string{"arn:aws:iam::444444444444:role/Audit", "arn:aws:iam::111111111111:role/Audit"}, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []*Account{
+			{ID: "111111111111"},
+			{ID: "444444444444", RoleArn: "arn:aws:iam::444444444444:role/Audit"},
+		}
+		if got, want := accounts, expected; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("invalid role", func(t *testing.T) {
+		if _, err := resolveAccounts(&mockAccountsSts{}, orgs, []string{"Audit"}, ""); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestFetchByTypeInAccounts(t *testing.T) {
+	instancesPerAccount := map[string][]*ec2.Instance{
+		"111111111111": {{InstanceId: awssdk.String("inst_1")}, {InstanceId: awssdk.String("inst_2")}},
+		"222222222222": {{InstanceId: awssdk.String("inst_3")}},
+	}
+	accounts := []*Account{{ID: "111111111111"}, {ID: "222222222222", RoleArn: "arn:aws:iam::222222222222:role/Audit"}, {ID: "333333333333", RoleArn: "arn:aws:iam::333333333333:role/Audit"}}
+
+	g, err := fetchByTypeInAccounts(context.Background(), cloud.Instance, accounts, func(acc *Account) (cloud.Service, error) {
+		if acc.ID == "333333333333" {
+			return &Infra{fetcher: fetch.NewFetcher(fetch.Funcs{cloud.Instance: func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+				return nil, nil, errors.New("access denied")
+			}})}, nil
+		}
+		mock := &mockEc2{instances: instancesPerAccount[acc.ID]}
+		return &Infra{fetcher: fetch.NewFetcher(awsfetch.BuildInfraFetchFuncs(awsfetch.NewConfig(mock)))}, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "333333333333") {
+		t.Fatalf("expected error for account 333333333333, got %v", err)
+	}
+
+	expected := map[string]string{"inst_1": "111111111111", "inst_2": "111111111111", "inst_3": "222222222222"}
+	resources, err := g.Find(cloud.NewQuery(cloud.Instance))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(resources), len(expected); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for _, r := range resources {
+		if got, want := r.Properties()[properties.Account], expected[r.Id()]; got != want {
+			t.Fatalf("%s: got %v, want %v", r.Id(), got, want)
+		}
+	}
+}
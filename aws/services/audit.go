@@ -0,0 +1,124 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+const AuditTargetConfigKey = "aws.audit.target"
+
+// AuditTarget is where the reports of template executions are shipped:
+// either a CloudWatch Logs group (`logs:<group>`) or an S3 prefix (`s3://<bucket>/<prefix>`)
+type AuditTarget struct {
+	LogGroup       string
+	Bucket, Prefix string
+}
+
+func ParseAuditTarget(s string) (*AuditTarget, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "logs:"):
+		if group := strings.TrimPrefix(s, "logs:"); group != "" {
+			return &AuditTarget{LogGroup: group}, nil
+		}
+	case strings.HasPrefix(s, "s3://"):
+		splits := strings.SplitN(strings.TrimPrefix(s, "s3://"), "/", 2)
+		if splits[0] != "" {
+			target := &AuditTarget{Bucket: splits[0]}
+			if len(splits) > 1 {
+				target.Prefix = strings.Trim(splits[1], "/")
+			}
+			return target, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid audit target '%s', expected logs:<group> or s3://<bucket>/<prefix>", s)
+}
+
+func (t *AuditTarget) String() string {
+	if t.LogGroup != "" {
+		return "logs:" + t.LogGroup
+	}
+	return "s3://" + path.Join(t.Bucket, t.Prefix)
+}
+
+// ShipAuditReport sends the JSON report of a template execution to the target configured
+// in `aws.audit.target`. Reports are write-once: a log stream per run in CloudWatch Logs,
+// or an object per run under a dated key in S3. Without configured target, nothing is shipped.
+func ShipAuditReport(runID string, date time.Time, report []byte) error {
+	s, _ := current.extraConf[AuditTargetConfigKey].(string)
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	if current.sess == nil {
+		return errors.New("ship audit report: AWS session not initialized")
+	}
+	target, err := ParseAuditTarget(s)
+	if err != nil {
+		return err
+	}
+	if target.LogGroup != "" {
+		return shipAuditReportToLogs(cloudwatchlogs.New(current.sess), target.LogGroup, runID, date, report)
+	}
+	return shipAuditReportToS3(s3.New(current.sess), target.Bucket, target.Prefix, runID, date, report)
+}
+
+func shipAuditReportToLogs(api cloudwatchlogsiface.CloudWatchLogsAPI, group, runID string, date time.Time, report []byte) error {
+	if _, err := api.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  awssdk.String(group),
+		LogStreamName: awssdk.String(runID),
+	}); err != nil {
+		if aerr, ok := err.(awserr.Error); !ok || aerr.Code() != cloudwatchlogs.ErrCodeResourceAlreadyExistsException {
+			return fmt.Errorf("ship audit report to log group %s: %s", group, err)
+		}
+	}
+	if _, err := api.PutLogEvents(&cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  awssdk.String(group),
+		LogStreamName: awssdk.String(runID),
+		LogEvents: []*cloudwatchlogs.InputLogEvent{
+			{Message: awssdk.String(string(report)), Timestamp: awssdk.Int64(date.UnixNano() / int64(time.Millisecond))},
+		},
+	}); err != nil {
+		return fmt.Errorf("ship audit report to log group %s: %s", group, err)
+	}
+	return nil
+}
+
+func shipAuditReportToS3(api s3iface.S3API, bucket, prefix, runID string, date time.Time, report []byte) error {
+	key := path.Join(prefix, date.UTC().Format("2006/01/02"), runID+".json")
+	if _, err := api.PutObject(&s3.PutObjectInput{
+		Bucket:      awssdk.String(bucket),
+		Key:         awssdk.String(key),
+		Body:        bytes.NewReader(report),
+		ContentType: awssdk.String("application/json"),
+	}); err != nil {
+		return fmt.Errorf("ship audit report to s3://%s/%s: %s", bucket, key, err)
+	}
+	return nil
+}
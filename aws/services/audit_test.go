@@ -0,0 +1,114 @@
+package awsservices
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+type mockAuditLogs struct {
+	cloudwatchlogsiface.CloudWatchLogsAPI
+	existingStreams map[string]bool
+	events          []*cloudwatchlogs.PutLogEventsInput
+}
+
+func (m *mockAuditLogs) CreateLogStream(input *cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	if m.existingStreams[awssdk.StringValue(input.LogStreamName)] {
+		return nil, awserr.New(cloudwatchlogs.ErrCodeResourceAlreadyExistsException, "exists", nil)
+	}
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func (m *mockAuditLogs) PutLogEvents(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	m.events = append(m.events, input)
+	return &cloudwatchlogs.PutLogEventsOutput{}, nil
+}
+
+type mockAuditS3 struct {
+	s3iface.S3API
+	bucket, key, body string
+}
+
+func (m *mockAuditS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	m.bucket, m.key = awssdk.StringValue(input.Bucket), awssdk.StringValue(input.Key)
+	b, err := ioutil.ReadAll(input.Body)
+	m.body = string(b)
+	return &s3.PutObjectOutput{}, err
+}
+
+func TestParseAuditTarget(t *testing.T) {
+	tcases := []struct {
+		in       string
+		expected *AuditTarget
+	}{
+		{in: "logs:awless-audit", expected: &AuditTarget{LogGroup: "awless-audit"}},
+		{in: "s3://my-bucket", expected: &AuditTarget{Bucket: "my-bucket"}},
+		{in: "s3://my-bucket/audit/awless/", expected: &AuditTarget{Bucket: "my-bucket", Prefix: "audit/awless"}},
+		{in: "logs:"},
+		{in: "s3://"},
+		{in: "my-bucket"},
+	}
+	for _, tcase := range tcases {
+		target, err := ParseAuditTarget(tcase.in)
+		if tcase.expected == nil {
+			if err == nil {
+				t.Fatalf("%s: expected error", tcase.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.in, err)
+		}
+		if got, want := *target, *tcase.expected; got != want {
+			t.Fatalf("%s: got %#v, want %#v", tcase.in, got, want)
+		}
+	}
+}
+
+func TestShipAuditReport(t *testing.T) {
+	date := time.Date(2017, 8, 3, 10, 30, 0, 0, time.UTC)
+	report := []byte(`{"runId":"01BPZ"}`)
+
+	t.Run("to log group", func(t *testing.T) {
+		api := &mockAuditLogs{existingStreams: map[string]bool{"01BPZ": true}}
+		if err := shipAuditReportToLogs(api, "audit", "01BPZ", date, report); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(api.events), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		event := api.events[0]
+		if got, want := awssdk.StringValue(event.LogStreamName), "01BPZ"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := awssdk.StringValue(event.LogEvents[0].Message), string(report); got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := awssdk.Int64Value(event.LogEvents[0].Timestamp), date.Unix()*1000; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("to s3 prefix", func(t *testing.T) {
+		api := &mockAuditS3{}
+		if err := shipAuditReportToS3(api, "my-bucket", "audit", "01BPZ", date, report); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := api.bucket, "my-bucket"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := api.key, "audit/2017/08/03/01BPZ.json"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := api.body, string(report); got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+}
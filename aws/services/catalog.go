@@ -0,0 +1,255 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/wallix/awless/aws/pool"
+)
+
+const RegionsOfflineConfigKey = "aws.regions.offline"
+
+const (
+	AvailabilityZoneType = "availability-zone"
+	LocalZoneType        = "local-zone"
+	WavelengthZoneType   = "wavelength-zone"
+)
+
+const regionsCatalogFile = "regions.json"
+
+var regionsCatalogTTL = 24 * time.Hour
+
+// endpoints IDs of the APIs whose name differs in awless
+var endpointsPerAPI = map[string]string{
+	"elbv2":      "elasticloadbalancing",
+	"cloudwatch": "monitoring",
+}
+
+// RegionInfo is a region with its zones and the APIs (as named in awless) it offers.
+// Zones are only known when the catalog is fetched online.
+type RegionInfo struct {
+	ID        string      `json:"id"`
+	Partition string      `json:"partition"`
+	Zones     []*ZoneInfo `json:"zones,omitempty"`
+	APIs      []string    `json:"apis"`
+	// UnknownAPIs are missing from the endpoints catalog for the region (ex: regions opened since the release of awless)
+	UnknownAPIs []string `json:"unknown_apis,omitempty"`
+}
+
+type ZoneInfo struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	State string `json:"state"`
+}
+
+type regionsCatalog struct {
+	Expiration time.Time     `json:"expiration"`
+	Regions    []*RegionInfo `json:"regions"`
+}
+
+// RegionsCatalog returns the regions enabled for the account with their zones, as fetched
+// from EC2 and cached for a day. With `aws.regions.offline` set, the catalog embedded in
+// awless is returned instead, without any API call (all known regions, no zones).
+func RegionsCatalog() ([]*RegionInfo, error) {
+	if offline, _ := current.extraConf[RegionsOfflineConfigKey].(bool); offline {
+		return offlineRegionsCatalog(), nil
+	}
+	if current.sess == nil {
+		return nil, errors.New("regions catalog: AWS session not initialized")
+	}
+
+	cacheDir, profile := os.Getenv("__AWLESS_CACHE"), current.profile
+	cacheFile := fmt.Sprintf("aws-profile-%s-%s", profile, regionsCatalogFile)
+	fold := &folder{cacheDir}
+	if cacheDir != "" {
+		if content, ok := fold.getFileContent(cacheFile); ok {
+			var cached *regionsCatalog
+			if err := json.Unmarshal(content, &cached); err == nil && cached.Expiration.After(time.Now().UTC()) {
+				current.log.ExtraVerbosef("loading regions catalog from '%s'", filepath.Join(cacheDir, cacheFile))
+				return cached.Regions, nil
+			}
+		}
+	}
+
+	regions, err := fetchRegionsCatalog(ec2.New(current.sess), func(region string) (ec2iface.EC2API, error) {
+		sess, err := awspool.Default.Session(profile, region)
+		if err != nil {
+			return nil, err
+		}
+		return ec2.New(sess), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheDir != "" {
+		content, err := json.Marshal(&regionsCatalog{Expiration: time.Now().UTC().Add(regionsCatalogTTL), Regions: regions})
+		if err != nil {
+			return regions, err
+		}
+		if err := fold.putFileContent(cacheFile, content); err != nil {
+			current.log.Warningf("cannot cache regions catalog: %s", err)
+		}
+	}
+	return regions, nil
+}
+
+func fetchRegionsCatalog(api ec2iface.EC2API, regionalAPI func(region string) (ec2iface.EC2API, error)) ([]*RegionInfo, error) {
+	out, err := api.DescribeRegions(&ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("describe regions: %s", err)
+	}
+
+	var regions []*RegionInfo
+	for _, r := range out.Regions {
+		regions = append(regions, regionInfo(awssdk.StringValue(r.RegionName)))
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i].ID < regions[j].ID })
+
+	var wg sync.WaitGroup
+	errc := make(chan error, len(regions))
+	for _, r := range regions {
+		wg.Add(1)
+		go func(r *RegionInfo) {
+			defer wg.Done()
+			regional, err := regionalAPI(r.ID)
+			if err != nil {
+				errc <- fmt.Errorf("region %s: %s", r.ID, err)
+				return
+			}
+			zones, err := regional.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{})
+			if err != nil {
+				errc <- fmt.Errorf("region %s: describe zones: %s", r.ID, err)
+				return
+			}
+			for _, z := range zones.AvailabilityZones {
+				name := awssdk.StringValue(z.ZoneName)
+				r.Zones = append(r.Zones, &ZoneInfo{Name: name, Type: zoneType(r.ID, name), State: awssdk.StringValue(z.State)})
+			}
+			sort.Slice(r.Zones, func(i, j int) bool { return r.Zones[i].Name < r.Zones[j].Name })
+		}(r)
+	}
+	wg.Wait()
+	close(errc)
+
+	var errs []string
+	for err := range errc {
+		errs = append(errs, err.Error())
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return regions, errors.New(strings.Join(errs, "; "))
+	}
+	return regions, nil
+}
+
+func offlineRegionsCatalog() (regions []*RegionInfo) {
+	for _, p := range endpoints.DefaultPartitions() {
+		for id := range p.Regions() {
+			regions = append(regions, regionInfo(id))
+		}
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i].ID < regions[j].ID })
+	return
+}
+
+func regionInfo(region string) *RegionInfo {
+	info := &RegionInfo{ID: region}
+	if p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region); ok {
+		info.Partition = p.ID()
+	}
+	for _, api := range APIs() {
+		switch available, known := APIAvailabilityInRegion(api, region); {
+		case !known:
+			info.UnknownAPIs = append(info.UnknownAPIs, api)
+		case available:
+			info.APIs = append(info.APIs, api)
+		}
+	}
+	return info
+}
+
+// APIs returns the APIs used by awless, sorted
+func APIs() (apis []string) {
+	unique := make(map[string]bool)
+	for _, api := range APIPerResourceType {
+		if !unique[api] {
+			unique[api] = true
+			apis = append(apis, api)
+		}
+	}
+	sort.Strings(apis)
+	return
+}
+
+// APIAvailabilityInRegion returns whether the API (as named in awless, ex: elbv2, lambda) is offered
+// in the region, according to the endpoints catalog. APIs with a global endpoint in the partition
+// of the region (ex: iam, route53) are available in all its regions.
+// The availability is not known when the catalog misses the region or the API, as the regions
+// opened since the release of awless (ex: eu-north-1): only a known unavailability is reliable.
+func APIAvailabilityInRegion(api, region string) (available bool, known bool) {
+	p, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return false, false
+	}
+	id := api
+	if e, ok := endpointsPerAPI[api]; ok {
+		id = e
+	}
+	srv, ok := p.Services()[id]
+	if !ok {
+		return false, false
+	}
+	srvRegions := srv.Regions()
+	if _, ok := srvRegions[region]; ok {
+		return true, true
+	}
+	if len(srvRegions) == 0 && len(srv.Endpoints()) > 0 {
+		return true, true
+	}
+	if _, ok := p.Regions()[region]; !ok {
+		return false, false
+	}
+	return false, true
+}
+
+// zoneType infers the type of a zone from its name: availability zones are the region followed
+// by a letter (us-west-2a), local zones carry a location (us-west-2-lax-1a) and wavelength zones
+// a carrier (us-east-1-wl1-bos-wlz-1)
+func zoneType(region, zone string) string {
+	switch {
+	case strings.Contains(zone, "-wlz-"):
+		return WavelengthZoneType
+	case strings.HasPrefix(zone, region+"-"):
+		return LocalZoneType
+	default:
+		return AvailabilityZoneType
+	}
+}
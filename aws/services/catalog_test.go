@@ -0,0 +1,109 @@
+package awsservices
+
+import (
+	"reflect"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+type mockCatalogEc2 struct {
+	ec2iface.EC2API
+	regions []string
+	zones   []string
+}
+
+func (m *mockCatalogEc2) DescribeRegions(*ec2.DescribeRegionsInput) (*ec2.DescribeRegionsOutput, error) {
+	out := &ec2.DescribeRegionsOutput{}
+	for _, r := range m.regions {
+		out.Regions = append(out.Regions, &ec2.Region{RegionName: awssdk.String(r)})
+	}
+	return out, nil
+}
+
+func (m *mockCatalogEc2) DescribeAvailabilityZones(*ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
+	out := &ec2.DescribeAvailabilityZonesOutput{}
+	for _, z := range m.zones {
+		out.AvailabilityZones = append(out.AvailabilityZones, &ec2.AvailabilityZone{ZoneName: awssdk.String(z), State: awssdk.String("available")})
+	}
+	return out, nil
+}
+
+func TestFetchRegionsCatalog(t *testing.T) {
+	zonesPerRegion := map[string][]string{
+		"us-west-2": {"us-west-2b", "us-west-2a", "us-west-2-lax-1a"},
+		"us-east-1": {"us-east-1a", "us-east-1-wl1-bos-wlz-1"},
+	}
+	regions, err := fetchRegionsCatalog(&mockCatalogEc2{regions: []string{"us-west-2", "us-east-1"}}, func(region string) (ec2iface.EC2API, error) {
+		return &mockCatalogEc2{zones: zonesPerRegion[region]}, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(regions), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := regions[0].ID, "us-east-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := regions[0].Partition, "aws"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	expected := []*ZoneInfo{
+		{Name: "us-west-2-lax-1a", Type: LocalZoneType, State: "available"},
+		{Name: "us-west-2a", Type: AvailabilityZoneType, State: "available"},
+		{Name: "us-west-2b", Type: AvailabilityZoneType, State: "available"},
+	}
+	if got, want := regions[1].Zones, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := regions[0].Zones[0].Type, WavelengthZoneType; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestAPIAvailabilityInRegion(t *testing.T) {
+	tcases := []struct {
+		api, region        string
+		expected, expKnown bool
+	}{
+		{api: "ec2", region: "eu-west-1", expected: true, expKnown: true},
+		{api: "elbv2", region: "eu-west-1", expected: true, expKnown: true},
+		{api: "cloudwatch", region: "eu-west-1", expected: true, expKnown: true},
+		{api: "iam", region: "ap-south-1", expected: true, expKnown: true},
+		{api: "route53", region: "eu-west-2", expected: true, expKnown: true},
+		{api: "lambda", region: "cn-northwest-1", expected: false, expKnown: true},
+		{api: "ec2", region: "eu-north-1", expected: false, expKnown: false},
+		{api: "ec2", region: "me-south-1", expected: false, expKnown: false},
+		{api: "unknownapi", region: "eu-west-1", expected: false, expKnown: false},
+	}
+	for _, tcase := range tcases {
+		available, known := APIAvailabilityInRegion(tcase.api, tcase.region)
+		if got, want := available, tcase.expected; got != want {
+			t.Fatalf("%s in %s: got %t, want %t", tcase.api, tcase.region, got, want)
+		}
+		if got, want := known, tcase.expKnown; got != want {
+			t.Fatalf("%s in %s: got known %t, want %t", tcase.api, tcase.region, got, want)
+		}
+	}
+}
+
+func TestOfflineRegionsCatalog(t *testing.T) {
+	var found bool
+	for _, r := range offlineRegionsCatalog() {
+		if r.ID == "eu-west-1" {
+			found = true
+			if len(r.Zones) != 0 {
+				t.Fatalf("expected no zones offline, got %d", len(r.Zones))
+			}
+			if len(r.APIs) == 0 {
+				t.Fatal("expected APIs in eu-west-1")
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected eu-west-1 in offline catalog")
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
+	"github.com/aws/aws-sdk-go/service/costexplorer/costexploreriface"
+)
+
+// Cost Explorer is only served from us-east-1, whatever the region of the resources
+const costExplorerRegion = "us-east-1"
+
+const costDateLayout = "2006-01-02"
+
+// Cost is the unblended cost of a service or of the value of a tag over a period
+type Cost struct {
+	Key    string
+	Amount float64
+	Unit   string
+}
+
+// CostReport is the unblended cost of the account over a period, grouped either by service or by
+// the values of a tag key, the most expensive first. Estimated is true while the period is not closed.
+type CostReport struct {
+	Start, End time.Time
+	GroupedBy  string
+	Total      Cost
+	Costs      []*Cost
+	Estimated  bool
+}
+
+// GetCosts returns the costs of the account between the dates (by day, the end
+// being excluded) per service or, when the tag key is given, per value of the tag
+func GetCosts(start, end time.Time, tagKey string) (*CostReport, error) {
+	if current.sess == nil {
+		return nil, errors.New("get costs: AWS session not initialized")
+	}
+	return getCosts(costexplorer.New(current.sess, awssdk.NewConfig().WithRegion(costExplorerRegion)), start, end, tagKey)
+}
+
+func getCosts(api costexploreriface.CostExplorerAPI, start, end time.Time, tagKey string) (*CostReport, error) {
+	report := &CostReport{Start: start, End: end, GroupedBy: "service"}
+	group := &costexplorer.GroupDefinition{Type: awssdk.String(costexplorer.GroupDefinitionTypeDimension), Key: awssdk.String(costexplorer.DimensionService)}
+	if tagKey != "" {
+		report.GroupedBy = tagKey
+		group = &costexplorer.GroupDefinition{Type: awssdk.String(costexplorer.GroupDefinitionTypeTag), Key: awssdk.String(tagKey)}
+	}
+	input := &costexplorer.GetCostAndUsageInput{
+		TimePeriod:  &costexplorer.DateInterval{Start: awssdk.String(start.Format(costDateLayout)), End: awssdk.String(end.Format(costDateLayout))},
+		Granularity: awssdk.String(costexplorer.GranularityMonthly),
+		Metrics:     []*string{awssdk.String("UnblendedCost")},
+		GroupBy:     []*costexplorer.GroupDefinition{group},
+	}
+
+	perKey := make(map[string]*Cost)
+	for {
+		out, err := api.GetCostAndUsage(input)
+		if err != nil {
+			return nil, fmt.Errorf("get costs: %s", err)
+		}
+		for _, result := range out.ResultsByTime {
+			if awssdk.BoolValue(result.Estimated) {
+				report.Estimated = true
+			}
+			for _, g := range result.Groups {
+				if len(g.Keys) == 0 {
+					continue
+				}
+				key := awssdk.StringValue(g.Keys[0])
+				if tagKey != "" {
+					// tag values are returned as 'key$value', with an empty value for untagged usages
+					key = strings.TrimPrefix(key, tagKey+"$")
+				}
+				metric, ok := g.Metrics["UnblendedCost"]
+				if !ok {
+					continue
+				}
+				amount, err := strconv.ParseFloat(awssdk.StringValue(metric.Amount), 64)
+				if err != nil {
+					return nil, fmt.Errorf("get costs: invalid amount for %s: %s", key, err)
+				}
+				c, ok := perKey[key]
+				if !ok {
+					c = &Cost{Key: key, Unit: awssdk.StringValue(metric.Unit)}
+					perKey[key] = c
+				}
+				c.Amount += amount
+				report.Total.Amount += amount
+				report.Total.Unit = c.Unit
+			}
+		}
+		if awssdk.StringValue(out.NextPageToken) == "" {
+			break
+		}
+		input.NextPageToken = out.NextPageToken
+	}
+
+	for _, c := range perKey {
+		report.Costs = append(report.Costs, c)
+	}
+	sort.Slice(report.Costs, func(i, j int) bool {
+		if report.Costs[i].Amount != report.Costs[j].Amount {
+			return report.Costs[i].Amount > report.Costs[j].Amount
+		}
+		return report.Costs[i].Key < report.Costs[j].Key
+	})
+	return report, nil
+}
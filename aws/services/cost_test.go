@@ -0,0 +1,81 @@
+package awsservices
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/costexplorer"
+	"github.com/aws/aws-sdk-go/service/costexplorer/costexploreriface"
+)
+
+type mockCostExplorer struct {
+	costexploreriface.CostExplorerAPI
+	pages  []*costexplorer.GetCostAndUsageOutput
+	inputs []*costexplorer.GetCostAndUsageInput
+}
+
+func (m *mockCostExplorer) GetCostAndUsage(input *costexplorer.GetCostAndUsageInput) (*costexplorer.GetCostAndUsageOutput, error) {
+	copied := *input
+	m.inputs = append(m.inputs, &copied)
+	return m.pages[len(m.inputs)-1], nil
+}
+
+func costGroup(key, amount string) *costexplorer.Group {
+	return &costexplorer.Group{
+		Keys:    []*string{awssdk.String(key)},
+		Metrics: map[string]*costexplorer.MetricValue{"UnblendedCost": {Amount: awssdk.String(amount), Unit: awssdk.String("USD")}},
+	}
+}
+
+func TestGetCosts(t *testing.T) {
+	start := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("per service", func(t *testing.T) {
+		api := &mockCostExplorer{pages: []*costexplorer.GetCostAndUsageOutput{
+			{NextPageToken: awssdk.String("next"), ResultsByTime: []*costexplorer.ResultByTime{{Groups: []*costexplorer.Group{costGroup("Amazon Simple Storage Service", "1.5")}}}},
+			{ResultsByTime: []*costexplorer.ResultByTime{{Estimated: awssdk.Bool(true), Groups: []*costexplorer.Group{
+				costGroup("Amazon Elastic Compute Cloud - Compute", "10.25"),
+				costGroup("Amazon Simple Storage Service", "0.5"),
+			}}}},
+		}}
+		report, err := getCosts(api, start, end, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(api.inputs), 2; got != want {
+			t.Fatalf("got %d calls, want %d", got, want)
+		}
+		if got, want := awssdk.StringValue(api.inputs[1].NextPageToken), "next"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := awssdk.StringValue(api.inputs[0].TimePeriod.End), "2017-07-01"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		exp := &CostReport{Start: start, End: end, GroupedBy: "service", Estimated: true,
+			Total: Cost{Amount: 12.25, Unit: "USD"},
+			Costs: []*Cost{{Key: "Amazon Elastic Compute Cloud - Compute", Amount: 10.25, Unit: "USD"}, {Key: "Amazon Simple Storage Service", Amount: 2, Unit: "USD"}},
+		}
+		if got, want := report, exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("per tag", func(t *testing.T) {
+		api := &mockCostExplorer{pages: []*costexplorer.GetCostAndUsageOutput{
+			{ResultsByTime: []*costexplorer.ResultByTime{{Groups: []*costexplorer.Group{costGroup("Env$", "3"), costGroup("Env$prod", "7")}}}},
+		}}
+		report, err := getCosts(api, start, end, "Env")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := awssdk.StringValue(api.inputs[0].GroupBy[0].Type), costexplorer.GroupDefinitionTypeTag; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := report.Costs, []*Cost{{Key: "prod", Amount: 7, Unit: "USD"}, {Key: "", Amount: 3, Unit: "USD"}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+}
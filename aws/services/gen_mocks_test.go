@@ -25,6 +25,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/acm"
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -33,16 +35,22 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/rds"
@@ -51,6 +59,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -62,21 +72,23 @@ import (
 
 type mockEc2 struct {
 	ec2iface.EC2API
-	instances         []*ec2.Instance
-	subnets           []*ec2.Subnet
-	vpcs              []*ec2.Vpc
-	keypairinfos      []*ec2.KeyPairInfo
-	securitygroups    []*ec2.SecurityGroup
-	volumes           []*ec2.Volume
-	internetgateways  []*ec2.InternetGateway
-	natgateways       []*ec2.NatGateway
-	routetables       []*ec2.RouteTable
-	availabilityzones []*ec2.AvailabilityZone
-	images            []*ec2.Image
-	importimagetasks  []*ec2.ImportImageTask
-	addresss          []*ec2.Address
-	snapshots         []*ec2.Snapshot
-	networkinterfaces []*ec2.NetworkInterface
+	instances               []*ec2.Instance
+	subnets                 []*ec2.Subnet
+	vpcs                    []*ec2.Vpc
+	keypairinfos            []*ec2.KeyPairInfo
+	securitygroups          []*ec2.SecurityGroup
+	volumes                 []*ec2.Volume
+	internetgateways        []*ec2.InternetGateway
+	natgateways             []*ec2.NatGateway
+	routetables             []*ec2.RouteTable
+	availabilityzones       []*ec2.AvailabilityZone
+	images                  []*ec2.Image
+	importimagetasks        []*ec2.ImportImageTask
+	addresss                []*ec2.Address
+	snapshots               []*ec2.Snapshot
+	networkinterfaces       []*ec2.NetworkInterface
+	spotfleetrequestconfigs []*ec2.SpotFleetRequestConfig
+	spotinstancerequests    []*ec2.SpotInstanceRequest
 }
 
 func (m *mockEc2) Name() string {
@@ -197,6 +209,27 @@ func (m *mockEc2) DescribeNetworkInterfaces(input *ec2.DescribeNetworkInterfaces
 	return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: m.networkinterfaces}, nil
 }
 
+func (m *mockEc2) DescribeSpotFleetRequestsPages(input *ec2.DescribeSpotFleetRequestsInput, fn func(p *ec2.DescribeSpotFleetRequestsOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*ec2.SpotFleetRequestConfig
+	for i := 0; i < len(m.spotfleetrequestconfigs); i += 2 {
+		page := []*ec2.SpotFleetRequestConfig{m.spotfleetrequestconfigs[i]}
+		if i+1 < len(m.spotfleetrequestconfigs) {
+			page = append(page, m.spotfleetrequestconfigs[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&ec2.DescribeSpotFleetRequestsOutput{SpotFleetRequestConfigs: page, NextToken: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
+func (m *mockEc2) DescribeSpotInstanceRequests(input *ec2.DescribeSpotInstanceRequestsInput) (*ec2.DescribeSpotInstanceRequestsOutput, error) {
+	return &ec2.DescribeSpotInstanceRequestsOutput{SpotInstanceRequests: m.spotinstancerequests}, nil
+}
+
 type mockElbv2 struct {
 	elbv2iface.ELBV2API
 	loadbalancers            []*elbv2.LoadBalancer
@@ -338,6 +371,65 @@ func (m *mockRds) DescribeDBSubnetGroupsPages(input *rds.DescribeDBSubnetGroupsI
 	return nil
 }
 
+type mockDynamodb struct {
+	dynamodbiface.DynamoDBAPI
+	tableNames        []*string
+	tabledescriptions []*dynamodb.TableDescription
+}
+
+func (m *mockDynamodb) Name() string {
+	return ""
+}
+
+func (m *mockDynamodb) Region() string {
+	return ""
+}
+
+func (m *mockDynamodb) Profile() string {
+	return ""
+}
+
+func (m *mockDynamodb) Provider() string {
+	return ""
+}
+
+func (m *mockDynamodb) ProviderAPI() string {
+	return ""
+}
+
+func (m *mockDynamodb) ResourceTypes() []string {
+	return []string{}
+}
+
+func (m *mockDynamodb) Fetch(context.Context) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockDynamodb) IsSyncDisabled() bool {
+	return false
+}
+
+func (m *mockDynamodb) FetchByType(context.Context, string) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockDynamodb) ListTablesPages(input *dynamodb.ListTablesInput, fn func(p *dynamodb.ListTablesOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*string
+	for i := 0; i < len(m.tableNames); i += 2 {
+		page := []*string{m.tableNames[i]}
+		if i+1 < len(m.tableNames) {
+			page = append(page, m.tableNames[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&dynamodb.ListTablesOutput{TableNames: page, LastEvaluatedTableName: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
 type mockAutoscaling struct {
 	autoscalingiface.AutoScalingAPI
 	launchconfigurations []*autoscaling.LaunchConfiguration
@@ -490,6 +582,160 @@ func (m *mockAcm) ListCertificatesPages(input *acm.ListCertificatesInput, fn fun
 	return nil
 }
 
+type mockKms struct {
+	kmsiface.KMSAPI
+	keyListEntries  []*kms.KeyListEntry
+	keymetadatas    []*kms.KeyMetadata
+	aliaslistentrys []*kms.AliasListEntry
+	grantlistentrys map[string][]*kms.GrantListEntry
+}
+
+func (m *mockKms) Name() string {
+	return ""
+}
+
+func (m *mockKms) Region() string {
+	return ""
+}
+
+func (m *mockKms) Profile() string {
+	return ""
+}
+
+func (m *mockKms) Provider() string {
+	return ""
+}
+
+func (m *mockKms) ProviderAPI() string {
+	return ""
+}
+
+func (m *mockKms) ResourceTypes() []string {
+	return []string{}
+}
+
+func (m *mockKms) Fetch(context.Context) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockKms) IsSyncDisabled() bool {
+	return false
+}
+
+func (m *mockKms) FetchByType(context.Context, string) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockKms) ListKeysPages(input *kms.ListKeysInput, fn func(p *kms.ListKeysOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*kms.KeyListEntry
+	for i := 0; i < len(m.keyListEntries); i += 2 {
+		page := []*kms.KeyListEntry{m.keyListEntries[i]}
+		if i+1 < len(m.keyListEntries) {
+			page = append(page, m.keyListEntries[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&kms.ListKeysOutput{Keys: page, NextMarker: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
+func (m *mockKms) ListAliasesPages(input *kms.ListAliasesInput, fn func(p *kms.ListAliasesOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*kms.AliasListEntry
+	for i := 0; i < len(m.aliaslistentrys); i += 2 {
+		page := []*kms.AliasListEntry{m.aliaslistentrys[i]}
+		if i+1 < len(m.aliaslistentrys) {
+			page = append(page, m.aliaslistentrys[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&kms.ListAliasesOutput{Aliases: page, NextMarker: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
+type mockElasticache struct {
+	elasticacheiface.ElastiCacheAPI
+	cacheclusters     []*elasticache.CacheCluster
+	replicationgroups []*elasticache.ReplicationGroup
+}
+
+func (m *mockElasticache) Name() string {
+	return ""
+}
+
+func (m *mockElasticache) Region() string {
+	return ""
+}
+
+func (m *mockElasticache) Profile() string {
+	return ""
+}
+
+func (m *mockElasticache) Provider() string {
+	return ""
+}
+
+func (m *mockElasticache) ProviderAPI() string {
+	return ""
+}
+
+func (m *mockElasticache) ResourceTypes() []string {
+	return []string{}
+}
+
+func (m *mockElasticache) Fetch(context.Context) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockElasticache) IsSyncDisabled() bool {
+	return false
+}
+
+func (m *mockElasticache) FetchByType(context.Context, string) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockElasticache) DescribeCacheClustersPages(input *elasticache.DescribeCacheClustersInput, fn func(p *elasticache.DescribeCacheClustersOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*elasticache.CacheCluster
+	for i := 0; i < len(m.cacheclusters); i += 2 {
+		page := []*elasticache.CacheCluster{m.cacheclusters[i]}
+		if i+1 < len(m.cacheclusters) {
+			page = append(page, m.cacheclusters[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&elasticache.DescribeCacheClustersOutput{CacheClusters: page, Marker: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
+func (m *mockElasticache) DescribeReplicationGroupsPages(input *elasticache.DescribeReplicationGroupsInput, fn func(p *elasticache.DescribeReplicationGroupsOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*elasticache.ReplicationGroup
+	for i := 0; i < len(m.replicationgroups); i += 2 {
+		page := []*elasticache.ReplicationGroup{m.replicationgroups[i]}
+		if i+1 < len(m.replicationgroups) {
+			page = append(page, m.replicationgroups[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&elasticache.DescribeReplicationGroupsOutput{ReplicationGroups: page, Marker: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
 type mockIam struct {
 	iamiface.IAMAPI
 	userdetails          []*iam.UserDetail
@@ -759,6 +1005,7 @@ type mockRoute53 struct {
 	route53iface.Route53API
 	hostedzones        []*route53.HostedZone
 	resourcerecordsets map[string][]*route53.ResourceRecordSet
+	healthchecks       []*route53.HealthCheck
 }
 
 func (m *mockRoute53) Name() string {
@@ -814,9 +1061,28 @@ func (m *mockRoute53) ListHostedZonesPages(input *route53.ListHostedZonesInput,
 	return nil
 }
 
+func (m *mockRoute53) ListHealthChecksPages(input *route53.ListHealthChecksInput, fn func(p *route53.ListHealthChecksOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*route53.HealthCheck
+	for i := 0; i < len(m.healthchecks); i += 2 {
+		page := []*route53.HealthCheck{m.healthchecks[i]}
+		if i+1 < len(m.healthchecks) {
+			page = append(page, m.healthchecks[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&route53.ListHealthChecksOutput{HealthChecks: page, NextMarker: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
 type mockLambda struct {
 	lambdaiface.LambdaAPI
-	functionconfigurations []*lambda.FunctionConfiguration
+	functionconfigurations           []*lambda.FunctionConfiguration
+	eventsourcemappingconfigurations []*lambda.EventSourceMappingConfiguration
+	aliasconfigurations              []*lambda.AliasConfiguration
 }
 
 func (m *mockLambda) Name() string {
@@ -872,6 +1138,143 @@ func (m *mockLambda) ListFunctionsPages(input *lambda.ListFunctionsInput, fn fun
 	return nil
 }
 
+func (m *mockLambda) ListEventSourceMappingsPages(input *lambda.ListEventSourceMappingsInput, fn func(p *lambda.ListEventSourceMappingsOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*lambda.EventSourceMappingConfiguration
+	for i := 0; i < len(m.eventsourcemappingconfigurations); i += 2 {
+		page := []*lambda.EventSourceMappingConfiguration{m.eventsourcemappingconfigurations[i]}
+		if i+1 < len(m.eventsourcemappingconfigurations) {
+			page = append(page, m.eventsourcemappingconfigurations[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&lambda.ListEventSourceMappingsOutput{EventSourceMappings: page, NextMarker: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
+type mockSfn struct {
+	sfniface.SFNAPI
+	statemachinelistitems []*sfn.StateMachineListItem
+	executionlistitems    []*sfn.ExecutionListItem
+}
+
+func (m *mockSfn) Name() string {
+	return ""
+}
+
+func (m *mockSfn) Region() string {
+	return ""
+}
+
+func (m *mockSfn) Profile() string {
+	return ""
+}
+
+func (m *mockSfn) Provider() string {
+	return ""
+}
+
+func (m *mockSfn) ProviderAPI() string {
+	return ""
+}
+
+func (m *mockSfn) ResourceTypes() []string {
+	return []string{}
+}
+
+func (m *mockSfn) Fetch(context.Context) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockSfn) IsSyncDisabled() bool {
+	return false
+}
+
+func (m *mockSfn) FetchByType(context.Context, string) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockSfn) ListStateMachinesPages(input *sfn.ListStateMachinesInput, fn func(p *sfn.ListStateMachinesOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*sfn.StateMachineListItem
+	for i := 0; i < len(m.statemachinelistitems); i += 2 {
+		page := []*sfn.StateMachineListItem{m.statemachinelistitems[i]}
+		if i+1 < len(m.statemachinelistitems) {
+			page = append(page, m.statemachinelistitems[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&sfn.ListStateMachinesOutput{StateMachines: page, NextToken: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
+type mockApigateway struct {
+	apigatewayiface.APIGatewayAPI
+	restapis    []*apigateway.RestApi
+	stages      map[string][]*apigateway.Stage
+	deployments map[string][]*apigateway.Deployment
+	resources   map[string][]*apigateway.Resource
+}
+
+func (m *mockApigateway) Name() string {
+	return ""
+}
+
+func (m *mockApigateway) Region() string {
+	return ""
+}
+
+func (m *mockApigateway) Profile() string {
+	return ""
+}
+
+func (m *mockApigateway) Provider() string {
+	return ""
+}
+
+func (m *mockApigateway) ProviderAPI() string {
+	return ""
+}
+
+func (m *mockApigateway) ResourceTypes() []string {
+	return []string{}
+}
+
+func (m *mockApigateway) Fetch(context.Context) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockApigateway) IsSyncDisabled() bool {
+	return false
+}
+
+func (m *mockApigateway) FetchByType(context.Context, string) (cloud.GraphAPI, error) {
+	return nil, nil
+}
+
+func (m *mockApigateway) GetRestApisPages(input *apigateway.GetRestApisInput, fn func(p *apigateway.GetRestApisOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*apigateway.RestApi
+	for i := 0; i < len(m.restapis); i += 2 {
+		page := []*apigateway.RestApi{m.restapis[i]}
+		if i+1 < len(m.restapis) {
+			page = append(page, m.restapis[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&apigateway.GetRestApisOutput{Items: page, Position: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
 type mockCloudwatch struct {
 	cloudwatchiface.CloudWatchAPI
 	metrics      []*cloudwatch.Metric
@@ -950,7 +1353,8 @@ func (m *mockCloudwatch) DescribeAlarmsPages(input *cloudwatch.DescribeAlarmsInp
 
 type mockCloudfront struct {
 	cloudfrontiface.CloudFrontAPI
-	distributionsummarys []*cloudfront.DistributionSummary
+	distributionsummarys         []*cloudfront.DistributionSummary
+	originaccessidentitysummarys []*cloudfront.OriginAccessIdentitySummary
 }
 
 func (m *mockCloudfront) Name() string {
@@ -1115,6 +1519,8 @@ type mockEcs struct {
 	tasksNames              map[string][]*string
 	containerinstancesNames map[string][]*string
 	containerinstances      map[string][]*ecs.ContainerInstance
+	servicesNames           map[string][]*string
+	services                map[string][]*ecs.Service
 }
 
 func (m *mockEcs) Name() string {
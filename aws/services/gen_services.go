@@ -26,10 +26,11 @@ import (
 	"sync"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/acm"
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
@@ -40,16 +41,22 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/rds"
@@ -58,6 +65,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
@@ -65,6 +74,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/wallix/awless/aws/fetch"
+	"github.com/wallix/awless/aws/pool"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/fetch"
 	"github.com/wallix/awless/graph"
@@ -72,8 +82,6 @@ import (
 	tstore "github.com/wallix/triplestore"
 )
 
-const accessDenied = "Access Denied"
-
 var ServiceNames = []string{
 	"infra",
 	"access",
@@ -102,20 +110,30 @@ var ResourceTypes = []string{
 	"elasticip",
 	"snapshot",
 	"networkinterface",
+	"spotfleet",
+	"spotrequest",
 	"loadbalancer",
 	"targetgroup",
 	"listener",
 	"database",
 	"dbsubnetgroup",
+	"cachecluster",
+	"replicationgroup",
+	"table",
+	"tableindex",
 	"launchconfiguration",
 	"scalinggroup",
 	"scalingpolicy",
 	"repository",
 	"containercluster",
+	"containerservice",
 	"containertask",
 	"container",
 	"containerinstance",
 	"certificate",
+	"key",
+	"alias",
+	"keygrant",
 	"user",
 	"group",
 	"role",
@@ -130,10 +148,19 @@ var ResourceTypes = []string{
 	"queue",
 	"zone",
 	"record",
+	"healthcheck",
 	"function",
+	"functionalias",
+	"eventsourcemapping",
+	"statemachine",
+	"execution",
+	"restapi",
+	"apistage",
+	"apideployment",
 	"metric",
 	"alarm",
 	"distribution",
+	"originaccessidentity",
 	"stack",
 }
 
@@ -141,11 +168,14 @@ var ServicePerAPI = map[string]string{
 	"ec2":         "infra",
 	"elbv2":       "infra",
 	"rds":         "infra",
+	"dynamodb":    "infra",
 	"autoscaling": "infra",
 	"ecr":         "infra",
 	"ecs":         "infra",
 	"applicationautoscaling": "infra",
 	"acm":            "infra",
+	"kms":                    "infra",
+	"elasticache":            "infra",
 	"iam":            "access",
 	"sts":            "access",
 	"s3":             "storage",
@@ -153,111 +183,151 @@ var ServicePerAPI = map[string]string{
 	"sqs":            "messaging",
 	"route53":        "dns",
 	"lambda":         "lambda",
+	"sfn":            "lambda",
+	"apigateway":     "lambda",
 	"cloudwatch":     "monitoring",
 	"cloudfront":     "cdn",
 	"cloudformation": "cloudformation",
 }
 
 var ServicePerResourceType = map[string]string{
-	"instance":            "infra",
-	"subnet":              "infra",
-	"vpc":                 "infra",
-	"keypair":             "infra",
-	"securitygroup":       "infra",
-	"volume":              "infra",
-	"internetgateway":     "infra",
-	"natgateway":          "infra",
-	"routetable":          "infra",
-	"availabilityzone":    "infra",
-	"image":               "infra",
-	"importimagetask":     "infra",
-	"elasticip":           "infra",
-	"snapshot":            "infra",
-	"networkinterface":    "infra",
-	"loadbalancer":        "infra",
-	"targetgroup":         "infra",
-	"listener":            "infra",
-	"database":            "infra",
-	"dbsubnetgroup":       "infra",
-	"launchconfiguration": "infra",
-	"scalinggroup":        "infra",
-	"scalingpolicy":       "infra",
-	"repository":          "infra",
-	"containercluster":    "infra",
-	"containertask":       "infra",
-	"container":           "infra",
-	"containerinstance":   "infra",
-	"certificate":         "infra",
-	"user":                "access",
-	"group":               "access",
-	"role":                "access",
-	"policy":              "access",
-	"accesskey":           "access",
-	"instanceprofile":     "access",
-	"mfadevice":           "access",
-	"bucket":              "storage",
-	"s3object":            "storage",
-	"subscription":        "messaging",
-	"topic":               "messaging",
-	"queue":               "messaging",
-	"zone":                "dns",
-	"record":              "dns",
-	"function":            "lambda",
-	"metric":              "monitoring",
-	"alarm":               "monitoring",
-	"distribution":        "cdn",
-	"stack":               "cloudformation",
+	"instance":             "infra",
+	"subnet":               "infra",
+	"vpc":                  "infra",
+	"keypair":              "infra",
+	"securitygroup":        "infra",
+	"volume":               "infra",
+	"internetgateway":      "infra",
+	"natgateway":           "infra",
+	"routetable":           "infra",
+	"availabilityzone":     "infra",
+	"image":                "infra",
+	"importimagetask":      "infra",
+	"elasticip":            "infra",
+	"snapshot":             "infra",
+	"networkinterface":     "infra",
+	"spotfleet":            "infra",
+	"spotrequest":          "infra",
+	"loadbalancer":         "infra",
+	"targetgroup":          "infra",
+	"listener":             "infra",
+	"database":             "infra",
+	"dbsubnetgroup":        "infra",
+	"cachecluster":         "infra",
+	"replicationgroup":     "infra",
+	"table":                "infra",
+	"tableindex":           "infra",
+	"launchconfiguration":  "infra",
+	"scalinggroup":         "infra",
+	"scalingpolicy":        "infra",
+	"repository":           "infra",
+	"containercluster":     "infra",
+	"containerservice":     "infra",
+	"containertask":        "infra",
+	"container":            "infra",
+	"containerinstance":    "infra",
+	"certificate":          "infra",
+	"key":                  "infra",
+	"alias":                "infra",
+	"keygrant":             "infra",
+	"user":                 "access",
+	"group":                "access",
+	"role":                 "access",
+	"policy":               "access",
+	"accesskey":            "access",
+	"instanceprofile":      "access",
+	"mfadevice":            "access",
+	"bucket":               "storage",
+	"s3object":             "storage",
+	"subscription":         "messaging",
+	"topic":                "messaging",
+	"queue":                "messaging",
+	"zone":                 "dns",
+	"record":               "dns",
+	"healthcheck":          "dns",
+	"function":             "lambda",
+	"functionalias":        "lambda",
+	"eventsourcemapping":   "lambda",
+	"statemachine":         "lambda",
+	"execution":            "lambda",
+	"restapi":              "lambda",
+	"apistage":             "lambda",
+	"apideployment":        "lambda",
+	"metric":               "monitoring",
+	"alarm":                "monitoring",
+	"distribution":         "cdn",
+	"originaccessidentity": "cdn",
+	"stack":                "cloudformation",
 }
 
 var APIPerResourceType = map[string]string{
-	"instance":            "ec2",
-	"subnet":              "ec2",
-	"vpc":                 "ec2",
-	"keypair":             "ec2",
-	"securitygroup":       "ec2",
-	"volume":              "ec2",
-	"internetgateway":     "ec2",
-	"natgateway":          "ec2",
-	"routetable":          "ec2",
-	"availabilityzone":    "ec2",
-	"image":               "ec2",
-	"importimagetask":     "ec2",
-	"elasticip":           "ec2",
-	"snapshot":            "ec2",
-	"networkinterface":    "ec2",
-	"loadbalancer":        "elbv2",
-	"targetgroup":         "elbv2",
-	"listener":            "elbv2",
-	"database":            "rds",
-	"dbsubnetgroup":       "rds",
-	"launchconfiguration": "autoscaling",
-	"scalinggroup":        "autoscaling",
-	"scalingpolicy":       "autoscaling",
-	"repository":          "ecr",
-	"containercluster":    "ecs",
-	"containertask":       "ecs",
-	"container":           "ecs",
-	"containerinstance":   "ecs",
-	"certificate":         "acm",
-	"user":                "iam",
-	"group":               "iam",
-	"role":                "iam",
-	"policy":              "iam",
-	"accesskey":           "iam",
-	"instanceprofile":     "iam",
-	"mfadevice":           "iam",
-	"bucket":              "s3",
-	"s3object":            "s3",
-	"subscription":        "sns",
-	"topic":               "sns",
-	"queue":               "sqs",
-	"zone":                "route53",
-	"record":              "route53",
-	"function":            "lambda",
-	"metric":              "cloudwatch",
-	"alarm":               "cloudwatch",
-	"distribution":        "cloudfront",
-	"stack":               "cloudformation",
+	"instance":             "ec2",
+	"subnet":               "ec2",
+	"vpc":                  "ec2",
+	"keypair":              "ec2",
+	"securitygroup":        "ec2",
+	"volume":               "ec2",
+	"internetgateway":      "ec2",
+	"natgateway":           "ec2",
+	"routetable":           "ec2",
+	"availabilityzone":     "ec2",
+	"image":                "ec2",
+	"importimagetask":      "ec2",
+	"elasticip":            "ec2",
+	"snapshot":             "ec2",
+	"networkinterface":     "ec2",
+	"spotfleet":            "ec2",
+	"spotrequest":          "ec2",
+	"loadbalancer":         "elbv2",
+	"targetgroup":          "elbv2",
+	"listener":             "elbv2",
+	"database":             "rds",
+	"dbsubnetgroup":        "rds",
+	"cachecluster":         "elasticache",
+	"replicationgroup":     "elasticache",
+	"table":                "dynamodb",
+	"tableindex":           "dynamodb",
+	"launchconfiguration":  "autoscaling",
+	"scalinggroup":         "autoscaling",
+	"scalingpolicy":        "autoscaling",
+	"repository":           "ecr",
+	"containercluster":     "ecs",
+	"containerservice":     "ecs",
+	"containertask":        "ecs",
+	"container":            "ecs",
+	"containerinstance":    "ecs",
+	"certificate":          "acm",
+	"key":                  "kms",
+	"alias":                "kms",
+	"keygrant":             "kms",
+	"user":                 "iam",
+	"group":                "iam",
+	"role":                 "iam",
+	"policy":               "iam",
+	"accesskey":            "iam",
+	"instanceprofile":      "iam",
+	"mfadevice":            "iam",
+	"bucket":               "s3",
+	"s3object":             "s3",
+	"subscription":         "sns",
+	"topic":                "sns",
+	"queue":                "sqs",
+	"zone":                 "route53",
+	"record":               "route53",
+	"healthcheck":          "route53",
+	"function":             "lambda",
+	"functionalias":        "lambda",
+	"eventsourcemapping":   "lambda",
+	"statemachine":         "sfn",
+	"execution":            "sfn",
+	"restapi":              "apigateway",
+	"apistage":             "apigateway",
+	"apideployment":        "apigateway",
+	"metric":               "cloudwatch",
+	"alarm":                "cloudwatch",
+	"distribution":         "cloudfront",
+	"originaccessidentity": "cloudfront",
+	"stack":                "cloudformation",
 }
 
 type Infra struct {
@@ -268,33 +338,42 @@ type Infra struct {
 	ec2iface.EC2API
 	elbv2iface.ELBV2API
 	rdsiface.RDSAPI
+	dynamodbiface.DynamoDBAPI
 	autoscalingiface.AutoScalingAPI
 	ecriface.ECRAPI
 	ecsiface.ECSAPI
 	applicationautoscalingiface.ApplicationAutoScalingAPI
 	acmiface.ACMAPI
+	kmsiface.KMSAPI
+	elasticacheiface.ElastiCacheAPI
 }
 
 func NewInfra(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := awssdk.StringValue(sess.Config.Region)
-	ec2API := ec2.New(sess)
-	elbv2API := elbv2.New(sess)
-	rdsAPI := rds.New(sess)
-	autoscalingAPI := autoscaling.New(sess)
-	ecrAPI := ecr.New(sess)
-	ecsAPI := ecs.New(sess)
-	applicationautoscalingAPI := applicationautoscaling.New(sess)
-	acmAPI := acm.New(sess)
+	ec2API := awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	elbv2API := awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
+	rdsAPI := awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
+	dynamodbAPI := awspool.Default.Client(sess, "dynamodb", func() interface{} { return dynamodb.New(sess) }).(dynamodbiface.DynamoDBAPI)
+	autoscalingAPI := awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
+	ecrAPI := awspool.Default.Client(sess, "ecr", func() interface{} { return ecr.New(sess) }).(ecriface.ECRAPI)
+	ecsAPI := awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
+	applicationautoscalingAPI := awspool.Default.Client(sess, "applicationautoscaling", func() interface{} { return applicationautoscaling.New(sess) }).(applicationautoscalingiface.ApplicationAutoScalingAPI)
+	acmAPI := awspool.Default.Client(sess, "acm", func() interface{} { return acm.New(sess) }).(acmiface.ACMAPI)
+	kmsAPI := awspool.Default.Client(sess, "kms", func() interface{} { return kms.New(sess) }).(kmsiface.KMSAPI)
+	elasticacheAPI := awspool.Default.Client(sess, "elasticache", func() interface{} { return elasticache.New(sess) }).(elasticacheiface.ElastiCacheAPI)
 
 	fetchConfig := awsfetch.NewConfig(
 		ec2API,
 		elbv2API,
 		rdsAPI,
+		dynamodbAPI,
 		autoscalingAPI,
 		ecrAPI,
 		ecsAPI,
 		applicationautoscalingAPI,
 		acmAPI,
+		kmsAPI,
+		elasticacheAPI,
 	)
 	fetchConfig.Extra = extraConf
 	fetchConfig.Log = log
@@ -303,12 +382,15 @@ func NewInfra(sess *session.Session, profile string, extraConf map[string]interf
 		EC2API:         ec2API,
 		ELBV2API:       elbv2API,
 		RDSAPI:         rdsAPI,
+		DynamoDBAPI:    dynamodbAPI,
 		AutoScalingAPI: autoscalingAPI,
 		ECRAPI:         ecrAPI,
 		ECSAPI:         ecsAPI,
 		ApplicationAutoScalingAPI: applicationautoscalingAPI,
 		ACMAPI:  acmAPI,
-		fetcher: fetch.NewFetcher(awsfetch.BuildInfraFetchFuncs(fetchConfig)),
+		KMSAPI:                    kmsAPI,
+		ElastiCacheAPI:            elasticacheAPI,
+		fetcher:                   fetch.NewFetcher(awsfetch.BuildInfraFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config:  extraConf,
 		region:  region,
 		profile: profile,
@@ -345,20 +427,30 @@ func (s *Infra) ResourceTypes() []string {
 		"elasticip",
 		"snapshot",
 		"networkinterface",
+		"spotfleet",
+		"spotrequest",
 		"loadbalancer",
 		"targetgroup",
 		"listener",
 		"database",
 		"dbsubnetgroup",
+	"cachecluster",
+	"replicationgroup",
+		"table",
+		"tableindex",
 		"launchconfiguration",
 		"scalinggroup",
 		"scalingpolicy",
 		"repository",
 		"containercluster",
+		"containerservice",
 		"containertask",
 		"container",
 		"containerinstance",
 		"certificate",
+		"key",
+		"alias",
+		"keygrant",
 	}
 }
 
@@ -367,26 +459,10 @@ func (s *Infra) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -726,6 +802,50 @@ func (s *Infra) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			}
 		}
 	}
+	if getBool(s.config, "aws.infra.spotfleet.sync", true) {
+		list, err := s.fetcher.Get("spotfleet_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*ec2.SpotFleetRequestConfig); !ok {
+			return gph, errors.New("cannot cast to '[]*ec2.SpotFleetRequestConfig' type from fetch context")
+		}
+		for _, r := range list.([]*ec2.SpotFleetRequestConfig) {
+			for _, fn := range addParentsFns["spotfleet"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *ec2.SpotFleetRequestConfig) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.infra.spotrequest.sync", true) {
+		list, err := s.fetcher.Get("spotrequest_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*ec2.SpotInstanceRequest); !ok {
+			return gph, errors.New("cannot cast to '[]*ec2.SpotInstanceRequest' type from fetch context")
+		}
+		for _, r := range list.([]*ec2.SpotInstanceRequest) {
+			for _, fn := range addParentsFns["spotrequest"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *ec2.SpotInstanceRequest) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
 	if getBool(s.config, "aws.infra.loadbalancer.sync", true) {
 		list, err := s.fetcher.Get("loadbalancer_objects")
 		if err != nil {
@@ -836,6 +956,94 @@ func (s *Infra) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			}
 		}
 	}
+	if getBool(s.config, "aws.infra.cachecluster.sync", true) {
+		list, err := s.fetcher.Get("cachecluster_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*elasticache.CacheCluster); !ok {
+			return gph, errors.New("cannot cast to '[]*elasticache.CacheCluster' type from fetch context")
+		}
+		for _, r := range list.([]*elasticache.CacheCluster) {
+			for _, fn := range addParentsFns["cachecluster"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *elasticache.CacheCluster) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.infra.replicationgroup.sync", true) {
+		list, err := s.fetcher.Get("replicationgroup_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*elasticache.ReplicationGroup); !ok {
+			return gph, errors.New("cannot cast to '[]*elasticache.ReplicationGroup' type from fetch context")
+		}
+		for _, r := range list.([]*elasticache.ReplicationGroup) {
+			for _, fn := range addParentsFns["replicationgroup"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *elasticache.ReplicationGroup) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.infra.table.sync", true) {
+		list, err := s.fetcher.Get("table_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*dynamodb.TableDescription); !ok {
+			return gph, errors.New("cannot cast to '[]*dynamodb.TableDescription' type from fetch context")
+		}
+		for _, r := range list.([]*dynamodb.TableDescription) {
+			for _, fn := range addParentsFns["table"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *dynamodb.TableDescription) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.infra.tableindex.sync", true) {
+		list, err := s.fetcher.Get("tableindex_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*dynamodb.GlobalSecondaryIndexDescription); !ok {
+			return gph, errors.New("cannot cast to '[]*dynamodb.GlobalSecondaryIndexDescription' type from fetch context")
+		}
+		for _, r := range list.([]*dynamodb.GlobalSecondaryIndexDescription) {
+			for _, fn := range addParentsFns["tableindex"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *dynamodb.GlobalSecondaryIndexDescription) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
 	if getBool(s.config, "aws.infra.launchconfiguration.sync", true) {
 		list, err := s.fetcher.Get("launchconfiguration_objects")
 		if err != nil {
@@ -946,6 +1154,28 @@ func (s *Infra) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			}
 		}
 	}
+	if getBool(s.config, "aws.infra.containerservice.sync", true) {
+		list, err := s.fetcher.Get("containerservice_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*ecs.Service); !ok {
+			return gph, errors.New("cannot cast to '[]*ecs.Service' type from fetch context")
+		}
+		for _, r := range list.([]*ecs.Service) {
+			for _, fn := range addParentsFns["containerservice"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *ecs.Service) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
 	if getBool(s.config, "aws.infra.containertask.sync", true) {
 		list, err := s.fetcher.Get("containertask_objects")
 		if err != nil {
@@ -1034,6 +1264,72 @@ func (s *Infra) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			}
 		}
 	}
+	if getBool(s.config, "aws.infra.key.sync", true) {
+		list, err := s.fetcher.Get("key_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*kms.KeyMetadata); !ok {
+			return gph, errors.New("cannot cast to '[]*kms.KeyMetadata' type from fetch context")
+		}
+		for _, r := range list.([]*kms.KeyMetadata) {
+			for _, fn := range addParentsFns["key"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *kms.KeyMetadata) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.infra.alias.sync", true) {
+		list, err := s.fetcher.Get("alias_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*kms.AliasListEntry); !ok {
+			return gph, errors.New("cannot cast to '[]*kms.AliasListEntry' type from fetch context")
+		}
+		for _, r := range list.([]*kms.AliasListEntry) {
+			for _, fn := range addParentsFns["alias"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *kms.AliasListEntry) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.infra.keygrant.sync", true) {
+		list, err := s.fetcher.Get("keygrant_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*kms.GrantListEntry); !ok {
+			return gph, errors.New("cannot cast to '[]*kms.GrantListEntry' type from fetch context")
+		}
+		for _, r := range list.([]*kms.GrantListEntry) {
+			for _, fn := range addParentsFns["keygrant"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *kms.GrantListEntry) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
 
 	go func() {
 		wg.Wait()
@@ -1073,8 +1369,8 @@ type Access struct {
 
 func NewAccess(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := "global"
-	iamAPI := iam.New(sess)
-	stsAPI := sts.New(sess)
+	iamAPI := awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
+	stsAPI := awspool.Default.Client(sess, "sts", func() interface{} { return sts.New(sess) }).(stsiface.STSAPI)
 
 	fetchConfig := awsfetch.NewConfig(
 		iamAPI,
@@ -1086,7 +1382,7 @@ func NewAccess(sess *session.Session, profile string, extraConf map[string]inter
 	return &Access{
 		IAMAPI:  iamAPI,
 		STSAPI:  stsAPI,
-		fetcher: fetch.NewFetcher(awsfetch.BuildAccessFetchFuncs(fetchConfig)),
+		fetcher: fetch.NewFetcher(awsfetch.BuildAccessFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config:  extraConf,
 		region:  region,
 		profile: profile,
@@ -1123,26 +1419,10 @@ func (s *Access) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1344,7 +1624,7 @@ type Storage struct {
 
 func NewStorage(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := awssdk.StringValue(sess.Config.Region)
-	s3API := s3.New(sess)
+	s3API := awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
 
 	fetchConfig := awsfetch.NewConfig(
 		s3API,
@@ -1354,7 +1634,7 @@ func NewStorage(sess *session.Session, profile string, extraConf map[string]inte
 
 	return &Storage{
 		S3API:   s3API,
-		fetcher: fetch.NewFetcher(awsfetch.BuildStorageFetchFuncs(fetchConfig)),
+		fetcher: fetch.NewFetcher(awsfetch.BuildStorageFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config:  extraConf,
 		region:  region,
 		profile: profile,
@@ -1386,26 +1666,10 @@ func (s *Storage) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1498,8 +1762,8 @@ type Messaging struct {
 
 func NewMessaging(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := awssdk.StringValue(sess.Config.Region)
-	snsAPI := sns.New(sess)
-	sqsAPI := sqs.New(sess)
+	snsAPI := awspool.Default.Client(sess, "sns", func() interface{} { return sns.New(sess) }).(snsiface.SNSAPI)
+	sqsAPI := awspool.Default.Client(sess, "sqs", func() interface{} { return sqs.New(sess) }).(sqsiface.SQSAPI)
 
 	fetchConfig := awsfetch.NewConfig(
 		snsAPI,
@@ -1511,7 +1775,7 @@ func NewMessaging(sess *session.Session, profile string, extraConf map[string]in
 	return &Messaging{
 		SNSAPI:  snsAPI,
 		SQSAPI:  sqsAPI,
-		fetcher: fetch.NewFetcher(awsfetch.BuildMessagingFetchFuncs(fetchConfig)),
+		fetcher: fetch.NewFetcher(awsfetch.BuildMessagingFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config:  extraConf,
 		region:  region,
 		profile: profile,
@@ -1544,26 +1808,10 @@ func (s *Messaging) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1677,7 +1925,7 @@ type Dns struct {
 
 func NewDns(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := "global"
-	route53API := route53.New(sess)
+	route53API := awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 
 	fetchConfig := awsfetch.NewConfig(
 		route53API,
@@ -1687,7 +1935,7 @@ func NewDns(sess *session.Session, profile string, extraConf map[string]interfac
 
 	return &Dns{
 		Route53API: route53API,
-		fetcher:    fetch.NewFetcher(awsfetch.BuildDnsFetchFuncs(fetchConfig)),
+		fetcher:    fetch.NewFetcher(awsfetch.BuildDnsFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config:     extraConf,
 		region:     region,
 		profile:    profile,
@@ -1711,6 +1959,7 @@ func (s *Dns) ResourceTypes() []string {
 	return []string{
 		"zone",
 		"record",
+		"healthcheck",
 	}
 }
 
@@ -1719,26 +1968,10 @@ func (s *Dns) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1792,6 +2025,28 @@ func (s *Dns) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			}
 		}
 	}
+	if getBool(s.config, "aws.dns.healthcheck.sync", true) {
+		list, err := s.fetcher.Get("healthcheck_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*route53.HealthCheck); !ok {
+			return gph, errors.New("cannot cast to '[]*route53.HealthCheck' type from fetch context")
+		}
+		for _, r := range list.([]*route53.HealthCheck) {
+			for _, fn := range addParentsFns["healthcheck"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *route53.HealthCheck) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
 
 	go func() {
 		wg.Wait()
@@ -1826,25 +2081,33 @@ type Lambda struct {
 	config          map[string]interface{}
 	log             *logger.Logger
 	lambdaiface.LambdaAPI
+	sfniface.SFNAPI
+	apigatewayiface.APIGatewayAPI
 }
 
 func NewLambda(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := awssdk.StringValue(sess.Config.Region)
-	lambdaAPI := lambda.New(sess)
+	lambdaAPI := awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
+	sfnAPI := awspool.Default.Client(sess, "sfn", func() interface{} { return sfn.New(sess) }).(sfniface.SFNAPI)
+	apigatewayAPI := awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 
 	fetchConfig := awsfetch.NewConfig(
 		lambdaAPI,
+		sfnAPI,
+		apigatewayAPI,
 	)
 	fetchConfig.Extra = extraConf
 	fetchConfig.Log = log
 
 	return &Lambda{
-		LambdaAPI: lambdaAPI,
-		fetcher:   fetch.NewFetcher(awsfetch.BuildLambdaFetchFuncs(fetchConfig)),
-		config:    extraConf,
-		region:    region,
-		profile:   profile,
-		log:       log,
+		LambdaAPI:     lambdaAPI,
+		SFNAPI:        sfnAPI,
+		APIGatewayAPI: apigatewayAPI,
+		fetcher:       fetch.NewFetcher(awsfetch.BuildLambdaFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
+		config:        extraConf,
+		region:        region,
+		profile:       profile,
+		log:           log,
 	}
 }
 
@@ -1863,6 +2126,13 @@ func (s *Lambda) Profile() string {
 func (s *Lambda) ResourceTypes() []string {
 	return []string{
 		"function",
+		"functionalias",
+		"eventsourcemapping",
+		"statemachine",
+		"execution",
+		"restapi",
+		"apistage",
+		"apideployment",
 	}
 }
 
@@ -1871,26 +2141,10 @@ func (s *Lambda) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -1922,6 +2176,160 @@ func (s *Lambda) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			}
 		}
 	}
+	if getBool(s.config, "aws.lambda.functionalias.sync", true) {
+		list, err := s.fetcher.Get("functionalias_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*lambda.AliasConfiguration); !ok {
+			return gph, errors.New("cannot cast to '[]*lambda.AliasConfiguration' type from fetch context")
+		}
+		for _, r := range list.([]*lambda.AliasConfiguration) {
+			for _, fn := range addParentsFns["functionalias"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *lambda.AliasConfiguration) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.lambda.eventsourcemapping.sync", true) {
+		list, err := s.fetcher.Get("eventsourcemapping_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*lambda.EventSourceMappingConfiguration); !ok {
+			return gph, errors.New("cannot cast to '[]*lambda.EventSourceMappingConfiguration' type from fetch context")
+		}
+		for _, r := range list.([]*lambda.EventSourceMappingConfiguration) {
+			for _, fn := range addParentsFns["eventsourcemapping"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *lambda.EventSourceMappingConfiguration) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.lambda.statemachine.sync", true) {
+		list, err := s.fetcher.Get("statemachine_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*sfn.StateMachineListItem); !ok {
+			return gph, errors.New("cannot cast to '[]*sfn.StateMachineListItem' type from fetch context")
+		}
+		for _, r := range list.([]*sfn.StateMachineListItem) {
+			for _, fn := range addParentsFns["statemachine"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *sfn.StateMachineListItem) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.lambda.execution.sync", true) {
+		list, err := s.fetcher.Get("execution_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*sfn.ExecutionListItem); !ok {
+			return gph, errors.New("cannot cast to '[]*sfn.ExecutionListItem' type from fetch context")
+		}
+		for _, r := range list.([]*sfn.ExecutionListItem) {
+			for _, fn := range addParentsFns["execution"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *sfn.ExecutionListItem) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.lambda.restapi.sync", true) {
+		list, err := s.fetcher.Get("restapi_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*apigateway.RestApi); !ok {
+			return gph, errors.New("cannot cast to '[]*apigateway.RestApi' type from fetch context")
+		}
+		for _, r := range list.([]*apigateway.RestApi) {
+			for _, fn := range addParentsFns["restapi"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *apigateway.RestApi) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.lambda.apistage.sync", true) {
+		list, err := s.fetcher.Get("apistage_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*apigateway.Stage); !ok {
+			return gph, errors.New("cannot cast to '[]*apigateway.Stage' type from fetch context")
+		}
+		for _, r := range list.([]*apigateway.Stage) {
+			for _, fn := range addParentsFns["apistage"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *apigateway.Stage) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
+	if getBool(s.config, "aws.lambda.apideployment.sync", true) {
+		list, err := s.fetcher.Get("apideployment_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*apigateway.Deployment); !ok {
+			return gph, errors.New("cannot cast to '[]*apigateway.Deployment' type from fetch context")
+		}
+		for _, r := range list.([]*apigateway.Deployment) {
+			for _, fn := range addParentsFns["apideployment"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *apigateway.Deployment) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
 
 	go func() {
 		wg.Wait()
@@ -1960,7 +2368,7 @@ type Monitoring struct {
 
 func NewMonitoring(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := awssdk.StringValue(sess.Config.Region)
-	cloudwatchAPI := cloudwatch.New(sess)
+	cloudwatchAPI := awspool.Default.Client(sess, "cloudwatch", func() interface{} { return cloudwatch.New(sess) }).(cloudwatchiface.CloudWatchAPI)
 
 	fetchConfig := awsfetch.NewConfig(
 		cloudwatchAPI,
@@ -1970,7 +2378,7 @@ func NewMonitoring(sess *session.Session, profile string, extraConf map[string]i
 
 	return &Monitoring{
 		CloudWatchAPI: cloudwatchAPI,
-		fetcher:       fetch.NewFetcher(awsfetch.BuildMonitoringFetchFuncs(fetchConfig)),
+		fetcher:       fetch.NewFetcher(awsfetch.BuildMonitoringFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config:        extraConf,
 		region:        region,
 		profile:       profile,
@@ -2002,26 +2410,10 @@ func (s *Monitoring) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -2113,7 +2505,7 @@ type Cdn struct {
 
 func NewCdn(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := "global"
-	cloudfrontAPI := cloudfront.New(sess)
+	cloudfrontAPI := awspool.Default.Client(sess, "cloudfront", func() interface{} { return cloudfront.New(sess) }).(cloudfrontiface.CloudFrontAPI)
 
 	fetchConfig := awsfetch.NewConfig(
 		cloudfrontAPI,
@@ -2123,7 +2515,7 @@ func NewCdn(sess *session.Session, profile string, extraConf map[string]interfac
 
 	return &Cdn{
 		CloudFrontAPI: cloudfrontAPI,
-		fetcher:       fetch.NewFetcher(awsfetch.BuildCdnFetchFuncs(fetchConfig)),
+		fetcher:       fetch.NewFetcher(awsfetch.BuildCdnFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config:        extraConf,
 		region:        region,
 		profile:       profile,
@@ -2146,6 +2538,7 @@ func (s *Cdn) Profile() string {
 func (s *Cdn) ResourceTypes() []string {
 	return []string{
 		"distribution",
+		"originaccessidentity",
 	}
 }
 
@@ -2154,26 +2547,10 @@ func (s *Cdn) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -2205,6 +2582,28 @@ func (s *Cdn) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 			}
 		}
 	}
+	if getBool(s.config, "aws.cdn.originaccessidentity.sync", true) {
+		list, err := s.fetcher.Get("originaccessidentity_objects")
+		if err != nil {
+			return gph, err
+		}
+		if _, ok := list.([]*cloudfront.OriginAccessIdentitySummary); !ok {
+			return gph, errors.New("cannot cast to '[]*cloudfront.OriginAccessIdentitySummary' type from fetch context")
+		}
+		for _, r := range list.([]*cloudfront.OriginAccessIdentitySummary) {
+			for _, fn := range addParentsFns["originaccessidentity"] {
+				wg.Add(1)
+				go func(f addParentFn, snap tstore.RDFGraph, region string, res *cloudfront.OriginAccessIdentitySummary) {
+					defer wg.Done()
+					err := f(gph, snap, region, res)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}(fn, snap, s.region, r)
+			}
+		}
+	}
 
 	go func() {
 		wg.Wait()
@@ -2243,7 +2642,7 @@ type Cloudformation struct {
 
 func NewCloudformation(sess *session.Session, profile string, extraConf map[string]interface{}, log *logger.Logger) cloud.Service {
 	region := awssdk.StringValue(sess.Config.Region)
-	cloudformationAPI := cloudformation.New(sess)
+	cloudformationAPI := awspool.Default.Client(sess, "cloudformation", func() interface{} { return cloudformation.New(sess) }).(cloudformationiface.CloudFormationAPI)
 
 	fetchConfig := awsfetch.NewConfig(
 		cloudformationAPI,
@@ -2253,7 +2652,7 @@ func NewCloudformation(sess *session.Session, profile string, extraConf map[stri
 
 	return &Cloudformation{
 		CloudFormationAPI: cloudformationAPI,
-		fetcher:           fetch.NewFetcher(awsfetch.BuildCloudformationFetchFuncs(fetchConfig)),
+		fetcher:           fetch.NewFetcher(awsfetch.BuildCloudformationFetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config:            extraConf,
 		region:            region,
 		profile:           profile,
@@ -2284,26 +2683,10 @@ func (s *Cloudformation) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
 	gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
 
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
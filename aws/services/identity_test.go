@@ -46,3 +46,37 @@ func TestGetIdentityParseAllTypesOfUsername(t *testing.T) {
 		}
 	}
 }
+
+func TestIdentityPolicySourceArn(t *testing.T) {
+	tcases := []struct {
+		arn, expArn, expErr string
+	}{
+		{arn: "arn:aws:iam::123456789012:root", expErr: "policies of the root account cannot be simulated"},
+		{arn: "arn:aws:iam::123456789012:user/division_abc/Donald", expArn: "arn:aws:iam::123456789012:user/division_abc/Donald"},
+		{arn: "arn:aws:sts::123456789012:assumed-role/Deployer/session-1", expArn: "arn:aws:iam::123456789012:role/Deployer"},
+		{arn: "arn:aws-cn:sts::123456789012:assumed-role/Deployer/session-1", expArn: "arn:aws-cn:iam::123456789012:role/Deployer"},
+		{arn: "arn:aws:sts::123456789012:federated-user/Bob", expErr: "policies of federated-user identities cannot be simulated"},
+	}
+
+	for _, tcase := range tcases {
+		out := &sts.GetCallerIdentityOutput{Arn: awssdk.String(tcase.arn), Account: awssdk.String("123456789012")}
+		access := Access{STSAPI: &mockSTS{output: out}}
+		id, err := access.GetIdentity()
+		if err != nil {
+			t.Fatal(err)
+		}
+		arn, err := id.PolicySourceArn()
+		if tcase.expErr != "" {
+			if err == nil || err.Error() != tcase.expErr {
+				t.Fatalf("%s: got %v, want %s", tcase.arn, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.arn, err)
+		}
+		if got, want := arn, tcase.expArn; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	}
+}
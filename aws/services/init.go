@@ -19,6 +19,8 @@ package awsservices
 import (
 	"errors"
 
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/wallix/awless/aws/pool"
 	"github.com/wallix/awless/aws/spec"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/graph"
@@ -38,11 +40,19 @@ func Init(profile, region string, extraConf map[string]interface{}, log *logger.
 	sb := newSessionResolver().withRegion(region).withProfile(profile).withNetworkMonitor(enableNetworkMonitor)
 	sb = sb.withProfileSetter(profileSetterCallback).withLogger(log).withCredentialResolvers()
 
-	sess, err := sb.resolve()
+	awspool.Default = awspool.New(func(p string) (*session.Session, error) {
+		if p == profile {
+			return sb.resolve()
+		}
+		return newSessionResolver().withRegion(region).withProfile(p).withLogger(log).withNetworkMonitor(enableNetworkMonitor).resolve()
+	})
+	sess, err := awspool.Default.Session(profile, "")
 	if err != nil {
 		return err
 	}
 
+	current.sess, current.profile, current.extraConf, current.log = sess, profile, extraConf, log
+
 	AccessService = NewAccess(sess, profile, extraConf, log)
 	InfraService = NewInfra(sess, profile, extraConf, log)
 	StorageService = NewStorage(sess, profile, extraConf, log)
@@ -0,0 +1,146 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+const instanceConnectServiceName = "ec2-instance-connect"
+
+// SendSSHPublicKey pushes a public key in the authorized_keys format for the OS user
+// of an instance of the current region, valid for the next 60 seconds
+func SendSSHPublicKey(instanceID, zone, user, publicKey string) error {
+	if current.sess == nil {
+		return errors.New("send ssh public key: AWS session not initialized")
+	}
+	return sendSSHPublicKey(newInstanceConnectClient(current.sess), instanceID, zone, user, publicKey)
+}
+
+// ImageDefaultUser returns the default OS user of the instances of an image of the current
+// region (ex: ec2-user for Amazon Linux, ubuntu for Ubuntu), guessed from the image name
+func ImageDefaultUser(imageID string) (string, error) {
+	if current.sess == nil {
+		return "", errors.New("image default user: AWS session not initialized")
+	}
+	return imageDefaultUser(ec2.New(current.sess), imageID)
+}
+
+// imageDefaultUsers are the default users by keyword of the image names, by order of precedence
+var imageDefaultUsers = []struct {
+	keyword, user string
+}{
+	{"bitnami", "bitnami"},
+	{"ubuntu", "ubuntu"},
+	{"debian", "admin"},
+	{"centos", "centos"},
+	{"fedora", "fedora"},
+	{"amzn", "ec2-user"},
+	{"amazon", "ec2-user"},
+	{"al2023", "ec2-user"},
+	{"rhel", "ec2-user"},
+	{"suse", "ec2-user"},
+}
+
+func imageDefaultUser(api ec2iface.EC2API, imageID string) (string, error) {
+	out, err := api.DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{awssdk.String(imageID)}})
+	if err != nil {
+		return "", fmt.Errorf("image default user: %s", err)
+	}
+	if len(out.Images) != 1 {
+		return "", fmt.Errorf("image default user: image %s not found", imageID)
+	}
+	name := strings.ToLower(awssdk.StringValue(out.Images[0].Name) + " " + awssdk.StringValue(out.Images[0].Description))
+	for _, u := range imageDefaultUsers {
+		if strings.Contains(name, u.keyword) {
+			return u.user, nil
+		}
+	}
+	return "", fmt.Errorf("image default user: unknown default user of image %s (%s)", imageID, awssdk.StringValue(out.Images[0].Name))
+}
+
+// The vendored SDK does not know the EC2 Instance Connect service,
+// so we build its JSON RPC client as the SDK does for the other services
+func newInstanceConnectClient(p client.ConfigProvider) *client.Client {
+	c := p.ClientConfig(instanceConnectServiceName)
+	cl := client.New(
+		*c.Config,
+		metadata.ClientInfo{
+			ServiceName:   instanceConnectServiceName,
+			SigningName:   c.SigningName,
+			SigningRegion: c.SigningRegion,
+			Endpoint:      c.Endpoint,
+			APIVersion:    "2018-04-02",
+			JSONVersion:   "1.1",
+			TargetPrefix:  "AWSEC2InstanceConnectService",
+		},
+		c.Handlers,
+	)
+	cl.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	cl.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	cl.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	cl.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	cl.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+	return cl
+}
+
+type sendSSHPublicKeyInput struct {
+	_                struct{} `type:"structure"`
+	AvailabilityZone *string  `type:"string" required:"true"`
+	InstanceId       *string  `type:"string" required:"true"`
+	InstanceOSUser   *string  `type:"string" required:"true"`
+	SSHPublicKey     *string  `type:"string" required:"true"`
+}
+
+type sendSSHPublicKeyOutput struct {
+	_         struct{} `type:"structure"`
+	RequestId *string  `type:"string"`
+	Success   *bool    `type:"boolean"`
+}
+
+func sendSSHPublicKey(cl *client.Client, instanceID, zone, user, publicKey string) error {
+	op := &request.Operation{
+		Name:       "SendSSHPublicKey",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	in := &sendSSHPublicKeyInput{
+		AvailabilityZone: awssdk.String(zone),
+		InstanceId:       awssdk.String(instanceID),
+		InstanceOSUser:   awssdk.String(user),
+		SSHPublicKey:     awssdk.String(publicKey),
+	}
+	out := &sendSSHPublicKeyOutput{}
+	if err := cl.NewRequest(op, in, out).Send(); err != nil {
+		return fmt.Errorf("send ssh public key: %s", err)
+	}
+	if !awssdk.BoolValue(out.Success) {
+		return fmt.Errorf("send ssh public key: rejected for user %s on %s (request %s)", user, instanceID, awssdk.StringValue(out.RequestId))
+	}
+	return nil
+}
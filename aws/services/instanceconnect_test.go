@@ -0,0 +1,110 @@
+package awsservices
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+func TestSendSSHPublicKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Amz-Target"), "AWSEC2InstanceConnectService.SendSSHPublicKey"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		if got, want := r.Header.Get("Authorization"), "/eu-west-1/ec2-instance-connect/aws4_request"; !strings.Contains(got, want) {
+			t.Errorf("got %s, want to contain %s", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), "i-unknown") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"EC2InstanceNotFoundException","Message":"Instance not found"}`))
+			return
+		}
+		if strings.Contains(string(body), "root") {
+			w.Write([]byte(`{"RequestId":"req-2","Success":false}`))
+			return
+		}
+		if got, want := string(body), `{"AvailabilityZone":"eu-west-1a","InstanceId":"i-1234","InstanceOSUser":"ec2-user","SSHPublicKey":"ssh-ed25519 AAAA"}`; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		w.Write([]byte(`{"RequestId":"req-1","Success":true}`))
+	}))
+	defer server.Close()
+
+	sess := session.Must(session.NewSession(&awssdk.Config{
+		Region:      awssdk.String("eu-west-1"),
+		Endpoint:    awssdk.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	}))
+	cl := newInstanceConnectClient(sess)
+
+	if err := sendSSHPublicKey(cl, "i-1234", "eu-west-1a", "ec2-user", "ssh-ed25519 AAAA"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sendSSHPublicKey(cl, "i-1234", "eu-west-1a", "root", "ssh-ed25519 AAAA"); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Fatalf("got %v, want rejected error", err)
+	}
+	if err := sendSSHPublicKey(cl, "i-unknown", "eu-west-1a", "ec2-user", "ssh-ed25519 AAAA"); err == nil || !strings.Contains(err.Error(), "EC2InstanceNotFoundException") {
+		t.Fatalf("got %v, want not found error", err)
+	}
+}
+
+type mockDescribeImages struct {
+	ec2iface.EC2API
+	images map[string]*ec2.Image
+}
+
+func (m *mockDescribeImages) DescribeImages(input *ec2.DescribeImagesInput) (*ec2.DescribeImagesOutput, error) {
+	out := &ec2.DescribeImagesOutput{}
+	for _, id := range input.ImageIds {
+		if img, ok := m.images[awssdk.StringValue(id)]; ok {
+			out.Images = append(out.Images, img)
+		}
+	}
+	return out, nil
+}
+
+func TestImageDefaultUser(t *testing.T) {
+	api := &mockDescribeImages{images: map[string]*ec2.Image{
+		"ami-amzn":    {Name: awssdk.String("amzn2-ami-hvm-2.0.20180622.1-x86_64-gp2")},
+		"ami-ubuntu":  {Name: awssdk.String("ubuntu/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server-20180814")},
+		"ami-debian":  {Name: awssdk.String("debian-stretch-hvm-x86_64-gp2-2018-08-13-59150")},
+		"ami-bitnami": {Name: awssdk.String("bitnami-wordpress-4.9.8-0-linux-ubuntu-14.04.3-x86_64-hvm-ebs")},
+		"ami-rhel":    {Name: awssdk.String("RHEL-7.5_HVM_GA-20180322-x86_64-1-Hourly2-GP2")},
+		"ami-custom":  {Name: awssdk.String("my-golden-image"), Description: awssdk.String("built from CentOS 7")},
+		"ami-unknown": {Name: awssdk.String("my-golden-image")},
+	}}
+	tcases := []struct {
+		image, exp string
+	}{
+		{image: "ami-amzn", exp: "ec2-user"},
+		{image: "ami-ubuntu", exp: "ubuntu"},
+		{image: "ami-debian", exp: "admin"},
+		{image: "ami-bitnami", exp: "bitnami"},
+		{image: "ami-rhel", exp: "ec2-user"},
+		{image: "ami-custom", exp: "centos"},
+	}
+	for _, tcase := range tcases {
+		user, err := imageDefaultUser(api, tcase.image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := user, tcase.exp; got != want {
+			t.Fatalf("%s: got %s, want %s", tcase.image, got, want)
+		}
+	}
+	if _, err := imageDefaultUser(api, "ami-unknown"); err == nil {
+		t.Fatal("expected error for image without known default user")
+	}
+	if _, err := imageDefaultUser(api, "ami-notfound"); err == nil {
+		t.Fatal("expected error for image not found")
+	}
+}
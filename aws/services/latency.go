@@ -0,0 +1,140 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// API whose regional endpoints are pinged: DynamoDB serves an unauthenticated /ping on every region
+const pingedAPI = "dynamodb"
+
+var pingClient = &http.Client{Timeout: 5 * time.Second}
+
+// RegionLatency is the round trip time to the endpoints of a region (median of the pings)
+type RegionLatency struct {
+	Region  string        `json:"region"`
+	Latency time.Duration `json:"latency"`
+	Err     error         `json:"-"`
+}
+
+// RegionsMatching returns the regions allowed by the patterns (ex: eu-*, us-east-1; all when empty)
+// and offering all the given APIs (as named in awless), or whose offer is unknown (see APIAvailabilityInRegion)
+func RegionsMatching(regions []*RegionInfo, allowed []string, apis []string) (matching []*RegionInfo) {
+	for _, r := range regions {
+		if len(allowed) > 0 && !matchesAnyRegionPattern(allowed, r.ID) {
+			continue
+		}
+		offering := true
+		for _, api := range apis {
+			if available, known := APIAvailabilityInRegion(api, r.ID); known && !available {
+				offering = false
+				break
+			}
+		}
+		if offering {
+			matching = append(matching, r)
+		}
+	}
+	return
+}
+
+func matchesAnyRegionPattern(patterns []string, region string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, region); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// PingRegions measures concurrently the latency to the regional endpoints, pinging each
+// of them the given number of times. Results are sorted by latency, unreachable regions last.
+func PingRegions(regions []string, attempts int) []*RegionLatency {
+	return pingRegions(regions, attempts, pingEndpoint)
+}
+
+func pingRegions(regions []string, attempts int, ping func(url string) (time.Duration, error)) []*RegionLatency {
+	if attempts < 1 {
+		attempts = 1
+	}
+	results := make([]*RegionLatency, len(regions))
+	var wg sync.WaitGroup
+	for i, region := range regions {
+		wg.Add(1)
+		go func(i int, region string) {
+			defer wg.Done()
+			results[i] = pingRegion(region, attempts, ping)
+		}(i, region)
+	}
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Err == nil) != (results[j].Err == nil) {
+			return results[i].Err == nil
+		}
+		return results[i].Latency < results[j].Latency
+	})
+	return results
+}
+
+func pingRegion(region string, attempts int, ping func(url string) (time.Duration, error)) *RegionLatency {
+	result := &RegionLatency{Region: region}
+	endpoint, err := endpoints.DefaultResolver().EndpointFor(pingedAPI, region)
+	if err != nil {
+		result.Err = fmt.Errorf("region %s: %s", region, err)
+		return result
+	}
+	url := endpoint.URL + "/ping"
+
+	var durations []time.Duration
+	for i := 0; i < attempts; i++ {
+		d, err := ping(url)
+		if err != nil {
+			result.Err = fmt.Errorf("region %s: %s", region, err)
+			continue
+		}
+		durations = append(durations, d)
+	}
+	if len(durations) == 0 {
+		return result
+	}
+	result.Err = nil
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	result.Latency = durations[len(durations)/2]
+	return result
+}
+
+func pingEndpoint(url string) (time.Duration, error) {
+	start := time.Now()
+	resp, err := pingClient.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	return time.Since(start), nil
+}
@@ -0,0 +1,84 @@
+package awsservices
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRegionsMatching(t *testing.T) {
+	regions := []*RegionInfo{{ID: "eu-west-1"}, {ID: "eu-west-3"}, {ID: "us-east-1"}, {ID: "ap-northeast-3"}}
+	tcases := []struct {
+		allowed, apis []string
+		exp           []string
+	}{
+		{exp: []string{"eu-west-1", "eu-west-3", "us-east-1", "ap-northeast-3"}},
+		{allowed: []string{"eu-*"}, exp: []string{"eu-west-1", "eu-west-3"}},
+		{allowed: []string{"eu-west-3", "us-east-1"}, exp: []string{"eu-west-3", "us-east-1"}},
+		{allowed: []string{"ca-*"}},
+	}
+	for i, tcase := range tcases {
+		var got []string
+		for _, r := range RegionsMatching(regions, tcase.allowed, tcase.apis) {
+			got = append(got, r.ID)
+		}
+		if want := tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+
+	var got []string
+	for _, r := range RegionsMatching([]*RegionInfo{{ID: "cn-northwest-1"}, {ID: "eu-north-1"}, {ID: "eu-west-1"}}, nil, []string{"lambda"}) {
+		got = append(got, r.ID)
+	}
+	if want := []string{"eu-north-1", "eu-west-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestPingRegions(t *testing.T) {
+	latencies := map[string][]time.Duration{
+		"eu-west-1": {30 * time.Millisecond, 200 * time.Millisecond, 32 * time.Millisecond},
+		"us-east-1": {90 * time.Millisecond, 80 * time.Millisecond, 85 * time.Millisecond},
+		"eu-west-3": {10 * time.Millisecond},
+	}
+	var mu sync.Mutex
+	var calls int
+	ping := func(url string) (time.Duration, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		for region, durations := range latencies {
+			if strings.Contains(url, region) {
+				d := durations[0]
+				latencies[region] = append(durations[1:], d)
+				return d, nil
+			}
+		}
+		return 0, errors.New("unreachable")
+	}
+	results := pingRegions([]string{"us-east-1", "ap-south-1", "eu-west-1", "eu-west-3"}, 3, ping)
+
+	var got []string
+	for _, r := range results {
+		got = append(got, r.Region)
+	}
+	if want := []string{"eu-west-3", "eu-west-1", "us-east-1", "ap-south-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := results[1].Latency, 32*time.Millisecond; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := results[2].Latency, 85*time.Millisecond; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if results[3].Err == nil {
+		t.Fatal("expected error for unreachable region")
+	}
+	if got, want := calls, 12; got != want {
+		t.Fatalf("got %d pings, want %d", got, want)
+	}
+}
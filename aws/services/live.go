@@ -0,0 +1,85 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/fetch"
+)
+
+var resourceTypesPerIDPrefix = []struct {
+	prefix, resourceType string
+}{
+	{"i-", cloud.Instance},
+	{"vpc-", cloud.Vpc},
+	{"subnet-", cloud.Subnet},
+	{"sg-", cloud.SecurityGroup},
+	{"vol-", cloud.Volume},
+	{"igw-", cloud.InternetGateway},
+	{"nat-", cloud.NatGateway},
+	{"rtb-", cloud.RouteTable},
+	{"ami-", cloud.Image},
+	{"import-ami-", cloud.ImportImageTask},
+	{"eipalloc-", cloud.ElasticIP},
+	{"snap-", cloud.Snapshot},
+	{"eni-", cloud.NetworkInterface},
+}
+
+// ResourceTypeFromID guesses the type of a resource from the prefix of its AWS ID (ex: 'i-' for instances)
+func ResourceTypeFromID(id string) (string, bool) {
+	for _, p := range resourceTypesPerIDPrefix {
+		if strings.HasPrefix(id, p.prefix) {
+			return p.resourceType, true
+		}
+	}
+	return "", false
+}
+
+// FetchResourceLive fetches from the AWS APIs the resource with the given ID, its type being guessed
+// from the ID prefix. The fetch is narrowed to the ID where the API allows it. The resource comes
+// without the relations computed on sync: it is meant to be merged into the local graphs.
+func FetchResourceLive(ctx context.Context, id string) (cloud.Resource, cloud.GraphAPI, error) {
+	resType, ok := ResourceTypeFromID(id)
+	if !ok {
+		return nil, nil, fmt.Errorf("cannot guess resource type of '%s'", id)
+	}
+	srvName, ok := ServicePerResourceType[resType]
+	if !ok {
+		return nil, nil, fmt.Errorf("cannot find service for resource type %s", resType)
+	}
+	srv, ok := cloud.ServiceRegistry[srvName]
+	if !ok {
+		return nil, nil, fmt.Errorf("service %s not initialized", srvName)
+	}
+
+	query := cloud.NewQuery(resType).Match(match.Property(properties.ID, id))
+	g, err := srv.FetchByType(fetch.WithQuery(context.WithValue(ctx, "force", true), query), resType)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := g.FindOne(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s '%s' not found live: %s", resType, id, err)
+	}
+	return res, g, nil
+}
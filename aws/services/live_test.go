@@ -0,0 +1,32 @@
+package awsservices
+
+import (
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+)
+
+func TestResourceTypeFromID(t *testing.T) {
+	tcases := []struct {
+		id      string
+		expType string
+		expOk   bool
+	}{
+		{id: "i-0123456789abcdef0", expType: cloud.Instance, expOk: true},
+		{id: "subnet-12345", expType: cloud.Subnet, expOk: true},
+		{id: "ami-12345", expType: cloud.Image, expOk: true},
+		{id: "import-ami-12345", expType: cloud.ImportImageTask, expOk: true},
+		{id: "eipalloc-12345", expType: cloud.ElasticIP, expOk: true},
+		{id: "my-instance", expOk: false},
+		{id: "arn:aws:iam::123456789012:user/jsmith", expOk: false},
+	}
+	for i, tcase := range tcases {
+		typ, ok := ResourceTypeFromID(tcase.id)
+		if got, want := ok, tcase.expOk; got != want {
+			t.Fatalf("%d: got %t, want %t", i+1, got, want)
+		}
+		if got, want := typ, tcase.expType; got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+	}
+}
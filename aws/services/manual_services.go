@@ -17,11 +17,18 @@ limitations under the License.
 package awsservices
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"regexp"
 	"strings"
 	"sync"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/wallix/awless/cloud"
 )
@@ -64,6 +71,96 @@ func ResourceTypesPerServiceName() map[string][]string {
 	return out
 }
 
+var ErrPasswordDataNotAvailable = errors.New("password not available yet (Windows instances need a few minutes after launch to generate it)")
+
+func (s *Infra) GetWindowsPasswordData(instanceID string) (string, error) {
+	out, err := s.GetPasswordData(&ec2.GetPasswordDataInput{InstanceId: awssdk.String(instanceID)})
+	if err != nil {
+		return "", err
+	}
+	data := strings.TrimSpace(awssdk.StringValue(out.PasswordData))
+	if data == "" {
+		return "", ErrPasswordDataNotAvailable
+	}
+	return data, nil
+}
+
+// GetInstanceUserData returns the decoded user data of an instance (gzipped user data is uncompressed)
+func (s *Infra) GetInstanceUserData(instanceID string) ([]byte, error) {
+	out, err := s.DescribeInstanceAttribute(&ec2.DescribeInstanceAttributeInput{
+		Attribute:  awssdk.String(ec2.InstanceAttributeNameUserData),
+		InstanceId: awssdk.String(instanceID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if out.UserData == nil || awssdk.StringValue(out.UserData.Value) == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(awssdk.StringValue(out.UserData.Value))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode user data: %s", err)
+	}
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("cannot uncompress user data: %s", err)
+		}
+		defer r.Close()
+		return ioutil.ReadAll(r)
+	}
+	return data, nil
+}
+
+// TagResources sets the key tag to value on EC2 resources given their IDs
+func (s *Infra) TagResources(ids []string, key, value string) error {
+	_, err := s.CreateTags(&ec2.CreateTagsInput{
+		Resources: awssdk.StringSlice(ids),
+		Tags:      []*ec2.Tag{{Key: awssdk.String(key), Value: awssdk.String(value)}},
+	})
+	return err
+}
+
+// LaunchTemplatesImages returns the IDs of the images referenced by any version of the launch templates
+func (s *Infra) LaunchTemplatesImages() ([]string, error) {
+	var templates []*ec2.LaunchTemplate
+	templatesInput := &ec2.DescribeLaunchTemplatesInput{}
+	for {
+		out, err := s.DescribeLaunchTemplates(templatesInput)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, out.LaunchTemplates...)
+		if out.NextToken == nil {
+			break
+		}
+		templatesInput.NextToken = out.NextToken
+	}
+
+	var images []string
+	unique := make(map[string]bool)
+	for _, tpl := range templates {
+		versionsInput := &ec2.DescribeLaunchTemplateVersionsInput{LaunchTemplateId: tpl.LaunchTemplateId}
+		for {
+			out, err := s.DescribeLaunchTemplateVersions(versionsInput)
+			if err != nil {
+				return nil, err
+			}
+			for _, version := range out.LaunchTemplateVersions {
+				if data := version.LaunchTemplateData; data != nil && data.ImageId != nil && !unique[*data.ImageId] {
+					unique[*data.ImageId] = true
+					images = append(images, *data.ImageId)
+				}
+			}
+			if out.NextToken == nil {
+				break
+			}
+			versionsInput.NextToken = out.NextToken
+		}
+	}
+	return images, nil
+}
+
 var arnResourceInfoRegex = regexp.MustCompile(`(root)|([\w-.]*)/([\w-./]*)`)
 
 type Identity struct {
@@ -78,6 +175,27 @@ func (i *Identity) IsUserType() bool {
 	return i.ResourceType == "user"
 }
 
+// PolicySourceArn returns the ARN of the user or role whose policies apply to the identity.
+// The role of an assumed role session is given without its path.
+func (i *Identity) PolicySourceArn() (string, error) {
+	if i.IsRoot() {
+		return "", errors.New("policies of the root account cannot be simulated")
+	}
+	switch i.ResourceType {
+	case "user":
+		return i.Arn, nil
+	case "assumed-role":
+		partition := "aws"
+		if splits := strings.Split(i.Arn, ":"); len(splits) > 1 {
+			partition = splits[1]
+		}
+		role := strings.SplitN(i.Resource, "/", 2)[0]
+		return fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, i.Account, role), nil
+	default:
+		return "", fmt.Errorf("policies of %s identities cannot be simulated", i.ResourceType)
+	}
+}
+
 func (s *Access) GetIdentity() (*Identity, error) {
 	resp, err := s.STSAPI.GetCallerIdentity(nil)
 	if err != nil {
@@ -212,3 +330,23 @@ func (s *Access) GetUserPolicies(username string) (*UserPolicies, error) {
 
 	return all, nil
 }
+
+// SimulateActions returns the decision of the IAM policies of the identity for each action
+// on any resource: allowed, implicitDeny or explicitDeny
+func (s *Access) SimulateActions(ident *Identity, actions []string) (map[string]string, error) {
+	arn, err := ident.PolicySourceArn()
+	if err != nil {
+		return nil, err
+	}
+	decisions := make(map[string]string)
+	err = s.SimulatePrincipalPolicyPages(&iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: awssdk.String(arn),
+		ActionNames:     awssdk.StringSlice(actions),
+	}, func(out *iam.SimulatePolicyResponse, lastPage bool) bool {
+		for _, res := range out.EvaluationResults {
+			decisions[awssdk.StringValue(res.EvalActionName)] = awssdk.StringValue(res.EvalDecision)
+		}
+		return true
+	})
+	return decisions, err
+}
@@ -3,15 +3,22 @@ package awsservices
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/apigateway"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sfn"
 	"github.com/aws/aws-sdk-go/service/sqs"
 )
 
@@ -38,6 +45,10 @@ func (m *mockRoute53) ListResourceRecordSetsPages(input *route53.ListResourceRec
 	return nil
 }
 
+func (m *mockIam) ListMFADevices(input *iam.ListMFADevicesInput) (*iam.ListMFADevicesOutput, error) {
+	return &iam.ListMFADevicesOutput{}, nil
+}
+
 func (m *mockIam) ListUsersPages(input *iam.ListUsersInput, fn func(p *iam.ListUsersOutput, lastPage bool) (shouldContinue bool)) error {
 	fn(&iam.ListUsersOutput{Users: m.users}, true)
 	return nil
@@ -112,6 +123,15 @@ func (m *mockCloudfront) ListDistributionsPages(input *cloudfront.ListDistributi
 	return nil
 }
 
+func (m *mockCloudfront) ListCloudFrontOriginAccessIdentitiesPages(input *cloudfront.ListCloudFrontOriginAccessIdentitiesInput, fn func(p *cloudfront.ListCloudFrontOriginAccessIdentitiesOutput, lastPage bool) (shouldContinue bool)) error {
+	for i, identity := range m.originaccessidentitysummarys {
+		fn(&cloudfront.ListCloudFrontOriginAccessIdentitiesOutput{CloudFrontOriginAccessIdentityList: &cloudfront.OriginAccessIdentityList{Items: []*cloudfront.OriginAccessIdentitySummary{identity}, NextMarker: awssdk.String(strconv.Itoa(i + 1))}},
+			i < len(m.originaccessidentitysummarys)-1,
+		)
+	}
+	return nil
+}
+
 func (m *mockEcs) DescribeClusters(input *ecs.DescribeClustersInput) (*ecs.DescribeClustersOutput, error) {
 	var clusters []*ecs.Cluster
 	for _, cluster := range m.clusters {
@@ -187,3 +207,90 @@ func (m *mockEcs) ListContainerInstancesPages(input *ecs.ListContainerInstancesI
 func (m *mockEcs) DescribeContainerInstances(input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
 	return &ecs.DescribeContainerInstancesOutput{ContainerInstances: m.containerinstances[awssdk.StringValue(input.Cluster)]}, nil
 }
+
+func (m *mockEcs) ListServicesPages(input *ecs.ListServicesInput, fn func(p *ecs.ListServicesOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*string
+	names := m.servicesNames[awssdk.StringValue(input.Cluster)]
+	for i := 0; i < len(names); i += 2 {
+		page := []*string{names[i]}
+		if i+1 < len(names) {
+			page = append(page, names[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&ecs.ListServicesOutput{ServiceArns: page, NextToken: awssdk.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
+func (m *mockEcs) DescribeServices(input *ecs.DescribeServicesInput) (*ecs.DescribeServicesOutput, error) {
+	var services []*ecs.Service
+	for _, service := range m.services[awssdk.StringValue(input.Cluster)] {
+		for _, arn := range input.Services {
+			if awssdk.StringValue(service.ServiceArn) == awssdk.StringValue(arn) {
+				services = append(services, service)
+			}
+		}
+	}
+	return &ecs.DescribeServicesOutput{Services: services}, nil
+}
+
+func (m *mockDynamodb) DescribeTable(input *dynamodb.DescribeTableInput) (*dynamodb.DescribeTableOutput, error) {
+	for _, table := range m.tabledescriptions {
+		if awssdk.StringValue(table.TableName) == awssdk.StringValue(input.TableName) {
+			return &dynamodb.DescribeTableOutput{Table: table}, nil
+		}
+	}
+	return nil, fmt.Errorf("table %s not found", awssdk.StringValue(input.TableName))
+}
+
+func (m *mockKms) DescribeKey(input *kms.DescribeKeyInput) (*kms.DescribeKeyOutput, error) {
+	for _, key := range m.keymetadatas {
+		if awssdk.StringValue(key.KeyId) == awssdk.StringValue(input.KeyId) {
+			return &kms.DescribeKeyOutput{KeyMetadata: key}, nil
+		}
+	}
+	return nil, fmt.Errorf("key %s not found", awssdk.StringValue(input.KeyId))
+}
+
+func (m *mockKms) ListGrantsPages(input *kms.ListGrantsInput, fn func(p *kms.ListGrantsResponse, lastPage bool) (shouldContinue bool)) error {
+	fn(&kms.ListGrantsResponse{Grants: m.grantlistentrys[awssdk.StringValue(input.KeyId)]}, true)
+	return nil
+}
+
+func (m *mockLambda) ListAliasesWithContext(ctx awssdk.Context, input *lambda.ListAliasesInput, opts ...request.Option) (*lambda.ListAliasesOutput, error) {
+	var aliases []*lambda.AliasConfiguration
+	for _, alias := range m.aliasconfigurations {
+		if strings.HasPrefix(awssdk.StringValue(alias.AliasArn), awssdk.StringValue(input.FunctionName)+":") {
+			aliases = append(aliases, alias)
+		}
+	}
+	return &lambda.ListAliasesOutput{Aliases: aliases}, nil
+}
+
+func (m *mockSfn) ListExecutionsWithContext(ctx awssdk.Context, input *sfn.ListExecutionsInput, opts ...request.Option) (*sfn.ListExecutionsOutput, error) {
+	var executions []*sfn.ExecutionListItem
+	for _, execution := range m.executionlistitems {
+		if awssdk.StringValue(execution.StateMachineArn) == awssdk.StringValue(input.StateMachineArn) {
+			executions = append(executions, execution)
+		}
+	}
+	return &sfn.ListExecutionsOutput{Executions: executions}, nil
+}
+
+func (m *mockApigateway) GetStagesWithContext(ctx awssdk.Context, input *apigateway.GetStagesInput, opts ...request.Option) (*apigateway.GetStagesOutput, error) {
+	return &apigateway.GetStagesOutput{Item: m.stages[awssdk.StringValue(input.RestApiId)]}, nil
+}
+
+func (m *mockApigateway) GetDeploymentsPages(input *apigateway.GetDeploymentsInput, fn func(p *apigateway.GetDeploymentsOutput, lastPage bool) (shouldContinue bool)) error {
+	fn(&apigateway.GetDeploymentsOutput{Items: m.deployments[awssdk.StringValue(input.RestApiId)]}, true)
+	return nil
+}
+
+func (m *mockApigateway) GetResourcesPages(input *apigateway.GetResourcesInput, fn func(p *apigateway.GetResourcesOutput, lastPage bool) (shouldContinue bool)) error {
+	fn(&apigateway.GetResourcesOutput{Items: m.resources[awssdk.StringValue(input.RestApiId)]}, true)
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/wallix/awless/aws/pool"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+const RegionsConfigKey = "aws.inventory.regions"
+
+const globalRegion = "global"
+
+// ResolveRegions returns the regions configured in `aws.inventory.regions` (comma separated).
+// When empty, all the regions of the partition of the current region are returned.
+func ResolveRegions() ([]string, error) {
+	if current.sess == nil {
+		return nil, errors.New("resolve regions: AWS session not initialized")
+	}
+	var regions []string
+	if s, ok := current.extraConf[RegionsConfigKey].(string); ok {
+		for _, r := range strings.Split(s, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				regions = append(regions, r)
+			}
+		}
+	}
+	if len(regions) > 0 {
+		return regions, nil
+	}
+
+	region := awssdk.StringValue(current.sess.Config.Region)
+	for _, p := range endpoints.DefaultPartitions() {
+		if _, ok := p.Regions()[region]; ok {
+			for id := range p.Regions() {
+				regions = append(regions, id)
+			}
+			sort.Strings(regions)
+			return regions, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot list regions of partition of '%s': set them with `awless config set %s`", region, RegionsConfigKey)
+}
+
+// NewMultiRegionService returns the service of the given name fetching concurrently in all the given regions
+func NewMultiRegionService(serviceName string, regions []string) (cloud.Service, error) {
+	if current.sess == nil {
+		return nil, errors.New("multi region service: AWS session not initialized")
+	}
+	newService, ok := newServiceFuncs[serviceName]
+	if !ok {
+		return nil, fmt.Errorf("multi region service: unknown service '%s'", serviceName)
+	}
+	var services []cloud.Service
+	for _, r := range regions {
+		sess, err := awspool.Default.Session(current.profile, r)
+		if err != nil {
+			return nil, fmt.Errorf("multi region service: %s", err)
+		}
+		services = append(services, newService(sess, current.profile, current.extraConf, current.log))
+	}
+	return newMultiRegionService(services...)
+}
+
+// MultiRegionService is a composite of the same service instantiated in several regions.
+// Fetching runs the fetchers of all regions concurrently and merges their graphs,
+// each resource being tagged with the region it has been fetched in.
+type MultiRegionService struct {
+	services []cloud.Service
+}
+
+func newMultiRegionService(services ...cloud.Service) (*MultiRegionService, error) {
+	if len(services) == 0 {
+		return nil, errors.New("multi region service: no region given")
+	}
+	// global services are the same in every region: fetch them only once
+	if services[0].Region() == globalRegion {
+		services = services[:1]
+	}
+	return &MultiRegionService{services: services}, nil
+}
+
+func (s *MultiRegionService) Name() string {
+	return s.services[0].Name()
+}
+
+func (s *MultiRegionService) Region() string {
+	var regions []string
+	for _, srv := range s.services {
+		regions = append(regions, srv.Region())
+	}
+	return strings.Join(regions, ",")
+}
+
+func (s *MultiRegionService) Profile() string {
+	return s.services[0].Profile()
+}
+
+func (s *MultiRegionService) ResourceTypes() []string {
+	return s.services[0].ResourceTypes()
+}
+
+func (s *MultiRegionService) IsSyncDisabled() bool {
+	return s.services[0].IsSyncDisabled()
+}
+
+// Fetch returns the graph of the regions successfully fetched, along with the errors of the others
+func (s *MultiRegionService) Fetch(ctx context.Context) (cloud.GraphAPI, error) {
+	return s.fetchAll(func(srv cloud.Service) (cloud.GraphAPI, error) {
+		return srv.Fetch(ctx)
+	})
+}
+
+// FetchByType returns the graph of the regions successfully fetched, along with the errors of the others
+func (s *MultiRegionService) FetchByType(ctx context.Context, resourceType string) (cloud.GraphAPI, error) {
+	return s.fetchAll(func(srv cloud.Service) (cloud.GraphAPI, error) {
+		return srv.FetchByType(ctx, resourceType)
+	})
+}
+
+func (s *MultiRegionService) fetchAll(fetchFn func(cloud.Service) (cloud.GraphAPI, error)) (cloud.GraphAPI, error) {
+	type regionResult struct {
+		region string
+		gph    cloud.GraphAPI
+		err    error
+	}
+	results := make(chan regionResult, len(s.services))
+
+	var wg sync.WaitGroup
+	for _, srv := range s.services {
+		wg.Add(1)
+		go func(srv cloud.Service) {
+			defer wg.Done()
+			g, err := fetchFn(srv)
+			results <- regionResult{region: srv.Region(), gph: g, err: err}
+		}(srv)
+	}
+	wg.Wait()
+	close(results)
+
+	merged := graph.NewGraph()
+	ferr := new(fetch.Error)
+	for res := range results {
+		for _, e := range *fetch.WrapError(res.err) {
+			if fetch.IsCanceled(e) {
+				ferr.Add(e)
+			} else {
+				ferr.Add(fmt.Errorf("region %s: %s", res.region, e))
+			}
+		}
+		if res.gph == nil {
+			continue
+		}
+		if err := s.addRegionResources(merged, res.gph, res.region); err != nil {
+			ferr.Add(fmt.Errorf("region %s: %s", res.region, err))
+		}
+	}
+
+	if ferr.Any() {
+		return merged, ferr
+	}
+	return merged, nil
+}
+
+func (s *MultiRegionService) addRegionResources(merged *graph.Graph, gph cloud.GraphAPI, region string) error {
+	g, ok := gph.(*graph.Graph)
+	if !ok {
+		return fmt.Errorf("unexpected graph type %T", gph)
+	}
+	merged.AddGraph(g)
+	if region == globalRegion {
+		return nil
+	}
+	resources, err := g.GetAllResources(s.ResourceTypes()...)
+	if err != nil {
+		return err
+	}
+	for _, res := range resources {
+		res.SetProperty(properties.Region, region)
+		if err := merged.AddResource(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package awsservices
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/wallix/awless/aws/fetch"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+func TestMultiRegionService(t *testing.T) {
+	newRegionInfra := func(region string, instances ...string) cloud.Service {
+		var list []*ec2.Instance
+		for _, id := range instances {
+			list = append(list, &ec2.Instance{InstanceId: awssdk.String(id)})
+		}
+		return &Infra{region: region, fetcher: fetch.NewFetcher(awsfetch.BuildInfraFetchFuncs(awsfetch.NewConfig(&mockEc2{instances: list})))}
+	}
+	failing := &Infra{region: "ap-south-1", fetcher: fetch.NewFetcher(fetch.Funcs{cloud.Instance: func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		return nil, nil, errors.New("access denied")
+	}})}
+
+	srv, err := newMultiRegionService(newRegionInfra("eu-west-1", "inst_1", "inst_2"), newRegionInfra("us-east-1", "inst_3"), failing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := srv.Region(), "eu-west-1,us-east-1,ap-south-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	g, err := srv.FetchByType(context.Background(), cloud.Instance)
+	if err == nil || !strings.Contains(err.Error(), "region ap-south-1: access denied") {
+		t.Fatalf("expected error for region ap-south-1, got %v", err)
+	}
+
+	expected := map[string]string{"inst_1": "eu-west-1", "inst_2": "eu-west-1", "inst_3": "us-east-1"}
+	resources, err := g.Find(cloud.NewQuery(cloud.Instance))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(resources), len(expected); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for _, r := range resources {
+		if got, want := r.Properties()[properties.Region], expected[r.Id()]; got != want {
+			t.Fatalf("%s: got %v, want %v", r.Id(), got, want)
+		}
+	}
+
+	if _, err = newMultiRegionService(); err == nil {
+		t.Fatal("expected error with no region")
+	}
+	global, err := newMultiRegionService(&Access{region: "global"}, &Access{region: "global"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := global.Region(), "global"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -92,6 +93,17 @@ var addParentsFns = map[string][]addParentFn{
 		funcBuilder{parent: cloud.SecurityGroup, fieldName: "GroupId", listName: "Groups", relation: APPLIES_ON}.build(),
 		funcBuilder{parent: cloud.Instance, fieldName: "Attachment.InstanceId", relation: DEPENDING_ON}.build(),
 	},
+	cloud.SpotFleet: {
+		addRegionParent,
+	},
+	cloud.SpotRequest: {
+		addRegionParent,
+		funcBuilder{parent: cloud.Instance, fieldName: "InstanceId", relation: DEPENDING_ON}.build(),
+	},
+	// KMS
+	cloud.Alias: {
+		funcBuilder{parent: cloud.Key, fieldName: "TargetKeyId"}.build(),
+	},
 	// Loadbalancer
 	cloud.LoadBalancer: {
 		funcBuilder{parent: cloud.Vpc, fieldName: "VpcId"}.build(),
@@ -112,6 +124,14 @@ var addParentsFns = map[string][]addParentFn{
 		funcBuilder{parent: cloud.AvailabilityZone, fieldName: "AvailabilityZone"}.build(),
 		funcBuilder{parent: cloud.SecurityGroup, listName: "VpcSecurityGroups", fieldName: "VpcSecurityGroupId", relation: APPLIES_ON}.build(),
 	},
+	cloud.CacheCluster: {
+		funcBuilder{parent: cloud.AvailabilityZone, fieldName: "PreferredAvailabilityZone"}.build(),
+		funcBuilder{parent: cloud.SecurityGroup, listName: "SecurityGroups", fieldName: "SecurityGroupId", relation: APPLIES_ON}.build(),
+	},
+	cloud.ReplicationGroup: {
+		addRegionParent,
+		funcBuilder{parent: cloud.CacheCluster, stringListName: "MemberClusters", relation: DEPENDING_ON}.build(),
+	},
 	// Autoscaling
 	cloud.LaunchConfiguration: {
 		addRegionParent,
@@ -131,6 +151,16 @@ var addParentsFns = map[string][]addParentFn{
 	cloud.Subscription: {
 		funcBuilder{parent: cloud.Topic, fieldName: "TopicArn"}.build(),
 	},
+	// DNS
+	cloud.Record: {
+		funcBuilder{parent: cloud.HealthCheck, fieldName: "HealthCheckId", relation: APPLIES_ON}.build(),
+	},
+	// CDN
+	cloud.Distribution: {addDistributionOriginAccessIdentities},
+	// Lambda
+	cloud.EventSourceMapping: {
+		funcBuilder{parent: cloud.Function, fieldName: "FunctionArn"}.build(),
+	},
 	cloud.Vpc:              {addRegionParent},
 	cloud.AvailabilityZone: {addRegionParent},
 	cloud.Keypair:          {addRegionParent},
@@ -138,12 +168,16 @@ var addParentsFns = map[string][]addParentFn{
 	cloud.Repository:       {addRegionParent},
 	cloud.ContainerCluster: {addRegionParent},
 	cloud.ContainerTask:    {addRegionParent},
+	cloud.Table:            {addRegionParent},
 	cloud.Certificate:      {addRegionParent},
+	cloud.Key:              {addRegionParent},
 	cloud.User:             {userAddGroupsRelations, addManagedPoliciesRelations},
 	cloud.Role:             {addManagedPoliciesRelations},
 	cloud.Group:            {addManagedPoliciesRelations},
 	cloud.Bucket:           {addRegionParent},
 	cloud.Function:         {addRegionParent},
+	cloud.StateMachine:     {addRegionParent},
+	cloud.RestApi:          {addRegionParent},
 	cloud.Topic:            {addRegionParent},
 	cloud.Alarm:            {addRegionParent, addAlarmMetric},
 	cloud.Metric:           {addRegionParent},
@@ -451,3 +485,31 @@ func addAlarmMetric(g *graph.Graph, snap tstore.RDFGraph, region string, i inter
 	}
 	return nil
 }
+
+func addDistributionOriginAccessIdentities(g *graph.Graph, snap tstore.RDFGraph, region string, i interface{}) error {
+	distribution, ok := i.(*cloudfront.DistributionSummary)
+	if !ok {
+		return fmt.Errorf("add distribution origin access identities relation: not a distribution, but a %T", i)
+	}
+	if distribution.Origins == nil {
+		return nil
+	}
+	res, err := awsconv.InitResource(distribution)
+	if err != nil {
+		return err
+	}
+	for _, origin := range distribution.Origins.Items {
+		if origin.S3OriginConfig == nil {
+			continue
+		}
+		// origin access identities are referenced as origin-access-identity/cloudfront/<id>
+		splits := strings.Split(awssdk.StringValue(origin.S3OriginConfig.OriginAccessIdentity), "/")
+		if len(splits) != 3 || splits[2] == "" {
+			continue
+		}
+		if err = g.AddAppliesOnRelation(graph.InitResource(cloud.OriginAccessIdentity, splits[2]), res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
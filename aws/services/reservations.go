@@ -0,0 +1,434 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+const (
+	ReservationsTargetConfigKey = "aws.reservations.target"
+	ReservationsTTLConfigKey    = "aws.reservations.ttl"
+
+	NameReservation = "name"
+	CIDRReservation = "cidr"
+)
+
+// Reservation is a claim of an awless user on a name or a CIDR block within a scope
+// (ex: eu-west-1/instance for names of instances, eu-west-1/vpc-12345 for CIDR blocks of subnets).
+// Reservations are hints coordinating concurrent users until the resources are created and synced.
+type Reservation struct {
+	Scope   string    `json:"scope"`
+	Kind    string    `json:"kind"`
+	Value   string    `json:"value"`
+	Owner   string    `json:"owner"`
+	Expires time.Time `json:"expires"`
+}
+
+func (r *Reservation) String() string {
+	return fmt.Sprintf("%s '%s' in %s", r.Kind, r.Value, r.Scope)
+}
+
+func (r *Reservation) key() string {
+	return path.Join(r.Scope, r.Kind, url.PathEscape(r.Value))
+}
+
+func (r *Reservation) activeFor(owner string, now time.Time) bool {
+	return r.Owner != owner && r.Expires.After(now)
+}
+
+func (r *Reservation) overlaps(other *Reservation) bool {
+	if r.Scope != other.Scope || r.Kind != other.Kind {
+		return false
+	}
+	if r.Kind != CIDRReservation {
+		return r.Value == other.Value
+	}
+	_, n1, err1 := net.ParseCIDR(r.Value)
+	_, n2, err2 := net.ParseCIDR(other.Value)
+	if err1 != nil || err2 != nil {
+		return r.Value == other.Value
+	}
+	return n1.Contains(n2.IP) || n2.Contains(n1.IP)
+}
+
+// ReservationConflictError is returned when a claim collides with the active reservation of another user
+type ReservationConflictError struct {
+	Claim, Holder *Reservation
+}
+
+func (e *ReservationConflictError) Error() string {
+	if e.Claim.Value != e.Holder.Value {
+		return fmt.Sprintf("%s overlaps %s '%s' reserved by %s until %s", e.Claim, e.Holder.Kind, e.Holder.Value, e.Holder.Owner, e.Holder.Expires.Local().Format("15:04:05"))
+	}
+	return fmt.Sprintf("%s is reserved by %s until %s", e.Claim, e.Holder.Owner, e.Holder.Expires.Local().Format("15:04:05"))
+}
+
+// ReservationsTarget is where reservations are stored: either a DynamoDB table
+// (`dynamodb:<table>` with a string hash key named `Key`) or an S3 prefix (`s3://<bucket>/<prefix>`)
+type ReservationsTarget struct {
+	Table          string
+	Bucket, Prefix string
+}
+
+func ParseReservationsTarget(s string) (*ReservationsTarget, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "dynamodb:"):
+		if table := strings.TrimPrefix(s, "dynamodb:"); table != "" {
+			return &ReservationsTarget{Table: table}, nil
+		}
+	case strings.HasPrefix(s, "s3://"):
+		splits := strings.SplitN(strings.TrimPrefix(s, "s3://"), "/", 2)
+		if splits[0] != "" {
+			target := &ReservationsTarget{Bucket: splits[0]}
+			if len(splits) > 1 {
+				target.Prefix = strings.Trim(splits[1], "/")
+			}
+			return target, nil
+		}
+	}
+	return nil, fmt.Errorf("invalid reservations target '%s', expected dynamodb:<table> or s3://<bucket>/<prefix>", s)
+}
+
+func (t *ReservationsTarget) String() string {
+	if t.Table != "" {
+		return "dynamodb:" + t.Table
+	}
+	return "s3://" + path.Join(t.Bucket, t.Prefix)
+}
+
+// ClaimReservations claims the names and CIDR blocks for the owner in the target configured
+// in `aws.reservations.target`, for `aws.reservations.ttl` minutes. Claims already held by
+// the owner are renewed. When one of the claims conflicts with the active reservation
+// of another user, the claims of this call are released and the conflicts returned.
+// Without configured target, nothing is claimed.
+func ClaimReservations(owner string, claims []*Reservation) ([]*ReservationConflictError, error) {
+	store, err := newReservationsStore()
+	if err != nil || store == nil {
+		return nil, err
+	}
+	ttl := 60 * time.Minute
+	if minutes, ok := current.extraConf[ReservationsTTLConfigKey].(int); ok && minutes > 0 {
+		ttl = time.Duration(minutes) * time.Minute
+	}
+	return claimReservations(store, owner, ttl, time.Now().UTC(), claims)
+}
+
+// ReleaseReservations releases the claims held by the owner in the configured target
+func ReleaseReservations(owner string, claims []*Reservation) error {
+	store, err := newReservationsStore()
+	if err != nil || store == nil {
+		return err
+	}
+	return releaseReservations(store, owner, claims)
+}
+
+type reservationsStore interface {
+	// claim stores the reservation unless an active reservation of another owner exists, which is returned
+	claim(r *Reservation, now time.Time) (*Reservation, error)
+	list(scope, kind string) ([]*Reservation, error)
+	release(r *Reservation) error
+}
+
+func newReservationsStore() (reservationsStore, error) {
+	s, _ := current.extraConf[ReservationsTargetConfigKey].(string)
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	if current.sess == nil {
+		return nil, errors.New("reservations: AWS session not initialized")
+	}
+	target, err := ParseReservationsTarget(s)
+	if err != nil {
+		return nil, err
+	}
+	if target.Table != "" {
+		return &dynamodbReservations{api: dynamodb.New(current.sess), table: target.Table}, nil
+	}
+	return &s3Reservations{api: s3.New(current.sess), bucket: target.Bucket, prefix: target.Prefix}, nil
+}
+
+func claimReservations(store reservationsStore, owner string, ttl time.Duration, now time.Time, claims []*Reservation) ([]*ReservationConflictError, error) {
+	var claimed []*Reservation
+	var conflicts []*ReservationConflictError
+	rollback := func() {
+		releaseReservations(store, owner, claimed)
+	}
+
+	for _, c := range claims {
+		claim := *c
+		claim.Owner, claim.Expires = owner, now.Add(ttl)
+
+		if claim.Kind == CIDRReservation {
+			existing, err := store.list(claim.Scope, claim.Kind)
+			if err != nil {
+				rollback()
+				return nil, err
+			}
+			var overlapping bool
+			for _, e := range existing {
+				if e.activeFor(owner, now) && e.Value != claim.Value && claim.overlaps(e) {
+					conflicts = append(conflicts, &ReservationConflictError{Claim: &claim, Holder: e})
+					overlapping = true
+				}
+			}
+			if overlapping {
+				continue
+			}
+		}
+
+		holder, err := store.claim(&claim, now)
+		if err != nil {
+			rollback()
+			return nil, err
+		}
+		if holder != nil {
+			conflicts = append(conflicts, &ReservationConflictError{Claim: &claim, Holder: holder})
+			continue
+		}
+		claimed = append(claimed, &claim)
+	}
+
+	if len(conflicts) > 0 {
+		rollback()
+	}
+	return conflicts, nil
+}
+
+func releaseReservations(store reservationsStore, owner string, claims []*Reservation) error {
+	var errs []string
+	for _, c := range claims {
+		r := *c
+		r.Owner = owner
+		if err := store.release(&r); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("release reservations: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+type dynamodbReservations struct {
+	api   dynamodbiface.DynamoDBAPI
+	table string
+}
+
+func (d *dynamodbReservations) claim(r *Reservation, now time.Time) (*Reservation, error) {
+	_, err := d.api.PutItem(&dynamodb.PutItemInput{
+		TableName:           awssdk.String(d.table),
+		Item:                reservationItem(r),
+		ConditionExpression: awssdk.String("attribute_not_exists(#key) OR #expires < :now OR #owner = :owner"),
+		ExpressionAttributeNames: map[string]*string{
+			"#key": awssdk.String("Key"), "#expires": awssdk.String("Expires"), "#owner": awssdk.String("Owner"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":now":   {N: awssdk.String(strconv.FormatInt(now.Unix(), 10))},
+			":owner": {S: awssdk.String(r.Owner)},
+		},
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		out, err := d.api.GetItem(&dynamodb.GetItemInput{
+			TableName:      awssdk.String(d.table),
+			Key:            map[string]*dynamodb.AttributeValue{"Key": {S: awssdk.String(r.key())}},
+			ConsistentRead: awssdk.Bool(true),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get reservation %s in table %s: %s", r.key(), d.table, err)
+		}
+		return itemReservation(out.Item), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("claim reservation %s in table %s: %s", r.key(), d.table, err)
+	}
+	return nil, nil
+}
+
+func (d *dynamodbReservations) list(scope, kind string) (all []*Reservation, err error) {
+	err = d.api.ScanPages(&dynamodb.ScanInput{
+		TableName:                awssdk.String(d.table),
+		ConsistentRead:           awssdk.Bool(true),
+		FilterExpression:         awssdk.String("#scope = :scope AND #kind = :kind"),
+		ExpressionAttributeNames: map[string]*string{"#scope": awssdk.String("Scope"), "#kind": awssdk.String("Kind")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":scope": {S: awssdk.String(scope)},
+			":kind":  {S: awssdk.String(kind)},
+		},
+	}, func(out *dynamodb.ScanOutput, lastPage bool) bool {
+		for _, item := range out.Items {
+			all = append(all, itemReservation(item))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list reservations in table %s: %s", d.table, err)
+	}
+	return
+}
+
+func (d *dynamodbReservations) release(r *Reservation) error {
+	_, err := d.api.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName:                 awssdk.String(d.table),
+		Key:                       map[string]*dynamodb.AttributeValue{"Key": {S: awssdk.String(r.key())}},
+		ConditionExpression:       awssdk.String("#owner = :owner"),
+		ExpressionAttributeNames:  map[string]*string{"#owner": awssdk.String("Owner")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":owner": {S: awssdk.String(r.Owner)}},
+	})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("release reservation %s in table %s: %s", r.key(), d.table, err)
+	}
+	return nil
+}
+
+func reservationItem(r *Reservation) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		"Key":     {S: awssdk.String(r.key())},
+		"Scope":   {S: awssdk.String(r.Scope)},
+		"Kind":    {S: awssdk.String(r.Kind)},
+		"Value":   {S: awssdk.String(r.Value)},
+		"Owner":   {S: awssdk.String(r.Owner)},
+		"Expires": {N: awssdk.String(strconv.FormatInt(r.Expires.Unix(), 10))},
+	}
+}
+
+func itemReservation(item map[string]*dynamodb.AttributeValue) *Reservation {
+	str := func(k string) string {
+		if v, ok := item[k]; ok && v != nil {
+			return awssdk.StringValue(v.S)
+		}
+		return ""
+	}
+	r := &Reservation{Scope: str("Scope"), Kind: str("Kind"), Value: str("Value"), Owner: str("Owner")}
+	if v, ok := item["Expires"]; ok && v != nil {
+		if sec, err := strconv.ParseInt(awssdk.StringValue(v.N), 10, 64); err == nil {
+			r.Expires = time.Unix(sec, 0).UTC()
+		}
+	}
+	return r
+}
+
+// s3Reservations stores a JSON object per reservation. S3 has no conditional writes:
+// claims are read back after being written to detect concurrent claims (last writer wins).
+type s3Reservations struct {
+	api            s3iface.S3API
+	bucket, prefix string
+}
+
+func (s *s3Reservations) claim(r *Reservation, now time.Time) (*Reservation, error) {
+	key := path.Join(s.prefix, r.key()) + ".json"
+	holder, err := s.get(key)
+	if err != nil {
+		return nil, err
+	}
+	if holder != nil && holder.activeFor(r.Owner, now) {
+		return holder, nil
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.api.PutObject(&s3.PutObjectInput{
+		Bucket:      awssdk.String(s.bucket),
+		Key:         awssdk.String(key),
+		Body:        bytes.NewReader(b),
+		ContentType: awssdk.String("application/json"),
+	}); err != nil {
+		return nil, fmt.Errorf("claim reservation s3://%s/%s: %s", s.bucket, key, err)
+	}
+	if holder, err = s.get(key); err != nil {
+		return nil, err
+	}
+	if holder != nil && holder.activeFor(r.Owner, now) {
+		return holder, nil
+	}
+	return nil, nil
+}
+
+func (s *s3Reservations) list(scope, kind string) (all []*Reservation, err error) {
+	var keys []string
+	prefix := path.Join(s.prefix, scope, kind) + "/"
+	if err = s.api.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: awssdk.String(s.bucket),
+		Prefix: awssdk.String(prefix),
+	}, func(out *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range out.Contents {
+			keys = append(keys, awssdk.StringValue(obj.Key))
+		}
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("list reservations s3://%s/%s: %s", s.bucket, prefix, err)
+	}
+	for _, key := range keys {
+		r, err := s.get(key)
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			all = append(all, r)
+		}
+	}
+	return
+}
+
+func (s *s3Reservations) release(r *Reservation) error {
+	key := path.Join(s.prefix, r.key()) + ".json"
+	holder, err := s.get(key)
+	if err != nil || holder == nil || holder.Owner != r.Owner {
+		return err
+	}
+	if _, err := s.api.DeleteObject(&s3.DeleteObjectInput{Bucket: awssdk.String(s.bucket), Key: awssdk.String(key)}); err != nil {
+		return fmt.Errorf("release reservation s3://%s/%s: %s", s.bucket, key, err)
+	}
+	return nil
+}
+
+func (s *s3Reservations) get(key string) (*Reservation, error) {
+	out, err := s.api.GetObject(&s3.GetObjectInput{Bucket: awssdk.String(s.bucket), Key: awssdk.String(key)})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get reservation s3://%s/%s: %s", s.bucket, key, err)
+	}
+	defer out.Body.Close()
+	r := &Reservation{}
+	if err := json.NewDecoder(out.Body).Decode(r); err != nil {
+		return nil, fmt.Errorf("get reservation s3://%s/%s: %s", s.bucket, key, err)
+	}
+	return r, nil
+}
@@ -0,0 +1,164 @@
+package awsservices
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+type mockReservationsS3 struct {
+	s3iface.S3API
+	objects map[string][]byte
+}
+
+func (m *mockReservationsS3) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	b, ok := m.objects[awssdk.StringValue(input.Key)]
+	if !ok {
+		return nil, awserr.New(s3.ErrCodeNoSuchKey, "not found", nil)
+	}
+	return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(b))}, nil
+}
+
+func (m *mockReservationsS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	b, err := ioutil.ReadAll(input.Body)
+	m.objects[awssdk.StringValue(input.Key)] = b
+	return &s3.PutObjectOutput{}, err
+}
+
+func (m *mockReservationsS3) DeleteObject(input *s3.DeleteObjectInput) (*s3.DeleteObjectOutput, error) {
+	delete(m.objects, awssdk.StringValue(input.Key))
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func (m *mockReservationsS3) ListObjectsV2Pages(input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool) error {
+	out := &s3.ListObjectsV2Output{}
+	for k := range m.objects {
+		if strings.HasPrefix(k, awssdk.StringValue(input.Prefix)) {
+			out.Contents = append(out.Contents, &s3.Object{Key: awssdk.String(k)})
+		}
+	}
+	fn(out, true)
+	return nil
+}
+
+func TestParseReservationsTarget(t *testing.T) {
+	tcases := []struct {
+		in       string
+		expected *ReservationsTarget
+	}{
+		{in: "dynamodb:awless-reservations", expected: &ReservationsTarget{Table: "awless-reservations"}},
+		{in: "s3://my-bucket/reservations/", expected: &ReservationsTarget{Bucket: "my-bucket", Prefix: "reservations"}},
+		{in: "dynamodb:"},
+		{in: "logs:group"},
+	}
+	for _, tcase := range tcases {
+		target, err := ParseReservationsTarget(tcase.in)
+		if tcase.expected == nil {
+			if err == nil {
+				t.Fatalf("%s: expected error", tcase.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.in, err)
+		}
+		if got, want := *target, *tcase.expected; got != want {
+			t.Fatalf("%s: got %#v, want %#v", tcase.in, got, want)
+		}
+		if got, want := target.String(), strings.TrimSuffix(tcase.in, "/"); got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	}
+}
+
+func TestClaimReservations(t *testing.T) {
+	api := &mockReservationsS3{objects: make(map[string][]byte)}
+	store := &s3Reservations{api: api, bucket: "my-bucket", prefix: "reservations"}
+	now := time.Date(2017, 8, 3, 10, 30, 0, 0, time.UTC)
+	ttl := time.Hour
+
+	aliceClaims := []*Reservation{
+		{Scope: "eu-west-1/instance", Kind: NameReservation, Value: "web"},
+		{Scope: "eu-west-1/vpc-1234", Kind: CIDRReservation, Value: "10.0.1.0/24"},
+	}
+	conflicts, err := claimReservations(store, "alice", ttl, now, aliceClaims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conflicts) != 0 {
+		t.Fatalf("unexpected conflicts %v", conflicts)
+	}
+	var keys []string
+	for k := range api.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if got, want := strings.Join(keys, " "), "reservations/eu-west-1/instance/name/web.json reservations/eu-west-1/vpc-1234/cidr/10.0.1.0%2F24.json"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	t.Run("renewed by owner", func(t *testing.T) {
+		conflicts, err := claimReservations(store, "alice", ttl, now.Add(10*time.Minute), aliceClaims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(conflicts) != 0 {
+			t.Fatalf("unexpected conflicts %v", conflicts)
+		}
+	})
+
+	t.Run("conflicts with other users", func(t *testing.T) {
+		bobClaims := []*Reservation{
+			{Scope: "eu-west-1/subnet", Kind: NameReservation, Value: "web"},
+			{Scope: "eu-west-1/instance", Kind: NameReservation, Value: "web"},
+			{Scope: "eu-west-1/vpc-1234", Kind: CIDRReservation, Value: "10.0.0.0/16"},
+			{Scope: "eu-west-1/vpc-5678", Kind: CIDRReservation, Value: "10.0.1.0/24"},
+		}
+		conflicts, err := claimReservations(store, "bob", ttl, now.Add(20*time.Minute), bobClaims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(conflicts), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := conflicts[0].Error(), "name 'web' in eu-west-1/instance is reserved by alice until"; !strings.HasPrefix(got, want) {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := conflicts[1].Error(), "cidr '10.0.0.0/16' in eu-west-1/vpc-1234 overlaps cidr '10.0.1.0/24' reserved by alice until"; !strings.HasPrefix(got, want) {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if _, ok := api.objects["reservations/eu-west-1/subnet/name/web.json"]; ok {
+			t.Fatal("expected claims of conflicting call to be released")
+		}
+	})
+
+	t.Run("expired reservations", func(t *testing.T) {
+		conflicts, err := claimReservations(store, "bob", ttl, now.Add(2*time.Hour), []*Reservation{{Scope: "eu-west-1/instance", Kind: NameReservation, Value: "web"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(conflicts) != 0 {
+			t.Fatalf("unexpected conflicts %v", conflicts)
+		}
+	})
+
+	t.Run("release", func(t *testing.T) {
+		if err := releaseReservations(store, "alice", aliceClaims); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := api.objects["reservations/eu-west-1/instance/name/web.json"]; !ok {
+			t.Fatal("expected reservation of other owner to be kept")
+		}
+		if _, ok := api.objects["reservations/eu-west-1/vpc-1234/cidr/10.0.1.0%2F24.json"]; ok {
+			t.Fatal("expected reservation to be released")
+		}
+	})
+}
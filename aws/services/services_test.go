@@ -28,19 +28,25 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/acm"
+	"github.com/aws/aws-sdk-go/service/apigateway"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecs"
+	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/kms"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sfn"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/wallix/awless/aws/conv"
 	"github.com/wallix/awless/aws/fetch"
 	"github.com/wallix/awless/cloud"
 	p "github.com/wallix/awless/cloud/properties"
@@ -212,7 +218,7 @@ func TestBuildAccessRdfGraph(t *testing.T) {
 		"role_2":           resourcetest.Role("role_2").Prop(p.InlinePolicies, []string{"npolicy_1"}).Build(),
 		"role_3":           resourcetest.Role("role_3").Prop(p.InlinePolicies, []string{"npolicy_2"}).Build(),
 		"role_4":           resourcetest.Role("role_4").Prop(p.InlinePolicies, []string{"npolicy_4"}).Build(),
-		"usr_1":            resourcetest.User("usr_1").Prop(p.InlinePolicies, []string{"npolicy_1", "npolicy_2"}).Prop(p.PasswordLastUsed, time.Unix(1486139077, 0).UTC()).Build(),
+		"usr_1":            resourcetest.User("usr_1").Prop(p.InlinePolicies, []string{"npolicy_1", "npolicy_2"}).Prop(p.PasswordLastUsed, time.Unix(1486139077, 0).UTC()).Prop(p.MFAActive, false).Build(),
 		"usr_2":            resourcetest.User("usr_2").Prop(p.InlinePolicies, []string{"npolicy_1"}).Build(),
 		"usr_3":            resourcetest.User("usr_3").Prop(p.InlinePolicies, []string{"npolicy_1", "npolicy_4"}).Build(),
 		"usr_4":            resourcetest.User("usr_4").Prop(p.InlinePolicies, []string{"npolicy_2"}).Build(),
@@ -541,6 +547,76 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		},
 	}
 
+	servicesNames := map[string][]*string{
+		"clust_1": {awssdk.String("svc_1")},
+		"clust_2": {awssdk.String("svc_2")},
+	}
+	services := map[string][]*ecs.Service{
+		"clust_1": {
+			{
+				ServiceArn:     awssdk.String("svc_1"),
+				ServiceName:    awssdk.String("container-service-1"),
+				ClusterArn:     awssdk.String("clust_1"),
+				TaskDefinition: awssdk.String("cs_2:1"),
+				DesiredCount:   awssdk.Int64(3),
+				RunningCount:   awssdk.Int64(3),
+				PendingCount:   awssdk.Int64(0),
+				LaunchType:     awssdk.String("EC2"),
+				RoleArn:        awssdk.String("role:arn"),
+				CreatedAt:      awssdk.Time(now),
+				Status:         awssdk.String("ACTIVE"),
+				LoadBalancers:  []*ecs.LoadBalancer{{TargetGroupArn: awssdk.String("tg_1")}},
+			},
+		},
+		"clust_2": {
+			{
+				ServiceArn:     awssdk.String("svc_2"),
+				ServiceName:    awssdk.String("container-service-2"),
+				ClusterArn:     awssdk.String("clust_2"),
+				TaskDefinition: awssdk.String("cs_2:2"),
+				LaunchType:     awssdk.String("FARGATE"),
+				NetworkConfiguration: &ecs.NetworkConfiguration{AwsvpcConfiguration: &ecs.AwsVpcConfiguration{
+					Subnets:        []*string{awssdk.String("sub_3")},
+					SecurityGroups: []*string{awssdk.String("securitygroup_2")},
+				}},
+			},
+		},
+	}
+
+	//DynamoDB
+	tableNames := []*string{awssdk.String("table_1"), awssdk.String("table_2")}
+	tables := []*dynamodb.TableDescription{
+		{
+			TableName:        awssdk.String("table_1"),
+			TableArn:         awssdk.String("arn:aws:dynamodb:eu-west-1:123456789012:table/table_1"),
+			TableStatus:      awssdk.String("ACTIVE"),
+			CreationDateTime: awssdk.Time(now),
+			TableSizeBytes:   awssdk.Int64(2048),
+			ItemCount:        awssdk.Int64(12),
+			KeySchema: []*dynamodb.KeySchemaElement{
+				{AttributeName: awssdk.String("id"), KeyType: awssdk.String("HASH")},
+				{AttributeName: awssdk.String("date"), KeyType: awssdk.String("RANGE")},
+			},
+			ProvisionedThroughput: &dynamodb.ProvisionedThroughputDescription{ReadCapacityUnits: awssdk.Int64(5), WriteCapacityUnits: awssdk.Int64(10)},
+			GlobalSecondaryIndexes: []*dynamodb.GlobalSecondaryIndexDescription{
+				{
+					IndexName:             awssdk.String("by_email"),
+					IndexArn:              awssdk.String("arn:aws:dynamodb:eu-west-1:123456789012:table/table_1/index/by_email"),
+					IndexStatus:           awssdk.String("ACTIVE"),
+					IndexSizeBytes:        awssdk.Int64(512),
+					ItemCount:             awssdk.Int64(3),
+					KeySchema:             []*dynamodb.KeySchemaElement{{AttributeName: awssdk.String("email"), KeyType: awssdk.String("HASH")}},
+					Projection:            &dynamodb.Projection{ProjectionType: awssdk.String("KEYS_ONLY")},
+					ProvisionedThroughput: &dynamodb.ProvisionedThroughputDescription{ReadCapacityUnits: awssdk.Int64(1), WriteCapacityUnits: awssdk.Int64(2)},
+				},
+			},
+		},
+		{
+			TableName: awssdk.String("table_2"),
+			KeySchema: []*dynamodb.KeySchemaElement{{AttributeName: awssdk.String("key"), KeyType: awssdk.String("HASH")}},
+		},
+	}
+
 	//ACM
 	certificates := []*acm.CertificateSummary{
 		{CertificateArn: awssdk.String("arn:certif_1234"), DomainName: awssdk.String("domain-name.1")},
@@ -548,29 +624,135 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		{CertificateArn: awssdk.String("arn:certif_3456"), DomainName: awssdk.String("domain-name.3")},
 	}
 
-	mock := &mockEc2{vpcs: vpcs, securitygroups: securityGroups, subnets: subnets, instances: instances, keypairinfos: keypairs, internetgateways: igws, routetables: routeTables, images: images, availabilityzones: availabilityZones, natgateways: natgws, networkinterfaces: networkInterfaces}
+	//KMS
+	keys := []*kms.KeyMetadata{
+		{
+			KeyId:        awssdk.String("key_1"),
+			Arn:          awssdk.String("arn:aws:kms:eu-west-1:123456789012:key/key_1"),
+			Description:  awssdk.String("my key"),
+			KeyState:     awssdk.String("Enabled"),
+			Enabled:      awssdk.Bool(true),
+			KeyManager:   awssdk.String("CUSTOMER"),
+			AWSAccountId: awssdk.String("123456789012"),
+			CreationDate: awssdk.Time(now),
+		},
+		{KeyId: awssdk.String("key_2"), KeyState: awssdk.String("PendingDeletion"), Enabled: awssdk.Bool(false)},
+	}
+	keyEntries := []*kms.KeyListEntry{{KeyId: awssdk.String("key_1")}, {KeyId: awssdk.String("key_2")}}
+	aliases := []*kms.AliasListEntry{
+		{AliasName: awssdk.String("alias/my-key"), AliasArn: awssdk.String("arn:aws:kms:eu-west-1:123456789012:alias/my-key"), TargetKeyId: awssdk.String("key_1")},
+	}
+	grants := map[string][]*kms.GrantListEntry{
+		"key_1": {
+			{
+				GrantId:          awssdk.String("grant_1"),
+				KeyId:            awssdk.String("arn:aws:kms:eu-west-1:123456789012:key/key_1"),
+				Name:             awssdk.String("my-grant"),
+				GranteePrincipal: awssdk.String("arn:aws:iam::123456789012:role/my-role"),
+				Operations:       []*string{awssdk.String("Encrypt"), awssdk.String("Decrypt")},
+				IssuingAccount:   awssdk.String("arn:aws:iam::123456789012:root"),
+				CreationDate:     awssdk.Time(now),
+			},
+		},
+	}
+
+	//ElastiCache
+	cacheClusters := []*elasticache.CacheCluster{
+		{
+			CacheClusterId:            awssdk.String("cache_1"),
+			CacheClusterStatus:        awssdk.String("available"),
+			CacheNodeType:             awssdk.String("cache.t2.micro"),
+			Engine:                    awssdk.String("redis"),
+			EngineVersion:             awssdk.String("3.2.10"),
+			NumCacheNodes:             awssdk.Int64(1),
+			PreferredAvailabilityZone: awssdk.String("us-west-1a"),
+			ReplicationGroupId:        awssdk.String("repgroup_1"),
+			SecurityGroups:            []*elasticache.SecurityGroupMembership{{SecurityGroupId: awssdk.String("securitygroup_1"), Status: awssdk.String("active")}},
+			CacheClusterCreateTime:    awssdk.Time(now),
+		},
+		{
+			CacheClusterId:            awssdk.String("cache_2"),
+			CacheClusterStatus:        awssdk.String("creating"),
+			CacheNodeType:             awssdk.String("cache.m4.large"),
+			Engine:                    awssdk.String("memcached"),
+			NumCacheNodes:             awssdk.Int64(2),
+			PreferredAvailabilityZone: awssdk.String("us-west-1b"),
+			ConfigurationEndpoint:     &elasticache.Endpoint{Address: awssdk.String("cache-2.cfg.euw1.cache.amazonaws.com"), Port: awssdk.Int64(11211)},
+		},
+	}
+	replicationGroups := []*elasticache.ReplicationGroup{
+		{
+			ReplicationGroupId: awssdk.String("repgroup_1"),
+			Description:        awssdk.String("my sessions"),
+			Status:             awssdk.String("available"),
+			CacheNodeType:      awssdk.String("cache.t2.micro"),
+			AutomaticFailover:  awssdk.String("disabled"),
+			MemberClusters:     []*string{awssdk.String("cache_1")},
+		},
+	}
+
+	//Spot
+	spotFleets := []*ec2.SpotFleetRequestConfig{
+		{
+			SpotFleetRequestId:    awssdk.String("sfr-1"),
+			SpotFleetRequestState: awssdk.String("active"),
+			CreateTime:            awssdk.Time(now),
+			SpotFleetRequestConfig: &ec2.SpotFleetRequestConfigData{
+				IamFleetRole:   awssdk.String("arn:aws:iam::123456789012:role/fleet-role"),
+				TargetCapacity: awssdk.Int64(3),
+				SpotPrice:      awssdk.String("0.05"),
+				Type:           awssdk.String("maintain"),
+			},
+		},
+	}
+	spotRequests := []*ec2.SpotInstanceRequest{
+		{
+			SpotInstanceRequestId:    awssdk.String("sir-1"),
+			State:                    awssdk.String("active"),
+			Type:                     awssdk.String("one-time"),
+			InstanceId:               awssdk.String("inst_1"),
+			SpotPrice:                awssdk.String("0.02"),
+			LaunchedAvailabilityZone: awssdk.String("us-west-1a"),
+			LaunchSpecification:      &ec2.LaunchSpecification{ImageId: awssdk.String("img_1")},
+			CreateTime:               awssdk.Time(now),
+		},
+		{
+			SpotInstanceRequestId: awssdk.String("sir-2"),
+			State:                 awssdk.String("open"),
+			Type:                  awssdk.String("persistent"),
+			SpotPrice:             awssdk.String("0.02"),
+		},
+	}
+
+	mock := &mockEc2{vpcs: vpcs, securitygroups: securityGroups, subnets: subnets, instances: instances, keypairinfos: keypairs, internetgateways: igws, routetables: routeTables, images: images, availabilityzones: availabilityZones, natgateways: natgws, networkinterfaces: networkInterfaces, spotfleetrequestconfigs: spotFleets, spotinstancerequests: spotRequests}
 	mockLb := &mockElbv2{loadbalancers: lbPages, targetgroups: targetGroups, listeners: listeners, targethealthdescriptions: targetHealths}
 	mockEcr := &mockEcr{repositorys: repositories}
-	mockEcs := &mockEcs{clusterNames: clusterNames, clusters: clusters, taskdefinitionNames: defNames, taskdefinitions: tasksDef, tasksNames: tasksNames, tasks: tasks, containerinstancesNames: containerInstancesNames, containerinstances: containerInstances}
+	mockEcs := &mockEcs{clusterNames: clusterNames, clusters: clusters, taskdefinitionNames: defNames, taskdefinitions: tasksDef, tasksNames: tasksNames, tasks: tasks, containerinstancesNames: containerInstancesNames, containerinstances: containerInstances, servicesNames: servicesNames, services: services}
 	mockRds := &mockRds{}
+	mockDynamodb := &mockDynamodb{tableNames: tableNames, tabledescriptions: tables}
 	mockAcm := &mockAcm{certificatesummarys: certificates}
 	mockAutoscaling := &mockAutoscaling{launchconfigurations: launchConfigs, groups: scalingGroups}
+	mockKms := &mockKms{keyListEntries: keyEntries, keymetadatas: keys, aliaslistentrys: aliases, grantlistentrys: grants}
+	mockElasticache := &mockElasticache{cacheclusters: cacheClusters, replicationgroups: replicationGroups}
 	InfraService = &Infra{
 		EC2API:         mock,
 		ECRAPI:         mockEcr,
 		ECSAPI:         mockEcs,
 		ELBV2API:       mockLb,
 		RDSAPI:         mockRds,
+		DynamoDBAPI:    mockDynamodb,
 		ACMAPI:         mockAcm,
 		AutoScalingAPI: mockAutoscaling,
+		KMSAPI:         mockKms,
+		ElastiCacheAPI: mockElasticache,
 		region:         "eu-west-1",
-		fetcher:        fetch.NewFetcher(awsfetch.BuildInfraFetchFuncs(awsfetch.NewConfig(mock, mockEcr, mockEcs, mockLb, mockRds, mockAutoscaling, mockAcm))),
+		fetcher:        fetch.NewFetcher(awsfetch.BuildInfraFetchFuncs(awsfetch.NewConfig(mock, mockEcr, mockEcs, mockLb, mockRds, mockDynamodb, mockAutoscaling, mockAcm, mockKms, mockElasticache))),
 	}
 	g, err := InfraService.Fetch(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	resources, err := g.Find(cloud.NewQuery("region", "instance", "vpc", "securitygroup", "subnet", "keypair", "internetgateway", cloud.NatGateway, "routetable", "loadbalancer", "targetgroup", "listener", "launchconfiguration", "scalinggroup", "image", "availabilityzone", "repository", cloud.ContainerCluster, cloud.ContainerTask, cloud.Container, cloud.ContainerInstance, cloud.NetworkInterface, cloud.Certificate))
+	resources, err := g.Find(cloud.NewQuery("region", "instance", "vpc", "securitygroup", "subnet", "keypair", "internetgateway", cloud.NatGateway, "routetable", "loadbalancer", "targetgroup", "listener", "launchconfiguration", "scalinggroup", "image", "availabilityzone", "repository", cloud.ContainerCluster, cloud.ContainerService, cloud.ContainerTask, cloud.Container, cloud.ContainerInstance, cloud.NetworkInterface, cloud.Certificate, cloud.Table, cloud.TableIndex, cloud.Key, cloud.Alias, cloud.KeyGrant, cloud.CacheCluster, cloud.ReplicationGroup, cloud.SpotFleet, cloud.SpotRequest))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -618,6 +800,9 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		if p, ok := res.Properties()[p.IPv6Addresses].([]string); ok {
 			sort.Strings(p)
 		}
+		if p, ok := res.Properties()[p.Operations].([]string); ok {
+			sort.Strings(p)
+		}
 	}
 
 	expected := map[string]cloud.Resource{
@@ -667,6 +852,10 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		"clust_1":          resourcetest.ContainerCluster("clust_1").Prop(p.Arn, "clust_1").Prop(p.Name, "my_cust_1").Prop(p.PendingTasksCount, 1).Prop(p.ActiveServicesCount, 3).Prop(p.RegisteredContainerInstancesCount, 3).Prop(p.RunningTasksCount, 2).Prop(p.State, "ACTIVE").Build(),
 		"clust_2":          resourcetest.ContainerCluster("clust_2").Prop(p.Arn, "clust_2").Build(),
 		"clust_3":          resourcetest.ContainerCluster("clust_3").Prop(p.Arn, "clust_3").Prop(p.Name, "my_cust_3").Build(),
+		"svc_1": resourcetest.ContainerService("svc_1").Prop(p.Arn, "svc_1").Prop(p.Name, "container-service-1").Prop(p.Cluster, "clust_1").Prop(p.ContainerTask, "cs_2:1").Prop(p.DesiredCount, 3).Prop(p.RunningTasksCount, 3).
+			Prop(p.PendingTasksCount, 0).Prop(p.LaunchType, "EC2").Prop(p.Role, "role:arn").Prop(p.Created, now).Prop(p.State, "ACTIVE").Build(),
+		"svc_2": resourcetest.ContainerService("svc_2").Prop(p.Arn, "svc_2").Prop(p.Name, "container-service-2").Prop(p.Cluster, "clust_2").Prop(p.ContainerTask, "cs_2:2").Prop(p.LaunchType, "FARGATE").
+			Prop(p.Subnets, []string{"sub_3"}).Prop(p.SecurityGroups, []string{"securitygroup_2"}).Build(),
 		"cs_1:1": resourcetest.ContainerTask("cs_1:1").Prop(p.Arn, "cs_1:1").Prop(p.ContainersImages, []*graph.KeyValue{{"cont_name_1", "image_1"}, {"cont_name_2", "image_2"}, {"cont_name_3", "image_3"}}).Prop(p.Name, "cs_1").Prop(p.Version, "1").
 			Prop(p.State, "1 task running").Prop(p.Role, "role:arn").Prop(p.Deployments, []*graph.KeyValue{{"clust_2", "cs_1 (running task)"}}).Build(),
 		"cs_2:1": resourcetest.ContainerTask("cs_2:1").Prop(p.Arn, "cs_2:1").Prop(p.Name, "cs_2").Prop(p.State, "1 service running").Prop(p.Version, "1").Prop(p.Deployments, []*graph.KeyValue{{"clust_1", "container-service-1 (running service)"}}).Build(),
@@ -689,20 +878,47 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		"arn:certif_1234": resourcetest.Certificate("arn:certif_1234").Prop(p.Arn, "arn:certif_1234").Prop(p.Name, "domain-name.1").Build(),
 		"arn:certif_2345": resourcetest.Certificate("arn:certif_2345").Prop(p.Arn, "arn:certif_2345").Prop(p.Name, "domain-name.2").Build(),
 		"arn:certif_3456": resourcetest.Certificate("arn:certif_3456").Prop(p.Arn, "arn:certif_3456").Prop(p.Name, "domain-name.3").Build(),
+		"table_1": resourcetest.Table("table_1").Prop(p.Name, "table_1").Prop(p.Arn, "arn:aws:dynamodb:eu-west-1:123456789012:table/table_1").Prop(p.State, "ACTIVE").Prop(p.Created, now).Prop(p.Size, 2048).
+			Prop(p.ItemCount, 12).Prop(p.HashKey, "id").Prop(p.RangeKey, "date").Prop(p.ReadCapacity, 5).Prop(p.WriteCapacity, 10).Build(),
+		"table_2": resourcetest.Table("table_2").Prop(p.Name, "table_2").Prop(p.HashKey, "key").Build(),
+		"arn:aws:dynamodb:eu-west-1:123456789012:table/table_1/index/by_email": resourcetest.TableIndex("arn:aws:dynamodb:eu-west-1:123456789012:table/table_1/index/by_email").Prop(p.Name, "by_email").
+			Prop(p.Arn, "arn:aws:dynamodb:eu-west-1:123456789012:table/table_1/index/by_email").Prop(p.State, "ACTIVE").Prop(p.Size, 512).Prop(p.ItemCount, 3).Prop(p.HashKey, "email").
+			Prop(p.Projection, "KEYS_ONLY").Prop(p.ReadCapacity, 1).Prop(p.WriteCapacity, 2).Build(),
+		"key_1": resourcetest.Key("key_1").Prop(p.Arn, "arn:aws:kms:eu-west-1:123456789012:key/key_1").Prop(p.Description, "my key").Prop(p.State, "Enabled").Prop(p.Enabled, true).
+			Prop(p.Type, "CUSTOMER").Prop(p.Owner, "123456789012").Prop(p.Created, now).Build(),
+		"key_2":        resourcetest.Key("key_2").Prop(p.State, "PendingDeletion").Prop(p.Enabled, false).Build(),
+		"alias/my-key": resourcetest.Alias("alias/my-key").Prop(p.Name, "alias/my-key").Prop(p.Arn, "arn:aws:kms:eu-west-1:123456789012:alias/my-key").Prop(p.Key, "key_1").Build(),
+		"grant_1": resourcetest.KeyGrant("grant_1").Prop(p.Name, "my-grant").Prop(p.Key, "arn:aws:kms:eu-west-1:123456789012:key/key_1").Prop(p.GranteePrincipal, "arn:aws:iam::123456789012:role/my-role").
+			Prop(p.Operations, []string{"Decrypt", "Encrypt"}).Prop(p.Account, "arn:aws:iam::123456789012:root").Prop(p.Created, now).Build(),
+		"cache_1": resourcetest.CacheCluster("cache_1").Prop(p.Name, "cache_1").Prop(p.State, "available").Prop(p.Class, "cache.t2.micro").Prop(p.Engine, "redis").Prop(p.EngineVersion, "3.2.10").
+			Prop(p.Size, 1).Prop(p.AvailabilityZone, "us-west-1a").Prop(p.SecurityGroups, []string{"securitygroup_1"}).Prop(p.Created, now).Build(),
+		"cache_2": resourcetest.CacheCluster("cache_2").Prop(p.Name, "cache_2").Prop(p.State, "creating").Prop(p.Class, "cache.m4.large").Prop(p.Engine, "memcached").Prop(p.Size, 2).
+			Prop(p.AvailabilityZone, "us-west-1b").Prop(p.Endpoint, "cache-2.cfg.euw1.cache.amazonaws.com").Prop(p.Port, 11211).Build(),
+		"repgroup_1": resourcetest.ReplicationGroup("repgroup_1").Prop(p.Name, "repgroup_1").Prop(p.Description, "my sessions").Prop(p.State, "available").Prop(p.Class, "cache.t2.micro").
+			Prop(p.Failover, "disabled").Build(),
+		"sfr-1": resourcetest.SpotFleet("sfr-1").Prop(p.State, "active").Prop(p.DesiredCapacity, 3).Prop(p.SpotPrice, "0.05").Prop(p.Role, "arn:aws:iam::123456789012:role/fleet-role").
+			Prop(p.Type, "maintain").Prop(p.Created, now).Build(),
+		"sir-1": resourcetest.SpotRequest("sir-1").Prop(p.State, "active").Prop(p.Type, "one-time").Prop(p.Instance, "inst_1").Prop(p.SpotPrice, "0.02").Prop(p.AvailabilityZone, "us-west-1a").
+			Prop(p.Image, "img_1").Prop(p.Created, now).Build(),
+		"sir-2": resourcetest.SpotRequest("sir-2").Prop(p.State, "open").Prop(p.Type, "persistent").Prop(p.SpotPrice, "0.02").Build(),
 	}
 
 	expectedChildren := map[string][]string{
-		"eu-west-1": {"arn:certif_1234", "arn:certif_2345", "arn:certif_3456", "asg_arn_1", "asg_arn_2", "clust_1", "clust_2", "clust_3", "cs_1:1", "cs_2:1", "cs_2:2", "cs_3:1", "igw_1", "img_1", "img_2", "launchconfig_arn", "my_key", "natgw_1", "repo_1", "repo_2", "repo_3", "us-west-1a", "us-west-1b", "vpc_1", "vpc_2"},
-		"lb_1":      {"list_1", "list_1.2"},
-		"lb_2":      {"list_2"},
-		"lb_3":      {"list_3"},
-		"sub_1":     {"eni-1", "inst_1"},
-		"sub_2":     {"inst_2"},
-		"sub_3":     {"eni-2", "inst_3", "inst_4", "inst_6"},
-		"vpc_1":     {"lb_1", "lb_3", "natgw_1", "rt_1", "securitygroup_1", "securitygroup_2", "sub_1", "sub_2", "tg_1"},
-		"vpc_2":     {"lb_2", "sub_3", "tg_2"},
-		"clust_1":   {"cont_inst_1", "cont_inst_2", "container_1", "container_2", "container_3"},
-		"clust_2":   {"cont_inst_3", "container_4", "container_5"},
+		"eu-west-1":  {"arn:certif_1234", "arn:certif_2345", "arn:certif_3456", "asg_arn_1", "asg_arn_2", "clust_1", "clust_2", "clust_3", "cs_1:1", "cs_2:1", "cs_2:2", "cs_3:1", "igw_1", "img_1", "img_2", "key_1", "key_2", "launchconfig_arn", "my_key", "natgw_1", "repgroup_1", "repo_1", "repo_2", "repo_3", "sfr-1", "sir-1", "sir-2", "table_1", "table_2", "us-west-1a", "us-west-1b", "vpc_1", "vpc_2"},
+		"lb_1":       {"list_1", "list_1.2"},
+		"lb_2":       {"list_2"},
+		"lb_3":       {"list_3"},
+		"sub_1":      {"eni-1", "inst_1"},
+		"sub_2":      {"inst_2"},
+		"sub_3":      {"eni-2", "inst_3", "inst_4", "inst_6"},
+		"vpc_1":      {"lb_1", "lb_3", "natgw_1", "rt_1", "securitygroup_1", "securitygroup_2", "sub_1", "sub_2", "tg_1"},
+		"vpc_2":      {"lb_2", "sub_3", "tg_2"},
+		"clust_1":    {"cont_inst_1", "cont_inst_2", "container_1", "container_2", "container_3", "svc_1"},
+		"clust_2":    {"cont_inst_3", "container_4", "container_5", "svc_2"},
+		"table_1":    {"arn:aws:dynamodb:eu-west-1:123456789012:table/table_1/index/by_email"},
+		"key_1":      {"alias/my-key", "grant_1"},
+		"us-west-1a": {"cache_1"},
+		"us-west-1b": {"cache_2"},
 	}
 
 	expectedAppliedOn := map[string][]string{
@@ -713,15 +929,15 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		"my_key":          {"inst_4", "inst_6", "launchconfig_arn"},
 		"natgw_1":         {"sub_1"},
 		"rt_1":            {"sub_1", "sub_2"},
-		"securitygroup_1": {"eni-1", "inst_2", "inst_4", "inst_6", "lb_3"},
+		"securitygroup_1": {"cache_1", "eni-1", "inst_2", "inst_4", "inst_6", "lb_3"},
 		"securitygroup_2": {"eni-1", "inst_4", "lb_3"},
-		"tg_1":            {"inst_1"},
+		"tg_1":            {"inst_1", "svc_1"},
 		"tg_2":            {"inst_2", "inst_3"},
 		"asg_arn_1":       {"inst_1", "inst_3", "sub_1", "sub_2"},
 		"asg_arn_2":       {"tg_1", "tg_2"},
 		"cs_1:1":          {"container_5"},
-		"cs_2:1":          {"container_1", "container_2", "container_3"},
-		"cs_2:2":          {"container_4"},
+		"cs_2:1":          {"container_1", "container_2", "container_3", "svc_1"},
+		"cs_2:2":          {"container_4", "svc_2"},
 		"inst_1":          {"cont_inst_3"},
 		"inst_2":          {"cont_inst_1"},
 		"inst_3":          {"cont_inst_2"},
@@ -729,6 +945,8 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		"cont_inst_2":     {"container_4"},
 		"cont_inst_3":     {"container_5"},
 		"eni-1":           {"inst_1"},
+		"repgroup_1":      {"cache_1"},
+		"sir-1":           {"inst_1"},
 	}
 
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
@@ -825,9 +1043,15 @@ func TestBuildDnsRdfGraph(t *testing.T) {
 		"/hostedzone/23456": {
 			{Type: awssdk.String("A"), TTL: awssdk.Int64(30), Name: awssdk.String("subdomain1.my.second.domain"), ResourceRecords: []*route53.ResourceRecord{{Value: awssdk.String("5.6.7.8")}}},
 			{Type: awssdk.String("CNAME"), TTL: awssdk.Int64(10), Name: awssdk.String("subdomain3.my.second.domain"), ResourceRecords: []*route53.ResourceRecord{{Value: awssdk.String("6.7.8.9")}}},
+			{Type: awssdk.String("A"), TTL: awssdk.Int64(60), Name: awssdk.String("www.my.second.domain"), SetIdentifier: awssdk.String("eu"), Weight: awssdk.Int64(80), HealthCheckId: awssdk.String("hc-1"), ResourceRecords: []*route53.ResourceRecord{{Value: awssdk.String("7.8.9.10")}}},
+			{Type: awssdk.String("A"), TTL: awssdk.Int64(60), Name: awssdk.String("www.my.second.domain"), SetIdentifier: awssdk.String("us"), Weight: awssdk.Int64(20), HealthCheckId: awssdk.String("hc-2"), ResourceRecords: []*route53.ResourceRecord{{Value: awssdk.String("8.9.10.11")}}},
 		},
 	}
-	mockRoute53 := &mockRoute53{hostedzones: zonePages, resourcerecordsets: recordPages}
+	healthchecks := []*route53.HealthCheck{
+		{Id: awssdk.String("hc-1"), CallerReference: awssdk.String("ref-1"), HealthCheckConfig: &route53.HealthCheckConfig{Type: awssdk.String("HTTP"), IPAddress: awssdk.String("7.8.9.10"), Port: awssdk.Int64(80), ResourcePath: awssdk.String("/health"), RequestInterval: awssdk.Int64(30), FailureThreshold: awssdk.Int64(3)}},
+		{Id: awssdk.String("hc-2"), CallerReference: awssdk.String("ref-2"), HealthCheckConfig: &route53.HealthCheckConfig{Type: awssdk.String("TCP"), FullyQualifiedDomainName: awssdk.String("us.my.second.domain"), Port: awssdk.Int64(443), RequestInterval: awssdk.Int64(10)}},
+	}
+	mockRoute53 := &mockRoute53{hostedzones: zonePages, resourcerecordsets: recordPages, healthchecks: healthchecks}
 
 	dns := Dns{
 		Route53API: mockRoute53, region: "eu-west-1",
@@ -839,7 +1063,7 @@ func TestBuildDnsRdfGraph(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	resources, err := g.Find(cloud.NewQuery("zone", "record"))
+	resources, err := g.Find(cloud.NewQuery("zone", "record", "healthcheck"))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -859,12 +1083,19 @@ func TestBuildDnsRdfGraph(t *testing.T) {
 		"awls-be1e0b6a":     resourcetest.Record("awls-be1e0b6a").Prop(p.Name, "subdomain3.my.first.domain").Prop(p.Type, "CNAME").Prop(p.TTL, 60).Prop(p.Records, []string{"4.5.6.7"}).Build(),
 		"awls-9c420a99":     resourcetest.Record("awls-9c420a99").Prop(p.Name, "subdomain1.my.second.domain").Prop(p.Type, "A").Prop(p.TTL, 30).Prop(p.Records, []string{"5.6.7.8"}).Build(),
 		"awls-c9b80bbe":     resourcetest.Record("awls-c9b80bbe").Prop(p.Name, "subdomain3.my.second.domain").Prop(p.Type, "CNAME").Prop(p.TTL, 10).Prop(p.Records, []string{"6.7.8.9"}).Build(),
+		"awls-6b7008e5":     resourcetest.Record("awls-6b7008e5").Prop(p.Name, "www.my.second.domain").Prop(p.Type, "A").Prop(p.TTL, 60).Prop(p.Records, []string{"7.8.9.10"}).Prop(p.Set, "eu").Prop(p.Weight, 80).Prop(p.HealthCheck, "hc-1").Build(),
+		"awls-6b8e08f3":     resourcetest.Record("awls-6b8e08f3").Prop(p.Name, "www.my.second.domain").Prop(p.Type, "A").Prop(p.TTL, 60).Prop(p.Records, []string{"8.9.10.11"}).Prop(p.Set, "us").Prop(p.Weight, 20).Prop(p.HealthCheck, "hc-2").Build(),
+		"hc-1":              resourcetest.HealthCheck("hc-1").Prop(p.CallerReference, "ref-1").Prop(p.Type, "HTTP").Prop(p.PublicIP, "7.8.9.10").Prop(p.Port, 80).Prop(p.CheckPath, "/health").Prop(p.CheckInterval, 30).Prop(p.UnhealthyThresholdCount, 3).Build(),
+		"hc-2":              resourcetest.HealthCheck("hc-2").Prop(p.CallerReference, "ref-2").Prop(p.Type, "TCP").Prop(p.PublicDNS, "us.my.second.domain").Prop(p.Port, 443).Prop(p.CheckInterval, 10).Build(),
 	}
 	expectedChildren := map[string][]string{
 		"/hostedzone/12345": {"awls-91fa0a45", "awls-920c0a46", "awls-be1e0b6a"},
-		"/hostedzone/23456": {"awls-9c420a99", "awls-c9b80bbe"},
+		"/hostedzone/23456": {"awls-6b7008e5", "awls-6b8e08f3", "awls-9c420a99", "awls-c9b80bbe"},
+	}
+	expectedAppliedOn := map[string][]string{
+		"hc-1": {"awls-6b7008e5"},
+		"hc-2": {"awls-6b8e08f3"},
 	}
-	expectedAppliedOn := map[string][]string{}
 
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
 }
@@ -964,13 +1195,73 @@ func TestBuildLambdaGraph(t *testing.T) {
 			Version:      awssdk.String("v2"),
 		},
 		{FunctionArn: awssdk.String("func_3_arn")},
+		{FunctionArn: awssdk.String("arn:aws:lambda:eu-west-1:123456789012:function:pets")},
+	}
+	aliases := []*lambda.AliasConfiguration{
+		{AliasArn: awssdk.String("func_2_arn:prod"), Name: awssdk.String("prod"), FunctionVersion: awssdk.String("2"), Description: awssdk.String("production")},
+		{AliasArn: awssdk.String("func_2_arn:staging"), Name: awssdk.String("staging"), FunctionVersion: awssdk.String("$LATEST")},
+	}
+	mappings := []*lambda.EventSourceMappingConfiguration{
+		{
+			UUID:                  awssdk.String("mapping_1"),
+			FunctionArn:           awssdk.String("func_2_arn"),
+			EventSourceArn:        awssdk.String("arn:aws:sqs:eu-west-1:123456789012:my-queue"),
+			State:                 awssdk.String("Enabled"),
+			StateTransitionReason: awssdk.String("USER_INITIATED"),
+			LastModified:          awssdk.Time(time.Unix(1136214245, 0)),
+		},
+		{UUID: awssdk.String("mapping_2"), FunctionArn: awssdk.String("func_3_arn")},
 	}
 
-	mock := &mockLambda{functionconfigurations: functions}
+	machines := []*sfn.StateMachineListItem{
+		{StateMachineArn: awssdk.String("machine_1_arn"), Name: awssdk.String("machine_1"), CreationDate: awssdk.Time(time.Unix(1136214245, 0))},
+		{StateMachineArn: awssdk.String("machine_2_arn"), Name: awssdk.String("machine_2")},
+	}
+	executions := []*sfn.ExecutionListItem{
+		{
+			ExecutionArn:    awssdk.String("execution_1_arn"),
+			StateMachineArn: awssdk.String("machine_1_arn"),
+			Name:            awssdk.String("execution_1"),
+			Status:          awssdk.String("SUCCEEDED"),
+			StartDate:       awssdk.Time(time.Unix(1136214245, 0)),
+			StopDate:        awssdk.Time(time.Unix(1136214305, 0)),
+		},
+		{ExecutionArn: awssdk.String("execution_2_arn"), StateMachineArn: awssdk.String("machine_1_arn"), Status: awssdk.String("RUNNING")},
+	}
+
+	restapis := []*apigateway.RestApi{
+		{Id: awssdk.String("api_1"), Name: awssdk.String("petstore"), Description: awssdk.String("pets api"), Version: awssdk.String("1.0"), CreatedDate: awssdk.Time(time.Unix(1136214245, 0))},
+		{Id: awssdk.String("api_2")},
+	}
+	stages := map[string][]*apigateway.Stage{
+		"api_1": {{StageName: awssdk.String("prod"), DeploymentId: awssdk.String("dep_1"), Description: awssdk.String("production"), LastUpdatedDate: awssdk.Time(time.Unix(1136214305, 0))}},
+	}
+	deployments := map[string][]*apigateway.Deployment{
+		"api_1": {
+			{Id: awssdk.String("dep_1"), Description: awssdk.String("first"), CreatedDate: awssdk.Time(time.Unix(1136214245, 0))},
+			{Id: awssdk.String("dep_2")},
+		},
+	}
+	apiResources := map[string][]*apigateway.Resource{
+		"api_1": {
+			{Id: awssdk.String("res_1"), Path: awssdk.String("/")},
+			{Id: awssdk.String("res_2"), Path: awssdk.String("/pets"), ResourceMethods: map[string]*apigateway.Method{
+				"GET": {MethodIntegration: &apigateway.Integration{
+					Type: awssdk.String("AWS_PROXY"),
+					Uri:  awssdk.String("arn:aws:apigateway:eu-west-1:lambda:path/2015-03-31/functions/arn:aws:lambda:eu-west-1:123456789012:function:pets/invocations"),
+				}},
+				"POST": {MethodIntegration: &apigateway.Integration{Type: awssdk.String("MOCK")}},
+			}},
+		},
+	}
+
+	mock := &mockLambda{functionconfigurations: functions, aliasconfigurations: aliases, eventsourcemappingconfigurations: mappings}
+	sfnMock := &mockSfn{statemachinelistitems: machines, executionlistitems: executions}
+	apigatewayMock := &mockApigateway{restapis: restapis, stages: stages, deployments: deployments, resources: apiResources}
 
 	service := Lambda{
-		LambdaAPI: mock, region: "eu-west-1",
-		fetcher: fetch.NewFetcher(awsfetch.BuildLambdaFetchFuncs(awsfetch.NewConfig(mock))),
+		LambdaAPI: mock, SFNAPI: sfnMock, APIGatewayAPI: apigatewayMock, region: "eu-west-1",
+		fetcher: fetch.NewFetcher(awsfetch.BuildLambdaFetchFuncs(awsfetch.NewConfig(mock, sfnMock, apigatewayMock))),
 	}
 
 	g, err := service.Fetch(context.Background())
@@ -978,23 +1269,51 @@ func TestBuildLambdaGraph(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	resources, err := g.Find(cloud.NewQuery("function"))
+	resources, err := g.Find(cloud.NewQuery("function", cloud.FunctionAlias, cloud.EventSourceMapping, cloud.StateMachine, cloud.Execution, cloud.RestApi, cloud.ApiStage, cloud.ApiDeployment))
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	petsArn := "arn:aws:lambda:eu-west-1:123456789012:function:pets"
+	prodStageId := awsconv.HashFields("dep_1", "prod")
+
 	expected := map[string]cloud.Resource{
 		"func_1_arn": resourcetest.Function("func_1_arn").Prop(p.Arn, "func_1_arn").Build(),
 		"func_2_arn": resourcetest.Function("func_2_arn").Prop(p.Arn, "func_2_arn").Prop(p.Name, "func_2_name").Prop(p.Hash, "abcdef123456789").Prop(p.Size, 1234).
 			Prop(p.Description, "my function desc").Prop(p.Handler, "handl").Prop(p.Modified, time.Unix(1136214245, 0).UTC()).Prop(p.Memory, 1234).Prop(p.Role, "role").
 			Prop(p.Runtime, "runtime").Prop(p.Timeout, 60).Prop(p.Version, "v2").Build(),
-		"func_3_arn": resourcetest.Function("func_3_arn").Prop(p.Arn, "func_3_arn").Build(),
+		"func_3_arn":         resourcetest.Function("func_3_arn").Prop(p.Arn, "func_3_arn").Build(),
+		"func_2_arn:prod":    resourcetest.FunctionAlias("func_2_arn:prod").Prop(p.Arn, "func_2_arn:prod").Prop(p.Name, "prod").Prop(p.Version, "2").Prop(p.Description, "production").Build(),
+		"func_2_arn:staging": resourcetest.FunctionAlias("func_2_arn:staging").Prop(p.Arn, "func_2_arn:staging").Prop(p.Name, "staging").Prop(p.Version, "$LATEST").Build(),
+		"mapping_1": resourcetest.EventSourceMapping("mapping_1").Prop(p.Source, "arn:aws:sqs:eu-west-1:123456789012:my-queue").Prop(p.State, "Enabled").
+			Prop(p.StateMessage, "USER_INITIATED").Prop(p.Modified, time.Unix(1136214245, 0).UTC()).Build(),
+		"mapping_2":     resourcetest.EventSourceMapping("mapping_2").Build(),
+		"machine_1_arn": resourcetest.StateMachine("machine_1_arn").Prop(p.Arn, "machine_1_arn").Prop(p.Name, "machine_1").Prop(p.Created, time.Unix(1136214245, 0).UTC()).Build(),
+		"machine_2_arn": resourcetest.StateMachine("machine_2_arn").Prop(p.Arn, "machine_2_arn").Prop(p.Name, "machine_2").Build(),
+		"execution_1_arn": resourcetest.Execution("execution_1_arn").Prop(p.Arn, "execution_1_arn").Prop(p.Name, "execution_1").Prop(p.State, "SUCCEEDED").
+			Prop(p.Launched, time.Unix(1136214245, 0).UTC()).Prop(p.Stopped, time.Unix(1136214305, 0).UTC()).Build(),
+		"execution_2_arn": resourcetest.Execution("execution_2_arn").Prop(p.Arn, "execution_2_arn").Prop(p.State, "RUNNING").Build(),
+		petsArn:           resourcetest.Function(petsArn).Prop(p.Arn, petsArn).Build(),
+		"api_1": resourcetest.RestApi("api_1").Prop(p.Name, "petstore").Prop(p.Description, "pets api").Prop(p.Version, "1.0").
+			Prop(p.Created, time.Unix(1136214245, 0).UTC()).Build(),
+		"api_2": resourcetest.RestApi("api_2").Build(),
+		prodStageId: resourcetest.ApiStage(prodStageId).Prop(p.Name, "prod").Prop(p.Deployment, "dep_1").Prop(p.Description, "production").
+			Prop(p.Modified, time.Unix(1136214305, 0).UTC()).Build(),
+		"dep_1": resourcetest.ApiDeployment("dep_1").Prop(p.Description, "first").Prop(p.Created, time.Unix(1136214245, 0).UTC()).Build(),
+		"dep_2": resourcetest.ApiDeployment("dep_2").Build(),
 	}
 
 	expectedChildren := map[string][]string{
-		"eu-west-1": {"func_1_arn", "func_2_arn", "func_3_arn"},
+		"eu-west-1":     {"api_1", "api_2", petsArn, "func_1_arn", "func_2_arn", "func_3_arn", "machine_1_arn", "machine_2_arn"},
+		"func_2_arn":    {"func_2_arn:prod", "func_2_arn:staging", "mapping_1"},
+		"func_3_arn":    {"mapping_2"},
+		"machine_1_arn": {"execution_1_arn", "execution_2_arn"},
+		"api_1":         {prodStageId, "dep_1", "dep_2"},
+	}
+	expectedAppliedOn := map[string][]string{
+		petsArn: {"api_1"},
+		"dep_1": {prodStageId},
 	}
-	expectedAppliedOn := map[string][]string{}
 
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
 }
@@ -1105,7 +1424,7 @@ func TestBuildCdnGraph(t *testing.T) {
 						DomainName:     awssdk.String("domain.name"),
 						Id:             awssdk.String("origin_1"),
 						OriginPath:     awssdk.String("my/s3/path"),
-						S3OriginConfig: &cloudfront.S3OriginConfig{OriginAccessIdentity: awssdk.String("origin-access-identity/CloudFront/ID-of-origin-access-identity")},
+						S3OriginConfig: &cloudfront.S3OriginConfig{OriginAccessIdentity: awssdk.String("origin-access-identity/CloudFront/oai_1")},
 					},
 					{
 						DomainName: awssdk.String("domain2.name"),
@@ -1135,7 +1454,12 @@ func TestBuildCdnGraph(t *testing.T) {
 		},
 	}
 
-	mock := &mockCloudfront{distributionsummarys: distributions}
+	identities := []*cloudfront.OriginAccessIdentitySummary{
+		{Id: awssdk.String("oai_1"), Comment: awssdk.String("access to my bucket"), S3CanonicalUserId: awssdk.String("canonical_1")},
+		{Id: awssdk.String("oai_2")},
+	}
+
+	mock := &mockCloudfront{distributionsummarys: distributions, originaccessidentitysummarys: identities}
 
 	service := Cdn{
 		CloudFrontAPI: mock, region: "eu-west-1",
@@ -1147,7 +1471,7 @@ func TestBuildCdnGraph(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	resources, err := g.Find(cloud.NewQuery("distribution"))
+	resources, err := g.Find(cloud.NewQuery("distribution", cloud.OriginAccessIdentity))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1182,16 +1506,20 @@ func TestBuildCdnGraph(t *testing.T) {
 			Prop(p.TLSVersionRequired, "TLSv1").
 			Prop(p.SSLSupportMethod, "sni-only").
 			Prop(p.Origins, []*graph.DistributionOrigin{
-				{ID: "origin_1", PublicDNS: "domain.name", OriginType: "s3", PathPrefix: "my/s3/path", Config: "origin-access-identity/CloudFront/ID-of-origin-access-identity"},
+				{ID: "origin_1", PublicDNS: "domain.name", OriginType: "s3", PathPrefix: "my/s3/path", Config: "origin-access-identity/CloudFront/oai_1"},
 				{ID: "origin_2", PublicDNS: "domain2.name", PathPrefix: "my/other/path"},
 			}).
 			Build(),
-		"ds_2": resourcetest.Distribution("ds_2").Prop(p.Arn, "ds_2_arn").Prop(p.PublicDNS, "other.domain.name").Build(),
-		"ds_3": resourcetest.Distribution("ds_3").Build(),
+		"ds_2":  resourcetest.Distribution("ds_2").Prop(p.Arn, "ds_2_arn").Prop(p.PublicDNS, "other.domain.name").Build(),
+		"ds_3":  resourcetest.Distribution("ds_3").Build(),
+		"oai_1": resourcetest.OriginAccessIdentity("oai_1").Prop(p.Comment, "access to my bucket").Prop(p.CanonicalUser, "canonical_1").Build(),
+		"oai_2": resourcetest.OriginAccessIdentity("oai_2").Build(),
 	}
 
 	expectedChildren := map[string][]string{}
-	expectedAppliedOn := map[string][]string{}
+	expectedAppliedOn := map[string][]string{
+		"oai_1": {"ds_1"},
+	}
 
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
 }
@@ -1325,13 +1653,16 @@ func TestBuildEmptyRdfGraphWhenNoData(t *testing.T) {
 		EC2API:         &mockEc2{},
 		ELBV2API:       &mockElbv2{},
 		RDSAPI:         &mockRds{},
+		DynamoDBAPI:    &mockDynamodb{},
 		AutoScalingAPI: &mockAutoscaling{},
 		ECRAPI:         &mockEcr{},
 		ECSAPI:         &mockEcs{},
 		ACMAPI:         &mockAcm{},
+		KMSAPI:         &mockKms{},
+		ElastiCacheAPI: &mockElasticache{},
 		region:         "eu-west-1",
 		fetcher: fetch.NewFetcher(awsfetch.BuildInfraFetchFuncs(awsfetch.NewConfig(
-			&mockEc2{}, &mockElbv2{}, &mockRds{}, &mockEcr{}, &mockEcs{}, &mockAutoscaling{}, &mockAcm{},
+			&mockEc2{}, &mockElbv2{}, &mockRds{}, &mockDynamodb{}, &mockEcr{}, &mockEcs{}, &mockAutoscaling{}, &mockAcm{}, &mockKms{}, &mockElasticache{},
 		))),
 	}
 
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+// SSMSession is a Session Manager session started on an instance, whose data
+// channel is to be opened with its stream URL and token
+type SSMSession struct {
+	SessionId, StreamUrl, TokenValue  string
+	Target, Region, Profile, Endpoint string
+}
+
+// StartSSMSession starts a Session Manager session on the instance of the current region
+func StartSSMSession(instanceID string) (*SSMSession, error) {
+	if current.sess == nil {
+		return nil, errors.New("start ssm session: AWS session not initialized")
+	}
+	session, err := startSSMSession(ssm.New(current.sess), instanceID)
+	if err != nil {
+		return nil, err
+	}
+	session.Profile = current.profile
+	return session, nil
+}
+
+// The vendored SDK does not expose the StartSession operation of Session Manager,
+// so we build the raw JSON RPC request ourselves
+type startSessionInput struct {
+	_      struct{} `type:"structure"`
+	Target *string  `type:"string" required:"true"`
+}
+
+type startSessionOutput struct {
+	_          struct{} `type:"structure"`
+	SessionId  *string  `type:"string"`
+	StreamUrl  *string  `type:"string"`
+	TokenValue *string  `type:"string"`
+}
+
+func startSSMSession(client *ssm.SSM, instanceID string) (*SSMSession, error) {
+	op := &request.Operation{
+		Name:       "StartSession",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	out := &startSessionOutput{}
+	if err := client.NewRequest(op, &startSessionInput{Target: awssdk.String(instanceID)}, out).Send(); err != nil {
+		if strings.Contains(err.Error(), "TargetNotConnected") {
+			return nil, fmt.Errorf("start ssm session: instance %s not connected to Session Manager: it needs a running SSM agent and an instance profile allowing Systems Manager (%s)", instanceID, err)
+		}
+		return nil, fmt.Errorf("start ssm session: %s", err)
+	}
+	return &SSMSession{
+		SessionId:  awssdk.StringValue(out.SessionId),
+		StreamUrl:  awssdk.StringValue(out.StreamUrl),
+		TokenValue: awssdk.StringValue(out.TokenValue),
+		Target:     instanceID,
+		Region:     awssdk.StringValue(client.Config.Region),
+		Endpoint:   client.Endpoint,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package awsservices
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+func TestStartSSMSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Amz-Target"), "AmazonSSM.StartSession"; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), "i-offline") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"TargetNotConnected","message":"i-offline is not connected."}`))
+			return
+		}
+		if got, want := string(body), `{"Target":"i-1234"}`; got != want {
+			t.Errorf("got %s, want %s", got, want)
+		}
+		w.Write([]byte(`{"SessionId":"alice-0123","StreamUrl":"wss://ssmmessages.eu-west-1.amazonaws.com/v1/data-channel/alice-0123","TokenValue":"token"}`))
+	}))
+	defer server.Close()
+
+	sess := session.Must(session.NewSession(&awssdk.Config{
+		Region:      awssdk.String("eu-west-1"),
+		Endpoint:    awssdk.String(server.URL),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	}))
+
+	started, err := startSSMSession(ssm.New(sess), "i-1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &SSMSession{
+		SessionId:  "alice-0123",
+		StreamUrl:  "wss://ssmmessages.eu-west-1.amazonaws.com/v1/data-channel/alice-0123",
+		TokenValue: "token",
+		Target:     "i-1234",
+		Region:     "eu-west-1",
+		Endpoint:   server.URL,
+	}
+	if got, want := started, exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	_, err = startSSMSession(ssm.New(sess), "i-offline")
+	if err == nil || !strings.Contains(err.Error(), "not connected to Session Manager") {
+		t.Fatalf("got %v, want not connected error", err)
+	}
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+const TemplatesLogTargetConfigKey = "aws.templates.log"
+
+// templatesLogLockTTL bounds the lock of a run which could not release it (ex: killed).
+// The lock of a run is renewed while it lasts.
+const templatesLogLockTTL = 10 * time.Minute
+
+// ParseTemplatesLogTarget parses where the executed templates are stored: either a DynamoDB table
+// (`dynamodb:<table>` with a string hash key named `Key`) or an S3 prefix (`s3://<bucket>/<prefix>`)
+func ParseTemplatesLogTarget(s string) (*ReservationsTarget, error) {
+	target, err := ParseReservationsTarget(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid templates log target '%s', expected dynamodb:<table> or s3://<bucket>/<prefix>", s)
+	}
+	return target, nil
+}
+
+// TemplatesLog stores the executed templates, as JSON documents by ID, in the target configured
+// in `aws.templates.log`, so that the users of an account share the log of their runs and
+// can revert each other's runs. In a DynamoDB table, runs are serialized with a lock stored
+// in the table. S3 has no conditional writes: runs logged in S3 are not serialized.
+type TemplatesLog struct {
+	store templatesLogStore
+	locks reservationsStore // nil when the target cannot hold a lock
+	ttl   time.Duration
+}
+
+type templatesLogStore interface {
+	put(id string, content []byte) error
+	get(id string) ([]byte, error)
+	list() (map[string][]byte, error)
+	delete(id string) error
+}
+
+// NewTemplatesLog returns the templates log of the configured target, nil without configured target
+func NewTemplatesLog() (*TemplatesLog, error) {
+	s, _ := current.extraConf[TemplatesLogTargetConfigKey].(string)
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	if current.sess == nil {
+		return nil, errors.New("templates log: AWS session not initialized")
+	}
+	target, err := ParseTemplatesLogTarget(s)
+	if err != nil {
+		return nil, err
+	}
+	if target.Table != "" {
+		api := dynamodb.New(current.sess)
+		return &TemplatesLog{
+			store: &dynamodbTemplatesLog{api: api, table: target.Table},
+			locks: &dynamodbReservations{api: api, table: target.Table},
+		}, nil
+	}
+	return &TemplatesLog{
+		store: &s3TemplatesLog{api: s3.New(current.sess), bucket: target.Bucket, prefix: target.Prefix},
+	}, nil
+}
+
+func (l *TemplatesLog) Put(id string, content []byte) error { return l.store.put(id, content) }
+
+// Get returns a nil content when no template has the ID
+func (l *TemplatesLog) Get(id string) ([]byte, error) { return l.store.get(id) }
+
+// List returns the contents of all the templates by ID
+func (l *TemplatesLog) List() (map[string][]byte, error) { return l.store.list() }
+
+func (l *TemplatesLog) Delete(id string) error { return l.store.delete(id) }
+
+// Serialized returns whether Lock prevents concurrent runs, as only a DynamoDB table can
+func (l *TemplatesLog) Serialized() bool { return l.locks != nil }
+
+// Lock prevents the other owners from running templates until released, failing when another
+// owner holds the lock. The lock is renewed until released. Without lock in the target, it is a no-op.
+func (l *TemplatesLog) Lock(owner string) (func() error, error) {
+	if l.locks == nil {
+		return func() error { return nil }, nil
+	}
+	ttl := l.ttl
+	if ttl == 0 {
+		ttl = templatesLogLockTTL
+	}
+	now := time.Now().UTC()
+	lock := &Reservation{Scope: "templates", Kind: "lock", Value: "run", Owner: owner, Expires: now.Add(ttl)}
+	holder, err := l.locks.claim(lock, now)
+	if err != nil {
+		return nil, err
+	}
+	if holder != nil {
+		return nil, fmt.Errorf("templates log locked by %s until %s: another run is in progress", holder.Owner, holder.Expires.Local().Format("15:04:05"))
+	}
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				now := time.Now().UTC()
+				renewed := *lock
+				renewed.Expires = now.Add(ttl)
+				holder, err := l.locks.claim(&renewed, now)
+				if holder != nil {
+					err = fmt.Errorf("taken by %s", holder.Owner)
+				}
+				if err != nil && current.log != nil {
+					current.log.Warningf("cannot renew lock of templates log: %s", err)
+				}
+			}
+		}
+	}()
+	return func() error {
+		close(stop)
+		<-done
+		return l.locks.release(lock)
+	}, nil
+}
+
+// templatesLogKeyPrefix separates the templates from the lock stored in the same target
+const templatesLogKeyPrefix = "runs/"
+
+type dynamodbTemplatesLog struct {
+	api   dynamodbiface.DynamoDBAPI
+	table string
+}
+
+func (d *dynamodbTemplatesLog) put(id string, content []byte) error {
+	if _, err := d.api.PutItem(&dynamodb.PutItemInput{
+		TableName: awssdk.String(d.table),
+		Item: map[string]*dynamodb.AttributeValue{
+			"Key":      {S: awssdk.String(templatesLogKeyPrefix + id)},
+			"Template": {S: awssdk.String(string(content))},
+		},
+	}); err != nil {
+		return fmt.Errorf("put template %s in table %s: %s", id, d.table, err)
+	}
+	return nil
+}
+
+func (d *dynamodbTemplatesLog) get(id string) ([]byte, error) {
+	out, err := d.api.GetItem(&dynamodb.GetItemInput{
+		TableName:      awssdk.String(d.table),
+		Key:            map[string]*dynamodb.AttributeValue{"Key": {S: awssdk.String(templatesLogKeyPrefix + id)}},
+		ConsistentRead: awssdk.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get template %s in table %s: %s", id, d.table, err)
+	}
+	if v, ok := out.Item["Template"]; ok && v != nil {
+		return []byte(awssdk.StringValue(v.S)), nil
+	}
+	return nil, nil
+}
+
+func (d *dynamodbTemplatesLog) list() (map[string][]byte, error) {
+	all := make(map[string][]byte)
+	err := d.api.ScanPages(&dynamodb.ScanInput{
+		TableName:                 awssdk.String(d.table),
+		ConsistentRead:            awssdk.Bool(true),
+		FilterExpression:          awssdk.String("begins_with(#key, :prefix)"),
+		ExpressionAttributeNames:  map[string]*string{"#key": awssdk.String("Key")},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{":prefix": {S: awssdk.String(templatesLogKeyPrefix)}},
+	}, func(out *dynamodb.ScanOutput, lastPage bool) bool {
+		for _, item := range out.Items {
+			if k, v := item["Key"], item["Template"]; k != nil && v != nil {
+				all[strings.TrimPrefix(awssdk.StringValue(k.S), templatesLogKeyPrefix)] = []byte(awssdk.StringValue(v.S))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list templates in table %s: %s", d.table, err)
+	}
+	return all, nil
+}
+
+func (d *dynamodbTemplatesLog) delete(id string) error {
+	if _, err := d.api.DeleteItem(&dynamodb.DeleteItemInput{
+		TableName: awssdk.String(d.table),
+		Key:       map[string]*dynamodb.AttributeValue{"Key": {S: awssdk.String(templatesLogKeyPrefix + id)}},
+	}); err != nil {
+		return fmt.Errorf("delete template %s in table %s: %s", id, d.table, err)
+	}
+	return nil
+}
+
+// s3TemplatesLog stores a JSON object per template
+type s3TemplatesLog struct {
+	api            s3iface.S3API
+	bucket, prefix string
+}
+
+func (s *s3TemplatesLog) key(id string) string {
+	return path.Join(s.prefix, templatesLogKeyPrefix+id) + ".json"
+}
+
+func (s *s3TemplatesLog) put(id string, content []byte) error {
+	key := s.key(id)
+	if _, err := s.api.PutObject(&s3.PutObjectInput{
+		Bucket:      awssdk.String(s.bucket),
+		Key:         awssdk.String(key),
+		Body:        bytes.NewReader(content),
+		ContentType: awssdk.String("application/json"),
+	}); err != nil {
+		return fmt.Errorf("put template s3://%s/%s: %s", s.bucket, key, err)
+	}
+	return nil
+}
+
+func (s *s3TemplatesLog) get(id string) ([]byte, error) {
+	key := s.key(id)
+	out, err := s.api.GetObject(&s3.GetObjectInput{Bucket: awssdk.String(s.bucket), Key: awssdk.String(key)})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get template s3://%s/%s: %s", s.bucket, key, err)
+	}
+	defer out.Body.Close()
+	b, err := ioutil.ReadAll(out.Body)
+	if err != nil {
+		return nil, fmt.Errorf("get template s3://%s/%s: %s", s.bucket, key, err)
+	}
+	return b, nil
+}
+
+func (s *s3TemplatesLog) list() (map[string][]byte, error) {
+	var ids []string
+	prefix := path.Join(s.prefix, templatesLogKeyPrefix) + "/"
+	if err := s.api.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: awssdk.String(s.bucket),
+		Prefix: awssdk.String(prefix),
+	}, func(out *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range out.Contents {
+			if key := awssdk.StringValue(obj.Key); strings.HasSuffix(key, ".json") {
+				ids = append(ids, strings.TrimSuffix(strings.TrimPrefix(key, prefix), ".json"))
+			}
+		}
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("list templates s3://%s/%s: %s", s.bucket, prefix, err)
+	}
+	all := make(map[string][]byte)
+	for _, id := range ids {
+		b, err := s.get(id)
+		if err != nil {
+			return nil, err
+		}
+		if b != nil {
+			all[id] = b
+		}
+	}
+	return all, nil
+}
+
+func (s *s3TemplatesLog) delete(id string) error {
+	key := s.key(id)
+	if _, err := s.api.DeleteObject(&s3.DeleteObjectInput{Bucket: awssdk.String(s.bucket), Key: awssdk.String(key)}); err != nil {
+		return fmt.Errorf("delete template s3://%s/%s: %s", s.bucket, key, err)
+	}
+	return nil
+}
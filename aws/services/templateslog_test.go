@@ -0,0 +1,184 @@
+package awsservices
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+)
+
+func TestS3TemplatesLog(t *testing.T) {
+	api := &mockReservationsS3{objects: make(map[string][]byte)}
+	log := &TemplatesLog{
+		store: &s3TemplatesLog{api: api, bucket: "my-bucket", prefix: "awless"},
+	}
+
+	if err := log.Put("01BA7RV6ES86PZYCM3H28WM6KZ", []byte(`{"id":"1"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Put("01BA7RV6ES86PZYCM3H28WM6KY", []byte(`{"id":"2"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if log.Serialized() {
+		t.Fatal("expected S3 templates log not to serialize runs")
+	}
+	if _, err := log.Lock("alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []string
+	for k := range api.objects {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if got, want := keys, []string{"awless/runs/01BA7RV6ES86PZYCM3H28WM6KY.json", "awless/runs/01BA7RV6ES86PZYCM3H28WM6KZ.json"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	all, err := log.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := all, map[string][]byte{"01BA7RV6ES86PZYCM3H28WM6KZ": []byte(`{"id":"1"}`), "01BA7RV6ES86PZYCM3H28WM6KY": []byte(`{"id":"2"}`)}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if err = log.Delete("01BA7RV6ES86PZYCM3H28WM6KZ"); err != nil {
+		t.Fatal(err)
+	}
+	content, err := log.Get("01BA7RV6ES86PZYCM3H28WM6KZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != nil {
+		t.Fatalf("got %s, want deleted", content)
+	}
+	if content, err = log.Get("01BA7RV6ES86PZYCM3H28WM6KY"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), `{"id":"2"}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+type mockTemplatesLogDynamoDB struct {
+	dynamodbiface.DynamoDBAPI
+	mu    sync.Mutex
+	items map[string]map[string]*dynamodb.AttributeValue
+}
+
+// PutItem and DeleteItem check the conditions on the owner and expiration of reservations
+func (m *mockTemplatesLogDynamoDB) PutItem(input *dynamodb.PutItemInput) (*dynamodb.PutItemOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := awssdk.StringValue(input.Item["Key"].S)
+	if existing, ok := m.items[key]; ok && input.ConditionExpression != nil {
+		expires, _ := strconv.ParseInt(awssdk.StringValue(existing["Expires"].N), 10, 64)
+		now, _ := strconv.ParseInt(awssdk.StringValue(input.ExpressionAttributeValues[":now"].N), 10, 64)
+		if expires >= now && awssdk.StringValue(existing["Owner"].S) != awssdk.StringValue(input.ExpressionAttributeValues[":owner"].S) {
+			return nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil)
+		}
+	}
+	m.items[key] = input.Item
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (m *mockTemplatesLogDynamoDB) GetItem(input *dynamodb.GetItemInput) (*dynamodb.GetItemOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &dynamodb.GetItemOutput{Item: m.items[awssdk.StringValue(input.Key["Key"].S)]}, nil
+}
+
+func (m *mockTemplatesLogDynamoDB) DeleteItem(input *dynamodb.DeleteItemInput) (*dynamodb.DeleteItemOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := awssdk.StringValue(input.Key["Key"].S)
+	if existing, ok := m.items[key]; ok && input.ConditionExpression != nil {
+		if awssdk.StringValue(existing["Owner"].S) != awssdk.StringValue(input.ExpressionAttributeValues[":owner"].S) {
+			return nil, awserr.New(dynamodb.ErrCodeConditionalCheckFailedException, "condition failed", nil)
+		}
+	}
+	delete(m.items, key)
+	return &dynamodb.DeleteItemOutput{}, nil
+}
+
+func (m *mockTemplatesLogDynamoDB) ScanPages(input *dynamodb.ScanInput, fn func(*dynamodb.ScanOutput, bool) bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prefix := awssdk.StringValue(input.ExpressionAttributeValues[":prefix"].S)
+	out := &dynamodb.ScanOutput{}
+	for k, item := range m.items {
+		if strings.HasPrefix(k, prefix) {
+			out.Items = append(out.Items, item)
+		}
+	}
+	fn(out, true)
+	return nil
+}
+
+func TestDynamoDBTemplatesLog(t *testing.T) {
+	api := &mockTemplatesLogDynamoDB{items: make(map[string]map[string]*dynamodb.AttributeValue)}
+	log := &TemplatesLog{
+		store: &dynamodbTemplatesLog{api: api, table: "awless-log"},
+		locks: &dynamodbReservations{api: api, table: "awless-log"},
+		ttl:   3 * time.Second,
+	}
+
+	if !log.Serialized() {
+		t.Fatal("expected DynamoDB templates log to serialize runs")
+	}
+	release, err := log.Lock("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = log.Lock("bob"); err == nil || !strings.Contains(err.Error(), "locked by alice") {
+		t.Fatalf("got %v, want locked error", err)
+	}
+	time.Sleep(4 * time.Second)
+	if _, err = log.Lock("bob"); err == nil || !strings.Contains(err.Error(), "locked by alice") {
+		t.Fatalf("got %v, want lock renewed beyond its TTL", err)
+	}
+	if err = release(); err != nil {
+		t.Fatal(err)
+	}
+	bobRelease, err := log.Lock("bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = bobRelease(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = log.Put("01BA7RV6ES86PZYCM3H28WM6KZ", []byte(`{"id":"1"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := awssdk.StringValue(api.items["runs/01BA7RV6ES86PZYCM3H28WM6KZ"]["Template"].S), `{"id":"1"}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	all, err := log.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := all, map[string][]byte{"01BA7RV6ES86PZYCM3H28WM6KZ": []byte(`{"id":"1"}`)}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if err = log.Delete("01BA7RV6ES86PZYCM3H28WM6KZ"); err != nil {
+		t.Fatal(err)
+	}
+	content, err := log.Get("01BA7RV6ES86PZYCM3H28WM6KZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != nil {
+		t.Fatalf("got %s, want deleted", content)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsservices
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+)
+
+// TrailEvent is an API call recorded by CloudTrail on resources
+type TrailEvent struct {
+	Date      time.Time
+	Username  string
+	EventName string
+	Source    string
+	Resources []TrailResource
+}
+
+// TrailResource is a resource referenced by a CloudTrail event: its name is an ID or an ARN
+type TrailResource struct {
+	Name, Type string
+}
+
+// LookupTrailEvents returns the events of the API calls modifying resources recorded
+// by CloudTrail in the current region between the dates, in chronological order
+func LookupTrailEvents(from, to time.Time) ([]*TrailEvent, error) {
+	if current.sess == nil {
+		return nil, errors.New("lookup cloudtrail events: AWS session not initialized")
+	}
+	return lookupTrailEvents(cloudtrail.New(current.sess), from, to)
+}
+
+func lookupTrailEvents(api cloudtrailiface.CloudTrailAPI, from, to time.Time) ([]*TrailEvent, error) {
+	var events []*TrailEvent
+	err := api.LookupEventsPages(&cloudtrail.LookupEventsInput{
+		StartTime: awssdk.Time(from),
+		EndTime:   awssdk.Time(to),
+		// the vendored SDK predates the ReadOnly lookup attribute key, supported by the API
+		LookupAttributes: []*cloudtrail.LookupAttribute{
+			{AttributeKey: awssdk.String("ReadOnly"), AttributeValue: awssdk.String("false")},
+		},
+	}, func(out *cloudtrail.LookupEventsOutput, lastPage bool) bool {
+		for _, e := range out.Events {
+			if len(e.Resources) == 0 {
+				continue
+			}
+			event := &TrailEvent{
+				Date:      awssdk.TimeValue(e.EventTime),
+				Username:  awssdk.StringValue(e.Username),
+				EventName: awssdk.StringValue(e.EventName),
+				Source:    strings.TrimSuffix(awssdk.StringValue(e.EventSource), ".amazonaws.com"),
+			}
+			for _, r := range e.Resources {
+				event.Resources = append(event.Resources, TrailResource{Name: awssdk.StringValue(r.ResourceName), Type: awssdk.StringValue(r.ResourceType)})
+			}
+			events = append(events, event)
+		}
+		return out.NextToken != nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lookup cloudtrail events: %s", err)
+	}
+
+	// CloudTrail returns the most recent events first
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
@@ -0,0 +1,65 @@
+package awsservices
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+)
+
+type mockTrail struct {
+	cloudtrailiface.CloudTrailAPI
+	pages [][]*cloudtrail.Event
+	input *cloudtrail.LookupEventsInput
+}
+
+func (m *mockTrail) LookupEventsPages(input *cloudtrail.LookupEventsInput, fn func(*cloudtrail.LookupEventsOutput, bool) bool) error {
+	m.input = input
+	for i, page := range m.pages {
+		out := &cloudtrail.LookupEventsOutput{Events: page}
+		if i < len(m.pages)-1 {
+			out.NextToken = awssdk.String("next")
+		}
+		if !fn(out, i == len(m.pages)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func TestLookupTrailEvents(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	instance := []*cloudtrail.Resource{{ResourceName: awssdk.String("i-1234"), ResourceType: awssdk.String("AWS::EC2::Instance")}}
+	api := &mockTrail{pages: [][]*cloudtrail.Event{
+		{
+			{EventName: awssdk.String("StopInstances"), EventSource: awssdk.String("ec2.amazonaws.com"), EventTime: awssdk.Time(now), Username: awssdk.String("alice"), Resources: instance},
+			{EventName: awssdk.String("ConsoleLogin"), EventSource: awssdk.String("signin.amazonaws.com"), EventTime: awssdk.Time(now.Add(-2 * time.Minute)), Username: awssdk.String("alice")},
+		},
+		{
+			{EventName: awssdk.String("RunInstances"), EventSource: awssdk.String("ec2.amazonaws.com"), EventTime: awssdk.Time(now.Add(-time.Hour)), Username: awssdk.String("bob"), Resources: instance},
+		},
+	}}
+
+	events, err := lookupTrailEvents(api, now.Add(-24*time.Hour), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []*TrailEvent{
+		{Date: now.Add(-time.Hour), Username: "bob", EventName: "RunInstances", Source: "ec2", Resources: []TrailResource{{Name: "i-1234", Type: "AWS::EC2::Instance"}}},
+		{Date: now, Username: "alice", EventName: "StopInstances", Source: "ec2", Resources: []TrailResource{{Name: "i-1234", Type: "AWS::EC2::Instance"}}},
+	}
+	if got, want := events, exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	expInput := &cloudtrail.LookupEventsInput{
+		StartTime:        awssdk.Time(now.Add(-24 * time.Hour)),
+		EndTime:          awssdk.Time(now),
+		LookupAttributes: []*cloudtrail.LookupAttribute{{AttributeKey: awssdk.String("ReadOnly"), AttributeValue: awssdk.String("false")}},
+	}
+	if got, want := api.input, expInput; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
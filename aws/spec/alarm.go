@@ -18,6 +18,7 @@ package awsspec
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/template/env"
@@ -102,16 +103,19 @@ func (cmd *StopAlarm) ParamsSpec() params.Spec {
 }
 
 type AttachAlarm struct {
-	_         string `action:"attach" entity:"alarm" awsAPI:"cloudwatch"`
-	logger    *logger.Logger
-	graph     cloud.GraphAPI
-	api       cloudwatchiface.CloudWatchAPI
-	Name      *string `templateName:"name"`
-	ActionArn *string `templateName:"action-arn"`
+	_            string `action:"attach" entity:"alarm" awsAPI:"cloudwatch"`
+	logger       *logger.Logger
+	graph        cloud.GraphAPI
+	api          cloudwatchiface.CloudWatchAPI
+	Name         *string   `templateName:"name"`
+	ActionArn    *string   `templateName:"action-arn"`
+	Instance     *string   `templateName:"instance"`
+	Scalinggroup *string   `templateName:"scalinggroup"`
+	Dimensions   []*string `templateName:"dimensions"`
 }
 
 func (cmd *AttachAlarm) ParamsSpec() params.Spec {
-	return params.NewSpec(params.AllOf(params.Key("action-arn"), params.Key("name")))
+	return params.NewSpec(params.AllOf(params.Key("name"), params.AtLeastOneOf(params.Key("action-arn"), params.Key("instance"), params.Key("scalinggroup"), params.Key("dimensions"))))
 }
 
 func (cmd *AttachAlarm) ManualRun(renv env.Running) (interface{}, error) {
@@ -119,41 +123,47 @@ func (cmd *AttachAlarm) ManualRun(renv env.Running) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	alarm.AlarmActions = append(alarm.AlarmActions, cmd.ActionArn)
+	if cmd.ActionArn != nil {
+		alarm.AlarmActions = append(alarm.AlarmActions, cmd.ActionArn)
+	}
+	var previous string
+	if cmd.Instance != nil || cmd.Scalinggroup != nil || len(cmd.Dimensions) > 0 {
+		previous = formatAlarmDimensions(alarm.Dimensions)
+		if alarm.Dimensions, err = parseAlarmDimensions(cmd.Dimensions); err != nil {
+			return nil, err
+		}
+		if cmd.Instance != nil {
+			alarm.Dimensions = setAlarmDimension(alarm.Dimensions, "InstanceId", cmd.Instance)
+		}
+		if cmd.Scalinggroup != nil {
+			alarm.Dimensions = setAlarmDimension(alarm.Dimensions, "AutoScalingGroupName", cmd.Scalinggroup)
+		}
+	}
 
-	return cmd.api.PutMetricAlarm(&cloudwatch.PutMetricAlarmInput{
-		ActionsEnabled:                   alarm.ActionsEnabled,
-		AlarmActions:                     alarm.AlarmActions,
-		AlarmDescription:                 alarm.AlarmDescription,
-		AlarmName:                        alarm.AlarmName,
-		ComparisonOperator:               alarm.ComparisonOperator,
-		Dimensions:                       alarm.Dimensions,
-		EvaluateLowSampleCountPercentile: alarm.EvaluateLowSampleCountPercentile,
-		EvaluationPeriods:                alarm.EvaluationPeriods,
-		ExtendedStatistic:                alarm.ExtendedStatistic,
-		InsufficientDataActions:          alarm.InsufficientDataActions,
-		MetricName:                       alarm.MetricName,
-		Namespace:                        alarm.Namespace,
-		OKActions:                        alarm.OKActions,
-		Period:                           alarm.Period,
-		Statistic:                        alarm.Statistic,
-		Threshold:                        alarm.Threshold,
-		TreatMissingData:                 alarm.TreatMissingData,
-		Unit:                             alarm.Unit,
-	})
+	if _, err = putMetricAlarm(cmd.api, alarm); err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+func (cmd *AttachAlarm) ExtractResult(i interface{}) string {
+	return i.(string)
 }
 
 type DetachAlarm struct {
-	_         string `action:"detach" entity:"alarm" awsAPI:"cloudwatch"`
-	logger    *logger.Logger
-	graph     cloud.GraphAPI
-	api       cloudwatchiface.CloudWatchAPI
-	Name      *string `templateName:"name"`
-	ActionArn *string `templateName:"action-arn"`
+	_            string `action:"detach" entity:"alarm" awsAPI:"cloudwatch"`
+	logger       *logger.Logger
+	graph        cloud.GraphAPI
+	api          cloudwatchiface.CloudWatchAPI
+	Name         *string   `templateName:"name"`
+	ActionArn    *string   `templateName:"action-arn"`
+	Instance     *string   `templateName:"instance"`
+	Scalinggroup *string   `templateName:"scalinggroup"`
+	Dimensions   []*string `templateName:"dimensions"`
 }
 
 func (cmd *DetachAlarm) ParamsSpec() params.Spec {
-	return params.NewSpec(params.AllOf(params.Key("action-arn"), params.Key("name")))
+	return params.NewSpec(params.AllOf(params.Key("name"), params.AtLeastOneOf(params.Key("action-arn"), params.Key("instance"), params.Key("scalinggroup"), params.Key("dimensions"))))
 }
 
 func (cmd *DetachAlarm) ManualRun(renv env.Running) (interface{}, error) {
@@ -161,23 +171,56 @@ func (cmd *DetachAlarm) ManualRun(renv env.Running) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	actionArn := aws.StringValue(cmd.ActionArn)
-	var found bool
-	var updatedActions []*string
-	for _, action := range alarm.AlarmActions {
-		if aws.StringValue(action) == actionArn {
-			found = true
-		} else {
-			updatedActions = append(updatedActions, action)
+	if cmd.ActionArn != nil {
+		actionArn := aws.StringValue(cmd.ActionArn)
+		var found bool
+		var updatedActions []*string
+		for _, action := range alarm.AlarmActions {
+			if aws.StringValue(action) == actionArn {
+				found = true
+			} else {
+				updatedActions = append(updatedActions, action)
+			}
 		}
+		if !found {
+			return nil, fmt.Errorf("detach alarm: action '%s' is not attached to alarm actions of alarm %s", actionArn, aws.StringValue(alarm.AlarmName))
+		}
+		alarm.AlarmActions = updatedActions
 	}
-	if !found {
-		return nil, fmt.Errorf("detach alarm: action '%s' is not attached to alarm actions of alarm %s", actionArn, aws.StringValue(alarm.AlarmName))
+	var previous string
+	if cmd.Instance != nil || cmd.Scalinggroup != nil || len(cmd.Dimensions) > 0 {
+		previous = formatAlarmDimensions(alarm.Dimensions)
+	}
+	if cmd.Instance != nil {
+		if alarm.Dimensions, err = removeAlarmDimension(alarm, "InstanceId", cmd.Instance); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.Scalinggroup != nil {
+		if alarm.Dimensions, err = removeAlarmDimension(alarm, "AutoScalingGroupName", cmd.Scalinggroup); err != nil {
+			return nil, err
+		}
+	}
+	if len(cmd.Dimensions) > 0 {
+		if alarm.Dimensions, err = parseAlarmDimensions(cmd.Dimensions); err != nil {
+			return nil, err
+		}
 	}
 
-	return cmd.api.PutMetricAlarm(&cloudwatch.PutMetricAlarmInput{
+	if _, err = putMetricAlarm(cmd.api, alarm); err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
+
+func (cmd *DetachAlarm) ExtractResult(i interface{}) string {
+	return i.(string)
+}
+
+func putMetricAlarm(api cloudwatchiface.CloudWatchAPI, alarm *cloudwatch.MetricAlarm) (*cloudwatch.PutMetricAlarmOutput, error) {
+	return api.PutMetricAlarm(&cloudwatch.PutMetricAlarmInput{
 		ActionsEnabled:                   alarm.ActionsEnabled,
-		AlarmActions:                     updatedActions,
+		AlarmActions:                     alarm.AlarmActions,
 		AlarmDescription:                 alarm.AlarmDescription,
 		AlarmName:                        alarm.AlarmName,
 		ComparisonOperator:               alarm.ComparisonOperator,
@@ -197,6 +240,56 @@ func (cmd *DetachAlarm) ManualRun(renv env.Running) (interface{}, error) {
 	})
 }
 
+func setAlarmDimension(dimensions []*cloudwatch.Dimension, name string, value *string) []*cloudwatch.Dimension {
+	for _, dim := range dimensions {
+		if aws.StringValue(dim.Name) == name {
+			dim.Value = value
+			return dimensions
+		}
+	}
+	return append(dimensions, &cloudwatch.Dimension{Name: aws.String(name), Value: value})
+}
+
+// parseAlarmDimensions parses dimensions given as 'Name:Value', as the
+// dimensions of 'create alarm'.
+func parseAlarmDimensions(dimensions []*string) ([]*cloudwatch.Dimension, error) {
+	var parsed []*cloudwatch.Dimension
+	for _, dim := range dimensions {
+		splits := strings.SplitN(aws.StringValue(dim), ":", 2)
+		if len(splits) != 2 {
+			return nil, fmt.Errorf("invalid dimension '%s', expected 'key:value'", aws.StringValue(dim))
+		}
+		parsed = append(parsed, &cloudwatch.Dimension{Name: aws.String(splits[0]), Value: aws.String(splits[1])})
+	}
+	return parsed, nil
+}
+
+// formatAlarmDimensions returns the dimensions as a comma separated list of
+// 'Name:Value', in the format of parseAlarmDimensions.
+func formatAlarmDimensions(dimensions []*cloudwatch.Dimension) string {
+	var formatted []string
+	for _, dim := range dimensions {
+		formatted = append(formatted, fmt.Sprintf("%s:%s", aws.StringValue(dim.Name), aws.StringValue(dim.Value)))
+	}
+	return strings.Join(formatted, ",")
+}
+
+func removeAlarmDimension(alarm *cloudwatch.MetricAlarm, name string, value *string) ([]*cloudwatch.Dimension, error) {
+	var found bool
+	var updatedDimensions []*cloudwatch.Dimension
+	for _, dim := range alarm.Dimensions {
+		if aws.StringValue(dim.Name) == name && aws.StringValue(dim.Value) == aws.StringValue(value) {
+			found = true
+		} else {
+			updatedDimensions = append(updatedDimensions, dim)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("detach alarm: dimension %s=%s is not set on alarm %s", name, aws.StringValue(value), aws.StringValue(alarm.AlarmName))
+	}
+	return updatedDimensions, nil
+}
+
 func getAlarm(api cloudwatchiface.CloudWatchAPI, name *string) (*cloudwatch.MetricAlarm, error) {
 	if name == nil {
 		return nil, errors.New("missing required params 'name'")
@@ -0,0 +1,75 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateAlias struct {
+	_      string `action:"create" entity:"alias" awsAPI:"kms" awsCall:"CreateAlias" awsInput:"kms.CreateAliasInput" awsOutput:"kms.CreateAliasOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    kmsiface.KMSAPI
+	Name   *string `awsName:"AliasName" awsType:"awsstr" templateName:"name"`
+	Key    *string `awsName:"TargetKeyId" awsType:"awsstr" templateName:"key"`
+}
+
+func (cmd *CreateAlias) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("key"), params.Key("name")),
+		params.Validators{"name": validateAliasName},
+	)
+}
+
+func (cmd *CreateAlias) ExtractResult(i interface{}) string {
+	return StringValue(cmd.Name)
+}
+
+type DeleteAlias struct {
+	_      string `action:"delete" entity:"alias" awsAPI:"kms" awsCall:"DeleteAlias" awsInput:"kms.DeleteAliasInput" awsOutput:"kms.DeleteAliasOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    kmsiface.KMSAPI
+	Name   *string `awsName:"AliasName" awsType:"awsstr" templateName:"name"`
+}
+
+func (cmd *DeleteAlias) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("name")),
+		params.Validators{"name": validateAliasName},
+	)
+}
+
+func validateAliasName(i interface{}, others map[string]interface{}) error {
+	name, ok := i.(string)
+	if !ok {
+		return errors.New("expected a string")
+	}
+	if !strings.HasPrefix(name, "alias/") {
+		return errors.New("expected name to begin with 'alias/'")
+	}
+	if strings.HasPrefix(name, "alias/aws/") {
+		return errors.New("'alias/aws/' prefix is reserved for AWS managed keys")
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+var (
+	cacheEngines = []string{"memcached", "redis"}
+	// cacheNodeTypes only checks the form of node types (ex: cache.t3.micro),
+	// AWS adding new node types over time
+	cacheNodeTypes = params.MatchRegex(`(?i)^cache\.[a-z0-9-]+\.[a-z0-9]+$`, "a cache node type (ex: cache.t3.micro)")
+)
+
+type CreateCachecluster struct {
+	_                 string `action:"create" entity:"cachecluster" awsAPI:"elasticache" awsCall:"CreateCacheCluster" awsInput:"elasticache.CreateCacheClusterInput" awsOutput:"elasticache.CreateCacheClusterOutput"`
+	logger            *logger.Logger
+	graph             cloud.GraphAPI
+	api               elasticacheiface.ElastiCacheAPI
+	Id                *string   `awsName:"CacheClusterId" awsType:"awsstr" templateName:"id"`
+	Engine            *string   `awsName:"Engine" awsType:"awsstr" templateName:"engine"`
+	Type              *string   `awsName:"CacheNodeType" awsType:"awsstr" templateName:"type"`
+	Count             *int64    `awsName:"NumCacheNodes" awsType:"awsint64" templateName:"count"`
+	ReplicationGroup  *string   `awsName:"ReplicationGroupId" awsType:"awsstr" templateName:"replication-group"`
+	Version           *string   `awsName:"EngineVersion" awsType:"awsstr" templateName:"version"`
+	Port              *int64    `awsName:"Port" awsType:"awsint64" templateName:"port"`
+	Subnetgroup       *string   `awsName:"CacheSubnetGroupName" awsType:"awsstr" templateName:"subnetgroup"`
+	Securitygroups    []*string `awsName:"SecurityGroupIds" awsType:"awsstringslice" templateName:"securitygroups"`
+	Parametergroup    *string   `awsName:"CacheParameterGroupName" awsType:"awsstr" templateName:"parametergroup"`
+	Availabilityzone  *string   `awsName:"PreferredAvailabilityZone" awsType:"awsstr" templateName:"availabilityzone"`
+	Maintenancewindow *string   `awsName:"PreferredMaintenanceWindow" awsType:"awsstr" templateName:"maintenancewindow"`
+	Autoupgrade       *bool     `awsName:"AutoMinorVersionUpgrade" awsType:"awsbool" templateName:"autoupgrade"`
+}
+
+func (cmd *CreateCachecluster) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(
+		params.AllOf(params.Key("id"), params.Key("engine"), params.Key("type"),
+			params.Opt("autoupgrade", "availabilityzone", "count", "maintenancewindow", "parametergroup", "port", "replication-group", "securitygroups", "subnetgroup", "version"),
+		),
+		params.Validators{
+			"engine": params.IsInEnumIgnoreCase(cacheEngines...),
+			"type":   cacheNodeTypes,
+		})
+	builder.AddReducer(lowerCaseCacheParams, "engine", "type")
+	return builder.Done()
+}
+
+func (cmd *CreateCachecluster) ValidateParams(values map[string]interface{}) error {
+	engine, ok := values["engine"]
+	if !ok {
+		return nil
+	}
+	if strings.EqualFold(castString(engine), "redis") {
+		if count, ok := values["count"]; ok {
+			if n, err := castInt(count); err == nil && n != 1 {
+				return fmt.Errorf("redis clusters have a single node but got 'count=%d', create a replicationgroup for more nodes", n)
+			}
+		}
+	} else if _, ok := values["replication-group"]; ok {
+		return fmt.Errorf("either 'replication-group' or 'engine=%s', not both: only redis clusters join a replication group", castString(engine))
+	}
+	return nil
+}
+
+func (cmd *CreateCachecluster) ExtractResult(i interface{}) string {
+	return StringValue(i.(*elasticache.CreateCacheClusterOutput).CacheCluster.CacheClusterId)
+}
+
+type DeleteCachecluster struct {
+	_        string `action:"delete" entity:"cachecluster" awsAPI:"elasticache" awsCall:"DeleteCacheCluster" awsInput:"elasticache.DeleteCacheClusterInput" awsOutput:"elasticache.DeleteCacheClusterOutput"`
+	logger   *logger.Logger
+	graph    cloud.GraphAPI
+	api      elasticacheiface.ElastiCacheAPI
+	Id       *string `awsName:"CacheClusterId" awsType:"awsstr" templateName:"id"`
+	Snapshot *string `awsName:"FinalSnapshotIdentifier" awsType:"awsstr" templateName:"snapshot"`
+}
+
+func (cmd *DeleteCachecluster) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.Opt("snapshot")))
+}
+
+// lowerCaseCacheParams normalizes engines and node types, ElastiCache only accepting them lower cased
+func lowerCaseCacheParams(values map[string]interface{}) (map[string]interface{}, error) {
+	for k, v := range values {
+		if s, ok := v.(string); ok {
+			values[k] = strings.ToLower(s)
+		}
+	}
+	return values, nil
+}
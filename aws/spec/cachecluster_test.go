@@ -0,0 +1,49 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreateCacheclusterValidateParams(t *testing.T) {
+	tcases := []struct {
+		params map[string]interface{}
+		expErr string
+	}{
+		{params: map[string]interface{}{"engine": "Redis", "count": 1, "replication-group": "my-sessions"}},
+		{params: map[string]interface{}{"engine": "memcached", "count": 3}},
+		{params: map[string]interface{}{"count": 3}},
+		{params: map[string]interface{}{"engine": "redis", "count": 2}, expErr: "redis clusters have a single node but got 'count=2'"},
+		{params: map[string]interface{}{"engine": "memcached", "replication-group": "my-sessions"}, expErr: "either 'replication-group' or 'engine=memcached', not both"},
+	}
+	for i, tcase := range tcases {
+		err := new(CreateCachecluster).ValidateParams(tcase.params)
+		if tcase.expErr == "" {
+			if err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
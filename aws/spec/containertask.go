@@ -37,20 +37,24 @@ type StartContainertask struct {
 	logger                    *logger.Logger
 	graph                     cloud.GraphAPI
 	api                       ecsiface.ECSAPI
-	Cluster                   *string `templateName:"cluster"`
-	DesiredCount              *int64  `templateName:"desired-count"`
-	Name                      *string `templateName:"name"`
-	Type                      *string `templateName:"type"`
-	Role                      *string `templateName:"role"`
-	DeploymentName            *string `templateName:"deployment-name"`
-	LoadBalancerContainerName *string `templateName:"loadbalancer.container-name"`
-	LoadBalancerContainerPort *int64  `templateName:"loadbalancer.container-port"`
-	LoadBalancerTargetgroup   *string `templateName:"loadbalancer.targetgroup"`
+	Cluster                   *string   `templateName:"cluster"`
+	DesiredCount              *int64    `templateName:"desired-count"`
+	Name                      *string   `templateName:"name"`
+	Type                      *string   `templateName:"type"`
+	Role                      *string   `templateName:"role"`
+	DeploymentName            *string   `templateName:"deployment-name"`
+	LoadBalancerContainerName *string   `templateName:"loadbalancer.container-name"`
+	LoadBalancerContainerPort *int64    `templateName:"loadbalancer.container-port"`
+	LoadBalancerTargetgroup   *string   `templateName:"loadbalancer.targetgroup"`
+	LaunchType                *string   `templateName:"launch-type"`
+	Subnets                   []*string `templateName:"subnets"`
+	SecurityGroups            []*string `templateName:"securitygroups"`
+	Public                    *bool     `templateName:"public"`
 }
 
 func (cmd *StartContainertask) ParamsSpec() params.Spec {
 	return params.NewSpec(
-		params.AllOf(params.Key("cluster"), params.Key("desired-count"), params.Key("name"), params.Key("type"), params.Opt("deployment-name", "loadbalancer.container-name", "loadbalancer.container-port", "loadbalancer.targetgroup", "role")),
+		params.AllOf(params.Key("cluster"), params.Key("desired-count"), params.Key("name"), params.Key("type"), params.Opt("deployment-name", "launch-type", "loadbalancer.container-name", "loadbalancer.container-port", "loadbalancer.targetgroup", "public", "role", "securitygroups", "subnets")),
 		params.Validators{
 			"type": func(i interface{}, others map[string]interface{}) error {
 				typ := fmt.Sprint(i)
@@ -63,10 +67,43 @@ func (cmd *StartContainertask) ParamsSpec() params.Spec {
 				}
 				return nil
 			},
+			"launch-type": validateContainerLaunchType,
 		})
 }
 
+func validateContainerLaunchType(i interface{}, others map[string]interface{}) error {
+	if typ := strings.ToLower(fmt.Sprint(i)); typ != "ec2" && typ != "fargate" {
+		return fmt.Errorf("expected any of [ec2 fargate] but got %s", i)
+	}
+	return nil
+}
+
+// networkSetters returns the setters of the launch type and of the awsvpc network configuration
+// (required by the fargate launch type) of services and tasks
+func (cmd *StartContainertask) networkSetters() (setters []setter) {
+	if cmd.LaunchType != nil {
+		setters = append(setters, setter{val: strings.ToUpper(StringValue(cmd.LaunchType)), fieldPath: "LaunchType", fieldType: awsstr})
+	}
+	if len(cmd.Subnets) > 0 {
+		setters = append(setters, setter{val: cmd.Subnets, fieldPath: "NetworkConfiguration.AwsvpcConfiguration.Subnets", fieldType: awsstringslice})
+	}
+	if len(cmd.SecurityGroups) > 0 {
+		setters = append(setters, setter{val: cmd.SecurityGroups, fieldPath: "NetworkConfiguration.AwsvpcConfiguration.SecurityGroups", fieldType: awsstringslice})
+	}
+	if cmd.Public != nil {
+		assign := ecs.AssignPublicIpDisabled
+		if BoolValue(cmd.Public) {
+			assign = ecs.AssignPublicIpEnabled
+		}
+		setters = append(setters, setter{val: assign, fieldPath: "NetworkConfiguration.AwsvpcConfiguration.AssignPublicIp", fieldType: awsstr})
+	}
+	return
+}
+
 func (cmd *StartContainertask) ManualRun(renv env.Running) (interface{}, error) {
+	if strings.ToLower(StringValue(cmd.LaunchType)) == "fargate" && len(cmd.Subnets) == 0 {
+		return nil, errors.New("missing required param 'subnets' when launch-type=fargate")
+	}
 	switch StringValue(cmd.Type) {
 	case "service":
 		setters := []setter{
@@ -87,6 +124,7 @@ func (cmd *StartContainertask) ManualRun(renv env.Running) (interface{}, error)
 		if cmd.LoadBalancerTargetgroup != nil {
 			setters = append(setters, setter{val: cmd.LoadBalancerTargetgroup, fieldPath: "LoadBalancers[0]TargetGroupArn", fieldType: awsslicestruct})
 		}
+		setters = append(setters, cmd.networkSetters()...)
 
 		call := &awsCall{
 			fnName:  "ecs.CreateService",
@@ -101,11 +139,11 @@ func (cmd *StartContainertask) ManualRun(renv env.Running) (interface{}, error)
 			fnName: "ecs.RunTask",
 			fn:     cmd.api.RunTask,
 			logger: cmd.logger,
-			setters: []setter{
+			setters: append([]setter{
 				{val: cmd.Cluster, fieldPath: "Cluster", fieldType: awsstr},
 				{val: cmd.Name, fieldPath: "TaskDefinition", fieldType: awsstr},
 				{val: cmd.DesiredCount, fieldPath: "Count", fieldType: awsint64},
-			},
+			}, cmd.networkSetters()...),
 		}
 
 		output, err := call.execute(&ecs.RunTaskInput{})
@@ -227,12 +265,18 @@ type AttachContainertask struct {
 	Privileged      *bool     `templateName:"privileged"`
 	Workdir         *string   `templateName:"workdir"`
 	Ports           []*string `templateName:"ports"`
+	LaunchType      *string   `templateName:"launch-type"`
+	Cpu             *string   `templateName:"cpu"`
+	Memory          *string   `templateName:"memory"`
+	ExecutionRole   *string   `templateName:"execution-role"`
 }
 
 func (cmd *AttachContainertask) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("container-name"), params.Key("image"), params.Key("memory-hard-limit"), params.Key("name"),
-		params.Opt("command", "env", "ports", "privileged", "workdir"),
-	))
+		params.Opt("command", "cpu", "env", "execution-role", "launch-type", "memory", "ports", "privileged", "workdir"),
+	), params.Validators{
+		"launch-type": validateContainerLaunchType,
+	})
 }
 
 func (cmd *AttachContainertask) ManualRun(renv env.Running) (interface{}, error) {
@@ -254,14 +298,21 @@ func (cmd *AttachContainertask) ManualRun(renv env.Running) (interface{}, error)
 	} else if err != nil {
 		return nil, err
 	} else {
-		taskDefinitionInput = &ecs.RegisterTaskDefinitionInput{
-			ContainerDefinitions: taskdefOutput.TaskDefinition.ContainerDefinitions,
-			Family:               taskdefOutput.TaskDefinition.Family,
-			NetworkMode:          taskdefOutput.TaskDefinition.NetworkMode,
-			PlacementConstraints: taskdefOutput.TaskDefinition.PlacementConstraints,
-			TaskRoleArn:          taskdefOutput.TaskDefinition.TaskRoleArn,
-			Volumes:              taskdefOutput.TaskDefinition.Volumes,
-		}
+		taskDefinitionInput = registerTaskDefinitionInput(taskdefOutput.TaskDefinition)
+	}
+
+	if strings.ToLower(StringValue(cmd.LaunchType)) == "fargate" {
+		taskDefinitionInput.RequiresCompatibilities = []*string{aws.String(ecs.CompatibilityFargate)}
+		taskDefinitionInput.NetworkMode = aws.String(ecs.NetworkModeAwsvpc)
+	}
+	if cmd.Cpu != nil {
+		taskDefinitionInput.Cpu = cmd.Cpu
+	}
+	if cmd.Memory != nil {
+		taskDefinitionInput.Memory = cmd.Memory
+	}
+	if cmd.ExecutionRole != nil {
+		taskDefinitionInput.ExecutionRoleArn = cmd.ExecutionRole
 	}
 
 	container := &ecs.ContainerDefinition{}
@@ -361,14 +412,8 @@ func (cmd *DetachContainertask) ManualRun(renv env.Running) (interface{}, error)
 	}
 
 	if len(containerDefinitions) > 0 { //At least one container remaining
-		taskDefinitionInput := &ecs.RegisterTaskDefinitionInput{
-			ContainerDefinitions: containerDefinitions,
-			Family:               taskdefOutput.TaskDefinition.Family,
-			NetworkMode:          taskdefOutput.TaskDefinition.NetworkMode,
-			PlacementConstraints: taskdefOutput.TaskDefinition.PlacementConstraints,
-			TaskRoleArn:          taskdefOutput.TaskDefinition.TaskRoleArn,
-			Volumes:              taskdefOutput.TaskDefinition.Volumes,
-		}
+		taskDefinitionInput := registerTaskDefinitionInput(taskdefOutput.TaskDefinition)
+		taskDefinitionInput.ContainerDefinitions = containerDefinitions
 		start := time.Now()
 
 		if _, err := cmd.api.RegisterTaskDefinition(taskDefinitionInput); err != nil {
@@ -392,6 +437,22 @@ func (cmd *DetachContainertask) ManualRun(renv env.Running) (interface{}, error)
 	return taskdefOutput, nil
 }
 
+// registerTaskDefinitionInput returns the input registering a new revision of the task definition
+func registerTaskDefinitionInput(def *ecs.TaskDefinition) *ecs.RegisterTaskDefinitionInput {
+	return &ecs.RegisterTaskDefinitionInput{
+		ContainerDefinitions:    def.ContainerDefinitions,
+		Cpu:                     def.Cpu,
+		ExecutionRoleArn:        def.ExecutionRoleArn,
+		Family:                  def.Family,
+		Memory:                  def.Memory,
+		NetworkMode:             def.NetworkMode,
+		PlacementConstraints:    def.PlacementConstraints,
+		RequiresCompatibilities: def.RequiresCompatibilities,
+		TaskRoleArn:             def.TaskRoleArn,
+		Volumes:                 def.Volumes,
+	}
+}
+
 type DeleteContainertask struct {
 	_           string `action:"delete" entity:"containertask" awsAPI:"ecs" awsDryRun:"manual"`
 	logger      *logger.Logger
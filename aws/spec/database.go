@@ -17,6 +17,7 @@ package awsspec
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/wallix/awless/cloud"
@@ -25,6 +26,7 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/rds"
 	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 	"github.com/wallix/awless/logger"
@@ -77,6 +79,9 @@ type CreateDatabase struct {
 
 	// Extra only for replica DB
 	CopyTagsToSnapshot *string `awsName:"CopyTagsToSnapshot" awsType:"awsbool" templateName:"copytagstosnapshot"`
+
+	Wait        *bool  `templateName:"wait"`
+	WaitTimeout *int64 `templateName:"wait-timeout"`
 }
 
 func (cmd *CreateDatabase) ParamsSpec() params.Spec {
@@ -85,62 +90,48 @@ func (cmd *CreateDatabase) ParamsSpec() params.Spec {
 		params.AllOf(params.Key("replica"), params.Key("replica-source")),
 		params.Opt("autoupgrade", "availabilityzone", "backupretention", "cluster", "dbname", "parametergroup",
 			"dbsecuritygroups", "subnetgroup", "domain", "iamrole", "version", "iops", "license", "multiaz", "optiongroup",
-			"port", "backupwindow", "maintenancewindow", "public", "encrypted", "storagetype", "timezone", "vpcsecuritygroups")),
+			"port", "backupwindow", "maintenancewindow", "public", "encrypted", "storagetype", "timezone", "vpcsecuritygroups",
+			"wait", "wait-timeout")),
 		params.Validators{
-			"password": params.MinLengthOf(8),
+			"password":     params.MinLengthOf(8),
+			"wait-timeout": validateWaitTimeout,
 			"replica": func(i interface{}, others map[string]interface{}) error {
-				msg := "param not allowed in replica (either not applicable or directly inherited from the source DB)"
-				if _, ok := others["backupretention"]; ok {
-					return fmt.Errorf("'backupretention' %s", msg)
-				}
-				if _, ok := others["backupwindow"]; ok {
-					return fmt.Errorf("'backupwindow' %s", msg)
-				}
-				if _, ok := others["cluster"]; ok {
-					return fmt.Errorf("'cluster' %s", msg)
-				}
-				if _, ok := others["dbname"]; ok {
-					return fmt.Errorf("'dbname' %s", msg)
-				}
-				if _, ok := others["dbsecuritygroups"]; ok {
-					return fmt.Errorf("'dbsecuritygroups' %s", msg)
-				}
-				if _, ok := others["domain"]; ok {
-					return fmt.Errorf("'domain' %s", msg)
-				}
-				if _, ok := others["encrypted"]; ok {
-					return fmt.Errorf("'encrypted' %s", msg)
-				}
-				if _, ok := others["iamrole"]; ok {
-					return fmt.Errorf("'iamrole' %s", msg)
-				}
-				if _, ok := others["license"]; ok {
-					return fmt.Errorf("'license' %s", msg)
-				}
-				if _, ok := others["maintenancewindow"]; ok {
-					return fmt.Errorf("'maintenancewindow' %s", msg)
-				}
-				if _, ok := others["multiaz"]; ok {
-					return fmt.Errorf("'multiaz' %s", msg)
-				}
-				if _, ok := others["parametergroup"]; ok {
-					return fmt.Errorf("'parametergroup' %s", msg)
-				}
-				if _, ok := others["timezone"]; ok {
-					return fmt.Errorf("'timezone' %s", msg)
-				}
-				if _, ok := others["vpcsecuritygroups"]; ok {
-					return fmt.Errorf("'vpcsecuritygroups' %s", msg)
-				}
-				if _, ok := others["version"]; ok {
-					return fmt.Errorf("'version' %s", msg)
+				if err := params.MutuallyExclusiveWith("backupretention", "backupwindow", "cluster", "dbname", "dbsecuritygroups", "domain", "encrypted",
+					"iamrole", "license", "maintenancewindow", "multiaz", "parametergroup", "timezone", "vpcsecuritygroups", "version")(i, others); err != nil {
+					return fmt.Errorf("%s (either not applicable or directly inherited from the source DB)", err)
 				}
 				return nil
 			},
+			"version": cmd.validateEngineVersion,
 		},
 	)
 }
 
+// validateEngineVersion checks the version against the versions RDS currently offers for the engine
+func (cmd *CreateDatabase) validateEngineVersion(i interface{}, others map[string]interface{}) error {
+	engine, ok := others["engine"].(string)
+	if !ok || cmd.api == nil {
+		return nil
+	}
+	validator := cachedDynamicEnumValidator(cmd.api, "rds.engineversions."+strings.ToLower(engine), func() (versions []string, err error) {
+		input := &rds.DescribeDBEngineVersionsInput{Engine: String(engine)}
+		for {
+			out, err := cmd.api.DescribeDBEngineVersions(input)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range out.DBEngineVersions {
+				versions = append(versions, StringValue(v.EngineVersion))
+			}
+			if out.Marker == nil {
+				return versions, nil
+			}
+			input.Marker = out.Marker
+		}
+	})
+	return validator.Validate(String(castString(i)))
+}
+
 func (cmd *CreateDatabase) ManualRun(renv env.Running) (output interface{}, err error) {
 	if replica := cmd.ReadReplicaIdentifier; replica != nil {
 		input := &rds.CreateDBInstanceReadReplicaInput{}
@@ -177,6 +168,14 @@ func (cmd *CreateDatabase) ExtractResult(i interface{}) string {
 	}
 }
 
+func (cmd *CreateDatabase) AfterRun(renv env.Running, output interface{}) error {
+	id := String(cmd.ExtractResult(output))
+	waitUntil(renv.Log(), cmd.Wait, cmd.WaitTimeout, fmt.Sprintf("database %s available", StringValue(id)), func(ctx awssdk.Context, opts ...request.WaiterOption) error {
+		return cmd.api.WaitUntilDBInstanceAvailableWithContext(ctx, &rds.DescribeDBInstancesInput{DBInstanceIdentifier: id}, opts...)
+	})
+	return nil
+}
+
 type DeleteDatabase struct {
 	_            string `action:"delete" entity:"database" awsAPI:"rds" awsCall:"DeleteDBInstance" awsInput:"rds.DeleteDBInstanceInput" awsOutput:"rds.DeleteDBInstanceOutput"`
 	logger       *logger.Logger
@@ -185,12 +184,21 @@ type DeleteDatabase struct {
 	Id           *string `awsName:"DBInstanceIdentifier" awsType:"awsstr" templateName:"id"`
 	SkipSnapshot *bool   `awsName:"SkipFinalSnapshot" awsType:"awsbool" templateName:"skip-snapshot"`
 	Snapshot     *string `awsName:"FinalDBSnapshotIdentifier" awsType:"awsstr" templateName:"snapshot"`
+	Wait         *bool   `templateName:"wait"`
+	WaitTimeout  *int64  `templateName:"wait-timeout"`
 }
 
 func (cmd *DeleteDatabase) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"),
-		params.Opt("skip-snapshot", "snapshot"),
-	))
+		params.Opt("skip-snapshot", "snapshot", "wait", "wait-timeout"),
+	), params.Validators{"wait-timeout": validateWaitTimeout})
+}
+
+func (cmd *DeleteDatabase) AfterRun(renv env.Running, output interface{}) error {
+	waitUntil(renv.Log(), cmd.Wait, cmd.WaitTimeout, fmt.Sprintf("database %s deleted", StringValue(cmd.Id)), func(ctx awssdk.Context, opts ...request.WaiterOption) error {
+		return cmd.api.WaitUntilDBInstanceDeletedWithContext(ctx, &rds.DescribeDBInstancesInput{DBInstanceIdentifier: cmd.Id}, opts...)
+	})
+	return nil
 }
 
 type CheckDatabase struct {
@@ -50,13 +50,14 @@ type CreateDistribution struct {
 	ForwardQueries *bool     `templateName:"forward-queries"`
 	HttpsBehaviour *string   `templateName:"https-behaviour"`
 	OriginPath     *string   `templateName:"origin-path"`
+	OriginIdentity *string   `templateName:"origin-access-identity"`
 	PriceClass     *string   `templateName:"price-class"`
 	MinTtl         *int64    `templateName:"min-ttl"`
 }
 
 func (cmd *CreateDistribution) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("origin-domain"),
-		params.Opt("certificate", "comment", "default-file", "domain-aliases", "enable", "forward-cookies", "forward-queries", "https-behaviour", "min-ttl", "origin-path", "price-class"),
+		params.Opt("certificate", "comment", "default-file", "domain-aliases", "enable", "forward-cookies", "forward-queries", "https-behaviour", "min-ttl", "origin-access-identity", "origin-path", "price-class"),
 	))
 }
 
@@ -92,6 +93,9 @@ func (cmd *CreateDistribution) ManualRun(renv env.Running) (interface{}, error)
 	if domain := StringValue(cmd.OriginDomain); strings.HasSuffix(domain, ".s3.amazonaws.com") || (strings.HasSuffix(domain, ".amazonaws.com") && strings.Contains(domain, ".s3-website-")) {
 		input.DistributionConfig.Origins.Items[0].S3OriginConfig = &cloudfront.S3OriginConfig{OriginAccessIdentity: aws.String("")}
 	}
+	if cmd.OriginIdentity != nil {
+		input.DistributionConfig.Origins.Items[0].S3OriginConfig = &cloudfront.S3OriginConfig{OriginAccessIdentity: aws.String(originAccessIdentityPath(StringValue(cmd.OriginIdentity)))}
+	}
 
 	call := &awsCall{
 		fnName: "cloudfront.CreateDistribution",
@@ -210,13 +214,14 @@ type UpdateDistribution struct {
 	ForwardQueries *bool     `templateName:"forward-queries"`
 	HttpsBehaviour *string   `templateName:"https-behaviour"`
 	OriginPath     *string   `templateName:"origin-path"`
+	OriginIdentity *string   `templateName:"origin-access-identity"`
 	PriceClass     *string   `templateName:"price-class"`
 	MinTtl         *int64    `templateName:"min-ttl"`
 }
 
 func (cmd *UpdateDistribution) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("id"),
-		params.Opt("certificate", "comment", "default-file", "domain-aliases", "enable", "forward-cookies", "forward-queries", "https-behaviour", "min-ttl", "origin-domain", "origin-path", "price-class"),
+		params.Opt("certificate", "comment", "default-file", "domain-aliases", "enable", "forward-cookies", "forward-queries", "https-behaviour", "min-ttl", "origin-access-identity", "origin-domain", "origin-path", "price-class"),
 	))
 }
 
@@ -245,7 +250,7 @@ func (cmd *UpdateDistribution) ManualRun(renv env.Running) (interface{}, error)
 			return nil, err
 		}
 	}
-	if cmd.OriginDomain != nil || cmd.OriginPath != nil {
+	if cmd.OriginDomain != nil || cmd.OriginPath != nil || cmd.OriginIdentity != nil {
 		if configToUpdate.Origins == nil || len(configToUpdate.Origins.Items) == 0 {
 			configToUpdate.Origins = &cloudfront.Origins{
 				Quantity: aws.Int64(1),
@@ -268,6 +273,9 @@ func (cmd *UpdateDistribution) ManualRun(renv env.Running) (interface{}, error)
 				return nil, err
 			}
 		}
+		if cmd.OriginIdentity != nil {
+			input.DistributionConfig.Origins.Items[0].S3OriginConfig = &cloudfront.S3OriginConfig{OriginAccessIdentity: aws.String(originAccessIdentityPath(StringValue(cmd.OriginIdentity)))}
+		}
 	}
 
 	if cmd.Certificate != nil {
@@ -407,3 +415,8 @@ func (cmd *DeleteDistribution) ManualRun(renv env.Running) (interface{}, error)
 	cmd.logger.ExtraVerbosef("cloudfront.DeleteDistribution call took %s", time.Since(start))
 	return output, err
 }
+
+// originAccessIdentityPath returns the reference of an origin access identity in a S3 origin config
+func originAccessIdentityPath(id string) string {
+	return "origin-access-identity/cloudfront/" + id
+}
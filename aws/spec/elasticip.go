@@ -16,24 +16,103 @@ limitations under the License.
 package awsspec
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
 	"github.com/wallix/awless/template/params"
 )
 
 type CreateElasticip struct {
-	_      string `action:"create" entity:"elasticip" awsAPI:"ec2" awsCall:"AllocateAddress" awsInput:"ec2.AllocateAddressInput" awsOutput:"ec2.AllocateAddressOutput" awsDryRun:""`
+	_      string `action:"create" entity:"elasticip" awsAPI:"ec2" awsDryRun:"manual"`
 	logger *logger.Logger
 	graph  cloud.GraphAPI
 	api    ec2iface.EC2API
 	Domain *string `awsName:"Domain" awsType:"awsstr" templateName:"domain"`
+	Reuse  *bool   `templateName:"reuse"`
 }
 
 func (cmd *CreateElasticip) ParamsSpec() params.Spec {
-	return params.NewSpec(params.AllOf(params.Key("domain")))
+	return params.NewSpec(params.AllOf(params.Key("domain"), params.Opt("reuse")))
+}
+
+func (cmd *CreateElasticip) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("dry run: cannot set params on command struct: %s", err)
+	}
+	if reused, err := cmd.checkQuota(); err != nil {
+		return nil, fmt.Errorf("dry run: %s", err)
+	} else if reused != nil {
+		cmd.logger.Verbosef("dry run: create elasticip will reuse '%s'", StringValue(reused.AllocationId))
+		return StringValue(reused.AllocationId), nil
+	}
+
+	input := &ec2.AllocateAddressInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("dry run: cannot inject in ec2.AllocateAddressInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.AllocateAddress(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound):
+			cmd.logger.ExtraVerbosef("dry run: ec2.AllocateAddress call took %s", time.Since(start))
+			cmd.logger.Verbose("dry run: create elasticip ok")
+			return fakeDryRunId("elasticip"), nil
+		}
+	}
+
+	return nil, fmt.Errorf("dry run: %s", err)
+}
+
+func (cmd *CreateElasticip) ManualRun(renv env.Running) (interface{}, error) {
+	reused, err := cmd.checkQuota()
+	if err != nil {
+		return nil, err
+	}
+	if reused != nil {
+		cmd.logger.Infof("reusing unassociated elastic IP %s (%s)", StringValue(reused.PublicIp), StringValue(reused.AllocationId))
+		return &ec2.AllocateAddressOutput{AllocationId: reused.AllocationId, PublicIp: reused.PublicIp, Domain: reused.Domain}, nil
+	}
+
+	input := &ec2.AllocateAddressInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.AllocateAddressInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.AllocateAddress(input)
+	cmd.logger.ExtraVerbosef("ec2.AllocateAddress call took %s", time.Since(start))
+	return output, err
+}
+
+// checkQuota returns the unassociated address to reuse when asked to, or a QuotaExceededError
+// when no more address can be allocated. Failing to retrieve the usage does not prevent the allocation.
+func (cmd *CreateElasticip) checkQuota() (*ec2.Address, error) {
+	allocated, limit, err := elasticIPsUsage(cmd.api, StringValue(cmd.Domain))
+	if err != nil {
+		cmd.logger.Verbosef("cannot check elastic IPs quota: %s", err)
+		return nil, nil
+	}
+	unassociated, err := unassociatedElasticIPs(cmd.graph, allocated)
+	if err != nil {
+		return nil, err
+	}
+	if BoolValue(cmd.Reuse) && len(unassociated) > 0 {
+		return unassociated[0], nil
+	}
+	if len(allocated) >= limit {
+		return nil, &QuotaExceededError{Entity: "elasticip", Used: len(allocated), Limit: limit, Hint: reuseHint(unassociated)}
+	}
+	return nil, nil
 }
 
 func (cmd *CreateElasticip) ExtractResult(i interface{}) string {
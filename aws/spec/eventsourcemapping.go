@@ -0,0 +1,78 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateEventsourcemapping struct {
+	_                string `action:"create" entity:"eventsourcemapping" awsAPI:"lambda" awsCall:"CreateEventSourceMapping" awsInput:"lambda.CreateEventSourceMappingInput" awsOutput:"lambda.EventSourceMappingConfiguration"`
+	logger           *logger.Logger
+	graph            cloud.GraphAPI
+	api              lambdaiface.LambdaAPI
+	Function         *string `awsName:"FunctionName" awsType:"awsstr" templateName:"function"`
+	Source           *string `awsName:"EventSourceArn" awsType:"awsstr" templateName:"source"`
+	StartingPosition *string `awsName:"StartingPosition" awsType:"awsstr" templateName:"starting-position"`
+	BatchSize        *int64  `awsName:"BatchSize" awsType:"awsint64" templateName:"batch-size"`
+	Enabled          *bool   `awsName:"Enabled" awsType:"awsbool" templateName:"enabled"`
+}
+
+func (cmd *CreateEventsourcemapping) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("function"), params.Key("source"), params.Key("starting-position"),
+			params.Opt("batch-size", "enabled"),
+		),
+		params.Validators{
+			"starting-position": params.IsInEnumIgnoreCase(lambda.EventSourcePositionTrimHorizon, lambda.EventSourcePositionLatest),
+		})
+}
+
+func (cmd *CreateEventsourcemapping) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*lambda.EventSourceMappingConfiguration).UUID)
+}
+
+type UpdateEventsourcemapping struct {
+	_         string `action:"update" entity:"eventsourcemapping" awsAPI:"lambda" awsCall:"UpdateEventSourceMapping" awsInput:"lambda.UpdateEventSourceMappingInput" awsOutput:"lambda.EventSourceMappingConfiguration"`
+	logger    *logger.Logger
+	graph     cloud.GraphAPI
+	api       lambdaiface.LambdaAPI
+	Id        *string `awsName:"UUID" awsType:"awsstr" templateName:"id"`
+	Function  *string `awsName:"FunctionName" awsType:"awsstr" templateName:"function"`
+	BatchSize *int64  `awsName:"BatchSize" awsType:"awsint64" templateName:"batch-size"`
+	Enabled   *bool   `awsName:"Enabled" awsType:"awsbool" templateName:"enabled"`
+}
+
+func (cmd *UpdateEventsourcemapping) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.AtLeastOneOf(params.Key("batch-size"), params.Key("enabled"), params.Key("function"))))
+}
+
+type DeleteEventsourcemapping struct {
+	_      string `action:"delete" entity:"eventsourcemapping" awsAPI:"lambda" awsCall:"DeleteEventSourceMapping" awsInput:"lambda.DeleteEventSourceMappingInput" awsOutput:"lambda.EventSourceMappingConfiguration"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    lambdaiface.LambdaAPI
+	Id     *string `awsName:"UUID" awsType:"awsstr" templateName:"id"`
+}
+
+func (cmd *DeleteEventsourcemapping) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id")))
+}
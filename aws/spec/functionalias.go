@@ -0,0 +1,74 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateFunctionalias struct {
+	_           string `action:"create" entity:"functionalias" awsAPI:"lambda" awsCall:"CreateAlias" awsInput:"lambda.CreateAliasInput" awsOutput:"lambda.AliasConfiguration"`
+	logger      *logger.Logger
+	graph       cloud.GraphAPI
+	api         lambdaiface.LambdaAPI
+	Function    *string `awsName:"FunctionName" awsType:"awsstr" templateName:"function"`
+	Name        *string `awsName:"Name" awsType:"awsstr" templateName:"name"`
+	Version     *string `awsName:"FunctionVersion" awsType:"awsstr" templateName:"version"`
+	Description *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+}
+
+func (cmd *CreateFunctionalias) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("function"), params.Key("name"), params.Key("version"),
+		params.Opt("description"),
+	))
+}
+
+func (cmd *CreateFunctionalias) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*lambda.AliasConfiguration).AliasArn)
+}
+
+type UpdateFunctionalias struct {
+	_           string `action:"update" entity:"functionalias" awsAPI:"lambda" awsCall:"UpdateAlias" awsInput:"lambda.UpdateAliasInput" awsOutput:"lambda.AliasConfiguration"`
+	logger      *logger.Logger
+	graph       cloud.GraphAPI
+	api         lambdaiface.LambdaAPI
+	Function    *string `awsName:"FunctionName" awsType:"awsstr" templateName:"function"`
+	Name        *string `awsName:"Name" awsType:"awsstr" templateName:"name"`
+	Version     *string `awsName:"FunctionVersion" awsType:"awsstr" templateName:"version"`
+	Description *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+}
+
+func (cmd *UpdateFunctionalias) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("function"), params.Key("name"), params.AtLeastOneOf(params.Key("description"), params.Key("version"))))
+}
+
+type DeleteFunctionalias struct {
+	_        string `action:"delete" entity:"functionalias" awsAPI:"lambda" awsCall:"DeleteAlias" awsInput:"lambda.DeleteAliasInput" awsOutput:"lambda.DeleteAliasOutput"`
+	logger   *logger.Logger
+	graph    cloud.GraphAPI
+	api      lambdaiface.LambdaAPI
+	Function *string `awsName:"FunctionName" awsType:"awsstr" templateName:"function"`
+	Name     *string `awsName:"Name" awsType:"awsstr" templateName:"name"`
+}
+
+func (cmd *DeleteFunctionalias) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("function"), params.Key("name")))
+}
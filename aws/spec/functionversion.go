@@ -0,0 +1,57 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateFunctionversion struct {
+	_           string `action:"create" entity:"functionversion" awsAPI:"lambda" awsCall:"PublishVersion" awsInput:"lambda.PublishVersionInput" awsOutput:"lambda.FunctionConfiguration"`
+	logger      *logger.Logger
+	graph       cloud.GraphAPI
+	api         lambdaiface.LambdaAPI
+	Function    *string `awsName:"FunctionName" awsType:"awsstr" templateName:"function"`
+	Description *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+}
+
+func (cmd *CreateFunctionversion) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("function"),
+		params.Opt("description"),
+	))
+}
+
+func (cmd *CreateFunctionversion) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*lambda.FunctionConfiguration).Version)
+}
+
+type DeleteFunctionversion struct {
+	_        string `action:"delete" entity:"functionversion" awsAPI:"lambda" awsCall:"DeleteFunction" awsInput:"lambda.DeleteFunctionInput" awsOutput:"lambda.DeleteFunctionOutput"`
+	logger   *logger.Logger
+	graph    cloud.GraphAPI
+	api      lambdaiface.LambdaAPI
+	Function *string `awsName:"FunctionName" awsType:"awsstr" templateName:"function"`
+	Version  *string `awsName:"Qualifier" awsType:"awsstr" templateName:"version"`
+}
+
+func (cmd *DeleteFunctionversion) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("function"), params.Key("version")))
+}
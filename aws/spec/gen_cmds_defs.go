@@ -18,167 +18,219 @@ limitations under the License.
 package awsspec
 
 var APIPerTemplateDefName = map[string]string{
-	"attachalarm":               "cloudwatch",
-	"attachcontainertask":       "ecs",
-	"attachelasticip":           "ec2",
-	"attachinstance":            "elbv2",
-	"attachinstanceprofile":     "ec2",
-	"attachinternetgateway":     "ec2",
-	"attachmfadevice":           "iam",
-	"attachnetworkinterface":    "ec2",
-	"attachpolicy":              "iam",
-	"attachrole":                "iam",
-	"attachroutetable":          "ec2",
-	"attachsecuritygroup":       "ec2",
-	"attachuser":                "iam",
-	"attachvolume":              "ec2",
-	"authenticateregistry":      "ecr",
-	"checkcertificate":          "acm",
-	"checkdatabase":             "rds",
-	"checkdistribution":         "cloudfront",
-	"checkinstance":             "ec2",
-	"checkloadbalancer":         "elbv2",
-	"checknatgateway":           "ec2",
-	"checknetworkinterface":     "ec2",
-	"checkscalinggroup":         "autoscaling",
-	"checksecuritygroup":        "ec2",
-	"checkvolume":               "ec2",
-	"copyimage":                 "ec2",
-	"copysnapshot":              "ec2",
-	"createaccesskey":           "iam",
-	"createalarm":               "cloudwatch",
-	"createappscalingpolicy":    "applicationautoscaling",
-	"createappscalingtarget":    "applicationautoscaling",
-	"createbucket":              "s3",
-	"createcertificate":         "acm",
-	"createcontainercluster":    "ecs",
-	"createdatabase":            "rds",
-	"createdbsubnetgroup":       "rds",
-	"createdistribution":        "cloudfront",
-	"createelasticip":           "ec2",
-	"createfunction":            "lambda",
-	"creategroup":               "iam",
-	"createimage":               "ec2",
-	"createinstance":            "ec2",
-	"createinstanceprofile":     "iam",
-	"createinternetgateway":     "ec2",
-	"createkeypair":             "ec2",
-	"createlaunchconfiguration": "autoscaling",
-	"createlistener":            "elbv2",
-	"createloadbalancer":        "elbv2",
-	"createloginprofile":        "iam",
-	"createmfadevice":           "iam",
-	"createnatgateway":          "ec2",
-	"createnetworkinterface":    "ec2",
-	"createpolicy":              "iam",
-	"createqueue":               "sqs",
-	"createrecord":              "route53",
-	"createrepository":          "ecr",
-	"createrole":                "iam",
-	"createroute":               "ec2",
-	"createroutetable":          "ec2",
-	"creates3object":            "s3",
-	"createscalinggroup":        "autoscaling",
-	"createscalingpolicy":       "autoscaling",
-	"createsecuritygroup":       "ec2",
-	"createsnapshot":            "ec2",
-	"createstack":               "cloudformation",
-	"createsubnet":              "ec2",
-	"createsubscription":        "sns",
-	"createtag":                 "ec2",
-	"createtargetgroup":         "elbv2",
-	"createtopic":               "sns",
-	"createuser":                "iam",
-	"createvolume":              "ec2",
-	"createvpc":                 "ec2",
-	"createzone":                "route53",
-	"deleteaccesskey":           "iam",
-	"deletealarm":               "cloudwatch",
-	"deleteappscalingpolicy":    "applicationautoscaling",
-	"deleteappscalingtarget":    "applicationautoscaling",
-	"deletebucket":              "s3",
-	"deletecertificate":         "acm",
-	"deletecontainercluster":    "ecs",
-	"deletecontainertask":       "ecs",
-	"deletedatabase":            "rds",
-	"deletedbsubnetgroup":       "rds",
-	"deletedistribution":        "cloudfront",
-	"deleteelasticip":           "ec2",
-	"deletefunction":            "lambda",
-	"deletegroup":               "iam",
-	"deleteimage":               "ec2",
-	"deleteinstance":            "ec2",
-	"deleteinstanceprofile":     "iam",
-	"deleteinternetgateway":     "ec2",
-	"deletekeypair":             "ec2",
-	"deletelaunchconfiguration": "autoscaling",
-	"deletelistener":            "elbv2",
-	"deleteloadbalancer":        "elbv2",
-	"deleteloginprofile":        "iam",
-	"deletemfadevice":           "iam",
-	"deletenatgateway":          "ec2",
-	"deletenetworkinterface":    "ec2",
-	"deletepolicy":              "iam",
-	"deletequeue":               "sqs",
-	"deleterecord":              "route53",
-	"deleterepository":          "ecr",
-	"deleterole":                "iam",
-	"deleteroute":               "ec2",
-	"deleteroutetable":          "ec2",
-	"deletes3object":            "s3",
-	"deletescalinggroup":        "autoscaling",
-	"deletescalingpolicy":       "autoscaling",
-	"deletesecuritygroup":       "ec2",
-	"deletesnapshot":            "ec2",
-	"deletestack":               "cloudformation",
-	"deletesubnet":              "ec2",
-	"deletesubscription":        "sns",
-	"deletetag":                 "ec2",
-	"deletetargetgroup":         "elbv2",
-	"deletetopic":               "sns",
-	"deleteuser":                "iam",
-	"deletevolume":              "ec2",
-	"deletevpc":                 "ec2",
-	"deletezone":                "route53",
-	"detachalarm":               "cloudwatch",
-	"detachcontainertask":       "ecs",
-	"detachelasticip":           "ec2",
-	"detachinstance":            "elbv2",
-	"detachinstanceprofile":     "ec2",
-	"detachinternetgateway":     "ec2",
-	"detachmfadevice":           "iam",
-	"detachnetworkinterface":    "ec2",
-	"detachpolicy":              "iam",
-	"detachrole":                "iam",
-	"detachroutetable":          "ec2",
-	"detachsecuritygroup":       "ec2",
-	"detachuser":                "iam",
-	"detachvolume":              "ec2",
-	"importimage":               "ec2",
-	"restartdatabase":           "rds",
-	"restartinstance":           "ec2",
-	"startalarm":                "cloudwatch",
-	"startcontainertask":        "ecs",
-	"startdatabase":             "rds",
-	"startinstance":             "ec2",
-	"stopalarm":                 "cloudwatch",
-	"stopcontainertask":         "ecs",
-	"stopdatabase":              "rds",
-	"stopinstance":              "ec2",
-	"updatebucket":              "s3",
-	"updatecontainertask":       "ecs",
-	"updatedistribution":        "cloudfront",
-	"updateimage":               "ec2",
-	"updateinstance":            "ec2",
-	"updateloginprofile":        "iam",
-	"updatepolicy":              "iam",
-	"updaterecord":              "route53",
-	"updates3object":            "s3",
-	"updatescalinggroup":        "autoscaling",
-	"updatesecuritygroup":       "ec2",
-	"updatestack":               "cloudformation",
-	"updatesubnet":              "ec2",
-	"updatetargetgroup":         "elbv2",
+	"attachalarm":                 "cloudwatch",
+	"attachcontainertask":         "ecs",
+	"attachelasticip":             "ec2",
+	"attachinstance":              "elbv2",
+	"attachinstanceprofile":       "ec2",
+	"attachintegration":           "apigateway",
+	"attachinternetgateway":       "ec2",
+	"attachkeygrant":              "kms",
+	"attachmfadevice":             "iam",
+	"attachnetworkinterface":      "ec2",
+	"attachpolicy":                "iam",
+	"attachrole":                  "iam",
+	"attachroutetable":            "ec2",
+	"attachsecuritygroup":         "ec2",
+	"attachuser":                  "iam",
+	"attachvolume":                "ec2",
+	"authenticateregistry":        "ecr",
+	"checkcertificate":            "acm",
+	"checkdatabase":               "rds",
+	"checkdistribution":           "cloudfront",
+	"checkinstance":               "ec2",
+	"checkloadbalancer":           "elbv2",
+	"checknatgateway":             "ec2",
+	"checknetworkinterface":       "ec2",
+	"checkscalinggroup":           "autoscaling",
+	"checksecuritygroup":          "ec2",
+	"checkvolume":                 "ec2",
+	"copyimage":                   "ec2",
+	"copysnapshot":                "ec2",
+	"createaccesskey":             "iam",
+	"createalarm":                 "cloudwatch",
+	"createalias":                 "kms",
+	"createapideployment":         "apigateway",
+	"createapimethod":             "apigateway",
+	"createapiresource":           "apigateway",
+	"createappscalingpolicy":      "applicationautoscaling",
+	"createappscalingtarget":      "applicationautoscaling",
+	"createbucket":                "s3",
+	"createcachecluster":          "elasticache",
+	"createcertificate":           "acm",
+	"createcontainercluster":      "ecs",
+	"createdatabase":              "rds",
+	"createdbsubnetgroup":         "rds",
+	"createdistribution":          "cloudfront",
+	"createelasticip":             "ec2",
+	"createeventsourcemapping":    "lambda",
+	"createfunction":              "lambda",
+	"createfunctionalias":         "lambda",
+	"createfunctionversion":       "lambda",
+	"creategroup":                 "iam",
+	"createhealthcheck":           "route53",
+	"createimage":                 "ec2",
+	"createinstance":              "ec2",
+	"createinstanceprofile":       "iam",
+	"createinternetgateway":       "ec2",
+	"createkey":                   "kms",
+	"createkeypair":               "ec2",
+	"createlaunchconfiguration":   "autoscaling",
+	"createlaunchtemplate":        "ec2",
+	"createlistener":              "elbv2",
+	"createloadbalancer":          "elbv2",
+	"createloginprofile":          "iam",
+	"createmfadevice":             "iam",
+	"createnatgateway":            "ec2",
+	"createnetworkinterface":      "ec2",
+	"createoriginaccessidentity":  "cloudfront",
+	"createpolicy":                "iam",
+	"createpresignedurl":          "s3",
+	"createqueue":                 "sqs",
+	"createrecord":                "route53",
+	"createreplicationgroup":      "elasticache",
+	"createrepository":            "ecr",
+	"createrestapi":               "apigateway",
+	"createrole":                  "iam",
+	"createroute":                 "ec2",
+	"createroutetable":            "ec2",
+	"creates3object":              "s3",
+	"createscalinggroup":          "autoscaling",
+	"createscalingpolicy":         "autoscaling",
+	"createsecuritygroup":         "ec2",
+	"createsnapshot":              "ec2",
+	"createspotfleet":             "ec2",
+	"createstack":                 "cloudformation",
+	"createstatemachine":          "sfn",
+	"createsubnet":                "ec2",
+	"createsubscription":          "sns",
+	"createtable":                 "dynamodb",
+	"createtag":                   "ec2",
+	"createtargetgroup":           "elbv2",
+	"createtopic":                 "sns",
+	"createtrafficpolicy":         "route53",
+	"createtrafficpolicyinstance": "route53",
+	"createuser":                  "iam",
+	"createvolume":                "ec2",
+	"createvpc":                   "ec2",
+	"createzone":                  "route53",
+	"deleteaccesskey":             "iam",
+	"deletealarm":                 "cloudwatch",
+	"deletealias":                 "kms",
+	"deleteapideployment":         "apigateway",
+	"deleteapimethod":             "apigateway",
+	"deleteapiresource":           "apigateway",
+	"deleteapistage":              "apigateway",
+	"deleteappscalingpolicy":      "applicationautoscaling",
+	"deleteappscalingtarget":      "applicationautoscaling",
+	"deletebucket":                "s3",
+	"deletecachecluster":          "elasticache",
+	"deletecertificate":           "acm",
+	"deletecontainercluster":      "ecs",
+	"deletecontainertask":         "ecs",
+	"deletedatabase":              "rds",
+	"deletedbsubnetgroup":         "rds",
+	"deletedistribution":          "cloudfront",
+	"deleteelasticip":             "ec2",
+	"deleteeventsourcemapping":    "lambda",
+	"deletefunction":              "lambda",
+	"deletefunctionalias":         "lambda",
+	"deletefunctionversion":       "lambda",
+	"deletegroup":                 "iam",
+	"deletehealthcheck":           "route53",
+	"deleteimage":                 "ec2",
+	"deleteinstance":              "ec2",
+	"deleteinstanceprofile":       "iam",
+	"deleteinternetgateway":       "ec2",
+	"deletekey":                   "kms",
+	"deletekeypair":               "ec2",
+	"deletelaunchconfiguration":   "autoscaling",
+	"deletelaunchtemplate":        "ec2",
+	"deletelistener":              "elbv2",
+	"deleteloadbalancer":          "elbv2",
+	"deleteloginprofile":          "iam",
+	"deletemfadevice":             "iam",
+	"deletenatgateway":            "ec2",
+	"deletenetworkinterface":      "ec2",
+	"deleteoriginaccessidentity":  "cloudfront",
+	"deletepolicy":                "iam",
+	"deletequeue":                 "sqs",
+	"deleterecord":                "route53",
+	"deletereplicationgroup":      "elasticache",
+	"deleterepository":            "ecr",
+	"deleterestapi":               "apigateway",
+	"deleterole":                  "iam",
+	"deleteroute":                 "ec2",
+	"deleteroutetable":            "ec2",
+	"deletes3object":              "s3",
+	"deletescalinggroup":          "autoscaling",
+	"deletescalingpolicy":         "autoscaling",
+	"deletesecuritygroup":         "ec2",
+	"deletesnapshot":              "ec2",
+	"deletespotfleet":             "ec2",
+	"deletestack":                 "cloudformation",
+	"deletestatemachine":          "sfn",
+	"deletesubnet":                "ec2",
+	"deletesubscription":          "sns",
+	"deletetable":                 "dynamodb",
+	"deletetag":                   "ec2",
+	"deletetargetgroup":           "elbv2",
+	"deletetopic":                 "sns",
+	"deletetrafficpolicy":         "route53",
+	"deletetrafficpolicyinstance": "route53",
+	"deleteuser":                  "iam",
+	"deletevolume":                "ec2",
+	"deletevpc":                   "ec2",
+	"deletezone":                  "route53",
+	"detachalarm":                 "cloudwatch",
+	"detachcontainertask":         "ecs",
+	"detachelasticip":             "ec2",
+	"detachinstance":              "elbv2",
+	"detachinstanceprofile":       "ec2",
+	"detachintegration":           "apigateway",
+	"detachinternetgateway":       "ec2",
+	"detachkeygrant":              "kms",
+	"detachmfadevice":             "iam",
+	"detachnetworkinterface":      "ec2",
+	"detachpolicy":                "iam",
+	"detachrole":                  "iam",
+	"detachroutetable":            "ec2",
+	"detachsecuritygroup":         "ec2",
+	"detachuser":                  "iam",
+	"detachvolume":                "ec2",
+	"importimage":                 "ec2",
+	"restartdatabase":             "rds",
+	"restartinstance":             "ec2",
+	"startalarm":                  "cloudwatch",
+	"startcontainertask":          "ecs",
+	"startdatabase":               "rds",
+	"startinstance":               "ec2",
+	"startstatemachine":           "sfn",
+	"stopalarm":                   "cloudwatch",
+	"stopcontainertask":           "ecs",
+	"stopdatabase":                "rds",
+	"stopinstance":                "ec2",
+	"syncs3object":                "s3",
+	"updatebucket":                "s3",
+	"updatecontainertask":         "ecs",
+	"updatedistribution":          "cloudfront",
+	"updateeventsourcemapping":    "lambda",
+	"updatefunctionalias":         "lambda",
+	"updateimage":                 "ec2",
+	"updateinstance":              "ec2",
+	"updatelaunchtemplate":        "ec2",
+	"updateloginprofile":          "iam",
+	"updateoriginaccessidentity":  "cloudfront",
+	"updatepolicy":                "iam",
+	"updaterecord":                "route53",
+	"updates3object":              "s3",
+	"updatescalinggroup":          "autoscaling",
+	"updatesecuritygroup":         "ec2",
+	"updatesnapshot":              "ec2",
+	"updatestack":                 "cloudformation",
+	"updatesubnet":                "ec2",
+	"updatetable":                 "dynamodb",
+	"updatetargetgroup":           "elbv2",
 }
 
 var AWSTemplatesDefinitions = map[string]Definition{
@@ -212,12 +264,24 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(AttachInstanceprofile).ParamsSpec().Rule(),
 	},
+	"attachintegration": {
+		Action: "attach",
+		Entity: "integration",
+		Api:    "apigateway",
+		Params: new(AttachIntegration).ParamsSpec().Rule(),
+	},
 	"attachinternetgateway": {
 		Action: "attach",
 		Entity: "internetgateway",
 		Api:    "ec2",
 		Params: new(AttachInternetgateway).ParamsSpec().Rule(),
 	},
+	"attachkeygrant": {
+		Action: "attach",
+		Entity: "keygrant",
+		Api:    "kms",
+		Params: new(AttachKeygrant).ParamsSpec().Rule(),
+	},
 	"attachmfadevice": {
 		Action: "attach",
 		Entity: "mfadevice",
@@ -356,6 +420,30 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "cloudwatch",
 		Params: new(CreateAlarm).ParamsSpec().Rule(),
 	},
+	"createalias": {
+		Action: "create",
+		Entity: "alias",
+		Api:    "kms",
+		Params: new(CreateAlias).ParamsSpec().Rule(),
+	},
+	"createapideployment": {
+		Action: "create",
+		Entity: "apideployment",
+		Api:    "apigateway",
+		Params: new(CreateApideployment).ParamsSpec().Rule(),
+	},
+	"createapimethod": {
+		Action: "create",
+		Entity: "apimethod",
+		Api:    "apigateway",
+		Params: new(CreateApimethod).ParamsSpec().Rule(),
+	},
+	"createapiresource": {
+		Action: "create",
+		Entity: "apiresource",
+		Api:    "apigateway",
+		Params: new(CreateApiresource).ParamsSpec().Rule(),
+	},
 	"createappscalingpolicy": {
 		Action: "create",
 		Entity: "appscalingpolicy",
@@ -374,6 +462,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "s3",
 		Params: new(CreateBucket).ParamsSpec().Rule(),
 	},
+	"createcachecluster": {
+		Action: "create",
+		Entity: "cachecluster",
+		Api:    "elasticache",
+		Params: new(CreateCachecluster).ParamsSpec().Rule(),
+	},
 	"createcertificate": {
 		Action: "create",
 		Entity: "certificate",
@@ -410,18 +504,42 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(CreateElasticip).ParamsSpec().Rule(),
 	},
+	"createeventsourcemapping": {
+		Action: "create",
+		Entity: "eventsourcemapping",
+		Api:    "lambda",
+		Params: new(CreateEventsourcemapping).ParamsSpec().Rule(),
+	},
 	"createfunction": {
 		Action: "create",
 		Entity: "function",
 		Api:    "lambda",
 		Params: new(CreateFunction).ParamsSpec().Rule(),
 	},
+	"createfunctionalias": {
+		Action: "create",
+		Entity: "functionalias",
+		Api:    "lambda",
+		Params: new(CreateFunctionalias).ParamsSpec().Rule(),
+	},
+	"createfunctionversion": {
+		Action: "create",
+		Entity: "functionversion",
+		Api:    "lambda",
+		Params: new(CreateFunctionversion).ParamsSpec().Rule(),
+	},
 	"creategroup": {
 		Action: "create",
 		Entity: "group",
 		Api:    "iam",
 		Params: new(CreateGroup).ParamsSpec().Rule(),
 	},
+	"createhealthcheck": {
+		Action: "create",
+		Entity: "healthcheck",
+		Api:    "route53",
+		Params: new(CreateHealthcheck).ParamsSpec().Rule(),
+	},
 	"createimage": {
 		Action: "create",
 		Entity: "image",
@@ -446,6 +564,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(CreateInternetgateway).ParamsSpec().Rule(),
 	},
+	"createkey": {
+		Action: "create",
+		Entity: "key",
+		Api:    "kms",
+		Params: new(CreateKey).ParamsSpec().Rule(),
+	},
 	"createkeypair": {
 		Action: "create",
 		Entity: "keypair",
@@ -458,6 +582,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "autoscaling",
 		Params: new(CreateLaunchconfiguration).ParamsSpec().Rule(),
 	},
+	"createlaunchtemplate": {
+		Action: "create",
+		Entity: "launchtemplate",
+		Api:    "ec2",
+		Params: new(CreateLaunchtemplate).ParamsSpec().Rule(),
+	},
 	"createlistener": {
 		Action: "create",
 		Entity: "listener",
@@ -494,12 +624,24 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(CreateNetworkinterface).ParamsSpec().Rule(),
 	},
+	"createoriginaccessidentity": {
+		Action: "create",
+		Entity: "originaccessidentity",
+		Api:    "cloudfront",
+		Params: new(CreateOriginaccessidentity).ParamsSpec().Rule(),
+	},
 	"createpolicy": {
 		Action: "create",
 		Entity: "policy",
 		Api:    "iam",
 		Params: new(CreatePolicy).ParamsSpec().Rule(),
 	},
+	"createpresignedurl": {
+		Action: "create",
+		Entity: "presignedurl",
+		Api:    "s3",
+		Params: new(CreatePresignedurl).ParamsSpec().Rule(),
+	},
 	"createqueue": {
 		Action: "create",
 		Entity: "queue",
@@ -512,12 +654,24 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "route53",
 		Params: new(CreateRecord).ParamsSpec().Rule(),
 	},
+	"createreplicationgroup": {
+		Action: "create",
+		Entity: "replicationgroup",
+		Api:    "elasticache",
+		Params: new(CreateReplicationgroup).ParamsSpec().Rule(),
+	},
 	"createrepository": {
 		Action: "create",
 		Entity: "repository",
 		Api:    "ecr",
 		Params: new(CreateRepository).ParamsSpec().Rule(),
 	},
+	"createrestapi": {
+		Action: "create",
+		Entity: "restapi",
+		Api:    "apigateway",
+		Params: new(CreateRestapi).ParamsSpec().Rule(),
+	},
 	"createrole": {
 		Action: "create",
 		Entity: "role",
@@ -566,12 +720,24 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(CreateSnapshot).ParamsSpec().Rule(),
 	},
+	"createspotfleet": {
+		Action: "create",
+		Entity: "spotfleet",
+		Api:    "ec2",
+		Params: new(CreateSpotfleet).ParamsSpec().Rule(),
+	},
 	"createstack": {
 		Action: "create",
 		Entity: "stack",
 		Api:    "cloudformation",
 		Params: new(CreateStack).ParamsSpec().Rule(),
 	},
+	"createstatemachine": {
+		Action: "create",
+		Entity: "statemachine",
+		Api:    "sfn",
+		Params: new(CreateStatemachine).ParamsSpec().Rule(),
+	},
 	"createsubnet": {
 		Action: "create",
 		Entity: "subnet",
@@ -584,6 +750,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "sns",
 		Params: new(CreateSubscription).ParamsSpec().Rule(),
 	},
+	"createtable": {
+		Action: "create",
+		Entity: "table",
+		Api:    "dynamodb",
+		Params: new(CreateTable).ParamsSpec().Rule(),
+	},
 	"createtag": {
 		Action: "create",
 		Entity: "tag",
@@ -602,6 +774,18 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "sns",
 		Params: new(CreateTopic).ParamsSpec().Rule(),
 	},
+	"createtrafficpolicy": {
+		Action: "create",
+		Entity: "trafficpolicy",
+		Api:    "route53",
+		Params: new(CreateTrafficpolicy).ParamsSpec().Rule(),
+	},
+	"createtrafficpolicyinstance": {
+		Action: "create",
+		Entity: "trafficpolicyinstance",
+		Api:    "route53",
+		Params: new(CreateTrafficpolicyinstance).ParamsSpec().Rule(),
+	},
 	"createuser": {
 		Action: "create",
 		Entity: "user",
@@ -638,6 +822,36 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "cloudwatch",
 		Params: new(DeleteAlarm).ParamsSpec().Rule(),
 	},
+	"deletealias": {
+		Action: "delete",
+		Entity: "alias",
+		Api:    "kms",
+		Params: new(DeleteAlias).ParamsSpec().Rule(),
+	},
+	"deleteapideployment": {
+		Action: "delete",
+		Entity: "apideployment",
+		Api:    "apigateway",
+		Params: new(DeleteApideployment).ParamsSpec().Rule(),
+	},
+	"deleteapimethod": {
+		Action: "delete",
+		Entity: "apimethod",
+		Api:    "apigateway",
+		Params: new(DeleteApimethod).ParamsSpec().Rule(),
+	},
+	"deleteapiresource": {
+		Action: "delete",
+		Entity: "apiresource",
+		Api:    "apigateway",
+		Params: new(DeleteApiresource).ParamsSpec().Rule(),
+	},
+	"deleteapistage": {
+		Action: "delete",
+		Entity: "apistage",
+		Api:    "apigateway",
+		Params: new(DeleteApistage).ParamsSpec().Rule(),
+	},
 	"deleteappscalingpolicy": {
 		Action: "delete",
 		Entity: "appscalingpolicy",
@@ -656,6 +870,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "s3",
 		Params: new(DeleteBucket).ParamsSpec().Rule(),
 	},
+	"deletecachecluster": {
+		Action: "delete",
+		Entity: "cachecluster",
+		Api:    "elasticache",
+		Params: new(DeleteCachecluster).ParamsSpec().Rule(),
+	},
 	"deletecertificate": {
 		Action: "delete",
 		Entity: "certificate",
@@ -698,18 +918,42 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(DeleteElasticip).ParamsSpec().Rule(),
 	},
+	"deleteeventsourcemapping": {
+		Action: "delete",
+		Entity: "eventsourcemapping",
+		Api:    "lambda",
+		Params: new(DeleteEventsourcemapping).ParamsSpec().Rule(),
+	},
 	"deletefunction": {
 		Action: "delete",
 		Entity: "function",
 		Api:    "lambda",
 		Params: new(DeleteFunction).ParamsSpec().Rule(),
 	},
+	"deletefunctionalias": {
+		Action: "delete",
+		Entity: "functionalias",
+		Api:    "lambda",
+		Params: new(DeleteFunctionalias).ParamsSpec().Rule(),
+	},
+	"deletefunctionversion": {
+		Action: "delete",
+		Entity: "functionversion",
+		Api:    "lambda",
+		Params: new(DeleteFunctionversion).ParamsSpec().Rule(),
+	},
 	"deletegroup": {
 		Action: "delete",
 		Entity: "group",
 		Api:    "iam",
 		Params: new(DeleteGroup).ParamsSpec().Rule(),
 	},
+	"deletehealthcheck": {
+		Action: "delete",
+		Entity: "healthcheck",
+		Api:    "route53",
+		Params: new(DeleteHealthcheck).ParamsSpec().Rule(),
+	},
 	"deleteimage": {
 		Action: "delete",
 		Entity: "image",
@@ -734,6 +978,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(DeleteInternetgateway).ParamsSpec().Rule(),
 	},
+	"deletekey": {
+		Action: "delete",
+		Entity: "key",
+		Api:    "kms",
+		Params: new(DeleteKey).ParamsSpec().Rule(),
+	},
 	"deletekeypair": {
 		Action: "delete",
 		Entity: "keypair",
@@ -746,6 +996,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "autoscaling",
 		Params: new(DeleteLaunchconfiguration).ParamsSpec().Rule(),
 	},
+	"deletelaunchtemplate": {
+		Action: "delete",
+		Entity: "launchtemplate",
+		Api:    "ec2",
+		Params: new(DeleteLaunchtemplate).ParamsSpec().Rule(),
+	},
 	"deletelistener": {
 		Action: "delete",
 		Entity: "listener",
@@ -782,6 +1038,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(DeleteNetworkinterface).ParamsSpec().Rule(),
 	},
+	"deleteoriginaccessidentity": {
+		Action: "delete",
+		Entity: "originaccessidentity",
+		Api:    "cloudfront",
+		Params: new(DeleteOriginaccessidentity).ParamsSpec().Rule(),
+	},
 	"deletepolicy": {
 		Action: "delete",
 		Entity: "policy",
@@ -800,12 +1062,24 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "route53",
 		Params: new(DeleteRecord).ParamsSpec().Rule(),
 	},
+	"deletereplicationgroup": {
+		Action: "delete",
+		Entity: "replicationgroup",
+		Api:    "elasticache",
+		Params: new(DeleteReplicationgroup).ParamsSpec().Rule(),
+	},
 	"deleterepository": {
 		Action: "delete",
 		Entity: "repository",
 		Api:    "ecr",
 		Params: new(DeleteRepository).ParamsSpec().Rule(),
 	},
+	"deleterestapi": {
+		Action: "delete",
+		Entity: "restapi",
+		Api:    "apigateway",
+		Params: new(DeleteRestapi).ParamsSpec().Rule(),
+	},
 	"deleterole": {
 		Action: "delete",
 		Entity: "role",
@@ -854,12 +1128,24 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(DeleteSnapshot).ParamsSpec().Rule(),
 	},
+	"deletespotfleet": {
+		Action: "delete",
+		Entity: "spotfleet",
+		Api:    "ec2",
+		Params: new(DeleteSpotfleet).ParamsSpec().Rule(),
+	},
 	"deletestack": {
 		Action: "delete",
 		Entity: "stack",
 		Api:    "cloudformation",
 		Params: new(DeleteStack).ParamsSpec().Rule(),
 	},
+	"deletestatemachine": {
+		Action: "delete",
+		Entity: "statemachine",
+		Api:    "sfn",
+		Params: new(DeleteStatemachine).ParamsSpec().Rule(),
+	},
 	"deletesubnet": {
 		Action: "delete",
 		Entity: "subnet",
@@ -872,6 +1158,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "sns",
 		Params: new(DeleteSubscription).ParamsSpec().Rule(),
 	},
+	"deletetable": {
+		Action: "delete",
+		Entity: "table",
+		Api:    "dynamodb",
+		Params: new(DeleteTable).ParamsSpec().Rule(),
+	},
 	"deletetag": {
 		Action: "delete",
 		Entity: "tag",
@@ -890,6 +1182,18 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "sns",
 		Params: new(DeleteTopic).ParamsSpec().Rule(),
 	},
+	"deletetrafficpolicy": {
+		Action: "delete",
+		Entity: "trafficpolicy",
+		Api:    "route53",
+		Params: new(DeleteTrafficpolicy).ParamsSpec().Rule(),
+	},
+	"deletetrafficpolicyinstance": {
+		Action: "delete",
+		Entity: "trafficpolicyinstance",
+		Api:    "route53",
+		Params: new(DeleteTrafficpolicyinstance).ParamsSpec().Rule(),
+	},
 	"deleteuser": {
 		Action: "delete",
 		Entity: "user",
@@ -944,12 +1248,24 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(DetachInstanceprofile).ParamsSpec().Rule(),
 	},
+	"detachintegration": {
+		Action: "detach",
+		Entity: "integration",
+		Api:    "apigateway",
+		Params: new(DetachIntegration).ParamsSpec().Rule(),
+	},
 	"detachinternetgateway": {
 		Action: "detach",
 		Entity: "internetgateway",
 		Api:    "ec2",
 		Params: new(DetachInternetgateway).ParamsSpec().Rule(),
 	},
+	"detachkeygrant": {
+		Action: "detach",
+		Entity: "keygrant",
+		Api:    "kms",
+		Params: new(DetachKeygrant).ParamsSpec().Rule(),
+	},
 	"detachmfadevice": {
 		Action: "detach",
 		Entity: "mfadevice",
@@ -1040,6 +1356,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(StartInstance).ParamsSpec().Rule(),
 	},
+	"startstatemachine": {
+		Action: "start",
+		Entity: "statemachine",
+		Api:    "sfn",
+		Params: new(StartStatemachine).ParamsSpec().Rule(),
+	},
 	"stopalarm": {
 		Action: "stop",
 		Entity: "alarm",
@@ -1064,6 +1386,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(StopInstance).ParamsSpec().Rule(),
 	},
+	"syncs3object": {
+		Action: "sync",
+		Entity: "s3object",
+		Api:    "s3",
+		Params: new(SyncS3object).ParamsSpec().Rule(),
+	},
 	"updatebucket": {
 		Action: "update",
 		Entity: "bucket",
@@ -1082,6 +1410,18 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "cloudfront",
 		Params: new(UpdateDistribution).ParamsSpec().Rule(),
 	},
+	"updateeventsourcemapping": {
+		Action: "update",
+		Entity: "eventsourcemapping",
+		Api:    "lambda",
+		Params: new(UpdateEventsourcemapping).ParamsSpec().Rule(),
+	},
+	"updatefunctionalias": {
+		Action: "update",
+		Entity: "functionalias",
+		Api:    "lambda",
+		Params: new(UpdateFunctionalias).ParamsSpec().Rule(),
+	},
 	"updateimage": {
 		Action: "update",
 		Entity: "image",
@@ -1094,12 +1434,24 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(UpdateInstance).ParamsSpec().Rule(),
 	},
+	"updatelaunchtemplate": {
+		Action: "update",
+		Entity: "launchtemplate",
+		Api:    "ec2",
+		Params: new(UpdateLaunchtemplate).ParamsSpec().Rule(),
+	},
 	"updateloginprofile": {
 		Action: "update",
 		Entity: "loginprofile",
 		Api:    "iam",
 		Params: new(UpdateLoginprofile).ParamsSpec().Rule(),
 	},
+	"updateoriginaccessidentity": {
+		Action: "update",
+		Entity: "originaccessidentity",
+		Api:    "cloudfront",
+		Params: new(UpdateOriginaccessidentity).ParamsSpec().Rule(),
+	},
 	"updatepolicy": {
 		Action: "update",
 		Entity: "policy",
@@ -1130,6 +1482,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(UpdateSecuritygroup).ParamsSpec().Rule(),
 	},
+	"updatesnapshot": {
+		Action: "update",
+		Entity: "snapshot",
+		Api:    "ec2",
+		Params: new(UpdateSnapshot).ParamsSpec().Rule(),
+	},
 	"updatestack": {
 		Action: "update",
 		Entity: "stack",
@@ -1142,6 +1500,12 @@ var AWSTemplatesDefinitions = map[string]Definition{
 		Api:    "ec2",
 		Params: new(UpdateSubnet).ParamsSpec().Rule(),
 	},
+	"updatetable": {
+		Action: "update",
+		Entity: "table",
+		Api:    "dynamodb",
+		Params: new(UpdateTable).ParamsSpec().Rule(),
+	},
 	"updatetargetgroup": {
 		Action: "update",
 		Entity: "targetgroup",
@@ -1151,16 +1515,17 @@ var AWSTemplatesDefinitions = map[string]Definition{
 }
 
 var DriverSupportedActions = map[string][]string{
-	"attach":       {"alarm", "containertask", "elasticip", "instance", "instanceprofile", "internetgateway", "mfadevice", "networkinterface", "policy", "role", "routetable", "securitygroup", "user", "volume"},
+	"attach":       {"alarm", "containertask", "elasticip", "instance", "instanceprofile", "integration", "internetgateway", "keygrant", "mfadevice", "networkinterface", "policy", "role", "routetable", "securitygroup", "user", "volume"},
 	"authenticate": {"registry"},
 	"check":        {"certificate", "database", "distribution", "instance", "loadbalancer", "natgateway", "networkinterface", "scalinggroup", "securitygroup", "volume"},
 	"copy":         {"image", "snapshot"},
-	"create":       {"accesskey", "alarm", "appscalingpolicy", "appscalingtarget", "bucket", "certificate", "containercluster", "database", "dbsubnetgroup", "distribution", "elasticip", "function", "group", "image", "instance", "instanceprofile", "internetgateway", "keypair", "launchconfiguration", "listener", "loadbalancer", "loginprofile", "mfadevice", "natgateway", "networkinterface", "policy", "queue", "record", "repository", "role", "route", "routetable", "s3object", "scalinggroup", "scalingpolicy", "securitygroup", "snapshot", "stack", "subnet", "subscription", "tag", "targetgroup", "topic", "user", "volume", "vpc", "zone"},
-	"delete":       {"accesskey", "alarm", "appscalingpolicy", "appscalingtarget", "bucket", "certificate", "containercluster", "containertask", "database", "dbsubnetgroup", "distribution", "elasticip", "function", "group", "image", "instance", "instanceprofile", "internetgateway", "keypair", "launchconfiguration", "listener", "loadbalancer", "loginprofile", "mfadevice", "natgateway", "networkinterface", "policy", "queue", "record", "repository", "role", "route", "routetable", "s3object", "scalinggroup", "scalingpolicy", "securitygroup", "snapshot", "stack", "subnet", "subscription", "tag", "targetgroup", "topic", "user", "volume", "vpc", "zone"},
-	"detach":       {"alarm", "containertask", "elasticip", "instance", "instanceprofile", "internetgateway", "mfadevice", "networkinterface", "policy", "role", "routetable", "securitygroup", "user", "volume"},
+	"create":       {"accesskey", "alarm", "alias", "apideployment", "apimethod", "apiresource", "appscalingpolicy", "appscalingtarget", "bucket", "cachecluster", "certificate", "containercluster", "database", "dbsubnetgroup", "distribution", "elasticip", "eventsourcemapping", "function", "functionalias", "functionversion", "group", "healthcheck", "image", "instance", "instanceprofile", "internetgateway", "key", "keypair", "launchconfiguration", "launchtemplate", "listener", "loadbalancer", "loginprofile", "mfadevice", "natgateway", "networkinterface", "originaccessidentity", "policy", "presignedurl", "queue", "record", "replicationgroup", "repository", "restapi", "role", "route", "routetable", "s3object", "scalinggroup", "scalingpolicy", "securitygroup", "snapshot", "spotfleet", "stack", "statemachine", "subnet", "subscription", "table", "tag", "targetgroup", "topic", "trafficpolicy", "trafficpolicyinstance", "user", "volume", "vpc", "zone"},
+	"delete":       {"accesskey", "alarm", "alias", "apideployment", "apimethod", "apiresource", "apistage", "appscalingpolicy", "appscalingtarget", "bucket", "cachecluster", "certificate", "containercluster", "containertask", "database", "dbsubnetgroup", "distribution", "elasticip", "eventsourcemapping", "function", "functionalias", "functionversion", "group", "healthcheck", "image", "instance", "instanceprofile", "internetgateway", "key", "keypair", "launchconfiguration", "launchtemplate", "listener", "loadbalancer", "loginprofile", "mfadevice", "natgateway", "networkinterface", "originaccessidentity", "policy", "queue", "record", "replicationgroup", "repository", "restapi", "role", "route", "routetable", "s3object", "scalinggroup", "scalingpolicy", "securitygroup", "snapshot", "spotfleet", "stack", "statemachine", "subnet", "subscription", "table", "tag", "targetgroup", "topic", "trafficpolicy", "trafficpolicyinstance", "user", "volume", "vpc", "zone"},
+	"detach":       {"alarm", "containertask", "elasticip", "instance", "instanceprofile", "integration", "internetgateway", "keygrant", "mfadevice", "networkinterface", "policy", "role", "routetable", "securitygroup", "user", "volume"},
 	"import":       {"image"},
 	"restart":      {"database", "instance"},
-	"start":        {"alarm", "containertask", "database", "instance"},
+	"start":        {"alarm", "containertask", "database", "instance", "statemachine"},
 	"stop":         {"alarm", "containertask", "database", "instance"},
-	"update":       {"bucket", "containertask", "distribution", "image", "instance", "loginprofile", "policy", "record", "s3object", "scalinggroup", "securitygroup", "stack", "subnet", "targetgroup"},
+	"sync":         {"s3object"},
+	"update":       {"bucket", "containertask", "distribution", "eventsourcemapping", "functionalias", "image", "instance", "launchtemplate", "loginprofile", "originaccessidentity", "policy", "record", "s3object", "scalinggroup", "securitygroup", "snapshot", "stack", "subnet", "table", "targetgroup"},
 }
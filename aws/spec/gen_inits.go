@@ -53,8 +53,12 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewAttachInstance(f.Sess, f.Graph, f.Log) }
 	case "attachinstanceprofile":
 		return func() interface{} { return NewAttachInstanceprofile(f.Sess, f.Graph, f.Log) }
+	case "attachintegration":
+		return func() interface{} { return NewAttachIntegration(f.Sess, f.Graph, f.Log) }
 	case "attachinternetgateway":
 		return func() interface{} { return NewAttachInternetgateway(f.Sess, f.Graph, f.Log) }
+	case "attachkeygrant":
+		return func() interface{} { return NewAttachKeygrant(f.Sess, f.Graph, f.Log) }
 	case "attachmfadevice":
 		return func() interface{} { return NewAttachMfadevice(f.Sess, f.Graph, f.Log) }
 	case "attachnetworkinterface":
@@ -101,12 +105,22 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewCreateAccesskey(f.Sess, f.Graph, f.Log) }
 	case "createalarm":
 		return func() interface{} { return NewCreateAlarm(f.Sess, f.Graph, f.Log) }
+	case "createalias":
+		return func() interface{} { return NewCreateAlias(f.Sess, f.Graph, f.Log) }
+	case "createapideployment":
+		return func() interface{} { return NewCreateApideployment(f.Sess, f.Graph, f.Log) }
+	case "createapimethod":
+		return func() interface{} { return NewCreateApimethod(f.Sess, f.Graph, f.Log) }
+	case "createapiresource":
+		return func() interface{} { return NewCreateApiresource(f.Sess, f.Graph, f.Log) }
 	case "createappscalingpolicy":
 		return func() interface{} { return NewCreateAppscalingpolicy(f.Sess, f.Graph, f.Log) }
 	case "createappscalingtarget":
 		return func() interface{} { return NewCreateAppscalingtarget(f.Sess, f.Graph, f.Log) }
 	case "createbucket":
 		return func() interface{} { return NewCreateBucket(f.Sess, f.Graph, f.Log) }
+	case "createcachecluster":
+		return func() interface{} { return NewCreateCachecluster(f.Sess, f.Graph, f.Log) }
 	case "createcertificate":
 		return func() interface{} { return NewCreateCertificate(f.Sess, f.Graph, f.Log) }
 	case "createcontainercluster":
@@ -119,10 +133,18 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewCreateDistribution(f.Sess, f.Graph, f.Log) }
 	case "createelasticip":
 		return func() interface{} { return NewCreateElasticip(f.Sess, f.Graph, f.Log) }
+	case "createeventsourcemapping":
+		return func() interface{} { return NewCreateEventsourcemapping(f.Sess, f.Graph, f.Log) }
 	case "createfunction":
 		return func() interface{} { return NewCreateFunction(f.Sess, f.Graph, f.Log) }
+	case "createfunctionalias":
+		return func() interface{} { return NewCreateFunctionalias(f.Sess, f.Graph, f.Log) }
+	case "createfunctionversion":
+		return func() interface{} { return NewCreateFunctionversion(f.Sess, f.Graph, f.Log) }
 	case "creategroup":
 		return func() interface{} { return NewCreateGroup(f.Sess, f.Graph, f.Log) }
+	case "createhealthcheck":
+		return func() interface{} { return NewCreateHealthcheck(f.Sess, f.Graph, f.Log) }
 	case "createimage":
 		return func() interface{} { return NewCreateImage(f.Sess, f.Graph, f.Log) }
 	case "createinstance":
@@ -131,10 +153,14 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewCreateInstanceprofile(f.Sess, f.Graph, f.Log) }
 	case "createinternetgateway":
 		return func() interface{} { return NewCreateInternetgateway(f.Sess, f.Graph, f.Log) }
+	case "createkey":
+		return func() interface{} { return NewCreateKey(f.Sess, f.Graph, f.Log) }
 	case "createkeypair":
 		return func() interface{} { return NewCreateKeypair(f.Sess, f.Graph, f.Log) }
 	case "createlaunchconfiguration":
 		return func() interface{} { return NewCreateLaunchconfiguration(f.Sess, f.Graph, f.Log) }
+	case "createlaunchtemplate":
+		return func() interface{} { return NewCreateLaunchtemplate(f.Sess, f.Graph, f.Log) }
 	case "createlistener":
 		return func() interface{} { return NewCreateListener(f.Sess, f.Graph, f.Log) }
 	case "createloadbalancer":
@@ -147,14 +173,22 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewCreateNatgateway(f.Sess, f.Graph, f.Log) }
 	case "createnetworkinterface":
 		return func() interface{} { return NewCreateNetworkinterface(f.Sess, f.Graph, f.Log) }
+	case "createoriginaccessidentity":
+		return func() interface{} { return NewCreateOriginaccessidentity(f.Sess, f.Graph, f.Log) }
 	case "createpolicy":
 		return func() interface{} { return NewCreatePolicy(f.Sess, f.Graph, f.Log) }
+	case "createpresignedurl":
+		return func() interface{} { return NewCreatePresignedurl(f.Sess, f.Graph, f.Log) }
 	case "createqueue":
 		return func() interface{} { return NewCreateQueue(f.Sess, f.Graph, f.Log) }
 	case "createrecord":
 		return func() interface{} { return NewCreateRecord(f.Sess, f.Graph, f.Log) }
+	case "createreplicationgroup":
+		return func() interface{} { return NewCreateReplicationgroup(f.Sess, f.Graph, f.Log) }
 	case "createrepository":
 		return func() interface{} { return NewCreateRepository(f.Sess, f.Graph, f.Log) }
+	case "createrestapi":
+		return func() interface{} { return NewCreateRestapi(f.Sess, f.Graph, f.Log) }
 	case "createrole":
 		return func() interface{} { return NewCreateRole(f.Sess, f.Graph, f.Log) }
 	case "createroute":
@@ -171,18 +205,28 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewCreateSecuritygroup(f.Sess, f.Graph, f.Log) }
 	case "createsnapshot":
 		return func() interface{} { return NewCreateSnapshot(f.Sess, f.Graph, f.Log) }
+	case "createspotfleet":
+		return func() interface{} { return NewCreateSpotfleet(f.Sess, f.Graph, f.Log) }
 	case "createstack":
 		return func() interface{} { return NewCreateStack(f.Sess, f.Graph, f.Log) }
+	case "createstatemachine":
+		return func() interface{} { return NewCreateStatemachine(f.Sess, f.Graph, f.Log) }
 	case "createsubnet":
 		return func() interface{} { return NewCreateSubnet(f.Sess, f.Graph, f.Log) }
 	case "createsubscription":
 		return func() interface{} { return NewCreateSubscription(f.Sess, f.Graph, f.Log) }
+	case "createtable":
+		return func() interface{} { return NewCreateTable(f.Sess, f.Graph, f.Log) }
 	case "createtag":
 		return func() interface{} { return NewCreateTag(f.Sess, f.Graph, f.Log) }
 	case "createtargetgroup":
 		return func() interface{} { return NewCreateTargetgroup(f.Sess, f.Graph, f.Log) }
 	case "createtopic":
 		return func() interface{} { return NewCreateTopic(f.Sess, f.Graph, f.Log) }
+	case "createtrafficpolicy":
+		return func() interface{} { return NewCreateTrafficpolicy(f.Sess, f.Graph, f.Log) }
+	case "createtrafficpolicyinstance":
+		return func() interface{} { return NewCreateTrafficpolicyinstance(f.Sess, f.Graph, f.Log) }
 	case "createuser":
 		return func() interface{} { return NewCreateUser(f.Sess, f.Graph, f.Log) }
 	case "createvolume":
@@ -195,12 +239,24 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewDeleteAccesskey(f.Sess, f.Graph, f.Log) }
 	case "deletealarm":
 		return func() interface{} { return NewDeleteAlarm(f.Sess, f.Graph, f.Log) }
+	case "deletealias":
+		return func() interface{} { return NewDeleteAlias(f.Sess, f.Graph, f.Log) }
+	case "deleteapideployment":
+		return func() interface{} { return NewDeleteApideployment(f.Sess, f.Graph, f.Log) }
+	case "deleteapimethod":
+		return func() interface{} { return NewDeleteApimethod(f.Sess, f.Graph, f.Log) }
+	case "deleteapiresource":
+		return func() interface{} { return NewDeleteApiresource(f.Sess, f.Graph, f.Log) }
+	case "deleteapistage":
+		return func() interface{} { return NewDeleteApistage(f.Sess, f.Graph, f.Log) }
 	case "deleteappscalingpolicy":
 		return func() interface{} { return NewDeleteAppscalingpolicy(f.Sess, f.Graph, f.Log) }
 	case "deleteappscalingtarget":
 		return func() interface{} { return NewDeleteAppscalingtarget(f.Sess, f.Graph, f.Log) }
 	case "deletebucket":
 		return func() interface{} { return NewDeleteBucket(f.Sess, f.Graph, f.Log) }
+	case "deletecachecluster":
+		return func() interface{} { return NewDeleteCachecluster(f.Sess, f.Graph, f.Log) }
 	case "deletecertificate":
 		return func() interface{} { return NewDeleteCertificate(f.Sess, f.Graph, f.Log) }
 	case "deletecontainercluster":
@@ -215,10 +271,18 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewDeleteDistribution(f.Sess, f.Graph, f.Log) }
 	case "deleteelasticip":
 		return func() interface{} { return NewDeleteElasticip(f.Sess, f.Graph, f.Log) }
+	case "deleteeventsourcemapping":
+		return func() interface{} { return NewDeleteEventsourcemapping(f.Sess, f.Graph, f.Log) }
 	case "deletefunction":
 		return func() interface{} { return NewDeleteFunction(f.Sess, f.Graph, f.Log) }
+	case "deletefunctionalias":
+		return func() interface{} { return NewDeleteFunctionalias(f.Sess, f.Graph, f.Log) }
+	case "deletefunctionversion":
+		return func() interface{} { return NewDeleteFunctionversion(f.Sess, f.Graph, f.Log) }
 	case "deletegroup":
 		return func() interface{} { return NewDeleteGroup(f.Sess, f.Graph, f.Log) }
+	case "deletehealthcheck":
+		return func() interface{} { return NewDeleteHealthcheck(f.Sess, f.Graph, f.Log) }
 	case "deleteimage":
 		return func() interface{} { return NewDeleteImage(f.Sess, f.Graph, f.Log) }
 	case "deleteinstance":
@@ -227,10 +291,14 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewDeleteInstanceprofile(f.Sess, f.Graph, f.Log) }
 	case "deleteinternetgateway":
 		return func() interface{} { return NewDeleteInternetgateway(f.Sess, f.Graph, f.Log) }
+	case "deletekey":
+		return func() interface{} { return NewDeleteKey(f.Sess, f.Graph, f.Log) }
 	case "deletekeypair":
 		return func() interface{} { return NewDeleteKeypair(f.Sess, f.Graph, f.Log) }
 	case "deletelaunchconfiguration":
 		return func() interface{} { return NewDeleteLaunchconfiguration(f.Sess, f.Graph, f.Log) }
+	case "deletelaunchtemplate":
+		return func() interface{} { return NewDeleteLaunchtemplate(f.Sess, f.Graph, f.Log) }
 	case "deletelistener":
 		return func() interface{} { return NewDeleteListener(f.Sess, f.Graph, f.Log) }
 	case "deleteloadbalancer":
@@ -243,14 +311,20 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewDeleteNatgateway(f.Sess, f.Graph, f.Log) }
 	case "deletenetworkinterface":
 		return func() interface{} { return NewDeleteNetworkinterface(f.Sess, f.Graph, f.Log) }
+	case "deleteoriginaccessidentity":
+		return func() interface{} { return NewDeleteOriginaccessidentity(f.Sess, f.Graph, f.Log) }
 	case "deletepolicy":
 		return func() interface{} { return NewDeletePolicy(f.Sess, f.Graph, f.Log) }
 	case "deletequeue":
 		return func() interface{} { return NewDeleteQueue(f.Sess, f.Graph, f.Log) }
 	case "deleterecord":
 		return func() interface{} { return NewDeleteRecord(f.Sess, f.Graph, f.Log) }
+	case "deletereplicationgroup":
+		return func() interface{} { return NewDeleteReplicationgroup(f.Sess, f.Graph, f.Log) }
 	case "deleterepository":
 		return func() interface{} { return NewDeleteRepository(f.Sess, f.Graph, f.Log) }
+	case "deleterestapi":
+		return func() interface{} { return NewDeleteRestapi(f.Sess, f.Graph, f.Log) }
 	case "deleterole":
 		return func() interface{} { return NewDeleteRole(f.Sess, f.Graph, f.Log) }
 	case "deleteroute":
@@ -267,18 +341,28 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewDeleteSecuritygroup(f.Sess, f.Graph, f.Log) }
 	case "deletesnapshot":
 		return func() interface{} { return NewDeleteSnapshot(f.Sess, f.Graph, f.Log) }
+	case "deletespotfleet":
+		return func() interface{} { return NewDeleteSpotfleet(f.Sess, f.Graph, f.Log) }
 	case "deletestack":
 		return func() interface{} { return NewDeleteStack(f.Sess, f.Graph, f.Log) }
+	case "deletestatemachine":
+		return func() interface{} { return NewDeleteStatemachine(f.Sess, f.Graph, f.Log) }
 	case "deletesubnet":
 		return func() interface{} { return NewDeleteSubnet(f.Sess, f.Graph, f.Log) }
 	case "deletesubscription":
 		return func() interface{} { return NewDeleteSubscription(f.Sess, f.Graph, f.Log) }
+	case "deletetable":
+		return func() interface{} { return NewDeleteTable(f.Sess, f.Graph, f.Log) }
 	case "deletetag":
 		return func() interface{} { return NewDeleteTag(f.Sess, f.Graph, f.Log) }
 	case "deletetargetgroup":
 		return func() interface{} { return NewDeleteTargetgroup(f.Sess, f.Graph, f.Log) }
 	case "deletetopic":
 		return func() interface{} { return NewDeleteTopic(f.Sess, f.Graph, f.Log) }
+	case "deletetrafficpolicy":
+		return func() interface{} { return NewDeleteTrafficpolicy(f.Sess, f.Graph, f.Log) }
+	case "deletetrafficpolicyinstance":
+		return func() interface{} { return NewDeleteTrafficpolicyinstance(f.Sess, f.Graph, f.Log) }
 	case "deleteuser":
 		return func() interface{} { return NewDeleteUser(f.Sess, f.Graph, f.Log) }
 	case "deletevolume":
@@ -297,8 +381,12 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewDetachInstance(f.Sess, f.Graph, f.Log) }
 	case "detachinstanceprofile":
 		return func() interface{} { return NewDetachInstanceprofile(f.Sess, f.Graph, f.Log) }
+	case "detachintegration":
+		return func() interface{} { return NewDetachIntegration(f.Sess, f.Graph, f.Log) }
 	case "detachinternetgateway":
 		return func() interface{} { return NewDetachInternetgateway(f.Sess, f.Graph, f.Log) }
+	case "detachkeygrant":
+		return func() interface{} { return NewDetachKeygrant(f.Sess, f.Graph, f.Log) }
 	case "detachmfadevice":
 		return func() interface{} { return NewDetachMfadevice(f.Sess, f.Graph, f.Log) }
 	case "detachnetworkinterface":
@@ -329,6 +417,8 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewStartDatabase(f.Sess, f.Graph, f.Log) }
 	case "startinstance":
 		return func() interface{} { return NewStartInstance(f.Sess, f.Graph, f.Log) }
+	case "startstatemachine":
+		return func() interface{} { return NewStartStatemachine(f.Sess, f.Graph, f.Log) }
 	case "stopalarm":
 		return func() interface{} { return NewStopAlarm(f.Sess, f.Graph, f.Log) }
 	case "stopcontainertask":
@@ -337,18 +427,28 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewStopDatabase(f.Sess, f.Graph, f.Log) }
 	case "stopinstance":
 		return func() interface{} { return NewStopInstance(f.Sess, f.Graph, f.Log) }
+	case "syncs3object":
+		return func() interface{} { return NewSyncS3object(f.Sess, f.Graph, f.Log) }
 	case "updatebucket":
 		return func() interface{} { return NewUpdateBucket(f.Sess, f.Graph, f.Log) }
 	case "updatecontainertask":
 		return func() interface{} { return NewUpdateContainertask(f.Sess, f.Graph, f.Log) }
 	case "updatedistribution":
 		return func() interface{} { return NewUpdateDistribution(f.Sess, f.Graph, f.Log) }
+	case "updateeventsourcemapping":
+		return func() interface{} { return NewUpdateEventsourcemapping(f.Sess, f.Graph, f.Log) }
+	case "updatefunctionalias":
+		return func() interface{} { return NewUpdateFunctionalias(f.Sess, f.Graph, f.Log) }
 	case "updateimage":
 		return func() interface{} { return NewUpdateImage(f.Sess, f.Graph, f.Log) }
 	case "updateinstance":
 		return func() interface{} { return NewUpdateInstance(f.Sess, f.Graph, f.Log) }
+	case "updatelaunchtemplate":
+		return func() interface{} { return NewUpdateLaunchtemplate(f.Sess, f.Graph, f.Log) }
 	case "updateloginprofile":
 		return func() interface{} { return NewUpdateLoginprofile(f.Sess, f.Graph, f.Log) }
+	case "updateoriginaccessidentity":
+		return func() interface{} { return NewUpdateOriginaccessidentity(f.Sess, f.Graph, f.Log) }
 	case "updatepolicy":
 		return func() interface{} { return NewUpdatePolicy(f.Sess, f.Graph, f.Log) }
 	case "updaterecord":
@@ -359,10 +459,14 @@ func (f *AWSFactory) Build(key string) func() interface{} {
 		return func() interface{} { return NewUpdateScalinggroup(f.Sess, f.Graph, f.Log) }
 	case "updatesecuritygroup":
 		return func() interface{} { return NewUpdateSecuritygroup(f.Sess, f.Graph, f.Log) }
+	case "updatesnapshot":
+		return func() interface{} { return NewUpdateSnapshot(f.Sess, f.Graph, f.Log) }
 	case "updatestack":
 		return func() interface{} { return NewUpdateStack(f.Sess, f.Graph, f.Log) }
 	case "updatesubnet":
 		return func() interface{} { return NewUpdateSubnet(f.Sess, f.Graph, f.Log) }
+	case "updatetable":
+		return func() interface{} { return NewUpdateTable(f.Sess, f.Graph, f.Log) }
 	case "updatetargetgroup":
 		return func() interface{} { return NewUpdateTargetgroup(f.Sess, f.Graph, f.Log) }
 	}
@@ -376,6 +480,7 @@ var (
 	_ command = &AttachInstance{}
 	_ command = &AttachInstanceprofile{}
 	_ command = &AttachInternetgateway{}
+	_ command = &AttachKeygrant{}
 	_ command = &AttachMfadevice{}
 	_ command = &AttachNetworkinterface{}
 	_ command = &AttachPolicy{}
@@ -399,32 +504,43 @@ var (
 	_ command = &CopySnapshot{}
 	_ command = &CreateAccesskey{}
 	_ command = &CreateAlarm{}
+	_ command = &CreateAlias{}
 	_ command = &CreateAppscalingpolicy{}
 	_ command = &CreateAppscalingtarget{}
 	_ command = &CreateBucket{}
+	_ command = &CreateCachecluster{}
 	_ command = &CreateCertificate{}
 	_ command = &CreateContainercluster{}
 	_ command = &CreateDatabase{}
 	_ command = &CreateDbsubnetgroup{}
 	_ command = &CreateDistribution{}
 	_ command = &CreateElasticip{}
+	_ command = &CreateEventsourcemapping{}
 	_ command = &CreateFunction{}
+	_ command = &CreateFunctionalias{}
+	_ command = &CreateFunctionversion{}
 	_ command = &CreateGroup{}
+	_ command = &CreateHealthcheck{}
 	_ command = &CreateImage{}
 	_ command = &CreateInstance{}
 	_ command = &CreateInstanceprofile{}
 	_ command = &CreateInternetgateway{}
+	_ command = &CreateKey{}
 	_ command = &CreateKeypair{}
 	_ command = &CreateLaunchconfiguration{}
+	_ command = &CreateLaunchtemplate{}
 	_ command = &CreateListener{}
 	_ command = &CreateLoadbalancer{}
 	_ command = &CreateLoginprofile{}
 	_ command = &CreateMfadevice{}
 	_ command = &CreateNatgateway{}
 	_ command = &CreateNetworkinterface{}
+	_ command = &CreateOriginaccessidentity{}
 	_ command = &CreatePolicy{}
+	_ command = &CreatePresignedurl{}
 	_ command = &CreateQueue{}
 	_ command = &CreateRecord{}
+	_ command = &CreateReplicationgroup{}
 	_ command = &CreateRepository{}
 	_ command = &CreateRole{}
 	_ command = &CreateRoute{}
@@ -434,21 +550,28 @@ var (
 	_ command = &CreateScalingpolicy{}
 	_ command = &CreateSecuritygroup{}
 	_ command = &CreateSnapshot{}
+	_ command = &CreateSpotfleet{}
 	_ command = &CreateStack{}
+	_ command = &CreateStatemachine{}
 	_ command = &CreateSubnet{}
 	_ command = &CreateSubscription{}
+	_ command = &CreateTable{}
 	_ command = &CreateTag{}
 	_ command = &CreateTargetgroup{}
 	_ command = &CreateTopic{}
+	_ command = &CreateTrafficpolicy{}
+	_ command = &CreateTrafficpolicyinstance{}
 	_ command = &CreateUser{}
 	_ command = &CreateVolume{}
 	_ command = &CreateVpc{}
 	_ command = &CreateZone{}
 	_ command = &DeleteAccesskey{}
 	_ command = &DeleteAlarm{}
+	_ command = &DeleteAlias{}
 	_ command = &DeleteAppscalingpolicy{}
 	_ command = &DeleteAppscalingtarget{}
 	_ command = &DeleteBucket{}
+	_ command = &DeleteCachecluster{}
 	_ command = &DeleteCertificate{}
 	_ command = &DeleteContainercluster{}
 	_ command = &DeleteContainertask{}
@@ -456,23 +579,31 @@ var (
 	_ command = &DeleteDbsubnetgroup{}
 	_ command = &DeleteDistribution{}
 	_ command = &DeleteElasticip{}
+	_ command = &DeleteEventsourcemapping{}
 	_ command = &DeleteFunction{}
+	_ command = &DeleteFunctionalias{}
+	_ command = &DeleteFunctionversion{}
 	_ command = &DeleteGroup{}
+	_ command = &DeleteHealthcheck{}
 	_ command = &DeleteImage{}
 	_ command = &DeleteInstance{}
 	_ command = &DeleteInstanceprofile{}
 	_ command = &DeleteInternetgateway{}
+	_ command = &DeleteKey{}
 	_ command = &DeleteKeypair{}
 	_ command = &DeleteLaunchconfiguration{}
+	_ command = &DeleteLaunchtemplate{}
 	_ command = &DeleteListener{}
 	_ command = &DeleteLoadbalancer{}
 	_ command = &DeleteLoginprofile{}
 	_ command = &DeleteMfadevice{}
 	_ command = &DeleteNatgateway{}
 	_ command = &DeleteNetworkinterface{}
+	_ command = &DeleteOriginaccessidentity{}
 	_ command = &DeletePolicy{}
 	_ command = &DeleteQueue{}
 	_ command = &DeleteRecord{}
+	_ command = &DeleteReplicationgroup{}
 	_ command = &DeleteRepository{}
 	_ command = &DeleteRole{}
 	_ command = &DeleteRoute{}
@@ -482,12 +613,17 @@ var (
 	_ command = &DeleteScalingpolicy{}
 	_ command = &DeleteSecuritygroup{}
 	_ command = &DeleteSnapshot{}
+	_ command = &DeleteSpotfleet{}
 	_ command = &DeleteStack{}
+	_ command = &DeleteStatemachine{}
 	_ command = &DeleteSubnet{}
 	_ command = &DeleteSubscription{}
+	_ command = &DeleteTable{}
 	_ command = &DeleteTag{}
 	_ command = &DeleteTargetgroup{}
 	_ command = &DeleteTopic{}
+	_ command = &DeleteTrafficpolicy{}
+	_ command = &DeleteTrafficpolicyinstance{}
 	_ command = &DeleteUser{}
 	_ command = &DeleteVolume{}
 	_ command = &DeleteVpc{}
@@ -498,6 +634,7 @@ var (
 	_ command = &DetachInstance{}
 	_ command = &DetachInstanceprofile{}
 	_ command = &DetachInternetgateway{}
+	_ command = &DetachKeygrant{}
 	_ command = &DetachMfadevice{}
 	_ command = &DetachNetworkinterface{}
 	_ command = &DetachPolicy{}
@@ -513,22 +650,30 @@ var (
 	_ command = &StartContainertask{}
 	_ command = &StartDatabase{}
 	_ command = &StartInstance{}
+	_ command = &StartStatemachine{}
 	_ command = &StopAlarm{}
 	_ command = &StopContainertask{}
 	_ command = &StopDatabase{}
 	_ command = &StopInstance{}
+	_ command = &SyncS3object{}
 	_ command = &UpdateBucket{}
 	_ command = &UpdateContainertask{}
 	_ command = &UpdateDistribution{}
+	_ command = &UpdateEventsourcemapping{}
+	_ command = &UpdateFunctionalias{}
 	_ command = &UpdateImage{}
 	_ command = &UpdateInstance{}
+	_ command = &UpdateLaunchtemplate{}
 	_ command = &UpdateLoginprofile{}
+	_ command = &UpdateOriginaccessidentity{}
 	_ command = &UpdatePolicy{}
 	_ command = &UpdateRecord{}
 	_ command = &UpdateS3object{}
 	_ command = &UpdateScalinggroup{}
 	_ command = &UpdateSecuritygroup{}
+	_ command = &UpdateSnapshot{}
 	_ command = &UpdateStack{}
 	_ command = &UpdateSubnet{}
+	_ command = &UpdateTable{}
 	_ command = &UpdateTargetgroup{}
 )
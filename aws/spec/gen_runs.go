@@ -26,6 +26,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/acm"
 	"github.com/aws/aws-sdk-go/service/acm/acmiface"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling"
 	"github.com/aws/aws-sdk-go/service/applicationautoscaling/applicationautoscalingiface"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
@@ -36,16 +38,22 @@ import (
 	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/ecs/ecsiface"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/rds"
@@ -54,10 +62,13 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53/route53iface"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
 	"github.com/aws/aws-sdk-go/service/sns"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
+	"github.com/wallix/awless/aws/pool"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/template/env"
@@ -71,7 +82,7 @@ func NewAttachAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudwatch.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudwatch", func() interface{} { return cloudwatch.New(sess) }).(cloudwatchiface.CloudWatchAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -107,7 +118,7 @@ func (cmd *AttachAlarm) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach alarm: AWS command returned nil output")
 		}
@@ -144,7 +155,7 @@ func NewAttachContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -180,7 +191,7 @@ func (cmd *AttachContainertask) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach containertask: AWS command returned nil output")
 		}
@@ -217,7 +228,7 @@ func NewAttachElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Lo
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -259,7 +270,7 @@ func (cmd *AttachElasticip) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach elasticip: AWS command returned nil output")
 		}
@@ -285,6 +296,12 @@ func (cmd *AttachElasticip) dryRun(renv env.Running, params map[string]interface
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
 	input := &ec2.AssociateAddressInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
@@ -317,7 +334,7 @@ func NewAttachInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Log
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -359,7 +376,7 @@ func (cmd *AttachInstance) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach instance: AWS command returned nil output")
 		}
@@ -396,7 +413,7 @@ func NewAttachInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*log
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -432,7 +449,7 @@ func (cmd *AttachInstanceprofile) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach instanceprofile: AWS command returned nil output")
 		}
@@ -457,6 +474,79 @@ func (cmd *AttachInstanceprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func NewAttachIntegration(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachIntegration {
+	cmd := new(AttachIntegration)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *AttachIntegration) SetApi(api apigatewayiface.APIGatewayAPI) {
+	cmd.api = api
+}
+
+func (cmd *AttachIntegration) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *AttachIntegration) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("attach integration: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("attach integration '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("attach integration done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *AttachIntegration) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("integration"), nil
+}
+
+func (cmd *AttachIntegration) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
 func NewAttachInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachInternetgateway {
 	cmd := new(AttachInternetgateway)
 	if len(l) > 0 {
@@ -465,7 +555,7 @@ func NewAttachInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*log
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -507,7 +597,7 @@ func (cmd *AttachInternetgateway) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach internetgateway: AWS command returned nil output")
 		}
@@ -533,6 +623,12 @@ func (cmd *AttachInternetgateway) dryRun(renv env.Running, params map[string]int
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
 	input := &ec2.AttachInternetGatewayInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
@@ -557,6 +653,85 @@ func (cmd *AttachInternetgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func NewAttachKeygrant(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachKeygrant {
+	cmd := new(AttachKeygrant)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "kms", func() interface{} { return kms.New(sess) }).(kmsiface.KMSAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *AttachKeygrant) SetApi(api kmsiface.KMSAPI) {
+	cmd.api = api
+}
+
+func (cmd *AttachKeygrant) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *AttachKeygrant) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &kms.CreateGrantInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in kms.CreateGrantInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CreateGrant(input)
+	renv.Log().ExtraVerbosef("kms.CreateGrant call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("attach keygrant: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("attach keygrant '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("attach keygrant done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *AttachKeygrant) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("keygrant"), nil
+}
+
+func (cmd *AttachKeygrant) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
 func NewAttachMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *AttachMfadevice {
 	cmd := new(AttachMfadevice)
 	if len(l) > 0 {
@@ -565,7 +740,7 @@ func NewAttachMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Lo
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -607,7 +782,7 @@ func (cmd *AttachMfadevice) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach mfadevice: AWS command returned nil output")
 		}
@@ -644,7 +819,7 @@ func NewAttachNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*lo
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -686,7 +861,7 @@ func (cmd *AttachNetworkinterface) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach networkinterface: AWS command returned nil output")
 		}
@@ -712,6 +887,12 @@ func (cmd *AttachNetworkinterface) dryRun(renv env.Running, params map[string]in
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
 	input := &ec2.AttachNetworkInterfaceInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
@@ -744,7 +925,7 @@ func NewAttachPolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -780,7 +961,7 @@ func (cmd *AttachPolicy) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach policy: AWS command returned nil output")
 		}
@@ -817,7 +998,7 @@ func NewAttachRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger)
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -859,7 +1040,7 @@ func (cmd *AttachRole) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach role: AWS command returned nil output")
 		}
@@ -896,7 +1077,7 @@ func NewAttachRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.L
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -938,7 +1119,7 @@ func (cmd *AttachRoutetable) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach routetable: AWS command returned nil output")
 		}
@@ -964,6 +1145,12 @@ func (cmd *AttachRoutetable) dryRun(renv env.Running, params map[string]interfac
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
 	input := &ec2.AssociateRouteTableInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
@@ -996,7 +1183,7 @@ func NewAttachSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -1032,7 +1219,7 @@ func (cmd *AttachSecuritygroup) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach securitygroup: AWS command returned nil output")
 		}
@@ -1069,7 +1256,7 @@ func NewAttachUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger)
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -1111,7 +1298,7 @@ func (cmd *AttachUser) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach user: AWS command returned nil output")
 		}
@@ -1148,7 +1335,7 @@ func NewAttachVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -1190,7 +1377,7 @@ func (cmd *AttachVolume) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("attach volume: AWS command returned nil output")
 		}
@@ -1216,6 +1403,12 @@ func (cmd *AttachVolume) dryRun(renv env.Running, params map[string]interface{})
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
 	input := &ec2.AttachVolumeInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
@@ -1248,7 +1441,7 @@ func NewAuthenticateRegistry(sess *session.Session, g cloud.GraphAPI, l ...*logg
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecr.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecr", func() interface{} { return ecr.New(sess) }).(ecriface.ECRAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -1284,7 +1477,7 @@ func (cmd *AuthenticateRegistry) run(renv env.Running, params map[string]interfa
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("authenticate registry: AWS command returned nil output")
 		}
@@ -1321,7 +1514,7 @@ func NewCheckCertificate(sess *session.Session, g cloud.GraphAPI, l ...*logger.L
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = acm.New(sess)
+		cmd.api = awspool.Default.Client(sess, "acm", func() interface{} { return acm.New(sess) }).(acmiface.ACMAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -1357,7 +1550,7 @@ func (cmd *CheckCertificate) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check certificate: AWS command returned nil output")
 		}
@@ -1394,7 +1587,7 @@ func NewCheckDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logg
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = rds.New(sess)
+		cmd.api = awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -1430,7 +1623,7 @@ func (cmd *CheckDatabase) run(renv env.Running, params map[string]interface{}) (
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check database: AWS command returned nil output")
 		}
@@ -1467,7 +1660,7 @@ func NewCheckDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudfront.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudfront", func() interface{} { return cloudfront.New(sess) }).(cloudfrontiface.CloudFrontAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -1503,7 +1696,7 @@ func (cmd *CheckDistribution) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check distribution: AWS command returned nil output")
 		}
@@ -1540,7 +1733,7 @@ func NewCheckInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logg
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -1576,7 +1769,7 @@ func (cmd *CheckInstance) run(renv env.Running, params map[string]interface{}) (
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check instance: AWS command returned nil output")
 		}
@@ -1613,7 +1806,7 @@ func NewCheckLoadbalancer(sess *session.Session, g cloud.GraphAPI, l ...*logger.
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -1649,7 +1842,7 @@ func (cmd *CheckLoadbalancer) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check loadbalancer: AWS command returned nil output")
 		}
@@ -1686,7 +1879,7 @@ func NewCheckNatgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Lo
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -1722,7 +1915,7 @@ func (cmd *CheckNatgateway) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check natgateway: AWS command returned nil output")
 		}
@@ -1759,7 +1952,7 @@ func NewCheckNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*log
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -1795,7 +1988,7 @@ func (cmd *CheckNetworkinterface) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check networkinterface: AWS command returned nil output")
 		}
@@ -1832,7 +2025,7 @@ func NewCheckScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = autoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -1868,7 +2061,7 @@ func (cmd *CheckScalinggroup) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check scalinggroup: AWS command returned nil output")
 		}
@@ -1905,7 +2098,7 @@ func NewCheckSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -1941,7 +2134,7 @@ func (cmd *CheckSecuritygroup) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check securitygroup: AWS command returned nil output")
 		}
@@ -1978,7 +2171,7 @@ func NewCheckVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -2014,7 +2207,7 @@ func (cmd *CheckVolume) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("check volume: AWS command returned nil output")
 		}
@@ -2051,7 +2244,7 @@ func NewCopyImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger)
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -2093,7 +2286,7 @@ func (cmd *CopyImage) run(renv env.Running, params map[string]interface{}) (inte
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("copy image: AWS command returned nil output")
 		}
@@ -2119,6 +2312,12 @@ func (cmd *CopyImage) dryRun(renv env.Running, params map[string]interface{}) (i
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
 	input := &ec2.CopyImageInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
@@ -2151,7 +2350,7 @@ func NewCopySnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -2193,7 +2392,7 @@ func (cmd *CopySnapshot) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("copy snapshot: AWS command returned nil output")
 		}
@@ -2219,6 +2418,12 @@ func (cmd *CopySnapshot) dryRun(renv env.Running, params map[string]interface{})
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
 	input := &ec2.CopySnapshotInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
@@ -2251,7 +2456,7 @@ func NewCreateAccesskey(sess *session.Session, g cloud.GraphAPI, l ...*logger.Lo
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -2293,7 +2498,7 @@ func (cmd *CreateAccesskey) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("create accesskey: AWS command returned nil output")
 		}
@@ -2330,7 +2535,7 @@ func NewCreateAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudwatch.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudwatch", func() interface{} { return cloudwatch.New(sess) }).(cloudwatchiface.CloudWatchAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -2372,7 +2577,7 @@ func (cmd *CreateAlarm) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("create alarm: AWS command returned nil output")
 		}
@@ -2401,32 +2606,32 @@ func (cmd *CreateAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateAppscalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAppscalingpolicy {
-	cmd := new(CreateAppscalingpolicy)
+func NewCreateAlias(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAlias {
+	cmd := new(CreateAlias)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = applicationautoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "kms", func() interface{} { return kms.New(sess) }).(kmsiface.KMSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateAppscalingpolicy) SetApi(api applicationautoscalingiface.ApplicationAutoScalingAPI) {
+func (cmd *CreateAlias) SetApi(api kmsiface.KMSAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateAppscalingpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateAlias) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateAppscalingpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateAlias) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -2437,13 +2642,13 @@ func (cmd *CreateAppscalingpolicy) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	input := &applicationautoscaling.PutScalingPolicyInput{}
+	input := &kms.CreateAliasInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in applicationautoscaling.PutScalingPolicyInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in kms.CreateAliasInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.PutScalingPolicy(input)
-	renv.Log().ExtraVerbosef("applicationautoscaling.PutScalingPolicy call took %s", time.Since(start))
+	output, err := cmd.api.CreateAlias(input)
+	renv.Log().ExtraVerbosef("kms.CreateAlias call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -2451,16 +2656,16 @@ func (cmd *CreateAppscalingpolicy) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create appscalingpolicy: AWS command returned nil output")
+			renv.Log().Warning("create alias: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create appscalingpolicy '%s' done", extracted)
+		renv.Log().Verbosef("create alias '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create appscalingpolicy done")
+		renv.Log().Verbose("create alias done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -2472,40 +2677,40 @@ func (cmd *CreateAppscalingpolicy) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *CreateAppscalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("appscalingpolicy"), nil
+func (cmd *CreateAlias) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("alias"), nil
 }
 
-func (cmd *CreateAppscalingpolicy) inject(params map[string]interface{}) error {
+func (cmd *CreateAlias) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateAppscalingtarget(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAppscalingtarget {
-	cmd := new(CreateAppscalingtarget)
+func NewCreateApideployment(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateApideployment {
+	cmd := new(CreateApideployment)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = applicationautoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateAppscalingtarget) SetApi(api applicationautoscalingiface.ApplicationAutoScalingAPI) {
+func (cmd *CreateApideployment) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateAppscalingtarget) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateApideployment) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateAppscalingtarget) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateApideployment) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -2516,13 +2721,13 @@ func (cmd *CreateAppscalingtarget) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	input := &applicationautoscaling.RegisterScalableTargetInput{}
+	input := &apigateway.CreateDeploymentInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in applicationautoscaling.RegisterScalableTargetInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in apigateway.CreateDeploymentInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.RegisterScalableTarget(input)
-	renv.Log().ExtraVerbosef("applicationautoscaling.RegisterScalableTarget call took %s", time.Since(start))
+	output, err := cmd.api.CreateDeployment(input)
+	renv.Log().ExtraVerbosef("apigateway.CreateDeployment call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -2530,16 +2735,16 @@ func (cmd *CreateAppscalingtarget) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create appscalingtarget: AWS command returned nil output")
+			renv.Log().Warning("create apideployment: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create appscalingtarget '%s' done", extracted)
+		renv.Log().Verbosef("create apideployment '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create appscalingtarget done")
+		renv.Log().Verbose("create apideployment done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -2551,40 +2756,40 @@ func (cmd *CreateAppscalingtarget) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *CreateAppscalingtarget) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("appscalingtarget"), nil
+func (cmd *CreateApideployment) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("apideployment"), nil
 }
 
-func (cmd *CreateAppscalingtarget) inject(params map[string]interface{}) error {
+func (cmd *CreateApideployment) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateBucket {
-	cmd := new(CreateBucket)
+func NewCreateApimethod(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateApimethod {
+	cmd := new(CreateApimethod)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = s3.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateBucket) SetApi(api s3iface.S3API) {
+func (cmd *CreateApimethod) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateBucket) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateApimethod) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateBucket) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateApimethod) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -2595,13 +2800,13 @@ func (cmd *CreateBucket) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &s3.CreateBucketInput{}
+	input := &apigateway.PutMethodInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in s3.CreateBucketInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in apigateway.PutMethodInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateBucket(input)
-	renv.Log().ExtraVerbosef("s3.CreateBucket call took %s", time.Since(start))
+	output, err := cmd.api.PutMethod(input)
+	renv.Log().ExtraVerbosef("apigateway.PutMethod call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -2609,16 +2814,16 @@ func (cmd *CreateBucket) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create bucket: AWS command returned nil output")
+			renv.Log().Warning("create apimethod: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create bucket '%s' done", extracted)
+		renv.Log().Verbosef("create apimethod '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create bucket done")
+		renv.Log().Verbose("create apimethod done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -2630,40 +2835,40 @@ func (cmd *CreateBucket) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *CreateBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("bucket"), nil
+func (cmd *CreateApimethod) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("apimethod"), nil
 }
 
-func (cmd *CreateBucket) inject(params map[string]interface{}) error {
+func (cmd *CreateApimethod) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateCertificate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateCertificate {
-	cmd := new(CreateCertificate)
+func NewCreateApiresource(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateApiresource {
+	cmd := new(CreateApiresource)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = acm.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateCertificate) SetApi(api acmiface.ACMAPI) {
+func (cmd *CreateApiresource) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateCertificate) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateApiresource) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateCertificate) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateApiresource) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -2674,7 +2879,13 @@ func (cmd *CreateCertificate) run(renv env.Running, params map[string]interface{
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &apigateway.CreateResourceInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in apigateway.CreateResourceInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CreateResource(input)
+	renv.Log().ExtraVerbosef("apigateway.CreateResource call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -2682,16 +2893,16 @@ func (cmd *CreateCertificate) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create certificate: AWS command returned nil output")
+			renv.Log().Warning("create apiresource: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create certificate '%s' done", extracted)
+		renv.Log().Verbosef("create apiresource '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create certificate done")
+		renv.Log().Verbose("create apiresource done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -2703,40 +2914,40 @@ func (cmd *CreateCertificate) run(renv env.Running, params map[string]interface{
 	return extracted, nil
 }
 
-func (cmd *CreateCertificate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("certificate"), nil
+func (cmd *CreateApiresource) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("apiresource"), nil
 }
 
-func (cmd *CreateCertificate) inject(params map[string]interface{}) error {
+func (cmd *CreateApiresource) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateContainercluster(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateContainercluster {
-	cmd := new(CreateContainercluster)
+func NewCreateAppscalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAppscalingpolicy {
+	cmd := new(CreateAppscalingpolicy)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "applicationautoscaling", func() interface{} { return applicationautoscaling.New(sess) }).(applicationautoscalingiface.ApplicationAutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateContainercluster) SetApi(api ecsiface.ECSAPI) {
+func (cmd *CreateAppscalingpolicy) SetApi(api applicationautoscalingiface.ApplicationAutoScalingAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateContainercluster) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateAppscalingpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateContainercluster) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateAppscalingpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -2747,13 +2958,13 @@ func (cmd *CreateContainercluster) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	input := &ecs.CreateClusterInput{}
+	input := &applicationautoscaling.PutScalingPolicyInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ecs.CreateClusterInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in applicationautoscaling.PutScalingPolicyInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateCluster(input)
-	renv.Log().ExtraVerbosef("ecs.CreateCluster call took %s", time.Since(start))
+	output, err := cmd.api.PutScalingPolicy(input)
+	renv.Log().ExtraVerbosef("applicationautoscaling.PutScalingPolicy call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -2761,16 +2972,16 @@ func (cmd *CreateContainercluster) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create containercluster: AWS command returned nil output")
+			renv.Log().Warning("create appscalingpolicy: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create containercluster '%s' done", extracted)
+		renv.Log().Verbosef("create appscalingpolicy '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create containercluster done")
+		renv.Log().Verbose("create appscalingpolicy done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -2782,40 +2993,40 @@ func (cmd *CreateContainercluster) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *CreateContainercluster) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containercluster"), nil
+func (cmd *CreateAppscalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("appscalingpolicy"), nil
 }
 
-func (cmd *CreateContainercluster) inject(params map[string]interface{}) error {
+func (cmd *CreateAppscalingpolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDatabase {
-	cmd := new(CreateDatabase)
+func NewCreateAppscalingtarget(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateAppscalingtarget {
+	cmd := new(CreateAppscalingtarget)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = rds.New(sess)
+		cmd.api = awspool.Default.Client(sess, "applicationautoscaling", func() interface{} { return applicationautoscaling.New(sess) }).(applicationautoscalingiface.ApplicationAutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateDatabase) SetApi(api rdsiface.RDSAPI) {
+func (cmd *CreateAppscalingtarget) SetApi(api applicationautoscalingiface.ApplicationAutoScalingAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateAppscalingtarget) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateAppscalingtarget) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -2826,7 +3037,13 @@ func (cmd *CreateDatabase) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &applicationautoscaling.RegisterScalableTargetInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in applicationautoscaling.RegisterScalableTargetInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.RegisterScalableTarget(input)
+	renv.Log().ExtraVerbosef("applicationautoscaling.RegisterScalableTarget call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -2834,16 +3051,16 @@ func (cmd *CreateDatabase) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create database: AWS command returned nil output")
+			renv.Log().Warning("create appscalingtarget: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create database '%s' done", extracted)
+		renv.Log().Verbosef("create appscalingtarget '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create database done")
+		renv.Log().Verbose("create appscalingtarget done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -2855,40 +3072,40 @@ func (cmd *CreateDatabase) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *CreateDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+func (cmd *CreateAppscalingtarget) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("appscalingtarget"), nil
 }
 
-func (cmd *CreateDatabase) inject(params map[string]interface{}) error {
+func (cmd *CreateAppscalingtarget) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateDbsubnetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDbsubnetgroup {
-	cmd := new(CreateDbsubnetgroup)
+func NewCreateBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateBucket {
+	cmd := new(CreateBucket)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = rds.New(sess)
+		cmd.api = awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateDbsubnetgroup) SetApi(api rdsiface.RDSAPI) {
+func (cmd *CreateBucket) SetApi(api s3iface.S3API) {
 	cmd.api = api
 }
 
-func (cmd *CreateDbsubnetgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateBucket) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateDbsubnetgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateBucket) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -2899,13 +3116,13 @@ func (cmd *CreateDbsubnetgroup) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	input := &rds.CreateDBSubnetGroupInput{}
+	input := &s3.CreateBucketInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in rds.CreateDBSubnetGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in s3.CreateBucketInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateDBSubnetGroup(input)
-	renv.Log().ExtraVerbosef("rds.CreateDBSubnetGroup call took %s", time.Since(start))
+	output, err := cmd.api.CreateBucket(input)
+	renv.Log().ExtraVerbosef("s3.CreateBucket call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -2913,16 +3130,16 @@ func (cmd *CreateDbsubnetgroup) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create dbsubnetgroup: AWS command returned nil output")
+			renv.Log().Warning("create bucket: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create dbsubnetgroup '%s' done", extracted)
+		renv.Log().Verbosef("create bucket '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create dbsubnetgroup done")
+		renv.Log().Verbose("create bucket done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -2934,40 +3151,40 @@ func (cmd *CreateDbsubnetgroup) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *CreateDbsubnetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("dbsubnetgroup"), nil
+func (cmd *CreateBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("bucket"), nil
 }
 
-func (cmd *CreateDbsubnetgroup) inject(params map[string]interface{}) error {
+func (cmd *CreateBucket) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDistribution {
-	cmd := new(CreateDistribution)
+func NewCreateCachecluster(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateCachecluster {
+	cmd := new(CreateCachecluster)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudfront.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elasticache", func() interface{} { return elasticache.New(sess) }).(elasticacheiface.ElastiCacheAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateDistribution) SetApi(api cloudfrontiface.CloudFrontAPI) {
+func (cmd *CreateCachecluster) SetApi(api elasticacheiface.ElastiCacheAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateDistribution) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateCachecluster) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateDistribution) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateCachecluster) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -2978,7 +3195,13 @@ func (cmd *CreateDistribution) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &elasticache.CreateCacheClusterInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in elasticache.CreateCacheClusterInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CreateCacheCluster(input)
+	renv.Log().ExtraVerbosef("elasticache.CreateCacheCluster call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -2986,16 +3209,16 @@ func (cmd *CreateDistribution) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create distribution: AWS command returned nil output")
+			renv.Log().Warning("create cachecluster: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create distribution '%s' done", extracted)
+		renv.Log().Verbosef("create cachecluster '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create distribution done")
+		renv.Log().Verbose("create cachecluster done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3007,40 +3230,40 @@ func (cmd *CreateDistribution) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *CreateDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("distribution"), nil
+func (cmd *CreateCachecluster) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("cachecluster"), nil
 }
 
-func (cmd *CreateDistribution) inject(params map[string]interface{}) error {
+func (cmd *CreateCachecluster) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateElasticip {
-	cmd := new(CreateElasticip)
+func NewCreateCertificate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateCertificate {
+	cmd := new(CreateCertificate)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "acm", func() interface{} { return acm.New(sess) }).(acmiface.ACMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateElasticip) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateCertificate) SetApi(api acmiface.ACMAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateElasticip) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateCertificate) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateElasticip) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateCertificate) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3051,13 +3274,7 @@ func (cmd *CreateElasticip) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.AllocateAddressInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.AllocateAddressInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.AllocateAddress(input)
-	renv.Log().ExtraVerbosef("ec2.AllocateAddress call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3065,16 +3282,16 @@ func (cmd *CreateElasticip) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create elasticip: AWS command returned nil output")
+			renv.Log().Warning("create certificate: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create elasticip '%s' done", extracted)
+		renv.Log().Verbosef("create certificate '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create elasticip done")
+		renv.Log().Verbose("create certificate done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3086,61 +3303,40 @@ func (cmd *CreateElasticip) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *CreateElasticip) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.AllocateAddressInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.AllocateAddressInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.AllocateAddress(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.AllocateAddress call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create elasticip ok")
-			return fakeDryRunId("elasticip"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreateCertificate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("certificate"), nil
 }
 
-func (cmd *CreateElasticip) inject(params map[string]interface{}) error {
+func (cmd *CreateCertificate) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateFunction(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateFunction {
-	cmd := new(CreateFunction)
+func NewCreateContainercluster(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateContainercluster {
+	cmd := new(CreateContainercluster)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = lambda.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateFunction) SetApi(api lambdaiface.LambdaAPI) {
+func (cmd *CreateContainercluster) SetApi(api ecsiface.ECSAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateFunction) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateContainercluster) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateFunction) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateContainercluster) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3151,13 +3347,13 @@ func (cmd *CreateFunction) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &lambda.CreateFunctionInput{}
+	input := &ecs.CreateClusterInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in lambda.CreateFunctionInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ecs.CreateClusterInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateFunction(input)
-	renv.Log().ExtraVerbosef("lambda.CreateFunction call took %s", time.Since(start))
+	output, err := cmd.api.CreateCluster(input)
+	renv.Log().ExtraVerbosef("ecs.CreateCluster call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3165,16 +3361,16 @@ func (cmd *CreateFunction) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create function: AWS command returned nil output")
+			renv.Log().Warning("create containercluster: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create function '%s' done", extracted)
+		renv.Log().Verbosef("create containercluster '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create function done")
+		renv.Log().Verbose("create containercluster done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3186,40 +3382,40 @@ func (cmd *CreateFunction) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *CreateFunction) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("function"), nil
+func (cmd *CreateContainercluster) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("containercluster"), nil
 }
 
-func (cmd *CreateFunction) inject(params map[string]interface{}) error {
+func (cmd *CreateContainercluster) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateGroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateGroup {
-	cmd := new(CreateGroup)
+func NewCreateDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDatabase {
+	cmd := new(CreateDatabase)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateGroup) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateDatabase) SetApi(api rdsiface.RDSAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateGroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateGroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3230,13 +3426,7 @@ func (cmd *CreateGroup) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &iam.CreateGroupInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.CreateGroupInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.CreateGroup(input)
-	renv.Log().ExtraVerbosef("iam.CreateGroup call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3244,16 +3434,16 @@ func (cmd *CreateGroup) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create group: AWS command returned nil output")
+			renv.Log().Warning("create database: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create group '%s' done", extracted)
+		renv.Log().Verbosef("create database '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create group done")
+		renv.Log().Verbose("create database done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3265,40 +3455,40 @@ func (cmd *CreateGroup) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *CreateGroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("group"), nil
+func (cmd *CreateDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("database"), nil
 }
 
-func (cmd *CreateGroup) inject(params map[string]interface{}) error {
+func (cmd *CreateDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateImage {
-	cmd := new(CreateImage)
+func NewCreateDbsubnetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDbsubnetgroup {
+	cmd := new(CreateDbsubnetgroup)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateImage) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateDbsubnetgroup) SetApi(api rdsiface.RDSAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateImage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateDbsubnetgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateImage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateDbsubnetgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3309,13 +3499,13 @@ func (cmd *CreateImage) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &ec2.CreateImageInput{}
+	input := &rds.CreateDBSubnetGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateImageInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in rds.CreateDBSubnetGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateImage(input)
-	renv.Log().ExtraVerbosef("ec2.CreateImage call took %s", time.Since(start))
+	output, err := cmd.api.CreateDBSubnetGroup(input)
+	renv.Log().ExtraVerbosef("rds.CreateDBSubnetGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3323,16 +3513,16 @@ func (cmd *CreateImage) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create image: AWS command returned nil output")
+			renv.Log().Warning("create dbsubnetgroup: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create image '%s' done", extracted)
+		renv.Log().Verbosef("create dbsubnetgroup '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create image done")
+		renv.Log().Verbose("create dbsubnetgroup done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3344,61 +3534,40 @@ func (cmd *CreateImage) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *CreateImage) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.CreateImageInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateImageInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.CreateImage(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateImage call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create image ok")
-			return fakeDryRunId("image"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreateDbsubnetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("dbsubnetgroup"), nil
 }
 
-func (cmd *CreateImage) inject(params map[string]interface{}) error {
+func (cmd *CreateDbsubnetgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInstance {
-	cmd := new(CreateInstance)
+func NewCreateDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateDistribution {
+	cmd := new(CreateDistribution)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudfront", func() interface{} { return cloudfront.New(sess) }).(cloudfrontiface.CloudFrontAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateInstance) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateDistribution) SetApi(api cloudfrontiface.CloudFrontAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateDistribution) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateDistribution) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3409,13 +3578,7 @@ func (cmd *CreateInstance) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.RunInstancesInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.RunInstancesInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.RunInstances(input)
-	renv.Log().ExtraVerbosef("ec2.RunInstances call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3423,16 +3586,16 @@ func (cmd *CreateInstance) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create instance: AWS command returned nil output")
+			renv.Log().Warning("create distribution: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create instance '%s' done", extracted)
+		renv.Log().Verbosef("create distribution '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create instance done")
+		renv.Log().Verbose("create distribution done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3444,61 +3607,40 @@ func (cmd *CreateInstance) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *CreateInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.RunInstancesInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.RunInstancesInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.RunInstances(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.RunInstances call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create instance ok")
-			return fakeDryRunId("instance"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreateDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("distribution"), nil
 }
 
-func (cmd *CreateInstance) inject(params map[string]interface{}) error {
+func (cmd *CreateDistribution) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInstanceprofile {
-	cmd := new(CreateInstanceprofile)
+func NewCreateElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateElasticip {
+	cmd := new(CreateElasticip)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateInstanceprofile) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateElasticip) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateInstanceprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateElasticip) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateInstanceprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateElasticip) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3509,13 +3651,7 @@ func (cmd *CreateInstanceprofile) run(renv env.Running, params map[string]interf
 		}
 	}
 
-	input := &iam.CreateInstanceProfileInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.CreateInstanceProfileInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.CreateInstanceProfile(input)
-	renv.Log().ExtraVerbosef("iam.CreateInstanceProfile call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3523,16 +3659,16 @@ func (cmd *CreateInstanceprofile) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create instanceprofile: AWS command returned nil output")
+			renv.Log().Warning("create elasticip: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create instanceprofile '%s' done", extracted)
+		renv.Log().Verbosef("create elasticip '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create instanceprofile done")
+		renv.Log().Verbose("create elasticip done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3544,40 +3680,36 @@ func (cmd *CreateInstanceprofile) run(renv env.Running, params map[string]interf
 	return extracted, nil
 }
 
-func (cmd *CreateInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instanceprofile"), nil
-}
-
-func (cmd *CreateInstanceprofile) inject(params map[string]interface{}) error {
+func (cmd *CreateElasticip) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInternetgateway {
-	cmd := new(CreateInternetgateway)
+func NewCreateEventsourcemapping(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateEventsourcemapping {
+	cmd := new(CreateEventsourcemapping)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateInternetgateway) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateEventsourcemapping) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateInternetgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateEventsourcemapping) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateInternetgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateEventsourcemapping) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3588,13 +3720,13 @@ func (cmd *CreateInternetgateway) run(renv env.Running, params map[string]interf
 		}
 	}
 
-	input := &ec2.CreateInternetGatewayInput{}
+	input := &lambda.CreateEventSourceMappingInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateInternetGatewayInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in lambda.CreateEventSourceMappingInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateInternetGateway(input)
-	renv.Log().ExtraVerbosef("ec2.CreateInternetGateway call took %s", time.Since(start))
+	output, err := cmd.api.CreateEventSourceMapping(input)
+	renv.Log().ExtraVerbosef("lambda.CreateEventSourceMapping call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3602,16 +3734,16 @@ func (cmd *CreateInternetgateway) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create internetgateway: AWS command returned nil output")
+			renv.Log().Warning("create eventsourcemapping: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create internetgateway '%s' done", extracted)
+		renv.Log().Verbosef("create eventsourcemapping '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create internetgateway done")
+		renv.Log().Verbose("create eventsourcemapping done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3623,61 +3755,40 @@ func (cmd *CreateInternetgateway) run(renv env.Running, params map[string]interf
 	return extracted, nil
 }
 
-func (cmd *CreateInternetgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.CreateInternetGatewayInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateInternetGatewayInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.CreateInternetGateway(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateInternetGateway call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create internetgateway ok")
-			return fakeDryRunId("internetgateway"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreateEventsourcemapping) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("eventsourcemapping"), nil
 }
 
-func (cmd *CreateInternetgateway) inject(params map[string]interface{}) error {
+func (cmd *CreateEventsourcemapping) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateKeypair(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateKeypair {
-	cmd := new(CreateKeypair)
+func NewCreateFunction(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateFunction {
+	cmd := new(CreateFunction)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateKeypair) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateFunction) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateKeypair) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateFunction) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateKeypair) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateFunction) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3688,13 +3799,13 @@ func (cmd *CreateKeypair) run(renv env.Running, params map[string]interface{}) (
 		}
 	}
 
-	input := &ec2.ImportKeyPairInput{}
+	input := &lambda.CreateFunctionInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.ImportKeyPairInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in lambda.CreateFunctionInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.ImportKeyPair(input)
-	renv.Log().ExtraVerbosef("ec2.ImportKeyPair call took %s", time.Since(start))
+	output, err := cmd.api.CreateFunction(input)
+	renv.Log().ExtraVerbosef("lambda.CreateFunction call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3702,16 +3813,16 @@ func (cmd *CreateKeypair) run(renv env.Running, params map[string]interface{}) (
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create keypair: AWS command returned nil output")
+			renv.Log().Warning("create function: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create keypair '%s' done", extracted)
+		renv.Log().Verbosef("create function '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create keypair done")
+		renv.Log().Verbose("create function done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3723,40 +3834,40 @@ func (cmd *CreateKeypair) run(renv env.Running, params map[string]interface{}) (
 	return extracted, nil
 }
 
-func (cmd *CreateKeypair) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("keypair"), nil
+func (cmd *CreateFunction) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("function"), nil
 }
 
-func (cmd *CreateKeypair) inject(params map[string]interface{}) error {
+func (cmd *CreateFunction) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateLaunchconfiguration(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLaunchconfiguration {
-	cmd := new(CreateLaunchconfiguration)
+func NewCreateFunctionalias(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateFunctionalias {
+	cmd := new(CreateFunctionalias)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = autoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateLaunchconfiguration) SetApi(api autoscalingiface.AutoScalingAPI) {
+func (cmd *CreateFunctionalias) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateLaunchconfiguration) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateFunctionalias) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateLaunchconfiguration) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateFunctionalias) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3767,13 +3878,13 @@ func (cmd *CreateLaunchconfiguration) run(renv env.Running, params map[string]in
 		}
 	}
 
-	input := &autoscaling.CreateLaunchConfigurationInput{}
+	input := &lambda.CreateAliasInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in autoscaling.CreateLaunchConfigurationInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in lambda.CreateAliasInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateLaunchConfiguration(input)
-	renv.Log().ExtraVerbosef("autoscaling.CreateLaunchConfiguration call took %s", time.Since(start))
+	output, err := cmd.api.CreateAlias(input)
+	renv.Log().ExtraVerbosef("lambda.CreateAlias call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3781,16 +3892,16 @@ func (cmd *CreateLaunchconfiguration) run(renv env.Running, params map[string]in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create launchconfiguration: AWS command returned nil output")
+			renv.Log().Warning("create functionalias: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create launchconfiguration '%s' done", extracted)
+		renv.Log().Verbosef("create functionalias '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create launchconfiguration done")
+		renv.Log().Verbose("create functionalias done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3802,40 +3913,40 @@ func (cmd *CreateLaunchconfiguration) run(renv env.Running, params map[string]in
 	return extracted, nil
 }
 
-func (cmd *CreateLaunchconfiguration) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("launchconfiguration"), nil
+func (cmd *CreateFunctionalias) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("functionalias"), nil
 }
 
-func (cmd *CreateLaunchconfiguration) inject(params map[string]interface{}) error {
+func (cmd *CreateFunctionalias) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateListener(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateListener {
-	cmd := new(CreateListener)
+func NewCreateFunctionversion(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateFunctionversion {
+	cmd := new(CreateFunctionversion)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateListener) SetApi(api elbv2iface.ELBV2API) {
+func (cmd *CreateFunctionversion) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateListener) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateFunctionversion) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateListener) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateFunctionversion) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3846,13 +3957,13 @@ func (cmd *CreateListener) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &elbv2.CreateListenerInput{}
+	input := &lambda.PublishVersionInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in elbv2.CreateListenerInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in lambda.PublishVersionInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateListener(input)
-	renv.Log().ExtraVerbosef("elbv2.CreateListener call took %s", time.Since(start))
+	output, err := cmd.api.PublishVersion(input)
+	renv.Log().ExtraVerbosef("lambda.PublishVersion call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3860,16 +3971,16 @@ func (cmd *CreateListener) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create listener: AWS command returned nil output")
+			renv.Log().Warning("create functionversion: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create listener '%s' done", extracted)
+		renv.Log().Verbosef("create functionversion '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create listener done")
+		renv.Log().Verbose("create functionversion done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3881,40 +3992,40 @@ func (cmd *CreateListener) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *CreateListener) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("listener"), nil
+func (cmd *CreateFunctionversion) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("functionversion"), nil
 }
 
-func (cmd *CreateListener) inject(params map[string]interface{}) error {
+func (cmd *CreateFunctionversion) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateLoadbalancer(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLoadbalancer {
-	cmd := new(CreateLoadbalancer)
+func NewCreateGroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateGroup {
+	cmd := new(CreateGroup)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateLoadbalancer) SetApi(api elbv2iface.ELBV2API) {
+func (cmd *CreateGroup) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateLoadbalancer) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateGroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateLoadbalancer) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateGroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -3925,13 +4036,13 @@ func (cmd *CreateLoadbalancer) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	input := &elbv2.CreateLoadBalancerInput{}
+	input := &iam.CreateGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in elbv2.CreateLoadBalancerInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in iam.CreateGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateLoadBalancer(input)
-	renv.Log().ExtraVerbosef("elbv2.CreateLoadBalancer call took %s", time.Since(start))
+	output, err := cmd.api.CreateGroup(input)
+	renv.Log().ExtraVerbosef("iam.CreateGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -3939,16 +4050,16 @@ func (cmd *CreateLoadbalancer) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create loadbalancer: AWS command returned nil output")
+			renv.Log().Warning("create group: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create loadbalancer '%s' done", extracted)
+		renv.Log().Verbosef("create group '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create loadbalancer done")
+		renv.Log().Verbose("create group done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -3960,40 +4071,40 @@ func (cmd *CreateLoadbalancer) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *CreateLoadbalancer) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loadbalancer"), nil
+func (cmd *CreateGroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("group"), nil
 }
 
-func (cmd *CreateLoadbalancer) inject(params map[string]interface{}) error {
+func (cmd *CreateGroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateLoginprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLoginprofile {
-	cmd := new(CreateLoginprofile)
+func NewCreateHealthcheck(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateHealthcheck {
+	cmd := new(CreateHealthcheck)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateLoginprofile) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateHealthcheck) SetApi(api route53iface.Route53API) {
 	cmd.api = api
 }
 
-func (cmd *CreateLoginprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateHealthcheck) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateLoginprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateHealthcheck) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4004,13 +4115,13 @@ func (cmd *CreateLoginprofile) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	input := &iam.CreateLoginProfileInput{}
+	input := &route53.CreateHealthCheckInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.CreateLoginProfileInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in route53.CreateHealthCheckInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateLoginProfile(input)
-	renv.Log().ExtraVerbosef("iam.CreateLoginProfile call took %s", time.Since(start))
+	output, err := cmd.api.CreateHealthCheck(input)
+	renv.Log().ExtraVerbosef("route53.CreateHealthCheck call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4018,16 +4129,16 @@ func (cmd *CreateLoginprofile) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create loginprofile: AWS command returned nil output")
+			renv.Log().Warning("create healthcheck: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create loginprofile '%s' done", extracted)
+		renv.Log().Verbosef("create healthcheck '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create loginprofile done")
+		renv.Log().Verbose("create healthcheck done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4039,40 +4150,40 @@ func (cmd *CreateLoginprofile) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *CreateLoginprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loginprofile"), nil
+func (cmd *CreateHealthcheck) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("healthcheck"), nil
 }
 
-func (cmd *CreateLoginprofile) inject(params map[string]interface{}) error {
+func (cmd *CreateHealthcheck) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateMfadevice {
-	cmd := new(CreateMfadevice)
+func NewCreateImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateImage {
+	cmd := new(CreateImage)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateMfadevice) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateImage) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateMfadevice) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateImage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateMfadevice) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateImage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4083,7 +4194,13 @@ func (cmd *CreateMfadevice) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &ec2.CreateImageInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateImageInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CreateImage(input)
+	renv.Log().ExtraVerbosef("ec2.CreateImage call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4091,16 +4208,16 @@ func (cmd *CreateMfadevice) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create mfadevice: AWS command returned nil output")
+			renv.Log().Warning("create image: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create mfadevice '%s' done", extracted)
+		renv.Log().Verbosef("create image '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create mfadevice done")
+		renv.Log().Verbose("create image done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4112,40 +4229,67 @@ func (cmd *CreateMfadevice) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *CreateMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("mfadevice"), nil
+func (cmd *CreateImage) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateImageInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateImageInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.CreateImage(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateImage call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create image ok")
+			return fakeDryRunId("image"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *CreateMfadevice) inject(params map[string]interface{}) error {
+func (cmd *CreateImage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateNatgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateNatgateway {
-	cmd := new(CreateNatgateway)
+func NewCreateInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInstance {
+	cmd := new(CreateInstance)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateNatgateway) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateInstance) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateNatgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateNatgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4156,13 +4300,13 @@ func (cmd *CreateNatgateway) run(renv env.Running, params map[string]interface{}
 		}
 	}
 
-	input := &ec2.CreateNatGatewayInput{}
+	input := &ec2.RunInstancesInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateNatGatewayInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.RunInstancesInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateNatGateway(input)
-	renv.Log().ExtraVerbosef("ec2.CreateNatGateway call took %s", time.Since(start))
+	output, err := cmd.api.RunInstances(input)
+	renv.Log().ExtraVerbosef("ec2.RunInstances call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4170,16 +4314,16 @@ func (cmd *CreateNatgateway) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create natgateway: AWS command returned nil output")
+			renv.Log().Warning("create instance: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create natgateway '%s' done", extracted)
+		renv.Log().Verbosef("create instance '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create natgateway done")
+		renv.Log().Verbose("create instance done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4191,40 +4335,67 @@ func (cmd *CreateNatgateway) run(renv env.Running, params map[string]interface{}
 	return extracted, nil
 }
 
-func (cmd *CreateNatgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("natgateway"), nil
-}
+func (cmd *CreateInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
 
-func (cmd *CreateNatgateway) inject(params map[string]interface{}) error {
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.RunInstancesInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.RunInstancesInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.RunInstances(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.RunInstances call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create instance ok")
+			return fakeDryRunId("instance"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *CreateInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateNetworkinterface {
-	cmd := new(CreateNetworkinterface)
+func NewCreateInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInstanceprofile {
+	cmd := new(CreateInstanceprofile)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateNetworkinterface) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateInstanceprofile) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateNetworkinterface) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateInstanceprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateNetworkinterface) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateInstanceprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4235,13 +4406,13 @@ func (cmd *CreateNetworkinterface) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	input := &ec2.CreateNetworkInterfaceInput{}
+	input := &iam.CreateInstanceProfileInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateNetworkInterfaceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in iam.CreateInstanceProfileInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateNetworkInterface(input)
-	renv.Log().ExtraVerbosef("ec2.CreateNetworkInterface call took %s", time.Since(start))
+	output, err := cmd.api.CreateInstanceProfile(input)
+	renv.Log().ExtraVerbosef("iam.CreateInstanceProfile call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4249,16 +4420,16 @@ func (cmd *CreateNetworkinterface) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create networkinterface: AWS command returned nil output")
+			renv.Log().Warning("create instanceprofile: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create networkinterface '%s' done", extracted)
+		renv.Log().Verbosef("create instanceprofile '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create networkinterface done")
+		renv.Log().Verbose("create instanceprofile done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4270,61 +4441,40 @@ func (cmd *CreateNetworkinterface) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *CreateNetworkinterface) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.CreateNetworkInterfaceInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateNetworkInterfaceInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.CreateNetworkInterface(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateNetworkInterface call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create networkinterface ok")
-			return fakeDryRunId("networkinterface"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreateInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("instanceprofile"), nil
 }
 
-func (cmd *CreateNetworkinterface) inject(params map[string]interface{}) error {
+func (cmd *CreateInstanceprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreatePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreatePolicy {
-	cmd := new(CreatePolicy)
+func NewCreateInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateInternetgateway {
+	cmd := new(CreateInternetgateway)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreatePolicy) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateInternetgateway) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreatePolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateInternetgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreatePolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateInternetgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4335,13 +4485,13 @@ func (cmd *CreatePolicy) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &iam.CreatePolicyInput{}
+	input := &ec2.CreateInternetGatewayInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.CreatePolicyInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateInternetGatewayInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreatePolicy(input)
-	renv.Log().ExtraVerbosef("iam.CreatePolicy call took %s", time.Since(start))
+	output, err := cmd.api.CreateInternetGateway(input)
+	renv.Log().ExtraVerbosef("ec2.CreateInternetGateway call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4349,16 +4499,16 @@ func (cmd *CreatePolicy) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create policy: AWS command returned nil output")
+			renv.Log().Warning("create internetgateway: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create policy '%s' done", extracted)
+		renv.Log().Verbosef("create internetgateway '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create policy done")
+		renv.Log().Verbose("create internetgateway done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4370,40 +4520,67 @@ func (cmd *CreatePolicy) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *CreatePolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("policy"), nil
+func (cmd *CreateInternetgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateInternetGatewayInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateInternetGatewayInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.CreateInternetGateway(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateInternetGateway call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create internetgateway ok")
+			return fakeDryRunId("internetgateway"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *CreatePolicy) inject(params map[string]interface{}) error {
+func (cmd *CreateInternetgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateQueue(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateQueue {
-	cmd := new(CreateQueue)
+func NewCreateKey(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateKey {
+	cmd := new(CreateKey)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = sqs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "kms", func() interface{} { return kms.New(sess) }).(kmsiface.KMSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateQueue) SetApi(api sqsiface.SQSAPI) {
+func (cmd *CreateKey) SetApi(api kmsiface.KMSAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateQueue) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateKey) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateQueue) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateKey) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4414,13 +4591,13 @@ func (cmd *CreateQueue) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &sqs.CreateQueueInput{}
+	input := &kms.CreateKeyInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in sqs.CreateQueueInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in kms.CreateKeyInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateQueue(input)
-	renv.Log().ExtraVerbosef("sqs.CreateQueue call took %s", time.Since(start))
+	output, err := cmd.api.CreateKey(input)
+	renv.Log().ExtraVerbosef("kms.CreateKey call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4428,16 +4605,16 @@ func (cmd *CreateQueue) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create queue: AWS command returned nil output")
+			renv.Log().Warning("create key: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create queue '%s' done", extracted)
+		renv.Log().Verbosef("create key '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create queue done")
+		renv.Log().Verbose("create key done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4449,40 +4626,40 @@ func (cmd *CreateQueue) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *CreateQueue) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("queue"), nil
+func (cmd *CreateKey) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("key"), nil
 }
 
-func (cmd *CreateQueue) inject(params map[string]interface{}) error {
+func (cmd *CreateKey) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateRecord(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRecord {
-	cmd := new(CreateRecord)
+func NewCreateKeypair(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateKeypair {
+	cmd := new(CreateKeypair)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = route53.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateRecord) SetApi(api route53iface.Route53API) {
+func (cmd *CreateKeypair) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateRecord) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateKeypair) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateRecord) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateKeypair) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4493,7 +4670,13 @@ func (cmd *CreateRecord) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &ec2.ImportKeyPairInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.ImportKeyPairInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.ImportKeyPair(input)
+	renv.Log().ExtraVerbosef("ec2.ImportKeyPair call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4501,16 +4684,16 @@ func (cmd *CreateRecord) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create record: AWS command returned nil output")
+			renv.Log().Warning("create keypair: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create record '%s' done", extracted)
+		renv.Log().Verbosef("create keypair '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create record done")
+		renv.Log().Verbose("create keypair done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4522,40 +4705,40 @@ func (cmd *CreateRecord) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *CreateRecord) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("record"), nil
+func (cmd *CreateKeypair) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("keypair"), nil
 }
 
-func (cmd *CreateRecord) inject(params map[string]interface{}) error {
+func (cmd *CreateKeypair) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateRepository(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRepository {
-	cmd := new(CreateRepository)
+func NewCreateLaunchconfiguration(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLaunchconfiguration {
+	cmd := new(CreateLaunchconfiguration)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecr.New(sess)
+		cmd.api = awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateRepository) SetApi(api ecriface.ECRAPI) {
+func (cmd *CreateLaunchconfiguration) SetApi(api autoscalingiface.AutoScalingAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateRepository) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateLaunchconfiguration) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateRepository) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateLaunchconfiguration) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4566,13 +4749,13 @@ func (cmd *CreateRepository) run(renv env.Running, params map[string]interface{}
 		}
 	}
 
-	input := &ecr.CreateRepositoryInput{}
+	input := &autoscaling.CreateLaunchConfigurationInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ecr.CreateRepositoryInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in autoscaling.CreateLaunchConfigurationInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateRepository(input)
-	renv.Log().ExtraVerbosef("ecr.CreateRepository call took %s", time.Since(start))
+	output, err := cmd.api.CreateLaunchConfiguration(input)
+	renv.Log().ExtraVerbosef("autoscaling.CreateLaunchConfiguration call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4580,16 +4763,16 @@ func (cmd *CreateRepository) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create repository: AWS command returned nil output")
+			renv.Log().Warning("create launchconfiguration: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create repository '%s' done", extracted)
+		renv.Log().Verbosef("create launchconfiguration '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create repository done")
+		renv.Log().Verbose("create launchconfiguration done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4601,40 +4784,40 @@ func (cmd *CreateRepository) run(renv env.Running, params map[string]interface{}
 	return extracted, nil
 }
 
-func (cmd *CreateRepository) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("repository"), nil
+func (cmd *CreateLaunchconfiguration) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("launchconfiguration"), nil
 }
 
-func (cmd *CreateRepository) inject(params map[string]interface{}) error {
+func (cmd *CreateLaunchconfiguration) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRole {
-	cmd := new(CreateRole)
+func NewCreateLaunchtemplate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLaunchtemplate {
+	cmd := new(CreateLaunchtemplate)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateRole) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateLaunchtemplate) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateRole) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateLaunchtemplate) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateRole) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateLaunchtemplate) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4645,7 +4828,13 @@ func (cmd *CreateRole) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &ec2.CreateLaunchTemplateInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateLaunchTemplateInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CreateLaunchTemplate(input)
+	renv.Log().ExtraVerbosef("ec2.CreateLaunchTemplate call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4653,16 +4842,16 @@ func (cmd *CreateRole) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create role: AWS command returned nil output")
+			renv.Log().Warning("create launchtemplate: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create role '%s' done", extracted)
+		renv.Log().Verbosef("create launchtemplate '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create role done")
+		renv.Log().Verbose("create launchtemplate done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4674,40 +4863,67 @@ func (cmd *CreateRole) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *CreateRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("role"), nil
+func (cmd *CreateLaunchtemplate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateLaunchTemplateInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateLaunchTemplateInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.CreateLaunchTemplate(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateLaunchTemplate call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create launchtemplate ok")
+			return fakeDryRunId("launchtemplate"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *CreateRole) inject(params map[string]interface{}) error {
+func (cmd *CreateLaunchtemplate) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateRoute(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRoute {
-	cmd := new(CreateRoute)
+func NewCreateListener(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateListener {
+	cmd := new(CreateListener)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateRoute) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateListener) SetApi(api elbv2iface.ELBV2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateRoute) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateListener) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateRoute) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateListener) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4718,13 +4934,13 @@ func (cmd *CreateRoute) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &ec2.CreateRouteInput{}
+	input := &elbv2.CreateListenerInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateRouteInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in elbv2.CreateListenerInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateRoute(input)
-	renv.Log().ExtraVerbosef("ec2.CreateRoute call took %s", time.Since(start))
+	output, err := cmd.api.CreateListener(input)
+	renv.Log().ExtraVerbosef("elbv2.CreateListener call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4732,16 +4948,16 @@ func (cmd *CreateRoute) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create route: AWS command returned nil output")
+			renv.Log().Warning("create listener: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create route '%s' done", extracted)
+		renv.Log().Verbosef("create listener '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create route done")
+		renv.Log().Verbose("create listener done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4753,61 +4969,40 @@ func (cmd *CreateRoute) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *CreateRoute) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.CreateRouteInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateRouteInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.CreateRoute(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateRoute call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create route ok")
-			return fakeDryRunId("route"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreateListener) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("listener"), nil
 }
 
-func (cmd *CreateRoute) inject(params map[string]interface{}) error {
+func (cmd *CreateListener) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRoutetable {
-	cmd := new(CreateRoutetable)
+func NewCreateLoadbalancer(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLoadbalancer {
+	cmd := new(CreateLoadbalancer)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateRoutetable) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateLoadbalancer) SetApi(api elbv2iface.ELBV2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateRoutetable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateLoadbalancer) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateRoutetable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateLoadbalancer) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4818,13 +5013,13 @@ func (cmd *CreateRoutetable) run(renv env.Running, params map[string]interface{}
 		}
 	}
 
-	input := &ec2.CreateRouteTableInput{}
+	input := &elbv2.CreateLoadBalancerInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateRouteTableInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in elbv2.CreateLoadBalancerInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateRouteTable(input)
-	renv.Log().ExtraVerbosef("ec2.CreateRouteTable call took %s", time.Since(start))
+	output, err := cmd.api.CreateLoadBalancer(input)
+	renv.Log().ExtraVerbosef("elbv2.CreateLoadBalancer call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4832,16 +5027,16 @@ func (cmd *CreateRoutetable) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create routetable: AWS command returned nil output")
+			renv.Log().Warning("create loadbalancer: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create routetable '%s' done", extracted)
+		renv.Log().Verbosef("create loadbalancer '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create routetable done")
+		renv.Log().Verbose("create loadbalancer done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4853,61 +5048,40 @@ func (cmd *CreateRoutetable) run(renv env.Running, params map[string]interface{}
 	return extracted, nil
 }
 
-func (cmd *CreateRoutetable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.CreateRouteTableInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateRouteTableInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.CreateRouteTable(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateRouteTable call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create routetable ok")
-			return fakeDryRunId("routetable"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreateLoadbalancer) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("loadbalancer"), nil
 }
 
-func (cmd *CreateRoutetable) inject(params map[string]interface{}) error {
+func (cmd *CreateLoadbalancer) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateS3object {
-	cmd := new(CreateS3object)
+func NewCreateLoginprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateLoginprofile {
+	cmd := new(CreateLoginprofile)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = s3.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateS3object) SetApi(api s3iface.S3API) {
+func (cmd *CreateLoginprofile) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateS3object) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateLoginprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateS3object) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateLoginprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4918,7 +5092,13 @@ func (cmd *CreateS3object) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &iam.CreateLoginProfileInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in iam.CreateLoginProfileInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CreateLoginProfile(input)
+	renv.Log().ExtraVerbosef("iam.CreateLoginProfile call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -4926,16 +5106,16 @@ func (cmd *CreateS3object) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create s3object: AWS command returned nil output")
+			renv.Log().Warning("create loginprofile: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create s3object '%s' done", extracted)
+		renv.Log().Verbosef("create loginprofile '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create s3object done")
+		renv.Log().Verbose("create loginprofile done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -4947,40 +5127,40 @@ func (cmd *CreateS3object) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *CreateS3object) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("s3object"), nil
+func (cmd *CreateLoginprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("loginprofile"), nil
 }
 
-func (cmd *CreateS3object) inject(params map[string]interface{}) error {
+func (cmd *CreateLoginprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateScalinggroup {
-	cmd := new(CreateScalinggroup)
+func NewCreateMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateMfadevice {
+	cmd := new(CreateMfadevice)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = autoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateScalinggroup) SetApi(api autoscalingiface.AutoScalingAPI) {
+func (cmd *CreateMfadevice) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateScalinggroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateMfadevice) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateScalinggroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateMfadevice) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -4991,13 +5171,7 @@ func (cmd *CreateScalinggroup) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	input := &autoscaling.CreateAutoScalingGroupInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in autoscaling.CreateAutoScalingGroupInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.CreateAutoScalingGroup(input)
-	renv.Log().ExtraVerbosef("autoscaling.CreateAutoScalingGroup call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5005,16 +5179,16 @@ func (cmd *CreateScalinggroup) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create scalinggroup: AWS command returned nil output")
+			renv.Log().Warning("create mfadevice: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create scalinggroup '%s' done", extracted)
+		renv.Log().Verbosef("create mfadevice '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create scalinggroup done")
+		renv.Log().Verbose("create mfadevice done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5026,40 +5200,40 @@ func (cmd *CreateScalinggroup) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *CreateScalinggroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalinggroup"), nil
+func (cmd *CreateMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("mfadevice"), nil
 }
 
-func (cmd *CreateScalinggroup) inject(params map[string]interface{}) error {
+func (cmd *CreateMfadevice) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateScalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateScalingpolicy {
-	cmd := new(CreateScalingpolicy)
+func NewCreateNatgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateNatgateway {
+	cmd := new(CreateNatgateway)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = autoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateScalingpolicy) SetApi(api autoscalingiface.AutoScalingAPI) {
+func (cmd *CreateNatgateway) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateScalingpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateNatgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateScalingpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateNatgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5070,13 +5244,13 @@ func (cmd *CreateScalingpolicy) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	input := &autoscaling.PutScalingPolicyInput{}
+	input := &ec2.CreateNatGatewayInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in autoscaling.PutScalingPolicyInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateNatGatewayInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.PutScalingPolicy(input)
-	renv.Log().ExtraVerbosef("autoscaling.PutScalingPolicy call took %s", time.Since(start))
+	output, err := cmd.api.CreateNatGateway(input)
+	renv.Log().ExtraVerbosef("ec2.CreateNatGateway call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5084,16 +5258,16 @@ func (cmd *CreateScalingpolicy) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create scalingpolicy: AWS command returned nil output")
+			renv.Log().Warning("create natgateway: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create scalingpolicy '%s' done", extracted)
+		renv.Log().Verbosef("create natgateway '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create scalingpolicy done")
+		renv.Log().Verbose("create natgateway done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5105,40 +5279,40 @@ func (cmd *CreateScalingpolicy) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *CreateScalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalingpolicy"), nil
+func (cmd *CreateNatgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("natgateway"), nil
 }
 
-func (cmd *CreateScalingpolicy) inject(params map[string]interface{}) error {
+func (cmd *CreateNatgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSecuritygroup {
-	cmd := new(CreateSecuritygroup)
+func NewCreateNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateNetworkinterface {
+	cmd := new(CreateNetworkinterface)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateSecuritygroup) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateNetworkinterface) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateSecuritygroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateNetworkinterface) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateSecuritygroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateNetworkinterface) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5149,13 +5323,13 @@ func (cmd *CreateSecuritygroup) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	input := &ec2.CreateSecurityGroupInput{}
+	input := &ec2.CreateNetworkInterfaceInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateSecurityGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateNetworkInterfaceInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateSecurityGroup(input)
-	renv.Log().ExtraVerbosef("ec2.CreateSecurityGroup call took %s", time.Since(start))
+	output, err := cmd.api.CreateNetworkInterface(input)
+	renv.Log().ExtraVerbosef("ec2.CreateNetworkInterface call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5163,16 +5337,16 @@ func (cmd *CreateSecuritygroup) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create securitygroup: AWS command returned nil output")
+			renv.Log().Warning("create networkinterface: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create securitygroup '%s' done", extracted)
+		renv.Log().Verbosef("create networkinterface '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create securitygroup done")
+		renv.Log().Verbose("create networkinterface done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5184,61 +5358,67 @@ func (cmd *CreateSecuritygroup) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *CreateSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateNetworkinterface) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
-	input := &ec2.CreateSecurityGroupInput{}
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateNetworkInterfaceInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateSecurityGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateNetworkInterfaceInput: %s", err)
 	}
 
 	start := time.Now()
-	_, err := cmd.api.CreateSecurityGroup(input)
+	_, err := cmd.api.CreateNetworkInterface(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateSecurityGroup call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create securitygroup ok")
-			return fakeDryRunId("securitygroup"), nil
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateNetworkInterface call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create networkinterface ok")
+			return fakeDryRunId("networkinterface"), nil
 		}
 	}
 
 	return nil, err
 }
 
-func (cmd *CreateSecuritygroup) inject(params map[string]interface{}) error {
+func (cmd *CreateNetworkinterface) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateSnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSnapshot {
-	cmd := new(CreateSnapshot)
+func NewCreateOriginaccessidentity(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateOriginaccessidentity {
+	cmd := new(CreateOriginaccessidentity)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudfront", func() interface{} { return cloudfront.New(sess) }).(cloudfrontiface.CloudFrontAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateSnapshot) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateOriginaccessidentity) SetApi(api cloudfrontiface.CloudFrontAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateSnapshot) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateOriginaccessidentity) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateSnapshot) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateOriginaccessidentity) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5249,13 +5429,7 @@ func (cmd *CreateSnapshot) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.CreateSnapshotInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateSnapshotInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.CreateSnapshot(input)
-	renv.Log().ExtraVerbosef("ec2.CreateSnapshot call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5263,16 +5437,16 @@ func (cmd *CreateSnapshot) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create snapshot: AWS command returned nil output")
+			renv.Log().Warning("create originaccessidentity: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create snapshot '%s' done", extracted)
+		renv.Log().Verbosef("create originaccessidentity '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create snapshot done")
+		renv.Log().Verbose("create originaccessidentity done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5284,61 +5458,40 @@ func (cmd *CreateSnapshot) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *CreateSnapshot) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
+func (cmd *CreateOriginaccessidentity) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("originaccessidentity"), nil
+}
 
-	input := &ec2.CreateSnapshotInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateSnapshotInput: %s", err)
+func (cmd *CreateOriginaccessidentity) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewCreatePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreatePolicy {
+	cmd := new(CreatePolicy)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
+	cmd.graph = g
+	return cmd
+}
 
-	start := time.Now()
-	_, err := cmd.api.CreateSnapshot(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateSnapshot call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create snapshot ok")
-			return fakeDryRunId("snapshot"), nil
-		}
-	}
-
-	return nil, err
-}
-
-func (cmd *CreateSnapshot) inject(params map[string]interface{}) error {
-	return structSetter(cmd, params)
-}
-
-func NewCreateStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateStack {
-	cmd := new(CreateStack)
-	if len(l) > 0 {
-		cmd.logger = l[0]
-	} else {
-		cmd.logger = logger.DiscardLogger
-	}
-	if sess != nil {
-		cmd.api = cloudformation.New(sess)
-	}
-	cmd.graph = g
-	return cmd
-}
-
-func (cmd *CreateStack) SetApi(api cloudformationiface.CloudFormationAPI) {
+func (cmd *CreatePolicy) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateStack) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreatePolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateStack) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreatePolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5349,13 +5502,13 @@ func (cmd *CreateStack) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &cloudformation.CreateStackInput{}
+	input := &iam.CreatePolicyInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in cloudformation.CreateStackInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in iam.CreatePolicyInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateStack(input)
-	renv.Log().ExtraVerbosef("cloudformation.CreateStack call took %s", time.Since(start))
+	output, err := cmd.api.CreatePolicy(input)
+	renv.Log().ExtraVerbosef("iam.CreatePolicy call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5363,16 +5516,16 @@ func (cmd *CreateStack) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create stack: AWS command returned nil output")
+			renv.Log().Warning("create policy: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create stack '%s' done", extracted)
+		renv.Log().Verbosef("create policy '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create stack done")
+		renv.Log().Verbose("create policy done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5384,40 +5537,40 @@ func (cmd *CreateStack) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *CreateStack) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("stack"), nil
+func (cmd *CreatePolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("policy"), nil
 }
 
-func (cmd *CreateStack) inject(params map[string]interface{}) error {
+func (cmd *CreatePolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateSubnet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSubnet {
-	cmd := new(CreateSubnet)
+func NewCreatePresignedurl(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreatePresignedurl {
+	cmd := new(CreatePresignedurl)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateSubnet) SetApi(api ec2iface.EC2API) {
+func (cmd *CreatePresignedurl) SetApi(api s3iface.S3API) {
 	cmd.api = api
 }
 
-func (cmd *CreateSubnet) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreatePresignedurl) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateSubnet) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreatePresignedurl) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5428,13 +5581,7 @@ func (cmd *CreateSubnet) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &ec2.CreateSubnetInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateSubnetInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.CreateSubnet(input)
-	renv.Log().ExtraVerbosef("ec2.CreateSubnet call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5442,16 +5589,16 @@ func (cmd *CreateSubnet) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create subnet: AWS command returned nil output")
+			renv.Log().Warning("create presignedurl: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create subnet '%s' done", extracted)
+		renv.Log().Verbosef("create presignedurl '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create subnet done")
+		renv.Log().Verbose("create presignedurl done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5463,61 +5610,40 @@ func (cmd *CreateSubnet) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *CreateSubnet) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.CreateSubnetInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateSubnetInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.CreateSubnet(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateSubnet call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create subnet ok")
-			return fakeDryRunId("subnet"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreatePresignedurl) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("presignedurl"), nil
 }
 
-func (cmd *CreateSubnet) inject(params map[string]interface{}) error {
+func (cmd *CreatePresignedurl) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateSubscription(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSubscription {
-	cmd := new(CreateSubscription)
+func NewCreateQueue(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateQueue {
+	cmd := new(CreateQueue)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = sns.New(sess)
+		cmd.api = awspool.Default.Client(sess, "sqs", func() interface{} { return sqs.New(sess) }).(sqsiface.SQSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateSubscription) SetApi(api snsiface.SNSAPI) {
+func (cmd *CreateQueue) SetApi(api sqsiface.SQSAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateSubscription) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateQueue) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateSubscription) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateQueue) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5528,13 +5654,13 @@ func (cmd *CreateSubscription) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	input := &sns.SubscribeInput{}
+	input := &sqs.CreateQueueInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in sns.SubscribeInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in sqs.CreateQueueInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.Subscribe(input)
-	renv.Log().ExtraVerbosef("sns.Subscribe call took %s", time.Since(start))
+	output, err := cmd.api.CreateQueue(input)
+	renv.Log().ExtraVerbosef("sqs.CreateQueue call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5542,16 +5668,16 @@ func (cmd *CreateSubscription) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create subscription: AWS command returned nil output")
+			renv.Log().Warning("create queue: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create subscription '%s' done", extracted)
+		renv.Log().Verbosef("create queue '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create subscription done")
+		renv.Log().Verbose("create queue done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5563,40 +5689,40 @@ func (cmd *CreateSubscription) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *CreateSubscription) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("subscription"), nil
+func (cmd *CreateQueue) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("queue"), nil
 }
 
-func (cmd *CreateSubscription) inject(params map[string]interface{}) error {
+func (cmd *CreateQueue) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateTag(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTag {
-	cmd := new(CreateTag)
+func NewCreateRecord(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRecord {
+	cmd := new(CreateRecord)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateTag) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateRecord) SetApi(api route53iface.Route53API) {
 	cmd.api = api
 }
 
-func (cmd *CreateTag) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRecord) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateTag) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRecord) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5615,16 +5741,16 @@ func (cmd *CreateTag) run(renv env.Running, params map[string]interface{}) (inte
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create tag: AWS command returned nil output")
+			renv.Log().Warning("create record: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create tag '%s' done", extracted)
+		renv.Log().Verbosef("create record '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create tag done")
+		renv.Log().Verbose("create record done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5636,36 +5762,40 @@ func (cmd *CreateTag) run(renv env.Running, params map[string]interface{}) (inte
 	return extracted, nil
 }
 
-func (cmd *CreateTag) inject(params map[string]interface{}) error {
+func (cmd *CreateRecord) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("record"), nil
+}
+
+func (cmd *CreateRecord) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTargetgroup {
-	cmd := new(CreateTargetgroup)
+func NewCreateReplicationgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateReplicationgroup {
+	cmd := new(CreateReplicationgroup)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elasticache", func() interface{} { return elasticache.New(sess) }).(elasticacheiface.ElastiCacheAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateTargetgroup) SetApi(api elbv2iface.ELBV2API) {
+func (cmd *CreateReplicationgroup) SetApi(api elasticacheiface.ElastiCacheAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateTargetgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateReplicationgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateTargetgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateReplicationgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5676,13 +5806,13 @@ func (cmd *CreateTargetgroup) run(renv env.Running, params map[string]interface{
 		}
 	}
 
-	input := &elbv2.CreateTargetGroupInput{}
+	input := &elasticache.CreateReplicationGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in elbv2.CreateTargetGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in elasticache.CreateReplicationGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateTargetGroup(input)
-	renv.Log().ExtraVerbosef("elbv2.CreateTargetGroup call took %s", time.Since(start))
+	output, err := cmd.api.CreateReplicationGroup(input)
+	renv.Log().ExtraVerbosef("elasticache.CreateReplicationGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5690,16 +5820,16 @@ func (cmd *CreateTargetgroup) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create targetgroup: AWS command returned nil output")
+			renv.Log().Warning("create replicationgroup: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create targetgroup '%s' done", extracted)
+		renv.Log().Verbosef("create replicationgroup '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create targetgroup done")
+		renv.Log().Verbose("create replicationgroup done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5711,40 +5841,40 @@ func (cmd *CreateTargetgroup) run(renv env.Running, params map[string]interface{
 	return extracted, nil
 }
 
-func (cmd *CreateTargetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("targetgroup"), nil
+func (cmd *CreateReplicationgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("replicationgroup"), nil
 }
 
-func (cmd *CreateTargetgroup) inject(params map[string]interface{}) error {
+func (cmd *CreateReplicationgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateTopic(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTopic {
-	cmd := new(CreateTopic)
+func NewCreateRepository(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRepository {
+	cmd := new(CreateRepository)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = sns.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecr", func() interface{} { return ecr.New(sess) }).(ecriface.ECRAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateTopic) SetApi(api snsiface.SNSAPI) {
+func (cmd *CreateRepository) SetApi(api ecriface.ECRAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateTopic) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRepository) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateTopic) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRepository) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5755,13 +5885,13 @@ func (cmd *CreateTopic) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &sns.CreateTopicInput{}
+	input := &ecr.CreateRepositoryInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in sns.CreateTopicInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ecr.CreateRepositoryInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateTopic(input)
-	renv.Log().ExtraVerbosef("sns.CreateTopic call took %s", time.Since(start))
+	output, err := cmd.api.CreateRepository(input)
+	renv.Log().ExtraVerbosef("ecr.CreateRepository call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5769,16 +5899,16 @@ func (cmd *CreateTopic) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create topic: AWS command returned nil output")
+			renv.Log().Warning("create repository: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create topic '%s' done", extracted)
+		renv.Log().Verbosef("create repository '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create topic done")
+		renv.Log().Verbose("create repository done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5790,40 +5920,40 @@ func (cmd *CreateTopic) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *CreateTopic) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("topic"), nil
+func (cmd *CreateRepository) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("repository"), nil
 }
 
-func (cmd *CreateTopic) inject(params map[string]interface{}) error {
+func (cmd *CreateRepository) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateUser {
-	cmd := new(CreateUser)
+func NewCreateRestapi(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRestapi {
+	cmd := new(CreateRestapi)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateUser) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateRestapi) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateUser) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRestapi) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateUser) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRestapi) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5834,13 +5964,13 @@ func (cmd *CreateUser) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	input := &iam.CreateUserInput{}
+	input := &apigateway.CreateRestApiInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.CreateUserInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in apigateway.CreateRestApiInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateUser(input)
-	renv.Log().ExtraVerbosef("iam.CreateUser call took %s", time.Since(start))
+	output, err := cmd.api.CreateRestApi(input)
+	renv.Log().ExtraVerbosef("apigateway.CreateRestApi call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5848,16 +5978,16 @@ func (cmd *CreateUser) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create user: AWS command returned nil output")
+			renv.Log().Warning("create restapi: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create user '%s' done", extracted)
+		renv.Log().Verbosef("create restapi '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create user done")
+		renv.Log().Verbose("create restapi done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5869,40 +5999,40 @@ func (cmd *CreateUser) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *CreateUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("user"), nil
+func (cmd *CreateRestapi) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("restapi"), nil
 }
 
-func (cmd *CreateUser) inject(params map[string]interface{}) error {
+func (cmd *CreateRestapi) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateVolume {
-	cmd := new(CreateVolume)
+func NewCreateRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRole {
+	cmd := new(CreateRole)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateVolume) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateRole) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *CreateVolume) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRole) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateVolume) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRole) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -5913,13 +6043,7 @@ func (cmd *CreateVolume) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &ec2.CreateVolumeInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateVolumeInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.CreateVolume(input)
-	renv.Log().ExtraVerbosef("ec2.CreateVolume call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -5927,16 +6051,16 @@ func (cmd *CreateVolume) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create volume: AWS command returned nil output")
+			renv.Log().Warning("create role: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create volume '%s' done", extracted)
+		renv.Log().Verbosef("create role '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create volume done")
+		renv.Log().Verbose("create role done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -5948,61 +6072,40 @@ func (cmd *CreateVolume) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *CreateVolume) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
+func (cmd *CreateRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("role"), nil
+}
 
-	input := &ec2.CreateVolumeInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateVolumeInput: %s", err)
-	}
+func (cmd *CreateRole) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
 
-	start := time.Now()
-	_, err := cmd.api.CreateVolume(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateVolume call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create volume ok")
-			return fakeDryRunId("volume"), nil
-		}
-	}
-
-	return nil, err
-}
-
-func (cmd *CreateVolume) inject(params map[string]interface{}) error {
-	return structSetter(cmd, params)
-}
-
-func NewCreateVpc(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateVpc {
-	cmd := new(CreateVpc)
+func NewCreateRoute(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRoute {
+	cmd := new(CreateRoute)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateVpc) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateRoute) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateVpc) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRoute) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateVpc) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRoute) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6013,13 +6116,13 @@ func (cmd *CreateVpc) run(renv env.Running, params map[string]interface{}) (inte
 		}
 	}
 
-	input := &ec2.CreateVpcInput{}
+	input := &ec2.CreateRouteInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateVpcInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateRouteInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateVpc(input)
-	renv.Log().ExtraVerbosef("ec2.CreateVpc call took %s", time.Since(start))
+	output, err := cmd.api.CreateRoute(input)
+	renv.Log().ExtraVerbosef("ec2.CreateRoute call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6027,16 +6130,16 @@ func (cmd *CreateVpc) run(renv env.Running, params map[string]interface{}) (inte
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create vpc: AWS command returned nil output")
+			renv.Log().Warning("create route: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create vpc '%s' done", extracted)
+		renv.Log().Verbosef("create route '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create vpc done")
+		renv.Log().Verbose("create route done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6048,61 +6151,67 @@ func (cmd *CreateVpc) run(renv env.Running, params map[string]interface{}) (inte
 	return extracted, nil
 }
 
-func (cmd *CreateVpc) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRoute) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
-	input := &ec2.CreateVpcInput{}
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateRouteInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.CreateVpcInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateRouteInput: %s", err)
 	}
 
 	start := time.Now()
-	_, err := cmd.api.CreateVpc(input)
+	_, err := cmd.api.CreateRoute(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.CreateVpc call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: create vpc ok")
-			return fakeDryRunId("vpc"), nil
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateRoute call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create route ok")
+			return fakeDryRunId("route"), nil
 		}
 	}
 
 	return nil, err
 }
 
-func (cmd *CreateVpc) inject(params map[string]interface{}) error {
+func (cmd *CreateRoute) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewCreateZone(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateZone {
-	cmd := new(CreateZone)
+func NewCreateRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateRoutetable {
+	cmd := new(CreateRoutetable)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = route53.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *CreateZone) SetApi(api route53iface.Route53API) {
+func (cmd *CreateRoutetable) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *CreateZone) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRoutetable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *CreateZone) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateRoutetable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6113,13 +6222,13 @@ func (cmd *CreateZone) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	input := &route53.CreateHostedZoneInput{}
+	input := &ec2.CreateRouteTableInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in route53.CreateHostedZoneInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateRouteTableInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.CreateHostedZone(input)
-	renv.Log().ExtraVerbosef("route53.CreateHostedZone call took %s", time.Since(start))
+	output, err := cmd.api.CreateRouteTable(input)
+	renv.Log().ExtraVerbosef("ec2.CreateRouteTable call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6127,16 +6236,16 @@ func (cmd *CreateZone) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("create zone: AWS command returned nil output")
+			renv.Log().Warning("create routetable: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("create zone '%s' done", extracted)
+		renv.Log().Verbosef("create routetable '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("create zone done")
+		renv.Log().Verbose("create routetable done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6148,40 +6257,67 @@ func (cmd *CreateZone) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *CreateZone) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("zone"), nil
+func (cmd *CreateRoutetable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateRouteTableInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateRouteTableInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.CreateRouteTable(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateRouteTable call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create routetable ok")
+			return fakeDryRunId("routetable"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *CreateZone) inject(params map[string]interface{}) error {
+func (cmd *CreateRoutetable) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteAccesskey(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAccesskey {
-	cmd := new(DeleteAccesskey)
+func NewCreateS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateS3object {
+	cmd := new(CreateS3object)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteAccesskey) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateS3object) SetApi(api s3iface.S3API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteAccesskey) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateS3object) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteAccesskey) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateS3object) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6192,13 +6328,7 @@ func (cmd *DeleteAccesskey) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &iam.DeleteAccessKeyInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.DeleteAccessKeyInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.DeleteAccessKey(input)
-	renv.Log().ExtraVerbosef("iam.DeleteAccessKey call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6206,16 +6336,16 @@ func (cmd *DeleteAccesskey) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete accesskey: AWS command returned nil output")
+			renv.Log().Warning("create s3object: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete accesskey '%s' done", extracted)
+		renv.Log().Verbosef("create s3object '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete accesskey done")
+		renv.Log().Verbose("create s3object done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6227,40 +6357,40 @@ func (cmd *DeleteAccesskey) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteAccesskey) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("accesskey"), nil
+func (cmd *CreateS3object) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("s3object"), nil
 }
 
-func (cmd *DeleteAccesskey) inject(params map[string]interface{}) error {
+func (cmd *CreateS3object) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAlarm {
-	cmd := new(DeleteAlarm)
+func NewCreateScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateScalinggroup {
+	cmd := new(CreateScalinggroup)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudwatch.New(sess)
+		cmd.api = awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteAlarm) SetApi(api cloudwatchiface.CloudWatchAPI) {
+func (cmd *CreateScalinggroup) SetApi(api autoscalingiface.AutoScalingAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteAlarm) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateScalinggroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteAlarm) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateScalinggroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6271,13 +6401,13 @@ func (cmd *DeleteAlarm) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &cloudwatch.DeleteAlarmsInput{}
+	input := &autoscaling.CreateAutoScalingGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in cloudwatch.DeleteAlarmsInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in autoscaling.CreateAutoScalingGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteAlarms(input)
-	renv.Log().ExtraVerbosef("cloudwatch.DeleteAlarms call took %s", time.Since(start))
+	output, err := cmd.api.CreateAutoScalingGroup(input)
+	renv.Log().ExtraVerbosef("autoscaling.CreateAutoScalingGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6285,16 +6415,16 @@ func (cmd *DeleteAlarm) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete alarm: AWS command returned nil output")
+			renv.Log().Warning("create scalinggroup: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete alarm '%s' done", extracted)
+		renv.Log().Verbosef("create scalinggroup '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete alarm done")
+		renv.Log().Verbose("create scalinggroup done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6306,40 +6436,40 @@ func (cmd *DeleteAlarm) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *DeleteAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+func (cmd *CreateScalinggroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("scalinggroup"), nil
 }
 
-func (cmd *DeleteAlarm) inject(params map[string]interface{}) error {
+func (cmd *CreateScalinggroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteAppscalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAppscalingpolicy {
-	cmd := new(DeleteAppscalingpolicy)
+func NewCreateScalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateScalingpolicy {
+	cmd := new(CreateScalingpolicy)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = applicationautoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteAppscalingpolicy) SetApi(api applicationautoscalingiface.ApplicationAutoScalingAPI) {
+func (cmd *CreateScalingpolicy) SetApi(api autoscalingiface.AutoScalingAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteAppscalingpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateScalingpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteAppscalingpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateScalingpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6350,13 +6480,13 @@ func (cmd *DeleteAppscalingpolicy) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	input := &applicationautoscaling.DeleteScalingPolicyInput{}
+	input := &autoscaling.PutScalingPolicyInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in applicationautoscaling.DeleteScalingPolicyInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in autoscaling.PutScalingPolicyInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteScalingPolicy(input)
-	renv.Log().ExtraVerbosef("applicationautoscaling.DeleteScalingPolicy call took %s", time.Since(start))
+	output, err := cmd.api.PutScalingPolicy(input)
+	renv.Log().ExtraVerbosef("autoscaling.PutScalingPolicy call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6364,16 +6494,16 @@ func (cmd *DeleteAppscalingpolicy) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete appscalingpolicy: AWS command returned nil output")
+			renv.Log().Warning("create scalingpolicy: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete appscalingpolicy '%s' done", extracted)
+		renv.Log().Verbosef("create scalingpolicy '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete appscalingpolicy done")
+		renv.Log().Verbose("create scalingpolicy done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6385,40 +6515,40 @@ func (cmd *DeleteAppscalingpolicy) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *DeleteAppscalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("appscalingpolicy"), nil
+func (cmd *CreateScalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("scalingpolicy"), nil
 }
 
-func (cmd *DeleteAppscalingpolicy) inject(params map[string]interface{}) error {
+func (cmd *CreateScalingpolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteAppscalingtarget(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAppscalingtarget {
-	cmd := new(DeleteAppscalingtarget)
+func NewCreateSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSecuritygroup {
+	cmd := new(CreateSecuritygroup)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = applicationautoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteAppscalingtarget) SetApi(api applicationautoscalingiface.ApplicationAutoScalingAPI) {
+func (cmd *CreateSecuritygroup) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteAppscalingtarget) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSecuritygroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteAppscalingtarget) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSecuritygroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6429,13 +6559,13 @@ func (cmd *DeleteAppscalingtarget) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	input := &applicationautoscaling.DeregisterScalableTargetInput{}
+	input := &ec2.CreateSecurityGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in applicationautoscaling.DeregisterScalableTargetInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateSecurityGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeregisterScalableTarget(input)
-	renv.Log().ExtraVerbosef("applicationautoscaling.DeregisterScalableTarget call took %s", time.Since(start))
+	output, err := cmd.api.CreateSecurityGroup(input)
+	renv.Log().ExtraVerbosef("ec2.CreateSecurityGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6443,16 +6573,16 @@ func (cmd *DeleteAppscalingtarget) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete appscalingtarget: AWS command returned nil output")
+			renv.Log().Warning("create securitygroup: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete appscalingtarget '%s' done", extracted)
+		renv.Log().Verbosef("create securitygroup '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete appscalingtarget done")
+		renv.Log().Verbose("create securitygroup done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6464,40 +6594,67 @@ func (cmd *DeleteAppscalingtarget) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *DeleteAppscalingtarget) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("appscalingtarget"), nil
+func (cmd *CreateSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateSecurityGroupInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateSecurityGroupInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.CreateSecurityGroup(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateSecurityGroup call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create securitygroup ok")
+			return fakeDryRunId("securitygroup"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *DeleteAppscalingtarget) inject(params map[string]interface{}) error {
+func (cmd *CreateSecuritygroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteBucket {
-	cmd := new(DeleteBucket)
+func NewCreateSnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSnapshot {
+	cmd := new(CreateSnapshot)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = s3.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteBucket) SetApi(api s3iface.S3API) {
+func (cmd *CreateSnapshot) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteBucket) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSnapshot) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteBucket) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSnapshot) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6508,13 +6665,13 @@ func (cmd *DeleteBucket) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &s3.DeleteBucketInput{}
+	input := &ec2.CreateSnapshotInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in s3.DeleteBucketInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateSnapshotInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteBucket(input)
-	renv.Log().ExtraVerbosef("s3.DeleteBucket call took %s", time.Since(start))
+	output, err := cmd.api.CreateSnapshot(input)
+	renv.Log().ExtraVerbosef("ec2.CreateSnapshot call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6522,16 +6679,16 @@ func (cmd *DeleteBucket) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete bucket: AWS command returned nil output")
+			renv.Log().Warning("create snapshot: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete bucket '%s' done", extracted)
+		renv.Log().Verbosef("create snapshot '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete bucket done")
+		renv.Log().Verbose("create snapshot done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6543,40 +6700,67 @@ func (cmd *DeleteBucket) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *DeleteBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("bucket"), nil
+func (cmd *CreateSnapshot) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateSnapshotInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateSnapshotInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.CreateSnapshot(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateSnapshot call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create snapshot ok")
+			return fakeDryRunId("snapshot"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *DeleteBucket) inject(params map[string]interface{}) error {
+func (cmd *CreateSnapshot) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteCertificate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteCertificate {
-	cmd := new(DeleteCertificate)
+func NewCreateSpotfleet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSpotfleet {
+	cmd := new(CreateSpotfleet)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = acm.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteCertificate) SetApi(api acmiface.ACMAPI) {
+func (cmd *CreateSpotfleet) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteCertificate) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSpotfleet) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteCertificate) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSpotfleet) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6587,13 +6771,7 @@ func (cmd *DeleteCertificate) run(renv env.Running, params map[string]interface{
 		}
 	}
 
-	input := &acm.DeleteCertificateInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in acm.DeleteCertificateInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.DeleteCertificate(input)
-	renv.Log().ExtraVerbosef("acm.DeleteCertificate call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6601,16 +6779,16 @@ func (cmd *DeleteCertificate) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete certificate: AWS command returned nil output")
+			renv.Log().Warning("create spotfleet: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete certificate '%s' done", extracted)
+		renv.Log().Verbosef("create spotfleet '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete certificate done")
+		renv.Log().Verbose("create spotfleet done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6622,40 +6800,40 @@ func (cmd *DeleteCertificate) run(renv env.Running, params map[string]interface{
 	return extracted, nil
 }
 
-func (cmd *DeleteCertificate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("certificate"), nil
+func (cmd *CreateSpotfleet) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("spotfleet"), nil
 }
 
-func (cmd *DeleteCertificate) inject(params map[string]interface{}) error {
+func (cmd *CreateSpotfleet) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteContainercluster(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteContainercluster {
-	cmd := new(DeleteContainercluster)
+func NewCreateStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateStack {
+	cmd := new(CreateStack)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudformation", func() interface{} { return cloudformation.New(sess) }).(cloudformationiface.CloudFormationAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteContainercluster) SetApi(api ecsiface.ECSAPI) {
+func (cmd *CreateStack) SetApi(api cloudformationiface.CloudFormationAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteContainercluster) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateStack) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteContainercluster) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateStack) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6666,13 +6844,13 @@ func (cmd *DeleteContainercluster) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	input := &ecs.DeleteClusterInput{}
+	input := &cloudformation.CreateStackInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ecs.DeleteClusterInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in cloudformation.CreateStackInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteCluster(input)
-	renv.Log().ExtraVerbosef("ecs.DeleteCluster call took %s", time.Since(start))
+	output, err := cmd.api.CreateStack(input)
+	renv.Log().ExtraVerbosef("cloudformation.CreateStack call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6680,16 +6858,16 @@ func (cmd *DeleteContainercluster) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete containercluster: AWS command returned nil output")
+			renv.Log().Warning("create stack: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete containercluster '%s' done", extracted)
+		renv.Log().Verbosef("create stack '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete containercluster done")
+		renv.Log().Verbose("create stack done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6701,40 +6879,40 @@ func (cmd *DeleteContainercluster) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *DeleteContainercluster) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containercluster"), nil
+func (cmd *CreateStack) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("stack"), nil
 }
 
-func (cmd *DeleteContainercluster) inject(params map[string]interface{}) error {
+func (cmd *CreateStack) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteContainertask {
-	cmd := new(DeleteContainertask)
+func NewCreateStatemachine(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateStatemachine {
+	cmd := new(CreateStatemachine)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "sfn", func() interface{} { return sfn.New(sess) }).(sfniface.SFNAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteContainertask) SetApi(api ecsiface.ECSAPI) {
+func (cmd *CreateStatemachine) SetApi(api sfniface.SFNAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateStatemachine) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateStatemachine) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6745,7 +6923,13 @@ func (cmd *DeleteContainertask) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &sfn.CreateStateMachineInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in sfn.CreateStateMachineInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CreateStateMachine(input)
+	renv.Log().ExtraVerbosef("sfn.CreateStateMachine call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6753,16 +6937,16 @@ func (cmd *DeleteContainertask) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete containertask: AWS command returned nil output")
+			renv.Log().Warning("create statemachine: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete containertask '%s' done", extracted)
+		renv.Log().Verbosef("create statemachine '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete containertask done")
+		renv.Log().Verbose("create statemachine done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6774,36 +6958,40 @@ func (cmd *DeleteContainertask) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *DeleteContainertask) inject(params map[string]interface{}) error {
+func (cmd *CreateStatemachine) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("statemachine"), nil
+}
+
+func (cmd *CreateStatemachine) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDatabase {
-	cmd := new(DeleteDatabase)
+func NewCreateSubnet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSubnet {
+	cmd := new(CreateSubnet)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = rds.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteDatabase) SetApi(api rdsiface.RDSAPI) {
+func (cmd *CreateSubnet) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSubnet) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSubnet) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6814,13 +7002,13 @@ func (cmd *DeleteDatabase) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &rds.DeleteDBInstanceInput{}
+	input := &ec2.CreateSubnetInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in rds.DeleteDBInstanceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateSubnetInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteDBInstance(input)
-	renv.Log().ExtraVerbosef("rds.DeleteDBInstance call took %s", time.Since(start))
+	output, err := cmd.api.CreateSubnet(input)
+	renv.Log().ExtraVerbosef("ec2.CreateSubnet call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6828,16 +7016,16 @@ func (cmd *DeleteDatabase) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete database: AWS command returned nil output")
+			renv.Log().Warning("create subnet: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete database '%s' done", extracted)
+		renv.Log().Verbosef("create subnet '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete database done")
+		renv.Log().Verbose("create subnet done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6849,40 +7037,67 @@ func (cmd *DeleteDatabase) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+func (cmd *CreateSubnet) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateSubnetInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CreateSubnetInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.CreateSubnet(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateSubnet call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create subnet ok")
+			return fakeDryRunId("subnet"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *DeleteDatabase) inject(params map[string]interface{}) error {
+func (cmd *CreateSubnet) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteDbsubnetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDbsubnetgroup {
-	cmd := new(DeleteDbsubnetgroup)
+func NewCreateSubscription(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateSubscription {
+	cmd := new(CreateSubscription)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = rds.New(sess)
+		cmd.api = awspool.Default.Client(sess, "sns", func() interface{} { return sns.New(sess) }).(snsiface.SNSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteDbsubnetgroup) SetApi(api rdsiface.RDSAPI) {
+func (cmd *CreateSubscription) SetApi(api snsiface.SNSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteDbsubnetgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSubscription) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteDbsubnetgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateSubscription) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6893,13 +7108,13 @@ func (cmd *DeleteDbsubnetgroup) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	input := &rds.DeleteDBSubnetGroupInput{}
+	input := &sns.SubscribeInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in rds.DeleteDBSubnetGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in sns.SubscribeInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteDBSubnetGroup(input)
-	renv.Log().ExtraVerbosef("rds.DeleteDBSubnetGroup call took %s", time.Since(start))
+	output, err := cmd.api.Subscribe(input)
+	renv.Log().ExtraVerbosef("sns.Subscribe call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -6907,16 +7122,16 @@ func (cmd *DeleteDbsubnetgroup) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete dbsubnetgroup: AWS command returned nil output")
+			renv.Log().Warning("create subscription: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete dbsubnetgroup '%s' done", extracted)
+		renv.Log().Verbosef("create subscription '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete dbsubnetgroup done")
+		renv.Log().Verbose("create subscription done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -6928,40 +7143,40 @@ func (cmd *DeleteDbsubnetgroup) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *DeleteDbsubnetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("dbsubnetgroup"), nil
+func (cmd *CreateSubscription) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("subscription"), nil
 }
 
-func (cmd *DeleteDbsubnetgroup) inject(params map[string]interface{}) error {
+func (cmd *CreateSubscription) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDistribution {
-	cmd := new(DeleteDistribution)
+func NewCreateTable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTable {
+	cmd := new(CreateTable)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudfront.New(sess)
+		cmd.api = awspool.Default.Client(sess, "dynamodb", func() interface{} { return dynamodb.New(sess) }).(dynamodbiface.DynamoDBAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteDistribution) SetApi(api cloudfrontiface.CloudFrontAPI) {
+func (cmd *CreateTable) SetApi(api dynamodbiface.DynamoDBAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteDistribution) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteDistribution) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -6980,16 +7195,16 @@ func (cmd *DeleteDistribution) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete distribution: AWS command returned nil output")
+			renv.Log().Warning("create table: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete distribution '%s' done", extracted)
+		renv.Log().Verbosef("create table '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete distribution done")
+		renv.Log().Verbose("create table done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7001,40 +7216,40 @@ func (cmd *DeleteDistribution) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *DeleteDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("distribution"), nil
+func (cmd *CreateTable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("table"), nil
 }
 
-func (cmd *DeleteDistribution) inject(params map[string]interface{}) error {
+func (cmd *CreateTable) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteElasticip {
-	cmd := new(DeleteElasticip)
+func NewCreateTag(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTag {
+	cmd := new(CreateTag)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteElasticip) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateTag) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteElasticip) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTag) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteElasticip) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTag) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7045,13 +7260,7 @@ func (cmd *DeleteElasticip) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.ReleaseAddressInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.ReleaseAddressInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.ReleaseAddress(input)
-	renv.Log().ExtraVerbosef("ec2.ReleaseAddress call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7059,16 +7268,16 @@ func (cmd *DeleteElasticip) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete elasticip: AWS command returned nil output")
+			renv.Log().Warning("create tag: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete elasticip '%s' done", extracted)
+		renv.Log().Verbosef("create tag '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete elasticip done")
+		renv.Log().Verbose("create tag done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7080,61 +7289,36 @@ func (cmd *DeleteElasticip) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteElasticip) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
+func (cmd *CreateTag) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
 
-	input := &ec2.ReleaseAddressInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.ReleaseAddressInput: %s", err)
+func NewCreateTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTargetgroup {
+	cmd := new(CreateTargetgroup)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
 	}
+	cmd.graph = g
+	return cmd
+}
 
-	start := time.Now()
-	_, err := cmd.api.ReleaseAddress(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.ReleaseAddress call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete elasticip ok")
-			return fakeDryRunId("elasticip"), nil
-		}
-	}
-
-	return nil, err
-}
-
-func (cmd *DeleteElasticip) inject(params map[string]interface{}) error {
-	return structSetter(cmd, params)
-}
-
-func NewDeleteFunction(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteFunction {
-	cmd := new(DeleteFunction)
-	if len(l) > 0 {
-		cmd.logger = l[0]
-	} else {
-		cmd.logger = logger.DiscardLogger
-	}
-	if sess != nil {
-		cmd.api = lambda.New(sess)
-	}
-	cmd.graph = g
-	return cmd
-}
-
-func (cmd *DeleteFunction) SetApi(api lambdaiface.LambdaAPI) {
+func (cmd *CreateTargetgroup) SetApi(api elbv2iface.ELBV2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteFunction) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTargetgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteFunction) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTargetgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7145,13 +7329,13 @@ func (cmd *DeleteFunction) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &lambda.DeleteFunctionInput{}
+	input := &elbv2.CreateTargetGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in lambda.DeleteFunctionInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in elbv2.CreateTargetGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteFunction(input)
-	renv.Log().ExtraVerbosef("lambda.DeleteFunction call took %s", time.Since(start))
+	output, err := cmd.api.CreateTargetGroup(input)
+	renv.Log().ExtraVerbosef("elbv2.CreateTargetGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7159,16 +7343,16 @@ func (cmd *DeleteFunction) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete function: AWS command returned nil output")
+			renv.Log().Warning("create targetgroup: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete function '%s' done", extracted)
+		renv.Log().Verbosef("create targetgroup '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete function done")
+		renv.Log().Verbose("create targetgroup done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7180,40 +7364,40 @@ func (cmd *DeleteFunction) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteFunction) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("function"), nil
+func (cmd *CreateTargetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("targetgroup"), nil
 }
 
-func (cmd *DeleteFunction) inject(params map[string]interface{}) error {
+func (cmd *CreateTargetgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteGroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteGroup {
-	cmd := new(DeleteGroup)
+func NewCreateTopic(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTopic {
+	cmd := new(CreateTopic)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "sns", func() interface{} { return sns.New(sess) }).(snsiface.SNSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteGroup) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateTopic) SetApi(api snsiface.SNSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteGroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTopic) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteGroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTopic) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7224,13 +7408,13 @@ func (cmd *DeleteGroup) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &iam.DeleteGroupInput{}
+	input := &sns.CreateTopicInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.DeleteGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in sns.CreateTopicInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteGroup(input)
-	renv.Log().ExtraVerbosef("iam.DeleteGroup call took %s", time.Since(start))
+	output, err := cmd.api.CreateTopic(input)
+	renv.Log().ExtraVerbosef("sns.CreateTopic call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7238,16 +7422,16 @@ func (cmd *DeleteGroup) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete group: AWS command returned nil output")
+			renv.Log().Warning("create topic: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete group '%s' done", extracted)
+		renv.Log().Verbosef("create topic '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete group done")
+		renv.Log().Verbose("create topic done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7259,40 +7443,40 @@ func (cmd *DeleteGroup) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *DeleteGroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("group"), nil
+func (cmd *CreateTopic) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("topic"), nil
 }
 
-func (cmd *DeleteGroup) inject(params map[string]interface{}) error {
+func (cmd *CreateTopic) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteImage {
-	cmd := new(DeleteImage)
+func NewCreateTrafficpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTrafficpolicy {
+	cmd := new(CreateTrafficpolicy)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteImage) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateTrafficpolicy) SetApi(api route53iface.Route53API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteImage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTrafficpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteImage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTrafficpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7303,7 +7487,13 @@ func (cmd *DeleteImage) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &route53.CreateTrafficPolicyInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in route53.CreateTrafficPolicyInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CreateTrafficPolicy(input)
+	renv.Log().ExtraVerbosef("route53.CreateTrafficPolicy call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7311,16 +7501,16 @@ func (cmd *DeleteImage) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete image: AWS command returned nil output")
+			renv.Log().Warning("create trafficpolicy: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete image '%s' done", extracted)
+		renv.Log().Verbosef("create trafficpolicy '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete image done")
+		renv.Log().Verbose("create trafficpolicy done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7332,36 +7522,40 @@ func (cmd *DeleteImage) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *DeleteImage) inject(params map[string]interface{}) error {
+func (cmd *CreateTrafficpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("trafficpolicy"), nil
+}
+
+func (cmd *CreateTrafficpolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInstance {
-	cmd := new(DeleteInstance)
+func NewCreateTrafficpolicyinstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateTrafficpolicyinstance {
+	cmd := new(CreateTrafficpolicyinstance)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteInstance) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateTrafficpolicyinstance) SetApi(api route53iface.Route53API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTrafficpolicyinstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateTrafficpolicyinstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7372,13 +7566,13 @@ func (cmd *DeleteInstance) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.TerminateInstancesInput{}
+	input := &route53.CreateTrafficPolicyInstanceInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.TerminateInstancesInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in route53.CreateTrafficPolicyInstanceInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.TerminateInstances(input)
-	renv.Log().ExtraVerbosef("ec2.TerminateInstances call took %s", time.Since(start))
+	output, err := cmd.api.CreateTrafficPolicyInstance(input)
+	renv.Log().ExtraVerbosef("route53.CreateTrafficPolicyInstance call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7386,16 +7580,16 @@ func (cmd *DeleteInstance) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete instance: AWS command returned nil output")
+			renv.Log().Warning("create trafficpolicyinstance: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete instance '%s' done", extracted)
+		renv.Log().Verbosef("create trafficpolicyinstance '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete instance done")
+		renv.Log().Verbose("create trafficpolicyinstance done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7407,61 +7601,40 @@ func (cmd *DeleteInstance) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.TerminateInstancesInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.TerminateInstancesInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.TerminateInstances(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.TerminateInstances call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete instance ok")
-			return fakeDryRunId("instance"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *CreateTrafficpolicyinstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("trafficpolicyinstance"), nil
 }
 
-func (cmd *DeleteInstance) inject(params map[string]interface{}) error {
+func (cmd *CreateTrafficpolicyinstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInstanceprofile {
-	cmd := new(DeleteInstanceprofile)
+func NewCreateUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateUser {
+	cmd := new(CreateUser)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteInstanceprofile) SetApi(api iamiface.IAMAPI) {
+func (cmd *CreateUser) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteInstanceprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateUser) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteInstanceprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateUser) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7472,13 +7645,13 @@ func (cmd *DeleteInstanceprofile) run(renv env.Running, params map[string]interf
 		}
 	}
 
-	input := &iam.DeleteInstanceProfileInput{}
+	input := &iam.CreateUserInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.DeleteInstanceProfileInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in iam.CreateUserInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteInstanceProfile(input)
-	renv.Log().ExtraVerbosef("iam.DeleteInstanceProfile call took %s", time.Since(start))
+	output, err := cmd.api.CreateUser(input)
+	renv.Log().ExtraVerbosef("iam.CreateUser call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7486,16 +7659,16 @@ func (cmd *DeleteInstanceprofile) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete instanceprofile: AWS command returned nil output")
+			renv.Log().Warning("create user: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete instanceprofile '%s' done", extracted)
+		renv.Log().Verbosef("create user '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete instanceprofile done")
+		renv.Log().Verbose("create user done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7507,40 +7680,40 @@ func (cmd *DeleteInstanceprofile) run(renv env.Running, params map[string]interf
 	return extracted, nil
 }
 
-func (cmd *DeleteInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instanceprofile"), nil
+func (cmd *CreateUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("user"), nil
 }
 
-func (cmd *DeleteInstanceprofile) inject(params map[string]interface{}) error {
+func (cmd *CreateUser) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInternetgateway {
-	cmd := new(DeleteInternetgateway)
+func NewCreateVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateVolume {
+	cmd := new(CreateVolume)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteInternetgateway) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateVolume) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteInternetgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateVolume) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteInternetgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateVolume) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7551,13 +7724,13 @@ func (cmd *DeleteInternetgateway) run(renv env.Running, params map[string]interf
 		}
 	}
 
-	input := &ec2.DeleteInternetGatewayInput{}
+	input := &ec2.CreateVolumeInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteInternetGatewayInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateVolumeInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteInternetGateway(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteInternetGateway call took %s", time.Since(start))
+	output, err := cmd.api.CreateVolume(input)
+	renv.Log().ExtraVerbosef("ec2.CreateVolume call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7565,16 +7738,16 @@ func (cmd *DeleteInternetgateway) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete internetgateway: AWS command returned nil output")
+			renv.Log().Warning("create volume: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete internetgateway '%s' done", extracted)
+		renv.Log().Verbosef("create volume '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete internetgateway done")
+		renv.Log().Verbose("create volume done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7586,61 +7759,67 @@ func (cmd *DeleteInternetgateway) run(renv env.Running, params map[string]interf
 	return extracted, nil
 }
 
-func (cmd *DeleteInternetgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateVolume) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
-	input := &ec2.DeleteInternetGatewayInput{}
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateVolumeInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteInternetGatewayInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateVolumeInput: %s", err)
 	}
 
 	start := time.Now()
-	_, err := cmd.api.DeleteInternetGateway(input)
+	_, err := cmd.api.CreateVolume(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteInternetGateway call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete internetgateway ok")
-			return fakeDryRunId("internetgateway"), nil
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateVolume call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create volume ok")
+			return fakeDryRunId("volume"), nil
 		}
 	}
 
 	return nil, err
 }
 
-func (cmd *DeleteInternetgateway) inject(params map[string]interface{}) error {
+func (cmd *CreateVolume) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteKeypair(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteKeypair {
-	cmd := new(DeleteKeypair)
+func NewCreateVpc(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateVpc {
+	cmd := new(CreateVpc)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteKeypair) SetApi(api ec2iface.EC2API) {
+func (cmd *CreateVpc) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteKeypair) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateVpc) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteKeypair) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateVpc) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7651,13 +7830,13 @@ func (cmd *DeleteKeypair) run(renv env.Running, params map[string]interface{}) (
 		}
 	}
 
-	input := &ec2.DeleteKeyPairInput{}
+	input := &ec2.CreateVpcInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteKeyPairInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateVpcInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteKeyPair(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteKeyPair call took %s", time.Since(start))
+	output, err := cmd.api.CreateVpc(input)
+	renv.Log().ExtraVerbosef("ec2.CreateVpc call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7665,16 +7844,16 @@ func (cmd *DeleteKeypair) run(renv env.Running, params map[string]interface{}) (
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete keypair: AWS command returned nil output")
+			renv.Log().Warning("create vpc: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete keypair '%s' done", extracted)
+		renv.Log().Verbosef("create vpc '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete keypair done")
+		renv.Log().Verbose("create vpc done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7686,61 +7865,67 @@ func (cmd *DeleteKeypair) run(renv env.Running, params map[string]interface{}) (
 	return extracted, nil
 }
 
-func (cmd *DeleteKeypair) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateVpc) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
-	input := &ec2.DeleteKeyPairInput{}
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateVpcInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteKeyPairInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateVpcInput: %s", err)
 	}
 
 	start := time.Now()
-	_, err := cmd.api.DeleteKeyPair(input)
+	_, err := cmd.api.CreateVpc(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteKeyPair call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete keypair ok")
-			return fakeDryRunId("keypair"), nil
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateVpc call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: create vpc ok")
+			return fakeDryRunId("vpc"), nil
 		}
 	}
 
 	return nil, err
 }
 
-func (cmd *DeleteKeypair) inject(params map[string]interface{}) error {
+func (cmd *CreateVpc) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteLaunchconfiguration(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLaunchconfiguration {
-	cmd := new(DeleteLaunchconfiguration)
+func NewCreateZone(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *CreateZone {
+	cmd := new(CreateZone)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = autoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteLaunchconfiguration) SetApi(api autoscalingiface.AutoScalingAPI) {
+func (cmd *CreateZone) SetApi(api route53iface.Route53API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteLaunchconfiguration) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateZone) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteLaunchconfiguration) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *CreateZone) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7751,13 +7936,13 @@ func (cmd *DeleteLaunchconfiguration) run(renv env.Running, params map[string]in
 		}
 	}
 
-	input := &autoscaling.DeleteLaunchConfigurationInput{}
+	input := &route53.CreateHostedZoneInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in autoscaling.DeleteLaunchConfigurationInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in route53.CreateHostedZoneInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteLaunchConfiguration(input)
-	renv.Log().ExtraVerbosef("autoscaling.DeleteLaunchConfiguration call took %s", time.Since(start))
+	output, err := cmd.api.CreateHostedZone(input)
+	renv.Log().ExtraVerbosef("route53.CreateHostedZone call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7765,16 +7950,16 @@ func (cmd *DeleteLaunchconfiguration) run(renv env.Running, params map[string]in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete launchconfiguration: AWS command returned nil output")
+			renv.Log().Warning("create zone: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete launchconfiguration '%s' done", extracted)
+		renv.Log().Verbosef("create zone '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete launchconfiguration done")
+		renv.Log().Verbose("create zone done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7786,40 +7971,40 @@ func (cmd *DeleteLaunchconfiguration) run(renv env.Running, params map[string]in
 	return extracted, nil
 }
 
-func (cmd *DeleteLaunchconfiguration) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("launchconfiguration"), nil
+func (cmd *CreateZone) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("zone"), nil
 }
 
-func (cmd *DeleteLaunchconfiguration) inject(params map[string]interface{}) error {
+func (cmd *CreateZone) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteListener(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteListener {
-	cmd := new(DeleteListener)
+func NewDeleteAccesskey(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAccesskey {
+	cmd := new(DeleteAccesskey)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteListener) SetApi(api elbv2iface.ELBV2API) {
+func (cmd *DeleteAccesskey) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteListener) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAccesskey) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteListener) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAccesskey) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7830,13 +8015,13 @@ func (cmd *DeleteListener) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &elbv2.DeleteListenerInput{}
+	input := &iam.DeleteAccessKeyInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in elbv2.DeleteListenerInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in iam.DeleteAccessKeyInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteListener(input)
-	renv.Log().ExtraVerbosef("elbv2.DeleteListener call took %s", time.Since(start))
+	output, err := cmd.api.DeleteAccessKey(input)
+	renv.Log().ExtraVerbosef("iam.DeleteAccessKey call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7844,16 +8029,16 @@ func (cmd *DeleteListener) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete listener: AWS command returned nil output")
+			renv.Log().Warning("delete accesskey: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete listener '%s' done", extracted)
+		renv.Log().Verbosef("delete accesskey '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete listener done")
+		renv.Log().Verbose("delete accesskey done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7865,40 +8050,40 @@ func (cmd *DeleteListener) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteListener) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("listener"), nil
+func (cmd *DeleteAccesskey) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("accesskey"), nil
 }
 
-func (cmd *DeleteListener) inject(params map[string]interface{}) error {
+func (cmd *DeleteAccesskey) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteLoadbalancer(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLoadbalancer {
-	cmd := new(DeleteLoadbalancer)
+func NewDeleteAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAlarm {
+	cmd := new(DeleteAlarm)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudwatch", func() interface{} { return cloudwatch.New(sess) }).(cloudwatchiface.CloudWatchAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteLoadbalancer) SetApi(api elbv2iface.ELBV2API) {
+func (cmd *DeleteAlarm) SetApi(api cloudwatchiface.CloudWatchAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteLoadbalancer) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAlarm) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteLoadbalancer) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAlarm) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7909,13 +8094,13 @@ func (cmd *DeleteLoadbalancer) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	input := &elbv2.DeleteLoadBalancerInput{}
+	input := &cloudwatch.DeleteAlarmsInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in elbv2.DeleteLoadBalancerInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in cloudwatch.DeleteAlarmsInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteLoadBalancer(input)
-	renv.Log().ExtraVerbosef("elbv2.DeleteLoadBalancer call took %s", time.Since(start))
+	output, err := cmd.api.DeleteAlarms(input)
+	renv.Log().ExtraVerbosef("cloudwatch.DeleteAlarms call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -7923,16 +8108,16 @@ func (cmd *DeleteLoadbalancer) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete loadbalancer: AWS command returned nil output")
+			renv.Log().Warning("delete alarm: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete loadbalancer '%s' done", extracted)
+		renv.Log().Verbosef("delete alarm '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete loadbalancer done")
+		renv.Log().Verbose("delete alarm done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -7944,40 +8129,40 @@ func (cmd *DeleteLoadbalancer) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *DeleteLoadbalancer) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loadbalancer"), nil
+func (cmd *DeleteAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("alarm"), nil
 }
 
-func (cmd *DeleteLoadbalancer) inject(params map[string]interface{}) error {
+func (cmd *DeleteAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteLoginprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLoginprofile {
-	cmd := new(DeleteLoginprofile)
+func NewDeleteAlias(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAlias {
+	cmd := new(DeleteAlias)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "kms", func() interface{} { return kms.New(sess) }).(kmsiface.KMSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteLoginprofile) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteAlias) SetApi(api kmsiface.KMSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteLoginprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAlias) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteLoginprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAlias) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -7988,13 +8173,13 @@ func (cmd *DeleteLoginprofile) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	input := &iam.DeleteLoginProfileInput{}
+	input := &kms.DeleteAliasInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.DeleteLoginProfileInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in kms.DeleteAliasInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteLoginProfile(input)
-	renv.Log().ExtraVerbosef("iam.DeleteLoginProfile call took %s", time.Since(start))
+	output, err := cmd.api.DeleteAlias(input)
+	renv.Log().ExtraVerbosef("kms.DeleteAlias call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8002,16 +8187,16 @@ func (cmd *DeleteLoginprofile) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete loginprofile: AWS command returned nil output")
+			renv.Log().Warning("delete alias: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete loginprofile '%s' done", extracted)
+		renv.Log().Verbosef("delete alias '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete loginprofile done")
+		renv.Log().Verbose("delete alias done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8023,40 +8208,40 @@ func (cmd *DeleteLoginprofile) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *DeleteLoginprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("loginprofile"), nil
+func (cmd *DeleteAlias) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("alias"), nil
 }
 
-func (cmd *DeleteLoginprofile) inject(params map[string]interface{}) error {
+func (cmd *DeleteAlias) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteMfadevice {
-	cmd := new(DeleteMfadevice)
+func NewDeleteApideployment(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteApideployment {
+	cmd := new(DeleteApideployment)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteMfadevice) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteApideployment) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteMfadevice) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteApideployment) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteMfadevice) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteApideployment) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8067,13 +8252,13 @@ func (cmd *DeleteMfadevice) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &iam.DeleteVirtualMFADeviceInput{}
+	input := &apigateway.DeleteDeploymentInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.DeleteVirtualMFADeviceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in apigateway.DeleteDeploymentInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteVirtualMFADevice(input)
-	renv.Log().ExtraVerbosef("iam.DeleteVirtualMFADevice call took %s", time.Since(start))
+	output, err := cmd.api.DeleteDeployment(input)
+	renv.Log().ExtraVerbosef("apigateway.DeleteDeployment call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8081,16 +8266,16 @@ func (cmd *DeleteMfadevice) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete mfadevice: AWS command returned nil output")
+			renv.Log().Warning("delete apideployment: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete mfadevice '%s' done", extracted)
+		renv.Log().Verbosef("delete apideployment '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete mfadevice done")
+		renv.Log().Verbose("delete apideployment done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8102,40 +8287,40 @@ func (cmd *DeleteMfadevice) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("mfadevice"), nil
+func (cmd *DeleteApideployment) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("apideployment"), nil
 }
 
-func (cmd *DeleteMfadevice) inject(params map[string]interface{}) error {
+func (cmd *DeleteApideployment) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteNatgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteNatgateway {
-	cmd := new(DeleteNatgateway)
+func NewDeleteApimethod(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteApimethod {
+	cmd := new(DeleteApimethod)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteNatgateway) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteApimethod) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteNatgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteApimethod) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteNatgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteApimethod) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8146,13 +8331,13 @@ func (cmd *DeleteNatgateway) run(renv env.Running, params map[string]interface{}
 		}
 	}
 
-	input := &ec2.DeleteNatGatewayInput{}
+	input := &apigateway.DeleteMethodInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteNatGatewayInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in apigateway.DeleteMethodInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteNatGateway(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteNatGateway call took %s", time.Since(start))
+	output, err := cmd.api.DeleteMethod(input)
+	renv.Log().ExtraVerbosef("apigateway.DeleteMethod call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8160,16 +8345,16 @@ func (cmd *DeleteNatgateway) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete natgateway: AWS command returned nil output")
+			renv.Log().Warning("delete apimethod: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete natgateway '%s' done", extracted)
+		renv.Log().Verbosef("delete apimethod '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete natgateway done")
+		renv.Log().Verbose("delete apimethod done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8181,40 +8366,40 @@ func (cmd *DeleteNatgateway) run(renv env.Running, params map[string]interface{}
 	return extracted, nil
 }
 
-func (cmd *DeleteNatgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("natgateway"), nil
+func (cmd *DeleteApimethod) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("apimethod"), nil
 }
 
-func (cmd *DeleteNatgateway) inject(params map[string]interface{}) error {
+func (cmd *DeleteApimethod) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteNetworkinterface {
-	cmd := new(DeleteNetworkinterface)
+func NewDeleteApiresource(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteApiresource {
+	cmd := new(DeleteApiresource)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteNetworkinterface) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteApiresource) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteNetworkinterface) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteApiresource) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteNetworkinterface) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteApiresource) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8225,13 +8410,13 @@ func (cmd *DeleteNetworkinterface) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	input := &ec2.DeleteNetworkInterfaceInput{}
+	input := &apigateway.DeleteResourceInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteNetworkInterfaceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in apigateway.DeleteResourceInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteNetworkInterface(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteNetworkInterface call took %s", time.Since(start))
+	output, err := cmd.api.DeleteResource(input)
+	renv.Log().ExtraVerbosef("apigateway.DeleteResource call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8239,16 +8424,16 @@ func (cmd *DeleteNetworkinterface) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete networkinterface: AWS command returned nil output")
+			renv.Log().Warning("delete apiresource: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete networkinterface '%s' done", extracted)
+		renv.Log().Verbosef("delete apiresource '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete networkinterface done")
+		renv.Log().Verbose("delete apiresource done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8260,61 +8445,40 @@ func (cmd *DeleteNetworkinterface) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *DeleteNetworkinterface) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DeleteNetworkInterfaceInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteNetworkInterfaceInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DeleteNetworkInterface(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteNetworkInterface call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete networkinterface ok")
-			return fakeDryRunId("networkinterface"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteApiresource) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("apiresource"), nil
 }
 
-func (cmd *DeleteNetworkinterface) inject(params map[string]interface{}) error {
+func (cmd *DeleteApiresource) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeletePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeletePolicy {
-	cmd := new(DeletePolicy)
+func NewDeleteApistage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteApistage {
+	cmd := new(DeleteApistage)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeletePolicy) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteApistage) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeletePolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteApistage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeletePolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteApistage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8325,13 +8489,13 @@ func (cmd *DeletePolicy) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &iam.DeletePolicyInput{}
+	input := &apigateway.DeleteStageInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.DeletePolicyInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in apigateway.DeleteStageInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeletePolicy(input)
-	renv.Log().ExtraVerbosef("iam.DeletePolicy call took %s", time.Since(start))
+	output, err := cmd.api.DeleteStage(input)
+	renv.Log().ExtraVerbosef("apigateway.DeleteStage call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8339,16 +8503,16 @@ func (cmd *DeletePolicy) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete policy: AWS command returned nil output")
+			renv.Log().Warning("delete apistage: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete policy '%s' done", extracted)
+		renv.Log().Verbosef("delete apistage '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete policy done")
+		renv.Log().Verbose("delete apistage done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8360,40 +8524,40 @@ func (cmd *DeletePolicy) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *DeletePolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("policy"), nil
+func (cmd *DeleteApistage) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("apistage"), nil
 }
 
-func (cmd *DeletePolicy) inject(params map[string]interface{}) error {
+func (cmd *DeleteApistage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteQueue(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteQueue {
-	cmd := new(DeleteQueue)
+func NewDeleteAppscalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAppscalingpolicy {
+	cmd := new(DeleteAppscalingpolicy)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = sqs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "applicationautoscaling", func() interface{} { return applicationautoscaling.New(sess) }).(applicationautoscalingiface.ApplicationAutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteQueue) SetApi(api sqsiface.SQSAPI) {
+func (cmd *DeleteAppscalingpolicy) SetApi(api applicationautoscalingiface.ApplicationAutoScalingAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteQueue) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAppscalingpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteQueue) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAppscalingpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8404,13 +8568,13 @@ func (cmd *DeleteQueue) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &sqs.DeleteQueueInput{}
+	input := &applicationautoscaling.DeleteScalingPolicyInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in sqs.DeleteQueueInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in applicationautoscaling.DeleteScalingPolicyInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteQueue(input)
-	renv.Log().ExtraVerbosef("sqs.DeleteQueue call took %s", time.Since(start))
+	output, err := cmd.api.DeleteScalingPolicy(input)
+	renv.Log().ExtraVerbosef("applicationautoscaling.DeleteScalingPolicy call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8418,16 +8582,16 @@ func (cmd *DeleteQueue) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete queue: AWS command returned nil output")
+			renv.Log().Warning("delete appscalingpolicy: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete queue '%s' done", extracted)
+		renv.Log().Verbosef("delete appscalingpolicy '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete queue done")
+		renv.Log().Verbose("delete appscalingpolicy done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8439,40 +8603,40 @@ func (cmd *DeleteQueue) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *DeleteQueue) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("queue"), nil
+func (cmd *DeleteAppscalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("appscalingpolicy"), nil
 }
 
-func (cmd *DeleteQueue) inject(params map[string]interface{}) error {
+func (cmd *DeleteAppscalingpolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteRecord(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRecord {
-	cmd := new(DeleteRecord)
+func NewDeleteAppscalingtarget(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteAppscalingtarget {
+	cmd := new(DeleteAppscalingtarget)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = route53.New(sess)
+		cmd.api = awspool.Default.Client(sess, "applicationautoscaling", func() interface{} { return applicationautoscaling.New(sess) }).(applicationautoscalingiface.ApplicationAutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteRecord) SetApi(api route53iface.Route53API) {
+func (cmd *DeleteAppscalingtarget) SetApi(api applicationautoscalingiface.ApplicationAutoScalingAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteRecord) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAppscalingtarget) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteRecord) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteAppscalingtarget) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8483,7 +8647,13 @@ func (cmd *DeleteRecord) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &applicationautoscaling.DeregisterScalableTargetInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in applicationautoscaling.DeregisterScalableTargetInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeregisterScalableTarget(input)
+	renv.Log().ExtraVerbosef("applicationautoscaling.DeregisterScalableTarget call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8491,16 +8661,16 @@ func (cmd *DeleteRecord) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete record: AWS command returned nil output")
+			renv.Log().Warning("delete appscalingtarget: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete record '%s' done", extracted)
+		renv.Log().Verbosef("delete appscalingtarget '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete record done")
+		renv.Log().Verbose("delete appscalingtarget done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8512,40 +8682,40 @@ func (cmd *DeleteRecord) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *DeleteRecord) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("record"), nil
+func (cmd *DeleteAppscalingtarget) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("appscalingtarget"), nil
 }
 
-func (cmd *DeleteRecord) inject(params map[string]interface{}) error {
+func (cmd *DeleteAppscalingtarget) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteRepository(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRepository {
-	cmd := new(DeleteRepository)
+func NewDeleteBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteBucket {
+	cmd := new(DeleteBucket)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecr.New(sess)
+		cmd.api = awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteRepository) SetApi(api ecriface.ECRAPI) {
+func (cmd *DeleteBucket) SetApi(api s3iface.S3API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteRepository) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteBucket) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteRepository) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteBucket) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8556,13 +8726,13 @@ func (cmd *DeleteRepository) run(renv env.Running, params map[string]interface{}
 		}
 	}
 
-	input := &ecr.DeleteRepositoryInput{}
+	input := &s3.DeleteBucketInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ecr.DeleteRepositoryInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in s3.DeleteBucketInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteRepository(input)
-	renv.Log().ExtraVerbosef("ecr.DeleteRepository call took %s", time.Since(start))
+	output, err := cmd.api.DeleteBucket(input)
+	renv.Log().ExtraVerbosef("s3.DeleteBucket call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8570,16 +8740,16 @@ func (cmd *DeleteRepository) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete repository: AWS command returned nil output")
+			renv.Log().Warning("delete bucket: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete repository '%s' done", extracted)
+		renv.Log().Verbosef("delete bucket '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete repository done")
+		renv.Log().Verbose("delete bucket done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8591,40 +8761,40 @@ func (cmd *DeleteRepository) run(renv env.Running, params map[string]interface{}
 	return extracted, nil
 }
 
-func (cmd *DeleteRepository) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("repository"), nil
+func (cmd *DeleteBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("bucket"), nil
 }
 
-func (cmd *DeleteRepository) inject(params map[string]interface{}) error {
+func (cmd *DeleteBucket) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRole {
-	cmd := new(DeleteRole)
+func NewDeleteCachecluster(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteCachecluster {
+	cmd := new(DeleteCachecluster)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elasticache", func() interface{} { return elasticache.New(sess) }).(elasticacheiface.ElastiCacheAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteRole) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteCachecluster) SetApi(api elasticacheiface.ElastiCacheAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteRole) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteCachecluster) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteRole) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteCachecluster) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8635,7 +8805,13 @@ func (cmd *DeleteRole) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &elasticache.DeleteCacheClusterInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in elasticache.DeleteCacheClusterInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteCacheCluster(input)
+	renv.Log().ExtraVerbosef("elasticache.DeleteCacheCluster call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8643,16 +8819,16 @@ func (cmd *DeleteRole) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete role: AWS command returned nil output")
+			renv.Log().Warning("delete cachecluster: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete role '%s' done", extracted)
+		renv.Log().Verbosef("delete cachecluster '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete role done")
+		renv.Log().Verbose("delete cachecluster done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8664,40 +8840,40 @@ func (cmd *DeleteRole) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *DeleteRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("role"), nil
+func (cmd *DeleteCachecluster) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("cachecluster"), nil
 }
 
-func (cmd *DeleteRole) inject(params map[string]interface{}) error {
+func (cmd *DeleteCachecluster) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteRoute(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRoute {
-	cmd := new(DeleteRoute)
+func NewDeleteCertificate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteCertificate {
+	cmd := new(DeleteCertificate)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "acm", func() interface{} { return acm.New(sess) }).(acmiface.ACMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteRoute) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteCertificate) SetApi(api acmiface.ACMAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteRoute) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteCertificate) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteRoute) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteCertificate) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8708,13 +8884,13 @@ func (cmd *DeleteRoute) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &ec2.DeleteRouteInput{}
+	input := &acm.DeleteCertificateInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteRouteInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in acm.DeleteCertificateInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteRoute(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteRoute call took %s", time.Since(start))
+	output, err := cmd.api.DeleteCertificate(input)
+	renv.Log().ExtraVerbosef("acm.DeleteCertificate call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8722,16 +8898,16 @@ func (cmd *DeleteRoute) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete route: AWS command returned nil output")
+			renv.Log().Warning("delete certificate: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete route '%s' done", extracted)
+		renv.Log().Verbosef("delete certificate '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete route done")
+		renv.Log().Verbose("delete certificate done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8743,61 +8919,40 @@ func (cmd *DeleteRoute) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *DeleteRoute) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DeleteRouteInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteRouteInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DeleteRoute(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteRoute call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete route ok")
-			return fakeDryRunId("route"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteCertificate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("certificate"), nil
 }
 
-func (cmd *DeleteRoute) inject(params map[string]interface{}) error {
+func (cmd *DeleteCertificate) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRoutetable {
-	cmd := new(DeleteRoutetable)
+func NewDeleteContainercluster(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteContainercluster {
+	cmd := new(DeleteContainercluster)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteRoutetable) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteContainercluster) SetApi(api ecsiface.ECSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteRoutetable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteContainercluster) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteRoutetable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteContainercluster) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8808,13 +8963,13 @@ func (cmd *DeleteRoutetable) run(renv env.Running, params map[string]interface{}
 		}
 	}
 
-	input := &ec2.DeleteRouteTableInput{}
+	input := &ecs.DeleteClusterInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteRouteTableInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ecs.DeleteClusterInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteRouteTable(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteRouteTable call took %s", time.Since(start))
+	output, err := cmd.api.DeleteCluster(input)
+	renv.Log().ExtraVerbosef("ecs.DeleteCluster call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8822,16 +8977,16 @@ func (cmd *DeleteRoutetable) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete routetable: AWS command returned nil output")
+			renv.Log().Warning("delete containercluster: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete routetable '%s' done", extracted)
+		renv.Log().Verbosef("delete containercluster '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete routetable done")
+		renv.Log().Verbose("delete containercluster done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8843,61 +8998,40 @@ func (cmd *DeleteRoutetable) run(renv env.Running, params map[string]interface{}
 	return extracted, nil
 }
 
-func (cmd *DeleteRoutetable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DeleteRouteTableInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteRouteTableInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DeleteRouteTable(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteRouteTable call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete routetable ok")
-			return fakeDryRunId("routetable"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteContainercluster) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("containercluster"), nil
 }
 
-func (cmd *DeleteRoutetable) inject(params map[string]interface{}) error {
+func (cmd *DeleteContainercluster) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteS3object {
-	cmd := new(DeleteS3object)
+func NewDeleteContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteContainertask {
+	cmd := new(DeleteContainertask)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = s3.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteS3object) SetApi(api s3iface.S3API) {
+func (cmd *DeleteContainertask) SetApi(api ecsiface.ECSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteS3object) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteS3object) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8908,13 +9042,7 @@ func (cmd *DeleteS3object) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &s3.DeleteObjectInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in s3.DeleteObjectInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.DeleteObject(input)
-	renv.Log().ExtraVerbosef("s3.DeleteObject call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -8922,16 +9050,16 @@ func (cmd *DeleteS3object) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete s3object: AWS command returned nil output")
+			renv.Log().Warning("delete containertask: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete s3object '%s' done", extracted)
+		renv.Log().Verbosef("delete containertask '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete s3object done")
+		renv.Log().Verbose("delete containertask done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -8943,40 +9071,36 @@ func (cmd *DeleteS3object) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteS3object) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("s3object"), nil
-}
-
-func (cmd *DeleteS3object) inject(params map[string]interface{}) error {
+func (cmd *DeleteContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteScalinggroup {
-	cmd := new(DeleteScalinggroup)
+func NewDeleteDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDatabase {
+	cmd := new(DeleteDatabase)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = autoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteScalinggroup) SetApi(api autoscalingiface.AutoScalingAPI) {
+func (cmd *DeleteDatabase) SetApi(api rdsiface.RDSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteScalinggroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteScalinggroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -8987,13 +9111,13 @@ func (cmd *DeleteScalinggroup) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	input := &autoscaling.DeleteAutoScalingGroupInput{}
+	input := &rds.DeleteDBInstanceInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in autoscaling.DeleteAutoScalingGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in rds.DeleteDBInstanceInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteAutoScalingGroup(input)
-	renv.Log().ExtraVerbosef("autoscaling.DeleteAutoScalingGroup call took %s", time.Since(start))
+	output, err := cmd.api.DeleteDBInstance(input)
+	renv.Log().ExtraVerbosef("rds.DeleteDBInstance call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9001,16 +9125,16 @@ func (cmd *DeleteScalinggroup) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete scalinggroup: AWS command returned nil output")
+			renv.Log().Warning("delete database: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete scalinggroup '%s' done", extracted)
+		renv.Log().Verbosef("delete database '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete scalinggroup done")
+		renv.Log().Verbose("delete database done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9022,40 +9146,40 @@ func (cmd *DeleteScalinggroup) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *DeleteScalinggroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalinggroup"), nil
+func (cmd *DeleteDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("database"), nil
 }
 
-func (cmd *DeleteScalinggroup) inject(params map[string]interface{}) error {
+func (cmd *DeleteDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteScalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteScalingpolicy {
-	cmd := new(DeleteScalingpolicy)
+func NewDeleteDbsubnetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDbsubnetgroup {
+	cmd := new(DeleteDbsubnetgroup)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = autoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteScalingpolicy) SetApi(api autoscalingiface.AutoScalingAPI) {
+func (cmd *DeleteDbsubnetgroup) SetApi(api rdsiface.RDSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteScalingpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteDbsubnetgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteScalingpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteDbsubnetgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9066,13 +9190,13 @@ func (cmd *DeleteScalingpolicy) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	input := &autoscaling.DeletePolicyInput{}
+	input := &rds.DeleteDBSubnetGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in autoscaling.DeletePolicyInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in rds.DeleteDBSubnetGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeletePolicy(input)
-	renv.Log().ExtraVerbosef("autoscaling.DeletePolicy call took %s", time.Since(start))
+	output, err := cmd.api.DeleteDBSubnetGroup(input)
+	renv.Log().ExtraVerbosef("rds.DeleteDBSubnetGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9080,16 +9204,16 @@ func (cmd *DeleteScalingpolicy) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete scalingpolicy: AWS command returned nil output")
+			renv.Log().Warning("delete dbsubnetgroup: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete scalingpolicy '%s' done", extracted)
+		renv.Log().Verbosef("delete dbsubnetgroup '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete scalingpolicy done")
+		renv.Log().Verbose("delete dbsubnetgroup done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9101,40 +9225,40 @@ func (cmd *DeleteScalingpolicy) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *DeleteScalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("scalingpolicy"), nil
+func (cmd *DeleteDbsubnetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("dbsubnetgroup"), nil
 }
 
-func (cmd *DeleteScalingpolicy) inject(params map[string]interface{}) error {
+func (cmd *DeleteDbsubnetgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSecuritygroup {
-	cmd := new(DeleteSecuritygroup)
+func NewDeleteDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteDistribution {
+	cmd := new(DeleteDistribution)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudfront", func() interface{} { return cloudfront.New(sess) }).(cloudfrontiface.CloudFrontAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteSecuritygroup) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteDistribution) SetApi(api cloudfrontiface.CloudFrontAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteSecuritygroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteDistribution) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteSecuritygroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteDistribution) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9145,13 +9269,7 @@ func (cmd *DeleteSecuritygroup) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	input := &ec2.DeleteSecurityGroupInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteSecurityGroupInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.DeleteSecurityGroup(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteSecurityGroup call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9159,16 +9277,16 @@ func (cmd *DeleteSecuritygroup) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete securitygroup: AWS command returned nil output")
+			renv.Log().Warning("delete distribution: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete securitygroup '%s' done", extracted)
+		renv.Log().Verbosef("delete distribution '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete securitygroup done")
+		renv.Log().Verbose("delete distribution done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9180,61 +9298,40 @@ func (cmd *DeleteSecuritygroup) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *DeleteSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
+func (cmd *DeleteDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("distribution"), nil
+}
 
-	input := &ec2.DeleteSecurityGroupInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteSecurityGroupInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DeleteSecurityGroup(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSecurityGroup call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete securitygroup ok")
-			return fakeDryRunId("securitygroup"), nil
-		}
-	}
-
-	return nil, err
-}
-
-func (cmd *DeleteSecuritygroup) inject(params map[string]interface{}) error {
+func (cmd *DeleteDistribution) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteSnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSnapshot {
-	cmd := new(DeleteSnapshot)
+func NewDeleteElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteElasticip {
+	cmd := new(DeleteElasticip)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteSnapshot) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteElasticip) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteSnapshot) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteElasticip) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteSnapshot) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteElasticip) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9245,13 +9342,13 @@ func (cmd *DeleteSnapshot) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.DeleteSnapshotInput{}
+	input := &ec2.ReleaseAddressInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteSnapshotInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.ReleaseAddressInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteSnapshot(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteSnapshot call took %s", time.Since(start))
+	output, err := cmd.api.ReleaseAddress(input)
+	renv.Log().ExtraVerbosef("ec2.ReleaseAddress call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9259,16 +9356,16 @@ func (cmd *DeleteSnapshot) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete snapshot: AWS command returned nil output")
+			renv.Log().Warning("delete elasticip: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete snapshot '%s' done", extracted)
+		renv.Log().Verbosef("delete elasticip '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete snapshot done")
+		renv.Log().Verbose("delete elasticip done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9280,61 +9377,67 @@ func (cmd *DeleteSnapshot) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DeleteSnapshot) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteElasticip) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
-	input := &ec2.DeleteSnapshotInput{}
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.ReleaseAddressInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteSnapshotInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.ReleaseAddressInput: %s", err)
 	}
 
 	start := time.Now()
-	_, err := cmd.api.DeleteSnapshot(input)
+	_, err := cmd.api.ReleaseAddress(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSnapshot call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete snapshot ok")
-			return fakeDryRunId("snapshot"), nil
+			renv.Log().ExtraVerbosef("dry run: ec2.ReleaseAddress call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete elasticip ok")
+			return fakeDryRunId("elasticip"), nil
 		}
 	}
 
 	return nil, err
 }
 
-func (cmd *DeleteSnapshot) inject(params map[string]interface{}) error {
+func (cmd *DeleteElasticip) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteStack {
-	cmd := new(DeleteStack)
+func NewDeleteEventsourcemapping(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteEventsourcemapping {
+	cmd := new(DeleteEventsourcemapping)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudformation.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteStack) SetApi(api cloudformationiface.CloudFormationAPI) {
+func (cmd *DeleteEventsourcemapping) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteStack) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteEventsourcemapping) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteStack) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteEventsourcemapping) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9345,13 +9448,13 @@ func (cmd *DeleteStack) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &cloudformation.DeleteStackInput{}
+	input := &lambda.DeleteEventSourceMappingInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in cloudformation.DeleteStackInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in lambda.DeleteEventSourceMappingInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteStack(input)
-	renv.Log().ExtraVerbosef("cloudformation.DeleteStack call took %s", time.Since(start))
+	output, err := cmd.api.DeleteEventSourceMapping(input)
+	renv.Log().ExtraVerbosef("lambda.DeleteEventSourceMapping call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9359,16 +9462,16 @@ func (cmd *DeleteStack) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete stack: AWS command returned nil output")
+			renv.Log().Warning("delete eventsourcemapping: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete stack '%s' done", extracted)
+		renv.Log().Verbosef("delete eventsourcemapping '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete stack done")
+		renv.Log().Verbose("delete eventsourcemapping done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9380,40 +9483,40 @@ func (cmd *DeleteStack) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *DeleteStack) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("stack"), nil
+func (cmd *DeleteEventsourcemapping) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("eventsourcemapping"), nil
 }
 
-func (cmd *DeleteStack) inject(params map[string]interface{}) error {
+func (cmd *DeleteEventsourcemapping) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteSubnet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSubnet {
-	cmd := new(DeleteSubnet)
+func NewDeleteFunction(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteFunction {
+	cmd := new(DeleteFunction)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteSubnet) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteFunction) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteSubnet) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteFunction) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteSubnet) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteFunction) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9424,13 +9527,13 @@ func (cmd *DeleteSubnet) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &ec2.DeleteSubnetInput{}
+	input := &lambda.DeleteFunctionInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteSubnetInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in lambda.DeleteFunctionInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteSubnet(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteSubnet call took %s", time.Since(start))
+	output, err := cmd.api.DeleteFunction(input)
+	renv.Log().ExtraVerbosef("lambda.DeleteFunction call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9438,16 +9541,16 @@ func (cmd *DeleteSubnet) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete subnet: AWS command returned nil output")
+			renv.Log().Warning("delete function: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete subnet '%s' done", extracted)
+		renv.Log().Verbosef("delete function '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete subnet done")
+		renv.Log().Verbose("delete function done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9459,61 +9562,40 @@ func (cmd *DeleteSubnet) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *DeleteSubnet) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DeleteSubnetInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteSubnetInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DeleteSubnet(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSubnet call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete subnet ok")
-			return fakeDryRunId("subnet"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteFunction) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("function"), nil
 }
 
-func (cmd *DeleteSubnet) inject(params map[string]interface{}) error {
+func (cmd *DeleteFunction) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteSubscription(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSubscription {
-	cmd := new(DeleteSubscription)
+func NewDeleteFunctionalias(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteFunctionalias {
+	cmd := new(DeleteFunctionalias)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = sns.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteSubscription) SetApi(api snsiface.SNSAPI) {
+func (cmd *DeleteFunctionalias) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteSubscription) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteFunctionalias) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteSubscription) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteFunctionalias) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9524,13 +9606,13 @@ func (cmd *DeleteSubscription) run(renv env.Running, params map[string]interface
 		}
 	}
 
-	input := &sns.UnsubscribeInput{}
+	input := &lambda.DeleteAliasInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in sns.UnsubscribeInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in lambda.DeleteAliasInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.Unsubscribe(input)
-	renv.Log().ExtraVerbosef("sns.Unsubscribe call took %s", time.Since(start))
+	output, err := cmd.api.DeleteAlias(input)
+	renv.Log().ExtraVerbosef("lambda.DeleteAlias call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9538,16 +9620,16 @@ func (cmd *DeleteSubscription) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete subscription: AWS command returned nil output")
+			renv.Log().Warning("delete functionalias: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete subscription '%s' done", extracted)
+		renv.Log().Verbosef("delete functionalias '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete subscription done")
+		renv.Log().Verbose("delete functionalias done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9559,40 +9641,40 @@ func (cmd *DeleteSubscription) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *DeleteSubscription) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("subscription"), nil
+func (cmd *DeleteFunctionalias) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("functionalias"), nil
 }
 
-func (cmd *DeleteSubscription) inject(params map[string]interface{}) error {
+func (cmd *DeleteFunctionalias) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteTag(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTag {
-	cmd := new(DeleteTag)
+func NewDeleteFunctionversion(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteFunctionversion {
+	cmd := new(DeleteFunctionversion)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteTag) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteFunctionversion) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteTag) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteFunctionversion) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteTag) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteFunctionversion) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9603,7 +9685,13 @@ func (cmd *DeleteTag) run(renv env.Running, params map[string]interface{}) (inte
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &lambda.DeleteFunctionInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in lambda.DeleteFunctionInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteFunction(input)
+	renv.Log().ExtraVerbosef("lambda.DeleteFunction call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9611,16 +9699,16 @@ func (cmd *DeleteTag) run(renv env.Running, params map[string]interface{}) (inte
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete tag: AWS command returned nil output")
+			renv.Log().Warning("delete functionversion: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete tag '%s' done", extracted)
+		renv.Log().Verbosef("delete functionversion '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete tag done")
+		renv.Log().Verbose("delete functionversion done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9632,36 +9720,40 @@ func (cmd *DeleteTag) run(renv env.Running, params map[string]interface{}) (inte
 	return extracted, nil
 }
 
-func (cmd *DeleteTag) inject(params map[string]interface{}) error {
+func (cmd *DeleteFunctionversion) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("functionversion"), nil
+}
+
+func (cmd *DeleteFunctionversion) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTargetgroup {
-	cmd := new(DeleteTargetgroup)
+func NewDeleteGroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteGroup {
+	cmd := new(DeleteGroup)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteTargetgroup) SetApi(api elbv2iface.ELBV2API) {
+func (cmd *DeleteGroup) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteTargetgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteGroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteTargetgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteGroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9672,13 +9764,13 @@ func (cmd *DeleteTargetgroup) run(renv env.Running, params map[string]interface{
 		}
 	}
 
-	input := &elbv2.DeleteTargetGroupInput{}
+	input := &iam.DeleteGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in elbv2.DeleteTargetGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in iam.DeleteGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteTargetGroup(input)
-	renv.Log().ExtraVerbosef("elbv2.DeleteTargetGroup call took %s", time.Since(start))
+	output, err := cmd.api.DeleteGroup(input)
+	renv.Log().ExtraVerbosef("iam.DeleteGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9686,16 +9778,16 @@ func (cmd *DeleteTargetgroup) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete targetgroup: AWS command returned nil output")
+			renv.Log().Warning("delete group: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete targetgroup '%s' done", extracted)
+		renv.Log().Verbosef("delete group '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete targetgroup done")
+		renv.Log().Verbose("delete group done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9707,40 +9799,40 @@ func (cmd *DeleteTargetgroup) run(renv env.Running, params map[string]interface{
 	return extracted, nil
 }
 
-func (cmd *DeleteTargetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("targetgroup"), nil
+func (cmd *DeleteGroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("group"), nil
 }
 
-func (cmd *DeleteTargetgroup) inject(params map[string]interface{}) error {
+func (cmd *DeleteGroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteTopic(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTopic {
-	cmd := new(DeleteTopic)
+func NewDeleteHealthcheck(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteHealthcheck {
+	cmd := new(DeleteHealthcheck)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = sns.New(sess)
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteTopic) SetApi(api snsiface.SNSAPI) {
+func (cmd *DeleteHealthcheck) SetApi(api route53iface.Route53API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteTopic) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteHealthcheck) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteTopic) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteHealthcheck) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9751,13 +9843,13 @@ func (cmd *DeleteTopic) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &sns.DeleteTopicInput{}
+	input := &route53.DeleteHealthCheckInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in sns.DeleteTopicInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in route53.DeleteHealthCheckInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteTopic(input)
-	renv.Log().ExtraVerbosef("sns.DeleteTopic call took %s", time.Since(start))
+	output, err := cmd.api.DeleteHealthCheck(input)
+	renv.Log().ExtraVerbosef("route53.DeleteHealthCheck call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9765,16 +9857,16 @@ func (cmd *DeleteTopic) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete topic: AWS command returned nil output")
+			renv.Log().Warning("delete healthcheck: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete topic '%s' done", extracted)
+		renv.Log().Verbosef("delete healthcheck '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete topic done")
+		renv.Log().Verbose("delete healthcheck done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9786,40 +9878,40 @@ func (cmd *DeleteTopic) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *DeleteTopic) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("topic"), nil
+func (cmd *DeleteHealthcheck) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("healthcheck"), nil
 }
 
-func (cmd *DeleteTopic) inject(params map[string]interface{}) error {
+func (cmd *DeleteHealthcheck) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteUser {
-	cmd := new(DeleteUser)
+func NewDeleteImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteImage {
+	cmd := new(DeleteImage)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteUser) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteImage) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteUser) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteImage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteUser) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteImage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9830,13 +9922,7 @@ func (cmd *DeleteUser) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	input := &iam.DeleteUserInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.DeleteUserInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.DeleteUser(input)
-	renv.Log().ExtraVerbosef("iam.DeleteUser call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9844,16 +9930,16 @@ func (cmd *DeleteUser) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete user: AWS command returned nil output")
+			renv.Log().Warning("delete image: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete user '%s' done", extracted)
+		renv.Log().Verbosef("delete image '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete user done")
+		renv.Log().Verbose("delete image done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9865,40 +9951,36 @@ func (cmd *DeleteUser) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *DeleteUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("user"), nil
-}
-
-func (cmd *DeleteUser) inject(params map[string]interface{}) error {
+func (cmd *DeleteImage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteVolume {
-	cmd := new(DeleteVolume)
+func NewDeleteInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInstance {
+	cmd := new(DeleteInstance)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteVolume) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteInstance) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteVolume) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteVolume) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -9909,13 +9991,13 @@ func (cmd *DeleteVolume) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &ec2.DeleteVolumeInput{}
+	input := &ec2.TerminateInstancesInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteVolumeInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.TerminateInstancesInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteVolume(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteVolume call took %s", time.Since(start))
+	output, err := cmd.api.TerminateInstances(input)
+	renv.Log().ExtraVerbosef("ec2.TerminateInstances call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -9923,16 +10005,16 @@ func (cmd *DeleteVolume) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete volume: AWS command returned nil output")
+			renv.Log().Warning("delete instance: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete volume '%s' done", extracted)
+		renv.Log().Verbosef("delete instance '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete volume done")
+		renv.Log().Verbose("delete instance done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -9944,61 +10026,67 @@ func (cmd *DeleteVolume) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *DeleteVolume) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
-	input := &ec2.DeleteVolumeInput{}
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.TerminateInstancesInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteVolumeInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.TerminateInstancesInput: %s", err)
 	}
 
 	start := time.Now()
-	_, err := cmd.api.DeleteVolume(input)
+	_, err := cmd.api.TerminateInstances(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteVolume call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete volume ok")
-			return fakeDryRunId("volume"), nil
+			renv.Log().ExtraVerbosef("dry run: ec2.TerminateInstances call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete instance ok")
+			return fakeDryRunId("instance"), nil
 		}
 	}
 
 	return nil, err
 }
 
-func (cmd *DeleteVolume) inject(params map[string]interface{}) error {
+func (cmd *DeleteInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteVpc(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteVpc {
-	cmd := new(DeleteVpc)
+func NewDeleteInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInstanceprofile {
+	cmd := new(DeleteInstanceprofile)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteVpc) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteInstanceprofile) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *DeleteVpc) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteInstanceprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteVpc) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteInstanceprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10009,13 +10097,13 @@ func (cmd *DeleteVpc) run(renv env.Running, params map[string]interface{}) (inte
 		}
 	}
 
-	input := &ec2.DeleteVpcInput{}
+	input := &iam.DeleteInstanceProfileInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteVpcInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in iam.DeleteInstanceProfileInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteVpc(input)
-	renv.Log().ExtraVerbosef("ec2.DeleteVpc call took %s", time.Since(start))
+	output, err := cmd.api.DeleteInstanceProfile(input)
+	renv.Log().ExtraVerbosef("iam.DeleteInstanceProfile call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10023,16 +10111,16 @@ func (cmd *DeleteVpc) run(renv env.Running, params map[string]interface{}) (inte
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete vpc: AWS command returned nil output")
+			renv.Log().Warning("delete instanceprofile: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete vpc '%s' done", extracted)
+		renv.Log().Verbosef("delete instanceprofile '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete vpc done")
+		renv.Log().Verbose("delete instanceprofile done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10044,61 +10132,40 @@ func (cmd *DeleteVpc) run(renv env.Running, params map[string]interface{}) (inte
 	return extracted, nil
 }
 
-func (cmd *DeleteVpc) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DeleteVpcInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DeleteVpcInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DeleteVpc(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DeleteVpc call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: delete vpc ok")
-			return fakeDryRunId("vpc"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("instanceprofile"), nil
 }
 
-func (cmd *DeleteVpc) inject(params map[string]interface{}) error {
+func (cmd *DeleteInstanceprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDeleteZone(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteZone {
-	cmd := new(DeleteZone)
+func NewDeleteInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteInternetgateway {
+	cmd := new(DeleteInternetgateway)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = route53.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DeleteZone) SetApi(api route53iface.Route53API) {
+func (cmd *DeleteInternetgateway) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DeleteZone) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteInternetgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DeleteZone) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteInternetgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10109,13 +10176,13 @@ func (cmd *DeleteZone) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	input := &route53.DeleteHostedZoneInput{}
+	input := &ec2.DeleteInternetGatewayInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in route53.DeleteHostedZoneInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteInternetGatewayInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeleteHostedZone(input)
-	renv.Log().ExtraVerbosef("route53.DeleteHostedZone call took %s", time.Since(start))
+	output, err := cmd.api.DeleteInternetGateway(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteInternetGateway call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10123,16 +10190,16 @@ func (cmd *DeleteZone) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("delete zone: AWS command returned nil output")
+			renv.Log().Warning("delete internetgateway: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("delete zone '%s' done", extracted)
+		renv.Log().Verbosef("delete internetgateway '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("delete zone done")
+		renv.Log().Verbose("delete internetgateway done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10144,40 +10211,67 @@ func (cmd *DeleteZone) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *DeleteZone) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("zone"), nil
+func (cmd *DeleteInternetgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteInternetGatewayInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteInternetGatewayInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteInternetGateway(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteInternetGateway call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete internetgateway ok")
+			return fakeDryRunId("internetgateway"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *DeleteZone) inject(params map[string]interface{}) error {
+func (cmd *DeleteInternetgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachAlarm {
-	cmd := new(DetachAlarm)
+func NewDeleteKey(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteKey {
+	cmd := new(DeleteKey)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudwatch.New(sess)
+		cmd.api = awspool.Default.Client(sess, "kms", func() interface{} { return kms.New(sess) }).(kmsiface.KMSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachAlarm) SetApi(api cloudwatchiface.CloudWatchAPI) {
+func (cmd *DeleteKey) SetApi(api kmsiface.KMSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DetachAlarm) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteKey) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachAlarm) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteKey) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10188,7 +10282,13 @@ func (cmd *DetachAlarm) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &kms.ScheduleKeyDeletionInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in kms.ScheduleKeyDeletionInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.ScheduleKeyDeletion(input)
+	renv.Log().ExtraVerbosef("kms.ScheduleKeyDeletion call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10196,16 +10296,16 @@ func (cmd *DetachAlarm) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach alarm: AWS command returned nil output")
+			renv.Log().Warning("delete key: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach alarm '%s' done", extracted)
+		renv.Log().Verbosef("delete key '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach alarm done")
+		renv.Log().Verbose("delete key done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10217,40 +10317,40 @@ func (cmd *DetachAlarm) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *DetachAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+func (cmd *DeleteKey) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("key"), nil
 }
 
-func (cmd *DetachAlarm) inject(params map[string]interface{}) error {
+func (cmd *DeleteKey) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachContainertask {
-	cmd := new(DetachContainertask)
+func NewDeleteKeypair(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteKeypair {
+	cmd := new(DeleteKeypair)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachContainertask) SetApi(api ecsiface.ECSAPI) {
+func (cmd *DeleteKeypair) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DetachContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteKeypair) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteKeypair) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10261,7 +10361,13 @@ func (cmd *DetachContainertask) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &ec2.DeleteKeyPairInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteKeyPairInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteKeyPair(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteKeyPair call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10269,16 +10375,16 @@ func (cmd *DetachContainertask) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach containertask: AWS command returned nil output")
+			renv.Log().Warning("delete keypair: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach containertask '%s' done", extracted)
+		renv.Log().Verbosef("delete keypair '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach containertask done")
+		renv.Log().Verbose("delete keypair done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10290,40 +10396,67 @@ func (cmd *DetachContainertask) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *DetachContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containertask"), nil
+func (cmd *DeleteKeypair) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteKeyPairInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteKeyPairInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteKeyPair(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteKeyPair call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete keypair ok")
+			return fakeDryRunId("keypair"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *DetachContainertask) inject(params map[string]interface{}) error {
+func (cmd *DeleteKeypair) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachElasticip {
-	cmd := new(DetachElasticip)
+func NewDeleteLaunchconfiguration(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLaunchconfiguration {
+	cmd := new(DeleteLaunchconfiguration)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachElasticip) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteLaunchconfiguration) SetApi(api autoscalingiface.AutoScalingAPI) {
 	cmd.api = api
 }
 
-func (cmd *DetachElasticip) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteLaunchconfiguration) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachElasticip) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteLaunchconfiguration) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10334,13 +10467,13 @@ func (cmd *DetachElasticip) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.DisassociateAddressInput{}
+	input := &autoscaling.DeleteLaunchConfigurationInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DisassociateAddressInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in autoscaling.DeleteLaunchConfigurationInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DisassociateAddress(input)
-	renv.Log().ExtraVerbosef("ec2.DisassociateAddress call took %s", time.Since(start))
+	output, err := cmd.api.DeleteLaunchConfiguration(input)
+	renv.Log().ExtraVerbosef("autoscaling.DeleteLaunchConfiguration call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10348,16 +10481,16 @@ func (cmd *DetachElasticip) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach elasticip: AWS command returned nil output")
+			renv.Log().Warning("delete launchconfiguration: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach elasticip '%s' done", extracted)
+		renv.Log().Verbosef("delete launchconfiguration '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach elasticip done")
+		renv.Log().Verbose("delete launchconfiguration done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10369,61 +10502,40 @@ func (cmd *DetachElasticip) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DetachElasticip) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DisassociateAddressInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DisassociateAddressInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DisassociateAddress(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DisassociateAddress call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: detach elasticip ok")
-			return fakeDryRunId("elasticip"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteLaunchconfiguration) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("launchconfiguration"), nil
 }
 
-func (cmd *DetachElasticip) inject(params map[string]interface{}) error {
+func (cmd *DeleteLaunchconfiguration) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInstance {
-	cmd := new(DetachInstance)
+func NewDeleteLaunchtemplate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLaunchtemplate {
+	cmd := new(DeleteLaunchtemplate)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachInstance) SetApi(api elbv2iface.ELBV2API) {
+func (cmd *DeleteLaunchtemplate) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DetachInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteLaunchtemplate) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteLaunchtemplate) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10434,13 +10546,13 @@ func (cmd *DetachInstance) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &elbv2.DeregisterTargetsInput{}
+	input := &ec2.DeleteLaunchTemplateInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in elbv2.DeregisterTargetsInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteLaunchTemplateInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeregisterTargets(input)
-	renv.Log().ExtraVerbosef("elbv2.DeregisterTargets call took %s", time.Since(start))
+	output, err := cmd.api.DeleteLaunchTemplate(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteLaunchTemplate call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10448,16 +10560,16 @@ func (cmd *DetachInstance) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach instance: AWS command returned nil output")
+			renv.Log().Warning("delete launchtemplate: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach instance '%s' done", extracted)
+		renv.Log().Verbosef("delete launchtemplate '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach instance done")
+		renv.Log().Verbose("delete launchtemplate done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10469,40 +10581,67 @@ func (cmd *DetachInstance) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DetachInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instance"), nil
+func (cmd *DeleteLaunchtemplate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteLaunchTemplateInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteLaunchTemplateInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteLaunchTemplate(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteLaunchTemplate call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete launchtemplate ok")
+			return fakeDryRunId("launchtemplate"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *DetachInstance) inject(params map[string]interface{}) error {
+func (cmd *DeleteLaunchtemplate) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInstanceprofile {
-	cmd := new(DetachInstanceprofile)
+func NewDeleteListener(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteListener {
+	cmd := new(DeleteListener)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachInstanceprofile) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteListener) SetApi(api elbv2iface.ELBV2API) {
 	cmd.api = api
 }
 
-func (cmd *DetachInstanceprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteListener) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachInstanceprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteListener) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10513,7 +10652,13 @@ func (cmd *DetachInstanceprofile) run(renv env.Running, params map[string]interf
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &elbv2.DeleteListenerInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in elbv2.DeleteListenerInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteListener(input)
+	renv.Log().ExtraVerbosef("elbv2.DeleteListener call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10521,16 +10666,16 @@ func (cmd *DetachInstanceprofile) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach instanceprofile: AWS command returned nil output")
+			renv.Log().Warning("delete listener: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach instanceprofile '%s' done", extracted)
+		renv.Log().Verbosef("delete listener '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach instanceprofile done")
+		renv.Log().Verbose("delete listener done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10542,40 +10687,40 @@ func (cmd *DetachInstanceprofile) run(renv env.Running, params map[string]interf
 	return extracted, nil
 }
 
-func (cmd *DetachInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("instanceprofile"), nil
+func (cmd *DeleteListener) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("listener"), nil
 }
 
-func (cmd *DetachInstanceprofile) inject(params map[string]interface{}) error {
+func (cmd *DeleteListener) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInternetgateway {
-	cmd := new(DetachInternetgateway)
+func NewDeleteLoadbalancer(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLoadbalancer {
+	cmd := new(DeleteLoadbalancer)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachInternetgateway) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteLoadbalancer) SetApi(api elbv2iface.ELBV2API) {
 	cmd.api = api
 }
 
-func (cmd *DetachInternetgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteLoadbalancer) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachInternetgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteLoadbalancer) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10586,13 +10731,13 @@ func (cmd *DetachInternetgateway) run(renv env.Running, params map[string]interf
 		}
 	}
 
-	input := &ec2.DetachInternetGatewayInput{}
+	input := &elbv2.DeleteLoadBalancerInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DetachInternetGatewayInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in elbv2.DeleteLoadBalancerInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DetachInternetGateway(input)
-	renv.Log().ExtraVerbosef("ec2.DetachInternetGateway call took %s", time.Since(start))
+	output, err := cmd.api.DeleteLoadBalancer(input)
+	renv.Log().ExtraVerbosef("elbv2.DeleteLoadBalancer call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10600,16 +10745,16 @@ func (cmd *DetachInternetgateway) run(renv env.Running, params map[string]interf
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach internetgateway: AWS command returned nil output")
+			renv.Log().Warning("delete loadbalancer: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach internetgateway '%s' done", extracted)
+		renv.Log().Verbosef("delete loadbalancer '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach internetgateway done")
+		renv.Log().Verbose("delete loadbalancer done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10621,61 +10766,40 @@ func (cmd *DetachInternetgateway) run(renv env.Running, params map[string]interf
 	return extracted, nil
 }
 
-func (cmd *DetachInternetgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DetachInternetGatewayInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DetachInternetGatewayInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DetachInternetGateway(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DetachInternetGateway call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: detach internetgateway ok")
-			return fakeDryRunId("internetgateway"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteLoadbalancer) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("loadbalancer"), nil
 }
 
-func (cmd *DetachInternetgateway) inject(params map[string]interface{}) error {
+func (cmd *DeleteLoadbalancer) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachMfadevice {
-	cmd := new(DetachMfadevice)
+func NewDeleteLoginprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteLoginprofile {
+	cmd := new(DeleteLoginprofile)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachMfadevice) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteLoginprofile) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *DetachMfadevice) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteLoginprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachMfadevice) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteLoginprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10686,13 +10810,13 @@ func (cmd *DetachMfadevice) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &iam.DeactivateMFADeviceInput{}
+	input := &iam.DeleteLoginProfileInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.DeactivateMFADeviceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in iam.DeleteLoginProfileInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.DeactivateMFADevice(input)
-	renv.Log().ExtraVerbosef("iam.DeactivateMFADevice call took %s", time.Since(start))
+	output, err := cmd.api.DeleteLoginProfile(input)
+	renv.Log().ExtraVerbosef("iam.DeleteLoginProfile call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10700,16 +10824,16 @@ func (cmd *DetachMfadevice) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach mfadevice: AWS command returned nil output")
+			renv.Log().Warning("delete loginprofile: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach mfadevice '%s' done", extracted)
+		renv.Log().Verbosef("delete loginprofile '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach mfadevice done")
+		renv.Log().Verbose("delete loginprofile done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10721,40 +10845,40 @@ func (cmd *DetachMfadevice) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *DetachMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("mfadevice"), nil
+func (cmd *DeleteLoginprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("loginprofile"), nil
 }
 
-func (cmd *DetachMfadevice) inject(params map[string]interface{}) error {
+func (cmd *DeleteLoginprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachNetworkinterface {
-	cmd := new(DetachNetworkinterface)
+func NewDeleteMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteMfadevice {
+	cmd := new(DeleteMfadevice)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachNetworkinterface) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteMfadevice) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *DetachNetworkinterface) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteMfadevice) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachNetworkinterface) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteMfadevice) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10765,7 +10889,13 @@ func (cmd *DetachNetworkinterface) run(renv env.Running, params map[string]inter
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &iam.DeleteVirtualMFADeviceInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in iam.DeleteVirtualMFADeviceInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteVirtualMFADevice(input)
+	renv.Log().ExtraVerbosef("iam.DeleteVirtualMFADevice call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10773,16 +10903,16 @@ func (cmd *DetachNetworkinterface) run(renv env.Running, params map[string]inter
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach networkinterface: AWS command returned nil output")
+			renv.Log().Warning("delete mfadevice: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach networkinterface '%s' done", extracted)
+		renv.Log().Verbosef("delete mfadevice '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach networkinterface done")
+		renv.Log().Verbose("delete mfadevice done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10794,36 +10924,40 @@ func (cmd *DetachNetworkinterface) run(renv env.Running, params map[string]inter
 	return extracted, nil
 }
 
-func (cmd *DetachNetworkinterface) inject(params map[string]interface{}) error {
+func (cmd *DeleteMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("mfadevice"), nil
+}
+
+func (cmd *DeleteMfadevice) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachPolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachPolicy {
-	cmd := new(DetachPolicy)
+func NewDeleteNatgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteNatgateway {
+	cmd := new(DeleteNatgateway)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachPolicy) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteNatgateway) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DetachPolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteNatgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachPolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteNatgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10834,7 +10968,13 @@ func (cmd *DetachPolicy) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &ec2.DeleteNatGatewayInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteNatGatewayInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteNatGateway(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteNatGateway call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10842,16 +10982,16 @@ func (cmd *DetachPolicy) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach policy: AWS command returned nil output")
+			renv.Log().Warning("delete natgateway: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach policy '%s' done", extracted)
+		renv.Log().Verbosef("delete natgateway '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach policy done")
+		renv.Log().Verbose("delete natgateway done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10863,40 +11003,40 @@ func (cmd *DetachPolicy) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *DetachPolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("policy"), nil
+func (cmd *DeleteNatgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("natgateway"), nil
 }
 
-func (cmd *DetachPolicy) inject(params map[string]interface{}) error {
+func (cmd *DeleteNatgateway) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachRole {
-	cmd := new(DetachRole)
+func NewDeleteNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteNetworkinterface {
+	cmd := new(DeleteNetworkinterface)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachRole) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteNetworkinterface) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *DetachRole) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteNetworkinterface) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachRole) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteNetworkinterface) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10907,13 +11047,13 @@ func (cmd *DetachRole) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	input := &iam.RemoveRoleFromInstanceProfileInput{}
+	input := &ec2.DeleteNetworkInterfaceInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.RemoveRoleFromInstanceProfileInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteNetworkInterfaceInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.RemoveRoleFromInstanceProfile(input)
-	renv.Log().ExtraVerbosef("iam.RemoveRoleFromInstanceProfile call took %s", time.Since(start))
+	output, err := cmd.api.DeleteNetworkInterface(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteNetworkInterface call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -10921,16 +11061,16 @@ func (cmd *DetachRole) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach role: AWS command returned nil output")
+			renv.Log().Warning("delete networkinterface: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach role '%s' done", extracted)
+		renv.Log().Verbosef("delete networkinterface '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach role done")
+		renv.Log().Verbose("delete networkinterface done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -10942,40 +11082,67 @@ func (cmd *DetachRole) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *DetachRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("role"), nil
+func (cmd *DeleteNetworkinterface) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteNetworkInterfaceInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteNetworkInterfaceInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteNetworkInterface(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteNetworkInterface call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete networkinterface ok")
+			return fakeDryRunId("networkinterface"), nil
+		}
+	}
+
+	return nil, err
 }
 
-func (cmd *DetachRole) inject(params map[string]interface{}) error {
+func (cmd *DeleteNetworkinterface) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachRoutetable {
-	cmd := new(DetachRoutetable)
+func NewDeleteOriginaccessidentity(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteOriginaccessidentity {
+	cmd := new(DeleteOriginaccessidentity)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudfront", func() interface{} { return cloudfront.New(sess) }).(cloudfrontiface.CloudFrontAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachRoutetable) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteOriginaccessidentity) SetApi(api cloudfrontiface.CloudFrontAPI) {
 	cmd.api = api
 }
 
-func (cmd *DetachRoutetable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteOriginaccessidentity) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachRoutetable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteOriginaccessidentity) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -10986,13 +11153,7 @@ func (cmd *DetachRoutetable) run(renv env.Running, params map[string]interface{}
 		}
 	}
 
-	input := &ec2.DisassociateRouteTableInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DisassociateRouteTableInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.DisassociateRouteTable(input)
-	renv.Log().ExtraVerbosef("ec2.DisassociateRouteTable call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11000,16 +11161,16 @@ func (cmd *DetachRoutetable) run(renv env.Running, params map[string]interface{}
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach routetable: AWS command returned nil output")
+			renv.Log().Warning("delete originaccessidentity: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach routetable '%s' done", extracted)
+		renv.Log().Verbosef("delete originaccessidentity '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach routetable done")
+		renv.Log().Verbose("delete originaccessidentity done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11021,61 +11182,40 @@ func (cmd *DetachRoutetable) run(renv env.Running, params map[string]interface{}
 	return extracted, nil
 }
 
-func (cmd *DetachRoutetable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DisassociateRouteTableInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DisassociateRouteTableInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DisassociateRouteTable(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DisassociateRouteTable call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: detach routetable ok")
-			return fakeDryRunId("routetable"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteOriginaccessidentity) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("originaccessidentity"), nil
 }
 
-func (cmd *DetachRoutetable) inject(params map[string]interface{}) error {
+func (cmd *DeleteOriginaccessidentity) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachSecuritygroup {
-	cmd := new(DetachSecuritygroup)
+func NewDeletePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeletePolicy {
+	cmd := new(DeletePolicy)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachSecuritygroup) SetApi(api ec2iface.EC2API) {
+func (cmd *DeletePolicy) SetApi(api iamiface.IAMAPI) {
 	cmd.api = api
 }
 
-func (cmd *DetachSecuritygroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeletePolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachSecuritygroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeletePolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11086,7 +11226,13 @@ func (cmd *DetachSecuritygroup) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &iam.DeletePolicyInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in iam.DeletePolicyInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeletePolicy(input)
+	renv.Log().ExtraVerbosef("iam.DeletePolicy call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11094,16 +11240,16 @@ func (cmd *DetachSecuritygroup) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach securitygroup: AWS command returned nil output")
+			renv.Log().Warning("delete policy: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach securitygroup '%s' done", extracted)
+		renv.Log().Verbosef("delete policy '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach securitygroup done")
+		renv.Log().Verbose("delete policy done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11115,40 +11261,40 @@ func (cmd *DetachSecuritygroup) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *DetachSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("securitygroup"), nil
+func (cmd *DeletePolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("policy"), nil
 }
 
-func (cmd *DetachSecuritygroup) inject(params map[string]interface{}) error {
+func (cmd *DeletePolicy) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachUser {
-	cmd := new(DetachUser)
+func NewDeleteQueue(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteQueue {
+	cmd := new(DeleteQueue)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "sqs", func() interface{} { return sqs.New(sess) }).(sqsiface.SQSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachUser) SetApi(api iamiface.IAMAPI) {
+func (cmd *DeleteQueue) SetApi(api sqsiface.SQSAPI) {
 	cmd.api = api
 }
 
-func (cmd *DetachUser) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteQueue) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachUser) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteQueue) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11159,13 +11305,13 @@ func (cmd *DetachUser) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	input := &iam.RemoveUserFromGroupInput{}
+	input := &sqs.DeleteQueueInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in iam.RemoveUserFromGroupInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in sqs.DeleteQueueInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.RemoveUserFromGroup(input)
-	renv.Log().ExtraVerbosef("iam.RemoveUserFromGroup call took %s", time.Since(start))
+	output, err := cmd.api.DeleteQueue(input)
+	renv.Log().ExtraVerbosef("sqs.DeleteQueue call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11173,16 +11319,16 @@ func (cmd *DetachUser) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach user: AWS command returned nil output")
+			renv.Log().Warning("delete queue: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach user '%s' done", extracted)
+		renv.Log().Verbosef("delete queue '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach user done")
+		renv.Log().Verbose("delete queue done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11194,40 +11340,40 @@ func (cmd *DetachUser) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *DetachUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("user"), nil
+func (cmd *DeleteQueue) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("queue"), nil
 }
 
-func (cmd *DetachUser) inject(params map[string]interface{}) error {
+func (cmd *DeleteQueue) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewDetachVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachVolume {
-	cmd := new(DetachVolume)
+func NewDeleteRecord(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRecord {
+	cmd := new(DeleteRecord)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *DetachVolume) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteRecord) SetApi(api route53iface.Route53API) {
 	cmd.api = api
 }
 
-func (cmd *DetachVolume) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteRecord) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *DetachVolume) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteRecord) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11238,13 +11384,7 @@ func (cmd *DetachVolume) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &ec2.DetachVolumeInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DetachVolumeInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.DetachVolume(input)
-	renv.Log().ExtraVerbosef("ec2.DetachVolume call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11252,16 +11392,16 @@ func (cmd *DetachVolume) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("detach volume: AWS command returned nil output")
+			renv.Log().Warning("delete record: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("detach volume '%s' done", extracted)
+		renv.Log().Verbosef("delete record '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("detach volume done")
+		renv.Log().Verbose("delete record done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11273,61 +11413,40 @@ func (cmd *DetachVolume) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *DetachVolume) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.DetachVolumeInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.DetachVolumeInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.DetachVolume(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.DetachVolume call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: detach volume ok")
-			return fakeDryRunId("volume"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteRecord) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("record"), nil
 }
 
-func (cmd *DetachVolume) inject(params map[string]interface{}) error {
+func (cmd *DeleteRecord) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewImportImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *ImportImage {
-	cmd := new(ImportImage)
+func NewDeleteReplicationgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteReplicationgroup {
+	cmd := new(DeleteReplicationgroup)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elasticache", func() interface{} { return elasticache.New(sess) }).(elasticacheiface.ElastiCacheAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *ImportImage) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteReplicationgroup) SetApi(api elasticacheiface.ElastiCacheAPI) {
 	cmd.api = api
 }
 
-func (cmd *ImportImage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteReplicationgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *ImportImage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteReplicationgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11338,13 +11457,13 @@ func (cmd *ImportImage) run(renv env.Running, params map[string]interface{}) (in
 		}
 	}
 
-	input := &ec2.ImportImageInput{}
+	input := &elasticache.DeleteReplicationGroupInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.ImportImageInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in elasticache.DeleteReplicationGroupInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.ImportImage(input)
-	renv.Log().ExtraVerbosef("ec2.ImportImage call took %s", time.Since(start))
+	output, err := cmd.api.DeleteReplicationGroup(input)
+	renv.Log().ExtraVerbosef("elasticache.DeleteReplicationGroup call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11352,16 +11471,16 @@ func (cmd *ImportImage) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("import image: AWS command returned nil output")
+			renv.Log().Warning("delete replicationgroup: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("import image '%s' done", extracted)
+		renv.Log().Verbosef("delete replicationgroup '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("import image done")
+		renv.Log().Verbose("delete replicationgroup done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11373,61 +11492,40 @@ func (cmd *ImportImage) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *ImportImage) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.ImportImageInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.ImportImageInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.ImportImage(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.ImportImage call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: import image ok")
-			return fakeDryRunId("image"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *DeleteReplicationgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("replicationgroup"), nil
 }
 
-func (cmd *ImportImage) inject(params map[string]interface{}) error {
+func (cmd *DeleteReplicationgroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewRestartDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *RestartDatabase {
-	cmd := new(RestartDatabase)
+func NewDeleteRepository(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRepository {
+	cmd := new(DeleteRepository)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = rds.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecr", func() interface{} { return ecr.New(sess) }).(ecriface.ECRAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *RestartDatabase) SetApi(api rdsiface.RDSAPI) {
+func (cmd *DeleteRepository) SetApi(api ecriface.ECRAPI) {
 	cmd.api = api
 }
 
-func (cmd *RestartDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteRepository) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *RestartDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteRepository) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11438,13 +11536,13 @@ func (cmd *RestartDatabase) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &rds.RebootDBInstanceInput{}
+	input := &ecr.DeleteRepositoryInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in rds.RebootDBInstanceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ecr.DeleteRepositoryInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.RebootDBInstance(input)
-	renv.Log().ExtraVerbosef("rds.RebootDBInstance call took %s", time.Since(start))
+	output, err := cmd.api.DeleteRepository(input)
+	renv.Log().ExtraVerbosef("ecr.DeleteRepository call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11452,16 +11550,16 @@ func (cmd *RestartDatabase) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("restart database: AWS command returned nil output")
+			renv.Log().Warning("delete repository: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("restart database '%s' done", extracted)
+		renv.Log().Verbosef("delete repository '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("restart database done")
+		renv.Log().Verbose("delete repository done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11473,40 +11571,40 @@ func (cmd *RestartDatabase) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *RestartDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+func (cmd *DeleteRepository) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("repository"), nil
 }
 
-func (cmd *RestartDatabase) inject(params map[string]interface{}) error {
+func (cmd *DeleteRepository) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewRestartInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *RestartInstance {
-	cmd := new(RestartInstance)
+func NewDeleteRestapi(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRestapi {
+	cmd := new(DeleteRestapi)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *RestartInstance) SetApi(api ec2iface.EC2API) {
+func (cmd *DeleteRestapi) SetApi(api apigatewayiface.APIGatewayAPI) {
 	cmd.api = api
 }
 
-func (cmd *RestartInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteRestapi) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *RestartInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *DeleteRestapi) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11517,13 +11615,13 @@ func (cmd *RestartInstance) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.RebootInstancesInput{}
+	input := &apigateway.DeleteRestApiInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.RebootInstancesInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in apigateway.DeleteRestApiInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.RebootInstances(input)
-	renv.Log().ExtraVerbosef("ec2.RebootInstances call took %s", time.Since(start))
+	output, err := cmd.api.DeleteRestApi(input)
+	renv.Log().ExtraVerbosef("apigateway.DeleteRestApi call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11531,16 +11629,16 @@ func (cmd *RestartInstance) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("restart instance: AWS command returned nil output")
+			renv.Log().Warning("delete restapi: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("restart instance '%s' done", extracted)
+		renv.Log().Verbosef("delete restapi '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("restart instance done")
+		renv.Log().Verbose("delete restapi done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11552,61 +11650,4069 @@ func (cmd *RestartInstance) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *RestartInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
+func (cmd *DeleteRestapi) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("restapi"), nil
+}
 
-	input := &ec2.RebootInstancesInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.RebootInstancesInput: %s", err)
+func (cmd *DeleteRestapi) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRole {
+	cmd := new(DeleteRole)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
 	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
 
-	start := time.Now()
-	_, err := cmd.api.RebootInstances(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.RebootInstances call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: restart instance ok")
+func (cmd *DeleteRole) SetApi(api iamiface.IAMAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteRole) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteRole) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete role: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete role '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete role done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("role"), nil
+}
+
+func (cmd *DeleteRole) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteRoute(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRoute {
+	cmd := new(DeleteRoute)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteRoute) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteRoute) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteRoute) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteRouteInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteRouteInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteRoute(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteRoute call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete route: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete route '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete route done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteRoute) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteRouteInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteRouteInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteRoute(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteRoute call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete route ok")
+			return fakeDryRunId("route"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DeleteRoute) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteRoutetable {
+	cmd := new(DeleteRoutetable)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteRoutetable) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteRoutetable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteRoutetable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteRouteTableInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteRouteTableInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteRouteTable(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteRouteTable call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete routetable: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete routetable '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete routetable done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteRoutetable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteRouteTableInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteRouteTableInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteRouteTable(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteRouteTable call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete routetable ok")
+			return fakeDryRunId("routetable"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DeleteRoutetable) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteS3object {
+	cmd := new(DeleteS3object)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteS3object) SetApi(api s3iface.S3API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteS3object) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteS3object) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &s3.DeleteObjectInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in s3.DeleteObjectInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteObject(input)
+	renv.Log().ExtraVerbosef("s3.DeleteObject call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete s3object: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete s3object '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete s3object done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteS3object) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("s3object"), nil
+}
+
+func (cmd *DeleteS3object) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteScalinggroup {
+	cmd := new(DeleteScalinggroup)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteScalinggroup) SetApi(api autoscalingiface.AutoScalingAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteScalinggroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteScalinggroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &autoscaling.DeleteAutoScalingGroupInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in autoscaling.DeleteAutoScalingGroupInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteAutoScalingGroup(input)
+	renv.Log().ExtraVerbosef("autoscaling.DeleteAutoScalingGroup call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete scalinggroup: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete scalinggroup '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete scalinggroup done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteScalinggroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("scalinggroup"), nil
+}
+
+func (cmd *DeleteScalinggroup) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteScalingpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteScalingpolicy {
+	cmd := new(DeleteScalingpolicy)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteScalingpolicy) SetApi(api autoscalingiface.AutoScalingAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteScalingpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteScalingpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &autoscaling.DeletePolicyInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in autoscaling.DeletePolicyInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeletePolicy(input)
+	renv.Log().ExtraVerbosef("autoscaling.DeletePolicy call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete scalingpolicy: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete scalingpolicy '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete scalingpolicy done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteScalingpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("scalingpolicy"), nil
+}
+
+func (cmd *DeleteScalingpolicy) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSecuritygroup {
+	cmd := new(DeleteSecuritygroup)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteSecuritygroup) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteSecuritygroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteSecuritygroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteSecurityGroupInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteSecurityGroupInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteSecurityGroup(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteSecurityGroup call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete securitygroup: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete securitygroup '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete securitygroup done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteSecurityGroupInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteSecurityGroupInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteSecurityGroup(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSecurityGroup call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete securitygroup ok")
+			return fakeDryRunId("securitygroup"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DeleteSecuritygroup) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteSnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSnapshot {
+	cmd := new(DeleteSnapshot)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteSnapshot) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteSnapshot) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteSnapshot) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteSnapshotInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteSnapshotInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteSnapshot(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteSnapshot call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete snapshot: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete snapshot '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete snapshot done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteSnapshot) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteSnapshotInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteSnapshotInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteSnapshot(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSnapshot call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete snapshot ok")
+			return fakeDryRunId("snapshot"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DeleteSnapshot) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteSpotfleet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSpotfleet {
+	cmd := new(DeleteSpotfleet)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteSpotfleet) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteSpotfleet) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteSpotfleet) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CancelSpotFleetRequestsInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.CancelSpotFleetRequestsInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.CancelSpotFleetRequests(input)
+	renv.Log().ExtraVerbosef("ec2.CancelSpotFleetRequests call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete spotfleet: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete spotfleet '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete spotfleet done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteSpotfleet) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("spotfleet"), nil
+}
+
+func (cmd *DeleteSpotfleet) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteStack {
+	cmd := new(DeleteStack)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "cloudformation", func() interface{} { return cloudformation.New(sess) }).(cloudformationiface.CloudFormationAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteStack) SetApi(api cloudformationiface.CloudFormationAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteStack) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteStack) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &cloudformation.DeleteStackInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in cloudformation.DeleteStackInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteStack(input)
+	renv.Log().ExtraVerbosef("cloudformation.DeleteStack call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete stack: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete stack '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete stack done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteStack) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("stack"), nil
+}
+
+func (cmd *DeleteStack) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteStatemachine(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteStatemachine {
+	cmd := new(DeleteStatemachine)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "sfn", func() interface{} { return sfn.New(sess) }).(sfniface.SFNAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteStatemachine) SetApi(api sfniface.SFNAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteStatemachine) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteStatemachine) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &sfn.DeleteStateMachineInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in sfn.DeleteStateMachineInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteStateMachine(input)
+	renv.Log().ExtraVerbosef("sfn.DeleteStateMachine call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete statemachine: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete statemachine '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete statemachine done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteStatemachine) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("statemachine"), nil
+}
+
+func (cmd *DeleteStatemachine) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteSubnet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSubnet {
+	cmd := new(DeleteSubnet)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteSubnet) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteSubnet) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteSubnet) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteSubnetInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteSubnetInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteSubnet(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteSubnet call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete subnet: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete subnet '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete subnet done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteSubnet) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteSubnetInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteSubnetInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteSubnet(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteSubnet call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete subnet ok")
+			return fakeDryRunId("subnet"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DeleteSubnet) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteSubscription(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteSubscription {
+	cmd := new(DeleteSubscription)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "sns", func() interface{} { return sns.New(sess) }).(snsiface.SNSAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteSubscription) SetApi(api snsiface.SNSAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteSubscription) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteSubscription) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &sns.UnsubscribeInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in sns.UnsubscribeInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.Unsubscribe(input)
+	renv.Log().ExtraVerbosef("sns.Unsubscribe call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete subscription: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete subscription '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete subscription done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteSubscription) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("subscription"), nil
+}
+
+func (cmd *DeleteSubscription) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteTable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTable {
+	cmd := new(DeleteTable)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "dynamodb", func() interface{} { return dynamodb.New(sess) }).(dynamodbiface.DynamoDBAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteTable) SetApi(api dynamodbiface.DynamoDBAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteTable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteTable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &dynamodb.DeleteTableInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in dynamodb.DeleteTableInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteTable(input)
+	renv.Log().ExtraVerbosef("dynamodb.DeleteTable call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete table: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete table '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete table done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteTable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("table"), nil
+}
+
+func (cmd *DeleteTable) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteTag(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTag {
+	cmd := new(DeleteTag)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteTag) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteTag) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteTag) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete tag: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete tag '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete tag done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteTag) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTargetgroup {
+	cmd := new(DeleteTargetgroup)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteTargetgroup) SetApi(api elbv2iface.ELBV2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteTargetgroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteTargetgroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &elbv2.DeleteTargetGroupInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in elbv2.DeleteTargetGroupInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteTargetGroup(input)
+	renv.Log().ExtraVerbosef("elbv2.DeleteTargetGroup call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete targetgroup: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete targetgroup '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete targetgroup done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteTargetgroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("targetgroup"), nil
+}
+
+func (cmd *DeleteTargetgroup) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteTopic(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTopic {
+	cmd := new(DeleteTopic)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "sns", func() interface{} { return sns.New(sess) }).(snsiface.SNSAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteTopic) SetApi(api snsiface.SNSAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteTopic) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteTopic) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &sns.DeleteTopicInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in sns.DeleteTopicInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteTopic(input)
+	renv.Log().ExtraVerbosef("sns.DeleteTopic call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete topic: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete topic '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete topic done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteTopic) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("topic"), nil
+}
+
+func (cmd *DeleteTopic) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteTrafficpolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTrafficpolicy {
+	cmd := new(DeleteTrafficpolicy)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteTrafficpolicy) SetApi(api route53iface.Route53API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteTrafficpolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteTrafficpolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &route53.DeleteTrafficPolicyInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in route53.DeleteTrafficPolicyInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteTrafficPolicy(input)
+	renv.Log().ExtraVerbosef("route53.DeleteTrafficPolicy call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete trafficpolicy: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete trafficpolicy '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete trafficpolicy done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteTrafficpolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("trafficpolicy"), nil
+}
+
+func (cmd *DeleteTrafficpolicy) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteTrafficpolicyinstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteTrafficpolicyinstance {
+	cmd := new(DeleteTrafficpolicyinstance)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteTrafficpolicyinstance) SetApi(api route53iface.Route53API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteTrafficpolicyinstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteTrafficpolicyinstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &route53.DeleteTrafficPolicyInstanceInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in route53.DeleteTrafficPolicyInstanceInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteTrafficPolicyInstance(input)
+	renv.Log().ExtraVerbosef("route53.DeleteTrafficPolicyInstance call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete trafficpolicyinstance: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete trafficpolicyinstance '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete trafficpolicyinstance done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteTrafficpolicyinstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("trafficpolicyinstance"), nil
+}
+
+func (cmd *DeleteTrafficpolicyinstance) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteUser {
+	cmd := new(DeleteUser)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteUser) SetApi(api iamiface.IAMAPI) {
+	cmd.api = api
+}
+
+func (cmd *DeleteUser) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteUser) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &iam.DeleteUserInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in iam.DeleteUserInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteUser(input)
+	renv.Log().ExtraVerbosef("iam.DeleteUser call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete user: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete user '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete user done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("user"), nil
+}
+
+func (cmd *DeleteUser) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteVolume {
+	cmd := new(DeleteVolume)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteVolume) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteVolume) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteVolume) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteVolumeInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteVolumeInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteVolume(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteVolume call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete volume: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete volume '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete volume done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteVolume) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteVolumeInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteVolumeInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteVolume(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteVolume call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete volume ok")
+			return fakeDryRunId("volume"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DeleteVolume) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteVpc(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteVpc {
+	cmd := new(DeleteVpc)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteVpc) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteVpc) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteVpc) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteVpcInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteVpcInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteVpc(input)
+	renv.Log().ExtraVerbosef("ec2.DeleteVpc call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete vpc: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete vpc '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete vpc done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteVpc) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DeleteVpcInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DeleteVpcInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DeleteVpc(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DeleteVpc call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: delete vpc ok")
+			return fakeDryRunId("vpc"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DeleteVpc) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDeleteZone(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DeleteZone {
+	cmd := new(DeleteZone)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DeleteZone) SetApi(api route53iface.Route53API) {
+	cmd.api = api
+}
+
+func (cmd *DeleteZone) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DeleteZone) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &route53.DeleteHostedZoneInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in route53.DeleteHostedZoneInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteHostedZone(input)
+	renv.Log().ExtraVerbosef("route53.DeleteHostedZone call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("delete zone: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("delete zone '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("delete zone done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DeleteZone) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("zone"), nil
+}
+
+func (cmd *DeleteZone) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachAlarm {
+	cmd := new(DetachAlarm)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "cloudwatch", func() interface{} { return cloudwatch.New(sess) }).(cloudwatchiface.CloudWatchAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachAlarm) SetApi(api cloudwatchiface.CloudWatchAPI) {
+	cmd.api = api
+}
+
+func (cmd *DetachAlarm) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachAlarm) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach alarm: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach alarm '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach alarm done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("alarm"), nil
+}
+
+func (cmd *DetachAlarm) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachContainertask {
+	cmd := new(DetachContainertask)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachContainertask) SetApi(api ecsiface.ECSAPI) {
+	cmd.api = api
+}
+
+func (cmd *DetachContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach containertask: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach containertask '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach containertask done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("containertask"), nil
+}
+
+func (cmd *DetachContainertask) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachElasticip(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachElasticip {
+	cmd := new(DetachElasticip)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachElasticip) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DetachElasticip) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachElasticip) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DisassociateAddressInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DisassociateAddressInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DisassociateAddress(input)
+	renv.Log().ExtraVerbosef("ec2.DisassociateAddress call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach elasticip: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach elasticip '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach elasticip done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachElasticip) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DisassociateAddressInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DisassociateAddressInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DisassociateAddress(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DisassociateAddress call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: detach elasticip ok")
+			return fakeDryRunId("elasticip"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DetachElasticip) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInstance {
+	cmd := new(DetachInstance)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachInstance) SetApi(api elbv2iface.ELBV2API) {
+	cmd.api = api
+}
+
+func (cmd *DetachInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &elbv2.DeregisterTargetsInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in elbv2.DeregisterTargetsInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeregisterTargets(input)
+	renv.Log().ExtraVerbosef("elbv2.DeregisterTargets call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach instance: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach instance '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach instance done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("instance"), nil
+}
+
+func (cmd *DetachInstance) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachInstanceprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInstanceprofile {
+	cmd := new(DetachInstanceprofile)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachInstanceprofile) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DetachInstanceprofile) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachInstanceprofile) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach instanceprofile: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach instanceprofile '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach instanceprofile done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachInstanceprofile) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("instanceprofile"), nil
+}
+
+func (cmd *DetachInstanceprofile) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachIntegration(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachIntegration {
+	cmd := new(DetachIntegration)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "apigateway", func() interface{} { return apigateway.New(sess) }).(apigatewayiface.APIGatewayAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachIntegration) SetApi(api apigatewayiface.APIGatewayAPI) {
+	cmd.api = api
+}
+
+func (cmd *DetachIntegration) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachIntegration) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &apigateway.DeleteIntegrationInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in apigateway.DeleteIntegrationInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeleteIntegration(input)
+	renv.Log().ExtraVerbosef("apigateway.DeleteIntegration call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach integration: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach integration '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach integration done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachIntegration) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("integration"), nil
+}
+
+func (cmd *DetachIntegration) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachInternetgateway(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachInternetgateway {
+	cmd := new(DetachInternetgateway)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachInternetgateway) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DetachInternetgateway) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachInternetgateway) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DetachInternetGatewayInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DetachInternetGatewayInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DetachInternetGateway(input)
+	renv.Log().ExtraVerbosef("ec2.DetachInternetGateway call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach internetgateway: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach internetgateway '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach internetgateway done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachInternetgateway) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DetachInternetGatewayInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DetachInternetGatewayInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DetachInternetGateway(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DetachInternetGateway call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: detach internetgateway ok")
+			return fakeDryRunId("internetgateway"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DetachInternetgateway) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachKeygrant(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachKeygrant {
+	cmd := new(DetachKeygrant)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "kms", func() interface{} { return kms.New(sess) }).(kmsiface.KMSAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachKeygrant) SetApi(api kmsiface.KMSAPI) {
+	cmd.api = api
+}
+
+func (cmd *DetachKeygrant) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachKeygrant) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &kms.RevokeGrantInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in kms.RevokeGrantInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.RevokeGrant(input)
+	renv.Log().ExtraVerbosef("kms.RevokeGrant call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach keygrant: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach keygrant '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach keygrant done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachKeygrant) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("keygrant"), nil
+}
+
+func (cmd *DetachKeygrant) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachMfadevice(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachMfadevice {
+	cmd := new(DetachMfadevice)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachMfadevice) SetApi(api iamiface.IAMAPI) {
+	cmd.api = api
+}
+
+func (cmd *DetachMfadevice) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachMfadevice) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &iam.DeactivateMFADeviceInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in iam.DeactivateMFADeviceInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DeactivateMFADevice(input)
+	renv.Log().ExtraVerbosef("iam.DeactivateMFADevice call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach mfadevice: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach mfadevice '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach mfadevice done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachMfadevice) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("mfadevice"), nil
+}
+
+func (cmd *DetachMfadevice) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachNetworkinterface(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachNetworkinterface {
+	cmd := new(DetachNetworkinterface)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachNetworkinterface) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DetachNetworkinterface) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachNetworkinterface) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach networkinterface: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach networkinterface '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach networkinterface done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachNetworkinterface) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachPolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachPolicy {
+	cmd := new(DetachPolicy)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachPolicy) SetApi(api iamiface.IAMAPI) {
+	cmd.api = api
+}
+
+func (cmd *DetachPolicy) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachPolicy) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach policy: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach policy '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach policy done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachPolicy) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("policy"), nil
+}
+
+func (cmd *DetachPolicy) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachRole(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachRole {
+	cmd := new(DetachRole)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachRole) SetApi(api iamiface.IAMAPI) {
+	cmd.api = api
+}
+
+func (cmd *DetachRole) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachRole) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &iam.RemoveRoleFromInstanceProfileInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in iam.RemoveRoleFromInstanceProfileInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.RemoveRoleFromInstanceProfile(input)
+	renv.Log().ExtraVerbosef("iam.RemoveRoleFromInstanceProfile call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach role: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach role '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach role done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachRole) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("role"), nil
+}
+
+func (cmd *DetachRole) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachRoutetable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachRoutetable {
+	cmd := new(DetachRoutetable)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachRoutetable) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DetachRoutetable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachRoutetable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DisassociateRouteTableInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DisassociateRouteTableInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DisassociateRouteTable(input)
+	renv.Log().ExtraVerbosef("ec2.DisassociateRouteTable call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach routetable: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach routetable '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach routetable done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachRoutetable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DisassociateRouteTableInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DisassociateRouteTableInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DisassociateRouteTable(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DisassociateRouteTable call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: detach routetable ok")
+			return fakeDryRunId("routetable"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DetachRoutetable) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachSecuritygroup {
+	cmd := new(DetachSecuritygroup)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachSecuritygroup) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DetachSecuritygroup) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachSecuritygroup) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach securitygroup: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach securitygroup '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach securitygroup done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachSecuritygroup) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("securitygroup"), nil
+}
+
+func (cmd *DetachSecuritygroup) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachUser(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachUser {
+	cmd := new(DetachUser)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachUser) SetApi(api iamiface.IAMAPI) {
+	cmd.api = api
+}
+
+func (cmd *DetachUser) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachUser) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &iam.RemoveUserFromGroupInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in iam.RemoveUserFromGroupInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.RemoveUserFromGroup(input)
+	renv.Log().ExtraVerbosef("iam.RemoveUserFromGroup call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach user: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach user '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach user done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachUser) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("user"), nil
+}
+
+func (cmd *DetachUser) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewDetachVolume(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *DetachVolume {
+	cmd := new(DetachVolume)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *DetachVolume) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *DetachVolume) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *DetachVolume) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DetachVolumeInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DetachVolumeInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.DetachVolume(input)
+	renv.Log().ExtraVerbosef("ec2.DetachVolume call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("detach volume: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("detach volume '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("detach volume done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *DetachVolume) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.DetachVolumeInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.DetachVolumeInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.DetachVolume(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.DetachVolume call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: detach volume ok")
+			return fakeDryRunId("volume"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *DetachVolume) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewImportImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *ImportImage {
+	cmd := new(ImportImage)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *ImportImage) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *ImportImage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *ImportImage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.ImportImageInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.ImportImageInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.ImportImage(input)
+	renv.Log().ExtraVerbosef("ec2.ImportImage call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("import image: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("import image '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("import image done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *ImportImage) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.ImportImageInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.ImportImageInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.ImportImage(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.ImportImage call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: import image ok")
+			return fakeDryRunId("image"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *ImportImage) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewRestartDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *RestartDatabase {
+	cmd := new(RestartDatabase)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *RestartDatabase) SetApi(api rdsiface.RDSAPI) {
+	cmd.api = api
+}
+
+func (cmd *RestartDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *RestartDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &rds.RebootDBInstanceInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in rds.RebootDBInstanceInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.RebootDBInstance(input)
+	renv.Log().ExtraVerbosef("rds.RebootDBInstance call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("restart database: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("restart database '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("restart database done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *RestartDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("database"), nil
+}
+
+func (cmd *RestartDatabase) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewRestartInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *RestartInstance {
+	cmd := new(RestartInstance)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *RestartInstance) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *RestartInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *RestartInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.RebootInstancesInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.RebootInstancesInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.RebootInstances(input)
+	renv.Log().ExtraVerbosef("ec2.RebootInstances call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("restart instance: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("restart instance '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("restart instance done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *RestartInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.RebootInstancesInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.RebootInstancesInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.RebootInstances(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.RebootInstances call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: restart instance ok")
+			return fakeDryRunId("instance"), nil
+		}
+	}
+
+	return nil, err
+}
+
+func (cmd *RestartInstance) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewStartAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartAlarm {
+	cmd := new(StartAlarm)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "cloudwatch", func() interface{} { return cloudwatch.New(sess) }).(cloudwatchiface.CloudWatchAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *StartAlarm) SetApi(api cloudwatchiface.CloudWatchAPI) {
+	cmd.api = api
+}
+
+func (cmd *StartAlarm) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *StartAlarm) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &cloudwatch.EnableAlarmActionsInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in cloudwatch.EnableAlarmActionsInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.EnableAlarmActions(input)
+	renv.Log().ExtraVerbosef("cloudwatch.EnableAlarmActions call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("start alarm: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("start alarm '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("start alarm done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *StartAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("alarm"), nil
+}
+
+func (cmd *StartAlarm) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewStartContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartContainertask {
+	cmd := new(StartContainertask)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *StartContainertask) SetApi(api ecsiface.ECSAPI) {
+	cmd.api = api
+}
+
+func (cmd *StartContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *StartContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("start containertask: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("start containertask '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("start containertask done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *StartContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("containertask"), nil
+}
+
+func (cmd *StartContainertask) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewStartDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartDatabase {
+	cmd := new(StartDatabase)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *StartDatabase) SetApi(api rdsiface.RDSAPI) {
+	cmd.api = api
+}
+
+func (cmd *StartDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *StartDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &rds.StartDBInstanceInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in rds.StartDBInstanceInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.StartDBInstance(input)
+	renv.Log().ExtraVerbosef("rds.StartDBInstance call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("start database: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("start database '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("start database done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *StartDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("database"), nil
+}
+
+func (cmd *StartDatabase) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewStartInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartInstance {
+	cmd := new(StartInstance)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *StartInstance) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *StartInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *StartInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &ec2.StartInstancesInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.StartInstancesInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.StartInstances(input)
+	renv.Log().ExtraVerbosef("ec2.StartInstances call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("start instance: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("start instance '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("start instance done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *StartInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.StartInstancesInput{}
+	input.SetDryRun(true)
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.StartInstancesInput: %s", err)
+	}
+
+	start := time.Now()
+	_, err := cmd.api.StartInstances(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			renv.Log().ExtraVerbosef("dry run: ec2.StartInstances call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: start instance ok")
 			return fakeDryRunId("instance"), nil
 		}
 	}
 
-	return nil, err
+	return nil, err
+}
+
+func (cmd *StartInstance) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
+func NewStartStatemachine(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartStatemachine {
+	cmd := new(StartStatemachine)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "sfn", func() interface{} { return sfn.New(sess) }).(sfniface.SFNAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *StartStatemachine) SetApi(api sfniface.SFNAPI) {
+	cmd.api = api
+}
+
+func (cmd *StartStatemachine) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *StartStatemachine) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	input := &sfn.StartExecutionInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in sfn.StartExecutionInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.StartExecution(input)
+	renv.Log().ExtraVerbosef("sfn.StartExecution call took %s", time.Since(start))
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("start statemachine: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("start statemachine '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("start statemachine done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
 }
 
-func (cmd *RestartInstance) inject(params map[string]interface{}) error {
+func (cmd *StartStatemachine) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("statemachine"), nil
+}
+
+func (cmd *StartStatemachine) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewStartAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartAlarm {
-	cmd := new(StartAlarm)
+func NewStopAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopAlarm {
+	cmd := new(StopAlarm)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudwatch.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudwatch", func() interface{} { return cloudwatch.New(sess) }).(cloudwatchiface.CloudWatchAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *StartAlarm) SetApi(api cloudwatchiface.CloudWatchAPI) {
+func (cmd *StopAlarm) SetApi(api cloudwatchiface.CloudWatchAPI) {
 	cmd.api = api
 }
 
-func (cmd *StartAlarm) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopAlarm) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *StartAlarm) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopAlarm) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11617,13 +15723,13 @@ func (cmd *StartAlarm) run(renv env.Running, params map[string]interface{}) (int
 		}
 	}
 
-	input := &cloudwatch.EnableAlarmActionsInput{}
+	input := &cloudwatch.DisableAlarmActionsInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in cloudwatch.EnableAlarmActionsInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in cloudwatch.DisableAlarmActionsInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.EnableAlarmActions(input)
-	renv.Log().ExtraVerbosef("cloudwatch.EnableAlarmActions call took %s", time.Since(start))
+	output, err := cmd.api.DisableAlarmActions(input)
+	renv.Log().ExtraVerbosef("cloudwatch.DisableAlarmActions call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11631,16 +15737,16 @@ func (cmd *StartAlarm) run(renv env.Running, params map[string]interface{}) (int
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("start alarm: AWS command returned nil output")
+			renv.Log().Warning("stop alarm: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("start alarm '%s' done", extracted)
+		renv.Log().Verbosef("stop alarm '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("start alarm done")
+		renv.Log().Verbose("stop alarm done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11652,40 +15758,40 @@ func (cmd *StartAlarm) run(renv env.Running, params map[string]interface{}) (int
 	return extracted, nil
 }
 
-func (cmd *StartAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	return fakeDryRunId("alarm"), nil
 }
 
-func (cmd *StartAlarm) inject(params map[string]interface{}) error {
+func (cmd *StopAlarm) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewStartContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartContainertask {
-	cmd := new(StartContainertask)
+func NewStopContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopContainertask {
+	cmd := new(StopContainertask)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *StartContainertask) SetApi(api ecsiface.ECSAPI) {
+func (cmd *StopContainertask) SetApi(api ecsiface.ECSAPI) {
 	cmd.api = api
 }
 
-func (cmd *StartContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *StartContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11704,16 +15810,16 @@ func (cmd *StartContainertask) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("start containertask: AWS command returned nil output")
+			renv.Log().Warning("stop containertask: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("start containertask '%s' done", extracted)
+		renv.Log().Verbosef("stop containertask '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("start containertask done")
+		renv.Log().Verbose("stop containertask done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11725,40 +15831,40 @@ func (cmd *StartContainertask) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *StartContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	return fakeDryRunId("containertask"), nil
 }
 
-func (cmd *StartContainertask) inject(params map[string]interface{}) error {
+func (cmd *StopContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewStartDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartDatabase {
-	cmd := new(StartDatabase)
+func NewStopDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopDatabase {
+	cmd := new(StopDatabase)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = rds.New(sess)
+		cmd.api = awspool.Default.Client(sess, "rds", func() interface{} { return rds.New(sess) }).(rdsiface.RDSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *StartDatabase) SetApi(api rdsiface.RDSAPI) {
+func (cmd *StopDatabase) SetApi(api rdsiface.RDSAPI) {
 	cmd.api = api
 }
 
-func (cmd *StartDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *StartDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11769,13 +15875,13 @@ func (cmd *StartDatabase) run(renv env.Running, params map[string]interface{}) (
 		}
 	}
 
-	input := &rds.StartDBInstanceInput{}
+	input := &rds.StopDBInstanceInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in rds.StartDBInstanceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in rds.StopDBInstanceInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.StartDBInstance(input)
-	renv.Log().ExtraVerbosef("rds.StartDBInstance call took %s", time.Since(start))
+	output, err := cmd.api.StopDBInstance(input)
+	renv.Log().ExtraVerbosef("rds.StopDBInstance call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11783,16 +15889,16 @@ func (cmd *StartDatabase) run(renv env.Running, params map[string]interface{}) (
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("start database: AWS command returned nil output")
+			renv.Log().Warning("stop database: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("start database '%s' done", extracted)
+		renv.Log().Verbosef("stop database '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("start database done")
+		renv.Log().Verbose("stop database done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11804,40 +15910,40 @@ func (cmd *StartDatabase) run(renv env.Running, params map[string]interface{}) (
 	return extracted, nil
 }
 
-func (cmd *StartDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	return fakeDryRunId("database"), nil
 }
 
-func (cmd *StartDatabase) inject(params map[string]interface{}) error {
+func (cmd *StopDatabase) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewStartInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StartInstance {
-	cmd := new(StartInstance)
+func NewStopInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopInstance {
+	cmd := new(StopInstance)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *StartInstance) SetApi(api ec2iface.EC2API) {
+func (cmd *StopInstance) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *StartInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *StartInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11848,13 +15954,13 @@ func (cmd *StartInstance) run(renv env.Running, params map[string]interface{}) (
 		}
 	}
 
-	input := &ec2.StartInstancesInput{}
+	input := &ec2.StopInstancesInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.StartInstancesInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.StopInstancesInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.StartInstances(input)
-	renv.Log().ExtraVerbosef("ec2.StartInstances call took %s", time.Since(start))
+	output, err := cmd.api.StopInstances(input)
+	renv.Log().ExtraVerbosef("ec2.StopInstances call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11862,16 +15968,16 @@ func (cmd *StartInstance) run(renv env.Running, params map[string]interface{}) (
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("start instance: AWS command returned nil output")
+			renv.Log().Warning("stop instance: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("start instance '%s' done", extracted)
+		renv.Log().Verbosef("stop instance '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("start instance done")
+		renv.Log().Verbose("stop instance done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11883,24 +15989,30 @@ func (cmd *StartInstance) run(renv env.Running, params map[string]interface{}) (
 	return extracted, nil
 }
 
-func (cmd *StartInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *StopInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
-	input := &ec2.StartInstancesInput{}
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.StopInstancesInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.StartInstancesInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.StopInstancesInput: %s", err)
 	}
 
 	start := time.Now()
-	_, err := cmd.api.StartInstances(input)
+	_, err := cmd.api.StopInstances(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.StartInstances call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: start instance ok")
+			renv.Log().ExtraVerbosef("dry run: ec2.StopInstances call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: stop instance ok")
 			return fakeDryRunId("instance"), nil
 		}
 	}
@@ -11908,36 +16020,36 @@ func (cmd *StartInstance) dryRun(renv env.Running, params map[string]interface{}
 	return nil, err
 }
 
-func (cmd *StartInstance) inject(params map[string]interface{}) error {
+func (cmd *StopInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewStopAlarm(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopAlarm {
-	cmd := new(StopAlarm)
+func NewSyncS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *SyncS3object {
+	cmd := new(SyncS3object)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudwatch.New(sess)
+		cmd.api = awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *StopAlarm) SetApi(api cloudwatchiface.CloudWatchAPI) {
+func (cmd *SyncS3object) SetApi(api s3iface.S3API) {
 	cmd.api = api
 }
 
-func (cmd *StopAlarm) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *SyncS3object) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *StopAlarm) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *SyncS3object) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -11948,13 +16060,7 @@ func (cmd *StopAlarm) run(renv env.Running, params map[string]interface{}) (inte
 		}
 	}
 
-	input := &cloudwatch.DisableAlarmActionsInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in cloudwatch.DisableAlarmActionsInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.DisableAlarmActions(input)
-	renv.Log().ExtraVerbosef("cloudwatch.DisableAlarmActions call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -11962,16 +16068,16 @@ func (cmd *StopAlarm) run(renv env.Running, params map[string]interface{}) (inte
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("stop alarm: AWS command returned nil output")
+			renv.Log().Warning("sync s3object: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("stop alarm '%s' done", extracted)
+		renv.Log().Verbosef("sync s3object '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("stop alarm done")
+		renv.Log().Verbose("sync s3object done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -11983,40 +16089,40 @@ func (cmd *StopAlarm) run(renv env.Running, params map[string]interface{}) (inte
 	return extracted, nil
 }
 
-func (cmd *StopAlarm) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("alarm"), nil
+func (cmd *SyncS3object) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("s3object"), nil
 }
 
-func (cmd *StopAlarm) inject(params map[string]interface{}) error {
+func (cmd *SyncS3object) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewStopContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopContainertask {
-	cmd := new(StopContainertask)
+func NewUpdateBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateBucket {
+	cmd := new(UpdateBucket)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *StopContainertask) SetApi(api ecsiface.ECSAPI) {
+func (cmd *UpdateBucket) SetApi(api s3iface.S3API) {
 	cmd.api = api
 }
 
-func (cmd *StopContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateBucket) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *StopContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateBucket) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -12035,16 +16141,16 @@ func (cmd *StopContainertask) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("stop containertask: AWS command returned nil output")
+			renv.Log().Warning("update bucket: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("stop containertask '%s' done", extracted)
+		renv.Log().Verbosef("update bucket '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("stop containertask done")
+		renv.Log().Verbose("update bucket done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -12056,40 +16162,40 @@ func (cmd *StopContainertask) run(renv env.Running, params map[string]interface{
 	return extracted, nil
 }
 
-func (cmd *StopContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containertask"), nil
+func (cmd *UpdateBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("bucket"), nil
 }
 
-func (cmd *StopContainertask) inject(params map[string]interface{}) error {
+func (cmd *UpdateBucket) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewStopDatabase(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopDatabase {
-	cmd := new(StopDatabase)
+func NewUpdateContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateContainertask {
+	cmd := new(UpdateContainertask)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = rds.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ecs", func() interface{} { return ecs.New(sess) }).(ecsiface.ECSAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *StopDatabase) SetApi(api rdsiface.RDSAPI) {
+func (cmd *UpdateContainertask) SetApi(api ecsiface.ECSAPI) {
 	cmd.api = api
 }
 
-func (cmd *StopDatabase) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *StopDatabase) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -12100,13 +16206,13 @@ func (cmd *StopDatabase) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &rds.StopDBInstanceInput{}
+	input := &ecs.UpdateServiceInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in rds.StopDBInstanceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ecs.UpdateServiceInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.StopDBInstance(input)
-	renv.Log().ExtraVerbosef("rds.StopDBInstance call took %s", time.Since(start))
+	output, err := cmd.api.UpdateService(input)
+	renv.Log().ExtraVerbosef("ecs.UpdateService call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -12114,16 +16220,16 @@ func (cmd *StopDatabase) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("stop database: AWS command returned nil output")
+			renv.Log().Warning("update containertask: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("stop database '%s' done", extracted)
+		renv.Log().Verbosef("update containertask '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("stop database done")
+		renv.Log().Verbose("update containertask done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -12135,40 +16241,40 @@ func (cmd *StopDatabase) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *StopDatabase) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("database"), nil
+func (cmd *UpdateContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("containertask"), nil
 }
 
-func (cmd *StopDatabase) inject(params map[string]interface{}) error {
+func (cmd *UpdateContainertask) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewStopInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *StopInstance {
-	cmd := new(StopInstance)
+func NewUpdateDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateDistribution {
+	cmd := new(UpdateDistribution)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudfront", func() interface{} { return cloudfront.New(sess) }).(cloudfrontiface.CloudFrontAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *StopInstance) SetApi(api ec2iface.EC2API) {
+func (cmd *UpdateDistribution) SetApi(api cloudfrontiface.CloudFrontAPI) {
 	cmd.api = api
 }
 
-func (cmd *StopInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateDistribution) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *StopInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateDistribution) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -12179,13 +16285,7 @@ func (cmd *StopInstance) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	input := &ec2.StopInstancesInput{}
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.StopInstancesInput: %s", err)
-	}
-	start := time.Now()
-	output, err := cmd.api.StopInstances(input)
-	renv.Log().ExtraVerbosef("ec2.StopInstances call took %s", time.Since(start))
+	output, err := cmd.ManualRun(renv)
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -12193,16 +16293,16 @@ func (cmd *StopInstance) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("stop instance: AWS command returned nil output")
+			renv.Log().Warning("update distribution: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("stop instance '%s' done", extracted)
+		renv.Log().Verbosef("update distribution '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("stop instance done")
+		renv.Log().Verbose("update distribution done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -12214,61 +16314,40 @@ func (cmd *StopInstance) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *StopInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	if err := cmd.inject(params); err != nil {
-		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
-	}
-
-	input := &ec2.StopInstancesInput{}
-	input.SetDryRun(true)
-	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.StopInstancesInput: %s", err)
-	}
-
-	start := time.Now()
-	_, err := cmd.api.StopInstances(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.StopInstances call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: stop instance ok")
-			return fakeDryRunId("instance"), nil
-		}
-	}
-
-	return nil, err
+func (cmd *UpdateDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("distribution"), nil
 }
 
-func (cmd *StopInstance) inject(params map[string]interface{}) error {
+func (cmd *UpdateDistribution) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewUpdateBucket(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateBucket {
-	cmd := new(UpdateBucket)
+func NewUpdateEventsourcemapping(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateEventsourcemapping {
+	cmd := new(UpdateEventsourcemapping)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = s3.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *UpdateBucket) SetApi(api s3iface.S3API) {
+func (cmd *UpdateEventsourcemapping) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *UpdateBucket) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateEventsourcemapping) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *UpdateBucket) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateEventsourcemapping) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -12279,7 +16358,13 @@ func (cmd *UpdateBucket) run(renv env.Running, params map[string]interface{}) (i
 		}
 	}
 
-	output, err := cmd.ManualRun(renv)
+	input := &lambda.UpdateEventSourceMappingInput{}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in lambda.UpdateEventSourceMappingInput: %s", err)
+	}
+	start := time.Now()
+	output, err := cmd.api.UpdateEventSourceMapping(input)
+	renv.Log().ExtraVerbosef("lambda.UpdateEventSourceMapping call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -12287,16 +16372,16 @@ func (cmd *UpdateBucket) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("update bucket: AWS command returned nil output")
+			renv.Log().Warning("update eventsourcemapping: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("update bucket '%s' done", extracted)
+		renv.Log().Verbosef("update eventsourcemapping '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("update bucket done")
+		renv.Log().Verbose("update eventsourcemapping done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -12308,40 +16393,40 @@ func (cmd *UpdateBucket) run(renv env.Running, params map[string]interface{}) (i
 	return extracted, nil
 }
 
-func (cmd *UpdateBucket) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("bucket"), nil
+func (cmd *UpdateEventsourcemapping) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("eventsourcemapping"), nil
 }
 
-func (cmd *UpdateBucket) inject(params map[string]interface{}) error {
+func (cmd *UpdateEventsourcemapping) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewUpdateContainertask(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateContainertask {
-	cmd := new(UpdateContainertask)
+func NewUpdateFunctionalias(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateFunctionalias {
+	cmd := new(UpdateFunctionalias)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ecs.New(sess)
+		cmd.api = awspool.Default.Client(sess, "lambda", func() interface{} { return lambda.New(sess) }).(lambdaiface.LambdaAPI)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *UpdateContainertask) SetApi(api ecsiface.ECSAPI) {
+func (cmd *UpdateFunctionalias) SetApi(api lambdaiface.LambdaAPI) {
 	cmd.api = api
 }
 
-func (cmd *UpdateContainertask) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateFunctionalias) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *UpdateContainertask) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateFunctionalias) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -12352,13 +16437,13 @@ func (cmd *UpdateContainertask) run(renv env.Running, params map[string]interfac
 		}
 	}
 
-	input := &ecs.UpdateServiceInput{}
+	input := &lambda.UpdateAliasInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ecs.UpdateServiceInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in lambda.UpdateAliasInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.UpdateService(input)
-	renv.Log().ExtraVerbosef("ecs.UpdateService call took %s", time.Since(start))
+	output, err := cmd.api.UpdateAlias(input)
+	renv.Log().ExtraVerbosef("lambda.UpdateAlias call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -12366,16 +16451,16 @@ func (cmd *UpdateContainertask) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("update containertask: AWS command returned nil output")
+			renv.Log().Warning("update functionalias: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("update containertask '%s' done", extracted)
+		renv.Log().Verbosef("update functionalias '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("update containertask done")
+		renv.Log().Verbose("update functionalias done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -12387,40 +16472,40 @@ func (cmd *UpdateContainertask) run(renv env.Running, params map[string]interfac
 	return extracted, nil
 }
 
-func (cmd *UpdateContainertask) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("containertask"), nil
+func (cmd *UpdateFunctionalias) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("functionalias"), nil
 }
 
-func (cmd *UpdateContainertask) inject(params map[string]interface{}) error {
+func (cmd *UpdateFunctionalias) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewUpdateDistribution(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateDistribution {
-	cmd := new(UpdateDistribution)
+func NewUpdateImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateImage {
+	cmd := new(UpdateImage)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudfront.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *UpdateDistribution) SetApi(api cloudfrontiface.CloudFrontAPI) {
+func (cmd *UpdateImage) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *UpdateDistribution) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateImage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *UpdateDistribution) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateImage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -12439,16 +16524,16 @@ func (cmd *UpdateDistribution) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("update distribution: AWS command returned nil output")
+			renv.Log().Warning("update image: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("update distribution '%s' done", extracted)
+		renv.Log().Verbosef("update image '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("update distribution done")
+		renv.Log().Verbose("update image done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -12460,40 +16545,36 @@ func (cmd *UpdateDistribution) run(renv env.Running, params map[string]interface
 	return extracted, nil
 }
 
-func (cmd *UpdateDistribution) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
-	return fakeDryRunId("distribution"), nil
-}
-
-func (cmd *UpdateDistribution) inject(params map[string]interface{}) error {
+func (cmd *UpdateImage) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewUpdateImage(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateImage {
-	cmd := new(UpdateImage)
+func NewUpdateInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateInstance {
+	cmd := new(UpdateInstance)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *UpdateImage) SetApi(api ec2iface.EC2API) {
+func (cmd *UpdateInstance) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *UpdateImage) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *UpdateImage) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -12512,16 +16593,16 @@ func (cmd *UpdateImage) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("update image: AWS command returned nil output")
+			renv.Log().Warning("update instance: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("update image '%s' done", extracted)
+		renv.Log().Verbosef("update instance '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("update image done")
+		renv.Log().Verbose("update instance done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -12533,36 +16614,36 @@ func (cmd *UpdateImage) run(renv env.Running, params map[string]interface{}) (in
 	return extracted, nil
 }
 
-func (cmd *UpdateImage) inject(params map[string]interface{}) error {
+func (cmd *UpdateInstance) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
-func NewUpdateInstance(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateInstance {
-	cmd := new(UpdateInstance)
+func NewUpdateLaunchtemplate(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateLaunchtemplate {
+	cmd := new(UpdateLaunchtemplate)
 	if len(l) > 0 {
 		cmd.logger = l[0]
 	} else {
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
 }
 
-func (cmd *UpdateInstance) SetApi(api ec2iface.EC2API) {
+func (cmd *UpdateLaunchtemplate) SetApi(api ec2iface.EC2API) {
 	cmd.api = api
 }
 
-func (cmd *UpdateInstance) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateLaunchtemplate) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if renv.IsDryRun() {
 		return cmd.dryRun(renv, params)
 	}
 	return cmd.run(renv, params)
 }
 
-func (cmd *UpdateInstance) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateLaunchtemplate) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
@@ -12573,13 +16654,13 @@ func (cmd *UpdateInstance) run(renv env.Running, params map[string]interface{})
 		}
 	}
 
-	input := &ec2.ModifyInstanceAttributeInput{}
+	input := &ec2.CreateLaunchTemplateVersionInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.ModifyInstanceAttributeInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateLaunchTemplateVersionInput: %s", err)
 	}
 	start := time.Now()
-	output, err := cmd.api.ModifyInstanceAttribute(input)
-	renv.Log().ExtraVerbosef("ec2.ModifyInstanceAttribute call took %s", time.Since(start))
+	output, err := cmd.api.CreateLaunchTemplateVersion(input)
+	renv.Log().ExtraVerbosef("ec2.CreateLaunchTemplateVersion call took %s", time.Since(start))
 	if err != nil {
 		return nil, decorateAWSError(err)
 	}
@@ -12587,16 +16668,16 @@ func (cmd *UpdateInstance) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
-			renv.Log().Warning("update instance: AWS command returned nil output")
+			renv.Log().Warning("update launchtemplate: AWS command returned nil output")
 		}
 	}
 
 	if extracted != nil {
-		renv.Log().Verbosef("update instance '%s' done", extracted)
+		renv.Log().Verbosef("update launchtemplate '%s' done", extracted)
 	} else {
-		renv.Log().Verbose("update instance done")
+		renv.Log().Verbose("update launchtemplate done")
 	}
 
 	if v, ok := implementsAfterRun(cmd); ok {
@@ -12608,32 +16689,38 @@ func (cmd *UpdateInstance) run(renv env.Running, params map[string]interface{})
 	return extracted, nil
 }
 
-func (cmd *UpdateInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+func (cmd *UpdateLaunchtemplate) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
 
-	input := &ec2.ModifyInstanceAttributeInput{}
+	if v, ok := implementsBeforeDryRun(cmd); ok {
+		if brErr := v.BeforeDryRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before dry run: %s", brErr)
+		}
+	}
+
+	input := &ec2.CreateLaunchTemplateVersionInput{}
 	input.SetDryRun(true)
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
-		return nil, fmt.Errorf("cannot inject in ec2.ModifyInstanceAttributeInput: %s", err)
+		return nil, fmt.Errorf("cannot inject in ec2.CreateLaunchTemplateVersionInput: %s", err)
 	}
 
 	start := time.Now()
-	_, err := cmd.api.ModifyInstanceAttribute(input)
+	_, err := cmd.api.CreateLaunchTemplateVersion(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			renv.Log().ExtraVerbosef("dry run: ec2.ModifyInstanceAttribute call took %s", time.Since(start))
-			renv.Log().Verbose("dry run: update instance ok")
-			return fakeDryRunId("instance"), nil
+			renv.Log().ExtraVerbosef("dry run: ec2.CreateLaunchTemplateVersion call took %s", time.Since(start))
+			renv.Log().Verbose("dry run: update launchtemplate ok")
+			return fakeDryRunId("launchtemplate"), nil
 		}
 	}
 
 	return nil, err
 }
 
-func (cmd *UpdateInstance) inject(params map[string]interface{}) error {
+func (cmd *UpdateLaunchtemplate) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
@@ -12645,7 +16732,7 @@ func NewUpdateLoginprofile(sess *session.Session, g cloud.GraphAPI, l ...*logger
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -12687,7 +16774,7 @@ func (cmd *UpdateLoginprofile) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update loginprofile: AWS command returned nil output")
 		}
@@ -12716,6 +16803,79 @@ func (cmd *UpdateLoginprofile) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func NewUpdateOriginaccessidentity(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateOriginaccessidentity {
+	cmd := new(UpdateOriginaccessidentity)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "cloudfront", func() interface{} { return cloudfront.New(sess) }).(cloudfrontiface.CloudFrontAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *UpdateOriginaccessidentity) SetApi(api cloudfrontiface.CloudFrontAPI) {
+	cmd.api = api
+}
+
+func (cmd *UpdateOriginaccessidentity) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *UpdateOriginaccessidentity) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("update originaccessidentity: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("update originaccessidentity '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("update originaccessidentity done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *UpdateOriginaccessidentity) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("originaccessidentity"), nil
+}
+
+func (cmd *UpdateOriginaccessidentity) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
 func NewUpdatePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdatePolicy {
 	cmd := new(UpdatePolicy)
 	if len(l) > 0 {
@@ -12724,7 +16884,7 @@ func NewUpdatePolicy(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = iam.New(sess)
+		cmd.api = awspool.Default.Client(sess, "iam", func() interface{} { return iam.New(sess) }).(iamiface.IAMAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -12766,7 +16926,7 @@ func (cmd *UpdatePolicy) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update policy: AWS command returned nil output")
 		}
@@ -12803,7 +16963,7 @@ func NewUpdateRecord(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = route53.New(sess)
+		cmd.api = awspool.Default.Client(sess, "route53", func() interface{} { return route53.New(sess) }).(route53iface.Route53API)
 	}
 	cmd.graph = g
 	return cmd
@@ -12839,7 +16999,7 @@ func (cmd *UpdateRecord) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update record: AWS command returned nil output")
 		}
@@ -12876,7 +17036,7 @@ func NewUpdateS3object(sess *session.Session, g cloud.GraphAPI, l ...*logger.Log
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = s3.New(sess)
+		cmd.api = awspool.Default.Client(sess, "s3", func() interface{} { return s3.New(sess) }).(s3iface.S3API)
 	}
 	cmd.graph = g
 	return cmd
@@ -12918,7 +17078,7 @@ func (cmd *UpdateS3object) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update s3object: AWS command returned nil output")
 		}
@@ -12955,7 +17115,7 @@ func NewUpdateScalinggroup(sess *session.Session, g cloud.GraphAPI, l ...*logger
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = autoscaling.New(sess)
+		cmd.api = awspool.Default.Client(sess, "autoscaling", func() interface{} { return autoscaling.New(sess) }).(autoscalingiface.AutoScalingAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -12997,7 +17157,7 @@ func (cmd *UpdateScalinggroup) run(renv env.Running, params map[string]interface
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update scalinggroup: AWS command returned nil output")
 		}
@@ -13034,7 +17194,7 @@ func NewUpdateSecuritygroup(sess *session.Session, g cloud.GraphAPI, l ...*logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -13070,7 +17230,7 @@ func (cmd *UpdateSecuritygroup) run(renv env.Running, params map[string]interfac
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update securitygroup: AWS command returned nil output")
 		}
@@ -13095,6 +17255,79 @@ func (cmd *UpdateSecuritygroup) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func NewUpdateSnapshot(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateSnapshot {
+	cmd := new(UpdateSnapshot)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *UpdateSnapshot) SetApi(api ec2iface.EC2API) {
+	cmd.api = api
+}
+
+func (cmd *UpdateSnapshot) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *UpdateSnapshot) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("update snapshot: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("update snapshot '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("update snapshot done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *UpdateSnapshot) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("snapshot"), nil
+}
+
+func (cmd *UpdateSnapshot) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
 func NewUpdateStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateStack {
 	cmd := new(UpdateStack)
 	if len(l) > 0 {
@@ -13103,7 +17336,7 @@ func NewUpdateStack(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = cloudformation.New(sess)
+		cmd.api = awspool.Default.Client(sess, "cloudformation", func() interface{} { return cloudformation.New(sess) }).(cloudformationiface.CloudFormationAPI)
 	}
 	cmd.graph = g
 	return cmd
@@ -13145,7 +17378,7 @@ func (cmd *UpdateStack) run(renv env.Running, params map[string]interface{}) (in
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update stack: AWS command returned nil output")
 		}
@@ -13182,7 +17415,7 @@ func NewUpdateSubnet(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logge
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = ec2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "ec2", func() interface{} { return ec2.New(sess) }).(ec2iface.EC2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -13224,7 +17457,7 @@ func (cmd *UpdateSubnet) run(renv env.Running, params map[string]interface{}) (i
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update subnet: AWS command returned nil output")
 		}
@@ -13253,6 +17486,79 @@ func (cmd *UpdateSubnet) inject(params map[string]interface{}) error {
 	return structSetter(cmd, params)
 }
 
+func NewUpdateTable(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateTable {
+	cmd := new(UpdateTable)
+	if len(l) > 0 {
+		cmd.logger = l[0]
+	} else {
+		cmd.logger = logger.DiscardLogger
+	}
+	if sess != nil {
+		cmd.api = awspool.Default.Client(sess, "dynamodb", func() interface{} { return dynamodb.New(sess) }).(dynamodbiface.DynamoDBAPI)
+	}
+	cmd.graph = g
+	return cmd
+}
+
+func (cmd *UpdateTable) SetApi(api dynamodbiface.DynamoDBAPI) {
+	cmd.api = api
+}
+
+func (cmd *UpdateTable) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return cmd.dryRun(renv, params)
+	}
+	return cmd.run(renv, params)
+}
+
+func (cmd *UpdateTable) run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
+	}
+
+	if v, ok := implementsBeforeRun(cmd); ok {
+		if brErr := v.BeforeRun(renv); brErr != nil {
+			return nil, fmt.Errorf("before run: %s", brErr)
+		}
+	}
+
+	output, err := cmd.ManualRun(renv)
+	if err != nil {
+		return nil, decorateAWSError(err)
+	}
+
+	var extracted interface{}
+	if v, ok := implementsResultExtractor(cmd); ok {
+		if output != nil {
+			extracted = extractResult(v, output)
+		} else {
+			renv.Log().Warning("update table: AWS command returned nil output")
+		}
+	}
+
+	if extracted != nil {
+		renv.Log().Verbosef("update table '%s' done", extracted)
+	} else {
+		renv.Log().Verbose("update table done")
+	}
+
+	if v, ok := implementsAfterRun(cmd); ok {
+		if brErr := v.AfterRun(renv, output); brErr != nil {
+			return nil, fmt.Errorf("after run: %s", brErr)
+		}
+	}
+
+	return extracted, nil
+}
+
+func (cmd *UpdateTable) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	return fakeDryRunId("table"), nil
+}
+
+func (cmd *UpdateTable) inject(params map[string]interface{}) error {
+	return structSetter(cmd, params)
+}
+
 func NewUpdateTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.Logger) *UpdateTargetgroup {
 	cmd := new(UpdateTargetgroup)
 	if len(l) > 0 {
@@ -13261,7 +17567,7 @@ func NewUpdateTargetgroup(sess *session.Session, g cloud.GraphAPI, l ...*logger.
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = elbv2.New(sess)
+		cmd.api = awspool.Default.Client(sess, "elbv2", func() interface{} { return elbv2.New(sess) }).(elbv2iface.ELBV2API)
 	}
 	cmd.graph = g
 	return cmd
@@ -13297,7 +17603,7 @@ func (cmd *UpdateTargetgroup) run(renv env.Running, params map[string]interface{
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("update targetgroup: AWS command returned nil output")
 		}
@@ -0,0 +1,92 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateHealthcheck struct {
+	_                string `action:"create" entity:"healthcheck" awsAPI:"route53" awsCall:"CreateHealthCheck" awsInput:"route53.CreateHealthCheckInput" awsOutput:"route53.CreateHealthCheckOutput"`
+	logger           *logger.Logger
+	graph            cloud.GraphAPI
+	api              route53iface.Route53API
+	Callerreference  *string `awsName:"CallerReference" awsType:"awsstr" templateName:"callerreference"`
+	Type             *string `awsName:"HealthCheckConfig.Type" awsType:"awsstr" templateName:"type"`
+	IP               *string `awsName:"HealthCheckConfig.IPAddress" awsType:"awsstr" templateName:"ip"`
+	Domain           *string `awsName:"HealthCheckConfig.FullyQualifiedDomainName" awsType:"awsstr" templateName:"domain"`
+	Port             *int64  `awsName:"HealthCheckConfig.Port" awsType:"awsint64" templateName:"port"`
+	Path             *string `awsName:"HealthCheckConfig.ResourcePath" awsType:"awsstr" templateName:"path"`
+	SearchString     *string `awsName:"HealthCheckConfig.SearchString" awsType:"awsstr" templateName:"search-string"`
+	Interval         *int64  `awsName:"HealthCheckConfig.RequestInterval" awsType:"awsint64" templateName:"interval"`
+	FailureThreshold *int64  `awsName:"HealthCheckConfig.FailureThreshold" awsType:"awsint64" templateName:"failure-threshold"`
+}
+
+func (cmd *CreateHealthcheck) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(
+		params.AllOf(params.Key("callerreference"), params.Key("type"), params.AtLeastOneOf(params.Key("ip"), params.Key("domain")),
+			params.Opt("failure-threshold", "interval", "path", "port", "search-string"),
+		),
+		params.Validators{
+			"type": params.All(
+				params.IsInEnumIgnoreCase("HTTP", "HTTPS", "HTTP_STR_MATCH", "HTTPS_STR_MATCH", "TCP"),
+				params.When(params.IsInEnumIgnoreCase("HTTP_STR_MATCH", "HTTPS_STR_MATCH"), params.Requires("search-string")),
+				params.When(params.IsInEnumIgnoreCase("TCP"), params.Requires("port")),
+			),
+			"ip": params.IsIP,
+			"interval": func(i interface{}, others map[string]interface{}) error {
+				interval, err := castInt(i)
+				if err != nil {
+					return err
+				}
+				if interval != 10 && interval != 30 {
+					return fmt.Errorf("expected 10 or 30 (seconds) but got %d", interval)
+				}
+				return nil
+			},
+		})
+	builder.AddReducer(func(values map[string]interface{}) (map[string]interface{}, error) {
+		if t, ok := values["type"].(string); ok {
+			return map[string]interface{}{"type": strings.ToUpper(t)}, nil
+		}
+		return values, nil
+	}, "type")
+	return builder.Done()
+}
+
+func (cmd *CreateHealthcheck) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*route53.CreateHealthCheckOutput).HealthCheck.Id)
+}
+
+type DeleteHealthcheck struct {
+	_      string `action:"delete" entity:"healthcheck" awsAPI:"route53" awsCall:"DeleteHealthCheck" awsInput:"route53.DeleteHealthCheckInput" awsOutput:"route53.DeleteHealthCheckOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    route53iface.Route53API
+	Id     *string `awsName:"HealthCheckId" awsType:"awsstr" templateName:"id"`
+}
+
+func (cmd *DeleteHealthcheck) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id")))
+}
@@ -0,0 +1,54 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/template/params"
+)
+
+func TestHealthcheckValidators(t *testing.T) {
+	tcases := []struct {
+		params map[string]interface{}
+		expErr string
+	}{
+		{params: map[string]interface{}{"type": "https", "domain": "www.example.com", "interval": 10}},
+		{params: map[string]interface{}{"type": "TCP", "ip": "192.0.2.1", "port": 22}},
+		{params: map[string]interface{}{"type": "HTTP_STR_MATCH", "ip": "192.0.2.1", "search-string": "ok"}},
+		{params: map[string]interface{}{"type": "UDP"}, expErr: "expected any of"},
+		{params: map[string]interface{}{"type": "HTTPS_STR_MATCH"}, expErr: "with value 'HTTPS_STR_MATCH': requires param(s) 'search-string'"},
+		{params: map[string]interface{}{"type": "TCP"}, expErr: "with value 'TCP': requires param(s) 'port'"},
+		{params: map[string]interface{}{"ip": "192.0.2"}, expErr: "expected valid IP address but got '192.0.2'"},
+		{params: map[string]interface{}{"interval": 20}, expErr: "expected 10 or 30 (seconds) but got 20"},
+	}
+	for i, tcase := range tcases {
+		err := params.Validate(new(CreateHealthcheck).ParamsSpec().Validators(), tcase.params)
+		if tcase.expErr == "" {
+			if err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
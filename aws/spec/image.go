@@ -26,6 +26,7 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/wallix/awless/logger"
@@ -40,13 +41,16 @@ type CreateImage struct {
 	Instance    *string `awsName:"InstanceId" awsType:"awsstr" templateName:"instance"`
 	Reboot      *bool   `awsName:"NoReboot" awsType:"awsbool" templateName:"reboot"`
 	Description *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+	Wait        *bool   `templateName:"wait"`
+	WaitTimeout *int64  `templateName:"wait-timeout"`
 }
 
 func (cmd *CreateImage) ParamsSpec() params.Spec {
 	return params.NewSpec(
-		params.AllOf(params.Key("instance"), params.Key("name"), params.Opt("description", "reboot")),
+		params.AllOf(params.Key("instance"), params.Key("name"), params.Opt("description", "reboot", "wait", "wait-timeout")),
 		params.Validators{
-			"name": params.MinLengthOf(3),
+			"name":         params.MinLengthOf(3),
+			"wait-timeout": validateWaitTimeout,
 		})
 }
 
@@ -63,6 +67,11 @@ func (cmd *CreateImage) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateImageOutput).ImageId)
 }
 
+func (cmd *CreateImage) AfterRun(renv env.Running, output interface{}) error {
+	waitImageAvailable(cmd.api, renv.Log(), cmd.Wait, cmd.WaitTimeout, cmd.ExtractResult(output))
+	return nil
+}
+
 type UpdateImage struct {
 	_            string `action:"update" entity:"image" awsAPI:"ec2" awsDryRun:"manual"`
 	logger       *logger.Logger
@@ -150,18 +159,62 @@ type CopyImage struct {
 	SourceRegion *string `awsName:"SourceRegion" awsType:"awsstr" templateName:"source-region"`
 	Encrypted    *bool   `awsName:"Encrypted" awsType:"awsbool" templateName:"encrypted"`
 	Description  *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+	Wait         *bool   `templateName:"wait"`
+	WaitTimeout  *int64  `templateName:"wait-timeout"`
 }
 
 func (cmd *CopyImage) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name"), params.Key("source-id"), params.Key("source-region"),
-		params.Opt("description", "encrypted"),
-	))
+		params.Opt("description", "encrypted", "wait", "wait-timeout"),
+	), params.Validators{"wait-timeout": validateWaitTimeout})
 }
 
 func (cmd *CopyImage) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CopyImageOutput).ImageId)
 }
 
+func (cmd *CopyImage) AfterRun(renv env.Running, output interface{}) error {
+	waitImageAvailable(cmd.api, renv.Log(), cmd.Wait, cmd.WaitTimeout, cmd.ExtractResult(output))
+	return nil
+}
+
+// waitImageAvailable waits for the image when wait is true, reporting the progress
+// of its snapshots each time the SDK waiter polls the image
+func waitImageAvailable(api ec2iface.EC2API, l *logger.Logger, wait *bool, timeout *int64, id string) {
+	waitUntil(l, wait, timeout, fmt.Sprintf("image %s available", id), func(ctx awssdk.Context, opts ...request.WaiterOption) error {
+		opts = append(opts, request.WithWaiterRequestOptions(func(r *request.Request) {
+			r.Handlers.Complete.PushBack(func(r *request.Request) {
+				if out, ok := r.Data.(*ec2.DescribeImagesOutput); ok && r.Error == nil {
+					logImagesProgress(api, l, out.Images)
+				}
+			})
+		}))
+		return api.WaitUntilImageAvailableWithContext(ctx, &ec2.DescribeImagesInput{ImageIds: []*string{String(id)}}, opts...)
+	})
+}
+
+func logImagesProgress(api ec2iface.EC2API, l *logger.Logger, images []*ec2.Image) {
+	for _, img := range images {
+		var snapIds []*string
+		for _, dev := range img.BlockDeviceMappings {
+			if dev.Ebs != nil && dev.Ebs.SnapshotId != nil {
+				snapIds = append(snapIds, dev.Ebs.SnapshotId)
+			}
+		}
+		if len(snapIds) == 0 {
+			l.InteractiveInfof("image %s %s", StringValue(img.ImageId), StringValue(img.State))
+			continue
+		}
+		var progress []string
+		if out, err := api.DescribeSnapshots(&ec2.DescribeSnapshotsInput{SnapshotIds: snapIds}); err == nil {
+			for _, snap := range out.Snapshots {
+				progress = append(progress, fmt.Sprintf("%s %s", StringValue(snap.SnapshotId), StringValue(snap.Progress)))
+			}
+		}
+		l.InteractiveInfof("image %s %s: %s", StringValue(img.ImageId), StringValue(img.State), strings.Join(progress, ", "))
+	}
+}
+
 type ImportImage struct {
 	_            string `action:"import" entity:"image" awsAPI:"ec2" awsCall:"ImportImage" awsInput:"ec2.ImportImageInput" awsOutput:"ec2.ImportImageOutput" awsDryRun:""`
 	logger       *logger.Logger
@@ -17,10 +17,12 @@ package awsspec
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
@@ -47,20 +49,68 @@ type CreateInstance struct {
 	Lock           *bool     `awsName:"DisableApiTermination" awsType:"awsbool" templateName:"lock"`
 	Role           *string   `awsName:"IamInstanceProfile.Name" awsType:"awsstr" templateName:"role"`
 	DistroQuery    *string   `awsType:"awsstr" templateName:"distro"`
+	Spot           *bool     `templateName:"spot"`
+	SpotPrice      *string   `awsName:"InstanceMarketOptions.SpotOptions.MaxPrice" awsType:"awsstr" templateName:"spotprice"`
+	SpotInterrupt  *string   `awsName:"InstanceMarketOptions.SpotOptions.InstanceInterruptionBehavior" awsType:"awsstr" templateName:"spotinterruption"`
+	MarketType     *string   `awsName:"InstanceMarketOptions.MarketType" awsType:"awsstr"`
+	SpotType       *string   `awsName:"InstanceMarketOptions.SpotOptions.SpotInstanceType" awsType:"awsstr"`
+	Wait           *bool     `templateName:"wait"`
+	WaitTimeout    *int64    `templateName:"wait-timeout"`
 }
 
 func (cmd *CreateInstance) ParamsSpec() params.Spec {
 	builder := params.SpecBuilder(
 		params.AllOf(params.OnlyOneOf(params.Key("distro"), params.Key("image")),
 			params.Key("count"), params.Key("type"), params.Key("name"), params.Key("subnet"),
-			params.Opt(params.Suggested("keypair", "securitygroup"), "ip", "userdata", "lock", "role"),
+			params.Opt(params.Suggested("keypair", "securitygroup"), "ip", "userdata", "lock", "role", "spot", "spotinterruption", "spotprice", "wait", "wait-timeout"),
 		),
-		params.Validators{"ip": params.IsIP},
+		params.Validators{
+			"ip":               params.IsIP,
+			"spotprice":        params.Requires("spot"),
+			"spotinterruption": params.All(params.IsInEnumIgnoreCase("terminate", "stop", "hibernate"), params.Requires("spot")),
+			"wait-timeout":     validateWaitTimeout,
+			"type":             func(i interface{}, others map[string]interface{}) error { return validateInstanceType(cmd.api, i) },
+		},
 	)
 	builder.AddReducer(cmd.convertDistroToAMI, "distro")
 	return builder.Done()
 }
 
+// validateInstanceType checks the type against the instance types EC2 offers in the region. The vendored SDK
+// predates DescribeInstanceTypeOfferings: the types offered are the ones of the reserved instances offerings.
+func validateInstanceType(api ec2iface.EC2API, i interface{}) error {
+	if api == nil {
+		return nil
+	}
+	validator := cachedDynamicEnumValidator(api, "ec2.instancetypes", func() (types []string, err error) {
+		unique := make(map[string]bool)
+		input := &ec2.DescribeReservedInstancesOfferingsInput{
+			IncludeMarketplace: Bool(false),
+			InstanceTenancy:    String(ec2.TenancyDefault),
+			OfferingClass:      String(ec2.OfferingClassTypeStandard),
+			OfferingType:       String(ec2.OfferingTypeValuesNoUpfront),
+			ProductDescription: String(ec2.RIProductDescriptionLinuxUnix),
+		}
+		for {
+			out, err := api.DescribeReservedInstancesOfferings(input)
+			if err != nil {
+				return nil, err
+			}
+			for _, offering := range out.ReservedInstancesOfferings {
+				if typ := StringValue(offering.InstanceType); !unique[typ] {
+					unique[typ] = true
+					types = append(types, typ)
+				}
+			}
+			if out.NextToken == nil {
+				return types, nil
+			}
+			input.NextToken = out.NextToken
+		}
+	})
+	return validator.Validate(String(castString(i)))
+}
+
 func (cmd *CreateInstance) convertDistroToAMI(values map[string]interface{}) (map[string]interface{}, error) {
 	if distro, ok := values["distro"].(string); ok {
 		query, err := ParseImageQuery(distro)
@@ -91,38 +141,222 @@ func (cmd *CreateInstance) ExtractResult(i interface{}) string {
 	return StringValue(i.(*ec2.Reservation).Instances[0].InstanceId)
 }
 
+func (cmd *CreateInstance) ExtractTypedResult(i interface{}) *env.Result {
+	inst := i.(*ec2.Reservation).Instances[0]
+	res := &env.Result{
+		ID: StringValue(inst.InstanceId),
+		Attributes: map[string]string{
+			"privateip": StringValue(inst.PrivateIpAddress),
+			"publicip":  StringValue(inst.PublicIpAddress),
+			"subnet":    StringValue(inst.SubnetId),
+			"vpc":       StringValue(inst.VpcId),
+		},
+	}
+	if inst.Placement != nil {
+		res.Attributes["availabilityzone"] = StringValue(inst.Placement.AvailabilityZone)
+	}
+	return res
+}
+
+func (cmd *CreateInstance) BeforeRun(renv env.Running) error {
+	cmd.setMarketOptions()
+	return nil
+}
+
+func (cmd *CreateInstance) BeforeDryRun(renv env.Running) error {
+	cmd.setMarketOptions()
+	return nil
+}
+
+// setMarketOptions requests a spot instance when spot=true. Since stopped or hibernated
+// spot instances are restarted by their request, such interruptions need a persistent request.
+func (cmd *CreateInstance) setMarketOptions() {
+	if !BoolValue(cmd.Spot) {
+		return
+	}
+	cmd.MarketType = String(ec2.MarketTypeSpot)
+	if cmd.SpotInterrupt == nil {
+		return
+	}
+	interruption := strings.ToLower(StringValue(cmd.SpotInterrupt))
+	cmd.SpotInterrupt = String(interruption)
+	if interruption == ec2.InstanceInterruptionBehaviorStop || interruption == ec2.InstanceInterruptionBehaviorHibernate {
+		cmd.SpotType = String(ec2.SpotInstanceTypePersistent)
+	}
+}
+
 func (cmd *CreateInstance) AfterRun(renv env.Running, output interface{}) error {
-	return createNameTag(String(cmd.ExtractResult(output)), cmd.Name, renv)
+	id := String(cmd.ExtractResult(output))
+	if err := createNameTag(id, cmd.Name, renv); err != nil {
+		return err
+	}
+	var ids []*string
+	for _, inst := range output.(*ec2.Reservation).Instances {
+		ids = append(ids, inst.InstanceId)
+	}
+	waitUntil(renv.Log(), cmd.Wait, cmd.WaitTimeout, fmt.Sprintf("instance(s) %s running", strings.Join(awssdk.StringValueSlice(ids), ", ")), func(ctx awssdk.Context, opts ...request.WaiterOption) error {
+		return cmd.api.WaitUntilInstanceRunningWithContext(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids}, opts...)
+	})
+	return nil
 }
 
 type UpdateInstance struct {
-	_      string `action:"update" entity:"instance" awsAPI:"ec2" awsCall:"ModifyInstanceAttribute" awsInput:"ec2.ModifyInstanceAttributeInput" awsOutput:"ec2.ModifyInstanceAttributeOutput" awsDryRun:""`
-	logger *logger.Logger
-	graph  cloud.GraphAPI
-	api    ec2iface.EC2API
-	Id     *string `awsName:"InstanceId" awsType:"awsstr" templateName:"id"`
-	Type   *string `awsName:"InstanceType.Value" awsType:"awsstr" templateName:"type"`
-	Lock   *bool   `awsName:"DisableApiTermination" awsType:"awsboolattribute" templateName:"lock"`
+	_              string `action:"update" entity:"instance" awsAPI:"ec2" awsDryRun:"manual"`
+	logger         *logger.Logger
+	graph          cloud.GraphAPI
+	api            ec2iface.EC2API
+	Id             *string `awsName:"InstanceId" awsType:"awsstr" templateName:"id"`
+	Type           *string `awsName:"InstanceType.Value" awsType:"awsstr" templateName:"type"`
+	Lock           *bool   `awsName:"DisableApiTermination" awsType:"awsboolattribute" templateName:"lock"`
+	MetadataTokens *string `templateName:"metadata-tokens"`
 }
 
 func (cmd *UpdateInstance) ParamsSpec() params.Spec {
-	return params.NewSpec(params.AllOf(params.Key("id"), params.Opt("lock", "type")))
+	return params.NewSpec(
+		params.AllOf(params.Key("id"), params.Opt("lock", "metadata-tokens", "type")),
+		params.Validators{
+			"metadata-tokens": params.IsInEnumIgnoreCase("required", "optional"),
+			"type":            func(i interface{}, others map[string]interface{}) error { return validateInstanceType(cmd.api, i) },
+		},
+	)
+}
+
+func (cmd *UpdateInstance) ManualRun(renv env.Running) (interface{}, error) {
+	if cmd.Type != nil || cmd.Lock != nil {
+		input := &ec2.ModifyInstanceAttributeInput{}
+		if err := structInjector(cmd, input, renv.Context()); err != nil {
+			return nil, fmt.Errorf("cannot inject in ec2.ModifyInstanceAttributeInput: %s", err)
+		}
+		start := time.Now()
+		if _, err := cmd.api.ModifyInstanceAttribute(input); err != nil {
+			return nil, err
+		}
+		renv.Log().ExtraVerbosef("ec2.ModifyInstanceAttribute call took %s", time.Since(start))
+	}
+	if cmd.MetadataTokens != nil {
+		if err := modifyInstanceMetadataTokens(cmd.api, StringValue(cmd.Id), strings.ToLower(StringValue(cmd.MetadataTokens)), false); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+func (cmd *UpdateInstance) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if err := cmd.inject(params); err != nil {
+		return nil, fmt.Errorf("dry run: cannot set params on command struct: %s", err)
+	}
+	dryRunOK := func(err error) bool {
+		awsErr, ok := err.(awserr.Error)
+		return ok && (awsErr.Code() == dryRunOperation || strings.HasSuffix(awsErr.Code(), notFound))
+	}
+
+	if cmd.Type != nil || cmd.Lock != nil {
+		input := &ec2.ModifyInstanceAttributeInput{}
+		input.SetDryRun(true)
+		if err := structInjector(cmd, input, renv.Context()); err != nil {
+			return nil, fmt.Errorf("dry run: cannot inject in ec2.ModifyInstanceAttributeInput: %s", err)
+		}
+		start := time.Now()
+		if _, err := cmd.api.ModifyInstanceAttribute(input); !dryRunOK(err) {
+			return nil, fmt.Errorf("dry run: %s", err)
+		}
+		renv.Log().ExtraVerbosef("dry run: ec2.ModifyInstanceAttribute call took %s", time.Since(start))
+	}
+	if cmd.MetadataTokens != nil {
+		if err := modifyInstanceMetadataTokens(cmd.api, StringValue(cmd.Id), strings.ToLower(StringValue(cmd.MetadataTokens)), true); !dryRunOK(err) {
+			return nil, fmt.Errorf("dry run: %s", err)
+		}
+	}
+	renv.Log().Verbose("dry run: update instance ok")
+	return fakeDryRunId("instance"), nil
+}
+
+// The vendored SDK does not expose the ModifyInstanceMetadataOptions operation
+// (IMDSv2 enforcement), so we build the raw EC2 query request ourselves
+type modifyInstanceMetadataOptionsInput struct {
+	_          struct{} `type:"structure"`
+	DryRun     *bool    `type:"boolean"`
+	InstanceId *string  `type:"string" required:"true"`
+	HttpTokens *string  `type:"string"`
+}
+
+type modifyInstanceMetadataOptionsOutput struct {
+	_          struct{} `type:"structure"`
+	InstanceId *string  `locationName:"instanceId" type:"string"`
+}
+
+func modifyInstanceMetadataTokens(api ec2iface.EC2API, id, tokens string, dryRun bool) error {
+	client, ok := api.(*ec2.EC2)
+	if !ok {
+		return fmt.Errorf("update instance metadata tokens: unsupported EC2 client %T", api)
+	}
+	op := &request.Operation{
+		Name:       "ModifyInstanceMetadataOptions",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	input := &modifyInstanceMetadataOptionsInput{InstanceId: awssdk.String(id), HttpTokens: awssdk.String(tokens)}
+	if dryRun {
+		input.DryRun = awssdk.Bool(true)
+	}
+	return client.NewRequest(op, input, &modifyInstanceMetadataOptionsOutput{}).Send()
 }
 
 type DeleteInstance struct {
-	_      string `action:"delete" entity:"instance" awsAPI:"ec2" awsCall:"TerminateInstances" awsInput:"ec2.TerminateInstancesInput" awsOutput:"ec2.TerminateInstancesOutput" awsDryRun:""`
-	logger *logger.Logger
-	graph  cloud.GraphAPI
-	api    ec2iface.EC2API
-	IDs    []*string `awsName:"InstanceIds" awsType:"awsstringslice" templateName:"ids"`
+	_           string `action:"delete" entity:"instance" awsAPI:"ec2" awsCall:"TerminateInstances" awsInput:"ec2.TerminateInstancesInput" awsOutput:"ec2.TerminateInstancesOutput" awsDryRun:""`
+	logger      *logger.Logger
+	graph       cloud.GraphAPI
+	api         ec2iface.EC2API
+	IDs         []*string `awsName:"InstanceIds" awsType:"awsstringslice" templateName:"ids"`
+	CancelSpot  *bool     `templateName:"cancel-spot-requests"`
+	Wait        *bool     `templateName:"wait"`
+	WaitTimeout *int64    `templateName:"wait-timeout"`
 }
 
 func (cmd *DeleteInstance) ParamsSpec() params.Spec {
-	builder := params.SpecBuilder(params.OnlyOneOf(params.Key("ids"), params.Key("id")))
+	builder := params.SpecBuilder(
+		params.AllOf(params.OnlyOneOf(params.Key("ids"), params.Key("id")), params.Opt("cancel-spot-requests", "wait", "wait-timeout")),
+		params.Validators{"wait-timeout": validateWaitTimeout},
+	)
 	builder.AddReducer(idToIds, "id")
 	return builder.Done()
 }
 
+// BeforeRun cancels the spot requests of the instances when cancel-spot-requests=true,
+// persistent spot requests launching new instances once theirs are terminated
+func (cmd *DeleteInstance) BeforeRun(renv env.Running) error {
+	if !BoolValue(cmd.CancelSpot) {
+		return nil
+	}
+	out, err := cmd.api.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: cmd.IDs})
+	if err != nil {
+		return fmt.Errorf("cannot find spot requests of instances: %s", err)
+	}
+	var requests []*string
+	for _, reserv := range out.Reservations {
+		for _, inst := range reserv.Instances {
+			if inst.SpotInstanceRequestId != nil {
+				requests = append(requests, inst.SpotInstanceRequestId)
+			}
+		}
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+	if _, err = cmd.api.CancelSpotInstanceRequests(&ec2.CancelSpotInstanceRequestsInput{SpotInstanceRequestIds: requests}); err != nil {
+		return fmt.Errorf("cannot cancel spot requests %s: %s", strings.Join(awssdk.StringValueSlice(requests), ", "), err)
+	}
+	renv.Log().Verbosef("spot request(s) %s cancelled", strings.Join(awssdk.StringValueSlice(requests), ", "))
+	return nil
+}
+
+func (cmd *DeleteInstance) AfterRun(renv env.Running, output interface{}) error {
+	waitUntil(renv.Log(), cmd.Wait, cmd.WaitTimeout, fmt.Sprintf("instance(s) %s terminated", strings.Join(awssdk.StringValueSlice(cmd.IDs), ", ")), func(ctx awssdk.Context, opts ...request.WaiterOption) error {
+		return cmd.api.WaitUntilInstanceTerminatedWithContext(ctx, &ec2.DescribeInstancesInput{InstanceIds: cmd.IDs}, opts...)
+	})
+	return nil
+}
+
 type StartInstance struct {
 	_      string `action:"start" entity:"instance" awsAPI:"ec2" awsCall:"StartInstances" awsInput:"ec2.StartInstancesInput" awsOutput:"ec2.StartInstancesOutput" awsDryRun:""`
 	logger *logger.Logger
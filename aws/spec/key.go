@@ -0,0 +1,150 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateKey struct {
+	_             string `action:"create" entity:"key" awsAPI:"kms" awsCall:"CreateKey" awsInput:"kms.CreateKeyInput" awsOutput:"kms.CreateKeyOutput"`
+	logger        *logger.Logger
+	graph         cloud.GraphAPI
+	api           kmsiface.KMSAPI
+	Description   *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+	Usage         *string `awsName:"KeyUsage" awsType:"awsstr" templateName:"usage"`
+	Origin        *string `awsName:"Origin" awsType:"awsstr" templateName:"origin"`
+	PolicyFile    *string `awsName:"Policy" awsType:"awsfiletostring" templateName:"policy-file"`
+	BypassLockout *bool   `awsName:"BypassPolicyLockoutSafetyCheck" awsType:"awsbool" templateName:"bypass-lockout"`
+}
+
+func (cmd *CreateKey) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(
+		params.AllOf(params.Opt("bypass-lockout", "description", "origin", "policy-file", "usage")),
+		params.Validators{
+			"usage":       params.IsInEnumIgnoreCase(kms.KeyUsageTypeEncryptDecrypt),
+			"origin":      params.IsInEnumIgnoreCase(kms.OriginTypeAwsKms, kms.OriginTypeExternal),
+			"policy-file": validateKeyPolicyFile,
+		})
+	builder.AddReducer(func(values map[string]interface{}) (map[string]interface{}, error) {
+		for k, v := range values {
+			if s, ok := v.(string); ok {
+				values[k] = strings.ToUpper(s)
+			}
+		}
+		return values, nil
+	}, "usage", "origin")
+	return builder.Done()
+}
+
+func (cmd *CreateKey) ExtractResult(i interface{}) string {
+	return StringValue(i.(*kms.CreateKeyOutput).KeyMetadata.KeyId)
+}
+
+type DeleteKey struct {
+	_           string `action:"delete" entity:"key" awsAPI:"kms" awsCall:"ScheduleKeyDeletion" awsInput:"kms.ScheduleKeyDeletionInput" awsOutput:"kms.ScheduleKeyDeletionOutput"`
+	logger      *logger.Logger
+	graph       cloud.GraphAPI
+	api         kmsiface.KMSAPI
+	Id          *string `awsName:"KeyId" awsType:"awsstr" templateName:"id"`
+	PendingDays *int64  `awsName:"PendingWindowInDays" awsType:"awsint64" templateName:"pending-days"`
+}
+
+func (cmd *DeleteKey) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("id"), params.Opt("pending-days")),
+		params.Validators{
+			"pending-days": func(i interface{}, others map[string]interface{}) error {
+				days, err := castInt(i)
+				if err != nil {
+					return err
+				}
+				if days < 7 || days > 30 {
+					return fmt.Errorf("expected between 7 and 30 days but got %d", days)
+				}
+				return nil
+			},
+		},
+	)
+}
+
+// validateKeyPolicyFile checks that the file holds a key policy document:
+// a JSON object with statements each having an effect, a principal and actions
+func validateKeyPolicyFile(i interface{}, others map[string]interface{}) error {
+	if err := params.IsFilepath(i, others); err != nil {
+		return err
+	}
+	content, err := ioutil.ReadFile(fmt.Sprint(i))
+	if err != nil {
+		return err
+	}
+	return validateKeyPolicy(content)
+}
+
+func validateKeyPolicy(content []byte) error {
+	var policy struct {
+		Statement json.RawMessage
+	}
+	if err := json.Unmarshal(content, &policy); err != nil {
+		return fmt.Errorf("invalid key policy: %s", err)
+	}
+	if len(policy.Statement) == 0 {
+		return errors.New("invalid key policy: missing 'Statement'")
+	}
+	type statement struct {
+		Sid       string
+		Effect    string
+		Principal interface{}
+		Action    interface{}
+	}
+	var statements []statement
+	if err := json.Unmarshal(policy.Statement, &statements); err != nil {
+		var single statement
+		if err = json.Unmarshal(policy.Statement, &single); err != nil {
+			return fmt.Errorf("invalid key policy: 'Statement': %s", err)
+		}
+		statements = append(statements, single)
+	}
+	if len(statements) == 0 {
+		return errors.New("invalid key policy: empty 'Statement'")
+	}
+	for i, stat := range statements {
+		name := stat.Sid
+		if name == "" {
+			name = fmt.Sprint(i + 1)
+		}
+		if stat.Effect != "Allow" && stat.Effect != "Deny" {
+			return fmt.Errorf("invalid key policy: statement '%s': expected 'Effect' Allow or Deny but got '%s'", name, stat.Effect)
+		}
+		if stat.Principal == nil {
+			return fmt.Errorf("invalid key policy: statement '%s': missing 'Principal'", name)
+		}
+		if stat.Action == nil {
+			return fmt.Errorf("invalid key policy: statement '%s': missing 'Action'", name)
+		}
+	}
+	return nil
+}
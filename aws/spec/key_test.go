@@ -0,0 +1,88 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/template/params"
+)
+
+func TestValidateKeyPolicy(t *testing.T) {
+	tcases := []struct {
+		policy string
+		expErr string
+	}{
+		{policy: `{"Version": "2012-10-17", "Statement": [{"Sid": "Enable IAM policies", "Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "kms:*", "Resource": "*"}]}`},
+		{policy: `{"Statement": {"Effect": "Deny", "Principal": "*", "Action": ["kms:Decrypt"], "Resource": "*"}}`},
+		{policy: `{"Statement": [`, expErr: "invalid key policy: unexpected end of JSON input"},
+		{policy: `{"Version": "2012-10-17"}`, expErr: "invalid key policy: missing 'Statement'"},
+		{policy: `{"Statement": []}`, expErr: "invalid key policy: empty 'Statement'"},
+		{policy: `{"Statement": "Allow"}`, expErr: "invalid key policy: 'Statement'"},
+		{policy: `{"Statement": [{"Sid": "root", "Effect": "Permit", "Principal": "*", "Action": "kms:*"}]}`, expErr: "invalid key policy: statement 'root': expected 'Effect' Allow or Deny but got 'Permit'"},
+		{policy: `{"Statement": [{"Effect": "Allow", "Principal": "*", "Action": "kms:*"}, {"Effect": "Allow", "Action": "kms:*"}]}`, expErr: "invalid key policy: statement '2': missing 'Principal'"},
+		{policy: `{"Statement": [{"Sid": "root", "Effect": "Allow", "Principal": "*"}]}`, expErr: "invalid key policy: statement 'root': missing 'Action'"},
+	}
+	for i, tcase := range tcases {
+		err := validateKeyPolicy([]byte(tcase.policy))
+		if tcase.expErr == "" {
+			if err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		if got, want := err.Error(), tcase.expErr; !strings.HasPrefix(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestKmsValidators(t *testing.T) {
+	tcases := []struct {
+		spec   params.Spec
+		params map[string]interface{}
+		expErr string
+	}{
+		{spec: new(CreateKey).ParamsSpec(), params: map[string]interface{}{"usage": "encrypt_decrypt", "origin": "EXTERNAL"}},
+		{spec: new(CreateKey).ParamsSpec(), params: map[string]interface{}{"origin": "CLOUDHSM"}, expErr: "expected any of"},
+		{spec: new(DeleteKey).ParamsSpec(), params: map[string]interface{}{"pending-days": 7}},
+		{spec: new(DeleteKey).ParamsSpec(), params: map[string]interface{}{"pending-days": 31}, expErr: "expected between 7 and 30 days but got 31"},
+		{spec: new(CreateAlias).ParamsSpec(), params: map[string]interface{}{"name": "alias/backups"}},
+		{spec: new(CreateAlias).ParamsSpec(), params: map[string]interface{}{"name": "backups"}, expErr: "expected name to begin with 'alias/'"},
+		{spec: new(DeleteAlias).ParamsSpec(), params: map[string]interface{}{"name": "alias/aws/s3"}, expErr: "'alias/aws/' prefix is reserved for AWS managed keys"},
+		{spec: new(AttachKeygrant).ParamsSpec(), params: map[string]interface{}{"operations": []interface{}{"Encrypt", "GenerateDataKey"}}},
+		{spec: new(AttachKeygrant).ParamsSpec(), params: map[string]interface{}{"operations": "Sign"}, expErr: "expected operations among"},
+	}
+	for i, tcase := range tcases {
+		err := params.Validate(tcase.spec.Validators(), tcase.params)
+		if tcase.expErr == "" {
+			if err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+var keyGrantOperations = []string{
+	kms.GrantOperationDecrypt, kms.GrantOperationEncrypt, kms.GrantOperationGenerateDataKey, kms.GrantOperationGenerateDataKeyWithoutPlaintext,
+	kms.GrantOperationReEncryptFrom, kms.GrantOperationReEncryptTo, kms.GrantOperationCreateGrant, kms.GrantOperationRetireGrant, kms.GrantOperationDescribeKey,
+}
+
+type AttachKeygrant struct {
+	_                 string `action:"attach" entity:"keygrant" awsAPI:"kms" awsCall:"CreateGrant" awsInput:"kms.CreateGrantInput" awsOutput:"kms.CreateGrantOutput"`
+	logger            *logger.Logger
+	graph             cloud.GraphAPI
+	api               kmsiface.KMSAPI
+	Key               *string   `awsName:"KeyId" awsType:"awsstr" templateName:"key"`
+	Grantee           *string   `awsName:"GranteePrincipal" awsType:"awsstr" templateName:"grantee"`
+	Operations        []*string `awsName:"Operations" awsType:"awsstringslice" templateName:"operations"`
+	Name              *string   `awsName:"Name" awsType:"awsstr" templateName:"name"`
+	RetiringPrincipal *string   `awsName:"RetiringPrincipal" awsType:"awsstr" templateName:"retiring-principal"`
+}
+
+func (cmd *AttachKeygrant) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("grantee"), params.Key("key"), params.Key("operations"), params.Opt("name", "retiring-principal")),
+		params.Validators{
+			"operations": func(i interface{}, others map[string]interface{}) error {
+				for _, op := range castStringSlice(i) {
+					if !contains(keyGrantOperations, op) {
+						return fmt.Errorf("expected operations among %s but got '%s'", keyGrantOperations, op)
+					}
+				}
+				return nil
+			},
+		},
+	)
+}
+
+func (cmd *AttachKeygrant) ExtractResult(i interface{}) string {
+	return StringValue(i.(*kms.CreateGrantOutput).GrantId)
+}
+
+type DetachKeygrant struct {
+	_      string `action:"detach" entity:"keygrant" awsAPI:"kms" awsCall:"RevokeGrant" awsInput:"kms.RevokeGrantInput" awsOutput:"kms.RevokeGrantOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    kmsiface.KMSAPI
+	Id     *string `awsName:"GrantId" awsType:"awsstr" templateName:"id"`
+	Key    *string `awsName:"KeyId" awsType:"awsstr" templateName:"key"`
+}
+
+func (cmd *DetachKeygrant) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("key")))
+}
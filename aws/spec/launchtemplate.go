@@ -0,0 +1,123 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateLaunchtemplate struct {
+	_              string `action:"create" entity:"launchtemplate" awsAPI:"ec2" awsCall:"CreateLaunchTemplate" awsInput:"ec2.CreateLaunchTemplateInput" awsOutput:"ec2.CreateLaunchTemplateOutput" awsDryRun:""`
+	logger         *logger.Logger
+	graph          cloud.GraphAPI
+	api            ec2iface.EC2API
+	Name           *string   `awsName:"LaunchTemplateName" awsType:"awsstr" templateName:"name"`
+	Description    *string   `awsName:"VersionDescription" awsType:"awsstr" templateName:"description"`
+	Image          *string   `awsName:"LaunchTemplateData.ImageId" awsType:"awsstr" templateName:"image"`
+	Type           *string   `awsName:"LaunchTemplateData.InstanceType" awsType:"awsstr" templateName:"type"`
+	Keypair        *string   `awsName:"LaunchTemplateData.KeyName" awsType:"awsstr" templateName:"keypair"`
+	Securitygroups []*string `awsName:"LaunchTemplateData.SecurityGroupIds" awsType:"awsstringslice" templateName:"securitygroups"`
+	Userdata       *string   `awsName:"LaunchTemplateData.UserData" awsType:"awsuserdatatobase64" templateName:"userdata"`
+	Role           *string   `awsName:"LaunchTemplateData.IamInstanceProfile.Name" awsType:"awsstr" templateName:"role"`
+	Lock           *bool     `awsName:"LaunchTemplateData.DisableApiTermination" awsType:"awsbool" templateName:"lock"`
+	DistroQuery    *string   `awsType:"awsstr" templateName:"distro"`
+}
+
+func (cmd *CreateLaunchtemplate) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(params.AllOf(
+		params.OnlyOneOf(params.Key("distro"), params.Key("image")),
+		params.Key("name"), params.Key("type"),
+		params.Opt("description", "keypair", "lock", "role", "securitygroups", "userdata"),
+	), params.Validators{
+		"type": func(i interface{}, others map[string]interface{}) error { return validateInstanceType(cmd.api, i) },
+	})
+	builder.AddReducer(func(values map[string]interface{}) (map[string]interface{}, error) {
+		fn := CommandFactory.Build("createinstance")().(*CreateInstance).convertDistroToAMI
+		return fn(values)
+	}, "distro")
+	return builder.Done()
+}
+
+func (cmd *CreateLaunchtemplate) ExtractResult(i interface{}) string {
+	return StringValue(i.(*ec2.CreateLaunchTemplateOutput).LaunchTemplate.LaunchTemplateId)
+}
+
+// UpdateLaunchtemplate creates a new version of the launch template, launch templates being immutable.
+// Scaling groups launching the '$Default' version use it once it is made the default one with default=true.
+type UpdateLaunchtemplate struct {
+	_              string `action:"update" entity:"launchtemplate" awsAPI:"ec2" awsCall:"CreateLaunchTemplateVersion" awsInput:"ec2.CreateLaunchTemplateVersionInput" awsOutput:"ec2.CreateLaunchTemplateVersionOutput" awsDryRun:""`
+	logger         *logger.Logger
+	graph          cloud.GraphAPI
+	api            ec2iface.EC2API
+	Id             *string   `awsName:"LaunchTemplateId" awsType:"awsstr" templateName:"id"`
+	SourceVersion  *string   `awsName:"SourceVersion" awsType:"awsstr" templateName:"source-version"`
+	Description    *string   `awsName:"VersionDescription" awsType:"awsstr" templateName:"description"`
+	Image          *string   `awsName:"LaunchTemplateData.ImageId" awsType:"awsstr" templateName:"image"`
+	Type           *string   `awsName:"LaunchTemplateData.InstanceType" awsType:"awsstr" templateName:"type"`
+	Keypair        *string   `awsName:"LaunchTemplateData.KeyName" awsType:"awsstr" templateName:"keypair"`
+	Securitygroups []*string `awsName:"LaunchTemplateData.SecurityGroupIds" awsType:"awsstringslice" templateName:"securitygroups"`
+	Userdata       *string   `awsName:"LaunchTemplateData.UserData" awsType:"awsuserdatatobase64" templateName:"userdata"`
+	Role           *string   `awsName:"LaunchTemplateData.IamInstanceProfile.Name" awsType:"awsstr" templateName:"role"`
+	Lock           *bool     `awsName:"LaunchTemplateData.DisableApiTermination" awsType:"awsbool" templateName:"lock"`
+	Default        *bool     `templateName:"default"`
+}
+
+func (cmd *UpdateLaunchtemplate) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"),
+		params.AtLeastOneOf(params.Key("image"), params.Key("type"), params.Key("keypair"), params.Key("securitygroups"), params.Key("userdata"), params.Key("role"), params.Key("lock")),
+		params.Opt("default", "description", "source-version"),
+	), params.Validators{
+		"type": func(i interface{}, others map[string]interface{}) error { return validateInstanceType(cmd.api, i) },
+	})
+}
+
+func (cmd *UpdateLaunchtemplate) ExtractResult(i interface{}) string {
+	return fmt.Sprint(Int64AsIntValue(i.(*ec2.CreateLaunchTemplateVersionOutput).LaunchTemplateVersion.VersionNumber))
+}
+
+func (cmd *UpdateLaunchtemplate) AfterRun(renv env.Running, output interface{}) error {
+	if !BoolValue(cmd.Default) {
+		return nil
+	}
+	version := cmd.ExtractResult(output)
+	start := time.Now()
+	if _, err := cmd.api.ModifyLaunchTemplate(&ec2.ModifyLaunchTemplateInput{LaunchTemplateId: cmd.Id, DefaultVersion: String(version)}); err != nil {
+		return fmt.Errorf("set version %s as default: %s", version, err)
+	}
+	cmd.logger.ExtraVerbosef("ec2.ModifyLaunchTemplate call took %s", time.Since(start))
+	cmd.logger.Verbosef("launchtemplate %s: version %s is now the default version", StringValue(cmd.Id), version)
+	return nil
+}
+
+type DeleteLaunchtemplate struct {
+	_      string `action:"delete" entity:"launchtemplate" awsAPI:"ec2" awsCall:"DeleteLaunchTemplate" awsInput:"ec2.DeleteLaunchTemplateInput" awsOutput:"ec2.DeleteLaunchTemplateOutput" awsDryRun:""`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    ec2iface.EC2API
+	Id     *string `awsName:"LaunchTemplateId" awsType:"awsstr" templateName:"id"`
+}
+
+func (cmd *DeleteLaunchtemplate) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id")))
+}
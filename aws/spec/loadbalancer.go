@@ -54,6 +54,19 @@ func (cmd *CreateLoadbalancer) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*elbv2.CreateLoadBalancerOutput).LoadBalancers[0].LoadBalancerArn)
 }
 
+func (cmd *CreateLoadbalancer) ExtractTypedResult(i interface{}) *env.Result {
+	lb := i.(*elbv2.CreateLoadBalancerOutput).LoadBalancers[0]
+	return &env.Result{
+		ID:  awssdk.StringValue(lb.LoadBalancerArn),
+		ARN: awssdk.StringValue(lb.LoadBalancerArn),
+		Attributes: map[string]string{
+			"dns":        awssdk.StringValue(lb.DNSName),
+			"hostedzone": awssdk.StringValue(lb.CanonicalHostedZoneId),
+			"vpc":        awssdk.StringValue(lb.VpcId),
+		},
+	}
+}
+
 type DeleteLoadbalancer struct {
 	_      string `action:"delete" entity:"loadbalancer" awsAPI:"elbv2" awsCall:"DeleteLoadBalancer" awsInput:"elbv2.DeleteLoadBalancerInput" awsOutput:"elbv2.DeleteLoadBalancerOutput"`
 	logger *logger.Logger
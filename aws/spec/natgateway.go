@@ -25,6 +25,7 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/wallix/awless/logger"
@@ -37,16 +38,29 @@ type CreateNatgateway struct {
 	api         ec2iface.EC2API
 	ElasticipId *string `awsName:"AllocationId" awsType:"awsstr" templateName:"elasticip-id"`
 	Subnet      *string `awsName:"SubnetId" awsType:"awsstr" templateName:"subnet"`
+	Wait        *bool   `templateName:"wait"`
+	WaitTimeout *int64  `templateName:"wait-timeout"`
 }
 
 func (cmd *CreateNatgateway) ParamsSpec() params.Spec {
-	return params.NewSpec(params.AllOf(params.Key("elasticip-id"), params.Key("subnet")))
+	return params.NewSpec(
+		params.AllOf(params.Key("elasticip-id"), params.Key("subnet"), params.Opt("wait", "wait-timeout")),
+		params.Validators{"wait-timeout": validateWaitTimeout},
+	)
 }
 
 func (cmd *CreateNatgateway) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CreateNatGatewayOutput).NatGateway.NatGatewayId)
 }
 
+func (cmd *CreateNatgateway) AfterRun(renv env.Running, output interface{}) error {
+	id := String(cmd.ExtractResult(output))
+	waitUntil(renv.Log(), cmd.Wait, cmd.WaitTimeout, fmt.Sprintf("natgateway %s available", StringValue(id)), func(ctx awssdk.Context, opts ...request.WaiterOption) error {
+		return cmd.api.WaitUntilNatGatewayAvailableWithContext(ctx, &ec2.DescribeNatGatewaysInput{NatGatewayIds: []*string{id}}, opts...)
+	})
+	return nil
+}
+
 type DeleteNatgateway struct {
 	_      string `action:"delete" entity:"natgateway" awsAPI:"ec2" awsCall:"DeleteNatGateway" awsInput:"ec2.DeleteNatGatewayInput" awsOutput:"ec2.DeleteNatGatewayOutput"`
 	logger *logger.Logger
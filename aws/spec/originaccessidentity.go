@@ -0,0 +1,121 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
+	"github.com/aws/aws-sdk-go/service/cloudfront/cloudfrontiface"
+	"github.com/wallix/awless/logger"
+)
+
+type CreateOriginaccessidentity struct {
+	_       string `action:"create" entity:"originaccessidentity" awsAPI:"cloudfront"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     cloudfrontiface.CloudFrontAPI
+	Comment *string `templateName:"comment"`
+}
+
+func (cmd *CreateOriginaccessidentity) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("comment")))
+}
+
+func (cmd *CreateOriginaccessidentity) ManualRun(renv env.Running) (interface{}, error) {
+	input := &cloudfront.CreateCloudFrontOriginAccessIdentityInput{
+		CloudFrontOriginAccessIdentityConfig: &cloudfront.OriginAccessIdentityConfig{
+			CallerReference: aws.String(CallerReferenceFunc()),
+			Comment:         cmd.Comment,
+		},
+	}
+
+	start := time.Now()
+	output, err := cmd.api.CreateCloudFrontOriginAccessIdentity(input)
+	cmd.logger.ExtraVerbosef("cloudfront.CreateCloudFrontOriginAccessIdentity call took %s", time.Since(start))
+	return output, err
+}
+
+func (cmd *CreateOriginaccessidentity) ExtractResult(i interface{}) string {
+	return StringValue(i.(*cloudfront.CreateCloudFrontOriginAccessIdentityOutput).CloudFrontOriginAccessIdentity.Id)
+}
+
+type UpdateOriginaccessidentity struct {
+	_       string `action:"update" entity:"originaccessidentity" awsAPI:"cloudfront"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     cloudfrontiface.CloudFrontAPI
+	Id      *string `templateName:"id"`
+	Comment *string `templateName:"comment"`
+}
+
+func (cmd *UpdateOriginaccessidentity) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("comment")))
+}
+
+func (cmd *UpdateOriginaccessidentity) ManualRun(renv env.Running) (interface{}, error) {
+	identOutput, err := cmd.api.GetCloudFrontOriginAccessIdentityConfig(&cloudfront.GetCloudFrontOriginAccessIdentityConfigInput{
+		Id: cmd.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	config := identOutput.CloudFrontOriginAccessIdentityConfig
+	config.Comment = cmd.Comment
+
+	start := time.Now()
+	output, err := cmd.api.UpdateCloudFrontOriginAccessIdentity(&cloudfront.UpdateCloudFrontOriginAccessIdentityInput{
+		Id:                                   cmd.Id,
+		IfMatch:                              identOutput.ETag,
+		CloudFrontOriginAccessIdentityConfig: config,
+	})
+	cmd.logger.ExtraVerbosef("cloudfront.UpdateCloudFrontOriginAccessIdentity call took %s", time.Since(start))
+	return output, err
+}
+
+type DeleteOriginaccessidentity struct {
+	_      string `action:"delete" entity:"originaccessidentity" awsAPI:"cloudfront"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    cloudfrontiface.CloudFrontAPI
+	Id     *string `templateName:"id"`
+}
+
+func (cmd *DeleteOriginaccessidentity) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id")))
+}
+
+func (cmd *DeleteOriginaccessidentity) ManualRun(renv env.Running) (interface{}, error) {
+	identOutput, err := cmd.api.GetCloudFrontOriginAccessIdentity(&cloudfront.GetCloudFrontOriginAccessIdentityInput{
+		Id: cmd.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	output, err := cmd.api.DeleteCloudFrontOriginAccessIdentity(&cloudfront.DeleteCloudFrontOriginAccessIdentityInput{
+		Id:      cmd.Id,
+		IfMatch: identOutput.ETag,
+	})
+	cmd.logger.ExtraVerbosef("cloudfront.DeleteCloudFrontOriginAccessIdentity call took %s", time.Since(start))
+	return output, err
+}
@@ -0,0 +1,56 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import "reflect"
+
+// iamServicePrefixes are the prefixes of IAM actions differing from the API name of commands
+var iamServicePrefixes = map[string]string{
+	"applicationautoscaling": "application-autoscaling",
+	"elbv2":                  "elasticloadbalancing",
+	"sfn":                    "states",
+}
+
+// unsimulableAPIs are the APIs whose IAM actions are not named after their calls
+// (ex: API Gateway actions are HTTP verbs on resources ARNs, as in apigateway:POST)
+var unsimulableAPIs = map[string]bool{
+	"apigateway": true,
+}
+
+// IAMAction returns the IAM action of the AWS call of a command (ex: ec2:RunInstances).
+// Commands without a single AWS call (manual runs) or whose API actions
+// cannot be derived from their calls have no action.
+func IAMAction(cmd interface{}) (string, bool) {
+	t := reflect.TypeOf(cmd)
+	if t == nil {
+		return "", false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.NumField() == 0 {
+		return "", false
+	}
+	tag := t.Field(0).Tag
+	api, call := tag.Get("awsAPI"), tag.Get("awsCall")
+	if api == "" || call == "" || unsimulableAPIs[api] {
+		return "", false
+	}
+	if prefix, ok := iamServicePrefixes[api]; ok {
+		api = prefix
+	}
+	return api + ":" + call, true
+}
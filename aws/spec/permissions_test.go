@@ -0,0 +1,27 @@
+package awsspec
+
+import "testing"
+
+func TestIAMAction(t *testing.T) {
+	tcases := []struct {
+		cmd       interface{}
+		expAction string
+		expOk     bool
+	}{
+		{cmd: &CreateInstance{}, expAction: "ec2:RunInstances", expOk: true},
+		{cmd: &CreateLoadbalancer{}, expAction: "elasticloadbalancing:CreateLoadBalancer", expOk: true},
+		{cmd: &CreateStatemachine{}, expAction: "states:CreateStateMachine", expOk: true},
+		{cmd: &CreateRestapi{}, expOk: false},
+		{cmd: &CreateElasticip{}, expOk: false},
+		{cmd: nil, expOk: false},
+	}
+	for i, tcase := range tcases {
+		action, ok := IAMAction(tcase.cmd)
+		if got, want := ok, tcase.expOk; got != want {
+			t.Fatalf("%d: got %t, want %t", i+1, got, want)
+		}
+		if got, want := action, tcase.expAction; got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+)
+
+const (
+	defaultPresignedURLExpiry = 15 * time.Minute
+	maxPresignedURLExpiry     = 7 * 24 * time.Hour
+)
+
+// CreatePresignedurl signs locally, without any call to AWS, a URL giving temporary
+// access to an object to anyone holding it: a GET URL to download the object or
+// a PUT URL to upload it. The URL is the result of the command.
+type CreatePresignedurl struct {
+	_       string `action:"create" entity:"presignedurl" awsAPI:"s3"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     s3iface.S3API
+	Bucket  *string `templateName:"bucket"`
+	Key     *string `templateName:"key"`
+	Method  *string `templateName:"method"`
+	Expires *int64  `templateName:"expires"`
+}
+
+func (cmd *CreatePresignedurl) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("bucket"), params.Key("key"), params.Opt("expires", "method")),
+		params.Validators{
+			"method":  params.IsInEnumIgnoreCase("get", "put"),
+			"expires": params.IsIntInRange(1, int(maxPresignedURLExpiry/time.Second)),
+		},
+	)
+}
+
+func (cmd *CreatePresignedurl) ManualRun(renv env.Running) (interface{}, error) {
+	expires := defaultPresignedURLExpiry
+	if cmd.Expires != nil {
+		expires = time.Duration(*cmd.Expires) * time.Second
+	}
+	url, err := PresignS3URL(cmd.api, StringValue(cmd.Method), StringValue(cmd.Bucket), StringValue(cmd.Key), expires)
+	if err != nil {
+		return nil, err
+	}
+	cmd.logger.Verbosef("presigned URL valid until %s", time.Now().Add(expires).Format(time.RFC3339))
+	return url, nil
+}
+
+func (cmd *CreatePresignedurl) ExtractResult(i interface{}) string {
+	return i.(string)
+}
+
+// PresignS3URL returns a URL to GET (default) or PUT the object of the bucket, valid for the given duration
+func PresignS3URL(api s3iface.S3API, method, bucket, key string, expires time.Duration) (string, error) {
+	if expires <= 0 || expires > maxPresignedURLExpiry {
+		return "", fmt.Errorf("presigned URL expiry must be between 1s and %s, got %s", maxPresignedURLExpiry, expires)
+	}
+	var req *request.Request
+	switch strings.ToLower(method) {
+	case "", "get":
+		req, _ = api.GetObjectRequest(&s3.GetObjectInput{Bucket: String(bucket), Key: String(key)})
+	case "put":
+		req, _ = api.PutObjectRequest(&s3.PutObjectInput{Bucket: String(bucket), Key: String(key)})
+	default:
+		return "", fmt.Errorf("invalid method '%s' for presigned URL, expecting get or put", method)
+	}
+	return req.Presign(expires)
+}
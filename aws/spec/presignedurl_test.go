@@ -0,0 +1,62 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestPresignS3URL(t *testing.T) {
+	api := s3.New(session.Must(session.NewSession(&aws.Config{
+		Region:      aws.String("eu-west-1"),
+		Credentials: credentials.NewStaticCredentials("AKID", "SECRET", ""),
+	})))
+
+	signed, err := PresignS3URL(api, "", "my-bucket", "reports/2017.pdf", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := u.Host, "my-bucket.s3.eu-west-1.amazonaws.com"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := u.Path, "/reports/2017.pdf"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := u.Query().Get("X-Amz-Expires"), "3600"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if u.Query().Get("X-Amz-Signature") == "" {
+		t.Fatal("expected signature")
+	}
+
+	if _, err = PresignS3URL(api, "delete", "my-bucket", "reports/2017.pdf", time.Hour); err == nil {
+		t.Fatal("expected error for unsupported method")
+	}
+	if _, err = PresignS3URL(api, "get", "my-bucket", "reports/2017.pdf", 8*24*time.Hour); err == nil {
+		t.Fatal("expected error for expiry over 7 days")
+	}
+}
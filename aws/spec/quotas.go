@@ -0,0 +1,132 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+)
+
+type QuotaExceededError struct {
+	Entity      string
+	Used, Limit int
+	// Wanted is the number of resources to create, when more than one
+	Wanted int
+	Hint   string
+}
+
+func (e *QuotaExceededError) Error() string {
+	msg := fmt.Sprintf("%s quota reached: %d used out of %d allowed", e.Entity, e.Used, e.Limit)
+	if e.Wanted > 1 {
+		msg = fmt.Sprintf("%s quota exceeded: %d to create with %d used out of %d allowed", e.Entity, e.Wanted, e.Used, e.Limit)
+	}
+	if e.Hint != "" {
+		msg += ". " + e.Hint
+	}
+	return msg
+}
+
+// elasticIPsUsage returns the addresses allocated in the domain (vpc or standard) and the maximum allowed
+func elasticIPsUsage(api ec2iface.EC2API, domain string) ([]*ec2.Address, int, error) {
+	attribute := "vpc-max-elastic-ips"
+	if domain == ec2.DomainTypeStandard {
+		attribute = "max-elastic-ips"
+	}
+	limit, err := accountAttributeLimit(api, attribute)
+	if err != nil {
+		return nil, 0, err
+	}
+	out, err := api.DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{{Name: String("domain"), Values: []*string{String(domain)}}},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return out.Addresses, limit, nil
+}
+
+// CheckQuotas returns a QuotaExceededError per entity whose regional quota cannot accommodate
+// all the resources to create, given their number per entity. The only known quota is the one
+// of elastic IPs (vpc domain), the others not being exposed in the EC2 account attributes.
+func CheckQuotas(api ec2iface.EC2API, creates map[string]int) (errs []error) {
+	if wanted := creates[cloud.ElasticIP]; wanted > 0 {
+		allocated, limit, err := elasticIPsUsage(api, ec2.DomainTypeVpc)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("cannot check elasticip quota: %s", err))
+		} else if used := len(allocated); used+wanted > limit {
+			errs = append(errs, &QuotaExceededError{Entity: cloud.ElasticIP, Used: used, Limit: limit, Wanted: wanted, Hint: reuseHint(nil)})
+		}
+	}
+	return
+}
+
+func accountAttributeLimit(api ec2iface.EC2API, attribute string) (int, error) {
+	out, err := api.DescribeAccountAttributes(&ec2.DescribeAccountAttributesInput{AttributeNames: []*string{String(attribute)}})
+	if err != nil {
+		return 0, err
+	}
+	for _, attr := range out.AccountAttributes {
+		if StringValue(attr.AttributeName) != attribute {
+			continue
+		}
+		for _, val := range attr.AttributeValues {
+			return strconv.Atoi(StringValue(val.AttributeValue))
+		}
+	}
+	return 0, fmt.Errorf("account attribute %s not found", attribute)
+}
+
+// unassociatedElasticIPs returns the addresses known in the graph as unassociated
+// and still unassociated among the given allocated addresses
+func unassociatedElasticIPs(g cloud.GraphAPI, allocated []*ec2.Address) ([]*ec2.Address, error) {
+	if g == nil {
+		return nil, nil
+	}
+	resources, err := g.Find(cloud.NewQuery(cloud.ElasticIP))
+	if err != nil {
+		return nil, err
+	}
+	unassociated := make(map[string]bool)
+	for _, r := range resources {
+		if assoc, _ := r.Properties()[properties.Association].(string); assoc == "" {
+			unassociated[r.Id()] = true
+		}
+	}
+	var addresses []*ec2.Address
+	for _, addr := range allocated {
+		if unassociated[StringValue(addr.AllocationId)] && addr.AssociationId == nil {
+			addresses = append(addresses, addr)
+		}
+	}
+	return addresses, nil
+}
+
+func reuseHint(addresses []*ec2.Address) string {
+	if len(addresses) == 0 {
+		return "Release unused elastic IPs or request a limit increase"
+	}
+	var ids []string
+	for _, addr := range addresses {
+		ids = append(ids, StringValue(addr.AllocationId))
+	}
+	return fmt.Sprintf("Unassociated elastic IPs can be reused with `reuse=true`: %s", strings.Join(ids, ", "))
+}
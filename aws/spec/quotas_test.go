@@ -0,0 +1,100 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/logger"
+)
+
+type quotasMock struct {
+	ec2iface.EC2API
+	limit     string
+	addresses []*ec2.Address
+}
+
+func (m *quotasMock) DescribeAccountAttributes(input *ec2.DescribeAccountAttributesInput) (*ec2.DescribeAccountAttributesOutput, error) {
+	return &ec2.DescribeAccountAttributesOutput{AccountAttributes: []*ec2.AccountAttribute{
+		{AttributeName: input.AttributeNames[0], AttributeValues: []*ec2.AccountAttributeValue{{AttributeValue: String(m.limit)}}},
+	}}, nil
+}
+
+func (m *quotasMock) DescribeAddresses(*ec2.DescribeAddressesInput) (*ec2.DescribeAddressesOutput, error) {
+	return &ec2.DescribeAddressesOutput{Addresses: m.addresses}, nil
+}
+
+func TestCreateElasticipQuota(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(resourcetest.ElasticIP("eipalloc-2").Build())
+	api := &quotasMock{limit: "2", addresses: []*ec2.Address{
+		{AllocationId: String("eipalloc-1"), AssociationId: String("eipassoc-1")},
+		{AllocationId: String("eipalloc-2")},
+	}}
+
+	tcases := []struct {
+		reuse     bool
+		graph     *graph.Graph
+		expReused string
+		expErr    string
+	}{
+		{reuse: true, graph: g, expReused: "eipalloc-2"},
+		{reuse: false, graph: g, expErr: "elasticip quota reached: 2 used out of 2 allowed. Unassociated elastic IPs can be reused with `reuse=true`: eipalloc-2"},
+		{reuse: true, graph: graph.NewGraph(), expErr: "elasticip quota reached: 2 used out of 2 allowed. Release unused elastic IPs or request a limit increase"},
+	}
+
+	for i, tcase := range tcases {
+		cmd := &CreateElasticip{api: api, graph: tcase.graph, logger: logger.DiscardLogger, Domain: String("vpc"), Reuse: Bool(tcase.reuse)}
+		reused, err := cmd.checkQuota()
+		if tcase.expErr != "" {
+			if err == nil || err.Error() != tcase.expErr {
+				t.Fatalf("%d: got %v, want %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := StringValue(reused.AllocationId), tcase.expReused; got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+	}
+
+	api.limit = "3"
+	cmd := &CreateElasticip{api: api, graph: g, logger: logger.DiscardLogger, Domain: String("vpc")}
+	if reused, err := cmd.checkQuota(); err != nil || reused != nil {
+		t.Fatalf("got %v, %v, want no reuse nor error", reused, err)
+	}
+}
+
+func TestCheckQuotas(t *testing.T) {
+	api := &quotasMock{limit: "5", addresses: []*ec2.Address{{AllocationId: String("eipalloc-1")}, {AllocationId: String("eipalloc-2")}}}
+
+	if errs := CheckQuotas(api, map[string]int{"elasticip": 3, "instance": 2}); len(errs) != 0 {
+		t.Fatalf("got %v, want no error", errs)
+	}
+	errs := CheckQuotas(api, map[string]int{"elasticip": 4})
+	if got, want := len(errs), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := errs[0].Error(), "elasticip quota exceeded: 4 to create with 2 used out of 5 allowed. Release unused elastic IPs or request a limit increase"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -16,7 +16,9 @@ limitations under the License.
 package awsspec
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/wallix/awless/cloud"
@@ -32,84 +34,112 @@ import (
 )
 
 type CreateRecord struct {
-	_       string `action:"create" entity:"record" awsAPI:"route53"`
-	logger  *logger.Logger
-	graph   cloud.GraphAPI
-	api     route53iface.Route53API
-	Zone    *string   `templateName:"zone"`
-	Name    *string   `templateName:"name"`
-	Type    *string   `templateName:"type"`
-	Values  []*string `templateName:"values"`
-	Ttl     *int64    `templateName:"ttl"`
-	Comment *string   `templateName:"comment"`
+	_             string `action:"create" entity:"record" awsAPI:"route53"`
+	logger        *logger.Logger
+	graph         cloud.GraphAPI
+	api           route53iface.Route53API
+	Zone          *string   `templateName:"zone"`
+	Name          *string   `templateName:"name"`
+	Type          *string   `templateName:"type"`
+	Values        []*string `templateName:"values"`
+	Ttl           *int64    `templateName:"ttl"`
+	Comment       *string   `templateName:"comment"`
+	SetIdentifier *string   `templateName:"set-identifier"`
+	Weight        *int64    `templateName:"weight"`
+	Region        *string   `templateName:"region"`
+	Failover      *string   `templateName:"failover"`
+	Healthcheck   *string   `templateName:"healthcheck"`
 }
 
 func (cmd *CreateRecord) ParamsSpec() params.Spec {
 	builder := params.SpecBuilder(params.AllOf(params.Key("name"), params.Key("ttl"), params.Key("type"), params.OnlyOneOf(params.Key("values"), params.Key("value")), params.Key("zone"),
-		params.Opt("comment"),
-	))
+		params.Opt("comment", "failover", "healthcheck", "region", "set-identifier", "weight"),
+	), recordRoutingValidators)
 	builder.AddReducer(valueToValues, "value")
 	return builder.Done()
 }
 
 func (cmd *CreateRecord) ManualRun(renv env.Running) (interface{}, error) {
 	start := time.Now()
-	output, err := changeResourceRecordSets(cmd.api, String("CREATE"), cmd.Zone, cmd.Name, cmd.Type, cmd.Values, cmd.Comment, cmd.Ttl)
+	output, err := changeResourceRecordSets(cmd.api, String("CREATE"), cmd.Zone, cmd.Name, cmd.Type, cmd.Values, cmd.Comment, cmd.Ttl, cmd.routing())
 	cmd.logger.ExtraVerbosef("route53.ChangeResourceRecordSets call took %s", time.Since(start))
 	return output, err
 }
 
+func (cmd *CreateRecord) routing() *recordRouting {
+	return &recordRouting{SetIdentifier: cmd.SetIdentifier, Weight: cmd.Weight, Region: cmd.Region, Failover: cmd.Failover, Healthcheck: cmd.Healthcheck}
+}
+
 func (cmd *CreateRecord) ExtractResult(i interface{}) string {
 	return StringValue(i.(*route53.ChangeResourceRecordSetsOutput).ChangeInfo.Id)
 }
 
 type UpdateRecord struct {
-	_      string `action:"update" entity:"record" awsAPI:"route53"`
-	logger *logger.Logger
-	graph  cloud.GraphAPI
-	api    route53iface.Route53API
-	Zone   *string   `templateName:"zone"`
-	Name   *string   `templateName:"name"`
-	Type   *string   `templateName:"type"`
-	Values []*string `templateName:"values"`
-	Ttl    *int64    `templateName:"ttl"`
+	_             string `action:"update" entity:"record" awsAPI:"route53"`
+	logger        *logger.Logger
+	graph         cloud.GraphAPI
+	api           route53iface.Route53API
+	Zone          *string   `templateName:"zone"`
+	Name          *string   `templateName:"name"`
+	Type          *string   `templateName:"type"`
+	Values        []*string `templateName:"values"`
+	Ttl           *int64    `templateName:"ttl"`
+	SetIdentifier *string   `templateName:"set-identifier"`
+	Weight        *int64    `templateName:"weight"`
+	Region        *string   `templateName:"region"`
+	Failover      *string   `templateName:"failover"`
+	Healthcheck   *string   `templateName:"healthcheck"`
 }
 
 func (cmd *UpdateRecord) ParamsSpec() params.Spec {
-	builder := params.SpecBuilder(params.AllOf(params.Key("name"), params.Key("ttl"), params.Key("type"), params.OnlyOneOf(params.Key("values"), params.Key("value")), params.Key("zone")))
+	builder := params.SpecBuilder(params.AllOf(params.Key("name"), params.Key("ttl"), params.Key("type"), params.OnlyOneOf(params.Key("values"), params.Key("value")), params.Key("zone"),
+		params.Opt("failover", "healthcheck", "region", "set-identifier", "weight"),
+	), recordRoutingValidators)
 	builder.AddReducer(valueToValues, "value")
 	return builder.Done()
 }
 
 func (cmd *UpdateRecord) ManualRun(renv env.Running) (interface{}, error) {
 	start := time.Now()
-	output, err := changeResourceRecordSets(cmd.api, String("UPSERT"), cmd.Zone, cmd.Name, cmd.Type, cmd.Values, nil, cmd.Ttl)
+	output, err := changeResourceRecordSets(cmd.api, String("UPSERT"), cmd.Zone, cmd.Name, cmd.Type, cmd.Values, nil, cmd.Ttl, cmd.routing())
 	cmd.logger.ExtraVerbosef("route53.ChangeResourceRecordSets call took %s", time.Since(start))
 	return output, err
 }
 
+func (cmd *UpdateRecord) routing() *recordRouting {
+	return &recordRouting{SetIdentifier: cmd.SetIdentifier, Weight: cmd.Weight, Region: cmd.Region, Failover: cmd.Failover, Healthcheck: cmd.Healthcheck}
+}
+
 func (cmd *UpdateRecord) ExtractResult(i interface{}) string {
 	return StringValue(i.(*route53.ChangeResourceRecordSetsOutput).ChangeInfo.Id)
 }
 
 type DeleteRecord struct {
-	_      string `action:"delete" entity:"record" awsAPI:"route53"`
-	logger *logger.Logger
-	graph  cloud.GraphAPI
-	api    route53iface.Route53API
-	Zone   *string   `templateName:"zone"`
-	Name   *string   `templateName:"name"`
-	Type   *string   `templateName:"type"`
-	Values []*string `templateName:"values"`
-	Ttl    *int64    `templateName:"ttl"`
+	_             string `action:"delete" entity:"record" awsAPI:"route53"`
+	logger        *logger.Logger
+	graph         cloud.GraphAPI
+	api           route53iface.Route53API
+	Zone          *string   `templateName:"zone"`
+	Name          *string   `templateName:"name"`
+	Type          *string   `templateName:"type"`
+	Values        []*string `templateName:"values"`
+	Ttl           *int64    `templateName:"ttl"`
+	SetIdentifier *string   `templateName:"set-identifier"`
+	Weight        *int64    `templateName:"weight"`
+	Region        *string   `templateName:"region"`
+	Failover      *string   `templateName:"failover"`
+	Healthcheck   *string   `templateName:"healthcheck"`
 }
 
 func (cmd *DeleteRecord) ParamsSpec() params.Spec {
 	builder := params.SpecBuilder(
 		params.OnlyOneOf(
-			params.AllOf(params.Key("name"), params.Key("ttl"), params.Key("type"), params.OnlyOneOf(params.Key("values"), params.Key("value")), params.Key("zone")),
+			params.AllOf(params.Key("name"), params.Key("ttl"), params.Key("type"), params.OnlyOneOf(params.Key("values"), params.Key("value")), params.Key("zone"),
+				params.Opt("failover", "healthcheck", "region", "set-identifier", "weight"),
+			),
 			params.AllOf(params.Key("id")),
 		),
+		recordRoutingValidators,
 	)
 	builder.AddReducer(valueToValues, "value")
 	builder.AddReducer(
@@ -136,6 +166,18 @@ func (cmd *DeleteRecord) ParamsSpec() params.Spec {
 				if rec, ok := r.Property(properties.Records); ok {
 					values["values"] = rec
 				}
+				routingProperties := map[string]string{
+					"set-identifier": properties.Set,
+					"weight":         properties.Weight,
+					"region":         properties.Region,
+					"failover":       properties.Failover,
+					"healthcheck":    properties.HealthCheck,
+				}
+				for key, prop := range routingProperties {
+					if v, ok := r.Property(prop); ok {
+						values[key] = v
+					}
+				}
 				parents, err := cmd.graph.ResourceRelations(r, rdf.ParentOf, false)
 				if err != nil {
 					return values, fmt.Errorf("cannot get record's zone: %s", err)
@@ -154,16 +196,72 @@ func (cmd *DeleteRecord) ParamsSpec() params.Spec {
 
 func (cmd *DeleteRecord) ManualRun(renv env.Running) (interface{}, error) {
 	start := time.Now()
-	output, err := changeResourceRecordSets(cmd.api, String("DELETE"), cmd.Zone, cmd.Name, cmd.Type, cmd.Values, nil, cmd.Ttl)
+	output, err := changeResourceRecordSets(cmd.api, String("DELETE"), cmd.Zone, cmd.Name, cmd.Type, cmd.Values, nil, cmd.Ttl, cmd.routing())
 	cmd.logger.ExtraVerbosef("route53.ChangeResourceRecordSets call took %s", time.Since(start))
 	return output, err
 }
 
+func (cmd *DeleteRecord) routing() *recordRouting {
+	return &recordRouting{SetIdentifier: cmd.SetIdentifier, Weight: cmd.Weight, Region: cmd.Region, Failover: cmd.Failover, Healthcheck: cmd.Healthcheck}
+}
+
 func (cmd *DeleteRecord) ExtractResult(i interface{}) string {
 	return StringValue(i.(*route53.ChangeResourceRecordSetsOutput).ChangeInfo.Id)
 }
 
-func changeResourceRecordSets(api route53iface.Route53API, action, zone, name, recordType *string, values []*string, comment *string, ttl *int64) (*route53.ChangeResourceRecordSetsOutput, error) {
+// recordRouting holds the params of weighted, latency and failover routing
+// policies, where several record sets share the same name and type
+type recordRouting struct {
+	SetIdentifier, Region, Failover, Healthcheck *string
+	Weight                                       *int64
+}
+
+var recordRoutingValidators = params.Validators{
+	"set-identifier": func(i interface{}, others map[string]interface{}) error {
+		for _, policy := range []string{"weight", "region", "failover"} {
+			if _, ok := others[policy]; ok {
+				return nil
+			}
+		}
+		return errors.New("expected one of 'weight', 'region' or 'failover' routing policy")
+	},
+	"weight": func(i interface{}, others map[string]interface{}) error {
+		if err := checkRecordRoutingPolicy("weight", others); err != nil {
+			return err
+		}
+		weight, err := castInt(i)
+		if err != nil {
+			return err
+		}
+		if weight < 0 || weight > 255 {
+			return fmt.Errorf("expected a weight between 0 and 255 but got %d", weight)
+		}
+		return nil
+	},
+	"region": func(i interface{}, others map[string]interface{}) error {
+		return checkRecordRoutingPolicy("region", others)
+	},
+	"failover": func(i interface{}, others map[string]interface{}) error {
+		if err := checkRecordRoutingPolicy("failover", others); err != nil {
+			return err
+		}
+		return params.IsInEnumIgnoreCase("PRIMARY", "SECONDARY")(i, others)
+	},
+}
+
+func checkRecordRoutingPolicy(policy string, others map[string]interface{}) error {
+	if _, ok := others["set-identifier"]; !ok {
+		return fmt.Errorf("'set-identifier' required with '%s' routing policy", policy)
+	}
+	for _, other := range []string{"weight", "region", "failover"} {
+		if _, ok := others[other]; ok && other != policy {
+			return fmt.Errorf("cannot be combined with '%s' routing policy", other)
+		}
+	}
+	return nil
+}
+
+func changeResourceRecordSets(api route53iface.Route53API, action, zone, name, recordType *string, values []*string, comment *string, ttl *int64, routing *recordRouting) (*route53.ChangeResourceRecordSetsOutput, error) {
 	input := &route53.ChangeResourceRecordSetsInput{}
 	var err error
 	// Required params
@@ -194,6 +292,15 @@ func changeResourceRecordSets(api route53iface.Route53API, action, zone, name, r
 	}
 
 	// Extra params
+	if routing != nil {
+		change.ResourceRecordSet.SetIdentifier = routing.SetIdentifier
+		change.ResourceRecordSet.Weight = routing.Weight
+		change.ResourceRecordSet.Region = routing.Region
+		change.ResourceRecordSet.HealthCheckId = routing.Healthcheck
+		if routing.Failover != nil {
+			change.ResourceRecordSet.Failover = String(strings.ToUpper(StringValue(routing.Failover)))
+		}
+	}
 	if comment != nil {
 		if err = setFieldWithType(comment, input, "ChangeBatch.Comment", awsstr); err != nil {
 			return nil, err
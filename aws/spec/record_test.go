@@ -0,0 +1,59 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/template/params"
+)
+
+func TestRecordRoutingValidators(t *testing.T) {
+	tcases := []struct {
+		params map[string]interface{}
+		expErr string
+	}{
+		{params: map[string]interface{}{"name": "www.example.com"}},
+		{params: map[string]interface{}{"set-identifier": "eu", "weight": 80, "healthcheck": "hc-1"}},
+		{params: map[string]interface{}{"set-identifier": "eu", "region": "eu-west-1"}},
+		{params: map[string]interface{}{"set-identifier": "main", "failover": "primary"}},
+		{params: map[string]interface{}{"set-identifier": "eu"}, expErr: "expected one of 'weight', 'region' or 'failover' routing policy"},
+		{params: map[string]interface{}{"weight": 80}, expErr: "'set-identifier' required with 'weight' routing policy"},
+		{params: map[string]interface{}{"set-identifier": "eu", "weight": 256}, expErr: "expected a weight between 0 and 255 but got 256"},
+		{params: map[string]interface{}{"set-identifier": "eu", "weight": 80, "region": "eu-west-1"}, expErr: "cannot be combined with 'region' routing policy"},
+		{params: map[string]interface{}{"set-identifier": "main", "failover": "tertiary"}, expErr: "expected any of [PRIMARY SECONDARY] but got 'tertiary'"},
+	}
+	for i, tcase := range tcases {
+		for _, cmd := range []interface {
+			ParamsSpec() params.Spec
+		}{new(CreateRecord), new(UpdateRecord), new(DeleteRecord)} {
+			err := params.Validate(cmd.ParamsSpec().Validators(), tcase.params)
+			if tcase.expErr == "" {
+				if err != nil {
+					t.Fatalf("%d: %s", i+1, err)
+				}
+				continue
+			}
+			if err == nil {
+				t.Fatalf("%d: expected error", i+1)
+			}
+			if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+				t.Fatalf("%d: got %q, want %q", i+1, got, want)
+			}
+		}
+	}
+}
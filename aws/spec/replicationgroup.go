@@ -0,0 +1,76 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/aws/aws-sdk-go/service/elasticache/elasticacheiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateReplicationgroup struct {
+	_              string `action:"create" entity:"replicationgroup" awsAPI:"elasticache" awsCall:"CreateReplicationGroup" awsInput:"elasticache.CreateReplicationGroupInput" awsOutput:"elasticache.CreateReplicationGroupOutput"`
+	logger         *logger.Logger
+	graph          cloud.GraphAPI
+	api            elasticacheiface.ElastiCacheAPI
+	Id             *string   `awsName:"ReplicationGroupId" awsType:"awsstr" templateName:"id"`
+	Description    *string   `awsName:"ReplicationGroupDescription" awsType:"awsstr" templateName:"description"`
+	Engine         *string   `awsName:"Engine" awsType:"awsstr" templateName:"engine"`
+	Type           *string   `awsName:"CacheNodeType" awsType:"awsstr" templateName:"type"`
+	Count          *int64    `awsName:"NumCacheClusters" awsType:"awsint64" templateName:"count"`
+	PrimaryCluster *string   `awsName:"PrimaryClusterId" awsType:"awsstr" templateName:"primary-cluster"`
+	Failover       *bool     `awsName:"AutomaticFailoverEnabled" awsType:"awsbool" templateName:"failover"`
+	Version        *string   `awsName:"EngineVersion" awsType:"awsstr" templateName:"version"`
+	Port           *int64    `awsName:"Port" awsType:"awsint64" templateName:"port"`
+	Subnetgroup    *string   `awsName:"CacheSubnetGroupName" awsType:"awsstr" templateName:"subnetgroup"`
+	Securitygroups []*string `awsName:"SecurityGroupIds" awsType:"awsstringslice" templateName:"securitygroups"`
+	Parametergroup *string   `awsName:"CacheParameterGroupName" awsType:"awsstr" templateName:"parametergroup"`
+	Encrypted      *bool     `awsName:"AtRestEncryptionEnabled" awsType:"awsbool" templateName:"encrypted"`
+}
+
+func (cmd *CreateReplicationgroup) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(
+		params.AllOf(params.Key("id"), params.Key("description"),
+			params.OnlyOneOf(params.AllOf(params.Key("engine"), params.Key("type")), params.Key("primary-cluster")),
+			params.Opt("count", "encrypted", "failover", "parametergroup", "port", "securitygroups", "subnetgroup", "version"),
+		),
+		params.Validators{
+			"engine": params.IsInEnumIgnoreCase("redis"),
+			"type":   cacheNodeTypes,
+		})
+	builder.AddReducer(lowerCaseCacheParams, "engine", "type")
+	return builder.Done()
+}
+
+func (cmd *CreateReplicationgroup) ExtractResult(i interface{}) string {
+	return StringValue(i.(*elasticache.CreateReplicationGroupOutput).ReplicationGroup.ReplicationGroupId)
+}
+
+type DeleteReplicationgroup struct {
+	_             string `action:"delete" entity:"replicationgroup" awsAPI:"elasticache" awsCall:"DeleteReplicationGroup" awsInput:"elasticache.DeleteReplicationGroupInput" awsOutput:"elasticache.DeleteReplicationGroupOutput"`
+	logger        *logger.Logger
+	graph         cloud.GraphAPI
+	api           elasticacheiface.ElastiCacheAPI
+	Id            *string `awsName:"ReplicationGroupId" awsType:"awsstr" templateName:"id"`
+	RetainPrimary *bool   `awsName:"RetainPrimaryCluster" awsType:"awsbool" templateName:"retain-primary"`
+	Snapshot      *string `awsName:"FinalSnapshotIdentifier" awsType:"awsstr" templateName:"snapshot"`
+}
+
+func (cmd *DeleteReplicationgroup) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.Opt("retain-primary", "snapshot")))
+}
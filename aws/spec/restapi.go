@@ -0,0 +1,291 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/apigateway/apigatewayiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateRestapi struct {
+	_           string `action:"create" entity:"restapi" awsAPI:"apigateway" awsCall:"CreateRestApi" awsInput:"apigateway.CreateRestApiInput" awsOutput:"apigateway.RestApi"`
+	logger      *logger.Logger
+	graph       cloud.GraphAPI
+	api         apigatewayiface.APIGatewayAPI
+	Name        *string `awsName:"Name" awsType:"awsstr" templateName:"name"`
+	Description *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+	Version     *string `awsName:"Version" awsType:"awsstr" templateName:"version"`
+	Clone       *string `awsName:"CloneFrom" awsType:"awsstr" templateName:"clone"`
+}
+
+func (cmd *CreateRestapi) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("name"), params.Opt("clone", "description", "version")))
+}
+
+func (cmd *CreateRestapi) ExtractResult(i interface{}) string {
+	return StringValue(i.(*apigateway.RestApi).Id)
+}
+
+type DeleteRestapi struct {
+	_      string `action:"delete" entity:"restapi" awsAPI:"apigateway" awsCall:"DeleteRestApi" awsInput:"apigateway.DeleteRestApiInput" awsOutput:"apigateway.DeleteRestApiOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    apigatewayiface.APIGatewayAPI
+	Id     *string `awsName:"RestApiId" awsType:"awsstr" templateName:"id"`
+}
+
+func (cmd *DeleteRestapi) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id")))
+}
+
+type CreateApideployment struct {
+	_                string `action:"create" entity:"apideployment" awsAPI:"apigateway" awsCall:"CreateDeployment" awsInput:"apigateway.CreateDeploymentInput" awsOutput:"apigateway.Deployment"`
+	logger           *logger.Logger
+	graph            cloud.GraphAPI
+	api              apigatewayiface.APIGatewayAPI
+	Restapi          *string `awsName:"RestApiId" awsType:"awsstr" templateName:"restapi"`
+	Stage            *string `awsName:"StageName" awsType:"awsstr" templateName:"stage"`
+	Description      *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+	StageDescription *string `awsName:"StageDescription" awsType:"awsstr" templateName:"stage-description"`
+}
+
+func (cmd *CreateApideployment) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("restapi"), params.Opt("description", "stage", "stage-description")))
+}
+
+func (cmd *CreateApideployment) ExtractResult(i interface{}) string {
+	return StringValue(i.(*apigateway.Deployment).Id)
+}
+
+type DeleteApideployment struct {
+	_       string `action:"delete" entity:"apideployment" awsAPI:"apigateway" awsCall:"DeleteDeployment" awsInput:"apigateway.DeleteDeploymentInput" awsOutput:"apigateway.DeleteDeploymentOutput"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     apigatewayiface.APIGatewayAPI
+	Id      *string `awsName:"DeploymentId" awsType:"awsstr" templateName:"id"`
+	Restapi *string `awsName:"RestApiId" awsType:"awsstr" templateName:"restapi"`
+}
+
+func (cmd *DeleteApideployment) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("restapi")))
+}
+
+type DeleteApistage struct {
+	_       string `action:"delete" entity:"apistage" awsAPI:"apigateway" awsCall:"DeleteStage" awsInput:"apigateway.DeleteStageInput" awsOutput:"apigateway.DeleteStageOutput"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     apigatewayiface.APIGatewayAPI
+	Name    *string `awsName:"StageName" awsType:"awsstr" templateName:"name"`
+	Restapi *string `awsName:"RestApiId" awsType:"awsstr" templateName:"restapi"`
+}
+
+func (cmd *DeleteApistage) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("name"), params.Key("restapi")))
+}
+
+type CreateApiresource struct {
+	_       string `action:"create" entity:"apiresource" awsAPI:"apigateway" awsCall:"CreateResource" awsInput:"apigateway.CreateResourceInput" awsOutput:"apigateway.Resource"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     apigatewayiface.APIGatewayAPI
+	Restapi *string `awsName:"RestApiId" awsType:"awsstr" templateName:"restapi"`
+	Path    *string `awsName:"PathPart" awsType:"awsstr" templateName:"path"`
+	Parent  *string `awsName:"ParentId" awsType:"awsstr" templateName:"parent"`
+}
+
+func (cmd *CreateApiresource) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("path"), params.Key("restapi"), params.Opt("parent")))
+}
+
+// BeforeRun creates the resource under the root resource ('/') of the rest api when no parent is given
+func (cmd *CreateApiresource) BeforeRun(renv env.Running) error {
+	if cmd.Parent != nil {
+		return nil
+	}
+	var root *string
+	input := &apigateway.GetResourcesInput{RestApiId: cmd.Restapi}
+	for root == nil {
+		out, err := cmd.api.GetResources(input)
+		if err != nil {
+			return err
+		}
+		for _, res := range out.Items {
+			if aws.StringValue(res.Path) == "/" {
+				root = res.Id
+			}
+		}
+		if out.Position == nil {
+			break
+		}
+		input.Position = out.Position
+	}
+	if root == nil {
+		return fmt.Errorf("cannot find root resource of rest api '%s'", aws.StringValue(cmd.Restapi))
+	}
+	cmd.Parent = root
+	return nil
+}
+
+func (cmd *CreateApiresource) ExtractResult(i interface{}) string {
+	return StringValue(i.(*apigateway.Resource).Id)
+}
+
+type DeleteApiresource struct {
+	_       string `action:"delete" entity:"apiresource" awsAPI:"apigateway" awsCall:"DeleteResource" awsInput:"apigateway.DeleteResourceInput" awsOutput:"apigateway.DeleteResourceOutput"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     apigatewayiface.APIGatewayAPI
+	Id      *string `awsName:"ResourceId" awsType:"awsstr" templateName:"id"`
+	Restapi *string `awsName:"RestApiId" awsType:"awsstr" templateName:"restapi"`
+}
+
+func (cmd *DeleteApiresource) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("restapi")))
+}
+
+type CreateApimethod struct {
+	_             string `action:"create" entity:"apimethod" awsAPI:"apigateway" awsCall:"PutMethod" awsInput:"apigateway.PutMethodInput" awsOutput:"apigateway.Method"`
+	logger        *logger.Logger
+	graph         cloud.GraphAPI
+	api           apigatewayiface.APIGatewayAPI
+	Restapi       *string `awsName:"RestApiId" awsType:"awsstr" templateName:"restapi"`
+	Resource      *string `awsName:"ResourceId" awsType:"awsstr" templateName:"resource"`
+	Method        *string `awsName:"HttpMethod" awsType:"awsstr" templateName:"method"`
+	Authorization *string `awsName:"AuthorizationType" awsType:"awsstr" templateName:"authorization"`
+	Authorizer    *string `awsName:"AuthorizerId" awsType:"awsstr" templateName:"authorizer"`
+	ApiKey        *bool   `awsName:"ApiKeyRequired" awsType:"awsbool" templateName:"apikey-required"`
+}
+
+func (cmd *CreateApimethod) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(params.AllOf(params.Key("method"), params.Key("resource"), params.Key("restapi"),
+		params.Opt("apikey-required", "authorization", "authorizer"),
+	))
+	builder.AddReducer(upperCaseMethodParam, "method")
+	return builder.Done()
+}
+
+// BeforeRun defaults to a method open to all callers, as in the API Gateway console
+func (cmd *CreateApimethod) BeforeRun(renv env.Running) error {
+	if cmd.Authorization == nil {
+		cmd.Authorization = String("NONE")
+	}
+	return nil
+}
+
+func (cmd *CreateApimethod) ExtractResult(i interface{}) string {
+	return StringValue(i.(*apigateway.Method).HttpMethod)
+}
+
+type DeleteApimethod struct {
+	_        string `action:"delete" entity:"apimethod" awsAPI:"apigateway" awsCall:"DeleteMethod" awsInput:"apigateway.DeleteMethodInput" awsOutput:"apigateway.DeleteMethodOutput"`
+	logger   *logger.Logger
+	graph    cloud.GraphAPI
+	api      apigatewayiface.APIGatewayAPI
+	Restapi  *string `awsName:"RestApiId" awsType:"awsstr" templateName:"restapi"`
+	Resource *string `awsName:"ResourceId" awsType:"awsstr" templateName:"resource"`
+	Method   *string `awsName:"HttpMethod" awsType:"awsstr" templateName:"method"`
+}
+
+func (cmd *DeleteApimethod) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(params.AllOf(params.Key("method"), params.Key("resource"), params.Key("restapi")))
+	builder.AddReducer(upperCaseMethodParam, "method")
+	return builder.Done()
+}
+
+type AttachIntegration struct {
+	_        string `action:"attach" entity:"integration" awsAPI:"apigateway"`
+	logger   *logger.Logger
+	graph    cloud.GraphAPI
+	api      apigatewayiface.APIGatewayAPI
+	Restapi  *string `templateName:"restapi"`
+	Resource *string `templateName:"resource"`
+	Method   *string `templateName:"method"`
+	Function *string `templateName:"function"`
+}
+
+func (cmd *AttachIntegration) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(
+		params.AllOf(params.Key("function"), params.Key("method"), params.Key("resource"), params.Key("restapi")),
+		params.Validators{"function": validateFunctionArn},
+	)
+	builder.AddReducer(upperCaseMethodParam, "method")
+	return builder.Done()
+}
+
+// ManualRun proxies the requests of the API method to the lambda function
+// (i.e. AWS_PROXY integration, lambda functions being always invoked with POST)
+func (cmd *AttachIntegration) ManualRun(renv env.Running) (interface{}, error) {
+	uri, err := lambdaIntegrationUri(aws.StringValue(cmd.Function))
+	if err != nil {
+		return nil, err
+	}
+	return cmd.api.PutIntegration(&apigateway.PutIntegrationInput{
+		RestApiId:             cmd.Restapi,
+		ResourceId:            cmd.Resource,
+		HttpMethod:            cmd.Method,
+		Type:                  aws.String(apigateway.IntegrationTypeAwsProxy),
+		IntegrationHttpMethod: aws.String("POST"),
+		Uri:                   aws.String(uri),
+	})
+}
+
+type DetachIntegration struct {
+	_        string `action:"detach" entity:"integration" awsAPI:"apigateway" awsCall:"DeleteIntegration" awsInput:"apigateway.DeleteIntegrationInput" awsOutput:"apigateway.DeleteIntegrationOutput"`
+	logger   *logger.Logger
+	graph    cloud.GraphAPI
+	api      apigatewayiface.APIGatewayAPI
+	Restapi  *string `awsName:"RestApiId" awsType:"awsstr" templateName:"restapi"`
+	Resource *string `awsName:"ResourceId" awsType:"awsstr" templateName:"resource"`
+	Method   *string `awsName:"HttpMethod" awsType:"awsstr" templateName:"method"`
+}
+
+func (cmd *DetachIntegration) ParamsSpec() params.Spec {
+	builder := params.SpecBuilder(params.AllOf(params.Key("method"), params.Key("resource"), params.Key("restapi")))
+	builder.AddReducer(upperCaseMethodParam, "method")
+	return builder.Done()
+}
+
+// upperCaseMethodParam normalizes HTTP methods, API Gateway only accepting them upper cased
+func upperCaseMethodParam(values map[string]interface{}) (map[string]interface{}, error) {
+	if method, ok := values["method"].(string); ok {
+		values["method"] = strings.ToUpper(method)
+	}
+	return values, nil
+}
+
+func validateFunctionArn(i interface{}, others map[string]interface{}) error {
+	_, err := lambdaIntegrationUri(fmt.Sprint(i))
+	return err
+}
+
+// lambdaIntegrationUri builds the API Gateway URI invoking the given lambda function
+// (see https://docs.aws.amazon.com/apigateway/latest/developerguide/set-up-lambda-proxy-integrations.html)
+func lambdaIntegrationUri(functionArn string) (string, error) {
+	parsed, err := arn.Parse(functionArn)
+	if err != nil || parsed.Service != "lambda" {
+		return "", fmt.Errorf("expected a lambda function arn, got '%s'", functionArn)
+	}
+	return fmt.Sprintf("arn:%s:apigateway:%s:lambda:path/2015-03-31/functions/%s/invocations", parsed.Partition, parsed.Region, functionArn), nil
+}
@@ -112,6 +112,15 @@ func (cmd *CreateRole) ExtractResult(i interface{}) string {
 	return StringValue(i.(*iam.CreateRoleOutput).Role.Arn)
 }
 
+func (cmd *CreateRole) ExtractTypedResult(i interface{}) *env.Result {
+	role := i.(*iam.CreateRoleOutput).Role
+	return &env.Result{
+		ID:         StringValue(role.Arn),
+		ARN:        StringValue(role.Arn),
+		Attributes: map[string]string{"name": StringValue(role.RoleName), "id": StringValue(role.RoleId)},
+	}
+}
+
 type DeleteRole struct {
 	_      string `action:"delete" entity:"role" awsAPI:"iam"`
 	logger *logger.Logger
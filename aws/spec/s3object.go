@@ -19,6 +19,7 @@ import (
 	"mime"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/template/env"
@@ -131,6 +132,46 @@ func (cmd *DeleteS3object) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("bucket"), params.Key("name")))
 }
 
+// SyncS3object uploads a local directory to a bucket (or downloads it from the bucket
+// with direction=download), skipping the files whose content matches the object ETag
+type SyncS3object struct {
+	_           string `action:"sync" entity:"s3object" awsAPI:"s3"`
+	logger      *logger.Logger
+	graph       cloud.GraphAPI
+	api         s3iface.S3API
+	Bucket      *string   `templateName:"bucket"`
+	Dir         *string   `templateName:"dir"`
+	Direction   *string   `templateName:"direction"`
+	Prefix      *string   `templateName:"prefix"`
+	Include     []*string `templateName:"include"`
+	Exclude     []*string `templateName:"exclude"`
+	Concurrency *int64    `templateName:"concurrency"`
+	Acl         *string   `templateName:"acl"`
+}
+
+func (cmd *SyncS3object) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("bucket"), params.Key("dir"), params.Opt("acl", "concurrency", "direction", "exclude", "include", "prefix")),
+		params.Validators{
+			"direction":   params.IsInEnumIgnoreCase(syncUpload, syncDownload),
+			"concurrency": params.IsIntInRange(1, 100),
+			"include":     validateSyncPatterns,
+			"exclude":     validateSyncPatterns,
+		},
+	)
+}
+
+func (cmd *SyncS3object) ManualRun(renv env.Running) (interface{}, error) {
+	if strings.ToLower(StringValue(cmd.Direction)) == syncDownload {
+		return cmd.download()
+	}
+	return cmd.upload()
+}
+
+func (cmd *SyncS3object) ExtractResult(i interface{}) string {
+	return StringValue(cmd.Bucket)
+}
+
 type ProgressReadSeeker struct {
 	file   *os.File
 	reader *ioprogress.Reader
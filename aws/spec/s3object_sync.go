@@ -0,0 +1,415 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// Files and objects are matched by their path relative to the synced directory and to the
+// bucket prefix. Include and exclude patterns (ex: '*.html', 'assets/*') apply to that
+// relative path, or to the base name for patterns without '/'. A file is skipped when
+// its MD5 matches the ETag of the object, or, for the objects whose ETag is not an MD5
+// (multipart uploads, SSE-KMS or SSE-C encryption), when both have the same size and
+// the destination is not older than the source.
+const (
+	syncUpload             = "upload"
+	syncDownload           = "download"
+	defaultSyncConcurrency = 5
+	// files are uploaded in parts above, as a single PutObject fails beyond 5GB
+	syncMultipartThreshold = 100 * 1024 * 1024
+)
+
+type syncEntry struct {
+	key        string // relative path, slash separated
+	size       int64
+	modified   time.Time
+	etag       string // MD5 in hexadecimal (or opaque ETag) without quotes
+	opaqueETag bool   // ETag of object which is not its MD5
+}
+
+type syncReport struct {
+	mu                   sync.Mutex
+	transferred, skipped []string
+}
+
+func (r *syncReport) add(key string, transferred bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if transferred {
+		r.transferred = append(r.transferred, key)
+	} else {
+		r.skipped = append(r.skipped, key)
+	}
+}
+
+func (cmd *SyncS3object) upload() (interface{}, error) {
+	dir := StringValue(cmd.Dir)
+	locals, err := cmd.localEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+	remotes, err := cmd.remoteEntries()
+	if err != nil {
+		return nil, err
+	}
+	report := new(syncReport)
+	err = cmd.parallelize(locals, func(local syncEntry) error {
+		if remote, ok := remotes[local.key]; ok && cmd.unchanged(local, remote, syncUpload) {
+			report.add(local.key, false)
+			return nil
+		}
+		if err := cmd.putObject(filepath.Join(dir, filepath.FromSlash(local.key)), local); err != nil {
+			return fmt.Errorf("upload %s: %s", local.key, err)
+		}
+		report.add(local.key, true)
+		return nil
+	})
+	cmd.logger.Infof("%d file(s) uploaded to bucket %s, %d unchanged", len(report.transferred), StringValue(cmd.Bucket), len(report.skipped))
+	return report, err
+}
+
+func (cmd *SyncS3object) download() (interface{}, error) {
+	dir := StringValue(cmd.Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	remotes, err := cmd.remoteEntries()
+	if err != nil {
+		return nil, err
+	}
+	locals, err := cmd.localEntries(dir)
+	if err != nil {
+		return nil, err
+	}
+	localsByKey := make(map[string]syncEntry)
+	for _, l := range locals {
+		localsByKey[l.key] = l
+	}
+	var all []syncEntry
+	for _, r := range remotes {
+		all = append(all, r)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].key < all[j].key })
+
+	report := new(syncReport)
+	err = cmd.parallelize(all, func(remote syncEntry) error {
+		if local, ok := localsByKey[remote.key]; ok && cmd.unchanged(local, remote, syncDownload) {
+			report.add(remote.key, false)
+			return nil
+		}
+		file, err := syncLocalPath(dir, remote.key)
+		if err != nil {
+			return fmt.Errorf("download %s: %s", remote.key, err)
+		}
+		if err := cmd.getObject(remote, file); err != nil {
+			return fmt.Errorf("download %s: %s", remote.key, err)
+		}
+		report.add(remote.key, true)
+		return nil
+	})
+	cmd.logger.Infof("%d object(s) downloaded from bucket %s, %d unchanged", len(report.transferred), StringValue(cmd.Bucket), len(report.skipped))
+	return report, err
+}
+
+// parallelize runs fn for all the entries with at most 'concurrency' running at once,
+// returning the first error met once all started transfers are done
+func (cmd *SyncS3object) parallelize(entries []syncEntry, fn func(syncEntry) error) error {
+	concurrency := defaultSyncConcurrency
+	if cmd.Concurrency != nil {
+		concurrency = int(*cmd.Concurrency)
+	}
+	jobs := make(chan syncEntry)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range jobs {
+				if err := fn(e); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	for _, e := range entries {
+		mu.Lock()
+		failed := firstErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		jobs <- e
+	}
+	close(jobs)
+	wg.Wait()
+	return firstErr
+}
+
+func (cmd *SyncS3object) localEntries(dir string) (entries []syncEntry, err error) {
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !matchSyncPatterns(key, cmd.Include, cmd.Exclude) {
+			return nil
+		}
+		sum, err := fileMD5(p)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, syncEntry{key: key, size: info.Size(), modified: info.ModTime(), etag: sum})
+		return nil
+	})
+	return
+}
+
+func (cmd *SyncS3object) remoteEntries() (map[string]syncEntry, error) {
+	entries := make(map[string]syncEntry)
+	prefix := cmd.keyPrefix()
+	input := &s3.ListObjectsV2Input{Bucket: cmd.Bucket}
+	if prefix != "" {
+		input.Prefix = String(prefix)
+	}
+	for {
+		start := time.Now()
+		out, err := cmd.api.ListObjectsV2(input)
+		if err != nil {
+			return nil, err
+		}
+		cmd.logger.ExtraVerbosef("s3.ListObjectsV2 call took %s", time.Since(start))
+		for _, obj := range out.Contents {
+			key := strings.TrimPrefix(StringValue(obj.Key), prefix)
+			if key == "" || strings.HasSuffix(key, "/") || !matchSyncPatterns(key, cmd.Include, cmd.Exclude) {
+				continue
+			}
+			entry := syncEntry{key: key, etag: strings.Trim(StringValue(obj.ETag), `"`)}
+			if obj.Size != nil {
+				entry.size = *obj.Size
+			}
+			if obj.LastModified != nil {
+				entry.modified = *obj.LastModified
+			}
+			entry.opaqueETag = strings.Contains(entry.etag, "-")
+			entries[key] = entry
+		}
+		if !BoolValue(out.IsTruncated) {
+			return entries, nil
+		}
+		input.ContinuationToken = out.NextContinuationToken
+	}
+}
+
+func (cmd *SyncS3object) putObject(file string, local syncEntry) error {
+	key := local.key
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	input := &s3.PutObjectInput{Bucket: cmd.Bucket, Key: String(cmd.keyPrefix() + key), Body: f}
+	if mimeType := mime.TypeByExtension(filepath.Ext(file)); mimeType != "" {
+		input.ContentType = String(mimeType)
+	}
+	if cmd.Acl != nil {
+		input.ACL = cmd.Acl
+	}
+	start := time.Now()
+	if local.size > syncMultipartThreshold {
+		_, err = s3manager.NewUploaderWithClient(cmd.api).Upload(&s3manager.UploadInput{
+			Bucket: input.Bucket, Key: input.Key, Body: f, ContentType: input.ContentType, ACL: input.ACL,
+		})
+	} else {
+		_, err = cmd.api.PutObject(input)
+	}
+	if err != nil {
+		return err
+	}
+	cmd.logger.Verbosef("uploaded '%s' in %s", key, time.Since(start))
+	return nil
+}
+
+// getObject writes the object to a temporary file first so that
+// an interrupted download never leaves a truncated file behind
+func (cmd *SyncS3object) getObject(remote syncEntry, file string) error {
+	key := remote.key
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	start := time.Now()
+	out, err := cmd.api.GetObject(&s3.GetObjectInput{Bucket: cmd.Bucket, Key: String(cmd.keyPrefix() + key)})
+	if err != nil {
+		return err
+	}
+	defer out.Body.Close()
+
+	tmp, err := ioutil.TempFile(filepath.Dir(file), ".awless-sync-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = io.Copy(tmp, out.Body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if !remote.modified.IsZero() {
+		if err = os.Chtimes(tmp.Name(), remote.modified, remote.modified); err != nil {
+			return err
+		}
+	}
+	if err = os.Rename(tmp.Name(), file); err != nil {
+		return err
+	}
+	cmd.logger.Verbosef("downloaded '%s' in %s", key, time.Since(start))
+	return nil
+}
+
+// keyPrefix returns the bucket prefix of the synced objects, ending with '/' when given
+func (cmd *SyncS3object) keyPrefix() string {
+	prefix := strings.TrimPrefix(StringValue(cmd.Prefix), "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return prefix
+}
+
+// syncLocalPath returns the path of the file of the key in dir, failing
+// for the keys escaping it (ex: ../../.ssh/authorized_keys)
+func syncLocalPath(dir, key string) (string, error) {
+	file := filepath.Join(dir, filepath.FromSlash(key))
+	rel, err := filepath.Rel(dir, file)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("key '%s' resolves outside of directory %s", key, dir)
+	}
+	return file, nil
+}
+
+// unchanged tells whether the transfer of the entry can be skipped. The ETag of an object
+// of same size but another MD5 is checked to be an MD5 at all, as encrypted objects have opaque ETags
+func (cmd *SyncS3object) unchanged(local, remote syncEntry, direction string) bool {
+	if !remote.opaqueETag && local.size == remote.size && local.etag != remote.etag {
+		remote.opaqueETag = cmd.encryptedWithKey(remote.key)
+	}
+	return sameContent(local, remote, direction)
+}
+
+// encryptedWithKey returns whether the object is encrypted with SSE-KMS or SSE-C
+func (cmd *SyncS3object) encryptedWithKey(key string) bool {
+	out, err := cmd.api.HeadObject(&s3.HeadObjectInput{Bucket: cmd.Bucket, Key: String(cmd.keyPrefix() + key)})
+	if err != nil {
+		cmd.logger.Verbosef("cannot get encryption of '%s': %s", key, err)
+		return false
+	}
+	return StringValue(out.ServerSideEncryption) == s3.ServerSideEncryptionAwsKms || out.SSECustomerAlgorithm != nil
+}
+
+func sameContent(local, remote syncEntry, direction string) bool {
+	if !remote.opaqueETag {
+		return local.etag == remote.etag
+	}
+	if local.size != remote.size {
+		return false
+	}
+	if direction == syncUpload {
+		return !remote.modified.Before(local.modified)
+	}
+	return !local.modified.Before(remote.modified)
+}
+
+func matchSyncPatterns(key string, include, exclude []*string) bool {
+	if len(include) > 0 && !matchAnySyncPattern(key, include) {
+		return false
+	}
+	return !matchAnySyncPattern(key, exclude)
+}
+
+func matchAnySyncPattern(key string, patterns []*string) bool {
+	for _, p := range patterns {
+		pattern := StringValue(p)
+		name := key
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(key)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSyncPatterns(i interface{}, others map[string]interface{}) error {
+	var patterns []string
+	switch v := i.(type) {
+	case []interface{}:
+		for _, p := range v {
+			patterns = append(patterns, fmt.Sprint(p))
+		}
+	default:
+		patterns = append(patterns, fmt.Sprint(v))
+	}
+	for _, p := range patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern '%s': %s", p, err)
+		}
+	}
+	return nil
+}
+
+func fileMD5(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,106 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+func TestMatchSyncPatterns(t *testing.T) {
+	tcases := []struct {
+		key              string
+		include, exclude []string
+		expect           bool
+	}{
+		{key: "index.html", expect: true},
+		{key: "css/site.css", include: []string{"*.css"}, expect: true},
+		{key: "css/site.css", include: []string{"*.html"}, expect: false},
+		{key: "css/site.css", include: []string{"css/*"}, expect: true},
+		{key: "css/vendor/lib.css", include: []string{"css/*"}, expect: false},
+		{key: "notes.tmp", exclude: []string{"*.tmp"}, expect: false},
+		{key: "drafts/post.html", include: []string{"*.html"}, exclude: []string{"drafts/*"}, expect: false},
+		{key: "posts/post.html", include: []string{"*.html"}, exclude: []string{"drafts/*"}, expect: true},
+	}
+	for i, tcase := range tcases {
+		if got, want := matchSyncPatterns(tcase.key, aws.StringSlice(tcase.include), aws.StringSlice(tcase.exclude)), tcase.expect; got != want {
+			t.Fatalf("%d: %s: got %t, want %t", i+1, tcase.key, got, want)
+		}
+	}
+}
+
+func TestSameSyncContent(t *testing.T) {
+	now := time.Now()
+	local := syncEntry{key: "a.txt", size: 5, modified: now, etag: "5d41402abc4b2a76b9719d911017c592"}
+	if !sameContent(local, syncEntry{key: "a.txt", size: 5, etag: "5d41402abc4b2a76b9719d911017c592"}, syncUpload) {
+		t.Fatal("expected same content for same md5")
+	}
+	if sameContent(local, syncEntry{key: "a.txt", size: 5, etag: "7d793037a0760186574b0282f2f435e7"}, syncUpload) {
+		t.Fatal("expected different content for different md5")
+	}
+	multipart := syncEntry{key: "a.txt", size: 5, modified: now.Add(time.Minute), etag: "9b2cf535f27731c974343645a3985328-2", opaqueETag: true}
+	if !sameContent(local, multipart, syncUpload) {
+		t.Fatal("expected same content for opaque ETag object of same size uploaded after the file")
+	}
+	if sameContent(local, multipart, syncDownload) {
+		t.Fatal("expected different content for opaque ETag object modified after the file")
+	}
+	multipart.size = 6
+	if sameContent(local, multipart, syncUpload) {
+		t.Fatal("expected different content for opaque ETag object of different size")
+	}
+	kms := syncEntry{key: "a.txt", size: 5, modified: now.Add(-time.Minute), etag: "7d793037a0760186574b0282f2f435e7", opaqueETag: true}
+	if sameContent(local, kms, syncUpload) {
+		t.Fatal("expected different content for opaque ETag object older than the file")
+	}
+	if !sameContent(local, kms, syncDownload) {
+		t.Fatal("expected same content for file modified after the opaque ETag object")
+	}
+}
+
+func TestSyncLocalPath(t *testing.T) {
+	dir := filepath.Join("home", "user", "site")
+	tcases := []struct {
+		key, expect string
+	}{
+		{key: "index.html", expect: filepath.Join(dir, "index.html")},
+		{key: "css/site.css", expect: filepath.Join(dir, "css", "site.css")},
+		{key: "css/../index.html", expect: filepath.Join(dir, "index.html")},
+		{key: "/etc/passwd", expect: filepath.Join(dir, "etc", "passwd")},
+		{key: "../../.ssh/authorized_keys"},
+		{key: "css/../../site2/index.html"},
+		{key: ".."},
+		{key: "css/.."},
+	}
+	for _, tcase := range tcases {
+		got, err := syncLocalPath(dir, tcase.key)
+		if tcase.expect == "" {
+			if err == nil {
+				t.Fatalf("%s: expected error, got %s", tcase.key, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.key, err)
+		}
+		if got != tcase.expect {
+			t.Fatalf("%s: got %s, want %s", tcase.key, got, tcase.expect)
+		}
+	}
+}
@@ -17,6 +17,7 @@ package awsspec
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/wallix/awless/cloud"
@@ -35,6 +36,8 @@ type CreateScalinggroup struct {
 	api                    autoscalingiface.AutoScalingAPI
 	Name                   *string   `awsName:"AutoScalingGroupName" awsType:"awsstr" templateName:"name"`
 	Launchconfiguration    *string   `awsName:"LaunchConfigurationName" awsType:"awsstr" templateName:"launchconfiguration"`
+	Launchtemplate         *string   `awsName:"LaunchTemplate.LaunchTemplateId" awsType:"awsstr" templateName:"launchtemplate"`
+	LaunchtemplateVersion  *string   `awsName:"LaunchTemplate.Version" awsType:"awsstr" templateName:"launchtemplate-version"`
 	MaxSize                *int64    `awsName:"MaxSize" awsType:"awsint64" templateName:"max-size"`
 	MinSize                *int64    `awsName:"MinSize" awsType:"awsint64" templateName:"min-size"`
 	Subnets                []*string `awsName:"VPCZoneIdentifier" awsType:"awscsvstr" templateName:"subnets"`
@@ -47,9 +50,15 @@ type CreateScalinggroup struct {
 }
 
 func (cmd *CreateScalinggroup) ParamsSpec() params.Spec {
-	return params.NewSpec(params.AllOf(params.Key("launchconfiguration"), params.Key("max-size"), params.Key("min-size"), params.Key("name"), params.Key("subnets"),
-		params.Opt("cooldown", "desired-capacity", "healthcheck-grace-period", "healthcheck-type", "new-instances-protected", "targetgroups"),
-	))
+	return params.NewSpec(params.AllOf(params.OnlyOneOf(params.Key("launchconfiguration"), params.Key("launchtemplate")),
+		params.Key("max-size"), params.Key("min-size"), params.Key("name"), params.Key("subnets"),
+		params.Opt("cooldown", "desired-capacity", "healthcheck-grace-period", "healthcheck-type", "launchtemplate-version", "new-instances-protected", "targetgroups"),
+	), params.Validators{"launchtemplate-version": params.Requires("launchtemplate")})
+}
+
+func (cmd *CreateScalinggroup) BeforeRun(renv env.Running) error {
+	cmd.LaunchtemplateVersion = launchTemplateVersion(cmd.LaunchtemplateVersion)
+	return nil
 }
 
 func (cmd *CreateScalinggroup) ExtractResult(i interface{}) string {
@@ -67,6 +76,8 @@ type UpdateScalinggroup struct {
 	HealthcheckGracePeriod *int64    `awsName:"HealthCheckGracePeriod" awsType:"awsint64" templateName:"healthcheck-grace-period"`
 	HealthcheckType        *string   `awsName:"HealthCheckType" awsType:"awsstr" templateName:"healthcheck-type"`
 	Launchconfiguration    *string   `awsName:"LaunchConfigurationName" awsType:"awsstr" templateName:"launchconfiguration"`
+	Launchtemplate         *string   `awsName:"LaunchTemplate.LaunchTemplateId" awsType:"awsstr" templateName:"launchtemplate"`
+	LaunchtemplateVersion  *string   `awsName:"LaunchTemplate.Version" awsType:"awsstr" templateName:"launchtemplate-version"`
 	MaxSize                *int64    `awsName:"MaxSize" awsType:"awsint64" templateName:"max-size"`
 	MinSize                *int64    `awsName:"MinSize" awsType:"awsint64" templateName:"min-size"`
 	NewInstancesProtected  *bool     `awsName:"NewInstancesProtectedFromScaleIn" awsType:"awsbool" templateName:"new-instances-protected"`
@@ -75,8 +86,26 @@ type UpdateScalinggroup struct {
 
 func (cmd *UpdateScalinggroup) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name"),
-		params.Opt("cooldown", "desired-capacity", "healthcheck-grace-period", "healthcheck-type", "launchconfiguration", "max-size", "min-size", "new-instances-protected", "subnets"),
-	))
+		params.Opt("cooldown", "desired-capacity", "healthcheck-grace-period", "healthcheck-type", "launchconfiguration", "launchtemplate", "launchtemplate-version", "max-size", "min-size", "new-instances-protected", "subnets"),
+	), params.Validators{
+		"launchconfiguration":    params.MutuallyExclusiveWith("launchtemplate"),
+		"launchtemplate-version": params.Requires("launchtemplate"),
+	})
+}
+
+func (cmd *UpdateScalinggroup) BeforeRun(renv env.Running) error {
+	cmd.LaunchtemplateVersion = launchTemplateVersion(cmd.LaunchtemplateVersion)
+	return nil
+}
+
+// launchTemplateVersion accepts 'latest' and 'default' for the $Latest and $Default
+// versions of a launch template, '$' introducing references in templates
+func launchTemplateVersion(version *string) *string {
+	switch v := strings.ToLower(StringValue(version)); v {
+	case "latest", "default":
+		return String("$" + strings.Title(v))
+	}
+	return version
 }
 
 type DeleteScalinggroup struct {
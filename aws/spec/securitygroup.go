@@ -56,23 +56,30 @@ type UpdateSecuritygroup struct {
 	logger        *logger.Logger
 	graph         cloud.GraphAPI
 	api           ec2iface.EC2API
-	Id            *string `templateName:"id"`
-	Protocol      *string `templateName:"protocol"`
-	CIDR          *string `templateName:"cidr"`
-	Securitygroup *string `templateName:"securitygroup"`
-	Inbound       *string `templateName:"inbound"`
-	Outbound      *string `templateName:"outbound"`
-	Portrange     *string `templateName:"portrange"`
+	Id            *string   `templateName:"id"`
+	Protocol      *string   `templateName:"protocol"`
+	CIDR          *string   `templateName:"cidr"`
+	Securitygroup *string   `templateName:"securitygroup"`
+	Inbound       *string   `templateName:"inbound"`
+	Outbound      *string   `templateName:"outbound"`
+	Portrange     *string   `templateName:"portrange"`
+	InboundRules  []*string `templateName:"inbound-rules"`
+	OutboundRules []*string `templateName:"outbound-rules"`
 }
 
 func (cmd *UpdateSecuritygroup) ParamsSpec() params.Spec {
 	return params.NewSpec(
-		params.AllOf(params.Key("id"), params.Key("protocol"), params.OnlyOneOf(params.Key("inbound"), params.Key("outbound")),
-			params.Opt(params.Suggested("cidr", "portrange"), "securitygroup")),
+		params.AllOf(params.Key("id"), params.OnlyOneOf(
+			params.AllOf(params.Key("protocol"), params.OnlyOneOf(params.Key("inbound"), params.Key("outbound")),
+				params.Opt(params.Suggested("cidr", "portrange"), "securitygroup")),
+			params.AtLeastOneOf(params.Key("inbound-rules"), params.Key("outbound-rules")),
+		)),
 		params.Validators{
-			"cidr":     params.IsCIDR,
-			"inbound":  params.IsInEnumIgnoreCase("authorize", "revoke"),
-			"outbound": params.IsInEnumIgnoreCase("authorize", "revoke"),
+			"cidr":           params.IsCIDR,
+			"inbound":        params.IsInEnumIgnoreCase("authorize", "revoke"),
+			"outbound":       params.IsInEnumIgnoreCase("authorize", "revoke"),
+			"inbound-rules":  validateSecurityGroupRules,
+			"outbound-rules": validateSecurityGroupRules,
 			// Fail fast when protocol is TCP/UDP and port range is missing, instead of waiting
 			// for AWS server validation error:
 			//     InvalidParameterValue: Invalid value 'Must specify both from and to ports with TCP/UDP.' for portRange.
@@ -90,6 +97,14 @@ func (cmd *UpdateSecuritygroup) dryRun(renv env.Running, params map[string]inter
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
+	if cmd.isReplacingRules() {
+		_, err := cmd.api.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{DryRun: Bool(true), GroupIds: []*string{cmd.Id}})
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dryRunOperation {
+			cmd.logger.Verbose("dry run: update securitygroup ok")
+			return nil, nil
+		}
+		return nil, fmt.Errorf("dry run: update securitygroup: %s", err)
+	}
 	ipPerms, err := cmd.buildIpPermissions()
 	if err != nil {
 		return nil, err
@@ -137,6 +152,9 @@ func (cmd *UpdateSecuritygroup) dryRun(renv env.Running, params map[string]inter
 }
 
 func (cmd *UpdateSecuritygroup) ManualRun(renv env.Running) (interface{}, error) {
+	if cmd.isReplacingRules() {
+		return nil, cmd.replaceRules()
+	}
 	ipPerms, err := cmd.buildIpPermissions()
 	if err != nil {
 		return nil, err
@@ -329,17 +347,22 @@ func (cmd *UpdateSecuritygroup) buildIpPermissions() ([]*ec2.IpPermission, error
 	} else {
 		return nil, errors.New("missing either 'cidr' or 'securitygroup' parameter")
 	}
+	if err := setIpPermissionPorts(ipPerm, StringValue(cmd.Protocol), cmd.Portrange); err != nil {
+		return nil, err
+	}
+	return []*ec2.IpPermission{ipPerm}, nil
+}
 
-	p := StringValue(cmd.Protocol)
+func setIpPermissionPorts(ipPerm *ec2.IpPermission, p string, pRange *string) error {
 	if strings.Contains("any", p) {
 		ipPerm.FromPort = Int64(-1)
 		ipPerm.ToPort = Int64(-1)
 		ipPerm.IpProtocol = String("-1")
-		return []*ec2.IpPermission{ipPerm}, nil
+		return nil
 	}
 	ipPerm.IpProtocol = String(p)
 
-	if pRange := cmd.Portrange; pRange != nil {
+	if pRange != nil {
 		ports := *pRange
 		switch {
 		case strings.Contains(ports, "any"):
@@ -353,25 +376,24 @@ func (cmd *UpdateSecuritygroup) buildIpPermissions() ([]*ec2.IpPermission, error
 		case strings.Contains(ports, "-"):
 			from, err := strconv.ParseInt(strings.SplitN(ports, "-", 2)[0], 10, 64)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			to, err := strconv.ParseInt(strings.SplitN(ports, "-", 2)[1], 10, 64)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			ipPerm.FromPort = Int64(from)
 			ipPerm.ToPort = Int64(to)
 		default:
 			port, err := strconv.ParseInt(ports, 10, 64)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			ipPerm.FromPort = Int64(port)
 			ipPerm.ToPort = Int64(port)
 		}
 	}
-
-	return []*ec2.IpPermission{ipPerm}, nil
+	return nil
 }
 
 func isTCPorUDP(p string) bool {
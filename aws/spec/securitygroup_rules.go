@@ -0,0 +1,234 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// Rules given to 'update securitygroup' in inbound-rules/outbound-rules replace all the
+// rules of the security group in that direction. A rule is written 'protocol:portrange:source'
+// with source being either a CIDR (IPv4 or IPv6) or a security group ID
+// (ex: tcp:443:0.0.0.0/0, tcp:8000-8080:sg-12345, icmp:any:10.0.0.0/16, any:any:::/0).
+// The single rule 'none' removes all the rules. Rules based on prefix lists are left untouched.
+const noSecurityGroupRules = "none"
+
+// securityGroupRule is a security group permission restricted to a single source
+type securityGroupRule struct {
+	protocol string
+	from, to int64
+	source   string
+}
+
+func (r securityGroupRule) String() string {
+	return fmt.Sprintf("%s:%d-%d:%s", r.protocol, r.from, r.to, r.source)
+}
+
+func (r securityGroupRule) ipPermission() *ec2.IpPermission {
+	ipPerm := &ec2.IpPermission{IpProtocol: String(r.protocol), FromPort: Int64(r.from), ToPort: Int64(r.to)}
+	switch {
+	case strings.HasPrefix(r.source, "sg-"):
+		ipPerm.UserIdGroupPairs = []*ec2.UserIdGroupPair{{GroupId: String(r.source)}}
+	case strings.Contains(r.source, ":"):
+		ipPerm.Ipv6Ranges = []*ec2.Ipv6Range{{CidrIpv6: String(r.source)}}
+	default:
+		ipPerm.IpRanges = []*ec2.IpRange{{CidrIp: String(r.source)}}
+	}
+	return ipPerm
+}
+
+func (cmd *UpdateSecuritygroup) isReplacingRules() bool {
+	return len(cmd.InboundRules) > 0 || len(cmd.OutboundRules) > 0
+}
+
+// replaceRules authorizes the missing rules before revoking the extra ones,
+// so that traffic allowed both before and after the update is never interrupted
+func (cmd *UpdateSecuritygroup) replaceRules() error {
+	out, err := cmd.api.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{GroupIds: []*string{cmd.Id}})
+	if err != nil {
+		return err
+	}
+	if len(out.SecurityGroups) == 0 {
+		return fmt.Errorf("securitygroup '%s' not found", StringValue(cmd.Id))
+	}
+	group := out.SecurityGroups[0]
+
+	if len(cmd.InboundRules) > 0 {
+		authorize, revoke, err := diffSecurityGroupRules(group.IpPermissions, cmd.InboundRules)
+		if err != nil {
+			return fmt.Errorf("inbound-rules: %s", err)
+		}
+		if len(authorize) > 0 {
+			start := time.Now()
+			if _, err = cmd.api.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{GroupId: cmd.Id, IpPermissions: authorize}); err != nil {
+				return err
+			}
+			cmd.logger.ExtraVerbosef("ec2.AuthorizeSecurityGroupIngress call took %s", time.Since(start))
+		}
+		if len(revoke) > 0 {
+			start := time.Now()
+			if _, err = cmd.api.RevokeSecurityGroupIngress(&ec2.RevokeSecurityGroupIngressInput{GroupId: cmd.Id, IpPermissions: revoke}); err != nil {
+				return err
+			}
+			cmd.logger.ExtraVerbosef("ec2.RevokeSecurityGroupIngress call took %s", time.Since(start))
+		}
+		cmd.logger.Verbosef("securitygroup %s: %d inbound rule(s) authorized, %d revoked", StringValue(cmd.Id), len(authorize), len(revoke))
+	}
+
+	if len(cmd.OutboundRules) > 0 {
+		authorize, revoke, err := diffSecurityGroupRules(group.IpPermissionsEgress, cmd.OutboundRules)
+		if err != nil {
+			return fmt.Errorf("outbound-rules: %s", err)
+		}
+		if len(authorize) > 0 {
+			start := time.Now()
+			if _, err = cmd.api.AuthorizeSecurityGroupEgress(&ec2.AuthorizeSecurityGroupEgressInput{GroupId: cmd.Id, IpPermissions: authorize}); err != nil {
+				return err
+			}
+			cmd.logger.ExtraVerbosef("ec2.AuthorizeSecurityGroupEgress call took %s", time.Since(start))
+		}
+		if len(revoke) > 0 {
+			start := time.Now()
+			if _, err = cmd.api.RevokeSecurityGroupEgress(&ec2.RevokeSecurityGroupEgressInput{GroupId: cmd.Id, IpPermissions: revoke}); err != nil {
+				return err
+			}
+			cmd.logger.ExtraVerbosef("ec2.RevokeSecurityGroupEgress call took %s", time.Since(start))
+		}
+		cmd.logger.Verbosef("securitygroup %s: %d outbound rule(s) authorized, %d revoked", StringValue(cmd.Id), len(authorize), len(revoke))
+	}
+	return nil
+}
+
+// diffSecurityGroupRules returns the permissions to authorize and to revoke
+// for the current permissions to match the desired rules
+func diffSecurityGroupRules(current []*ec2.IpPermission, desired []*string) (authorize, revoke []*ec2.IpPermission, err error) {
+	wanted, err := parseSecurityGroupRules(desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	currentRules := flattenIpPermissions(current)
+	existing := make(map[securityGroupRule]bool)
+	for _, r := range currentRules {
+		existing[r] = true
+	}
+	wantedSet := make(map[securityGroupRule]bool)
+	for _, r := range wanted {
+		wantedSet[r] = true
+		if !existing[r] {
+			authorize = append(authorize, r.ipPermission())
+		}
+	}
+	for _, r := range currentRules {
+		if !wantedSet[r] {
+			revoke = append(revoke, r.ipPermission())
+		}
+	}
+	return
+}
+
+// flattenIpPermissions splits permissions into one rule per source, sorted and without duplicates
+func flattenIpPermissions(perms []*ec2.IpPermission) (rules []securityGroupRule) {
+	unique := make(map[securityGroupRule]bool)
+	for _, perm := range perms {
+		r := securityGroupRule{protocol: strings.ToLower(StringValue(perm.IpProtocol)), from: -1, to: -1}
+		if perm.FromPort != nil {
+			r.from = *perm.FromPort
+		}
+		if perm.ToPort != nil {
+			r.to = *perm.ToPort
+		}
+		var sources []string
+		for _, ipRange := range perm.IpRanges {
+			sources = append(sources, StringValue(ipRange.CidrIp))
+		}
+		for _, ipRange := range perm.Ipv6Ranges {
+			sources = append(sources, StringValue(ipRange.CidrIpv6))
+		}
+		for _, pair := range perm.UserIdGroupPairs {
+			sources = append(sources, StringValue(pair.GroupId))
+		}
+		for _, source := range sources {
+			r.source = source
+			if !unique[r] {
+				unique[r] = true
+				rules = append(rules, r)
+			}
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].String() < rules[j].String() })
+	return
+}
+
+func parseSecurityGroupRules(rules []*string) ([]securityGroupRule, error) {
+	if len(rules) == 1 && strings.ToLower(StringValue(rules[0])) == noSecurityGroupRules {
+		return nil, nil
+	}
+	var perms []*ec2.IpPermission
+	for _, rule := range rules {
+		splits := strings.SplitN(StringValue(rule), ":", 3)
+		if len(splits) != 3 {
+			return nil, fmt.Errorf("invalid rule '%s', expected 'protocol:portrange:source'", StringValue(rule))
+		}
+		protocol, portrange, source := strings.ToLower(splits[0]), strings.ToLower(splits[1]), splits[2]
+		if protocol == "" || portrange == "" {
+			return nil, fmt.Errorf("invalid rule '%s', expected 'protocol:portrange:source'", StringValue(rule))
+		}
+		ipPerm := &ec2.IpPermission{}
+		if err := setIpPermissionPorts(ipPerm, protocol, String(portrange)); err != nil {
+			return nil, fmt.Errorf("invalid portrange in rule '%s': %s", StringValue(rule), err)
+		}
+		switch {
+		case strings.HasPrefix(source, "sg-"):
+			ipPerm.UserIdGroupPairs = []*ec2.UserIdGroupPair{{GroupId: String(source)}}
+		case strings.Contains(source, ":"):
+			if _, _, err := net.ParseCIDR(source); err != nil {
+				return nil, fmt.Errorf("invalid source in rule '%s': %s", StringValue(rule), err)
+			}
+			ipPerm.Ipv6Ranges = []*ec2.Ipv6Range{{CidrIpv6: String(source)}}
+		default:
+			if _, _, err := net.ParseCIDR(source); err != nil {
+				return nil, fmt.Errorf("invalid source in rule '%s': %s", StringValue(rule), err)
+			}
+			ipPerm.IpRanges = []*ec2.IpRange{{CidrIp: String(source)}}
+		}
+		perms = append(perms, ipPerm)
+	}
+	return flattenIpPermissions(perms), nil
+}
+
+func validateSecurityGroupRules(i interface{}, others map[string]interface{}) error {
+	var rules []*string
+	switch v := i.(type) {
+	case []interface{}:
+		for _, rule := range v {
+			rules = append(rules, String(fmt.Sprint(rule)))
+		}
+	default:
+		rules = append(rules, String(fmt.Sprint(v)))
+	}
+	if len(rules) == 0 {
+		return errors.New("no rules, use 'none' to remove all the rules")
+	}
+	_, err := parseSecurityGroupRules(rules)
+	return err
+}
@@ -132,3 +132,42 @@ func TestBuildIpPermissionsFromParams(t *testing.T) {
 		}
 	}
 }
+
+func TestDiffSecurityGroupRules(t *testing.T) {
+	current := []*ec2.IpPermission{
+		{IpProtocol: aws.String("-1"), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/8")}}},
+		{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(0), ToPort: aws.Int64(65535), UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-12345"), UserId: aws.String("123456789012")}}},
+		{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(443), ToPort: aws.Int64(443), Ipv6Ranges: []*ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}}},
+	}
+	authorize, revoke, err := diffSecurityGroupRules(current, []*string{aws.String("any:any:10.0.0.0/8"), aws.String("TCP:any:sg-12345"), aws.String("udp:53:::/0")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := authorize, []*ec2.IpPermission{
+		{IpProtocol: aws.String("udp"), FromPort: aws.Int64(53), ToPort: aws.Int64(53), Ipv6Ranges: []*ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}}},
+	}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if got, want := revoke, []*ec2.IpPermission{
+		{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(443), ToPort: aws.Int64(443), Ipv6Ranges: []*ec2.Ipv6Range{{CidrIpv6: aws.String("::/0")}}},
+	}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	authorize, revoke, err = diffSecurityGroupRules(current, []*string{aws.String("none")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(authorize), 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := len(revoke), 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	for _, invalid := range []string{"tcp:443", "tcp:http:0.0.0.0/0", "tcp:443:10.0.0.1", ":443:0.0.0.0/0"} {
+		if _, _, err := diffSecurityGroupRules(current, []*string{aws.String(invalid)}); err == nil {
+			t.Fatalf("expected error for rule '%s'", invalid)
+		}
+	}
+}
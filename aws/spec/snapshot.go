@@ -16,11 +16,17 @@ limitations under the License.
 package awsspec
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
 	"github.com/wallix/awless/template/params"
 )
 
@@ -31,18 +37,25 @@ type CreateSnapshot struct {
 	api         ec2iface.EC2API
 	Volume      *string `awsName:"VolumeId" awsType:"awsstr" templateName:"volume"`
 	Description *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+	Wait        *bool   `templateName:"wait"`
+	WaitTimeout *int64  `templateName:"wait-timeout"`
 }
 
 func (cmd *CreateSnapshot) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("volume"),
-		params.Opt("description"),
-	))
+		params.Opt("description", "wait", "wait-timeout"),
+	), params.Validators{"wait-timeout": validateWaitTimeout})
 }
 
 func (cmd *CreateSnapshot) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.Snapshot).SnapshotId)
 }
 
+func (cmd *CreateSnapshot) AfterRun(renv env.Running, output interface{}) error {
+	waitSnapshotCompleted(cmd.api, renv.Log(), cmd.Wait, cmd.WaitTimeout, cmd.ExtractResult(output))
+	return nil
+}
+
 type DeleteSnapshot struct {
 	_      string `action:"delete" entity:"snapshot" awsAPI:"ec2" awsCall:"DeleteSnapshot" awsInput:"ec2.DeleteSnapshotInput" awsOutput:"ec2.DeleteSnapshotOutput" awsDryRun:""`
 	logger *logger.Logger
@@ -64,14 +77,76 @@ type CopySnapshot struct {
 	SourceRegion *string `awsName:"SourceRegion" awsType:"awsstr" templateName:"source-region"`
 	Encrypted    *bool   `awsName:"Encrypted" awsType:"awsbool" templateName:"encrypted"`
 	Description  *string `awsName:"Description" awsType:"awsstr" templateName:"description"`
+	Wait         *bool   `templateName:"wait"`
+	WaitTimeout  *int64  `templateName:"wait-timeout"`
 }
 
 func (cmd *CopySnapshot) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("source-id"), params.Key("source-region"),
-		params.Opt("description", "encrypted"),
-	))
+		params.Opt("description", "encrypted", "wait", "wait-timeout"),
+	), params.Validators{"wait-timeout": validateWaitTimeout})
 }
 
 func (cmd *CopySnapshot) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*ec2.CopySnapshotOutput).SnapshotId)
 }
+
+func (cmd *CopySnapshot) AfterRun(renv env.Running, output interface{}) error {
+	waitSnapshotCompleted(cmd.api, renv.Log(), cmd.Wait, cmd.WaitTimeout, cmd.ExtractResult(output))
+	return nil
+}
+
+// UpdateSnapshot shares a snapshot with other AWS accounts (or makes it public with groups=all)
+// by adding or removing create volume permissions
+type UpdateSnapshot struct {
+	_         string `action:"update" entity:"snapshot" awsAPI:"ec2" awsDryRun:"manual"`
+	logger    *logger.Logger
+	graph     cloud.GraphAPI
+	api       ec2iface.EC2API
+	Id        *string   `awsName:"SnapshotId" awsType:"awsstr" templateName:"id"`
+	Accounts  []*string `awsName:"UserIds" awsType:"awsstringslice" templateName:"accounts"`
+	Groups    []*string `awsName:"GroupNames" awsType:"awsstringslice" templateName:"groups"`
+	Operation *string   `awsName:"OperationType" awsType:"awsstr" templateName:"operation"`
+}
+
+func (cmd *UpdateSnapshot) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("id"), params.Key("operation"), params.AtLeastOneOf(params.Key("accounts"), params.Key("groups"))),
+		params.Validators{"operation": params.IsInEnumIgnoreCase("add", "remove")},
+	)
+}
+
+func (cmd *UpdateSnapshot) ManualRun(renv env.Running) (interface{}, error) {
+	input := &ec2.ModifySnapshotAttributeInput{Attribute: String(ec2.SnapshotAttributeNameCreateVolumePermission)}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.ModifySnapshotAttributeInput: %s", err)
+	}
+	input.OperationType = String(strings.ToLower(StringValue(input.OperationType)))
+	start := time.Now()
+	output, err := cmd.api.ModifySnapshotAttribute(input)
+	cmd.logger.ExtraVerbosef("ec2.ModifySnapshotAttribute call took %s", time.Since(start))
+	return output, err
+}
+
+// waitSnapshotCompleted waits for the snapshot when wait is true,
+// reporting its progress each time the SDK waiter polls it
+func waitSnapshotCompleted(api ec2iface.EC2API, l *logger.Logger, wait *bool, timeout *int64, id string) {
+	waitUntil(l, wait, timeout, fmt.Sprintf("snapshot %s completed", id), func(ctx awssdk.Context, opts ...request.WaiterOption) error {
+		opts = append(opts, request.WithWaiterRequestOptions(logSnapshotsProgress(l)))
+		return api.WaitUntilSnapshotCompletedWithContext(ctx, &ec2.DescribeSnapshotsInput{SnapshotIds: []*string{String(id)}}, opts...)
+	})
+}
+
+func logSnapshotsProgress(l *logger.Logger) request.Option {
+	return func(r *request.Request) {
+		r.Handlers.Complete.PushBack(func(r *request.Request) {
+			out, ok := r.Data.(*ec2.DescribeSnapshotsOutput)
+			if r.Error != nil || !ok {
+				return
+			}
+			for _, snap := range out.Snapshots {
+				l.InteractiveInfof("snapshot %s %s: %s", StringValue(snap.SnapshotId), StringValue(snap.State), StringValue(snap.Progress))
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -26,6 +27,12 @@ type BeforeRunner interface {
 	BeforeRun(env.Running) error
 }
 
+// BeforeDryRunner prepares the command before its dry run, so that the dry run
+// validates the same AWS request as the run
+type BeforeDryRunner interface {
+	BeforeDryRun(env.Running) error
+}
+
 type AfterRunner interface {
 	AfterRun(env.Running, interface{}) error
 }
@@ -34,6 +41,12 @@ type ResultExtractor interface {
 	ExtractResult(interface{}) string
 }
 
+// TypedResultExtractor is implemented by commands giving more than an identifier as result,
+// their ARN and attributes being referenced in templates with $var.arn or $var.attribute
+type TypedResultExtractor interface {
+	ExtractTypedResult(interface{}) *env.Result
+}
+
 type command interface {
 	ParamsSpec() params.Spec
 	inject(map[string]interface{}) error
@@ -45,6 +58,11 @@ func implementsBeforeRun(i interface{}) (BeforeRunner, bool) {
 	return v, ok
 }
 
+func implementsBeforeDryRun(i interface{}) (BeforeDryRunner, bool) {
+	v, ok := i.(BeforeDryRunner)
+	return v, ok
+}
+
 func implementsAfterRun(i interface{}) (AfterRunner, bool) {
 	v, ok := i.(AfterRunner)
 	return v, ok
@@ -55,6 +73,17 @@ func implementsResultExtractor(i interface{}) (ResultExtractor, bool) {
 	return v, ok
 }
 
+// extractResult returns the typed result of the commands implementing TypedResultExtractor
+// and the identifier given by ExtractResult otherwise
+func extractResult(v ResultExtractor, output interface{}) interface{} {
+	if typed, ok := v.(TypedResultExtractor); ok {
+		if res := typed.ExtractTypedResult(output); res != nil {
+			return res
+		}
+	}
+	return v.ExtractResult(output)
+}
+
 func fakeDryRunId(entity string) string {
 	suffix := rand.Intn(1e6)
 	switch entity {
@@ -157,13 +186,55 @@ func (c *checker) check() error {
 
 type enumValidator struct {
 	expected []string
+	fetch    func() ([]string, error)
+	once     sync.Once
 }
 
 func NewEnumValidator(expected ...string) *enumValidator {
 	return &enumValidator{expected: expected}
 }
 
+// NewDynamicEnumValidator returns a validator whose expected values are fetched (ex: from AWS)
+// on first validation. When fetching fails, it falls back on the given values or accepts any value.
+func NewDynamicEnumValidator(fetch func() ([]string, error), fallback ...string) *enumValidator {
+	return &enumValidator{expected: fallback, fetch: fetch}
+}
+
+type dynamicEnumKey struct {
+	api  interface{}
+	name string
+}
+
+var dynamicEnums = struct {
+	sync.Mutex
+	validators map[dynamicEnumKey]*enumValidator
+}{validators: make(map[dynamicEnumKey]*enumValidator)}
+
+// cachedDynamicEnumValidator returns the dynamic enum validator cached for the given name and API client,
+// so that values are fetched once per client (i.e. per profile and region) and not for every command
+func cachedDynamicEnumValidator(api interface{}, name string, fetch func() ([]string, error)) *enumValidator {
+	dynamicEnums.Lock()
+	defer dynamicEnums.Unlock()
+	key := dynamicEnumKey{api: api, name: name}
+	if v, ok := dynamicEnums.validators[key]; ok {
+		return v
+	}
+	v := NewDynamicEnumValidator(fetch)
+	dynamicEnums.validators[key] = v
+	return v
+}
+
 func (v *enumValidator) Validate(in *string) error {
+	if v.fetch != nil {
+		v.once.Do(func() {
+			if fetched, err := v.fetch(); err == nil && len(fetched) > 0 {
+				v.expected = fetched
+			}
+		})
+		if len(v.expected) == 0 {
+			return nil
+		}
+	}
 	val := strings.ToLower(StringValue(in))
 	for _, e := range v.expected {
 		if val == strings.ToLower(e) {
@@ -1,8 +1,14 @@
 package awsspec
 
 import (
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/wallix/awless/template/env"
 )
 
 func checkErrs(t *testing.T, errs []error, length int, expected ...string) {
@@ -54,3 +60,106 @@ func TestEnumValidator(t *testing.T) {
 		}
 	}
 }
+
+func TestDynamicEnumValidator(t *testing.T) {
+	var fetchCount int
+	validator := NewDynamicEnumValidator(func() ([]string, error) {
+		fetchCount++
+		return []string{"5.6.39", "5.7.21"}, nil
+	}, "5.6.39")
+	if err := validator.Validate(String("5.7.21")); err != nil {
+		t.Fatal(err)
+	}
+	if err := validator.Validate(String("5.5.61")); err == nil || !strings.Contains(err.Error(), "'5.6.39' or '5.7.21'") {
+		t.Fatalf("got %v, want error", err)
+	}
+	if got, want := fetchCount, 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	failing := NewDynamicEnumValidator(func() ([]string, error) { return nil, errors.New("access denied") }, "5.6.39")
+	if err := failing.Validate(String("5.7.21")); err == nil {
+		t.Fatal("expected error with fallback values")
+	}
+	failingNoFallback := NewDynamicEnumValidator(func() ([]string, error) { return nil, errors.New("access denied") })
+	if err := failingNoFallback.Validate(String("any")); err != nil {
+		t.Fatal(err)
+	}
+
+	api1, api2 := new(struct{ name string }), new(struct{ name string })
+	fetch := func() ([]string, error) { fetchCount++; return []string{"a"}, nil }
+	if got, want := cachedDynamicEnumValidator(api1, "versions", fetch), cachedDynamicEnumValidator(api1, "versions", fetch); got != want {
+		t.Fatal("expected cached validator for same api and name")
+	}
+	if got, want := cachedDynamicEnumValidator(api2, "versions", fetch), cachedDynamicEnumValidator(api1, "versions", fetch); got == want {
+		t.Fatal("expected distinct validators for distinct apis")
+	}
+}
+
+func TestExtractResult(t *testing.T) {
+	reservation := &ec2.Reservation{Instances: []*ec2.Instance{{
+		InstanceId:       String("i-12345"),
+		PrivateIpAddress: String("10.0.0.5"),
+		SubnetId:         String("subnet-12345"),
+		VpcId:            String("vpc-12345"),
+		Placement:        &ec2.Placement{AvailabilityZone: String("eu-west-1a")},
+	}}}
+	res, ok := extractResult(new(CreateInstance), reservation).(*env.Result)
+	if !ok {
+		t.Fatal("expected typed result")
+	}
+	if got, want := res.ID, "i-12345"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	exp := map[string]string{"privateip": "10.0.0.5", "subnet": "subnet-12345", "vpc": "vpc-12345", "availabilityzone": "eu-west-1a"}
+	if got, want := res.Attrs(), exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if got, want := extractResult(new(CreateVpc), &ec2.CreateVpcOutput{Vpc: &ec2.Vpc{VpcId: String("vpc-12345")}}), "vpc-12345"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+type offeringsMock struct {
+	ec2iface.EC2API
+	pages [][]string
+	err   error
+	calls int
+}
+
+func (m *offeringsMock) DescribeReservedInstancesOfferings(input *ec2.DescribeReservedInstancesOfferingsInput) (*ec2.DescribeReservedInstancesOfferingsOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	page := m.calls
+	m.calls++
+	out := &ec2.DescribeReservedInstancesOfferingsOutput{}
+	for _, typ := range m.pages[page] {
+		out.ReservedInstancesOfferings = append(out.ReservedInstancesOfferings, &ec2.ReservedInstancesOffering{InstanceType: String(typ)})
+	}
+	if page < len(m.pages)-1 {
+		out.NextToken = String("next")
+	}
+	return out, nil
+}
+
+func TestValidateInstanceType(t *testing.T) {
+	api := &offeringsMock{pages: [][]string{{"t2.micro", "t2.nano", "t2.micro"}, {"m5.large"}}}
+	if err := validateInstanceType(api, "m5.large"); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateInstanceType(api, "t2.mini"); err == nil || !strings.Contains(err.Error(), "'t2.micro', 't2.nano' or 'm5.large'") {
+		t.Fatalf("got %v, want error", err)
+	}
+	if got, want := api.calls, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	if err := validateInstanceType(&offeringsMock{err: errors.New("access denied")}, "t2.mini"); err != nil {
+		t.Fatal(err)
+	}
+	if err := validateInstanceType(nil, "t2.mini"); err != nil {
+		t.Fatal(err)
+	}
+}
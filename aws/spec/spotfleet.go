@@ -0,0 +1,121 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateSpotfleet struct {
+	_              string `action:"create" entity:"spotfleet" awsAPI:"ec2"`
+	logger         *logger.Logger
+	graph          cloud.GraphAPI
+	api            ec2iface.EC2API
+	Role           *string   `awsName:"SpotFleetRequestConfig.IamFleetRole" awsType:"awsstr" templateName:"role"`
+	Capacity       *int64    `awsName:"SpotFleetRequestConfig.TargetCapacity" awsType:"awsint64" templateName:"capacity"`
+	Price          *string   `awsName:"SpotFleetRequestConfig.SpotPrice" awsType:"awsstr" templateName:"price"`
+	Interruption   *string   `awsName:"SpotFleetRequestConfig.InstanceInterruptionBehavior" awsType:"awsstr" templateName:"interruption"`
+	Allocation     *string   `awsName:"SpotFleetRequestConfig.AllocationStrategy" awsType:"awsstr" templateName:"allocation"`
+	Image          *string   `templateName:"image"`
+	Type           *string   `templateName:"type"`
+	Subnet         *string   `templateName:"subnet"`
+	Keypair        *string   `templateName:"keypair"`
+	Securitygroups []*string `templateName:"securitygroups"`
+}
+
+func (cmd *CreateSpotfleet) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("role"), params.Key("capacity"), params.Key("image"), params.Key("type"),
+			params.Opt("allocation", "interruption", "keypair", "price", "securitygroups", "subnet"),
+		),
+		params.Validators{
+			"role":         params.IsARN,
+			"capacity":     params.IsIntInRange(1, 10000),
+			"interruption": params.IsInEnumIgnoreCase(ec2.InstanceInterruptionBehaviorTerminate, ec2.InstanceInterruptionBehaviorStop, ec2.InstanceInterruptionBehaviorHibernate),
+			"allocation":   params.IsInEnumIgnoreCase(ec2.AllocationStrategyLowestPrice, ec2.AllocationStrategyDiversified),
+			"type":         func(i interface{}, others map[string]interface{}) error { return validateInstanceType(cmd.api, i) },
+		})
+}
+
+// ManualRun requests a fleet launching its instances from a single launch specification,
+// the config of the fleet being injected from the template params
+func (cmd *CreateSpotfleet) ManualRun(renv env.Running) (interface{}, error) {
+	input := &ec2.RequestSpotFleetInput{SpotFleetRequestConfig: &ec2.SpotFleetRequestConfigData{}}
+	if err := structInjector(cmd, input, renv.Context()); err != nil {
+		return nil, fmt.Errorf("cannot inject in ec2.RequestSpotFleetInput: %s", err)
+	}
+	config := input.SpotFleetRequestConfig
+	if cmd.Interruption != nil {
+		config.InstanceInterruptionBehavior = String(strings.ToLower(StringValue(cmd.Interruption)))
+	}
+	if cmd.Allocation != nil {
+		for _, strategy := range []string{ec2.AllocationStrategyLowestPrice, ec2.AllocationStrategyDiversified} {
+			if strings.EqualFold(strategy, StringValue(cmd.Allocation)) {
+				config.AllocationStrategy = String(strategy)
+			}
+		}
+	}
+
+	spec := &ec2.SpotFleetLaunchSpecification{
+		ImageId:      cmd.Image,
+		InstanceType: cmd.Type,
+		KeyName:      cmd.Keypair,
+		SubnetId:     cmd.Subnet,
+	}
+	for _, group := range cmd.Securitygroups {
+		spec.SecurityGroups = append(spec.SecurityGroups, &ec2.GroupIdentifier{GroupId: group})
+	}
+	config.LaunchSpecifications = []*ec2.SpotFleetLaunchSpecification{spec}
+
+	start := time.Now()
+	output, err := cmd.api.RequestSpotFleet(input)
+	cmd.logger.ExtraVerbosef("ec2.RequestSpotFleet call took %s", time.Since(start))
+	return output, err
+}
+
+func (cmd *CreateSpotfleet) ExtractResult(i interface{}) string {
+	return StringValue(i.(*ec2.RequestSpotFleetOutput).SpotFleetRequestId)
+}
+
+type DeleteSpotfleet struct {
+	_         string `action:"delete" entity:"spotfleet" awsAPI:"ec2" awsCall:"CancelSpotFleetRequests" awsInput:"ec2.CancelSpotFleetRequestsInput" awsOutput:"ec2.CancelSpotFleetRequestsOutput"`
+	logger    *logger.Logger
+	graph     cloud.GraphAPI
+	api       ec2iface.EC2API
+	Id        *string `awsName:"SpotFleetRequestIds" awsType:"awsstringslice" templateName:"id"`
+	Terminate *bool   `awsName:"TerminateInstances" awsType:"awsbool" templateName:"terminate-instances"`
+}
+
+func (cmd *DeleteSpotfleet) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.Opt("terminate-instances")))
+}
+
+// BeforeRun terminates the instances of the fleet unless told otherwise, AWS requiring the choice to be explicit
+func (cmd *DeleteSpotfleet) BeforeRun(renv env.Running) error {
+	if cmd.Terminate == nil {
+		cmd.Terminate = Bool(true)
+	}
+	return nil
+}
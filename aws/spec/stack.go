@@ -27,6 +27,8 @@ import (
 	"github.com/wallix/awless/template/env"
 	"github.com/wallix/awless/template/params"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/wallix/awless/logger"
@@ -52,12 +54,14 @@ type CreateStack struct {
 	Tags            []*string `awsName:"Tags" awsType:"awstagslice" templateName:"tags"`
 	PolicyBody      *string   `awsName:"StackPolicyBody" awsType:"awsstr"`
 	StackFile       *string   `templateName:"stack-file"`
+	Wait            *bool     `templateName:"wait"`
+	WaitTimeout     *int64    `templateName:"wait-timeout"`
 }
 
 func (cmd *CreateStack) ParamsSpec() params.Spec {
 	return params.NewSpec(
-		params.AllOf(params.Key("name"), params.Key("template-file"), params.Opt("capabilities", "disable-rollback", "notifications", "on-failure", "parameters", "policy-file", "resource-types", "role", "stack-file", "tags", "timeout")),
-		params.Validators{"template-file": params.IsFilepath},
+		params.AllOf(params.Key("name"), params.Key("template-file"), params.Opt("capabilities", "disable-rollback", "notifications", "on-failure", "parameters", "policy-file", "resource-types", "role", "stack-file", "tags", "timeout", "wait", "wait-timeout")),
+		params.Validators{"template-file": params.IsFilepath, "wait-timeout": validateWaitTimeout},
 	)
 }
 
@@ -74,6 +78,13 @@ func (cmd *CreateStack) BeforeRun(renv env.Running) error {
 	return err
 }
 
+func (cmd *CreateStack) AfterRun(renv env.Running, output interface{}) error {
+	waitUntil(renv.Log(), cmd.Wait, cmd.WaitTimeout, fmt.Sprintf("stack %s create complete", StringValue(cmd.Name)), func(ctx aws.Context, opts ...request.WaiterOption) error {
+		return cmd.api.WaitUntilStackCreateCompleteWithContext(ctx, &cloudformation.DescribeStacksInput{StackName: cmd.Name}, opts...)
+	})
+	return nil
+}
+
 type UpdateStack struct {
 	_                   string `action:"update" entity:"stack" awsAPI:"cloudformation" awsCall:"UpdateStack" awsInput:"cloudformation.UpdateStackInput" awsOutput:"cloudformation.UpdateStackOutput"`
 	logger              *logger.Logger
@@ -92,12 +103,14 @@ type UpdateStack struct {
 	Tags                []*string `awsName:"Tags" awsType:"awstagslice" templateName:"tags"`
 	PolicyBody          *string   `awsName:"StackPolicyBody" awsType:"awsstr"`
 	StackFile           *string   `templateName:"stack-file"`
+	Wait                *bool     `templateName:"wait"`
+	WaitTimeout         *int64    `templateName:"wait-timeout"`
 }
 
 func (cmd *UpdateStack) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name"),
-		params.Opt("capabilities", "notifications", "parameters", "policy-file", "policy-update-file", "resource-types", "role", "stack-file", "tags", "template-file", "use-previous-template"),
-	))
+		params.Opt("capabilities", "notifications", "parameters", "policy-file", "policy-update-file", "resource-types", "role", "stack-file", "tags", "template-file", "use-previous-template", "wait", "wait-timeout"),
+	), params.Validators{"wait-timeout": validateWaitTimeout})
 }
 
 func (cmd *UpdateStack) ExtractResult(i interface{}) string {
@@ -113,6 +126,13 @@ func (cmd *UpdateStack) BeforeRun(renv env.Running) error {
 	return err
 }
 
+func (cmd *UpdateStack) AfterRun(renv env.Running, output interface{}) error {
+	waitUntil(renv.Log(), cmd.Wait, cmd.WaitTimeout, fmt.Sprintf("stack %s update complete", StringValue(cmd.Name)), func(ctx aws.Context, opts ...request.WaiterOption) error {
+		return cmd.api.WaitUntilStackUpdateCompleteWithContext(ctx, &cloudformation.DescribeStacksInput{StackName: cmd.Name}, opts...)
+	})
+	return nil
+}
+
 type stackFile struct {
 	Parameters  map[string]string      `yaml:"Parameters"`
 	Tags        map[string]string      `yaml:"Tags"`
@@ -243,10 +263,19 @@ type DeleteStack struct {
 	api             cloudformationiface.CloudFormationAPI
 	Name            *string   `awsName:"StackName" awsType:"awsstr" templateName:"name"`
 	RetainResources []*string `awsName:"RetainResources" awsType:"awsstringslice" templateName:"retain-resources"`
+	Wait            *bool     `templateName:"wait"`
+	WaitTimeout     *int64    `templateName:"wait-timeout"`
 }
 
 func (cmd *DeleteStack) ParamsSpec() params.Spec {
 	return params.NewSpec(params.AllOf(params.Key("name"),
-		params.Opt("retain-resources"),
-	))
+		params.Opt("retain-resources", "wait", "wait-timeout"),
+	), params.Validators{"wait-timeout": validateWaitTimeout})
+}
+
+func (cmd *DeleteStack) AfterRun(renv env.Running, output interface{}) error {
+	waitUntil(renv.Log(), cmd.Wait, cmd.WaitTimeout, fmt.Sprintf("stack %s delete complete", StringValue(cmd.Name)), func(ctx aws.Context, opts ...request.WaiterOption) error {
+		return cmd.api.WaitUntilStackDeleteCompleteWithContext(ctx, &cloudformation.DescribeStacksInput{StackName: cmd.Name}, opts...)
+	})
+	return nil
 }
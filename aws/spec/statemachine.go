@@ -0,0 +1,131 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/aws/aws-sdk-go/service/sfn"
+	"github.com/aws/aws-sdk-go/service/sfn/sfniface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateStatemachine struct {
+	_              string `action:"create" entity:"statemachine" awsAPI:"sfn" awsCall:"CreateStateMachine" awsInput:"sfn.CreateStateMachineInput" awsOutput:"sfn.CreateStateMachineOutput"`
+	logger         *logger.Logger
+	graph          cloud.GraphAPI
+	api            sfniface.SFNAPI
+	Name           *string `awsName:"Name" awsType:"awsstr" templateName:"name"`
+	DefinitionFile *string `awsName:"Definition" awsType:"awsfiletostring" templateName:"definition-file"`
+	Role           *string `awsName:"RoleArn" awsType:"awsstr" templateName:"role"`
+}
+
+func (cmd *CreateStatemachine) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("definition-file"), params.Key("name"), params.Key("role")),
+		params.Validators{"definition-file": validateStateMachineDefinitionFile},
+	)
+}
+
+func (cmd *CreateStatemachine) ExtractResult(i interface{}) string {
+	return StringValue(i.(*sfn.CreateStateMachineOutput).StateMachineArn)
+}
+
+type DeleteStatemachine struct {
+	_      string `action:"delete" entity:"statemachine" awsAPI:"sfn" awsCall:"DeleteStateMachine" awsInput:"sfn.DeleteStateMachineInput" awsOutput:"sfn.DeleteStateMachineOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    sfniface.SFNAPI
+	Id     *string `awsName:"StateMachineArn" awsType:"awsstr" templateName:"id"`
+}
+
+func (cmd *DeleteStatemachine) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id")))
+}
+
+type StartStatemachine struct {
+	_      string `action:"start" entity:"statemachine" awsAPI:"sfn" awsCall:"StartExecution" awsInput:"sfn.StartExecutionInput" awsOutput:"sfn.StartExecutionOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    sfniface.SFNAPI
+	Id     *string `awsName:"StateMachineArn" awsType:"awsstr" templateName:"id"`
+	Input  *string `awsName:"Input" awsType:"awsstr" templateName:"input"`
+	Name   *string `awsName:"Name" awsType:"awsstr" templateName:"name"`
+}
+
+func (cmd *StartStatemachine) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("id"), params.Opt("input", "name")),
+		params.Validators{"input": validateJSON},
+	)
+}
+
+func (cmd *StartStatemachine) ExtractResult(i interface{}) string {
+	return StringValue(i.(*sfn.StartExecutionOutput).ExecutionArn)
+}
+
+// validateStateMachineDefinitionFile checks that the file holds a state machine definition
+// in Amazon States Language: a JSON object with the states and the state to start at
+func validateStateMachineDefinitionFile(i interface{}, others map[string]interface{}) error {
+	if err := params.IsFilepath(i, others); err != nil {
+		return err
+	}
+	content, err := ioutil.ReadFile(fmt.Sprint(i))
+	if err != nil {
+		return err
+	}
+	return validateStateMachineDefinition(content)
+}
+
+func validateStateMachineDefinition(content []byte) error {
+	var definition struct {
+		StartAt string
+		States  map[string]struct {
+			Type string
+		}
+	}
+	if err := json.Unmarshal(content, &definition); err != nil {
+		return fmt.Errorf("invalid state machine definition: %s", err)
+	}
+	if definition.StartAt == "" {
+		return errors.New("invalid state machine definition: missing 'StartAt'")
+	}
+	if len(definition.States) == 0 {
+		return errors.New("invalid state machine definition: missing 'States'")
+	}
+	if _, ok := definition.States[definition.StartAt]; !ok {
+		return fmt.Errorf("invalid state machine definition: 'StartAt' state '%s' not found in 'States'", definition.StartAt)
+	}
+	for name, state := range definition.States {
+		if state.Type == "" {
+			return fmt.Errorf("invalid state machine definition: missing 'Type' of state '%s'", name)
+		}
+	}
+	return nil
+}
+
+func validateJSON(i interface{}, others map[string]interface{}) error {
+	var v interface{}
+	if err := json.Unmarshal([]byte(fmt.Sprint(i)), &v); err != nil {
+		return fmt.Errorf("invalid JSON: %s", err)
+	}
+	return nil
+}
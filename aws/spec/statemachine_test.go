@@ -0,0 +1,51 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateStateMachineDefinition(t *testing.T) {
+	tcases := []struct {
+		definition string
+		expErr     string
+	}{
+		{definition: `{"StartAt": "Hello", "States": {"Hello": {"Type": "Pass", "End": true}}}`},
+		{definition: `{"StartAt": "Hello", "States": {"Hello": {"Type": "Pass", "Next": "World"}, "World": {"Type": "Succeed"}}}`},
+		{definition: `StartAt: Hello`, expErr: "invalid state machine definition: invalid character"},
+		{definition: `{"States": {"Hello": {"Type": "Pass", "End": true}}}`, expErr: "missing 'StartAt'"},
+		{definition: `{"StartAt": "Hello"}`, expErr: "missing 'States'"},
+		{definition: `{"StartAt": "Hello", "States": {"World": {"Type": "Pass", "End": true}}}`, expErr: "'StartAt' state 'Hello' not found in 'States'"},
+		{definition: `{"StartAt": "Hello", "States": {"Hello": {"End": true}}}`, expErr: "missing 'Type' of state 'Hello'"},
+	}
+	for i, tcase := range tcases {
+		err := validateStateMachineDefinition([]byte(tcase.definition))
+		if tcase.expErr == "" {
+			if err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
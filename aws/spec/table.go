@@ -0,0 +1,207 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateTable struct {
+	_             string `action:"create" entity:"table" awsAPI:"dynamodb"`
+	logger        *logger.Logger
+	graph         cloud.GraphAPI
+	api           dynamodbiface.DynamoDBAPI
+	Name          *string `templateName:"name"`
+	HashKey       *string `templateName:"hash-key"`
+	HashKeyType   *string `templateName:"hash-key-type"`
+	RangeKey      *string `templateName:"range-key"`
+	RangeKeyType  *string `templateName:"range-key-type"`
+	ReadCapacity  *int64  `templateName:"read-capacity"`
+	WriteCapacity *int64  `templateName:"write-capacity"`
+}
+
+func (cmd *CreateTable) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("hash-key"), params.Key("name"), params.Key("read-capacity"), params.Key("write-capacity"),
+			params.Opt("hash-key-type", "range-key", "range-key-type"),
+		),
+		params.Validators{
+			"name":           params.MinLengthOf(3),
+			"hash-key-type":  params.IsInEnumIgnoreCase(tableKeyTypes...),
+			"range-key":      validateTableRangeKey,
+			"range-key-type": validateTableRangeKeyType,
+			"read-capacity":  validateTableCapacity,
+			"write-capacity": validateTableCapacity,
+		})
+}
+
+func (cmd *CreateTable) ManualRun(renv env.Running) (interface{}, error) {
+	input := &dynamodb.CreateTableInput{
+		TableName: cmd.Name,
+		ProvisionedThroughput: &dynamodb.ProvisionedThroughput{
+			ReadCapacityUnits:  cmd.ReadCapacity,
+			WriteCapacityUnits: cmd.WriteCapacity,
+		},
+	}
+	addKey := func(name, attrType *string, keyType string) {
+		input.KeySchema = append(input.KeySchema, &dynamodb.KeySchemaElement{AttributeName: name, KeyType: awssdk.String(keyType)})
+		input.AttributeDefinitions = append(input.AttributeDefinitions, &dynamodb.AttributeDefinition{AttributeName: name, AttributeType: awssdk.String(tableKeyType(attrType))})
+	}
+	addKey(cmd.HashKey, cmd.HashKeyType, dynamodb.KeyTypeHash)
+	if cmd.RangeKey != nil {
+		addKey(cmd.RangeKey, cmd.RangeKeyType, dynamodb.KeyTypeRange)
+	}
+
+	start := time.Now()
+	output, err := cmd.api.CreateTable(input)
+	cmd.logger.ExtraVerbosef("dynamodb.CreateTable call took %s", time.Since(start))
+	return output, err
+}
+
+func (cmd *CreateTable) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*dynamodb.CreateTableOutput).TableDescription.TableName)
+}
+
+type UpdateTable struct {
+	_             string `action:"update" entity:"table" awsAPI:"dynamodb"`
+	logger        *logger.Logger
+	graph         cloud.GraphAPI
+	api           dynamodbiface.DynamoDBAPI
+	Name          *string `templateName:"name"`
+	Index         *string `templateName:"index"`
+	ReadCapacity  *int64  `templateName:"read-capacity"`
+	WriteCapacity *int64  `templateName:"write-capacity"`
+}
+
+func (cmd *UpdateTable) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("name"), params.AtLeastOneOf(params.Key("read-capacity"), params.Key("write-capacity")), params.Opt("index")),
+		params.Validators{
+			"read-capacity":  validateTableCapacity,
+			"write-capacity": validateTableCapacity,
+		})
+}
+
+// ManualRun updates the provisioned throughput of the table, or of one of its global secondary indexes.
+// AWS requiring both read and write capacities, the one not given is kept at its current value.
+func (cmd *UpdateTable) ManualRun(renv env.Running) (interface{}, error) {
+	throughput := &dynamodb.ProvisionedThroughput{ReadCapacityUnits: cmd.ReadCapacity, WriteCapacityUnits: cmd.WriteCapacity}
+	if throughput.ReadCapacityUnits == nil || throughput.WriteCapacityUnits == nil {
+		current, err := cmd.currentThroughput()
+		if err != nil {
+			return nil, err
+		}
+		if throughput.ReadCapacityUnits == nil {
+			throughput.ReadCapacityUnits = current.ReadCapacityUnits
+		}
+		if throughput.WriteCapacityUnits == nil {
+			throughput.WriteCapacityUnits = current.WriteCapacityUnits
+		}
+	}
+
+	input := &dynamodb.UpdateTableInput{TableName: cmd.Name}
+	if cmd.Index != nil {
+		input.GlobalSecondaryIndexUpdates = []*dynamodb.GlobalSecondaryIndexUpdate{
+			{Update: &dynamodb.UpdateGlobalSecondaryIndexAction{IndexName: cmd.Index, ProvisionedThroughput: throughput}},
+		}
+	} else {
+		input.ProvisionedThroughput = throughput
+	}
+
+	start := time.Now()
+	output, err := cmd.api.UpdateTable(input)
+	cmd.logger.ExtraVerbosef("dynamodb.UpdateTable call took %s", time.Since(start))
+	return output, err
+}
+
+func (cmd *UpdateTable) currentThroughput() (*dynamodb.ProvisionedThroughputDescription, error) {
+	out, err := cmd.api.DescribeTable(&dynamodb.DescribeTableInput{TableName: cmd.Name})
+	if err != nil {
+		return nil, err
+	}
+	if cmd.Index == nil {
+		return out.Table.ProvisionedThroughput, nil
+	}
+	for _, index := range out.Table.GlobalSecondaryIndexes {
+		if awssdk.StringValue(index.IndexName) == StringValue(cmd.Index) {
+			return index.ProvisionedThroughput, nil
+		}
+	}
+	return nil, fmt.Errorf("update table: no global secondary index '%s' in table %s", StringValue(cmd.Index), StringValue(cmd.Name))
+}
+
+func (cmd *UpdateTable) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*dynamodb.UpdateTableOutput).TableDescription.TableName)
+}
+
+type DeleteTable struct {
+	_      string `action:"delete" entity:"table" awsAPI:"dynamodb" awsCall:"DeleteTable" awsInput:"dynamodb.DeleteTableInput" awsOutput:"dynamodb.DeleteTableOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    dynamodbiface.DynamoDBAPI
+	Name   *string `awsName:"TableName" awsType:"awsstr" templateName:"name"`
+}
+
+func (cmd *DeleteTable) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("name")))
+}
+
+// Types of the attributes of the table keys: string, number or binary
+var tableKeyTypes = []string{dynamodb.ScalarAttributeTypeS, dynamodb.ScalarAttributeTypeN, dynamodb.ScalarAttributeTypeB}
+
+func tableKeyType(t *string) string {
+	if t == nil {
+		return dynamodb.ScalarAttributeTypeS
+	}
+	return strings.ToUpper(*t)
+}
+
+func validateTableRangeKey(i interface{}, others map[string]interface{}) error {
+	if fmt.Sprint(i) == fmt.Sprint(others["hash-key"]) {
+		return errors.New("range key must differ from the hash key")
+	}
+	return nil
+}
+
+func validateTableRangeKeyType(i interface{}, others map[string]interface{}) error {
+	if _, ok := others["range-key"]; !ok {
+		return errors.New("requires a 'range-key'")
+	}
+	return params.IsInEnumIgnoreCase(tableKeyTypes...)(i, others)
+}
+
+// validateTableCapacity checks provisioned read and write capacity units (from 1 to 40000, the default account limit)
+func validateTableCapacity(i interface{}, others map[string]interface{}) error {
+	capacity, err := castInt64(i)
+	if err != nil {
+		return err
+	}
+	if capacity < 1 || capacity > 40000 {
+		return fmt.Errorf("expected capacity units between 1 and 40000 but got %d", capacity)
+	}
+	return nil
+}
@@ -16,10 +16,13 @@ limitations under the License.
 package awsspec
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/wallix/awless/aws/pool"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/template/env"
 	"github.com/wallix/awless/template/params"
@@ -29,6 +32,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
 	"github.com/wallix/awless/logger"
 )
 
@@ -37,19 +42,26 @@ type CreateTag struct {
 	logger   *logger.Logger
 	graph    cloud.GraphAPI
 	api      ec2iface.EC2API
-	Resource *string `awsName:"Resources" awsType:"awsstringslice" templateName:"resource"`
-	Key      *string `templateName:"key"`
-	Value    *string `templateName:"value"`
+	Resource *string   `awsName:"Resources" awsType:"awsstringslice" templateName:"resource"`
+	Query    []*string `templateName:"query"`
+	Key      *string   `templateName:"key"`
+	Value    *string   `templateName:"value"`
 }
 
 func (cmd *CreateTag) ParamsSpec() params.Spec {
-	return params.NewSpec(params.AllOf(params.Key("key"), params.Key("resource"), params.Key("value")))
+	return params.NewSpec(
+		params.AllOf(params.Key("key"), params.OnlyOneOf(params.Key("resource"), params.Key("query")), params.Key("value")),
+		params.Validators{"query": validateTagQuery},
+	)
 }
 
 func (cmd *CreateTag) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("dry run: cannot set params on command struct: %s", err)
 	}
+	if !isEC2TagResource(cmd.Resource) {
+		return dryRunTagResources(cmd.logger, "create", cmd.Resource, cmd.Query)
+	}
 
 	input := &ec2.CreateTagsInput{}
 	input.SetDryRun(true)
@@ -73,6 +85,9 @@ func (cmd *CreateTag) dryRun(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *CreateTag) ManualRun(renv env.Running) (interface{}, error) {
+	if !isEC2TagResource(cmd.Resource) {
+		return nil, cmd.tagResources()
+	}
 	input := &ec2.CreateTagsInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
 		return nil, fmt.Errorf("cannot inject in ec2.CreateTagsInput: %s", err)
@@ -89,26 +104,63 @@ func (cmd *CreateTag) ManualRun(renv env.Running) (interface{}, error) {
 	return nil, nil
 }
 
+func (cmd *CreateTag) tagResources() error {
+	api, err := taggingAPI()
+	if err != nil {
+		return err
+	}
+	arns, err := resolveTagResources(api, cmd.Resource, cmd.Query)
+	if err != nil {
+		return err
+	}
+	err = batchTagResources(arns, func(batch []*string) (map[string]*resourcegroupstaggingapi.FailureInfo, error) {
+		start := time.Now()
+		out, err := api.TagResources(&resourcegroupstaggingapi.TagResourcesInput{
+			ResourceARNList: batch,
+			Tags:            map[string]*string{StringValue(cmd.Key): cmd.Value},
+		})
+		cmd.logger.ExtraVerbosef("resourcegroupstaggingapi.TagResources call took %s", time.Since(start))
+		if err != nil {
+			return nil, err
+		}
+		return out.FailedResourcesMap, nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(cmd.Query) > 0 {
+		cmd.logger.Infof("tag '%s' created on %d resource(s)", StringValue(cmd.Key), len(arns))
+	}
+	return nil
+}
+
 type DeleteTag struct {
 	_        string `action:"delete" entity:"tag" awsAPI:"ec2" awsDryRun:"manual"`
 	logger   *logger.Logger
 	graph    cloud.GraphAPI
 	api      ec2iface.EC2API
-	Resource *string `awsName:"Resources" awsType:"awsstringslice" templateName:"resource"`
-	Key      *string `templateName:"key"`
-	Value    *string `templateName:"value"`
+	Resource *string   `awsName:"Resources" awsType:"awsstringslice" templateName:"resource"`
+	Query    []*string `templateName:"query"`
+	Key      *string   `templateName:"key"`
+	Value    *string   `templateName:"value"`
 }
 
 func (cmd *DeleteTag) ParamsSpec() params.Spec {
-	return params.NewSpec(params.AllOf(params.Key("key"), params.Key("resource"),
-		params.Opt("value"),
-	))
+	return params.NewSpec(
+		params.AllOf(params.Key("key"), params.OnlyOneOf(params.Key("resource"), params.Key("query")),
+			params.Opt("value"),
+		),
+		params.Validators{"query": validateTagQuery},
+	)
 }
 
 func (cmd *DeleteTag) dryRun(renv env.Running, params map[string]interface{}) (interface{}, error) {
 	if err := cmd.inject(params); err != nil {
 		return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 	}
+	if !isEC2TagResource(cmd.Resource) {
+		return dryRunTagResources(cmd.logger, "delete", cmd.Resource, cmd.Query)
+	}
 
 	input := &ec2.DeleteTagsInput{}
 	input.SetDryRun(true)
@@ -132,6 +184,9 @@ func (cmd *DeleteTag) dryRun(renv env.Running, params map[string]interface{}) (i
 }
 
 func (cmd *DeleteTag) ManualRun(renv env.Running) (interface{}, error) {
+	if !isEC2TagResource(cmd.Resource) {
+		return nil, cmd.untagResources()
+	}
 	input := &ec2.DeleteTagsInput{}
 	if err := structInjector(cmd, input, renv.Context()); err != nil {
 		return nil, fmt.Errorf("cannot inject in ec2.DeleteTagsInput: %s", err)
@@ -144,6 +199,183 @@ func (cmd *DeleteTag) ManualRun(renv env.Running) (interface{}, error) {
 	return nil, err
 }
 
+// untagResources removes the tag key whatever its value, the resource groups
+// tagging API not supporting conditional deletion (i.e. use a query to target values)
+func (cmd *DeleteTag) untagResources() error {
+	api, err := taggingAPI()
+	if err != nil {
+		return err
+	}
+	arns, err := resolveTagResources(api, cmd.Resource, cmd.Query)
+	if err != nil {
+		return err
+	}
+	err = batchTagResources(arns, func(batch []*string) (map[string]*resourcegroupstaggingapi.FailureInfo, error) {
+		start := time.Now()
+		out, err := api.UntagResources(&resourcegroupstaggingapi.UntagResourcesInput{
+			ResourceARNList: batch,
+			TagKeys:         []*string{cmd.Key},
+		})
+		cmd.logger.ExtraVerbosef("resourcegroupstaggingapi.UntagResources call took %s", time.Since(start))
+		if err != nil {
+			return nil, err
+		}
+		return out.FailedResourcesMap, nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(cmd.Query) > 0 {
+		cmd.logger.Infof("tag '%s' deleted on %d resource(s)", StringValue(cmd.Key), len(arns))
+	}
+	return nil
+}
+
+// The resource groups tagging API accepts at most 20 ARNs per (un)tag call
+const maxTagResourcesPerCall = 20
+
+// taggingAPIFactory is implemented by command factories giving access to the
+// resource groups tagging API, used to tag resources outside of EC2 by ARN
+type taggingAPIFactory interface {
+	TaggingAPI() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+}
+
+func (f *AWSFactory) TaggingAPI() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+	if f.Sess == nil {
+		return nil
+	}
+	return awspool.Default.Client(f.Sess, "resourcegroupstaggingapi", func() interface{} { return resourcegroupstaggingapi.New(f.Sess) }).(resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI)
+}
+
+func taggingAPI() (resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI, error) {
+	if f, ok := CommandFactory.(taggingAPIFactory); ok {
+		if api := f.TaggingAPI(); api != nil {
+			return api, nil
+		}
+	}
+	return nil, errors.New("resource groups tagging API unavailable")
+}
+
+// isEC2TagResource returns false for resources given by ARN (or by query) that are
+// tagged through the resource groups tagging API rather than the EC2 API
+func isEC2TagResource(resource *string) bool {
+	return resource != nil && !strings.HasPrefix(StringValue(resource), "arn:")
+}
+
+func dryRunTagResources(l *logger.Logger, action string, resource *string, query []*string) (interface{}, error) {
+	if len(query) > 0 {
+		api, err := taggingAPI()
+		if err != nil {
+			return nil, fmt.Errorf("dry run: %s", err)
+		}
+		arns, err := resolveTagResources(api, resource, query)
+		if err != nil {
+			return nil, fmt.Errorf("dry run: %s", err)
+		}
+		l.Verbosef("dry run: %s tag on %d resource(s) matching query", action, len(arns))
+	}
+	l.Verbosef("dry run: %s tag ok", action)
+	return fakeDryRunId("tag"), nil
+}
+
+// resolveTagResources returns the ARN of the given resource or the ARNs of
+// all the resources matching the query
+func resolveTagResources(api resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI, resource *string, query []*string) ([]*string, error) {
+	if resource != nil {
+		return []*string{resource}, nil
+	}
+	input, err := parseTagQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	var arns []*string
+	for {
+		out, err := api.GetResources(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, mapping := range out.ResourceTagMappingList {
+			arns = append(arns, mapping.ResourceARN)
+		}
+		if StringValue(out.PaginationToken) == "" {
+			return arns, nil
+		}
+		input.PaginationToken = out.PaginationToken
+	}
+}
+
+func batchTagResources(arns []*string, call func([]*string) (map[string]*resourcegroupstaggingapi.FailureInfo, error)) error {
+	var failures []string
+	for i := 0; i < len(arns); i += maxTagResourcesPerCall {
+		end := i + maxTagResourcesPerCall
+		if end > len(arns) {
+			end = len(arns)
+		}
+		failed, err := call(arns[i:end])
+		if err != nil {
+			return err
+		}
+		for arn, info := range failed {
+			failures = append(failures, fmt.Sprintf("%s: %s", arn, StringValue(info.ErrorMessage)))
+		}
+	}
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("%d resource(s) failed:\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// parseTagQuery builds the resource filters from a tag query whose items are either
+// tags given as 'Key=Value' ('Key=*' matching any value) or resource types (ex: ec2:instance, s3, lambda:function).
+// Values given for the same tag key are OR'ed, whereas different keys and the resource types are AND'ed.
+func parseTagQuery(query []*string) (*resourcegroupstaggingapi.GetResourcesInput, error) {
+	input := &resourcegroupstaggingapi.GetResourcesInput{}
+	filters := make(map[string]*resourcegroupstaggingapi.TagFilter)
+	for _, item := range query {
+		q := strings.TrimSpace(StringValue(item))
+		if q == "" {
+			continue
+		}
+		if !strings.Contains(q, "=") {
+			input.ResourceTypeFilters = append(input.ResourceTypeFilters, String(q))
+			continue
+		}
+		splits := strings.SplitN(q, "=", 2)
+		key, value := strings.TrimSpace(splits[0]), strings.TrimSpace(splits[1])
+		if key == "" {
+			return nil, fmt.Errorf("invalid tag query item '%s', expected 'Key=Value'", q)
+		}
+		filter, ok := filters[key]
+		if !ok {
+			filter = &resourcegroupstaggingapi.TagFilter{Key: String(key)}
+			filters[key] = filter
+			input.TagFilters = append(input.TagFilters, filter)
+		}
+		if value != "*" {
+			filter.Values = append(filter.Values, String(value))
+		}
+	}
+	if len(input.TagFilters) == 0 && len(input.ResourceTypeFilters) == 0 {
+		return nil, errors.New("empty tag query")
+	}
+	return input, nil
+}
+
+func validateTagQuery(i interface{}, others map[string]interface{}) error {
+	var query []*string
+	switch v := i.(type) {
+	case []interface{}:
+		for _, item := range v {
+			query = append(query, String(fmt.Sprint(item)))
+		}
+	default:
+		query = append(query, String(fmt.Sprint(v)))
+	}
+	_, err := parseTagQuery(query)
+	return err
+}
+
 func createNameTag(resource, name *string, renv env.Running) error {
 	createTag := CommandFactory.Build("createtag")().(*CreateTag)
 	entries := map[string]interface{}{
@@ -0,0 +1,74 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+)
+
+func TestParseTagQuery(t *testing.T) {
+	tcases := []struct {
+		query  []string
+		exp    *resourcegroupstaggingapi.GetResourcesInput
+		expErr string
+	}{
+		{
+			query: []string{"Env=staging"},
+			exp: &resourcegroupstaggingapi.GetResourcesInput{
+				TagFilters: []*resourcegroupstaggingapi.TagFilter{{Key: String("Env"), Values: []*string{String("staging")}}},
+			},
+		},
+		{
+			query: []string{"Env=staging", "ec2:instance", "Env=test", "Team=*", "s3"},
+			exp: &resourcegroupstaggingapi.GetResourcesInput{
+				TagFilters: []*resourcegroupstaggingapi.TagFilter{
+					{Key: String("Env"), Values: []*string{String("staging"), String("test")}},
+					{Key: String("Team")},
+				},
+				ResourceTypeFilters: []*string{String("ec2:instance"), String("s3")},
+			},
+		},
+		{
+			query: []string{"lambda:function"},
+			exp:   &resourcegroupstaggingapi.GetResourcesInput{ResourceTypeFilters: []*string{String("lambda:function")}},
+		},
+		{query: []string{"=staging"}, expErr: "invalid tag query item"},
+		{query: []string{" "}, expErr: "empty tag query"},
+	}
+	for i, tcase := range tcases {
+		var query []*string
+		for _, q := range tcase.query {
+			query = append(query, String(q))
+		}
+		input, err := parseTagQuery(query)
+		if tcase.expErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%d: got %v, want %q", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := input, tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/route53/route53iface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+type CreateTrafficpolicy struct {
+	_        string `action:"create" entity:"trafficpolicy" awsAPI:"route53" awsCall:"CreateTrafficPolicy" awsInput:"route53.CreateTrafficPolicyInput" awsOutput:"route53.CreateTrafficPolicyOutput"`
+	logger   *logger.Logger
+	graph    cloud.GraphAPI
+	api      route53iface.Route53API
+	Name     *string `awsName:"Name" awsType:"awsstr" templateName:"name"`
+	Document *string `awsName:"Document" awsType:"awsfiletostring" templateName:"document-file"`
+	Comment  *string `awsName:"Comment" awsType:"awsstr" templateName:"comment"`
+}
+
+func (cmd *CreateTrafficpolicy) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("name"), params.Key("document-file"), params.Opt("comment")),
+		params.Validators{
+			"document-file": params.IsFilepath,
+		})
+}
+
+func (cmd *CreateTrafficpolicy) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*route53.CreateTrafficPolicyOutput).TrafficPolicy.Id)
+}
+
+type DeleteTrafficpolicy struct {
+	_       string `action:"delete" entity:"trafficpolicy" awsAPI:"route53" awsCall:"DeleteTrafficPolicy" awsInput:"route53.DeleteTrafficPolicyInput" awsOutput:"route53.DeleteTrafficPolicyOutput"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     route53iface.Route53API
+	Id      *string `awsName:"Id" awsType:"awsstr" templateName:"id"`
+	Version *int64  `awsName:"Version" awsType:"awsint64" templateName:"version"`
+}
+
+func (cmd *DeleteTrafficpolicy) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id"), params.Key("version")),
+		params.Validators{
+			"version": params.IsIntInRange(1, 1000),
+		})
+}
+
+// CreateTrafficpolicyinstance creates the records of a domain name from a version of a traffic policy
+type CreateTrafficpolicyinstance struct {
+	_       string `action:"create" entity:"trafficpolicyinstance" awsAPI:"route53" awsCall:"CreateTrafficPolicyInstance" awsInput:"route53.CreateTrafficPolicyInstanceInput" awsOutput:"route53.CreateTrafficPolicyInstanceOutput"`
+	logger  *logger.Logger
+	graph   cloud.GraphAPI
+	api     route53iface.Route53API
+	Zone    *string `awsName:"HostedZoneId" awsType:"awsstr" templateName:"zone"`
+	Name    *string `awsName:"Name" awsType:"awsstr" templateName:"name"`
+	TTL     *int64  `awsName:"TTL" awsType:"awsint64" templateName:"ttl"`
+	Policy  *string `awsName:"TrafficPolicyId" awsType:"awsstr" templateName:"policy"`
+	Version *int64  `awsName:"TrafficPolicyVersion" awsType:"awsint64" templateName:"version"`
+}
+
+func (cmd *CreateTrafficpolicyinstance) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("zone"), params.Key("name"), params.Key("ttl"), params.Key("policy"), params.Key("version")),
+		params.Validators{
+			"version": params.IsIntInRange(1, 1000),
+		})
+}
+
+func (cmd *CreateTrafficpolicyinstance) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*route53.CreateTrafficPolicyInstanceOutput).TrafficPolicyInstance.Id)
+}
+
+type DeleteTrafficpolicyinstance struct {
+	_      string `action:"delete" entity:"trafficpolicyinstance" awsAPI:"route53" awsCall:"DeleteTrafficPolicyInstance" awsInput:"route53.DeleteTrafficPolicyInstanceInput" awsOutput:"route53.DeleteTrafficPolicyInstanceOutput"`
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    route53iface.Route53API
+	Id     *string `awsName:"Id" awsType:"awsstr" templateName:"id"`
+}
+
+func (cmd *DeleteTrafficpolicyinstance) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("id")))
+}
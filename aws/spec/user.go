@@ -21,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/env"
 	"github.com/wallix/awless/template/params"
 )
 
@@ -40,6 +41,15 @@ func (cmd *CreateUser) ExtractResult(i interface{}) string {
 	return awssdk.StringValue(i.(*iam.CreateUserOutput).User.UserId)
 }
 
+func (cmd *CreateUser) ExtractTypedResult(i interface{}) *env.Result {
+	user := i.(*iam.CreateUserOutput).User
+	return &env.Result{
+		ID:         awssdk.StringValue(user.UserId),
+		ARN:        awssdk.StringValue(user.Arn),
+		Attributes: map[string]string{"name": awssdk.StringValue(user.UserName)},
+	}
+}
+
 type DeleteUser struct {
 	_      string `action:"delete" entity:"user" awsAPI:"iam" awsCall:"DeleteUser" awsInput:"iam.DeleteUserInput" awsOutput:"iam.DeleteUserOutput"`
 	logger *logger.Logger
@@ -0,0 +1,64 @@
+/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+
+// Commands creating or deleting resources that take time to be ready (or gone) accept
+// 'wait=true' to only return once the resource is, so that the following statements of a
+// template can depend on it without check commands. The wait is bounded by 'wait-timeout' (seconds).
+// A failed wait is only a warning: the command did run and its result (ex: the created ID) is kept,
+// so that the resource remains in the log and revertible.
+const defaultWaitTimeout = 10 * time.Minute
+
+var validateWaitTimeout = params.All(params.IsIntInRange(1, 24*3600), params.Requires("wait"))
+
+type sdkWaiter func(aws.Context, ...request.WaiterOption) error
+
+// waitUntil runs the AWS SDK waiter when wait is true, the SDK waiter
+// polling with its own delay until the timeout expires
+func waitUntil(l *logger.Logger, wait *bool, timeout *int64, description string, waiter sdkWaiter) {
+	if !BoolValue(wait) {
+		return
+	}
+	d := defaultWaitTimeout
+	if timeout != nil {
+		d = time.Duration(*timeout) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	start := time.Now()
+	l.InteractiveInfof("waiting for %s (timeout in %s)", description, d)
+	if err := waiter(ctx, request.WithWaiterMaxAttempts(math.MaxInt32)); err != nil {
+		if ctx.Err() != nil {
+			l.Warningf("wait for %s: timeout of %s expired", description, d)
+		} else {
+			l.Warningf("wait for %s: %s", description, err)
+		}
+		return
+	}
+	l.Verbosef("%s after %s", description, time.Since(start).Round(time.Second))
+}
@@ -18,13 +18,10 @@ package cloud
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"strings"
 )
 
-var ErrFetchAccessDenied = errors.New("access denied to cloud resource")
-
 // Resources
 const (
 	Region string = "region"
@@ -45,14 +42,20 @@ const (
 	ElasticIP        string = "elasticip"
 	Snapshot         string = "snapshot"
 	NetworkInterface string = "networkinterface"
+	SpotFleet        string = "spotfleet"
+	SpotRequest      string = "spotrequest"
 	Certificate      string = "certificate"
 	//loadbalancer
 	LoadBalancer string = "loadbalancer"
 	TargetGroup  string = "targetgroup"
 	Listener     string = "listener"
 	//database
-	Database      string = "database"
-	DbSubnetGroup string = "dbsubnetgroup"
+	Database         string = "database"
+	DbSubnetGroup    string = "dbsubnetgroup"
+	Table            string = "table"
+	TableIndex       string = "tableindex"
+	CacheCluster     string = "cachecluster"
+	ReplicationGroup string = "replicationgroup"
 	//access
 	User         string = "user"
 	Role         string = "role"
@@ -71,10 +74,20 @@ const (
 	//queue
 	Queue string = "queue"
 	//dns
-	Zone   string = "zone"
-	Record string = "record"
+	Zone        string = "zone"
+	Record      string = "record"
+	HealthCheck string = "healthcheck"
 	//lambda
-	Function string = "function"
+	Function           string = "function"
+	FunctionAlias      string = "functionalias"
+	EventSourceMapping string = "eventsourcemapping"
+	//step functions
+	StateMachine string = "statemachine"
+	Execution    string = "execution"
+	//api gateway
+	RestApi       string = "restapi"
+	ApiStage      string = "apistage"
+	ApiDeployment string = "apideployment"
 	//autoscaling
 	LaunchConfiguration string = "launchconfiguration"
 	ScalingGroup        string = "scalinggroup"
@@ -83,7 +96,8 @@ const (
 	Metric string = "metric"
 	Alarm  string = "alarm"
 	//cdn
-	Distribution string = "distribution"
+	Distribution         string = "distribution"
+	OriginAccessIdentity string = "originaccessidentity"
 	//cloudformation
 	Stack string = "stack"
 	//container
@@ -97,6 +111,10 @@ const (
 	//application autoscaling
 	AppScalingTarget string = "appscalingtarget"
 	AppScalingPolicy string = "appscalingpolicy"
+	//encryption
+	Key      string = "key"
+	Alias    string = "alias"
+	KeyGrant string = "keygrant"
 )
 
 type Service interface {
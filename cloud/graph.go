@@ -29,9 +29,26 @@ type GraphAPI interface {
 	ResourceRelations(r Resource, relation string, recursive bool) ([]Resource, error)
 	VisitRelations(Resource, string, bool, func(Resource, int) error) error
 	ResourceSiblings(Resource) ([]Resource, error)
+	Related(r Resource, relation string) ([]Resource, error)
 	Merge(GraphAPI) error
 }
 
+// Typed relations navigable with GraphAPI.Related, from the given resource
+const (
+	// ParentOfRel leads to the children of the resource (ex: the subnets of a vpc)
+	ParentOfRel = "parentOf"
+	// AppliesOnRel leads to the resources the resource applies on (ex: the instances of a security group)
+	AppliesOnRel = "appliesOn"
+	// AttachedToRel leads to the resources referenced in the properties of the resource
+	// (ex: the subnet, security groups and keypair of an instance)
+	AttachedToRel = "attachedTo"
+	// DependsOnRel leads to the resources the resource needs: its parents, the resources applying
+	// on it and the resources it is attached to
+	DependsOnRel = "dependsOn"
+)
+
+var TypedRelations = []string{ParentOfRel, AppliesOnRel, AttachedToRel, DependsOnRel}
+
 type Resource interface {
 	Type() string
 	Id() string
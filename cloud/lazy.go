@@ -74,6 +74,11 @@ func (g *LazyGraph) ResourceSiblings(r Resource) ([]Resource, error) {
 	return g.api.ResourceSiblings(r)
 }
 
+func (g *LazyGraph) Related(r Resource, relation string) ([]Resource, error) {
+	g.load()
+	return g.api.Related(r, relation)
+}
+
 func (g *LazyGraph) Merge(aG GraphAPI) error {
 	g.load()
 	return g.api.Merge(aG)
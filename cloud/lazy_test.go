@@ -69,6 +69,10 @@ func (g *StubGraph) VisitRelations(Resource, string, bool, func(Resource, int) e
 	return nil
 }
 
+func (g *StubGraph) Related(Resource, string) ([]Resource, error) {
+	return nil, nil
+}
+
 func (g *StubGraph) ResourceSiblings(Resource) ([]Resource, error) {
 	return nil, nil
 }
@@ -0,0 +1,267 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package match
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/rdf"
+)
+
+// Comparison operators
+const (
+	Equal        = "="
+	NotEqual     = "!="
+	Less         = "<"
+	LessEqual    = "<="
+	Greater      = ">"
+	GreaterEqual = ">="
+)
+
+type comparisonMatcher struct {
+	name, op, value string
+}
+
+// Compare matches resources whose property compares to the value with the operator.
+// Numbers and dates (RFC3339 or 2006-01-02) are compared as such, other values as case insensitive strings.
+// The property can be a tag (ex: tag:Env) or an annotation (ex: annotation:owner). A list property matches when any of its values does.
+// Resources without the property only match the NotEqual operator.
+func Compare(name, op, value string) cloud.Matcher {
+	return comparisonMatcher{name: name, op: op, value: value}
+}
+
+func (m comparisonMatcher) Match(r cloud.Resource) bool {
+	v, found := lookupProperty(r, m.name)
+	if !found {
+		return m.op == NotEqual
+	}
+	if m.op == NotEqual {
+		return !(comparisonMatcher{name: m.name, op: Equal, value: m.value}).Match(r)
+	}
+	for _, val := range values(v) {
+		cmp := compareValue(val, m.value)
+		switch m.op {
+		case Equal:
+			if cmp == 0 {
+				return true
+			}
+		case Less:
+			if cmp < 0 {
+				return true
+			}
+		case LessEqual:
+			if cmp <= 0 {
+				return true
+			}
+		case Greater:
+			if cmp > 0 {
+				return true
+			}
+		case GreaterEqual:
+			if cmp >= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type regexMatcher struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// Regex matches resources whose property (or any of its values for a list) matches the regular expression
+func Regex(name string, re *regexp.Regexp) cloud.Matcher {
+	return regexMatcher{name: name, re: re}
+}
+
+func (m regexMatcher) Match(r cloud.Resource) bool {
+	v, found := lookupProperty(r, m.name)
+	if !found {
+		return false
+	}
+	for _, val := range values(v) {
+		if m.re.MatchString(fmt.Sprint(val)) {
+			return true
+		}
+	}
+	return false
+}
+
+type openToMatcher struct {
+	name    string
+	port    int64
+	network *net.IPNet
+}
+
+type firewallRule interface {
+	Opens(port int64, network *net.IPNet) bool
+}
+
+// OpenTo matches resources having a firewall rule in the property (ex: InboundRules of security groups)
+// allowing the port (any port when negative) from the whole network (ex: 0.0.0.0/0 for anywhere)
+func OpenTo(name string, port int64, network *net.IPNet) cloud.Matcher {
+	return openToMatcher{name: name, port: port, network: network}
+}
+
+func (m openToMatcher) Match(r cloud.Resource) bool {
+	v, found := lookupProperty(r, m.name)
+	if !found {
+		return false
+	}
+	rules := reflect.ValueOf(v)
+	if rules.Kind() != reflect.Slice {
+		return false
+	}
+	for i := 0; i < rules.Len(); i++ {
+		if rule, ok := rules.Index(i).Interface().(firewallRule); ok && rule.Opens(m.port, m.network) {
+			return true
+		}
+	}
+	return false
+}
+
+type related struct {
+	resourceType string
+	matcher      cloud.Matcher
+}
+
+// Related matches resources having a related resource of the given type matched by the matcher.
+// Related resources are the ancestors and descendants of the resource (ex: subnet and vpc of an instance),
+// the resources it depends on and the ones it applies on (ex: security groups of an instance).
+// It only matches once resolved in a graph (see cloud.GraphMatcher).
+func Related(resourceType string, matcher cloud.Matcher) cloud.Matcher {
+	return related{resourceType: resourceType, matcher: matcher}
+}
+
+func (m related) Match(r cloud.Resource) bool {
+	return false
+}
+
+func (m related) InGraph(g cloud.GraphAPI) cloud.Matcher {
+	matcher := m.matcher
+	if gm, ok := matcher.(cloud.GraphMatcher); ok {
+		matcher = gm.InGraph(g)
+	}
+	return relatedInGraph{resourceType: m.resourceType, matcher: matcher, graph: g}
+}
+
+type relatedInGraph struct {
+	resourceType string
+	matcher      cloud.Matcher
+	graph        cloud.GraphAPI
+}
+
+func (m relatedInGraph) Match(r cloud.Resource) bool {
+	relations := []struct {
+		name      string
+		recursive bool
+	}{
+		{rdf.ParentOf, true}, {rdf.ChildrenOfRel, true}, {rdf.DependingOnRel, false}, {rdf.ApplyOn, false},
+	}
+	for _, rel := range relations {
+		resources, err := m.graph.ResourceRelations(r, rel.name, rel.recursive)
+		if err != nil {
+			continue
+		}
+		for _, res := range resources {
+			if res.Type() == m.resourceType && m.matcher.Match(res) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// lookupProperty returns the property of the resource ignoring the case of its name,
+// or the value of the tag (resp. annotation) when the name is prefixed with 'tag:' (resp. 'annotation:')
+func lookupProperty(r cloud.Resource, name string) (interface{}, bool) {
+	for prefix, prop := range map[string]string{"tag:": "Tags", "annotation:": "Annotations"} {
+		if !strings.HasPrefix(strings.ToLower(name), prefix) {
+			continue
+		}
+		list, _ := r.Properties()[prop].([]string)
+		for _, t := range list {
+			if splits := strings.SplitN(t, "=", 2); len(splits) == 2 && splits[0] == name[len(prefix):] {
+				return splits[1], true
+			}
+		}
+		return nil, false
+	}
+	if v, ok := r.Property(name); ok {
+		return v, true
+	}
+	for k, v := range r.Properties() {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func values(v interface{}) []interface{} {
+	switch vv := v.(type) {
+	case []string:
+		var all []interface{}
+		for _, s := range vv {
+			all = append(all, s)
+		}
+		return all
+	case []interface{}:
+		return vv
+	default:
+		return []interface{}{v}
+	}
+}
+
+func compareValue(v interface{}, expected string) int {
+	switch vv := v.(type) {
+	case time.Time:
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, expected); err == nil {
+				switch {
+				case vv.Before(t):
+					return -1
+				case vv.After(t):
+					return 1
+				default:
+					return 0
+				}
+			}
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		if f, err := strconv.ParseFloat(expected, 64); err == nil {
+			actual, _ := strconv.ParseFloat(fmt.Sprint(vv), 64)
+			switch {
+			case actual < f:
+				return -1
+			case actual > f:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+	return strings.Compare(strings.ToLower(fmt.Sprint(v)), strings.ToLower(expected))
+}
@@ -40,6 +40,25 @@ func And(matchers ...cloud.Matcher) cloud.Matcher {
 	return and{matchers: matchers}
 }
 
+func (m and) Matchers() []cloud.Matcher {
+	return m.matchers
+}
+
+func (m and) IndexedProperty() (string, string, bool) {
+	for _, match := range m.matchers {
+		if im, ok := match.(cloud.IndexedMatcher); ok {
+			if name, value, ok := im.IndexedProperty(); ok {
+				return name, value, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func (m and) InGraph(g cloud.GraphAPI) cloud.Matcher {
+	return and{matchers: inGraph(g, m.matchers)}
+}
+
 type or struct {
 	matchers []cloud.Matcher
 }
@@ -57,6 +76,61 @@ func Or(matchers ...cloud.Matcher) cloud.Matcher {
 	return or{matchers: matchers}
 }
 
+func (m or) InGraph(g cloud.GraphAPI) cloud.Matcher {
+	return or{matchers: inGraph(g, m.matchers)}
+}
+
+type not struct {
+	matcher cloud.Matcher
+}
+
+func (m not) Match(r cloud.Resource) bool {
+	return !m.matcher.Match(r)
+}
+
+func Not(matcher cloud.Matcher) cloud.Matcher {
+	return not{matcher: matcher}
+}
+
+func (m not) InGraph(g cloud.GraphAPI) cloud.Matcher {
+	return not{matcher: inGraph(g, []cloud.Matcher{m.matcher})[0]}
+}
+
+func inGraph(g cloud.GraphAPI, matchers []cloud.Matcher) []cloud.Matcher {
+	bound := make([]cloud.Matcher, len(matchers))
+	for i, m := range matchers {
+		if gm, ok := m.(cloud.GraphMatcher); ok {
+			bound[i] = gm.InGraph(g)
+		} else {
+			bound[i] = m
+		}
+	}
+	return bound
+}
+
+// IsRelational returns true when the matcher relies on the relations of the resources
+func IsRelational(m cloud.Matcher) bool {
+	switch mm := m.(type) {
+	case related:
+		return true
+	case and:
+		for _, child := range mm.matchers {
+			if IsRelational(child) {
+				return true
+			}
+		}
+	case or:
+		for _, child := range mm.matchers {
+			if IsRelational(child) {
+				return true
+			}
+		}
+	case not:
+		return IsRelational(mm.matcher)
+	}
+	return false
+}
+
 type propertyMatcher struct {
 	name          string
 	value         interface{}
@@ -93,6 +167,10 @@ func (m propertyMatcher) Match(r cloud.Resource) bool {
 	return reflect.DeepEqual(v, expectVal)
 }
 
+func (m propertyMatcher) IndexedProperty() (string, string, bool) {
+	return m.name, fmt.Sprint(m.value), !m.contains
+}
+
 func Property(name string, val interface{}) propertyMatcher {
 	return propertyMatcher{name: name, value: val}
 }
@@ -133,6 +211,10 @@ func Tag(key, val string) tagMatcher {
 	return tagMatcher{key: key, value: val}
 }
 
+func (m tagMatcher) Tag() (string, string) {
+	return m.key, m.value
+}
+
 type tagKeyMatcher struct {
 	key string
 }
@@ -157,6 +239,10 @@ func TagKey(key string) tagKeyMatcher {
 	return tagKeyMatcher{key: key}
 }
 
+func (m tagKeyMatcher) TagKey() string {
+	return m.key
+}
+
 type tagValueMatcher struct {
 	value string
 }
@@ -180,3 +266,29 @@ func (m tagValueMatcher) Match(r cloud.Resource) bool {
 func TagValue(value string) tagValueMatcher {
 	return tagValueMatcher{value: value}
 }
+
+func (m tagValueMatcher) TagValue() string {
+	return m.value
+}
+
+type annotationMatcher struct {
+	key, value string
+}
+
+func (m annotationMatcher) Match(r cloud.Resource) bool {
+	annotations, ok := r.Properties()["Annotations"].([]string)
+	if !ok {
+		return false
+	}
+	for _, a := range annotations {
+		if fmt.Sprintf("%s=%s", m.key, m.value) == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Annotation matches the resources annotated locally with the given key and value (see `awless annotate`)
+func Annotation(key, val string) annotationMatcher {
+	return annotationMatcher{key: key, value: val}
+}
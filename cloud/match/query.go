@@ -0,0 +1,306 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package match
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/wallix/awless/cloud"
+)
+
+const (
+	regexOp    = "~"
+	notRegexOp = "!~"
+)
+
+// Parse returns the matcher of a query such as:
+//
+//	state=running AND (type~^t2 OR tag:Env=prod) AND NOT vpc.name=default
+//
+// Conditions compare a property (case insensitive name), a tag (tag:Key), a local annotation
+// (annotation:Key) or the property of a related resource (<type>.<property>, ex: vpc.id=vpc-1234) to a value with one of the operators
+// =, !=, <, <=, >, >=, ~ (regex) and !~. Values with spaces, parentheses or operators are quoted.
+// Firewall rules are matched with inbound:<port>=<cidr> (resp. outbound:) when a rule opens the port (or * for any)
+// to the whole network (ex: inbound:22=0.0.0.0/0).
+// Conditions combine with AND (or juxtaposition), OR and NOT, and are grouped with parentheses.
+func Parse(query string) (cloud.Matcher, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("query: empty")
+	}
+	p := &parser{tokens: tokens}
+	m, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("query: unexpected '%s' at position %d", p.peek().text, p.peek().pos)
+	}
+	return m, nil
+}
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	quotedToken
+	operatorToken
+	openToken
+	closeToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == wordToken && strings.EqualFold(t.text, keyword)
+}
+
+var operators = []string{NotEqual, notRegexOp, LessEqual, GreaterEqual, Equal, Less, Greater, regexOp}
+
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: openToken, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: closeToken, text: ")", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			start := i
+			var value []rune
+			for i++; i < len(runes) && runes[i] != c; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == c {
+					i++
+				}
+				value = append(value, runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("query: unterminated quoted value at position %d", start)
+			}
+			i++
+			tokens = append(tokens, token{kind: quotedToken, text: string(value), pos: start})
+		case isOperatorChar(c):
+			op := ""
+			for _, o := range operators {
+				if strings.HasPrefix(string(runes[i:]), o) {
+					op = o
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("query: invalid operator at position %d", i)
+			}
+			tokens = append(tokens, token{kind: operatorToken, text: op, pos: i})
+			i += len(op)
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !isOperatorChar(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				i++
+			}
+			tokens = append(tokens, token{kind: wordToken, text: string(runes[start:i]), pos: start})
+		}
+	}
+	return tokens, nil
+}
+
+func isOperatorChar(c rune) bool {
+	return c == '=' || c == '!' || c == '<' || c == '>' || c == '~'
+}
+
+type parser struct {
+	tokens []token
+	index  int
+}
+
+func (p *parser) done() bool {
+	return p.index >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, fmt.Errorf("query: unexpected end")
+	}
+	t := p.tokens[p.index]
+	p.index++
+	return t, nil
+}
+
+func (p *parser) parseOr() (cloud.Matcher, error) {
+	m, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	matchers := []cloud.Matcher{m}
+	for !p.done() && p.peek().isKeyword("OR") {
+		p.index++
+		if m, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	if len(matchers) == 1 {
+		return matchers[0], nil
+	}
+	return Or(matchers...), nil
+}
+
+func (p *parser) parseAnd() (cloud.Matcher, error) {
+	m, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	matchers := []cloud.Matcher{m}
+	for !p.done() && p.peek().kind != closeToken && !p.peek().isKeyword("OR") {
+		if p.peek().isKeyword("AND") {
+			p.index++
+		}
+		if m, err = p.parseUnary(); err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	if len(matchers) == 1 {
+		return matchers[0], nil
+	}
+	return And(matchers...), nil
+}
+
+func (p *parser) parseUnary() (cloud.Matcher, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case t.isKeyword("NOT"):
+		m, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return Not(m), nil
+	case t.kind == openToken:
+		m, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, err := p.next(); err != nil || closing.kind != closeToken {
+			return nil, fmt.Errorf("query: missing closing parenthesis for the one at position %d", t.pos)
+		}
+		return m, nil
+	case t.kind == wordToken:
+		op, err := p.next()
+		if err != nil || op.kind != operatorToken {
+			return nil, fmt.Errorf("query: expected operator after '%s' at position %d", t.text, t.pos)
+		}
+		value, err := p.next()
+		if err != nil || (value.kind != wordToken && value.kind != quotedToken) {
+			return nil, fmt.Errorf("query: expected value after '%s%s' at position %d", t.text, op.text, op.pos)
+		}
+		return condition(t.text, op.text, value.text)
+	default:
+		return nil, fmt.Errorf("query: unexpected '%s' at position %d", t.text, t.pos)
+	}
+}
+
+func condition(key, op, value string) (cloud.Matcher, error) {
+	lower := strings.ToLower(key)
+	switch {
+	case strings.HasPrefix(lower, "tag:"):
+		if op == Equal {
+			return Tag(key[4:], value), nil
+		}
+	case strings.HasPrefix(lower, "annotation:"):
+		if op == Equal {
+			return Annotation(key[11:], value), nil
+		}
+	case strings.HasPrefix(lower, "inbound:"), strings.HasPrefix(lower, "outbound:"):
+		return openToCondition(key, op, value)
+	default:
+		if splits := strings.SplitN(key, ".", 2); len(splits) == 2 {
+			m, err := condition(splits[1], op, value)
+			if err != nil {
+				return nil, err
+			}
+			return Related(strings.ToLower(splits[0]), m), nil
+		}
+	}
+
+	switch op {
+	case regexOp, notRegexOp:
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("query: invalid regex for %s: %s", key, err)
+		}
+		if op == notRegexOp {
+			return Not(Regex(key, re)), nil
+		}
+		return Regex(key, re), nil
+	default:
+		return Compare(key, op, value), nil
+	}
+}
+
+func openToCondition(key, op, value string) (cloud.Matcher, error) {
+	if op != Equal {
+		return nil, fmt.Errorf("query: expected %s<port>=<cidr>, got operator %s", key[:strings.Index(key, ":")+1], op)
+	}
+	splits := strings.SplitN(key, ":", 2)
+	name := "InboundRules"
+	if strings.EqualFold(splits[0], "outbound") {
+		name = "OutboundRules"
+	}
+	port := int64(-1)
+	if splits[1] != "*" {
+		p, err := strconv.ParseInt(splits[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("query: invalid port '%s' in %s: expected a number or *", splits[1], key)
+		}
+		port = p
+	}
+	if !strings.Contains(value, "/") {
+		if ip := net.ParseIP(value); ip != nil && ip.To4() != nil {
+			value += "/32"
+		} else {
+			value += "/128"
+		}
+	}
+	_, network, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("query: invalid network in %s=%s: %s", key, value, err)
+	}
+	return OpenTo(name, port, network), nil
+}
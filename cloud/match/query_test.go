@@ -0,0 +1,177 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package match
+
+import (
+	"net"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestParseQuery(t *testing.T) {
+	launched := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	inst := resourcetest.Instance("inst_1").Prop("Name", "redis").Prop("State", "running").Prop("Type", "t2.micro").
+		Prop("Launched", launched).Prop("Cores", 2).Prop("Tags", []string{"Env=prod", "Dept=IT"}).
+		Prop("Annotations", []string{"owner=team.a", "decommission-by=2024-06"}).Build()
+
+	tcases := []struct {
+		query  string
+		expect bool
+	}{
+		{query: "state=running", expect: true},
+		{query: "State=RUNNING", expect: true},
+		{query: "state!=running", expect: false},
+		{query: "state=stopped", expect: false},
+		{query: "inexisting=value", expect: false},
+		{query: "inexisting!=value", expect: true},
+		{query: "type~^t2\\.", expect: true},
+		{query: "type!~^t2", expect: false},
+		{query: "name~'^re(d|x)is$'", expect: true},
+		{query: "cores>1", expect: true},
+		{query: "cores>=2 AND cores<=2", expect: true},
+		{query: "cores<2", expect: false},
+		{query: "launched>2017-01-01", expect: true},
+		{query: "launched<2017-05-31T23:59:59Z", expect: false},
+		{query: "tag:Env=prod", expect: true},
+		{query: "tag:Env!=prod", expect: false},
+		{query: "tag:Dept~^I", expect: true},
+		{query: "tag:Unknown=prod", expect: false},
+		{query: "annotation:owner=team.a", expect: true},
+		{query: "annotation:owner=team.b", expect: false},
+		{query: "annotation:decommission-by<2024-07", expect: true},
+		{query: "annotation:Env=prod", expect: false},
+		{query: `tags="Dept=IT"`, expect: true},
+		{query: "state=running AND type=t2.nano", expect: false},
+		{query: "state=running type=t2.micro", expect: true},
+		{query: "state=stopped OR type=t2.micro", expect: true},
+		{query: "state=stopped OR type=t2.nano AND name=redis", expect: false},
+		{query: "(state=stopped OR type=t2.micro) AND name=redis", expect: true},
+		{query: "NOT state=stopped", expect: true},
+		{query: "not (state=running and name=redis)", expect: false},
+		{query: `name="redis"`, expect: true},
+	}
+	for i, tcase := range tcases {
+		m, err := Parse(tcase.query)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := m.Match(inst), tcase.expect; got != want {
+			t.Fatalf("%d: %s: got %t, want %t", i+1, tcase.query, got, want)
+		}
+	}
+}
+
+func TestParseInvalidQuery(t *testing.T) {
+	for _, query := range []string{"", "state", "state=", "=running", "state=running AND", "(state=running", "state=running)", "name~'(unclosed'", "name='unterminated", "state=!running"} {
+		if _, err := Parse(query); err == nil {
+			t.Fatalf("%s: expected error", query)
+		}
+	}
+}
+
+func TestQueryOnFirewallRules(t *testing.T) {
+	_, anywhere, _ := net.ParseCIDR("0.0.0.0/0")
+	_, private, _ := net.ParseCIDR("10.0.0.0/8")
+	sg := resourcetest.SecurityGroup("sg_1").Prop("InboundRules", []*graph.FirewallRule{
+		{PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", IPRanges: []*net.IPNet{private}},
+		{PortRange: graph.PortRange{FromPort: 80, ToPort: 443}, Protocol: "tcp", IPRanges: []*net.IPNet{anywhere}},
+	}).Build()
+
+	tcases := []struct {
+		query  string
+		expect bool
+	}{
+		{query: "inbound:22=0.0.0.0/0", expect: false},
+		{query: "inbound:22=10.0.0.0/16", expect: true},
+		{query: "inbound:22=10.1.2.3", expect: true},
+		{query: "inbound:22=192.168.0.0/16", expect: false},
+		{query: "inbound:443=0.0.0.0/0", expect: true},
+		{query: "inbound:8080=0.0.0.0/0", expect: false},
+		{query: "inbound:*=0.0.0.0/0", expect: true},
+		{query: "outbound:*=0.0.0.0/0", expect: false},
+		{query: "NOT inbound:22=0.0.0.0/0", expect: true},
+	}
+	for i, tcase := range tcases {
+		m, err := Parse(tcase.query)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := m.Match(sg), tcase.expect; got != want {
+			t.Fatalf("%d: %s: got %t, want %t", i+1, tcase.query, got, want)
+		}
+	}
+
+	for _, query := range []string{"inbound:22!=0.0.0.0/0", "inbound:ssh=0.0.0.0/0", "inbound:22=anywhere"} {
+		if _, err := Parse(query); err == nil {
+			t.Fatalf("%s: expected error", query)
+		}
+	}
+}
+
+func TestQueryOnRelatedResources(t *testing.T) {
+	g := graph.NewGraph()
+	vpc1, vpc2 := resourcetest.VPC("vpc_1").Prop("Name", "prod").Build(), resourcetest.VPC("vpc_2").Prop("Name", "dev").Build()
+	sub1, sub2 := resourcetest.Subnet("sub_1").Build(), resourcetest.Subnet("sub_2").Build()
+	inst1, inst2 := resourcetest.Instance("inst_1").Build(), resourcetest.Instance("inst_2").Build()
+	sg := resourcetest.SecurityGroup("sg_1").Prop("Name", "web").Build()
+	g.AddResource(vpc1, vpc2, sub1, sub2, inst1, inst2, sg)
+	g.AddParentRelation(vpc1, sub1)
+	g.AddParentRelation(vpc2, sub2)
+	g.AddParentRelation(sub1, inst1)
+	g.AddParentRelation(sub2, inst2)
+	g.AddAppliesOnRelation(sg, inst2)
+
+	tcases := []struct {
+		resourceType string
+		query        string
+		expect       []string
+	}{
+		{resourceType: "instance", query: "vpc.name=prod", expect: []string{"inst_1"}},
+		{resourceType: "instance", query: "vpc.id=vpc_2 AND subnet.id=sub_2", expect: []string{"inst_2"}},
+		{resourceType: "instance", query: "NOT vpc.name=prod", expect: []string{"inst_2"}},
+		{resourceType: "instance", query: "securitygroup.name=web", expect: []string{"inst_2"}},
+		{resourceType: "vpc", query: "instance.id=inst_1", expect: []string{"vpc_1"}},
+		{resourceType: "subnet", query: "vpc.name~^d OR instance.id=inst_1", expect: []string{"sub_1", "sub_2"}},
+		{resourceType: "instance", query: "vpc.name=staging"},
+	}
+	for i, tcase := range tcases {
+		m, err := Parse(tcase.query)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if !IsRelational(m) {
+			t.Fatalf("%d: expected relational matcher", i+1)
+		}
+		resources, err := g.Find(cloud.NewQuery(tcase.resourceType).Match(m))
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		var ids []string
+		for _, r := range resources {
+			ids = append(ids, r.Id())
+		}
+		sort.Strings(ids)
+		if got, want := ids, tcase.expect; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: %s: got %v, want %v", i+1, tcase.query, got, want)
+		}
+	}
+}
@@ -32,6 +32,7 @@ const (
 	AlarmNames                        = "AlarmNames"
 	Alias                             = "Alias"
 	Aliases                           = "Aliases"
+	Annotations                       = "Annotations"
 	ApproximateMessageCount           = "ApproximateMessageCount"
 	Architecture                      = "Architecture"
 	Arn                               = "Arn"
@@ -48,6 +49,7 @@ const (
 	BackupRetentionPeriod             = "BackupRetentionPeriod"
 	Bucket                            = "Bucket"
 	CallerReference                   = "CallerReference"
+	CanonicalUser                     = "CanonicalUser"
 	Capabilities                      = "Capabilities"
 	Certificate                       = "Certificate"
 	CertificateAuthority              = "CertificateAuthority"
@@ -80,10 +82,12 @@ const (
 	Default                           = "Default"
 	DefaultCooldown                   = "DefaultCooldown"
 	Delay                             = "Delay"
+	Deployment                        = "Deployment"
 	DeploymentName                    = "DeploymentName"
 	Deployments                       = "Deployments"
 	Description                       = "Description"
 	DesiredCapacity                   = "DesiredCapacity"
+	DesiredCount                      = "DesiredCount"
 	Dimensions                        = "Dimensions"
 	DisableRollback                   = "DisableRollback"
 	DockerVersion                     = "DockerVersion"
@@ -97,10 +101,12 @@ const (
 	Failover                          = "Failover"
 	Fingerprint                       = "Fingerprint"
 	GlobalID                          = "GlobalID"
+	GranteePrincipal                  = "GranteePrincipal"
 	GranteeType                       = "GranteeType"
 	Grants                            = "Grants"
 	Handler                           = "Handler"
 	Hash                              = "Hash"
+	HashKey                           = "HashKey"
 	HealthCheck                       = "HealthCheck"
 	HealthCheckGracePeriod            = "HealthCheckGracePeriod"
 	HealthCheckType                   = "HealthCheckType"
@@ -120,21 +126,26 @@ const (
 	IPType                            = "IPType"
 	IPv6Addresses                     = "IPv6Addresses"
 	IPv6Enabled                       = "IPv6Enabled"
+	ItemCount                         = "ItemCount"
 	Key                               = "Key"
 	KeyName                           = "KeyName"
 	KeyPair                           = "KeyPair"
 	LatestRestorableTime              = "LatestRestorableTime"
+	LastUsed                          = "LastUsed"
 	LaunchConfigurationName           = "LaunchConfigurationName"
 	Launched                          = "Launched"
+	LaunchType                        = "LaunchType"
 	License                           = "License"
 	Lifecycle                         = "Lifecycle"
 	LoadBalancer                      = "LoadBalancer"
 	Location                          = "Location"
 	MACAddress                        = "MACAddress"
+	MFAActive                         = "MFAActive"
 	Main                              = "Main"
 	MaxSize                           = "MaxSize"
 	Memory                            = "Memory"
 	Messages                          = "Messages"
+	MetadataTokens                    = "MetadataTokens"
 	MetricName                        = "MetricName"
 	MinSize                           = "MinSize"
 	Modified                          = "Modified"
@@ -147,6 +158,7 @@ const (
 	NewInstancesProtected             = "NewInstancesProtected"
 	Notifications                     = "Notifications"
 	OKActions                         = "OKActions"
+	Operations                        = "Operations"
 	OptionGroups                      = "OptionGroups"
 	Origins                           = "Origins"
 	OutboundRules                     = "OutboundRules"
@@ -159,6 +171,7 @@ const (
 	PathPrefix                        = "PathPrefix"
 	PendingTasksCount                 = "PendingTasksCount"
 	PlacementGroup                    = "PlacementGroup"
+	Platform                          = "Platform"
 	Port                              = "Port"
 	PortRange                         = "PortRange"
 	PreferredBackupDate               = "PreferredBackupDate"
@@ -169,10 +182,13 @@ const (
 	PrivateIP                         = "PrivateIP"
 	Profile                           = "Profile"
 	Progress                          = "Progress"
+	Projection                        = "Projection"
 	Protocol                          = "Protocol"
 	Public                            = "Public"
 	PublicDNS                         = "PublicDNS"
 	PublicIP                          = "PublicIP"
+	RangeKey                          = "RangeKey"
+	ReadCapacity                      = "ReadCapacity"
 	RecordCount                       = "RecordCount"
 	Records                           = "Records"
 	Region                            = "Region"
@@ -225,5 +241,6 @@ const (
 	Vpcs                              = "Vpcs"
 	WebACL                            = "WebACL"
 	Weight                            = "Weight"
+	WriteCapacity                     = "WriteCapacity"
 	Zone                              = "Zone"
 )
@@ -25,6 +25,21 @@ type Matcher interface {
 	Match(r Resource) bool
 }
 
+// GraphMatcher is a Matcher relying on the relations of the resources (ex: parent VPC of an instance).
+// InGraph returns the matcher resolving the relations in the given graph.
+type GraphMatcher interface {
+	Matcher
+	InGraph(GraphAPI) Matcher
+}
+
+// IndexedMatcher is a Matcher only matching resources whose property has the given value, when ok.
+// Graphs resolve it with their property index, looking up the value as a case insensitive string,
+// before matching the found resources.
+type IndexedMatcher interface {
+	Matcher
+	IndexedProperty() (name, value string, ok bool)
+}
+
 func NewQuery(resourceType ...string) Query {
 	return Query{ResourceType: resourceType}
 }
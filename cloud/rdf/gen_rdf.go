@@ -34,6 +34,7 @@ const (
 	AlarmNames                        = "cloud:alarmNames"
 	Alias                             = "cloud:alias"
 	Aliases                           = "cloud:aliases"
+	Annotations                       = "cloud:annotations"
 	ApproximateMessageCount           = "cloud:approximateMessageCount"
 	Architecture                      = "cloud:architecture"
 	Arn                               = "cloud:arn"
@@ -50,6 +51,7 @@ const (
 	BackupRetentionPeriod             = "cloud:backupRetentionPeriod"
 	Bucket                            = "cloud:bucketName"
 	CallerReference                   = "cloud:callerReference"
+	CanonicalUser                     = "cloud:canonicalUser"
 	Capabilities                      = "cloud:capabilities"
 	Certificate                       = "cloud:certificate"
 	CertificateAuthority              = "cloud:certificateAuthority"
@@ -82,10 +84,12 @@ const (
 	Default                           = "cloud:default"
 	DefaultCooldown                   = "cloud:defaultCooldown"
 	Delay                             = "cloud:delaySeconds"
+	Deployment                        = "cloud:deployment"
 	DeploymentName                    = "cloud:deploymentName"
 	Deployments                       = "cloud:deployments"
 	Description                       = "cloud:description"
 	DesiredCapacity                   = "cloud:desiredCapacity"
+	DesiredCount                      = "cloud:desiredCount"
 	Dimensions                        = "cloud:dimensions"
 	DisableRollback                   = "cloud:disableRollback"
 	DockerVersion                     = "cloud:dockerVersion"
@@ -99,10 +103,12 @@ const (
 	Failover                          = "cloud:failover"
 	Fingerprint                       = "cloud:fingerprint"
 	GlobalID                          = "cloud:globalID"
+	GranteePrincipal                  = "cloud:granteePrincipal"
 	GranteeType                       = "cloud:granteeType"
 	Grants                            = "cloud:grants"
 	Handler                           = "cloud:handler"
 	Hash                              = "cloud:hash"
+	HashKey                           = "cloud:hashKey"
 	HealthCheck                       = "cloud:healthCheck"
 	HealthCheckGracePeriod            = "cloud:healthCheckGracePeriod"
 	HealthCheckType                   = "cloud:healthCheckType"
@@ -122,21 +128,26 @@ const (
 	IPType                            = "net:ipType"
 	IPv6Addresses                     = "cloud:ipv6Addresses"
 	IPv6Enabled                       = "cloud:ipv6Enabled"
+	ItemCount                         = "cloud:itemCount"
 	Key                               = "cloud:key"
 	KeyName                           = "cloud:keyName"
 	KeyPair                           = "cloud:keyPair"
 	LatestRestorableTime              = "cloud:latestRestorableTime"
+	LastUsed                          = "cloud:lastUsed"
 	LaunchConfigurationName           = "cloud:launchConfigurationName"
 	Launched                          = "cloud:launched"
+	LaunchType                        = "cloud:launchType"
 	License                           = "cloud:license"
 	Lifecycle                         = "cloud:lifecycle"
 	LoadBalancer                      = "cloud:loadBalancer"
 	Location                          = "cloud:location"
 	MACAddress                        = "cloud:macAddress"
+	MFAActive                         = "cloud:mfaActive"
 	Main                              = "cloud:main"
 	MaxSize                           = "cloud:maxSize"
 	Memory                            = "cloud:memory"
 	Messages                          = "cloud:messages"
+	MetadataTokens                    = "cloud:metadataTokens"
 	MetricName                        = "cloud:metricName"
 	MinSize                           = "cloud:minSize"
 	Modified                          = "cloud:modified"
@@ -149,6 +160,7 @@ const (
 	NewInstancesProtected             = "cloud:newInstancesProtected"
 	Notifications                     = "cloud:notifications"
 	OKActions                         = "cloud:okActions"
+	Operations                        = "cloud:operations"
 	OptionGroups                      = "cloud:optionGroups"
 	Origins                           = "cloud:origins"
 	OutboundRules                     = "net:outboundRules"
@@ -161,6 +173,7 @@ const (
 	PathPrefix                        = "cloud:pathPrefix"
 	PendingTasksCount                 = "cloud:pendingTasksCount"
 	PlacementGroup                    = "cloud:placementGroup"
+	Platform                          = "cloud:platform"
 	Port                              = "net:port"
 	PortRange                         = "net:portRange"
 	PreferredBackupDate               = "cloud:preferredBackupDate"
@@ -171,10 +184,13 @@ const (
 	PrivateIP                         = "net:privateIP"
 	Profile                           = "cloud:profile"
 	Progress                          = "cloud:progress"
+	Projection                        = "cloud:projection"
 	Protocol                          = "net:protocol"
 	Public                            = "cloud:public"
 	PublicDNS                         = "cloud:publicDNS"
 	PublicIP                          = "net:publicIP"
+	RangeKey                          = "cloud:rangeKey"
+	ReadCapacity                      = "cloud:readCapacity"
 	RecordCount                       = "cloud:records"
 	Records                           = "cloud:recordCount"
 	Region                            = "cloud:region"
@@ -227,6 +243,7 @@ const (
 	Vpcs                              = "cloud:vpcs"
 	WebACL                            = "cloud:webACL"
 	Weight                            = "cloud:weight"
+	WriteCapacity                     = "cloud:writeCapacity"
 	Zone                              = "cloud:zone"
 )
 
@@ -245,6 +262,7 @@ var Labels = map[string]string{
 	properties.AlarmNames:                        AlarmNames,
 	properties.Alias:                             Alias,
 	properties.Aliases:                           Aliases,
+	properties.Annotations:                       Annotations,
 	properties.ApproximateMessageCount:           ApproximateMessageCount,
 	properties.Architecture:                      Architecture,
 	properties.Arn:                               Arn,
@@ -261,6 +279,7 @@ var Labels = map[string]string{
 	properties.BackupRetentionPeriod:             BackupRetentionPeriod,
 	properties.Bucket:                            Bucket,
 	properties.CallerReference:                   CallerReference,
+	properties.CanonicalUser:                     CanonicalUser,
 	properties.Capabilities:                      Capabilities,
 	properties.Certificate:                       Certificate,
 	properties.CertificateAuthority:              CertificateAuthority,
@@ -293,10 +312,12 @@ var Labels = map[string]string{
 	properties.Default:                           Default,
 	properties.DefaultCooldown:                   DefaultCooldown,
 	properties.Delay:                             Delay,
+	properties.Deployment:                        Deployment,
 	properties.DeploymentName:                    DeploymentName,
 	properties.Deployments:                       Deployments,
 	properties.Description:                       Description,
 	properties.DesiredCapacity:                   DesiredCapacity,
+	properties.DesiredCount:                      DesiredCount,
 	properties.Dimensions:                        Dimensions,
 	properties.DisableRollback:                   DisableRollback,
 	properties.DockerVersion:                     DockerVersion,
@@ -310,10 +331,12 @@ var Labels = map[string]string{
 	properties.Failover:                          Failover,
 	properties.Fingerprint:                       Fingerprint,
 	properties.GlobalID:                          GlobalID,
+	properties.GranteePrincipal:                  GranteePrincipal,
 	properties.GranteeType:                       GranteeType,
 	properties.Grants:                            Grants,
 	properties.Handler:                           Handler,
 	properties.Hash:                              Hash,
+	properties.HashKey:                           HashKey,
 	properties.HealthCheck:                       HealthCheck,
 	properties.HealthCheckGracePeriod:            HealthCheckGracePeriod,
 	properties.HealthCheckType:                   HealthCheckType,
@@ -333,21 +356,26 @@ var Labels = map[string]string{
 	properties.IPType:                            IPType,
 	properties.IPv6Addresses:                     IPv6Addresses,
 	properties.IPv6Enabled:                       IPv6Enabled,
+	properties.ItemCount:                         ItemCount,
 	properties.Key:                               Key,
 	properties.KeyName:                           KeyName,
 	properties.KeyPair:                           KeyPair,
 	properties.LatestRestorableTime:              LatestRestorableTime,
+	properties.LastUsed:                          LastUsed,
 	properties.LaunchConfigurationName:           LaunchConfigurationName,
 	properties.Launched:                          Launched,
+	properties.LaunchType:                        LaunchType,
 	properties.License:                           License,
 	properties.Lifecycle:                         Lifecycle,
 	properties.LoadBalancer:                      LoadBalancer,
 	properties.Location:                          Location,
 	properties.MACAddress:                        MACAddress,
+	properties.MFAActive:                         MFAActive,
 	properties.Main:                              Main,
 	properties.MaxSize:                           MaxSize,
 	properties.Memory:                            Memory,
 	properties.Messages:                          Messages,
+	properties.MetadataTokens:                    MetadataTokens,
 	properties.MetricName:                        MetricName,
 	properties.MinSize:                           MinSize,
 	properties.Modified:                          Modified,
@@ -360,6 +388,7 @@ var Labels = map[string]string{
 	properties.NewInstancesProtected:             NewInstancesProtected,
 	properties.Notifications:                     Notifications,
 	properties.OKActions:                         OKActions,
+	properties.Operations:                        Operations,
 	properties.OptionGroups:                      OptionGroups,
 	properties.Origins:                           Origins,
 	properties.OutboundRules:                     OutboundRules,
@@ -372,6 +401,7 @@ var Labels = map[string]string{
 	properties.PathPrefix:                        PathPrefix,
 	properties.PendingTasksCount:                 PendingTasksCount,
 	properties.PlacementGroup:                    PlacementGroup,
+	properties.Platform:                          Platform,
 	properties.Port:                              Port,
 	properties.PortRange:                         PortRange,
 	properties.PreferredBackupDate:               PreferredBackupDate,
@@ -382,10 +412,13 @@ var Labels = map[string]string{
 	properties.PrivateIP:                         PrivateIP,
 	properties.Profile:                           Profile,
 	properties.Progress:                          Progress,
+	properties.Projection:                        Projection,
 	properties.Protocol:                          Protocol,
 	properties.Public:                            Public,
 	properties.PublicDNS:                         PublicDNS,
 	properties.PublicIP:                          PublicIP,
+	properties.RangeKey:                          RangeKey,
+	properties.ReadCapacity:                      ReadCapacity,
 	properties.RecordCount:                       RecordCount,
 	properties.Records:                           Records,
 	properties.Region:                            Region,
@@ -438,6 +471,7 @@ var Labels = map[string]string{
 	properties.Vpcs:                              Vpcs,
 	properties.WebACL:                            WebACL,
 	properties.Weight:                            Weight,
+	properties.WriteCapacity:                     WriteCapacity,
 	properties.Zone:                              Zone,
 }
 
@@ -456,6 +490,7 @@ var Properties = RDFProperties{
 	AlarmNames:              {ID: AlarmNames, RdfType: "rdf:Property", RdfsLabel: "AlarmNames", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Alias:                   {ID: Alias, RdfType: "rdf:Property", RdfsLabel: "Alias", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Aliases:                 {ID: Aliases, RdfType: "rdf:Property", RdfsLabel: "Aliases", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
+	Annotations:             {ID: Annotations, RdfType: "rdf:Property", RdfsLabel: "Annotations", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	ApproximateMessageCount: {ID: ApproximateMessageCount, RdfType: "rdf:Property", RdfsLabel: "ApproximateMessageCount", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Architecture:            {ID: Architecture, RdfType: "rdf:Property", RdfsLabel: "Architecture", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Arn:                     {ID: Arn, RdfType: "rdf:Property", RdfsLabel: "Arn", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -472,6 +507,7 @@ var Properties = RDFProperties{
 	BackupRetentionPeriod:   {ID: BackupRetentionPeriod, RdfType: "rdf:Property", RdfsLabel: "BackupRetentionPeriod", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:dateTime"},
 	Bucket:                  {ID: Bucket, RdfType: "rdf:Property", RdfsLabel: "Bucket", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	CallerReference:         {ID: CallerReference, RdfType: "rdf:Property", RdfsLabel: "CallerReference", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	CanonicalUser:           {ID: CanonicalUser, RdfType: "rdf:Property", RdfsLabel: "CanonicalUser", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Capabilities:            {ID: Capabilities, RdfType: "rdf:Property", RdfsLabel: "Capabilities", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Certificate:             {ID: Certificate, RdfType: "rdf:Property", RdfsLabel: "Certificate", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	CertificateAuthority:    {ID: CertificateAuthority, RdfType: "rdf:Property", RdfsLabel: "CertificateAuthority", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -504,10 +540,12 @@ var Properties = RDFProperties{
 	Default:                 {ID: Default, RdfType: "rdf:Property", RdfsLabel: "Default", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	DefaultCooldown:         {ID: DefaultCooldown, RdfType: "rdf:Property", RdfsLabel: "DefaultCooldown", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Delay:                   {ID: Delay, RdfType: "rdf:Property", RdfsLabel: "Delay", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	Deployment:              {ID: Deployment, RdfType: "rdf:Property", RdfsLabel: "Deployment", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	DeploymentName:          {ID: DeploymentName, RdfType: "rdf:Property", RdfsLabel: "DeploymentName", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Deployments:             {ID: Deployments, RdfType: "rdf:Property", RdfsLabel: "Deployments", RdfsDefinedBy: "rdfs:list", RdfsDataType: "cloud-owl:KeyValue"},
 	Description:             {ID: Description, RdfType: "rdf:Property", RdfsLabel: "Description", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	DesiredCapacity:         {ID: DesiredCapacity, RdfType: "rdf:Property", RdfsLabel: "DesiredCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	DesiredCount:            {ID: DesiredCount, RdfType: "rdf:Property", RdfsLabel: "DesiredCount", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Dimensions:              {ID: Dimensions, RdfType: "rdf:Property", RdfsLabel: "Dimensions", RdfsDefinedBy: "rdfs:list", RdfsDataType: "cloud-owl:KeyValue"},
 	DisableRollback:         {ID: DisableRollback, RdfType: "rdf:Property", RdfsLabel: "DisableRollback", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	DockerVersion:           {ID: DockerVersion, RdfType: "rdf:Property", RdfsLabel: "DockerVersion", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -521,10 +559,12 @@ var Properties = RDFProperties{
 	Failover:                {ID: Failover, RdfType: "rdf:Property", RdfsLabel: "Failover", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Fingerprint:             {ID: Fingerprint, RdfType: "rdf:Property", RdfsLabel: "Fingerprint", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	GlobalID:                {ID: GlobalID, RdfType: "rdf:Property", RdfsLabel: "GlobalID", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	GranteePrincipal:        {ID: GranteePrincipal, RdfType: "rdf:Property", RdfsLabel: "GranteePrincipal", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	GranteeType:             {ID: GranteeType, RdfType: "rdf:Property", RdfsLabel: "GranteeType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Grants:                  {ID: Grants, RdfType: "rdf:Property", RdfsLabel: "Grants", RdfsDefinedBy: "rdfs:list", RdfsDataType: "cloud-owl:Grant"},
 	Handler:                 {ID: Handler, RdfType: "rdf:Property", RdfsLabel: "Handler", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Hash:                    {ID: Hash, RdfType: "rdf:Property", RdfsLabel: "Hash", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	HashKey:                 {ID: HashKey, RdfType: "rdf:Property", RdfsLabel: "HashKey", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	HealthCheck:             {ID: HealthCheck, RdfType: "rdf:Property", RdfsLabel: "HealthCheck", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	HealthCheckGracePeriod:  {ID: HealthCheckGracePeriod, RdfType: "rdf:Property", RdfsLabel: "HealthCheckGracePeriod", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	HealthCheckType:         {ID: HealthCheckType, RdfType: "rdf:Property", RdfsLabel: "HealthCheckType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -544,21 +584,26 @@ var Properties = RDFProperties{
 	IPType:                   {ID: IPType, RdfType: "rdf:Property", RdfsLabel: "IPType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	IPv6Addresses:            {ID: IPv6Addresses, RdfType: "rdf:Property", RdfsLabel: "IPv6Addresses", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	IPv6Enabled:              {ID: IPv6Enabled, RdfType: "rdf:Property", RdfsLabel: "IPv6Enabled", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
+	ItemCount:                {ID: ItemCount, RdfType: "rdf:Property", RdfsLabel: "ItemCount", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Key:                      {ID: Key, RdfType: "rdf:Property", RdfsLabel: "Key", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	KeyName:                  {ID: KeyName, RdfType: "rdf:Property", RdfsLabel: "KeyName", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	KeyPair:                  {ID: KeyPair, RdfType: "rdf:Property", RdfsLabel: "KeyPair", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	LatestRestorableTime:     {ID: LatestRestorableTime, RdfType: "rdf:Property", RdfsLabel: "LatestRestorableTime", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:dateTime"},
+	LastUsed:                 {ID: LastUsed, RdfType: "rdf:Property", RdfsLabel: "LastUsed", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:dateTime"},
 	LaunchConfigurationName:  {ID: LaunchConfigurationName, RdfType: "rdf:Property", RdfsLabel: "LaunchConfigurationName", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Launched:                 {ID: Launched, RdfType: "rdf:Property", RdfsLabel: "Launched", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:dateTime"},
+	LaunchType:               {ID: LaunchType, RdfType: "rdf:Property", RdfsLabel: "LaunchType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	License:                  {ID: License, RdfType: "rdf:Property", RdfsLabel: "License", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Lifecycle:                {ID: Lifecycle, RdfType: "rdf:Property", RdfsLabel: "Lifecycle", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	LoadBalancer:             {ID: LoadBalancer, RdfType: "rdf:Property", RdfsLabel: "LoadBalancer", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	Location:                 {ID: Location, RdfType: "rdf:Property", RdfsLabel: "Location", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	MACAddress:               {ID: MACAddress, RdfType: "rdf:Property", RdfsLabel: "MACAddress", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	MFAActive:                {ID: MFAActive, RdfType: "rdf:Property", RdfsLabel: "MFAActive", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	Main:                     {ID: Main, RdfType: "rdf:Property", RdfsLabel: "Main", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	MaxSize:                  {ID: MaxSize, RdfType: "rdf:Property", RdfsLabel: "MaxSize", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Memory:                   {ID: Memory, RdfType: "rdf:Property", RdfsLabel: "Memory", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Messages:                 {ID: Messages, RdfType: "rdf:Property", RdfsLabel: "Messages", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
+	MetadataTokens:           {ID: MetadataTokens, RdfType: "rdf:Property", RdfsLabel: "MetadataTokens", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	MetricName:               {ID: MetricName, RdfType: "rdf:Property", RdfsLabel: "MetricName", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	MinSize:                  {ID: MinSize, RdfType: "rdf:Property", RdfsLabel: "MinSize", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Modified:                 {ID: Modified, RdfType: "rdf:Property", RdfsLabel: "Modified", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:dateTime"},
@@ -571,6 +616,7 @@ var Properties = RDFProperties{
 	NewInstancesProtected:    {ID: NewInstancesProtected, RdfType: "rdf:Property", RdfsLabel: "NewInstancesProtected", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	Notifications:            {ID: Notifications, RdfType: "rdf:Property", RdfsLabel: "Notifications", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
 	OKActions:                {ID: OKActions, RdfType: "rdf:Property", RdfsLabel: "OKActions", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
+	Operations:               {ID: Operations, RdfType: "rdf:Property", RdfsLabel: "Operations", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	OptionGroups:             {ID: OptionGroups, RdfType: "rdf:Property", RdfsLabel: "OptionGroups", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Origins:                  {ID: Origins, RdfType: "rdf:Property", RdfsLabel: "Origins", RdfsDefinedBy: "rdfs:list", RdfsDataType: "cloud-owl:DistributionOrigin"},
 	OutboundRules:            {ID: OutboundRules, RdfType: "rdf:Property", RdfsLabel: "OutboundRules", RdfsDefinedBy: "rdfs:list", RdfsDataType: "net-owl:FirewallRule"},
@@ -583,6 +629,7 @@ var Properties = RDFProperties{
 	PathPrefix:               {ID: PathPrefix, RdfType: "rdf:Property", RdfsLabel: "PathPrefix", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	PendingTasksCount:        {ID: PendingTasksCount, RdfType: "rdf:Property", RdfsLabel: "PendingTasksCount", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	PlacementGroup:           {ID: PlacementGroup, RdfType: "rdf:Property", RdfsLabel: "PlacementGroup", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Platform:                 {ID: Platform, RdfType: "rdf:Property", RdfsLabel: "Platform", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Port:                     {ID: Port, RdfType: "rdf:Property", RdfsLabel: "Port", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	PortRange:                {ID: PortRange, RdfType: "rdfs:subPropertyOf", RdfsLabel: "PortRange", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	PreferredBackupDate:      {ID: PreferredBackupDate, RdfType: "rdf:Property", RdfsLabel: "PreferredBackupDate", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -593,10 +640,13 @@ var Properties = RDFProperties{
 	PrivateIP:                {ID: PrivateIP, RdfType: "rdf:Property", RdfsLabel: "PrivateIP", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Profile:                  {ID: Profile, RdfType: "rdf:Property", RdfsLabel: "Profile", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Progress:                 {ID: Progress, RdfType: "rdf:Property", RdfsLabel: "Progress", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Projection:               {ID: Projection, RdfType: "rdf:Property", RdfsLabel: "Projection", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Protocol:                 {ID: Protocol, RdfType: "rdf:Property", RdfsLabel: "Protocol", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Public:                   {ID: Public, RdfType: "rdf:Property", RdfsLabel: "Public", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	PublicDNS:                {ID: PublicDNS, RdfType: "rdf:Property", RdfsLabel: "PublicDNS", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	PublicIP:                 {ID: PublicIP, RdfType: "rdf:Property", RdfsLabel: "PublicIP", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	RangeKey:                 {ID: RangeKey, RdfType: "rdf:Property", RdfsLabel: "RangeKey", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	ReadCapacity:             {ID: ReadCapacity, RdfType: "rdf:Property", RdfsLabel: "ReadCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	RecordCount:              {ID: RecordCount, RdfType: "rdf:Property", RdfsLabel: "RecordCount", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Records:                  {ID: Records, RdfType: "rdf:Property", RdfsLabel: "Records", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Region:                   {ID: Region, RdfType: "rdf:Property", RdfsLabel: "Region", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -649,5 +699,6 @@ var Properties = RDFProperties{
 	Vpcs:                    {ID: Vpcs, RdfType: "rdf:Property", RdfsLabel: "Vpcs", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
 	WebACL:                  {ID: WebACL, RdfType: "rdf:Property", RdfsLabel: "WebACL", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Weight:                  {ID: Weight, RdfType: "rdf:Property", RdfsLabel: "Weight", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	WriteCapacity:           {ID: WriteCapacity, RdfType: "rdf:Property", RdfsLabel: "WriteCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Zone:                    {ID: Zone, RdfType: "rdf:Property", RdfsLabel: "Zone", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 }
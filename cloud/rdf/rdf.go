@@ -1,6 +1,9 @@
 package rdf
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // Namespaces
 const (
@@ -129,3 +132,41 @@ func (r RDFProperties) GetDefinedBy(prop string) (string, error) {
 	}
 	return p.RdfsDefinedBy, nil
 }
+
+// RegisterLiteralProperty declares at runtime a property not known at compile time
+// so that it can be stored in and loaded from the graphs. Data type is one of xsd:string,
+// xsd:int, xsd:boolean, xsd:dateTime. When list is true, the property holds a string slice.
+func RegisterLiteralProperty(label, dataType string, list bool) (string, error) {
+	definedBy := RdfsLiteral
+	if list {
+		if dataType != XsdString {
+			return "", fmt.Errorf("register property '%s': only string lists are supported", label)
+		}
+		definedBy = RdfsList
+	}
+
+	if id, ok := Labels[label]; ok {
+		if p := Properties[id]; p.RdfsDefinedBy != definedBy || p.RdfsDataType != dataType {
+			return "", fmt.Errorf("register property '%s': already exists as %s %s", label, p.RdfsDefinedBy, p.RdfsDataType)
+		}
+		return id, nil
+	}
+
+	switch dataType {
+	case XsdString, XsdInt, XsdBoolean, XsdDateTime:
+	default:
+		return "", fmt.Errorf("register property '%s': unsupported data type %s", label, dataType)
+	}
+
+	if label == "" {
+		return "", fmt.Errorf("register property: empty label")
+	}
+	id := fmt.Sprintf("%s:%s", CloudNS, strings.ToLower(label[:1])+label[1:])
+	if _, ok := Properties[id]; ok {
+		return "", fmt.Errorf("register property '%s': id %s already exists", label, id)
+	}
+
+	Labels[label] = id
+	Properties[id] = rdfProp{ID: id, RdfType: RdfProperty, RdfsLabel: label, RdfsDefinedBy: definedBy, RdfsDataType: dataType}
+	return id, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rules evaluates user-defined checks over the resources of a graph, as in:
+//
+//	rules:
+//	- name: no-public-ssh
+//	  description: security groups must not open SSH to the internet
+//	  type: securitygroup
+//	  assert: NOT inbound:22=0.0.0.0/0
+//	- name: instances-named
+//	  type: instance
+//	  where: state=running
+//	  assert: tag:Name~.
+//	  severity: warning
+//
+// The resources of the type selected by the optional `where` query must match the `assert`
+// query (see match.Parse for the query syntax), otherwise they violate the rule.
+package rules
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
+	"gopkg.in/yaml.v2"
+)
+
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Rule is a check over the resources of a type
+type Rule struct {
+	Name        string   `yaml:"name" json:"name"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Type        string   `yaml:"type" json:"type"`
+	Where       string   `yaml:"where,omitempty" json:"where,omitempty"`
+	Assert      string   `yaml:"assert" json:"assert"`
+	Severity    Severity `yaml:"severity,omitempty" json:"severity"`
+
+	where, assert cloud.Matcher
+}
+
+// Compile validates the rule and parses its queries. Rules are compiled when parsed.
+func (r *Rule) Compile() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("rule without name")
+	}
+	if strings.TrimSpace(r.Type) == "" {
+		return fmt.Errorf("rule %s: missing resource type", r.Name)
+	}
+	switch r.Severity {
+	case "":
+		r.Severity = Error
+	case Error, Warning:
+	default:
+		return fmt.Errorf("rule %s: invalid severity '%s', expected %s or %s", r.Name, r.Severity, Error, Warning)
+	}
+	var err error
+	if strings.TrimSpace(r.Where) != "" {
+		if r.where, err = match.Parse(r.Where); err != nil {
+			return fmt.Errorf("rule %s: where: %s", r.Name, err)
+		}
+	}
+	if r.assert, err = match.Parse(r.Assert); err != nil {
+		return fmt.Errorf("rule %s: assert: %s", r.Name, err)
+	}
+	return nil
+}
+
+// Parse returns the compiled rules of a YAML rules file
+func Parse(content []byte) ([]*Rule, error) {
+	var file struct {
+		Rules []*Rule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+	names := make(map[string]bool)
+	for _, r := range file.Rules {
+		if err := r.Compile(); err != nil {
+			return nil, err
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("rule %s: defined twice", r.Name)
+		}
+		names[r.Name] = true
+	}
+	return file.Rules, nil
+}
+
+// LoadFiles returns the compiled rules of the YAML rules files
+func LoadFiles(paths ...string) ([]*Rule, error) {
+	var all []*Rule
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		rules, err := Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+		all = append(all, rules...)
+	}
+	return all, nil
+}
+
+// Violation is a resource not matching the assertion of a rule
+type Violation struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Type     string   `json:"type"`
+	ID       string   `json:"id"`
+	Name     string   `json:"name,omitempty"`
+}
+
+// Result is the outcome of a rule over the resources it selected
+type Result struct {
+	Rule       *Rule        `json:"rule"`
+	Checked    int          `json:"checked"`
+	Violations []*Violation `json:"violations,omitempty"`
+}
+
+// Report gathers the results of the rules evaluated over a graph
+type Report struct {
+	Results []*Result `json:"results"`
+}
+
+// Violations returns the violations of all the rules, ordered by rule then resource
+func (r *Report) Violations() (all []*Violation) {
+	for _, res := range r.Results {
+		all = append(all, res.Violations...)
+	}
+	return
+}
+
+// Passed returns true when no resource violates a rule of severity error
+func (r *Report) Passed() bool {
+	for _, v := range r.Violations() {
+		if v.Severity == Error {
+			return false
+		}
+	}
+	return true
+}
+
+// Evaluate checks the rules over the resources of the graph.
+// Rules not yet compiled (i.e. not built with Parse) are compiled first.
+func Evaluate(g cloud.GraphAPI, rules ...*Rule) (*Report, error) {
+	report := &Report{}
+	for _, rule := range rules {
+		if rule.assert == nil {
+			if err := rule.Compile(); err != nil {
+				return report, err
+			}
+		}
+		query := cloud.NewQuery(rule.Type)
+		if rule.where != nil {
+			query = query.Match(rule.where)
+		}
+		selected, err := g.Find(query)
+		if err != nil {
+			return report, fmt.Errorf("rule %s: %s", rule.Name, err)
+		}
+		failing := match.Not(rule.assert)
+		if rule.where != nil {
+			failing = match.And(rule.where, failing)
+		}
+		violating, err := g.Find(cloud.NewQuery(rule.Type).Match(failing))
+		if err != nil {
+			return report, fmt.Errorf("rule %s: %s", rule.Name, err)
+		}
+
+		result := &Result{Rule: rule, Checked: len(selected)}
+		for _, res := range violating {
+			name, _ := res.Properties()[properties.Name].(string)
+			result.Violations = append(result.Violations, &Violation{
+				Rule: rule.Name, Severity: rule.Severity, Type: res.Type(), ID: res.Id(), Name: name,
+			})
+		}
+		sort.Slice(result.Violations, func(i, j int) bool { return result.Violations[i].ID < result.Violations[j].ID })
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
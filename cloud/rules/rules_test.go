@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := Parse([]byte(`
+rules:
+- name: no-public-ssh
+  type: securitygroup
+  assert: NOT inbound:22=0.0.0.0/0
+- name: instances-named
+  type: instance
+  where: state=running
+  assert: tag:Name~.
+  severity: warning
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(rules), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := rules[0].Severity, Error; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	tcases := []struct {
+		content, expErr string
+	}{
+		{content: "rules:\n- type: instance\n  assert: state=running", expErr: "rule without name"},
+		{content: "rules:\n- name: r\n  assert: state=running", expErr: "missing resource type"},
+		{content: "rules:\n- name: r\n  type: instance\n  assert: state=", expErr: "rule r: assert: query"},
+		{content: "rules:\n- name: r\n  type: instance\n  where: (state=running\n  assert: state=running", expErr: "rule r: where: query"},
+		{content: "rules:\n- name: r\n  type: instance\n  assert: state=running\n  severity: fatal", expErr: "invalid severity"},
+		{content: "rules:\n- name: r\n  type: instance\n  assert: state=running\n- name: r\n  type: subnet\n  assert: state=available", expErr: "defined twice"},
+	}
+	for i, tcase := range tcases {
+		if _, err := Parse([]byte(tcase.content)); err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+			t.Fatalf("%d: got %v, want error containing '%s'", i+1, err, tcase.expErr)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	_, anywhere, _ := net.ParseCIDR("0.0.0.0/0")
+	_, private, _ := net.ParseCIDR("10.0.0.0/8")
+
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.SecurityGroup("sg_1").Prop("Name", "bastion").Prop("InboundRules", []*graph.FirewallRule{
+			{PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", IPRanges: []*net.IPNet{anywhere}},
+		}).Build(),
+		resourcetest.SecurityGroup("sg_2").Prop("InboundRules", []*graph.FirewallRule{
+			{PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", IPRanges: []*net.IPNet{private}},
+		}).Build(),
+		resourcetest.Instance("inst_1").Prop("State", "running").Prop("Tags", []string{"Name=web"}).Build(),
+		resourcetest.Instance("inst_2").Prop("State", "running").Build(),
+		resourcetest.Instance("inst_3").Prop("State", "stopped").Build(),
+	)
+
+	rules := []*Rule{
+		{Name: "no-public-ssh", Type: "securitygroup", Assert: "NOT inbound:22=0.0.0.0/0"},
+		{Name: "instances-named", Type: "instance", Where: "state=running", Assert: "tag:Name~.", Severity: Warning},
+	}
+	report, err := Evaluate(g, rules...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(report.Results), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := report.Results[0].Checked, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := report.Results[1].Checked, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	violations := report.Violations()
+	if got, want := len(violations), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := *violations[0], (Violation{Rule: "no-public-ssh", Severity: Error, Type: "securitygroup", ID: "sg_1", Name: "bastion"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := *violations[1], (Violation{Rule: "instances-named", Severity: Warning, Type: "instance", ID: "inst_2"}); got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if report.Passed() {
+		t.Fatal("expected report to fail")
+	}
+
+	report, err = Evaluate(g, rules[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Passed() {
+		t.Fatal("expected report with warnings only to pass")
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/params"
+)
+
+var adoptRunFlag string
+
+func init() {
+	RootCmd.AddCommand(adoptCmd)
+
+	adoptCmd.Flags().StringVar(&adoptRunFlag, "run", "", "ID of the template execution adopting the resources (see `awless log`)")
+}
+
+var adoptCmd = &cobra.Command{
+	Use:   "adopt REFERENCE... --run REVERTID",
+	Short: "Attach existing resources given their REFERENCE (name, id, arn, ...) to a template execution, so that reverting it also deletes them",
+	Long: `Attach existing resources given their REFERENCE (name, id, arn, ...) to a template execution, typically resources created manually next to the ones created by the template.
+
+Reverting the template execution (see 'awless revert') then deletes the adopted resources first, in reverse order of adoption, before reverting the template commands.`,
+	Example:           "  awless adopt i-0a2b1c3d --run 01BA7RV6ES86PZYCM3H28WM6KZ\n  awless adopt @my-bastion @my-keypair --run 01BA7RV6ES86PZYCM3H28WM6KZ",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(c *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("REFERENCE required. See examples.")
+		}
+		if adoptRunFlag == "" {
+			return errors.New("--run required (see `awless log` to list revert ids)")
+		}
+
+		var loaded *template.TemplateExecution
+		exitOn(database.Execute(func(db *database.DB) (terr error) {
+			loaded, terr = db.GetTemplate(adoptRunFlag)
+			return
+		}))
+
+		if loc := loaded.Locale; loc != "" && loc != config.GetAWSRegion() {
+			logger.Errorf("This template was originally run in region %s", loc)
+			logger.Infof("Adopt with `awless adopt ... --run %s -r %s -p %s`", adoptRunFlag, loc, loaded.Profile)
+			os.Exit(1)
+		}
+		if prof := loaded.Profile; prof != config.GetAWSProfile() {
+			logger.Warningf("This template was originally run with profile %s", prof)
+		}
+
+		var adopted []*template.AdoptedResource
+		for _, ref := range args {
+			_, resources, _ := resolveResourceFromRefInCurrentRegion(ref)
+			switch len(resources) {
+			case 0:
+				exitOn(fmt.Errorf("resource '%s' not found in local data (run `awless sync` to refresh)", ref))
+			case 1:
+			default:
+				exitOn(fmt.Errorf("reference '%s' is ambiguous: matching %d resources", ref, len(resources)))
+			}
+			res, err := adoptedResource(resources[0])
+			exitOn(err)
+			adopted = append(adopted, res)
+		}
+
+		var count int
+		for _, res := range adopted {
+			if loaded.Adopt(res) {
+				count++
+				logger.Infof("%s adopted by run %s", res, loaded.ID)
+			} else {
+				logger.Warningf("%s already adopted by run %s", res, loaded.ID)
+			}
+		}
+		if count == 0 {
+			return nil
+		}
+
+		exitOn(database.Execute(func(db *database.DB) error {
+			return db.AddTemplate(loaded)
+		}))
+		return nil
+	},
+}
+
+// adoptedResource identifies the resource with the param of its delete command: id, or name if deleted by name
+func adoptedResource(r cloud.Resource) (*template.AdoptedResource, error) {
+	def, ok := awsspec.AWSLookupDefinitions(fmt.Sprintf("delete%s", r.Type()))
+	if !ok {
+		return nil, fmt.Errorf("cannot adopt %s %s: %s resources cannot be deleted with awless", r.Type(), r.Id(), r.Type())
+	}
+	if params.Run(def.Params, []string{"id"}) == nil {
+		return &template.AdoptedResource{Type: r.Type(), ID: r.Id(), Key: "id", Value: r.Id()}, nil
+	}
+	if name, ok := r.Property(properties.Name); ok && params.Run(def.Params, []string{"name"}) == nil {
+		return &template.AdoptedResource{Type: r.Type(), ID: r.Id(), Key: "name", Value: fmt.Sprint(name)}, nil
+	}
+	return nil, fmt.Errorf("cannot adopt %s %s: no id or name to delete it by", r.Type(), r.Id())
+}
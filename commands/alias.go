@@ -0,0 +1,134 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wallix/awless/config"
+)
+
+// ExpandAlias replaces the user-defined alias given as first argument (config key `alias.NAME`)
+// by its command line. Builtin commands cannot be overridden by an alias. Ex:
+//
+//	awless config set alias.up "run ./infra/main.aws -v env=$1"
+//	awless up dev    # awless run ./infra/main.aws -v env=dev
+func ExpandAlias(args []string) ([]string, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return args, nil
+	}
+	if cmd, _, err := RootCmd.Find(args[:1]); err == nil && cmd != RootCmd {
+		return args, nil
+	}
+	if _, err := os.Stat(config.DBPath); err != nil { // do not create the database before first install
+		return args, nil
+	}
+	if err := config.LoadConfig(); err != nil {
+		return args, err
+	}
+
+	alias, ok := config.GetAlias(args[0])
+	if !ok {
+		return args, nil
+	}
+	expanded, err := expandAlias(alias, args[1:])
+	if err != nil {
+		return args, fmt.Errorf("alias '%s': %s", args[0], err)
+	}
+	return expanded, nil
+}
+
+var aliasArgRegex = regexp.MustCompile(`\$([1-9][0-9]*)`)
+
+// expandAlias templates the alias command line with $1, $2, ... (positional args) and $@ (all args).
+// Args not referenced after the last positional one are appended, unless $@ is used.
+func expandAlias(alias string, args []string) ([]string, error) {
+	tokens, err := splitCommandLine(alias)
+	if err != nil {
+		return nil, err
+	}
+
+	var expanded []string
+	var maxIndex int
+	var allUsed bool
+	for _, tok := range tokens {
+		if tok == "$@" {
+			allUsed = true
+			expanded = append(expanded, args...)
+			continue
+		}
+		var missing error
+		tok = aliasArgRegex.ReplaceAllStringFunc(tok, func(s string) string {
+			index, _ := strconv.Atoi(s[1:])
+			if index > len(args) {
+				missing = fmt.Errorf("missing argument %s (got %d arguments)", s, len(args))
+				return s
+			}
+			if index > maxIndex {
+				maxIndex = index
+			}
+			return args[index-1]
+		})
+		if missing != nil {
+			return nil, missing
+		}
+		expanded = append(expanded, tok)
+	}
+	if !allUsed {
+		expanded = append(expanded, args[maxIndex:]...)
+	}
+	return expanded, nil
+}
+
+// splitCommandLine splits on spaces, keeping the content of single or double quotes in one token
+func splitCommandLine(s string) ([]string, error) {
+	var tokens []string
+	var current bytes.Buffer
+	var quote rune
+	var inToken bool
+	for _, r := range s {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inToken = r, true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in '%s'", s)
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandAlias(t *testing.T) {
+	tcases := []struct {
+		alias  string
+		args   []string
+		exp    []string
+		expErr bool
+	}{
+		{alias: "run ./infra/main.aws -v env=dev", exp: []string{"run", "./infra/main.aws", "-v", "env=dev"}},
+		{alias: "run ./infra/main.aws", args: []string{"env=dev", "-f"}, exp: []string{"run", "./infra/main.aws", "env=dev", "-f"}},
+		{alias: "run ./infra/main.aws env=$1", args: []string{"prod", "-f"}, exp: []string{"run", "./infra/main.aws", "env=prod", "-f"}},
+		{alias: "ssh $2@$1", args: []string{"my-instance", "ubuntu"}, exp: []string{"ssh", "ubuntu@my-instance"}},
+		{alias: "list instances $@ --sort uptime", args: []string{"--filter", "state=running"}, exp: []string{"list", "instances", "--filter", "state=running", "--sort", "uptime"}},
+		{alias: `run repo:create_vpc "name=my vpc" 'cidr=10.0.0.0/16'`, exp: []string{"run", "repo:create_vpc", "name=my vpc", "cidr=10.0.0.0/16"}},
+		{alias: "create subnet vpc=$1 cidr=$2", args: []string{"vpc-123"}, expErr: true},
+		{alias: `run "unterminated`, expErr: true},
+	}
+	for i, tcase := range tcases {
+		got, err := expandAlias(tcase.alias, tcase.args)
+		if tcase.expErr {
+			if err == nil {
+				t.Fatalf("%d: expected error, got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if want := tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+)
+
+var removeAnnotationsFlag []string
+
+func init() {
+	RootCmd.AddCommand(annotateCmd)
+	annotateCmd.Flags().StringSliceVar(&removeAnnotationsFlag, "remove", []string{}, "Keys of the annotations to remove from the resource")
+}
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate REFERENCE [KEY=VALUE...]",
+	Short: "Attach local notes to a resource, kept across syncs and queryable with annotation:KEY=VALUE",
+	Example: `  awless annotate i-8d43b21b owner=teamA decommission-by=2024-06
+  awless annotate @redis-prod --remove owner
+  awless list instances --filter annotation:owner=teamA
+  awless list instances --query "annotation:decommission-by<2024-07"`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("REFERENCE required. See examples.")
+		}
+		if len(args) == 1 && len(removeAnnotationsFlag) == 0 {
+			return errors.New("KEY=VALUE annotations or --remove required. See examples.")
+		}
+
+		resource, _ := findResourceInLocalGraphs(args[0])
+		if resource == nil {
+			runFullSync()
+			if resource, _ = findResourceInLocalGraphs(args[0]); resource == nil {
+				exitOn(decorateWithSuggestion(fmt.Errorf("resource '%s' not found", deprefix(args[0])), args[0]))
+			}
+		}
+
+		annotations, err := sync.Annotate(config.GetAWSProfile(), resource, args[1:], removeAnnotationsFlag...)
+		exitOn(err)
+
+		if len(annotations) == 0 {
+			logger.Infof("%s %s has no annotations", resource.Type(), resource.Id())
+		} else {
+			logger.Infof("%s %s annotated with %s", resource.Type(), resource.Id(), strings.Join(annotations, ", "))
+		}
+		return nil
+	},
+}
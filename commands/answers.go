@@ -0,0 +1,151 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Keys of the confirmation prompts in answers files
+const (
+	runConfirmAnswer            = "run"
+	scheduleConfirmAnswer       = "schedule"
+	aliasConflictsConfirmAnswer = "alias-conflicts"
+)
+
+// runAnswers holds the responses to the questions asked during a run, as in:
+//
+//	holes:
+//	  vpc.cidr: 10.0.0.0/16
+//	  instance.keypair: ""    # skip an optional param
+//	aliases:
+//	  prod-db: i-0123456789   # resource of an alias matching several resources
+//	confirm:
+//	  run: yes
+//
+// Replayed answers (--answers) make the run noninteractive: a question without answer fails the run.
+// Recorded answers (--record-answers) are written to their file as the questions are answered.
+type runAnswers struct {
+	Holes   map[string]string `yaml:"holes,omitempty"`
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+	Confirm map[string]bool   `yaml:"confirm,omitempty"`
+
+	replay     bool
+	recordPath string
+}
+
+// answers of the current run, nil when not replaying or recording
+var answers *runAnswers
+
+func loadRunAnswers(path string) (*runAnswers, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	a := &runAnswers{replay: true}
+	if err := yaml.Unmarshal(content, a); err != nil {
+		return nil, fmt.Errorf("answers file %s: %s", path, err)
+	}
+	return a, nil
+}
+
+func newRecordedRunAnswers(path string) *runAnswers {
+	return &runAnswers{recordPath: path}
+}
+
+// hole returns the replayed value of the hole or asks it. When replaying, a missing optional
+// hole is skipped while a missing required hole fails
+func (a *runAnswers) hole(name string, optional bool, ask func() string) (string, error) {
+	if a != nil && a.replay {
+		if v, ok := a.Holes[name]; ok {
+			return v, nil
+		}
+		if optional {
+			return "", nil
+		}
+		return "", fmt.Errorf("no value for hole '%s' in answers file", name)
+	}
+	v := ask()
+	if a != nil {
+		if a.Holes == nil {
+			a.Holes = make(map[string]string)
+		}
+		a.Holes[name] = v
+		return v, a.save()
+	}
+	return v, nil
+}
+
+// alias returns the resource ID chosen for the alias when replaying
+func (a *runAnswers) alias(name string) (string, bool) {
+	if a == nil || !a.replay {
+		return "", false
+	}
+	id, ok := a.Aliases[name]
+	return id, ok
+}
+
+func (a *runAnswers) recordAlias(name, id string) error {
+	if a == nil || a.replay {
+		return nil
+	}
+	if a.Aliases == nil {
+		a.Aliases = make(map[string]string)
+	}
+	a.Aliases[name] = id
+	return a.save()
+}
+
+func (a *runAnswers) replaying() bool {
+	return a != nil && a.replay
+}
+
+// confirm returns the replayed confirmation or asks it
+func (a *runAnswers) confirm(key string, ask func() (bool, error)) (bool, error) {
+	if a != nil && a.replay {
+		if ok, found := a.Confirm[key]; found {
+			return ok, nil
+		}
+		return false, fmt.Errorf("no confirmation '%s' in answers file", key)
+	}
+	ok, err := ask()
+	if err != nil || a == nil {
+		return ok, err
+	}
+	if a.Confirm == nil {
+		a.Confirm = make(map[string]bool)
+	}
+	a.Confirm[key] = ok
+	return ok, a.save()
+}
+
+func (a *runAnswers) save() error {
+	if a.recordPath == "" {
+		return nil
+	}
+	content, err := yaml.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(a.recordPath, content, 0600); err != nil {
+		return fmt.Errorf("recording answers: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/template"
+)
+
+func TestRunAnswers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-answers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "answers.yaml")
+
+	t.Run("record", func(t *testing.T) {
+		recorded := newRecordedRunAnswers(path)
+		if _, err := recorded.hole("vpc.cidr", false, func() string { return "10.0.0.0/16" }); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := recorded.hole("instance.keypair", true, func() string { return "" }); err != nil {
+			t.Fatal(err)
+		}
+		if err := recorded.recordAlias("prod-db", "i-2"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := recorded.confirm(runConfirmAnswer, func() (bool, error) { return true, nil }); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		replayed, err := loadRunAnswers(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mustNotAsk := func() string {
+			t.Fatal("unexpected question")
+			return ""
+		}
+		if got, err := replayed.hole("vpc.cidr", false, mustNotAsk); err != nil || got != "10.0.0.0/16" {
+			t.Fatalf("got %s (err: %v), want 10.0.0.0/16", got, err)
+		}
+		if got, err := replayed.hole("instance.keypair", true, mustNotAsk); err != nil || got != "" {
+			t.Fatalf("got %s (err: %v), want empty", got, err)
+		}
+		if got, err := replayed.hole("instance.name", true, mustNotAsk); err != nil || got != "" {
+			t.Fatalf("got %s (err: %v), want empty", got, err)
+		}
+		if _, err := replayed.hole("subnet.cidr", false, mustNotAsk); err == nil {
+			t.Fatal("expected error got none")
+		}
+		if got, ok := replayed.alias("prod-db"); !ok || got != "i-2" {
+			t.Fatalf("got %s (found: %t), want i-2", got, ok)
+		}
+		confirmed, err := replayed.confirm(runConfirmAnswer, func() (bool, error) {
+			t.Fatal("unexpected confirmation")
+			return false, nil
+		})
+		if err != nil || !confirmed {
+			t.Fatalf("got %t (err: %v), want true", confirmed, err)
+		}
+		if _, err := replayed.confirm(aliasConflictsConfirmAnswer, nil); err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+
+	t.Run("no answers", func(t *testing.T) {
+		var none *runAnswers
+		if got, err := none.hole("vpc.cidr", false, func() string { return "asked" }); err != nil || got != "asked" {
+			t.Fatalf("got %s (err: %v), want asked", got, err)
+		}
+		if _, ok := none.alias("prod-db"); ok {
+			t.Fatal("expected no alias")
+		}
+		if err := none.recordAlias("prod-db", "i-1"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "holes:\n  instance.keypair: \"\"\n  vpc.cidr: 10.0.0.0/16\naliases:\n  prod-db: i-2\nconfirm:\n  run: true\n"
+	if got, want := string(content), expected; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestConfirmWithReplayedAnswersPrintsPlan(t *testing.T) {
+	defer func(a *runAnswers) { answers = a }(answers)
+	answers = &runAnswers{replay: true, Confirm: map[string]bool{runConfirmAnswer: true}}
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	confirmed, err := confirmWith(runConfirmAnswer, &confirmRequest{prompt: "Confirm? [y/N] ", template: template.MustParse("delete instance id=i-1")})
+	os.Stdout = stdout
+	w.Close()
+	if err != nil || !confirmed {
+		t.Fatalf("got %t (err: %v), want true", confirmed, err)
+	}
+
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "delete instance id=i-1") {
+		t.Fatalf("expected plan in %q", out)
+	}
+	if !strings.Contains(string(out), "Confirm? [y/N] y (from answers file)") {
+		t.Fatalf("expected replayed answer in %q", out)
+	}
+}
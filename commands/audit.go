@@ -0,0 +1,155 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/audit"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+)
+
+var (
+	auditChecksFlag []string
+	auditFailOnFlag string
+)
+
+func init() {
+	RootCmd.AddCommand(auditCmd)
+
+	auditCmd.Flags().StringSliceVar(&auditChecksFlag, "checks", nil, fmt.Sprintf("Only run the given checks among: %s", strings.Join(allAuditChecks(), ", ")))
+	auditCmd.Flags().StringVar(&auditFailOnFlag, "fail-on", "", "Exit with status 1 when a finding has at least this severity: low, medium or high (ex: in CI)")
+}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Report the security weaknesses of your infrastructure, the most severe first",
+	Long:  fmt.Sprintf("Run built-in checks on your synced resources:\n%s", auditChecksDoc()),
+	Example: `  awless audit
+  awless audit --checks public_buckets,open_securitygroups --local
+  awless audit --format json --fail-on high     # in CI`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(c *cobra.Command, args []string) error {
+		checks := audit.Checks
+		if len(auditChecksFlag) > 0 {
+			checks = nil
+			for _, name := range auditChecksFlag {
+				check, ok := audit.LookupCheck(strings.TrimSpace(name))
+				if !ok {
+					return fmt.Errorf("unknown check '%s', expecting one of: %s", name, strings.Join(allAuditChecks(), ", "))
+				}
+				checks = append(checks, check)
+			}
+		}
+		var failOn audit.Severity
+		if auditFailOnFlag != "" {
+			var err error
+			failOn, err = audit.ParseSeverity(auditFailOnFlag)
+			exitOn(err)
+		}
+
+		if !localGlobalFlag {
+			logger.Info("Running full sync before audit (disable it with --local flag)\n")
+			var services []cloud.Service
+			for _, srv := range cloud.ServiceRegistry {
+				services = append(services, srv)
+			}
+			if _, err := sync.DefaultSyncer.Sync(services...); err != nil {
+				logger.Verbose(err)
+			}
+		}
+
+		g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+		exitOn(err)
+
+		findings, err := audit.Run(g, time.Now().UTC(), checks...)
+		exitOn(err)
+
+		if console.IsStructuredFormat(formatGlobalFlag) {
+			exitOn(console.EncodeStructured(os.Stdout, formatGlobalFlag, findings))
+		} else {
+			displayAuditFindings(findings)
+		}
+
+		if failOn > 0 && len(findings) > 0 && findings[0].Severity >= failOn {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func displayAuditFindings(findings []*audit.Finding) {
+	if len(findings) == 0 {
+		logger.Info("No findings")
+		return
+	}
+	counts := make(map[audit.Severity]int)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if !noHeadersFlag {
+		fmt.Fprintln(w, "Severity\tCheck\tResource\tFinding")
+		fmt.Fprintln(w, "--------\t-----\t--------\t-------")
+	}
+	for _, f := range findings {
+		counts[f.Severity]++
+		resource := fmt.Sprintf("%s[%s]", f.ResourceID, f.ResourceType)
+		if f.ResourceName != "" && f.ResourceName != f.ResourceID {
+			resource = fmt.Sprintf("%s (%s)[%s]", f.ResourceName, f.ResourceID, f.ResourceType)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", renderSeverity(f.Severity), f.Check, resource, f.Message)
+	}
+	exitOn(w.Flush())
+	fmt.Printf("\n%d findings: %d high, %d medium, %d low\n", len(findings), counts[audit.High], counts[audit.Medium], counts[audit.Low])
+}
+
+func renderSeverity(s audit.Severity) string {
+	switch s {
+	case audit.High:
+		return renderRedFn(s)
+	case audit.Medium:
+		return renderYellowFn(s)
+	}
+	return s.String()
+}
+
+func allAuditChecks() (names []string) {
+	for _, c := range audit.Checks {
+		names = append(names, c.Name)
+	}
+	return
+}
+
+func auditChecksDoc() string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+	for _, c := range audit.Checks {
+		fmt.Fprintf(w, "  %s\t%s\n", c.Name, c.Description)
+	}
+	w.Flush()
+	return buf.String()
+}
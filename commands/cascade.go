@@ -0,0 +1,271 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+// cascadeTemplate prepends to a delete statement the statements detaching or deleting
+// the resources depending on the deleted resource, according to the local graph
+func cascadeTemplate(tpl *template.Template) (*template.Template, error) {
+	cmds := tpl.CommandNodesIterator()
+	if len(cmds) != 1 || cmds[0].Action != "delete" {
+		return nil, errors.New("cascade: expecting a single delete statement")
+	}
+	ref, ok := cmds[0].Params["id"]
+	if !ok {
+		return nil, fmt.Errorf("cascade: missing id of %s to delete", cmds[0].Entity)
+	}
+	gph, resources, _ := resolveResourceFromRefInCurrentRegion(ref.String())
+	if len(resources) != 1 {
+		return nil, fmt.Errorf("cascade: cannot find %s '%s' in local graph, try to sync first", cmds[0].Entity, ref.String())
+	}
+	g, ok := gph.(*graph.Graph)
+	if !ok {
+		return nil, fmt.Errorf("cascade: unexpected graph %T", gph)
+	}
+	res, ok := resources[0].(*graph.Resource)
+	if !ok {
+		return nil, fmt.Errorf("cascade: unexpected resource %T", resources[0])
+	}
+
+	statements, unhandled, err := cascadeDeletion(g, res)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range unhandled {
+		logger.Warningf("cascade: %s depends on %s (%s) and is left as is", printResourceRef(d.Resource), printResourceRef(res), d.Through)
+	}
+	if len(statements) == 0 {
+		logger.Infof("No resources to detach or delete before %s", printResourceRef(res))
+		return tpl, nil
+	}
+	return template.Parse(strings.Join(append(statements, tpl.String()), "\n"))
+}
+
+// cascadeDeletion returns the ordered statements detaching the resource from the resources it is attached to
+// and detaching (or deleting) the resources depending on it, deepest dependents first. The dependents
+// that cannot be detached nor deleted are returned unhandled, for the deletion may fail because of them.
+func cascadeDeletion(g *graph.Graph, res *graph.Resource) ([]string, []*graph.Dependent, error) {
+	c := &cascade{g: g, visited: make(map[string]bool), deleted: make(map[string]bool), unique: make(map[string]bool)}
+	if err := c.visit(res, true); err != nil {
+		return nil, nil, err
+	}
+	return c.statements, c.unhandled, nil
+}
+
+type cascade struct {
+	g                        *graph.Graph
+	statements               []string
+	unhandled                []*graph.Dependent
+	visited, deleted, unique map[string]bool
+}
+
+func (c *cascade) add(format string, a ...interface{}) {
+	st := fmt.Sprintf(format, a...)
+	if !c.unique[st] {
+		c.unique[st] = true
+		c.statements = append(c.statements, st)
+	}
+}
+
+func (c *cascade) visit(res *graph.Resource, root bool) error {
+	c.visited[res.Id()] = true
+	c.detachAttachments(res)
+
+	dependents, err := c.g.Dependents(res)
+	if err != nil {
+		return err
+	}
+	for _, d := range dependents {
+		if c.deleted[d.Id()] {
+			continue
+		}
+		if err := c.handle(res, d); err != nil {
+			return err
+		}
+	}
+
+	if root {
+		return nil
+	}
+	if _, ok := awsspec.AWSLookupDefinitions("delete" + res.Type()); !ok {
+		c.unhandled = append(c.unhandled, &graph.Dependent{Resource: res, Through: "no delete command"})
+		return nil
+	}
+	if res.Type() == cloud.Instance {
+		c.add("delete instance id=%s wait=true", res.Id())
+	} else {
+		c.add("delete %s id=%s", res.Type(), res.Id())
+	}
+	c.deleted[res.Id()] = true
+	return nil
+}
+
+// detachAttachments detaches the resource from the resources it is attached to
+func (c *cascade) detachAttachments(res *graph.Resource) {
+	props := res.Properties()
+	switch res.Type() {
+	case cloud.InternetGateway:
+		for _, vpc := range stringSliceProp(props[properties.Vpcs]) {
+			c.add("detach internetgateway id=%s vpc=%s", res.Id(), vpc)
+		}
+	case cloud.Volume:
+		for _, inst := range stringSliceProp(props[properties.Instances]) {
+			c.add("detach volume id=%s instance=%s device={volume.device}", res.Id(), inst)
+		}
+	case cloud.NetworkInterface:
+		if attachment, _ := props[properties.Attachment].(string); attachment != "" {
+			c.add("detach networkinterface attachment=%s", attachment)
+		}
+	case cloud.ElasticIP:
+		if assoc, _ := props[properties.Association].(string); assoc != "" {
+			c.add("detach elasticip association=%s", assoc)
+		}
+	case cloud.RouteTable:
+		if assocs, ok := props[properties.Associations].([]*graph.KeyValue); ok {
+			for _, assoc := range assocs {
+				c.add("detach routetable association=%s", assoc.KeyName)
+			}
+		}
+	}
+}
+
+// handle detaches the dependent from the resource when possible, deletes it otherwise
+func (c *cascade) handle(res *graph.Resource, d *graph.Dependent) error {
+	switch {
+	case isDefaultResource(d.Resource):
+		// the default security group and main route table go along with their VPC
+		return nil
+	case res.Type() == cloud.Instance && (d.Through == properties.Instance || d.Through == properties.Instances):
+		// volumes, network interfaces and elastic IPs are detached when the instance terminates
+		return nil
+	case d.Through == graph.AppliesOnRelation && (res.Type() == cloud.SecurityGroup || res.Type() == cloud.Keypair):
+		// security groups are detached through the SecurityGroups property, keypairs are not needed by instances
+		return nil
+	case d.Type() == cloud.NetworkInterface && attachedInstance(d.Resource) != "":
+		// an attached network interface goes along with its instance
+		if d.Through == properties.SecurityGroups {
+			return nil
+		}
+		inst, err := c.g.GetResource(cloud.Instance, attachedInstance(d.Resource))
+		if err != nil || c.visited[inst.Id()] {
+			return nil
+		}
+		return c.visit(inst, false)
+	case d.Through == properties.SecurityGroups && d.Type() == cloud.Instance:
+		c.add("detach securitygroup id=%s instance=%s", res.Id(), d.Id())
+	case d.Through == properties.InboundRules || d.Through == properties.OutboundRules:
+		rules, _ := d.Properties()[d.Through].([]*graph.FirewallRule)
+		direction := "inbound"
+		if d.Through == properties.OutboundRules {
+			direction = "outbound"
+		}
+		for _, r := range rules {
+			for _, source := range r.Sources {
+				if source == res.Id() {
+					c.add("update securitygroup id=%s %s=revoke protocol=%s portrange=%s securitygroup=%s", d.Id(), direction, r.Protocol, portRangeParam(r.PortRange), res.Id())
+				}
+			}
+		}
+	case d.Through == properties.Routes:
+		routes, _ := d.Properties()[properties.Routes].([]*graph.Route)
+		for _, r := range routes {
+			for _, t := range r.Targets {
+				if t.Ref != res.Id() {
+					continue
+				}
+				if r.Destination != nil {
+					c.add("delete route table=%s cidr=%s", d.Id(), r.Destination)
+				}
+				if r.DestinationIPv6 != nil {
+					c.add("delete route table=%s cidr=%s", d.Id(), r.DestinationIPv6)
+				}
+			}
+		}
+	case d.Through == properties.Associations:
+		assocs, _ := d.Properties()[properties.Associations].([]*graph.KeyValue)
+		for _, assoc := range assocs {
+			if assoc.Value == res.Id() {
+				c.add("detach routetable association=%s", assoc.KeyName)
+			}
+		}
+	case d.Through == properties.Vpcs && d.Type() == cloud.InternetGateway:
+		c.add("detach internetgateway id=%s vpc=%s", d.Id(), res.Id())
+	case d.Through == graph.ChildRelation || d.Through == properties.Subnet || d.Through == properties.Vpc,
+		d.Through == properties.SecurityGroups && d.Type() == cloud.NetworkInterface:
+		if c.visited[d.Id()] {
+			return nil
+		}
+		return c.visit(d.Resource, false)
+	default:
+		c.unhandled = append(c.unhandled, d)
+	}
+	return nil
+}
+
+func isDefaultResource(res *graph.Resource) bool {
+	switch res.Type() {
+	case cloud.SecurityGroup:
+		name, _ := res.Properties()[properties.Name].(string)
+		return name == "default"
+	case cloud.RouteTable:
+		isDefault, _ := res.Properties()[properties.Default].(bool)
+		return isDefault
+	}
+	return false
+}
+
+func attachedInstance(res *graph.Resource) string {
+	inst, _ := res.Properties()[properties.Instance].(string)
+	return inst
+}
+
+func portRangeParam(p graph.PortRange) string {
+	switch {
+	case p.Any:
+		return "any"
+	case p.FromPort == p.ToPort:
+		return fmt.Sprint(p.FromPort)
+	default:
+		return fmt.Sprintf("%d-%d", p.FromPort, p.ToPort)
+	}
+}
+
+func stringSliceProp(i interface{}) []string {
+	switch v := i.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		var strs []string
+		for _, s := range v {
+			strs = append(strs, fmt.Sprint(s))
+		}
+		return strs
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/template"
+)
+
+func TestCascadeDeletion(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("0.0.0.0/0")
+
+	g := graph.NewGraph()
+	vpc := resourcetest.VPC("vpc_1").Build()
+	sub := resourcetest.Subnet("sub_1").Prop(properties.Vpc, "vpc_1").Build()
+	defaultSg := resourcetest.SecurityGroup("sg_default").Prop(properties.Name, "default").Prop(properties.Vpc, "vpc_1").Build()
+	sg := resourcetest.SecurityGroup("sg_1").Prop(properties.Vpc, "vpc_1").Build()
+	inst := resourcetest.Instance("inst_1").Prop(properties.Vpc, "vpc_1").Prop(properties.Subnet, "sub_1").Prop(properties.SecurityGroups, []string{"sg_1"}).Build()
+	eni := resourcetest.NetworkInterface("eni_1").Prop(properties.Vpc, "vpc_1").Prop(properties.Subnet, "sub_1").Prop(properties.Instance, "inst_1").
+		Prop(properties.Attachment, "attach_1").Prop(properties.SecurityGroups, []string{"sg_1"}).Build()
+	g.AddResource(vpc, sub, defaultSg, sg, inst, eni,
+		resourcetest.SecurityGroup("sg_2").Prop(properties.InboundRules, []*graph.FirewallRule{
+			{PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", Sources: []string{"sg_1"}},
+		}).Build(),
+		resourcetest.InternetGw("igw_1").Prop(properties.Vpcs, []string{"vpc_1"}).Build(),
+		resourcetest.RouteTable("rt_main").Prop(properties.Vpc, "vpc_1").Prop(properties.Default, true).Build(),
+		resourcetest.RouteTable("rt_1").Prop(properties.Vpc, "vpc_1").Prop(properties.Routes, []*graph.Route{
+			{Destination: cidr, Targets: []*graph.RouteTarget{{Type: graph.GatewayTarget, Ref: "igw_1"}}},
+		}).Prop(properties.Associations, []*graph.KeyValue{{KeyName: "assoc_1", Value: "sub_1"}}).Build(),
+	)
+	g.AddParentRelation(vpc, sub)
+	g.AddParentRelation(vpc, defaultSg)
+	g.AddParentRelation(vpc, sg)
+	g.AddParentRelation(sub, inst)
+	g.AddParentRelation(sub, eni)
+	g.AddAppliesOnRelation(sg, inst)
+
+	tcases := []struct {
+		res           *graph.Resource
+		expStatements []string
+	}{
+		{res: sg, expStatements: []string{
+			"detach securitygroup id=sg_1 instance=inst_1",
+			"update securitygroup id=sg_2 inbound=revoke protocol=tcp portrange=22 securitygroup=sg_1",
+		}},
+		{res: sub, expStatements: []string{
+			"delete instance id=inst_1 wait=true",
+			"detach routetable association=assoc_1",
+		}},
+		{res: vpc, expStatements: []string{
+			"delete instance id=inst_1 wait=true",
+			"detach internetgateway id=igw_1 vpc=vpc_1",
+			"detach routetable association=assoc_1",
+			"delete routetable id=rt_1",
+			"update securitygroup id=sg_2 inbound=revoke protocol=tcp portrange=22 securitygroup=sg_1",
+			"delete securitygroup id=sg_1",
+			"delete subnet id=sub_1",
+		}},
+		{res: eni, expStatements: []string{"detach networkinterface attachment=attach_1"}},
+		{res: inst},
+	}
+	for _, tcase := range tcases {
+		statements, unhandled, err := cascadeDeletion(g, tcase.res)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := statements, tcase.expStatements; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: got %q, want %q", tcase.res.Id(), got, want)
+		}
+		if len(unhandled) > 0 {
+			t.Fatalf("%s: unexpected unhandled dependents %v", tcase.res.Id(), unhandled)
+		}
+		for _, st := range statements {
+			if _, err := template.Parse(st); err != nil {
+				t.Fatalf("%s: %s", st, err)
+			}
+		}
+	}
+}
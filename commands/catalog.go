@@ -0,0 +1,43 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/conv"
+)
+
+func init() {
+	RootCmd.AddCommand(catalogCmd)
+}
+
+var catalogCmd = &cobra.Command{
+	Use:    "catalog",
+	Hidden: true,
+	Short:  "Export as JSON the catalog of properties extracted for each resource type (including extra properties)",
+
+	PersistentPreRun: applyHooks(initAwlessEnvHook),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		exitOn(enc.Encode(awsconv.Catalog()))
+	},
+}
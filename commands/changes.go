@@ -0,0 +1,183 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/sync/repo"
+)
+
+var (
+	changesSinceFlag   string
+	changesNoTrailFlag bool
+)
+
+func init() {
+	RootCmd.AddCommand(changesCmd)
+
+	changesCmd.Flags().StringVar(&changesSinceFlag, "since", "24h", "Start date (ex: 2017-06-01, '2017-06-01 15:04', RFC3339) or duration ago (ex: 36h, 7d)")
+	changesCmd.Flags().BoolVar(&changesNoTrailFlag, "no-trail", false, "Only report the changes of the synced snapshots, without CloudTrail attribution")
+}
+
+var changesCmd = &cobra.Command{
+	Use:   "changes",
+	Short: "Report per resource what changed and who changed it, from your synced snapshots and CloudTrail",
+	Example: `  awless changes                # what changed in the last 24 hours
+  awless changes --since 7d
+  awless changes --since 2017-06-01 --no-trail`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, err := parseHistoryDate(changesSinceFlag)
+		exitOn(err)
+		now := time.Now()
+
+		r, err := repo.New()
+		exitOn(err)
+		diffs, err := sync.History(r, config.GetAWSProfile(), []string{"global", config.GetAWSRegion()}, since, now)
+		if err == sync.ErrNoHistory {
+			logger.Warning(err)
+		} else {
+			exitOn(err)
+		}
+
+		var events []*awsservices.TrailEvent
+		if !changesNoTrailFlag {
+			if events, err = awsservices.LookupTrailEvents(since, now); err != nil {
+				logger.Warningf("changes not attributed: %s", err)
+			}
+		}
+
+		all := mergeResourcesChanges(diffs, events)
+		if len(all) == 0 {
+			logger.Infof("No changes since %s", since.Format(time.RFC3339))
+			return nil
+		}
+		for _, res := range all {
+			displayResourceChanges(res)
+		}
+		return nil
+	},
+}
+
+// resourceChange is either a change detected between synced snapshots or an API call recorded by CloudTrail
+type resourceChange struct {
+	date   time.Time
+	synced bool
+	op     string
+	text   string
+}
+
+type resourceChanges struct {
+	display string
+	changes []*resourceChange
+}
+
+// mergeResourcesChanges groups per resource the changes of the synced snapshots and the CloudTrail events,
+// in chronological order. Events reference resources either by ID or by ARN.
+func mergeResourcesChanges(diffs []*sync.RevisionDiff, events []*awsservices.TrailEvent) []*resourceChanges {
+	perID := make(map[string]*resourceChanges)
+	idsPerArn := make(map[string]string)
+	add := func(id, display string, c *resourceChange) {
+		res, ok := perID[id]
+		if !ok {
+			res = &resourceChanges{display: display}
+			perID[id] = res
+		}
+		res.changes = append(res.changes, c)
+	}
+	addSynced := func(r *graph.Resource, c *resourceChange) {
+		if arn, ok := r.Properties()[properties.Arn].(string); ok && arn != "" {
+			idsPerArn[arn] = r.Id()
+		}
+		c.synced = true
+		add(r.Id(), r.String(), c)
+	}
+
+	for _, diff := range diffs {
+		for _, r := range diff.Created {
+			addSynced(r, &resourceChange{date: diff.To.Date, op: "+", text: "created"})
+		}
+		for _, r := range diff.Deleted {
+			addSynced(r, &resourceChange{date: diff.To.Date, op: "-", text: "deleted"})
+		}
+		for _, r := range diff.Modified {
+			var names []string
+			for _, c := range r.Changes {
+				names = append(names, c.Name)
+			}
+			addSynced(r.Resource, &resourceChange{date: diff.To.Date, op: "~", text: fmt.Sprintf("modified (%s)", strings.Join(names, ", "))})
+		}
+	}
+
+	for _, e := range events {
+		for _, r := range e.Resources {
+			id := r.Name
+			if synced, ok := idsPerArn[r.Name]; ok {
+				id = synced
+			}
+			add(id, fmt.Sprintf("%s[%s]", r.Name, r.Type), &resourceChange{date: e.Date, text: fmt.Sprintf("%s by %s (%s)", e.EventName, e.Username, e.Source)})
+		}
+	}
+
+	var all []*resourceChanges
+	for _, res := range perID {
+		sort.SliceStable(res.changes, func(i, j int) bool { return res.changes[i].date.Before(res.changes[j].date) })
+		all = append(all, res)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if di, dj := all[i].changes[0].date, all[j].changes[0].date; !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		return all[i].display < all[j].display
+	})
+	return all
+}
+
+func displayResourceChanges(res *resourceChanges) {
+	fmt.Printf("▶ %s\n", res.display)
+	for _, c := range res.changes {
+		date := c.date.Local().Format("Mon Jan 2 15:04:05")
+		if !c.synced {
+			fmt.Printf("   %s   %s\n", date, c.text)
+			continue
+		}
+		op := c.op
+		switch c.op {
+		case "+":
+			op = renderGreenFn(c.op)
+		case "-":
+			op = renderRedFn(c.op)
+		case "~":
+			op = renderYellowFn(c.op)
+		}
+		fmt.Printf("   %s %s %s (synced)\n", date, op, c.text)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/sync/repo"
+)
+
+func TestMergeResourcesChanges(t *testing.T) {
+	now := time.Now()
+	diffs := []*sync.RevisionDiff{
+		{To: &repo.Rev{Date: now.Add(-2 * time.Hour)}, ResourcesDiff: &graph.ResourcesDiff{
+			Created: []*graph.Resource{resourcetest.Instance("i-1").Build()},
+		}},
+		{To: &repo.Rev{Date: now.Add(-time.Hour)}, ResourcesDiff: &graph.ResourcesDiff{
+			Deleted: []*graph.Resource{resourcetest.Role("AROA1").Prop(properties.Arn, "arn:aws:iam::123456789012:role/ops").Build()},
+			Modified: []*graph.ModifiedResource{
+				{Resource: resourcetest.Instance("i-1").Build(), Changes: []*graph.PropertyChange{{Name: "State"}, {Name: "Type"}}},
+			},
+		}},
+	}
+	events := []*awsservices.TrailEvent{
+		{Date: now.Add(-3 * time.Hour), Username: "bob", EventName: "RunInstances", Source: "ec2", Resources: []awsservices.TrailResource{{Name: "i-1", Type: "AWS::EC2::Instance"}}},
+		{Date: now.Add(-90 * time.Minute), Username: "alice", EventName: "DeleteRole", Source: "iam", Resources: []awsservices.TrailResource{{Name: "arn:aws:iam::123456789012:role/ops", Type: "AWS::IAM::Role"}}},
+		{Date: now.Add(-80 * time.Minute), Username: "alice", EventName: "CreateBucket", Source: "s3", Resources: []awsservices.TrailResource{{Name: "my-bucket", Type: "AWS::S3::Bucket"}}},
+	}
+
+	all := mergeResourcesChanges(diffs, events)
+	type change struct {
+		Date   time.Time
+		Synced bool
+		Text   string
+	}
+	var got [][]change
+	var displays []string
+	for _, res := range all {
+		displays = append(displays, res.display)
+		var changes []change
+		for _, c := range res.changes {
+			changes = append(changes, change{c.date, c.synced, c.text})
+		}
+		got = append(got, changes)
+	}
+
+	if want := []string{"i-1[instance]", "AROA1[role]", "my-bucket[AWS::S3::Bucket]"}; !reflect.DeepEqual(displays, want) {
+		t.Fatalf("got %q, want %q", displays, want)
+	}
+	exp := [][]change{
+		{
+			{now.Add(-3 * time.Hour), false, "RunInstances by bob (ec2)"},
+			{now.Add(-2 * time.Hour), true, "created"},
+			{now.Add(-time.Hour), true, "modified (State, Type)"},
+		},
+		{
+			{now.Add(-90 * time.Minute), false, "DeleteRole by alice (iam)"},
+			{now.Add(-time.Hour), true, "deleted"},
+		},
+		{
+			{now.Add(-80 * time.Minute), false, "CreateBucket by alice (s3)"},
+		},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v, want %v", got, exp)
+	}
+}
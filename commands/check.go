@@ -0,0 +1,119 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/cloud/rules"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+)
+
+func init() {
+	RootCmd.AddCommand(checkCmd)
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check RULES_FILE...",
+	Short: "Check the resources of your local graph against the rules of YAML files, exiting with an error status on violations (ex: in CI)",
+	Long: `Check the resources of your local graph (see 'awless sync') against user-defined rules, as in:
+
+  rules:
+  - name: no-public-ssh
+    description: security groups must not open SSH to the internet
+    type: securitygroup
+    assert: NOT inbound:22=0.0.0.0/0
+  - name: instances-named
+    type: instance
+    where: state=running
+    assert: tag:Name~.
+    severity: warning        # error (default) or warning
+
+The resources of the type selected by the 'where' query must match the 'assert' query (same syntax as 'awless list --query').
+The command exits with status 1 when a resource violates a rule of severity error.`,
+	Example: `  awless check rules.yml
+  awless check security.yml tagging.yml --format json`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("RULES_FILE required. See examples.")
+		}
+		rs, err := rules.LoadFiles(args...)
+		exitOn(err)
+
+		g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+		exitOn(err)
+
+		report, err := rules.Evaluate(g, rs...)
+		exitOn(err)
+
+		if console.IsStructuredFormat(formatGlobalFlag) {
+			exitOn(console.EncodeStructured(os.Stdout, formatGlobalFlag, report))
+		} else {
+			printCheckReport(report)
+		}
+
+		if !report.Passed() {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func printCheckReport(report *rules.Report) {
+	var checked, errs, warnings int
+	violations := report.Violations()
+	for _, res := range report.Results {
+		checked += res.Checked
+	}
+	for _, v := range violations {
+		if v.Severity == rules.Error {
+			errs++
+		} else {
+			warnings++
+		}
+	}
+
+	if len(violations) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "Rule\tSeverity\tType\tID\tName")
+		fmt.Fprintln(w, "----\t--------\t----\t--\t----")
+		for _, v := range violations {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", v.Rule, v.Severity, v.Type, v.ID, v.Name)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	summary := fmt.Sprintf("%d rules checked over %d resources: %d errors, %d warnings", len(report.Results), checked, errs, warnings)
+	if errs > 0 {
+		logger.Error(summary)
+	} else if warnings > 0 {
+		logger.Warning(summary)
+	} else {
+		logger.Info(summary)
+	}
+}
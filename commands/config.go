@@ -37,7 +37,7 @@ func init() {
 var configCmd = &cobra.Command{
 	Use:               "config",
 	Short:             "get, set, unset configuration values",
-	Example:           "  awless config        # list all your config\n  awless config set aws.region eu-west-1\n  awless config unset instance.count",
+	Example:           "  awless config        # list all your config\n  awless config set aws.region eu-west-1\n  awless config set defaults.instance.keypair ops  # keypair of created instances when not given\n  awless config unset instance.count\n  awless config set alias.up \"run ./infra/main.aws env=$1\"  # then: awless up dev",
 	PersistentPreRunE: initAwlessEnvHook,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -0,0 +1,262 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+// Confirmation backends (see `confirm.backend`)
+const (
+	terminalConfirmBackend = "terminal"
+	slackConfirmBackend    = "slack"
+	webhookConfirmBackend  = "webhook"
+	policyConfirmBackend   = "policy"
+	denyConfirmBackend     = "deny"
+)
+
+// confirmer approves or rejects the operations awless is about to perform
+type confirmer interface {
+	confirm(req *confirmRequest) (bool, error)
+}
+
+// confirmRequest is a question asked before operating on the cloud
+type confirmRequest struct {
+	prompt      string
+	template    *template.Template // nil when not confirming a template
+	defaults    []*template.AppliedDefault
+	blastRadius []*template.BlastRadius
+}
+
+func (r *confirmRequest) question() string {
+	return strings.TrimSuffix(strings.TrimSpace(r.prompt), " [y/N]")
+}
+
+// confirmWith asks the confirmation from the configured backend, replaying or recording the answer (see answers.go).
+// A replayed confirmation is printed along with the plan it confirms.
+func confirmWith(key string, req *confirmRequest) (bool, error) {
+	confirmed, err := answers.confirm(key, func() (bool, error) {
+		c, err := newConfirmer()
+		if err != nil {
+			return false, err
+		}
+		return c.confirm(req)
+	})
+	if err == nil && answers.replaying() {
+		w := promptOutput()
+		printPlan(w, req)
+		answer := "n"
+		if confirmed {
+			answer = "y"
+		}
+		fmt.Fprintf(w, "%s%s (from answers file)\n", req.prompt, answer)
+	}
+	return confirmed, err
+}
+
+// newConfirmer returns the backend configured with `confirm.backend`, asking in the terminal by default
+func newConfirmer() (confirmer, error) {
+	backend, _ := config.Config["confirm.backend"].(string)
+	if backend != policyConfirmBackend {
+		return newConfirmerForBackend(backend)
+	}
+	fallback, _ := config.Config["confirm.policy.fallback"].(string)
+	if fallback == policyConfirmBackend {
+		return nil, fmt.Errorf("invalid confirm.policy.fallback: %s", fallback)
+	}
+	next, err := newConfirmerForBackend(fallback)
+	if err != nil {
+		return nil, err
+	}
+	allowed, _ := config.Config["confirm.policy.allow"].(string)
+	return &policyConfirmer{allow: splitConfigList(allowed), fallback: next}, nil
+}
+
+func newConfirmerForBackend(backend string) (confirmer, error) {
+	switch backend {
+	case "", terminalConfirmBackend:
+		return &terminalConfirmer{}, nil
+	case denyConfirmBackend:
+		return &denyConfirmer{}, nil
+	case slackConfirmBackend:
+		token, _ := config.Config["confirm.slack.token"].(string)
+		channel, _ := config.Config["confirm.slack.channel"].(string)
+		if token == "" || channel == "" {
+			return nil, fmt.Errorf("slack confirmation: missing confirm.slack.token or confirm.slack.channel")
+		}
+		approvers, _ := config.Config["confirm.slack.approvers"].(string)
+		c := newSlackConfirmer(token, channel, splitConfigList(approvers))
+		if minutes, ok := config.Config["confirm.slack.timeout"].(int); ok && minutes > 0 {
+			c.timeout = time.Duration(minutes) * time.Minute
+		}
+		return c, nil
+	case webhookConfirmBackend:
+		url, _ := config.Config["confirm.webhook.url"].(string)
+		if url == "" {
+			return nil, fmt.Errorf("webhook confirmation: missing confirm.webhook.url")
+		}
+		token, _ := config.Config["confirm.webhook.token"].(string)
+		c := newWebhookConfirmer(url, token)
+		if minutes, ok := config.Config["confirm.webhook.timeout"].(int); ok && minutes > 0 {
+			c.timeout = time.Duration(minutes) * time.Minute
+		}
+		return c, nil
+	default:
+		return nil, fmt.Errorf("unknown confirmation backend '%s'", backend)
+	}
+}
+
+// terminalConfirmer prints the template and asks on stdin
+type terminalConfirmer struct{}
+
+func (c *terminalConfirmer) confirm(req *confirmRequest) (bool, error) {
+	w := promptOutput()
+	printPlan(w, req)
+	return promptConfirm(w, req.prompt)
+}
+
+// printPlan prints the template to confirm, if any, with its blast radius and applied defaults
+func printPlan(w io.Writer, req *confirmRequest) {
+	if req.template != nil {
+		fmt.Fprintf(w, "%s\n\n", renderGreenFn(req.template))
+		printBlastRadius(w, req.blastRadius)
+		printAppliedDefaults(w, req.defaults)
+	}
+}
+
+// promptOutput returns stderr with the structured formats, keeping stdout for the values only
+func promptOutput() io.Writer {
+	if console.IsStructuredFormat(formatGlobalFlag) {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// printBlastRadius prints the score of each delete/update statement, the riskiest highlighted
+func printBlastRadius(out io.Writer, all []*template.BlastRadius) {
+	if len(all) == 0 {
+		return
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "\tScore\tDependents\tStatement")
+	for _, r := range all {
+		fmt.Fprintf(w, "\t%d\t%s\t%s\n", r.Score, summarizeDependents(r.Dependents), r.Statement)
+	}
+	w.Flush()
+
+	riskiest := template.RiskiestBlastRadius(all)
+	fmt.Fprintln(out, "Blast radius (dependent resources in your local graph):")
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if i > 0 && all[i-1] == riskiest {
+			line = renderRedFn(line)
+		}
+		fmt.Fprintln(out, line)
+	}
+	fmt.Fprintln(out)
+}
+
+// summarizeDependents counts the dependents per type (ex: 3 instances, 1 subnet)
+func summarizeDependents(dependents []cloud.Resource) string {
+	if len(dependents) == 0 {
+		return "none"
+	}
+	counts := make(map[string]int)
+	var types []string
+	for _, r := range dependents {
+		if counts[r.Type()] == 0 {
+			types = append(types, r.Type())
+		}
+		counts[r.Type()]++
+	}
+	var parts []string
+	for _, t := range types {
+		if counts[t] > 1 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[t], cloud.PluralizeResource(t)))
+		} else {
+			parts = append(parts, fmt.Sprintf("1 %s", t))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// denyConfirmer rejects every request, as a policy fallback in noninteractive settings
+type denyConfirmer struct{}
+
+func (c *denyConfirmer) confirm(req *confirmRequest) (bool, error) {
+	logger.Warningf("%s: denied (see `awless config`)", req.question())
+	return false, nil
+}
+
+// policyConfirmer auto-approves the templates whose commands all match the allowed
+// `action.entity` patterns (ex: create.*, start.instance), and asks its fallback otherwise
+type policyConfirmer struct {
+	allow    []string
+	fallback confirmer
+}
+
+func (c *policyConfirmer) confirm(req *confirmRequest) (bool, error) {
+	if req.template != nil && c.allows(req.template) {
+		logger.Infof("%s: approved by policy (%s)", req.question(), strings.Join(c.allow, ", "))
+		return true, nil
+	}
+	return c.fallback.confirm(req)
+}
+
+func (c *policyConfirmer) allows(tpl *template.Template) bool {
+	cmds := tpl.CommandNodesIterator()
+	if len(cmds) == 0 {
+		return false
+	}
+	for _, cmd := range cmds {
+		if !c.allowsCommand(cmd.Action, cmd.Entity) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *policyConfirmer) allowsCommand(action, entity string) bool {
+	for _, pattern := range c.allow {
+		if ok, _ := path.Match(pattern, action+"."+entity); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func splitConfigList(s string) (list []string) {
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			list = append(list, e)
+		}
+	}
+	return
+}
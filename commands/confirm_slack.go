@@ -0,0 +1,200 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+const slackAPIURL = "https://slack.com/api"
+
+// Reactions of the approvers on the confirmation message
+var (
+	slackApproveReactions = []string{"white_check_mark", "heavy_check_mark", "+1"}
+	slackRejectReactions  = []string{"x", "no_entry", "-1"}
+)
+
+// slackConfirmer posts the confirmation to a Slack channel and waits for an approver
+// to react to the message: a check mark approves, a cross rejects
+type slackConfirmer struct {
+	token, channel string
+	approvers      []string // Slack user IDs, anyone in the channel when empty
+
+	apiURL       string
+	client       *http.Client
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+func newSlackConfirmer(token, channel string, approvers []string) *slackConfirmer {
+	return &slackConfirmer{
+		token:        token,
+		channel:      channel,
+		approvers:    approvers,
+		apiURL:       slackAPIURL,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		pollInterval: 5 * time.Second,
+		timeout:      15 * time.Minute,
+	}
+}
+
+type slackMessage struct {
+	Channel  string `json:"channel"`
+	Text     string `json:"text"`
+	ThreadTS string `json:"thread_ts,omitempty"`
+}
+
+type slackResponse struct {
+	OK      bool   `json:"ok"`
+	Error   string `json:"error"`
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+	Message struct {
+		Reactions []struct {
+			Name  string   `json:"name"`
+			Users []string `json:"users"`
+		} `json:"reactions"`
+	} `json:"message"`
+}
+
+func (c *slackConfirmer) confirm(req *confirmRequest) (bool, error) {
+	posted, err := c.call("chat.postMessage", &slackMessage{Channel: c.channel, Text: c.text(req)})
+	if err != nil {
+		return false, fmt.Errorf("slack confirmation: %s", err)
+	}
+	logger.Infof("waiting for approval in Slack channel %s (timeout %s)", c.channel, c.timeout)
+
+	deadline := time.Now().Add(c.timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(c.pollInterval)
+		resp, err := c.call("reactions.get?"+url.Values{"channel": {posted.Channel}, "timestamp": {posted.TS}, "full": {"true"}}.Encode(), nil)
+		if err != nil {
+			logger.Verbosef("slack confirmation: %s", err)
+			continue
+		}
+		approved, user, decided := c.decision(resp)
+		if !decided {
+			continue
+		}
+		verdict := "Rejected"
+		if approved {
+			verdict = "Approved"
+		}
+		logger.Infof("%s in Slack by %s", strings.ToLower(verdict), user)
+		if _, err := c.call("chat.postMessage", &slackMessage{Channel: posted.Channel, ThreadTS: posted.TS, Text: fmt.Sprintf("%s by <@%s>", verdict, user)}); err != nil {
+			logger.Verbosef("slack confirmation: %s", err)
+		}
+		return approved, nil
+	}
+	return false, fmt.Errorf("slack confirmation: no approval in channel %s after %s", c.channel, c.timeout)
+}
+
+// decision returns the verdict of the first approver found, a rejection prevailing over approvals
+func (c *slackConfirmer) decision(resp *slackResponse) (approved bool, user string, decided bool) {
+	for _, reaction := range resp.Message.Reactions {
+		for _, u := range reaction.Users {
+			if !c.isApprover(u) {
+				continue
+			}
+			switch {
+			case contains(slackRejectReactions, reaction.Name):
+				return false, u, true
+			case contains(slackApproveReactions, reaction.Name) && !decided:
+				approved, user, decided = true, u, true
+			}
+		}
+	}
+	return
+}
+
+func (c *slackConfirmer) isApprover(user string) bool {
+	return len(c.approvers) == 0 || contains(c.approvers, user)
+}
+
+func (c *slackConfirmer) text(req *confirmRequest) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "*awless* (profile `%s`, region `%s`): %s\n", config.GetAWSProfile(), config.GetAWSRegion(), req.question())
+	if req.template != nil {
+		fmt.Fprintf(&buf, "```\n%s\n```\n", req.template)
+	}
+	if riskiest := template.RiskiestBlastRadius(req.blastRadius); riskiest != nil {
+		fmt.Fprintf(&buf, "Riskiest statement (blast radius %d: %s): `%s`\n", riskiest.Score, summarizeDependents(riskiest.Dependents), riskiest.Statement)
+	}
+	for _, d := range req.defaults {
+		fmt.Fprintf(&buf, "_%s%s = %s (from defaults)_\n", config.DefaultsPrefix, d.Key, d.Value)
+	}
+	fmt.Fprintf(&buf, "React with :%s: to approve or :%s: to reject", slackApproveReactions[0], slackRejectReactions[0])
+	return buf.String()
+}
+
+// call invokes a Slack Web API method, with a JSON body when msg is not nil
+func (c *slackConfirmer) call(method string, msg *slackMessage) (*slackResponse, error) {
+	var req *http.Request
+	var err error
+	if msg != nil {
+		body, merr := json.Marshal(msg)
+		if merr != nil {
+			return nil, merr
+		}
+		req, err = http.NewRequest("POST", c.apiURL+"/"+method, bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+		}
+	} else {
+		req, err = http.NewRequest("GET", c.apiURL+"/"+method, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	httpResp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", method, httpResp.Status)
+	}
+	resp := &slackResponse{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("%s: %s", method, err)
+	}
+	if !resp.OK {
+		return nil, fmt.Errorf("%s: %s", strings.SplitN(method, "?", 2)[0], resp.Error)
+	}
+	return resp, nil
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/template"
+)
+
+type stubConfirmer struct {
+	asked  int
+	answer bool
+}
+
+func (c *stubConfirmer) confirm(req *confirmRequest) (bool, error) {
+	c.asked++
+	return c.answer, nil
+}
+
+func TestPolicyConfirmer(t *testing.T) {
+	tcases := []struct {
+		tpl          string
+		expApproved  bool
+		expFallbacks int
+	}{
+		{tpl: "create instance name=web\nstart instance id=i-1", expApproved: true},
+		{tpl: "create subnet cidr=10.0.0.0/24 vpc=vpc-1", expApproved: true},
+		{tpl: "create instance name=web\ndelete subnet id=sub-1", expFallbacks: 1},
+		{tpl: "delete instance id=i-1", expFallbacks: 1},
+	}
+	for _, tcase := range tcases {
+		fallback := &stubConfirmer{}
+		c := &policyConfirmer{allow: []string{"create.*", "start.instance"}, fallback: fallback}
+		approved, err := c.confirm(&confirmRequest{prompt: "Confirm? [y/N] ", template: template.MustParse(tcase.tpl)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := approved, tcase.expApproved; got != want {
+			t.Fatalf("%q: got %t, want %t", tcase.tpl, got, want)
+		}
+		if got, want := fallback.asked, tcase.expFallbacks; got != want {
+			t.Fatalf("%q: got %d fallbacks, want %d", tcase.tpl, got, want)
+		}
+	}
+
+	fallback := &stubConfirmer{answer: true}
+	c := &policyConfirmer{allow: []string{"*.*"}, fallback: fallback}
+	if approved, _ := c.confirm(&confirmRequest{prompt: "Continue anyway? [y/N] "}); !approved || fallback.asked != 1 {
+		t.Fatalf("expected request without template to be asked to fallback")
+	}
+}
+
+func TestSlackConfirmer(t *testing.T) {
+	tcases := []struct {
+		reactions   string
+		expApproved bool
+		expErr      bool
+	}{
+		{reactions: `[{"name":"white_check_mark","users":["U_APPROVER"]}]`, expApproved: true},
+		{reactions: `[{"name":"+1","users":["U_APPROVER"]},{"name":"x","users":["U_APPROVER"]}]`, expApproved: false},
+		{reactions: `[{"name":"white_check_mark","users":["U_OTHER"]}]`, expErr: true},
+		{reactions: `[]`, expErr: true},
+	}
+	for _, tcase := range tcases {
+		var mu sync.Mutex
+		var posted []slackMessage
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got, want := r.Header.Get("Authorization"), "Bearer xoxb-token"; got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+			switch r.URL.Path {
+			case "/chat.postMessage":
+				var msg slackMessage
+				if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+					t.Error(err)
+				}
+				mu.Lock()
+				posted = append(posted, msg)
+				mu.Unlock()
+				w.Write([]byte(`{"ok":true,"channel":"C123","ts":"1500000000.000100"}`))
+			case "/reactions.get":
+				if got, want := r.URL.Query().Get("timestamp"), "1500000000.000100"; got != want {
+					t.Errorf("got %s, want %s", got, want)
+				}
+				w.Write([]byte(`{"ok":true,"message":{"reactions":` + tcase.reactions + `}}`))
+			default:
+				w.Write([]byte(`{"ok":false,"error":"unknown_method"}`))
+			}
+		}))
+
+		c := newSlackConfirmer("xoxb-token", "C123", []string{"U_APPROVER"})
+		c.apiURL = server.URL
+		c.pollInterval = time.Millisecond
+		c.timeout = 20 * time.Millisecond
+
+		approved, err := c.confirm(&confirmRequest{prompt: "Confirm? [y/N] ", template: template.MustParse("delete instance id=i-1")})
+		server.Close()
+
+		if tcase.expErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", tcase.reactions)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.reactions, err)
+		}
+		if got, want := approved, tcase.expApproved; got != want {
+			t.Fatalf("%s: got %t, want %t", tcase.reactions, got, want)
+		}
+		if got, want := len(posted), 2; got != want {
+			t.Fatalf("%s: got %d messages, want %d", tcase.reactions, got, want)
+		}
+		if !strings.Contains(posted[0].Text, "delete instance id=i-1") {
+			t.Fatalf("%s: expected template in %q", tcase.reactions, posted[0].Text)
+		}
+		if got, want := posted[1].ThreadTS, "1500000000.000100"; got != want {
+			t.Fatalf("%s: got %s, want %s", tcase.reactions, got, want)
+		}
+	}
+}
+
+func TestWebhookConfirmer(t *testing.T) {
+	tcases := []struct {
+		posted, polled string
+		expApproved    bool
+		expErr         bool
+	}{
+		{posted: `{"status":"approved","approver":"alice"}`, expApproved: true},
+		{posted: `{"status":"pending","poll_url":"/approvals/1"}`, polled: `{"status":"approved","approver":"alice"}`, expApproved: true},
+		{posted: `{"status":"pending","poll_url":"/approvals/1"}`, polled: `{"status":"rejected","reason":"freeze"}`, expApproved: false},
+		{posted: `{"status":"pending","poll_url":"/approvals/1"}`, polled: `{"status":"pending"}`, expErr: true},
+		{posted: `{"status":"pending","poll_url":"approvals/1"}`, polled: `{"status":"approved"}`, expApproved: true},
+		{posted: `{"status":"pending","poll_url":"http://attacker.example.com/approvals/1"}`, expErr: true},
+		{posted: `{"status":"pending"}`, expErr: true},
+		{posted: `{"status":"unknown"}`, expErr: true},
+	}
+	for _, tcase := range tcases {
+		var received webhookApprovalRequest
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got, want := r.Header.Get("Authorization"), "Bearer secret"; got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+			switch {
+			case r.Method == "POST" && r.URL.Path == "/approve":
+				if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+					t.Error(err)
+				}
+				w.Write([]byte(tcase.posted))
+			case r.Method == "GET" && r.URL.Path == "/approvals/1":
+				w.Write([]byte(tcase.polled))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		tcase.posted = strings.Replace(tcase.posted, `"/approvals/1"`, `"`+server.URL+`/approvals/1"`, 1)
+
+		c := newWebhookConfirmer(server.URL+"/approve", "secret")
+		c.pollInterval = time.Millisecond
+		c.timeout = 20 * time.Millisecond
+
+		approved, err := c.confirm(&confirmRequest{prompt: "Confirm? [y/N] ", template: template.MustParse("delete instance id=i-1")})
+		server.Close()
+
+		if tcase.expErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", tcase.posted)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.posted, err)
+		}
+		if got, want := approved, tcase.expApproved; got != want {
+			t.Fatalf("%s: got %t, want %t", tcase.posted, got, want)
+		}
+		if got, want := received.Question, "Confirm?"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if !strings.Contains(received.Template, "delete instance id=i-1") {
+			t.Fatalf("expected template in %q", received.Template)
+		}
+	}
+}
+
+func TestSummarizeDependents(t *testing.T) {
+	if got, want := summarizeDependents(nil), "none"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	dependents := []cloud.Resource{
+		resourcetest.Database("db_1").Build(),
+		resourcetest.Instance("inst_1").Build(),
+		resourcetest.Instance("inst_2").Build(),
+		resourcetest.Subnet("sub_1").Build(),
+	}
+	if got, want := summarizeDependents(dependents), "1 database, 2 instances, 1 subnet"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
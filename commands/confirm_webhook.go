@@ -0,0 +1,187 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+// Statuses of the approval requests answered by the endpoint
+const (
+	webhookApproved = "approved"
+	webhookRejected = "rejected"
+	webhookPending  = "pending"
+)
+
+// webhookConfirmer posts the confirmation to an external approval endpoint (ex: a change management system)
+// and blocks until approved or rejected. The endpoint answers with a status, and while pending with the URL
+// polled for the decision, on the same scheme and host as the endpoint since it is sent the token
+type webhookConfirmer struct {
+	url, token string
+
+	client       *http.Client
+	pollInterval time.Duration
+	timeout      time.Duration
+}
+
+func newWebhookConfirmer(url, token string) *webhookConfirmer {
+	return &webhookConfirmer{
+		url:          url,
+		token:        token,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		pollInterval: 5 * time.Second,
+		timeout:      15 * time.Minute,
+	}
+}
+
+type webhookApprovalRequest struct {
+	Profile     string   `json:"profile"`
+	Region      string   `json:"region"`
+	Question    string   `json:"question"`
+	TemplateID  string   `json:"template_id,omitempty"`
+	Template    string   `json:"template,omitempty"`
+	BlastRadius int      `json:"blast_radius,omitempty"`
+	Defaults    []string `json:"defaults,omitempty"`
+}
+
+type webhookApprovalResponse struct {
+	Status   string `json:"status"`
+	Approver string `json:"approver"`
+	Reason   string `json:"reason"`
+	PollURL  string `json:"poll_url"`
+}
+
+func (c *webhookConfirmer) confirm(req *confirmRequest) (bool, error) {
+	approvalReq := &webhookApprovalRequest{Profile: config.GetAWSProfile(), Region: config.GetAWSRegion(), Question: req.question()}
+	if req.template != nil {
+		approvalReq.TemplateID, approvalReq.Template = req.template.ID, req.template.String()
+	}
+	if riskiest := template.RiskiestBlastRadius(req.blastRadius); riskiest != nil {
+		approvalReq.BlastRadius = riskiest.Score
+	}
+	for _, d := range req.defaults {
+		approvalReq.Defaults = append(approvalReq.Defaults, fmt.Sprintf("%s%s=%s", config.DefaultsPrefix, d.Key, d.Value))
+	}
+	body, err := json.Marshal(approvalReq)
+	if err != nil {
+		return false, err
+	}
+	resp, err := c.call("POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("webhook confirmation: %s", err)
+	}
+	if resp.Status == webhookPending {
+		if resp.PollURL == "" {
+			return false, fmt.Errorf("webhook confirmation: pending approval without poll_url")
+		}
+		logger.Infof("waiting for approval from %s (timeout %s)", c.url, c.timeout)
+	}
+
+	pollURL, err := c.resolvePollURL(resp.PollURL)
+	if err != nil {
+		return false, fmt.Errorf("webhook confirmation: %s", err)
+	}
+	deadline := time.Now().Add(c.timeout)
+	for {
+		switch resp.Status {
+		case webhookApproved, webhookRejected:
+			c.logDecision(resp)
+			return resp.Status == webhookApproved, nil
+		case webhookPending:
+		default:
+			return false, fmt.Errorf("webhook confirmation: unknown status '%s'", resp.Status)
+		}
+		if !time.Now().Before(deadline) {
+			return false, fmt.Errorf("webhook confirmation: no approval from %s after %s", c.url, c.timeout)
+		}
+		time.Sleep(c.pollInterval)
+		polled, err := c.call("GET", pollURL, nil)
+		if err != nil {
+			logger.Warningf("webhook confirmation: %s", err)
+			continue
+		}
+		resp = polled
+	}
+}
+
+// resolvePollURL resolves the poll_url against the URL of the endpoint, refusing
+// to send the token to another scheme or host
+func (c *webhookConfirmer) resolvePollURL(pollURL string) (string, error) {
+	if pollURL == "" {
+		return "", nil
+	}
+	base, err := url.Parse(c.url)
+	if err != nil {
+		return "", err
+	}
+	poll, err := url.Parse(pollURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid poll_url: %s", err)
+	}
+	poll = base.ResolveReference(poll)
+	if poll.Scheme != base.Scheme || poll.Host != base.Host {
+		return "", fmt.Errorf("poll_url %s is not on %s://%s", pollURL, base.Scheme, base.Host)
+	}
+	return poll.String(), nil
+}
+
+func (c *webhookConfirmer) logDecision(resp *webhookApprovalResponse) {
+	msg := resp.Status
+	if resp.Approver != "" {
+		msg = fmt.Sprintf("%s by %s", msg, resp.Approver)
+	}
+	if resp.Reason != "" {
+		msg = fmt.Sprintf("%s: %s", msg, resp.Reason)
+	}
+	logger.Info(msg)
+}
+
+func (c *webhookConfirmer) call(method, url string, body io.Reader) (*webhookApprovalResponse, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	httpResp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s: %s", method, url, httpResp.Status)
+	}
+	resp := &webhookApprovalResponse{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("%s %s: %s", method, url, err)
+	}
+	return resp, nil
+}
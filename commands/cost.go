@@ -0,0 +1,244 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/sync"
+)
+
+var (
+	costMonthFlag    string
+	costTagFlag      string
+	costForecastFlag bool
+)
+
+func init() {
+	RootCmd.AddCommand(costCmd)
+
+	costCmd.Flags().StringVar(&costMonthFlag, "month", "", "Month of the report (ex: 2017-06), the current month by default")
+	costCmd.Flags().StringVar(&costTagFlag, "tag", "", "Group the costs by the values of this tag key instead of by service (ex: Env)")
+	costCmd.Flags().BoolVar(&costForecastFlag, "forecast", false, "Estimate the costs at the end of the current month by extrapolating linearly the month-to-date costs")
+}
+
+var costCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "Report the spend of the account per service or per tag value, with the matching resources of your synced graphs",
+	Example: `  awless cost                     # spend of the current month per service
+  awless cost --forecast
+  awless cost --tag Env --month 2017-06`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now().UTC()
+		start, end, err := costPeriod(costMonthFlag, now)
+		exitOn(err)
+		if costForecastFlag && !now.Before(end) {
+			exitOn(fmt.Errorf("cannot forecast the costs of %s: month already ended", start.Format("2006-01")))
+		}
+
+		// Cost Explorer refuses periods ending after tomorrow
+		queryEnd := end
+		if tomorrow := now.Truncate(24 * time.Hour).Add(24 * time.Hour); tomorrow.Before(queryEnd) {
+			queryEnd = tomorrow
+		}
+		report, err := awsservices.GetCosts(start, queryEnd, costTagFlag)
+		exitOn(err)
+
+		g, err := sync.LoadAllLocalGraphs(config.GetAWSProfile())
+		exitOn(err)
+		all, err := g.Find(cloud.NewQuery(awsservices.ResourceTypes...))
+		exitOn(err)
+
+		rows := costRows(report, all, costTagFlag)
+		if costForecastFlag {
+			for _, r := range rows {
+				r.Forecast = forecastCost(r.Cost, start, end, now)
+			}
+		}
+
+		if console.IsStructuredFormat(formatGlobalFlag) {
+			exitOn(console.EncodeStructured(os.Stdout, formatGlobalFlag, rows))
+			return nil
+		}
+		displayCostRows(report, rows, start, end, now)
+		return nil
+	},
+}
+
+// costRow is the cost of a service or of a tag value joined with the resources it is
+// made of in the local graphs, counted by type
+type costRow struct {
+	Key       string         `json:"key"`
+	Cost      float64        `json:"cost"`
+	Forecast  float64        `json:"estimatedForecast,omitempty"`
+	Unit      string         `json:"unit"`
+	Resources map[string]int `json:"resources,omitempty"`
+}
+
+// costServicesResourceTypes are the types of the resources billed under the services of Cost Explorer
+var costServicesResourceTypes = map[string][]string{
+	"Amazon Elastic Compute Cloud - Compute": {cloud.Instance},
+	"EC2 - Other":                            {cloud.Volume, cloud.Snapshot, cloud.NatGateway, cloud.ElasticIP},
+	"Amazon Elastic Load Balancing":          {cloud.LoadBalancer},
+	"Amazon Relational Database Service":     {cloud.Database},
+	"Amazon DynamoDB":                        {cloud.Table},
+	"Amazon ElastiCache":                     {cloud.CacheCluster},
+	"Amazon Simple Storage Service":          {cloud.Bucket},
+	"Amazon Simple Notification Service":     {cloud.Topic},
+	"Amazon Simple Queue Service":            {cloud.Queue},
+	"Amazon Route 53":                        {cloud.Zone, cloud.HealthCheck},
+	"AWS Lambda":                             {cloud.Function},
+	"AWS Step Functions":                     {cloud.StateMachine},
+	"Amazon API Gateway":                     {cloud.RestApi},
+	"AmazonCloudWatch":                       {cloud.Alarm},
+	"Amazon CloudFront":                      {cloud.Distribution},
+	"Amazon EC2 Container Registry (ECR)":    {cloud.Repository},
+	"Amazon EC2 Container Service":           {cloud.ContainerCluster},
+	"AWS Key Management Service":             {cloud.Key},
+}
+
+// costRows joins the costs with the resources: per service through the types of their resources,
+// per tag value through the resources having this tag value
+func costRows(report *awsservices.CostReport, resources []cloud.Resource, tagKey string) []*costRow {
+	countsPerKey := make(map[string]map[string]int)
+	count := func(key, typ string) {
+		if countsPerKey[key] == nil {
+			countsPerKey[key] = make(map[string]int)
+		}
+		countsPerKey[key][typ]++
+	}
+	servicesPerType := make(map[string][]string)
+	for service, types := range costServicesResourceTypes {
+		for _, typ := range types {
+			servicesPerType[typ] = append(servicesPerType[typ], service)
+		}
+	}
+
+	for _, res := range resources {
+		if tagKey == "" {
+			for _, service := range servicesPerType[res.Type()] {
+				count(service, res.Type())
+			}
+			continue
+		}
+		tags, _ := res.Properties()[properties.Tags].([]string)
+		for _, tag := range tags {
+			if splits := strings.SplitN(tag, "=", 2); len(splits) == 2 && splits[0] == tagKey && splits[1] != "" {
+				count(splits[1], res.Type())
+			}
+		}
+	}
+
+	var rows []*costRow
+	for _, c := range report.Costs {
+		rows = append(rows, &costRow{Key: c.Key, Cost: c.Amount, Unit: c.Unit, Resources: countsPerKey[c.Key]})
+	}
+	return rows
+}
+
+// costPeriod returns the first day of the month and the first day of the following one
+func costPeriod(month string, now time.Time) (time.Time, time.Time, error) {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if month != "" {
+		var err error
+		if start, err = time.Parse("2006-01", month); err != nil {
+			return start, start, fmt.Errorf("invalid month '%s', expecting YYYY-MM (ex: 2017-06)", month)
+		}
+		if start.After(now) {
+			return start, start, fmt.Errorf("invalid month '%s': not started yet", month)
+		}
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// forecastCost extrapolates linearly the amount spent so far in the period to its end. This is
+// only an estimate: the forecasts of Cost Explorer (GetCostForecast) are not in the vendored SDK
+func forecastCost(amount float64, start, end, now time.Time) float64 {
+	if !now.Before(end) || !now.After(start) {
+		return amount
+	}
+	return amount * float64(end.Sub(start)) / float64(now.Sub(start))
+}
+
+func displayCostRows(report *awsservices.CostReport, rows []*costRow, start, end, now time.Time) {
+	title := "Service"
+	if costTagFlag != "" {
+		title = fmt.Sprintf("Tag %s", costTagFlag)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	if !noHeadersFlag {
+		if costForecastFlag {
+			fmt.Fprintf(w, "%s\tCost\tForecast (estimate)\tSynced resources\n", title)
+			fmt.Fprintf(w, "%s\t----\t-------------------\t----------------\n", strings.Repeat("-", len(title)))
+		} else {
+			fmt.Fprintf(w, "%s\tCost\tSynced resources\n", title)
+			fmt.Fprintf(w, "%s\t----\t----------------\n", strings.Repeat("-", len(title)))
+		}
+	}
+	for _, r := range rows {
+		key := r.Key
+		if key == "" {
+			key = "(untagged)"
+		}
+		if costForecastFlag {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", key, formatCost(r.Cost, r.Unit), formatCost(r.Forecast, r.Unit), summarizeResourcesCount(r.Resources))
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", key, formatCost(r.Cost, r.Unit), summarizeResourcesCount(r.Resources))
+		}
+	}
+	exitOn(w.Flush())
+
+	total := fmt.Sprintf("\nTotal from %s to %s: %s", start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02"), formatCost(report.Total.Amount, report.Total.Unit))
+	if costForecastFlag {
+		total += fmt.Sprintf(" (forecast %s, linear estimate)", formatCost(forecastCost(report.Total.Amount, start, end, now), report.Total.Unit))
+	}
+	if report.Estimated {
+		total += ", estimated"
+	}
+	fmt.Println(total)
+}
+
+func formatCost(amount float64, unit string) string {
+	return fmt.Sprintf("%.2f %s", amount, unit)
+}
+
+func summarizeResourcesCount(counts map[string]int) string {
+	var types []string
+	for typ := range counts {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	var all []string
+	for _, typ := range types {
+		all = append(all, fmt.Sprintf("%d %s", counts[typ], typ))
+	}
+	return strings.Join(all, ", ")
+}
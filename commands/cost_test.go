@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestCostRows(t *testing.T) {
+	resources := []cloud.Resource{
+		resourcetest.Instance("inst_1").Prop(properties.Tags, []string{"Env=prod"}).Build(),
+		resourcetest.Instance("inst_2").Prop(properties.Tags, []string{"Env=dev", "Team=web"}).Build(),
+		resourcetest.Bucket("bucket_1").Prop(properties.Tags, []string{"Env=prod"}).Build(),
+		resourcetest.Subnet("sub_1").Build(),
+	}
+
+	report := &awsservices.CostReport{Costs: []*awsservices.Cost{
+		{Key: "Amazon Elastic Compute Cloud - Compute", Amount: 12.5, Unit: "USD"},
+		{Key: "Amazon Simple Storage Service", Amount: 1, Unit: "USD"},
+		{Key: "Tax", Amount: 0.5, Unit: "USD"},
+	}}
+	exp := []*costRow{
+		{Key: "Amazon Elastic Compute Cloud - Compute", Cost: 12.5, Unit: "USD", Resources: map[string]int{"instance": 2}},
+		{Key: "Amazon Simple Storage Service", Cost: 1, Unit: "USD", Resources: map[string]int{"bucket": 1}},
+		{Key: "Tax", Cost: 0.5, Unit: "USD"},
+	}
+	if got, want := costRows(report, resources, ""), exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	report = &awsservices.CostReport{Costs: []*awsservices.Cost{
+		{Key: "prod", Amount: 10, Unit: "USD"},
+		{Key: "", Amount: 3, Unit: "USD"},
+		{Key: "dev", Amount: 1, Unit: "USD"},
+	}}
+	exp = []*costRow{
+		{Key: "prod", Cost: 10, Unit: "USD", Resources: map[string]int{"instance": 1, "bucket": 1}},
+		{Key: "", Cost: 3, Unit: "USD"},
+		{Key: "dev", Cost: 1, Unit: "USD", Resources: map[string]int{"instance": 1}},
+	}
+	if got, want := costRows(report, resources, "Env"), exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := summarizeResourcesCount(exp[0].Resources), "1 bucket, 1 instance"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestCostPeriodAndForecast(t *testing.T) {
+	now := time.Date(2017, 6, 11, 0, 0, 0, 0, time.UTC)
+	start, end, err := costPeriod("", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := start, time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := end, time.Date(2017, 7, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := forecastCost(10, start, end, now), 30.0; got != want {
+		t.Fatalf("got %f, want %f", got, want)
+	}
+	if got, want := forecastCost(10, start, end, end.Add(time.Hour)), 10.0; got != want {
+		t.Fatalf("got %f, want %f", got, want)
+	}
+
+	if start, _, err = costPeriod("2017-02", now); err != nil || !start.Equal(time.Date(2017, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("got %s, %v", start, err)
+	}
+	if _, _, err = costPeriod("2017-07", now); err == nil {
+		t.Fatal("expected error for month not started")
+	}
+	if _, _, err = costPeriod("june", now); err == nil {
+		t.Fatal("expected error for invalid month")
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+)
+
+var (
+	exportFormatFlag     string
+	importFormatFlag     string
+	exportAllRegionsFlag bool
+)
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVar(&exportFormatFlag, "format", graph.NQuadsFormat, fmt.Sprintf("Output format: %s", strings.Join(graph.DatasetFormats, ", ")))
+	exportCmd.Flags().BoolVar(&exportAllRegionsFlag, "all-regions", false, "Export the local graphs of all regions (see `aws.inventory.regions`) instead of the current one")
+
+	importGraphCmd.Flags().StringVar(&importFormatFlag, "format", "", fmt.Sprintf("Input format: %s (when empty: jsonld for .jsonld/.json files, nquads otherwise)", strings.Join(graph.DatasetFormats, ", ")))
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the locally synced graphs as N-Quads or JSON-LD, with a named graph per profile and region, for RDF tools and SPARQL stores",
+	Example: `  awless export > awless.nq
+  awless export --all-regions --format jsonld > awless.jsonld`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		regions := []string{config.GetAWSRegion()}
+		if exportAllRegionsFlag {
+			var err error
+			regions, err = awsservices.ResolveRegions()
+			exitOn(err)
+		}
+		regions = append([]string{"global"}, regions...)
+
+		profile := config.GetAWSProfile()
+		dataset := make(graph.Dataset)
+		for _, region := range regions {
+			g, err := sync.DefaultStore.Load(profile, []string{region})
+			exitOn(err)
+			if g.AsRDFGraphSnaphot().Count() > 0 {
+				dataset[fmt.Sprintf("%s/%s", profile, region)] = g
+			}
+		}
+		if len(dataset) == 0 {
+			logger.Warningf("no local graphs for profile '%s' in regions %s: run `awless sync` first", profile, strings.Join(regions, ", "))
+		}
+
+		exitOn(dataset.MarshalTo(os.Stdout, strings.ToLower(exportFormatFlag)))
+		return nil
+	},
+}
+
+var importGraphCmd = &cobra.Command{
+	Use:   "graph FILE",
+	Short: "Import graphs exported with `awless export` into the local graphs, replacing the synced graphs of their profiles and regions",
+	Example: `  awless import graph awless.nq
+  awless import graph awless.jsonld`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initSyncerHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("FILE required. See examples.")
+		}
+		format := strings.ToLower(importFormatFlag)
+		if format == "" {
+			switch strings.ToLower(filepath.Ext(args[0])) {
+			case ".jsonld", ".json":
+				format = graph.JSONLDFormat
+			default:
+				format = graph.NQuadsFormat
+			}
+		}
+
+		f, err := os.Open(args[0])
+		exitOn(err)
+		dataset, err := graph.UnmarshalDataset(f, format)
+		f.Close()
+		exitOn(err)
+
+		typesPerService := make(map[string][]string)
+		for typ, service := range awsservices.ServicePerResourceType {
+			typesPerService[service] = append(typesPerService[service], typ)
+		}
+
+		var names, paths []string
+		for name := range dataset {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			splits := strings.Split(name, "/")
+			if len(splits) != 2 || splits[0] == "" || splits[1] == "" {
+				logger.Warningf("skipping graph '%s': expected a graph named <profile>/<region>", name)
+				continue
+			}
+			profile, region := splits[0], splits[1]
+			for service, types := range typesPerService {
+				g, err := dataset[name].ResourcesGraph(types...)
+				exitOn(err)
+				if g.AsRDFGraphSnaphot().Count() == 0 {
+					continue
+				}
+				path, err := sync.DefaultStore.Save(profile, region, service, g)
+				exitOn(err)
+				if path != "" {
+					paths = append(paths, path)
+				}
+			}
+			logger.Infof("imported graph of profile '%s' in region '%s'", profile, region)
+		}
+
+		if runtime.GOOS != "windows" && len(paths) > 0 { // https://github.com/wallix/awless/issues/119
+			exitOn(sync.DefaultSyncer.Commit(paths...))
+		}
+		return nil
+	},
+}
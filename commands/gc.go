@@ -0,0 +1,149 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync/repo"
+)
+
+// leftovers older than this are considered orphaned (i.e. not in use by a running awless)
+const orphanedLeftoverAge = 24 * time.Hour
+
+func init() {
+	RootCmd.AddCommand(gcCmd)
+}
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Prune old graph snapshots, leftovers of interrupted commands, stale caches and compact the local database",
+	Long: `Prune the local awless data according to the retentions in config (in days, 0 keeps all):
+  gc.snapshots.retention: synced graph snapshots (used by 'awless history' and 'awless list --at')
+  gc.cache.retention:     cached files (regions catalog, credentials, etc.)
+  gc.templates.retention: templates runs (used by 'awless log' and 'awless revert')
+To delete unused cloud resources instead, see 'awless gc resources'.`,
+	Example: `  awless gc
+  awless config set gc.snapshots.retention 30 && awless gc`,
+	PersistentPreRun: applyHooks(initLoggerHook, initAwlessEnvHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshotsRetention, cacheRetention, templatesRetention := config.GetGCRetentions()
+		now := time.Now()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		var total int64
+		report := func(data, done string, reclaimed int64) {
+			total += reclaimed
+			fmt.Fprintf(w, "%s\t%s\t%s\n", data, done, console.HumanizeBytes(uint64(reclaimed)))
+		}
+		fmt.Fprintln(w, "Data\tPruned\tReclaimed")
+		fmt.Fprintln(w, "----\t------\t---------")
+
+		if snapshotsRetention > 0 {
+			gitDir := filepath.Join(repo.BaseDir(), ".git")
+			before := dirSize(gitDir)
+			pruned, err := repo.PruneRevisions(repo.BaseDir(), now.Add(-snapshotsRetention))
+			if err != nil {
+				logger.Errorf("pruning graph snapshots: %s", err)
+			} else {
+				report("graph snapshots", fmt.Sprintf("%d revisions", pruned), before-dirSize(gitDir))
+			}
+		}
+
+		var leftovers []string
+		leftovers = append(leftovers, repo.PruningDir(repo.BaseDir()), database.CompactingPath(config.DBPath))
+		rdpFiles, _ := filepath.Glob(filepath.Join(os.TempDir(), "awless-*.rdp"))
+		leftovers = append(leftovers, rdpFiles...)
+		count, reclaimed := removeOlderThan(now.Add(-orphanedLeftoverAge), leftovers...)
+		report("leftovers of interrupted commands", fmt.Sprintf("%d files", count), reclaimed)
+
+		if cacheRetention > 0 {
+			var cached []string
+			filepath.Walk(os.Getenv("__AWLESS_CACHE"), func(path string, info os.FileInfo, err error) error {
+				if err == nil && !info.IsDir() {
+					cached = append(cached, path)
+				}
+				return nil
+			})
+			count, reclaimed := removeOlderThan(now.Add(-cacheRetention), cached...)
+			report("caches", fmt.Sprintf("%d files", count), reclaimed)
+		}
+
+		if templatesRetention > 0 {
+			var deleted int
+			err := database.Execute(func(db *database.DB) (derr error) {
+				deleted, derr = db.DeleteTemplatesBefore(now.Add(-templatesRetention))
+				return
+			})
+			if err != nil {
+				logger.Errorf("pruning templates runs: %s", err)
+			} else {
+				report("templates runs", fmt.Sprintf("%d runs", deleted), 0)
+			}
+		}
+
+		compacted, err := database.Compact()
+		if err != nil {
+			logger.Errorf("compacting database: %s", err)
+		} else {
+			report("database", "compacted", compacted)
+		}
+
+		w.Flush()
+		logger.Infof("%s reclaimed", console.HumanizeBytes(uint64(total)))
+		return nil
+	},
+}
+
+// removeOlderThan removes the files and dirs last modified before the date, returning
+// how many were removed and the space reclaimed. Missing paths are ignored.
+func removeOlderThan(date time.Time, paths ...string) (count int, reclaimed int64) {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Before(date) {
+			continue
+		}
+		size := dirSize(path)
+		if err := os.RemoveAll(path); err != nil {
+			logger.Warningf("cannot remove %s: %s", path, err)
+			continue
+		}
+		count++
+		reclaimed += size
+	}
+	return
+}
+
+func dirSize(path string) (size int64) {
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return
+}
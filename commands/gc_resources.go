@@ -0,0 +1,241 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/template"
+)
+
+var (
+	gcStoppedDaysFlag int
+	gcOutputFlag      string
+	gcRunFlag         bool
+)
+
+func init() {
+	gcCmd.AddCommand(gcResourcesCmd)
+
+	gcResourcesCmd.Flags().IntVar(&gcStoppedDaysFlag, "stopped-days", 30, "Collect the instances stopped for more than this number of days")
+	gcResourcesCmd.Flags().StringVarP(&gcOutputFlag, "output", "o", "", "Write the deletion template to this file, to review it before 'awless run'")
+	gcResourcesCmd.Flags().BoolVar(&gcRunFlag, "run", false, "Run the deletion template, displayed for confirmation")
+}
+
+var gcResourcesCmd = &cobra.Command{
+	Use:   "resources",
+	Short: "Generate a template deleting the unused resources of your synced infrastructure",
+	Long: `Detect the unused resources of the local graph of the current region and generate a template deleting them:
+  unattached volumes and elastic IPs, security groups no resource depends on, instances stopped
+  long ago and untagged images not used by instances, launch configurations or launch templates.
+The template is printed (or written with --output) for review, each statement commented with its reason.`,
+	Example: `  awless gc resources
+  awless gc resources --stopped-days 90 -o cleanup.aws && awless run cleanup.aws
+  awless gc resources --run`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !localGlobalFlag {
+			srv, err := cloud.GetServiceForType(cloud.Instance)
+			exitOn(err)
+			logger.Verbosef("syncing service %s before collecting unused resources", srv.Name())
+			if _, err := sync.DefaultSyncer.Sync(srv); err != nil {
+				logger.Verbose(err)
+			}
+		}
+		gph, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+		exitOn(err)
+		g, ok := gph.(*graph.Graph)
+		if !ok {
+			exitOn(fmt.Errorf("cannot collect resources: unexpected graph %T", gph))
+		}
+
+		unused, err := unusedResources(g, time.Now().UTC(), time.Duration(gcStoppedDaysFlag)*24*time.Hour, launchTemplatesImages())
+		exitOn(err)
+		if len(unused) == 0 {
+			logger.Info("No unused resources found")
+			return nil
+		}
+		text := unusedResourcesTemplate(unused)
+
+		if gcOutputFlag != "" {
+			exitOn(ioutil.WriteFile(gcOutputFlag, []byte(text), 0600))
+			logger.Infof("Deletion template of %d resources written to %s (review it, then: awless run %s)", len(unused), gcOutputFlag, gcOutputFlag)
+		}
+		if gcRunFlag {
+			tpl, err := template.Parse(text)
+			exitOn(err)
+			exitOn(NewRunner(tpl, "Deletion of unused resources", gcOutputFlag).Run())
+			return nil
+		}
+		if gcOutputFlag == "" {
+			fmt.Fprint(os.Stdout, text)
+		}
+		return nil
+	},
+}
+
+// launchTemplatesImages returns the images referenced by launch templates, which are not synced,
+// or nil when they cannot be fetched
+func launchTemplatesImages() []string {
+	if localGlobalFlag {
+		logger.Warning("launch templates are not synced: images are not collected with --local")
+		return nil
+	}
+	infra, ok := awsservices.InfraService.(*awsservices.Infra)
+	if !ok {
+		logger.Warning("cannot fetch launch templates: images are not collected")
+		return nil
+	}
+	images, err := infra.LaunchTemplatesImages()
+	if err != nil {
+		logger.Warningf("cannot fetch launch templates: images are not collected: %s", err)
+		return nil
+	}
+	return images
+}
+
+// unusedResource is a resource to collect and why
+type unusedResource struct {
+	res       cloud.Resource
+	reason    string
+	statement string
+}
+
+// unusedResources returns the unattached volumes and elastic IPs, the security groups no resource
+// depends on, the instances stopped for longer than the given duration and the untagged images not used
+// by instances, launch configurations or the given images of launch templates, sorted by type then ID.
+// Images are not collected when the images of launch templates are unknown (nil).
+func unusedResources(g *graph.Graph, now time.Time, stoppedFor time.Duration, launchTemplatesImages []string) ([]*unusedResource, error) {
+	var unused []*unusedResource
+	var err error
+	find := func(typ string) []cloud.Resource {
+		if err != nil {
+			return nil
+		}
+		var rs []cloud.Resource
+		rs, err = g.Find(cloud.NewQuery(typ))
+		return rs
+	}
+
+	instances := find(cloud.Instance)
+	if launchTemplatesImages != nil {
+		imagesInUse := make(map[string]bool)
+		for _, res := range append(instances, find(cloud.LaunchConfiguration)...) {
+			imagesInUse[fmt.Sprint(res.Properties()[properties.Image])] = true
+		}
+		for _, img := range launchTemplatesImages {
+			imagesInUse[img] = true
+		}
+		for _, img := range find(cloud.Image) {
+			tags, _ := img.Properties()[properties.Tags].([]string)
+			if len(tags) == 0 && !imagesInUse[img.Id()] {
+				unused = append(unused, &unusedResource{res: img, reason: "untagged image not used by instances, launch configurations or launch templates", statement: fmt.Sprintf("delete image id=%s delete-snapshots=true", img.Id())})
+			}
+		}
+	}
+
+	for _, eip := range find(cloud.ElasticIP) {
+		if assoc, _ := eip.Properties()[properties.Association].(string); assoc == "" {
+			unused = append(unused, &unusedResource{res: eip, reason: "elastic IP not associated", statement: fmt.Sprintf("delete elasticip id=%s", eip.Id())})
+		}
+	}
+
+	for _, inst := range instances {
+		if state, _ := inst.Properties()[properties.State].(string); state != "stopped" {
+			continue
+		}
+		stopped, ok := instanceStopTime(inst)
+		if ok && now.Sub(stopped) > stoppedFor {
+			reason := fmt.Sprintf("instance stopped %d days ago", int(now.Sub(stopped).Hours()/24))
+			unused = append(unused, &unusedResource{res: inst, reason: reason, statement: fmt.Sprintf("delete instance id=%s", inst.Id())})
+		}
+	}
+
+	for _, sg := range find(cloud.SecurityGroup) {
+		if name, _ := sg.Properties()[properties.Name].(string); name == "default" {
+			continue
+		}
+		res, ok := sg.(*graph.Resource)
+		if !ok {
+			continue
+		}
+		dependents, derr := g.Dependents(res)
+		if derr != nil {
+			return nil, derr
+		}
+		if len(dependents) == 0 {
+			unused = append(unused, &unusedResource{res: sg, reason: "security group no resource depends on", statement: fmt.Sprintf("delete securitygroup id=%s", sg.Id())})
+		}
+	}
+
+	for _, vol := range find(cloud.Volume) {
+		if state, _ := vol.Properties()[properties.State].(string); state == "available" {
+			unused = append(unused, &unusedResource{res: vol, reason: "volume not attached", statement: fmt.Sprintf("delete volume id=%s", vol.Id())})
+		}
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].res.Type() != unused[j].res.Type() {
+			return unused[i].res.Type() < unused[j].res.Type()
+		}
+		return unused[i].res.Id() < unused[j].res.Id()
+	})
+	return unused, err
+}
+
+// stateTransitionTimeRegex matches the time of the state transition reasons of instances
+// (ex: "User initiated (2017-06-01 12:34:56 GMT)")
+var stateTransitionTimeRegex = regexp.MustCompile(`\((\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}) GMT\)`)
+
+// instanceStopTime returns the time an instance was stopped, given by its state transition reason
+func instanceStopTime(inst cloud.Resource) (time.Time, bool) {
+	reason, _ := inst.Properties()[properties.StateMessage].(string)
+	matches := stateTransitionTimeRegex.FindStringSubmatch(reason)
+	if len(matches) < 2 {
+		return time.Time{}, false
+	}
+	stopped, err := time.Parse("2006-01-02 15:04:05", matches[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return stopped, true
+}
+
+func unusedResourcesTemplate(unused []*unusedResource) string {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Deletion of %d unused resources generated by 'awless gc resources'\n", len(unused))
+	fmt.Fprintln(&buf, "# Review it and remove the statements of the resources to keep")
+	for _, u := range unused {
+		fmt.Fprintf(&buf, "\n# %s: %s\n%s\n", u.res.String(), u.reason, u.statement)
+	}
+	return buf.String()
+}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/template"
+)
+
+func TestUnusedResources(t *testing.T) {
+	now := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Volume("vol_1").Prop(properties.State, "available").Build(),
+		resourcetest.Volume("vol_2").Prop(properties.State, "in-use").Build(),
+		resourcetest.ElasticIP("eip_1").Build(),
+		resourcetest.ElasticIP("eip_2").Prop(properties.Association, "eipassoc_1").Build(),
+		resourcetest.SecurityGroup("sg_default").Prop(properties.Name, "default").Build(),
+		resourcetest.SecurityGroup("sg_empty").Build(),
+		resourcetest.SecurityGroup("sg_used").Build(),
+		resourcetest.Instance("inst_old").Prop(properties.State, "stopped").Prop(properties.StateMessage, "User initiated (2017-04-17 08:30:00 GMT)").Prop(properties.Image, "ami_used").Build(),
+		resourcetest.Instance("inst_recent").Prop(properties.State, "stopped").Prop(properties.StateMessage, "User initiated (2017-05-27 08:30:00 GMT)").Prop(properties.Launched, now.AddDate(0, 0, -90)).Build(),
+		resourcetest.Instance("inst_unknown_stop").Prop(properties.State, "stopped").Prop(properties.Launched, now.AddDate(0, 0, -90)).Build(),
+		resourcetest.Instance("inst_running").Prop(properties.State, "running").Prop(properties.Launched, now.AddDate(0, 0, -45)).Prop(properties.SecurityGroups, []string{"sg_used"}).Build(),
+		resourcetest.Image("ami_used").Build(),
+		resourcetest.Image("ami_tagged").Prop(properties.Tags, []string{"Env=prod"}).Build(),
+		resourcetest.Image("ami_untagged").Build(),
+		resourcetest.Image("ami_launchconfig").Build(),
+		resourcetest.Image("ami_launchtemplate").Build(),
+		resourcetest.LaunchConfiguration("lc_1").Prop(properties.Image, "ami_launchconfig").Build(),
+	)
+
+	unused, err := unusedResources(g, now, 30*24*time.Hour, []string{"ami_launchtemplate"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var statements []string
+	for _, u := range unused {
+		statements = append(statements, u.statement)
+	}
+	exp := []string{
+		"delete elasticip id=eip_1",
+		"delete image id=ami_untagged delete-snapshots=true",
+		"delete instance id=inst_old",
+		"delete securitygroup id=sg_empty",
+		"delete volume id=vol_1",
+	}
+	if got, want := statements, exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := unused[2].reason, "instance stopped 44 days ago"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	tpl, err := template.Parse(unusedResourcesTemplate(unused))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(tpl.CommandNodesIterator()), 5; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	unused, err = unusedResources(g, now, 30*24*time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range unused {
+		if u.res.Type() == "image" {
+			t.Fatalf("got %s, want no image collected when launch templates are unknown", u.statement)
+		}
+	}
+}
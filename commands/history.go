@@ -18,108 +18,157 @@ package commands
 
 import (
 	"fmt"
-	"os"
-
-	"github.com/wallix/awless/aws/services"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
-	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/config"
-	"github.com/wallix/awless/console"
 	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/sync/repo"
 )
 
 var (
-	showProperties bool
+	showProperties  bool
+	historyFromFlag string
+	historyToFlag   string
 )
 
 func init() {
 	RootCmd.AddCommand(historyCmd)
 
 	historyCmd.Flags().BoolVar(&showProperties, "properties", false, "Full diff with resources properties")
+	historyCmd.Flags().StringVar(&historyFromFlag, "from", "", "Start date (ex: 2017-06-01, '2017-06-01 15:04', RFC3339) or duration ago (ex: 36h, 7d) (default: first sync)")
+	historyCmd.Flags().StringVar(&historyToFlag, "to", "", "End date (ex: 2017-06-30, '2017-06-30 15:04', RFC3339) or duration ago (ex: 36h, 7d) (default: now)")
 }
 
 var historyCmd = &cobra.Command{
-	Use:               "history",
-	Hidden:            true,
-	Short:             "(in progress) Show a infra resource history & changes using your locally sync snapshots",
-	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
+	Use:   "history [REFERENCE]",
+	Short: "Show the changes of resources (or of a given resource) from your locally synced snapshots",
+	Example: `  awless history --from 7d                   # resources created, deleted or modified in the last 7 days
+  awless history i-8d43b21b --properties     # when did this instance appear and how did it change
+  awless history sg-1234 --from 2017-06-01 --to 2017-06-30 --properties
+  awless list instances --at 2017-06-01      # instances as synced at a past date`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
-		region := config.GetAWSRegion()
-
-		root := graph.InitResource(cloud.Region, region)
-
-		var diffs []*sync.Diff
-
-		all, err := sync.DefaultSyncer.List()
-		exitOn(err)
-
-		for i := 1; i < len(all); i++ {
-			from, err := sync.DefaultSyncer.LoadRev(all[i-1].Id)
+		from, to := time.Time{}, time.Now()
+		var err error
+		if historyFromFlag != "" {
+			from, err = parseHistoryDate(historyFromFlag)
 			exitOn(err)
-
-			to, err := sync.DefaultSyncer.LoadRev(all[i].Id)
+		}
+		if historyToFlag != "" {
+			to, err = parseHistoryDate(historyToFlag)
 			exitOn(err)
+		}
 
-			d, err := sync.BuildDiff(from, to, root.Id())
-			exitOn(err)
+		r, err := repo.New()
+		exitOn(err)
+		diffs, err := sync.History(r, config.GetAWSProfile(), []string{"global", config.GetAWSRegion()}, from, to)
+		exitOn(err)
 
-			diffs = append(diffs, d)
+		var ref string
+		if len(args) > 0 {
+			ref = deprefix(args[0])
 		}
 
+		var found bool
 		for _, diff := range diffs {
-			displayRevisionDiff(diff, awsservices.InfraService.Name(), root, verboseGlobalFlag)
+			if ref != "" {
+				diff = filterRevisionDiff(diff, ref)
+				if diff.Empty() {
+					continue
+				}
+			}
+			found = true
+			displayRevisionDiff(diff)
+		}
+		if !found {
+			if ref != "" {
+				logger.Infof("No changes of '%s' in synced snapshots", ref)
+			} else {
+				logger.Info("No changes in synced snapshots")
+			}
 		}
-
 		return nil
 	},
 }
 
-func displayRevisionDiff(diff *sync.Diff, cloudService string, root *graph.Resource, verbose bool) {
-	fromRevision := "repository creation"
-	if diff.From.Id != "" {
-		fromRevision = diff.From.Id[:7] + " on " + diff.From.Date.Format("Monday January 2, 15:04")
+func displayRevisionDiff(diff *sync.RevisionDiff) {
+	fromRevision := "first sync"
+	if diff.From != nil {
+		fromRevision = diff.From.DateString()
+	}
+	fmt.Printf("▶ %s (from %s, revision %.7s)\n", diff.To.DateString(), fromRevision, diff.To.Id)
+	for _, r := range diff.Created {
+		fmt.Printf("   %s %s\n", renderGreenFn("+"), r)
+	}
+	for _, r := range diff.Deleted {
+		fmt.Printf("   %s %s\n", renderRedFn("-"), r)
 	}
+	for _, r := range diff.Modified {
+		if !showProperties {
+			var names []string
+			for _, c := range r.Changes {
+				names = append(names, c.Name)
+			}
+			fmt.Printf("   %s %s (%s)\n", renderYellowFn("~"), r.Resource, strings.Join(names, ", "))
+			continue
+		}
+		fmt.Printf("   %s %s\n", renderYellowFn("~"), r.Resource)
+		for _, c := range r.Changes {
+			fmt.Printf("       %s\n", c)
+		}
+	}
+	fmt.Println()
+}
 
-	var graphdiff *graph.Diff
-	if cloudService == awsservices.InfraService.Name() {
-		graphdiff = diff.InfraDiff
+// filterRevisionDiff keeps the changes of the resource with the given id or name
+func filterRevisionDiff(diff *sync.RevisionDiff, ref string) *sync.RevisionDiff {
+	matches := func(r *graph.Resource) bool {
+		name, _ := r.Properties()[properties.Name].(string)
+		return r.Id() == ref || name == ref
+	}
+	filtered := &sync.RevisionDiff{From: diff.From, To: diff.To, ResourcesDiff: new(graph.ResourcesDiff)}
+	for _, r := range diff.Created {
+		if matches(r) {
+			filtered.Created = append(filtered.Created, r)
+		}
+	}
+	for _, r := range diff.Deleted {
+		if matches(r) {
+			filtered.Deleted = append(filtered.Deleted, r)
+		}
 	}
+	for _, r := range diff.Modified {
+		if matches(r.Resource) {
+			filtered.Modified = append(filtered.Modified, r)
+		}
+	}
+	return filtered
+}
 
-	if showProperties {
-		if graphdiff.HasDiff() {
-			fmt.Println("▶", cloudService, "properties, from", fromRevision,
-				"to", diff.To.Id[:7], "on", diff.To.Date.Format("Monday January 2, 15:04"))
-			displayer, err := console.BuildOptions(
-				console.WithFormat("table"),
-				console.WithRootNode(root),
-			).SetSource(graphdiff).Build()
-			exitOn(err)
-			exitOn(displayer.Print(os.Stdout))
-			fmt.Println()
-		} else if verbose {
-			fmt.Println("▶", cloudService, "properties, from", fromRevision,
-				"to", diff.To.Id[:7], "on", diff.To.Date.Format("Monday January 2, 15:04"))
-			fmt.Println("No changes.")
+var historyDateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// parseHistoryDate parses a date (in local time when without zone) or a duration ago (ex: 36h, 7d)
+func parseHistoryDate(s string) (time.Time, error) {
+	for _, layout := range historyDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
 		}
-	} else {
-		if graphdiff.HasDiff() {
-			fmt.Println("▶", cloudService, "resources, from", fromRevision,
-				"to", diff.To.Id[:7], "on", diff.To.Date.Format("Monday January 2, 15:04"))
-			displayer, err := console.BuildOptions(
-				console.WithFormat("tree"),
-				console.WithRootNode(root),
-			).SetSource(graphdiff).Build()
-			exitOn(err)
-			exitOn(displayer.Print(os.Stdout))
-			fmt.Println()
-		} else if verbose {
-			fmt.Println("▶", cloudService, "resources, from", fromRevision,
-				"to", diff.To.Id[:7], "on", diff.To.Date.Format("Monday January 2, 15:04"))
-			fmt.Println("No resource changes.")
+	}
+	if strings.HasSuffix(s, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil {
+			return time.Now().AddDate(0, 0, -days), nil
 		}
 	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date '%s': expected a date (ex: 2017-06-01, '2017-06-01 15:04', RFC3339) or a duration ago (ex: 36h, 7d)", s)
 }
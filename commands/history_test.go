@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHistoryDate(t *testing.T) {
+	tcases := []struct {
+		in  string
+		exp time.Time
+	}{
+		{"2017-06-01", time.Date(2017, 6, 1, 0, 0, 0, 0, time.Local)},
+		{"2017-06-01 15:04", time.Date(2017, 6, 1, 15, 4, 0, 0, time.Local)},
+		{"2017-06-01T15:04:05Z", time.Date(2017, 6, 1, 15, 4, 5, 0, time.UTC)},
+	}
+	for _, tcase := range tcases {
+		got, err := parseHistoryDate(tcase.in)
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.in, err)
+		}
+		if !got.Equal(tcase.exp) {
+			t.Fatalf("%s: got %s, want %s", tcase.in, got, tcase.exp)
+		}
+	}
+
+	got, err := parseHistoryDate("7d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := time.Now().AddDate(0, 0, -7); exp.Sub(got) > time.Minute || got.Sub(exp) > time.Minute {
+		t.Fatalf("got %s, want %s", got, exp)
+	}
+	got, err = parseHistoryDate("36h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := time.Now().Add(-36 * time.Hour); exp.Sub(got) > time.Minute || got.Sub(exp) > time.Minute {
+		t.Fatalf("got %s, want %s", got, exp)
+	}
+
+	if _, err = parseHistoryDate("yesterday"); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/conv"
 	"github.com/wallix/awless/aws/services"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/config"
@@ -46,6 +47,14 @@ func initAwlessEnvHook(cmd *cobra.Command, args []string) error {
 	if err := config.InitAwlessEnv(); err != nil {
 		return fmt.Errorf("cannot init awless environment: %s", err)
 	}
+	if err := awsconv.LoadExtraPropertiesFile(config.ExtraPropertiesPath); err != nil {
+		return fmt.Errorf("cannot load extra properties: %s", err)
+	}
+	store, err := sync.NewStore(config.GetGraphStore())
+	if err != nil {
+		return err
+	}
+	sync.DefaultStore = store
 	if awsRegionGlobalFlag != "" {
 		if err := config.SetVolatile(config.RegionConfigKey, awsRegionGlobalFlag); err != nil {
 			return err
@@ -80,11 +89,11 @@ func initCloudServicesHook(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	profile, region := config.GetAWSProfile(), config.GetAWSRegion()
-
-	logger.Verbosef("awless %s - loading AWS session with profile '%s' and region '%s'", config.Version, profile, region)
+	if err := initAWSServices(); err != nil {
+		return err
+	}
 
-	if err := awsservices.Init(profile, region, config.GetConfigWithPrefix("aws."), logger.DefaultLogger, config.SetProfileCallback, networkMonitorFlag); err != nil {
+	if err := initTemplatesLog(); err != nil {
 		return err
 	}
 
@@ -94,7 +103,7 @@ func initCloudServicesHook(cmd *cobra.Command, args []string) error {
 			services = append(services, s)
 		}
 		if !noSyncGlobalFlag {
-			logger.Infof("Syncing new region '%s'... (disable with --no-sync global flag)", region)
+			logger.Infof("Syncing new region '%s'... (disable with --no-sync global flag)", config.GetAWSRegion())
 			sync.NewSyncer(logger.DefaultLogger).Sync(services...)
 		}
 	}
@@ -102,6 +111,14 @@ func initCloudServicesHook(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func initAWSServices() error {
+	profile, region := config.GetAWSProfile(), config.GetAWSRegion()
+
+	logger.Verbosef("awless %s - loading AWS session with profile '%s' and region '%s'", config.Version, profile, region)
+
+	return awsservices.Init(profile, region, config.GetConfigWithPrefix("aws."), logger.DefaultLogger, config.SetProfileCallback, networkMonitorFlag)
+}
+
 func includeHookIf(cond *bool, hook func(*cobra.Command, []string) error) func(*cobra.Command, []string) error {
 	return func(c *cobra.Command, args []string) error {
 		if *cond {
@@ -18,31 +18,44 @@ package commands
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/aws/services"
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/sync/repo"
 )
 
 var (
-	listingFormat              string
 	listingFiltersFlag         []string
 	listingTagFiltersFlag      []string
 	listingTagKeyFiltersFlag   []string
 	listingTagValueFiltersFlag []string
+	listingQueryFlag           string
 	listingColumnsFlag         []string
 	listOnlyIDs                bool
 	noHeadersFlag              bool
 	sortBy                     []string
 	reverseFlag                bool
+	noTruncateFlag             bool
+	listAllAccountsFlag        bool
+	listAllRegionsFlag         bool
+	listAtFlag                 string
+	listWatchFlag              time.Duration
 )
 
 func init() {
@@ -65,22 +78,27 @@ func init() {
 		}
 	}
 
-	listCmd.PersistentFlags().StringVar(&listingFormat, "format", "table", "Output format: table, csv, tsv, json (default to table)")
 	listCmd.PersistentFlags().StringSliceVar(&listingFiltersFlag, "filter", []string{}, "Filter resources given key/values fields (case insensitive). Ex: --filter type=t2.micro")
 	listCmd.PersistentFlags().StringSliceVar(&listingTagFiltersFlag, "tag", []string{}, "Filter EC2 resources given tags (case sensitive!). Ex: --tag Env=Production")
 	listCmd.PersistentFlags().StringSliceVar(&listingTagKeyFiltersFlag, "tag-key", []string{}, "Filter EC2 resources given a tag key only (case sensitive!). Ex: --tag-key Env")
 	listCmd.PersistentFlags().StringSliceVar(&listingTagValueFiltersFlag, "tag-value", []string{}, "Filter EC2 resources given a tag value only (case sensitive!). Ex: --tag-value Staging")
-	listCmd.PersistentFlags().StringSliceVar(&listingColumnsFlag, "columns", []string{}, "Select the properties to display in the columns. Ex: --columns id,name,cidr")
+	listCmd.PersistentFlags().StringVar(&listingQueryFlag, "query", "", "Filter resources with a query combining conditions on properties, tags and related resources. Ex: --query 'state=running AND (type~^t2 OR vpc.name=prod)'")
+	listCmd.PersistentFlags().StringSliceVar(&listingColumnsFlag, "columns", []string{}, "Select the properties to display in the columns, any property of the resources (case insensitive). Ex: --columns id,name,cidr")
 	listCmd.PersistentFlags().BoolVar(&listOnlyIDs, "ids", false, "List only ids")
 	listCmd.PersistentFlags().BoolVar(&noHeadersFlag, "no-headers", false, "Do not display headers")
 	listCmd.PersistentFlags().BoolVar(&reverseFlag, "reverse", false, "Use in conjunction with --sort to reverse sort")
-	listCmd.PersistentFlags().StringSliceVar(&sortBy, "sort", []string{"Id"}, "Sort tables by column(s) name(s)")
+	listCmd.PersistentFlags().StringSliceVar(&sortBy, "sort", []string{"Id"}, "Sort by column(s) name(s) or any property of the resources, displayed or not, in all formats. Ex: --sort type,launchtime")
+	listCmd.PersistentFlags().BoolVar(&noTruncateFlag, "no-truncate", false, "Display all the columns and full values in tables, instead of hiding the columns not fitting the terminal and wrapping long values")
+	listCmd.PersistentFlags().BoolVar(&listAllAccountsFlag, "all-accounts", false, "Fetch in parallel all accounts reachable with the roles of `awless config set aws.accounts.roles` (or AWS Organizations)")
+	listCmd.PersistentFlags().StringVar(&listAtFlag, "at", "", "List resources as locally synced at a past date (ex: 2017-06-01, '2017-06-01 15:04', 7d). See also `awless history`")
+	listCmd.PersistentFlags().DurationVar(&listWatchFlag, "watch", 0, "Refetch and display the resources every interval, highlighting new and changed rows (ex: --watch 5s)")
+	listCmd.PersistentFlags().BoolVar(&listAllRegionsFlag, "all-regions", false, "Fetch in parallel all regions set with `awless config set aws.inventory.regions` (or all regions)")
 }
 
 var listCmd = &cobra.Command{
 	Use:               "list",
 	Aliases:           []string{"ls"},
-	Example:           "  awless list instances --sort uptime\n  awless list users --format csv\n  awless list volumes --filter state=use --filter type=gp2\n  awless list volumes --tag-value Purchased\n  awless list vpcs --tag-key Dept --tag-key Internal\n  awless list instances --tag Env=Production,Dept=Marketing\n  awless list instances --filter state=running,type=micro\n  awless list s3objects --filter bucket=pdf-bucket ",
+	Example:           "  awless list instances --sort uptime\n  awless list instances --columns id,name,launchtime --sort launchtime --reverse\n  awless list instances --no-truncate\n  awless list users --format csv\n  awless list instances --format jsonl | jq .ID\n  awless list instances --format csv --columns id,name,type,state,launched > instances.csv\n  awless list volumes --filter state=use --filter type=gp2\n  awless list volumes --tag-value Purchased\n  awless list vpcs --tag-key Dept --tag-key Internal\n  awless list instances --tag Env=Production,Dept=Marketing\n  awless list instances --filter state=running,type=micro\n  awless list instances --filter tag:Env=prod\n  awless list instances --query 'uptime>2017-01-01 AND NOT tag:Env=prod'\n  awless list instances --query 'subnet.name~^private AND securitygroup.name=web'\n  awless list s3objects --filter bucket=pdf-bucket\n  awless list instances --all-accounts\n  awless list vpcs --all-regions\n  awless list instances --at 2017-06-01\n  awless list instances --filter state=pending --watch 5s",
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 	Short:             "List resources: sorting, filtering via tag/properties, output formatting, etc...",
@@ -107,26 +125,94 @@ var listSpecificResourceCmd = func(resType string) *cobra.Command {
 					os.Exit(1)
 				}
 			}
-			var g cloud.GraphAPI
-
-			if localGlobalFlag {
-				if srvName, ok := awsservices.ServicePerResourceType[resType]; ok {
-					g = sync.LoadLocalGraphForService(srvName, config.GetAWSProfile(), config.GetAWSRegion())
-				} else {
-					exitOn(fmt.Errorf("cannot find service for resource type %s", resType))
+			if listWatchFlag != 0 {
+				exitOn(validateWatchInterval(listWatchFlag))
+				if listAtFlag != "" {
+					exitOn(errors.New("--watch and --at cannot be used together"))
 				}
-			} else {
-				srv, err := cloud.GetServiceForType(resType)
-				exitOn(err)
-				g, err = srv.FetchByType(context.WithValue(context.Background(), "force", true), resType)
-				exitOn(err)
+				var previous cloud.GraphAPI
+				watch(listWatchFlag, func(w io.Writer) {
+					g := fetchListing(resType)
+					printResources(w, g, resType, previous)
+					previous = g
+				})
 			}
 
-			printResources(g, resType)
+			printResources(os.Stdout, fetchListing(resType), resType, nil)
+			printListedAlongside(os.Stdout, resType)
 		},
 	}
 }
 
+// listedAlongside are the resource types also listed in tables after a resource type
+// when no filter applies (ex: caches along with databases)
+var listedAlongside = map[string][]string{
+	cloud.Database: {cloud.CacheCluster, cloud.ReplicationGroup},
+}
+
+func printListedAlongside(w io.Writer, resType string) {
+	if formatGlobalFlag != "table" || listOnlyIDs || listAtFlag != "" || listAllAccountsFlag || listAllRegionsFlag || listingQueryFlag != "" {
+		return
+	}
+	if len(listingFiltersFlag)+len(listingTagFiltersFlag)+len(listingTagKeyFiltersFlag)+len(listingTagValueFiltersFlag)+len(listingColumnsFlag) > 0 {
+		return
+	}
+	for _, other := range listedAlongside[resType] {
+		var g cloud.GraphAPI
+		if localGlobalFlag {
+			g = sync.LoadLocalGraphForService(awsservices.ServicePerResourceType[other], config.GetAWSProfile(), config.GetAWSRegion())
+		} else {
+			srv, err := cloud.GetServiceForType(other)
+			if err != nil {
+				logger.Warningf("cannot list %s: %s", cloud.PluralizeResource(other), err)
+				continue
+			}
+			if g, err = srv.FetchByType(context.WithValue(context.Background(), "force", true), other); err != nil {
+				logger.Warningf("cannot list %s: %s", cloud.PluralizeResource(other), err)
+				continue
+			}
+		}
+		if resources, err := g.Find(cloud.NewQuery(other)); err != nil || len(resources) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n", strings.Title(cloud.PluralizeResource(other)))
+		printResources(w, withAnnotations(g), other, nil)
+	}
+}
+
+// fetchListing fetches the resources to list according to the listing flags
+func fetchListing(resType string) cloud.GraphAPI {
+	var g cloud.GraphAPI
+
+	if listAtFlag != "" {
+		g = loadLocalGraphsAt(listAtFlag)
+	} else if localGlobalFlag {
+		if srvName, ok := awsservices.ServicePerResourceType[resType]; ok {
+			g = sync.LoadLocalGraphForService(srvName, config.GetAWSProfile(), config.GetAWSRegion())
+		} else {
+			exitOn(fmt.Errorf("cannot find service for resource type %s", resType))
+		}
+	} else if listAllAccountsFlag && listAllRegionsFlag {
+		exitOn(errors.New("--all-accounts and --all-regions cannot be used together"))
+	} else if listAllAccountsFlag {
+		g = fetchInAllAccounts(resType)
+	} else if listAllRegionsFlag {
+		g = fetchInAllRegions(resType)
+	} else {
+		srv, err := cloud.GetServiceForType(resType)
+		exitOn(err)
+		query, err := listingFiltersQuery(resType)
+		exitOn(err)
+		ctx := fetch.WithQuery(context.WithValue(context.Background(), "force", true), query)
+		g, err = srv.FetchByType(ctx, resType)
+		exitOn(err)
+	}
+	if !listAllAccountsFlag {
+		g = withAnnotations(g)
+	}
+	return g
+}
+
 var listAllResourceInServiceCmd = func(srvName string) *cobra.Command {
 	return &cobra.Command{
 		Use:    srvName,
@@ -134,9 +220,9 @@ var listAllResourceInServiceCmd = func(srvName string) *cobra.Command {
 		Hidden: true,
 
 		Run: func(cmd *cobra.Command, args []string) {
-			g := sync.LoadLocalGraphForService(srvName, config.GetAWSProfile(), config.GetAWSRegion())
+			g := withAnnotations(sync.LoadLocalGraphForService(srvName, config.GetAWSProfile(), config.GetAWSRegion()))
 			displayer, err := console.BuildOptions(
-				console.WithFormat(listingFormat),
+				console.WithFormat(formatGlobalFlag),
 				console.WithMaxWidth(console.GetTerminalWidth()),
 				console.WithIDsOnly(listOnlyIDs),
 			).SetSource(g).Build()
@@ -146,7 +232,116 @@ var listAllResourceInServiceCmd = func(srvName string) *cobra.Command {
 	}
 }
 
-func printResources(g cloud.GraphAPI, resType string) {
+func loadLocalGraphsAt(date string) cloud.GraphAPI {
+	at, err := parseHistoryDate(date)
+	exitOn(err)
+	r, err := repo.New()
+	exitOn(err)
+	g, rev, err := sync.LoadLocalGraphsAt(r, config.GetAWSProfile(), []string{"global", config.GetAWSRegion()}, at)
+	exitOn(err)
+	logger.Infof("Resources as synced on %s (revision %.7s)", rev.DateString(), rev.Id)
+	return g
+}
+
+// listingFiltersQuery returns the query resulting from the filters flags, passed along
+// when fetching so that it can be turned into server side filters
+func listingFiltersQuery(resType string) (cloud.Query, error) {
+	return console.BuildOptions(
+		console.WithRdfType(resType),
+		console.WithColumns(listingColumnsFlag),
+		console.WithFilters(listingFiltersFlag),
+		console.WithTagFilters(listingTagFiltersFlag),
+		console.WithTagKeyFilters(listingTagKeyFiltersFlag),
+		console.WithTagValueFilters(listingTagValueFiltersFlag),
+		console.WithQuery(listingQueryFlag),
+	).Query()
+}
+
+func fetchInAllAccounts(resType string) cloud.GraphAPI {
+	accounts, err := awsservices.ResolveAccounts()
+	exitOn(err)
+	logger.Verbosef("fetching %s in %d accounts", cloud.PluralizeResource(resType), len(accounts))
+
+	query, err := listingFiltersQuery(resType)
+	exitOn(err)
+	ctx := fetch.WithQuery(context.WithValue(context.Background(), "force", true), query)
+	g, err := awsservices.FetchByTypeInAccounts(ctx, resType, accounts)
+	logFetchErrors(err, logger.Warning)
+
+	if len(listingColumnsFlag) == 0 {
+		listingColumnsFlag = append([]string{properties.Account}, console.ColumnsInListing[resType]...)
+	}
+	return g
+}
+
+func fetchInAllRegions(resType string) cloud.GraphAPI {
+	srvName, ok := awsservices.ServicePerResourceType[resType]
+	if !ok {
+		exitOn(fmt.Errorf("cannot find service for resource type %s", resType))
+	}
+	regions, err := awsservices.ResolveRegions()
+	exitOn(err)
+	srv, err := awsservices.NewMultiRegionService(srvName, regions)
+	exitOn(err)
+	logger.Verbosef("fetching %s in regions %s", cloud.PluralizeResource(resType), srv.Region())
+
+	query, err := listingFiltersQuery(resType)
+	exitOn(err)
+	ctx := fetch.WithQuery(context.WithValue(context.Background(), "force", true), query)
+	g, err := srv.FetchByType(ctx, resType)
+	logFetchErrors(err, logger.Warning)
+
+	if len(listingColumnsFlag) == 0 {
+		listingColumnsFlag = append([]string{properties.Region}, console.ColumnsInListing[resType]...)
+	}
+	return g
+}
+
+// relationsGraph returns the graph resolving the relations of a query on related resources:
+// the fetched resources do not come with their related resources, which are taken from the local graphs
+func relationsGraph(g cloud.GraphAPI) cloud.GraphAPI {
+	if listingQueryFlag == "" || localGlobalFlag {
+		return nil
+	}
+	if m, err := match.Parse(listingQueryFlag); err != nil || !match.IsRelational(m) {
+		return nil
+	}
+	local, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+	if err != nil {
+		logger.Warningf("cannot load local graphs to resolve relations of query: %s", err)
+		return nil
+	}
+	// fetched resources are fresher than the local ones
+	localGraph, isLocalGraph := local.(*graph.Graph)
+	fetched, isFetchedGraph := g.(*graph.Graph)
+	if isLocalGraph && isFetchedGraph {
+		merged, err := graph.Merge(localGraph, fetched, graph.LastWriteWins)
+		if err != nil {
+			logger.Warningf("cannot resolve relations of query: %s", err)
+			return nil
+		}
+		return merged
+	}
+	if err := local.Merge(g); err != nil {
+		logger.Warningf("cannot resolve relations of query: %s", err)
+		return nil
+	}
+	return local
+}
+
+// withAnnotations adds the local annotations of the current profile to the resources of the graph
+func withAnnotations(g cloud.GraphAPI) cloud.GraphAPI {
+	annotated, err := sync.WithAnnotations(config.GetAWSProfile(), g)
+	if err != nil {
+		logger.Warningf("cannot load annotations: %s", err)
+		return g
+	}
+	return annotated
+}
+
+// printResources displays the resources of the given type, highlighting
+// the ones that are new or changed since the previous graph if any
+func printResources(w io.Writer, g cloud.GraphAPI, resType string, previous cloud.GraphAPI) {
 	displayer, err := console.BuildOptions(
 		console.WithRdfType(resType),
 		console.WithColumns(listingColumnsFlag),
@@ -154,14 +349,18 @@ func printResources(g cloud.GraphAPI, resType string) {
 		console.WithTagFilters(listingTagFiltersFlag),
 		console.WithTagKeyFilters(listingTagKeyFiltersFlag),
 		console.WithTagValueFilters(listingTagValueFiltersFlag),
+		console.WithQuery(listingQueryFlag),
+		console.WithRelationsGraph(relationsGraph(g)),
 		console.WithMaxWidth(console.GetTerminalWidth()),
-		console.WithFormat(listingFormat),
+		console.WithFormat(formatGlobalFlag),
 		console.WithIDsOnly(listOnlyIDs),
 		console.WithSortBy(sortBy...),
 		console.WithReverseSort(reverseFlag),
 		console.WithNoHeaders(noHeadersFlag),
+		console.WithNoTruncate(noTruncateFlag),
+		console.WithPrevious(previous),
 	).SetSource(g).Build()
 	exitOn(err)
 
-	exitOn(displayer.Print(os.Stdout))
+	exitOn(displayer.Print(w))
 }
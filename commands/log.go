@@ -31,6 +31,7 @@ var (
 	limitLogCountFlag             int
 	rawJSONLogFlag, idOnlyLogFlag bool
 	fullLogFlag, shortLogFlag     bool
+	migrateLogsFlag               bool
 )
 
 func init() {
@@ -43,12 +44,13 @@ func init() {
 	logCmd.Flags().BoolVar(&shortLogFlag, "short", false, "Display one or more template log with less info")
 	logCmd.Flags().BoolVar(&fullLogFlag, "full", false, "Display template logs with full info")
 	logCmd.Flags().BoolVar(&idOnlyLogFlag, "id-only", false, "Show only log template IDs (i.e. revert IDs)")
+	logCmd.Flags().BoolVar(&migrateLogsFlag, "migrate", false, "Move the logs of the local db to the shared templates log (see aws.templates.log config)")
 }
 
 var logCmd = &cobra.Command{
 	Use:               "log [REVERTID]",
 	Short:             "Show all awless template actions against your cloud infrastructure",
-	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, firstInstallDoneHook),
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initTemplatesLogHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
 
 	RunE: func(c *cobra.Command, args []string) error {
@@ -83,6 +85,17 @@ var logCmd = &cobra.Command{
 			return nil
 		}
 
+		if migrateLogsFlag {
+			exitOn(database.Execute(func(db *database.DB) error {
+				moved, err := db.MigrateLocalTemplates()
+				logger.Infof("%d logs moved to the shared templates log", moved)
+				return err
+			}))
+			return nil
+		}
+
+		warnHiddenLocalTemplates()
+
 		exitOn(database.Execute(func(db *database.DB) (dberr error) {
 			all, dberr = db.ListTemplates()
 			return
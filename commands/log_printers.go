@@ -27,7 +27,7 @@ func (p *fullLogPrinter) print(t *template.TemplateExecution) error {
 		fmt.Fprintf(p.w, "\t%s\n\n", t.Message)
 	}
 
-	for _, cmd := range t.CommandNodesIterator() {
+	for i, cmd := range t.CommandNodesIterator() {
 		var status string
 		if cmd.CmdErr != nil {
 			status = renderRedFn("KO")
@@ -37,9 +37,9 @@ func (p *fullLogPrinter) print(t *template.TemplateExecution) error {
 
 		var line string
 		if v, ok := cmd.CmdResult.(string); ok && v != "" {
-			line = fmt.Sprintf("    %s\t%s\t[%s]", status, cmd.String(), v)
+			line = fmt.Sprintf("    %2d  %s\t%s\t[%s]", i+1, status, cmd.String(), v)
 		} else {
-			line = fmt.Sprintf("    %s\t%s", status, cmd.String())
+			line = fmt.Sprintf("    %2d  %s\t%s", i+1, status, cmd.String())
 		}
 
 		fmt.Fprintln(p.w, line)
@@ -106,20 +106,23 @@ func writeLogHeader(t *template.TemplateExecution, w io.Writer) {
 	if t.Author != "" {
 		fmt.Fprintf(w, " by %s", renderBlueFn(t.Author))
 	}
+	if t.Operator != "" {
+		fmt.Fprintf(w, " (%s)", renderBlueFn(t.Operator))
+	}
 	if t.Profile != "" {
 		fmt.Fprintf(w, " with profile %s", renderBlueFn(t.Profile))
 	}
 	if t.Locale != "" {
 		fmt.Fprintf(w, " in %s", renderBlueFn(t.Locale))
 	}
-	if !template.IsRevertible(t.Template) {
+	if !t.IsRevertible() {
 		fmt.Fprintf(w, " (not revertible)")
 	}
 }
 
 func writeMultilineLogHeader(t *template.TemplateExecution, w io.Writer) {
 	color.New(color.FgYellow).Fprintf(w, "id %s", t.ID)
-	if !template.IsRevertible(t.Template) {
+	if !t.IsRevertible() {
 		fmt.Fprintln(w, " (not revertible)")
 	} else {
 		fmt.Fprintln(w)
@@ -129,11 +132,17 @@ func writeMultilineLogHeader(t *template.TemplateExecution, w io.Writer) {
 	if t.Author != "" {
 		fmt.Fprintf(w, "Author: %s\n", t.Author)
 	}
+	if t.Operator != "" {
+		fmt.Fprintf(w, "Operator: %s\n", t.Operator)
+	}
 	if t.Profile != "" {
 		fmt.Fprintf(w, "Profile: %s\n", t.Profile)
 	}
 	if t.Locale != "" {
 		fmt.Fprintf(w, "Region: %s\n", t.Locale)
 	}
+	for _, a := range t.Adopted {
+		fmt.Fprintf(w, "Adopted: %s\n", a)
+	}
 	fmt.Fprintln(w)
 }
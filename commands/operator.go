@@ -0,0 +1,112 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+// operatorResolvers resolve the human operator running awless, as selected and ordered by `operator.sources`
+var operatorResolvers = map[string]func() (string, error){
+	"env": func() (string, error) {
+		return os.Getenv("AWLESS_OPERATOR"), nil
+	},
+	"git": func() (string, error) {
+		out, err := exec.Command("git", "config", "--get", "user.email").Output()
+		return string(out), err
+	},
+	"aws": func() (string, error) {
+		me, err := awsservices.AccessService.(*awsservices.Access).GetIdentity()
+		if err != nil {
+			return "", err
+		}
+		if me.IsRoot() {
+			return "", errors.New("root account has no operator")
+		}
+		// IAM user name, or session name of an assumed role (the user with AWS SSO)
+		return path.Base(me.ResourcePath), nil
+	},
+}
+
+// operatorTaggedEntities are the created resources tagged with the operator
+var operatorTaggedEntities = map[string]bool{
+	"instance": true, "vpc": true, "subnet": true, "securitygroup": true, "volume": true, "internetgateway": true,
+	"natgateway": true, "routetable": true, "image": true, "snapshot": true, "elasticip": true, "networkinterface": true,
+}
+
+// resolveOperator returns the first operator resolved by the configured sources
+func resolveOperator(sources string) string {
+	for _, source := range splitConfigList(sources) {
+		resolve, ok := operatorResolvers[source]
+		if !ok {
+			logger.Warningf("unknown operator source '%s'", source)
+			continue
+		}
+		operator, err := resolve()
+		if err != nil {
+			logger.Verbosef("cannot resolve operator from %s: %s", source, err)
+			continue
+		}
+		if operator = strings.TrimSpace(operator); operator != "" {
+			return operator
+		}
+	}
+	return ""
+}
+
+// operatorTaggedIDs returns the IDs of the resources successfully created by the template that are tagged with the operator
+func operatorTaggedIDs(tpl *template.Template) (ids []string) {
+	for _, cmd := range tpl.CommandNodesIterator() {
+		if cmd.Action != "create" || cmd.CmdErr != nil || !operatorTaggedEntities[cmd.Entity] {
+			continue
+		}
+		if id, ok := cmd.CmdResult.(string); ok && id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return
+}
+
+func tagCreatedResourcesWithOperator(tplExec *template.TemplateExecution) {
+	key, _ := config.Config["operator.tag"].(string)
+	if tplExec.Operator == "" || key == "" {
+		return
+	}
+	ids := operatorTaggedIDs(tplExec.Template)
+	if len(ids) == 0 {
+		return
+	}
+	infra, ok := awsservices.InfraService.(*awsservices.Infra)
+	if !ok {
+		logger.Errorf("cannot tag created resources with operator: invalid infra service")
+		return
+	}
+	if err := infra.TagResources(ids, key, tplExec.Operator); err != nil {
+		logger.Errorf("cannot tag created resources with operator: %s", err)
+		return
+	}
+	logger.ExtraVerbosef("tagged %s with %s=%s", strings.Join(ids, ", "), key, tplExec.Operator)
+}
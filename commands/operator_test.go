@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/template"
+)
+
+func TestResolveOperator(t *testing.T) {
+	defer func(resolvers map[string]func() (string, error)) { operatorResolvers = resolvers }(operatorResolvers)
+	operatorResolvers = map[string]func() (string, error){
+		"env": func() (string, error) { return "", nil },
+		"git": func() (string, error) { return "john@example.com\n", nil },
+		"aws": func() (string, error) { return "", errors.New("no credentials") },
+	}
+
+	tcases := []struct {
+		sources, exp string
+	}{
+		{sources: "", exp: ""},
+		{sources: "env", exp: ""},
+		{sources: "env,git", exp: "john@example.com"},
+		{sources: "aws, git", exp: "john@example.com"},
+		{sources: "unknown,aws", exp: ""},
+	}
+	for _, tcase := range tcases {
+		if got, want := resolveOperator(tcase.sources), tcase.exp; got != want {
+			t.Fatalf("%q: got %q, want %q", tcase.sources, got, want)
+		}
+	}
+}
+
+func TestOperatorTaggedIDs(t *testing.T) {
+	tpl := template.MustParse("create vpc cidr=10.0.0.0/16\ncreate subnet cidr=10.0.0.0/24 vpc=vpc-1\ncreate instance name=web\ncreate keypair name=mykey\ndelete instance id=i-2")
+	cmds := tpl.CommandNodesIterator()
+	cmds[0].CmdResult = "vpc-1"
+	cmds[1].CmdErr = errors.New("cidr conflict")
+	cmds[2].CmdResult = "i-1"
+	cmds[3].CmdResult = "mykey"
+
+	if got, want := operatorTaggedIDs(tpl), []string{"vpc-1", "i-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+)
+
+func init() {
+	RootCmd.AddCommand(preflightCmd)
+}
+
+var preflightCmd = &cobra.Command{
+	Use:   "preflight PATH",
+	Short: "Check that a template can run against the account of the current profile and region, without running it",
+	Long: `Check that a template can run against the account of the current profile and region, without running it.
+
+The template is compiled (holes and aliases resolution) and then checked with read-only calls only:
+  - aliases resolution against the locally synced data
+  - validation: locked resources, APIs available in the region, names already used
+  - existence in the locally synced data of the resources targeted by id
+  - quotas of the resources to create (elastic IPs)
+  - permissions of the caller for the AWS call of each statement (IAM policy simulation)
+  - dry run of each statement
+
+The command exits with status 1 when a check fails.`,
+	Example:           "  awless preflight my.aws -p prod\n  awless preflight repo:create_vpc cidr=10.0.0.0/16 --format json",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("missing PATH arg (filepath or url)")
+		}
+		if localGlobalFlag {
+			exitOn(errors.New("`--local` flag prevent the command from checking the template against the account"))
+		}
+
+		content, _, err := getTemplateText(args[0])
+		exitOn(err)
+		tpl, err := template.Parse(string(content))
+		exitOn(err)
+		extraParams, err := template.ParseParams(strings.Join(args[1:], " "))
+		exitOn(err)
+
+		report := preflight(tpl, config.Defaults, extraParams)
+
+		if console.IsStructuredFormat(formatGlobalFlag) {
+			exitOn(console.EncodeStructured(os.Stdout, formatGlobalFlag, report))
+		} else {
+			printPreflightReport(report)
+		}
+
+		if !report.Passed() {
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+const (
+	preflightOK      = "ok"
+	preflightWarning = "warning"
+	preflightFailed  = "failed"
+	preflightSkipped = "skipped"
+)
+
+type preflightCheck struct {
+	Check     string `json:"check"`
+	Statement string `json:"statement,omitempty"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+type preflightReport struct {
+	Checks []*preflightCheck `json:"checks"`
+}
+
+func (r *preflightReport) add(check, statement, status, detail string) {
+	r.Checks = append(r.Checks, &preflightCheck{Check: check, Statement: statement, Status: status, Detail: detail})
+}
+
+func (r *preflightReport) count(status string) (n int) {
+	for _, c := range r.Checks {
+		if c.Status == status {
+			n++
+		}
+	}
+	return
+}
+
+func (r *preflightReport) Passed() bool {
+	return r.count(preflightFailed) == 0
+}
+
+// preflight compiles the template as a run would and checks it against the account
+// with read-only calls: nothing is run, reserved, tagged nor recorded in the logs
+func preflight(tpl *template.Template, fillers ...map[string]interface{}) *preflightReport {
+	report := &preflightReport{}
+	runner := NewRunnerRequiredParamsOnly(tpl, "", "", fillers...)
+
+	aliasFunc := func(paramPath, alias string) string {
+		id := runner.AliasFunc(paramPath, alias)
+		if id == "" {
+			report.add("alias", paramPath, preflightFailed, fmt.Sprintf("'%s' not found in locally synced data", alias))
+		} else {
+			report.add("alias", paramPath, preflightOK, fmt.Sprintf("'%s' resolved to '%s'", alias, id))
+		}
+		return id
+	}
+	cenv := template.NewEnv().WithAliasFunc(aliasFunc).WithMissingHolesFunc(runner.MissingHolesFunc).WithBuiltinFunc(runner.BuiltinFunc).
+		WithLookupCommandFunc(runner.CmdLookuper).WithLog(runner.Log).WithParamsMode(runner.ParamsSuggested).Build()
+	cenv.Push(env.FILLERS, runner.Fillers...)
+	cenv.Push(env.ENTITY_DEFAULTS, runner.Defaults)
+
+	compiled, cenv, err := template.Compile(tpl, cenv, template.NewRunnerCompileMode)
+	if err != nil {
+		report.add("compile", "", preflightFailed, err.Error())
+		return report
+	}
+
+	validators := append(runner.Validators, &template.ExistingResourcesValidator{LookupGraph: func(key string) (cloud.GraphAPI, bool) {
+		service, ok := awsservices.ServicePerResourceType[key]
+		if !ok {
+			return nil, false
+		}
+		return sync.LoadLocalGraphForService(service, config.GetAWSProfile(), config.GetAWSRegion()), true
+	}})
+	report.addValidationErrors(compiled.Validate(validators...))
+
+	if infra, ok := awsservices.InfraService.(*awsservices.Infra); ok {
+		creates := make(map[string]int)
+		for _, cmd := range compiled.CommandNodesIterator() {
+			if cmd.Action == "create" {
+				creates[cmd.Entity]++
+			}
+		}
+		report.addQuotaErrors(awsspec.CheckQuotas(infra.EC2API, creates))
+	}
+
+	decisions, err := simulateTemplateActions(compiled)
+	report.addPermissions(compiled, decisions, err)
+
+	renv := template.NewRunEnv(cenv)
+	renv.Metadata().Profile, renv.Metadata().Region = config.GetAWSProfile(), config.GetAWSRegion()
+	dryRun, err := compiled.DryRun(renv)
+	if _, isCmdErrs := err.(*template.Errors); err != nil && !isCmdErrs {
+		report.add("dryrun", "", preflightFailed, err.Error())
+	} else {
+		for _, cmd := range dryRun.CommandNodesIterator() {
+			if cmd.CmdErr != nil {
+				report.add("dryrun", cmd.String(), preflightFailed, cmd.CmdErr.Error())
+			} else {
+				report.add("dryrun", cmd.String(), preflightOK, "")
+			}
+		}
+	}
+
+	return report
+}
+
+// addValidationErrors fails on the errors preventing a run: locked resources,
+// APIs unavailable in the region and missing resources. Others are warnings.
+func (r *preflightReport) addValidationErrors(errs []error) {
+	for _, err := range errs {
+		switch err.(type) {
+		case *template.MissingResourceError:
+			r.add("resource", "", preflightFailed, err.Error()+" in locally synced data (see `awless sync`)")
+		case *template.LockedResourceError, *template.UnavailableAPIError:
+			r.add("validation", "", preflightFailed, err.Error())
+		default:
+			r.add("validation", "", preflightWarning, err.Error())
+		}
+	}
+}
+
+func (r *preflightReport) addQuotaErrors(errs []error) {
+	for _, err := range errs {
+		if _, ok := err.(*awsspec.QuotaExceededError); ok {
+			r.add("quota", "", preflightFailed, err.Error())
+		} else {
+			r.add("quota", "", preflightWarning, err.Error())
+		}
+	}
+}
+
+// addPermissions reports the decision of the simulation of the IAM action of each statement
+func (r *preflightReport) addPermissions(tpl *template.Template, decisions map[string]string, simulationErr error) {
+	if simulationErr != nil {
+		r.add("permission", "", preflightWarning, fmt.Sprintf("cannot simulate permissions: %s", simulationErr))
+		return
+	}
+	for _, cmd := range tpl.CommandNodesIterator() {
+		action, ok := awsspec.IAMAction(cmd.Command)
+		if !ok {
+			r.add("permission", cmd.String(), preflightSkipped, "no IAM action to simulate")
+			continue
+		}
+		if decision := decisions[action]; decision == "allowed" {
+			r.add("permission", cmd.String(), preflightOK, action)
+		} else {
+			r.add("permission", cmd.String(), preflightFailed, fmt.Sprintf("%s: %s", action, decision))
+		}
+	}
+}
+
+// simulateTemplateActions simulates the policies of the caller for the IAM actions of the statements
+func simulateTemplateActions(tpl *template.Template) (map[string]string, error) {
+	var actions []string
+	unique := make(map[string]bool)
+	for _, cmd := range tpl.CommandNodesIterator() {
+		if action, ok := awsspec.IAMAction(cmd.Command); ok && !unique[action] {
+			unique[action] = true
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) == 0 {
+		return nil, nil
+	}
+	return simulateCallerActions(actions)
+}
+
+func printPreflightReport(report *preflightReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "Check\tStatus\tStatement\tDetail")
+	fmt.Fprintln(w, "-----\t------\t---------\t------")
+	for _, c := range report.Checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Check, c.Status, c.Statement, c.Detail)
+	}
+	w.Flush()
+	fmt.Println()
+
+	failed, warnings := report.count(preflightFailed), report.count(preflightWarning)
+	summary := fmt.Sprintf("%d checks: %d failed, %d warnings, %d skipped", len(report.Checks), failed, warnings, report.count(preflightSkipped))
+	if failed > 0 {
+		logger.Error(summary)
+	} else if warnings > 0 {
+		logger.Warning(summary)
+	} else {
+		logger.Info(summary)
+	}
+}
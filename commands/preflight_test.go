@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/template"
+)
+
+func TestPreflightReport(t *testing.T) {
+	tpl := template.MustParse("create instance name=web\ncreate elasticip domain=vpc\ndelete keypair id=mykey")
+	cmds := tpl.CommandNodesIterator()
+	cmds[0].Command = &awsspec.CreateInstance{}
+	cmds[1].Command = &awsspec.CreateElasticip{}
+	cmds[2].Command = &awsspec.DeleteKeypair{}
+
+	report := &preflightReport{}
+	report.addValidationErrors([]error{
+		&template.MissingResourceError{Action: "delete", Entity: "keypair", ID: "mykey"},
+		&template.LockedResourceError{Action: "delete", Entity: "keypair", ID: "mykey", Reason: "in lock list"},
+		errors.New("'web' name already used for instance i-1"),
+	})
+	report.addQuotaErrors([]error{&awsspec.QuotaExceededError{Entity: "elasticip", Used: 5, Limit: 5}})
+	report.addPermissions(tpl, map[string]string{"ec2:RunInstances": "allowed", "ec2:DeleteKeyPair": "implicitDeny"}, nil)
+
+	exp := []*preflightCheck{
+		{Check: "resource", Status: "failed", Detail: "delete keypair: resource 'mykey' not found in locally synced data (see `awless sync`)"},
+		{Check: "validation", Status: "failed", Detail: "delete keypair: resource 'mykey' is locked (in lock list)"},
+		{Check: "validation", Status: "warning", Detail: "'web' name already used for instance i-1"},
+		{Check: "quota", Status: "failed", Detail: "elasticip quota reached: 5 used out of 5 allowed"},
+		{Check: "permission", Statement: "create instance name=web", Status: "ok", Detail: "ec2:RunInstances"},
+		{Check: "permission", Statement: "create elasticip domain=vpc", Status: "skipped", Detail: "no IAM action to simulate"},
+		{Check: "permission", Statement: "delete keypair id=mykey", Status: "failed", Detail: "ec2:DeleteKeyPair: implicitDeny"},
+	}
+	if got, want := report.Checks, exp; !reflect.DeepEqual(got, want) {
+		for i := range got {
+			t.Logf("%d: %+v", i, got[i])
+		}
+		t.Fatalf("got %d checks, want %d", len(got), len(want))
+	}
+	if report.Passed() {
+		t.Fatal("expected report not to pass")
+	}
+
+	report = &preflightReport{}
+	report.addPermissions(tpl, nil, errors.New("access denied"))
+	report.add("dryrun", "create instance name=web", preflightOK, "")
+	if got, want := report.count(preflightWarning), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if !report.Passed() {
+		t.Fatal("expected report to pass")
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/ssh"
+)
+
+var (
+	rdpKeyPathFlag       string
+	rdpPortFlag          int
+	rdpPrivateIPFlag     bool
+	rdpPrintPasswordFlag bool
+	rdpOpenFlag          bool
+	rdpFileFlag          string
+)
+
+const defaultWindowsUser = "Administrator"
+
+func init() {
+	RootCmd.AddCommand(rdpCmd)
+	rdpCmd.Flags().StringVarP(&rdpKeyPathFlag, "identity", "i", "", "Set path or name toward the identity (key file) used to decrypt the Windows password")
+	rdpCmd.Flags().IntVar(&rdpPortFlag, "port", ssh.DefaultRDPPort, "Set RDP target port")
+	rdpCmd.Flags().BoolVar(&rdpPrivateIPFlag, "private", false, "Use private ip to connect to host")
+	rdpCmd.Flags().BoolVar(&rdpPrintPasswordFlag, "print-password", false, "Print only the decrypted administrator password")
+	rdpCmd.Flags().StringVar(&rdpFileFlag, "rdp-file", "", "Write the RDP connection file to the given path")
+	rdpCmd.Flags().BoolVar(&rdpOpenFlag, "open", false, "Open the RDP connection file with the default system RDP client")
+}
+
+var rdpCmd = &cobra.Command{
+	Use:   "rdp [USER@]INSTANCE",
+	Short: "Retrieve the password of a Windows instance and connect to it through RDP",
+	Long:  "Retrieve and decrypt the administrator password of a Windows instance given an id or alias, then print or open an RDP connection file. Addressing is derived from the instance as with `awless ssh`.",
+	Example: `  awless rdp i-8d43b21b                        # print password and RDP connection file
+  awless rdp win-prod --open                   # open the RDP file with your system client
+  awless rdp win-prod --rdp-file ~/win.rdp     # write the RDP connection file
+  awless rdp win-prod --print-password         # print only the decrypted password
+  awless rdp win-prod -i ~/path/toward/key     # specifying a full key path
+  awless rdp win-private --private             # connect using the private IP (when you have a VPN, tunnel, etc ...)`,
+
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return fmt.Errorf("instance required")
+		}
+
+		connectionCtx, err := initInstanceConnectionContext(args[0], rdpKeyPathFlag)
+		exitOn(err)
+
+		if platform, _ := connectionCtx.instance.Properties()[properties.Platform].(string); platform != "windows" {
+			logger.Warningf("instance %s does not seem to be a Windows instance", connectionCtx.instance.Id())
+		}
+
+		if connectionCtx.keypath == "" {
+			exitOn(fmt.Errorf("no keypair resolved for instance %s: specify a key with `-i /path/to/key.pem`", connectionCtx.instance.Id()))
+		}
+
+		infra, ok := awsservices.InfraService.(*awsservices.Infra)
+		if !ok {
+			exitOn(fmt.Errorf("cannot retrieve password: invalid infra service"))
+		}
+		passwordData, err := infra.GetWindowsPasswordData(connectionCtx.instance.Id())
+		exitOn(err)
+
+		password, keypath, err := ssh.DecryptWindowsPassword(passwordData, connectionCtx.keypath, config.KeysDir, filepath.Join(os.Getenv("HOME"), ".ssh"))
+		exitOn(err)
+		logger.ExtraVerbosef("decrypted password with key %s", keypath)
+
+		if rdpPrintPasswordFlag {
+			fmt.Println(password)
+			return nil
+		}
+
+		rdpConf := &ssh.RDPConfig{User: connectionCtx.user, Port: rdpPortFlag}
+		if rdpConf.User == "" {
+			rdpConf.User = defaultWindowsUser
+		}
+
+		if rdpPrivateIPFlag {
+			if rdpConf.IP = connectionCtx.privip; rdpConf.IP == "" {
+				exitOn(fmt.Errorf("no private IP resolved for instance %s (state '%s')", connectionCtx.instance.Id(), connectionCtx.state))
+			}
+		} else {
+			if rdpConf.IP = connectionCtx.ip; rdpConf.IP == "" {
+				logger.Infof("`--private` flag can be used to connect through instance's private IP '%s'", connectionCtx.privip)
+				exitOn(fmt.Errorf("no public IP resolved for instance %s (state '%s')", connectionCtx.instance.Id(), connectionCtx.state))
+			}
+		}
+
+		if e := connectionCtx.checkInstanceAccessible(int64(rdpConf.Port)); e != nil {
+			logger.Warning(e.Error())
+		}
+
+		rdpPath := rdpFileFlag
+		if rdpPath == "" && rdpOpenFlag {
+			rdpPath = filepath.Join(os.TempDir(), fmt.Sprintf("awless-%s.rdp", connectionCtx.instance.Id()))
+		}
+
+		if rdpPath == "" {
+			logger.Infof("Password for user '%s' on %s: %s", rdpConf.User, rdpConf.IP, password)
+			fmt.Print(rdpConf.String())
+			return nil
+		}
+
+		exitOn(ioutil.WriteFile(rdpPath, []byte(rdpConf.String()), 0600))
+		logger.Infof("RDP connection file written to %s", rdpPath)
+		logger.Infof("Password for user '%s' on %s: %s", rdpConf.User, rdpConf.IP, password)
+
+		if rdpOpenFlag {
+			exitOn(openWithSystemHandler(rdpPath))
+		}
+
+		return nil
+	},
+}
+
+func openWithSystemHandler(path string) error {
+	var bin string
+	var args []string
+	switch runtime.GOOS {
+	case "darwin":
+		bin = "open"
+	case "windows":
+		bin, args = "cmd", []string{"/c", "start", ""}
+	default:
+		bin = "xdg-open"
+	}
+	if _, err := exec.LookPath(bin); err != nil {
+		return fmt.Errorf("cannot open %s: %s not found", path, bin)
+	}
+	return exec.Command(bin, append(args, path)...).Start()
+}
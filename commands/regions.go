@@ -0,0 +1,107 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/logger"
+)
+
+var listRegionsServiceFlag string
+
+func init() {
+	listCmd.AddCommand(listRegionsCmd)
+	listRegionsCmd.Flags().StringVar(&listRegionsServiceFlag, "service", "", "Only list the regions offering the given API (ex: lambda, ecs, elbv2)")
+}
+
+var listRegionsCmd = &cobra.Command{
+	Use:     "regions",
+	Short:   "List regions with their availability, local and wavelength zones, and the APIs they offer",
+	Example: "  awless list regions\n  awless list regions --service lambda\n  awless config set aws.regions.offline true   # no API call, no zones",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		regions, err := awsservices.RegionsCatalog()
+		if regions == nil {
+			exitOn(err)
+		}
+		logFetchErrors(err, logger.Warning)
+
+		if listRegionsServiceFlag != "" {
+			var offering []*awsservices.RegionInfo
+			for _, r := range regions {
+				switch available, known := awsservices.APIAvailabilityInRegion(listRegionsServiceFlag, r.ID); {
+				case !known:
+					logger.Warningf("%s: cannot tell whether %s is available (unknown to the endpoints catalog of awless), listed anyway", r.ID, listRegionsServiceFlag)
+					offering = append(offering, r)
+				case available:
+					offering = append(offering, r)
+				}
+			}
+			regions = offering
+		}
+
+		if console.IsStructuredFormat(formatGlobalFlag) {
+			exitOn(console.EncodeStructured(os.Stdout, formatGlobalFlag, regions))
+			return
+		}
+		if listOnlyIDs {
+			for _, r := range regions {
+				fmt.Println(r.ID)
+			}
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		if !noHeadersFlag {
+			fmt.Fprintln(w, "Region\tPartition\tZones\tUnavailable APIs\tUnknown APIs")
+			fmt.Fprintln(w, "------\t---------\t-----\t----------------\t------------")
+		}
+		for _, r := range regions {
+			var zones, unavailable []string
+			offered := make(map[string]bool)
+			for _, api := range append(r.APIs, r.UnknownAPIs...) {
+				offered[api] = true
+			}
+			for _, z := range r.Zones {
+				switch z.Type {
+				case awsservices.AvailabilityZoneType:
+					zones = append(zones, z.Name)
+				default:
+					zones = append(zones, fmt.Sprintf("%s (%s)", z.Name, z.Type))
+				}
+			}
+			for _, api := range awsservices.APIs() {
+				if !offered[api] {
+					unavailable = append(unavailable, api)
+				}
+			}
+			unknown := strings.Join(r.UnknownAPIs, ", ")
+			if len(r.UnknownAPIs) == len(awsservices.APIs()) {
+				unknown = "all (region unknown to the catalog)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.ID, r.Partition, strings.Join(zones, ", "), strings.Join(unavailable, ", "), unknown)
+		}
+		exitOn(w.Flush())
+	},
+}
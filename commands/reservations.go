@@ -0,0 +1,153 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+const installationIDKey = "installation.id"
+
+// reservationsOwnerID is the owner of the claims of this run, resolved on first claim
+var reservationsOwnerID string
+
+// claimReservations claims the names and CIDR blocks created by the template (see `aws.reservations.target`),
+// failing when other users reserved them for their own runs
+func claimReservations(tplExec *template.TemplateExecution, region string) error {
+	var claims []*awsservices.Reservation
+	for _, cmd := range tplExec.Template.CommandNodesIterator() {
+		claims = append(claims, reservationClaims(cmd.Action, cmd.Entity, region, cmd.ToDriverParamsExcludingRefs())...)
+	}
+	if len(claims) == 0 {
+		return nil
+	}
+	owner, err := reservationsOwner()
+	if err != nil {
+		logger.Warningf("cannot claim names and CIDR blocks without owner identity: %s", err)
+		return nil
+	}
+	conflicts, err := awsservices.ClaimReservations(owner, claims)
+	if err != nil {
+		logger.Warningf("cannot claim names and CIDR blocks: %s", err)
+		return nil
+	}
+	if len(conflicts) > 0 {
+		for _, c := range conflicts {
+			logger.Error(c)
+		}
+		return errors.New("template creates names or CIDR blocks reserved by other users")
+	}
+	return nil
+}
+
+// releaseFailedReservations releases the claims of the creations which failed during the run
+func releaseFailedReservations(tplExec *template.TemplateExecution, region string) {
+	releaseReservations(tplExec, region, true)
+}
+
+// releaseAllReservations releases the claims of a template which will not run
+func releaseAllReservations(tplExec *template.TemplateExecution, region string) {
+	releaseReservations(tplExec, region, false)
+}
+
+func releaseReservations(tplExec *template.TemplateExecution, region string, failedOnly bool) {
+	if reservationsOwnerID == "" {
+		return
+	}
+	var claims []*awsservices.Reservation
+	for _, cmd := range tplExec.Template.CommandNodesIterator() {
+		if !failedOnly || cmd.Err() != nil {
+			claims = append(claims, reservationClaims(cmd.Action, cmd.Entity, region, cmd.ToDriverParamsExcludingRefs())...)
+		}
+	}
+	if len(claims) == 0 {
+		return
+	}
+	if err := awsservices.ReleaseReservations(reservationsOwnerID, claims); err != nil {
+		logger.Warning(err)
+	}
+}
+
+// reservationsOwner returns the owner of the claims, stable across the runs of an awless
+// installation: the IAM principal of the caller (a role without its session name, which may
+// change on each session) and the ID of the installation, so that two users sharing a
+// principal do not share their claims
+func reservationsOwner() (string, error) {
+	if reservationsOwnerID != "" {
+		return reservationsOwnerID, nil
+	}
+	me, err := awsservices.AccessService.(*awsservices.Access).GetIdentity()
+	if err != nil {
+		return "", err
+	}
+	var installationID string
+	err = database.Execute(func(db *database.DB) error {
+		if installationID, err = db.GetStringValue(installationIDKey); err != nil || installationID != "" {
+			return err
+		}
+		b := make([]byte, 8)
+		if _, err = rand.Read(b); err != nil {
+			return err
+		}
+		installationID = hex.EncodeToString(b)
+		return db.SetStringValue(installationIDKey, installationID)
+	})
+	if err != nil {
+		return "", err
+	}
+	reservationsOwnerID = reservationsOwnerOf(me, installationID)
+	return reservationsOwnerID, nil
+}
+
+func reservationsOwnerOf(me *awsservices.Identity, installationID string) string {
+	principal, err := me.PolicySourceArn()
+	if err != nil {
+		principal = me.Arn
+	}
+	return fmt.Sprintf("%s#%s", principal, installationID)
+}
+
+func reservationClaims(action, entity, region string, params map[string]interface{}) (claims []*awsservices.Reservation) {
+	if action != "create" {
+		return
+	}
+	if name, ok := params["name"].(string); ok && strings.TrimSpace(name) != "" {
+		claims = append(claims, &awsservices.Reservation{Scope: fmt.Sprintf("%s/%s", region, entity), Kind: awsservices.NameReservation, Value: name})
+	}
+	cidr, ok := params["cidr"].(string)
+	if !ok || cidr == "" {
+		return
+	}
+	switch entity {
+	case "vpc":
+		claims = append(claims, &awsservices.Reservation{Scope: fmt.Sprintf("%s/vpc", region), Kind: awsservices.CIDRReservation, Value: cidr})
+	case "subnet":
+		if vpc, ok := params["vpc"].(string); ok && vpc != "" {
+			claims = append(claims, &awsservices.Reservation{Scope: fmt.Sprintf("%s/%s", region, vpc), Kind: awsservices.CIDRReservation, Value: cidr})
+		}
+	}
+	return
+}
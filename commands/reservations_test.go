@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/wallix/awless/aws/services"
+)
+
+func TestReservationsOwner(t *testing.T) {
+	tcases := []struct {
+		identity *awsservices.Identity
+		exp      string
+	}{
+		{
+			identity: &awsservices.Identity{Account: "123456789012", Arn: "arn:aws:iam::123456789012:user/alice", ResourceType: "user", Resource: "alice"},
+			exp:      "arn:aws:iam::123456789012:user/alice#0a1b",
+		},
+		{
+			identity: &awsservices.Identity{Account: "123456789012", Arn: "arn:aws:sts::123456789012:assumed-role/Admin/session-1", ResourceType: "assumed-role", Resource: "Admin/session-1"},
+			exp:      "arn:aws:iam::123456789012:role/Admin#0a1b",
+		},
+		{
+			identity: &awsservices.Identity{Account: "123456789012", Arn: "arn:aws:sts::123456789012:assumed-role/Admin/session-2", ResourceType: "assumed-role", Resource: "Admin/session-2"},
+			exp:      "arn:aws:iam::123456789012:role/Admin#0a1b",
+		},
+		{
+			identity: &awsservices.Identity{Account: "123456789012", Arn: "arn:aws:iam::123456789012:root", ResourceType: "user", Resource: "root"},
+			exp:      "arn:aws:iam::123456789012:root#0a1b",
+		},
+	}
+	for _, tcase := range tcases {
+		if got, want := reservationsOwnerOf(tcase.identity, "0a1b"), tcase.exp; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	}
+}
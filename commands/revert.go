@@ -19,24 +19,29 @@ package commands
 import (
 	"errors"
 	"fmt"
-
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
 	"github.com/wallix/awless/template"
 )
 
+var revertOnlyFlag []int
+
 func init() {
 	RootCmd.AddCommand(revertCmd)
+	revertCmd.Flags().IntSliceVar(&revertOnlyFlag, "only", nil, "Revert only the statements at these positions (comma separated, as numbered by `awless log REVERTID`)")
 }
 
 var revertCmd = &cobra.Command{
 	Use:               "revert REVERTID",
 	Short:             "Revert a template execution given a revert ID (see `awless log` to list revert ids)",
-	Example:           "  awless revert 01BA7RV6ES86PZYCM3H28WM6KZ",
+	Example:           "  awless revert 01BA7RV6ES86PZYCM3H28WM6KZ\n  awless revert 01BA7RV6ES86PZYCM3H28WM6KZ --only 2,4   # revert only the 2nd and 4th statements",
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
@@ -48,10 +53,13 @@ var revertCmd = &cobra.Command{
 		revertID := args[0]
 
 		var loaded *template.TemplateExecution
-		exitOn(database.Execute(func(db *database.DB) (terr error) {
+		if err := database.Execute(func(db *database.DB) (terr error) {
 			loaded, terr = db.GetTemplate(revertID)
 			return
-		}))
+		}); err != nil {
+			warnHiddenLocalTemplates()
+			exitOn(err)
+		}
 
 		if loc := loaded.Locale; loc != "" && loc != config.GetAWSRegion() {
 			logger.Errorf("This template was originally run in region %s", loc)
@@ -63,7 +71,21 @@ var revertCmd = &cobra.Command{
 			logger.Warningf("This template was originally run with profile %s", prof)
 		}
 
-		reverted, err := loaded.Template.Revert()
+		confirmAliasConflicts(loaded)
+
+		var reverted *template.Template
+		var err error
+		message := fmt.Sprintf("Revert %s: %s", loaded.ID, loaded.Message)
+		if len(revertOnlyFlag) > 0 {
+			reverted, err = loaded.RevertStatements(revertOnlyFlag, localGraphForRevert())
+			var positions []string
+			for _, p := range revertOnlyFlag {
+				positions = append(positions, fmt.Sprint(p))
+			}
+			message = fmt.Sprintf("Revert statements %s of %s: %s", strings.Join(positions, ","), loaded.ID, loaded.Message)
+		} else {
+			reverted, err = loaded.Revert()
+		}
 		exitOn(err)
 
 		tplExec := &template.TemplateExecution{
@@ -72,10 +94,24 @@ var revertCmd = &cobra.Command{
 			Profile:  config.GetAWSProfile(),
 			Source:   reverted.String(),
 		}
-		tplExec.SetMessage(fmt.Sprintf("Revert %s: %s", loaded.ID, loaded.Message))
+		tplExec.SetMessage(message)
 
-		exitOn(NewRunnerRequiredParamsOnly(tplExec.Template, tplExec.Message, tplExec.Path).Run())
+		runner := NewRunnerRequiredParamsOnly(tplExec.Template, tplExec.Message, tplExec.Path)
+		runner.RevertOf, runner.RevertedStatements = loaded.ID, revertOnlyFlag
+		exitOn(runner.Run())
 
 		return nil
 	},
 }
+
+// localGraphForRevert returns the local graph to check the dependents of the resources to revert,
+// or nil when it cannot be loaded
+func localGraphForRevert() *graph.Graph {
+	gph, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+	if err != nil {
+		logger.Verbosef("cannot load local graph to check dependents: %s", err)
+		return nil
+	}
+	g, _ := gph.(*graph.Graph)
+	return g
+}
@@ -32,6 +32,7 @@ var (
 	awsRegionGlobalFlag    string
 	awsProfileGlobalFlag   string
 	awsColorGlobalFlag     string
+	formatGlobalFlag       string
 	networkMonitorFlag     bool
 
 	renderGreenFn    = color.New(color.FgGreen).SprintFunc()
@@ -53,6 +54,7 @@ func init() {
 	RootCmd.PersistentFlags().StringVarP(&awsProfileGlobalFlag, "aws-profile", "p", "", "Override AWS profile temporarily for the current command")
 	RootCmd.PersistentFlags().SetAnnotation("aws-profile", cobra.BashCompCustom, []string{"__awless_profile_list"})
 	RootCmd.PersistentFlags().StringVar(&awsColorGlobalFlag, "color", "auto", "Force enabling/disabling colors in display (auto, never, always)")
+	RootCmd.PersistentFlags().StringVar(&formatGlobalFlag, "format", "table", "Output format: table, json, yaml, jsonl (JSON lines) for list, show, run, sync and the reports (check, preflight, audit, cost). Lists also support csv, tsv, porcelain, and show supports dot, d3")
 	RootCmd.PersistentFlags().BoolVar(&networkMonitorFlag, "network-monitor", false, "Debug requests with network monitor")
 	RootCmd.PersistentFlags().MarkHidden("network-monitor")
 
@@ -153,6 +155,10 @@ __custom_func() {
 				awless_ssh )
             __awless_get_instances_ids
             return
+            ;;
+				awless_rdp )
+            __awless_get_instances_ids
+            return
             ;;
 				awless_show )
             __awless_get_all_ids
@@ -37,6 +37,7 @@ import (
 
 	"github.com/chzyer/readline"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/wallix/awless-scheduler/client"
 	"github.com/wallix/awless/aws/doc"
 	"github.com/wallix/awless/aws/services"
@@ -45,6 +46,7 @@ import (
 	"github.com/wallix/awless/cloud/match"
 	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
 	"github.com/wallix/awless/template"
@@ -58,6 +60,14 @@ var (
 	listRemoteTemplatesFlag bool
 	noSuggestedParamsFlag   bool
 	allSuggestedParamsFlag  bool
+	runHookScriptsFlag      []string
+	runUpdateOfFlag         string
+	runRetryLastFlag        bool
+	runAnswersFlag          string
+	runRecordAnswersFlag    string
+	runProgressFdFlag       int
+	runCheckPermissionsFlag bool
+	deleteCascadeFlag       bool
 )
 
 func init() {
@@ -66,6 +76,13 @@ func init() {
 	runCmd.Flags().StringVar(&scheduleRunInFlag, "run-in", "", "Postpone the execution of this template")
 	runCmd.Flags().StringVar(&scheduleRevertInFlag, "revert-in", "", "Schedule the revertion of this template")
 	runCmd.Flags().StringVarP(&runLogMessage, "message", "m", "", "Add a message for this template execution to be persisted in your logs")
+	runCmd.Flags().StringSliceVar(&runHookScriptsFlag, "hook", []string{}, "Script executed after each statement with the run metadata as env variables (AWLESS_RUN_ID, AWLESS_RUN_STATEMENT_INDEX, AWLESS_VAR_<NAME>, ...)")
+	runCmd.Flags().StringVar(&runAnswersFlag, "answers", "", "Run noninteractively with the holes values, aliases choices and confirmations of a YAML answers file")
+	runCmd.Flags().StringVar(&runRecordAnswersFlag, "record-answers", "", "Record the answers given during the run to a YAML file to replay with --answers")
+	runCmd.Flags().IntVar(&runProgressFdFlag, "progress-fd", 0, "Write the progress of the run (compile started, hole needed, statement done, ...) as newline-delimited JSON events to the given file descriptor (ex: 3)")
+	runCmd.Flags().BoolVar(&runRetryLastFlag, "retry-last", false, "Run again the failed statement of the last run, with the same bound references, once its failure is fixed (ex: quota raised)")
+	runCmd.Flags().StringVar(&runUpdateOfFlag, "update-of", "", "Converge the resources created by a previous run (see `awless log` for ids) instead of creating them again")
+	runCmd.Flags().BoolVar(&runCheckPermissionsFlag, "check-permissions", false, "Simulate the IAM policies of the caller for the AWS call of each statement and abort before running when one would be denied")
 
 	var actions []string
 	for a := range awsspec.DriverSupportedActions {
@@ -77,18 +94,46 @@ func init() {
 		entities := awsspec.DriverSupportedActions[action]
 		sort.Strings(entities)
 		cmd := createDriverCommands(action, entities)
-		cmd.PersistentFlags().StringVar(&scheduleRunInFlag, "run-in", "", "Postpone the execution of this command")
-		cmd.PersistentFlags().StringVar(&scheduleRevertInFlag, "revert-in", "", "Schedule the revertion of this command")
+		if parent, ok := driverParentCommands[action]; ok {
+			for _, entityCmd := range cmd.Commands() {
+				addDriverFlags(entityCmd.Flags())
+				parent.AddCommand(entityCmd)
+			}
+			continue
+		}
+		addDriverFlags(cmd.PersistentFlags())
+		cmd.AddCommand(driverExtraCommands[action]...)
 		RootCmd.AddCommand(cmd)
 	}
 }
 
+func addDriverFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&scheduleRunInFlag, "run-in", "", "Postpone the execution of this command")
+	flags.StringVar(&scheduleRevertInFlag, "revert-in", "", "Schedule the revertion of this command")
+	flags.StringSliceVar(&runHookScriptsFlag, "hook", []string{}, "Script executed after the command with the run metadata as env variables (AWLESS_RUN_ID, AWLESS_VAR_<NAME>, ...)")
+	flags.IntVar(&runProgressFdFlag, "progress-fd", 0, "Write the progress of the command as newline-delimited JSON events to the given file descriptor (ex: 3)")
+	flags.BoolVar(&runCheckPermissionsFlag, "check-permissions", false, "Simulate the IAM policies of the caller for the AWS call of the command and abort before running when it would be denied")
+}
+
 const maxMsgLen = 140
 
+// driverExtraCommands are added to the driver command of an action
+// as they share its name without running a template definition
+var driverExtraCommands = map[string][]*cobra.Command{
+	"import": {importGraphCmd, importZonefileCmd},
+}
+
+// driverParentCommands are existing commands named after an action: the driver commands
+// of its entities are added to them instead of to a new action command (ex: awless sync s3object)
+var driverParentCommands = map[string]*cobra.Command{
+	"sync": syncCmd,
+}
+
 var runCmd = &cobra.Command{
 	Use:               "run PATH",
 	Short:             "Run a template given a filepath or URL",
-	Example:           "  awless run ~/templates/my-infra.txt\n  awless run https://raw.githubusercontent.com/wallix/awless-templates/master/create_vpc.awls\n  awless run repo:create_vpc",
+	Long:              "Run a template given a filepath or URL.\n\nHoles are asked when not given as KEY=VALUE args, except builtin holes filled by awless: {date.today}, {date.now}, {date.unix}, {aws.account}, {aws.user}, {aws.region} and {aws.profile} (ex: name=backups-{aws.account}-{date.today}).\n\nTemplates generated by other templating tools using braces (Jinja, Go templates, ...) can delimit their holes otherwise with a directive on their first line (ex: '# awless:holes [[ ]]' for holes as [[ name ]])",
+	Example:           "  awless run ~/templates/my-infra.txt\n  awless run https://raw.githubusercontent.com/wallix/awless-templates/master/create_vpc.awls\n  awless run repo:create_vpc\n  awless run ~/templates/my-infra.txt --update-of 01BA7RV6ES86PZYCM3H28WM6KZ\n  awless run --retry-last\n  awless run ~/templates/my-infra.txt --record-answers answers.yaml\n  awless run ~/templates/my-infra.txt --answers answers.yaml\n  awless run ~/templates/my-infra.txt --progress-fd 3 3>progress.log",
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
@@ -97,6 +142,10 @@ var runCmd = &cobra.Command{
 			exitOn(listRemoteTemplates())
 			return nil
 		}
+		if runRetryLastFlag {
+			exitOn(retryLastRun())
+			return nil
+		}
 		if len(args) < 1 {
 			return errors.New("missing PATH arg (filepath or url)")
 		}
@@ -105,6 +154,17 @@ var runCmd = &cobra.Command{
 			exitOn(fmt.Errorf("message to be persisted should not exceed %d characters", maxMsgLen))
 		}
 
+		if runAnswersFlag != "" && runRecordAnswersFlag != "" {
+			exitOn(errors.New("--answers and --record-answers are mutually exclusive"))
+		}
+		if runAnswersFlag != "" {
+			var err error
+			answers, err = loadRunAnswers(runAnswersFlag)
+			exitOn(err)
+		} else if runRecordAnswersFlag != "" {
+			answers = newRecordedRunAnswers(runRecordAnswersFlag)
+		}
+
 		content, fullPath, err := getTemplateText(args[0])
 		exitOn(err)
 
@@ -125,20 +185,85 @@ var runCmd = &cobra.Command{
 			Source:   templ.String(),
 		}
 
-		exitOn(NewRunnerRequiredParamsOnly(tplExec.Template, tplExec.Message, tplExec.Path, config.Defaults, extraParams).Run())
+		runner := NewRunnerRequiredParamsOnly(tplExec.Template, tplExec.Message, tplExec.Path, config.Defaults, extraParams)
+		if runUpdateOfFlag != "" {
+			runner.UpdateOf = loadUpdatedRun(runUpdateOfFlag)
+			if runner.Message == "" {
+				runner.Message = fmt.Sprintf("Update of %s", runner.UpdateOf.ID)
+			}
+		}
+		exitOn(runner.Run())
 
 		return nil
 	},
 }
 
+func loadUpdatedRun(id string) *template.TemplateExecution {
+	var loaded *template.TemplateExecution
+	exitOn(database.Execute(func(db *database.DB) (terr error) {
+		loaded, terr = db.GetTemplate(id)
+		return
+	}))
+
+	if loc := loaded.Locale; loc != "" && loc != config.GetAWSRegion() {
+		logger.Errorf("This template was originally run in region %s", loc)
+		logger.Infof("Update with `awless run ... --update-of %s -r %s -p %s`", id, loc, loaded.Profile)
+		os.Exit(1)
+	}
+	if prof := loaded.Profile; prof != config.GetAWSProfile() {
+		logger.Warningf("This template was originally run with profile %s", prof)
+	}
+	confirmAliasConflicts(loaded)
+	return loaded
+}
+
+// retryLastRun runs again the failed statements of the last run in the current profile and region
+func retryLastRun() error {
+	var last *template.TemplateExecution
+	if err := database.Execute(func(db *database.DB) error {
+		loaded, err := db.ListTemplates()
+		if err != nil {
+			return err
+		}
+		for i := len(loaded) - 1; i >= 0; i-- {
+			if l := loaded[i]; l.Err == nil && l.TplExec.Profile == config.GetAWSProfile() && l.TplExec.Locale == config.GetAWSRegion() {
+				last = l.TplExec
+				return nil
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if last == nil {
+		return fmt.Errorf("no run to retry with profile %s in %s", config.GetAWSProfile(), config.GetAWSRegion())
+	}
+
+	tpl, err := last.Retry()
+	if err != nil {
+		return fmt.Errorf("cannot retry last run %s: %s", last.ID, err)
+	}
+	logger.Infof("Retrying failed statement of run %s", last.ID)
+
+	return NewRunnerRequiredParamsOnly(tpl, fmt.Sprintf("Retry of %s", last.ID), last.Path).Run()
+}
+
 func missingHolesStdinFunc() func(string, []string, bool) string {
+	askStdin := missingHolesPromptFunc()
+	return func(hole string, paramPaths []string, optional bool) string {
+		response, err := answers.hole(hole, optional, func() string { return askStdin(hole, paramPaths, optional) })
+		exitOn(err)
+		return response
+	}
+}
+
+func missingHolesPromptFunc() func(string, []string, bool) string {
 	var count int
 	return func(hole string, paramPaths []string, optional bool) (response string) {
 		if count < 1 {
 			fmt.Println("Please specify (Ctrl+C to quit, Tab for completion, Enter to skip optionals):")
 		}
-		var docs, enums []string
-		var typedParam *awsdoc.ParamType
+		var docs []string
 		for _, param := range paramPaths {
 			splits := strings.Split(param, ".")
 			if len(splits) != 3 {
@@ -147,25 +272,12 @@ func missingHolesStdinFunc() func(string, []string, bool) string {
 			if doc, hasDoc := awsdoc.TemplateParamsDoc(splits[0], splits[1], splits[2]); hasDoc {
 				docs = append(docs, doc)
 			}
-			if enum, hasEnum := awsdoc.EnumDoc[param]; hasEnum {
-				enums = append(enums, enum...)
-			}
-			if tparam, has := awsdoc.ParamTypeDoc[param]; has {
-				typedParam = tparam
-			}
 		}
 		if len(docs) > 0 {
 			fmt.Fprintln(os.Stderr, strings.Join(docs, "; ")+":")
 		}
 
-		autocomplete := holeAutoCompletion(allGraphsOnce.mustLoad(), paramPaths)
-		if typedParam != nil {
-			autocomplete = typedParamCompletionFunc(allGraphsOnce.mustLoad(), typedParam.ResourceType, typedParam.PropertyName)
-		}
-
-		if len(enums) > 0 {
-			autocomplete = enumCompletionFunc(enums)
-		}
+		autocomplete := paramsAutoCompletion(allGraphsOnce.mustLoad(), paramPaths)
 
 		var promptSuffix string
 		if optional {
@@ -290,6 +402,11 @@ func createDriverCommands(action string, entities []string) *cobra.Command {
 					exitOn(err)
 				}
 
+				if deleteCascadeFlag {
+					templ, err = cascadeTemplate(templ)
+					exitOn(err)
+				}
+
 				tplExec := &template.TemplateExecution{
 					Template: templ,
 					Locale:   config.GetAWSRegion(),
@@ -344,6 +461,9 @@ func createDriverCommands(action string, entities []string) *cobra.Command {
 {{end}}{{if or .Runnable .HasSubCommands}}{{.UsageString}}{{end}}`)
 		currentCmd.Flags().BoolVar(&noSuggestedParamsFlag, "prompt-only-required", false, "Prompt only required parameters")
 		currentCmd.Flags().BoolVarP(&allSuggestedParamsFlag, "prompt-all", "a", false, "Prompt all non-provided parameters")
+		if action == "delete" {
+			currentCmd.Flags().BoolVar(&deleteCascadeFlag, "cascade", false, "Detach or delete first the resources depending on the deleted one in the local graph (see `awless show --dependents`), previewed before confirmation")
+		}
 
 		actionCmd.AddCommand(currentCmd)
 	}
@@ -376,6 +496,9 @@ func runSyncFor(tplExec *template.TemplateExecution) {
 }
 
 func resolveAliasFunc(paramPath, alias string) string {
+	if id, ok := answers.alias(alias); ok {
+		return id
+	}
 	splits := strings.Split(paramPath, ".")
 	if len(splits) != 3 {
 		logger.Errorf("resolve alias: invalid param path: %s", paramPath)
@@ -417,6 +540,10 @@ func resolveAliasFunc(paramPath, alias string) string {
 		if len(resources) > 0 {
 			matchingResource = resources[0]
 		}
+		if len(resources) > 1 {
+			logger.Warningf("alias '@%s' matches %d resources: using %s (choose another one with an answers file)", alias, len(resources), matchingResource.Id())
+			exitOn(answers.recordAlias(alias, matchingResource.Id()))
+		}
 	}
 	if matchingResource == nil {
 		return ""
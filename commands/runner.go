@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -9,7 +10,9 @@ import (
 	"github.com/wallix/awless/aws/spec"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
 	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
 	"github.com/wallix/awless/template"
@@ -32,8 +35,14 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 	runner.Message = msg
 	runner.TemplatePath = tplPath
 	runner.Fillers = fillers
+	runner.Defaults = config.Defaults
 	runner.AliasFunc = resolveAliasFunc
 	runner.MissingHolesFunc = missingHolesStdinFunc()
+	runner.BuiltinFunc = resolveBuiltinFunc()
+	runner.HookScripts = runHookScriptsFlag
+	if runProgressFdFlag > 0 {
+		runner.Progress = template.NewProgressWriter(progressFile(runProgressFdFlag))
+	}
 	if allSuggestedParamsFlag {
 		runner.ParamsSuggested = env.ALL_PARAMS
 	}
@@ -46,8 +55,28 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 			g := sync.LoadLocalGraphForService(awsservices.ServicePerResourceType[key], config.GetAWSProfile(), config.GetAWSRegion())
 			return g, true
 		}},
+		&template.LockedResourcesValidator{LockedIDs: config.GetLockedResources(), Graph: func() cloud.GraphAPI {
+			g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+			if err != nil {
+				logger.Verbosef("cannot load local graph to check locked resources: %s", err)
+				return graph.NewGraph()
+			}
+			return g
+		}},
+		&template.RegionAPIsValidator{Region: config.GetAWSRegion(), APIPerEntity: awsservices.APIPerResourceType, APIAvailability: awsservices.APIAvailabilityInRegion},
 		&template.ParamIsSetValidator{Action: "create", Entity: "instance", Param: "keypair", WarningMessage: "This instance has no access keypair. You might not be able to connect to it. Use `awless create instance keypair=my-keypair ...`"},
 	}
+	if runCheckPermissionsFlag {
+		runner.Validators = append(runner.Validators, &template.PermissionsValidator{IAMAction: awsspec.IAMAction, Simulate: simulateCallerActions})
+	}
+
+	runner.SucceededKeys = func(profile, region string) (keys map[string]*env.Result, err error) {
+		err = database.Execute(func(db *database.DB) (dberr error) {
+			keys, dberr = db.GetSucceededKeys(profile, region)
+			return
+		})
+		return
+	}
 
 	runner.CmdLookuper = func(tokens ...string) interface{} {
 		newCommandFunc := awsspec.CommandFactory.Build(strings.Join(tokens, ""))
@@ -58,22 +87,23 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 	}
 
 	runner.BeforeRun = func(tplExec *template.TemplateExecution) (bool, error) {
-		var yesorno string
-		if forceGlobalFlag {
-			yesorno = "y"
-		} else {
-			fmt.Printf("%s\n\n", renderGreenFn(tplExec.Template))
+		confirmed := forceGlobalFlag
+		if !confirmed {
+			answerKey, prompt := runConfirmAnswer, "Confirm? [y/N] "
 			if isSchedulingMode() {
-				fmt.Print("Confirm scheduling? [y/N] ")
-			} else {
-				fmt.Print("Confirm? [y/N] ")
+				answerKey, prompt = scheduleConfirmAnswer, "Confirm scheduling? [y/N] "
 			}
-			if _, err := fmt.Scanln(&yesorno); err != nil && err.Error() != "unexpected newline" {
+			var err error
+			confirmed, err = confirmWith(answerKey, &confirmRequest{prompt: prompt, template: tplExec.Template, defaults: tplExec.Defaults, blastRadius: computeBlastRadius(tplExec.Template)})
+			if err != nil {
 				return false, err
 			}
 		}
 
-		if strings.TrimSpace(strings.ToLower(yesorno)) == "y" {
+		if !confirmed && inShell {
+			return false, nil
+		}
+		if confirmed {
 			me, err := awsservices.AccessService.(*awsservices.Access).GetIdentity()
 			if err != nil {
 				logger.Warningf("cannot resolve template author identity: %s", err)
@@ -81,9 +111,20 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 				tplExec.Author = me.ResourcePath
 				logger.ExtraVerbosef("resolved template author: %s", tplExec.Author)
 			}
+			if sources, _ := config.Config["operator.sources"].(string); sources != "" {
+				tplExec.Operator = resolveOperator(sources)
+				logger.ExtraVerbosef("resolved template operator: %s", tplExec.Operator)
+			}
 			if isSchedulingMode() {
 				return false, scheduleTemplate(tplExec.Template, scheduleRunInFlag, scheduleRevertInFlag)
 			}
+			if err := claimReservations(tplExec, config.GetAWSRegion()); err != nil {
+				return false, err
+			}
+			if err := lockTemplatesLog(tplExec); err != nil {
+				releaseAllReservations(tplExec, config.GetAWSRegion())
+				return false, err
+			}
 			return true, nil
 		}
 		os.Exit(1)
@@ -91,6 +132,9 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 	}
 
 	runner.AfterRun = func(tplExec *template.TemplateExecution) error {
+		releaseFailedReservations(tplExec, config.GetAWSRegion())
+		tagCreatedResourcesWithOperator(tplExec)
+
 		if tplExec.Message == "" {
 			if tplExec.IsOneLiner() {
 				tplExec.SetMessage(fmt.Sprintf("Run %s", tplExec.Template))
@@ -109,9 +153,27 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 		}); err != nil {
 			logger.Errorf("Cannot save executed template in awless logs: %s", err)
 		}
+		unlockTemplatesLog()
+
+		report := tplExec.Report()
+		if content, err := report.JSON(); err != nil {
+			logger.Errorf("Cannot build audit report: %s", err)
+		} else if err := awsservices.ShipAuditReport(tplExec.ID, tplExec.Date(), content); err != nil {
+			logger.Errorf("Cannot ship audit report: %s", err)
+		}
+
+		// the report of the run is the output of the structured formats
+		structured := console.IsStructuredFormat(formatGlobalFlag)
+		if structured {
+			if err := console.EncodeStructured(os.Stdout, formatGlobalFlag, report); err != nil {
+				logger.Errorf("Cannot output run report: %s", err)
+			}
+		}
 
 		if template.IsRevertible(tplExec.Template) {
-			fmt.Println()
+			if !structured {
+				fmt.Println()
+			}
 			logger.Infof("Revert this template with `awless revert %s`", tplExec.Template.ID)
 		}
 
@@ -122,3 +184,110 @@ func NewRunner(tpl *template.Template, msg, tplPath string, fillers ...map[strin
 
 	return runner
 }
+
+// resolveBuiltinFunc returns the values of the identity builtin holes of templates (ex: {aws.account}),
+// resolving the caller identity once when needed
+func resolveBuiltinFunc() func(string) (string, error) {
+	var me *awsservices.Identity
+	return func(name string) (string, error) {
+		switch name {
+		case "aws.region":
+			return config.GetAWSRegion(), nil
+		case "aws.profile":
+			return config.GetAWSProfile(), nil
+		}
+		if me == nil {
+			var err error
+			if me, err = awsservices.AccessService.(*awsservices.Access).GetIdentity(); err != nil {
+				return "", fmt.Errorf("cannot resolve caller identity: %s", err)
+			}
+		}
+		switch name {
+		case "aws.account":
+			return me.Account, nil
+		case "aws.user":
+			return me.Resource, nil
+		default:
+			return "", fmt.Errorf("unknown builtin")
+		}
+	}
+}
+
+// simulateCallerActions simulates the IAM policies of the caller (iam:SimulatePrincipalPolicy) for the given actions
+func simulateCallerActions(actions []string) (map[string]string, error) {
+	access := awsservices.AccessService.(*awsservices.Access)
+	me, err := access.GetIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return access.SimulateActions(me, actions)
+}
+
+// confirmAliasConflicts warns when the aliases recorded with a previous run now resolve
+// to other resources, and asks for confirmation before operating on that run
+func confirmAliasConflicts(tplExec *template.TemplateExecution) {
+	conflicts := tplExec.AliasConflicts(resolveAliasFunc)
+	if len(conflicts) == 0 {
+		return
+	}
+	for _, c := range conflicts {
+		logger.Warning(c)
+	}
+	logger.Warningf("run %s operates on the resources resolved at the time of the run", tplExec.ID)
+
+	if forceGlobalFlag {
+		return
+	}
+	confirmed, err := confirmWith(aliasConflictsConfirmAnswer, &confirmRequest{prompt: "Continue anyway? [y/N] "})
+	exitOn(err)
+	if !confirmed {
+		os.Exit(1)
+	}
+}
+
+func promptConfirm(w io.Writer, prompt string) (bool, error) {
+	var yesorno string
+	fmt.Fprint(w, prompt)
+	if _, err := fmt.Scanln(&yesorno); err != nil && err.Error() != "unexpected newline" {
+		return false, err
+	}
+	return strings.TrimSpace(strings.ToLower(yesorno)) == "y", nil
+}
+
+// computeBlastRadius scores the delete/update statements of the template against the local graphs
+func computeBlastRadius(tpl *template.Template) []*template.BlastRadius {
+	g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+	if err != nil {
+		logger.Verbosef("cannot load local graph to compute blast radius: %s", err)
+		return nil
+	}
+	all, err := template.ComputeBlastRadius(tpl, g)
+	if err != nil {
+		logger.Verbosef("cannot compute blast radius: %s", err)
+		return nil
+	}
+	return all
+}
+
+func printAppliedDefaults(w io.Writer, defaults []*template.AppliedDefault) {
+	if len(defaults) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "Params set from your defaults (see `awless config`):")
+	for _, d := range defaults {
+		fmt.Fprintf(w, "\t%s%s = %s\n", config.DefaultsPrefix, d.Key, d.Value)
+	}
+	fmt.Fprintln(w)
+}
+
+// progressFile returns the file of the descriptor inherited from the parent process (ex: `awless run --progress-fd 3 ... 3>progress.log`)
+func progressFile(fd int) *os.File {
+	f := os.NewFile(uintptr(fd), fmt.Sprintf("progress-fd-%d", fd))
+	if f == nil {
+		exitOn(fmt.Errorf("invalid progress file descriptor %d", fd))
+	}
+	if _, err := f.Stat(); err != nil {
+		exitOn(fmt.Errorf("invalid progress file descriptor %d: %s", fd, err))
+	}
+	return f
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/ssh"
+)
+
+var scpRecursiveFlag bool
+
+func init() {
+	RootCmd.AddCommand(scpCmd)
+	scpCmd.Flags().BoolVarP(&scpRecursiveFlag, "recursive", "r", false, "Recursively copy entire directories")
+	scpCmd.Flags().StringVarP(&keyPathFlag, "identity", "i", "", "Set path or name toward the identity (key file) to use to connect through SSH")
+	scpCmd.Flags().IntVar(&sshPortFlag, "port", 22, "Set SSH target port")
+	scpCmd.Flags().IntVar(&sshTroughPortFlag, "through-port", 22, "Set SSH proxy port")
+	scpCmd.Flags().StringVar(&proxyInstanceThroughFlag, "through", "", "Name of instance to proxy through to connect to a destination host")
+	scpCmd.Flags().BoolVar(&privateIPFlag, "private", false, "Use private ip to connect to host")
+	scpCmd.Flags().BoolVar(&disableStrictHostKeyCheckingFlag, "disable-strict-host-keychecking", false, "Disable the remote host key check from ~/.ssh/known_hosts or ~/.awless/known_hosts file")
+}
+
+var scpCmd = &cobra.Command{
+	Use:   "scp SOURCE... DESTINATION",
+	Short: "Copy files to or from an instance given an id or alias, through SFTP",
+	Long:  "Copy files to or from an instance given an id or alias, through SFTP. Remote paths are written [USER@]INSTANCE:PATH and connection details are derived from the instance as with `awless ssh`.",
+	Example: `  awless scp app.tar.gz redis-prod:/tmp                   # upload a file into a remote directory
+  awless scp -r conf/ ec2-user@redis-prod:conf             # upload a directory
+  awless scp *.sql db-private:backups --through my-bastion # upload files matching a local pattern through a bastion
+  awless scp redis-prod:/var/log/redis/*.log .             # download files matching a remote pattern
+  awless scp -r i-8d43b21b:/etc/nginx nginx-conf           # download a directory`,
+
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return errors.New("source and destination required")
+		}
+		sources, dest := args[:len(args)-1], args[len(args)-1]
+
+		var progress io.Writer = os.Stderr
+		if silentGlobalFlag {
+			progress = ioutil.Discard
+		}
+
+		if host, remote, ok := splitRemotePath(dest); ok {
+			for _, src := range sources {
+				if _, _, remoteSrc := splitRemotePath(src); remoteSrc {
+					return errors.New("cannot copy between instances: either the sources or the destination must be local")
+				}
+			}
+			client, _ := dialInstance(host)
+			defer client.CloseAll()
+			exitOn(client.Upload(sources, remote, scpRecursiveFlag, newTransferProgress(progress)))
+			return nil
+		}
+
+		var host string
+		var remotes []string
+		for _, src := range sources {
+			h, remote, ok := splitRemotePath(src)
+			if !ok {
+				return fmt.Errorf("'%s' is local: either the sources or the destination must be on an instance ([USER@]INSTANCE:PATH)", src)
+			}
+			if host != "" && h != host {
+				return errors.New("cannot copy from several instances at once")
+			}
+			host = h
+			remotes = append(remotes, remote)
+		}
+		client, _ := dialInstance(host)
+		defer client.CloseAll()
+		exitOn(client.Download(remotes, dest, scpRecursiveFlag, newTransferProgress(progress)))
+		return nil
+	},
+}
+
+// splitRemotePath splits a [USER@]INSTANCE:PATH argument, the path defaulting to the home directory
+func splitRemotePath(arg string) (string, string, bool) {
+	i := strings.Index(arg, ":")
+	if i <= 0 || strings.ContainsAny(arg[:i], `/\`) {
+		return "", "", false
+	}
+	remote := arg[i+1:]
+	if remote == "" {
+		remote = "."
+	}
+	return arg[:i], remote, true
+}
+
+// newTransferProgress displays the progress of each file on a single line, refreshed at most every 100ms
+func newTransferProgress(w io.Writer) ssh.ProgressFunc {
+	var last time.Time
+	return func(file string, transferred, size int64) {
+		done := transferred >= size
+		if !done && time.Since(last) < 100*time.Millisecond {
+			return
+		}
+		last = time.Now()
+		percent := int64(100)
+		if size > 0 {
+			percent = transferred * 100 / size
+		}
+		fmt.Fprintf(w, "\r%s  %s / %s  %3d%%", file, console.HumanizeBytes(uint64(transferred)), console.HumanizeBytes(uint64(size)), percent)
+		if done {
+			fmt.Fprintln(w)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package commands
+
+import "testing"
+
+func TestSplitRemotePath(t *testing.T) {
+	tcases := []struct {
+		arg, host, path string
+		remote          bool
+	}{
+		{arg: "redis-prod:/tmp", host: "redis-prod", path: "/tmp", remote: true},
+		{arg: "ec2-user@i-8d43b21b:conf/app.yml", host: "ec2-user@i-8d43b21b", path: "conf/app.yml", remote: true},
+		{arg: "redis-prod:", host: "redis-prod", path: ".", remote: true},
+		{arg: "app.tar.gz"},
+		{arg: "./dir/with:colon"},
+		{arg: ":/tmp"},
+	}
+	for i, tcase := range tcases {
+		host, path, remote := splitRemotePath(tcase.arg)
+		if host != tcase.host || path != tcase.path || remote != tcase.remote {
+			t.Fatalf("case %d: got (%s, %s, %t), want (%s, %s, %t)", i+1, host, path, remote, tcase.host, tcase.path, tcase.remote)
+		}
+	}
+}
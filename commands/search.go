@@ -18,23 +18,31 @@ package commands
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
 )
 
 var (
 	showIdsOnlyFlag, showIdOnlyFlag, showLatestIdOnly bool
+	searchLimitFlag                                   int
 )
 
 func init() {
 	RootCmd.AddCommand(searchCmd)
 
+	searchCmd.Flags().IntVar(&searchLimitFlag, "limit", 20, "Maximum number of results to display (0 for all)")
+
 	awsImagesCmd.Flags().BoolVar(&showLatestIdOnly, "latest-id", false, "Returns the id only of the latest AMI matching your query")
 	awsImagesCmd.Flags().BoolVar(&showIdOnlyFlag, "id-only", false, "(DEPRECATED, use latest-id) Returns only one (the latest) AMI id matching the query")
 
@@ -42,8 +50,56 @@ func init() {
 }
 
 var searchCmd = &cobra.Command{
-	Use:   "search",
-	Short: "Perform various searches and resolution",
+	Use:   "search TERM...",
+	Short: "Full-text search of your locally synced resources, or perform various searches and resolution",
+	Long: `Full-text search of your locally synced resources (current region and global) through all their properties: names, tags, ARNs, ...
+
+Resources must match all the terms (case insensitive, prefixes allowed). Results are ranked by relevance: exact words score higher than prefixes, rare words higher than common ones and matches on id, name, ARN and tags higher than matches on other properties.`,
+	Example: `  awless search prod
+  awless search web frontend
+  awless search 10.0.1 --limit 5`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("TERM required. See examples.")
+		}
+		local, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+		exitOn(err)
+		g, ok := local.(*graph.Graph)
+		if !ok {
+			exitOn(fmt.Errorf("search: unexpected graph type %T", local))
+		}
+
+		results, err := g.Search(args...)
+		exitOn(err)
+		if len(results) == 0 {
+			logger.Infof("no resource matching '%s' in local data of region %s (run `awless sync` to refresh)", strings.Join(args, " "), config.GetAWSRegion())
+			return nil
+		}
+
+		count := len(results)
+		if searchLimitFlag > 0 && len(results) > searchLimitFlag {
+			results = results[:searchLimitFlag]
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "Type\tID\tName\tMatched\tScore")
+		fmt.Fprintln(w, "----\t--\t----\t-------\t-----")
+		for _, r := range results {
+			name, ok := r.Resource.Property(properties.Name)
+			if !ok {
+				name = ""
+			}
+			fmt.Fprintf(w, "%s\t%s\t%v\t%s\t%.2f\n", r.Resource.Type(), r.Resource.Id(), name, strings.Join(r.Properties, ","), r.Score)
+		}
+		w.Flush()
+		if count > len(results) {
+			logger.Infof("%d more results (use --limit)", count-len(results))
+		}
+		return nil
+	},
 }
 
 var awsImagesCmd = &cobra.Command{
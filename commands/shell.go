@@ -0,0 +1,215 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/spec"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/params"
+)
+
+// inShell is set when statements are run from the interactive shell,
+// which must keep prompting when a statement fails or is not confirmed
+var inShell bool
+
+func init() {
+	RootCmd.AddCommand(shellCmd)
+}
+
+var shellCmd = &cobra.Command{
+	Use:               "shell",
+	Short:             "Interactive prompt running template statements with completion of actions, entities, params and resources",
+	Long:              "Interactive prompt running template statements (ex: create instance name=web subnet=@my-subnet) one line at a time.\n\nTab completes actions, entities and params, and the values of params from their enums or the ids, names (@name) and properties of the resources of the local graph. The history of the statements is kept across sessions. Type 'help' for help, 'exit' or Ctrl+D to quit.",
+	Example:           "  awless shell\n  awless shell --aws-region eu-west-1",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inShell = true
+		completer := new(shellCompleter)
+		completer.loadGraph()
+		vars := make(map[string]interface{})
+
+		for {
+			line, err := readShellLine(completer)
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			switch line {
+			case "":
+				continue
+			case "exit", "quit":
+				return nil
+			case "help":
+				fmt.Println(shellHelp)
+				continue
+			}
+			if err := runShellStatement(line, vars); err != nil {
+				logger.Error(err)
+			}
+			completer.loadGraph()
+		}
+	},
+}
+
+const shellHelp = `Type template statements as you would write them in a template or after 'awless':
+  create instance name=web subnet=@my-subnet
+  inst = create instance name=web
+  delete instance id=$inst
+
+Variables assigned in a line (ex: inst) can be referenced by the next ones.
+Tab completes actions, entities, params and their values. Type 'exit' or Ctrl+D to quit.`
+
+// readShellLine prompts with a new readline instance each time so that
+// the prompts of the runs (holes, confirmations) have the terminal to themselves
+func readShellLine(completer readline.AutoCompleter) (string, error) {
+	l, err := readline.NewEx(&readline.Config{
+		Prompt:          renderCyanBoldFn(fmt.Sprintf("awless (%s)", config.GetAWSRegion())) + " > ",
+		HistoryFile:     filepath.Join(config.AwlessHome, "shell_history"),
+		AutoComplete:    completer,
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+
+	for {
+		line, err := l.Readline()
+		if err == readline.ErrInterrupt {
+			continue
+		}
+		return strings.TrimSpace(line), err
+	}
+}
+
+// runShellStatement runs the line with the variables assigned by the previous lines,
+// adding the results of its own assignments to them
+func runShellStatement(line string, vars map[string]interface{}) error {
+	tpl, err := template.Parse(line)
+	if err != nil {
+		return err
+	}
+	runner := NewRunner(tpl.WithVariables(vars), "", "", config.Defaults)
+	runner.ReturnErrors = true
+	afterRun := runner.AfterRun
+	runner.AfterRun = func(tplExec *template.TemplateExecution) error {
+		for ident, result := range tplExec.Template.DeclaredResults() {
+			vars[ident] = result
+		}
+		return afterRun(tplExec)
+	}
+	return runner.Run()
+}
+
+// shellCompleter completes the statements typed in the shell: actions, entities,
+// params then the values of params (see paramsAutoCompletion)
+type shellCompleter struct {
+	g cloud.GraphAPI
+}
+
+func (c *shellCompleter) loadGraph() {
+	g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+	if err != nil {
+		logger.Verbosef("cannot load local graph for completion: %s", err)
+		g = graph.NewGraph()
+	}
+	c.g = g
+}
+
+func (c *shellCompleter) Do(line []rune, pos int) ([][]rune, int) {
+	text := string(line[:pos])
+	words := strings.Fields(text)
+	var current string
+	if len(words) > 0 && !strings.HasSuffix(text, " ") {
+		current, words = words[len(words)-1], words[:len(words)-1]
+	}
+	if len(words) >= 2 && words[1] == "=" {
+		words = words[2:]
+	}
+
+	var candidates []string
+	switch len(words) {
+	case 0:
+		candidates = shellActions()
+	case 1:
+		candidates = awsspec.DriverSupportedActions[words[0]]
+	default:
+		action, entity := words[0], words[1]
+		def, ok := awsspec.AWSLookupDefinitions(action + entity)
+		if !ok {
+			return nil, 0
+		}
+		if i := strings.Index(current, "="); i >= 0 {
+			value := []rune(current[i+1:])
+			return paramsAutoCompletion(c.g, []string{fmt.Sprintf("%s.%s.%s", action, entity, current[:i])}).Do(value, len(value))
+		}
+		return completeWords(remainingParams(def, words[2:]), current, "=")
+	}
+	return completeWords(candidates, current, " ")
+}
+
+func shellActions() (actions []string) {
+	for action := range awsspec.DriverSupportedActions {
+		actions = append(actions, action)
+	}
+	return append(actions, "exit", "help")
+}
+
+// remainingParams returns the params of the definition not given yet
+func remainingParams(def awsspec.Definition, given []string) (remaining []string) {
+	done := make(map[string]bool)
+	for _, w := range given {
+		done[strings.SplitN(w, "=", 2)[0]] = true
+	}
+	all, _, _ := params.List(def.Params)
+	for _, p := range all {
+		if !done[p] {
+			remaining = append(remaining, p)
+		}
+	}
+	return
+}
+
+// completeWords returns the ends of the candidates starting with the current word,
+// followed by the suffix, and the length of the current word as expected by readline
+func completeWords(candidates []string, current, suffix string) (completions [][]rune, offset int) {
+	sort.Strings(candidates)
+	for _, c := range candidates {
+		if strings.HasPrefix(c, current) {
+			completions = append(completions, []rune(c[len(current):]+suffix))
+		}
+	}
+	return completions, len([]rune(current))
+}
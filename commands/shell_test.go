@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestShellCompleter(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(resourcetest.Subnet("s-5").Prop(p.Name, "public").Build())
+	g.AddResource(resourcetest.Subnet("s-6").Prop(p.Name, "private").Build())
+	completer := &shellCompleter{g: g}
+
+	tcases := []struct {
+		line       string
+		expect     []string
+		expectOffs int
+	}{
+		{line: "rest", expect: []string{"art "}, expectOffs: 4},
+		{line: "restart ", expect: []string{"database ", "instance "}, expectOffs: 0},
+		{line: "restart inst", expect: []string{"ance "}, expectOffs: 4},
+		{line: "inst = restart inst", expect: []string{"ance "}, expectOffs: 4},
+		{line: "restart instance i", expect: []string{"d=", "ds="}, expectOffs: 1},
+		{line: "create natgateway elasticip-id=eip-1 ", expect: []string{"subnet="}, expectOffs: 0},
+		{line: "create natgateway subnet=@pu", expect: []string{"blic"}, expectOffs: 3},
+		{line: "create presignedurl method=p", expect: []string{"ut "}, expectOffs: 1},
+		{line: "create unknown ", expectOffs: 0},
+	}
+	for _, tcase := range tcases {
+		list, offset := completer.Do([]rune(tcase.line), len(tcase.line))
+		var got []string
+		for _, l := range list {
+			got = append(got, string(l))
+		}
+		if want := tcase.expect; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: got %q, want %q", tcase.line, got, want)
+		}
+		if got, want := offset, tcase.expectOffs; got != want {
+			t.Fatalf("%s: got %d, want %d", tcase.line, got, want)
+		}
+	}
+}
@@ -18,20 +18,25 @@ package commands
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/aws/config"
+	"github.com/wallix/awless/aws/services"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/cloud/rdf"
 	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
 )
@@ -40,6 +45,9 @@ var (
 	listAllSiblingsFlag          bool
 	noAliasFlag                  bool
 	showPropertiesValuesOnlyFlag []string
+	showDependentsFlag           bool
+	showLiveFlag                 bool
+	showWatchFlag                time.Duration
 )
 
 func init() {
@@ -47,6 +55,50 @@ func init() {
 	showCmd.Flags().BoolVar(&listAllSiblingsFlag, "siblings", false, "List all the resource's siblings")
 	showCmd.Flags().BoolVar(&noAliasFlag, "no-alias", false, "Disable the resolution of ID to alias")
 	showCmd.Flags().StringSliceVar(&showPropertiesValuesOnlyFlag, "values-for", []string{}, "Output values only for given properties keys")
+	showCmd.Flags().BoolVar(&showDependentsFlag, "dependents", false, "List the resources depending on the resource (to check before deleting it)")
+	showCmd.Flags().BoolVar(&showLiveFlag, "live", false, "Fetch the resource from the AWS API when not found locally, instead of running a full sync")
+	showCmd.Flags().DurationVar(&showWatchFlag, "watch", 0, "Refetch and display the resource every interval, highlighting changed and removed properties (ex: --watch 5s)")
+
+	showCmd.AddCommand(showUserDataCmd)
+}
+
+var showUserDataCmd = &cobra.Command{
+	Use:   "userdata INSTANCE",
+	Short: "Show the decoded user data of an instance given an id or name",
+	Example: `  awless show userdata i-8d43b21b
+  awless show userdata @redis-prod`,
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("INSTANCE required. See examples.")
+		}
+
+		resource, _ := findResourceInLocalGraphs(args[0])
+		if resource == nil {
+			runFullSync()
+			if resource, _ = findResourceInLocalGraphs(args[0]); resource == nil {
+				exitOn(decorateWithSuggestion(fmt.Errorf("instance '%s' not found", deprefix(args[0])), args[0]))
+			}
+		}
+		if resource.Type() != cloud.Instance {
+			exitOn(fmt.Errorf("'%s' is a %s, expected an instance", deprefix(args[0]), resource.Type()))
+		}
+
+		infra, ok := awsservices.InfraService.(*awsservices.Infra)
+		if !ok {
+			exitOn(errors.New("cannot retrieve user data: invalid infra service"))
+		}
+		userdata, err := infra.GetInstanceUserData(resource.Id())
+		exitOn(err)
+
+		if len(userdata) == 0 {
+			logger.Infof("no user data for instance %s", resource.Id())
+			return nil
+		}
+		fmt.Print(string(userdata))
+
+		return nil
+	},
 }
 
 var showCmd = &cobra.Command{
@@ -55,7 +107,13 @@ var showCmd = &cobra.Command{
 	Example: `  awless show i-8d43b21b            # show an instance via its ref
   awless show AIDAJ3Z24GOKHTZO4OIX6 # show a user via its ref
   awless show jsmith                # show a user via its ref,
-  awless show @jsmith               # forcing search by name`,
+  awless show @jsmith               # forcing search by name
+  awless show sg-1234 --dependents  # list what depends on a security group before deleting it
+  awless show i-8d43b21b --live     # fetch the instance live when not synced locally yet
+  awless show i-8d43b21b --watch 5s # refetch the instance every 5 seconds until Ctrl+C
+  awless show i-8d43b21b --format yaml # output the properties of the instance as YAML
+  awless show vpc-123 --format dot | dot -Tpng > vpc.png # visualize the topology of a vpc with Graphviz
+  awless show vpc-123 --format d3   # export the topology of a vpc as D3 JSON nodes and links`,
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
@@ -65,6 +123,14 @@ var showCmd = &cobra.Command{
 		}
 
 		ref := args[0]
+		if showWatchFlag != 0 {
+			if err := validateWatchInterval(showWatchFlag); err != nil {
+				return err
+			}
+			if len(showPropertiesValuesOnlyFlag) > 0 || showDependentsFlag || isTopologyFormat(formatGlobalFlag) {
+				return errors.New("--watch cannot be used with --values-for, --dependents or a topology format")
+			}
+		}
 		notFound := fmt.Errorf("resource '%s' not found", deprefix(ref))
 
 		if _, err := awsconfig.ParseRegion(ref); err == nil && ref != config.GetAWSRegion() {
@@ -75,12 +141,17 @@ var showCmd = &cobra.Command{
 
 		var resource cloud.Resource
 		var gph cloud.GraphAPI
+		var fetchedLive bool
 
 		resource, gph = findResourceInLocalGraphs(ref)
 
 		if resource == nil && localGlobalFlag {
 			exitOn(decorateWithSuggestion(notFound, ref))
-		} else if resource == nil {
+		} else if resource == nil && showLiveFlag {
+			resource, gph = findResourceLive(ref)
+			fetchedLive = resource != nil
+		}
+		if resource == nil {
 			runFullSync()
 
 			if resource, gph = findResourceInLocalGraphs(ref); resource == nil {
@@ -88,7 +159,7 @@ var showCmd = &cobra.Command{
 			}
 		}
 
-		if !localGlobalFlag && config.GetAutosync() {
+		if !localGlobalFlag && !fetchedLive && config.GetAutosync() {
 			var services []cloud.Service
 			if resource.Type() == cloud.Region {
 				services = append(services, cloud.AllServices()...)
@@ -108,8 +179,14 @@ var showCmd = &cobra.Command{
 		if resource != nil {
 			if len(showPropertiesValuesOnlyFlag) > 0 {
 				showResourceValuesOnlyFor(resource, showPropertiesValuesOnlyFlag)
+			} else if showDependentsFlag {
+				showResourceDependents(resource, gph)
+			} else if isTopologyFormat(formatGlobalFlag) {
+				exportResourceTopology(resource, gph, formatGlobalFlag)
+			} else if showWatchFlag != 0 {
+				watchResource(resource, gph)
 			} else {
-				showResource(resource, gph)
+				showResource(os.Stdout, resource, gph, nil)
 			}
 		}
 
@@ -152,15 +229,63 @@ func showResourceValuesOnlyFor(resource cloud.Resource, propKeys []string) {
 	}
 }
 
-func showResource(resource cloud.Resource, gph cloud.GraphAPI) {
+func isTopologyFormat(format string) bool {
+	for _, f := range graph.ExportFormats {
+		if f == strings.ToLower(format) {
+			return true
+		}
+	}
+	return false
+}
+
+func exportResourceTopology(resource cloud.Resource, gph cloud.GraphAPI, format string) {
+	g, ok := gph.(*graph.Graph)
+	if !ok {
+		exitOn(fmt.Errorf("cannot export topology: unexpected graph %T", gph))
+	}
+	res, ok := resource.(*graph.Resource)
+	if !ok {
+		exitOn(fmt.Errorf("cannot export topology: unexpected resource %T", resource))
+	}
+	topo, err := g.Topology(res)
+	exitOn(err)
+	exitOn(topo.ExportTo(os.Stdout, strings.ToLower(format)))
+}
+
+func showResourceDependents(resource cloud.Resource, gph cloud.GraphAPI) {
+	g, ok := gph.(*graph.Graph)
+	if !ok {
+		exitOn(fmt.Errorf("cannot list dependents: unexpected graph %T", gph))
+	}
+	res, ok := resource.(*graph.Resource)
+	if !ok {
+		exitOn(fmt.Errorf("cannot list dependents: unexpected resource %T", resource))
+	}
+	dependents, err := g.Dependents(res)
+	exitOn(err)
+
+	if len(dependents) == 0 {
+		logger.Infof("No resources depending on %s", printResourceRef(resource))
+		return
+	}
+	fmt.Printf("Resources depending on %s:\n", printResourceRef(resource, renderGreenFn))
+	for _, d := range dependents {
+		fmt.Printf("\t%s (%s)\n", printResourceRef(d.Resource), d.Through)
+	}
+}
+
+// showResource displays the resource and its relations, highlighting
+// the properties that changed since the previous resource if any
+func showResource(w io.Writer, resource cloud.Resource, gph cloud.GraphAPI, previous cloud.Resource) {
 	displayer, err := console.BuildOptions(
 		console.WithColumnDefinitions(console.DefaultsColumnDefinitions[resource.Type()]),
-		console.WithFormat(listingFormat),
+		console.WithFormat(formatGlobalFlag),
 		console.WithMaxWidth(console.GetTerminalWidth()),
+		console.WithPrevious(previous),
 	).SetSource(resource).Build()
 	exitOn(err)
 
-	exitOn(displayer.Print(os.Stdout))
+	exitOn(displayer.Print(w))
 
 	parents, err := gph.ResourceRelations(resource, rdf.ParentOf, true)
 	exitOn(err)
@@ -198,22 +323,44 @@ func showResource(resource cloud.Resource, gph cloud.GraphAPI) {
 	exitOn(err)
 
 	if len(parents) > 0 || hasChildren {
-		fmt.Println(renderCyanBoldFn("\nLineage:"))
-		fmt.Printf(parentsW.String())
-		fmt.Printf(childrenW.String())
+		fmt.Fprintln(w, renderCyanBoldFn("\nLineage:"))
+		fmt.Fprint(w, parentsW.String())
+		fmt.Fprint(w, childrenW.String())
 	}
 
 	appliedOn, err := gph.ResourceRelations(resource, rdf.ApplyOn, false)
 	exitOn(err)
-	printResourceList(renderCyanBoldFn("Applied on"), appliedOn)
+	printResourceList(w, renderCyanBoldFn("Applied on"), appliedOn)
 
 	dependingOn, err := gph.ResourceRelations(resource, rdf.DependingOnRel, false)
 	exitOn(err)
-	printResourceList(renderCyanBoldFn("Depending on"), dependingOn)
+	printResourceList(w, renderCyanBoldFn("Depending on"), dependingOn)
 
 	siblings, err := gph.ResourceSiblings(resource)
 	exitOn(err)
-	printResourceList(renderCyanBoldFn("Siblings"), siblings, "display all with flag --siblings")
+	printResourceList(w, renderCyanBoldFn("Siblings"), siblings, "display all with flag --siblings")
+}
+
+// watchResource shows the resource every interval of the --watch flag, syncing its service
+// beforehand (unless --local) so that its properties and relations are up to date
+func watchResource(resource cloud.Resource, gph cloud.GraphAPI) {
+	srv, err := cloud.GetServiceForType(resource.Type())
+	exitOn(err)
+	var previous cloud.Resource
+	watch(showWatchFlag, func(w io.Writer) {
+		if previous != nil {
+			if !localGlobalFlag {
+				if _, err := sync.DefaultSyncer.Sync(srv); err != nil {
+					logger.Verbose(err)
+				}
+			}
+			if resource, gph = findResourceInLocalGraphs(resource.Id()); resource == nil {
+				exitOn(fmt.Errorf("resource '%s' not found anymore", previous.Id()))
+			}
+		}
+		showResource(w, resource, gph, previous)
+		previous = resource
+	})
 }
 
 func runFullSync() {
@@ -259,6 +406,27 @@ func findResourceInLocalGraphs(ref string) (cloud.Resource, cloud.GraphAPI) {
 	return nil, nil
 }
 
+// findResourceLive fetches the resource from the AWS API and merges it into the local graphs,
+// so that it is shown with its locally synced relatives. It returns nil when it cannot be fetched.
+func findResourceLive(ref string) (cloud.Resource, cloud.GraphAPI) {
+	if strings.HasPrefix(ref, "@") {
+		logger.Verbosef("cannot fetch live by name '%s'", deprefix(ref))
+		return nil, nil
+	}
+	logger.Verbosef("cannot find resource in existing data synced locally: fetching '%s' live", ref)
+	res, live, err := awsservices.FetchResourceLive(context.Background(), ref)
+	if err != nil {
+		logger.Verbose(err)
+		return nil, nil
+	}
+	g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+	exitOn(err)
+	exitOn(g.Merge(live))
+
+	logger.Infof("%s freshly fetched live: not synced locally yet", printResourceRef(res))
+	return res, g
+}
+
 func resolveResourceFromRefInCurrentRegion(ref string) (cloud.GraphAPI, []cloud.Resource, string) {
 	g, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
 	exitOn(err)
@@ -322,16 +490,16 @@ func decorateWithSuggestion(err error, ref string) error {
 	return errors.New(buf.String())
 }
 
-func printResourceList(title string, list []cloud.Resource, shortenListMsg ...string) {
+func printResourceList(w io.Writer, title string, list []cloud.Resource, shortenListMsg ...string) {
 	sort.Sort(byTypeAndString{list})
 	all := cloud.Resources(list).Map(func(r cloud.Resource) string { return printResourceRef(r) })
 	count := len(all)
 	max := 3
 	if count > 0 {
 		if !listAllSiblingsFlag && len(shortenListMsg) > 0 && count > max {
-			fmt.Printf("\n%s: %s, ... (%s)\n", title, strings.Join(all[0:max], ", "), shortenListMsg[0])
+			fmt.Fprintf(w, "\n%s: %s, ... (%s)\n", title, strings.Join(all[0:max], ", "), shortenListMsg[0])
 		} else {
-			fmt.Printf("\n%s: %s\n", title, strings.Join(all, ", "))
+			fmt.Fprintf(w, "\n%s: %s\n", title, strings.Join(all, ", "))
 		}
 	}
 }
@@ -45,6 +45,10 @@ var printSSHConfigFlag bool
 var printSSHCLIFlag bool
 var privateIPFlag bool
 var disableStrictHostKeyCheckingFlag bool
+var sshLocalForwardsFlag, sshRemoteForwardsFlag []string
+var sshSocksFlag string
+var sshThroughSSMFlag bool
+var sshOnFlag string
 
 func init() {
 	RootCmd.AddCommand(sshCmd)
@@ -56,6 +60,11 @@ func init() {
 	sshCmd.Flags().BoolVar(&printSSHCLIFlag, "print-cli", false, "Print the CLI one-liner to connect with SSH. (/usr/bin/ssh user@ip -i ...)")
 	sshCmd.Flags().BoolVar(&privateIPFlag, "private", false, "Use private ip to connect to host")
 	sshCmd.Flags().BoolVar(&disableStrictHostKeyCheckingFlag, "disable-strict-host-keychecking", false, "Disable the remote host key check from ~/.ssh/known_hosts or ~/.awless/known_hosts file")
+	sshCmd.Flags().StringArrayVarP(&sshLocalForwardsFlag, "local-forward", "L", nil, "Forward a local port to a host reachable from the instance: [bind_address:]port:host:hostport")
+	sshCmd.Flags().StringArrayVarP(&sshRemoteForwardsFlag, "remote-forward", "R", nil, "Forward a port of the instance to a host reachable locally: [bind_address:]port:host:hostport")
+	sshCmd.Flags().StringVar(&sshSocksFlag, "socks", "", "Run a local SOCKS5 proxy connecting through the instance, listening on [bind_address:]port")
+	sshCmd.Flags().StringVar(&sshOnFlag, "on", "", "Run the command given after -- concurrently on all the running instances matching this query (ex: --on tag:Role=web), see 'awless list --query'")
+	sshCmd.Flags().BoolVar(&sshThroughSSMFlag, "through-ssm", false, "Open a shell through AWS Session Manager: no SSH key, public IP or opened port needed (requires the session-manager-plugin)")
 }
 
 var defaultAMIUsers = []string{"ec2-user", "ubuntu", "centos", "bitnami", "admin", "root"}
@@ -63,7 +72,7 @@ var defaultAMIUsers = []string{"ec2-user", "ubuntu", "centos", "bitnami", "admin
 var sshCmd = &cobra.Command{
 	Use:   "ssh [USER@]INSTANCE",
 	Short: "Launch a SSH session to an instance given an id or alias",
-	Long:  "Launch a SSH session to an instance given an id or alias. All connection details are derived from a given instance name/id.",
+	Long:  "Launch a SSH session to an instance given an id or alias. All connection details are derived from a given instance name/id. When the key pair of the instance is not found locally, an ephemeral key is pushed with EC2 Instance Connect for the given user or else for the default user of the image of the instance. Instances without public IP are reached through the bastion of the ssh.bastion config or else through the running instance tagged awless:bastion in their VPC.",
 	Example: `  awless ssh i-8d43b21b                       # using the instance id
   awless ssh redis-prod                       # using name only (other infos are derived)
   awless ssh ec2-user@redis-prod              # forcing the user
@@ -74,117 +83,283 @@ var sshCmd = &cobra.Command{
   awless ssh redis-prod -i ~/path/toward/key  # specifying a full key path
 
   awless ssh db-private --through my-bastion  # connect to a private inst through a public one
+  awless ssh db-private                       # connect through the bastion tagged awless:bastion in the VPC (or set with 'awless config set ssh.bastion')
   awless ssh db-private --private             # connect using the private IP (when you have a VPN, tunnel, etc ...)
+  awless ssh i-8d43b21b --through-ssm         # connect through AWS Session Manager (SSM agent on the instance)
 
   awless ssh redis-prod --print-cli           # print out the full terminal command to connect to instance
   awless ssh redis-prod --print-config        # print out the full SSH config (i.e: ~/.ssh/config) to connect to instance
   
   awless ssh private-redis --through my-proxy                                # connect to private through proxy instance
   awless ssh private-redis --through my-proxy --through-port 23              # specifying proxy port
-  awless ssh 172.31.77.151 --port 2222 --through my-proxy --through-port 23  # specifying target & proxy port`,
+  awless ssh 172.31.77.151 --port 2222 --through my-proxy --through-port 23  # specifying target & proxy port
+
+  awless ssh my-bastion -L 5432:mydb.abc.eu-west-1.rds.amazonaws.com:5432   # tunnel to a private RDS endpoint
+  awless ssh my-bastion -R 8080:localhost:3000                              # expose a local port on the instance
+  awless ssh my-bastion --socks 1080                                        # SOCKS proxy into the VPC
+
+  awless ssh --on tag:Role=web -- uptime                                  # run a command on a fleet, exiting with the highest status
+  awless ssh --on 'tag:Env=prod AND vpc.name=main' --through my-bastion -- 'sudo systemctl restart app'`,
 
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if sshOnFlag != "" {
+			if len(args) == 0 {
+				return errors.New("command required: awless ssh --on QUERY -- COMMAND")
+			}
+			if sshThroughSSMFlag || printSSHConfigFlag || printSSHCLIFlag || len(sshLocalForwardsFlag) > 0 || len(sshRemoteForwardsFlag) > 0 || sshSocksFlag != "" {
+				return errors.New("--on cannot be combined with --through-ssm, --print-config, --print-cli or forwardings")
+			}
+			status, err := runOnFleet(sshOnFlag, strings.Join(args, " "))
+			exitOn(err)
+			if status != 0 {
+				os.Exit(status)
+			}
+			return nil
+		}
 		if len(args) != 1 {
 			return fmt.Errorf("instance required")
 		}
 
 		var err error
-		var connectionCtx *instanceConnectionContext
+		var localForwards, remoteForwards []*ssh.Forward
+		for _, spec := range sshLocalForwardsFlag {
+			f, err := ssh.ParseForward(spec)
+			exitOn(err)
+			localForwards = append(localForwards, f)
+		}
+		for _, spec := range sshRemoteForwardsFlag {
+			f, err := ssh.ParseForward(spec)
+			exitOn(err)
+			remoteForwards = append(remoteForwards, f)
+		}
+		var socksProxy string
+		if sshSocksFlag != "" {
+			socksProxy, err = ssh.ParseDynamicForward(sshSocksFlag)
+			exitOn(err)
+		}
 
-		if proxyInstanceThroughFlag != "" {
-			connectionCtx, err = initInstanceConnectionContext(proxyInstanceThroughFlag, keyPathFlag)
-		} else {
-			connectionCtx, err = initInstanceConnectionContext(args[0], keyPathFlag)
+		if sshThroughSSMFlag {
+			if proxyInstanceThroughFlag != "" || privateIPFlag || printSSHConfigFlag || len(localForwards) > 0 || len(remoteForwards) > 0 || socksProxy != "" {
+				exitOn(errors.New("--through-ssm cannot be combined with --through, --private, --print-config or forwardings"))
+			}
+			exitOn(connectThroughSSM(args[0]))
+			return nil
 		}
-		exitOn(err)
 
-		firsHopClient, err := ssh.InitClient(connectionCtx.keypath, config.KeysDir, filepath.Join(os.Getenv("HOME"), ".ssh"))
-		exitOn(err)
+		targetClient, host := dialInstance(args[0])
 
-		if err != nil && strings.Contains(err.Error(), "cannot find SSH key") && keyPathFlag == "" {
-			logger.Info("you may want to specify a key filepath with `-i /path/to/key.pem`")
-		}
-		exitOn(err)
+		targetClient.LocalForwards = localForwards
+		targetClient.RemoteForwards = remoteForwards
+		targetClient.SocksProxy = socksProxy
 
-		firsHopClient.SetLogger(logger.DefaultLogger)
-		firsHopClient.SetStrictHostKeyChecking(!disableStrictHostKeyCheckingFlag)
-		firsHopClient.InteractiveTerminalFunc = console.InteractiveTerminal
-		if proxyInstanceThroughFlag != "" {
-			firsHopClient.Port = sshTroughPortFlag
-		} else {
-			firsHopClient.Port = sshPortFlag
+		if targetClient.EphemeralKey != "" && (printSSHConfigFlag || printSSHCLIFlag) {
+			logger.Warning("no local key found: the printed command will rely on your own keys, the ephemeral key pushed with EC2 Instance Connect only living in awless")
 		}
 
-		if privateIPFlag {
-			if priv := connectionCtx.privip; priv != "" {
-				firsHopClient.IP = connectionCtx.privip
-			} else {
-				exitOn(fmt.Errorf(
-					"no private IP resolved for instance %s (state '%s')",
-					connectionCtx.instance.Id(), connectionCtx.state,
-				))
-			}
-		} else {
-			if pub := connectionCtx.ip; pub != "" {
-				firsHopClient.IP = connectionCtx.ip
-			} else {
-				logger.Infof("`--private` flag can be used to connect through instance's private IP '%s'", connectionCtx.privip)
-				exitOn(fmt.Errorf("no public IP resolved for instance %s (state '%s')", connectionCtx.instance.Id(), connectionCtx.state))
-			}
+		if printSSHConfigFlag {
+			fmt.Println(targetClient.SSHConfigString(host))
+			return nil
 		}
 
-		if connectionCtx.user != "" {
-			err = firsHopClient.DialWithUsers(connectionCtx.user)
-		} else {
-			err = firsHopClient.DialWithUsers(defaultAMIUsers...)
+		if printSSHCLIFlag {
+			fmt.Println(targetClient.ConnectString())
+			return nil
 		}
 
-		if isConnectionRefusedErr(err) {
-			logger.Warning("cannot connect to this instance, maybe the system is still booting?")
-			exitOn(err)
+		if len(localForwards) > 0 || len(remoteForwards) > 0 || socksProxy != "" {
+			targetClient.SetLogger(logger.DefaultLogger)
+			exitOn(targetClient.Forward())
 			return nil
 		}
 
+		exitOn(targetClient.Connect())
+		return nil
+	},
+}
+
+// dialInstance connects through SSH to the instance, hopping through the --through instance if any
+// or else through the bastion of the instance when it has no public IP, and returns the client
+// with the name of the host to use in a SSH config
+func dialInstance(userhost string) (*ssh.Client, string) {
+	targetCtx, err := initInstanceConnectionContext(userhost, keyPathFlag)
+	exitOn(err)
+
+	connectionCtx := targetCtx
+	port := sshPortFlag
+	if proxyInstanceThroughFlag != "" {
+		connectionCtx, err = initInstanceConnectionContext(proxyInstanceThroughFlag, keyPathFlag)
+		exitOn(err)
+		port = sshTroughPortFlag
+	} else if !privateIPFlag {
+		bastion, err := findBastion(targetCtx.resourcesGraph, targetCtx.instance)
+		exitOn(err)
+		if bastion != nil {
+			logger.Infof("instance %s has no public IP: connecting through bastion %s", targetCtx.instance.Id(), bastion.Id())
+			connectionCtx = &instanceConnectionContext{resourcesGraph: targetCtx.resourcesGraph, myip: targetCtx.myip}
+			connectionCtx.setInstance(bastion, keyPathFlag)
+			port = sshTroughPortFlag
+		}
+	}
+	proxied := connectionCtx != targetCtx
+
+	firsHopClient, err := newSSHClient(connectionCtx, port)
+	exitOn(err)
+
+	err = firsHopClient.DialWithUsers(connectionCtx.users()...)
+
+	if isConnectionRefusedErr(err) {
+		logger.Warning("cannot connect to this instance, maybe the system is still booting?")
+		exitOn(err)
+	}
+
+	if err != nil {
+		if e := connectionCtx.checkInstanceAccessible(22); e != nil {
+			logger.Error(e.Error())
+		}
+		exitOn(err)
+	}
+
+	targetClient := firsHopClient
+
+	if proxied {
+		targetClient, err = firsHopClient.NewClientWithProxy(targetCtx.privip, sshPortFlag, targetCtx.users()...)
+		exitOn(err)
+	}
+
+	host := targetCtx.instanceName
+	if proxied {
+		host = userhost
+	}
+	return targetClient, host
+}
+
+// newSSHClient returns the SSH client, not connected yet, of the instance of the context
+// on its public IP or on its private IP with --private
+func newSSHClient(ctx *instanceConnectionContext, port int) (*ssh.Client, error) {
+	keyFolders := []string{config.KeysDir, filepath.Join(os.Getenv("HOME"), ".ssh")}
+	var client *ssh.Client
+	var err error
+	if keyPathFlag == "" && !ssh.HasPrivateKey(ctx.keypath, keyFolders...) {
+		if ctx.keypath != "" {
+			logger.Verbosef("no local key '%s' found for instance %s: pushing an ephemeral key with EC2 Instance Connect", ctx.keypath, ctx.instance.Id())
+		} else {
+			logger.Verbosef("no key pair for instance %s: pushing an ephemeral key with EC2 Instance Connect", ctx.instance.Id())
+		}
+		if client, err = ssh.InitEphemeralClient(); err != nil {
+			return nil, err
+		}
+		client.AuthorizeUser = pushKeyWithInstanceConnect(ctx.resourcesGraph, client.EphemeralKey)
+		client.ResolveUser = resolveImageDefaultUser(ctx.resourcesGraph)
+	} else {
+		client, err = ssh.InitClient(ctx.keypath, keyFolders...)
 		if err != nil {
-			if e := connectionCtx.checkInstanceAccessible(); e != nil {
-				logger.Error(e.Error())
+			if strings.Contains(err.Error(), "cannot find SSH key") && keyPathFlag == "" {
+				logger.Info("you may want to specify a key filepath with `-i /path/to/key.pem`")
 			}
-			exitOn(err)
+			return nil, err
 		}
+	}
 
-		targetClient := firsHopClient
+	client.SetLogger(logger.DefaultLogger)
+	client.SetStrictHostKeyChecking(!disableStrictHostKeyCheckingFlag)
+	client.InteractiveTerminalFunc = console.InteractiveTerminal
+	client.Port = port
 
-		if proxyInstanceThroughFlag != "" {
-			destInstanceCtx, err := initInstanceConnectionContext(args[0], keyPathFlag)
-			exitOn(err)
-			if destInstanceCtx.user != "" {
-				targetClient, err = firsHopClient.NewClientWithProxy(destInstanceCtx.privip, sshPortFlag, destInstanceCtx.user)
-			} else {
-				targetClient, err = firsHopClient.NewClientWithProxy(destInstanceCtx.privip, sshPortFlag, defaultAMIUsers...)
-			}
-			exitOn(err)
+	if privateIPFlag {
+		if ctx.privip == "" {
+			return nil, fmt.Errorf("no private IP resolved for instance %s (state '%s')", ctx.instance.Id(), ctx.state)
+		}
+		client.IP = ctx.privip
+	} else {
+		if ctx.ip == "" {
+			logger.Infof("`--private` flag can be used to connect through instance's private IP '%s'", ctx.privip)
+			return nil, fmt.Errorf("no public IP resolved for instance %s (state '%s')", ctx.instance.Id(), ctx.state)
 		}
+		client.IP = ctx.ip
+	}
+	return client, nil
+}
 
-		if printSSHConfigFlag {
-			host := connectionCtx.instanceName
-			if proxyInstanceThroughFlag != "" {
-				host = args[0]
-			}
-			fmt.Println(targetClient.SSHConfigString(host))
-			return nil
+// pushKeyWithInstanceConnect returns the authorization of the public key with EC2 Instance Connect
+// on the instance of the graph having the dialed IP
+func pushKeyWithInstanceConnect(g cloud.GraphAPI, publicKey string) func(host, user string) error {
+	return func(host, user string) error {
+		inst, err := findInstanceWithIP(g, host)
+		if err != nil {
+			return err
 		}
+		zone, _ := inst.Properties()[properties.AvailabilityZone].(string)
+		return awsservices.SendSSHPublicKey(inst.Id(), zone, user, publicKey)
+	}
+}
 
-		if printSSHCLIFlag {
-			fmt.Println(targetClient.ConnectString())
-			return nil
+// resolveImageDefaultUser returns the resolution of the user to push the key for, as the
+// default user of the image of the instance of the graph having the dialed IP
+func resolveImageDefaultUser(g cloud.GraphAPI) func(host string) (string, error) {
+	return func(host string) (string, error) {
+		inst, err := findInstanceWithIP(g, host)
+		if err != nil {
+			return "", err
+		}
+		image, ok := inst.Properties()[properties.Image].(string)
+		if !ok {
+			return "", fmt.Errorf("no image for instance %s: connect with USER@INSTANCE", inst.Id())
 		}
+		user, err := awsservices.ImageDefaultUser(image)
+		if err != nil {
+			return "", fmt.Errorf("%s: connect with USER@INSTANCE", err)
+		}
+		return user, nil
+	}
+}
 
-		exitOn(targetClient.Connect())
+func findInstanceWithIP(g cloud.GraphAPI, ip string) (cloud.Resource, error) {
+	instances, err := g.Find(cloud.NewQuery(cloud.Instance).Match(match.Or(match.Property(properties.PublicIP, ip), match.Property(properties.PrivateIP, ip))))
+	if err != nil {
+		return nil, err
+	}
+	if len(instances) != 1 {
+		return nil, fmt.Errorf("found %d instances with IP %s", len(instances), ip)
+	}
+	return instances[0], nil
+}
+
+func connectThroughSSM(userhost string) error {
+	ctx, err := initInstanceConnectionContext(userhost, "")
+	if err != nil {
+		return err
+	}
+	if ctx.user != "" {
+		logger.Warningf("ignoring user '%s': Session Manager shells run as ssm-user", ctx.user)
+	}
+	id := ctx.instance.Id()
+	if printSSHCLIFlag {
+		fmt.Println(ssh.SSMConnectString(id, config.GetAWSRegion(), config.GetAWSProfile()))
 		return nil
-	},
+	}
+	if ctx.state != "running" {
+		return fmt.Errorf("instance %s is '%s' (cannot connect to a non running state)", id, ctx.state)
+	}
+	if _, err = ssh.SSMPluginPath(); err != nil {
+		return err
+	}
+
+	session, err := awsservices.StartSSMSession(id)
+	if err != nil {
+		return err
+	}
+	return (&ssh.SSMSession{
+		ID:        session.SessionId,
+		StreamURL: session.StreamUrl,
+		Token:     session.TokenValue,
+		Target:    session.Target,
+		Region:    session.Region,
+		Profile:   session.Profile,
+		Endpoint:  session.Endpoint,
+	}).Connect(logger.DefaultLogger)
 }
 
 func isConnectionRefusedErr(err error) bool {
@@ -252,20 +427,32 @@ func initInstanceConnectionContext(userhost, keypath string) (*instanceConnectio
 		}
 	}
 
-	ctx.privip, _ = ctx.instance.Properties()[properties.PrivateIP].(string)
-	ctx.ip, _ = ctx.instance.Properties()[properties.PublicIP].(string)
-	ctx.state, _ = ctx.instance.Properties()[properties.State].(string)
+	ctx.setInstance(ctx.instance, keypath)
+	return ctx, nil
+}
+
+func (ctx *instanceConnectionContext) setInstance(instance cloud.Resource, keypath string) {
+	ctx.instance = instance
+	ctx.privip, _ = instance.Properties()[properties.PrivateIP].(string)
+	ctx.ip, _ = instance.Properties()[properties.PublicIP].(string)
+	ctx.state, _ = instance.Properties()[properties.State].(string)
 
 	if keypath != "" {
 		ctx.keypath = keypath
 	} else {
-		keypair, ok := ctx.instance.Properties()[properties.KeyPair].(string)
+		keypair, ok := instance.Properties()[properties.KeyPair].(string)
 		if ok {
 			ctx.keypath = fmt.Sprint(keypair)
 		}
 	}
+}
 
-	return ctx, nil
+// users returns the user given with the instance or the default users of the AMIs
+func (ctx *instanceConnectionContext) users() []string {
+	if ctx.user != "" {
+		return []string{ctx.user}
+	}
+	return defaultAMIUsers
 }
 
 func (ctx *instanceConnectionContext) fetchConnectionInfo() {
@@ -315,9 +502,9 @@ func (ctx *instanceConnectionContext) fetchConnectionInfo() {
 	return
 }
 
-func (ctx *instanceConnectionContext) checkInstanceAccessible() (err error) {
+func (ctx *instanceConnectionContext) checkInstanceAccessible(port int64) (err error) {
 	if st := ctx.state; st != "running" {
-		logger.Warningf("this instance is '%s' (cannot ssh to a non running state)", st)
+		logger.Warningf("this instance is '%s' (cannot connect to a non running state)", st)
 		if st == "stopped" {
 			logger.Warningf("you can start it with `awless -f start instance id=%s`", ctx.instance.Id())
 		}
@@ -326,7 +513,7 @@ func (ctx *instanceConnectionContext) checkInstanceAccessible() (err error) {
 
 	sgroups, ok := ctx.instance.Properties()[properties.SecurityGroups].([]string)
 	if ok {
-		var sshPortOpen, myIPAllowed bool
+		var portOpen, myIPAllowed bool
 		for _, id := range sgroups {
 			var sgroup cloud.Resource
 			sgroup, err = findResource(ctx.resourcesGraph, id, cloud.SecurityGroup)
@@ -338,8 +525,8 @@ func (ctx *instanceConnectionContext) checkInstanceAccessible() (err error) {
 			rules, ok := sgroup.Properties()[properties.InboundRules].([]*graph.FirewallRule)
 			if ok {
 				for _, r := range rules {
-					if r.PortRange.Contains(22) {
-						sshPortOpen = true
+					if r.PortRange.Contains(port) {
+						portOpen = true
 					}
 					if ctx.myip != nil && r.Contains(ctx.myip.String()) {
 						myIPAllowed = true
@@ -348,8 +535,8 @@ func (ctx *instanceConnectionContext) checkInstanceAccessible() (err error) {
 			}
 		}
 
-		if !sshPortOpen {
-			logger.Warning("port 22 is not open on this instance")
+		if !portOpen {
+			logger.Warningf("port %d is not open on this instance", port)
 			return errors.New("instance not accessible")
 		}
 
@@ -359,7 +546,7 @@ func (ctx *instanceConnectionContext) checkInstanceAccessible() (err error) {
 			if len(sgroups) == 1 {
 				group = sgroups[0]
 			}
-			logger.Warningf("`awless update securitygroup id=%s inbound=authorize protocol=tcp cidr=%s/32 portrange=22`", group, ctx.myip)
+			logger.Warningf("`awless update securitygroup id=%s inbound=authorize protocol=tcp cidr=%s/32 portrange=%d`", group, ctx.myip, port)
 			return errors.New("instance not accessible")
 		}
 	}
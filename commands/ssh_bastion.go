@@ -0,0 +1,86 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+)
+
+// bastionTagKey tags the instances hopped through to reach the instances without public IP of their VPC
+const bastionTagKey = "awless:bastion"
+
+// findBastion returns the instance to hop through to reach the target: the instance of the
+// ssh.bastion config or else the running instance tagged awless:bastion with a public IP in
+// the VPC of the target. It returns nil when the target has a public IP or no bastion is found.
+func findBastion(g cloud.GraphAPI, target cloud.Resource) (cloud.Resource, error) {
+	if ip, _ := target.Properties()[properties.PublicIP].(string); ip != "" {
+		return nil, nil
+	}
+	if name, _ := config.Config["ssh.bastion"].(string); name != "" {
+		bastions, err := g.Find(cloud.NewQuery(cloud.Instance).Match(match.And(
+			match.Or(match.Property(properties.ID, name), match.Property(properties.Name, name)),
+			match.Property(properties.State, "running"),
+		)))
+		if err != nil {
+			return nil, err
+		}
+		if len(bastions) != 1 {
+			return nil, fmt.Errorf("found %d running instances for bastion '%s' of ssh.bastion config", len(bastions), name)
+		}
+		if bastions[0].Id() == target.Id() {
+			return nil, nil
+		}
+		return bastions[0], nil
+	}
+
+	vpc, _ := target.Properties()[properties.Vpc].(string)
+	if vpc == "" {
+		return nil, nil
+	}
+	candidates, err := g.Find(cloud.NewQuery(cloud.Instance).Match(match.And(
+		match.TagKey(bastionTagKey),
+		match.Property(properties.Vpc, vpc),
+		match.Property(properties.State, "running"),
+	)))
+	if err != nil {
+		return nil, err
+	}
+	var bastions []cloud.Resource
+	for _, c := range candidates {
+		if ip, _ := c.Properties()[properties.PublicIP].(string); ip != "" && c.Id() != target.Id() {
+			bastions = append(bastions, c)
+		}
+	}
+	switch len(bastions) {
+	case 0:
+		return nil, nil
+	case 1:
+		return bastions[0], nil
+	default:
+		var ids []string
+		for _, b := range bastions {
+			ids = append(ids, b.Id())
+		}
+		return nil, fmt.Errorf("several bastions tagged %s in %s (%s): set one with `awless config set ssh.bastion` or use --through", bastionTagKey, vpc, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestFindBastion(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_public").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_1").Prop(properties.PublicIP, "1.2.3.4").Build(),
+		resourcetest.Instance("inst_private").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_1").Build(),
+		resourcetest.Instance("inst_bastion").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_1").Prop(properties.PublicIP, "5.6.7.8").Prop(properties.Tags, []string{"awless:bastion=true"}).Build(),
+		resourcetest.Instance("inst_hidden_bastion").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_1").Prop(properties.Tags, []string{"awless:bastion=true"}).Build(),
+		resourcetest.Instance("inst_stopped_bastion").Prop(properties.State, "stopped").Prop(properties.Vpc, "vpc_1").Prop(properties.PublicIP, "5.6.7.9").Prop(properties.Tags, []string{"awless:bastion="}).Build(),
+		resourcetest.Instance("inst_lonely").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_2").Build(),
+		resourcetest.Instance("inst_private_3").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_3").Build(),
+		resourcetest.Instance("inst_bastion_3a").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_3").Prop(properties.PublicIP, "9.9.9.1").Prop(properties.Tags, []string{"awless:bastion="}).Build(),
+		resourcetest.Instance("inst_bastion_3b").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_3").Prop(properties.PublicIP, "9.9.9.2").Prop(properties.Tags, []string{"awless:bastion="}).Build(),
+		resourcetest.Instance("inst_jump").Prop(properties.State, "running").Prop(properties.Vpc, "vpc_2").Prop(properties.Name, "jump").Prop(properties.PublicIP, "4.4.4.4").Build(),
+	)
+	find := func(id string) (string, error) {
+		target, err := g.FindOne(cloud.NewQuery(cloud.Instance).Match(match.Property(properties.ID, id)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bastion, err := findBastion(g, target)
+		if bastion == nil {
+			return "", err
+		}
+		return bastion.Id(), err
+	}
+
+	tcases := []struct {
+		target, config, exp, expErr string
+	}{
+		{target: "inst_public", exp: ""},
+		{target: "inst_private", exp: "inst_bastion"},
+		{target: "inst_hidden_bastion", exp: "inst_bastion"},
+		{target: "inst_lonely", exp: ""},
+		{target: "inst_private_3", expErr: "several bastions"},
+		{target: "inst_private_3", config: "jump", exp: "inst_jump"},
+		{target: "inst_lonely", config: "inst_jump", exp: "inst_jump"},
+		{target: "inst_public", config: "jump", exp: ""},
+		{target: "inst_private", config: "unknown", expErr: "found 0 running instances"},
+	}
+	defer delete(config.Config, "ssh.bastion")
+	for i, tcase := range tcases {
+		config.Config["ssh.bastion"] = tcase.config
+		got, err := find(tcase.target)
+		if tcase.expErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%d: got %v, want error containing %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got != tcase.exp {
+			t.Fatalf("%d: got %s, want %s", i+1, got, tcase.exp)
+		}
+	}
+}
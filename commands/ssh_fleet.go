@@ -0,0 +1,274 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	stdsync "sync"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/ssh"
+	"github.com/wallix/awless/sync"
+)
+
+// maxParallelSSH is the number of instances of a fleet running the command at the same time
+const maxParallelSSH = 20
+
+// sshUnreachableStatus is the exit status of the instances the command could not run on, as with OpenSSH
+const sshUnreachableStatus = 255
+
+type fleetResult struct {
+	label  string
+	status int
+	err    error
+}
+
+// runOnFleet runs the command concurrently on the running instances matching the query, prefixing
+// their output lines with their name, and returns the highest exit status among them
+func runOnFleet(query, command string) (int, error) {
+	m, err := match.Parse(query)
+	if err != nil {
+		return 0, err
+	}
+	fetched := &instanceConnectionContext{}
+	fetched.fetchConnectionInfo()
+	if gm, ok := m.(cloud.GraphMatcher); ok && match.IsRelational(m) {
+		local, err := sync.LoadLocalGraphs(config.GetAWSProfile(), config.GetAWSRegion())
+		if err != nil {
+			return 0, fmt.Errorf("cannot load local graphs to resolve relations of query: %s", err)
+		}
+		m = gm.InGraph(local)
+	}
+	instances, err := fetched.resourcesGraph.Find(cloud.NewQuery(cloud.Instance).Match(match.And(m, match.Property(properties.State, "running"))))
+	if err != nil {
+		return 0, err
+	}
+	if len(instances) == 0 {
+		return 0, fmt.Errorf("no running instance matching '%s'", query)
+	}
+	labels := fleetLabels(instances)
+	sort.Slice(instances, func(i, j int) bool { return labels[instances[i].Id()] < labels[instances[j].Id()] })
+	logger.Infof("Running '%s' on %d instances", command, len(instances))
+
+	var bastion *ssh.Client
+	if proxyInstanceThroughFlag != "" {
+		bastionCtx, err := initInstanceConnectionContext(proxyInstanceThroughFlag, keyPathFlag)
+		if err != nil {
+			return 0, err
+		}
+		if bastion, err = newSSHClient(bastionCtx, sshTroughPortFlag); err != nil {
+			return 0, err
+		}
+		if err = bastion.DialWithUsers(bastionCtx.users()...); err != nil {
+			return 0, err
+		}
+		defer bastion.CloseAll()
+	}
+	var hops map[string]*ssh.Client
+	if bastion == nil && !privateIPFlag {
+		var bastions map[string]*ssh.Client
+		if hops, bastions, err = dialFleetBastions(fetched, instances); err != nil {
+			return 0, err
+		}
+		for _, b := range bastions {
+			defer b.CloseAll()
+		}
+	}
+
+	width := 0
+	for _, l := range labels {
+		if len(l) > width {
+			width = len(l)
+		}
+	}
+	var outMu stdsync.Mutex
+	results := make([]*fleetResult, len(instances))
+	sem := make(chan struct{}, maxParallelSSH)
+	var wg stdsync.WaitGroup
+	for i, inst := range instances {
+		wg.Add(1)
+		go func(i int, inst cloud.Resource) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			label := labels[inst.Id()]
+			prefix := fmt.Sprintf("[%-*s] ", width, label)
+			stdout := &prefixWriter{mu: &outMu, out: os.Stdout, prefix: prefix}
+			stderr := &prefixWriter{mu: &outMu, out: os.Stderr, prefix: prefix}
+			hop := bastion
+			if hop == nil {
+				hop = hops[inst.Id()]
+			}
+			status, err := runOnFleetInstance(fetched, inst, hop, command, stdout, stderr)
+			stdout.Flush()
+			stderr.Flush()
+			results[i] = &fleetResult{label: label, status: status, err: err}
+		}(i, inst)
+	}
+	wg.Wait()
+
+	return summarizeFleetResults(results), nil
+}
+
+// dialFleetBastions connects to the bastions of the instances without public IP and returns
+// the clients by instance ID along with the clients by bastion ID, a bastion being dialed once
+func dialFleetBastions(fetched *instanceConnectionContext, instances []cloud.Resource) (map[string]*ssh.Client, map[string]*ssh.Client, error) {
+	dialed := make(map[string]*ssh.Client)
+	hops := make(map[string]*ssh.Client)
+	fail := func(err error) (map[string]*ssh.Client, map[string]*ssh.Client, error) {
+		for _, c := range dialed {
+			c.CloseAll()
+		}
+		return nil, nil, err
+	}
+	for _, inst := range instances {
+		b, err := findBastion(fetched.resourcesGraph, inst)
+		if err != nil {
+			return fail(err)
+		}
+		if b == nil {
+			continue
+		}
+		if _, ok := dialed[b.Id()]; !ok {
+			bastionCtx := &instanceConnectionContext{resourcesGraph: fetched.resourcesGraph, myip: fetched.myip}
+			bastionCtx.setInstance(b, keyPathFlag)
+			client, err := newSSHClient(bastionCtx, sshTroughPortFlag)
+			if err == nil {
+				err = client.DialWithUsers(bastionCtx.users()...)
+			}
+			if err != nil {
+				return fail(fmt.Errorf("bastion %s: %s", b.Id(), err))
+			}
+			logger.Verbosef("connected to bastion %s", b.Id())
+			dialed[b.Id()] = client
+		}
+		hops[inst.Id()] = dialed[b.Id()]
+	}
+	return hops, dialed, nil
+}
+
+func runOnFleetInstance(fetched *instanceConnectionContext, inst cloud.Resource, bastion *ssh.Client, command string, stdout, stderr io.Writer) (int, error) {
+	ctx := &instanceConnectionContext{resourcesGraph: fetched.resourcesGraph, myip: fetched.myip}
+	ctx.setInstance(inst, keyPathFlag)
+
+	var client *ssh.Client
+	var err error
+	if bastion != nil {
+		client, err = bastion.NewClientWithProxy(ctx.privip, sshPortFlag, ctx.users()...)
+	} else {
+		if client, err = newSSHClient(ctx, sshPortFlag); err == nil {
+			err = client.DialWithUsers(ctx.users()...)
+		}
+	}
+	if err != nil {
+		return sshUnreachableStatus, err
+	}
+	defer client.CloseAll()
+
+	status, err := client.Run(command, stdout, stderr)
+	if err != nil {
+		return sshUnreachableStatus, err
+	}
+	return status, nil
+}
+
+// summarizeFleetResults logs the instances on which the command failed and returns the highest exit status
+func summarizeFleetResults(results []*fleetResult) int {
+	var failed, max int
+	for _, r := range results {
+		if r.status > max {
+			max = r.status
+		}
+		switch {
+		case r.err != nil:
+			failed++
+			logger.Errorf("%s: %s", r.label, r.err)
+		case r.status != 0:
+			failed++
+			logger.Errorf("%s: exited with status %d", r.label, r.status)
+		}
+	}
+	if failed > 0 {
+		logger.Warningf("Command failed on %d of %d instances", failed, len(results))
+	} else {
+		logger.Infof("Command succeeded on %d instances", len(results))
+	}
+	return max
+}
+
+// fleetLabels returns the labels of the instances prefixing their output: their name, unless shared, or their ID
+func fleetLabels(instances []cloud.Resource) map[string]string {
+	names := make(map[string]int)
+	for _, inst := range instances {
+		if name, _ := inst.Properties()[properties.Name].(string); name != "" {
+			names[name]++
+		}
+	}
+	labels := make(map[string]string)
+	for _, inst := range instances {
+		labels[inst.Id()] = inst.Id()
+		if name, _ := inst.Properties()[properties.Name].(string); name != "" && names[name] == 1 {
+			labels[inst.Id()] = name
+		}
+	}
+	return labels
+}
+
+// prefixWriter writes the complete lines of an output prefixed, a line at a time to not mix
+// the lines of the writers sharing the mutex
+type prefixWriter struct {
+	mu     *stdsync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.writeLine(w.buf[:i+1])
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes the last line when it does not end with a newline
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(append(w.buf, '\n'))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fmt.Fprintf(w.out, "%s%s", w.prefix, line)
+}
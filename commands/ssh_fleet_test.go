@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	var mu sync.Mutex
+	web1 := &prefixWriter{mu: &mu, out: &out, prefix: "[web-1] "}
+	web2 := &prefixWriter{mu: &mu, out: &out, prefix: "[web-2] "}
+
+	web1.Write([]byte("up 3 days"))
+	web2.Write([]byte("up 1 day\nload: 0.1"))
+	web1.Write([]byte(", load: 0.5\n"))
+	web1.Flush()
+	web2.Flush()
+
+	if got, want := out.String(), "[web-2] up 1 day\n[web-1] up 3 days, load: 0.5\n[web-2] load: 0.1\n"; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFleetLabels(t *testing.T) {
+	instances := []cloud.Resource{
+		resourcetest.Instance("i-1").Prop("Name", "web").Build(),
+		resourcetest.Instance("i-2").Prop("Name", "api").Build(),
+		resourcetest.Instance("i-3").Prop("Name", "api").Build(),
+		resourcetest.Instance("i-4").Build(),
+	}
+	exp := map[string]string{"i-1": "web", "i-2": "i-2", "i-3": "i-3", "i-4": "i-4"}
+	if got, want := fleetLabels(instances), exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	results := []*fleetResult{{label: "web"}, {label: "i-2", status: 2}, {label: "i-3", status: sshUnreachableStatus}}
+	if got, want := summarizeFleetResults(results), sshUnreachableStatus; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
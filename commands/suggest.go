@@ -0,0 +1,136 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+)
+
+var (
+	suggestRegionServicesFlag []string
+	suggestRegionAllowedFlag  []string
+	suggestRegionAttemptsFlag int
+	suggestRegionTopFlag      int
+	suggestRegionSetFlag      bool
+)
+
+func init() {
+	RootCmd.AddCommand(suggestCmd)
+	suggestCmd.AddCommand(suggestRegionCmd)
+
+	suggestRegionCmd.Flags().StringSliceVar(&suggestRegionServicesFlag, "service", nil, "Only suggest regions offering the given APIs (ex: --service lambda,ecs)")
+	suggestRegionCmd.Flags().StringSliceVar(&suggestRegionAllowedFlag, "allowed", nil, "Regions allowed, wildcards allowed (ex: --allowed 'eu-*'). Overrides the `aws.regions.allowed` config")
+	suggestRegionCmd.Flags().IntVar(&suggestRegionAttemptsFlag, "attempts", 3, "Number of pings per region (the median latency is kept)")
+	suggestRegionCmd.Flags().IntVar(&suggestRegionTopFlag, "top", 5, "Number of regions displayed (0 for all)")
+	suggestRegionCmd.Flags().BoolVar(&suggestRegionSetFlag, "set", false, "Set the suggested region in your config")
+}
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest settings suited to your location and constraints",
+}
+
+var suggestRegionCmd = &cobra.Command{
+	Use:   "region",
+	Short: "Suggest the region with the lowest latency among the regions allowed and offering the APIs you need",
+	Long: `Ping the endpoints of the candidate regions from your location and suggest the one with the lowest latency.
+
+Candidate regions are the regions enabled for your account, restricted by your data residency allow-list (see ` + "`awless config set aws.regions.allowed`" + `) and to the regions offering the APIs required with --service.`,
+	Example: `  awless suggest region
+  awless suggest region --allowed 'eu-*' --service ecs,lambda
+  awless suggest region --set`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(includeHookIf(&config.TriggerSyncOnConfigUpdate, initCloudServicesHook)),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		regions, err := awsservices.RegionsCatalog()
+		if regions == nil {
+			exitOn(err)
+		}
+		logFetchErrors(err, logger.Warning)
+
+		allowed := config.GetAllowedRegions()
+		if len(suggestRegionAllowedFlag) > 0 {
+			allowed = suggestRegionAllowedFlag
+		}
+		candidates := awsservices.RegionsMatching(regions, allowed, suggestRegionServicesFlag)
+		if len(candidates) == 0 {
+			exitOn(fmt.Errorf("no region allowed (%v) offering the APIs %v", allowed, suggestRegionServicesFlag))
+		}
+
+		var ids []string
+		for _, r := range candidates {
+			ids = append(ids, r.ID)
+			for _, api := range suggestRegionServicesFlag {
+				if _, known := awsservices.APIAvailabilityInRegion(api, r.ID); !known {
+					logger.Warningf("%s: cannot tell whether %s is available (unknown to the endpoints catalog of awless)", r.ID, api)
+				}
+			}
+		}
+		logger.Verbosef("pinging %d regions", len(ids))
+		latencies := awsservices.PingRegions(ids, suggestRegionAttemptsFlag)
+
+		best := latencies[0]
+		if best.Err != nil {
+			exitOn(fmt.Errorf("cannot reach any region: %s", best.Err))
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		if !noHeadersFlag {
+			fmt.Fprintln(w, "Region\tLatency\t")
+			fmt.Fprintln(w, "------\t-------\t")
+		}
+		current := config.GetAWSRegion()
+		for i, l := range latencies {
+			if suggestRegionTopFlag > 0 && i >= suggestRegionTopFlag {
+				break
+			}
+			latency := "unreachable"
+			if l.Err == nil {
+				latency = l.Latency.Truncate(time.Millisecond).String()
+			} else {
+				logger.Verbose(l.Err)
+			}
+			var mark string
+			if l.Region == current {
+				mark = "(current)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", l.Region, latency, mark)
+		}
+		exitOn(w.Flush())
+		fmt.Println()
+
+		if best.Region == current {
+			logger.Infof("you are already using the suggested region %s", best.Region)
+			return
+		}
+		if !suggestRegionSetFlag {
+			logger.Infof("suggested region: %s (set it with `awless switch %s`)", best.Region, best.Region)
+			return
+		}
+		exitOn(config.Set(config.RegionConfigKey, best.Region))
+		logger.Infof("region set to %s", best.Region)
+	},
+}
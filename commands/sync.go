@@ -17,18 +17,25 @@ limitations under the License.
 package commands
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"runtime"
 	"runtime/pprof"
+	"sort"
 	"strings"
+	gosync "sync"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/aws/services"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
 )
@@ -36,11 +43,13 @@ import (
 var (
 	servicesToSyncFlags map[string]*bool
 	profileSyncFlag     bool
+	showChangesSyncFlag bool
 )
 
 func init() {
 	RootCmd.AddCommand(syncCmd)
 	syncCmd.Flags().BoolVar(&profileSyncFlag, "profile-sync", false, "Will dump a cpu and mem profiling file")
+	syncCmd.Flags().BoolVar(&showChangesSyncFlag, "show-changes", false, "Display the resources created, deleted or modified since last sync")
 
 	servicesToSyncFlags = make(map[string]*bool)
 	for _, service := range awsservices.ServiceNames {
@@ -76,8 +85,14 @@ var syncCmd = &cobra.Command{
 
 		var syncErr error
 		var graphs map[string]cloud.GraphAPI
+		progress := new(syncProgress)
+		ctx, cancel := context.WithCancel(fetch.WithProgress(context.Background(), progress.report))
+		defer cancel()
+		go cancelOnInterrupt(ctx, cancel)
+
 		syncFn := func() {
-			graphs, syncErr = sync.DefaultSyncer.Sync(services...)
+			graphs, syncErr = sync.DefaultSyncer.SyncWithContext(ctx, services...)
+			progress.clear()
 		}
 
 		start := time.Now()
@@ -86,12 +101,31 @@ var syncCmd = &cobra.Command{
 		} else {
 			syncFn()
 		}
-		if syncErr != nil {
-			logger.Verbose(syncErr)
+		if fetch.IsCanceled(syncErr) {
+			logger.Warningf("sync interrupted after %s: local data left untouched", time.Since(start))
+			os.Exit(130)
+		}
+		logFetchErrors(syncErr, logger.Verbose)
+
+		var synced []string
+		for k := range graphs {
+			synced = append(synced, k)
 		}
+		sort.Strings(synced)
 
-		for k, g := range graphs {
-			displaySyncStats(k, g)
+		if console.IsStructuredFormat(formatGlobalFlag) {
+			var summaries []*syncSummary
+			for _, k := range synced {
+				summaries = append(summaries, newSyncSummary(k, localGraphs[k], graphs[k]))
+			}
+			exitOn(console.EncodeStructured(os.Stdout, formatGlobalFlag, summaries))
+		} else {
+			for _, k := range synced {
+				displaySyncStats(k, graphs[k])
+				if showChangesSyncFlag {
+					displaySyncChanges(k, localGraphs[k], graphs[k])
+				}
+			}
 		}
 		logger.Infof("sync took %s", time.Since(start))
 
@@ -99,6 +133,39 @@ var syncCmd = &cobra.Command{
 	},
 }
 
+// logFetchErrors warns about the denied or throttled fetching of resources, quietly skips
+// the resources not supported in the region, and logs the other errors with the given func
+func logFetchErrors(err error, logOthers func(...interface{})) {
+	if err == nil {
+		return
+	}
+	for _, e := range *fetch.WrapError(err) {
+		switch e.(type) {
+		case *fetch.NotSupportedInRegionError:
+			logger.ExtraVerbose(e)
+		case *fetch.AccessDeniedError, *fetch.ThrottledError:
+			logger.Warning(e)
+		default:
+			logOthers(e)
+		}
+	}
+}
+
+// cancelOnInterrupt cancels the context on the first interrupt signal so that
+// in-flight fetches stop paginating. A second interrupt kills the process.
+func cancelOnInterrupt(ctx context.Context, cancel context.CancelFunc) {
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, os.Interrupt)
+	defer signal.Stop(sigc)
+
+	select {
+	case <-sigc:
+		logger.Warning("interrupting sync (hit Ctrl-C again to force exit)...")
+		cancel()
+	case <-ctx.Done():
+	}
+}
+
 func withProfiling(fn func()) {
 	logger.Infof("sync profiling on")
 	mem, err := os.Create("mem-sync.prof")
@@ -126,21 +193,130 @@ func withProfiling(fn func()) {
 	logger.Infof("Generated profiling files %s and %s", cpu.Name(), mem.Name())
 }
 
-func displaySyncStats(serviceName string, g cloud.GraphAPI) {
-	var strs []string
+// syncSummary is the outcome of the sync of a service in the structured formats
+type syncSummary struct {
+	Service   string         `json:"service"`
+	Region    string         `json:"region"`
+	Resources map[string]int `json:"resources"`
+	Created   []string       `json:"created,omitempty"`
+	Deleted   []string       `json:"deleted,omitempty"`
+	Modified  []string       `json:"modified,omitempty"`
+}
+
+func newSyncSummary(serviceName string, previous, synced cloud.GraphAPI) *syncSummary {
+	summary := &syncSummary{Service: serviceName, Region: config.GetAWSRegion(), Resources: syncStats(serviceName, synced)}
+	if !showChangesSyncFlag {
+		return summary
+	}
+	diff, err := syncChanges(serviceName, previous, synced)
+	if err != nil {
+		logger.Errorf("%s: cannot compute changes: %s", serviceName, err)
+		return summary
+	}
+	for _, r := range diff.Created {
+		summary.Created = append(summary.Created, r.Id())
+	}
+	for _, r := range diff.Deleted {
+		summary.Deleted = append(summary.Deleted, r.Id())
+	}
+	for _, r := range diff.Modified {
+		summary.Modified = append(summary.Modified, r.Id())
+	}
+	return summary
+}
+
+// syncStats returns the count of synced resources of the service per resource type
+func syncStats(serviceName string, g cloud.GraphAPI) map[string]int {
+	stats := make(map[string]int)
 	for rt, service := range awsservices.ServicePerResourceType {
 		if service == serviceName {
 			res, err := g.Find(cloud.NewQuery(rt))
 			if err != nil {
 				continue
 			}
-			nbRes := len(res)
-			if nbRes > 1 {
-				strs = append(strs, fmt.Sprintf("%d %s", nbRes, cloud.PluralizeResource(rt)))
-			} else {
-				strs = append(strs, fmt.Sprintf("%d %s", nbRes, rt))
-			}
+			stats[rt] = len(res)
+		}
+	}
+	return stats
+}
+
+func displaySyncStats(serviceName string, g cloud.GraphAPI) {
+	var strs []string
+	for rt, nbRes := range syncStats(serviceName, g) {
+		if nbRes > 1 {
+			strs = append(strs, fmt.Sprintf("%d %s", nbRes, cloud.PluralizeResource(rt)))
+		} else {
+			strs = append(strs, fmt.Sprintf("%d %s", nbRes, rt))
 		}
 	}
 	logger.Infof("-> %s: %s", serviceName, strings.Join(strs, ", "))
 }
+
+// syncChanges returns the resources of the service created, deleted or modified by the sync
+func syncChanges(serviceName string, previous, synced cloud.GraphAPI) (*graph.ResourcesDiff, error) {
+	from, ok := previous.(*graph.Graph)
+	if !ok {
+		from = graph.NewGraph()
+	}
+	to, ok := synced.(*graph.Graph)
+	if !ok {
+		return new(graph.ResourcesDiff), nil
+	}
+	var resourceTypes []string
+	for rt, service := range awsservices.ServicePerResourceType {
+		if service == serviceName {
+			resourceTypes = append(resourceTypes, rt)
+		}
+	}
+	return graph.DiffResources(from, to, resourceTypes...)
+}
+
+func displaySyncChanges(serviceName string, previous, synced cloud.GraphAPI) {
+	diff, err := syncChanges(serviceName, previous, synced)
+	if err != nil {
+		logger.Errorf("%s: cannot compute changes: %s", serviceName, err)
+		return
+	}
+	if diff.Empty() {
+		logger.Infof("   no changes since last sync")
+		return
+	}
+	for _, r := range diff.Created {
+		fmt.Printf("   %s %s\n", renderGreenFn("+"), r)
+	}
+	for _, r := range diff.Deleted {
+		fmt.Printf("   %s %s\n", renderRedFn("-"), r)
+	}
+	for _, r := range diff.Modified {
+		fmt.Printf("   %s %s\n", renderYellowFn("~"), r.Resource)
+		for _, c := range r.Changes {
+			fmt.Printf("       %s\n", c)
+		}
+	}
+}
+
+type syncProgress struct {
+	mu      gosync.Mutex
+	printed bool
+}
+
+// report displays the count of resources fetched so far for
+// resources that need several pages to be fetched (i.e. big accounts)
+func (p *syncProgress) report(pr fetch.Progress) {
+	if pr.Done || pr.Pages < 2 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	logger.DefaultLogger.InteractiveInfof("%s: %s fetched...", cloud.PluralizeResource(pr.ResourceType), console.HumanizeCount(pr.Resources))
+	p.printed = true
+}
+
+func (p *syncProgress) clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.printed {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+		p.printed = false
+	}
+}
@@ -7,12 +7,35 @@ import (
 	"unicode"
 
 	"github.com/chzyer/readline"
+	"github.com/wallix/awless/aws/doc"
 	"github.com/wallix/awless/aws/services"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/template"
 )
 
+// paramsAutoCompletion completes the value of params (ex: create.instance.subnet) from their enums,
+// from the property of their typed resources or else from the resources of the local graph
+func paramsAutoCompletion(g cloud.GraphAPI, paramPaths []string) readline.AutoCompleter {
+	var enums []string
+	var typedParam *awsdoc.ParamType
+	for _, param := range paramPaths {
+		if enum, hasEnum := awsdoc.EnumDoc[param]; hasEnum {
+			enums = append(enums, enum...)
+		}
+		if tparam, has := awsdoc.ParamTypeDoc[param]; has {
+			typedParam = tparam
+		}
+	}
+	if len(enums) > 0 {
+		return enumCompletionFunc(enums)
+	}
+	if typedParam != nil {
+		return typedParamCompletionFunc(g, typedParam.ResourceType, typedParam.PropertyName)
+	}
+	return holeAutoCompletion(g, paramPaths)
+}
+
 func enumCompletionFunc(enum []string) readline.AutoCompleter {
 	if len(enum) == 1 && enum[0] == "" {
 		return readline.NewPrefixCompleter()
@@ -195,7 +218,7 @@ func quotedSortedSet(list []string) (out []string) {
 // according to given holes questions.
 // See corresponding unit test for logic
 func guessEntityTypeFromHoleQuestion(hole string) (resolved []string, prop string) {
-	tokens := strings.Split(strings.TrimSpace(hole), ".")
+	tokens := mergeResourceTypeTokens(strings.Split(strings.TrimSpace(hole), "."))
 	if len(tokens) == 0 {
 		return
 	}
@@ -270,6 +293,29 @@ func appendWithNameAliases(slice []string, res cloud.Resource) []string {
 	return slice
 }
 
+// mergeResourceTypeTokens joins consecutive tokens naming a resource type
+// (i.e. route.table is routetable rather than table)
+func mergeResourceTypeTokens(tokens []string) (merged []string) {
+	for i := 0; i < len(tokens); i++ {
+		if i+1 < len(tokens) && isResourceTypeWithPlural(tokens[i]+tokens[i+1]) {
+			merged = append(merged, tokens[i]+tokens[i+1])
+			i++
+			continue
+		}
+		merged = append(merged, tokens[i])
+	}
+	return
+}
+
+func isResourceTypeWithPlural(s string) bool {
+	for _, r := range resourcesTypesWithPlural {
+		if s == r {
+			return true
+		}
+	}
+	return false
+}
+
 var resourcesTypesWithPlural []string
 
 func init() {
@@ -0,0 +1,210 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	stdsync "sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+const repoTemplateSource = "repo"
+
+var templateSearchLongFlag bool
+
+func init() {
+	RootCmd.AddCommand(templateCmd)
+	templateCmd.AddCommand(templateSearchCmd)
+	templateSearchCmd.Flags().BoolVar(&templateSearchLongFlag, "long", false, "Display the description and source of the templates")
+}
+
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Discover templates from https://github.com/wallix/awless-templates and sources of `awless config set template.sources`",
+}
+
+var templateSearchCmd = &cobra.Command{
+	Use:   "search [KEYWORD...]",
+	Short: "Search templates matching all keywords in their name, title, description, tags or required holes",
+	Example: `  awless template search vpc
+  awless template search instance autoscaling --long
+  awless config set template.sources ~/templates,https://my.company.org/templates`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var matching []*template.LibraryEntry
+		for _, entry := range indexTemplateSources(append([]string{repoTemplateSource}, config.GetTemplateSources()...)) {
+			if entry.MinimalVersion != "" {
+				if comp, err := config.CompareSemver(entry.MinimalVersion, config.Version); err == nil && comp > 0 {
+					logger.ExtraVerbosef("template %s requires awless %s", entry.Location, entry.MinimalVersion)
+					continue
+				}
+			}
+			if entry.Match(args...) {
+				matching = append(matching, entry)
+			}
+		}
+
+		if len(matching) == 0 {
+			logger.Infof("No templates found matching %q", args)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "Title\tRequired holes\tRun it with")
+		fmt.Fprintln(w, "-----\t--------------\t-----------")
+		for _, entry := range matching {
+			title := entry.Title
+			if title == "" {
+				title = entry.Name
+			}
+			fmt.Fprintf(w, "%s\t%s\tawless run %s\n", title, strings.Join(entry.RequiredHoles, ","), runnableTemplateLocation(entry))
+			if templateSearchLongFlag {
+				if entry.Description != "" {
+					fmt.Fprintf(w, "\t%s\t\n", entry.Description)
+				}
+				fmt.Fprintf(w, "\tsource: %s, tags: %s\t\n", entry.Source, strings.Join(entry.Tags, ","))
+			}
+		}
+		w.Flush()
+		return nil
+	},
+}
+
+// indexTemplateSources indexes concurrently the templates of the sources: the official
+// templates repository (repo), local directories or remote URLs serving a manifest.json
+func indexTemplateSources(sources []string) (entries []*template.LibraryEntry) {
+	var wg stdsync.WaitGroup
+	var mu stdsync.Mutex
+	for _, src := range sources {
+		wg.Add(1)
+		go func(src string) {
+			defer wg.Done()
+			indexed, err := indexTemplateSource(src)
+			if err != nil {
+				logger.Warningf("cannot index templates of %s: %s", src, err)
+			}
+			mu.Lock()
+			entries = append(entries, indexed...)
+			mu.Unlock()
+		}(src)
+	}
+	wg.Wait()
+
+	sort.Slice(entries, func(i, j int) bool {
+		if isRepo := entries[i].Source == repoTemplateSource; isRepo != (entries[j].Source == repoTemplateSource) {
+			return isRepo
+		}
+		if entries[i].Source != entries[j].Source {
+			return entries[i].Source < entries[j].Source
+		}
+		return entries[i].Location < entries[j].Location
+	})
+	return
+}
+
+func indexTemplateSource(src string) ([]*template.LibraryEntry, error) {
+	switch {
+	case src == repoTemplateSource:
+		return indexRemoteTemplates(src, DEFAULT_REPO_PREFIX)
+	case strings.HasPrefix(src, "http"):
+		return indexRemoteTemplates(src, strings.TrimSuffix(src, "/"))
+	default:
+		return indexLocalTemplates(src)
+	}
+}
+
+func indexLocalTemplates(dir string) ([]*template.LibraryEntry, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+FILE_EXT))
+	if err != nil {
+		return nil, err
+	}
+	var entries []*template.LibraryEntry
+	for _, path := range files {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return entries, err
+		}
+		name := strings.TrimSuffix(filepath.Base(path), FILE_EXT)
+		entry, err := template.NewLibraryEntry(name, dir, path, content)
+		if err != nil {
+			logger.ExtraVerbosef("indexing template %s: %s", path, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func indexRemoteTemplates(src, prefix string) ([]*template.LibraryEntry, error) {
+	manifestFile, err := readHttpContent(prefix + "/manifest.json")
+	if err != nil {
+		return nil, err
+	}
+	var manifest []*templateMetadata
+	if err = json.Unmarshal(manifestFile, &manifest); err != nil {
+		return nil, err
+	}
+
+	entries := make([]*template.LibraryEntry, len(manifest))
+	var wg stdsync.WaitGroup
+	for i, tpl := range manifest {
+		wg.Add(1)
+		go func(i int, tpl *templateMetadata) {
+			defer wg.Done()
+			location := fmt.Sprintf("%s/%s%s", prefix, tpl.Name, FILE_EXT)
+			content, err := readHttpContent(location)
+			if err != nil {
+				logger.ExtraVerbosef("fetching template %s: %s", location, err)
+			}
+			entry, err := template.NewLibraryEntry(tpl.Name, src, location, content)
+			if err != nil {
+				logger.ExtraVerbosef("indexing template %s: %s", location, err)
+			}
+			if entry.Title == "" {
+				entry.Title = tpl.Title
+			}
+			if len(entry.Tags) == 0 {
+				entry.Tags = tpl.Tags
+			}
+			if entry.MinimalVersion == "" {
+				entry.MinimalVersion = tpl.MinimalVersion
+			}
+			entries[i] = entry
+		}(i, tpl)
+	}
+	wg.Wait()
+	return entries, nil
+}
+
+func runnableTemplateLocation(entry *template.LibraryEntry) string {
+	if entry.Source == repoTemplateSource {
+		return "repo:" + entry.Name
+	}
+	return entry.Location
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIndexLocalTemplates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"create_vpc.aws":  "# Title: Create a VPC\n# Tags: infra\ncreate vpc cidr={vpc.cidr}",
+		"webserver.aws":   "# Title: Web server\ncreate instance subnet={instance.subnet} name=web",
+		"notemplate.json": "{}",
+	}
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := indexTemplateSources([]string{dir})
+
+	var titles, locations []string
+	for _, e := range entries {
+		titles = append(titles, e.Title)
+		locations = append(locations, runnableTemplateLocation(e))
+	}
+	if got, want := titles, []string{"Create a VPC", "Web server"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := locations, []string{filepath.Join(dir, "create_vpc.aws"), filepath.Join(dir, "webserver.aws")}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := entries[1].RequiredHoles, []string{"instance.subnet"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
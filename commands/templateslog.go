@@ -0,0 +1,108 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws/services"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+// releaseTemplatesLog releases the lock of the shared templates log held during a run
+var releaseTemplatesLog func() error
+
+// initTemplatesLog logs the executed templates in the shared templates log when configured (see `aws.templates.log`)
+func initTemplatesLog() error {
+	l, err := awsservices.NewTemplatesLog()
+	if err != nil {
+		return err
+	}
+	if l != nil {
+		database.RemoteTemplates = l
+	}
+	return nil
+}
+
+// initTemplatesLogHook loads the AWS session only when the templates log is shared, for the commands
+// reading the log which do not need it otherwise
+func initTemplatesLogHook(cmd *cobra.Command, args []string) error {
+	if localGlobalFlag {
+		return nil
+	}
+	if target, _ := config.Config[awsservices.TemplatesLogTargetConfigKey].(string); strings.TrimSpace(target) == "" {
+		return nil
+	}
+	if err := initAWSServices(); err != nil {
+		return err
+	}
+	return initTemplatesLog()
+}
+
+// warnHiddenLocalTemplates warns about the runs of the local log hidden by the shared templates log
+func warnHiddenLocalTemplates() {
+	if database.RemoteTemplates == nil {
+		return
+	}
+	var count int
+	database.Execute(func(db *database.DB) (err error) {
+		count, err = db.CountLocalTemplates()
+		return
+	})
+	if count > 0 {
+		logger.Warningf("%d runs of the local log are hidden by the shared templates log (%s): move them to it with `awless log --migrate`", count, awsservices.TemplatesLogTargetConfigKey)
+	}
+}
+
+// lockTemplatesLog prevents the other users sharing the templates log from running templates until the end of the run
+func lockTemplatesLog(tplExec *template.TemplateExecution) error {
+	l, ok := database.RemoteTemplates.(*awsservices.TemplatesLog)
+	if !ok {
+		return nil
+	}
+	if !l.Serialized() {
+		logger.Warning("the templates log in S3 does not prevent concurrent runs (S3 has no conditional writes): store it in a DynamoDB table to serialize runs")
+		return nil
+	}
+	author := tplExec.Author
+	if author == "" {
+		author = "unknown"
+	}
+	host, _ := os.Hostname()
+	release, err := l.Lock(fmt.Sprintf("%s (%s, pid %d)", author, host, os.Getpid()))
+	if err != nil {
+		return err
+	}
+	releaseTemplatesLog = release
+	return nil
+}
+
+func unlockTemplatesLog() {
+	if releaseTemplatesLog == nil {
+		return
+	}
+	if err := releaseTemplatesLog(); err != nil {
+		logger.Warningf("cannot release lock of templates log: %s", err)
+	}
+	releaseTemplatesLog = nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const minWatchInterval = time.Second
+
+func validateWatchInterval(interval time.Duration) error {
+	if interval < minWatchInterval {
+		return fmt.Errorf("--watch interval must be at least %s", minWatchInterval)
+	}
+	return nil
+}
+
+// watch calls render every interval until interrupted (Ctrl+C), render being in charge of
+// refetching and of highlighting what changed since its last call. The terminal is only
+// cleared once rendered, so that the previous display stays visible while refetching.
+func watch(interval time.Duration, render func(w io.Writer)) {
+	title := fmt.Sprintf("Every %s: awless %s", interval, strings.Join(os.Args[1:], " "))
+	for {
+		var buf bytes.Buffer
+		render(&buf)
+		fmt.Print("\033[H\033[2J")
+		fmt.Printf("%s\t%s\n\n", renderCyanBoldFn(title), time.Now().Format("15:04:05"))
+		buf.WriteTo(os.Stdout)
+		time.Sleep(interval)
+	}
+}
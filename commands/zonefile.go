@@ -0,0 +1,412 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+var (
+	zonefileZoneFlag   string
+	zonefileOriginFlag string
+	zonefilePrintFlag  bool
+)
+
+func init() {
+	importZonefileCmd.Flags().StringVar(&zonefileZoneFlag, "zone", "", "ID (or @name) of the hosted zone receiving the records. When empty, a hosted zone is created for the origin of the zone file")
+	importZonefileCmd.Flags().StringVar(&zonefileOriginFlag, "origin", "", "Origin of the relative names when the zone file has no $ORIGIN directive (default: EXAMPLE.COM for a db.EXAMPLE.COM file)")
+	importZonefileCmd.Flags().BoolVar(&zonefilePrintFlag, "print", false, "Print the generated template instead of running it, to review it and run it later with `awless run`")
+}
+
+var importZonefileCmd = &cobra.Command{
+	Use:   "zonefile FILE",
+	Short: "Import the records of a BIND zone file into a Route53 hosted zone, as create record statements previewed before running",
+	Long: `Import the records of a BIND zone file into a Route53 hosted zone, as create record statements previewed before running.
+
+The zone file is parsed in the standard syntax ($ORIGIN and $TTL directives, relative names, multi-line records in parentheses, ...). The records with the same name and type are grouped in one record set. The SOA record and the NS records of the zone apex are skipped since Route53 manages them.`,
+	Example:           "  awless import zonefile db.example.com --zone Z1D633PJN98FT9\n  awless import zonefile db.example.com\n  awless import zonefile example.zone --origin example.com --print > import-example.aws",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook, firstInstallDoneHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade, networkMonitorHook),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("FILE required. See examples.")
+		}
+		content, err := ioutil.ReadFile(args[0])
+		exitOn(err)
+
+		origin := zonefileOriginFlag
+		if base := filepath.Base(args[0]); origin == "" && strings.HasPrefix(base, "db.") {
+			origin = strings.TrimPrefix(base, "db.")
+		}
+		zf, err := parseZonefile(string(content), origin)
+		exitOn(err)
+		for _, skipped := range zf.Skipped {
+			logger.Warningf("skipping %s", skipped)
+		}
+		if len(zf.Records) == 0 {
+			return fmt.Errorf("no records to import from %s", args[0])
+		}
+
+		text, err := zf.templateText(zonefileZoneFlag)
+		exitOn(err)
+		if zonefilePrintFlag {
+			fmt.Print(text)
+			return nil
+		}
+
+		templ, err := template.Parse(text)
+		exitOn(err)
+
+		exitOn(NewRunnerRequiredParamsOnly(templ, fmt.Sprintf("Import of zone file %s", filepath.Base(args[0])), "").Run())
+		return nil
+	},
+}
+
+type zonefileRecord struct {
+	Name, Type string
+	TTL        int64
+	Values     []string
+}
+
+type zonefile struct {
+	Origin  string
+	Records []*zonefileRecord
+	Skipped []string
+}
+
+var zonefileValuesCount = map[string]int{
+	"A": 1, "AAAA": 1, "CNAME": 1, "NS": 1, "PTR": 1, "TXT": 1, "SPF": 1, "MX": 2, "CAA": 3, "SRV": 4, "NAPTR": 6,
+}
+
+func parseZonefile(text, origin string) (*zonefile, error) {
+	lines, err := tokenizeZonefile(text)
+	if err != nil {
+		return nil, err
+	}
+	zf := &zonefile{}
+	if origin != "" {
+		zf.Origin = strings.TrimSuffix(origin, ".") + "."
+	}
+
+	recordSets := make(map[string]*zonefileRecord)
+	defaultTTL, lastTTL := int64(-1), int64(-1)
+	var owner string
+	for _, line := range lines {
+		tokens := line.tokens
+		switch directive := strings.ToUpper(tokens[0]); directive {
+		case "$ORIGIN":
+			if len(tokens) < 2 {
+				return nil, fmt.Errorf("line %d: missing domain of $ORIGIN", line.num)
+			}
+			if zf.Origin, err = absoluteZonefileName(tokens[1], zf.Origin); err != nil {
+				return nil, fmt.Errorf("line %d: %s", line.num, err)
+			}
+			continue
+		case "$TTL":
+			if len(tokens) < 2 {
+				return nil, fmt.Errorf("line %d: missing value of $TTL", line.num)
+			}
+			if defaultTTL, err = parseZonefileTTL(tokens[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %s", line.num, err)
+			}
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s directive not supported", line.num, directive)
+		}
+
+		if !line.continuesOwner {
+			if owner, err = absoluteZonefileName(tokens[0], zf.Origin); err != nil {
+				return nil, fmt.Errorf("line %d: %s", line.num, err)
+			}
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: missing name of the record", line.num)
+		}
+
+		ttl := int64(-1)
+		for len(tokens) > 0 {
+			if class := strings.ToUpper(tokens[0]); class == "IN" || class == "CH" || class == "HS" {
+				if class != "IN" {
+					return nil, fmt.Errorf("line %d: class %s not supported", line.num, class)
+				}
+				tokens = tokens[1:]
+				continue
+			}
+			if parsed, terr := parseZonefileTTL(tokens[0]); terr == nil && ttl < 0 {
+				ttl = parsed
+				tokens = tokens[1:]
+				continue
+			}
+			break
+		}
+		if len(tokens) == 0 {
+			return nil, fmt.Errorf("line %d: missing type of the record", line.num)
+		}
+		if ttl < 0 {
+			ttl = defaultTTL
+		}
+		if ttl < 0 {
+			ttl = lastTTL
+		}
+		if ttl < 0 {
+			return nil, fmt.Errorf("line %d: missing TTL of the record and no $TTL directive", line.num)
+		}
+		lastTTL = ttl
+
+		typ := strings.ToUpper(tokens[0])
+		switch {
+		case typ == "SOA":
+			zf.Skipped = append(zf.Skipped, fmt.Sprintf("line %d: SOA record of %s (managed by Route53)", line.num, owner))
+			continue
+		case typ == "NS" && owner == zf.Origin:
+			zf.Skipped = append(zf.Skipped, fmt.Sprintf("line %d: NS record of the zone apex %s (managed by Route53)", line.num, owner))
+			continue
+		}
+		if _, ok := zonefileValuesCount[typ]; !ok {
+			zf.Skipped = append(zf.Skipped, fmt.Sprintf("line %d: %s record of %s (type not supported by Route53)", line.num, typ, owner))
+			continue
+		}
+		value, err := zonefileRecordValue(typ, tokens[1:], zf.Origin)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line.num, err)
+		}
+
+		key := owner + " " + typ
+		if set, ok := recordSets[key]; ok {
+			set.Values = append(set.Values, value)
+			continue
+		}
+		recordSets[key] = &zonefileRecord{Name: owner, Type: typ, TTL: ttl, Values: []string{value}}
+		zf.Records = append(zf.Records, recordSets[key])
+	}
+	return zf, nil
+}
+
+// templateText returns the create record statements in the given hosted zone,
+// preceded by the creation of the hosted zone of the origin when no zone is given
+func (zf *zonefile) templateText(zone string) (string, error) {
+	var lines []string
+	if zone == "" {
+		if zf.Origin == "" {
+			return "", errors.New("missing origin of the hosted zone to create")
+		}
+		lines = append(lines, fmt.Sprintf("zone = create zone name=%s callerreference=awless-import-{date.unix}", strings.TrimSuffix(zf.Origin, ".")))
+		zone = "$zone"
+	} else {
+		quoted, err := quoteZonefileParam(zone)
+		if err != nil {
+			return "", err
+		}
+		zone = quoted
+	}
+
+	for _, rec := range zf.Records {
+		var values []string
+		for _, v := range rec.Values {
+			quoted, err := quoteZonefileParam(v)
+			if err != nil {
+				return "", fmt.Errorf("%s record of %s: %s", rec.Type, rec.Name, err)
+			}
+			values = append(values, quoted)
+		}
+		valuesParam := "value=" + values[0]
+		if len(values) > 1 {
+			valuesParam = "values=[" + strings.Join(values, ",") + "]"
+		}
+		name, err := quoteZonefileParam(rec.Name)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, fmt.Sprintf("create record zone=%s name=%s type=%s ttl=%d %s", zone, name, rec.Type, rec.TTL, valuesParam))
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+func quoteZonefileParam(s string) (string, error) {
+	switch {
+	case template.MatchStringParamValue(s):
+		return s, nil
+	case !strings.ContainsRune(s, '\''):
+		return "'" + s + "'", nil
+	case !strings.ContainsRune(s, '"'):
+		return "\"" + s + "\"", nil
+	default:
+		return "", fmt.Errorf("value %s cannot be quoted in a template: containing both single and double quotes", s)
+	}
+}
+
+func zonefileRecordValue(typ string, fields []string, origin string) (string, error) {
+	if count := zonefileValuesCount[typ]; len(fields) < count {
+		return "", fmt.Errorf("%s record expecting %d fields, got %d", typ, count, len(fields))
+	}
+	var err error
+	switch typ {
+	case "CNAME", "NS", "PTR":
+		fields[0], err = absoluteZonefileName(fields[0], origin)
+	case "MX":
+		fields[1], err = absoluteZonefileName(fields[1], origin)
+	case "SRV":
+		fields[3], err = absoluteZonefileName(fields[3], origin)
+	case "NAPTR":
+		fields[5], err = absoluteZonefileName(fields[5], origin)
+	case "TXT", "SPF":
+		for i, f := range fields {
+			if !strings.HasPrefix(f, "\"") {
+				fields[i] = strconv.Quote(f)
+			}
+		}
+	}
+	return strings.Join(fields, " "), err
+}
+
+func absoluteZonefileName(name, origin string) (string, error) {
+	if strings.HasSuffix(name, ".") {
+		return name, nil
+	}
+	if origin == "" {
+		return "", fmt.Errorf("relative name '%s' without origin: use a $ORIGIN directive or the --origin flag", name)
+	}
+	if name == "@" {
+		return origin, nil
+	}
+	return name + "." + origin, nil
+}
+
+func parseZonefileTTL(s string) (int64, error) {
+	if ttl, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return ttl, nil
+	}
+	units := map[rune]int64{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	var ttl, current int64
+	var digits bool
+	for _, r := range strings.ToLower(s) {
+		if r >= '0' && r <= '9' {
+			current = current*10 + int64(r-'0')
+			digits = true
+			continue
+		}
+		unit, ok := units[r]
+		if !ok || !digits {
+			return 0, fmt.Errorf("invalid TTL '%s'", s)
+		}
+		ttl += current * unit
+		current, digits = 0, false
+	}
+	if digits || s == "" {
+		return 0, fmt.Errorf("invalid TTL '%s'", s)
+	}
+	return ttl, nil
+}
+
+type zonefileLine struct {
+	num            int
+	continuesOwner bool
+	tokens         []string
+}
+
+// tokenizeZonefile splits the zone file in logical lines of fields, removing the comments
+// and joining the lines between parentheses. Quoted strings are kept as one field with their quotes
+func tokenizeZonefile(text string) ([]*zonefileLine, error) {
+	var lines []*zonefileLine
+	var token []rune
+	var inQuotes, escaped, inComment bool
+	var parens int
+	num := 1
+	line := &zonefileLine{num: num}
+	atLineStart := true
+
+	endToken := func() {
+		if len(token) > 0 {
+			line.tokens = append(line.tokens, string(token))
+			token = nil
+		}
+	}
+	for _, r := range text {
+		if atLineStart && r != '\n' {
+			line.continuesOwner = r == ' ' || r == '\t'
+			atLineStart = false
+		}
+		switch {
+		case r == '\n':
+			if inQuotes {
+				return lines, fmt.Errorf("line %d: unterminated quoted string", num)
+			}
+			num++
+			inComment = false
+			if parens > 0 {
+				endToken()
+				continue
+			}
+			endToken()
+			if len(line.tokens) > 0 {
+				lines = append(lines, line)
+			}
+			line = &zonefileLine{num: num}
+			atLineStart = true
+		case inComment:
+		case inQuotes:
+			token = append(token, r)
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == '"':
+				inQuotes = false
+			}
+		case r == '"':
+			inQuotes = true
+			token = append(token, r)
+		case r == ';':
+			inComment = true
+		case r == '(':
+			endToken()
+			parens++
+		case r == ')':
+			endToken()
+			if parens == 0 {
+				return lines, fmt.Errorf("line %d: unexpected closing parenthesis", num)
+			}
+			parens--
+		case r == ' ' || r == '\t' || r == '\r':
+			endToken()
+		default:
+			token = append(token, r)
+		}
+	}
+	if inQuotes {
+		return lines, fmt.Errorf("line %d: unterminated quoted string", num)
+	}
+	if parens > 0 {
+		return lines, fmt.Errorf("line %d: missing closing parenthesis", line.num)
+	}
+	endToken()
+	if len(line.tokens) > 0 {
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
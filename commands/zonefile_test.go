@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/template"
+)
+
+const testZonefile = `$TTL 1h ; default TTL
+@	IN	SOA	ns1.example.com. admin.example.com. (
+		2018010101 ; serial
+		7200       ; refresh
+		3600       ; retry
+		1209600    ; expire
+		3600 )     ; minimum
+	IN	NS	ns1.example.com.
+	IN	NS	ns2
+	IN	MX	10 mail
+	IN	MX	20 mail.backup.net.
+@	300	IN	A	192.0.2.1
+	IN	TXT	"v=spf1 mx -all"
+www	IN	CNAME	@
+mail	IN	A	192.0.2.10
+	IN	A	192.0.2.11
+dev	IN	NS	ns1.dev
+$ORIGIN services.example.com.
+_sip._tcp	86400	IN	SRV	10 60 5060 sip
+*	IN	HINFO	"PC" "Linux"
+`
+
+func TestParseZonefile(t *testing.T) {
+	zf, err := parseZonefile(testZonefile, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := zf.Origin, "services.example.com."; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	expected := []*zonefileRecord{
+		{Name: "example.com.", Type: "MX", TTL: 3600, Values: []string{"10 mail.example.com.", "20 mail.backup.net."}},
+		{Name: "example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}},
+		{Name: "example.com.", Type: "TXT", TTL: 3600, Values: []string{`"v=spf1 mx -all"`}},
+		{Name: "www.example.com.", Type: "CNAME", TTL: 3600, Values: []string{"example.com."}},
+		{Name: "mail.example.com.", Type: "A", TTL: 3600, Values: []string{"192.0.2.10", "192.0.2.11"}},
+		{Name: "dev.example.com.", Type: "NS", TTL: 3600, Values: []string{"ns1.dev.example.com."}},
+		{Name: "_sip._tcp.services.example.com.", Type: "SRV", TTL: 86400, Values: []string{"10 60 5060 sip.services.example.com."}},
+	}
+	if got, want := zf.Records, expected; !reflect.DeepEqual(got, want) {
+		for i := range got {
+			t.Logf("%d: %#v", i, got[i])
+		}
+		t.Fatalf("got %d records, want %d", len(got), len(want))
+	}
+	if got, want := len(zf.Skipped), 4; got != want {
+		t.Fatalf("got %d skipped (%v), want %d", got, zf.Skipped, want)
+	}
+	if got, want := zf.Skipped[0], "line 2: SOA record of example.com. (managed by Route53)"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := zf.Skipped[3], "line 20: HINFO record of *.services.example.com. (type not supported by Route53)"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestParseZonefileErrors(t *testing.T) {
+	tcases := []struct {
+		zonefile, origin, expErr string
+	}{
+		{zonefile: "www 300 IN A 192.0.2.1", expErr: "line 1: relative name 'www' without origin"},
+		{zonefile: "www IN A 192.0.2.1", origin: "example.com", expErr: "line 1: missing TTL"},
+		{zonefile: "$TTL 1x\n", expErr: "line 1: invalid TTL '1x'"},
+		{zonefile: "$TTL 300\n\n  IN A 192.0.2.1", origin: "example.com", expErr: "line 3: missing name"},
+		{zonefile: "$TTL 300\nwww IN MX mail", origin: "example.com", expErr: "line 2: MX record expecting 2 fields, got 1"},
+		{zonefile: "$TTL 300\nwww IN TXT \"unterminated\n", origin: "example.com", expErr: "line 2: unterminated quoted string"},
+		{zonefile: "$TTL 300\n@ IN SOA ns1 admin ( 1 2 3 4\n", origin: "example.com", expErr: "missing closing parenthesis"},
+		{zonefile: "$INCLUDE other.zone", expErr: "line 1: $INCLUDE directive not supported"},
+		{zonefile: "$TTL 300\nwww CH A 192.0.2.1", origin: "example.com", expErr: "line 2: class CH not supported"},
+	}
+	for i, tcase := range tcases {
+		_, err := parseZonefile(tcase.zonefile, tcase.origin)
+		if err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestParseZonefileTTL(t *testing.T) {
+	tcases := map[string]int64{"300": 300, "1h": 3600, "1H30M": 5400, "2d": 172800, "1w": 604800}
+	for in, exp := range tcases {
+		if got, err := parseZonefileTTL(in); err != nil || got != exp {
+			t.Fatalf("%s: got %d (%v), want %d", in, got, err, exp)
+		}
+	}
+	for _, in := range []string{"", "h", "1h3", "IN"} {
+		if _, err := parseZonefileTTL(in); err == nil {
+			t.Fatalf("%q: expected error", in)
+		}
+	}
+}
+
+func TestZonefileTemplateText(t *testing.T) {
+	zf := &zonefile{Origin: "example.com.", Records: []*zonefileRecord{
+		{Name: "example.com.", Type: "MX", TTL: 3600, Values: []string{"10 mail.example.com.", "20 mail.backup.net."}},
+		{Name: "example.com.", Type: "TXT", TTL: 300, Values: []string{`"v=spf1 mx -all"`}},
+		{Name: "*.example.com.", Type: "A", TTL: 300, Values: []string{"192.0.2.1"}},
+	}}
+
+	text, err := zf.templateText("Z1D633PJN98FT9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `create record zone=Z1D633PJN98FT9 name=example.com. type=MX ttl=3600 values=['10 mail.example.com.','20 mail.backup.net.']
+create record zone=Z1D633PJN98FT9 name=example.com. type=TXT ttl=300 value='"v=spf1 mx -all"'
+create record zone=Z1D633PJN98FT9 name=*.example.com. type=A ttl=300 value=192.0.2.1
+`
+	if got, want := text, exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	tpl, err := template.Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds := tpl.CommandNodesIterator()
+	if got, want := cmds[0].ToDriverParams()["values"], []interface{}{"10 mail.example.com.", "20 mail.backup.net."}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := cmds[1].ToDriverParams()["value"], `"v=spf1 mx -all"`; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	text, err = zf.templateText("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.SplitN(text, "\n", 3)[:2], []string{
+		"zone = create zone name=example.com callerreference=awless-import-{date.unix}",
+		"create record zone=$zone name=example.com. type=MX ttl=3600 values=['10 mail.example.com.','20 mail.backup.net.']",
+	}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if _, err = template.Parse(text); err != nil {
+		t.Fatal(err)
+	}
+
+	zf.Records[1].Values = []string{`"it's" "ok"`}
+	if _, err = zf.templateText("Z1D633PJN98FT9"); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"text/tabwriter"
 
 	"github.com/wallix/awless/aws/config"
+	"github.com/wallix/awless/aws/services"
 	"github.com/wallix/awless/aws/spec"
 	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/sync"
 )
 
 var (
@@ -26,31 +28,68 @@ const (
 	autosyncConfigKey              = "autosync"
 	checkUpgradeFrequencyConfigKey = "upgrade.checkfrequency"
 	schedulerURL                   = "scheduler.url"
+	lockedResourcesConfigKey       = "locked.resources"
+	graphStoreConfigKey            = "sync.store"
+	templateSourcesConfigKey       = "template.sources"
+	gcSnapshotsRetentionConfigKey  = "gc.snapshots.retention"
+	gcCacheRetentionConfigKey      = "gc.cache.retention"
+	gcTemplatesRetentionConfigKey  = "gc.templates.retention"
 	RegionConfigKey                = "aws.region"
 	ProfileConfigKey               = "aws.profile"
 
 	//Config prefix
 	awsCloudPrefix = "aws."
+	AliasPrefix    = "alias."
+	// DefaultsPrefix optionally prefixes template defaults (ex: defaults.instance.type)
+	DefaultsPrefix = "defaults."
 )
 
 var configDefinitions = map[string]*Definition{
-	autosyncConfigKey:              {help: "Automatically synchronize your cloud locally", defaultValue: "true", parseParamFn: parseBool},
-	RegionConfigKey:                {help: "AWS region", parseParamFn: awsconfig.ParseRegion, stdinParamProviderFn: awsconfig.StdinRegionSelector, onUpdateFns: []onUpdateFunc{runSyncWithUpdatedRegion}},
-	ProfileConfigKey:               {help: "AWS profile", defaultValue: "default"},
-	"aws.infra.sync":               {help: "Enable/disable sync of infra services (EC2, RDS, etc.) (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	"aws.access.sync":              {help: "Enable/disable sync of IAM service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	"aws.storage.sync":             {help: "Enable/disable sync of S3 service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	"aws.storage.s3object.sync":    {help: "Enable/disable sync of S3/s3object (when empty: true)", defaultValue: "false", parseParamFn: parseBool},
-	"aws.dns.sync":                 {help: "Enable/disable sync of DNS service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	"aws.dns.record.sync":          {help: "Enable/disable sync of DNS/record (when empty: true)", defaultValue: "false", parseParamFn: parseBool},
-	"aws.notification.sync":        {help: "Enable/disable sync of SNS service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	"aws.monitoring.sync":          {help: "Enable/disable sync of CloudWatch service (when empty: true)", defaultValue: "false", parseParamFn: parseBool},
-	"aws.lambda.sync":              {help: "Enable/disable sync of Lambda service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	"aws.messaging.sync":           {help: "Enable/disable sync of SQS/SNS service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	"aws.cdn.sync":                 {help: "Enable/disable sync of CloudFront service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	"aws.cloudformation.sync":      {help: "Enable/disable sync of CloudFormation service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
-	checkUpgradeFrequencyConfigKey: {help: "Upgrade check frequency (hours); a negative value disables check", defaultValue: "8", parseParamFn: parseInt},
-	schedulerURL:                   {help: "URL used by awless CLI to interact with pre-installed https://github.com/wallix/awless-scheduler", defaultValue: "http://localhost:8082"},
+	autosyncConfigKey:                {help: "Automatically synchronize your cloud locally", defaultValue: "true", parseParamFn: parseBool},
+	RegionConfigKey:                  {help: "AWS region", parseParamFn: awsconfig.ParseRegion, stdinParamProviderFn: awsconfig.StdinRegionSelector, onUpdateFns: []onUpdateFunc{runSyncWithUpdatedRegion}},
+	ProfileConfigKey:                 {help: "AWS profile", defaultValue: "default"},
+	"aws.infra.sync":                 {help: "Enable/disable sync of infra services (EC2, RDS, etc.) (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.access.sync":                {help: "Enable/disable sync of IAM service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.storage.sync":               {help: "Enable/disable sync of S3 service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.storage.s3object.sync":      {help: "Enable/disable sync of S3/s3object (when empty: true)", defaultValue: "false", parseParamFn: parseBool},
+	"aws.dns.sync":                   {help: "Enable/disable sync of DNS service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.dns.record.sync":            {help: "Enable/disable sync of DNS/record (when empty: true)", defaultValue: "false", parseParamFn: parseBool},
+	"aws.notification.sync":          {help: "Enable/disable sync of SNS service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.monitoring.sync":            {help: "Enable/disable sync of CloudWatch service (when empty: true)", defaultValue: "false", parseParamFn: parseBool},
+	"aws.lambda.sync":                {help: "Enable/disable sync of Lambda service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.messaging.sync":             {help: "Enable/disable sync of SQS/SNS service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.cdn.sync":                   {help: "Enable/disable sync of CloudFront service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.cloudformation.sync":        {help: "Enable/disable sync of CloudFormation service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.accounts.roles":             {help: "Roles ARNs (comma separated) assumed to list resources with --all-accounts (when empty: AWS Organizations accounts)"},
+	"aws.accounts.organization.role": {help: "Role assumed in AWS Organizations accounts to list resources with --all-accounts", defaultValue: "OrganizationAccountAccessRole"},
+	"aws.inventory.regions":          {help: "Regions (comma separated) fetched concurrently to list resources with --all-regions (when empty: all regions)"},
+	"aws.regions.offline":            {help: "Use the regions catalog embedded in awless instead of fetching regions and zones from EC2 (when empty: false)", defaultValue: "false", parseParamFn: parseBool},
+	"aws.regions.allowed":            {help: "Regions (comma separated, wildcards allowed, ex: eu-*) where your data may reside: the only ones recommended by `awless suggest region` (when empty: all regions)"},
+	"aws.audit.target":               {help: "Ship every run report (author, template hash, resources, outcome) to a CloudWatch Logs group (logs:<group>) or an S3 prefix (s3://<bucket>/<prefix>)", parseParamFn: parseAuditTarget},
+	"aws.reservations.target":        {help: "Claim the names and CIDR blocks created by runs in a DynamoDB table (dynamodb:<table>) or an S3 prefix (s3://<bucket>/<prefix>) shared by the users of the account, so that concurrent runs do not collide. Claims are owned by the IAM user or role of the caller on this awless installation", parseParamFn: parseReservationsTarget},
+	"aws.templates.log":              {help: "Store the log of the executed templates in a DynamoDB table (dynamodb:<table>) or an S3 prefix (s3://<bucket>/<prefix>) shared by the users of the account instead of locally (see `awless log` and `awless revert`, and `awless log --migrate` to move the local logs). Runs are serialized with a lock in a DynamoDB table only: S3 gives NO mutual exclusion between concurrent runs", parseParamFn: parseTemplatesLogTarget},
+	"aws.reservations.ttl":           {help: "Minutes during which the names and CIDR blocks claimed by a run stay reserved", defaultValue: "60", parseParamFn: parseInt},
+	checkUpgradeFrequencyConfigKey:   {help: "Upgrade check frequency (hours); a negative value disables check", defaultValue: "8", parseParamFn: parseInt},
+	lockedResourcesConfigKey:         {help: "Resources IDs or names (comma separated) that templates are not allowed to modify (as resources tagged awless:locked)"},
+	graphStoreConfigKey:              {help: "Storage of the synced graphs: rdf (versioned N-Triples files) or boltdb (faster with large infrastructures)", defaultValue: sync.RDFStore, parseParamFn: parseGraphStore},
+	templateSourcesConfigKey:         {help: "Directories or URLs serving a manifest.json (comma separated) searched by `awless template search`, along with https://github.com/wallix/awless-templates"},
+	gcSnapshotsRetentionConfigKey:    {help: "Days of synced graph snapshots kept by `awless gc` (the last one is always kept); 0 keeps all", defaultValue: "90", parseParamFn: parseInt},
+	gcCacheRetentionConfigKey:        {help: "Days after which cached files are removed by `awless gc`; 0 keeps all", defaultValue: "7", parseParamFn: parseInt},
+	gcTemplatesRetentionConfigKey:    {help: "Days of templates runs kept in the log by `awless gc` (older runs cannot be reverted); 0 keeps all", defaultValue: "0", parseParamFn: parseInt},
+	"confirm.backend":                {help: "Backend approving runs and reverts: terminal (ask on stdin), slack (wait for a reaction in a Slack channel), webhook (wait for the decision of an approval endpoint), policy (auto-approve allowed commands) or deny", defaultValue: "terminal", parseParamFn: parseConfirmBackend},
+	"confirm.policy.allow":           {help: "Commands (comma separated action.entity patterns, ex: create.*,start.instance) auto-approved with the policy backend"},
+	"confirm.policy.fallback":        {help: "Backend asked by the policy backend for the templates with commands not allowed: terminal, slack, webhook or deny", defaultValue: "terminal", parseParamFn: parseConfirmBackend},
+	"confirm.slack.token":            {help: "Slack bot token (needs chat:write and reactions:read scopes) of the slack confirmation backend"},
+	"confirm.slack.channel":          {help: "Slack channel ID where the slack confirmation backend posts approval requests"},
+	"confirm.slack.approvers":        {help: "Slack user IDs (comma separated) allowed to approve or reject with the slack backend (when empty: anyone in the channel)"},
+	"confirm.slack.timeout":          {help: "Minutes to wait for a Slack approval before failing", defaultValue: "15", parseParamFn: parseInt},
+	"confirm.webhook.url":            {help: "Approval endpoint of the webhook confirmation backend: it receives the template as JSON in a POST and answers with a status (approved, rejected or pending) and, while pending, the poll_url polled for the decision, on the same scheme and host"},
+	"confirm.webhook.token":          {help: "Bearer token authenticating the requests of the webhook confirmation backend (when empty: no authentication)"},
+	"confirm.webhook.timeout":        {help: "Minutes to wait for the decision of the approval endpoint before failing", defaultValue: "15", parseParamFn: parseInt},
+	"operator.sources":               {help: "Sources (comma separated, tried in order) resolving the human operator recorded in the runs log: env (AWLESS_OPERATOR variable), git (git config user.email) or aws (IAM user, or role session name as with AWS SSO) (when empty: no operator)", parseParamFn: parseOperatorSources},
+	"operator.tag":                   {help: "Tag key set to the operator on the EC2 resources created by runs (when empty: no tag)", defaultValue: "CreatedBy"},
+	"ssh.bastion":                    {help: "Instance name or ID hopped through by `awless ssh` and `awless scp` to reach the instances without public IP (when empty: the running instance tagged awless:bastion in their VPC)"},
+	schedulerURL:                     {help: "URL used by awless CLI to interact with pre-installed https://github.com/wallix/awless-scheduler", defaultValue: "http://localhost:8082"},
 }
 
 var defaultsDefinitions = map[string]*Definition{
@@ -65,6 +104,8 @@ var defaultsDefinitions = map[string]*Definition{
 	"database.type":          {defaultValue: "db.t2.micro", help: "Default RDS database type"},
 }
 
+var aliasDefinition = &Definition{help: "User-defined command alias ($1, $2, ... for positional args, $@ for all args)", parseParamFn: parseAlias}
+
 var deprecated = map[string]string{
 	"sync.auto": autosyncConfigKey,
 	"region":    RegionConfigKey,
@@ -137,6 +178,7 @@ func Set(key, value string) error {
 		databaseKey = defaultsDatabaseKey
 	}
 
+	key, _ = trimDefaultsPrefix(key)
 	if err := database.Execute(func(db *database.DB) error {
 		return db.SetConfig(databaseKey, key, v)
 	}); err != nil {
@@ -157,6 +199,7 @@ func SetProfileCallback(value string) error {
 }
 
 func Unset(key string) error {
+	key, _ = trimDefaultsPrefix(key)
 	var dbKey string
 	if _, ok := Config[key]; ok {
 		delete(Config, key)
@@ -178,6 +221,7 @@ func Unset(key string) error {
 }
 
 func Get(key string) (interface{}, bool) {
+	key, _ = trimDefaultsPrefix(key)
 	if v, ok := Config[key]; ok {
 		return v, ok
 	}
@@ -191,6 +235,7 @@ func SetVolatile(key, value string) error {
 }
 
 func InteractiveSet(key string) error {
+	key, _ = trimDefaultsPrefix(key)
 	var val string
 	if def, ok := configDefinitions[key]; ok && def.stdinParamProviderFn != nil {
 		val = def.stdinParamProviderFn()
@@ -228,6 +273,65 @@ func defaultParser(value string) (interface{}, error) {
 	return value, nil
 }
 
+func parseGraphStore(v string) (interface{}, error) {
+	for _, backend := range sync.StoreBackends {
+		if v == backend {
+			return v, nil
+		}
+	}
+	return v, fmt.Errorf("invalid value, expected one of %s, got '%s'", strings.Join(sync.StoreBackends, ", "), v)
+}
+
+func parseAlias(v string) (interface{}, error) {
+	if strings.TrimSpace(v) == "" {
+		return v, fmt.Errorf("invalid value, expected a command line (ex: run ./infra.aws env=dev)")
+	}
+	return v, nil
+}
+
+func parseAuditTarget(v string) (interface{}, error) {
+	if strings.TrimSpace(v) == "" {
+		return v, nil
+	}
+	_, err := awsservices.ParseAuditTarget(v)
+	return v, err
+}
+
+func parseTemplatesLogTarget(v string) (interface{}, error) {
+	if strings.TrimSpace(v) == "" {
+		return v, nil
+	}
+	_, err := awsservices.ParseTemplatesLogTarget(v)
+	return v, err
+}
+
+func parseReservationsTarget(v string) (interface{}, error) {
+	if strings.TrimSpace(v) == "" {
+		return v, nil
+	}
+	_, err := awsservices.ParseReservationsTarget(v)
+	return v, err
+}
+
+func parseConfirmBackend(v string) (interface{}, error) {
+	switch v {
+	case "terminal", "slack", "webhook", "policy", "deny":
+		return v, nil
+	}
+	return v, fmt.Errorf("invalid value, expected one of terminal, slack, webhook, policy, deny, got '%s'", v)
+}
+
+func parseOperatorSources(v string) (interface{}, error) {
+	for _, source := range strings.Split(v, ",") {
+		switch strings.TrimSpace(source) {
+		case "", "env", "git", "aws":
+		default:
+			return v, fmt.Errorf("invalid value, expected comma separated env, git, aws, got '%s'", source)
+		}
+	}
+	return v, nil
+}
+
 func parseDistroQuery(v string) (interface{}, error) {
 	_, err := awsspec.ParseImageQuery(v)
 	return v, err
@@ -244,19 +348,25 @@ func defaultStdinParamProvider() string {
 
 func setVolatile(key, value string) (interface{}, *Definition, bool, error) {
 	var isConf bool
+	key, isDefault := trimDefaultsPrefix(key)
 	confDef, confOk := configDefinitions[key]
 	defDef, defOk := defaultsDefinitions[key]
 	var def *Definition
 	switch {
 	case confOk && defOk:
 		return nil, def, isConf, fmt.Errorf("%s can not be in both config and defaults", key)
+	case confOk && isDefault:
+		return nil, def, isConf, fmt.Errorf("%s is a config parameter, not a template default", key)
 	case confOk:
 		isConf = true
 		def = confDef
 	case defOk:
 		def = defDef
+	case !isDefault && strings.HasPrefix(key, AliasPrefix):
+		isConf = true
+		def = aliasDefinition
 	default:
-		if strings.Contains(key, awsCloudPrefix) {
+		if !isDefault && strings.Contains(key, awsCloudPrefix) {
 			isConf = true
 		}
 	}
@@ -279,6 +389,15 @@ func setVolatile(key, value string) (interface{}, *Definition, bool, error) {
 	return v, def, isConf, nil
 }
 
+// trimDefaultsPrefix returns the key of a template default given with or without
+// the defaults prefix, and whether the prefix was given
+func trimDefaultsPrefix(key string) (string, bool) {
+	if strings.HasPrefix(key, DefaultsPrefix) {
+		return strings.TrimPrefix(key, DefaultsPrefix), true
+	}
+	return key, false
+}
+
 func displayConfig() string {
 	var b bytes.Buffer
 	b.WriteString("# Config parameters\n")
@@ -114,6 +114,24 @@ func TestDefaults(t *testing.T) {
 		}
 	})
 
+	t.Run("Set default with prefix", func(t *testing.T) {
+		if err := Set("defaults.instance.type", "t2.small"); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := Defaults["instance.type"], "t2.small"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if v, _ := Get("defaults.instance.type"); v != "t2.small" {
+			t.Fatalf("got %v, want %v", v, "t2.small")
+		}
+		if err := Set("defaults.aws.region", "eu-west-3"); err == nil {
+			t.Fatal("expect error when setting a config parameter as a default")
+		}
+		if err := Set("defaults.instance.type", "t2.nano"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
 	t.Run("Display whole config", func(t *testing.T) {
 		expect := `# Config parameters
    aws.region:     us-west-1   (string)   # AWS region
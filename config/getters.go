@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/wallix/awless/sync"
 )
 
 func GetAWSRegion() string {
@@ -45,6 +47,67 @@ func GetSchedulerURL() string {
 	return ""
 }
 
+func GetLockedResources() (locked []string) {
+	if s, ok := Config[lockedResourcesConfigKey].(string); ok {
+		for _, id := range strings.Split(s, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				locked = append(locked, id)
+			}
+		}
+	}
+	return
+}
+
+// GetAllowedRegions returns the region patterns of the data residency allow-list, empty when all regions are allowed
+func GetAllowedRegions() (allowed []string) {
+	if s, ok := Config["aws.regions.allowed"].(string); ok {
+		for _, r := range strings.Split(s, ",") {
+			if r = strings.TrimSpace(r); r != "" {
+				allowed = append(allowed, r)
+			}
+		}
+	}
+	return
+}
+
+func GetTemplateSources() (sources []string) {
+	if s, ok := Config[templateSourcesConfigKey].(string); ok {
+		for _, src := range strings.Split(s, ",") {
+			if src = strings.TrimSpace(src); src != "" {
+				sources = append(sources, src)
+			}
+		}
+	}
+	return
+}
+
+func GetGraphStore() string {
+	if s, ok := Config[graphStoreConfigKey].(string); ok && s != "" {
+		return s
+	}
+	return sync.RDFStore
+}
+
+// GetGCRetentions returns how long `awless gc` keeps the graph snapshots, the cached files
+// and the templates runs. A zero duration keeps all.
+func GetGCRetentions() (snapshots, cache, templates time.Duration) {
+	days := func(key string, def int) time.Duration {
+		if d, ok := Config[key].(int); ok {
+			def = d
+		}
+		if def < 0 {
+			def = 0
+		}
+		return time.Duration(def) * 24 * time.Hour
+	}
+	return days(gcSnapshotsRetentionConfigKey, 90), days(gcCacheRetentionConfigKey, 7), days(gcTemplatesRetentionConfigKey, 0)
+}
+
+func GetAlias(name string) (string, bool) {
+	alias, ok := Config[AliasPrefix+name].(string)
+	return alias, ok
+}
+
 func GetConfigWithPrefix(prefix string) map[string]interface{} {
 	conf := make(map[string]interface{})
 	for k, v := range Config {
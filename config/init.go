@@ -28,11 +28,12 @@ import (
 )
 
 var (
-	AwlessHome         = filepath.Join(os.Getenv("HOME"), ".awless")
-	DBPath             = filepath.Join(AwlessHome, database.Filename)
-	Dir                = filepath.Join(AwlessHome, "aws")
-	KeysDir            = filepath.Join(AwlessHome, "keys")
-	AwlessFirstInstall bool
+	AwlessHome          = filepath.Join(os.Getenv("HOME"), ".awless")
+	DBPath              = filepath.Join(AwlessHome, database.Filename)
+	Dir                 = filepath.Join(AwlessHome, "aws")
+	KeysDir             = filepath.Join(AwlessHome, "keys")
+	ExtraPropertiesPath = filepath.Join(AwlessHome, "properties.json")
+	AwlessFirstInstall  bool
 )
 
 func init() {
@@ -23,54 +23,73 @@ import (
 )
 
 var ColumnsInListing = map[string][]string{
-	cloud.Instance:            {properties.ID, properties.AvailabilityZone, properties.Name, properties.State, properties.Type, properties.PublicIP, properties.PrivateIP, properties.Launched, properties.KeyPair},
-	cloud.Vpc:                 {properties.ID, properties.Name, properties.Default, properties.State, properties.CIDR},
-	cloud.Subnet:              {properties.ID, properties.Name, properties.CIDR, properties.AvailabilityZone, properties.Default, properties.Vpc, properties.Public, properties.State},
-	cloud.SecurityGroup:       {properties.ID, properties.Vpc, properties.InboundRules, properties.OutboundRules, properties.Name, properties.Description},
-	cloud.InternetGateway:     {properties.ID, properties.Name, properties.Vpcs},
-	cloud.NatGateway:          {properties.ID, properties.State, properties.Vpc, properties.Subnet, properties.Created},
-	cloud.RouteTable:          {properties.ID, properties.Name, properties.Vpc, properties.Default, properties.Routes, properties.Associations},
-	cloud.Keypair:             {properties.ID, properties.Fingerprint},
-	cloud.Image:               {properties.ID, properties.Name, properties.State, properties.Location, properties.Public, properties.Type, properties.Created, properties.Architecture, properties.Hypervisor, properties.Virtualization},
-	cloud.ImportImageTask:     {properties.ID, properties.Description, properties.Image, properties.Progress, properties.State, properties.StateMessage},
-	cloud.Volume:              {properties.ID, properties.Name, properties.Type, properties.State, properties.Size, properties.Encrypted, properties.Created, properties.AvailabilityZone, properties.Instances},
-	cloud.AvailabilityZone:    {properties.Name, properties.State, properties.Region, properties.Messages},
-	cloud.ElasticIP:           {properties.ID, properties.PublicIP, properties.PrivateIP, properties.Association},
-	cloud.Snapshot:            {properties.ID, properties.Volume, properties.Encrypted, properties.Owner, properties.State, properties.Progress, properties.Created, properties.Size},
-	cloud.NetworkInterface:    {properties.ID, properties.Vpc, properties.Subnet, properties.State, properties.Instance, properties.PrivateIP, properties.PublicIP, properties.Description},
-	cloud.LoadBalancer:        {properties.Name, properties.Vpc, properties.State, properties.PublicDNS, properties.Created, properties.Scheme},
-	cloud.TargetGroup:         {properties.Name, properties.Vpc, properties.CheckHTTPCode, properties.Port, properties.Protocol, properties.CheckInterval, properties.CheckPath, properties.CheckPort, properties.CheckProtocol},
-	cloud.Listener:            {properties.ID, properties.AlarmActions, properties.LoadBalancer, properties.Port, properties.Protocol, properties.CipherSuite},
-	cloud.Database:            {properties.ID, properties.Name, properties.AvailabilityZone, properties.Class, properties.State, properties.Storage, properties.Port, properties.Username, properties.Public, properties.ReplicaOf, properties.Engine, properties.EngineVersion, properties.Created},
-	cloud.DbSubnetGroup:       {properties.ID, properties.State, properties.Vpc, properties.Subnets, properties.Description},
-	cloud.LaunchConfiguration: {properties.Name, properties.Type, properties.Created, properties.KeyPair},
-	cloud.ScalingGroup:        {properties.Name, properties.LaunchConfigurationName, properties.DesiredCapacity, properties.State, properties.Created, properties.NewInstancesProtected},
-	cloud.ScalingPolicy:       {properties.Name, properties.Type, properties.ScalingGroupName, properties.AlarmNames, properties.AdjustmentType, properties.ScalingAdjustment},
-	cloud.Repository:          {properties.Name, properties.URI, properties.Created, properties.Account, properties.Arn},
-	cloud.ContainerCluster:    {properties.Name, properties.State, properties.ActiveServicesCount, properties.PendingTasksCount, properties.RegisteredContainerInstancesCount, properties.RunningTasksCount},
-	cloud.ContainerTask:       {properties.Name, properties.Version, properties.State, properties.ContainersImages, properties.Deployments},
-	cloud.Container:           {properties.Name, properties.DeploymentName, properties.State, properties.Created, properties.Launched, properties.Stopped, properties.Cluster, properties.ContainerTask},
-	cloud.ContainerInstance:   {properties.ID, properties.Instance, properties.Cluster, properties.State, properties.RunningTasksCount, properties.PendingTasksCount, properties.Created, properties.AgentConnected},
-	cloud.Certificate:         {properties.Arn, properties.Name},
-	cloud.User:                {properties.ID, properties.Name, properties.PasswordLastUsed, properties.Created},
-	cloud.Role:                {properties.ID, properties.Name, properties.Created},
-	cloud.InstanceProfile:     {properties.ID, properties.Name, properties.Path, properties.Created},
-	cloud.Policy:              {properties.ID, properties.Name, properties.Type, properties.Created, properties.Updated, properties.Attached},
-	cloud.Group:               {properties.ID, properties.Name, properties.Created},
-	cloud.AccessKey:           {properties.ID, properties.State, properties.Username, properties.Created},
-	cloud.MFADevice:           {properties.ID, properties.AttachedAt},
-	cloud.Bucket:              {properties.ID, properties.Grants, properties.Created},
-	cloud.S3Object:            {properties.ID, properties.Bucket, properties.Modified, properties.Owner, properties.Size, properties.Class},
-	cloud.Subscription:        {properties.Arn, properties.Topic, properties.Endpoint, properties.Protocol, properties.Owner},
-	cloud.Topic:               {properties.ID},
-	cloud.Queue:               {properties.ID, properties.ApproximateMessageCount, properties.Created, properties.Modified, properties.Delay},
-	cloud.Zone:                {properties.ID, properties.Name, properties.Comment, properties.Private, properties.RecordCount, properties.CallerReference},
-	cloud.Record:              {properties.ID, properties.Type, properties.Name, properties.Records, properties.Alias, properties.TTL},
-	cloud.Function:            {properties.Name, properties.Size, properties.Memory, properties.Runtime, properties.Version, properties.Modified, properties.Description},
-	cloud.Metric:              {properties.ID, properties.Name, properties.Namespace, properties.Dimensions},
-	cloud.Alarm:               {properties.Name, properties.Namespace, properties.MetricName, properties.Description, properties.State, properties.Updated, properties.Dimensions},
-	cloud.Distribution:        {properties.ID, properties.PublicDNS, properties.Enabled, properties.State, properties.Modified, properties.Aliases, properties.SSLSupportMethod, properties.Origins},
-	cloud.Stack:               {properties.ID, properties.Name, properties.State, properties.Created, properties.Modified},
+	cloud.Instance:             {properties.ID, properties.AvailabilityZone, properties.Name, properties.State, properties.Type, properties.PublicIP, properties.PrivateIP, properties.Launched, properties.KeyPair},
+	cloud.Vpc:                  {properties.ID, properties.Name, properties.Default, properties.State, properties.CIDR},
+	cloud.Subnet:               {properties.ID, properties.Name, properties.CIDR, properties.AvailabilityZone, properties.Default, properties.Vpc, properties.Public, properties.State},
+	cloud.SecurityGroup:        {properties.ID, properties.Vpc, properties.InboundRules, properties.OutboundRules, properties.Name, properties.Description},
+	cloud.InternetGateway:      {properties.ID, properties.Name, properties.Vpcs},
+	cloud.NatGateway:           {properties.ID, properties.State, properties.Vpc, properties.Subnet, properties.Created},
+	cloud.RouteTable:           {properties.ID, properties.Name, properties.Vpc, properties.Default, properties.Routes, properties.Associations},
+	cloud.Keypair:              {properties.ID, properties.Fingerprint},
+	cloud.Image:                {properties.ID, properties.Name, properties.State, properties.Location, properties.Public, properties.Type, properties.Created, properties.Architecture, properties.Hypervisor, properties.Virtualization},
+	cloud.ImportImageTask:      {properties.ID, properties.Description, properties.Image, properties.Progress, properties.State, properties.StateMessage},
+	cloud.Volume:               {properties.ID, properties.Name, properties.Type, properties.State, properties.Size, properties.Encrypted, properties.Created, properties.AvailabilityZone, properties.Instances},
+	cloud.AvailabilityZone:     {properties.Name, properties.State, properties.Region, properties.Messages},
+	cloud.ElasticIP:            {properties.ID, properties.PublicIP, properties.PrivateIP, properties.Association},
+	cloud.Snapshot:             {properties.ID, properties.Volume, properties.Encrypted, properties.Owner, properties.State, properties.Progress, properties.Created, properties.Size},
+	cloud.NetworkInterface:     {properties.ID, properties.Vpc, properties.Subnet, properties.State, properties.Instance, properties.PrivateIP, properties.PublicIP, properties.Description},
+	cloud.SpotFleet:            {properties.ID, properties.State, properties.Type, properties.DesiredCapacity, properties.SpotPrice, properties.Role, properties.Created},
+	cloud.SpotRequest:          {properties.ID, properties.State, properties.Type, properties.Instance, properties.Image, properties.SpotPrice, properties.AvailabilityZone, properties.Created},
+	cloud.LoadBalancer:         {properties.Name, properties.Vpc, properties.State, properties.PublicDNS, properties.Created, properties.Scheme},
+	cloud.TargetGroup:          {properties.Name, properties.Vpc, properties.CheckHTTPCode, properties.Port, properties.Protocol, properties.CheckInterval, properties.CheckPath, properties.CheckPort, properties.CheckProtocol},
+	cloud.Listener:             {properties.ID, properties.AlarmActions, properties.LoadBalancer, properties.Port, properties.Protocol, properties.CipherSuite},
+	cloud.Database:             {properties.ID, properties.Name, properties.AvailabilityZone, properties.Class, properties.State, properties.Storage, properties.Port, properties.Username, properties.Public, properties.ReplicaOf, properties.Engine, properties.EngineVersion, properties.Created},
+	cloud.DbSubnetGroup:        {properties.ID, properties.State, properties.Vpc, properties.Subnets, properties.Description},
+	cloud.CacheCluster:         {properties.ID, properties.AvailabilityZone, properties.Class, properties.State, properties.Engine, properties.EngineVersion, properties.Size, properties.Endpoint, properties.Port, properties.Created},
+	cloud.ReplicationGroup:     {properties.ID, properties.Description, properties.Class, properties.State, properties.Failover, properties.Endpoint, properties.Port},
+	cloud.Table:                {properties.Name, properties.State, properties.HashKey, properties.RangeKey, properties.ReadCapacity, properties.WriteCapacity, properties.ItemCount, properties.Size, properties.Created},
+	cloud.TableIndex:           {properties.Name, properties.State, properties.HashKey, properties.RangeKey, properties.Projection, properties.ReadCapacity, properties.WriteCapacity, properties.ItemCount, properties.Size},
+	cloud.LaunchConfiguration:  {properties.Name, properties.Type, properties.Created, properties.KeyPair},
+	cloud.ScalingGroup:         {properties.Name, properties.LaunchConfigurationName, properties.DesiredCapacity, properties.State, properties.Created, properties.NewInstancesProtected},
+	cloud.ScalingPolicy:        {properties.Name, properties.Type, properties.ScalingGroupName, properties.AlarmNames, properties.AdjustmentType, properties.ScalingAdjustment},
+	cloud.Repository:           {properties.Name, properties.URI, properties.Created, properties.Account, properties.Arn},
+	cloud.ContainerCluster:     {properties.Name, properties.State, properties.ActiveServicesCount, properties.PendingTasksCount, properties.RegisteredContainerInstancesCount, properties.RunningTasksCount},
+	cloud.ContainerService:     {properties.Name, properties.Cluster, properties.ContainerTask, properties.LaunchType, properties.State, properties.DesiredCount, properties.RunningTasksCount, properties.Created},
+	cloud.ContainerTask:        {properties.Name, properties.Version, properties.State, properties.ContainersImages, properties.Deployments},
+	cloud.Container:            {properties.Name, properties.DeploymentName, properties.State, properties.Created, properties.Launched, properties.Stopped, properties.Cluster, properties.ContainerTask},
+	cloud.ContainerInstance:    {properties.ID, properties.Instance, properties.Cluster, properties.State, properties.RunningTasksCount, properties.PendingTasksCount, properties.Created, properties.AgentConnected},
+	cloud.Certificate:          {properties.Arn, properties.Name},
+	cloud.Key:                  {properties.ID, properties.Description, properties.State, properties.Type, properties.Created},
+	cloud.Alias:                {properties.Name, properties.Key, properties.Arn},
+	cloud.KeyGrant:             {properties.ID, properties.Name, properties.Key, properties.GranteePrincipal, properties.Operations, properties.Created},
+	cloud.User:                 {properties.ID, properties.Name, properties.PasswordLastUsed, properties.Created},
+	cloud.Role:                 {properties.ID, properties.Name, properties.Created},
+	cloud.InstanceProfile:      {properties.ID, properties.Name, properties.Path, properties.Created},
+	cloud.Policy:               {properties.ID, properties.Name, properties.Type, properties.Created, properties.Updated, properties.Attached},
+	cloud.Group:                {properties.ID, properties.Name, properties.Created},
+	cloud.AccessKey:            {properties.ID, properties.State, properties.Username, properties.Created},
+	cloud.MFADevice:            {properties.ID, properties.AttachedAt},
+	cloud.Bucket:               {properties.ID, properties.Grants, properties.Created},
+	cloud.S3Object:             {properties.ID, properties.Bucket, properties.Modified, properties.Owner, properties.Size, properties.Class},
+	cloud.Subscription:         {properties.Arn, properties.Topic, properties.Endpoint, properties.Protocol, properties.Owner},
+	cloud.Topic:                {properties.ID},
+	cloud.Queue:                {properties.ID, properties.ApproximateMessageCount, properties.Created, properties.Modified, properties.Delay},
+	cloud.Zone:                 {properties.ID, properties.Name, properties.Comment, properties.Private, properties.RecordCount, properties.CallerReference},
+	cloud.Record:               {properties.ID, properties.Type, properties.Name, properties.Records, properties.Alias, properties.TTL},
+	cloud.HealthCheck:          {properties.ID, properties.Type, properties.PublicIP, properties.PublicDNS, properties.Port, properties.CheckPath, properties.CheckInterval},
+	cloud.Function:             {properties.Name, properties.Size, properties.Memory, properties.Runtime, properties.Version, properties.Modified, properties.Description},
+	cloud.FunctionAlias:        {properties.Name, properties.Version, properties.Description, properties.Arn},
+	cloud.EventSourceMapping:   {properties.ID, properties.Source, properties.State, properties.StateMessage, properties.Modified},
+	cloud.StateMachine:         {properties.Name, properties.Arn, properties.Created},
+	cloud.Execution:            {properties.Name, properties.State, properties.Launched, properties.Stopped, properties.Arn},
+	cloud.RestApi:              {properties.ID, properties.Name, properties.Description, properties.Version, properties.Created},
+	cloud.ApiStage:             {properties.Name, properties.Deployment, properties.Description, properties.Created, properties.Modified},
+	cloud.ApiDeployment:        {properties.ID, properties.Description, properties.Created},
+	cloud.Metric:               {properties.ID, properties.Name, properties.Namespace, properties.Dimensions},
+	cloud.Alarm:                {properties.Name, properties.Namespace, properties.MetricName, properties.Description, properties.State, properties.Updated, properties.Dimensions},
+	cloud.Distribution:         {properties.ID, properties.PublicDNS, properties.Enabled, properties.State, properties.Modified, properties.Aliases, properties.SSLSupportMethod, properties.Origins},
+	cloud.OriginAccessIdentity: {properties.ID, properties.Comment, properties.CanonicalUser},
+	cloud.Stack:                {properties.ID, properties.Name, properties.State, properties.Created, properties.Modified},
 }
 
 var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
@@ -214,6 +233,29 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.PublicIP},
 		StringColumnDefinition{Prop: properties.Description},
 	},
+	cloud.SpotFleet: {
+		StringColumnDefinition{Prop: properties.ID},
+		ColoredValueColumnDefinition{
+			StringColumnDefinition: StringColumnDefinition{Prop: properties.State},
+			ColoredValues:          map[string]color.Attribute{"active": color.FgGreen, "cancelled_running": color.FgYellow, "cancelled_terminating": color.FgRed}},
+		StringColumnDefinition{Prop: properties.Type},
+		StringColumnDefinition{Prop: properties.DesiredCapacity, Friendly: "Capacity"},
+		StringColumnDefinition{Prop: properties.SpotPrice, Friendly: "Price"},
+		StringColumnDefinition{Prop: properties.Role},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
+	cloud.SpotRequest: {
+		StringColumnDefinition{Prop: properties.ID},
+		ColoredValueColumnDefinition{
+			StringColumnDefinition: StringColumnDefinition{Prop: properties.State},
+			ColoredValues:          map[string]color.Attribute{"active": color.FgGreen, "open": color.FgYellow, "failed": color.FgRed}},
+		StringColumnDefinition{Prop: properties.Type},
+		StringColumnDefinition{Prop: properties.Instance},
+		StringColumnDefinition{Prop: properties.Image},
+		StringColumnDefinition{Prop: properties.SpotPrice, Friendly: "Price"},
+		StringColumnDefinition{Prop: properties.AvailabilityZone, Friendly: "Zone"},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
 	// Loadbalancer
 	cloud.LoadBalancer: {
 		StringColumnDefinition{Prop: properties.Name},
@@ -270,6 +312,53 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.Subnets},
 		StringColumnDefinition{Prop: properties.Description},
 	},
+	cloud.CacheCluster: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.AvailabilityZone, Friendly: "Zone"},
+		StringColumnDefinition{Prop: properties.Class},
+		ColoredValueColumnDefinition{
+			StringColumnDefinition: StringColumnDefinition{Prop: properties.State},
+			ColoredValues:          map[string]color.Attribute{"available": color.FgGreen, "deleting": color.FgRed}},
+		StringColumnDefinition{Prop: properties.Engine},
+		StringColumnDefinition{Prop: properties.EngineVersion, Friendly: "Version"},
+		StringColumnDefinition{Prop: properties.Size, Friendly: "Nodes"},
+		StringColumnDefinition{Prop: properties.Endpoint},
+		StringColumnDefinition{Prop: properties.Port},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created, Friendly: "Created"}},
+	},
+	cloud.ReplicationGroup: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Description},
+		StringColumnDefinition{Prop: properties.Class},
+		ColoredValueColumnDefinition{
+			StringColumnDefinition: StringColumnDefinition{Prop: properties.State},
+			ColoredValues:          map[string]color.Attribute{"available": color.FgGreen, "deleting": color.FgRed}},
+		StringColumnDefinition{Prop: properties.Failover},
+		StringColumnDefinition{Prop: properties.Endpoint},
+		StringColumnDefinition{Prop: properties.Port},
+	},
+	cloud.Table: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.HashKey},
+		StringColumnDefinition{Prop: properties.RangeKey},
+		StringColumnDefinition{Prop: properties.ReadCapacity, Friendly: "Read"},
+		StringColumnDefinition{Prop: properties.WriteCapacity, Friendly: "Write"},
+		StringColumnDefinition{Prop: properties.ItemCount, Friendly: "Items"},
+		StorageColumnDefinition{Unit: b, StringColumnDefinition: StringColumnDefinition{Prop: properties.Size}},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
+	cloud.TableIndex: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.HashKey},
+		StringColumnDefinition{Prop: properties.RangeKey},
+		StringColumnDefinition{Prop: properties.Projection},
+		StringColumnDefinition{Prop: properties.ReadCapacity, Friendly: "Read"},
+		StringColumnDefinition{Prop: properties.WriteCapacity, Friendly: "Write"},
+		StringColumnDefinition{Prop: properties.ItemCount, Friendly: "Items"},
+		StorageColumnDefinition{Unit: b, StringColumnDefinition: StringColumnDefinition{Prop: properties.Size}},
+	},
 	//Autoscaling
 	cloud.LaunchConfiguration: {
 		StringColumnDefinition{Prop: properties.Name},
@@ -309,6 +398,16 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.RegisteredContainerInstancesCount, Friendly: "RegisteredContainerInstances"},
 		StringColumnDefinition{Prop: properties.RunningTasksCount, Friendly: "RunningTasks"},
 	},
+	cloud.ContainerService: {
+		StringColumnDefinition{Prop: properties.Name},
+		ARNLastValueColumnDefinition{Separator: "/", StringColumnDefinition: StringColumnDefinition{Prop: properties.Cluster}},
+		ARNLastValueColumnDefinition{Separator: "/", StringColumnDefinition: StringColumnDefinition{Prop: properties.ContainerTask}},
+		StringColumnDefinition{Prop: properties.LaunchType},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.DesiredCount, Friendly: "DesiredTasks"},
+		StringColumnDefinition{Prop: properties.RunningTasksCount, Friendly: "RunningTasks"},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
 	cloud.ContainerTask: {
 		StringColumnDefinition{Prop: properties.Name},
 		StringColumnDefinition{Prop: properties.Version},
@@ -341,6 +440,30 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.Arn},
 		StringColumnDefinition{Prop: properties.Name},
 	},
+	//KMS
+	cloud.Key: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Description},
+		ColoredValueColumnDefinition{
+			StringColumnDefinition: StringColumnDefinition{Prop: properties.State},
+			ColoredValues:          map[string]color.Attribute{"Enabled": color.FgGreen, "PendingDeletion": color.FgRed},
+		},
+		StringColumnDefinition{Prop: properties.Type},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
+	cloud.Alias: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Key},
+		StringColumnDefinition{Prop: properties.Arn},
+	},
+	cloud.KeyGrant: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Key},
+		StringColumnDefinition{Prop: properties.GranteePrincipal, Friendly: "Grantee"},
+		SliceColumnDefinition{StringColumnDefinition{Prop: properties.Operations}},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
 	//IAM
 	cloud.User: {
 		StringColumnDefinition{Prop: properties.ID},
@@ -434,6 +557,22 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		SliceColumnDefinition{StringColumnDefinition{Prop: properties.Records}},
 		StringColumnDefinition{Prop: properties.Alias},
 		StringColumnDefinition{Prop: properties.TTL},
+		StringColumnDefinition{Prop: properties.Set},
+		StringColumnDefinition{Prop: properties.Weight},
+		StringColumnDefinition{Prop: properties.Region},
+		StringColumnDefinition{Prop: properties.Failover},
+		StringColumnDefinition{Prop: properties.HealthCheck},
+	},
+	cloud.HealthCheck: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Type},
+		StringColumnDefinition{Prop: properties.PublicIP, Friendly: "IP"},
+		StringColumnDefinition{Prop: properties.PublicDNS, Friendly: "Domain"},
+		StringColumnDefinition{Prop: properties.Port},
+		StringColumnDefinition{Prop: properties.CheckPath, Friendly: "Path"},
+		StringColumnDefinition{Prop: properties.CheckInterval, Friendly: "Interval(s)"},
+		StringColumnDefinition{Prop: properties.UnhealthyThresholdCount, Friendly: "FailureThreshold"},
+		StringColumnDefinition{Prop: properties.CallerReference},
 	},
 	// Lamba
 	cloud.Function: {
@@ -445,6 +584,50 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Modified}},
 		StringColumnDefinition{Prop: properties.Description},
 	},
+	cloud.FunctionAlias: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Version},
+		StringColumnDefinition{Prop: properties.Description},
+		StringColumnDefinition{Prop: properties.Arn},
+	},
+	cloud.EventSourceMapping: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Source},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.StateMessage, Friendly: "Reason"},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Modified}},
+	},
+	cloud.StateMachine: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Arn},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
+	cloud.Execution: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.State},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Launched}},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Stopped}},
+		StringColumnDefinition{Prop: properties.Arn},
+	},
+	cloud.RestApi: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Description},
+		StringColumnDefinition{Prop: properties.Version},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
+	cloud.ApiStage: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Deployment},
+		StringColumnDefinition{Prop: properties.Description},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Modified}},
+	},
+	cloud.ApiDeployment: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Description},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
 	//Monitoring
 	cloud.Metric: {
 		StringColumnDefinition{Prop: properties.ID},
@@ -472,6 +655,11 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.SSLSupportMethod},
 		SliceColumnDefinition{StringColumnDefinition{Prop: properties.Origins}},
 	},
+	cloud.OriginAccessIdentity: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Comment},
+		StringColumnDefinition{Prop: properties.CanonicalUser},
+	},
 	//Cloudformation
 	cloud.Stack: {
 		StringColumnDefinition{Prop: properties.ID},
@@ -17,8 +17,7 @@ limitations under the License.
 package console
 
 import (
-	"bytes"
-	"encoding/json"
+	"encoding/csv"
 	"fmt"
 	"io"
 	"os"
@@ -37,6 +36,7 @@ import (
 var (
 	tableColWidth   = 30
 	autowrapMaxSize = 35
+	highlightFn     = color.New(color.FgYellow, color.Bold).SprintFunc()
 )
 
 type Displayer interface {
@@ -44,7 +44,7 @@ type Displayer interface {
 }
 
 type sorter interface {
-	sort(table)
+	sort([]cloud.Resource)
 	columns() []int
 	symbol() string
 }
@@ -54,15 +54,19 @@ type Builder struct {
 	tagFilters        []string
 	tagKeyFilters     []string
 	tagValueFilters   []string
+	query             string
+	relations         cloud.GraphAPI
 	columnDefinitions []ColumnDefinition
 	format            string
 	rdfType           string
-	sort              []int
+	sortBy            []string
 	reverseSort       bool
+	noTruncate        bool
 	maxwidth          int
 	dataSource        interface{}
 	root              cloud.Resource
 	noHeaders         bool
+	previous          interface{}
 }
 
 func (b *Builder) SetSource(i interface{}) *Builder {
@@ -74,7 +78,11 @@ func (b *Builder) buildQuery() (cloud.Query, error) {
 	var matchers []cloud.Matcher
 	for _, f := range b.filters {
 		splits := strings.SplitN(f, "=", 2)
-		if len(splits) == 2 {
+		if len(splits) == 2 && strings.HasPrefix(strings.ToLower(splits[0]), "tag:") {
+			matchers = append(matchers, match.Tag(strings.TrimSpace(splits[0][4:]), strings.TrimSpace(splits[1])))
+		} else if len(splits) == 2 && strings.HasPrefix(strings.ToLower(splits[0]), "annotation:") {
+			matchers = append(matchers, match.Annotation(strings.TrimSpace(splits[0][11:]), strings.TrimSpace(splits[1])))
+		} else if len(splits) == 2 {
 			name, val := strings.TrimSpace(strings.Title(splits[0])), strings.TrimSpace(splits[1])
 			key := ColumnDefinitions(b.columnDefinitions).resolveKey(name)
 
@@ -105,6 +113,20 @@ func (b *Builder) buildQuery() (cloud.Query, error) {
 	for _, v := range b.tagValueFilters {
 		matchers = append(matchers, match.TagValue(v))
 	}
+
+	if b.query != "" {
+		m, err := match.Parse(b.query)
+		if err != nil {
+			return cloud.Query{}, err
+		}
+		if and, ok := m.(interface {
+			Matchers() []cloud.Matcher
+		}); ok {
+			matchers = append(matchers, and.Matchers()...)
+		} else {
+			matchers = append(matchers, m)
+		}
+	}
 	q := cloud.NewQuery(b.rdfType)
 	if len(matchers) > 0 {
 		q = cloud.NewQuery(b.rdfType).Match(match.And(matchers...))
@@ -113,8 +135,16 @@ func (b *Builder) buildQuery() (cloud.Query, error) {
 	return q, nil
 }
 
+// Query returns the query built from the filters options
+func (b *Builder) Query() (cloud.Query, error) {
+	return b.buildQuery()
+}
+
 func (b *Builder) Build() (Displayer, error) {
-	base := fromGraphDisplayer{sorter: &defaultSorter{sortBy: b.sort, descending: b.reverseSort}, rdfType: b.rdfType, columnDefinitions: b.columnDefinitions, maxwidth: b.maxwidth, noHeaders: b.noHeaders}
+	base := fromGraphDisplayer{sorter: b.resourcesSorter(), rdfType: b.rdfType, columnDefinitions: b.columnDefinitions, maxwidth: b.maxwidth, noHeaders: b.noHeaders, noTruncate: b.noTruncate}
+	if previous, ok := b.previous.(cloud.GraphAPI); ok {
+		base.previous = previous
+	}
 
 	switch b.dataSource.(type) {
 	case cloud.GraphAPI:
@@ -125,8 +155,8 @@ func (b *Builder) Build() (Displayer, error) {
 				dis := &multiResourcesTableDisplayer{base}
 				dis.setGraph(gph)
 				return dis, nil
-			case "json":
-				dis := &multiResourcesJSONDisplayer{base}
+			case JSONFormat, YAMLFormat, JSONLinesFormat:
+				dis := &multiResourcesJSONDisplayer{fromGraphDisplayer: base, format: b.format}
 				dis.setGraph(gph)
 				return dis, nil
 			case "porcelain":
@@ -146,9 +176,16 @@ func (b *Builder) Build() (Displayer, error) {
 		if err != nil {
 			return nil, err
 		}
+		if gm, ok := q.Matcher.(cloud.GraphMatcher); ok && b.relations != nil {
+			q.Matcher = gm.InGraph(b.relations)
+		}
 		if filteredGraph, err = filteredGraph.FilterGraph(q); err != nil {
 			return nil, err
 		}
+		if err = b.resolveColumnProperties(filteredGraph); err != nil {
+			return nil, err
+		}
+		base.columnDefinitions, base.sorter = b.columnDefinitions, b.resourcesSorter()
 
 		switch b.format {
 		case "csv":
@@ -159,8 +196,8 @@ func (b *Builder) Build() (Displayer, error) {
 			dis := &tsvDisplayer{base}
 			dis.setGraph(filteredGraph)
 			return dis, nil
-		case "json":
-			dis := &jsonDisplayer{base}
+		case JSONFormat, YAMLFormat, JSONLinesFormat:
+			dis := &jsonDisplayer{fromGraphDisplayer: base, format: b.format}
 			dis.setGraph(filteredGraph)
 			return dis, nil
 		case "porcelain":
@@ -178,8 +215,14 @@ func (b *Builder) Build() (Displayer, error) {
 			return dis, nil
 		}
 	case cloud.Resource:
+		if IsStructuredFormat(b.format) {
+			return &structuredResourceDisplayer{r: b.dataSource.(cloud.Resource), format: b.format}, nil
+		}
 		dis := &tableResourceDisplayer{columnDefinitions: b.columnDefinitions, maxwidth: b.maxwidth}
 		dis.SetResource(b.dataSource.(cloud.Resource))
+		if previous, ok := b.previous.(cloud.Resource); ok {
+			dis.previous = previous
+		}
 		return dis, nil
 	case *graph.Diff:
 		base := fromDiffDisplayer{root: b.root}
@@ -208,7 +251,6 @@ type optsFn func(b *Builder) *Builder
 func BuildOptions(opts ...optsFn) *Builder {
 	b := &Builder{}
 
-	b.sort = []int{0}
 	b.format = "table"
 
 	for _, fn := range opts {
@@ -288,6 +330,23 @@ func WithTagValueFilters(fs []string) optsFn {
 	}
 }
 
+// WithQuery filters the resources with a query (see match.Parse)
+func WithQuery(query string) optsFn {
+	return func(b *Builder) *Builder {
+		b.query = query
+		return b
+	}
+}
+
+// WithRelationsGraph sets the graph in which the relations of the displayed resources are resolved
+// when filtering with a query on related resources (by default, the displayed graph)
+func WithRelationsGraph(g cloud.GraphAPI) optsFn {
+	return func(b *Builder) *Builder {
+		b.relations = g
+		return b
+	}
+}
+
 func WithIDsOnly(only bool) optsFn {
 	return func(b *Builder) *Builder {
 		if only {
@@ -302,15 +361,11 @@ func WithIDsOnly(only bool) optsFn {
 	}
 }
 
+// WithSortBy sorts the resources on columns (given by their title or property)
+// or on any other property of the resources, displayed or not
 func WithSortBy(sortingBy ...string) optsFn {
 	return func(b *Builder) *Builder {
-		indexes, err := resolveSortIndexes(b.columnDefinitions, sortingBy...)
-		if err != nil {
-			fmt.Fprint(os.Stderr, err, "\n")
-		}
-
-		b.sort = indexes
-
+		b.sortBy = sortingBy
 		return b
 	}
 }
@@ -350,6 +405,24 @@ func WithNoHeaders(nh bool) optsFn {
 	}
 }
 
+// WithNoTruncate displays all the columns and the full values in tables,
+// instead of hiding the columns beyond the max width and wrapping long values
+func WithNoTruncate(nt bool) optsFn {
+	return func(b *Builder) *Builder {
+		b.noTruncate = nt
+		return b
+	}
+}
+
+// WithPrevious highlights in tables the rows that changed since the previous source
+// (a graph or a resource, of the same kind as the displayed source)
+func WithPrevious(previous interface{}) optsFn {
+	return func(b *Builder) *Builder {
+		b.previous = previous
+		return b
+	}
+}
+
 type table [][]interface{}
 
 type fromGraphDisplayer struct {
@@ -359,59 +432,51 @@ type fromGraphDisplayer struct {
 	columnDefinitions []ColumnDefinition
 	maxwidth          int
 	noHeaders         bool
+	noTruncate        bool
+	previous          cloud.GraphAPI
 }
 
 func (d *fromGraphDisplayer) setGraph(g cloud.GraphAPI) {
 	d.g = g
 }
 
-type csvDisplayer struct {
-	fromGraphDisplayer
-}
-
-func (d *csvDisplayer) Print(w io.Writer) error {
-	resources, err := d.g.Find(cloud.NewQuery(d.rdfType))
+// changedResources returns the resources that are new or whose displayed
+// columns changed since the previous graph (none without previous graph)
+func (d *fromGraphDisplayer) changedResources(resources []cloud.Resource) (map[string]bool, error) {
+	changed := make(map[string]bool)
+	if d.previous == nil {
+		return changed, nil
+	}
+	previousResources, err := d.previous.Find(cloud.NewQuery(d.rdfType))
 	if err != nil {
-		return err
+		return changed, err
 	}
-
-	if len(d.columnDefinitions) == 0 {
-		return nil
+	previousByID := make(map[string]cloud.Resource)
+	for _, res := range previousResources {
+		previousByID[res.Id()] = res
 	}
-
-	values := make(table, len(resources))
-	for i, res := range resources {
-		if v := values[i]; v == nil {
-			values[i] = make([]interface{}, len(d.columnDefinitions))
+	for _, res := range resources {
+		prev, ok := previousByID[res.Id()]
+		if !ok {
+			changed[res.Id()] = true
+			continue
 		}
-		for j, h := range d.columnDefinitions {
-			values[i][j] = res.Properties()[h.propKey()]
+		for _, h := range d.columnDefinitions {
+			if h.format(res.Properties()[h.propKey()]) != h.format(prev.Properties()[h.propKey()]) {
+				changed[res.Id()] = true
+				break
+			}
 		}
 	}
+	return changed, nil
+}
 
-	d.sorter.sort(values)
-
-	var buff bytes.Buffer
-
-	var head []string
-	for _, h := range d.columnDefinitions {
-		head = append(head, h.title())
-	}
-
-	if !d.noHeaders {
-		buff.WriteString(strings.Join(head, ",") + "\n")
-	}
-
-	for i := range values {
-		var props []string
-		for j, h := range d.columnDefinitions {
-			props = append(props, h.format(values[i][j]))
-		}
-		buff.WriteString(strings.Join(props, ",") + "\n")
-	}
+type csvDisplayer struct {
+	fromGraphDisplayer
+}
 
-	_, err = w.Write(buff.Bytes())
-	return err
+func (d *csvDisplayer) Print(w io.Writer) error {
+	return d.export(w, ',')
 }
 
 type tsvDisplayer struct {
@@ -419,7 +484,15 @@ type tsvDisplayer struct {
 }
 
 func (d *tsvDisplayer) Print(w io.Writer) error {
-	color.NoColor = true // as default tabwriter does not play nice with the color library
+	return d.export(w, '\t')
+}
+
+// export writes the resources as delimited values for spreadsheets and inventory tools:
+// values are quoted when needed, uncolored, and dates are written as RFC3339 rather than humanized
+func (d *fromGraphDisplayer) export(w io.Writer, delimiter rune) error {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
 
 	resources, err := d.g.Find(cloud.NewQuery(d.rdfType))
 	if err != nil {
@@ -430,6 +503,7 @@ func (d *tsvDisplayer) Print(w io.Writer) error {
 		return nil
 	}
 
+	d.sorter.sort(resources)
 	values := make(table, len(resources))
 	for i, res := range resources {
 		if v := values[i]; v == nil {
@@ -440,30 +514,44 @@ func (d *tsvDisplayer) Print(w io.Writer) error {
 		}
 	}
 
-	d.sorter.sort(values)
-
-	var head []string
-	for _, h := range d.columnDefinitions {
-		head = append(head, h.title())
-	}
+	writer := csv.NewWriter(w)
+	writer.Comma = delimiter
 
 	if !d.noHeaders {
-		fmt.Fprintln(w, strings.Join(head, "\t"))
+		var head []string
+		for _, h := range d.columnDefinitions {
+			head = append(head, h.title())
+		}
+		if err := writer.Write(head); err != nil {
+			return err
+		}
 	}
 
 	for i := range values {
 		var props []string
 		for j, h := range d.columnDefinitions {
-			props = append(props, h.format(values[i][j]))
+			props = append(props, exportValue(h, values[i][j]))
+		}
+		if err := writer.Write(props); err != nil {
+			return err
 		}
-		fmt.Fprintln(w, strings.Join(props, "\t"))
 	}
 
-	return nil
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportValue(h ColumnDefinition, i interface{}) string {
+	if t, ok := i.(time.Time); ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return h.format(i)
 }
 
+// jsonDisplayer displays the resources in one of the structured formats (json, yaml, jsonl)
 type jsonDisplayer struct {
 	fromGraphDisplayer
+	format string
 }
 
 func (d *jsonDisplayer) Print(w io.Writer) error {
@@ -472,17 +560,14 @@ func (d *jsonDisplayer) Print(w io.Writer) error {
 		return err
 	}
 
-	sort.Slice(resources, func(i, j int) bool { return resources[i].Id() < resources[j].Id() })
+	d.sorter.sort(resources)
 
 	var props []map[string]interface{}
 	for _, res := range resources {
 		props = append(props, res.Properties())
 	}
 
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", " ")
-
-	return enc.Encode(props)
+	return EncodeStructured(w, d.format, props)
 }
 
 type tableDisplayer struct {
@@ -503,17 +588,25 @@ func (d *tableDisplayer) Print(w io.Writer) error {
 		return nil
 	}
 
+	changed, err := d.changedResources(resources)
+	if err != nil {
+		return err
+	}
+
+	d.sorter.sort(resources)
+
+	// the last value of each line tells whether the resource changed
 	values := make(table, len(resources))
 	for i, res := range resources {
 		if v := values[i]; v == nil {
-			values[i] = make([]interface{}, len(d.columnDefinitions))
+			values[i] = make([]interface{}, len(d.columnDefinitions)+1)
 		}
 		for j, h := range d.columnDefinitions {
 			values[i][j] = res.Properties()[h.propKey()]
 		}
+		values[i][len(d.columnDefinitions)] = changed[res.Id()]
 	}
 
-	d.sorter.sort(values)
 	markColumnAsc := -1
 	if len(d.sorter.columns()) > 0 {
 		markColumnAsc = d.sorter.columns()[0]
@@ -521,7 +614,7 @@ func (d *tableDisplayer) Print(w io.Writer) error {
 
 	columnsToDisplay := d.columnDefinitions
 	maxWidthNoWraping := 1
-	if d.maxwidth != 0 {
+	if d.maxwidth != 0 && !d.noTruncate {
 		columnsToDisplay = []ColumnDefinition{}
 		currentWidth := 1 // first border
 		for j, h := range d.columnDefinitions {
@@ -544,6 +637,7 @@ func (d *tableDisplayer) Print(w io.Writer) error {
 	table.SetCenterSeparator("|")
 	table.SetAlignment(tablewriter.ALIGN_LEFT)
 	table.SetColWidth(tableColWidth)
+	table.SetAutoWrapText(!d.noTruncate)
 	if !d.noHeaders {
 		var displayHeaders []string
 		for i, h := range columnsToDisplay {
@@ -557,7 +651,7 @@ func (d *tableDisplayer) Print(w io.Writer) error {
 	}
 
 	var enableWraping bool
-	if d.maxwidth <= maxWidthNoWraping {
+	if d.maxwidth <= maxWidthNoWraping && !d.noTruncate {
 		enableWraping = true
 	}
 
@@ -567,11 +661,12 @@ func (d *tableDisplayer) Print(w io.Writer) error {
 		for j, h := range columnsToDisplay {
 			val := h.format(values[i][j])
 			if enableWraping {
-				props = append(props, wraper.Wrap(val))
-			} else {
-				props = append(props, val)
+				val = wraper.Wrap(val)
 			}
-
+			if values[i][len(d.columnDefinitions)] == true {
+				val = highlight(val)
+			}
+			props = append(props, val)
 		}
 		table.Append(props)
 	}
@@ -591,6 +686,15 @@ func (d *tableDisplayer) Print(w io.Writer) error {
 	return nil
 }
 
+// highlight colors each line of a cell so that the color does not leak onto the table borders
+func highlight(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = highlightFn(l)
+	}
+	return strings.Join(lines, "\n")
+}
+
 type porcelainDisplayer struct {
 	fromGraphDisplayer
 }
@@ -606,24 +710,25 @@ func (d *porcelainDisplayer) Print(w io.Writer) error {
 		types = append(types, d.rdfType)
 	}
 
-	var values table
+	var all []cloud.Resource
 	for _, t := range types {
 		resources, err := d.g.Find(cloud.NewQuery(t))
 		if err != nil {
 			return err
 		}
+		all = append(all, resources...)
+	}
 
-		for _, res := range resources {
-			var row = make([]interface{}, len(d.columnDefinitions))
-			for j, h := range d.columnDefinitions {
-				row[j] = res.Properties()[h.propKey()]
-			}
-			values = append(values, row)
+	d.sorter.sort(all)
+	var values table
+	for _, res := range all {
+		var row = make([]interface{}, len(d.columnDefinitions))
+		for j, h := range d.columnDefinitions {
+			row[j] = res.Properties()[h.propKey()]
 		}
+		values = append(values, row)
 	}
 
-	d.sorter.sort(values)
-
 	var lines []string
 
 	for i := range values {
@@ -704,6 +809,7 @@ func (d *multiResourcesTableDisplayer) Print(w io.Writer) error {
 
 type multiResourcesJSONDisplayer struct {
 	fromGraphDisplayer
+	format string
 }
 
 func (d *multiResourcesJSONDisplayer) Print(w io.Writer) error {
@@ -711,7 +817,13 @@ func (d *multiResourcesJSONDisplayer) Print(w io.Writer) error {
 	var err error
 
 	all := make(map[string]interface{})
+	var lines []map[string]interface{}
+	var types []string
 	for t := range DefaultsColumnDefinitions {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
 		resources, err = d.g.Find(cloud.NewQuery(t))
 		if err != nil {
 			return err
@@ -722,13 +834,15 @@ func (d *multiResourcesJSONDisplayer) Print(w io.Writer) error {
 		}
 		if len(resources) > 0 {
 			all[cloud.PluralizeResource(t)] = props
+			lines = append(lines, props...)
 		}
 	}
 
-	enc := json.NewEncoder(w)
-	enc.SetIndent("", " ")
-
-	return enc.Encode(all)
+	// JSON lines give each resource on its own line, as when listing resources of a given type
+	if d.format == JSONLinesFormat {
+		return EncodeStructured(w, d.format, lines)
+	}
+	return EncodeStructured(w, d.format, all)
 }
 
 type fromDiffDisplayer struct {
@@ -932,8 +1046,121 @@ func (d *defaultSorter) symbol() string {
 	return " ▲"
 }
 
+// resourcesSorter sorts resources on their properties, displayed or not, so that all
+// formats list them in the same order. Resources with equal properties are sorted by id.
+type resourcesSorter struct {
+	props      []string
+	descending bool
+	displayed  []int
+}
+
+func (s *resourcesSorter) sort(resources []cloud.Resource) {
+	sort.SliceStable(resources, func(i, j int) bool {
+		if s.descending {
+			i, j = j, i
+		}
+		for _, prop := range s.props {
+			a, b := propertyValue(resources[i], prop), propertyValue(resources[j], prop)
+			if reflect.DeepEqual(a, b) {
+				continue
+			}
+			return valueLowerOrEqual(a, b)
+		}
+		return resources[i].Id() < resources[j].Id()
+	})
+}
+
+func (s *resourcesSorter) columns() []int {
+	return s.displayed
+}
+
+func (s *resourcesSorter) symbol() string {
+	if s.descending {
+		return " ▼"
+	}
+	return " ▲"
+}
+
+// resourcesSorter resolves the sort options to the properties of the resources:
+// titles or properties of the columns, then any property (ex: --sort launchtime)
+func (b *Builder) resourcesSorter() *resourcesSorter {
+	sortBy := b.sortBy
+	if len(sortBy) == 0 {
+		sortBy = []string{"id"}
+	}
+	s := &resourcesSorter{descending: b.reverseSort}
+	for _, name := range sortBy {
+		prop := ColumnDefinitions(b.columnDefinitions).resolveKey(name)
+		if prop == "" {
+			prop = ColumnDefinitions(DefaultsColumnDefinitions[b.rdfType]).resolveKey(name)
+		}
+		if prop == "" && strings.ToLower(name) == "id" {
+			prop = "ID"
+		}
+		if prop == "" {
+			prop = name
+		}
+		s.props = append(s.props, prop)
+		for i, h := range b.columnDefinitions {
+			if h.propKey() == prop {
+				s.displayed = append(s.displayed, i)
+			}
+		}
+	}
+	return s
+}
+
+// resolveColumnProperties matches the columns that are not known properties of the resource type
+// with the properties of the resources, case insensitively (ex: --columns launchtime), and
+// warns about the sort properties that none of the resources have
+func (b *Builder) resolveColumnProperties(g cloud.GraphAPI) error {
+	if b.rdfType == "" {
+		return nil
+	}
+	resources, err := g.Find(cloud.NewQuery(b.rdfType))
+	if err != nil {
+		return err
+	}
+	props := make(map[string]string)
+	for _, res := range resources {
+		for k := range res.Properties() {
+			props[strings.ToLower(k)] = k
+		}
+	}
+	for i, h := range b.columnDefinitions {
+		if def, ok := h.(StringColumnDefinition); ok {
+			if prop, ok := props[strings.ToLower(def.Prop)]; ok && prop != def.Prop {
+				def.Prop = prop
+				b.columnDefinitions[i] = def
+			}
+		}
+	}
+	for _, name := range b.sortBy {
+		if ColumnDefinitions(b.columnDefinitions).resolveKey(name) != "" || strings.ToLower(name) == "id" {
+			continue
+		}
+		if _, ok := props[strings.ToLower(name)]; !ok && len(resources) > 0 {
+			fmt.Fprintf(os.Stderr, "Invalid sort property '%s'\n", name)
+		}
+	}
+	return nil
+}
+
+// propertyValue returns the value of the property, matching its name case insensitively if needed
+func propertyValue(res cloud.Resource, prop string) interface{} {
+	if v, ok := res.Properties()[prop]; ok {
+		return v
+	}
+	for k, v := range res.Properties() {
+		if strings.EqualFold(k, prop) {
+			return v
+		}
+	}
+	return nil
+}
+
 func valueLowerOrEqual(a, b interface{}) bool {
-	if a == b {
+	if reflect.DeepEqual(a, b) {
 		return true
 	}
 	if a == nil {
@@ -943,17 +1170,23 @@ func valueLowerOrEqual(a, b interface{}) bool {
 		return false
 	}
 	if reflect.TypeOf(a) != reflect.TypeOf(b) {
-		panic(fmt.Sprintf("can not compare values of type %T and %T", a, b))
+		return fmt.Sprint(a) <= fmt.Sprint(b)
 	}
 	switch a.(type) {
 	case int:
 		aa := a.(int)
 		bb := b.(int)
 		return aa <= bb
+	case int64:
+		aa := a.(int64)
+		bb := b.(int64)
+		return aa <= bb
 	case float64:
 		aa := a.(float64)
 		bb := b.(float64)
 		return aa <= bb
+	case bool:
+		return !a.(bool) || b.(bool)
 	case string:
 		aa := a.(string)
 		bb := b.(string)
@@ -963,31 +1196,9 @@ func valueLowerOrEqual(a, b interface{}) bool {
 		bb := b.(time.Time)
 		return aa.After(bb)
 	default:
-		panic(fmt.Sprintf("can not compare values of type %T", a))
-	}
-}
-
-func resolveSortIndexes(headers []ColumnDefinition, sortingBy ...string) ([]int, error) {
-	sortBy := []string{"id"}
-	if len(sortingBy) > 0 {
-		sortBy = sortingBy
-	}
-
-	normalized := make(map[string]int)
-	for i, h := range headers {
-		normalized[strings.ToLower(h.title())] = i
+		// sorting on any property, other values are compared as displayed
+		return fmt.Sprint(a) <= fmt.Sprint(b)
 	}
-
-	var ids []int
-	for _, t := range sortBy {
-		id, ok := normalized[strings.ToLower(t)]
-		if !ok && strings.ToLower(t) != "id" {
-			return ids, fmt.Errorf("Invalid column name '%s'", t)
-		}
-		ids = append(ids, id)
-	}
-
-	return ids, nil
 }
 
 func colWidth(j int, t table, h ColumnDefinition, sortSymbol string) int {
@@ -18,6 +18,8 @@ package console
 
 import (
 	"bytes"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -82,6 +84,76 @@ func TestSorting(t *testing.T) {
 	})
 }
 
+func TestColumnsAndSortOnAnyProperty(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_1").Prop(p.ID, "inst_1").Prop(p.Name, "redis").Prop(p.Type, "t2.micro").Prop(p.Hypervisor, "xen").Build(),
+		resourcetest.Instance("inst_2").Prop(p.ID, "inst_2").Prop(p.Name, "django").Prop(p.Type, "t2.medium").Prop(p.Hypervisor, "nitro").Build(),
+		resourcetest.Instance("inst_3").Prop(p.ID, "inst_3").Prop(p.Name, "apache").Prop(p.Type, "t2.xlarge").Prop(p.Hypervisor, "nitro").Build(),
+	)
+
+	tcases := []struct {
+		columns []string
+		sortBy  []string
+		reverse bool
+		format  string
+		expect  string
+	}{
+		{columns: []string{"id", "HYPERVISOR"}, format: "csv", expect: "ID,Hypervisor\ninst_1,xen\ninst_2,nitro\ninst_3,nitro\n"},
+		{columns: []string{"id", "name"}, sortBy: []string{"type"}, format: "csv", expect: "ID,Name\ninst_2,django\ninst_1,redis\ninst_3,apache\n"},
+		{columns: []string{"id", "name"}, sortBy: []string{"hypervisor", "name"}, format: "csv", expect: "ID,Name\ninst_3,apache\ninst_2,django\ninst_1,redis\n"},
+		{columns: []string{"id", "name"}, sortBy: []string{"HYPERVISOR"}, reverse: true, format: "csv", expect: "ID,Name\ninst_1,redis\ninst_3,apache\ninst_2,django\n"},
+		{columns: []string{"id", "name"}, sortBy: []string{"type"}, format: "porcelain", expect: "inst_2\ndjango\ninst_1\nredis\ninst_3\napache"},
+		{columns: []string{"id", "name"}, sortBy: []string{"type"}, format: "table", expect: "|   ID   |  NAME  |\n|--------|--------|\n| inst_2 | django |\n| inst_1 | redis  |\n| inst_3 | apache |\n"},
+		{columns: []string{"id", "name"}, sortBy: []string{"name"}, format: "table", expect: "|   ID   | NAME ▲ |\n|--------|--------|\n| inst_3 | apache |\n| inst_2 | django |\n| inst_1 | redis  |\n"},
+	}
+	for i, tcase := range tcases {
+		displayer, err := BuildOptions(
+			WithRdfType("instance"),
+			WithColumns(tcase.columns),
+			WithSortBy(tcase.sortBy...),
+			WithReverseSort(tcase.reverse),
+			WithFormat(tcase.format),
+		).SetSource(g).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var w bytes.Buffer
+		if err := displayer.Print(&w); err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := w.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%q\nwant\n%q", i+1, got, want)
+		}
+	}
+}
+
+func TestNoTruncate(t *testing.T) {
+	g := createInfraGraph()
+	columns := []string{"ID", "Name", "State", "Type", "PublicIP"}
+
+	displayer, _ := BuildOptions(
+		WithRdfType("instance"),
+		WithColumns(columns),
+		WithMaxWidth(20),
+		WithNoTruncate(true),
+	).SetSource(g).Build()
+
+	expected := `|  ID ▲  |  NAME  |  STATE  |   TYPE    | PUBLIC IP |
+|--------|--------|---------|-----------|-----------|
+| inst_1 | redis  | running | t2.micro  | 1.2.3.4   |
+| inst_2 | django | stopped | t2.medium |           |
+| inst_3 | apache | running | t2.xlarge |           |
+`
+	var w bytes.Buffer
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.String(), expected; got != want {
+		t.Fatalf("got \n%s\n\nwant\n\n%s\n", got, want)
+	}
+}
+
 func TestJSONDisplays(t *testing.T) {
 	g := createInfraGraph()
 	var w bytes.Buffer
@@ -448,6 +520,49 @@ func TestDateLists(t *testing.T) {
 	}
 }
 
+func TestExportDisplays(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_1").Prop(p.Name, "redis, cache").Prop(p.Type, "t2.micro").Prop(p.State, "running").Prop(p.Launched, time.Unix(1482405203, 0).UTC()).Build(),
+		resourcetest.Instance("inst_2").Prop(p.Name, `web "front"`).Prop(p.Type, "t2.medium").Prop(p.State, "stopped").Build(),
+	)
+	columns := []string{"id", "name", "type", "state", "launched"}
+
+	displayer, _ := BuildOptions(
+		WithRdfType("instance"),
+		WithColumns(columns),
+		WithFormat("csv"),
+	).SetSource(g).Build()
+
+	expected := "ID,Name,Type,State,Uptime\n" +
+		"inst_1,\"redis, cache\",t2.micro,running,2016-12-22T11:13:23Z\n" +
+		"inst_2,\"web \"\"front\"\"\",t2.medium,stopped,\n"
+	var w bytes.Buffer
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.String(), expected; got != want {
+		t.Fatalf("got \n%q\n\nwant\n\n%q\n", got, want)
+	}
+
+	displayer, _ = BuildOptions(
+		WithRdfType("instance"),
+		WithColumns(columns),
+		WithFormat("tsv"),
+	).SetSource(g).Build()
+
+	expected = "ID\tName\tType\tState\tUptime\n" +
+		"inst_1\tredis, cache\tt2.micro\trunning\t2016-12-22T11:13:23Z\n" +
+		"inst_2\t\"web \"\"front\"\"\"\tt2.medium\tstopped\t\n"
+	w.Reset()
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.String(), expected; got != want {
+		t.Fatalf("got \n%q\n\nwant\n\n%q\n", got, want)
+	}
+}
+
 func TestMaxWidth(t *testing.T) {
 	g := createInfraGraph()
 	columns := []string{"ID", "Name", "State", "Type", "PublicIP"}
@@ -655,6 +770,46 @@ func TestFilter(t *testing.T) {
 		}
 		compareJSON(t, w.String(), expected)
 	})
+	t.Run("Filter tag", func(t *testing.T) {
+		tagged := graph.NewGraph()
+		tagged.AddResource(
+			resourcetest.Subnet("sub_1").Prop(p.Tags, []string{"Env=dev"}).Build(),
+			resourcetest.Subnet("sub_2").Prop(p.Tags, []string{"Env=prod"}).Build(),
+		)
+		var w bytes.Buffer
+		displayer, _ := BuildOptions(
+			WithRdfType("subnet"),
+			WithFormat("json"),
+			WithFilters([]string{"tag:Env=prod"}),
+		).SetSource(tagged).Build()
+		expected := `[{"ID":"sub_2","Tags":["Env=prod"]}]`
+		if err := displayer.Print(&w); err != nil {
+			t.Fatal(err)
+		}
+		compareJSON(t, w.String(), expected)
+	})
+	t.Run("Filter query", func(t *testing.T) {
+		var w bytes.Buffer
+		displayer, err := BuildOptions(
+			WithRdfType("subnet"),
+			WithFormat("json"),
+			WithFilters([]string{"Vpc=vpc_1"}),
+			WithQuery("public=false OR name!~^my_"),
+		).SetSource(g).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := `[{"ID":"sub_3","Public":false,"Name":"my_subnet","Vpc":"vpc_1"}]`
+		if err := displayer.Print(&w); err != nil {
+			t.Fatal(err)
+		}
+		compareJSON(t, w.String(), expected)
+	})
+	t.Run("Filter invalid query", func(t *testing.T) {
+		if _, err := BuildOptions(WithRdfType("subnet"), WithQuery("public=false OR")).SetSource(g).Build(); err == nil {
+			t.Fatal("expected error")
+		}
+	})
 }
 
 func TestCompareInterface(t *testing.T) {
@@ -900,3 +1055,59 @@ func TestNoHeadersDisplay(t *testing.T) {
 		t.Fatalf("got \n%s\n\nwant\n\n%s\n", got, want)
 	}
 }
+
+func TestHighlightChangedRows(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+
+	previous := graph.NewGraph()
+	previous.AddResource(
+		resourcetest.Instance("inst_1").Prop(p.ID, "inst_1").Prop(p.State, "running").Build(),
+		resourcetest.Instance("inst_2").Prop(p.ID, "inst_2").Prop(p.State, "pending").Build(),
+	)
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_1").Prop(p.ID, "inst_1").Prop(p.State, "running").Build(),
+		resourcetest.Instance("inst_2").Prop(p.ID, "inst_2").Prop(p.State, "running").Build(),
+		resourcetest.Instance("inst_3").Prop(p.ID, "inst_3").Prop(p.State, "pending").Build(),
+	)
+	columnDefs := []ColumnDefinition{
+		StringColumnDefinition{Prop: p.ID},
+		StringColumnDefinition{Prop: p.State},
+	}
+
+	displayer, _ := BuildOptions(
+		WithRdfType("instance"),
+		WithColumnDefinitions(columnDefs),
+		WithPrevious(previous),
+	).SetSource(g).Build()
+
+	var w bytes.Buffer
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	highlighted := make(map[string]bool)
+	for _, line := range strings.Split(w.String(), "\n") {
+		for _, id := range []string{"inst_1", "inst_2", "inst_3"} {
+			if strings.Contains(line, id) {
+				highlighted[id] = strings.Contains(line, "\x1b[")
+			}
+		}
+	}
+	if got, want := highlighted, map[string]bool{"inst_1": false, "inst_2": true, "inst_3": true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	displayer, _ = BuildOptions(
+		WithRdfType("instance"),
+		WithColumnDefinitions(columnDefs),
+	).SetSource(g).Build()
+
+	w.Reset()
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(w.String(), "\x1b[") {
+		t.Fatalf("got highlighted rows without previous graph:\n%s", w.String())
+	}
+}
@@ -80,6 +80,11 @@ func HumanizeStorage(nb uint64, unit storageUnit) string {
 	}
 }
 
+// HumanizeBytes returns the storage size of a number of bytes (ex: ~12M)
+func HumanizeBytes(nb uint64) string {
+	return HumanizeStorage(nb, b)
+}
+
 func divideValue(from, by uint64) string {
 	res := from / by
 	if from%by != 0 {
@@ -87,3 +92,18 @@ func divideValue(from, by uint64) string {
 	}
 	return fmt.Sprint(res)
 }
+
+func HumanizeCount(nb int) string {
+	if nb < 0 {
+		return "-" + HumanizeCount(-nb)
+	}
+	str := fmt.Sprint(nb)
+	var buf []byte
+	for i := range str {
+		if i > 0 && (len(str)-i)%3 == 0 {
+			buf = append(buf, ',')
+		}
+		buf = append(buf, str[i])
+	}
+	return string(buf)
+}
@@ -70,3 +70,23 @@ func TestHumanizeStorage(t *testing.T) {
 		}
 	}
 }
+
+func TestHumanizeCount(t *testing.T) {
+	tcases := []struct {
+		from   int
+		expect string
+	}{
+		{from: 0, expect: "0"},
+		{from: 999, expect: "999"},
+		{from: 3200, expect: "3,200"},
+		{from: 123456, expect: "123,456"},
+		{from: 1234567, expect: "1,234,567"},
+		{from: -3200, expect: "-3,200"},
+	}
+
+	for _, tcase := range tcases {
+		if got, want := HumanizeCount(tcase.from), tcase.expect; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	}
+}
@@ -27,6 +27,7 @@ import (
 type tableResourceDisplayer struct {
 	maxwidth          int
 	r                 cloud.Resource
+	previous          cloud.Resource
 	columnDefinitions []ColumnDefinition
 }
 
@@ -36,26 +37,32 @@ func (d *tableResourceDisplayer) Print(w io.Writer) error {
 	i := 0
 	propertyNameMaxWith := 13
 	for prop, val := range d.r.Properties() {
-		var header ColumnDefinition
-		for _, h := range d.columnDefinitions {
-			if h.propKey() == prop {
-				header = h
-			}
-		}
-		if header == nil {
-			header = &StringColumnDefinition{Prop: prop}
-		}
+		header := d.columnDefinition(prop)
 
 		if v := values[i]; v == nil {
-			values[i] = make([]interface{}, 2)
+			values[i] = make([]interface{}, 3)
 		}
 		values[i][0] = header.title()
 		if l := len(header.title()); l > propertyNameMaxWith {
 			propertyNameMaxWith = l
 		}
 		values[i][1] = header.format(val)
+		if d.previous != nil {
+			values[i][2] = header.format(d.previous.Properties()[prop]) != values[i][1]
+		}
 		i++
 	}
+	if d.previous != nil {
+		for prop, val := range d.previous.Properties() {
+			if _, ok := d.r.Properties()[prop]; ok {
+				continue
+			}
+			header := d.columnDefinition(prop)
+			if formatted := header.format(val); formatted != "" {
+				values = append(values, []interface{}{header.title(), formatted + " (removed)", true})
+			}
+		}
+	}
 
 	ds := defaultSorter{sortBy: []int{0}}
 	ds.sort(values)
@@ -76,7 +83,11 @@ func (d *tableResourceDisplayer) Print(w io.Writer) error {
 
 	for i := range values {
 		if val := fmt.Sprint(values[i][1]); val != "" {
-			table.Append([]string{fmt.Sprint(values[i][0]), wraper.Wrap(val)})
+			val = wraper.Wrap(val)
+			if values[i][2] == true {
+				val = highlight(val)
+			}
+			table.Append([]string{fmt.Sprint(values[i][0]), val})
 		}
 	}
 
@@ -85,6 +96,29 @@ func (d *tableResourceDisplayer) Print(w io.Writer) error {
 	return nil
 }
 
+func (d *tableResourceDisplayer) columnDefinition(prop string) ColumnDefinition {
+	var header ColumnDefinition
+	for _, h := range d.columnDefinitions {
+		if h.propKey() == prop {
+			header = h
+		}
+	}
+	if header == nil {
+		header = &StringColumnDefinition{Prop: prop}
+	}
+	return header
+}
+
 func (d *tableResourceDisplayer) SetResource(r cloud.Resource) {
 	d.r = r
 }
+
+// structuredResourceDisplayer displays the properties of the resource in one of the structured formats
+type structuredResourceDisplayer struct {
+	r      cloud.Resource
+	format string
+}
+
+func (d *structuredResourceDisplayer) Print(w io.Writer) error {
+	return EncodeStructured(w, d.format, d.r.Properties())
+}
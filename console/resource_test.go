@@ -18,8 +18,10 @@ package console
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/fatih/color"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/graph/resourcetest"
 )
@@ -59,3 +61,32 @@ func TestResourceDisplay(t *testing.T) {
 		t.Fatalf("got \n%s\n\nwant\n\n%s\n", got, want)
 	}
 }
+
+func TestResourceDisplayHighlightsChangedProperties(t *testing.T) {
+	color.NoColor = false
+	defer func() { color.NoColor = true }()
+
+	previous := resourcetest.Instance("inst_1").Prop("ID", "inst_1").Prop("State", "pending").Prop("PublicIP", "1.2.3.4").Build()
+	r := resourcetest.Instance("inst_1").Prop("ID", "inst_1").Prop("State", "running").Build()
+
+	displayer, _ := BuildOptions(
+		WithColumns([]string{"ID", "State"}),
+		WithPrevious(previous),
+	).SetSource(r).Build()
+
+	var w bytes.Buffer
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(w.String(), "\n") {
+		if strings.Contains(line, "inst_1") && strings.Contains(line, "\x1b[") {
+			t.Fatalf("unchanged property highlighted: %q", line)
+		}
+		if strings.Contains(line, "running") && !strings.Contains(line, "\x1b[") {
+			t.Fatalf("changed property not highlighted: %q", line)
+		}
+	}
+	if !strings.Contains(w.String(), "1.2.3.4") || !strings.Contains(w.String(), "(removed)") {
+		t.Fatalf("removed property not displayed:\n%s", w.String())
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Structured formats output values for scripts and pipelines. They all share the schema
+// of the JSON encoding of the values: YAML is converted from JSON, and JSON lines give
+// each element of a list on its own line (a single value taking one line).
+const (
+	JSONFormat      = "json"
+	YAMLFormat      = "yaml"
+	JSONLinesFormat = "jsonl"
+)
+
+var StructuredFormats = []string{JSONFormat, YAMLFormat, JSONLinesFormat}
+
+func IsStructuredFormat(format string) bool {
+	for _, f := range StructuredFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeStructured writes the value in the given structured format
+func EncodeStructured(w io.Writer, format string, v interface{}) error {
+	switch format {
+	case JSONFormat:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", " ")
+		return enc.Encode(v)
+	case JSONLinesFormat:
+		enc := json.NewEncoder(w)
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+			for i := 0; i < rv.Len(); i++ {
+				if err := enc.Encode(rv.Index(i).Interface()); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return enc.Encode(v)
+	case YAMLFormat:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err = json.Unmarshal(b, &generic); err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(generic)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	default:
+		return fmt.Errorf("unknown structured format '%s'", format)
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package console
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestEncodeStructured(t *testing.T) {
+	type item struct {
+		Name  string   `json:"name"`
+		Count int      `json:"count"`
+		Tags  []string `json:"tags,omitempty"`
+	}
+	items := []item{{Name: "first", Count: 1, Tags: []string{"a", "b"}}, {Name: "second", Count: 2}}
+
+	tcases := []struct {
+		format string
+		value  interface{}
+		expect string
+	}{
+		{format: "json", value: items, expect: "[\n {\n  \"name\": \"first\",\n  \"count\": 1,\n  \"tags\": [\n   \"a\",\n   \"b\"\n  ]\n },\n {\n  \"name\": \"second\",\n  \"count\": 2\n }\n]\n"},
+		{format: "jsonl", value: items, expect: "{\"name\":\"first\",\"count\":1,\"tags\":[\"a\",\"b\"]}\n{\"name\":\"second\",\"count\":2}\n"},
+		{format: "jsonl", value: items[1], expect: "{\"name\":\"second\",\"count\":2}\n"},
+		{format: "yaml", value: items, expect: "- count: 1\n  name: first\n  tags:\n  - a\n  - b\n- count: 2\n  name: second\n"},
+		{format: "yaml", value: items[1], expect: "count: 2\nname: second\n"},
+	}
+	for i, tcase := range tcases {
+		var w bytes.Buffer
+		if err := EncodeStructured(&w, tcase.format, tcase.value); err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := w.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%q\nwant\n%q", i+1, got, want)
+		}
+	}
+
+	if err := EncodeStructured(&bytes.Buffer{}, "xml", items); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
+
+func TestStructuredDisplays(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_1").Prop("ID", "inst_1").Prop("Name", "redis").Build(),
+		resourcetest.Instance("inst_2").Prop("ID", "inst_2").Prop("Name", "django").Build(),
+	)
+
+	tcases := []struct {
+		format string
+		source interface{}
+		expect string
+	}{
+		{format: "jsonl", source: g, expect: "{\"ID\":\"inst_1\",\"Name\":\"redis\"}\n{\"ID\":\"inst_2\",\"Name\":\"django\"}\n"},
+		{format: "yaml", source: g, expect: "- ID: inst_1\n  Name: redis\n- ID: inst_2\n  Name: django\n"},
+		{format: "yaml", source: resourcetest.Instance("inst_1").Prop("ID", "inst_1").Prop("Name", "redis").Build(), expect: "ID: inst_1\nName: redis\n"},
+		{format: "jsonl", source: resourcetest.Instance("inst_1").Prop("ID", "inst_1").Prop("Name", "redis").Build(), expect: "{\"ID\":\"inst_1\",\"Name\":\"redis\"}\n"},
+	}
+	for i, tcase := range tcases {
+		displayer, err := BuildOptions(
+			WithRdfType("instance"),
+			WithFormat(tcase.format),
+		).SetSource(tcase.source).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var w bytes.Buffer
+		if err := displayer.Print(&w); err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := w.String(), tcase.expect; got != want {
+			t.Fatalf("%d: got\n%q\nwant\n%q", i+1, got, want)
+		}
+	}
+}
@@ -150,6 +150,87 @@ func (db *DB) Close() {
 	}
 }
 
+// Compact rewrites the database of the awless home into a new file, reclaiming
+// the space left free by deleted values. It returns the number of bytes reclaimed.
+func Compact() (int64, error) {
+	awlessHome := os.Getenv("__AWLESS_HOME")
+	if awlessHome == "" {
+		return 0, errors.New("database: awless home is not set")
+	}
+	return compact(filepath.Join(awlessHome, Filename))
+}
+
+// CompactingPath returns the temporary file the database at the given path is compacted into.
+// It is only left behind by an interrupted compaction.
+func CompactingPath(path string) string {
+	return path + ".compact"
+}
+
+func compact(path string) (int64, error) {
+	before, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	src, err := open(path)
+	if err != nil {
+		return 0, err
+	}
+	compacted := CompactingPath(path)
+	os.Remove(compacted)
+	dst, err := open(compacted)
+	if err != nil {
+		src.Close()
+		return 0, err
+	}
+
+	err = src.bolt.View(func(srcTx *bolt.Tx) error {
+		return dst.bolt.Update(func(dstTx *bolt.Tx) error {
+			return srcTx.ForEach(func(name []byte, b *bolt.Bucket) error {
+				copied, err := dstTx.CreateBucket(name)
+				if err != nil {
+					return err
+				}
+				return copyBucket(b, copied)
+			})
+		})
+	})
+	dst.Close()
+	src.Close()
+	if err != nil {
+		os.Remove(compacted)
+		return 0, fmt.Errorf("compacting %s: %s", path, err)
+	}
+
+	if err = os.Rename(compacted, path); err != nil {
+		os.Remove(compacted)
+		return 0, err
+	}
+	after, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if reclaimed := before.Size() - after.Size(); reclaimed > 0 {
+		return reclaimed, nil
+	}
+	return 0, nil
+}
+
+func copyBucket(src, dst *bolt.Bucket) error {
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+		nested, err := dst.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+		return copyBucket(src.Bucket(k), nested)
+	})
+}
+
 func (db *DB) deleteBucket(name string) error {
 	return db.bolt.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(name))
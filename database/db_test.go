@@ -17,8 +17,13 @@ limitations under the License.
 package database
 
 import (
+	"bytes"
+	"crypto/rand"
 	"testing"
 	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/oklog/ulid"
 )
 
 func TestGetSetDatabaseValues(t *testing.T) {
@@ -89,3 +94,78 @@ func TestGetSetDatabaseValues(t *testing.T) {
 		t.Fatalf("got %s, want %s", got, want)
 	}
 }
+
+func TestDeleteTemplatesBeforeAndCompact(t *testing.T) {
+	db, close := newTestDb()
+	defer close()
+
+	old, recent := time.Now().Add(-48*time.Hour), time.Now()
+	ids := []string{
+		ulid.MustNew(ulid.Timestamp(old), rand.Reader).String(),
+		ulid.MustNew(ulid.Timestamp(old), rand.Reader).String(),
+		ulid.MustNew(ulid.Timestamp(recent), rand.Reader).String(),
+	}
+	err := db.bolt.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(TEMPLATES_BUCKET))
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := b.Put([]byte(id), bytes.Repeat([]byte("x"), 1<<20)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.SetStringValue("mykey", "myvalue"); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := db.DeleteTemplatesBefore(recent.Add(-24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := deleted, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	db.Close()
+
+	reclaimed, err := Compact()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reclaimed < 1<<20 {
+		t.Fatalf("got %d bytes reclaimed, want at least %d", reclaimed, 1<<20)
+	}
+
+	db, err = current()
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := db.GetStringValue("mykey")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := value, "myvalue"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	err = db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(TEMPLATES_BUCKET))
+		var keys []string
+		b.ForEach(func(k, v []byte) error {
+			keys = append(keys, string(k))
+			return nil
+		})
+		if len(keys) != 1 || keys[0] != ids[2] {
+			t.Fatalf("got %v, want [%s]", keys, ids[2])
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+}
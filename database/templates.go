@@ -19,20 +19,44 @@ package database
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
 
 	"github.com/boltdb/bolt"
+	"github.com/oklog/ulid"
 )
 
 const TEMPLATES_BUCKET = "templates"
 
+// RemoteTemplatesLog stores the executed templates as JSON documents by ID
+// out of the local database, to share them between users (ex: in S3)
+type RemoteTemplatesLog interface {
+	Put(id string, content []byte) error
+	// Get returns a nil content when no template has the ID
+	Get(id string) ([]byte, error)
+	// List returns the contents of all the templates by ID
+	List() (map[string][]byte, error)
+	Delete(id string) error
+}
+
+// RemoteTemplates, when set, replaces the local database as log of the executed templates
+var RemoteTemplates RemoteTemplatesLog
+
 func (db *DB) AddTemplate(tplExec *template.TemplateExecution) error {
-	return db.bolt.Update(func(tx *bolt.Tx) error {
-		if tplExec.ID == "" {
-			return errors.New("cannot persist template with empty ID")
+	if tplExec.ID == "" {
+		return errors.New("cannot persist template with empty ID")
+	}
+	if RemoteTemplates != nil {
+		b, err := tplExec.MarshalJSON()
+		if err != nil {
+			return err
 		}
-
+		return RemoteTemplates.Put(tplExec.ID, b)
+	}
+	return db.bolt.Update(func(tx *bolt.Tx) error {
 		bucket, err := tx.CreateBucketIfNotExists([]byte(TEMPLATES_BUCKET))
 		if err != nil {
 			return fmt.Errorf("create bucket %s: %s", TEMPLATES_BUCKET, err)
@@ -50,6 +74,17 @@ func (db *DB) AddTemplate(tplExec *template.TemplateExecution) error {
 func (db *DB) GetTemplate(id string) (*template.TemplateExecution, error) {
 	tplExec := &template.TemplateExecution{}
 
+	if RemoteTemplates != nil {
+		content, err := RemoteTemplates.Get(id)
+		if err != nil {
+			return tplExec, err
+		}
+		if content == nil {
+			return tplExec, fmt.Errorf("no content for id '%s'", id)
+		}
+		return tplExec, tplExec.UnmarshalJSON(content)
+	}
+
 	err := db.bolt.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(TEMPLATES_BUCKET))
 		if b == nil {
@@ -66,6 +101,9 @@ func (db *DB) GetTemplate(id string) (*template.TemplateExecution, error) {
 }
 
 func (db *DB) DeleteTemplates() error {
+	if RemoteTemplates != nil {
+		return errors.New("cannot delete all the templates of the shared templates log")
+	}
 	return db.bolt.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(TEMPLATES_BUCKET))
 		if b == nil {
@@ -76,6 +114,9 @@ func (db *DB) DeleteTemplates() error {
 }
 
 func (db *DB) DeleteTemplate(id string) error {
+	if RemoteTemplates != nil {
+		return RemoteTemplates.Delete(id)
+	}
 	return db.bolt.Update(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(TEMPLATES_BUCKET))
 		if b == nil {
@@ -85,6 +126,37 @@ func (db *DB) DeleteTemplate(id string) error {
 	})
 }
 
+// DeleteTemplatesBefore deletes the templates run before the date (as given by their ULID).
+// It returns the number of templates deleted. The retention of a shared templates log
+// is left to its owners: its templates are never deleted.
+func (db *DB) DeleteTemplatesBefore(date time.Time) (int, error) {
+	var deleted int
+	if RemoteTemplates != nil {
+		return deleted, nil
+	}
+	err := db.bolt.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(TEMPLATES_BUCKET))
+		if b == nil {
+			return nil
+		}
+		var keys [][]byte
+		c := b.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			if id, err := ulid.Parse(string(k)); err == nil && id.Time() < ulid.Timestamp(date) {
+				keys = append(keys, append([]byte{}, k...))
+			}
+		}
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	})
+	return deleted, err
+}
+
 type LoadedTemplate struct {
 	Err      error
 	TplExec  *template.TemplateExecution
@@ -94,18 +166,24 @@ type LoadedTemplate struct {
 func (db *DB) GetLoadedTemplate(id string) (*LoadedTemplate, error) {
 	loadedTpl := &LoadedTemplate{}
 
+	if RemoteTemplates != nil {
+		content, err := RemoteTemplates.Get(id)
+		if err != nil {
+			return loadedTpl, err
+		}
+		if content == nil {
+			return loadedTpl, fmt.Errorf("no content for id '%s'", id)
+		}
+		return newLoadedTemplate(id, content), nil
+	}
+
 	err := db.bolt.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(TEMPLATES_BUCKET))
 		if b == nil {
 			return errors.New("no templates stored yet")
 		}
 		if content := b.Get([]byte(id)); content != nil {
-			tplExec := &template.TemplateExecution{}
-			terr := tplExec.UnmarshalJSON(content)
-			loadedTpl.TplExec = tplExec
-			loadedTpl.Err = terr
-			loadedTpl.Key = string(id)
-			loadedTpl.Raw = string(content)
+			loadedTpl = newLoadedTemplate(id, content)
 			return nil
 		}
 		return fmt.Errorf("no content for id '%s'", id)
@@ -117,6 +195,22 @@ func (db *DB) GetLoadedTemplate(id string) (*LoadedTemplate, error) {
 func (db *DB) ListTemplates() ([]*LoadedTemplate, error) {
 	var results []*LoadedTemplate
 
+	if RemoteTemplates != nil {
+		all, err := RemoteTemplates.List()
+		if err != nil {
+			return results, err
+		}
+		var ids []string
+		for id := range all {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			results = append(results, newLoadedTemplate(id, all[id]))
+		}
+		return results, nil
+	}
+
 	err := db.bolt.View(func(tx *bolt.Tx) error {
 		b := tx.Bucket([]byte(TEMPLATES_BUCKET))
 		if b == nil {
@@ -126,10 +220,7 @@ func (db *DB) ListTemplates() ([]*LoadedTemplate, error) {
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
-			tplExec := &template.TemplateExecution{}
-			terr := tplExec.UnmarshalJSON(v)
-			lt := &LoadedTemplate{TplExec: tplExec, Err: terr, Key: string(k), Raw: string(v)}
-			results = append(results, lt)
+			results = append(results, newLoadedTemplate(string(k), v))
 		}
 
 		return nil
@@ -137,3 +228,102 @@ func (db *DB) ListTemplates() ([]*LoadedTemplate, error) {
 
 	return results, err
 }
+
+// CountLocalTemplates returns the number of templates of the local database,
+// which are not listed when a shared templates log is set
+func (db *DB) CountLocalTemplates() (count int, err error) {
+	err = db.bolt.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket([]byte(TEMPLATES_BUCKET)); b != nil {
+			count = b.Stats().KeyN
+		}
+		return nil
+	})
+	return
+}
+
+// MigrateLocalTemplates moves the templates of the local database to the shared templates log.
+// It returns the number of templates moved.
+func (db *DB) MigrateLocalTemplates() (int, error) {
+	var moved int
+	if RemoteTemplates == nil {
+		return moved, errors.New("no shared templates log to migrate the local templates to")
+	}
+	err := db.bolt.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(TEMPLATES_BUCKET))
+		if b == nil {
+			return nil
+		}
+		var keys [][]byte
+		c := b.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if err := RemoteTemplates.Put(string(k), v); err != nil {
+				return err
+			}
+			keys = append(keys, append([]byte{}, k...))
+		}
+		for _, k := range keys {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+			moved++
+		}
+		return nil
+	})
+	return moved, err
+}
+
+func newLoadedTemplate(key string, content []byte) *LoadedTemplate {
+	tplExec := &template.TemplateExecution{}
+	terr := tplExec.UnmarshalJSON(content)
+	return &LoadedTemplate{TplExec: tplExec, Err: terr, Key: key, Raw: string(content)}
+}
+
+// GetSucceededKeys returns the results of the statements per idempotency key
+// which succeeded in the templates run with the profile and region, and were not reverted since
+func (db *DB) GetSucceededKeys(profile, region string) (map[string]*env.Result, error) {
+	loaded, err := db.ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+	// positions of the reverted statements per run, nil when all were reverted
+	reverted := make(map[string]map[int]bool)
+	for _, l := range loaded {
+		if l.Err != nil || l.TplExec.RevertOf == "" || l.TplExec.Stats().KOCount > 0 {
+			continue
+		}
+		if len(l.TplExec.RevertedStatements) == 0 {
+			reverted[l.TplExec.RevertOf] = nil
+			continue
+		}
+		positions, ok := reverted[l.TplExec.RevertOf]
+		if ok && positions == nil {
+			continue
+		}
+		if !ok {
+			positions = make(map[int]bool)
+			reverted[l.TplExec.RevertOf] = positions
+		}
+		for _, p := range l.TplExec.RevertedStatements {
+			positions[p] = true
+		}
+	}
+
+	keys := make(map[string]*env.Result)
+	for _, l := range loaded {
+		if l.Err != nil || l.TplExec.Profile != profile || l.TplExec.Locale != region {
+			continue
+		}
+		positions, isReverted := reverted[l.TplExec.ID]
+		for i, cmd := range l.TplExec.CommandNodesIterator() {
+			if cmd.IdempotencyKey == "" || cmd.CmdErr != nil {
+				continue
+			}
+			if isReverted && (positions == nil || positions[i+1]) {
+				continue
+			}
+			id, _ := cmd.CmdResult.(string)
+			keys[cmd.IdempotencyKey] = &env.Result{ID: id, Attributes: cmd.CmdResultAttributes}
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+)
+
+type memoryTemplatesLog map[string][]byte
+
+func (m memoryTemplatesLog) Put(id string, content []byte) error { m[id] = content; return nil }
+func (m memoryTemplatesLog) Get(id string) ([]byte, error)       { return m[id], nil }
+func (m memoryTemplatesLog) List() (map[string][]byte, error)    { return m, nil }
+func (m memoryTemplatesLog) Delete(id string) error              { delete(m, id); return nil }
+
+func TestRemoteTemplates(t *testing.T) {
+	db, close := newTestDb()
+	defer close()
+
+	remote := make(memoryTemplatesLog)
+	RemoteTemplates = remote
+	defer func() { RemoteTemplates = nil }()
+
+	for _, id := range []string{"01BA7RV6ES86PZYCM3H28WM6KZ", "01BA7RV6ES86PZYCM3H28WM6KA"} {
+		tplExec := &template.TemplateExecution{}
+		if err := tplExec.UnmarshalJSON([]byte(`{"id":"` + id + `","commands":[{"line":"create vpc cidr=10.0.0.0/16","results":["vpc-1"]}]}`)); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddTemplate(tplExec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := len(remote), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	db.bolt.View(func(tx *bolt.Tx) error {
+		if tx.Bucket([]byte(TEMPLATES_BUCKET)) != nil {
+			t.Fatal("expected no template in local database")
+		}
+		return nil
+	})
+
+	loaded, err := db.GetTemplate("01BA7RV6ES86PZYCM3H28WM6KZ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded.Template.String(), "create vpc cidr=10.0.0.0/16"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if _, err = db.GetTemplate("unknown"); err == nil {
+		t.Fatal("expected error got none")
+	}
+
+	all, err := db.ListTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, l := range all {
+		if l.Err != nil {
+			t.Fatal(l.Err)
+		}
+		keys = append(keys, l.Key)
+	}
+	if got, want := keys, []string{"01BA7RV6ES86PZYCM3H28WM6KA", "01BA7RV6ES86PZYCM3H28WM6KZ"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if err = db.DeleteTemplates(); err == nil {
+		t.Fatal("expected error got none")
+	}
+	if deleted, err := db.DeleteTemplatesBefore(time.Now()); err != nil || deleted != 0 {
+		t.Fatalf("got %d deleted (err: %v), want none", deleted, err)
+	}
+	if err = db.DeleteTemplate("01BA7RV6ES86PZYCM3H28WM6KA"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(remote), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestMigrateLocalTemplates(t *testing.T) {
+	db, close := newTestDb()
+	defer close()
+
+	for _, id := range []string{"01BA7RV6ES86PZYCM3H28WM6KZ", "01BA7RV6ES86PZYCM3H28WM6KA"} {
+		tplExec := &template.TemplateExecution{Template: template.MustParse("create vpc cidr=10.0.0.0/16")}
+		tplExec.ID = id
+		if err := db.AddTemplate(tplExec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.MigrateLocalTemplates(); err == nil {
+		t.Fatal("expected error without shared templates log")
+	}
+
+	remote := make(memoryTemplatesLog)
+	RemoteTemplates = remote
+	defer func() { RemoteTemplates = nil }()
+
+	if count, err := db.CountLocalTemplates(); err != nil || count != 2 {
+		t.Fatalf("got %d local templates (err: %v), want 2", count, err)
+	}
+	moved, err := db.MigrateLocalTemplates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := moved, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := len(remote), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if count, err := db.CountLocalTemplates(); err != nil || count != 0 {
+		t.Fatalf("got %d local templates (err: %v), want 0", count, err)
+	}
+	loaded, err := db.GetTemplate("01BA7RV6ES86PZYCM3H28WM6KA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded.Template.String(), "create vpc cidr=10.0.0.0/16"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestGetSucceededKeys(t *testing.T) {
+	db, close := newTestDb()
+	defer close()
+
+	for _, content := range []string{
+		`{"id":"01BA7RV6ES86PZYCM3H28WM6KA","profile":"default","locale":"eu-west-1","commands":[{"line":"create vpc cidr=10.0.0.0/16","key":"vpc","results":["vpc-1"],"attributes":{"arn":"arn:aws:ec2:eu-west-1:0123:vpc/vpc-1"}},{"line":"create subnet cidr=10.0.0.0/24 vpc=vpc-1","key":"subnet","results":["sub-1"]},{"line":"create keypair name=mykey","key":"keypair","results":["mykey"]}]}`,
+		`{"id":"01BA7RV6ES86PZYCM3H28WM6KB","profile":"default","locale":"eu-west-1","revertOf":"01BA7RV6ES86PZYCM3H28WM6KA","revertedStatements":[3],"commands":[{"line":"delete keypair name=mykey"}]}`,
+		`{"id":"01BA7RV6ES86PZYCM3H28WM6KC","profile":"default","locale":"eu-west-1","commands":[{"line":"create queue name=jobs","key":"queue","results":["jobs-url"]}]}`,
+		`{"id":"01BA7RV6ES86PZYCM3H28WM6KD","profile":"default","locale":"eu-west-1","revertOf":"01BA7RV6ES86PZYCM3H28WM6KC","commands":[{"line":"delete queue url=jobs-url","errors":["failed"]}]}`,
+		`{"id":"01BA7RV6ES86PZYCM3H28WM6KE","profile":"default","locale":"us-east-1","commands":[{"line":"create vpc cidr=10.0.0.0/16","key":"other","results":["vpc-2"]}]}`,
+	} {
+		tplExec := &template.TemplateExecution{}
+		if err := tplExec.UnmarshalJSON([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.AddTemplate(tplExec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keys, err := db.GetSucceededKeys("default", "eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := keys, map[string]*env.Result{
+		"vpc":    {ID: "vpc-1", Attributes: map[string]string{"arn": "arn:aws:ec2:eu-west-1:0123:vpc/vpc-1"}},
+		"subnet": {ID: "sub-1"},
+		"queue":  {ID: "jobs-url"},
+	}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	revertAll := &template.TemplateExecution{}
+	if err := revertAll.UnmarshalJSON([]byte(`{"id":"01BA7RV6ES86PZYCM3H28WM6KF","revertOf":"01BA7RV6ES86PZYCM3H28WM6KA","commands":[{"line":"delete subnet id=sub-1"},{"line":"delete vpc id=vpc-1"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AddTemplate(revertAll); err != nil {
+		t.Fatal(err)
+	}
+	if keys, err = db.GetSucceededKeys("default", "eu-west-1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := keys, map[string]*env.Result{"queue": {ID: "jobs-url"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
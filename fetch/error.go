@@ -1,6 +1,11 @@
 package fetch
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+)
 
 // Not goroutine safe as for now
 type Error []error
@@ -37,3 +42,61 @@ func (fe *Error) Error() string {
 	}
 	return strings.Join(all, "\n")
 }
+
+// CanceledError is returned when the fetching has been interrupted through the context.
+// The graph returned alongside holds the resources fetched until the cancellation.
+type CanceledError struct {
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return "fetch interrupted: " + e.Err.Error()
+}
+
+func IsCanceled(err error) bool {
+	switch ee := err.(type) {
+	case *CanceledError:
+		return true
+	case *Error:
+		for _, e := range *ee {
+			if IsCanceled(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AccessDeniedError is returned when the credentials are not allowed to fetch a resource type.
+// Action is the denied API action (ex: ec2:DescribeVolumes), if known.
+type AccessDeniedError struct {
+	ResourceType, Action string
+	Err                  error
+}
+
+func (e *AccessDeniedError) Error() string {
+	if e.Action == "" {
+		return fmt.Sprintf("access denied to %s: missing permission to list them", cloud.PluralizeResource(e.ResourceType))
+	}
+	return fmt.Sprintf("access denied to %s: missing %s permission", cloud.PluralizeResource(e.ResourceType), e.Action)
+}
+
+// ThrottledError is returned when the cloud provider limited the rate of the fetching of a resource type
+type ThrottledError struct {
+	ResourceType string
+	Err          error
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("fetching %s throttled (retry later or disable their sync): %s", cloud.PluralizeResource(e.ResourceType), e.Err)
+}
+
+// NotSupportedInRegionError is returned when a resource type is not available in the fetched region
+type NotSupportedInRegionError struct {
+	ResourceType, Region string
+	Err                  error
+}
+
+func (e *NotSupportedInRegionError) Error() string {
+	return fmt.Sprintf("%s not supported in region %s", cloud.PluralizeResource(e.ResourceType), e.Region)
+}
@@ -1,6 +1,7 @@
 package fetch_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -37,3 +38,22 @@ func TestErrorWrapping(t *testing.T) {
 		}
 	}
 }
+
+func TestIsCanceled(t *testing.T) {
+	canceled := &fetch.CanceledError{Err: context.Canceled}
+	tcases := []struct {
+		err      error
+		canceled bool
+	}{
+		{nil, false},
+		{errors.New("any"), false},
+		{canceled, true},
+		{fetch.WrapError(errors.New("any"), canceled), true},
+		{fetch.WrapError(errors.New("any")), false},
+	}
+	for i, tcase := range tcases {
+		if got, want := fetch.IsCanceled(tcase.err), tcase.canceled; got != want {
+			t.Fatalf("%d: got %t, want %t", i+1, got, want)
+		}
+	}
+}
@@ -37,14 +37,16 @@ type fetcher struct {
 	resourceTypes []string
 }
 
-func NewFetcher(funcs Funcs) *fetcher {
+// NewFetcher returns a fetcher of the given funcs, each of them being wrapped
+// by the middlewares (the first middleware being the outermost)
+func NewFetcher(funcs Funcs, middlewares ...Middleware) *fetcher {
 	ftr := &fetcher{
 		fetchFuncs: make(Funcs),
 		cache:      newCache(),
 	}
 	for resType, f := range funcs {
 		ftr.resourceTypes = append(ftr.resourceTypes, resType)
-		ftr.fetchFuncs[resType] = f
+		ftr.fetchFuncs[resType] = chain(resType, f, middlewares...)
 	}
 	return ftr
 }
@@ -76,6 +78,10 @@ func (f *fetcher) Fetch(ctx context.Context) (*graph.Graph, error) {
 		gph.AddResource(res.Resources...)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return gph, &CanceledError{Err: err}
+	}
+
 	if ferr.Any() {
 		return gph, ferr
 	}
@@ -92,6 +98,10 @@ func (f *fetcher) FetchByType(ctx context.Context, resourceType string) (*graph.
 	gph := graph.NewGraph()
 	select {
 	case res := <-results:
+		if err := ctx.Err(); err != nil {
+			gph.AddResource(res.Resources...)
+			return gph, &CanceledError{Err: err}
+		}
 		if err := res.Err; err != nil {
 			return gph, err
 		}
@@ -108,14 +118,19 @@ func (f *fetcher) fetchResource(ctx context.Context, resourceType string, result
 	resources := make([]*graph.Resource, 0)
 
 	fn, ok := f.fetchFuncs[resourceType]
-	if ok {
-		resources, objects, err = fn(ctx, f.cache)
-	} else {
+	switch {
+	case !ok:
 		err = fmt.Errorf("no fetch func defined for resource type '%s'", resourceType)
+	case ctx.Err() != nil:
+		err = ctx.Err()
+	default:
+		resources, objects, err = fn(ctx, f.cache)
 	}
 
 	f.cache.Store(fmt.Sprintf("%s_objects", resourceType), objects)
 
+	ReportProgress(ctx, Progress{ResourceType: resourceType, Resources: len(resources), Done: true})
+
 	results <- FetchResult{
 		ResourceType: resourceType,
 		Err:          err,
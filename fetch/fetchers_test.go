@@ -3,6 +3,9 @@ package fetch_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/wallix/awless/fetch"
@@ -104,3 +107,78 @@ func TestFetcher(t *testing.T) {
 		}
 	})
 }
+
+func TestFetcherProgress(t *testing.T) {
+	funcs := map[string]fetch.Func{
+		"instance": func(ctx context.Context, _ fetch.Cache) ([]*graph.Resource, interface{}, error) {
+			var resources []*graph.Resource
+			for page := 1; page <= 3; page++ {
+				resources = append(resources, graph.InitResource("instance", fmt.Sprintf("inst_%d_1", page)), graph.InitResource("instance", fmt.Sprintf("inst_%d_2", page)))
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "instance", Pages: page, Resources: len(resources)})
+			}
+			return resources, nil, nil
+		},
+	}
+
+	var mu sync.Mutex
+	var reported []fetch.Progress
+	ctx := fetch.WithProgress(context.Background(), func(p fetch.Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, p)
+	})
+
+	if _, err := fetch.NewFetcher(funcs).Fetch(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []fetch.Progress{
+		{ResourceType: "instance", Pages: 1, Resources: 2},
+		{ResourceType: "instance", Pages: 2, Resources: 4},
+		{ResourceType: "instance", Pages: 3, Resources: 6},
+		{ResourceType: "instance", Resources: 6, Done: true},
+	}
+	if got, want := reported, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestFetcherCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var pagesFetched int
+	funcs := map[string]fetch.Func{
+		"instance": func(ctx context.Context, _ fetch.Cache) ([]*graph.Resource, interface{}, error) {
+			var resources []*graph.Resource
+			for page := 1; page <= 5 && ctx.Err() == nil; page++ {
+				pagesFetched++
+				resources = append(resources, graph.InitResource("instance", fmt.Sprintf("inst_%d", page)))
+				if page == 2 {
+					cancel()
+				}
+			}
+			return resources, nil, nil
+		},
+	}
+
+	gph, err := fetch.NewFetcher(funcs).Fetch(ctx)
+	if !fetch.IsCanceled(err) {
+		t.Fatalf("expected canceled error, got %v", err)
+	}
+	if ce, ok := err.(*fetch.CanceledError); !ok || ce.Err != context.Canceled {
+		t.Fatalf("expected wrapped context.Canceled, got %#v", err)
+	}
+	if got, want := pagesFetched, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if all, _ := gph.GetAllResources("instance"); len(all) != 2 {
+		t.Fatalf("expected partial graph with 2 instances, got %d", len(all))
+	}
+
+	if _, err = fetch.NewFetcher(funcs).FetchByType(ctx, "instance"); !fetch.IsCanceled(err) {
+		t.Fatalf("expected canceled error, got %v", err)
+	}
+	if got, want := pagesFetched, 2; got != want {
+		t.Fatalf("fetch func should not be called on canceled context: got %d pages, want %d", got, want)
+	}
+}
@@ -0,0 +1,113 @@
+package fetch
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+)
+
+// Middleware wraps the fetch func of a resource type to add a cross-cutting behavior
+// (logging, retry, caching, metrics, ...). Middlewares are given to NewFetcher.
+type Middleware func(resourceType string, next Func) Func
+
+// chain wraps the func with the middlewares, the first middleware being the outermost
+func chain(resourceType string, fn Func, middlewares ...Middleware) Func {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		fn = middlewares[i](resourceType, fn)
+	}
+	return fn
+}
+
+// LoggingMiddleware logs the duration and count of resources of each fetch
+func LoggingMiddleware(log *logger.Logger) Middleware {
+	return func(resourceType string, next Func) Func {
+		return func(ctx context.Context, cache Cache) ([]*graph.Resource, interface{}, error) {
+			start := time.Now()
+			resources, objects, err := next(ctx, cache)
+			if err != nil {
+				log.ExtraVerbosef("fetch: %s failed after %s: %s", resourceType, time.Since(start), err)
+			} else {
+				log.ExtraVerbosef("fetch: %d %s in %s", len(resources), resourceType, time.Since(start))
+			}
+			return resources, objects, err
+		}
+	}
+}
+
+// RetryMiddleware runs again a fetch failing with a retryable error, up to the given number of attempts.
+// The delay between attempts starts at the given backoff and doubles at each attempt.
+func RetryMiddleware(attempts int, backoff time.Duration, retryable func(error) bool) Middleware {
+	return func(resourceType string, next Func) Func {
+		return func(ctx context.Context, cache Cache) (resources []*graph.Resource, objects interface{}, err error) {
+			delay := backoff
+			for i := 0; i < attempts; i++ {
+				if resources, objects, err = next(ctx, cache); err == nil || !retryable(err) {
+					return
+				}
+				if i == attempts-1 {
+					break
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+				delay *= 2
+			}
+			return
+		}
+	}
+}
+
+// CachingMiddleware reuses the resources fetched successfully for the given duration
+func CachingMiddleware(ttl time.Duration) Middleware {
+	type cached struct {
+		resources []*graph.Resource
+		objects   interface{}
+		at        time.Time
+	}
+	var mu sync.Mutex
+	results := make(map[string]*cached)
+
+	return func(resourceType string, next Func) Func {
+		return func(ctx context.Context, cache Cache) ([]*graph.Resource, interface{}, error) {
+			mu.Lock()
+			c, ok := results[resourceType]
+			mu.Unlock()
+			if ok && time.Since(c.at) < ttl {
+				return c.resources, c.objects, nil
+			}
+
+			resources, objects, err := next(ctx, cache)
+			if err == nil {
+				mu.Lock()
+				results[resourceType] = &cached{resources: resources, objects: objects, at: time.Now()}
+				mu.Unlock()
+			}
+			return resources, objects, err
+		}
+	}
+}
+
+// Metric describes the fetching of a resource type
+type Metric struct {
+	ResourceType string
+	Duration     time.Duration
+	Resources    int
+	Err          error
+}
+
+// MetricsMiddleware reports a metric after each fetch
+func MetricsMiddleware(report func(Metric)) Middleware {
+	return func(resourceType string, next Func) Func {
+		return func(ctx context.Context, cache Cache) ([]*graph.Resource, interface{}, error) {
+			start := time.Now()
+			resources, objects, err := next(ctx, cache)
+			report(Metric{ResourceType: resourceType, Duration: time.Since(start), Resources: len(resources), Err: err})
+			return resources, objects, err
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package fetch_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+func TestMiddlewaresChaining(t *testing.T) {
+	var calls []string
+	tracing := func(name string) fetch.Middleware {
+		return func(resourceType string, next fetch.Func) fetch.Func {
+			return func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+				calls = append(calls, name+" "+resourceType)
+				return next(ctx, cache)
+			}
+		}
+	}
+	f := fetch.NewFetcher(fetch.Funcs{"instance": func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		calls = append(calls, "fetch")
+		return []*graph.Resource{graph.InitResource("instance", "inst_1")}, nil, nil
+	}}, tracing("first"), tracing("second"))
+
+	g, err := f.FetchByType(context.Background(), "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := calls, []string{"first instance", "second instance", "fetch"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, err := g.GetResource("instance", "inst_1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRetryMiddleware(t *testing.T) {
+	errRetryable, errFatal := errors.New("retryable"), errors.New("fatal")
+	tcases := []struct {
+		errs     []error
+		attempts int
+		expErr   error
+		expCalls int
+	}{
+		{errs: []error{nil}, attempts: 3, expCalls: 1},
+		{errs: []error{errRetryable, nil}, attempts: 3, expCalls: 2},
+		{errs: []error{errRetryable, errRetryable, errRetryable}, attempts: 3, expErr: errRetryable, expCalls: 3},
+		{errs: []error{errRetryable, errFatal}, attempts: 3, expErr: errFatal, expCalls: 2},
+	}
+
+	for i, tcase := range tcases {
+		var calls int
+		fn := fetch.RetryMiddleware(tcase.attempts, time.Millisecond, func(err error) bool { return err == errRetryable })("instance", func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+			err := tcase.errs[calls]
+			calls++
+			return nil, nil, err
+		})
+		if _, _, err := fn(context.Background(), nil); err != tcase.expErr {
+			t.Fatalf("%d: got %v, want %v", i+1, err, tcase.expErr)
+		}
+		if got, want := calls, tcase.expCalls; got != want {
+			t.Fatalf("%d: got %d, want %d", i+1, got, want)
+		}
+	}
+}
+
+func TestCachingAndMetricsMiddlewares(t *testing.T) {
+	var calls int
+	var metrics []fetch.Metric
+	fn := func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		calls++
+		return []*graph.Resource{graph.InitResource("instance", "inst_1")}, nil, nil
+	}
+	caching := fetch.CachingMiddleware(time.Hour)
+	report := fetch.MetricsMiddleware(func(m fetch.Metric) { metrics = append(metrics, m) })
+
+	for i := 0; i < 2; i++ {
+		f := fetch.NewFetcher(fetch.Funcs{"instance": fn}, report, caching)
+		if _, err := f.FetchByType(context.Background(), "instance"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := calls, 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := len(metrics), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for _, m := range metrics {
+		if m.ResourceType != "instance" || m.Resources != 1 || m.Err != nil {
+			t.Fatalf("unexpected metric %#v", m)
+		}
+	}
+}
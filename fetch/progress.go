@@ -0,0 +1,30 @@
+package fetch
+
+import "context"
+
+const progressCtxKey = "progress"
+
+// Progress describes how far the fetching of a given resource type went
+type Progress struct {
+	ResourceType string
+	Pages        int
+	Resources    int
+	Done         bool
+}
+
+type ProgressFunc func(Progress)
+
+// WithProgress returns a context on which fetch funcs will report their incremental progress
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressCtxKey, fn)
+}
+
+// ReportProgress calls the progress func carried by the context, if any
+func ReportProgress(ctx context.Context, p Progress) {
+	if ctx == nil {
+		return
+	}
+	if fn, ok := ctx.Value(progressCtxKey).(ProgressFunc); ok && fn != nil {
+		fn(p)
+	}
+}
@@ -0,0 +1,33 @@
+package fetch
+
+import (
+	"context"
+
+	"github.com/wallix/awless/cloud"
+)
+
+const queryCtxKey = "query"
+
+// WithQuery returns a context carrying a query that fetch funcs may translate
+// into server side filters to avoid downloading entire resource sets.
+// The query is only a hint: resources not matching it can still be returned.
+func WithQuery(ctx context.Context, q cloud.Query) context.Context {
+	return context.WithValue(ctx, queryCtxKey, q)
+}
+
+// QueryForType returns the query carried by the context if it applies to the given resource type
+func QueryForType(ctx context.Context, resourceType string) (cloud.Query, bool) {
+	if ctx == nil {
+		return cloud.Query{}, false
+	}
+	q, ok := ctx.Value(queryCtxKey).(cloud.Query)
+	if !ok || q.Matcher == nil {
+		return cloud.Query{}, false
+	}
+	for _, t := range q.ResourceType {
+		if t == resourceType {
+			return q, true
+		}
+	}
+	return cloud.Query{}, false
+}
@@ -0,0 +1,119 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/wallix/awless/graph"
+)
+
+// Snapshotter saves and restores the cached values of a fetcher,
+// for instance to record real API objects once and replay them in tests
+type Snapshotter interface {
+	Snapshot(io.Writer) error
+	Restore(io.Reader) error
+}
+
+var cacheTypes = struct {
+	sync.RWMutex
+	m map[string]reflect.Type
+}{m: make(map[string]reflect.Type)}
+
+// RegisterCacheTypes registers the types of the values a snapshot can hold
+// (ex: []*ec2.Instance{}). Values of unregistered types cannot be snapshotted.
+func RegisterCacheTypes(values ...interface{}) {
+	cacheTypes.Lock()
+	defer cacheTypes.Unlock()
+	for _, v := range values {
+		t := reflect.TypeOf(v)
+		cacheTypes.m[t.String()] = t
+	}
+}
+
+// LoadSnapshot returns a cache restored from a snapshot
+func LoadSnapshot(r io.Reader) (Cache, error) {
+	c := newCache()
+	return c, c.Restore(r)
+}
+
+type snapshotEntry struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Snapshot writes as JSON the values successfully cached
+func (c *cache) Snapshot(w io.Writer) error {
+	entries := make(map[string]snapshotEntry)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for key, kc := range c.cached {
+		if kc.err != nil || kc.result == nil {
+			continue
+		}
+		typ := reflect.TypeOf(kc.result).String()
+		cacheTypes.RLock()
+		_, ok := cacheTypes.m[typ]
+		cacheTypes.RUnlock()
+		if !ok {
+			return fmt.Errorf("snapshot: unregistered type %s for key %s", typ, key)
+		}
+		b, err := json.Marshal(kc.result)
+		if err != nil {
+			return fmt.Errorf("snapshot: key %s: %s", key, err)
+		}
+		entries[key] = snapshotEntry{Type: typ, Value: b}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
+
+// Restore loads in the cache the values of a snapshot. Restored values are
+// returned as is by Get: the given funcs are not called for these keys.
+func (c *cache) Restore(r io.Reader) error {
+	var entries map[string]snapshotEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return fmt.Errorf("restore: %s", err)
+	}
+
+	for key, entry := range entries {
+		cacheTypes.RLock()
+		typ, ok := cacheTypes.m[entry.Type]
+		cacheTypes.RUnlock()
+		if !ok {
+			return fmt.Errorf("restore: unregistered type %s for key %s", entry.Type, key)
+		}
+		val := reflect.New(typ)
+		if err := json.Unmarshal(entry.Value, val.Interface()); err != nil {
+			return fmt.Errorf("restore: key %s: %s", key, err)
+		}
+		kc := &keyCache{result: val.Elem().Interface()}
+		kc.once.Do(func() {})
+
+		c.mu.Lock()
+		c.cached[key] = kc
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// ReplayMiddleware returns the resources built from the objects of the snapshot instead of fetching them.
+// Resource types without objects in the snapshot are fetched.
+func ReplayMiddleware(snapshot Cache, toResources func(resourceType string, objects interface{}) ([]*graph.Resource, error)) Middleware {
+	return func(resourceType string, next Func) Func {
+		return func(ctx context.Context, cache Cache) ([]*graph.Resource, interface{}, error) {
+			objects, err := snapshot.Get(fmt.Sprintf("%s_objects", resourceType))
+			if err != nil || objects == nil {
+				return next(ctx, cache)
+			}
+			resources, err := toResources(resourceType, objects)
+			return resources, objects, err
+		}
+	}
+}
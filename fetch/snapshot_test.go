@@ -0,0 +1,84 @@
+package fetch_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/fetch"
+	"github.com/wallix/awless/graph"
+)
+
+type object struct {
+	ID   string
+	Tags []string
+}
+
+func TestSnapshotAndReplay(t *testing.T) {
+	fetch.RegisterCacheTypes([]*object{}, []string{})
+
+	var apiCalls int
+	funcs := fetch.Funcs{"instance": func(ctx context.Context, cache fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		if _, err := cache.Get("getOwners", func() (interface{}, error) {
+			apiCalls++
+			return []string{"me"}, nil
+		}); err != nil {
+			return nil, nil, err
+		}
+		apiCalls++
+		objects := []*object{{ID: "inst_1", Tags: []string{"env=prod"}}, {ID: "inst_2"}}
+		return []*graph.Resource{graph.InitResource("instance", "inst_1"), graph.InitResource("instance", "inst_2")}, objects, nil
+	}}
+
+	recorder := fetch.NewFetcher(funcs)
+	if _, err := recorder.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var buff bytes.Buffer
+	if err := recorder.Snapshot(&buff); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := fetch.LoadSnapshot(&buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := snapshot.Get("getOwners", func() (interface{}, error) { t.Fatal("unexpected call"); return nil, nil }); err != nil || !reflect.DeepEqual(got, []string{"me"}) {
+		t.Fatalf("got %#v, %v", got, err)
+	}
+
+	var replayed []string
+	replay := fetch.ReplayMiddleware(snapshot, func(resourceType string, objects interface{}) (resources []*graph.Resource, err error) {
+		for _, o := range objects.([]*object) {
+			replayed = append(replayed, o.ID)
+			resources = append(resources, graph.InitResource(resourceType, o.ID))
+		}
+		return
+	})
+
+	apiCalls = 0
+	g, err := fetch.NewFetcher(funcs, replay).FetchByType(context.Background(), "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := apiCalls, 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := replayed, []string{"inst_1", "inst_2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, err := g.GetResource("instance", "inst_2"); err != nil {
+		t.Fatal(err)
+	}
+
+	unregistered := fetch.NewFetcher(fetch.Funcs{"subnet": func(context.Context, fetch.Cache) ([]*graph.Resource, interface{}, error) {
+		return nil, []int{1}, nil
+	}})
+	if _, err := unregistered.Fetch(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := unregistered.Snapshot(&buff); err == nil {
+		t.Fatal("expected error for unregistered type")
+	}
+}
@@ -34,6 +34,12 @@ func ApiToInterface(api string) string {
 		return "ApplicationAutoScalingAPI"
 	case "cloudformation":
 		return "CloudFormationAPI"
+	case "dynamodb":
+		return "DynamoDBAPI"
+	case "elasticache":
+		return "ElastiCacheAPI"
+	case "apigateway":
+		return "APIGatewayAPI"
 	case "route53", "lambda":
 		return strings.Title(api) + "API"
 	default:
@@ -41,6 +47,22 @@ func ApiToInterface(api string) string {
 	}
 }
 
+// IAMAction returns the IAM action allowing to call the given API method (ex: ec2:DescribeVolumes)
+func IAMAction(api, method string) string {
+	var prefix string
+	switch api {
+	case "elbv2":
+		prefix = "elasticloadbalancing"
+	case "applicationautoscaling":
+		prefix = "application-autoscaling"
+	case "sfn":
+		prefix = "states"
+	default:
+		prefix = api
+	}
+	return prefix + ":" + strings.TrimSuffix(method, "Pages")
+}
+
 type fetchersDef struct {
 	Name     string
 	Global   bool
@@ -62,37 +84,47 @@ type fetcher struct {
 var FetchersDefs = []fetchersDef{
 	{
 		Name: "infra",
-		Api:  []string{"ec2", "elbv2", "rds", "autoscaling", "ecr", "ecs", "applicationautoscaling", "acm"},
+		Api:  []string{"ec2", "elbv2", "rds", "dynamodb", "autoscaling", "ecr", "ecs", "applicationautoscaling", "acm", "kms", "elasticache"},
 		Fetchers: []fetcher{
-			{Api: "ec2", ResourceType: cloud.Instance, AWSType: "ec2.Instance", ApiMethod: "DescribeInstancesPages", Input: "ec2.DescribeInstancesInput{}", Output: "ec2.DescribeInstancesOutput", OutputsExtractor: "Instances", OutputsContainers: "Reservations", Multipage: true, NextPageMarker: "NextToken"},
-			{Api: "ec2", ResourceType: cloud.Subnet, AWSType: "ec2.Subnet", ApiMethod: "DescribeSubnets", Input: "ec2.DescribeSubnetsInput{}", Output: "ec2.DescribeSubnetsOutput", OutputsExtractor: "Subnets"},
-			{Api: "ec2", ResourceType: cloud.Vpc, AWSType: "ec2.Vpc", ApiMethod: "DescribeVpcs", Input: "ec2.DescribeVpcsInput{}", Output: "ec2.DescribeVpcsOutput", OutputsExtractor: "Vpcs"},
+			{Api: "ec2", ResourceType: cloud.Instance, AWSType: "ec2.Instance", ManualFetcher: true},
+			{Api: "ec2", ResourceType: cloud.Subnet, AWSType: "ec2.Subnet", ApiMethod: "DescribeSubnets", Input: "ec2.DescribeSubnetsInput{Filters: ec2FiltersFromContext(ctx, \"subnet\")}", Output: "ec2.DescribeSubnetsOutput", OutputsExtractor: "Subnets"},
+			{Api: "ec2", ResourceType: cloud.Vpc, AWSType: "ec2.Vpc", ApiMethod: "DescribeVpcs", Input: "ec2.DescribeVpcsInput{Filters: ec2FiltersFromContext(ctx, \"vpc\")}", Output: "ec2.DescribeVpcsOutput", OutputsExtractor: "Vpcs"},
 			{Api: "ec2", ResourceType: cloud.Keypair, AWSType: "ec2.KeyPairInfo", ApiMethod: "DescribeKeyPairs", Input: "ec2.DescribeKeyPairsInput{}", Output: "ec2.DescribeKeyPairsOutput", OutputsExtractor: "KeyPairs"},
-			{Api: "ec2", ResourceType: cloud.SecurityGroup, AWSType: "ec2.SecurityGroup", ApiMethod: "DescribeSecurityGroups", Input: "ec2.DescribeSecurityGroupsInput{}", Output: "ec2.DescribeSecurityGroupsOutput", OutputsExtractor: "SecurityGroups"},
-			{Api: "ec2", ResourceType: cloud.Volume, AWSType: "ec2.Volume", ApiMethod: "DescribeVolumesPages", Input: "ec2.DescribeVolumesInput{}", Output: "ec2.DescribeVolumesOutput", OutputsExtractor: "Volumes", Multipage: true, NextPageMarker: "NextToken"},
-			{Api: "ec2", ResourceType: cloud.InternetGateway, AWSType: "ec2.InternetGateway", ApiMethod: "DescribeInternetGateways", Input: "ec2.DescribeInternetGatewaysInput{}", Output: "ec2.DescribeInternetGatewaysOutput", OutputsExtractor: "InternetGateways"},
-			{Api: "ec2", ResourceType: cloud.NatGateway, AWSType: "ec2.NatGateway", ApiMethod: "DescribeNatGateways", Input: "ec2.DescribeNatGatewaysInput{}", Output: "ec2.DescribeNatGatewaysOutput", OutputsExtractor: "NatGateways"},
-			{Api: "ec2", ResourceType: cloud.RouteTable, AWSType: "ec2.RouteTable", ApiMethod: "DescribeRouteTables", Input: "ec2.DescribeRouteTablesInput{}", Output: "ec2.DescribeRouteTablesOutput", OutputsExtractor: "RouteTables"},
+			{Api: "ec2", ResourceType: cloud.SecurityGroup, AWSType: "ec2.SecurityGroup", ApiMethod: "DescribeSecurityGroups", Input: "ec2.DescribeSecurityGroupsInput{Filters: ec2FiltersFromContext(ctx, \"securitygroup\")}", Output: "ec2.DescribeSecurityGroupsOutput", OutputsExtractor: "SecurityGroups"},
+			{Api: "ec2", ResourceType: cloud.Volume, AWSType: "ec2.Volume", ApiMethod: "DescribeVolumesPages", Input: "ec2.DescribeVolumesInput{Filters: ec2FiltersFromContext(ctx, \"volume\")}", Output: "ec2.DescribeVolumesOutput", OutputsExtractor: "Volumes", Multipage: true, NextPageMarker: "NextToken"},
+			{Api: "ec2", ResourceType: cloud.InternetGateway, AWSType: "ec2.InternetGateway", ApiMethod: "DescribeInternetGateways", Input: "ec2.DescribeInternetGatewaysInput{Filters: ec2FiltersFromContext(ctx, \"internetgateway\")}", Output: "ec2.DescribeInternetGatewaysOutput", OutputsExtractor: "InternetGateways"},
+			{Api: "ec2", ResourceType: cloud.NatGateway, AWSType: "ec2.NatGateway", ApiMethod: "DescribeNatGateways", Input: "ec2.DescribeNatGatewaysInput{Filter: ec2FiltersFromContext(ctx, \"natgateway\")}", Output: "ec2.DescribeNatGatewaysOutput", OutputsExtractor: "NatGateways"},
+			{Api: "ec2", ResourceType: cloud.RouteTable, AWSType: "ec2.RouteTable", ApiMethod: "DescribeRouteTables", Input: "ec2.DescribeRouteTablesInput{Filters: ec2FiltersFromContext(ctx, \"routetable\")}", Output: "ec2.DescribeRouteTablesOutput", OutputsExtractor: "RouteTables"},
 			{Api: "ec2", ResourceType: cloud.AvailabilityZone, AWSType: "ec2.AvailabilityZone", ApiMethod: "DescribeAvailabilityZones", Input: "ec2.DescribeAvailabilityZonesInput{}", Output: "ec2.DescribeAvailabilityZonesOutput", OutputsExtractor: "AvailabilityZones"},
-			{Api: "ec2", ResourceType: cloud.Image, AWSType: "ec2.Image", ApiMethod: "DescribeImages", Input: "ec2.DescribeImagesInput{Owners: []*string{awssdk.String(\"self\")}}", Output: "ec2.DescribeImagesOutput", OutputsExtractor: "Images"},
+			{Api: "ec2", ResourceType: cloud.Image, AWSType: "ec2.Image", ApiMethod: "DescribeImages", Input: "ec2.DescribeImagesInput{Owners: []*string{awssdk.String(\"self\")}, Filters: ec2FiltersFromContext(ctx, \"image\")}", Output: "ec2.DescribeImagesOutput", OutputsExtractor: "Images"},
 			{Api: "ec2", ResourceType: cloud.ImportImageTask, AWSType: "ec2.ImportImageTask", ApiMethod: "DescribeImportImageTasks", Input: "ec2.DescribeImportImageTasksInput{}", Output: "ec2.DescribeImportImageTasksOutput", OutputsExtractor: "ImportImageTasks"},
-			{Api: "ec2", ResourceType: cloud.ElasticIP, AWSType: "ec2.Address", ApiMethod: "DescribeAddresses", Input: "ec2.DescribeAddressesInput{}", Output: "ec2.DescribeAddressesOutput", OutputsExtractor: "Addresses"},
-			{Api: "ec2", ResourceType: cloud.Snapshot, AWSType: "ec2.Snapshot", ApiMethod: "DescribeSnapshotsPages", Input: "ec2.DescribeSnapshotsInput{OwnerIds:[]*string{awssdk.String(\"self\")}}", Output: "ec2.DescribeSnapshotsOutput", OutputsExtractor: "Snapshots", Multipage: true, NextPageMarker: "NextToken"},
-			{Api: "ec2", ResourceType: cloud.NetworkInterface, AWSType: "ec2.NetworkInterface", ApiMethod: "DescribeNetworkInterfaces", Input: "ec2.DescribeNetworkInterfacesInput{}", Output: "ec2.DescribeNetworkInterfacesOutput", OutputsExtractor: "NetworkInterfaces"},
+			{Api: "ec2", ResourceType: cloud.ElasticIP, AWSType: "ec2.Address", ApiMethod: "DescribeAddresses", Input: "ec2.DescribeAddressesInput{Filters: ec2FiltersFromContext(ctx, \"elasticip\")}", Output: "ec2.DescribeAddressesOutput", OutputsExtractor: "Addresses"},
+			{Api: "ec2", ResourceType: cloud.Snapshot, AWSType: "ec2.Snapshot", ApiMethod: "DescribeSnapshotsPages", Input: "ec2.DescribeSnapshotsInput{OwnerIds:[]*string{awssdk.String(\"self\")}, Filters: ec2FiltersFromContext(ctx, \"snapshot\")}", Output: "ec2.DescribeSnapshotsOutput", OutputsExtractor: "Snapshots", Multipage: true, NextPageMarker: "NextToken"},
+			{Api: "ec2", ResourceType: cloud.NetworkInterface, AWSType: "ec2.NetworkInterface", ApiMethod: "DescribeNetworkInterfaces", Input: "ec2.DescribeNetworkInterfacesInput{Filters: ec2FiltersFromContext(ctx, \"networkinterface\")}", Output: "ec2.DescribeNetworkInterfacesOutput", OutputsExtractor: "NetworkInterfaces"},
+			{Api: "ec2", ResourceType: cloud.SpotFleet, AWSType: "ec2.SpotFleetRequestConfig", ApiMethod: "DescribeSpotFleetRequestsPages", Input: "ec2.DescribeSpotFleetRequestsInput{}", Output: "ec2.DescribeSpotFleetRequestsOutput", OutputsExtractor: "SpotFleetRequestConfigs", Multipage: true, NextPageMarker: "NextToken"},
+			{Api: "ec2", ResourceType: cloud.SpotRequest, AWSType: "ec2.SpotInstanceRequest", ApiMethod: "DescribeSpotInstanceRequests", Input: "ec2.DescribeSpotInstanceRequestsInput{}", Output: "ec2.DescribeSpotInstanceRequestsOutput", OutputsExtractor: "SpotInstanceRequests"},
 			{Api: "elbv2", ResourceType: cloud.LoadBalancer, AWSType: "elbv2.LoadBalancer", ApiMethod: "DescribeLoadBalancersPages", Input: "elbv2.DescribeLoadBalancersInput{}", Output: "elbv2.DescribeLoadBalancersOutput", OutputsExtractor: "LoadBalancers", Multipage: true, NextPageMarker: "NextMarker"},
 			{Api: "elbv2", ResourceType: cloud.TargetGroup, AWSType: "elbv2.TargetGroup", ApiMethod: "DescribeTargetGroups", Input: "elbv2.DescribeTargetGroupsInput{}", Output: "elbv2.DescribeTargetGroupsOutput", OutputsExtractor: "TargetGroups"},
 			{Api: "elbv2", ResourceType: cloud.Listener, AWSType: "elbv2.Listener", ManualFetcher: true},
 			{Api: "rds", ResourceType: cloud.Database, AWSType: "rds.DBInstance", ApiMethod: "DescribeDBInstancesPages", Input: "rds.DescribeDBInstancesInput{}", Output: "rds.DescribeDBInstancesOutput", OutputsExtractor: "DBInstances", Multipage: true, NextPageMarker: "Marker"},
 			{Api: "rds", ResourceType: cloud.DbSubnetGroup, AWSType: "rds.DBSubnetGroup", ApiMethod: "DescribeDBSubnetGroupsPages", Input: "rds.DescribeDBSubnetGroupsInput{}", Output: "rds.DescribeDBSubnetGroupsOutput", OutputsExtractor: "DBSubnetGroups", Multipage: true, NextPageMarker: "Marker"},
+			{Api: "elasticache", ResourceType: cloud.CacheCluster, AWSType: "elasticache.CacheCluster", ApiMethod: "DescribeCacheClustersPages", Input: "elasticache.DescribeCacheClustersInput{}", Output: "elasticache.DescribeCacheClustersOutput", OutputsExtractor: "CacheClusters", Multipage: true, NextPageMarker: "Marker"},
+			{Api: "elasticache", ResourceType: cloud.ReplicationGroup, AWSType: "elasticache.ReplicationGroup", ApiMethod: "DescribeReplicationGroupsPages", Input: "elasticache.DescribeReplicationGroupsInput{}", Output: "elasticache.DescribeReplicationGroupsOutput", OutputsExtractor: "ReplicationGroups", Multipage: true, NextPageMarker: "Marker"},
+			{Api: "dynamodb", ResourceType: cloud.Table, AWSType: "dynamodb.TableDescription", ManualFetcher: true},
+			{Api: "dynamodb", ResourceType: cloud.TableIndex, AWSType: "dynamodb.GlobalSecondaryIndexDescription", ManualFetcher: true},
 			{Api: "autoscaling", ResourceType: cloud.LaunchConfiguration, AWSType: "autoscaling.LaunchConfiguration", ApiMethod: "DescribeLaunchConfigurationsPages", Input: "autoscaling.DescribeLaunchConfigurationsInput{}", Output: "autoscaling.DescribeLaunchConfigurationsOutput", OutputsExtractor: "LaunchConfigurations", Multipage: true, NextPageMarker: "NextToken"},
 			{Api: "autoscaling", ResourceType: cloud.ScalingGroup, AWSType: "autoscaling.Group", ApiMethod: "DescribeAutoScalingGroupsPages", Input: "autoscaling.DescribeAutoScalingGroupsInput{}", Output: "autoscaling.DescribeAutoScalingGroupsOutput", OutputsExtractor: "AutoScalingGroups", Multipage: true, NextPageMarker: "NextToken"},
 			{Api: "autoscaling", ResourceType: cloud.ScalingPolicy, AWSType: "autoscaling.ScalingPolicy", ApiMethod: "DescribePoliciesPages", Input: "autoscaling.DescribePoliciesInput{}", Output: "autoscaling.DescribePoliciesOutput", OutputsExtractor: "ScalingPolicies", Multipage: true, NextPageMarker: "NextToken"},
 			{Api: "ecr", ResourceType: cloud.Repository, AWSType: "ecr.Repository", ApiMethod: "DescribeRepositoriesPages", Input: "ecr.DescribeRepositoriesInput{}", Output: "ecr.DescribeRepositoriesOutput", OutputsExtractor: "Repositories", Multipage: true, NextPageMarker: "NextToken"},
 			{Api: "ecs", ResourceType: cloud.ContainerCluster, AWSType: "ecs.Cluster", ManualFetcher: true},
+			{Api: "ecs", ResourceType: cloud.ContainerService, AWSType: "ecs.Service", ManualFetcher: true},
 			{Api: "ecs", ResourceType: cloud.ContainerTask, AWSType: "ecs.TaskDefinition", ManualFetcher: true},
 			{Api: "ecs", ResourceType: cloud.Container, AWSType: "ecs.Container", ManualFetcher: true},
 			{Api: "ecs", ResourceType: cloud.ContainerInstance, AWSType: "ecs.ContainerInstance", ManualFetcher: true},
 			{Api: "acm", ResourceType: cloud.Certificate, AWSType: "acm.CertificateSummary", ApiMethod: "ListCertificatesPages", Input: "acm.ListCertificatesInput{}", Output: "acm.ListCertificatesOutput", OutputsExtractor: "CertificateSummaryList", Multipage: true, NextPageMarker: "NextToken"},
+			{Api: "kms", ResourceType: cloud.Key, AWSType: "kms.KeyMetadata", ManualFetcher: true},
+			{Api: "kms", ResourceType: cloud.Alias, AWSType: "kms.AliasListEntry", ApiMethod: "ListAliasesPages", Input: "kms.ListAliasesInput{}", Output: "kms.ListAliasesOutput", OutputsExtractor: "Aliases", Multipage: true, NextPageMarker: "NextMarker"},
+			{Api: "kms", ResourceType: cloud.KeyGrant, AWSType: "kms.GrantListEntry", ManualFetcher: true},
 		},
 	},
 	{
@@ -133,14 +165,22 @@ var FetchersDefs = []fetchersDef{
 		Fetchers: []fetcher{
 			{Api: "route53", ResourceType: cloud.Zone, AWSType: "route53.HostedZone", ApiMethod: "ListHostedZonesPages", Input: "route53.ListHostedZonesInput{}", Output: "route53.ListHostedZonesOutput", OutputsExtractor: "HostedZones", Multipage: true, NextPageMarker: "NextMarker"},
 			{Api: "route53", ResourceType: cloud.Record, AWSType: "route53.ResourceRecordSet", ManualFetcher: true},
+			{Api: "route53", ResourceType: cloud.HealthCheck, AWSType: "route53.HealthCheck", ApiMethod: "ListHealthChecksPages", Input: "route53.ListHealthChecksInput{}", Output: "route53.ListHealthChecksOutput", OutputsExtractor: "HealthChecks", Multipage: true, NextPageMarker: "NextMarker"},
 		},
 	},
 
 	{
 		Name: "lambda",
-		Api:  []string{"lambda"},
+		Api:  []string{"lambda", "sfn", "apigateway"},
 		Fetchers: []fetcher{
 			{Api: "lambda", ResourceType: cloud.Function, AWSType: "lambda.FunctionConfiguration", ApiMethod: "ListFunctionsPages", Input: "lambda.ListFunctionsInput{}", Output: "lambda.ListFunctionsOutput", OutputsExtractor: "Functions", Multipage: true, NextPageMarker: "NextMarker"},
+			{Api: "lambda", ResourceType: cloud.FunctionAlias, AWSType: "lambda.AliasConfiguration", ManualFetcher: true},
+			{Api: "lambda", ResourceType: cloud.EventSourceMapping, AWSType: "lambda.EventSourceMappingConfiguration", ManualFetcher: true},
+			{Api: "sfn", ResourceType: cloud.StateMachine, AWSType: "sfn.StateMachineListItem", ApiMethod: "ListStateMachinesPages", Input: "sfn.ListStateMachinesInput{}", Output: "sfn.ListStateMachinesOutput", OutputsExtractor: "StateMachines", Multipage: true, NextPageMarker: "NextToken"},
+			{Api: "sfn", ResourceType: cloud.Execution, AWSType: "sfn.ExecutionListItem", ManualFetcher: true},
+			{Api: "apigateway", ResourceType: cloud.RestApi, AWSType: "apigateway.RestApi", ManualFetcher: true},
+			{Api: "apigateway", ResourceType: cloud.ApiStage, AWSType: "apigateway.Stage", ManualFetcher: true},
+			{Api: "apigateway", ResourceType: cloud.ApiDeployment, AWSType: "apigateway.Deployment", ManualFetcher: true},
 		},
 	},
 	{
@@ -157,6 +197,7 @@ var FetchersDefs = []fetchersDef{
 		Api:    []string{"cloudfront"},
 		Fetchers: []fetcher{
 			{Api: "cloudfront", ResourceType: cloud.Distribution, AWSType: "cloudfront.DistributionSummary", ApiMethod: "ListDistributionsPages", Input: "cloudfront.ListDistributionsInput{}", Output: "cloudfront.ListDistributionsOutput", OutputsExtractor: "DistributionList.Items", Multipage: true, NextPageMarker: "DistributionList.NextMarker"},
+			{Api: "cloudfront", ResourceType: cloud.OriginAccessIdentity, AWSType: "cloudfront.OriginAccessIdentitySummary", ApiMethod: "ListCloudFrontOriginAccessIdentitiesPages", Input: "cloudfront.ListCloudFrontOriginAccessIdentitiesInput{}", Output: "cloudfront.ListCloudFrontOriginAccessIdentitiesOutput", OutputsExtractor: "CloudFrontOriginAccessIdentityList.Items", Multipage: true, NextPageMarker: "CloudFrontOriginAccessIdentityList.NextMarker"},
 		},
 	},
 	{
@@ -264,7 +264,7 @@ func New{{ $cmdName }}(sess *session.Session, g cloud.GraphAPI, l ...*logger.Log
 		cmd.logger = logger.DiscardLogger
 	}
 	if sess != nil {
-		cmd.api = {{ $tag.API }}.New(sess)
+		cmd.api = awspool.Default.Client(sess, "{{ $tag.API }}", func() interface{} { return {{ $tag.API }}.New(sess) }).({{$tag.API}}iface.{{ ApiToInterface $tag.API }})
 	}
 	cmd.graph = g
 	return cmd
@@ -314,7 +314,7 @@ func (cmd *{{ $cmdName }}) run(renv env.Running, params map[string]interface{})
 	var extracted interface{}
 	if v, ok := implementsResultExtractor(cmd); ok {
 		if output != nil {
-			extracted = v.ExtractResult(output)
+			extracted = extractResult(v, output)
 		} else {
 			renv.Log().Warning("{{ $tag.Action }} {{ $tag.Entity }}: AWS command returned nil output")
 		}
@@ -342,6 +342,12 @@ func (cmd *{{ $cmdName }}) run(renv env.Running, params map[string]interface{})
 			return nil, fmt.Errorf("cannot set params on command struct: %s", err)
 		}
 
+		if v, ok := implementsBeforeDryRun(cmd); ok {
+			if brErr := v.BeforeDryRun(renv); brErr != nil {
+				return nil, fmt.Errorf("before dry run: %s", brErr)
+			}
+		}
+
 		input := &{{ $tag.Input }}{}
 		input.SetDryRun(true)
 		if err := structInjector(cmd, input, renv.Context()) ; err != nil {
@@ -32,6 +32,7 @@ func generateFetcherFuncs() {
 		"ToUpper":        strings.ToUpper,
 		"Join":           strings.Join,
 		"ApiToInterface": aws.ApiToInterface,
+		"IAMAction":      aws.IAMAction,
 	}).Parse(fetchersTempl)
 
 	if err != nil {
@@ -108,6 +109,7 @@ func Build{{ Title $service.Name }}FetchFuncs(conf *Config) fetch.Funcs {
 		
 		{{- if $fetcher.Multipage }}
 		var badResErr error
+		var pages int
 		err := conf.APIs.{{ Title $fetcher.Api}}.{{ $fetcher.ApiMethod }}(&{{ $fetcher.Input }},
 			func(out *{{ $fetcher.Output }}, lastPage bool) (shouldContinue bool) {
 				{{- if ne $fetcher.OutputsContainers "" }}
@@ -127,7 +129,9 @@ func Build{{ Title $service.Name }}FetchFuncs(conf *Config) fetch.Funcs {
 				{{- if ne $fetcher.OutputsContainers "" }}
 				}
 				{{- end }}
-				return out.{{ $fetcher.NextPageMarker }} != nil
+				pages++
+				fetch.ReportProgress(ctx, fetch.Progress{ResourceType: "{{ $fetcher.ResourceType }}", Pages: pages, Resources: len(resources)})
+				return out.{{ $fetcher.NextPageMarker }} != nil && ctx.Err() == nil
 			})
 		if err != nil {
 			return resources, objects, err
@@ -156,4 +160,24 @@ func Build{{ Title $service.Name }}FetchFuncs(conf *Config) fetch.Funcs {
 {{- end }}
 	return funcs
 }
-{{- end }}`
+{{- end }}
+
+var fetchActionPerResourceType = map[string]string{
+{{- range $index, $service := . }}
+{{- range $index, $fetcher := $service.Fetchers }}
+	{{- if not $fetcher.ManualFetcher }}
+	"{{ $fetcher.ResourceType }}": "{{ IAMAction $fetcher.Api $fetcher.ApiMethod }}",
+	{{- end }}
+{{- end }}
+{{- end }}
+}
+
+var generatedCacheTypes = []interface{}{
+{{- range $index, $service := . }}
+{{- range $index, $fetcher := $service.Fetchers }}
+	{{- if not $fetcher.ManualFetcher }}
+	[]*{{ $fetcher.AWSType }}{},
+	{{- end }}
+{{- end }}
+{{- end }}
+}`
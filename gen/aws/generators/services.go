@@ -76,7 +76,6 @@ import (
 	"sync"
 
   awssdk "github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/aws/awserr"
   "github.com/aws/aws-sdk-go/aws/session"
   {{- range $index, $service := . }}
   {{- range $, $api := $service.Api }}
@@ -89,11 +88,10 @@ import (
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/fetch"
 	"github.com/wallix/awless/aws/fetch"
+	"github.com/wallix/awless/aws/pool"
 	tstore "github.com/wallix/triplestore"
 )
 
-const accessDenied = "Access Denied"
-
 var ServiceNames = []string{
 	{{- range $index, $service := . }}
   "{{ $service.Name }}",
@@ -151,7 +149,7 @@ func New{{ Title $service.Name }}(sess *session.Session, profile string, extraCo
 	{{- end}}	
 
 	{{- range $, $api := $service.Api }}
-		{{$api }}API := {{ $api }}.New(sess)
+		{{$api }}API := awspool.Default.Client(sess, "{{ $api }}", func() interface{} { return {{ $api }}.New(sess) }).({{ $api }}iface.{{ ApiToInterface $api }})
 	{{- end }}
 
 	fetchConfig := awsfetch.NewConfig(
@@ -166,7 +164,7 @@ func New{{ Title $service.Name }}(sess *session.Session, profile string, extraCo
 	{{- range $, $api := $service.Api }}
 		{{ApiToInterface $api }}: {{ $api }}API,
 	{{- end }}
-		fetcher: fetch.NewFetcher(awsfetch.Build{{ Title $service.Name }}FetchFuncs(fetchConfig)),
+		fetcher: fetch.NewFetcher(awsfetch.Build{{ Title $service.Name }}FetchFuncs(fetchConfig), awsfetch.Middlewares(fetchConfig)...),
 		config: extraConf,
 		region: region,
 		profile: profile,
@@ -199,26 +197,10 @@ func (s *{{ Title $service.Name }}) Fetch(ctx context.Context) (cloud.GraphAPI,
 		return graph.NewGraph(), nil
 	}
 
-	allErrors := new(fetch.Error)
-
   gph, err := s.fetcher.Fetch(context.WithValue(ctx, "region", s.region))
 	defer s.fetcher.Reset()
-	
-	for _, e := range *fetch.WrapError(err) {
-		switch ee := e.(type) {
-		case awserr.RequestFailure:
-			switch ee.Message() {
-			case accessDenied:
-				allErrors.Add(cloud.ErrFetchAccessDenied)
-			default:
-				allErrors.Add(ee)
-			}
-		case nil:
-			continue
-		default:
-			allErrors.Add(ee)
-		}
-	}
+
+	allErrors := fetch.WrapError(err)
 
 	if err := gph.AddResource(graph.InitResource(cloud.Region, s.region)); err != nil {
 		return gph, err
@@ -49,6 +49,8 @@ var mocksDefs = []*mockDef{
 			{FuncType: "list", AWSType: "ec2.Address", ApiMethod: "DescribeAddresses", Input: "ec2.DescribeAddressesInput", Output: "ec2.DescribeAddressesOutput", OutputsExtractor: "Addresses"},
 			{FuncType: "list", AWSType: "ec2.Snapshot", ApiMethod: "DescribeSnapshotsPages", Input: "ec2.DescribeSnapshotsInput", Output: "ec2.DescribeSnapshotsOutput", OutputsExtractor: "Snapshots", Multipage: true, NextPageMarker: "NextToken"},
 			{FuncType: "list", AWSType: "ec2.NetworkInterface", ApiMethod: "DescribeNetworkInterfaces", Input: "ec2.DescribeNetworkInterfacesInput", Output: "ec2.DescribeNetworkInterfacesOutput", OutputsExtractor: "NetworkInterfaces"},
+			{FuncType: "list", AWSType: "ec2.SpotFleetRequestConfig", ApiMethod: "DescribeSpotFleetRequestsPages", Input: "ec2.DescribeSpotFleetRequestsInput", Output: "ec2.DescribeSpotFleetRequestsOutput", OutputsExtractor: "SpotFleetRequestConfigs", Multipage: true, NextPageMarker: "NextToken"},
+			{FuncType: "list", AWSType: "ec2.SpotInstanceRequest", ApiMethod: "DescribeSpotInstanceRequests", Input: "ec2.DescribeSpotInstanceRequestsInput", Output: "ec2.DescribeSpotInstanceRequestsOutput", OutputsExtractor: "SpotInstanceRequests"},
 		},
 	},
 	{
@@ -67,6 +69,13 @@ var mocksDefs = []*mockDef{
 			{FuncType: "list", AWSType: "rds.DBSubnetGroup", ApiMethod: "DescribeDBSubnetGroupsPages", Input: "rds.DescribeDBSubnetGroupsInput", Output: "rds.DescribeDBSubnetGroupsOutput", OutputsExtractor: "DBSubnetGroups", Multipage: true, NextPageMarker: "Marker"},
 		},
 	},
+	{
+		Api: "dynamodb",
+		Funcs: []*mockFuncDef{
+			{FuncType: "list", MockField: "tableNames", AWSType: "string", ApiMethod: "ListTablesPages", Input: "dynamodb.ListTablesInput", Output: "dynamodb.ListTablesOutput", OutputsExtractor: "TableNames", Multipage: true, NextPageMarker: "LastEvaluatedTableName"},
+			{FuncType: "list", AWSType: "dynamodb.TableDescription", Manual: true},
+		},
+	},
 	{
 		Api: "autoscaling",
 		Funcs: []*mockFuncDef{
@@ -81,6 +90,22 @@ var mocksDefs = []*mockDef{
 			{FuncType: "list", AWSType: "acm.CertificateSummary", ApiMethod: "ListCertificatesPages", Input: "acm.ListCertificatesInput", Output: "acm.ListCertificatesOutput", OutputsExtractor: "CertificateSummaryList", Multipage: true, NextPageMarker: "NextToken"},
 		},
 	},
+	{
+		Api: "kms",
+		Funcs: []*mockFuncDef{
+			{FuncType: "list", MockField: "keyListEntries", AWSType: "kms.KeyListEntry", ApiMethod: "ListKeysPages", Input: "kms.ListKeysInput", Output: "kms.ListKeysOutput", OutputsExtractor: "Keys", Multipage: true, NextPageMarker: "NextMarker"},
+			{FuncType: "list", AWSType: "kms.KeyMetadata", Manual: true},
+			{FuncType: "list", AWSType: "kms.AliasListEntry", ApiMethod: "ListAliasesPages", Input: "kms.ListAliasesInput", Output: "kms.ListAliasesOutput", OutputsExtractor: "Aliases", Multipage: true, NextPageMarker: "NextMarker"},
+			{FuncType: "list", AWSType: "kms.GrantListEntry", Manual: true, MockFieldType: "mapslice"},
+		},
+	},
+	{
+		Api: "elasticache",
+		Funcs: []*mockFuncDef{
+			{FuncType: "list", AWSType: "elasticache.CacheCluster", ApiMethod: "DescribeCacheClustersPages", Input: "elasticache.DescribeCacheClustersInput", Output: "elasticache.DescribeCacheClustersOutput", OutputsExtractor: "CacheClusters", Multipage: true, NextPageMarker: "Marker"},
+			{FuncType: "list", AWSType: "elasticache.ReplicationGroup", ApiMethod: "DescribeReplicationGroupsPages", Input: "elasticache.DescribeReplicationGroupsInput", Output: "elasticache.DescribeReplicationGroupsOutput", OutputsExtractor: "ReplicationGroups", Multipage: true, NextPageMarker: "Marker"},
+		},
+	},
 	{
 		Api: "iam",
 		Funcs: []*mockFuncDef{
@@ -122,12 +147,31 @@ var mocksDefs = []*mockDef{
 		Funcs: []*mockFuncDef{
 			{FuncType: "list", AWSType: "route53.HostedZone", ApiMethod: "ListHostedZonesPages", Input: "route53.ListHostedZonesInput", Output: "route53.ListHostedZonesOutput", OutputsExtractor: "HostedZones", Multipage: true, NextPageMarker: "NextMarker"},
 			{FuncType: "list", AWSType: "route53.ResourceRecordSet", Manual: true, MockFieldType: "mapslice"},
+			{FuncType: "list", AWSType: "route53.HealthCheck", ApiMethod: "ListHealthChecksPages", Input: "route53.ListHealthChecksInput", Output: "route53.ListHealthChecksOutput", OutputsExtractor: "HealthChecks", Multipage: true, NextPageMarker: "NextMarker"},
 		},
 	},
 	{
 		Api: "lambda",
 		Funcs: []*mockFuncDef{
 			{FuncType: "list", AWSType: "lambda.FunctionConfiguration", ApiMethod: "ListFunctionsPages", Input: "lambda.ListFunctionsInput", Output: "lambda.ListFunctionsOutput", OutputsExtractor: "Functions", Multipage: true, NextPageMarker: "NextMarker"},
+			{FuncType: "list", AWSType: "lambda.EventSourceMappingConfiguration", ApiMethod: "ListEventSourceMappingsPages", Input: "lambda.ListEventSourceMappingsInput", Output: "lambda.ListEventSourceMappingsOutput", OutputsExtractor: "EventSourceMappings", Multipage: true, NextPageMarker: "NextMarker"},
+			{FuncType: "list", AWSType: "lambda.AliasConfiguration", Manual: true},
+		},
+	},
+	{
+		Api: "sfn",
+		Funcs: []*mockFuncDef{
+			{FuncType: "list", AWSType: "sfn.StateMachineListItem", ApiMethod: "ListStateMachinesPages", Input: "sfn.ListStateMachinesInput", Output: "sfn.ListStateMachinesOutput", OutputsExtractor: "StateMachines", Multipage: true, NextPageMarker: "NextToken"},
+			{FuncType: "list", AWSType: "sfn.ExecutionListItem", Manual: true},
+		},
+	},
+	{
+		Api: "apigateway",
+		Funcs: []*mockFuncDef{
+			{FuncType: "list", AWSType: "apigateway.RestApi", ApiMethod: "GetRestApisPages", Input: "apigateway.GetRestApisInput", Output: "apigateway.GetRestApisOutput", OutputsExtractor: "Items", Multipage: true, NextPageMarker: "Position"},
+			{FuncType: "list", AWSType: "apigateway.Stage", Manual: true, MockFieldType: "mapslice"},
+			{FuncType: "list", AWSType: "apigateway.Deployment", Manual: true, MockFieldType: "mapslice"},
+			{FuncType: "list", AWSType: "apigateway.Resource", Manual: true, MockFieldType: "mapslice"},
 		},
 	},
 	{
@@ -141,6 +185,7 @@ var mocksDefs = []*mockDef{
 		Api: "cloudfront",
 		Funcs: []*mockFuncDef{
 			{FuncType: "list", AWSType: "cloudfront.DistributionSummary", Manual: true},
+			{FuncType: "list", AWSType: "cloudfront.OriginAccessIdentitySummary", Manual: true},
 		},
 	},
 	{
@@ -166,6 +211,8 @@ var mocksDefs = []*mockDef{
 			{FuncType: "list", MockFieldType: "mapslice", MockField: "tasksNames", AWSType: "string", Manual: true},
 			{FuncType: "list", MockFieldType: "mapslice", MockField: "containerinstancesNames", AWSType: "string", Manual: true},
 			{FuncType: "list", MockFieldType: "mapslice", AWSType: "ecs.ContainerInstance", Manual: true},
+			{FuncType: "list", MockFieldType: "mapslice", MockField: "servicesNames", AWSType: "string", Manual: true},
+			{FuncType: "list", MockFieldType: "mapslice", AWSType: "ecs.Service", Manual: true},
 		},
 	},
 }
@@ -0,0 +1,433 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Scaffold a new spec command (struct, params rules, validators, result extraction
+// and acceptance test with mock) from a short YAML description.
+//
+// From the root of the repository:
+//
+//	go run gen/aws/scaffold/*.go gen/aws/scaffold/example.yml
+//
+// The description can also be derived from the AWS SDK API model of the service,
+// giving the entity, the awless API name and the operation of each action:
+//
+//	go run gen/aws/scaffold/*.go -model vendor/github.com/aws/aws-sdk-go/models/apis/sqs/2012-11-05/api-2.json \
+//		-entity queue -api sqs create=CreateQueue delete=DeleteQueue
+//
+// Add -yaml to only print the derived description, to review and edit it before scaffolding.
+//
+// then regenerate the runs, inits and mocks with `go generate gen/aws/generators/main.go`.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/wallix/awless/gen/aws"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	rootDirFlag string
+	forceFlag   bool
+	modelFlag   string
+	entityFlag  string
+	apiFlag     string
+	yamlFlag    bool
+)
+
+func main() {
+	flag.StringVar(&rootDirFlag, "root", ".", "Root directory of the awless repository")
+	flag.BoolVar(&forceFlag, "force", false, "Overwrite existing files")
+	flag.StringVar(&modelFlag, "model", "", "AWS SDK API model (api-2.json) to derive the description from")
+	flag.StringVar(&entityFlag, "entity", "", "Entity of the commands derived from the model (ex: queue)")
+	flag.StringVar(&apiFlag, "api", "", "awless API of the commands derived from the model (ex: sqs)")
+	flag.BoolVar(&yamlFlag, "yaml", false, "Print the description derived from the model instead of scaffolding")
+	flag.Parse()
+
+	var def *definition
+	if modelFlag != "" {
+		if flag.NArg() == 0 || entityFlag == "" || apiFlag == "" {
+			fmt.Fprintln(os.Stderr, "usage: scaffold [-root DIR] [-force] [-yaml] -model API-2.json -entity ENTITY -api API ACTION=OPERATION...")
+			os.Exit(2)
+		}
+		content, err := ioutil.ReadFile(modelFlag)
+		exitOn(err)
+		model, err := parseModel(content)
+		exitOn(err)
+		var warnings []string
+		def, warnings, err = definitionFromModel(model, entityFlag, apiFlag, flag.Args())
+		exitOn(err)
+		for _, w := range warnings {
+			fmt.Fprintf(os.Stderr, "scaffold: %s\n", w)
+		}
+		if yamlFlag {
+			out, err := yaml.Marshal(def)
+			exitOn(err)
+			fmt.Print(string(out))
+			return
+		}
+	} else {
+		if flag.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "usage: scaffold [-root DIR] [-force] DESCRIPTION.yml")
+			os.Exit(2)
+		}
+		content, err := ioutil.ReadFile(flag.Arg(0))
+		exitOn(err)
+		def, err = parseDefinition(content)
+		exitOn(err)
+	}
+
+	specFile := filepath.Join(rootDirFlag, "aws", "spec", def.Entity+".go")
+	testFile := filepath.Join(rootDirFlag, "acceptance", "aws", def.Entity+"_test.go")
+
+	exitOn(render(specFile, specTemplate, def))
+	exitOn(render(testFile, acceptanceTemplate, def))
+
+	fmt.Printf("generated %s\n", specFile)
+	fmt.Printf("generated %s\n", testFile)
+	fmt.Println("\nnext steps:")
+	fmt.Println("  - regenerate runs, inits and mocks: go generate gen/aws/generators/main.go")
+	fmt.Printf("  - document the params in aws/doc/paramsdoc.go ('<action>.%s')\n", def.Entity)
+	fmt.Println("  - revert the commands in template/revert.go, starting from the stub:")
+	stub, err := revertStub(def)
+	exitOn(err)
+	fmt.Printf("\n%s", stub)
+}
+
+func render(path, tpl string, def *definition) error {
+	if _, err := os.Stat(path); err == nil && !forceFlag {
+		return fmt.Errorf("%s already exists (use -force to overwrite)", path)
+	}
+
+	t, err := template.New(filepath.Base(path)).Funcs(template.FuncMap{
+		"ApiToInterface": aws.ApiToInterface,
+		"Title":          strings.Title,
+		"Join":           strings.Join,
+	}).Parse(tpl)
+	if err != nil {
+		return err
+	}
+
+	var buff bytes.Buffer
+	if err = t.Execute(&buff, def); err != nil {
+		return err
+	}
+
+	formatted, err := format.Source(buff.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting %s: %s\n%s", path, err, buff.String())
+	}
+
+	return ioutil.WriteFile(path, formatted, 0666)
+}
+
+// revertStub returns the code reverting the commands of the definition in template/revert.go
+func revertStub(def *definition) (string, error) {
+	t, err := template.New("revert").Parse(revertTemplate)
+	if err != nil {
+		return "", err
+	}
+	var buff bytes.Buffer
+	if err = t.Execute(&buff, def); err != nil {
+		return "", err
+	}
+	return buff.String(), nil
+}
+
+func exitOn(err error) {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "scaffold: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+type definition struct {
+	Entity   string     `yaml:"entity"`
+	API      string     `yaml:"api"`
+	Commands []*command `yaml:"commands"`
+}
+
+type command struct {
+	Action string   `yaml:"action"`
+	Call   string   `yaml:"call"`
+	Input  string   `yaml:"input,omitempty"`
+	Output string   `yaml:"output,omitempty"`
+	DryRun bool     `yaml:"dryrun,omitempty"`
+	Result string   `yaml:"result,omitempty"`
+	Params []*param `yaml:"params,omitempty"`
+
+	// computed
+	Entity, API string `yaml:"-"`
+}
+
+type param struct {
+	Name     string   `yaml:"name"`
+	Field    string   `yaml:"field"`
+	Type     string   `yaml:"type,omitempty"`
+	Required bool     `yaml:"required,omitempty"`
+	Enum     []string `yaml:"enum,omitempty"`
+}
+
+var (
+	entityRegex      = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	paramNameRegex   = regexp.MustCompile(`^[a-z][a-z0-9.-]*$`)
+	simpleFieldRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+)
+
+var goTypes = map[string]string{
+	"awsstr":         "*string",
+	"awsint64":       "*int64",
+	"awsbool":        "*bool",
+	"awsfloat":       "*float64",
+	"awsstringslice": "[]*string",
+	"awscsvstr":      "*string",
+
+	"awsstringpointermap": "*string",
+}
+
+func parseDefinition(content []byte) (*definition, error) {
+	def := new(definition)
+	if err := yaml.Unmarshal(content, def); err != nil {
+		return nil, err
+	}
+	if err := def.validate(); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+func (def *definition) validate() error {
+	if !entityRegex.MatchString(def.Entity) {
+		return fmt.Errorf("invalid entity '%s': expecting lowercase alphanumeric", def.Entity)
+	}
+	if def.API == "" {
+		return fmt.Errorf("missing api (ex: ec2, sqs, ...) for entity '%s'", def.Entity)
+	}
+	if len(def.Commands) == 0 {
+		return fmt.Errorf("no commands defined for entity '%s'", def.Entity)
+	}
+
+	for _, cmd := range def.Commands {
+		cmd.Entity, cmd.API = def.Entity, def.API
+		if cmd.Action == "" || cmd.Call == "" {
+			return fmt.Errorf("%s: action and call are required for each command", def.Entity)
+		}
+		if cmd.Input == "" {
+			cmd.Input = cmd.Call + "Input"
+		}
+		if cmd.Output == "" {
+			cmd.Output = cmd.Call + "Output"
+		}
+		for _, p := range cmd.Params {
+			if !paramNameRegex.MatchString(p.Name) {
+				return fmt.Errorf("%s %s: invalid param name '%s'", cmd.Action, def.Entity, p.Name)
+			}
+			if p.Field == "" {
+				return fmt.Errorf("%s %s: missing AWS field for param '%s'", cmd.Action, def.Entity, p.Name)
+			}
+			if p.Type == "" {
+				p.Type = "awsstr"
+			}
+			if _, ok := goTypes[p.Type]; !ok {
+				return fmt.Errorf("%s %s: unsupported type '%s' for param '%s'", cmd.Action, def.Entity, p.Type, p.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *command) StructName() string {
+	return strings.Title(c.Action) + strings.Title(c.Entity)
+}
+
+func (p *param) GoField() string {
+	var parts []string
+	for _, s := range strings.FieldsFunc(p.Name, func(r rune) bool { return r == '-' || r == '.' }) {
+		parts = append(parts, strings.Title(s))
+	}
+	return strings.Join(parts, "")
+}
+
+func (p *param) GoType() string {
+	return goTypes[p.Type]
+}
+
+// SimpleField reports whether the AWS field can be expected directly in the input struct literal
+func (p *param) SimpleField() bool {
+	return simpleFieldRegex.MatchString(p.Field)
+}
+
+func (c *command) RequiredParams() (out []string) {
+	for _, p := range c.Params {
+		if p.Required {
+			out = append(out, p.Name)
+		}
+	}
+	sort.Strings(out)
+	return
+}
+
+func (c *command) OptionalParams() (out []string) {
+	for _, p := range c.Params {
+		if !p.Required {
+			out = append(out, p.Name)
+		}
+	}
+	sort.Strings(out)
+	return
+}
+
+func (c *command) EnumParams() (out []*param) {
+	for _, p := range c.Params {
+		if len(p.Enum) > 0 {
+			out = append(out, p)
+		}
+	}
+	return
+}
+
+// TemplateValue returns the value given to the param in the acceptance test template
+func (p *param) TemplateValue() string {
+	if len(p.Enum) > 0 {
+		return p.Enum[0]
+	}
+	switch p.Type {
+	case "awsint64":
+		return "42"
+	case "awsfloat":
+		return "4.2"
+	case "awsbool":
+		return "true"
+	case "awsstringslice":
+		return fmt.Sprintf("[my-%s-1,my-%s-2]", p.Name, p.Name)
+	default:
+		return "my-" + p.Name
+	}
+}
+
+// ExpectedValue returns the Go expression of the value expected in the AWS input of the acceptance test
+func (p *param) ExpectedValue() string {
+	switch p.Type {
+	case "awsint64":
+		return "Int64(42)"
+	case "awsfloat":
+		return "Float64(4.2)"
+	case "awsbool":
+		return "Bool(true)"
+	case "awsstringslice":
+		return fmt.Sprintf(`[]*string{String("my-%s-1"), String("my-%s-2")}`, p.Name, p.Name)
+	default:
+		return fmt.Sprintf("String(%q)", p.TemplateValue())
+	}
+}
+
+func (c *command) TemplateLine() string {
+	line := []string{c.Action, c.Entity}
+	for _, p := range c.Params {
+		line = append(line, fmt.Sprintf("%s=%s", p.Name, p.TemplateValue()))
+	}
+	return strings.Join(line, " ")
+}
+
+// ResultLiteral returns the Go fields of the mocked AWS output holding the result of the acceptance test,
+// a nested result (ex: NatGateway.NatGatewayId) being held by the struct of the same name
+func (c *command) ResultLiteral() string {
+	literal := fmt.Sprintf(`String("my-%s-result")`, c.Entity)
+	fields := strings.Split(c.Result, ".")
+	for i := len(fields) - 1; i > 0; i-- {
+		literal = fmt.Sprintf("&%s.%s{%s: %s}", c.API, fields[i-1], fields[i], literal)
+	}
+	return fmt.Sprintf("%s: %s", fields[0], literal)
+}
+
+type revertParam struct {
+	Name       string
+	FromResult bool
+}
+
+func (d *definition) command(action string) *command {
+	for _, c := range d.Commands {
+		if c.Action == action {
+			return c
+		}
+	}
+	return nil
+}
+
+// RevertCreate returns the required params of the delete command reverting a create, the one
+// set with the AWS field of the create result being given the result of the command
+func (d *definition) RevertCreate() (out []*revertParam) {
+	create, del := d.command("create"), d.command("delete")
+	if create == nil || del == nil || create.Result == "" {
+		return nil
+	}
+	fields := strings.Split(create.Result, ".")
+	resultField := fields[len(fields)-1]
+	for _, p := range del.Params {
+		if p.Required {
+			out = append(out, &revertParam{Name: p.Name, FromResult: p.Field == resultField})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return
+}
+
+func (d *definition) CreateWithoutDelete() bool {
+	return d.command("create") != nil && d.command("delete") == nil
+}
+
+func (d *definition) CreateWithoutResult() bool {
+	create := d.command("create")
+	return create != nil && create.Result == ""
+}
+
+// NotRevertible reports whether the revert of the action has no counterpart in template/revert.go
+func (c *command) NotRevertible() bool {
+	switch c.Action {
+	case "create", "delete", "start", "stop", "attach", "detach":
+		return false
+	}
+	return true
+}
+
+func (d *definition) HasResult() bool {
+	for _, c := range d.Commands {
+		if c.Result != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *definition) HasValidators() bool {
+	for _, c := range d.Commands {
+		if len(c.EnumParams()) > 0 {
+			return true
+		}
+	}
+	return false
+}
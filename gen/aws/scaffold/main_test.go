@@ -0,0 +1,75 @@
+package main
+
+import "testing"
+
+func TestRevertStub(t *testing.T) {
+	def, err := parseDefinition([]byte(`
+entity: queue
+api: sqs
+commands:
+  - action: create
+    call: CreateQueue
+    result: QueueUrl
+    params:
+      - name: name
+        field: QueueName
+        required: true
+  - action: delete
+    call: DeleteQueue
+    params:
+      - name: url
+        field: QueueUrl
+        required: true
+      - name: region
+        field: Region
+        required: true
+  - action: update
+    call: SetQueueAttributes
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub, err := revertStub(def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := `// Revert stub of the queue commands, to review and complete in template/revert.go
+
+// in Revert(), as a case of the entities reverting a create:
+case "queue":
+	params = append(params, fmt.Sprintf("region=%s", cmd.Params["region"].String()))
+	params = append(params, fmt.Sprintf("url=%s", quoteParamIfNeeded(cmd.CmdResult)))
+
+// in isRevertible():
+if cmd.Entity == "queue" && cmd.Action == "update" {
+	return false
+}
+`
+	if got, want := stub, exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+
+	def, err = parseDefinition([]byte(`
+entity: topic
+api: sns
+commands:
+  - action: create
+    call: CreateTopic
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stub, err = revertStub(def); err != nil {
+		t.Fatal(err)
+	}
+	exp = `// Revert stub of the topic commands, to review and complete in template/revert.go
+
+// create topic has no delete command to be reverted with: add it or make create topic not revertible
+
+// create topic has no result: its revert cannot identify the created resource
+`
+	if got, want := stub, exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
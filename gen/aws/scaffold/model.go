@@ -0,0 +1,207 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// apiModel is the subset of an AWS SDK API model (models/apis/<service>/<version>/api-2.json)
+// needed to describe the commands of an entity
+type apiModel struct {
+	Operations map[string]struct {
+		Input  *shapeRef `json:"input"`
+		Output *shapeRef `json:"output"`
+	} `json:"operations"`
+	Shapes map[string]*shape `json:"shapes"`
+}
+
+type shapeRef struct {
+	Shape            string `json:"shape"`
+	IdempotencyToken bool   `json:"idempotencyToken"`
+}
+
+type shape struct {
+	Type     string               `json:"type"`
+	Required []string             `json:"required"`
+	Members  map[string]*shapeRef `json:"members"`
+	Member   *shapeRef            `json:"member"`
+	Enum     []string             `json:"enum"`
+}
+
+// resultSuffixes are the suffixes of the output fields taken as command result, by order of preference
+var resultSuffixes = []string{"Id", "Arn", "Url", "Name"}
+
+func parseModel(content []byte) (*apiModel, error) {
+	model := new(apiModel)
+	if err := json.Unmarshal(content, model); err != nil {
+		return nil, err
+	}
+	if len(model.Operations) == 0 {
+		return nil, fmt.Errorf("no operations found in model")
+	}
+	return model, nil
+}
+
+// definitionFromModel describes the commands of an entity from the operations of the AWS API model,
+// the actions being given as 'action=Operation' (ex: create=CreateQueue). Unsupported input members
+// (structures, maps, timestamps, ...) are skipped and reported in the returned warnings
+func definitionFromModel(model *apiModel, entity, api string, actions []string) (def *definition, warnings []string, err error) {
+	def = &definition{Entity: entity, API: api}
+	for _, a := range actions {
+		splits := strings.SplitN(a, "=", 2)
+		if len(splits) != 2 || splits[0] == "" || splits[1] == "" {
+			return nil, nil, fmt.Errorf("invalid action '%s': expecting action=Operation (ex: create=CreateQueue)", a)
+		}
+		action, call := splits[0], splits[1]
+		op, ok := model.Operations[call]
+		if !ok {
+			return nil, nil, fmt.Errorf("operation '%s' not found in model", call)
+		}
+		cmd := &command{Action: action, Call: call}
+		if op.Input != nil {
+			input := model.Shapes[op.Input.Shape]
+			if input == nil {
+				return nil, nil, fmt.Errorf("%s: input shape '%s' not found in model", call, op.Input.Shape)
+			}
+			required := make(map[string]bool)
+			for _, r := range input.Required {
+				required[r] = true
+			}
+			for _, name := range sortedMembers(input) {
+				member := input.Members[name]
+				if name == "DryRun" {
+					cmd.DryRun = true
+					continue
+				}
+				if member.IdempotencyToken || name == "ClientToken" {
+					continue
+				}
+				typ, enum, ok := model.awsType(member)
+				if !ok {
+					warnings = append(warnings, fmt.Sprintf("%s %s: skipping %s of unsupported shape '%s'", action, entity, name, member.Shape))
+					continue
+				}
+				cmd.Params = append(cmd.Params, &param{
+					Name:     paramName(entity, name),
+					Field:    goFieldName(name),
+					Type:     typ,
+					Required: required[name],
+					Enum:     enum,
+				})
+			}
+		}
+		if op.Output != nil && action != "delete" {
+			cmd.Result = model.resultField(op.Output.Shape)
+		}
+		def.Commands = append(def.Commands, cmd)
+	}
+	if err = def.validate(); err != nil {
+		return nil, nil, err
+	}
+	return def, warnings, nil
+}
+
+// awsType returns how the value of a template param is set in the member of the AWS input
+func (m *apiModel) awsType(ref *shapeRef) (string, []string, bool) {
+	s := m.Shapes[ref.Shape]
+	if s == nil {
+		return "", nil, false
+	}
+	switch s.Type {
+	case "string":
+		return "awsstr", s.Enum, true
+	case "integer", "long":
+		return "awsint64", nil, true
+	case "boolean":
+		return "awsbool", nil, true
+	case "double", "float":
+		return "awsfloat", nil, true
+	case "list":
+		if s.Member != nil {
+			if elem := m.Shapes[s.Member.Shape]; elem != nil && elem.Type == "string" {
+				return "awsstringslice", nil, true
+			}
+		}
+	}
+	return "", nil, false
+}
+
+// resultField returns the string field of the output (or of one of its structures)
+// identifying the resource, ex: QueueUrl or NatGateway.NatGatewayId
+func (m *apiModel) resultField(output string) string {
+	s := m.Shapes[output]
+	if s == nil {
+		return ""
+	}
+	for _, suffix := range resultSuffixes {
+		if field := m.stringMemberWithSuffix(s, suffix); field != "" {
+			return field
+		}
+		for _, name := range sortedMembers(s) {
+			nested := m.Shapes[s.Members[name].Shape]
+			if nested == nil || nested.Type != "structure" {
+				continue
+			}
+			if field := m.stringMemberWithSuffix(nested, suffix); field != "" {
+				return goFieldName(name) + "." + field
+			}
+		}
+	}
+	return ""
+}
+
+func (m *apiModel) stringMemberWithSuffix(s *shape, suffix string) string {
+	for _, name := range sortedMembers(s) {
+		if member := m.Shapes[s.Members[name].Shape]; member != nil && member.Type == "string" && strings.HasSuffix(name, suffix) {
+			return goFieldName(name)
+		}
+	}
+	return ""
+}
+
+func sortedMembers(s *shape) (names []string) {
+	for name := range s.Members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return
+}
+
+// goFieldName returns the name of the member in the SDK Go struct (ex: restApiId -> RestApiId)
+func goFieldName(member string) string {
+	if member == "" {
+		return member
+	}
+	r := []rune(member)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// paramName returns the template param name of a member, lowercased
+// and without the entity prefix (ex: QueueName -> name for entity queue)
+func paramName(entity, member string) string {
+	name := strings.ToLower(member)
+	if strings.HasPrefix(name, entity) && len(name) > len(entity) {
+		name = strings.TrimPrefix(name, entity)
+	}
+	return name
+}
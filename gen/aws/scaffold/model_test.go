@@ -0,0 +1,141 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testModel = `{
+  "operations": {
+    "CreateQueue": {"input": {"shape": "CreateQueueRequest"}, "output": {"shape": "CreateQueueResult"}},
+    "DeleteQueue": {"input": {"shape": "DeleteQueueRequest"}},
+    "CreateNatGateway": {"input": {"shape": "CreateNatGatewayRequest"}, "output": {"shape": "CreateNatGatewayResult"}}
+  },
+  "shapes": {
+    "CreateQueueRequest": {
+      "type": "structure",
+      "required": ["QueueName"],
+      "members": {
+        "QueueName": {"shape": "String"},
+        "Attributes": {"shape": "QueueAttributeMap"},
+        "DelaySeconds": {"shape": "Integer"},
+        "FifoQueue": {"shape": "Boolean"},
+        "Tags": {"shape": "StringList"},
+        "Mode": {"shape": "Mode"},
+        "ClientToken": {"shape": "String", "idempotencyToken": true}
+      }
+    },
+    "CreateQueueResult": {"type": "structure", "members": {"QueueName": {"shape": "String"}, "QueueUrl": {"shape": "String"}}},
+    "DeleteQueueRequest": {"type": "structure", "required": ["QueueUrl"], "members": {"QueueUrl": {"shape": "String"}}},
+    "CreateNatGatewayRequest": {
+      "type": "structure",
+      "required": ["subnetId"],
+      "members": {"subnetId": {"shape": "String"}, "DryRun": {"shape": "Boolean"}}
+    },
+    "CreateNatGatewayResult": {"type": "structure", "members": {"NatGateway": {"shape": "NatGateway"}}},
+    "NatGateway": {"type": "structure", "members": {"NatGatewayId": {"shape": "String"}, "SubnetId": {"shape": "String"}}},
+    "QueueAttributeMap": {"type": "map", "key": {"shape": "String"}, "value": {"shape": "String"}},
+    "StringList": {"type": "list", "member": {"shape": "String"}},
+    "Mode": {"type": "string", "enum": ["fast", "slow"]},
+    "String": {"type": "string"},
+    "Integer": {"type": "integer"},
+    "Boolean": {"type": "boolean"}
+  }
+}`
+
+func TestDefinitionFromModel(t *testing.T) {
+	model, err := parseModel([]byte(testModel))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("create and delete", func(t *testing.T) {
+		def, warnings, err := definitionFromModel(model, "queue", "sqs", []string{"create=CreateQueue", "delete=DeleteQueue"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := warnings, []string{"create queue: skipping Attributes of unsupported shape 'QueueAttributeMap'"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if got, want := len(def.Commands), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+
+		create := def.Commands[0]
+		if got, want := create.Result, "QueueUrl"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := create.Input, "CreateQueueInput"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		expParams := []*param{
+			{Name: "delayseconds", Field: "DelaySeconds", Type: "awsint64"},
+			{Name: "fifoqueue", Field: "FifoQueue", Type: "awsbool"},
+			{Name: "mode", Field: "Mode", Type: "awsstr", Enum: []string{"fast", "slow"}},
+			{Name: "name", Field: "QueueName", Type: "awsstr", Required: true},
+			{Name: "tags", Field: "Tags", Type: "awsstringslice"},
+		}
+		if got, want := create.Params, expParams; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+
+		del := def.Commands[1]
+		if got, want := del.Result, ""; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := del.Params, []*param{{Name: "url", Field: "QueueUrl", Type: "awsstr", Required: true}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("nested result and dry run", func(t *testing.T) {
+		def, _, err := definitionFromModel(model, "natgateway", "ec2", []string{"create=CreateNatGateway"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		create := def.Commands[0]
+		if got, want := create.Result, "NatGateway.NatGatewayId"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if !create.DryRun {
+			t.Fatal("expected dry run")
+		}
+		if got, want := create.Params, []*param{{Name: "subnetid", Field: "SubnetId", Type: "awsstr", Required: true}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tcases := []struct {
+			actions []string
+			expErr  string
+		}{
+			{actions: []string{"create"}, expErr: "invalid action 'create'"},
+			{actions: []string{"create=CreateTopic"}, expErr: "operation 'CreateTopic' not found"},
+			{actions: nil, expErr: "no commands defined"},
+		}
+		for _, tcase := range tcases {
+			_, _, err := definitionFromModel(model, "queue", "sqs", tcase.actions)
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%v: got %v, want error containing %q", tcase.actions, err, tcase.expErr)
+			}
+		}
+	})
+}
+
+func TestParamName(t *testing.T) {
+	tcases := []struct {
+		entity, member, exp string
+	}{
+		{entity: "queue", member: "QueueName", exp: "name"},
+		{entity: "queue", member: "Queue", exp: "queue"},
+		{entity: "restapi", member: "restApiId", exp: "id"},
+		{entity: "queue", member: "DelaySeconds", exp: "delayseconds"},
+	}
+	for _, tcase := range tcases {
+		if got, want := paramName(tcase.entity, tcase.member), tcase.exp; got != want {
+			t.Fatalf("%s %s: got %s, want %s", tcase.entity, tcase.member, got, want)
+		}
+	}
+}
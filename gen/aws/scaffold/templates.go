@@ -0,0 +1,126 @@
+package main
+
+const specTemplate = `/* Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsspec
+
+import (
+	{{- if .HasResult }}
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/{{ .API }}"
+	{{- end }}
+	"github.com/aws/aws-sdk-go/service/{{ .API }}/{{ .API }}iface"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/params"
+)
+{{ range $cmd := .Commands }}
+type {{ $cmd.StructName }} struct {
+	_      string ` + "`" + `action:"{{ $cmd.Action }}" entity:"{{ $cmd.Entity }}" awsAPI:"{{ $cmd.API }}" awsCall:"{{ $cmd.Call }}" awsInput:"{{ $cmd.API }}.{{ $cmd.Input }}" awsOutput:"{{ $cmd.API }}.{{ $cmd.Output }}"{{ if $cmd.DryRun }} awsDryRun:""{{ end }}` + "`" + `
+	logger *logger.Logger
+	graph  cloud.GraphAPI
+	api    {{ $cmd.API }}iface.{{ ApiToInterface $cmd.API }}
+	{{- range $p := $cmd.Params }}
+	{{ $p.GoField }} {{ $p.GoType }} ` + "`" + `awsName:"{{ $p.Field }}" awsType:"{{ $p.Type }}" templateName:"{{ $p.Name }}"` + "`" + `
+	{{- end }}
+}
+
+func (cmd *{{ $cmd.StructName }}) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(
+		{{- range $name := $cmd.RequiredParams }}params.Key("{{ $name }}"), {{ end }}
+		{{- with $cmd.OptionalParams }}params.Opt({{ range $i, $name := . }}{{ if $i }}, {{ end }}"{{ $name }}"{{ end }}){{ end }}),
+	{{- with $cmd.EnumParams }}
+		params.Validators{
+		{{- range $p := . }}
+			"{{ $p.Name }}": params.IsInEnumIgnoreCase({{ range $i, $v := $p.Enum }}{{ if $i }}, {{ end }}"{{ $v }}"{{ end }}),
+		{{- end }}
+		},
+	{{- end }}
+	)
+}
+{{- if $cmd.Result }}
+
+func (cmd *{{ $cmd.StructName }}) ExtractResult(i interface{}) string {
+	return awssdk.StringValue(i.(*{{ $cmd.API }}.{{ $cmd.Output }}).{{ $cmd.Result }})
+}
+{{- end }}
+{{ end }}`
+
+const acceptanceTemplate = `package awsat
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/{{ .API }}"
+)
+
+func Test{{ Title .Entity }}(t *testing.T) {
+{{- range $cmd := .Commands }}
+	t.Run("{{ $cmd.Action }}", func(t *testing.T) {
+		Template("{{ $cmd.TemplateLine }}").
+			Mock(&{{ $cmd.API }}Mock{
+				{{ $cmd.Call }}Func: func(param0 *{{ $cmd.API }}.{{ $cmd.Input }}) (*{{ $cmd.API }}.{{ $cmd.Output }}, error) {
+					return &{{ $cmd.API }}.{{ $cmd.Output }}{ {{- if $cmd.Result }}{{ $cmd.ResultLiteral }}{{ end }}}, nil
+				},
+			}).ExpectInput("{{ $cmd.Call }}", &{{ $cmd.API }}.{{ $cmd.Input }}{
+			{{- range $p := $cmd.Params }}
+			{{- if $p.SimpleField }}
+			{{ $p.Field }}: {{ $p.ExpectedValue }},
+			{{- else }}
+			// TODO: expect {{ $p.ExpectedValue }} in {{ $p.Field }}
+			{{- end }}
+			{{- end }}
+		}).
+			{{- if $cmd.Result }}
+			ExpectCommandResult("my-{{ $cmd.Entity }}-result").
+			{{- end }}
+			ExpectCalls("{{ $cmd.Call }}").Run(t)
+	})
+{{ end -}}
+}
+`
+
+const revertTemplate = `// Revert stub of the {{ .Entity }} commands, to review and complete in template/revert.go
+{{- with .RevertCreate }}
+
+// in Revert(), as a case of the entities reverting a create:
+case "{{ $.Entity }}":
+{{- range $p := . }}
+{{- if $p.FromResult }}
+	params = append(params, fmt.Sprintf("{{ $p.Name }}=%s", quoteParamIfNeeded(cmd.CmdResult)))
+{{- else }}
+	params = append(params, fmt.Sprintf("{{ $p.Name }}=%s", cmd.Params["{{ $p.Name }}"].String()))
+{{- end }}
+{{- end }}
+{{- end }}
+{{- range $cmd := .Commands }}
+{{- if $cmd.NotRevertible }}
+
+// in isRevertible():
+if cmd.Entity == "{{ $cmd.Entity }}" && cmd.Action == "{{ $cmd.Action }}" {
+	return false
+}
+{{- end }}
+{{- end }}
+{{- if .CreateWithoutDelete }}
+
+// create {{ .Entity }} has no delete command to be reverted with: add it or make create {{ .Entity }} not revertible
+{{- end }}
+{{- if .CreateWithoutResult }}
+
+// create {{ .Entity }} has no result: its revert cannot identify the created resource
+{{- end }}
+`
@@ -0,0 +1,75 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+// ParseAnnotation returns the key and value of an annotation written as key=value (ex: owner=teamA)
+func ParseAnnotation(s string) (string, string, error) {
+	splits := strings.SplitN(s, "=", 2)
+	if len(splits) != 2 {
+		return "", "", fmt.Errorf("invalid annotation '%s', expected key=value", s)
+	}
+	key, value := strings.TrimSpace(splits[0]), strings.TrimSpace(splits[1])
+	if key == "" {
+		return "", "", errors.New("invalid annotation: empty key")
+	}
+	return key, value, nil
+}
+
+// Annotate adds to the resources of the graph the annotations of the same resources in the overlay graph.
+// The resources of the overlay absent from the graph (ex: deleted since annotated) are ignored.
+func (g *Graph) Annotate(overlay *Graph) error {
+	annotated, err := overlay.allResources()
+	if err != nil {
+		return err
+	}
+	snap := g.store.Snapshot()
+	for _, res := range annotated {
+		annotations, ok := res.Properties()[properties.Annotations].([]string)
+		if !ok || len(annotations) == 0 || !isCloudResource(snap, res.Id()) {
+			continue
+		}
+		if typ, err := resolveResourceType(snap, res.Id()); err != nil || typ != res.Type() {
+			continue
+		}
+		for _, a := range annotations {
+			g.store.Add(tstore.SubjPred(res.Id(), rdf.Annotations).StringLiteral(a))
+		}
+	}
+	return nil
+}
+
+// SetAnnotations replaces the annotations of the resource in the overlay graph. The resource
+// is removed from the overlay when it has no annotations left.
+func (g *Graph) SetAnnotations(res *Resource, annotations []string) error {
+	g.store.Remove(g.store.Snapshot().WithSubject(res.Id())...)
+	if len(annotations) == 0 {
+		return nil
+	}
+	annotated := InitResource(res.Type(), res.Id())
+	annotated.SetProperty(properties.Annotations, annotations)
+	return g.AddResource(annotated)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud/rdf"
+)
+
+// ResourcesDiff classifies the resources that changed between two graphs
+type ResourcesDiff struct {
+	Created  []*Resource
+	Deleted  []*Resource
+	Modified []*ModifiedResource
+}
+
+// ModifiedResource is a resource present in both graphs with different properties
+type ModifiedResource struct {
+	*Resource
+	Changes []*PropertyChange
+}
+
+// PropertyChange is a property added (nil Old), removed (nil New) or updated on a resource
+type PropertyChange struct {
+	Name     string
+	Old, New interface{}
+}
+
+func (c *PropertyChange) String() string {
+	switch {
+	case c.Old == nil:
+		return fmt.Sprintf("%s: +%v", c.Name, c.New)
+	case c.New == nil:
+		return fmt.Sprintf("%s: -%v", c.Name, c.Old)
+	default:
+		return fmt.Sprintf("%s: %v -> %v", c.Name, c.Old, c.New)
+	}
+}
+
+func (d *ResourcesDiff) Empty() bool {
+	return len(d.Created) == 0 && len(d.Deleted) == 0 && len(d.Modified) == 0
+}
+
+// DiffResources compares the resources of the given types (all when none given) from the old graph to the new graph
+func DiffResources(from, to *Graph, resourceTypes ...string) (*ResourcesDiff, error) {
+	if len(resourceTypes) == 0 {
+		resourceTypes = cloudResourceTypes(from, to)
+	}
+	oldResources, err := from.GetAllResources(resourceTypes...)
+	if err != nil {
+		return nil, err
+	}
+	newResources, err := to.GetAllResources(resourceTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := new(ResourcesDiff)
+	olds := make(map[string]*Resource)
+	for _, r := range oldResources {
+		olds[r.Type()+"/"+r.Id()] = r
+	}
+	for _, r := range newResources {
+		key := r.Type() + "/" + r.Id()
+		o, ok := olds[key]
+		if !ok {
+			diff.Created = append(diff.Created, r)
+			continue
+		}
+		delete(olds, key)
+		if changes := propertiesChanges(o.Properties(), r.Properties()); len(changes) > 0 {
+			diff.Modified = append(diff.Modified, &ModifiedResource{Resource: r, Changes: changes})
+		}
+	}
+	for _, r := range olds {
+		diff.Deleted = append(diff.Deleted, r)
+	}
+
+	sortResources(diff.Created)
+	sortResources(diff.Deleted)
+	sort.Slice(diff.Modified, func(i, j int) bool {
+		return lessResource(diff.Modified[i].Resource, diff.Modified[j].Resource)
+	})
+	return diff, nil
+}
+
+func cloudResourceTypes(graphs ...*Graph) (types []string) {
+	unique := make(map[string]bool)
+	for _, g := range graphs {
+		for _, t := range g.store.Snapshot().WithPredicate(rdf.RdfType) {
+			node, ok := t.Object().Resource()
+			if !ok || !strings.HasPrefix(node, rdf.CloudOwlNS+":") {
+				continue
+			}
+			typ, err := unmarshalResourceType(t.Object())
+			if err == nil && !unique[typ] {
+				unique[typ] = true
+				types = append(types, typ)
+			}
+		}
+	}
+	sort.Strings(types)
+	return
+}
+
+func propertiesChanges(from, to map[string]interface{}) (changes []*PropertyChange) {
+	for k, v := range to {
+		if ov, ok := from[k]; !ok || !reflect.DeepEqual(ov, v) {
+			changes = append(changes, &PropertyChange{Name: k, Old: ov, New: v})
+		}
+	}
+	for k, v := range from {
+		if _, ok := to[k]; !ok {
+			changes = append(changes, &PropertyChange{Name: k, Old: v})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return
+}
+
+func sortResources(resources []*Resource) {
+	sort.Slice(resources, func(i, j int) bool { return lessResource(resources[i], resources[j]) })
+}
+
+func lessResource(a, b *Resource) bool {
+	if a.Type() != b.Type() {
+		return a.Type() < b.Type()
+	}
+	return a.Id() < b.Id()
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffResources(t *testing.T) {
+	from := NewGraph()
+	inst1 := InitResource("instance", "inst_1")
+	inst1.SetProperty("State", "running")
+	inst1.SetProperty("Name", "web")
+	inst2 := InitResource("instance", "inst_2")
+	inst2.SetProperty("State", "running")
+	from.AddResource(inst1, inst2, InitResource("subnet", "sub_1"), InitResource("vpc", "vpc_1"))
+
+	to := NewGraph()
+	inst1 = InitResource("instance", "inst_1")
+	inst1.SetProperty("State", "stopped")
+	inst1.SetProperty("Type", "t2.micro")
+	inst2 = InitResource("instance", "inst_2")
+	inst2.SetProperty("State", "running")
+	to.AddResource(inst1, inst2, InitResource("instance", "inst_3"), InitResource("vpc", "vpc_2"))
+
+	diff, err := DiffResources(from, to, "instance", "subnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := Resources(diff.Created).Map(func(r *Resource) string { return r.Id() }), []string{"inst_3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := Resources(diff.Deleted).Map(func(r *Resource) string { return r.Id() }), []string{"sub_1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := len(diff.Modified), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	var changes []string
+	for _, c := range diff.Modified[0].Changes {
+		changes = append(changes, c.String())
+	}
+	if got, want := changes, []string{"Name: -web", "State: running -> stopped", "Type: +t2.micro"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if diff, err = DiffResources(to, to, "instance", "vpc"); err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("expected no diff, got %#v", diff)
+	}
+}
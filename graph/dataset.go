@@ -0,0 +1,662 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+// Serialization formats of datasets, to be ingested by RDF tooling and SPARQL stores
+const (
+	NQuadsFormat = "nquads"
+	JSONLDFormat = "jsonld"
+)
+
+var DatasetFormats = []string{NQuadsFormat, JSONLDFormat}
+
+// Base IRIs of the resources and of the named graphs of serialized datasets.
+// Ex: the instance i-1234 is serialized as <http://awless.io/resources/i-1234>
+const (
+	ResourcesBaseIRI = "http://awless.io/resources/"
+	GraphsBaseIRI    = "http://awless.io/graphs/"
+)
+
+// Namespaces maps the prefixes of the awless vocabulary (ex: cloud:tags) to IRIs,
+// so that serialized datasets only hold absolute IRIs
+var Namespaces = map[string]string{
+	rdf.RdfNS:      "http://www.w3.org/1999/02/22-rdf-syntax-ns#",
+	rdf.RdfsNS:     "http://www.w3.org/2000/01/rdf-schema#",
+	rdf.XsdNS:      "http://www.w3.org/2001/XMLSchema#",
+	rdf.CloudNS:    "http://awless.io/rdf/cloud#",
+	rdf.CloudRelNS: "http://awless.io/rdf/cloud-rel#",
+	rdf.CloudOwlNS: "http://awless.io/rdf/cloud-owl#",
+	rdf.NetNS:      "http://awless.io/rdf/net#",
+	rdf.NetowlNS:   "http://awless.io/rdf/net-owl#",
+}
+
+// Dataset gathers graphs per name (ex: <profile>/<region>). The graph with an empty name is the default graph.
+type Dataset map[string]*Graph
+
+// MarshalTo writes the dataset as N-Quads (each triple followed by the IRI of its named graph)
+// or as a JSON-LD document (a node object per resource, in a named graph object per graph)
+func (d Dataset) MarshalTo(w io.Writer, format string) error {
+	switch format {
+	case NQuadsFormat:
+		return d.marshalNQuadsTo(w)
+	case JSONLDFormat:
+		return d.marshalJSONLDTo(w)
+	default:
+		return fmt.Errorf("unknown dataset format '%s', expected one of %s", format, strings.Join(DatasetFormats, ", "))
+	}
+}
+
+// UnmarshalDataset reads a dataset written as N-Quads or JSON-LD. JSON-LD documents are read as
+// written by awless: prefixes defined in an embedded @context, no remote context nor @list.
+func UnmarshalDataset(r io.Reader, format string) (Dataset, error) {
+	switch format {
+	case NQuadsFormat:
+		return unmarshalNQuads(r)
+	case JSONLDFormat:
+		return unmarshalJSONLD(r)
+	default:
+		return nil, fmt.Errorf("unknown dataset format '%s', expected one of %s", format, strings.Join(DatasetFormats, ", "))
+	}
+}
+
+// ResourcesGraph returns a graph holding the resources of the given types with their relations
+// and the nodes they reference (ex: firewall rules, grants). Ex: to split a graph per service.
+func (g *Graph) ResourcesGraph(types ...string) (*Graph, error) {
+	resources, err := g.GetAllResources(types...)
+	if err != nil {
+		return nil, err
+	}
+	snap := g.store.Snapshot()
+	sub := NewGraph()
+	visited := make(map[string]bool)
+	var collect func(string)
+	collect = func(node string) {
+		if visited[node] {
+			return
+		}
+		visited[node] = true
+		for _, t := range snap.WithSubject(node) {
+			sub.store.Add(t)
+			if ref, ok := t.Object().Bnode(); ok {
+				collect(ref)
+			} else if ref, ok := t.Object().Resource(); ok && t.Predicate() != rdf.RdfType && !isCloudResource(snap, ref) {
+				collect(ref)
+			}
+		}
+	}
+	for _, r := range resources {
+		collect(r.Id())
+	}
+	return sub, nil
+}
+
+func (d Dataset) names() []string {
+	var names []string
+	for name := range d {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (d Dataset) marshalNQuadsTo(w io.Writer) error {
+	buff := bufio.NewWriter(w)
+	for _, name := range d.names() {
+		var graphTerm string
+		if name != "" {
+			graphTerm = " <" + graphIRI(name) + ">"
+		}
+		triples, bnodes := sortedTriples(d[name])
+		for _, t := range triples {
+			buff.WriteString(subjectTerm(t.Subject(), bnodes) + " <" + expandIRI(t.Predicate()) + "> " + objectTerm(t.Object()) + graphTerm + " .\n")
+		}
+	}
+	return buff.Flush()
+}
+
+func (d Dataset) marshalJSONLDTo(w io.Writer) error {
+	context := make(map[string]string)
+	for prefix, ns := range Namespaces {
+		context[prefix] = ns
+	}
+	var graphs []interface{}
+	for _, name := range d.names() {
+		nodes := jsonldNodes(d[name])
+		if name == "" {
+			graphs = append(graphs, nodes...)
+		} else {
+			graphs = append(graphs, map[string]interface{}{"@id": graphIRI(name), "@graph": nodes})
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]interface{}{"@context": context, "@graph": graphs})
+}
+
+func jsonldNodes(g *Graph) []interface{} {
+	triples, bnodes := sortedTriples(g)
+	var nodes []interface{}
+	var node map[string]interface{}
+	var current string
+	for _, t := range triples {
+		if node == nil || t.Subject() != current {
+			current = t.Subject()
+			node = map[string]interface{}{"@id": strings.Trim(subjectTerm(current, bnodes), "<>")}
+			nodes = append(nodes, node)
+		}
+		if t.Predicate() == rdf.RdfType {
+			if typ, ok := t.Object().Resource(); ok {
+				types, _ := node["@type"].([]string)
+				node["@type"] = append(types, jsonldIRI(typ))
+				continue
+			}
+		}
+		key := jsonldIRI(t.Predicate())
+		values, _ := node[key].([]interface{})
+		node[key] = append(values, jsonldValue(t.Object()))
+	}
+	return nodes
+}
+
+func jsonldValue(obj tstore.Object) interface{} {
+	if bnode, ok := obj.Bnode(); ok {
+		return map[string]string{"@id": "_:" + bnode}
+	}
+	if res, ok := obj.Resource(); ok {
+		return map[string]string{"@id": jsonldIRI(res)}
+	}
+	lit, _ := obj.Literal()
+	switch {
+	case lit.Lang() != "":
+		return map[string]string{"@value": lit.Value(), "@language": lit.Lang()}
+	case lit.Type() == tstore.XsdString:
+		return lit.Value()
+	default:
+		return map[string]string{"@value": lit.Value(), "@type": string(lit.Type())}
+	}
+}
+
+// jsonldIRI returns the prefixed name of the awless vocabulary (defined in the @context) or the absolute IRI of a resource
+func jsonldIRI(id string) string {
+	if splits := strings.SplitN(id, ":", 2); len(splits) == 2 {
+		if _, ok := Namespaces[splits[0]]; ok {
+			return id
+		}
+	}
+	return expandIRI(id)
+}
+
+// sortedTriples returns the triples of the graph sorted by subject, predicate and object, along with
+// the nodes used as blank nodes (ex: grantees), serialized as such when subjects
+func sortedTriples(g *Graph) ([]tstore.Triple, map[string]bool) {
+	triples := g.store.CopyTriples()
+	bnodes := make(map[string]bool)
+	keys := make(map[tstore.Triple]string)
+	for _, t := range triples {
+		if bnode, ok := t.Object().Bnode(); ok {
+			bnodes[bnode] = true
+		}
+		keys[t] = t.Subject() + "\x00" + t.Predicate() + "\x00" + objectTerm(t.Object())
+	}
+	sort.Slice(triples, func(i, j int) bool { return keys[triples[i]] < keys[triples[j]] })
+	return triples, bnodes
+}
+
+func subjectTerm(sub string, bnodes map[string]bool) string {
+	if bnodes[sub] {
+		return "_:" + sub
+	}
+	return "<" + expandIRI(sub) + ">"
+}
+
+func objectTerm(obj tstore.Object) string {
+	if bnode, ok := obj.Bnode(); ok {
+		return "_:" + bnode
+	}
+	if res, ok := obj.Resource(); ok {
+		return "<" + expandIRI(res) + ">"
+	}
+	lit, _ := obj.Literal()
+	term := `"` + escapeLiteral(lit.Value()) + `"`
+	switch {
+	case lit.Lang() != "":
+		term += "@" + lit.Lang()
+	case lit.Type() != tstore.XsdString:
+		term += "^^<" + expandIRI(string(lit.Type())) + ">"
+	}
+	return term
+}
+
+// expandIRI returns the absolute IRI of a prefixed name (ex: cloud:tags) or of a resource id
+func expandIRI(id string) string {
+	if splits := strings.SplitN(id, ":", 2); len(splits) == 2 {
+		if ns, ok := Namespaces[splits[0]]; ok {
+			return ns + splits[1]
+		}
+	}
+	return ResourcesBaseIRI + url.PathEscape(id)
+}
+
+// compactIRI returns the prefixed name or the resource id of an absolute IRI. Other IRIs are kept as is.
+func compactIRI(iri string) string {
+	for prefix, ns := range Namespaces {
+		if strings.HasPrefix(iri, ns) {
+			return prefix + ":" + iri[len(ns):]
+		}
+	}
+	if strings.HasPrefix(iri, ResourcesBaseIRI) {
+		if id, err := url.PathUnescape(iri[len(ResourcesBaseIRI):]); err == nil {
+			return id
+		}
+	}
+	return iri
+}
+
+func graphIRI(name string) string {
+	segments := strings.Split(name, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return GraphsBaseIRI + strings.Join(segments, "/")
+}
+
+func graphName(iri string) string {
+	if !strings.HasPrefix(iri, GraphsBaseIRI) {
+		return iri
+	}
+	segments := strings.Split(iri[len(GraphsBaseIRI):], "/")
+	for i, s := range segments {
+		if unescaped, err := url.PathUnescape(s); err == nil {
+			segments[i] = unescaped
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+var literalEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+func escapeLiteral(s string) string {
+	return literalEscaper.Replace(s)
+}
+
+// literalObject returns the literal of the given value, datatype (as prefixed name) and language
+func literalObject(value, datatype, lang string) (tstore.Object, error) {
+	if lang != "" {
+		return tstore.StringLiteralWithLang(value, lang), nil
+	}
+	switch tstore.XsdType(datatype) {
+	case tstore.XsdBoolean:
+		b, err := strconv.ParseBool(value)
+		return tstore.BooleanLiteral(b), err
+	case tstore.XsdInteger:
+		i, err := strconv.Atoi(value)
+		return tstore.IntegerLiteral(i), err
+	case tstore.XsdUinteger:
+		u, err := strconv.ParseUint(value, 10, 64)
+		return tstore.UintegerLiteral(uint(u)), err
+	case tstore.XsdByte:
+		i, err := strconv.ParseInt(value, 10, 8)
+		return tstore.Int8Literal(int8(i)), err
+	case tstore.XsdShort:
+		i, err := strconv.ParseInt(value, 10, 16)
+		return tstore.Int16Literal(int16(i)), err
+	case tstore.XsdUnsignedByte:
+		u, err := strconv.ParseUint(value, 10, 8)
+		return tstore.Uint8Literal(uint8(u)), err
+	case tstore.XsdUnsignedShort:
+		u, err := strconv.ParseUint(value, 10, 16)
+		return tstore.Uint16Literal(uint16(u)), err
+	case tstore.XsdDouble:
+		f, err := strconv.ParseFloat(value, 64)
+		return tstore.Float64Literal(f), err
+	case tstore.XsdFloat:
+		f, err := strconv.ParseFloat(value, 32)
+		return tstore.Float32Literal(float32(f)), err
+	case tstore.XsdDateTime:
+		var t time.Time
+		err := t.UnmarshalText([]byte(value))
+		return tstore.DateTimeLiteral(t), err
+	default: // xsd:string and datatypes unknown to awless
+		return tstore.StringLiteral(value), nil
+	}
+}
+
+func unmarshalNQuads(r io.Reader) (Dataset, error) {
+	d := make(Dataset)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	var line int
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		t, name, err := parseNQuad(text)
+		if err != nil {
+			return d, fmt.Errorf("n-quads line %d: %s", line, err)
+		}
+		if _, ok := d[name]; !ok {
+			d[name] = NewGraph()
+		}
+		d[name].store.Add(t)
+	}
+	return d, scanner.Err()
+}
+
+type nquadTerm struct {
+	kind                  byte // '<' IRI, '_' blank node, '"' literal
+	value, datatype, lang string
+}
+
+func parseNQuad(line string) (tstore.Triple, string, error) {
+	var terms []nquadTerm
+	rest := line
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			return nil, "", errors.New("missing final '.'")
+		}
+		if rest == "." || strings.HasPrefix(rest, ". ") || strings.HasPrefix(rest, ".#") {
+			break
+		}
+		term, remaining, err := parseNQuadTerm(rest)
+		if err != nil {
+			return nil, "", err
+		}
+		terms = append(terms, term)
+		rest = remaining
+	}
+	if len(terms) != 3 && len(terms) != 4 {
+		return nil, "", fmt.Errorf("expected 3 or 4 terms, got %d", len(terms))
+	}
+	sub, pred, obj := terms[0], terms[1], terms[2]
+	if sub.kind == '"' || pred.kind != '<' {
+		return nil, "", errors.New("invalid subject or predicate")
+	}
+	builder := tstore.SubjPred(compactIRI(sub.value), compactIRI(pred.value))
+	if sub.kind == '_' {
+		builder = tstore.SubjPred(sub.value, compactIRI(pred.value))
+	}
+
+	var name string
+	if len(terms) == 4 {
+		if terms[3].kind == '"' {
+			return nil, "", errors.New("invalid graph name")
+		}
+		name = graphName(terms[3].value)
+	}
+
+	switch obj.kind {
+	case '<':
+		return builder.Resource(compactIRI(obj.value)), name, nil
+	case '_':
+		return builder.Bnode(obj.value), name, nil
+	default:
+		lit, err := literalObject(obj.value, compactIRI(obj.datatype), obj.lang)
+		if err != nil {
+			return nil, "", err
+		}
+		return builder.Object(lit), name, nil
+	}
+}
+
+func parseNQuadTerm(s string) (nquadTerm, string, error) {
+	switch {
+	case s[0] == '<':
+		end := strings.IndexByte(s, '>')
+		if end < 0 {
+			return nquadTerm{}, "", errors.New("unterminated IRI")
+		}
+		return nquadTerm{kind: '<', value: s[1:end]}, s[end+1:], nil
+	case strings.HasPrefix(s, "_:"):
+		end := strings.IndexAny(s, " \t")
+		if end < 0 {
+			end = len(s)
+		}
+		return nquadTerm{kind: '_', value: s[2:end]}, s[end:], nil
+	case s[0] == '"':
+		var value bytes.Buffer
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] != '\\' {
+				value.WriteByte(s[i])
+				continue
+			}
+			if i++; i >= len(s) {
+				break
+			}
+			switch s[i] {
+			case 't':
+				value.WriteByte('\t')
+			case 'b':
+				value.WriteByte('\b')
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 'f':
+				value.WriteByte('\f')
+			case 'u', 'U':
+				size := 4
+				if s[i] == 'U' {
+					size = 8
+				}
+				if i+size >= len(s) {
+					return nquadTerm{}, "", errors.New("invalid unicode escape")
+				}
+				code, err := strconv.ParseUint(s[i+1:i+1+size], 16, 32)
+				if err != nil || code > math.MaxInt32 {
+					return nquadTerm{}, "", errors.New("invalid unicode escape")
+				}
+				value.WriteRune(rune(code))
+				i += size
+			default:
+				value.WriteByte(s[i])
+			}
+		}
+		if i >= len(s) {
+			return nquadTerm{}, "", errors.New("unterminated literal")
+		}
+		term, rest := nquadTerm{kind: '"', value: value.String()}, s[i+1:]
+		switch {
+		case strings.HasPrefix(rest, "^^<"):
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return nquadTerm{}, "", errors.New("unterminated datatype IRI")
+			}
+			term.datatype, rest = rest[3:end], rest[end+1:]
+		case strings.HasPrefix(rest, "@"):
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				end = len(rest)
+			}
+			term.lang, rest = rest[1:end], rest[end:]
+		}
+		return term, rest, nil
+	default:
+		return nquadTerm{}, "", fmt.Errorf("unexpected term at '%s'", s)
+	}
+}
+
+func unmarshalJSONLD(r io.Reader) (Dataset, error) {
+	var doc interface{}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("json-ld: %s", err)
+	}
+	dec := &jsonldDecoder{dataset: make(Dataset), prefixes: make(map[string]string)}
+	top := doc
+	if m, ok := doc.(map[string]interface{}); ok {
+		if ctx, ok := m["@context"].(map[string]interface{}); ok {
+			for prefix, ns := range ctx {
+				if s, ok := ns.(string); ok {
+					dec.prefixes[prefix] = s
+				}
+			}
+		}
+		if g, ok := m["@graph"]; ok {
+			top = g
+		}
+	}
+	for _, elem := range asList(top) {
+		m, ok := elem.(map[string]interface{})
+		if !ok {
+			return dec.dataset, fmt.Errorf("json-ld: expected node objects, got %T", elem)
+		}
+		if nodes, isGraph := m["@graph"]; isGraph {
+			id, _ := m["@id"].(string)
+			name := graphName(dec.expand(id))
+			for _, n := range asList(nodes) {
+				if _, err := dec.node(name, n); err != nil {
+					return dec.dataset, err
+				}
+			}
+		} else if _, err := dec.node("", m); err != nil {
+			return dec.dataset, err
+		}
+	}
+	return dec.dataset, nil
+}
+
+type jsonldDecoder struct {
+	dataset  Dataset
+	prefixes map[string]string
+	bnodes   int
+}
+
+// expand returns the absolute IRI of a compact IRI (ex: cloud:tags) given the prefixes of the @context
+func (dec *jsonldDecoder) expand(term string) string {
+	if splits := strings.SplitN(term, ":", 2); len(splits) == 2 && !strings.HasPrefix(splits[1], "//") {
+		if ns, ok := dec.prefixes[splits[0]]; ok {
+			return ns + splits[1]
+		}
+	}
+	return term
+}
+
+func (dec *jsonldDecoder) add(name string, t tstore.Triple) {
+	if _, ok := dec.dataset[name]; !ok {
+		dec.dataset[name] = NewGraph()
+	}
+	dec.dataset[name].store.Add(t)
+}
+
+// node adds the triples of a node object (and of its nested nodes) to the named graph, returning its subject
+func (dec *jsonldDecoder) node(name string, n interface{}) (string, error) {
+	m, ok := n.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("json-ld: expected a node object, got %T", n)
+	}
+	var sub string
+	if id, ok := m["@id"].(string); ok && !strings.HasPrefix(id, "_:") {
+		sub = compactIRI(dec.expand(id))
+	} else if ok {
+		sub = strings.TrimPrefix(id, "_:")
+	} else {
+		dec.bnodes++
+		sub = fmt.Sprintf("b%d", dec.bnodes)
+	}
+
+	for _, typ := range asList(m["@type"]) {
+		if s, ok := typ.(string); ok {
+			dec.add(name, tstore.SubjPred(sub, rdf.RdfType).Resource(compactIRI(dec.expand(s))))
+		}
+	}
+	for key, values := range m {
+		if strings.HasPrefix(key, "@") {
+			continue
+		}
+		pred := compactIRI(dec.expand(key))
+		for _, v := range asList(values) {
+			obj, err := dec.object(name, v)
+			if err != nil {
+				return sub, fmt.Errorf("json-ld: %s: %s", key, err)
+			}
+			dec.add(name, tstore.SubjPred(sub, pred).Object(obj))
+		}
+	}
+	return sub, nil
+}
+
+func (dec *jsonldDecoder) object(name string, v interface{}) (tstore.Object, error) {
+	switch vv := v.(type) {
+	case string:
+		return tstore.StringLiteral(vv), nil
+	case bool:
+		return tstore.BooleanLiteral(vv), nil
+	case float64:
+		if vv == math.Trunc(vv) && math.Abs(vv) < math.MaxInt32 {
+			return tstore.IntegerLiteral(int(vv)), nil
+		}
+		return tstore.Float64Literal(vv), nil
+	case map[string]interface{}:
+		if value, ok := vv["@value"]; ok {
+			datatype, _ := vv["@type"].(string)
+			lang, _ := vv["@language"].(string)
+			if s, isString := value.(string); isString {
+				return literalObject(s, compactIRI(dec.expand(datatype)), lang)
+			}
+			return dec.object(name, value)
+		}
+		id, hasID := vv["@id"].(string)
+		if hasID && len(vv) == 1 {
+			if strings.HasPrefix(id, "_:") {
+				return tstore.SubjPred("", "").Bnode(strings.TrimPrefix(id, "_:")).Object(), nil
+			}
+			return tstore.Resource(compactIRI(dec.expand(id))), nil
+		}
+		sub, err := dec.node(name, vv)
+		if err != nil {
+			return nil, err
+		}
+		if !hasID || strings.HasPrefix(id, "_:") {
+			return tstore.SubjPred("", "").Bnode(sub).Object(), nil
+		}
+		return tstore.Resource(sub), nil
+	default:
+		return nil, fmt.Errorf("unsupported value %v", v)
+	}
+}
+
+func asList(v interface{}) []interface{} {
+	switch vv := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return vv
+	default:
+		return []interface{}{vv}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDatasetRoundTrip(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/16")
+	launched := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	euWest := NewGraph()
+	sub := InitResource("subnet", "sub_1")
+	inst := InitResource("instance", "inst_1")
+	inst.SetProperty("Name", `my "redis"`+"\nserver")
+	inst.SetProperty("Tags", []string{"Env=prod", "Dept=IT"})
+	inst.SetProperty("Launched", launched)
+	inst.SetProperty("Memory", 2)
+	inst.SetProperty("PublicDNS", "")
+	sg := InitResource("securitygroup", "sg_1")
+	sg.SetProperty("InboundRules", []*FirewallRule{{PortRange: PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", IPRanges: []*net.IPNet{cidr}}})
+	if err := euWest.AddResource(sub, inst, sg); err != nil {
+		t.Fatal(err)
+	}
+	euWest.AddParentRelation(sub, inst)
+	euWest.AddAppliesOnRelation(sg, inst)
+
+	global := NewGraph()
+	bucket := InitResource("bucket", "arn:aws:s3:::my/bucket")
+	bucket.SetProperty("Grants", []*Grant{{Permission: "READ", Grantee: Grantee{GranteeID: "usr_1", GranteeType: "CanonicalUser"}}})
+	if err := global.AddResource(bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	dataset := Dataset{"default/eu-west-1": euWest, "default/global": global}
+
+	triples := func(g *Graph) []string {
+		var all []string
+		ts, _ := sortedTriples(g)
+		for _, tr := range ts {
+			all = append(all, tr.Subject()+" "+tr.Predicate()+" "+objectTerm(tr.Object()))
+		}
+		return all
+	}
+
+	for _, format := range DatasetFormats {
+		t.Run(format, func(t *testing.T) {
+			var buff bytes.Buffer
+			if err := dataset.MarshalTo(&buff, format); err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := UnmarshalDataset(&buff, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(decoded), 2; got != want {
+				t.Fatalf("got %d graphs, want %d", got, want)
+			}
+			for name, g := range dataset {
+				if _, ok := decoded[name]; !ok {
+					t.Fatalf("missing graph %s", name)
+				}
+				if got, want := triples(decoded[name]), triples(g); !reflect.DeepEqual(got, want) {
+					t.Fatalf("%s: got\n%s\nwant\n%s", name, strings.Join(got, "\n"), strings.Join(want, "\n"))
+				}
+			}
+			res, err := decoded["default/eu-west-1"].GetResource("instance", "inst_1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := res.Properties()["Launched"], launched; got != want {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("n-quads", func(t *testing.T) {
+		var buff bytes.Buffer
+		if err := dataset.MarshalTo(&buff, NQuadsFormat); err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range []string{
+			`<http://awless.io/resources/inst_1> <http://awless.io/rdf/cloud#name> "my \"redis\"\nserver" <http://awless.io/graphs/default/eu-west-1> .`,
+			`<http://awless.io/resources/inst_1> <http://www.w3.org/1999/02/22-rdf-syntax-ns#type> <http://awless.io/rdf/cloud-owl#Instance> <http://awless.io/graphs/default/eu-west-1> .`,
+			`<http://awless.io/resources/sub_1> <http://awless.io/rdf/cloud-rel#parentOf> <http://awless.io/resources/inst_1> <http://awless.io/graphs/default/eu-west-1> .`,
+			`<http://awless.io/resources/arn:aws:s3:::my%2Fbucket> <http://awless.io/rdf/cloud#id> "arn:aws:s3:::my/bucket" <http://awless.io/graphs/default/global> .`,
+		} {
+			if !strings.Contains(buff.String(), line+"\n") {
+				t.Fatalf("missing line %s in\n%s", line, buff.String())
+			}
+		}
+	})
+
+	t.Run("foreign json-ld", func(t *testing.T) {
+		doc := `{
+  "@context": {"c": "http://awless.io/rdf/cloud#", "owl": "http://awless.io/rdf/cloud-owl#"},
+  "@id": "http://awless.io/resources/inst_2",
+  "@type": "owl:Instance",
+  "c:name": "web",
+  "c:memory": 4,
+  "c:subnet": {"@id": "http://awless.io/resources/sub_1"}
+}`
+		decoded, err := UnmarshalDataset(strings.NewReader(doc), JSONLDFormat)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := decoded[""].GetResource("instance", "inst_2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := res.Properties()["Name"], "web"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := res.Properties()["Memory"], 4; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := res.Properties()["Subnet"], "sub_1"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestResourcesGraph(t *testing.T) {
+	g := NewGraph()
+	sg := InitResource("securitygroup", "sg_1")
+	sg.SetProperty("InboundRules", []*FirewallRule{{PortRange: PortRange{Any: true}, Protocol: "any"}})
+	inst := InitResource("instance", "inst_1")
+	user := InitResource("user", "usr_1")
+	g.AddResource(sg, inst, user)
+	g.AddAppliesOnRelation(sg, inst)
+
+	infra, err := g.ResourcesGraph("securitygroup", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := infra.GetResource("securitygroup", "sg_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules, ok := res.Properties()["InboundRules"].([]*FirewallRule); !ok || len(rules) != 1 || rules[0].Protocol != "any" {
+		t.Fatalf("unexpected rules %#v", res.Properties()["InboundRules"])
+	}
+	applied, err := infra.ListResourcesAppliedOn(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 1 || applied[0].Id() != "inst_1" {
+		t.Fatalf("unexpected applied on %v", applied)
+	}
+	if found, _ := infra.FindResource("usr_1"); found != nil {
+		t.Fatalf("unexpected user in infra graph")
+	}
+}
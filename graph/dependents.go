@@ -0,0 +1,151 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+// Dependent is a resource depending on another one, either through a relation
+// (ChildRelation, AppliesOnRelation) or through one of its properties (ex: SecurityGroups)
+type Dependent struct {
+	*Resource
+	Through string
+}
+
+// Dependents returns the resources depending on the given one, to check before deleting it:
+// its direct children, the resources it applies on and the resources referencing it in their
+// properties, including in firewall rules or routes (ex: the instances, network interfaces and
+// load balancers of a security group)
+func (g *Graph) Dependents(res *Resource) ([]*Dependent, error) {
+	snap := g.store.Snapshot()
+
+	var dependents []*Dependent
+	unique := make(map[string]bool)
+	add := func(id, through string) error {
+		if id == res.Id() || unique[id+through] {
+			return nil
+		}
+		typ, err := resolveResourceType(snap, id)
+		if err == errTypeNotFound {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		r, err := g.GetResource(typ, id)
+		if err != nil {
+			return err
+		}
+		unique[id+through] = true
+		dependents = append(dependents, &Dependent{Resource: r, Through: through})
+		return nil
+	}
+
+	for _, rel := range []struct{ pred, name string }{{rdf.ParentOf, ChildRelation}, {rdf.ApplyOn, AppliesOnRelation}} {
+		for _, t := range snap.WithSubjPred(res.Id(), rel.pred) {
+			if id, ok := t.Object().Resource(); ok {
+				if err := add(id, rel.name); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// owners adds the resources holding the node in a property, going up the nodes of
+	// structured properties (ex: the security group holding a firewall rule)
+	var owners func(t tstore.Triple, depth int) error
+	owners = func(t tstore.Triple, depth int) error {
+		if isCloudResource(snap, t.Subject()) {
+			through, err := rdf.Properties.GetLabel(t.Predicate())
+			if err != nil {
+				through = trimNS(t.Predicate())
+			}
+			return add(t.Subject(), through)
+		}
+		if depth >= maxPropertyNodesDepth {
+			return nil
+		}
+		for _, parent := range referencingTriples(snap, t.Subject()) {
+			if err := owners(parent, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, t := range referencingTriples(snap, res.Id()) {
+		switch t.Predicate() {
+		case rdf.ParentOf, rdf.ApplyOn, rdf.RdfType:
+			continue
+		}
+		if err := owners(t, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(dependents, func(i, j int) bool {
+		if !dependents[i].Same(dependents[j].Resource) {
+			return lessResource(dependents[i].Resource, dependents[j].Resource)
+		}
+		return dependents[i].Through < dependents[j].Through
+	})
+	return dependents, nil
+}
+
+const maxPropertyNodesDepth = 3
+
+// referencingTriples returns the triples whose object is the node, as a resource, a blank node, a string
+// or the reference of a route target
+func referencingTriples(snap tstore.RDFGraph, node string) []tstore.Triple {
+	var triples []tstore.Triple
+	triples = append(triples, snap.WithObject(tstore.Resource(node))...)
+	triples = append(triples, snap.WithObject(tstore.SubjPred("", "").Bnode(node).Object())...)
+	triples = append(triples, snap.WithObject(tstore.StringLiteral(node))...)
+	for _, t := range snap.WithPredicate(rdf.NetRouteTargets) {
+		text, err := tstore.ParseString(t.Object())
+		if err != nil {
+			continue
+		}
+		if target, err := ParseRouteTarget(text); err == nil && target.Ref == node {
+			triples = append(triples, t)
+		}
+	}
+	return triples
+}
+
+// isPropertyNodeType returns true for the types of the nodes of structured properties
+// sharing the namespace of cloud resources (ex: the key values of route table associations)
+func isPropertyNodeType(typ string) bool {
+	switch typ {
+	case rdf.Grant, rdf.CloudGrantee, rdf.KeyValue, rdf.DistributionOrigin:
+		return true
+	}
+	return false
+}
+
+func isCloudResource(snap tstore.RDFGraph, node string) bool {
+	for _, t := range snap.WithSubjPred(node, rdf.RdfType) {
+		if typ, ok := t.Object().Resource(); ok && strings.HasPrefix(typ, rdf.CloudOwlNS+":") && !isPropertyNodeType(typ) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestDependents(t *testing.T) {
+	g := graph.NewGraph()
+	sub := resourcetest.Subnet("sub_1").Build()
+	sg := resourcetest.SecurityGroup("sg_1").Build()
+	inst := resourcetest.Instance("inst_1").Prop(properties.SecurityGroups, []string{"sg_1"}).Build()
+	g.AddResource(sub, sg, inst,
+		resourcetest.Instance("inst_2").Build(),
+		resourcetest.SecurityGroup("sg_2").Prop(properties.InboundRules, []*graph.FirewallRule{
+			{PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", Sources: []string{"sg_1"}},
+		}).Build(),
+		resourcetest.RouteTable("rt_1").Prop(properties.Routes, []*graph.Route{
+			{Targets: []*graph.RouteTarget{{Type: graph.EgressOnlyInternetGatewayTarget, Ref: "sg_1"}}},
+		}).Build(),
+	)
+	g.AddParentRelation(sub, inst)
+	g.AddAppliesOnRelation(sg, inst)
+
+	toString := func(deps []*graph.Dependent) (out []string) {
+		for _, d := range deps {
+			out = append(out, fmt.Sprintf("%s[%s] (%s)", d.Id(), d.Type(), d.Through))
+		}
+		return
+	}
+
+	deps, err := g.Dependents(sg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"inst_1[instance] (SecurityGroups)", "inst_1[instance] (applies_on)", "rt_1[routetable] (Routes)", "sg_2[securitygroup] (InboundRules)"}
+	if got, want := toString(deps), expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	deps, err = g.Dependents(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := toString(deps), []string{"inst_1[instance] (child)"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	deps, err = g.Dependents(inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(deps), 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
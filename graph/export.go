@@ -0,0 +1,243 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/cloud/rdf"
+)
+
+// Export formats to visualize the topology of resources
+const (
+	DOTFormat = "dot"
+	D3Format  = "d3"
+)
+
+var ExportFormats = []string{DOTFormat, D3Format}
+
+// Types of the relations between resources
+const (
+	ParentRelation    = "parent"
+	ChildRelation     = "child"
+	AppliesOnRelation = "applies_on"
+)
+
+// Relation links a parent to its child or a resource to the one it applies on (ex: a security group to an instance)
+type Relation struct {
+	Source, Target *Resource
+	Type           string
+}
+
+// Topology gathers resources and the relations between them
+type Topology struct {
+	Resources []*Resource
+	Relations []*Relation
+}
+
+// Topology returns the resources of the graph with their relations. When resources are given, it only
+// contains them, their ancestors, their descendants and the resources applying or applied on any of those.
+func (g *Graph) Topology(from ...*Resource) (*Topology, error) {
+	snap := g.store.Snapshot()
+
+	resources := make(map[string]*Resource)
+	resolve := func(id string) (*Resource, error) {
+		if r, ok := resources[id]; ok {
+			return r, nil
+		}
+		typ, err := resolveResourceType(snap, id)
+		if err != nil {
+			return nil, err
+		}
+		return g.GetResource(typ, id)
+	}
+
+	var links []*Relation
+	for _, rel := range []struct{ pred, name string }{{rdf.ParentOf, ParentRelation}, {rdf.ApplyOn, AppliesOnRelation}} {
+		for _, t := range snap.WithPredicate(rel.pred) {
+			targetId, ok := t.Object().Resource()
+			if !ok {
+				continue
+			}
+			source, err := resolve(t.Subject())
+			if err == errTypeNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			resources[source.Id()] = source
+			target, err := resolve(targetId)
+			if err == errTypeNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			resources[target.Id()] = target
+			links = append(links, &Relation{Source: source, Target: target, Type: rel.name})
+		}
+	}
+
+	selected := make(map[string]bool)
+	if len(from) == 0 {
+		for _, t := range snap.WithPredicate(rdf.RdfType) {
+			r, err := resolve(t.Subject())
+			if err != nil {
+				return nil, err
+			}
+			resources[r.Id()] = r
+			selected[r.Id()] = true
+		}
+	} else {
+		collect := func(r *Resource, depth int) error {
+			selected[r.Id()] = true
+			return nil
+		}
+		for _, r := range from {
+			selected[r.Id()] = true
+			resources[r.Id()] = r
+			if err := g.Accept(&ParentsVisitor{From: r, Each: collect}); err != nil {
+				return nil, err
+			}
+			if err := g.Accept(&ChildrenVisitor{From: r, Each: collect}); err != nil {
+				return nil, err
+			}
+		}
+		var appliesOn []string
+		for _, l := range links {
+			if l.Type != AppliesOnRelation {
+				continue
+			}
+			if selected[l.Source.Id()] {
+				appliesOn = append(appliesOn, l.Target.Id())
+			}
+			if selected[l.Target.Id()] {
+				appliesOn = append(appliesOn, l.Source.Id())
+			}
+		}
+		for _, id := range appliesOn {
+			selected[id] = true
+		}
+	}
+
+	topo := new(Topology)
+	for id := range selected {
+		topo.Resources = append(topo.Resources, resources[id])
+	}
+	for _, l := range links {
+		if selected[l.Source.Id()] && selected[l.Target.Id()] {
+			topo.Relations = append(topo.Relations, l)
+		}
+	}
+
+	sortResources(topo.Resources)
+	sort.Slice(topo.Relations, func(i, j int) bool {
+		a, b := topo.Relations[i], topo.Relations[j]
+		if a.Type != b.Type {
+			return a.Type > b.Type
+		}
+		if !a.Source.Same(b.Source) {
+			return lessResource(a.Source, b.Source)
+		}
+		return lessResource(a.Target, b.Target)
+	})
+	return topo, nil
+}
+
+// ExportTo writes the topology in the given format (dot or d3)
+func (t *Topology) ExportTo(w io.Writer, format string) error {
+	switch format {
+	case DOTFormat:
+		return t.MarshalDOTTo(w)
+	case D3Format:
+		return t.MarshalD3To(w)
+	default:
+		return fmt.Errorf("unknown export format '%s', expected one of %s", format, strings.Join(ExportFormats, ", "))
+	}
+}
+
+// MarshalDOTTo writes the topology as a Graphviz directed graph, where the resources applying
+// on others (ex: security groups) are linked with dashed edges
+func (t *Topology) MarshalDOTTo(w io.Writer) error {
+	buff := bufio.NewWriter(w)
+	buff.WriteString("digraph awless {\n")
+	buff.WriteString("\tnode [shape=box];\n")
+	for _, r := range t.Resources {
+		fmt.Fprintf(buff, "\t%s [label=\"%s\\n%s\"];\n", dotQuote(r.Id()), dotEscape(resourceName(r)), dotEscape(r.Type()))
+	}
+	for _, rel := range t.Relations {
+		fmt.Fprintf(buff, "\t%s -> %s", dotQuote(rel.Source.Id()), dotQuote(rel.Target.Id()))
+		if rel.Type == AppliesOnRelation {
+			buff.WriteString(" [style=dashed]")
+		}
+		buff.WriteString(";\n")
+	}
+	buff.WriteString("}\n")
+	return buff.Flush()
+}
+
+type d3Node struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type d3Link struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// MarshalD3To writes the topology as JSON nodes and links, as expected by D3 force layouts
+func (t *Topology) MarshalD3To(w io.Writer) error {
+	out := struct {
+		Nodes []d3Node `json:"nodes"`
+		Links []d3Link `json:"links"`
+	}{Nodes: []d3Node{}, Links: []d3Link{}}
+
+	for _, r := range t.Resources {
+		name, _ := r.Properties()[properties.Name].(string)
+		out.Nodes = append(out.Nodes, d3Node{Id: r.Id(), Type: r.Type(), Name: name})
+	}
+	for _, rel := range t.Relations {
+		out.Links = append(out.Links, d3Link{Source: rel.Source.Id(), Target: rel.Target.Id(), Type: rel.Type})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func resourceName(r *Resource) string {
+	if name, ok := r.Properties()[properties.Name].(string); ok && name != "" {
+		return name
+	}
+	return r.Id()
+}
+
+func dotQuote(s string) string {
+	return "\"" + dotEscape(s) + "\""
+}
+
+func dotEscape(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(s)
+}
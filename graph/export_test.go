@@ -0,0 +1,146 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestExportTopology(t *testing.T) {
+	g := NewGraph()
+	vpc1 := InitResource("vpc", "vpc_1")
+	vpc1.SetProperty("Name", "main")
+	vpc2 := InitResource("vpc", "vpc_2")
+	sub1 := InitResource("subnet", "sub_1")
+	sub2 := InitResource("subnet", "sub_2")
+	inst1 := InitResource("instance", "inst_1")
+	sg1 := InitResource("securitygroup", "sg_1")
+	g.AddResource(vpc1, vpc2, sub1, sub2, inst1, sg1)
+	g.AddParentRelation(vpc1, sub1)
+	g.AddParentRelation(vpc1, sg1)
+	g.AddParentRelation(sub1, inst1)
+	g.AddParentRelation(vpc2, sub2)
+	g.AddAppliesOnRelation(sg1, inst1)
+
+	ids := func(topo *Topology) []string {
+		return Resources(topo.Resources).Map(func(r *Resource) string { return r.Id() })
+	}
+
+	t.Run("whole graph", func(t *testing.T) {
+		topo, err := g.Topology()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ids(topo), []string{"inst_1", "sg_1", "sub_1", "sub_2", "vpc_1", "vpc_2"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := len(topo.Relations), 5; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("from resource", func(t *testing.T) {
+		topo, err := g.Topology(sub1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ids(topo), []string{"inst_1", "sg_1", "sub_1", "vpc_1"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		var dot bytes.Buffer
+		if err := topo.ExportTo(&dot, DOTFormat); err != nil {
+			t.Fatal(err)
+		}
+		expected := `digraph awless {
+	node [shape=box];
+	"inst_1" [label="inst_1\ninstance"];
+	"sg_1" [label="sg_1\nsecuritygroup"];
+	"sub_1" [label="sub_1\nsubnet"];
+	"vpc_1" [label="main\nvpc"];
+	"sub_1" -> "inst_1";
+	"vpc_1" -> "sg_1";
+	"vpc_1" -> "sub_1";
+	"sg_1" -> "inst_1" [style=dashed];
+}
+`
+		if got, want := dot.String(), expected; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+
+		var d3 bytes.Buffer
+		if err := topo.ExportTo(&d3, D3Format); err != nil {
+			t.Fatal(err)
+		}
+		expected = `{
+  "nodes": [
+    {
+      "id": "inst_1",
+      "type": "instance"
+    },
+    {
+      "id": "sg_1",
+      "type": "securitygroup"
+    },
+    {
+      "id": "sub_1",
+      "type": "subnet"
+    },
+    {
+      "id": "vpc_1",
+      "type": "vpc",
+      "name": "main"
+    }
+  ],
+  "links": [
+    {
+      "source": "sub_1",
+      "target": "inst_1",
+      "type": "parent"
+    },
+    {
+      "source": "vpc_1",
+      "target": "sg_1",
+      "type": "parent"
+    },
+    {
+      "source": "vpc_1",
+      "target": "sub_1",
+      "type": "parent"
+    },
+    {
+      "source": "sg_1",
+      "target": "inst_1",
+      "type": "applies_on"
+    }
+  ]
+}
+`
+		if got, want := d3.String(), expected; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		topo, _ := g.Topology(vpc2)
+		if err := topo.ExportTo(new(bytes.Buffer), "svg"); err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+}
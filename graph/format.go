@@ -0,0 +1,122 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+// FormatVersion is the version of the format of the persisted graphs, written along with their triples.
+// Bump it when the triples of a resource change (ex: renamed property) and register in formatMigrations
+// how to read the previous version, so that local graphs keep loading after an upgrade of awless.
+// Version 1 are the graphs persisted before versioning (i.e. without version triple).
+const FormatVersion = 2
+
+const formatVersionSubject = "awless-graph"
+
+var formatVersionPredicate = fmt.Sprintf("%s:formatVersion", rdf.CloudNS)
+
+// formatMigrations converts the triples of a version to the next version
+var formatMigrations = map[int]func([]tstore.Triple) ([]tstore.Triple, error){
+	1: func(ts []tstore.Triple) ([]tstore.Triple, error) { return ts, nil },
+}
+
+type UnsupportedFormatError struct {
+	Version int
+}
+
+func (e *UnsupportedFormatError) Error() string {
+	if e.Version > FormatVersion {
+		return fmt.Sprintf("graph format version %d is newer than the supported version %d: upgrade awless or sync again", e.Version, FormatVersion)
+	}
+	return fmt.Sprintf("graph format version %d is no longer supported: sync again", e.Version)
+}
+
+// MarshalVersionedTo writes the graph as N-Triples along with the current format version
+func (g *Graph) MarshalVersionedTo(w io.Writer) error {
+	return marshalVersionedTo(g, w, false)
+}
+
+// MarshalBinaryTo writes the graph in the triplestore binary format along with the current format version.
+// This format is more compact and faster to decode than N-Triples.
+func (g *Graph) MarshalBinaryTo(w io.Writer) error {
+	return marshalVersionedTo(g, w, true)
+}
+
+// UnmarshalVersionedFromReaders loads graphs persisted in the current or a previous format version
+// (N-Triples or binary), migrating their triples to the current version
+func (g *Graph) UnmarshalVersionedFromReaders(readers ...io.Reader) error {
+	for _, r := range readers {
+		ts, err := tstore.NewAutoDecoder(r).Decode()
+		if err != nil {
+			return err
+		}
+		if ts, err = migrateTriples(ts); err != nil {
+			return err
+		}
+		g.store.Add(ts...)
+	}
+	return nil
+}
+
+// marshalVersionedTo encodes the version then the triples of the graph in batches. The triples
+// are the ones of the graph snapshot, shared rather than copied since triples are immutable
+func marshalVersionedTo(g *Graph, w io.Writer, binary bool) error {
+	enc := newBatchEncoder(w, binary)
+	if err := enc.Encode(tstore.SubjPred(formatVersionSubject, formatVersionPredicate).IntegerLiteral(FormatVersion)); err != nil {
+		return err
+	}
+	if err := enc.Encode(g.store.Snapshot().Triples()...); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+func migrateTriples(ts []tstore.Triple) ([]tstore.Triple, error) {
+	version := 1
+	var triples []tstore.Triple
+	for _, t := range ts {
+		if t.Subject() == formatVersionSubject && t.Predicate() == formatVersionPredicate {
+			v, err := tstore.ParseInteger(t.Object())
+			if err != nil {
+				return nil, fmt.Errorf("graph format version: %s", err)
+			}
+			version = v
+			continue
+		}
+		triples = append(triples, t)
+	}
+
+	if version > FormatVersion {
+		return nil, &UnsupportedFormatError{Version: version}
+	}
+	for ; version < FormatVersion; version++ {
+		migrate, ok := formatMigrations[version]
+		if !ok {
+			return nil, &UnsupportedFormatError{Version: version}
+		}
+		var err error
+		if triples, err = migrate(triples); err != nil {
+			return nil, fmt.Errorf("migrating graph format from version %d: %s", version, err)
+		}
+	}
+	return triples, nil
+}
@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+	tstore "github.com/wallix/triplestore"
+)
+
+func TestVersionedFormat(t *testing.T) {
+	g := NewGraph()
+	inst := InitResource("instance", "inst_1")
+	inst.properties[properties.Name] = "redis"
+	if err := g.AddResource(inst); err != nil {
+		t.Fatal(err)
+	}
+
+	marshalers := map[string]func(*bytes.Buffer) error{
+		"ntriples": func(b *bytes.Buffer) error { return g.MarshalVersionedTo(b) },
+		"binary":   func(b *bytes.Buffer) error { return g.MarshalBinaryTo(b) },
+		"legacy":   func(b *bytes.Buffer) error { return g.MarshalTo(b) },
+	}
+	for name, marshal := range marshalers {
+		t.Run(name, func(t *testing.T) {
+			var buff bytes.Buffer
+			if err := marshal(&buff); err != nil {
+				t.Fatal(err)
+			}
+			loaded := NewGraph()
+			if err := loaded.UnmarshalVersionedFromReaders(&buff); err != nil {
+				t.Fatal(err)
+			}
+			res, err := loaded.GetResource("instance", "inst_1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := res.Properties()[properties.Name], "redis"; got != want {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+			if got, want := sortedLines(loaded.MustMarshal()), sortedLines(g.MustMarshal()); !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		})
+	}
+
+	t.Run("newer version", func(t *testing.T) {
+		var buff bytes.Buffer
+		tstore.NewLenientNTEncoder(&buff).Encode(tstore.SubjPred(formatVersionSubject, formatVersionPredicate).IntegerLiteral(FormatVersion + 1))
+		err := NewGraph().UnmarshalVersionedFromReaders(&buff)
+		if _, ok := err.(*UnsupportedFormatError); !ok {
+			t.Fatalf("got %v, want UnsupportedFormatError", err)
+		}
+		if got, want := err.Error(), "upgrade awless"; !strings.Contains(got, want) {
+			t.Fatalf("got %s, want to contain %s", got, want)
+		}
+	})
+}
+
+func TestFormatMigrations(t *testing.T) {
+	for v := 1; v < FormatVersion; v++ {
+		if _, ok := formatMigrations[v]; !ok {
+			t.Fatalf("missing migration from format version %d", v)
+		}
+	}
+}
+
+func sortedLines(s string) []string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	sort.Strings(lines)
+	return lines
+}
+
+func TestMarshalBinaryChunks(t *testing.T) {
+	defer func(size int) { BatchSize = size }(BatchSize)
+	BatchSize = 4
+
+	g := NewGraph()
+	for i := 0; i < 5; i++ {
+		g.AddResource(InitResource("instance", fmt.Sprintf("inst_%d", i)))
+	}
+
+	var readers []io.Reader
+	if err := g.MarshalBinaryChunks(func(chunk []byte) error {
+		readers = append(readers, bytes.NewReader(append([]byte{}, chunk...)))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(readers), 3; got != want {
+		t.Fatalf("got %d chunks, want %d", got, want)
+	}
+
+	loaded := NewGraph()
+	if err := loaded.UnmarshalVersionedFromReaders(readers...); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded.AsRDFGraphSnaphot().Count(), g.AsRDFGraphSnaphot().Count(); got != want {
+		t.Fatalf("got %d triples, want %d", got, want)
+	}
+	for _, tri := range g.AsRDFGraphSnaphot().Triples() {
+		if tri == nil {
+			t.Fatal("marshaling released the triples of the graph")
+		}
+	}
+
+	var chunks int
+	if err := NewGraph().MarshalBinaryChunks(func(chunk []byte) error { chunks++; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := chunks, 1; got != want {
+		t.Fatalf("got %d chunks, want %d", got, want)
+	}
+}
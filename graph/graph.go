@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
 
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/cloud/rdf"
@@ -29,10 +30,14 @@ import (
 
 type Graph struct {
 	store tstore.Source
+
+	indexMu   sync.Mutex
+	idx       *resourcesIndex
+	searchIdx *searchIndex
 }
 
 func NewGraph() *Graph {
-	return &Graph{tstore.NewSource()}
+	return &Graph{store: tstore.NewSource()}
 }
 
 func NewGraphFromFile(filepath string) (*Graph, error) {
@@ -41,7 +46,8 @@ func NewGraphFromFile(filepath string) (*Graph, error) {
 	if err != nil {
 		return g, err
 	}
-	err = g.UnmarshalFromReaders(f)
+	defer f.Close()
+	err = g.UnmarshalVersionedFromReaders(f)
 	return g, err
 }
 
@@ -49,7 +55,10 @@ func (g *Graph) AsRDFGraphSnaphot() tstore.RDFGraph {
 	return g.store.Snapshot()
 }
 
+// AddResource inserts the triples of the resources and of their relations in batches
+// (see BatchSize), so that adding many resources does not contend on the graph store
 func (g *Graph) AddResource(resources ...*Resource) error {
+	inserter := &batchInserter{store: g.store}
 	for _, res := range resources {
 		triples, err := res.marshalFullRDF()
 		if err != nil {
@@ -60,21 +69,18 @@ func (g *Graph) AddResource(resources ...*Resource) error {
 			switch relType {
 			case rdf.ChildrenOfRel:
 				for _, attached := range attachedRes {
-					if err := g.AddParentRelation(attached, res); err != nil {
-						return err
-					}
+					inserter.add(tstore.SubjPred(attached.Id(), rdf.ParentOf).Resource(res.Id()))
 				}
 			case rdf.DependingOnRel:
 				for _, attached := range attachedRes {
-					if err := g.AddAppliesOnRelation(attached, res); err != nil {
-						return err
-					}
+					inserter.add(tstore.SubjPred(attached.Id(), rdf.ApplyOn).Resource(res.Id()))
 				}
 			}
 		}
 
-		g.store.Add(triples...)
+		inserter.add(triples...)
 	}
+	inserter.flush()
 	return nil
 }
 
@@ -160,13 +166,15 @@ func (g *Graph) FilterGraph(q cloud.Query) (cloud.GraphAPI, error) {
 	if len(q.ResourceType) != 1 {
 		return nil, fmt.Errorf("invalid query: must have exactly one resource type, got %d", len(q.ResourceType))
 	}
-	resourceType := q.ResourceType[0]
-	return g.Filter(resourceType, func(r *Resource) bool {
-		if q.Matcher == nil {
-			return true
-		}
-		return q.Matcher.Match(r)
-	})
+	resources, err := g.resolveMatching(q.ResourceType[0], q.Matcher)
+	if err != nil {
+		return nil, err
+	}
+	filtered := NewGraph()
+	if err := filtered.AddResource(resources...); err != nil {
+		return nil, err
+	}
+	return filtered, nil
 }
 
 func (g *Graph) Find(q cloud.Query) ([]cloud.Resource, error) {
@@ -176,12 +184,7 @@ func (g *Graph) Find(q cloud.Query) ([]cloud.Resource, error) {
 	case 0:
 		return nil, fmt.Errorf("invalid query: need at least one resource type")
 	case 1:
-		var filtered cloud.GraphAPI
-		filtered, err = g.FilterGraph(q)
-		if err != nil {
-			return nil, err
-		}
-		resources, err = filtered.(*Graph).GetAllResources(q.ResourceType[0])
+		resources, err = g.resolveMatching(q.ResourceType[0], q.Matcher)
 		if err != nil {
 			return nil, err
 		}
@@ -219,11 +222,10 @@ func (g *Graph) FindWithProperties(props map[string]interface{}) ([]cloud.Resour
 }
 
 func (g *Graph) FindOne(q cloud.Query) (cloud.Resource, error) {
-	filtered, err := g.FilterGraph(q)
-	if err != nil {
-		return nil, err
+	if len(q.ResourceType) != 1 {
+		return nil, fmt.Errorf("invalid query: must have exactly one resource type, got %d", len(q.ResourceType))
 	}
-	resources, err := filtered.(*Graph).GetAllResources(q.ResourceType[0])
+	resources, err := g.resolveMatching(q.ResourceType[0], q.Matcher)
 	if err != nil {
 		return nil, err
 	}
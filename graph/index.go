@@ -0,0 +1,150 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+// resourcesIndex holds the ids of the resources of a graph snapshot per type, property and value,
+// so that queries on a property value resolve by lookup instead of unmarshalling and matching
+// all the resources of the type (resources per type are already indexed by the triplestore)
+type resourcesIndex struct {
+	snap       tstore.RDFGraph
+	byProperty map[string]map[string]map[string][]string // type -> property -> value key -> ids
+}
+
+// index returns the index of the current snapshot of the graph, building it
+// on first use after the graph was loaded or modified
+func (g *Graph) index() (*resourcesIndex, error) {
+	snap := g.store.Snapshot()
+
+	g.indexMu.Lock()
+	defer g.indexMu.Unlock()
+	if g.idx != nil && g.idx.snap == snap {
+		return g.idx, nil
+	}
+	idx, err := buildResourcesIndex(snap)
+	if err != nil {
+		return nil, err
+	}
+	g.idx = idx
+	return idx, nil
+}
+
+func buildResourcesIndex(snap tstore.RDFGraph) (*resourcesIndex, error) {
+	idx := &resourcesIndex{
+		snap:       snap,
+		byProperty: make(map[string]map[string]map[string][]string),
+	}
+	for _, t := range snap.WithPredicate(rdf.RdfType) {
+		if node, ok := t.Object().Resource(); !ok || !strings.HasPrefix(node, rdf.CloudOwlNS+":") {
+			continue
+		}
+		typ, err := unmarshalResourceType(t.Object())
+		if err != nil {
+			return nil, err
+		}
+		res := InitResource(typ, t.Subject())
+		if err := res.unmarshalFullRdf(snap); err != nil {
+			return nil, err
+		}
+		byProperty, ok := idx.byProperty[typ]
+		if !ok {
+			byProperty = make(map[string]map[string][]string)
+			idx.byProperty[typ] = byProperty
+		}
+		for name, value := range res.Properties() {
+			if byProperty[name] == nil {
+				byProperty[name] = make(map[string][]string)
+			}
+			key := indexKey(value)
+			byProperty[name][key] = append(byProperty[name][key], res.Id())
+		}
+	}
+	for _, byProperty := range idx.byProperty {
+		for _, byValue := range byProperty {
+			for _, ids := range byValue {
+				sort.Strings(ids)
+			}
+		}
+	}
+	return idx, nil
+}
+
+// lookup returns the ids of the resources of the type whose property may match the value
+func (idx *resourcesIndex) lookup(resourceType, property, value string) []string {
+	return idx.byProperty[resourceType][property][strings.ToLower(value)]
+}
+
+func indexKey(v interface{}) string {
+	return strings.ToLower(fmt.Sprint(v))
+}
+
+// resolveMatching returns the resources of the type matched by the matcher (all when nil).
+// Matchers on a property value (see cloud.IndexedMatcher) resolve with the index of the graph.
+func (g *Graph) resolveMatching(resourceType string, matcher cloud.Matcher) ([]*Resource, error) {
+	if gm, ok := matcher.(cloud.GraphMatcher); ok {
+		matcher = gm.InGraph(g)
+	}
+	im, ok := matcher.(cloud.IndexedMatcher)
+	if !ok {
+		return g.filterAll(resourceType, matcher)
+	}
+	property, value, ok := im.IndexedProperty()
+	if !ok {
+		return g.filterAll(resourceType, matcher)
+	}
+	idx, err := g.index()
+	if err != nil {
+		return nil, err
+	}
+	var resources []*Resource
+	for _, id := range idx.lookup(resourceType, property, value) {
+		res, err := g.GetResource(resourceType, id)
+		if err != nil {
+			return resources, err
+		}
+		if matcher.Match(res) {
+			resources = append(resources, res)
+		}
+	}
+	return resources, nil
+}
+
+func (g *Graph) filterAll(resourceType string, matcher cloud.Matcher) ([]*Resource, error) {
+	all, err := g.GetAllResources(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	if matcher == nil {
+		return all, nil
+	}
+	var resources []*Resource
+	for _, res := range all {
+		if matcher.Match(res) {
+			resources = append(resources, res)
+		}
+	}
+	return resources, nil
+}
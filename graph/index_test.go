@@ -0,0 +1,104 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/cloud/properties"
+)
+
+func TestFindWithIndex(t *testing.T) {
+	g := NewGraph()
+	for i := 1; i <= 5; i++ {
+		inst := InitResource("instance", fmt.Sprintf("inst_%d", i))
+		inst.properties[properties.Name] = fmt.Sprintf("Web-%d", i%2)
+		inst.properties[properties.State] = "running"
+		g.AddResource(inst)
+	}
+	sub := InitResource("subnet", "sub_1")
+	sub.properties[properties.Name] = "web-1"
+	g.AddResource(sub)
+
+	tcases := []struct {
+		matcher cloud.Matcher
+		expIds  []string
+	}{
+		{match.Property(properties.Name, "Web-1"), []string{"inst_1", "inst_3", "inst_5"}},
+		{match.Property(properties.Name, "web-1"), nil},
+		{match.Property(properties.Name, "web-1").IgnoreCase(), []string{"inst_1", "inst_3", "inst_5"}},
+		{match.And(match.Property(properties.State, "running"), match.Property(properties.Name, "Web-0")), []string{"inst_2", "inst_4"}},
+		{match.And(match.Property(properties.Name, "Web-0"), match.Property(properties.ID, "inst_4")), []string{"inst_4"}},
+		{match.Property(properties.Name, "Web").Contains(), []string{"inst_1", "inst_2", "inst_3", "inst_4", "inst_5"}},
+		{match.Or(match.Property(properties.ID, "inst_1"), match.Property(properties.ID, "inst_2")), []string{"inst_1", "inst_2"}},
+	}
+	for i, tcase := range tcases {
+		found, err := g.Find(cloud.NewQuery("instance").Match(tcase.matcher))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, r := range found {
+			ids = append(ids, r.Id())
+		}
+		sort.Strings(ids)
+		if got, want := fmt.Sprint(ids), fmt.Sprint(tcase.expIds); got != want {
+			t.Fatalf("%d: got %s, want %s", i, got, want)
+		}
+	}
+
+	idx, err := g.index()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(idx.lookup("subnet", properties.Name, "WEB-1")), "[sub_1]"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if again, _ := g.index(); again != idx {
+		t.Fatal("expected index to be reused when graph is unchanged")
+	}
+
+	inst := InitResource("instance", "inst_6")
+	inst.properties[properties.Name] = "Web-1"
+	g.AddResource(inst)
+	if _, err := g.FindOne(cloud.NewQuery("instance").Match(match.Property(properties.ID, "inst_6"))); err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt, _ := g.index(); rebuilt == idx {
+		t.Fatal("expected index to be rebuilt when graph is modified")
+	}
+}
+
+func BenchmarkFindOne(b *testing.B) {
+	g := NewGraph()
+	for i := 0; i < 10000; i++ {
+		inst := InitResource("instance", fmt.Sprintf("inst_%d", i))
+		inst.properties[properties.Name] = fmt.Sprintf("instance-%d", i)
+		g.AddResource(inst)
+	}
+	q := cloud.NewQuery("instance").Match(match.Property(properties.Name, "instance-5000"))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := g.FindOne(q); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
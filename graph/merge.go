@@ -0,0 +1,146 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/wallix/awless/cloud/rdf"
+)
+
+// MergeStrategy resolves the properties of a resource present in both merged graphs
+type MergeStrategy int
+
+const (
+	// LastWriteWins keeps the properties of the resource in the last graph, the properties
+	// only set in the first graph being kept
+	LastWriteWins MergeStrategy = iota
+	// PropertyUnion keeps the properties of the resource in the first graph, adding the
+	// properties only set in the last graph and the missing elements of list properties
+	// (ex: the tags annotated by users are added to the tags fetched)
+	PropertyUnion
+)
+
+func (s MergeStrategy) String() string {
+	switch s {
+	case LastWriteWins:
+		return "last-write-wins"
+	case PropertyUnion:
+		return "property-union"
+	default:
+		return fmt.Sprintf("MergeStrategy(%d)", int(s))
+	}
+}
+
+// Merge returns a new graph with the resources of both graphs and their relations. The properties
+// of the resources present in both graphs are resolved with the given strategy. Graphs are left unchanged.
+func Merge(first, last *Graph, strategy MergeStrategy) (*Graph, error) {
+	merged := NewGraph()
+
+	firstResources, err := first.allResources()
+	if err != nil {
+		return merged, err
+	}
+	lastResources, err := last.allResources()
+	if err != nil {
+		return merged, err
+	}
+
+	byKey := make(map[string]*Resource)
+	var ordered []*Resource
+	for _, res := range firstResources {
+		byKey[res.Type()+"/"+res.Id()] = res
+		ordered = append(ordered, res)
+	}
+	for _, res := range lastResources {
+		existing, ok := byKey[res.Type()+"/"+res.Id()]
+		if !ok {
+			ordered = append(ordered, res)
+			continue
+		}
+		for key, value := range res.Properties() {
+			current, set := existing.Properties()[key]
+			switch {
+			case !set || strategy == LastWriteWins:
+				existing.SetProperty(key, value)
+			case strategy == PropertyUnion:
+				existing.SetProperty(key, unionValues(current, value))
+			}
+		}
+	}
+
+	if err := merged.AddResource(ordered...); err != nil {
+		return merged, err
+	}
+	for _, g := range []*Graph{first, last} {
+		for _, t := range g.store.Snapshot().Triples() {
+			if strings.HasPrefix(t.Predicate(), rdf.CloudRelNS+":") {
+				merged.store.Add(t)
+			}
+		}
+	}
+	return merged, nil
+}
+
+func (g *Graph) allResources() ([]*Resource, error) {
+	snap := g.store.Snapshot()
+	var resources []*Resource
+	unique := make(map[string]bool)
+	for _, t := range snap.WithPredicate(rdf.RdfType) {
+		id := t.Subject()
+		if unique[id] || !isCloudResource(snap, id) {
+			continue
+		}
+		unique[id] = true
+		typ, err := resolveResourceType(snap, id)
+		if err != nil {
+			return resources, err
+		}
+		res, err := g.GetResource(typ, id)
+		if err != nil {
+			return resources, err
+		}
+		resources = append(resources, res)
+	}
+	return resources, nil
+}
+
+// unionValues appends to a list the elements of the other list it does not contain.
+// Other values are kept as is.
+func unionValues(value, other interface{}) interface{} {
+	v, o := reflect.ValueOf(value), reflect.ValueOf(other)
+	if v.Kind() != reflect.Slice || o.Kind() != reflect.Slice || v.Type() != o.Type() {
+		return value
+	}
+	union := reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, v.Len()+o.Len()), v)
+	for i := 0; i < o.Len(); i++ {
+		elem := o.Index(i).Interface()
+		var found bool
+		for j := 0; j < v.Len(); j++ {
+			if reflect.DeepEqual(v.Index(j).Interface(), elem) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			union = reflect.Append(union, o.Index(i))
+		}
+	}
+	return union.Interface()
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph_test
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestMerge(t *testing.T) {
+	newGraphs := func() (*graph.Graph, *graph.Graph) {
+		fetched := graph.NewGraph()
+		sub := resourcetest.Subnet("sub_1").Build()
+		inst := resourcetest.Instance("inst_1").Prop(properties.State, "running").Prop(properties.Tags, []string{"Env=prod"}).Build()
+		fetched.AddResource(sub, inst, resourcetest.Instance("inst_2").Prop(properties.State, "stopped").Build())
+		fetched.AddParentRelation(sub, inst)
+
+		annotated := graph.NewGraph()
+		sgroup := resourcetest.SecurityGroup("sg_1").Build()
+		annotated.AddResource(
+			resourcetest.Instance("inst_1").Prop(properties.State, "terminated").Prop(properties.Tags, []string{"Owner=john"}).Prop(properties.Name, "web").Build(),
+			resourcetest.Instance("inst_3").Build(),
+			sgroup,
+		)
+		annotated.AddAppliesOnRelation(sgroup, resourcetest.Instance("inst_1").Build())
+		annotated.Unmarshal([]byte("<inst_3> <cloud-rel:ownedBy> <sub_1> .\n"))
+		return fetched, annotated
+	}
+
+	tcases := []struct {
+		strategy graph.MergeStrategy
+		expState string
+		expTags  []string
+	}{
+		{strategy: graph.LastWriteWins, expState: "terminated", expTags: []string{"Owner=john"}},
+		{strategy: graph.PropertyUnion, expState: "running", expTags: []string{"Env=prod", "Owner=john"}},
+	}
+	for _, tcase := range tcases {
+		t.Run(tcase.strategy.String(), func(t *testing.T) {
+			fetched, annotated := newGraphs()
+			merged, err := graph.Merge(fetched, annotated, tcase.strategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			instances, err := merged.GetAllResources("instance")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := len(instances), 3; got != want {
+				t.Fatalf("got %d, want %d", got, want)
+			}
+			inst, err := merged.GetResource("instance", "inst_1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := inst.Properties()[properties.State], tcase.expState; got != want {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+			tags, _ := inst.Properties()[properties.Tags].([]string)
+			sort.Strings(tags)
+			if got, want := tags, tcase.expTags; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+			if got, want := inst.Properties()[properties.Name], "web"; got != want {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+			if parent := merged.FindAncestor(inst, "subnet"); parent == nil || parent.Id() != "sub_1" {
+				t.Fatalf("expected subnet parent, got %v", parent)
+			}
+			if appliers, _ := merged.ListResourcesDependingOn(inst); len(appliers) != 1 || appliers[0].Id() != "sg_1" {
+				t.Fatalf("expected securitygroup applying on instance, got %v", appliers)
+			}
+			if !strings.Contains(merged.MustMarshal(), "<inst_3> <cloud-rel:ownedBy> <sub_1>") {
+				t.Fatalf("expected custom relation in merged graph, got\n%s", merged.MustMarshal())
+			}
+			fetchedInst, _ := fetched.GetResource("instance", "inst_1")
+			if got, want := fetchedInst.Properties()[properties.State], "running"; got != want {
+				t.Fatalf("fetched graph modified: got %v, want %v", got, want)
+			}
+		})
+	}
+}
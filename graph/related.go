@@ -0,0 +1,129 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+// Related returns the resources linked to the given one by a typed relation (see cloud.TypedRelations),
+// sorted by type and id. Ex: g.Related(instance, cloud.AttachedToRel) returns its subnet, vpc, security groups, etc.
+func (g *Graph) Related(from cloud.Resource, relation string) ([]cloud.Resource, error) {
+	snap := g.store.Snapshot()
+
+	var ids []string
+	switch relation {
+	case cloud.ParentOfRel:
+		ids = objectResources(snap, from.Id(), rdf.ParentOf)
+	case cloud.AppliesOnRel:
+		ids = objectResources(snap, from.Id(), rdf.ApplyOn)
+	case cloud.AttachedToRel:
+		ids = attachedResources(snap, from.Id(), 0)
+	case cloud.DependsOnRel:
+		ids = append(ids, subjectResources(snap, from.Id(), rdf.ParentOf)...)
+		ids = append(ids, subjectResources(snap, from.Id(), rdf.ApplyOn)...)
+		ids = append(ids, attachedResources(snap, from.Id(), 0)...)
+	default:
+		return nil, fmt.Errorf("unknown relation '%s', expected one of %s", relation, strings.Join(cloud.TypedRelations, ", "))
+	}
+
+	var related []*Resource
+	unique := make(map[string]bool)
+	for _, id := range ids {
+		if id == from.Id() || unique[id] {
+			continue
+		}
+		unique[id] = true
+		typ, err := resolveResourceType(snap, id)
+		if err == errTypeNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		r, err := g.GetResource(typ, id)
+		if err != nil {
+			return nil, err
+		}
+		related = append(related, r)
+	}
+
+	sort.Slice(related, func(i, j int) bool { return lessResource(related[i], related[j]) })
+	var collect []cloud.Resource
+	for _, r := range related {
+		collect = append(collect, r)
+	}
+	return collect, nil
+}
+
+func objectResources(snap tstore.RDFGraph, subject, pred string) (ids []string) {
+	for _, t := range snap.WithSubjPred(subject, pred) {
+		if id, ok := t.Object().Resource(); ok {
+			ids = append(ids, id)
+		}
+	}
+	return
+}
+
+func subjectResources(snap tstore.RDFGraph, object, pred string) (ids []string) {
+	for _, t := range snap.WithPredObj(pred, tstore.Resource(object)) {
+		ids = append(ids, t.Subject())
+	}
+	return
+}
+
+// attachedResources returns the cloud resources referenced in the properties of the node, going down
+// the nodes of structured properties (ex: the source security groups of firewall rules, route targets)
+func attachedResources(snap tstore.RDFGraph, node string, depth int) (ids []string) {
+	for _, t := range snap.WithSubject(node) {
+		switch t.Predicate() {
+		case rdf.ParentOf, rdf.ApplyOn, rdf.RdfType:
+			continue
+		}
+		obj := t.Object()
+		if _, isLiteral := obj.Literal(); !isLiteral {
+			ref, ok := obj.Bnode()
+			if !ok {
+				ref, _ = obj.Resource()
+			}
+			if isCloudResource(snap, ref) {
+				ids = append(ids, ref)
+			} else if depth < maxPropertyNodesDepth {
+				ids = append(ids, attachedResources(snap, ref, depth+1)...)
+			}
+			continue
+		}
+		text, err := tstore.ParseString(obj)
+		if err != nil {
+			continue
+		}
+		if t.Predicate() == rdf.NetRouteTargets {
+			if target, err := ParseRouteTarget(text); err == nil {
+				text = target.Ref
+			}
+		}
+		if isCloudResource(snap, text) {
+			ids = append(ids, text)
+		}
+	}
+	return
+}
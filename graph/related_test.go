@@ -0,0 +1,81 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestRelated(t *testing.T) {
+	g := graph.NewGraph()
+	vpc := resourcetest.VPC("vpc_1").Build()
+	sub := resourcetest.Subnet("sub_1").Prop(properties.Vpc, "vpc_1").Build()
+	sg := resourcetest.SecurityGroup("sg_1").Build()
+	inst := resourcetest.Instance("inst_1").Prop(properties.SecurityGroups, []string{"sg_1"}).Prop(properties.Subnet, "sub_1").Build()
+	rt := resourcetest.RouteTable("rt_1").Prop(properties.Routes, []*graph.Route{
+		{Targets: []*graph.RouteTarget{{Type: graph.InstanceTarget, Ref: "inst_1"}}},
+	}).Build()
+	sg2 := resourcetest.SecurityGroup("sg_2").Prop(properties.InboundRules, []*graph.FirewallRule{
+		{PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", Sources: []string{"sg_1"}},
+	}).Build()
+	g.AddResource(vpc, sub, sg, inst, rt, sg2)
+	g.AddParentRelation(vpc, sub)
+	g.AddParentRelation(sub, inst)
+	g.AddAppliesOnRelation(sg, inst)
+
+	ids := func(res []cloud.Resource) (out []string) {
+		for _, r := range res {
+			out = append(out, r.Id())
+		}
+		return
+	}
+
+	tcases := []struct {
+		from     *graph.Resource
+		relation string
+		expected []string
+	}{
+		{from: vpc, relation: cloud.ParentOfRel, expected: []string{"sub_1"}},
+		{from: sub, relation: cloud.ParentOfRel, expected: []string{"inst_1"}},
+		{from: sg, relation: cloud.AppliesOnRel, expected: []string{"inst_1"}},
+		{from: inst, relation: cloud.AppliesOnRel},
+		{from: inst, relation: cloud.AttachedToRel, expected: []string{"sg_1", "sub_1"}},
+		{from: rt, relation: cloud.AttachedToRel, expected: []string{"inst_1"}},
+		{from: sg2, relation: cloud.AttachedToRel, expected: []string{"sg_1"}},
+		{from: inst, relation: cloud.DependsOnRel, expected: []string{"sg_1", "sub_1"}},
+		{from: sub, relation: cloud.DependsOnRel, expected: []string{"vpc_1"}},
+	}
+	for _, tcase := range tcases {
+		res, err := g.Related(tcase.from, tcase.relation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ids(res), tcase.expected; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s %s: got %q, want %q", tcase.from.Id(), tcase.relation, got, want)
+		}
+	}
+
+	if _, err := g.Related(inst, "unknown"); err == nil {
+		t.Fatal("expected error for unknown relation")
+	}
+}
@@ -102,6 +102,10 @@ func Record(id string) *rBuilder {
 	return new("record", id)
 }
 
+func HealthCheck(id string) *rBuilder {
+	return new("healthcheck", id)
+}
+
 func ScalingGroup(id string) *rBuilder {
 	return new("scalinggroup", id)
 }
@@ -126,6 +130,46 @@ func Function(id string) *rBuilder {
 	return new("function", id)
 }
 
+func FunctionAlias(id string) *rBuilder {
+	return new("functionalias", id)
+}
+
+func EventSourceMapping(id string) *rBuilder {
+	return new("eventsourcemapping", id)
+}
+
+func StateMachine(id string) *rBuilder {
+	return new("statemachine", id)
+}
+
+func Execution(id string) *rBuilder {
+	return new("execution", id)
+}
+
+func RestApi(id string) *rBuilder {
+	return new("restapi", id)
+}
+
+func ApiStage(id string) *rBuilder {
+	return new("apistage", id)
+}
+
+func ApiDeployment(id string) *rBuilder {
+	return new("apideployment", id)
+}
+
+func Key(id string) *rBuilder {
+	return new("key", id)
+}
+
+func Alias(id string) *rBuilder {
+	return new("alias", id)
+}
+
+func KeyGrant(id string) *rBuilder {
+	return new("keygrant", id)
+}
+
 func Alarm(id string) *rBuilder {
 	return new("alarm", id)
 }
@@ -134,6 +178,10 @@ func Metric(id string) *rBuilder {
 	return new("metric", id)
 }
 
+func ElasticIP(id string) *rBuilder {
+	return new("elasticip", id)
+}
+
 func Image(id string) *rBuilder {
 	return new("image", id)
 }
@@ -142,6 +190,10 @@ func Distribution(id string) *rBuilder {
 	return new("distribution", id)
 }
 
+func OriginAccessIdentity(id string) *rBuilder {
+	return new("originaccessidentity", id)
+}
+
 func Stack(id string) *rBuilder {
 	return new("stack", id)
 }
@@ -162,14 +214,46 @@ func Container(id string) *rBuilder {
 	return new("container", id)
 }
 
+func ContainerService(id string) *rBuilder {
+	return new("containerservice", id)
+}
+
 func ContainerInstance(id string) *rBuilder {
 	return new("containerinstance", id)
 }
 
+func Database(id string) *rBuilder {
+	return new("database", id)
+}
+
+func CacheCluster(id string) *rBuilder {
+	return new("cachecluster", id)
+}
+
+func ReplicationGroup(id string) *rBuilder {
+	return new("replicationgroup", id)
+}
+
+func Table(id string) *rBuilder {
+	return new("table", id)
+}
+
+func TableIndex(id string) *rBuilder {
+	return new("tableindex", id)
+}
+
 func NetworkInterface(id string) *rBuilder {
 	return new("networkinterface", id)
 }
 
+func SpotFleet(id string) *rBuilder {
+	return new("spotfleet", id)
+}
+
+func SpotRequest(id string) *rBuilder {
+	return new("spotrequest", id)
+}
+
 func Certificate(id string) *rBuilder {
 	return new("certificate", id)
 }
@@ -178,6 +262,10 @@ func AccessKey(id string) *rBuilder {
 	return new("accesskey", id)
 }
 
+func Volume(id string) *rBuilder {
+	return new("volume", id)
+}
+
 func (b *rBuilder) Prop(key string, value interface{}) *rBuilder {
 	b.props[key] = value
 	return b
@@ -206,3 +294,7 @@ func AddParents(g *graph.Graph, relations ...string) {
 		}
 	}
 }
+
+func LaunchConfiguration(id string) *rBuilder {
+	return new("launchconfiguration", id)
+}
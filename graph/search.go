@@ -0,0 +1,238 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+// searchWeights ranks the matches on identifying properties above the matches on other properties
+var searchWeights = map[string]float64{
+	properties.ID:   4,
+	properties.Name: 4,
+	properties.Arn:  3,
+	properties.Tags: 2,
+}
+
+// SearchResult is a resource matching all the terms of a search
+type SearchResult struct {
+	Resource *Resource
+	Score    float64
+	// Properties holds the names of the properties matching the terms, sorted
+	Properties []string
+}
+
+// searchIndex is a full-text inverted index of the values of the properties of the resources
+// of a graph snapshot (names, tags, ARNs, ...), tokenized on non alphanumeric characters
+type searchIndex struct {
+	snap      tstore.RDFGraph
+	resources []*Resource
+	tokens    []string                   // sorted, for prefix lookups
+	postings  map[string]map[int]posting // token -> resource index -> posting
+}
+
+type posting struct {
+	weight     float64
+	properties []string
+}
+
+// Search returns the resources whose properties contain all the terms (case insensitive), ranked
+// by relevance: exact words score higher than prefixes, rare words higher than common ones,
+// and identifying properties (id, name, ARN, tags) higher than others.
+// The index is built on first search after the graph was loaded or modified.
+func (g *Graph) Search(terms ...string) ([]*SearchResult, error) {
+	idx, err := g.searchIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var words []string
+	for _, term := range terms {
+		words = append(words, tokenize(term)...)
+	}
+	words = uniqueSorted(words)
+	if len(words) == 0 {
+		return nil, nil
+	}
+	// each word consumes the token it matches, so that a word prefixing the token of another
+	// word does not match for free. Longest words are matched first: their tokens are also
+	// tokens of the words prefixing them, but not the other way round.
+	sort.SliceStable(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+
+	scores := make(map[int]*SearchResult)
+	consumed := make(map[int]map[string]bool)
+	for i, word := range words {
+		type match struct {
+			token string
+			score float64
+		}
+		best := make(map[int]match)
+		for _, token := range idx.prefixed(word) {
+			factor := 0.5
+			if token == word {
+				factor = 1
+			}
+			idf := math.Log(1 + float64(len(idx.resources))/float64(len(idx.postings[token])))
+			for res, p := range idx.postings[token] {
+				if i > 0 && scores[res] == nil {
+					continue
+				}
+				if consumed[res][token] {
+					continue
+				}
+				if score := p.weight * factor * idf; score > best[res].score {
+					best[res] = match{token: token, score: score}
+				}
+			}
+		}
+		matched := make(map[int]*SearchResult)
+		for res, m := range best {
+			r, ok := scores[res]
+			if !ok {
+				r = &SearchResult{Resource: idx.resources[res]}
+				consumed[res] = make(map[string]bool)
+			}
+			r.Score += m.score
+			r.Properties = append(r.Properties, idx.postings[m.token][res].properties...)
+			consumed[res][m.token] = true
+			matched[res] = r
+		}
+		scores = matched
+	}
+
+	var results []*SearchResult
+	for _, r := range scores {
+		r.Properties = uniqueSorted(r.Properties)
+		results = append(results, r)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Resource.Type() != results[j].Resource.Type() {
+			return results[i].Resource.Type() < results[j].Resource.Type()
+		}
+		return results[i].Resource.Id() < results[j].Resource.Id()
+	})
+	return results, nil
+}
+
+func (g *Graph) searchIndex() (*searchIndex, error) {
+	snap := g.store.Snapshot()
+
+	g.indexMu.Lock()
+	defer g.indexMu.Unlock()
+	if g.searchIdx != nil && g.searchIdx.snap == snap {
+		return g.searchIdx, nil
+	}
+	idx, err := buildSearchIndex(snap)
+	if err != nil {
+		return nil, err
+	}
+	g.searchIdx = idx
+	return idx, nil
+}
+
+func buildSearchIndex(snap tstore.RDFGraph) (*searchIndex, error) {
+	idx := &searchIndex{snap: snap, postings: make(map[string]map[int]posting)}
+	for _, t := range snap.WithPredicate(rdf.RdfType) {
+		if node, ok := t.Object().Resource(); !ok || !strings.HasPrefix(node, rdf.CloudOwlNS+":") {
+			continue
+		}
+		typ, err := unmarshalResourceType(t.Object())
+		if err != nil {
+			return nil, err
+		}
+		res := InitResource(typ, t.Subject())
+		if err := res.unmarshalFullRdf(snap); err != nil {
+			return nil, err
+		}
+		resIndex := len(idx.resources)
+		idx.resources = append(idx.resources, res)
+
+		for name, value := range res.Properties() {
+			weight, ok := searchWeights[name]
+			if !ok {
+				weight = 1
+			}
+			for _, text := range searchableTexts(value) {
+				for _, token := range tokenize(text) {
+					byResource, ok := idx.postings[token]
+					if !ok {
+						byResource = make(map[int]posting)
+						idx.postings[token] = byResource
+					}
+					p := byResource[resIndex]
+					p.weight = math.Max(p.weight, weight)
+					p.properties = append(p.properties, name)
+					byResource[resIndex] = p
+				}
+			}
+		}
+	}
+	for token := range idx.postings {
+		idx.tokens = append(idx.tokens, token)
+	}
+	sort.Strings(idx.tokens)
+	return idx, nil
+}
+
+// prefixed returns the indexed tokens starting with the word
+func (idx *searchIndex) prefixed(word string) (tokens []string) {
+	for i := sort.SearchStrings(idx.tokens, word); i < len(idx.tokens) && strings.HasPrefix(idx.tokens[i], word); i++ {
+		tokens = append(tokens, idx.tokens[i])
+	}
+	return
+}
+
+// searchableTexts returns the texts of the values of strings, lists of strings and numbers
+func searchableTexts(v interface{}) []string {
+	switch vv := v.(type) {
+	case string:
+		return []string{vv}
+	case []string:
+		return vv
+	case int, int64, float64:
+		return []string{fmt.Sprint(vv)}
+	default:
+		return nil
+	}
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func uniqueSorted(list []string) (unique []string) {
+	sort.Strings(list)
+	for i, s := range list {
+		if i == 0 || s != list[i-1] {
+			unique = append(unique, s)
+		}
+	}
+	return
+}
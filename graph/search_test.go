@@ -0,0 +1,88 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+)
+
+func TestSearch(t *testing.T) {
+	g := NewGraph()
+	inst := InitResource("instance", "inst_1")
+	inst.properties[properties.Name] = "web-frontend"
+	inst.properties[properties.Tags] = []string{"Env=production"}
+	g.AddResource(inst)
+	inst2 := InitResource("instance", "inst_2")
+	inst2.properties[properties.Name] = "backend"
+	inst2.properties[properties.KeyPair] = "web-key"
+	g.AddResource(inst2)
+	sub := InitResource("subnet", "sub_1")
+	sub.properties[properties.Name] = "webserver"
+	sub.properties[properties.Arn] = "arn:aws:ec2:eu-west-1:0123:subnet/sub_1"
+	g.AddResource(sub)
+
+	tcases := []struct {
+		terms      []string
+		expIds     []string
+		expMatches [][]string
+	}{
+		{terms: []string{"web"}, expIds: []string{"inst_1", "sub_1", "inst_2"}, expMatches: [][]string{{"Name"}, {"Name"}, {"KeyPair"}}},
+		{terms: []string{"WEB", "prod"}, expIds: []string{"inst_1"}, expMatches: [][]string{{"Name", "Tags"}}},
+		{terms: []string{"web production"}, expIds: []string{"inst_1"}, expMatches: [][]string{{"Name", "Tags"}}},
+		{terms: []string{"eu-west"}, expIds: []string{"sub_1"}, expMatches: [][]string{{"Arn"}}},
+		{terms: []string{"inst_2"}, expIds: []string{"inst_2"}, expMatches: [][]string{{"ID"}}},
+		{terms: []string{"web", "unknown"}},
+		{terms: []string{"webserver", "w"}, expIds: []string{"sub_1"}, expMatches: [][]string{{"Arn", "Name"}}},
+		{terms: []string{"frontend", "f"}},
+		{terms: []string{"prod", "production"}},
+		{terms: []string{" "}},
+	}
+	for i, tcase := range tcases {
+		results, err := g.Search(tcase.terms...)
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		var ids []string
+		var matches [][]string
+		for _, r := range results {
+			ids = append(ids, r.Resource.Id())
+			matches = append(matches, r.Properties)
+		}
+		if got, want := ids, tcase.expIds; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+		if got, want := matches, tcase.expMatches; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+
+	t.Run("reindex on modification", func(t *testing.T) {
+		db := InitResource("database", "db_1")
+		db.properties[properties.Name] = "web-db"
+		g.AddResource(db)
+		results, err := g.Search("db")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(results), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+
+	tstore "github.com/wallix/triplestore"
+)
+
+// BatchSize is the number of triples inserted in a graph or encoded at once. It bounds
+// the memory used on top of the graph itself when building or persisting large graphs.
+var BatchSize = 10000
+
+// batchEncoder encodes triples in batches through a buffered writer, so that
+// at most a batch of encoded triples is held in memory before reaching the writer
+type batchEncoder struct {
+	w     *bufio.Writer
+	enc   tstore.Encoder
+	batch []tstore.Triple
+}
+
+func newBatchEncoder(w io.Writer, binary bool) *batchEncoder {
+	bw := bufio.NewWriterSize(w, 64*1024)
+	e := &batchEncoder{w: bw, enc: tstore.NewLenientNTEncoder(bw)}
+	if binary {
+		e.enc = tstore.NewBinaryEncoder(bw)
+	}
+	return e
+}
+
+func (e *batchEncoder) Encode(ts ...tstore.Triple) error {
+	for _, t := range ts {
+		e.batch = append(e.batch, t)
+		if len(e.batch) >= BatchSize {
+			if err := e.encodeBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *batchEncoder) Flush() error {
+	if err := e.encodeBatch(); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+func (e *batchEncoder) encodeBatch() error {
+	if len(e.batch) == 0 {
+		return nil
+	}
+	err := e.enc.Encode(e.batch...)
+	e.batch = e.batch[:0]
+	return err
+}
+
+// batchInserter adds triples to a graph store in batches, taking the store lock once per batch
+type batchInserter struct {
+	store tstore.Source
+	batch []tstore.Triple
+}
+
+func (b *batchInserter) add(ts ...tstore.Triple) {
+	b.batch = append(b.batch, ts...)
+	if len(b.batch) >= BatchSize {
+		b.flush()
+	}
+}
+
+func (b *batchInserter) flush() {
+	if len(b.batch) > 0 {
+		b.store.Add(b.batch...)
+		b.batch = b.batch[:0]
+	}
+}
+
+// MarshalBinaryChunks writes the graph in the triplestore binary format as successive chunks
+// of at most BatchSize triples. Each chunk holds the current format version and can thus be
+// loaded on its own (see UnmarshalVersionedFromReaders), which lets stores write large graphs
+// without encoding them at once. The triples are shared with the graph snapshot, not copied.
+func (g *Graph) MarshalBinaryChunks(fn func(chunk []byte) error) error {
+	triples := g.store.Snapshot().Triples()
+	version := tstore.SubjPred(formatVersionSubject, formatVersionPredicate).IntegerLiteral(FormatVersion)
+
+	var buff bytes.Buffer
+	for start := 0; start == 0 || start < len(triples); start += BatchSize {
+		end := start + BatchSize
+		if end > len(triples) {
+			end = len(triples)
+		}
+		buff.Reset()
+		if err := tstore.NewBinaryEncoder(&buff).Encode(append([]tstore.Triple{version}, triples[start:end]...)...); err != nil {
+			return err
+		}
+		if err := fn(buff.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -58,6 +58,21 @@ func (r *FirewallRule) Contains(ip string) bool {
 	return false
 }
 
+// Opens returns true when the rule allows the port (any port when negative)
+// from the whole network (i.e. one of its IP ranges contains the network)
+func (r *FirewallRule) Opens(port int64, network *net.IPNet) bool {
+	if port >= 0 && !r.PortRange.Contains(port) {
+		return false
+	}
+	ones, _ := network.Mask.Size()
+	for _, n := range r.IPRanges {
+		if rangeOnes, _ := n.Mask.Size(); n.Contains(network.IP) && rangeOnes <= ones {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *FirewallRule) String() string {
 	return fmt.Sprintf("PortRange:%+v; Protocol:%s; IPRanges:%+v; Sources:%+v", r.PortRange, r.Protocol, r.IPRanges, r.Sources)
 }
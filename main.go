@@ -16,8 +16,19 @@ limitations under the License.
 
 package main
 
-import "github.com/wallix/awless/commands"
+import (
+	"fmt"
+	"os"
+
+	"github.com/wallix/awless/commands"
+)
 
 func main() {
+	args, err := commands.ExpandAlias(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	commands.RootCmd.SetArgs(args)
 	commands.RootCmd.Execute()
 }
@@ -0,0 +1,314 @@
+package ssh
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// Forward is a port forwarding: connections accepted on the bind address are
+// forwarded to the host port, as with the -L and -R options of OpenSSH.
+// An empty bind address listens on all interfaces.
+type Forward struct {
+	BindAddr string
+	BindPort int
+	Host     string
+	HostPort int
+}
+
+// ParseForward parses a forwarding specification [bind_address:]port:host:hostport,
+// the bind address defaulting to localhost, '*' binding all interfaces.
+// IPv6 addresses are enclosed in square brackets
+func ParseForward(spec string) (*Forward, error) {
+	splits := splitForward(spec)
+	if len(splits) == 3 {
+		splits = append([]string{"localhost"}, splits...)
+	}
+	if len(splits) != 4 || splits[0] == "" || splits[2] == "" {
+		return nil, fmt.Errorf("invalid forwarding '%s', expecting [bind_address:]port:host:hostport", spec)
+	}
+	bindPort, err := parsePort(splits[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid forwarding '%s': %s", spec, err)
+	}
+	hostPort, err := parsePort(splits[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid forwarding '%s': %s", spec, err)
+	}
+	return &Forward{BindAddr: bindAddr(splits[0]), BindPort: bindPort, Host: splits[2], HostPort: hostPort}, nil
+}
+
+// ParseDynamicForward parses the listening address [bind_address:]port of a SOCKS proxy,
+// the bind address defaulting to localhost, '*' binding all interfaces
+func ParseDynamicForward(spec string) (string, error) {
+	host, port := "localhost", spec
+	if splits := splitForward(spec); len(splits) == 2 {
+		host, port = splits[0], splits[1]
+	} else if len(splits) > 2 {
+		port = ""
+	}
+	p, err := parsePort(port)
+	if err != nil || host == "" {
+		return "", fmt.Errorf("invalid SOCKS proxy address '%s', expecting [bind_address:]port", spec)
+	}
+	return net.JoinHostPort(bindAddr(host), strconv.Itoa(p)), nil
+}
+
+func (f *Forward) String() string {
+	return fmt.Sprintf("%s:%s", f.bindAddress(), f.hostAddress())
+}
+
+func (f *Forward) bindAddress() string {
+	return net.JoinHostPort(f.BindAddr, strconv.Itoa(f.BindPort))
+}
+
+func (f *Forward) hostAddress() string {
+	return net.JoinHostPort(f.Host, strconv.Itoa(f.HostPort))
+}
+
+// splitForward splits a forwarding specification on the colons that are not
+// enclosed in square brackets, removing the brackets
+func splitForward(spec string) (splits []string) {
+	var bracketed bool
+	var start int
+	for i, r := range spec {
+		switch r {
+		case '[':
+			bracketed = true
+		case ']':
+			bracketed = false
+		case ':':
+			if !bracketed {
+				splits = append(splits, unbracket(spec[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	return append(splits, unbracket(spec[start:]))
+}
+
+func unbracket(s string) string {
+	if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// bindAddr returns the address to listen on: '*' stands for all interfaces
+func bindAddr(addr string) string {
+	if addr == "*" {
+		return ""
+	}
+	return addr
+}
+
+func parsePort(s string) (int, error) {
+	port, err := strconv.Atoi(s)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port '%s'", s)
+	}
+	return port, nil
+}
+
+// Forward serves the local and remote forwardings and the SOCKS proxy of the client
+// until its connection is closed
+func (c *Client) Forward() error {
+	if len(c.LocalForwards) == 0 && len(c.RemoteForwards) == 0 && c.SocksProxy == "" {
+		return errors.New("no forwarding to serve")
+	}
+	var listeners []net.Listener
+	defer func() {
+		for _, l := range listeners {
+			l.Close()
+		}
+	}()
+
+	for _, f := range c.LocalForwards {
+		l, err := net.Listen("tcp", f.bindAddress())
+		if err != nil {
+			return fmt.Errorf("local forwarding %s: %s", f, err)
+		}
+		listeners = append(listeners, l)
+		c.logger.Infof("Forwarding local %s to %s through %s", f.bindAddress(), f.hostAddress(), c.IP)
+		go c.serveForward(l, c.Dial, f.hostAddress())
+	}
+	for _, f := range c.RemoteForwards {
+		l, err := c.Listen("tcp", f.bindAddress())
+		if err != nil {
+			return fmt.Errorf("remote forwarding %s: %s", f, err)
+		}
+		listeners = append(listeners, l)
+		c.logger.Infof("Forwarding %s on %s to local %s", f.bindAddress(), c.IP, f.hostAddress())
+		go c.serveForward(l, net.Dial, f.hostAddress())
+	}
+	if c.SocksProxy != "" {
+		l, err := net.Listen("tcp", c.SocksProxy)
+		if err != nil {
+			return fmt.Errorf("SOCKS proxy: %s", err)
+		}
+		listeners = append(listeners, l)
+		c.logger.Infof("SOCKS proxy listening on %s through %s", c.SocksProxy, c.IP)
+		go c.serveSocks(l)
+	}
+	c.logger.Info("Press Ctrl+C to stop forwarding")
+
+	return c.Wait()
+}
+
+func (c *Client) serveForward(l net.Listener, dial func(string, string) (net.Conn, error), addr string) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			remote, err := dial("tcp", addr)
+			if err != nil {
+				c.logger.Errorf("cannot forward %s to %s: %s", conn.RemoteAddr(), addr, err)
+				conn.Close()
+				return
+			}
+			c.logger.ExtraVerbosef("forwarding %s to %s", conn.RemoteAddr(), addr)
+			pipe(conn, remote)
+		}()
+	}
+}
+
+func (c *Client) serveSocks(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			remote, addr, err := socksConnect(conn, c.Dial)
+			if err != nil {
+				c.logger.Errorf("SOCKS proxy: %s", err)
+				conn.Close()
+				return
+			}
+			c.logger.ExtraVerbosef("proxying %s to %s", conn.RemoteAddr(), addr)
+			pipe(conn, remote)
+		}()
+	}
+}
+
+const (
+	socksVersion        = 5
+	socksNoAuth         = 0
+	socksNoAcceptable   = 0xff
+	socksConnectCommand = 1
+
+	socksIPv4   = 1
+	socksDomain = 3
+	socksIPv6   = 4
+
+	socksSucceeded           = 0
+	socksGeneralFailure      = 1
+	socksCommandNotSupported = 7
+	socksAddressNotSupported = 8
+)
+
+// socksConnect negotiates a SOCKS5 CONNECT (RFC 1928) without authentication on the connection
+// and returns the connection dialed to the requested destination with its address
+func socksConnect(conn net.Conn, dial func(string, string) (net.Conn, error)) (net.Conn, string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, "", err
+	}
+	if header[0] != socksVersion {
+		return nil, "", fmt.Errorf("unsupported SOCKS version %d", header[0])
+	}
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return nil, "", err
+	}
+	var noAuth bool
+	for _, m := range methods {
+		if m == socksNoAuth {
+			noAuth = true
+		}
+	}
+	if !noAuth {
+		conn.Write([]byte{socksVersion, socksNoAcceptable})
+		return nil, "", errors.New("SOCKS client requires authentication")
+	}
+	if _, err := conn.Write([]byte{socksVersion, socksNoAuth}); err != nil {
+		return nil, "", err
+	}
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return nil, "", err
+	}
+	if request[1] != socksConnectCommand {
+		socksReply(conn, socksCommandNotSupported)
+		return nil, "", fmt.Errorf("unsupported SOCKS command %d", request[1])
+	}
+	var host string
+	switch request[3] {
+	case socksIPv4, socksIPv6:
+		ip := make(net.IP, net.IPv4len)
+		if request[3] == socksIPv6 {
+			ip = make(net.IP, net.IPv6len)
+		}
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return nil, "", err
+		}
+		host = ip.String()
+	case socksDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return nil, "", err
+		}
+		domain := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return nil, "", err
+		}
+		host = string(domain)
+	default:
+		socksReply(conn, socksAddressNotSupported)
+		return nil, "", fmt.Errorf("unsupported SOCKS address type %d", request[3])
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return nil, "", err
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+
+	remote, err := dial("tcp", addr)
+	if err != nil {
+		socksReply(conn, socksGeneralFailure)
+		return nil, addr, fmt.Errorf("cannot connect to %s: %s", addr, err)
+	}
+	if err := socksReply(conn, socksSucceeded); err != nil {
+		remote.Close()
+		return nil, addr, err
+	}
+	return remote, addr, nil
+}
+
+func socksReply(conn net.Conn, status byte) error {
+	_, err := conn.Write([]byte{socksVersion, status, 0, socksIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
+
+// pipe copies the data between both connections until one of them is closed
+func pipe(a, b net.Conn) {
+	defer a.Close()
+	defer b.Close()
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(a, b)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(b, a)
+		done <- struct{}{}
+	}()
+	<-done
+}
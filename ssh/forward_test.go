@@ -0,0 +1,146 @@
+package ssh
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestParseForward(t *testing.T) {
+	tcases := []struct {
+		spec   string
+		exp    *Forward
+		expErr bool
+	}{
+		{spec: "5432:db.internal:5432", exp: &Forward{BindAddr: "localhost", BindPort: 5432, Host: "db.internal", HostPort: 5432}},
+		{spec: "0.0.0.0:8080:localhost:80", exp: &Forward{BindAddr: "0.0.0.0", BindPort: 8080, Host: "localhost", HostPort: 80}},
+		{spec: "*:8080:localhost:80", exp: &Forward{BindAddr: "", BindPort: 8080, Host: "localhost", HostPort: 80}},
+		{spec: "[::1]:8080:localhost:80", exp: &Forward{BindAddr: "::1", BindPort: 8080, Host: "localhost", HostPort: 80}},
+		{spec: "8080:[fd00::10]:80", exp: &Forward{BindAddr: "localhost", BindPort: 8080, Host: "fd00::10", HostPort: 80}},
+		{spec: "5432:db.internal", expErr: true},
+		{spec: "::1:8080:localhost:80", expErr: true},
+		{spec: "5432::5432", expErr: true},
+		{spec: "port:db.internal:5432", expErr: true},
+		{spec: "5432:db.internal:70000", expErr: true},
+	}
+	for i, tcase := range tcases {
+		f, err := ParseForward(tcase.spec)
+		if tcase.expErr {
+			if err == nil {
+				t.Fatalf("case %d: expected error got none", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("case %d: %s", i+1, err)
+		}
+		if got, want := f, tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("case %d: got %#v, want %#v", i+1, got, want)
+		}
+	}
+
+	if got, err := ParseDynamicForward("1080"); err != nil || got != "localhost:1080" {
+		t.Fatalf("got %s (err: %v), want localhost:1080", got, err)
+	}
+	if got, err := ParseDynamicForward("0.0.0.0:1080"); err != nil || got != "0.0.0.0:1080" {
+		t.Fatalf("got %s (err: %v), want 0.0.0.0:1080", got, err)
+	}
+	if got, err := ParseDynamicForward("*:1080"); err != nil || got != ":1080" {
+		t.Fatalf("got %s (err: %v), want :1080", got, err)
+	}
+	if got, err := ParseDynamicForward("[::1]:1080"); err != nil || got != "[::1]:1080" {
+		t.Fatalf("got %s (err: %v), want [::1]:1080", got, err)
+	}
+	if got, want := (&Forward{BindAddr: "::1", BindPort: 8080, Host: "fd00::10", HostPort: 80}).String(), "[::1]:8080:[fd00::10]:80"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if _, err := ParseDynamicForward(":1080"); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestSocksConnect(t *testing.T) {
+	t.Run("domain", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+		destination, dialed := net.Pipe()
+		var dialedAddr string
+		dial := func(network, addr string) (net.Conn, error) {
+			dialedAddr = addr
+			return dialed, nil
+		}
+
+		errc := make(chan error, 1)
+		go func() {
+			remote, addr, err := socksConnect(server, dial)
+			if err == nil && addr != "db.internal:5432" {
+				err = errors.New("unexpected address " + addr)
+			}
+			if err == nil {
+				go pipe(server, remote)
+			}
+			errc <- err
+		}()
+
+		client.Write([]byte{5, 1, 0})
+		expectRead(t, client, []byte{5, 0})
+		client.Write(append(append([]byte{5, 1, 0, 3, 11}, "db.internal"...), 0x15, 0x38))
+		expectRead(t, client, []byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0})
+		if err := <-errc; err != nil {
+			t.Fatal(err)
+		}
+		if got, want := dialedAddr, "db.internal:5432"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+
+		go client.Write([]byte("ping"))
+		expectRead(t, destination, []byte("ping"))
+	})
+
+	t.Run("unsupported command", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+		errc := make(chan error, 1)
+		go func() {
+			_, _, err := socksConnect(server, func(string, string) (net.Conn, error) { return nil, errors.New("unexpected dial") })
+			errc <- err
+		}()
+
+		client.Write([]byte{5, 1, 0})
+		expectRead(t, client, []byte{5, 0})
+		client.Write([]byte{5, 2, 0, 1})
+		expectRead(t, client, []byte{5, 7, 0, 1, 0, 0, 0, 0, 0, 0})
+		if err := <-errc; err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+
+	t.Run("authentication required", func(t *testing.T) {
+		client, server := net.Pipe()
+		defer client.Close()
+		errc := make(chan error, 1)
+		go func() {
+			_, _, err := socksConnect(server, nil)
+			errc <- err
+		}()
+
+		client.Write([]byte{5, 1, 2})
+		expectRead(t, client, []byte{5, 0xff})
+		if err := <-errc; err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+}
+
+func expectRead(t *testing.T, conn net.Conn, exp []byte) {
+	got := make([]byte, len(exp))
+	if _, err := io.ReadFull(conn, got); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, exp) {
+		t.Fatalf("got %v, want %v", got, exp)
+	}
+}
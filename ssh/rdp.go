@@ -0,0 +1,101 @@
+package ssh
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"text/template"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const DefaultRDPPort = 3389
+
+type RDPConfig struct {
+	IP, User string
+	Port     int
+}
+
+// DecryptWindowsPassword decrypts the base64 encoded password data returned by EC2 GetPasswordData
+// for Windows instances, using the private key of the keypair the instance was launched with
+func DecryptWindowsPassword(passwordData, keyname string, keyFolders ...string) (string, string, error) {
+	privkey, ok := findPrivateKeyFromName(keyname, keyFolders...)
+	if !ok {
+		return "", "", fmt.Errorf("cannot find SSH key '%s' to decrypt password", keyname)
+	}
+
+	rsaKey, err := parseRSAPrivateKey(privkey)
+	if err != nil {
+		return "", privkey.path, err
+	}
+
+	encrypted, err := base64.StdEncoding.DecodeString(strings.TrimSpace(passwordData))
+	if err != nil {
+		return "", privkey.path, fmt.Errorf("cannot decode password data: %s", err)
+	}
+
+	decrypted, err := rsa.DecryptPKCS1v15(rand.Reader, rsaKey, encrypted)
+	if err != nil {
+		return "", privkey.path, fmt.Errorf("cannot decrypt password with key '%s': %s", privkey.path, err)
+	}
+
+	return string(decrypted), privkey.path, nil
+}
+
+func parseRSAPrivateKey(priv privateKey) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(priv.body)
+	if block == nil {
+		return nil, fmt.Errorf("cannot decode PEM key at '%s'", priv.path)
+	}
+
+	der := block.Bytes
+	if x509.IsEncryptedPEMBlock(block) {
+		fmt.Fprintf(os.Stderr, "This SSH key is encrypted. Please enter passphrase for key '%s':", priv.path)
+		passphrase, err := terminal.ReadPassword(int(syscall.Stdin))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintln(os.Stderr)
+		if der, err = x509.DecryptPEMBlock(block, passphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(der)
+	if err != nil {
+		return nil, errors.New("only RSA keys can decrypt Windows passwords")
+	}
+	return key, nil
+}
+
+func (c *RDPConfig) String() string {
+	var buf bytes.Buffer
+
+	port := c.Port
+	if port == 0 {
+		port = DefaultRDPPort
+	}
+
+	params := struct {
+		IP, User string
+		Port     int
+	}{c.IP, c.User, port}
+
+	template.Must(template.New("rdp_file").Parse(`full address:s:{{ .IP }}:{{ .Port }}
+{{- if .User }}
+username:s:{{ .User }}
+{{- end }}
+prompt for credentials:i:1
+administrative session:i:1
+`)).Execute(&buf, params)
+
+	return buf.String()
+}
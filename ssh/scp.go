@@ -0,0 +1,305 @@
+package ssh
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ProgressFunc reports the bytes transferred so far of a copied file
+type ProgressFunc func(file string, transferred, size int64)
+
+// Upload copies through SFTP the local files matching the patterns to the remote destination,
+// into it when it is a directory or when copying several files, as scp does
+func (c *Client) Upload(patterns []string, dest string, recursive bool, progress ProgressFunc) error {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return err
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("%s: no such file or directory", pattern)
+		}
+		files = append(files, matches...)
+	}
+	sftp, err := c.newSFTPClient()
+	if err != nil {
+		return err
+	}
+	defer sftp.Close()
+	return upload(sftp, files, dest, recursive, progress)
+}
+
+// Download copies through SFTP the remote files matching the patterns to the local destination,
+// into it when it is a directory or when copying several files, as scp does. Only the last
+// element of a remote path can be a pattern.
+func (c *Client) Download(patterns []string, dest string, recursive bool, progress ProgressFunc) error {
+	sftp, err := c.newSFTPClient()
+	if err != nil {
+		return err
+	}
+	defer sftp.Close()
+	return download(sftp, patterns, dest, recursive, progress)
+}
+
+func (c *Client) newSFTPClient() (*sftpClient, error) {
+	session, err := c.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	w, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	r, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	if err = session.RequestSubsystem("sftp"); err != nil {
+		session.Close()
+		return nil, fmt.Errorf("cannot start sftp on %s: %s", c.IP, err)
+	}
+	client, err := newSFTPClient(r, w)
+	if err != nil {
+		session.Close()
+		return nil, err
+	}
+	client.closer = session
+	return client, nil
+}
+
+func upload(sftp *sftpClient, files []string, dest string, recursive bool, progress ProgressFunc) error {
+	intoDir := len(files) > 1
+	if attrs, err := sftp.stat(dest); err == nil && attrs.isDir() {
+		intoDir = true
+	} else if intoDir {
+		return fmt.Errorf("%s: not a directory", dest)
+	}
+	for _, file := range files {
+		target := dest
+		if intoDir {
+			target = path.Join(dest, filepath.Base(file))
+		}
+		if err := uploadPath(sftp, file, target, recursive, progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uploadPath(sftp *sftpClient, local, remote string, recursive bool, progress ProgressFunc) error {
+	info, err := os.Stat(local)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return uploadFile(sftp, local, remote, info, progress)
+	}
+	if !recursive {
+		return fmt.Errorf("%s: is a directory (copy it with --recursive)", local)
+	}
+	if err = sftp.mkdir(remote, info.Mode().Perm()); err != nil {
+		if attrs, serr := sftp.stat(remote); serr != nil || !attrs.isDir() {
+			return err
+		}
+	}
+	entries, err := readDirNames(local)
+	if err != nil {
+		return err
+	}
+	for _, name := range entries {
+		if err := uploadPath(sftp, filepath.Join(local, name), path.Join(remote, name), recursive, progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uploadFile(sftp *sftpClient, local, remote string, info os.FileInfo, progress ProgressFunc) error {
+	f, err := os.Open(local)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	handle, err := sftp.open(remote, sftpOpenWrite|sftpOpenCreate|sftpOpenTruncate, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, sftpChunkSize)
+	var offset int64
+	progress(local, offset, info.Size())
+	for {
+		n, rerr := f.Read(buf)
+		if n > 0 {
+			if err = sftp.write(handle, uint64(offset), buf[:n]); err != nil {
+				sftp.close(handle)
+				return err
+			}
+			offset += int64(n)
+			progress(local, offset, info.Size())
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			sftp.close(handle)
+			return rerr
+		}
+	}
+	return sftp.close(handle)
+}
+
+func download(sftp *sftpClient, patterns []string, dest string, recursive bool, progress ProgressFunc) error {
+	var files []string
+	for _, pattern := range patterns {
+		matches, err := globRemote(sftp, pattern)
+		if err != nil {
+			return err
+		}
+		files = append(files, matches...)
+	}
+
+	intoDir := len(files) > 1
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		intoDir = true
+	} else if intoDir {
+		return fmt.Errorf("%s: not a directory", dest)
+	}
+	for _, file := range files {
+		target := dest
+		if intoDir {
+			target = filepath.Join(dest, path.Base(file))
+		}
+		if err := downloadPath(sftp, file, target, recursive, make(map[string]bool), progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadPath copies the remote file or directory, the canonical paths of the directories being
+// copied (i.e. ancestors) detecting the symbolic links looping back to one of them
+func downloadPath(sftp *sftpClient, remote, local string, recursive bool, ancestors map[string]bool, progress ProgressFunc) error {
+	attrs, err := sftp.stat(remote)
+	if err != nil {
+		return err
+	}
+	if !attrs.isDir() {
+		return downloadFile(sftp, remote, local, attrs, progress)
+	}
+	if !recursive {
+		return fmt.Errorf("%s: is a directory (copy it with --recursive)", remote)
+	}
+	canonical, err := sftp.realpath(remote)
+	if err != nil {
+		return err
+	}
+	if ancestors[canonical] {
+		return fmt.Errorf("%s: symbolic link loop to %s", remote, canonical)
+	}
+	ancestors[canonical] = true
+	defer delete(ancestors, canonical)
+	if err = os.Mkdir(local, attrs.perm()); err != nil {
+		if info, serr := os.Stat(local); serr != nil || !info.IsDir() {
+			return err
+		}
+	}
+	entries, err := sftp.readDir(remote)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := downloadPath(sftp, path.Join(remote, e.name), filepath.Join(local, e.name), recursive, ancestors, progress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func downloadFile(sftp *sftpClient, remote, local string, attrs *sftpAttrs, progress ProgressFunc) error {
+	handle, err := sftp.open(remote, sftpOpenRead, 0)
+	if err != nil {
+		return err
+	}
+	defer sftp.close(handle)
+	f, err := os.OpenFile(local, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, attrs.perm())
+	if err != nil {
+		return err
+	}
+
+	size := int64(attrs.size)
+	var offset int64
+	progress(remote, offset, size)
+	for {
+		data, err := sftp.read(handle, uint64(offset), sftpChunkSize)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if _, err = f.Write(data); err != nil {
+			f.Close()
+			return err
+		}
+		offset += int64(len(data))
+		progress(remote, offset, size)
+	}
+	return f.Close()
+}
+
+// globRemote returns the remote paths matching the pattern, whose last element only can have wildcards
+func globRemote(sftp *sftpClient, pattern string) ([]string, error) {
+	if !strings.ContainsAny(pattern, "*?[") {
+		return []string{pattern}, nil
+	}
+	dir, base := path.Split(pattern)
+	if strings.ContainsAny(dir, "*?[") {
+		return nil, fmt.Errorf("%s: only the last element of a remote path can have wildcards", pattern)
+	}
+	listed := dir
+	if listed == "" {
+		listed = "."
+	}
+	entries, err := sftp.readDir(listed)
+	if err != nil {
+		return nil, err
+	}
+	var matches []string
+	for _, e := range entries {
+		ok, err := path.Match(base, e.name)
+		if err != nil {
+			return nil, err
+		}
+		if ok && (!strings.HasPrefix(e.name, ".") || strings.HasPrefix(base, ".")) {
+			matches = append(matches, dir+e.name)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%s: no such file or directory", pattern)
+	}
+	return matches, nil
+}
+
+func readDirNames(dir string) ([]string, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	names, err := f.Readdirnames(-1)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,290 @@
+package ssh
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUploadAndDownload(t *testing.T) {
+	local, err := ioutil.TempDir("", "scp-local")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(local)
+	remote, err := ioutil.TempDir("", "scp-remote")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(remote)
+
+	big := bytes.Repeat([]byte("0123456789"), 10000)
+	writeFiles(t, local, map[string]string{
+		"src/a.txt":     "hello",
+		"src/c.log":     "log",
+		"src/sub/b.txt": string(big),
+	})
+
+	sftp, server := newFakeSFTPClient(t, remote)
+	defer sftp.Close()
+	transferred := make(map[string]int64)
+	progress := func(file string, done, size int64) {
+		if done > size {
+			t.Fatalf("%s: transferred %d of %d", file, done, size)
+		}
+		transferred[file] = done
+	}
+
+	if err = upload(sftp, []string{filepath.Join(local, "src", "a.txt")}, "a-copy.txt", false, progress); err != nil {
+		t.Fatal(err)
+	}
+	expectFileContent(t, filepath.Join(remote, "a-copy.txt"), "hello")
+
+	if err = upload(sftp, []string{filepath.Join(local, "src")}, "dest", false, progress); err == nil || !strings.Contains(err.Error(), "is a directory") {
+		t.Fatalf("got %v, want directory error", err)
+	}
+	if err = upload(sftp, []string{filepath.Join(local, "src", "a.txt"), filepath.Join(local, "src", "c.log")}, "missing", false, progress); err == nil || !strings.Contains(err.Error(), "not a directory") {
+		t.Fatalf("got %v, want not a directory error", err)
+	}
+
+	if err = upload(sftp, []string{filepath.Join(local, "src")}, "dest", true, progress); err != nil {
+		t.Fatal(err)
+	}
+	expectFileContent(t, filepath.Join(remote, "dest", "a.txt"), "hello")
+	expectFileContent(t, filepath.Join(remote, "dest", "sub", "b.txt"), string(big))
+	if got, want := transferred[filepath.Join(local, "src", "sub", "b.txt")], int64(len(big)); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	out := filepath.Join(local, "out")
+	if err = os.Mkdir(out, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = download(sftp, []string{"dest/*.txt", "dest/c.log"}, out, false, progress); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	files, _ := ioutil.ReadDir(out)
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	if got, want := names, []string{"a.txt", "c.log"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if err = download(sftp, []string{"dest"}, filepath.Join(out, "tree"), true, progress); err != nil {
+		t.Fatal(err)
+	}
+	expectFileContent(t, filepath.Join(out, "tree", "sub", "b.txt"), string(big))
+	if got, want := transferred["dest/sub/b.txt"], int64(len(big)); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	if err = os.Symlink("..", filepath.Join(remote, "dest", "sub", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	if err = download(sftp, []string{"dest"}, filepath.Join(out, "looping"), true, progress); err == nil || !strings.Contains(err.Error(), "symbolic link loop") {
+		t.Fatalf("got %v, want symbolic link loop error", err)
+	}
+
+	server.extraName = "../escaped"
+	if err = download(sftp, []string{"dest"}, filepath.Join(out, "escaping"), true, progress); err == nil || !strings.Contains(err.Error(), "invalid file name") {
+		t.Fatalf("got %v, want invalid file name error", err)
+	}
+	server.extraName = ""
+
+	if err = download(sftp, []string{"dest/*.md"}, out, false, progress); err == nil {
+		t.Fatal("expected error got none")
+	}
+	if err = download(sftp, []string{"unknown"}, out, false, progress); !os.IsNotExist(err) {
+		t.Fatalf("got %v, want not exist error", err)
+	}
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func expectFileContent(t *testing.T, path, exp string) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), exp; got != want {
+		t.Fatalf("%s: got %d bytes, want %d bytes", path, len(got), len(want))
+	}
+}
+
+func newFakeSFTPClient(t *testing.T, root string) (*sftpClient, *fakeSFTPServer) {
+	clientR, serverW := io.Pipe()
+	serverR, clientW := io.Pipe()
+	server := &fakeSFTPServer{root: root, files: make(map[string]*os.File), dirs: make(map[string][]os.FileInfo)}
+	go server.serve(serverR, serverW)
+	client, err := newSFTPClient(clientR, clientW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, server
+}
+
+// fakeSFTPServer serves the files of its root directory, listing extraName in every directory when set
+type fakeSFTPServer struct {
+	root      string
+	files     map[string]*os.File
+	dirs      map[string][]os.FileInfo
+	handles   int
+	extraName string
+}
+
+func (s *fakeSFTPServer) serve(r io.Reader, w io.WriteCloser) {
+	defer w.Close()
+	for {
+		typ, req, err := readSFTPPacket(r)
+		if err != nil {
+			return
+		}
+		resp := new(sftpPacket)
+		if typ == sftpPacketInit {
+			resp.uint32(sftpProtocolVersion)
+			writeSFTPPacket(w, sftpPacketVersion, resp)
+			continue
+		}
+		resp.uint32(req.uint32())
+		if err = writeSFTPPacket(w, s.handle(typ, req, resp), resp); err != nil {
+			return
+		}
+	}
+}
+
+func (s *fakeSFTPServer) handle(typ byte, req *sftpReader, resp *sftpPacket) byte {
+	switch typ {
+	case sftpPacketStat:
+		info, err := os.Stat(s.path(req.string()))
+		if err != nil {
+			return s.status(resp, err)
+		}
+		mode := uint32(info.Mode().Perm())
+		if info.IsDir() {
+			mode |= sftpModeTypeDir
+		}
+		resp.uint32(sftpAttrSize | sftpAttrPerms)
+		resp.uint64(uint64(info.Size()))
+		resp.uint32(mode)
+		return sftpPacketAttrs
+	case sftpPacketRealpath:
+		path, err := filepath.EvalSymlinks(s.path(req.string()))
+		if err != nil {
+			return s.status(resp, err)
+		}
+		resp.uint32(1)
+		resp.string(filepath.ToSlash(path))
+		resp.string(filepath.ToSlash(path))
+		resp.uint32(0)
+		return sftpPacketName
+	case sftpPacketMkdir:
+		path := s.path(req.string())
+		return s.status(resp, os.Mkdir(path, os.FileMode(req.attrs().mode)))
+	case sftpPacketOpen:
+		path, flags, attrs := s.path(req.string()), req.uint32(), req.attrs()
+		osFlags := os.O_RDONLY
+		if flags&sftpOpenWrite != 0 {
+			osFlags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		}
+		f, err := os.OpenFile(path, osFlags, attrs.perm())
+		if err != nil {
+			return s.status(resp, err)
+		}
+		return s.newHandle(resp, func(h string) { s.files[h] = f })
+	case sftpPacketClose:
+		h := req.string()
+		if f, ok := s.files[h]; ok {
+			delete(s.files, h)
+			return s.status(resp, f.Close())
+		}
+		delete(s.dirs, h)
+		return s.status(resp, nil)
+	case sftpPacketWrite:
+		f, offset, data := s.files[req.string()], req.uint64(), req.string()
+		_, err := f.WriteAt([]byte(data), int64(offset))
+		return s.status(resp, err)
+	case sftpPacketRead:
+		f, offset, length := s.files[req.string()], req.uint64(), req.uint32()
+		data := make([]byte, length)
+		n, err := f.ReadAt(data, int64(offset))
+		if n == 0 {
+			return s.status(resp, err)
+		}
+		resp.string(string(data[:n]))
+		return sftpPacketData
+	case sftpPacketOpendir:
+		infos, err := ioutil.ReadDir(s.path(req.string()))
+		if err != nil {
+			return s.status(resp, err)
+		}
+		return s.newHandle(resp, func(h string) { s.dirs[h] = infos })
+	case sftpPacketReaddir:
+		h := req.string()
+		infos := s.dirs[h]
+		if len(infos) == 0 {
+			return s.status(resp, io.EOF)
+		}
+		s.dirs[h] = nil
+		names := make([]string, 0, len(infos)+1)
+		for _, info := range infos {
+			names = append(names, info.Name())
+		}
+		if s.extraName != "" {
+			names = append(names, s.extraName)
+		}
+		resp.uint32(uint32(len(names)))
+		for _, name := range names {
+			resp.string(name)
+			resp.string(name)
+			resp.uint32(0)
+		}
+		return sftpPacketName
+	}
+	return s.status(resp, fmt.Errorf("unsupported packet %d", typ))
+}
+
+func (s *fakeSFTPServer) path(p string) string {
+	return filepath.Join(s.root, filepath.FromSlash(p))
+}
+
+func (s *fakeSFTPServer) newHandle(resp *sftpPacket, store func(string)) byte {
+	s.handles++
+	h := fmt.Sprint(s.handles)
+	store(h)
+	resp.string(h)
+	return sftpPacketHandle
+}
+
+func (s *fakeSFTPServer) status(resp *sftpPacket, err error) byte {
+	code := uint32(sftpStatusOK)
+	switch {
+	case err == io.EOF:
+		code = sftpStatusEOF
+	case os.IsNotExist(err):
+		code = sftpStatusNoFile
+	case err != nil:
+		code = 4
+	}
+	resp.uint32(code)
+	resp.string(fmt.Sprint(err))
+	resp.string("")
+	return sftpPacketStatus
+}
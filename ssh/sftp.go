@@ -0,0 +1,449 @@
+package ssh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Subset of the SFTP protocol version 3 (draft-ietf-secsh-filexfer-02) needed to copy files
+const (
+	sftpProtocolVersion = 3
+	sftpMaxPacketSize   = 256 * 1024
+	sftpChunkSize       = 32 * 1024
+
+	sftpPacketInit     = 1
+	sftpPacketVersion  = 2
+	sftpPacketOpen     = 3
+	sftpPacketClose    = 4
+	sftpPacketRead     = 5
+	sftpPacketWrite    = 6
+	sftpPacketOpendir  = 11
+	sftpPacketReaddir  = 12
+	sftpPacketMkdir    = 14
+	sftpPacketRealpath = 16
+	sftpPacketStat     = 17
+	sftpPacketStatus   = 101
+	sftpPacketHandle   = 102
+	sftpPacketData     = 103
+	sftpPacketName     = 104
+	sftpPacketAttrs    = 105
+
+	sftpStatusOK     = 0
+	sftpStatusEOF    = 1
+	sftpStatusNoFile = 2
+	sftpStatusDenied = 3
+
+	sftpOpenRead     = 0x01
+	sftpOpenWrite    = 0x02
+	sftpOpenCreate   = 0x08
+	sftpOpenTruncate = 0x10
+
+	sftpAttrSize      = 0x01
+	sftpAttrUIDGID    = 0x02
+	sftpAttrPerms     = 0x04
+	sftpAttrACModTime = 0x08
+	sftpAttrExtended  = 0x80000000
+
+	sftpModeTypeMask  = 0170000
+	sftpModeTypeDir   = 0040000
+	sftpModePermsMask = 0777
+)
+
+const sftpUnexpectedType = "unexpected sftp packet type %d in response to %s"
+
+// sftpClient sends the requests one at a time and waits for their response
+type sftpClient struct {
+	r      io.Reader
+	w      io.WriteCloser
+	closer io.Closer
+	nextID uint32
+}
+
+func newSFTPClient(r io.Reader, w io.WriteCloser) (*sftpClient, error) {
+	c := &sftpClient{r: r, w: w}
+	init := new(sftpPacket)
+	init.uint32(sftpProtocolVersion)
+	if err := c.send(sftpPacketInit, init); err != nil {
+		return nil, fmt.Errorf("sftp init: %s", err)
+	}
+	typ, resp, err := c.recv()
+	if err != nil {
+		return nil, fmt.Errorf("sftp init: %s", err)
+	}
+	if typ != sftpPacketVersion {
+		return nil, fmt.Errorf(sftpUnexpectedType, typ, "init")
+	}
+	if version := resp.uint32(); version != sftpProtocolVersion {
+		return nil, fmt.Errorf("unsupported sftp version %d", version)
+	}
+	return c, nil
+}
+
+func (c *sftpClient) Close() error {
+	err := c.w.Close()
+	if c.closer != nil {
+		if cerr := c.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+type sftpAttrs struct {
+	size uint64
+	mode uint32
+}
+
+func (a *sftpAttrs) isDir() bool {
+	return a.mode&sftpModeTypeMask == sftpModeTypeDir
+}
+
+func (a *sftpAttrs) perm() os.FileMode {
+	return os.FileMode(a.mode & sftpModePermsMask)
+}
+
+type sftpEntry struct {
+	name  string
+	attrs *sftpAttrs
+}
+
+func (c *sftpClient) stat(path string) (*sftpAttrs, error) {
+	typ, resp, err := c.request(sftpPacketStat, func(p *sftpPacket) { p.string(path) })
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case sftpPacketAttrs:
+		return resp.attrs(), resp.err
+	case sftpPacketStatus:
+		return nil, resp.statusError("stat", path)
+	}
+	return nil, fmt.Errorf(sftpUnexpectedType, typ, "stat")
+}
+
+// realpath returns the canonical absolute path, with the symbolic links resolved
+func (c *sftpClient) realpath(path string) (string, error) {
+	typ, resp, err := c.request(sftpPacketRealpath, func(p *sftpPacket) { p.string(path) })
+	if err != nil {
+		return "", err
+	}
+	switch typ {
+	case sftpPacketName:
+		if count := resp.uint32(); count != 1 && resp.err == nil {
+			return "", fmt.Errorf("realpath %s: got %d names", path, count)
+		}
+		return resp.string(), resp.err
+	case sftpPacketStatus:
+		if err = resp.statusError("realpath", path); err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf(sftpUnexpectedType, typ, "realpath")
+}
+
+func (c *sftpClient) mkdir(path string, perm os.FileMode) error {
+	typ, resp, err := c.request(sftpPacketMkdir, func(p *sftpPacket) {
+		p.string(path)
+		p.perms(perm)
+	})
+	if err != nil {
+		return err
+	}
+	if typ != sftpPacketStatus {
+		return fmt.Errorf(sftpUnexpectedType, typ, "mkdir")
+	}
+	return resp.statusError("mkdir", path)
+}
+
+func (c *sftpClient) open(path string, flags uint32, perm os.FileMode) (string, error) {
+	typ, resp, err := c.request(sftpPacketOpen, func(p *sftpPacket) {
+		p.string(path)
+		p.uint32(flags)
+		p.perms(perm)
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.handle(typ, "open", path)
+}
+
+func (c *sftpClient) close(handle string) error {
+	typ, resp, err := c.request(sftpPacketClose, func(p *sftpPacket) { p.string(handle) })
+	if err != nil {
+		return err
+	}
+	if typ != sftpPacketStatus {
+		return fmt.Errorf(sftpUnexpectedType, typ, "close")
+	}
+	return resp.statusError("close", "")
+}
+
+func (c *sftpClient) write(handle string, offset uint64, data []byte) error {
+	typ, resp, err := c.request(sftpPacketWrite, func(p *sftpPacket) {
+		p.string(handle)
+		p.uint64(offset)
+		p.string(string(data))
+	})
+	if err != nil {
+		return err
+	}
+	if typ != sftpPacketStatus {
+		return fmt.Errorf(sftpUnexpectedType, typ, "write")
+	}
+	return resp.statusError("write", "")
+}
+
+// read returns io.EOF at the end of the file
+func (c *sftpClient) read(handle string, offset uint64, length uint32) ([]byte, error) {
+	typ, resp, err := c.request(sftpPacketRead, func(p *sftpPacket) {
+		p.string(handle)
+		p.uint64(offset)
+		p.uint32(length)
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case sftpPacketData:
+		return []byte(resp.string()), resp.err
+	case sftpPacketStatus:
+		if err := resp.statusError("read", ""); err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf(sftpUnexpectedType, typ, "read")
+}
+
+// readDir returns the entries of the directory sorted by name, without . and ..
+// It fails on names with a path separator, which could escape the local destination
+func (c *sftpClient) readDir(path string) ([]*sftpEntry, error) {
+	typ, resp, err := c.request(sftpPacketOpendir, func(p *sftpPacket) { p.string(path) })
+	if err != nil {
+		return nil, err
+	}
+	handle, err := resp.handle(typ, "opendir", path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*sftpEntry
+	for {
+		typ, resp, err = c.request(sftpPacketReaddir, func(p *sftpPacket) { p.string(handle) })
+		if err != nil {
+			return nil, err
+		}
+		if typ == sftpPacketStatus {
+			if err = resp.statusError("readdir", path); err == io.EOF {
+				break
+			}
+			c.close(handle)
+			if err == nil {
+				err = fmt.Errorf(sftpUnexpectedType, typ, "readdir")
+			}
+			return nil, err
+		}
+		if typ != sftpPacketName {
+			c.close(handle)
+			return nil, fmt.Errorf(sftpUnexpectedType, typ, "readdir")
+		}
+		for count := resp.uint32(); count > 0 && resp.err == nil; count-- {
+			name := resp.string()
+			resp.string() // long name, as displayed by ls -l
+			attrs := resp.attrs()
+			if strings.ContainsAny(name, `/\`) {
+				c.close(handle)
+				return nil, fmt.Errorf("readdir %s: invalid file name %q", path, name)
+			}
+			if name != "." && name != ".." {
+				entries = append(entries, &sftpEntry{name: name, attrs: attrs})
+			}
+		}
+		if resp.err != nil {
+			c.close(handle)
+			return nil, resp.err
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, c.close(handle)
+}
+
+func (c *sftpClient) request(typ byte, build func(*sftpPacket)) (byte, *sftpReader, error) {
+	c.nextID++
+	id := c.nextID
+	p := new(sftpPacket)
+	p.uint32(id)
+	build(p)
+	if err := c.send(typ, p); err != nil {
+		return 0, nil, err
+	}
+	respTyp, resp, err := c.recv()
+	if err != nil {
+		return 0, nil, err
+	}
+	if respID := resp.uint32(); respID != id || resp.err != nil {
+		return 0, nil, fmt.Errorf("unexpected sftp response id %d to request %d", respID, id)
+	}
+	return respTyp, resp, nil
+}
+
+func (c *sftpClient) send(typ byte, p *sftpPacket) error {
+	return writeSFTPPacket(c.w, typ, p)
+}
+
+func (c *sftpClient) recv() (byte, *sftpReader, error) {
+	return readSFTPPacket(c.r)
+}
+
+func writeSFTPPacket(w io.Writer, typ byte, p *sftpPacket) error {
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header, uint32(p.Len()+1))
+	header[4] = typ
+	_, err := w.Write(append(header, p.Bytes()...))
+	return err
+}
+
+func readSFTPPacket(r io.Reader) (byte, *sftpReader, error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+	length := binary.BigEndian.Uint32(header)
+	if length < 1 || length > sftpMaxPacketSize {
+		return 0, nil, fmt.Errorf("invalid sftp packet length %d", length)
+	}
+	payload := make([]byte, length-1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	return header[4], &sftpReader{b: payload}, nil
+}
+
+type sftpPacket struct {
+	bytes.Buffer
+}
+
+func (p *sftpPacket) uint32(v uint32) {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, v)
+	p.Write(b)
+}
+
+func (p *sftpPacket) uint64(v uint64) {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	p.Write(b)
+}
+
+func (p *sftpPacket) string(s string) {
+	p.uint32(uint32(len(s)))
+	p.WriteString(s)
+}
+
+func (p *sftpPacket) perms(perm os.FileMode) {
+	p.uint32(sftpAttrPerms)
+	p.uint32(uint32(perm.Perm()))
+}
+
+// sftpReader decodes a packet, keeping the first error as bufio.Scanner does
+type sftpReader struct {
+	b   []byte
+	err error
+}
+
+var errShortSFTPPacket = errors.New("short sftp packet")
+
+func (r *sftpReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if len(r.b) < n {
+		r.err = errShortSFTPPacket
+		return nil
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *sftpReader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *sftpReader) uint64() uint64 {
+	if b := r.next(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *sftpReader) string() string {
+	return string(r.next(int(r.uint32())))
+}
+
+func (r *sftpReader) attrs() *sftpAttrs {
+	attrs := &sftpAttrs{}
+	flags := r.uint32()
+	if flags&sftpAttrSize != 0 {
+		attrs.size = r.uint64()
+	}
+	if flags&sftpAttrUIDGID != 0 {
+		r.uint32()
+		r.uint32()
+	}
+	if flags&sftpAttrPerms != 0 {
+		attrs.mode = r.uint32()
+	}
+	if flags&sftpAttrACModTime != 0 {
+		r.uint32()
+		r.uint32()
+	}
+	if flags&sftpAttrExtended != 0 {
+		for count := r.uint32(); count > 0 && r.err == nil; count-- {
+			r.string()
+			r.string()
+		}
+	}
+	return attrs
+}
+
+func (r *sftpReader) handle(typ byte, op, path string) (string, error) {
+	switch typ {
+	case sftpPacketHandle:
+		return r.string(), r.err
+	case sftpPacketStatus:
+		if err := r.statusError(op, path); err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf(sftpUnexpectedType, typ, op)
+}
+
+// statusError returns the error of a status packet: nil when OK, io.EOF at the end
+// of a file or directory and errors satisfying os.IsNotExist and os.IsPermission
+func (r *sftpReader) statusError(op, path string) error {
+	code := r.uint32()
+	msg := r.string()
+	if r.err != nil {
+		return r.err
+	}
+	switch code {
+	case sftpStatusOK:
+		return nil
+	case sftpStatusEOF:
+		return io.EOF
+	case sftpStatusNoFile:
+		return &os.PathError{Op: op, Path: path, Err: os.ErrNotExist}
+	case sftpStatusDenied:
+		return &os.PathError{Op: op, Path: path, Err: os.ErrPermission}
+	}
+	return &os.PathError{Op: op, Path: path, Err: fmt.Errorf("%s (sftp status %d)", msg, code)}
+}
@@ -2,10 +2,12 @@ package ssh
 
 import (
 	"bytes"
+	"crypto/rand"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"os"
@@ -13,13 +15,16 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"text/template"
 	"time"
 
 	"github.com/wallix/awless/logger"
 
+	"golang.org/x/crypto/ed25519"
 	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/crypto/ssh/knownhosts"
 )
 
@@ -32,6 +37,12 @@ type Client struct {
 	HostKeyCallback         gossh.HostKeyCallback
 	StrictHostKeyChecking   bool
 	InteractiveTerminalFunc func(*gossh.Client) error
+	AuthorizeUser           func(host, user string) error
+	ResolveUser             func(host string) (string, error)
+	EphemeralKey            string
+	LocalForwards           []*Forward
+	RemoteForwards          []*Forward
+	SocksProxy              string
 	logger                  *logger.Logger
 }
 
@@ -66,6 +77,47 @@ func InitClient(keyname string, keyFolders ...string) (*Client, error) {
 	}, nil
 }
 
+// InitEphemeralClient returns a client authenticating with a new ED25519 key, then with the keys
+// of the SSH agent if any. Its public key, in EphemeralKey, has to be authorized on the host before
+// dialing (ex: pushed with EC2 Instance Connect) with AuthorizeUser, for the single user given or
+// else resolved with ResolveUser.
+func InitEphemeralClient() (*Client, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := gossh.NewSignerFromKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	signers := func() ([]gossh.Signer, error) { return []gossh.Signer{signer}, nil }
+	if sock, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK")); err == nil {
+		agentSigners := agent.NewClient(sock).Signers
+		signers = func() ([]gossh.Signer, error) {
+			fromAgent, _ := agentSigners()
+			return append([]gossh.Signer{signer}, fromAgent...), nil
+		}
+	}
+
+	return &Client{
+		Config: &gossh.ClientConfig{
+			Auth:            []gossh.AuthMethod{gossh.PublicKeysCallback(signers)},
+			Timeout:         2 * time.Second,
+			HostKeyCallback: checkHostKey,
+		},
+		logger:                  logger.DiscardLogger,
+		InteractiveTerminalFunc: func(*gossh.Client) error { return nil },
+		StrictHostKeyChecking:   true,
+		EphemeralKey:            strings.TrimSpace(string(gossh.MarshalAuthorizedKey(signer.PublicKey()))),
+	}, nil
+}
+
+// HasPrivateKey returns whether the key is found, as a path or as a name in the folders
+func HasPrivateKey(keyname string, keyFolders ...string) bool {
+	_, ok := findPrivateKeyFromName(keyname, keyFolders...)
+	return ok
+}
+
 func (c *Client) SetLogger(l *logger.Logger) {
 	c.logger = l
 }
@@ -80,6 +132,9 @@ func (c *Client) DialWithUsers(usernames ...string) error {
 
 	hostport := fmt.Sprintf("%s:%d", c.IP, c.Port)
 
+	if usernames, err = c.authorizedUsers(c.IP, usernames); err != nil {
+		return err
+	}
 	for _, user := range usernames {
 		newConfig := *c.Config
 		newConfig.User = user
@@ -102,6 +157,10 @@ func (c *Client) DialWithUsers(usernames ...string) error {
 
 func (c *Client) NewClientWithProxy(destinationHost string, destinationPort int, usernames ...string) (*Client, error) {
 	hostport := fmt.Sprintf("%s:%d", destinationHost, destinationPort)
+	usernames, err := c.authorizedUsers(destinationHost, usernames)
+	if err != nil {
+		return nil, err
+	}
 	for _, user := range usernames {
 		netConn, err := c.Dial("tcp", hostport)
 		if err != nil {
@@ -122,13 +181,14 @@ func (c *Client) NewClientWithProxy(destinationHost string, destinationPort int,
 		c.logger.ExtraVerbosef("proxied successfully with user %s", user)
 
 		return &Client{
-			Client:  gossh.NewClient(conn, chans, reqs),
-			Proxy:   c,
-			IP:      destinationHost,
-			User:    user,
-			Keypath: c.Keypath,
-			Port:    destinationPort,
+			Client:                  gossh.NewClient(conn, chans, reqs),
+			Proxy:                   c,
+			IP:                      destinationHost,
+			User:                    user,
+			Keypath:                 c.Keypath,
+			Port:                    destinationPort,
 			InteractiveTerminalFunc: func(*gossh.Client) error { return nil },
+			EphemeralKey:            c.EphemeralKey,
 			StrictHostKeyChecking:   c.StrictHostKeyChecking,
 			logger:                  logger.DiscardLogger,
 		}, nil
@@ -137,6 +197,30 @@ func (c *Client) NewClientWithProxy(destinationHost string, destinationPort int,
 	return nil, fmt.Errorf("cannot proxy from %s:%d to %s:%d with users %q", c.IP, c.Port, destinationHost, destinationPort, usernames)
 }
 
+// authorizedUsers returns the users to dial the host with. With AuthorizeUser, the key is authorized
+// once, for the single user given or else resolved with ResolveUser, failures being left to the
+// authentication which may still succeed with other keys
+func (c *Client) authorizedUsers(host string, usernames []string) ([]string, error) {
+	if c.AuthorizeUser == nil || len(usernames) == 0 {
+		return usernames, nil
+	}
+	if len(usernames) > 1 {
+		if c.ResolveUser == nil {
+			return nil, fmt.Errorf("cannot authorize key on %s for users %q: expecting a single user", host, usernames)
+		}
+		user, err := c.ResolveUser(host)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve the user to authorize key on %s: %s", host, err)
+		}
+		c.logger.ExtraVerbosef("resolved user %s on %s", user, host)
+		usernames = []string{user}
+	}
+	if err := c.AuthorizeUser(host, usernames[0]); err != nil {
+		c.logger.ExtraVerbosef("cannot authorize key of user %s on %s: %s", usernames[0], host, err)
+	}
+	return usernames, nil
+}
+
 func (c *Client) CloseAll() error {
 	if c != nil {
 		if c.Client != nil {
@@ -151,7 +235,8 @@ func (c *Client) CloseAll() error {
 
 func (c *Client) Connect() (err error) {
 	args, installed := c.localExec()
-	if installed {
+	// an ephemeral key only lives in memory, out of reach of the local SSH client
+	if installed && c.EphemeralKey == "" {
 		c.logger.Infof("Login as '%s' on '%s'; client '%s'", c.User, c.IP, args[0])
 		c.logger.ExtraVerbosef("running locally %s", args)
 		if err := c.CloseAll(); err != nil {
@@ -167,6 +252,22 @@ func (c *Client) Connect() (err error) {
 	return c.InteractiveTerminalFunc(c.Client)
 }
 
+// Run runs the command on the remote host and returns its exit status
+func (c *Client) Run(command string, stdout, stderr io.Writer) (int, error) {
+	session, err := c.NewSession()
+	if err != nil {
+		return 0, err
+	}
+	defer session.Close()
+	session.Stdout, session.Stderr = stdout, stderr
+
+	err = session.Run(command)
+	if exitErr, ok := err.(*gossh.ExitError); ok {
+		return exitErr.ExitStatus(), nil
+	}
+	return 0, err
+}
+
 func (c *Client) SSHConfigString(hostname string) string {
 	var buf bytes.Buffer
 
@@ -188,10 +289,22 @@ func (c *Client) SSHConfigString(hostname string) string {
 		extraOpts["ProxyCommand"] = fmt.Sprintf("ssh %s %s@%s -p %d -W %%h:%%p", keyArg, c.Proxy.User, c.Proxy.IP, c.Proxy.Port)
 	}
 
+	var forwards []string
+	for _, f := range c.LocalForwards {
+		forwards = append(forwards, fmt.Sprintf("LocalForward %s %s", f.bindAddress(), f.hostAddress()))
+	}
+	for _, f := range c.RemoteForwards {
+		forwards = append(forwards, fmt.Sprintf("RemoteForward %s %s", f.bindAddress(), f.hostAddress()))
+	}
+	if c.SocksProxy != "" {
+		forwards = append(forwards, fmt.Sprintf("DynamicForward %s", c.SocksProxy))
+	}
+
 	params := struct {
 		IP, User, Name string
 		Extra          map[string]string
-	}{c.IP, c.User, hostname, extraOpts}
+		Forwards       []string
+	}{c.IP, c.User, hostname, extraOpts, forwards}
 
 	template.Must(template.New("ssh_config").Parse(`
 Host {{ .Name }}
@@ -200,6 +313,9 @@ Host {{ .Name }}
 {{- range $key, $value := .Extra }}
   {{ $key }} {{ $value -}}
 {{ end -}}
+{{- range .Forwards }}
+  {{ . -}}
+{{ end -}}
 `)).Execute(&buf, params)
 
 	return buf.String()
@@ -234,6 +350,18 @@ func (c *Client) localExec() ([]string, bool) {
 		}
 		args = append(args, "-o", fmt.Sprintf("ProxyCommand='ssh %s %s@%s -p %d -W %%h:%%p'", keyArg, c.Proxy.User, c.Proxy.IP, c.Proxy.Port))
 	}
+	for _, f := range c.LocalForwards {
+		args = append(args, "-L", f.String())
+	}
+	for _, f := range c.RemoteForwards {
+		args = append(args, "-R", f.String())
+	}
+	if c.SocksProxy != "" {
+		args = append(args, "-D", c.SocksProxy)
+	}
+	if len(c.LocalForwards) > 0 || len(c.RemoteForwards) > 0 || c.SocksProxy != "" {
+		args = append(args, "-N")
+	}
 
 	return args, exists
 }
@@ -297,7 +425,13 @@ func findPrivateKeyFromName(keyname string, keyFolders ...string) (privateKey, b
 	return priv, false
 }
 
+// hostKeyMu serializes the checks of the hosts dialed concurrently, prompting to trust their key
+var hostKeyMu sync.Mutex
+
 func checkHostKey(hostname string, remote net.Addr, key gossh.PublicKey) error {
+	hostKeyMu.Lock()
+	defer hostKeyMu.Unlock()
+
 	var knownHostsFiles []string
 	var fileToAddKnownKey string
 
@@ -1,14 +1,22 @@
 package ssh
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
+	"golang.org/x/crypto/ed25519"
 	gossh "golang.org/x/crypto/ssh"
 )
 
@@ -207,6 +215,15 @@ func TestCLIAndConfig(t *testing.T) {
 			"/usr/bin/ssh ec2-user@1.2.3.4 -o StrictHostKeychecking=no",
 			"\nHost TestHost\n  Hostname 1.2.3.4\n  User ec2-user\n  StrictHostKeychecking no",
 		},
+		{
+			&Client{Port: 22, IP: "1.2.3.4", User: "ec2-user", StrictHostKeyChecking: true,
+				LocalForwards:  []*Forward{{BindAddr: "localhost", BindPort: 5432, Host: "db.internal", HostPort: 5432}},
+				RemoteForwards: []*Forward{{BindAddr: "0.0.0.0", BindPort: 8080, Host: "localhost", HostPort: 3000}},
+				SocksProxy:     "localhost:1080",
+			},
+			"/usr/bin/ssh ec2-user@1.2.3.4 -L localhost:5432:db.internal:5432 -R 0.0.0.0:8080:localhost:3000 -D localhost:1080 -N",
+			"\nHost TestHost\n  Hostname 1.2.3.4\n  User ec2-user\n  LocalForward localhost:5432 db.internal:5432\n  RemoteForward 0.0.0.0:8080 localhost:3000\n  DynamicForward localhost:1080",
+		},
 	}
 
 	var got string
@@ -223,3 +240,172 @@ func TestCLIAndConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestDecryptWindowsPassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyContent := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err = ioutil.WriteFile(filepath.Join(dir, "windows.pem"), keyContent, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := rsa.EncryptPKCS1v15(rand.Reader, &key.PublicKey, []byte("s3cr3t-p4ssw0rd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	passwordData := "\r\n" + base64.StdEncoding.EncodeToString(encrypted) + "\r\n"
+
+	password, keypath, err := DecryptWindowsPassword(passwordData, "windows", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := password, "s3cr3t-p4ssw0rd"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := keypath, filepath.Join(dir, "windows.pem"); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, _, err = DecryptWindowsPassword(passwordData, "unknown", dir); err == nil {
+		t.Fatal("expected error got none")
+	}
+	if _, _, err = DecryptWindowsPassword("not base64", "windows", dir); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestRDPConfig(t *testing.T) {
+	tcases := []struct {
+		conf *RDPConfig
+		exp  string
+	}{
+		{
+			&RDPConfig{IP: "1.2.3.4", User: "Administrator"},
+			"full address:s:1.2.3.4:3389\nusername:s:Administrator\nprompt for credentials:i:1\nadministrative session:i:1\n",
+		},
+		{
+			&RDPConfig{IP: "1.2.3.4", Port: 8389},
+			"full address:s:1.2.3.4:8389\nprompt for credentials:i:1\nadministrative session:i:1\n",
+		},
+	}
+
+	for i, tcase := range tcases {
+		if got, want := tcase.conf.String(), tcase.exp; got != want {
+			t.Fatalf("case %d: got '%s', want '%s'", i+1, got, want)
+		}
+	}
+}
+
+func TestSSMSession(t *testing.T) {
+	s := &SSMSession{ID: "alice-0123", StreamURL: "wss://stream", Token: "token", Target: "i-1234", Region: "eu-west-1", Profile: "prod", Endpoint: "https://ssm.eu-west-1.amazonaws.com"}
+	args, err := s.pluginArgs("/usr/local/bin/session-manager-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{
+		"/usr/local/bin/session-manager-plugin",
+		`{"SessionId":"alice-0123","StreamUrl":"wss://stream","TokenValue":"token"}`,
+		"eu-west-1", "StartSession", "prod", `{"Target":"i-1234"}`, "https://ssm.eu-west-1.amazonaws.com",
+	}
+	if got, want := args, exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if got, want := SSMConnectString("i-1234", "eu-west-1", "default"), "aws ssm start-session --target i-1234 --region eu-west-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := SSMConnectString("i-1234", "eu-west-1", "prod"), "aws ssm start-session --target i-1234 --region eu-west-1 --profile prod"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestEphemeralClient(t *testing.T) {
+	os.Setenv("SSH_AUTH_SOCK", "")
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostKey, err := gossh.NewSignerFromKey(hostPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authorized := make(map[string]string)
+	serverConf := &gossh.ServerConfig{
+		PublicKeyCallback: func(meta gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+			if strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key))) == authorized[meta.User()] {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unauthorized key for %s", meta.User())
+		},
+	}
+	serverConf.AddHostKey(hostKey)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			if _, chans, reqs, err := gossh.NewServerConn(conn, serverConf); err == nil {
+				go gossh.DiscardRequests(reqs)
+				go func() {
+					for ch := range chans {
+						ch.Reject(gossh.Prohibited, "no channel")
+					}
+				}()
+			}
+		}
+	}()
+
+	client, err := InitEphemeralClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(client.EphemeralKey, "ssh-ed25519 ") {
+		t.Fatalf("got %s, want ssh-ed25519 authorized key", client.EphemeralKey)
+	}
+	addr := listener.Addr().(*net.TCPAddr)
+	client.IP, client.Port = addr.IP.String(), addr.Port
+	client.SetStrictHostKeyChecking(false)
+	var hosts []string
+	client.AuthorizeUser = func(host, user string) error {
+		hosts = append(hosts, host)
+		if user == "root" {
+			return errors.New("root login disabled")
+		}
+		authorized[user] = client.EphemeralKey
+		return nil
+	}
+
+	if err = client.DialWithUsers("root", "ec2-user"); err == nil || !strings.Contains(err.Error(), "expecting a single user") {
+		t.Fatalf("got %v, want single user error", err)
+	}
+	if err = client.DialWithUsers("root"); err == nil {
+		t.Fatal("expected authentication error")
+	}
+	client.ResolveUser = func(host string) (string, error) {
+		return "ec2-user", nil
+	}
+	if err = client.DialWithUsers("root", "ec2-user"); err != nil {
+		t.Fatal(err)
+	}
+	defer client.CloseAll()
+	if got, want := client.User, "ec2-user"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := hosts, []string{"127.0.0.1", "127.0.0.1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+package ssh
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/wallix/awless/logger"
+)
+
+const ssmPluginBin = "session-manager-plugin"
+
+// SSMSession is a Session Manager session started on an instance. Its data channel is
+// opened by the session-manager-plugin of AWS, as done by `aws ssm start-session`,
+// so that no SSH key nor opened port is needed
+type SSMSession struct {
+	ID, StreamURL, Token              string
+	Target, Region, Profile, Endpoint string
+}
+
+// SSMPluginPath returns the path of the session-manager-plugin, required to connect through Session Manager
+func SSMPluginPath() (string, error) {
+	bin, err := exec.LookPath(ssmPluginBin)
+	if err != nil {
+		return "", fmt.Errorf("cannot find %s in PATH, required to connect through Session Manager: install it from https://docs.aws.amazon.com/systems-manager/latest/userguide/session-manager-working-with-install-plugin.html", ssmPluginBin)
+	}
+	return bin, nil
+}
+
+// Connect replaces the current process with the session-manager-plugin opening an interactive shell
+func (s *SSMSession) Connect(l *logger.Logger) error {
+	bin, err := SSMPluginPath()
+	if err != nil {
+		return err
+	}
+	args, err := s.pluginArgs(bin)
+	if err != nil {
+		return err
+	}
+	l.Infof("Starting Session Manager shell on '%s'; client '%s'", s.Target, bin)
+	l.ExtraVerbosef("running locally %s %s", bin, s.ID)
+	return syscall.Exec(bin, args, os.Environ())
+}
+
+// pluginArgs returns the arguments of the session-manager-plugin as given by the AWS CLI:
+// the session, the region, the operation, the profile, the request and the endpoint
+func (s *SSMSession) pluginArgs(bin string) ([]string, error) {
+	session, err := json.Marshal(struct {
+		SessionId, StreamUrl, TokenValue string
+	}{s.ID, s.StreamURL, s.Token})
+	if err != nil {
+		return nil, err
+	}
+	request, err := json.Marshal(struct{ Target string }{s.Target})
+	if err != nil {
+		return nil, err
+	}
+	return []string{bin, string(session), s.Region, "StartSession", s.Profile, string(request), s.Endpoint}, nil
+}
+
+// SSMConnectString returns the CLI one-liner starting a Session Manager shell on the instance
+func SSMConnectString(target, region, profile string) string {
+	args := []string{"aws", "ssm", "start-session", "--target", target}
+	if region != "" {
+		args = append(args, "--region", region)
+	}
+	if profile != "" && profile != "default" {
+		args = append(args, "--profile", profile)
+	}
+	return strings.Join(args, " ")
+}
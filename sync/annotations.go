@@ -0,0 +1,117 @@
+/*
+Copyright 2017 WALLIX
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync/repo"
+)
+
+const annotationsDir = "annotations"
+
+// AnnotationsPath returns the file of the overlay graph holding the annotations of the resources of the profile.
+// It lives outside of the sync repo so that annotations are neither overwritten by syncs nor versioned.
+func AnnotationsPath(profile string) string {
+	return filepath.Join(filepath.Dir(repo.BaseDir()), annotationsDir, fmt.Sprintf("%s%s", profile, fileExt))
+}
+
+// LoadAnnotations returns the overlay graph of the annotations of the profile (empty when none)
+func LoadAnnotations(profile string) (*graph.Graph, error) {
+	path := AnnotationsPath(profile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return graph.NewGraph(), nil
+	}
+	g, err := graph.NewGraphFromFile(path)
+	if err != nil {
+		return g, fmt.Errorf("loading annotations '%s': %s", path, err)
+	}
+	return g, nil
+}
+
+// Annotate sets the given key=value annotations on the resource, replacing the values of existing keys,
+// and removes the annotations with the given keys. It returns the resulting annotations of the resource.
+func Annotate(profile string, res cloud.Resource, annotations []string, removedKeys ...string) ([]string, error) {
+	overlay, err := LoadAnnotations(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]string)
+	var current []string
+	if existing, err := overlay.GetResource(res.Type(), res.Id()); err == nil {
+		current, _ = existing.Properties()[properties.Annotations].([]string)
+	}
+	for _, a := range current {
+		if k, v, err := graph.ParseAnnotation(a); err == nil {
+			byKey[k] = v
+		}
+	}
+	for _, a := range annotations {
+		k, v, err := graph.ParseAnnotation(a)
+		if err != nil {
+			return nil, err
+		}
+		byKey[k] = v
+	}
+	for _, k := range removedKeys {
+		delete(byKey, k)
+	}
+
+	var result []string
+	for k, v := range byKey {
+		result = append(result, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(result)
+
+	if err := overlay.SetAnnotations(graph.InitResource(res.Type(), res.Id()), result); err != nil {
+		return nil, err
+	}
+	return result, saveAnnotations(profile, overlay)
+}
+
+// WithAnnotations adds the annotations of the profile to the resources of the graph
+func WithAnnotations(profile string, g cloud.GraphAPI) (cloud.GraphAPI, error) {
+	gph, ok := g.(*graph.Graph)
+	if !ok {
+		return g, nil
+	}
+	overlay, err := LoadAnnotations(profile)
+	if err != nil {
+		return g, err
+	}
+	return gph, gph.Annotate(overlay)
+}
+
+func saveAnnotations(profile string, g *graph.Graph) error {
+	path := AnnotationsPath(profile)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("opening %s: %s", path, err)
+	}
+	if err := marshalGraph(g, f, false); err != nil {
+		f.Close()
+		return fmt.Errorf("marshal to %s: %s", path, err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,119 @@
+package sync
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/match"
+	"github.com/wallix/awless/graph"
+)
+
+func TestAnnotationsSurviveSyncs(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("__AWLESS_HOME", tmpDir)
+	defer func(s Store) { DefaultStore = s }(DefaultStore)
+	DefaultStore = NewRDFStore()
+
+	syncInstances := func(ids ...string) {
+		g := graph.NewGraph()
+		for _, id := range ids {
+			res := graph.InitResource(cloud.Instance, id)
+			res.SetProperty("Name", "name_"+id)
+			g.AddResource(res)
+		}
+		if _, err := DefaultStore.Save("admin", "eu-west-1", "infra", g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	syncInstances("inst_1", "inst_2")
+
+	annotations, err := Annotate("admin", graph.InitResource(cloud.Instance, "inst_1"), []string{"owner=teamA", "decommission-by=2024-06"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := annotations, []string{"decommission-by=2024-06", "owner=teamA"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, err = Annotate("admin", graph.InitResource(cloud.Instance, "inst_2"), []string{"owner=teamB"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Annotate("admin", graph.InitResource(cloud.Instance, "inst_1"), []string{"owner"}); err == nil {
+		t.Fatal("expected error for annotation without value")
+	}
+
+	syncInstances("inst_1", "inst_2", "inst_3")
+
+	g, err := LoadLocalGraphs("admin", "eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := g.FindOne(cloud.NewQuery(cloud.Instance).Match(match.Annotation("owner", "teamA")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := res.Id(), "inst_1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := res.Properties()["Name"], "name_inst_1"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	matching, err := g.Find(cloud.NewQuery(cloud.Instance).Match(match.Compare("annotation:decommission-by", match.Less, "2024-07")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(matching), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	annotations, err = Annotate("admin", graph.InitResource(cloud.Instance, "inst_1"), []string{"owner=teamC"}, "decommission-by")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := annotations, []string{"owner=teamC"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	syncInstances("inst_1")
+
+	g, err = LoadLocalGraphs("admin", "eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	all, err := g.Find(cloud.NewQuery(cloud.Instance))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(all), 1; got != want {
+		t.Fatalf("got %d instances, want %d", got, want)
+	}
+	if got, want := all[0].Properties()["Annotations"], []string{"owner=teamC"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	annotations, err = Annotate("admin", graph.InitResource(cloud.Instance, "inst_1"), nil, "owner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := annotations; len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+	g, err = LoadLocalGraphs("admin", "eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err = g.FindOne(cloud.NewQuery(cloud.Instance))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := res.Properties()["Annotations"]; ok {
+		t.Fatalf("got %v, want no annotations", got)
+	}
+}
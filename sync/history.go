@@ -0,0 +1,132 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync/repo"
+)
+
+// ErrNoHistory is returned when no graphs were synced in the sync repo,
+// as happens with the boltdb store which does not version graphs
+var ErrNoHistory = errors.New("no synced graphs history (only graphs synced with the rdf store are versioned)")
+
+// RevisionDiff holds the resources changed between two revisions of the sync repo
+type RevisionDiff struct {
+	From, To *repo.Rev
+	*graph.ResourcesDiff
+}
+
+// LoadLocalGraphsAt returns the graphs of the profile in the regions (all when none given)
+// as they were synced at the date, with the revision they were loaded from
+func LoadLocalGraphsAt(r repo.Repo, profile string, regions []string, date time.Time) (*graph.Graph, *repo.Rev, error) {
+	revs, err := r.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(revs) == 0 {
+		return nil, nil, ErrNoHistory
+	}
+	rev, ok := repo.LastRevAt(revs, date)
+	if !ok {
+		return nil, nil, fmt.Errorf("no graphs synced before %s (first sync on %s)", date.Format(time.RFC3339), revs[0].Date.Format(time.RFC3339))
+	}
+	g, err := loadRevGraphs(r, rev, profile, regions)
+	return g, rev, err
+}
+
+// History returns the changes of the resources of the given types (all when none given) between
+// the consecutive revisions synced from one date to the other, skipping the revisions without changes
+// in the profile and regions. The first revision is compared to the graphs synced at the from date.
+func History(r repo.Repo, profile string, regions []string, from, to time.Time, resourceTypes ...string) ([]*RevisionDiff, error) {
+	revs, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(revs) == 0 {
+		return nil, ErrNoHistory
+	}
+
+	previousRev, _ := repo.LastRevAt(revs, from)
+	previous := graph.NewGraph()
+	if previousRev != nil {
+		if previous, err = loadRevGraphs(r, previousRev, profile, regions); err != nil {
+			return nil, err
+		}
+	}
+
+	var diffs []*RevisionDiff
+	for _, rev := range revs {
+		if !rev.Date.After(from) {
+			continue
+		}
+		if rev.Date.After(to) {
+			break
+		}
+		current, err := loadRevGraphs(r, rev, profile, regions)
+		if err != nil {
+			return diffs, err
+		}
+		diff, err := graph.DiffResources(previous, current, resourceTypes...)
+		if err != nil {
+			return diffs, err
+		}
+		if !diff.Empty() {
+			diffs = append(diffs, &RevisionDiff{From: previousRev, To: rev, ResourcesDiff: diff})
+		}
+		previousRev, previous = rev, current
+	}
+	return diffs, nil
+}
+
+func loadRevGraphs(r repo.Repo, rev *repo.Rev, profile string, regions []string) (*graph.Graph, error) {
+	if len(regions) == 0 {
+		regions = []string{"*"}
+	}
+	var patterns []string
+	for _, region := range regions {
+		patterns = append(patterns, path.Join(profile, region, "*"+fileExt))
+	}
+	files, err := r.ReadFiles(rev.Id, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	var readers []io.Reader
+	for _, p := range paths {
+		readers = append(readers, bytes.NewReader(files[p]))
+	}
+
+	g := graph.NewGraph()
+	if err := g.UnmarshalVersionedFromReaders(readers...); err != nil {
+		return g, fmt.Errorf("loading graphs of revision %s: %s", rev.Id, err)
+	}
+	return g, nil
+}
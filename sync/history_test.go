@@ -0,0 +1,143 @@
+package sync
+
+import (
+	"bytes"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync/repo"
+)
+
+type memoryRepo struct {
+	repo.NullRepo
+	revs  []*repo.Rev
+	files map[string]map[string][]byte
+}
+
+func (r *memoryRepo) List() ([]*repo.Rev, error) { return r.revs, nil }
+
+func (r *memoryRepo) ReadFiles(version string, patterns ...string) (map[string][]byte, error) {
+	contents := make(map[string][]byte)
+	for name, content := range r.files[version] {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				contents[name] = content
+			}
+		}
+	}
+	return contents, nil
+}
+
+func (r *memoryRepo) commit(t *testing.T, date time.Time, region string, resources ...*graph.Resource) {
+	g := graph.NewGraph()
+	g.AddResource(resources...)
+	var buff bytes.Buffer
+	if err := marshalGraph(g, &buff, false); err != nil {
+		t.Fatal(err)
+	}
+	id := date.Format("150405")
+	files := make(map[string][]byte)
+	if len(r.revs) > 0 {
+		for name, content := range r.files[r.revs[len(r.revs)-1].Id] {
+			files[name] = content
+		}
+	}
+	files[path.Join("default", region, "infra"+fileExt)] = buff.Bytes()
+	r.files[id] = files
+	r.revs = append(r.revs, &repo.Rev{Id: id, Date: date})
+}
+
+func TestHistory(t *testing.T) {
+	day := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+	instance := func(id, state string) *graph.Resource {
+		r := graph.InitResource("instance", id)
+		r.SetProperty(properties.State, state)
+		return r
+	}
+
+	r := &memoryRepo{files: make(map[string]map[string][]byte)}
+	r.commit(t, at(1), "eu-west-1", instance("inst_1", "running"))
+	r.commit(t, at(2), "eu-west-1", instance("inst_1", "running"), instance("inst_2", "pending"))
+	r.commit(t, at(3), "us-east-1", instance("inst_3", "running"))
+	r.commit(t, at(4), "eu-west-1", instance("inst_2", "running"))
+
+	t.Run("load graphs at date", func(t *testing.T) {
+		if _, _, err := LoadLocalGraphsAt(r, "default", nil, at(0)); err == nil {
+			t.Fatal("expected error before first sync")
+		}
+		g, rev, err := LoadLocalGraphsAt(r, "default", []string{"eu-west-1"}, at(3).Add(30*time.Minute))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := rev.Id, r.revs[2].Id; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		all, err := g.GetAllResources("instance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(all), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		res, err := g.GetResource("instance", "inst_2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := res.Properties()[properties.State], "pending"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("history of region", func(t *testing.T) {
+		diffs, err := History(r, "default", []string{"eu-west-1"}, at(0), at(5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		type change struct {
+			rev                        string
+			created, deleted, modified []string
+		}
+		var got []change
+		for _, d := range diffs {
+			c := change{rev: d.To.Id}
+			c.created = graph.Resources(d.Created).Map(func(r *graph.Resource) string { return r.Id() })
+			c.deleted = graph.Resources(d.Deleted).Map(func(r *graph.Resource) string { return r.Id() })
+			for _, m := range d.Modified {
+				c.modified = append(c.modified, m.Id())
+			}
+			got = append(got, c)
+		}
+		want := []change{
+			{rev: r.revs[0].Id, created: []string{"inst_1"}},
+			{rev: r.revs[1].Id, created: []string{"inst_2"}},
+			{rev: r.revs[3].Id, deleted: []string{"inst_1"}, modified: []string{"inst_2"}},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("history between dates", func(t *testing.T) {
+		diffs, err := History(r, "default", nil, at(2), at(3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(diffs), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := diffs[0].From.Id, r.revs[1].Id; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := len(diffs[0].Created), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := diffs[0].Created[0].Id(), "inst_3"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+}
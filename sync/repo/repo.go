@@ -19,7 +19,9 @@ package repo
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -48,6 +50,9 @@ type Repo interface {
 	Commit(files ...string) error
 	List() ([]*Rev, error)
 	LoadRev(version string) (*Rev, error)
+	// ReadFiles returns the content of the files of the revision matching the patterns
+	// (ex: default/eu-west-1/*.nt), per path relative to the base dir
+	ReadFiles(version string, patterns ...string) (map[string][]byte, error)
 	BaseDir() string
 }
 
@@ -57,6 +62,9 @@ func (NullRepo) Commit(files ...string) error         { return nil }
 func (NullRepo) List() ([]*Rev, error)                { return nil, nil }
 func (NullRepo) LoadRev(version string) (*Rev, error) { return nil, nil }
 func (NullRepo) BaseDir() string                      { return "" }
+func (NullRepo) ReadFiles(version string, patterns ...string) (map[string][]byte, error) {
+	return nil, nil
+}
 
 type gitRepo struct {
 	repo    *git.Repository
@@ -114,6 +122,18 @@ func (r *gitRepo) List() ([]*Rev, error) {
 	return all, nil
 }
 
+// LastRevAt returns the last of the revisions (sorted by date) committed at or before the date
+func LastRevAt(revs []*Rev, date time.Time) (*Rev, bool) {
+	var last *Rev
+	for _, rev := range revs {
+		if rev.Date.After(date) {
+			break
+		}
+		last = rev
+	}
+	return last, last != nil
+}
+
 func reduceToLastRevOfEachDay(revs []*Rev) []*Rev {
 	perDay := make(map[string][]*Rev)
 
@@ -154,6 +174,33 @@ func (r *gitRepo) LoadRev(version string) (*Rev, error) {
 	return rev, nil
 }
 
+func (r *gitRepo) ReadFiles(version string, patterns ...string) (map[string][]byte, error) {
+	commit, err := r.repo.CommitObject(plumbing.NewHash(version))
+	if err != nil {
+		return nil, err
+	}
+	files, err := commit.Files()
+	if err != nil {
+		return nil, err
+	}
+	contents := make(map[string][]byte)
+	err = files.ForEach(func(f *object.File) error {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, f.Name); !ok {
+				continue
+			}
+			content, err := f.Contents()
+			if err != nil {
+				return fmt.Errorf("reading %s at revision %s: %s", f.Name, version, err)
+			}
+			contents[f.Name] = []byte(content)
+			return nil
+		}
+		return nil
+	})
+	return contents, err
+}
+
 func unmarshalIntoGraph(g *graph.Graph, commit *object.Commit, filename string) error {
 	f, err := commit.File(filename)
 	if err != nil && err != object.ErrFileNotFound {
@@ -186,3 +233,112 @@ func (r *gitRepo) Commit(relativePaths ...string) error {
 	_, err = wt.Commit(msg, &git.CommitOptions{Author: committer})
 	return err
 }
+
+// PruneRevisions rewrites the history of the repo at the given dir, dropping the revisions committed
+// before the date (the last revision is always kept). It returns the number of revisions dropped.
+// As go-git cannot garbage collect objects, the retained revisions are replayed in a new repo
+// whose .git dir then replaces the existing one.
+func PruneRevisions(dir string, before time.Time) (int, error) {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		return 0, nil
+	}
+	r, err := newGitRepo(dir)
+	if err != nil {
+		return 0, err
+	}
+	current := r.(*gitRepo)
+	iter, err := current.repo.Log(&git.LogOptions{})
+	if err == plumbing.ErrReferenceNotFound { // nothing committed yet
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	var history []*object.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		history = append(history, c)
+		return nil
+	})
+	iter.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	var kept []*object.Commit // oldest first, HEAD always kept
+	for i := len(history) - 1; i >= 0; i-- {
+		if !history[i].Committer.When.Before(before) || i == 0 {
+			kept = append(kept, history[i])
+		}
+	}
+	pruned := len(history) - len(kept)
+	if pruned == 0 {
+		return 0, nil
+	}
+
+	tmpDir := PruningDir(dir)
+	os.RemoveAll(tmpDir)
+	defer os.RemoveAll(tmpDir)
+
+	rebuilt, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		return 0, err
+	}
+	wt, err := rebuilt.Worktree()
+	if err != nil {
+		return 0, err
+	}
+
+	previous := make(map[string]bool)
+	for _, commit := range kept {
+		files, err := commit.Files()
+		if err != nil {
+			return 0, err
+		}
+		present := make(map[string]bool)
+		err = files.ForEach(func(f *object.File) error {
+			content, err := f.Contents()
+			if err != nil {
+				return fmt.Errorf("reading %s at revision %s: %s", f.Name, commit.Hash, err)
+			}
+			path := filepath.Join(tmpDir, filepath.FromSlash(f.Name))
+			os.MkdirAll(filepath.Dir(path), 0700)
+			if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+				return err
+			}
+			present[f.Name] = true
+			_, err = wt.Add(f.Name)
+			return err
+		})
+		if err != nil {
+			return 0, err
+		}
+		for name := range previous {
+			if !present[name] {
+				if _, err := wt.Remove(name); err != nil {
+					return 0, err
+				}
+			}
+		}
+		previous = present
+
+		author, committer := commit.Author, commit.Committer
+		if _, err := wt.Commit(commit.Message, &git.CommitOptions{Author: &author, Committer: &committer}); err != nil {
+			return 0, err
+		}
+	}
+
+	gitDir, prunedGitDir := filepath.Join(dir, ".git"), filepath.Join(tmpDir, ".git.pruned")
+	if err := os.Rename(gitDir, prunedGitDir); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(filepath.Join(tmpDir, ".git"), gitDir); err != nil {
+		os.Rename(prunedGitDir, gitDir)
+		return 0, err
+	}
+	return pruned, nil
+}
+
+// PruningDir returns the temporary dir where the history of the repo at the given dir is rewritten.
+// It is only left behind by an interrupted pruning.
+func PruningDir(dir string) string {
+	return filepath.Clean(dir) + ".gc"
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package repo
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 	"time"
@@ -50,6 +53,82 @@ func TestReduceToLastRevOfEachDay(t *testing.T) {
 	}
 }
 
+func TestPruneRevisions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	r, err := newGitRepo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pruned, err := PruneRevisions(dir, time.Now()); err != nil || pruned != 0 {
+		t.Fatalf("got %d, %v on empty repo", pruned, err)
+	}
+	os.MkdirAll(filepath.Join(dir, "default", "eu-west-1"), 0700)
+	commit := func(content map[string]string) {
+		var files []string
+		for f, c := range content {
+			if err := ioutil.WriteFile(filepath.Join(dir, f), []byte(c), 0600); err != nil {
+				t.Fatal(err)
+			}
+			files = append(files, f)
+		}
+		if err := r.Commit(files...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit(map[string]string{"default/eu-west-1/infra.nt": "v1", "default/eu-west-1/access.nt": "v1"})
+	commit(map[string]string{"default/eu-west-1/infra.nt": "v2"})
+	commit(map[string]string{"default/eu-west-1/infra.nt": "v3"})
+
+	if pruned, err := PruneRevisions(dir, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	} else if pruned != 0 {
+		t.Fatalf("got %d, want 0", pruned)
+	}
+
+	pruned, err := PruneRevisions(dir, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pruned, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if _, err := os.Stat(PruningDir(dir)); !os.IsNotExist(err) {
+		t.Fatalf("expected pruning dir to be removed, got %v", err)
+	}
+
+	r, err = newGitRepo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	revs, err := r.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(revs), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	files, err := r.ReadFiles(revs[0].Id, "*/*/*.nt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(files["default/eu-west-1/infra.nt"]), "v3"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := string(files["default/eu-west-1/access.nt"]), "v1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	commit(map[string]string{"default/eu-west-1/infra.nt": "v4"})
+	if revs, _ = r.List(); len(revs) != 2 {
+		t.Fatalf("got %d revisions after commit, want 2", len(revs))
+	}
+}
+
 func mustParse(s string) time.Time {
 	layout := "2006-01-02 15:04"
 	t, err := time.Parse(layout, s)
@@ -58,3 +137,29 @@ func mustParse(s string) time.Time {
 	}
 	return t
 }
+
+func TestLastRevAt(t *testing.T) {
+	revs := []*Rev{
+		{Id: "1", Date: mustParse("2017-01-17 10:05")},
+		{Id: "2", Date: mustParse("2017-01-18 15:05")},
+		{Id: "3", Date: mustParse("2017-01-19 09:05")},
+	}
+	tcases := []struct {
+		date  string
+		expId string
+	}{
+		{"2017-01-17 10:00", ""},
+		{"2017-01-17 10:05", "1"},
+		{"2017-01-18 23:00", "2"},
+		{"2017-02-01 00:00", "3"},
+	}
+	for _, tcase := range tcases {
+		rev, ok := LastRevAt(revs, mustParse(tcase.date))
+		if got, want := ok, tcase.expId != ""; got != want {
+			t.Fatalf("%s: got %t, want %t", tcase.date, got, want)
+		}
+		if ok && rev.Id != tcase.expId {
+			t.Fatalf("%s: got %s, want %s", tcase.date, rev.Id, tcase.expId)
+		}
+	}
+}
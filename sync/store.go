@@ -0,0 +1,288 @@
+/*
+Copyright 2017 WALLIX
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync/repo"
+)
+
+const (
+	RDFStore  = "rdf"
+	BoltStore = "boltdb"
+
+	boltStoreFilename = "graphs.db"
+)
+
+var StoreBackends = []string{RDFStore, BoltStore}
+
+// DefaultStore is the store the synced graphs are saved to and loaded from
+var DefaultStore Store = NewRDFStore()
+
+// Store persists the graphs of the services per profile and region
+type Store interface {
+	// Save persists the graph of the service. It returns the path, relative to the
+	// base dir of the sync repo, of the file to commit (empty when not versioned).
+	Save(profile, region, service string, g cloud.GraphAPI) (string, error)
+	// Load returns the merged graphs of the services (all when none given)
+	// in the regions (all when none given) of the profile
+	Load(profile string, regions []string, services ...string) (*graph.Graph, error)
+}
+
+// NewStore returns the store of the given backend (rdf or boltdb)
+func NewStore(backend string) (Store, error) {
+	switch backend {
+	case "", RDFStore:
+		return NewRDFStore(), nil
+	case BoltStore:
+		return NewBoltStore(filepath.Join(filepath.Dir(repo.BaseDir()), boltStoreFilename)), nil
+	default:
+		return nil, fmt.Errorf("unknown graph store '%s', expected one of %s", backend, strings.Join(StoreBackends, ", "))
+	}
+}
+
+type rdfStore struct{}
+
+// NewRDFStore returns a store writing the graphs as N-Triples files
+// under <repo base dir>/<profile>/<region>/<service>.nt, versioned by the sync repo
+func NewRDFStore() Store {
+	return new(rdfStore)
+}
+
+func (s *rdfStore) Save(profile, region, service string, g cloud.GraphAPI) (string, error) {
+	serviceDir := filepath.Join(repo.BaseDir(), profile, region)
+	os.MkdirAll(serviceDir, 0700)
+
+	fullpath := filepath.Join(serviceDir, fmt.Sprintf("%s%s", service, fileExt))
+	f, err := os.OpenFile(fullpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", fmt.Errorf("opening %s: %s", fullpath, err)
+	}
+	if err := marshalGraph(g, f, false); err != nil {
+		f.Close()
+		return "", fmt.Errorf("marshal to %s: %s", fullpath, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("closing file %s: %s", fullpath, err)
+	}
+	return filepath.Rel(repo.BaseDir(), fullpath)
+}
+
+func (s *rdfStore) Load(profile string, regions []string, services ...string) (*graph.Graph, error) {
+	if len(regions) == 0 {
+		regions = []string{"*"}
+	}
+	if len(services) == 0 {
+		services = []string{"*"}
+	}
+
+	var files []string
+	for _, region := range regions {
+		for _, service := range services {
+			matches, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, region, fmt.Sprintf("%s%s", service, fileExt)))
+			files = append(files, matches...)
+		}
+	}
+
+	g := graph.NewGraph()
+
+	var readers []io.Reader
+	for _, f := range files {
+		reader, err := os.Open(f)
+		if err != nil {
+			return g, fmt.Errorf("loading '%s': %s", f, err)
+		}
+		defer reader.Close()
+		readers = append(readers, reader)
+	}
+
+	err := g.UnmarshalVersionedFromReaders(readers...)
+	return g, err
+}
+
+type boltStore struct {
+	path string
+}
+
+// NewBoltStore returns a store keeping the graphs in a BoltDB file, in a bucket per profile
+// holding a bucket per region, where each service graph is saved in the triplestore binary format.
+// Graphs are neither written as text nor versioned, which makes saving and loading large graphs faster.
+func NewBoltStore(path string) Store {
+	return &boltStore{path: path}
+}
+
+func (s *boltStore) Save(profile, region, service string, g cloud.GraphAPI) (string, error) {
+	chunked, isChunked := g.(chunkedMarshaler)
+
+	var buff bytes.Buffer
+	if !isChunked {
+		if err := marshalGraph(g, &buff, true); err != nil {
+			return "", fmt.Errorf("marshal %s graph: %s", service, err)
+		}
+	}
+
+	return "", s.execute(func(db *bolt.DB) error {
+		return db.Update(func(tx *bolt.Tx) error {
+			profileBucket, err := tx.CreateBucketIfNotExists([]byte(profile))
+			if err != nil {
+				return err
+			}
+			regionBucket, err := profileBucket.CreateBucketIfNotExists([]byte(region))
+			if err != nil {
+				return err
+			}
+			if regionBucket.Bucket([]byte(service)) != nil {
+				if err := regionBucket.DeleteBucket([]byte(service)); err != nil {
+					return err
+				}
+			}
+			if !isChunked {
+				return regionBucket.Put([]byte(service), buff.Bytes())
+			}
+			if err := regionBucket.Delete([]byte(service)); err != nil {
+				return err
+			}
+			serviceBucket, err := regionBucket.CreateBucket([]byte(service))
+			if err != nil {
+				return err
+			}
+			var count int
+			if err := chunked.MarshalBinaryChunks(func(chunk []byte) error {
+				count++
+				return serviceBucket.Put([]byte(fmt.Sprintf("%08d", count)), copyBytes(chunk))
+			}); err != nil {
+				return fmt.Errorf("marshal %s graph: %s", service, err)
+			}
+			return nil
+		})
+	})
+}
+
+func (s *boltStore) Load(profile string, regions []string, services ...string) (*graph.Graph, error) {
+	g := graph.NewGraph()
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return g, nil
+	}
+
+	var readers []io.Reader
+	err := s.execute(func(db *bolt.DB) error {
+		return db.View(func(tx *bolt.Tx) error {
+			profileBucket := tx.Bucket([]byte(profile))
+			if profileBucket == nil {
+				return nil
+			}
+			collectService := func(regionBucket *bolt.Bucket, service []byte) error {
+				if v := regionBucket.Get(service); v != nil {
+					readers = append(readers, bytes.NewReader(copyBytes(v)))
+					return nil
+				}
+				if chunks := regionBucket.Bucket(service); chunks != nil {
+					return chunks.ForEach(func(k, v []byte) error {
+						readers = append(readers, bytes.NewReader(copyBytes(v)))
+						return nil
+					})
+				}
+				return nil
+			}
+			collect := func(regionBucket *bolt.Bucket) error {
+				if len(services) == 0 {
+					return regionBucket.ForEach(func(k, v []byte) error {
+						return collectService(regionBucket, k)
+					})
+				}
+				for _, service := range services {
+					if err := collectService(regionBucket, []byte(service)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			if len(regions) == 0 {
+				return profileBucket.ForEach(func(k, v []byte) error {
+					if v != nil {
+						return nil
+					}
+					return collect(profileBucket.Bucket(k))
+				})
+			}
+			for _, region := range regions {
+				if regionBucket := profileBucket.Bucket([]byte(region)); regionBucket != nil {
+					if err := collect(regionBucket); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return g, err
+	}
+
+	err = g.UnmarshalVersionedFromReaders(readers...)
+	return g, err
+}
+
+func (s *boltStore) execute(fn func(*bolt.DB) error) error {
+	os.MkdirAll(filepath.Dir(s.path), 0700)
+	db, err := bolt.Open(s.path, 0600, &bolt.Options{Timeout: 2 * time.Second})
+	if err != nil {
+		return fmt.Errorf("opening graph store at %s: %s (any awless existing process running?)", s.path, err)
+	}
+	defer db.Close()
+
+	return fn(db)
+}
+
+// chunkedMarshaler is implemented by graphs written in self-contained chunks,
+// which stores persist one at a time instead of encoding the whole graph at once
+type chunkedMarshaler interface {
+	MarshalBinaryChunks(func(chunk []byte) error) error
+}
+
+type versionedMarshaler interface {
+	MarshalVersionedTo(io.Writer) error
+	MarshalBinaryTo(io.Writer) error
+}
+
+// marshalGraph writes the graph in the current format version. Graphs not supporting
+// versioning are written unversioned, and thus read back as the first format version.
+func marshalGraph(g cloud.GraphAPI, w io.Writer, binary bool) error {
+	versioned, ok := g.(versionedMarshaler)
+	switch {
+	case !ok:
+		return g.MarshalTo(w)
+	case binary:
+		return versioned.MarshalBinaryTo(w)
+	default:
+		return versioned.MarshalVersionedTo(w)
+	}
+}
+
+// copyBytes copies values read from BoltDB, valid only for the life of the transaction
+func copyBytes(b []byte) []byte {
+	c := make([]byte, len(b))
+	copy(c, b)
+	return c
+}
@@ -0,0 +1,143 @@
+package sync
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wallix/awless/graph"
+)
+
+func TestStoresSaveAndLoad(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	stores := map[string]Store{
+		RDFStore:  NewRDFStore(),
+		BoltStore: NewBoltStore(filepath.Join(tmpDir, "graphs.db")),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			saved := []struct {
+				region, service, typ, id string
+			}{
+				{"eu-west-1", "infra", "instance", "inst_1"},
+				{"eu-west-1", "storage", "bucket", "bucket_1"},
+				{"us-east-1", "infra", "instance", "inst_2"},
+				{"global", "access", "user", "user_1"},
+			}
+			for _, s := range saved {
+				g := graph.NewGraph()
+				g.AddResource(graph.InitResource(s.typ, s.id))
+				if _, err := store.Save("admin", s.region, s.service, g); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			tcases := []struct {
+				regions  []string
+				services []string
+				expIds   []string
+			}{
+				{regions: []string{"eu-west-1"}, services: []string{"infra"}, expIds: []string{"inst_1"}},
+				{regions: []string{"global", "eu-west-1"}, expIds: []string{"bucket_1", "inst_1", "user_1"}},
+				{services: []string{"infra"}, expIds: []string{"inst_1", "inst_2"}},
+				{expIds: []string{"bucket_1", "inst_1", "inst_2", "user_1"}},
+				{regions: []string{"ap-south-1"}},
+			}
+			for i, tcase := range tcases {
+				g, err := store.Load("admin", tcase.regions, tcase.services...)
+				if err != nil {
+					t.Fatalf("%d: %s", i+1, err)
+				}
+				resources, err := g.GetAllResources("instance", "bucket", "user")
+				if err != nil {
+					t.Fatalf("%d: %s", i+1, err)
+				}
+				if got, want := len(resources), len(tcase.expIds); got != want {
+					t.Fatalf("%d: got %d resources, want %d", i+1, got, want)
+				}
+				for _, id := range tcase.expIds {
+					if _, err := g.FindResource(id); err != nil {
+						t.Fatalf("%d: %s", i+1, err)
+					}
+				}
+			}
+
+			g, err := store.Load("unknown", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resources, _ := g.GetAllResources("instance"); len(resources) != 0 {
+				t.Fatalf("got %d resources, want none", len(resources))
+			}
+		})
+	}
+}
+
+func TestStoresSaveAndLoadInBatches(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	defer func(size int) { graph.BatchSize = size }(graph.BatchSize)
+	graph.BatchSize = 7
+
+	stores := map[string]Store{
+		RDFStore:  NewRDFStore(),
+		BoltStore: NewBoltStore(filepath.Join(tmpDir, "graphs.db")),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			for _, count := range []int{100, 10} {
+				g := graph.NewGraph()
+				var resources []*graph.Resource
+				for i := 0; i < count; i++ {
+					resources = append(resources, graph.InitResource("instance", fmt.Sprintf("inst_%d", i)))
+				}
+				if err := g.AddResource(resources...); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := store.Save("admin", "eu-west-1", "infra", g); err != nil {
+					t.Fatal(err)
+				}
+
+				loaded, err := store.Load("admin", []string{"eu-west-1"}, "infra")
+				if err != nil {
+					t.Fatal(err)
+				}
+				instances, err := loaded.GetAllResources("instance")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got, want := len(instances), count; got != want {
+					t.Fatalf("got %d, want %d", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	for _, backend := range []string{"", RDFStore, BoltStore} {
+		if _, err := NewStore(backend); err != nil {
+			t.Fatalf("%s: %s", backend, err)
+		}
+	}
+	if _, err := NewStore("sqlite"); err == nil {
+		t.Fatal("expected error for unknown backend")
+	}
+}
@@ -15,11 +15,7 @@ package sync
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"io"
-	"os"
-	"path/filepath"
 	"strings"
 	gosync "sync"
 	"time"
@@ -27,6 +23,7 @@ import (
 	"runtime"
 
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/fetch"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync/repo"
@@ -39,6 +36,7 @@ var DefaultSyncer Syncer
 type Syncer interface {
 	repo.Repo
 	Sync(...cloud.Service) (map[string]cloud.GraphAPI, error)
+	SyncWithContext(context.Context, ...cloud.Service) (map[string]cloud.GraphAPI, error)
 }
 
 type noopsyncer struct {
@@ -51,8 +49,13 @@ func (s *noopsyncer) Sync(services ...cloud.Service) (map[string]cloud.GraphAPI,
 	return map[string]cloud.GraphAPI{}, nil
 }
 
+func (s *noopsyncer) SyncWithContext(ctx context.Context, services ...cloud.Service) (map[string]cloud.GraphAPI, error) {
+	return map[string]cloud.GraphAPI{}, nil
+}
+
 type syncer struct {
 	repo.Repo
+	store  Store
 	logger *logger.Logger
 }
 
@@ -62,7 +65,7 @@ func NewSyncer(l ...*logger.Logger) Syncer {
 		panic(err)
 	}
 
-	s := &syncer{Repo: repo}
+	s := &syncer{Repo: repo, store: DefaultStore}
 
 	if len(l) > 0 {
 		s.logger = l[0]
@@ -74,6 +77,13 @@ func NewSyncer(l ...*logger.Logger) Syncer {
 }
 
 func (s *syncer) Sync(services ...cloud.Service) (map[string]cloud.GraphAPI, error) {
+	return s.SyncWithContext(context.Background(), services...)
+}
+
+// SyncWithContext fetches the given services passing along the context, so that
+// callers can for instance follow the fetching progress (see fetch.WithProgress).
+// When the context is canceled, the partially fetched graphs are returned but not persisted.
+func (s *syncer) SyncWithContext(ctx context.Context, services ...cloud.Service) (map[string]cloud.GraphAPI, error) {
 	var workers gosync.WaitGroup
 
 	type result struct {
@@ -94,7 +104,7 @@ func (s *syncer) Sync(services ...cloud.Service) (map[string]cloud.GraphAPI, err
 		go func(srv cloud.Service) {
 			defer workers.Done()
 			start := time.Now()
-			g, err := srv.Fetch(context.Background())
+			g, err := srv.Fetch(ctx)
 			resultc <- &result{service: srv, gph: g, start: start, err: err}
 		}(service)
 	}
@@ -115,7 +125,14 @@ Loop:
 				break Loop
 			}
 			if res.err != nil {
-				allErrors = append(allErrors, fmt.Errorf("syncing %s: %s", res.service.Name(), res.err))
+				for _, e := range *fetch.WrapError(res.err) {
+					switch e.(type) {
+					case *fetch.AccessDeniedError, *fetch.ThrottledError, *fetch.NotSupportedInRegionError:
+						allErrors = append(allErrors, e)
+					default:
+						allErrors = append(allErrors, fmt.Errorf("syncing %s: %s", res.service.Name(), e))
+					}
+				}
 			} else {
 				s.logger.ExtraVerbosef("sync: fetched %s service took %s", res.service.Name(), time.Since(res.start))
 			}
@@ -128,42 +145,26 @@ Loop:
 		}
 	}
 
+	if err := ctx.Err(); err != nil {
+		s.logger.Verbose("sync: interrupted, local data left untouched")
+		return graphs, &fetch.CanceledError{Err: err}
+	}
+
 	var filepaths []string
 
 	for name, g := range graphs {
-		serviceRegion := servicesByName[name].Region()
-		serviceProfile := servicesByName[name].Profile()
-		serviceDir := filepath.Join(s.BaseDir(), serviceProfile, serviceRegion)
-		os.MkdirAll(serviceDir, 0700)
-
-		fullpath := filepath.Join(serviceDir, fmt.Sprintf("%s%s", name, fileExt))
-		f, err := os.OpenFile(fullpath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
-		if err != nil {
-			allErrors = append(allErrors, fmt.Errorf("opening %s: %s", fullpath, err))
-			continue
-		}
-		closeFile := func() {
-			if err := f.Close(); err != nil {
-				allErrors = append(allErrors, fmt.Errorf("closing file %s: %s", fullpath, err))
-			}
-		}
-		if err := g.MarshalTo(f); err != nil {
-			allErrors = append(allErrors, fmt.Errorf("marshal to %s: %s", fullpath, err))
-			closeFile()
-			continue
-		}
-		relPath, err := filepath.Rel(s.BaseDir(), fullpath)
+		serv := servicesByName[name]
+		relPath, err := s.store.Save(serv.Profile(), serv.Region(), name, g)
 		if err != nil {
 			allErrors = append(allErrors, err)
-			closeFile()
 			continue
 		}
-
-		filepaths = append(filepaths, relPath)
-		closeFile()
+		if relPath != "" {
+			filepaths = append(filepaths, relPath)
+		}
 	}
 
-	if runtime.GOOS != "windows" { // https://github.com/wallix/awless/issues/119
+	if runtime.GOOS != "windows" && len(filepaths) > 0 { // https://github.com/wallix/awless/issues/119
 		if err := s.Commit(filepaths...); err != nil {
 			allErrors = append(allErrors, fmt.Errorf("committing %s: %s", strings.Join(filepaths, ", "), err))
 		}
@@ -172,17 +173,12 @@ Loop:
 	return graphs, concatErrors(allErrors)
 }
 
+// concatErrors keeps the typed fetch errors so that callers can handle them specifically
 func concatErrors(errs []error) error {
 	if len(errs) == 0 {
 		return nil
 	}
-
-	lines := []string{"syncing errors:"}
-	for _, err := range errs {
-		lines = append(lines, fmt.Sprintf("\t\t%s", err))
-	}
-
-	return errors.New(strings.Join(lines, "\n"))
+	return fetch.WrapError(errs...)
 }
 
 func LoadLocalGraphForService(serviceName, profile, region string) cloud.GraphAPI {
@@ -190,8 +186,7 @@ func LoadLocalGraphForService(serviceName, profile, region string) cloud.GraphAP
 	if serviceName == "access" || serviceName == "dns" || serviceName == "cdn" {
 		regionDir = "global"
 	}
-	path := filepath.Join(repo.BaseDir(), profile, regionDir, fmt.Sprintf("%s%s", serviceName, fileExt))
-	g, err := graph.NewGraphFromFile(path)
+	g, err := DefaultStore.Load(profile, []string{regionDir}, serviceName)
 	if err != nil {
 		return graph.NewGraph()
 	}
@@ -199,43 +194,17 @@ func LoadLocalGraphForService(serviceName, profile, region string) cloud.GraphAP
 }
 
 func LoadLocalGraphs(profile, region string) (cloud.GraphAPI, error) {
-	var files []string
-	globalFiles, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, "global", fmt.Sprintf("*%s", fileExt)))
-	regionFiles, _ := filepath.Glob(filepath.Join(repo.BaseDir(), profile, region, fmt.Sprintf("*%s", fileExt)))
-
-	files = append(files, globalFiles...)
-	files = append(files, regionFiles...)
-
-	g := graph.NewGraph()
-
-	var readers []io.Reader
-	for _, f := range files {
-		reader, err := os.Open(f)
-		if err != nil {
-			return g, fmt.Errorf("loading '%s': %s", f, err)
-		}
-		readers = append(readers, reader)
+	g, err := DefaultStore.Load(profile, []string{"global", region})
+	if err != nil {
+		return g, err
 	}
-
-	err := g.UnmarshalFromReaders(readers...)
-	return g, err
+	return WithAnnotations(profile, g)
 }
 
 func LoadAllLocalGraphs(profile string) (cloud.GraphAPI, error) {
-	path := filepath.Join(repo.BaseDir(), profile, "*", fmt.Sprintf("*%s", fileExt))
-	files, _ := filepath.Glob(path)
-
-	g := graph.NewGraph()
-
-	var readers []io.Reader
-	for _, f := range files {
-		reader, err := os.Open(f)
-		if err != nil {
-			return g, fmt.Errorf("loading '%s': %s", f, err)
-		}
-		readers = append(readers, reader)
+	g, err := DefaultStore.Load(profile, nil)
+	if err != nil {
+		return g, err
 	}
-
-	err := g.UnmarshalFromReaders(readers...)
-	return g, err
+	return WithAnnotations(profile, g)
 }
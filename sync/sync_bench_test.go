@@ -273,6 +273,10 @@ type iamMock struct {
 	iamiface.IAMAPI
 }
 
+func (*iamMock) ListMFADevices(input *iam.ListMFADevicesInput) (*iam.ListMFADevicesOutput, error) {
+	return &iam.ListMFADevicesOutput{}, nil
+}
+
 func (*iamMock) ListUsersPages(input *iam.ListUsersInput, fn func(p *iam.ListUsersOutput, lastPage bool) (shouldContinue bool)) error {
 	users := []*iam.User{
 		{UserId: awssdk.String("usr_1"), PasswordLastUsed: awssdk.Time(time.Unix(1486139077, 0).UTC())},
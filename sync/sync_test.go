@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/fetch"
 
 	"io/ioutil"
 
@@ -65,6 +66,32 @@ func TestSyncTripleFiles(t *testing.T) {
 	}
 }
 
+func TestSyncCanceledDoesNotPersist(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "awlessunittest_")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	srv := &mockService{g: graph.NewGraph(), name: "testservice", region: "paris", profile: "admin"}
+
+	os.Setenv("__AWLESS_HOME", tmpDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	graphs, err := NewSyncer().SyncWithContext(ctx, srv)
+	if !fetch.IsCanceled(err) {
+		t.Fatalf("expected canceled error, got %v", err)
+	}
+	if _, ok := graphs[srv.Name()]; !ok {
+		t.Fatal("expected partial graph to be returned")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "aws", "rdf", srv.Profile(), srv.Region(), srv.Name()+fileExt)); !os.IsNotExist(err) {
+		t.Fatalf("expected no file written, got %v", err)
+	}
+}
+
 type mockService struct {
 	name, region, profile string
 	g                     *graph.Graph
@@ -0,0 +1,66 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AdoptedResource is a pre-existing resource attached to a template execution (see `awless adopt`)
+// so that reverting the execution also deletes it. Key is the param identifying the resource
+// when deleting it (ex: id for instances, name for users)
+type AdoptedResource struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (a *AdoptedResource) String() string {
+	return fmt.Sprintf("%s %s", a.Type, a.ID)
+}
+
+// Adopt attaches a resource to the execution, returning false if it was already adopted
+func (t *TemplateExecution) Adopt(res *AdoptedResource) bool {
+	for _, a := range t.Adopted {
+		if a.Type == res.Type && a.ID == res.ID {
+			return false
+		}
+	}
+	t.Adopted = append(t.Adopted, res)
+	return true
+}
+
+// IsRevertible returns true if the template is revertible or resources were adopted
+func (t *TemplateExecution) IsRevertible() bool {
+	return len(t.Adopted) > 0 || IsRevertible(t.Template)
+}
+
+// Revert returns the template reverting the execution. The adopted resources are deleted first,
+// in reverse order of adoption, as they may depend on the resources created by the template.
+func (t *TemplateExecution) Revert() (*Template, error) {
+	var lines []string
+	for i := len(t.Adopted) - 1; i >= 0; i-- {
+		a := t.Adopted[i]
+		lines = append(lines, fmt.Sprintf("delete %s %s=%s", a.Type, a.Key, quoteParamIfNeeded(a.Value)))
+		if a.Type == "instance" {
+			lines = append(lines, fmt.Sprintf("check instance id=%s state=terminated timeout=180", quoteParamIfNeeded(a.Value)))
+		}
+	}
+
+	if IsRevertible(t.Template) {
+		reverted, err := t.Template.Revert()
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, reverted.String())
+	} else if len(lines) > 0 && t.Adopted[0].Type == "instance" {
+		lines = lines[:len(lines)-1] // no need to wait for the last instance termination
+	}
+
+	text := strings.Join(lines, "\n")
+	tpl, err := Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("revert: \n%s\n%s", text, err)
+	}
+	return tpl, nil
+}
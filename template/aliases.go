@@ -0,0 +1,61 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResolvedAlias records the resource ID an alias (ex: @prod-db) resolved to for a param when a template ran
+type ResolvedAlias struct {
+	Alias     string `json:"alias"`
+	ParamPath string `json:"param"`
+	ID        string `json:"id"`
+}
+
+// AliasConflict is a recorded alias which now resolves to another resource ID, or no longer resolves
+type AliasConflict struct {
+	*ResolvedAlias
+	CurrentID string
+}
+
+func (c *AliasConflict) String() string {
+	if c.CurrentID == "" {
+		return fmt.Sprintf("alias '@%s' resolved to '%s' for %s during the run but no longer resolves", c.Alias, c.ID, c.ParamPath)
+	}
+	return fmt.Sprintf("alias '@%s' resolved to '%s' for %s during the run but now resolves to '%s'", c.Alias, c.ID, c.ParamPath, c.CurrentID)
+}
+
+// AliasConflicts resolves again the aliases recorded with the run and returns the ones resolving differently
+func (t *TemplateExecution) AliasConflicts(aliasFunc func(paramPath, alias string) string) (conflicts []*AliasConflict) {
+	if aliasFunc == nil {
+		return
+	}
+	for _, a := range t.Aliases {
+		if current := aliasFunc(a.ParamPath, a.Alias); current != a.ID {
+			conflicts = append(conflicts, &AliasConflict{ResolvedAlias: a, CurrentID: current})
+		}
+	}
+	return
+}
+
+func aliasResolutionKey(paramPath, alias string) string {
+	return paramPath + ":" + alias
+}
+
+func resolvedAliases(resolutions map[string]interface{}) (aliases []*ResolvedAlias) {
+	for k, v := range resolutions {
+		splits := strings.SplitN(k, ":", 2)
+		if len(splits) != 2 {
+			continue
+		}
+		aliases = append(aliases, &ResolvedAlias{ParamPath: splits[0], Alias: splits[1], ID: fmt.Sprint(v)})
+	}
+	sort.Slice(aliases, func(i, j int) bool {
+		if aliases[i].Alias != aliases[j].Alias {
+			return aliases[i].Alias < aliases[j].Alias
+		}
+		return aliases[i].ParamPath < aliases[j].ParamPath
+	})
+	return
+}
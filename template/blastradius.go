@@ -0,0 +1,132 @@
+package template
+
+import (
+	"sort"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+)
+
+// BlastRadiusCriticality weights the resource types counted in a blast radius score.
+// Types not listed weigh 1.
+var BlastRadiusCriticality = map[string]int{
+	cloud.Database:         5,
+	cloud.Table:            5,
+	cloud.Bucket:           4,
+	cloud.Instance:         3,
+	cloud.LoadBalancer:     3,
+	cloud.ScalingGroup:     3,
+	cloud.ContainerCluster: 3,
+	cloud.ContainerService: 3,
+	cloud.Zone:             3,
+	cloud.Distribution:     2,
+	cloud.Function:         2,
+	cloud.Queue:            2,
+	cloud.Stack:            2,
+	cloud.Topic:            2,
+}
+
+// BlastRadius is the impact of a delete or update statement: the resources of the graph
+// depending, directly or not, on the resources the statement targets
+type BlastRadius struct {
+	Statement  string
+	Action     string
+	Entity     string
+	Targets    []cloud.Resource
+	Dependents []cloud.Resource
+	Score      int
+}
+
+// ComputeBlastRadius returns the blast radius of each delete and update statement of the template, in order.
+// The dependents of a resource are its children and the resources it applies on, recursively,
+// and the score sums their criticality (see BlastRadiusCriticality).
+func ComputeBlastRadius(t *Template, g cloud.GraphAPI) ([]*BlastRadius, error) {
+	var all []*BlastRadius
+	for _, cmd := range t.CommandNodesIterator() {
+		if cmd.Action != "delete" && cmd.Action != "update" {
+			continue
+		}
+		radius := &BlastRadius{Statement: cmd.String(), Action: cmd.Action, Entity: cmd.Entity}
+		for _, id := range targetedIDs(identifyingParams(cmd.ToDriverParams())) {
+			resources, err := g.FindWithProperties(map[string]interface{}{properties.ID: id})
+			if err != nil {
+				return all, err
+			}
+			radius.Targets = append(radius.Targets, resources...)
+		}
+		dependents, err := collectDependents(g, radius.Targets)
+		if err != nil {
+			return all, err
+		}
+		radius.Dependents = dependents
+		for _, r := range dependents {
+			radius.Score += criticality(r)
+		}
+		all = append(all, radius)
+	}
+	return all, nil
+}
+
+// RiskiestBlastRadius returns the blast radius with the highest score, or nil when all scores are null
+func RiskiestBlastRadius(all []*BlastRadius) *BlastRadius {
+	var riskiest *BlastRadius
+	for _, r := range all {
+		if r.Score > 0 && (riskiest == nil || r.Score > riskiest.Score) {
+			riskiest = r
+		}
+	}
+	return riskiest
+}
+
+// identifyingParams returns the params naming the resources deleted or updated by a statement,
+// leaving out the other resources it refers to (ex: the resource of a tag, the subnets of a load balancer)
+func identifyingParams(params map[string]interface{}) map[string]interface{} {
+	identifying := make(map[string]interface{})
+	for _, k := range []string{"id", "ids", "name"} {
+		if v, ok := params[k]; ok {
+			identifying[k] = v
+		}
+	}
+	return identifying
+}
+
+func collectDependents(g cloud.GraphAPI, targets []cloud.Resource) ([]cloud.Resource, error) {
+	visited := make(map[string]bool)
+	for _, t := range targets {
+		visited[t.Id()] = true
+	}
+	var dependents []cloud.Resource
+	queue := append([]cloud.Resource{}, targets...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, rel := range []string{cloud.ParentOfRel, cloud.AppliesOnRel} {
+			related, err := g.Related(current, rel)
+			if err != nil {
+				return dependents, err
+			}
+			for _, r := range related {
+				if visited[r.Id()] {
+					continue
+				}
+				visited[r.Id()] = true
+				dependents = append(dependents, r)
+				queue = append(queue, r)
+			}
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		if dependents[i].Type() != dependents[j].Type() {
+			return dependents[i].Type() < dependents[j].Type()
+		}
+		return dependents[i].Id() < dependents[j].Id()
+	})
+	return dependents, nil
+}
+
+func criticality(r cloud.Resource) int {
+	if w, ok := BlastRadiusCriticality[r.Type()]; ok {
+		return w
+	}
+	return 1
+}
@@ -0,0 +1,66 @@
+package template_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/template"
+)
+
+func TestComputeBlastRadius(t *testing.T) {
+	g := graph.NewGraph()
+	vpc := resourcetest.VPC("vpc_1").Build()
+	sub1 := resourcetest.Subnet("sub_1").Build()
+	sub2 := resourcetest.Subnet("sub_2").Build()
+	inst1 := resourcetest.Instance("inst_1").Build()
+	inst2 := resourcetest.Instance("inst_2").Build()
+	db := resourcetest.Database("db_1").Build()
+	sg := resourcetest.SecurityGroup("sg_1").Build()
+	key := resourcetest.KeyPair("my_key").Build()
+	g.AddResource(vpc, sub1, sub2, inst1, inst2, db, sg, key)
+	g.AddParentRelation(vpc, sub1)
+	g.AddParentRelation(vpc, sub2)
+	g.AddParentRelation(vpc, sg)
+	g.AddParentRelation(sub1, inst1)
+	g.AddParentRelation(sub2, db)
+	g.AddAppliesOnRelation(sg, inst1)
+	g.AddAppliesOnRelation(sg, inst2)
+	g.AddAppliesOnRelation(key, inst2)
+
+	tpl := template.MustParse("create instance subnet=sub_1\ndelete keypair name=my_key\nupdate securitygroup id=sg_1 inbound=revoke cidr=0.0.0.0/0 protocol=tcp portrange=22\ndelete vpc id=vpc_1\ndelete subnet id=sub_unknown\ndelete tag key=Env resource=vpc_1 value=prod")
+
+	all, err := template.ComputeBlastRadius(tpl, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		action, entity string
+		dependents     []string
+		score          int
+	}
+	var got []result
+	for _, r := range all {
+		got = append(got, result{r.Action, r.Entity, cloud.Resources(r.Dependents).Map(func(r cloud.Resource) string { return r.Id() }), r.Score})
+	}
+	exp := []result{
+		{"delete", "keypair", []string{"inst_2"}, 3},
+		{"update", "securitygroup", []string{"inst_1", "inst_2"}, 6},
+		{"delete", "vpc", []string{"db_1", "inst_1", "inst_2", "sg_1", "sub_1", "sub_2"}, 14},
+		{"delete", "subnet", nil, 0},
+		{"delete", "tag", nil, 0},
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Fatalf("got %v, want %v", got, exp)
+	}
+
+	if got, want := template.RiskiestBlastRadius(all).Entity, "vpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got := template.RiskiestBlastRadius(all[3:]); got != nil {
+		t.Fatalf("got %v, want nil", got)
+	}
+}
@@ -0,0 +1,80 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// Builtin holes are filled at compile time, unless given, with the clock or the identity
+// of the run (ex: create bucket name=backups-{aws.account}-{date.today}):
+//
+//	{date.today}   2017-08-03
+//	{date.now}     20170803-103000 (UTC)
+//	{date.unix}    1501756200
+//	{aws.account}  account ID of the caller (see BuiltinFunc of the compile env)
+//	{aws.user}     user or role session name of the caller
+//	{aws.region}   region of the run
+//	{aws.profile}  profile of the run
+var clockBuiltins = map[string]func(time.Time) string{
+	"date.today": func(t time.Time) string { return t.Format("2006-01-02") },
+	"date.now":   func(t time.Time) string { return t.UTC().Format("20060102-150405") },
+	"date.unix":  func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) },
+}
+
+// IdentityBuiltins are the builtin holes resolved with the BuiltinFunc of the compile env
+var IdentityBuiltins = []string{"aws.account", "aws.user", "aws.region", "aws.profile"}
+
+var builtinsClock = time.Now
+
+// IsBuiltin returns true when the hole is filled by awless when not given
+func IsBuiltin(hole string) bool {
+	_, ok := clockBuiltins[hole]
+	return ok || contains(IdentityBuiltins, hole)
+}
+
+// resolveBuiltinsPass fills the builtin holes absent from the fillers. Identity builtins
+// stay holes (i.e. asked) when the env has no BuiltinFunc.
+func resolveBuiltinsPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
+	fillers := cenv.Get(env.FILLERS)
+	var builtins []string
+	tpl.visitHoles(func(h ast.WithHoles) {
+		for name := range h.GetHoles() {
+			if _, filled := fillers[name]; !filled && IsBuiltin(name) && !contains(builtins, name) {
+				builtins = append(builtins, name)
+			}
+		}
+	})
+	if len(builtins) == 0 {
+		return tpl, cenv, nil
+	}
+	sort.Strings(builtins)
+
+	now := builtinsClock()
+	resolved := make(map[string]interface{})
+	for _, name := range builtins {
+		if fn, ok := clockBuiltins[name]; ok {
+			resolved[name] = fn(now)
+			continue
+		}
+		if cenv.BuiltinFunc() == nil {
+			continue
+		}
+		val, err := cenv.BuiltinFunc()(name)
+		if err != nil {
+			return tpl, cenv, fmt.Errorf("resolving {%s}: %s", name, err)
+		}
+		if strings.TrimSpace(val) == "" {
+			return tpl, cenv, fmt.Errorf("resolving {%s}: empty value", name)
+		}
+		resolved[name] = val
+	}
+	cenv.Log().ExtraVerbosef("resolved builtin holes: %v", resolved)
+	cenv.Push(env.FILLERS, resolved)
+	return tpl, cenv, nil
+}
@@ -17,20 +17,27 @@ var (
 	TestCompileMode = []compileFunc{
 		injectCommandsInNodesPass,
 		failOnDeclarationWithNoResultPass,
+		applyEntityDefaultsPass,
 		processAndValidateParamsPass,
 		checkInvalidReferenceDeclarationsPass,
+		checkParallelBlocksPass,
+		resolveBuiltinsPass,
 		resolveHolesPass,
 		resolveMissingHolesPass,
 		removeOptionalHolesPass,
 		resolveAliasPass,
 		inlineVariableValuePass,
+		extractIdempotencyKeysPass,
 	}
 
 	NewRunnerCompileMode = []compileFunc{
 		injectCommandsInNodesPass,
 		failOnDeclarationWithNoResultPass,
+		applyEntityDefaultsPass,
 		processAndValidateParamsPass,
 		checkInvalidReferenceDeclarationsPass,
+		checkParallelBlocksPass,
+		resolveBuiltinsPass,
 		resolveHolesPass,
 		resolveMissingHolesPass,
 		removeOptionalHolesPass,
@@ -38,6 +45,7 @@ var (
 		inlineVariableValuePass,
 		failOnUnresolvedHolesPass,
 		failOnUnresolvedAliasPass,
+		extractIdempotencyKeysPass,
 		convertParamsPass,
 		validateCommandsPass,
 	}
@@ -120,16 +128,74 @@ func failOnDeclarationWithNoResultPass(tpl *Template, cenv env.Compiling) (*Temp
 	return tpl, cenv, nil
 }
 
+// applyEntityDefaultsPass sets the params absent from commands with the defaults of their entity
+// (ex: instance.keypair). Defaults apply to the required params of any command but only to the optional
+// params of create commands, and are skipped when conflicting with the given params (ex: instance.distro
+// with an image) or when the template is run with another value for it (ex: instance.type=t2.large)
+func applyEntityDefaultsPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
+	defaults := cenv.Get(env.ENTITY_DEFAULTS)
+	if len(defaults) == 0 {
+		return tpl, cenv, nil
+	}
+	fillers := cenv.Get(env.FILLERS)
+
+	applyDefaults := func(node *ast.CommandNode) error {
+		spec := node.ParamsSpec()
+		if spec == nil || spec.Rule() == nil {
+			return nil
+		}
+		rule := spec.Rule()
+		required, optionals, _ := params.List(rule)
+		candidates := append([]string{}, required...)
+		if node.Action == "create" {
+			candidates = append(candidates, optionals...)
+		}
+
+		for _, param := range candidates {
+			if _, ok := node.Params[param]; ok {
+				continue
+			}
+			key := fmt.Sprintf("%s.%s", node.Entity, param)
+			value, ok := defaults[key]
+			if !ok {
+				continue
+			}
+			if filler, ok := fillers[key]; ok && fmt.Sprint(filler) != fmt.Sprint(value) {
+				continue
+			}
+			parsed, err := parseParamsAsCompositeValues(fmt.Sprintf("%s=%v", param, value))
+			if err != nil {
+				if parsed, err = parseParamsAsCompositeValues(fmt.Sprintf("%s=%s", param, quoteString(fmt.Sprint(value)))); err != nil {
+					return cmdErr(node, "default %s: %s", key, err)
+				}
+			}
+			node.Params[param] = parsed[param]
+			keys := commandParamsKeys(node)
+			if err := params.Run(rule, append(keys, rule.Missing(keys)...)); err != nil {
+				delete(node.Params, param)
+				continue
+			}
+			cenv.Push(env.APPLIED_DEFAULTS, map[string]interface{}{key: value})
+		}
+		return nil
+	}
+
+	err := tpl.visitCommandNodesE(applyDefaults)
+	return tpl, cenv, err
+}
+
 func processAndValidateParamsPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
 	normalizeMissingRequiredParamsAsHoleAndValidate := func(node *ast.CommandNode) error {
 		rule := node.ParamsSpec().Rule()
 
-		missingRequired := rule.Missing(node.Keys())
+		keys := commandParamsKeys(node)
+		missingRequired := rule.Missing(keys)
 		for _, e := range missingRequired {
 			normalized := fmt.Sprintf("%s.%s", node.Entity, e)
 			node.Params[e] = ast.NewHoleValue(normalized)
 		}
-		if err := params.Run(rule, node.Keys()); err != nil {
+		keys = commandParamsKeys(node)
+		if err := params.Run(rule, keys); err != nil {
 			return cmdErr(node, err)
 		}
 
@@ -139,9 +205,9 @@ func processAndValidateParamsPass(tpl *Template, cenv env.Compiling) (*Template,
 		case env.REQUIRED_PARAMS_ONLY:
 			return nil
 		case env.REQUIRED_AND_SUGGESTED_PARAMS:
-			suggested = excludeFromSlice(suggested, node.Keys())
+			suggested = excludeFromSlice(suggested, keys)
 		case env.ALL_PARAMS:
-			suggested = excludeFromSlice(optionals, node.Keys())
+			suggested = excludeFromSlice(optionals, keys)
 		}
 
 		for _, e := range suggested {
@@ -154,6 +220,60 @@ func processAndValidateParamsPass(tpl *Template, cenv env.Compiling) (*Template,
 	return tpl, cenv, err
 }
 
+// IdempotencyKeyParam gives a key to a statement (ex: create securitygroup key=web-sg ...).
+// When running a template again, statements whose key already succeeded with the same profile
+// and region are skipped. The param is left to the commands declaring a 'key' param (ex: create tag).
+const IdempotencyKeyParam = "key"
+
+func hasIdempotencyKey(node *ast.CommandNode) bool {
+	if _, ok := node.Params[IdempotencyKeyParam]; !ok {
+		return false
+	}
+	spec := node.ParamsSpec()
+	if spec == nil || spec.Rule() == nil {
+		return true
+	}
+	required, optionals, _ := params.List(spec.Rule())
+	return !contains(required, IdempotencyKeyParam) && !contains(optionals, IdempotencyKeyParam)
+}
+
+// commandParamsKeys returns the keys of the params of the command, excluding its idempotency key
+func commandParamsKeys(node *ast.CommandNode) []string {
+	if !hasIdempotencyKey(node) {
+		return node.Keys()
+	}
+	return excludeFromSlice(node.Keys(), []string{IdempotencyKeyParam})
+}
+
+func extractIdempotencyKeysPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
+	keys := make(map[string]bool)
+	extract := func(node *ast.CommandNode) error {
+		if !hasIdempotencyKey(node) {
+			return nil
+		}
+		val := node.Params[IdempotencyKeyParam]
+		if withRefs, ok := val.(ast.WithRefs); ok && len(withRefs.GetRefs()) > 0 {
+			return cmdErr(node, "idempotency key cannot reference a variable")
+		}
+		if withHoles, ok := val.(ast.WithHoles); ok && len(withHoles.GetHoles()) > 0 {
+			return nil
+		}
+		key := fmt.Sprint(val.Value())
+		if key == "" {
+			return cmdErr(node, "empty idempotency key")
+		}
+		if keys[key] {
+			return cmdErr(node, "idempotency key '%s' used by several statements", key)
+		}
+		keys[key] = true
+		node.IdempotencyKey = key
+		delete(node.Params, IdempotencyKeyParam)
+		return nil
+	}
+	err := tpl.visitCommandNodesE(extract)
+	return tpl, cenv, err
+}
+
 func convertParamsPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
 	convert := func(node *ast.CommandNode) error {
 		refsParams := make(map[string]struct{})
@@ -187,9 +307,26 @@ func convertParamsPass(tpl *Template, cenv env.Compiling) (*Template, env.Compil
 	return tpl, cenv, err
 }
 
+// paramsValidator is implemented by commands validating their params together
+// (ex: params conflicting with each other) on top of the validators of each param
+type paramsValidator interface {
+	ValidateParams(map[string]interface{}) error
+}
+
 func validateCommandsPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
 	collectValidationErrs := func(node *ast.CommandNode) error {
-		if err := params.Validate(node.ParamsSpec().Validators(), node.ToDriverParamsExcludingRefs()); err != nil {
+		values := node.ToDriverParamsExcludingRefs()
+		err := params.Validate(node.ParamsSpec().Validators(), values)
+		if v, ok := node.Command.(paramsValidator); ok {
+			if verr := v.ValidateParams(values); verr != nil {
+				if err == nil {
+					err = fmt.Errorf("param validation:\n\t\t- %s", verr)
+				} else {
+					err = fmt.Errorf("%s\n\t\t- %s", err, verr)
+				}
+			}
+		}
+		if err != nil {
 			return cmdErr(node, err)
 		}
 		return nil
@@ -198,6 +335,42 @@ func validateCommandsPass(tpl *Template, cenv env.Compiling) (*Template, env.Com
 	return tpl, cenv, err
 }
 
+// checkParallelBlocksPass fails when a statement of a parallel block references the result of
+// a command of the same block: statements of a block run concurrently so they must be independent
+func checkParallelBlocksPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
+	resultsBlock := make(map[string]int)
+
+	for _, st := range tpl.Statements {
+		var refs []string
+		switch n := st.Node.(type) {
+		case ast.WithRefs:
+			refs = n.GetRefs()
+		case *ast.DeclarationNode:
+			if withRefs, ok := n.Expr.(ast.WithRefs); ok {
+				refs = withRefs.GetRefs()
+			}
+		}
+		for _, ref := range refs {
+			variable := ref
+			if v, isAttr := resultAttributeRef(ref); isAttr {
+				if _, declared := resultsBlock[ref]; !declared {
+					variable = v
+				}
+			}
+			if block, ok := resultsBlock[variable]; ok && block != 0 && block == st.Block {
+				return tpl, cenv, fmt.Errorf("parallel block: '%s' uses '$%s' resulting from a statement of the same block", st, ref)
+			}
+		}
+		if decl, ok := st.Node.(*ast.DeclarationNode); ok {
+			if _, isCmd := decl.Expr.(*ast.CommandNode); isCmd {
+				resultsBlock[decl.Ident] = st.Block
+			}
+		}
+	}
+
+	return tpl, cenv, nil
+}
+
 func checkInvalidReferenceDeclarationsPass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
 	usedRefs := make(map[string]struct{})
 
@@ -211,9 +384,13 @@ func checkInvalidReferenceDeclarationsPass(tpl *Template, cenv env.Compiling) (*
 
 	var each = func(withRef ast.WithRefs) error {
 		for _, ref := range withRef.GetRefs() {
-			if _, ok := knownRefs[ref]; !ok {
-				return fmt.Errorf("using reference '$%s' but '%s' is undefined in template\n", ref, ref)
+			if _, ok := knownRefs[ref]; ok {
+				continue
 			}
+			if variable, isAttr := resultAttributeRef(ref); isAttr && knownRefs[variable] {
+				continue
+			}
+			return fmt.Errorf("using reference '$%s' but '%s' is undefined in template\n", ref, ref)
 		}
 		return nil
 	}
@@ -238,13 +415,23 @@ func checkInvalidReferenceDeclarationsPass(tpl *Template, cenv env.Compiling) (*
 			if _, ok := knownRefs[ref]; ok {
 				return tpl, cenv, fmt.Errorf("using reference '$%s' but '%s' has already been assigned in template\n", ref, ref)
 			}
-			knownRefs[ref] = true
+			_, isCmd := decl.Expr.(*ast.CommandNode)
+			knownRefs[ref] = isCmd
 		}
 	}
 
 	return tpl, cenv, nil
 }
 
+// resultAttributeRef returns the variable of a reference to an attribute of a command result
+// (ex: 'inst' for $inst.privateip)
+func resultAttributeRef(ref string) (string, bool) {
+	if i := strings.LastIndex(ref, "."); i > 0 && i < len(ref)-1 {
+		return ref[:i], true
+	}
+	return "", false
+}
+
 func inlineVariableValuePass(tpl *Template, cenv env.Compiling) (*Template, env.Compiling, error) {
 	newTpl := &Template{ID: tpl.ID, AST: tpl.AST.Clone()}
 	newTpl.Statements = []*ast.Statement{}
@@ -380,6 +567,7 @@ func resolveAliasPass(tpl *Template, cenv env.Compiling) (*Template, env.Compili
 				return "", false
 			} else {
 				cenv.Log().ExtraVerbosef("alias: resolved '%s' to '%s' for key %s", alias, actual, key)
+				cenv.Push(env.RESOLVED_ALIASES, map[string]interface{}{aliasResolutionKey(normalized, alias): actual})
 				return actual, true
 			}
 		}
@@ -283,3 +283,22 @@ func TestExternallyProvidedParams(t *testing.T) {
 		}
 	}
 }
+
+func TestTemplateWithVariables(t *testing.T) {
+	cenv := template.NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		return awsspec.MockAWSSessionFactory.Build(strings.Join(tokens, ""))()
+	}).Build()
+
+	tpl := template.MustParse("sub = create subnet cidr=10.0.0.0/24 vpc=$vpc\ncreate instance count=1 image=ami-123 name=web subnet=$sub type=t2.micro")
+	vars := map[string]interface{}{"vpc": "vpc-1", "sub": "subnet-1"}
+	compiled, _, err := template.Compile(tpl.WithVariables(vars), cenv, template.NewRunnerCompileMode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := compiled.String(), "sub = create subnet cidr=10.0.0.0/24 vpc=vpc-1\ncreate instance count=1 image=ami-123 name=web subnet=$sub type=t2.micro"; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	if got, want := len(tpl.Statements), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
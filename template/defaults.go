@@ -0,0 +1,25 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AppliedDefault records a param absent from the template and set from the configured
+// defaults of its entity (ex: instance.type for create instance)
+type AppliedDefault struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func (d *AppliedDefault) String() string {
+	return fmt.Sprintf("%s=%s", d.Key, d.Value)
+}
+
+func appliedDefaults(applied map[string]interface{}) (defaults []*AppliedDefault) {
+	for k, v := range applied {
+		defaults = append(defaults, &AppliedDefault{Key: k, Value: fmt.Sprint(v)})
+	}
+	sort.Slice(defaults, func(i, j int) bool { return defaults[i].Key < defaults[j].Key })
+	return
+}
@@ -1,10 +1,14 @@
 package template
 
 import (
+	"fmt"
+	"os"
+	"os/exec"
 	"sync"
 
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/internal/ast"
 )
 
 var (
@@ -16,6 +20,11 @@ type runEnv struct {
 	log    *logger.Logger
 	dryRun bool
 	ctx    map[string]interface{}
+	meta   *env.Metadata
+	hooks  []string
+
+	succeededKeys map[string]*env.Result
+	progress      func(*ProgressEvent)
 }
 
 func NewRunEnv(cenv env.Compiling, context ...map[string]interface{}) env.Running {
@@ -29,6 +38,7 @@ func NewRunEnv(cenv env.Compiling, context ...map[string]interface{}) env.Runnin
 	}
 	renv.ctx["Variables"] = cenv.Get(env.RESOLVED_VARS)
 	renv.ctx["References"] = cenv.Get(env.RESOLVED_VARS) // retro-compatibility with v0.1.2
+	renv.meta = &env.Metadata{Variables: cenv.Get(env.RESOLVED_VARS)}
 
 	return renv
 }
@@ -46,9 +56,58 @@ func (e *runEnv) Context() (out map[string]interface{}) {
 	for k, v := range e.ctx {
 		out[k] = v
 	}
+	out["RunID"] = e.meta.RunID
+	out["Profile"] = e.meta.Profile
+	out["Region"] = e.meta.Region
+	out["StatementIndex"] = e.meta.StatementIndex
+	out["Variables"] = e.meta.Variables
 	return
 }
 
+func (e *runEnv) Metadata() *env.Metadata {
+	return e.meta
+}
+
+// AfterStatement runs the hook scripts with the run metadata as environment variables.
+// A failing hook is only reported since the statement has already been run.
+func (e *runEnv) AfterStatement() {
+	if e.dryRun {
+		return
+	}
+	for _, script := range e.hooks {
+		cmd := exec.Command(script)
+		cmd.Env = append(os.Environ(), e.meta.Environ()...)
+		cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+		if err := cmd.Run(); err != nil {
+			e.log.Warningf("hook '%s' (statement %d): %s", script, e.meta.StatementIndex, err)
+		}
+	}
+}
+
+// statementDone reports the statement run at the given index to the progress func, if any
+func (e *runEnv) statementDone(index int, cmd *ast.CommandNode) {
+	if e.dryRun || e.progress == nil || cmd == nil {
+		return
+	}
+	event := &ProgressEvent{Event: StatementDoneEvent, RunID: e.meta.RunID, Index: &index, Line: cmd.String(), Skipped: cmd.Skipped, Error: errorString(cmd.CmdErr)}
+	if cmd.CmdResult != nil {
+		event.Result = fmt.Sprint(cmd.CmdResult)
+	}
+	e.progress(event)
+}
+
+// succeededResult returns the result of the statement which already succeeded with the idempotency key
+func (e *runEnv) succeededResult(key string) (*env.Result, bool) {
+	if key == "" || e.succeededKeys == nil {
+		return nil, false
+	}
+	res, ok := e.succeededKeys[key]
+	if ok && res == nil {
+		res = &env.Result{}
+	}
+	return res, ok
+}
+
 func (e *runEnv) Log() *logger.Logger {
 	return e.log
 }
@@ -58,6 +117,7 @@ type compileEnv struct {
 	lookupCommandFunc func(...string) interface{}
 	aliasFunc         func(paramPath, alias string) string
 	missingHolesFunc  func(string, []string, bool) string
+	builtinFunc       func(string) (string, error)
 	log               *logger.Logger
 	paramsSuggested   int
 }
@@ -74,6 +134,10 @@ func (e *compileEnv) MissingHolesFunc() func(string, []string, bool) string {
 	return e.missingHolesFunc
 }
 
+func (e *compileEnv) BuiltinFunc() func(string) (string, error) {
+	return e.builtinFunc
+}
+
 func (e *compileEnv) ParamsMode() int {
 	return e.paramsSuggested
 }
@@ -138,6 +202,11 @@ func (b *envBuilder) WithMissingHolesFunc(fn func(string, []string, bool) string
 	return b
 }
 
+func (b *envBuilder) WithBuiltinFunc(fn func(string) (string, error)) *envBuilder {
+	b.E.builtinFunc = fn
+	return b
+}
+
 func (b *envBuilder) WithLookupCommandFunc(fn func(...string) interface{}) *envBuilder {
 	b.E.lookupCommandFunc = fn
 	return b
@@ -1,6 +1,11 @@
 package env
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/wallix/awless/logger"
 )
 
@@ -8,6 +13,9 @@ const (
 	FILLERS = iota
 	PROCESSED_FILLERS
 	RESOLVED_VARS
+	RESOLVED_ALIASES
+	ENTITY_DEFAULTS
+	APPLIED_DEFAULTS
 )
 
 const (
@@ -23,6 +31,8 @@ type log interface {
 type Running interface {
 	log
 	Context() map[string]interface{}
+	Metadata() *Metadata
+	AfterStatement()
 	IsDryRun() bool
 	SetDryRun(b bool)
 }
@@ -32,7 +42,56 @@ type Compiling interface {
 	LookupCommandFunc() func(...string) interface{}
 	AliasFunc() func(paramPath, alias string) string
 	MissingHolesFunc() func(string, []string, bool) string
+	BuiltinFunc() func(string) (string, error)
 	ParamsMode() int
 	Push(int, ...map[string]interface{})
 	Get(int) map[string]interface{}
 }
+
+// Metadata describes the current run of a template. It is given to userdata
+// rendering (ex: {{ .RunID }}, {{ .Variables.myvar }}) and to hook scripts as environment variables.
+type Metadata struct {
+	RunID, Profile, Region string
+	StatementIndex         int
+	Variables              map[string]interface{}
+}
+
+// Environ returns the metadata as environment variables:
+// AWLESS_RUN_ID, AWLESS_RUN_PROFILE, AWLESS_RUN_REGION, AWLESS_RUN_STATEMENT_INDEX
+// and AWLESS_VAR_<NAME> for each resolved variable (uppercased, with '-' and '.' replaced by '_')
+func (m *Metadata) Environ() []string {
+	environ := []string{
+		"AWLESS_RUN_ID=" + m.RunID,
+		"AWLESS_RUN_PROFILE=" + m.Profile,
+		"AWLESS_RUN_REGION=" + m.Region,
+		"AWLESS_RUN_STATEMENT_INDEX=" + strconv.Itoa(m.StatementIndex),
+	}
+	var names []string
+	for k := range m.Variables {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	r := strings.NewReplacer("-", "_", ".", "_")
+	for _, k := range names {
+		v := m.Variables[k]
+		if v == nil {
+			continue
+		}
+		var val string
+		switch vv := v.(type) {
+		case []interface{}:
+			var values []string
+			for _, s := range vv {
+				values = append(values, fmt.Sprint(s))
+			}
+			val = strings.Join(values, ",")
+		case []string:
+			val = strings.Join(vv, ",")
+		default:
+			val = fmt.Sprint(vv)
+		}
+		environ = append(environ, fmt.Sprintf("AWLESS_VAR_%s=%s", strings.ToUpper(r.Replace(k)), val))
+	}
+	return environ
+}
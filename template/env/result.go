@@ -0,0 +1,48 @@
+package env
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Result is the typed result of a command. Its ID is the value of the variable the command
+// is assigned to (ex: $inst) while its ARN and attributes are referenced with
+// $inst.arn or $inst.privateip in the following statements.
+type Result struct {
+	ID         string
+	ARN        string
+	Attributes map[string]string
+}
+
+// Attrs returns the attributes of the result, including its ARN when known
+func (r *Result) Attrs() map[string]string {
+	attrs := make(map[string]string)
+	for k, v := range r.Attributes {
+		if v != "" {
+			attrs[k] = v
+		}
+	}
+	if r.ARN != "" {
+		attrs["arn"] = r.ARN
+	}
+	return attrs
+}
+
+func (r *Result) String() string {
+	return r.ID
+}
+
+// Summary gives the ID followed by the sorted attributes (ex: i-12345 privateip=10.0.0.5)
+func Summary(id interface{}, attrs map[string]string) string {
+	var keys []string
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	all := []string{fmt.Sprint(id)}
+	for _, k := range keys {
+		all = append(all, fmt.Sprintf("%s=%s", k, attrs[k]))
+	}
+	return strings.Join(all, " ")
+}
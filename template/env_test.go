@@ -0,0 +1,375 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+)
+
+type metadataRecorderCommand struct {
+	result   string
+	recorded *[]map[string]interface{}
+}
+
+func (c *metadataRecorderCommand) ParamsSpec() params.Spec { return nil }
+func (c *metadataRecorderCommand) Run(renv env.Running, _ map[string]interface{}) (interface{}, error) {
+	*c.recorded = append(*c.recorded, renv.Context())
+	return c.result, nil
+}
+
+func TestRunMetadata(t *testing.T) {
+	var recorded []map[string]interface{}
+	cenv := NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		return &metadataRecorderCommand{result: strings.Join(tokens, ""), recorded: &recorded}
+	}).Build()
+	cenv.Push(env.RESOLVED_VARS, map[string]interface{}{"cidr": "10.0.0.0/16"})
+
+	tpl, cenv, err := newMultiPass(injectCommandsInNodesPass).compile(MustParse("net = create vpc\ncreate subnet vpc=$net"), cenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "awless-hooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	hook := filepath.Join(dir, "hook.sh")
+	out := filepath.Join(dir, "out")
+	script := "#!/bin/sh\necho \"$AWLESS_RUN_ID $AWLESS_RUN_REGION $AWLESS_RUN_STATEMENT_INDEX $AWLESS_VAR_NET\" >> " + out + "\n"
+	if err = ioutil.WriteFile(hook, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	renv := NewRunEnv(cenv)
+	renv.Metadata().Profile, renv.Metadata().Region = "default", "eu-west-1"
+	renv.(*runEnv).hooks = []string{hook}
+
+	if _, err = tpl.DryRun(renv); err != nil {
+		t.Fatal(err)
+	}
+	recorded = nil
+
+	ran, err := tpl.Run(renv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := len(recorded), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for i, ctx := range recorded {
+		if got, want := ctx["RunID"], ran.ID; got != want {
+			t.Fatalf("%d: got %v, want %v", i, got, want)
+		}
+		if got, want := ctx["Region"], "eu-west-1"; got != want {
+			t.Fatalf("%d: got %v, want %v", i, got, want)
+		}
+		if got, want := ctx["StatementIndex"], i; got != want {
+			t.Fatalf("%d: got %v, want %v", i, got, want)
+		}
+	}
+	if got, want := recorded[0]["Variables"], map[string]interface{}{"cidr": "10.0.0.0/16"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := recorded[1]["Variables"], map[string]interface{}{"cidr": "10.0.0.0/16", "net": "createvpc"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	content, err := ioutil.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ran.ID + " eu-west-1 0 createvpc\n" + ran.ID + " eu-west-1 1 createvpc\n"
+	if got, want := string(content), expected; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+type typedResultCommand struct {
+	received *[]map[string]interface{}
+}
+
+func (c *typedResultCommand) ParamsSpec() params.Spec { return nil }
+func (c *typedResultCommand) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	*c.received = append(*c.received, params)
+	if renv.IsDryRun() {
+		return "dryrun-id", nil
+	}
+	return &env.Result{ID: "i-12345", ARN: "arn:aws:ec2:eu-west-1:123456789012:instance/i-12345", Attributes: map[string]string{"privateip": "10.0.0.5", "publicip": ""}}, nil
+}
+
+func TestRunTypedResults(t *testing.T) {
+	var received []map[string]interface{}
+	cenv := NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		return &typedResultCommand{received: &received}
+	}).Build()
+
+	tpl, cenv, err := newMultiPass(injectCommandsInNodesPass, checkInvalidReferenceDeclarationsPass).
+		compile(MustParse("inst = create instance\ncreate record value=$inst.privateip target=$inst.arn instance=$inst"), cenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ran, err := tpl.Run(NewRunEnv(cenv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]interface{}{"value": "10.0.0.5", "target": "arn:aws:ec2:eu-west-1:123456789012:instance/i-12345", "instance": "i-12345"}
+	if got, want := received[1], exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	cmd := ran.CommandNodesIterator()[0]
+	if got, want := cmd.CmdResult, "i-12345"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := cmd.CmdResultAttributes, map[string]string{"privateip": "10.0.0.5", "arn": "arn:aws:ec2:eu-west-1:123456789012:instance/i-12345"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		received = nil
+		tpl, cenv, err := newMultiPass(injectCommandsInNodesPass).compile(MustParse("inst = create instance\ncreate record value=$inst.privateip instance=$inst"), cenv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tpl.DryRun(NewRunEnv(cenv)); err != nil {
+			t.Fatal(err)
+		}
+		exp := map[string]interface{}{"value": "dryrun-inst-privateip", "instance": "dryrun-id"}
+		if got, want := received[1], exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unknown attribute", func(t *testing.T) {
+		received = nil
+		tpl, cenv, err := newMultiPass(injectCommandsInNodesPass).compile(MustParse("inst = create instance\ncreate record value=$inst.dns"), cenv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ran, _ := tpl.Run(NewRunEnv(cenv))
+		if got, want := len(received), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if err := ran.CommandNodesIterator()[1].CmdErr; err == nil || !strings.Contains(err.Error(), "unresolved reference(s): $inst.dns") {
+			t.Fatalf("got %v, want unresolved reference error", err)
+		}
+	})
+}
+
+func TestResultSummary(t *testing.T) {
+	res := &env.Result{ID: "i-12345", ARN: "arn:i-12345", Attributes: map[string]string{"privateip": "10.0.0.5"}}
+	if got, want := env.Summary(res.ID, res.Attrs()), "i-12345 arn=arn:i-12345 privateip=10.0.0.5"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := env.Summary("i-12345", nil), "i-12345"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMetadataEnviron(t *testing.T) {
+	meta := &env.Metadata{RunID: "01BX", Profile: "default", Region: "us-east-1", StatementIndex: 2,
+		Variables: map[string]interface{}{"my-subnet": "sub-123", "ids": []interface{}{"i-1", "i-2"}, "none": nil},
+	}
+	expected := []string{
+		"AWLESS_RUN_ID=01BX",
+		"AWLESS_RUN_PROFILE=default",
+		"AWLESS_RUN_REGION=us-east-1",
+		"AWLESS_RUN_STATEMENT_INDEX=2",
+		"AWLESS_VAR_IDS=i-1,i-2",
+		"AWLESS_VAR_MY_SUBNET=sub-123",
+	}
+	if got, want := meta.Environ(), expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRunSkipsSucceededKeys(t *testing.T) {
+	var recorded []map[string]interface{}
+	cenv := NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		return &metadataRecorderCommand{result: strings.Join(tokens, ""), recorded: &recorded}
+	}).Build()
+
+	tpl, cenv, err := newMultiPass(injectCommandsInNodesPass, extractIdempotencyKeysPass).compile(MustParse("net = create vpc key=main-vpc\nsub = create subnet vpc=$net cidr=$net.cidr key=main-sub\ncreate instance subnet=$sub"), cenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	renv := NewRunEnv(cenv)
+	renv.(*runEnv).succeededKeys = map[string]*env.Result{
+		"main-vpc": {ID: "vpc-1234", Attributes: map[string]string{"cidr": "10.0.0.0/16"}},
+		"other":    {ID: "sub-1"},
+	}
+
+	if _, err = tpl.DryRun(renv); err != nil {
+		t.Fatal(err)
+	}
+	recorded = nil
+
+	ran, err := tpl.Run(renv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(recorded), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	cmds := ran.CommandNodesIterator()
+	if got, want := cmds[0].Skipped, true; got != want {
+		t.Fatalf("got %t, want %t", got, want)
+	}
+	if got, want := cmds[0].CmdResult, "vpc-1234"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := cmds[0].CmdResultAttributes, map[string]string{"cidr": "10.0.0.0/16"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := cmds[1].ToDriverParams()["vpc"], "vpc-1234"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := cmds[1].ToDriverParams()["cidr"], "10.0.0.0/16"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if err := cmds[1].CmdErr; err != nil {
+		t.Fatal(err)
+	}
+	if cmds[1].Skipped || cmds[2].Skipped {
+		t.Fatal("expected statements without succeeded keys to run")
+	}
+
+	reverted, err := ran.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(reverted.String(), "delete vpc") {
+		t.Fatalf("skipped statement should not be reverted, got %s", reverted)
+	}
+}
+
+type barrierCommand struct {
+	arrived *sync.WaitGroup
+}
+
+func (c *barrierCommand) ParamsSpec() params.Spec { return nil }
+func (c *barrierCommand) Run(renv env.Running, params map[string]interface{}) (interface{}, error) {
+	if renv.IsDryRun() {
+		return params["name"], nil
+	}
+	c.arrived.Done()
+	done := make(chan struct{})
+	go func() { c.arrived.Wait(); close(done) }()
+	select {
+	case <-done:
+		return params["name"], nil
+	case <-time.After(2 * time.Second):
+		return nil, errors.New("statements of the parallel block did not run concurrently")
+	}
+}
+
+func TestRunParallelBlocks(t *testing.T) {
+	arrived := new(sync.WaitGroup)
+	arrived.Add(3)
+	var recorded []map[string]interface{}
+	cenv := NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		if strings.Join(tokens, "") == "createqueue" {
+			return &barrierCommand{arrived: arrived}
+		}
+		return &metadataRecorderCommand{result: strings.Join(tokens, ""), recorded: &recorded}
+	}).Build()
+
+	tpl, cenv, err := newMultiPass(injectCommandsInNodesPass).compile(MustParse("parallel:\n  q1 = create queue name=q1\n  create queue name=q2\n  create queue name=q3\nend\ncreate subscription endpoint=$q1"), cenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	renv := NewRunEnv(cenv)
+	if _, err = tpl.DryRun(renv); err != nil {
+		t.Fatal(err)
+	}
+
+	ran, err := tpl.Run(renv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds := ran.CommandNodesIterator()
+	for _, cmd := range cmds[:3] {
+		if cmd.CmdErr != nil {
+			t.Fatal(cmd.CmdErr)
+		}
+	}
+	if got, want := cmds[3].ToDriverParams()["endpoint"], "q1"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := ran.Statements[1].Block, 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestRunProgressEvents(t *testing.T) {
+	var recorded []map[string]interface{}
+	cenv := NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		return &metadataRecorderCommand{result: strings.Join(tokens, ""), recorded: &recorded}
+	}).Build()
+
+	tpl, cenv, err := newMultiPass(injectCommandsInNodesPass).compile(MustParse("net = create vpc\ncreate subnet vpc=$net"), cenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buff bytes.Buffer
+	renv := NewRunEnv(cenv)
+	renv.(*runEnv).progress = NewProgressWriter(&buff)
+
+	if _, err = tpl.DryRun(renv); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buff.Len(), 0; got != want {
+		t.Fatalf("expected no event on dry run, got %s", buff.String())
+	}
+	ran, err := tpl.Run(renv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var events []*ProgressEvent
+	dec := json.NewDecoder(&buff)
+	for dec.More() {
+		var e *ProgressEvent
+		if err := dec.Decode(&e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, e)
+	}
+	if got, want := len(events), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	for i, e := range events {
+		if got, want := e.Event, StatementDoneEvent; got != want {
+			t.Fatalf("%d: got %s, want %s", i, got, want)
+		}
+		if got, want := e.RunID, ran.ID; got != want {
+			t.Fatalf("%d: got %s, want %s", i, got, want)
+		}
+		if e.Index == nil || *e.Index != i {
+			t.Fatalf("%d: got index %v", i, e.Index)
+		}
+		if e.Time.IsZero() {
+			t.Fatalf("%d: expected event time", i)
+		}
+	}
+	if got, want := events[0].Result, "createvpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := events[1].Line, "create subnet vpc=createvpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
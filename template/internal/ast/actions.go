@@ -20,6 +20,7 @@ const (
 	Detach Action = "detach"
 
 	Copy Action = "copy"
+	Sync Action = "sync"
 
 	Import       Action = "import"
 	Authenticate Action = "authenticate"
@@ -37,6 +38,7 @@ var actions = map[Action]struct{}{
 	Attach:       {},
 	Detach:       {},
 	Copy:         {},
+	Sync:         {},
 	Import:       {},
 	Authenticate: {},
 }
@@ -47,6 +47,9 @@ type AST struct {
 
 type Statement struct {
 	Node
+	// Block is the number of the parallel block of the statement (0 when not in a block).
+	// The statements of a block run concurrently.
+	Block int
 }
 
 type DeclarationNode struct {
@@ -81,8 +84,17 @@ type CommandNode struct {
 	CmdResult interface{}
 	CmdErr    error
 
+	// CmdResultAttributes holds the ARN and attributes of a typed command result
+	// (ex: privateip), referenced in templates with $var.arn or $var.privateip
+	CmdResultAttributes map[string]string
+
 	Action, Entity string
 	Params         map[string]CompositeValue
+
+	// IdempotencyKey identifies the statement across runs. Skipped is set
+	// when the statement was not run since its key already succeeded.
+	IdempotencyKey string
+	Skipped        bool
 }
 
 func (c *CommandNode) Result() interface{} { return c.CmdResult }
@@ -119,7 +131,8 @@ func (c *CommandNode) clone() Node {
 	cmd := &CommandNode{
 		Command: c.Command,
 		Action:  c.Action, Entity: c.Entity,
-		Params: make(map[string]CompositeValue),
+		Params:         make(map[string]CompositeValue),
+		IdempotencyKey: c.IdempotencyKey,
 	}
 
 	for k, v := range c.Params {
@@ -292,7 +305,7 @@ func (n *ValueNode) GetHoles() map[string]*Hole {
 }
 
 func (s *Statement) Clone() *Statement {
-	newStat := &Statement{}
+	newStat := &Statement{Block: s.Block}
 	newStat.Node = s.Node.clone()
 
 	return newStat
@@ -300,8 +313,25 @@ func (s *Statement) Clone() *Statement {
 
 func (a *AST) String() string {
 	var all []string
+	var block int
 	for _, stat := range a.Statements {
-		all = append(all, stat.String())
+		if stat.Block != block {
+			if block != 0 {
+				all = append(all, ParallelBlockEnd)
+			}
+			if stat.Block != 0 {
+				all = append(all, ParallelBlockStart)
+			}
+			block = stat.Block
+		}
+		if block != 0 {
+			all = append(all, "  "+stat.String())
+		} else {
+			all = append(all, stat.String())
+		}
+	}
+	if block != 0 {
+		all = append(all, ParallelBlockEnd)
 	}
 	return strings.Join(all, "\n")
 }
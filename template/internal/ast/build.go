@@ -82,14 +82,18 @@ func (b *statementBuilder) buildList() *statementBuilder {
 }
 
 func (a *AST) addAction(text string) {
-	if IsInvalidAction(text) {
+	if text != parallelMarkerAction && IsInvalidAction(text) {
 		panic(fmt.Errorf("unknown action '%s'", text))
 	}
 	a.stmtBuilder.action = text
 }
 
 func (a *AST) addEntity(text string) {
-	if IsInvalidEntity(text) {
+	if a.stmtBuilder.action == parallelMarkerAction {
+		if text != parallelMarkerStart && text != parallelMarkerEnd {
+			panic(fmt.Errorf("unknown parallel block marker '%s'", text))
+		}
+	} else if IsInvalidEntity(text) {
 		panic(fmt.Errorf("unknown entity '%s'", text))
 	}
 	a.stmtBuilder.entity = text
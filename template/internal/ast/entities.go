@@ -5,57 +5,80 @@ type Entity string
 var entities = map[Entity]struct{}{
 	"none": {},
 
-	"accesskey":           {},
-	"alarm":               {},
-	"appscalingtarget":    {},
-	"appscalingpolicy":    {},
-	"scalinggroup":        {},
-	"bucket":              {},
-	"certificate":         {},
-	"container":           {},
-	"containercluster":    {},
-	"containerservice":    {},
-	"containertask":       {},
-	"database":            {},
-	"distribution":        {},
-	"dbsubnetgroup":       {},
-	"elasticip":           {},
-	"function":            {},
-	"group":               {},
-	"instance":            {},
-	"image":               {},
-	"internetgateway":     {},
-	"mfadevice":           {},
-	"natgateway":          {},
-	"networkinterface":    {},
-	"instanceprofile":     {},
-	"keypair":             {},
-	"launchconfiguration": {},
-	"listener":            {},
-	"loadbalancer":        {},
-	"loginprofile":        {},
-	"policy":              {},
-	"queue":               {},
-	"record":              {},
-	"registry":            {},
-	"repository":          {},
-	"role":                {},
-	"route":               {},
-	"routetable":          {},
-	"s3object":            {},
-	"scalingpolicy":       {},
-	"securitygroup":       {},
-	"snapshot":            {},
-	"stack":               {},
-	"subnet":              {},
-	"subscription":        {},
-	"tag":                 {},
-	"targetgroup":         {},
-	"topic":               {},
-	"user":                {},
-	"volume":              {},
-	"vpc":                 {},
-	"zone":                {},
+	"accesskey":             {},
+	"alarm":                 {},
+	"alias":                 {},
+	"apideployment":         {},
+	"apimethod":             {},
+	"apiresource":           {},
+	"apistage":              {},
+	"appscalingtarget":      {},
+	"appscalingpolicy":      {},
+	"scalinggroup":          {},
+	"bucket":                {},
+	"cachecluster":          {},
+	"certificate":           {},
+	"container":             {},
+	"containercluster":      {},
+	"containerservice":      {},
+	"containertask":         {},
+	"database":              {},
+	"distribution":          {},
+	"dbsubnetgroup":         {},
+	"elasticip":             {},
+	"eventsourcemapping":    {},
+	"function":              {},
+	"functionalias":         {},
+	"functionversion":       {},
+	"group":                 {},
+	"healthcheck":           {},
+	"instance":              {},
+	"image":                 {},
+	"internetgateway":       {},
+	"mfadevice":             {},
+	"natgateway":            {},
+	"networkinterface":      {},
+	"originaccessidentity":  {},
+	"instanceprofile":       {},
+	"integration":           {},
+	"key":                   {},
+	"keygrant":              {},
+	"keypair":               {},
+	"launchconfiguration":   {},
+	"launchtemplate":        {},
+	"listener":              {},
+	"loadbalancer":          {},
+	"loginprofile":          {},
+	"policy":                {},
+	"presignedurl":          {},
+	"queue":                 {},
+	"record":                {},
+	"registry":              {},
+	"replicationgroup":      {},
+	"repository":            {},
+	"restapi":               {},
+	"role":                  {},
+	"route":                 {},
+	"routetable":            {},
+	"s3object":              {},
+	"scalingpolicy":         {},
+	"securitygroup":         {},
+	"snapshot":              {},
+	"spotfleet":             {},
+	"stack":                 {},
+	"statemachine":          {},
+	"subnet":                {},
+	"subscription":          {},
+	"table":                 {},
+	"tag":                   {},
+	"targetgroup":           {},
+	"topic":                 {},
+	"trafficpolicy":         {},
+	"trafficpolicyinstance": {},
+	"user":                  {},
+	"volume":                {},
+	"vpc":                   {},
+	"zone":                  {},
 }
 
 func IsInvalidEntity(s string) bool {
@@ -0,0 +1,44 @@
+package ast
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// HolesDirective declares in a comment on the first line of a template the delimiters of its holes
+// instead of braces (ex: `# awless:holes [[ ]]`), for templates generated by other templating
+// tools using braces (Jinja, Go templates, ...)
+const HolesDirective = "awless:holes"
+
+// ApplyHolesDirective rewrites the holes delimited as declared by the directive of the template
+// into holes between braces. Templates without directive are returned unchanged.
+func ApplyHolesDirective(text string) (string, error) {
+	lines := strings.Split(text, "\n")
+	first := -1
+	for i, l := range lines {
+		if strings.TrimSpace(l) != "" {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return text, nil
+	}
+	comment := strings.TrimSpace(lines[first])
+	if !strings.HasPrefix(comment, "#") && !strings.HasPrefix(comment, "//") {
+		return text, nil
+	}
+	fields := strings.Fields(strings.TrimLeft(comment, "#/"))
+	if len(fields) == 0 || fields[0] != HolesDirective {
+		return text, nil
+	}
+	if len(fields) != 3 {
+		return "", fmt.Errorf("invalid %s directive: expecting opening and closing delimiters (ex: # %s [[ ]])", HolesDirective, HolesDirective)
+	}
+	hole := regexp.MustCompile(regexp.QuoteMeta(fields[1]) + `[ \t]*([a-zA-Z0-9-_.]+)[ \t]*` + regexp.QuoteMeta(fields[2]))
+	for i := first + 1; i < len(lines); i++ {
+		lines[i] = hole.ReplaceAllString(lines[i], "{$1}")
+	}
+	return strings.Join(lines, "\n"), nil
+}
@@ -0,0 +1,70 @@
+package ast
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Keywords delimiting a parallel block in templates
+const (
+	ParallelBlockStart = "parallel:"
+	ParallelBlockEnd   = "end"
+)
+
+// Delimiters of parallel blocks are parsed as marker commands, then folded into the blocks of statements
+const (
+	parallelMarkerAction = "parallelblock"
+	parallelMarkerStart  = "start"
+	parallelMarkerEnd    = "end"
+)
+
+// MarkParallelBlocks replaces the lines delimiting parallel blocks with marker commands to be parsed
+func MarkParallelBlocks(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, l := range lines {
+		switch strings.TrimSpace(l) {
+		case ParallelBlockStart:
+			lines[i] = fmt.Sprintf("%s %s", parallelMarkerAction, parallelMarkerStart)
+		case ParallelBlockEnd:
+			lines[i] = fmt.Sprintf("%s %s", parallelMarkerAction, parallelMarkerEnd)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FoldParallelBlocks removes the marker commands of the parsed statements, numbering the blocks
+// of the statements in between. Blocks cannot be nested nor empty.
+func (a *AST) FoldParallelBlocks() error {
+	var statements []*Statement
+	var block, blocks, size int
+	for _, st := range a.Statements {
+		if cmd, ok := st.Node.(*CommandNode); ok && cmd.Action == parallelMarkerAction {
+			switch cmd.Entity {
+			case parallelMarkerStart:
+				if block != 0 {
+					return errors.New("nested parallel blocks are not supported")
+				}
+				blocks++
+				block, size = blocks, 0
+			case parallelMarkerEnd:
+				if block == 0 {
+					return fmt.Errorf("'%s' without opening '%s'", ParallelBlockEnd, ParallelBlockStart)
+				}
+				if size == 0 {
+					return errors.New("empty parallel block")
+				}
+				block = 0
+			}
+			continue
+		}
+		st.Block = block
+		size++
+		statements = append(statements, st)
+	}
+	if block != 0 {
+		return fmt.Errorf("parallel block not closed with '%s'", ParallelBlockEnd)
+	}
+	a.Statements = statements
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// LibraryEntry summarizes a template of a local or remote source to make it discoverable
+type LibraryEntry struct {
+	Name, Source, Location string
+	Title, Description     string
+	MinimalVersion         string
+	Tags                   []string
+	RequiredHoles          []string
+}
+
+var annotationRegex = regexp.MustCompile(`^\s*#\s*(Title|Description|Tags|MinimalVersion)\s*:\s*(.*)$`)
+
+// NewLibraryEntry indexes the template text from its annotations, i.e. comments such as:
+//
+//	# Title: Create a VPC with a public subnet
+//	# Description: Internet routed subnet (multiple lines are joined)
+//	# Tags: infra, vpc
+//	# MinimalVersion: v0.1.7
+//
+// along with its required holes. A template failing to parse is indexed from its annotations only.
+func NewLibraryEntry(name, source, location string, content []byte) (*LibraryEntry, error) {
+	entry := &LibraryEntry{Name: name, Source: source, Location: location}
+
+	var descriptions []string
+	scn := bufio.NewScanner(bytes.NewReader(content))
+	for scn.Scan() {
+		matches := annotationRegex.FindStringSubmatch(scn.Text())
+		if len(matches) < 3 {
+			continue
+		}
+		value := strings.TrimSpace(matches[2])
+		switch matches[1] {
+		case "Title":
+			entry.Title = value
+		case "Description":
+			descriptions = append(descriptions, value)
+		case "MinimalVersion":
+			entry.MinimalVersion = value
+		case "Tags":
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					entry.Tags = append(entry.Tags, tag)
+				}
+			}
+		}
+	}
+	entry.Description = strings.Join(descriptions, " ")
+
+	tpl, err := Parse(string(content))
+	if err != nil {
+		return entry, err
+	}
+	entry.RequiredHoles = tpl.RequiredHoles()
+	return entry, nil
+}
+
+// Match returns true when all the keywords are found (case insensitive) in the name,
+// title, description, tags or required holes of the template
+func (e *LibraryEntry) Match(keywords ...string) bool {
+	indexed := strings.ToLower(strings.Join(append([]string{e.Name, e.Title, e.Description, strings.Join(e.Tags, " ")}, e.RequiredHoles...), " "))
+	for _, k := range keywords {
+		if !strings.Contains(indexed, strings.ToLower(k)) {
+			return false
+		}
+	}
+	return true
+}
+
+// RequiredHoles returns the sorted names of the holes of the template which are not optional
+func (s *Template) RequiredHoles() (holes []string) {
+	unique := make(map[string]struct{})
+	s.visitHoles(func(h ast.WithHoles) {
+		for name, hole := range h.GetHoles() {
+			if !hole.IsOptional {
+				unique[name] = struct{}{}
+			}
+		}
+	})
+	for name := range unique {
+		holes = append(holes, name)
+	}
+	sort.Strings(holes)
+	return
+}
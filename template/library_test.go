@@ -0,0 +1,73 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewLibraryEntry(t *testing.T) {
+	content := `# Title: Create a VPC with a public subnet
+# Description: Create a VPC and a subnet
+#   Description: routed to the internet
+# Tags: infra, vpc
+# MinimalVersion: v0.1.7
+
+vpc = create vpc cidr={vpc.cidr} name={vpc.name}
+create subnet cidr={subnet.cidr} vpc=$vpc
+`
+	entry, err := NewLibraryEntry("create_vpc", "repo", "https://example.com/create_vpc.aws", []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &LibraryEntry{
+		Name: "create_vpc", Source: "repo", Location: "https://example.com/create_vpc.aws",
+		Title:          "Create a VPC with a public subnet",
+		Description:    "Create a VPC and a subnet routed to the internet",
+		MinimalVersion: "v0.1.7",
+		Tags:           []string{"infra", "vpc"},
+		RequiredHoles:  []string{"subnet.cidr", "vpc.cidr", "vpc.name"},
+	}
+	if got, want := entry, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	tcases := []struct {
+		keywords []string
+		exp      bool
+	}{
+		{[]string{"vpc"}, true},
+		{[]string{"INTERNET", "Infra"}, true},
+		{[]string{"subnet.cidr"}, true},
+		{[]string{"vpc", "instance"}, false},
+		{nil, true},
+	}
+	for i, tcase := range tcases {
+		if got, want := entry.Match(tcase.keywords...), tcase.exp; got != want {
+			t.Fatalf("%d: got %t, want %t", i+1, got, want)
+		}
+	}
+
+	entry, err = NewLibraryEntry("broken", "local", "/tmp/broken.aws", []byte("# Title: Broken\ncreate vpc cidr=="))
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	if got, want := entry.Title, "Broken"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
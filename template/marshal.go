@@ -17,7 +17,15 @@ type TemplateExecution struct {
 	*Template
 	Author, Source, Locale string
 	Profile, Path, Message string
+	Operator               string
 	Fillers                map[string]interface{}
+	Aliases                []*ResolvedAlias
+	Defaults               []*AppliedDefault
+	Adopted                []*AdoptedResource
+	// RevertOf is the ID of the run reverted by this one, and RevertedStatements
+	// the positions of its reverted statements (all when empty)
+	RevertOf           string
+	RevertedStatements []int
 }
 
 // Date extract the date from the ulid template identifier
@@ -52,12 +60,18 @@ func (t *TemplateExecution) MarshalJSON() ([]byte, error) {
 	out := &toJSON{}
 	out.ID = t.ID
 	out.Author = t.Author
+	out.Operator = t.Operator
 	out.Source = t.Source
 	out.Locale = t.Locale
 	out.Profile = t.Profile
 	out.Message = t.Message
 	out.Path = t.Path
 	out.Fillers = t.Fillers
+	out.Aliases = t.Aliases
+	out.Defaults = t.Defaults
+	out.Adopted = t.Adopted
+	out.RevertOf = t.RevertOf
+	out.RevertedStatements = t.RevertedStatements
 	if out.Fillers == nil {
 		out.Fillers = make(map[string]interface{}, 0) // friendlier for json, avoiding "fillers": null,
 	}
@@ -66,6 +80,8 @@ func (t *TemplateExecution) MarshalJSON() ([]byte, error) {
 	for _, cmd := range t.CommandNodesIterator() {
 		newCmd := command{}
 		newCmd.Line = cmd.String()
+		newCmd.Key = cmd.IdempotencyKey
+		newCmd.Skipped = cmd.Skipped
 		if cmd.CmdErr != nil {
 			newCmd.Errors = append(newCmd.Errors, cmd.CmdErr.Error())
 		}
@@ -74,6 +90,7 @@ func (t *TemplateExecution) MarshalJSON() ([]byte, error) {
 				newCmd.Results = append(newCmd.Results, s)
 			}
 		}
+		newCmd.Attributes = cmd.CmdResultAttributes
 		out.Commands = append(out.Commands, newCmd)
 	}
 
@@ -101,7 +118,13 @@ func (t *TemplateExecution) UnmarshalJSON(b []byte) error {
 	t.Message = v.Message
 	t.Path = v.Path
 	t.Author = v.Author
+	t.Operator = v.Operator
 	t.Fillers = v.Fillers
+	t.Aliases = v.Aliases
+	t.Defaults = v.Defaults
+	t.Adopted = v.Adopted
+	t.RevertOf = v.RevertOf
+	t.RevertedStatements = v.RevertedStatements
 
 	tpl := &Template{ID: v.ID, AST: &ast.AST{
 		Statements: make([]*ast.Statement, 0),
@@ -119,9 +142,11 @@ func (t *TemplateExecution) UnmarshalJSON(b []byte) error {
 			if len(c.Results) > 0 {
 				n.CmdResult = c.Results[0]
 			}
+			n.CmdResultAttributes = c.Attributes
 			if len(c.Errors) > 0 {
 				n.CmdErr = errors.New(c.Errors[0])
 			}
+			n.IdempotencyKey, n.Skipped = c.Key, c.Skipped
 			tpl.Statements = append(tpl.Statements, &ast.Statement{Node: n})
 		}
 	}
@@ -166,17 +191,28 @@ func (t *TemplateExecution) Stats() *TemplateExecutionStats {
 type toJSON struct {
 	ID       string                 `json:"id"`
 	Author   string                 `json:"author,omitempty"`
+	Operator string                 `json:"operator,omitempty"`
 	Source   string                 `json:"source"`
 	Locale   string                 `json:"locale"`
 	Profile  string                 `json:"profile,omitempty"`
 	Message  string                 `json:"message,omitempty"`
 	Path     string                 `json:"path,omitempty"`
 	Fillers  map[string]interface{} `json:"fillers"`
+	Aliases  []*ResolvedAlias       `json:"aliases,omitempty"`
+	Defaults []*AppliedDefault      `json:"defaults,omitempty"`
+	Adopted  []*AdoptedResource     `json:"adopted,omitempty"`
 	Commands []command              `json:"commands"`
+
+	RevertOf           string `json:"revertOf,omitempty"`
+	RevertedStatements []int  `json:"revertedStatements,omitempty"`
 }
 
 type command struct {
 	Line    string   `json:"line"`
+	Key     string   `json:"key,omitempty"`
+	Skipped bool     `json:"skipped,omitempty"`
 	Errors  []string `json:"errors,omitempty"`
 	Results []string `json:"results,omitempty"`
+	// Attributes of a typed result (ex: arn, privateip)
+	Attributes map[string]string `json:"attributes,omitempty"`
 }
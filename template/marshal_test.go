@@ -1,6 +1,7 @@
 package template
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,6 +28,85 @@ func TestTemplateIsOneLiner(t *testing.T) {
 		t.Fatal("expecting one-liner")
 	}
 }
+func TestMarshalIdempotencyKeys(t *testing.T) {
+	tplExec := &TemplateExecution{}
+	if err := tplExec.UnmarshalJSON([]byte(`{"id":"01BX","commands":[{"line":"create vpc cidr=10.0.0.0/16","key":"main-vpc","skipped":true,"results":["vpc-1"]},{"line":"create subnet"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	b, err := tplExec.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := &TemplateExecution{}
+	if err := reloaded.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	cmds := reloaded.CommandNodesIterator()
+	if got, want := cmds[0].IdempotencyKey, "main-vpc"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := cmds[0].Skipped, true; got != want {
+		t.Fatalf("got %t, want %t", got, want)
+	}
+	if got, want := cmds[1].IdempotencyKey, ""; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestMarshalResultAttributes(t *testing.T) {
+	tplExec := &TemplateExecution{}
+	if err := tplExec.UnmarshalJSON([]byte(`{"id":"01BX","commands":[{"line":"create instance","results":["i-1"],"attributes":{"privateip":"10.0.0.5"}},{"line":"create subnet"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	b, err := tplExec.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := &TemplateExecution{}
+	if err := reloaded.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	cmds := reloaded.CommandNodesIterator()
+	if got, want := cmds[0].CmdResultAttributes, map[string]string{"privateip": "10.0.0.5"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := cmds[1].CmdResultAttributes; got != nil {
+		t.Fatalf("got %v, want none", got)
+	}
+}
+
+func TestAliasConflicts(t *testing.T) {
+	tplExec := &TemplateExecution{}
+	if err := tplExec.UnmarshalJSON([]byte(`{"id":"01BX","aliases":[{"alias":"prod-db","param":"attach.securitygroup.instance","id":"i-1"},{"alias":"prod-sg","param":"attach.securitygroup.id","id":"sg-1"},{"alias":"old-subnet","param":"create.instance.subnet","id":"sub-1"}],"commands":[{"line":"attach securitygroup id=sg-1 instance=i-1"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	current := map[string]string{"prod-db": "i-2", "prod-sg": "sg-1"}
+
+	var conflicts []string
+	for _, c := range tplExec.AliasConflicts(func(paramPath, alias string) string { return current[alias] }) {
+		conflicts = append(conflicts, c.String())
+	}
+	expected := []string{
+		"alias '@prod-db' resolved to 'i-1' for attach.securitygroup.instance during the run but now resolves to 'i-2'",
+		"alias '@old-subnet' resolved to 'sub-1' for create.instance.subnet during the run but no longer resolves",
+	}
+	if got, want := conflicts, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	b, err := tplExec.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded := &TemplateExecution{}
+	if err := reloaded.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reloaded.Aliases, tplExec.Aliases; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
 func TestSetMessageTruncatingSizeWhenNeeded(t *testing.T) {
 	valid := strings.Repeat("a", 140)
 
@@ -321,3 +401,43 @@ func identJSON(content []byte) string {
 	}
 	return string(ident)
 }
+
+func TestTemplateExecutionReport(t *testing.T) {
+	tplExec := &TemplateExecution{}
+	if err := tplExec.UnmarshalJSON([]byte(`{"id":"01BPZ3QTX7KZ2W1R3J8S7TVAPG","author":"john","operator":"john@example.com","locale":"eu-west-1","source":"create vpc cidr=10.0.0.0/16\ndelete instance id=i-1\nstop instance id=i-2","commands":[
+		{"line":"create vpc cidr=10.0.0.0/16","results":["vpc-1"]},
+		{"line":"delete instance id=i-1"},
+		{"line":"stop instance id=i-2","errors":["cannot stop"]}
+	]}`)); err != nil {
+		t.Fatal(err)
+	}
+	report := tplExec.Report()
+	if got, want := report.Outcome, PartialOutcome; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := report.TemplateHash, fmt.Sprintf("%x", sha256.Sum256([]byte(tplExec.Source))); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := report.Author, "john"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := report.Operator, "john@example.com"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := report.Region, "eu-west-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	expected := []*ReportedResource{
+		{Action: "create", Type: "vpc", ID: "vpc-1"},
+		{Action: "delete", Type: "instance", ID: "i-1"},
+	}
+	if got, want := report.Resources, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := report.Commands[2].Error, "cannot stop"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if report.Date.IsZero() {
+		t.Fatal("expected report date from run id")
+	}
+}
@@ -6,15 +6,23 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 func Validate(all Validators, paramValues map[string]interface{}) error {
 	msg := bytes.NewBufferString("param validation:")
 	var hasErr bool
-	for key, vFn := range all {
+	var keys []string
+	for key := range all {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
 		if val, ok := paramValues[key]; ok {
-			if err := vFn(val, paramValues); err != nil {
+			if err := all[key](val, paramValues); err != nil {
 				hasErr = true
 				msg.WriteString(fmt.Sprintf("\n\t\t- param '%s': %s", key, err))
 			}
@@ -30,6 +38,66 @@ type validatorFunc func(val interface{}, others map[string]interface{}) error
 
 type Validators map[string]validatorFunc
 
+// All returns a validator running all the given validators and reporting all their errors
+func All(validators ...validatorFunc) validatorFunc {
+	return func(i interface{}, others map[string]interface{}) error {
+		var msgs []string
+		for _, v := range validators {
+			if err := v(i, others); err != nil {
+				msgs = append(msgs, err.Error())
+			}
+		}
+		if len(msgs) > 0 {
+			return errors.New(strings.Join(msgs, "; "))
+		}
+		return nil
+	}
+}
+
+// When returns a validator running the 'then' validator only when the 'condition' validator passes,
+// ex: "lifecycle": When(IsInEnumIgnoreCase("spot"), Requires("spotprice"))
+func When(condition, then validatorFunc) validatorFunc {
+	return func(i interface{}, others map[string]interface{}) error {
+		if err := condition(i, others); err != nil {
+			return nil
+		}
+		if err := then(i, others); err != nil {
+			return fmt.Errorf("with value '%v': %s", i, err)
+		}
+		return nil
+	}
+}
+
+func Requires(keys ...string) validatorFunc {
+	return func(i interface{}, others map[string]interface{}) error {
+		var missing []string
+		for _, k := range keys {
+			if _, ok := others[k]; !ok {
+				missing = append(missing, k)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("requires param(s) '%s'", strings.Join(missing, "', '"))
+		}
+		return nil
+	}
+}
+
+func MutuallyExclusiveWith(keys ...string) validatorFunc {
+	return func(i interface{}, others map[string]interface{}) error {
+		var found []string
+		for _, k := range keys {
+			if _, ok := others[k]; ok {
+				found = append(found, k)
+			}
+		}
+		if len(found) > 0 {
+			return fmt.Errorf("cannot be used with param(s) '%s'", strings.Join(found, "', '"))
+		}
+		return nil
+	}
+}
+
 func IsInEnumIgnoreCase(items ...string) validatorFunc {
 	included := func(arr []string, s string) bool {
 		for _, a := range arr {
@@ -77,6 +145,46 @@ func MinLengthOf(l int) validatorFunc {
 	}
 }
 
+func MatchRegex(expr, description string) validatorFunc {
+	re := regexp.MustCompile(expr)
+	return func(i interface{}, others map[string]interface{}) error {
+		s, err := toString(i)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("expected %s but got '%s'", description, s)
+		}
+		return nil
+	}
+}
+
+func IsIntInRange(min, max int) validatorFunc {
+	return func(i interface{}, others map[string]interface{}) error {
+		n, err := toInt(i)
+		if err != nil {
+			return err
+		}
+		if n < min || n > max {
+			return fmt.Errorf("expected integer between %d and %d but got %d", min, max, n)
+		}
+		return nil
+	}
+}
+
+var arnRegex = regexp.MustCompile(`^arn:aws[a-z-]*:[a-z0-9-]+:[a-z0-9-]*:(\d{12})?:.+$`)
+
+func IsARN(i interface{}, others map[string]interface{}) error {
+	s, err := toString(i)
+	if err != nil {
+		return err
+	}
+	if !arnRegex.MatchString(s) {
+		return fmt.Errorf("expected valid ARN (arn:partition:service:region:account:resource) but got '%s'", s)
+	}
+	return nil
+}
+
 func IsFilepath(i interface{}, others map[string]interface{}) error {
 	filepath, err := toString(i)
 	if err != nil {
@@ -116,6 +224,23 @@ func IsIP(i interface{}, others map[string]interface{}) (err error) {
 	return
 }
 
+func toInt(i interface{}) (int, error) {
+	switch v := i.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return n, fmt.Errorf("expected an integer but got '%s'", v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("expected an integer but got %T", i)
+	}
+}
+
 func toString(i interface{}) (string, error) {
 	s, ok := i.(string)
 	if !ok {
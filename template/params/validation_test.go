@@ -28,3 +28,78 @@ func TestValidation(t *testing.T) {
 		t.Fatalf("expected '%s' to contains: %s", got, want)
 	}
 }
+
+func TestCompositeValidators(t *testing.T) {
+	tcases := []struct {
+		validator func(interface{}, map[string]interface{}) error
+		val       interface{}
+		others    map[string]interface{}
+		expErr    string
+	}{
+		{validator: params.MatchRegex("^ami-[0-9a-f]+$", "an AMI id"), val: "ami-12ab"},
+		{validator: params.MatchRegex("^ami-[0-9a-f]+$", "an AMI id"), val: "i-12ab", expErr: "expected an AMI id but got 'i-12ab'"},
+		{validator: params.IsARN, val: "arn:aws:iam::123456789012:role/admin"},
+		{validator: params.IsARN, val: "arn:aws:s3:::my-bucket"},
+		{validator: params.IsARN, val: "arn:aws-cn:lambda:cn-north-1:123456789012:function:pets"},
+		{validator: params.IsARN, val: "arn:aws:iam::1234:role/admin", expErr: "expected valid ARN"},
+		{validator: params.IsARN, val: "my-role", expErr: "expected valid ARN"},
+		{validator: params.IsIntInRange(1, 10), val: 10},
+		{validator: params.IsIntInRange(1, 10), val: "5"},
+		{validator: params.IsIntInRange(1, 10), val: 0, expErr: "expected integer between 1 and 10 but got 0"},
+		{validator: params.IsIntInRange(1, 10), val: "five", expErr: "expected an integer but got 'five'"},
+		{validator: params.MutuallyExclusiveWith("ip", "cidr"), val: "x", others: map[string]interface{}{"name": "y"}},
+		{validator: params.MutuallyExclusiveWith("ip", "cidr"), val: "x", others: map[string]interface{}{"ip": "y", "cidr": "z"}, expErr: "cannot be used with param(s) 'ip', 'cidr'"},
+		{
+			validator: params.When(params.IsInEnumIgnoreCase("spot"), params.Requires("spotprice")),
+			val:       "on-demand",
+		},
+		{
+			validator: params.When(params.IsInEnumIgnoreCase("spot"), params.Requires("spotprice")),
+			val:       "spot", others: map[string]interface{}{"spotprice": "0.05"},
+		},
+		{
+			validator: params.When(params.IsInEnumIgnoreCase("spot"), params.Requires("spotprice")),
+			val:       "Spot", expErr: "with value 'Spot': requires param(s) 'spotprice'",
+		},
+		{
+			validator: params.All(params.MinLengthOf(3), params.MatchRegex("^[a-z]+$", "lowercase letters")),
+			val:       "A", expErr: "expected min length of 3 but got 1; expected lowercase letters but got 'A'",
+		},
+		{validator: params.All(params.MinLengthOf(3), params.MaxLengthOf(5)), val: "abcd"},
+	}
+	for i, tcase := range tcases {
+		others := tcase.others
+		if others == nil {
+			others = make(map[string]interface{})
+		}
+		err := tcase.validator(tcase.val, others)
+		if tcase.expErr == "" {
+			if err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		if got, want := err.Error(), tcase.expErr; !strings.Contains(got, want) {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestValidationReportsAllErrorsSorted(t *testing.T) {
+	vals := params.Validators{
+		"lifecycle": params.When(params.IsInEnumIgnoreCase("spot"), params.Requires("spotprice")),
+		"count":     params.IsIntInRange(1, 10),
+		"ip":        params.IsIP,
+	}
+	err := params.Validate(vals, map[string]interface{}{"lifecycle": "spot", "count": 20, "ip": "10.0.0.1"})
+	if err == nil {
+		t.Fatal("expected error got none")
+	}
+	exp := "param validation:\n\t\t- param 'count': expected integer between 1 and 10 but got 20\n\t\t- param 'lifecycle': with value 'spot': requires param(s) 'spotprice'"
+	if got, want := err.Error(), exp; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
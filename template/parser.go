@@ -45,7 +45,12 @@ func Parse(text string) (tmpl *Template, err error) {
 
 	tmpl = &Template{}
 
-	p := &ast.Peg{AST: &ast.AST{}, Buffer: string(text)}
+	withHoles, err := ast.ApplyHolesDirective(text)
+	if err != nil {
+		return nil, fmt.Errorf("template parsing: %s", err)
+	}
+
+	p := &ast.Peg{AST: &ast.AST{}, Buffer: ast.MarkParallelBlocks(withHoles)}
 	p.Init()
 
 	if err = p.Parse(); err != nil {
@@ -55,6 +60,9 @@ func Parse(text string) (tmpl *Template, err error) {
 	p.Execute()
 
 	tmpl.AST = p.AST
+	if err = tmpl.AST.FoldParallelBlocks(); err != nil {
+		err = fmt.Errorf("template parsing: %s", err)
+	}
 
 	return
 }
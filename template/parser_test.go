@@ -231,6 +231,42 @@ func TestParsingEmptyTemplate(t *testing.T) {
 	}
 }
 
+func TestParseParallelBlocks(t *testing.T) {
+	tpl, err := Parse("create vpc cidr=10.0.0.0/16\nparallel:\n  create queue name=q1\n  q2 = create queue name=q2\nend\n\nparallel:\ncreate queue name=q3\nend\ncreate queue name=q4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var blocks []int
+	for _, st := range tpl.Statements {
+		blocks = append(blocks, st.Block)
+	}
+	if got, want := blocks, []int{0, 1, 1, 2, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	exp := "create vpc cidr=10.0.0.0/16\nparallel:\n  create queue name=q1\n  q2 = create queue name=q2\nend\nparallel:\n  create queue name=q3\nend\ncreate queue name=q4"
+	if got, want := tpl.String(), exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+	reparsed := MustParse(tpl.String())
+	if got, want := reparsed.Statements[3].Block, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	tcases := []struct {
+		tpl, expErr string
+	}{
+		{"parallel:\ncreate queue name=q1\nparallel:\ncreate queue name=q2\nend\nend", "nested parallel blocks"},
+		{"create queue name=q1\nend", "'end' without opening 'parallel:'"},
+		{"parallel:\ncreate queue name=q1", "not closed with 'end'"},
+		{"parallel:\nend\ncreate queue name=q1", "empty parallel block"},
+	}
+	for _, tcase := range tcases {
+		if _, err := Parse(tcase.tpl); err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+			t.Fatalf("%q: got %v, want %s", tcase.tpl, err, tcase.expErr)
+		}
+	}
+}
+
 func TestWrapPegParseError(t *testing.T) {
 	t.Run("Display better error message", func(t *testing.T) {
 		text := "create subnet\ncreate instance type= wrong=\ncreate vpc"
@@ -1003,3 +1039,30 @@ func isValueNode(n ast.Node) error {
 	}
 	return nil
 }
+
+func TestParseHolesDirective(t *testing.T) {
+	tcases := []struct {
+		tpl, exp, expErr string
+	}{
+		{tpl: "create instance name={name} subnet=[[ subnet ]]", expErr: "error parsing template"},
+		{tpl: "\n# awless:holes [[ ]]\ncreate instance name=[[name]] subnet=[[ my.subnet ]]\ncreate tag resource=$inst key=env value=[[env]]-prod", exp: "create instance name={name} subnet={my.subnet}\ncreate tag key=env resource=$inst value={env}+'-prod'"},
+		{tpl: "// awless:holes <% %>\ncreate vpc cidr=<%cidr%> name='vpc-<% name %>'", exp: "create vpc cidr={cidr} name='vpc-{name}'"},
+		{tpl: "# the template awless:holes [[ ]]\ncreate vpc cidr={cidr}", exp: "create vpc cidr={cidr}"},
+		{tpl: "# awless:holes [[\ncreate vpc cidr=[[cidr]]", expErr: "invalid awless:holes directive"},
+	}
+	for _, tcase := range tcases {
+		tpl, err := Parse(tcase.tpl)
+		if tcase.expErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%q: got %v, want %s", tcase.tpl, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %s", tcase.tpl, err)
+		}
+		if got, want := tpl.String(), tcase.exp; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+	}
+}
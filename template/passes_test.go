@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/wallix/awless/aws/spec"
 	"github.com/wallix/awless/template/env"
@@ -94,6 +95,9 @@ func TestCheckInvalidReferencesDeclarationPass(t *testing.T) {
 		{"new_inst = create instance autoref=$new_inst\n", "'new_inst' is undefined in template"},
 		{"a = $test", "'test' is undefined in template"},
 		{"b = [test1,$test2,{test4}]", "'test2' is undefined in template"},
+		{"inst = create instance\ncreate elasticip ip=$inst.privateip", ""},
+		{"inst = create instance\ncreate elasticip ip=$inst_2.privateip", "'inst_2.privateip' is undefined in template"},
+		{"ip = 127.0.0.1\ncreate instance ip=$ip.private", "'ip.private' is undefined in template"},
 	}
 
 	for i, tcase := range tcases {
@@ -107,6 +111,32 @@ func TestCheckInvalidReferencesDeclarationPass(t *testing.T) {
 	}
 }
 
+func TestCheckParallelBlocksPass(t *testing.T) {
+	env := NewEnv().Build()
+	tcases := []struct {
+		tpl    string
+		expErr string
+	}{
+		{"parallel:\ncreate queue name=q1\ncreate queue name=q2\nend", ""},
+		{"parallel:\nq1 = create queue name=q1\nend\ncreate subscription endpoint=$q1", ""},
+		{"parallel:\nq1 = create queue name=q1\nend\nparallel:\ncreate subscription endpoint=$q1\nend", ""},
+		{"name = myqueue\nparallel:\ncreate queue name=$name\nend", ""},
+		{"parallel:\nq1 = create queue name=q1\ncreate subscription endpoint=$q1\nend", "uses '$q1' resulting from a statement of the same block"},
+		{"parallel:\nsub = create subnet\ninst = create instance subnet=$sub\nend", "uses '$sub' resulting from a statement of the same block"},
+		{"parallel:\ninst = create instance\ncreate elasticip ip=$inst.privateip\nend", "uses '$inst.privateip' resulting from a statement of the same block"},
+	}
+
+	for i, tcase := range tcases {
+		_, _, err := checkParallelBlocksPass(MustParse(tcase.tpl), env)
+		if tcase.expErr == "" && err != nil {
+			t.Fatalf("%d: %v", i+1, err)
+		}
+		if tcase.expErr != "" && (err == nil || !strings.Contains(err.Error(), tcase.expErr)) {
+			t.Fatalf("%d: got %v, expected %s", i+1, err, tcase.expErr)
+		}
+	}
+}
+
 type mockCommandWithResult struct{ id string }
 
 func (c *mockCommandWithResult) ParamsSpec() params.Spec { return nil }
@@ -226,8 +256,18 @@ func TestResolveMissingSuggestedPass(t *testing.T) {
 			return "true"
 		case "create.instance.role":
 			return "arole"
+		case "create.instance.spot":
+			return "true"
+		case "create.instance.spotinterruption":
+			return "stop"
+		case "create.instance.spotprice":
+			return "0.05"
 		case "create.instance.userdata":
 			return "/path/to/my/file"
+		case "create.instance.wait":
+			return "true"
+		case "create.instance.wait-timeout":
+			return "60"
 		default:
 			t.Fatalf("unexepected optional parameter %s: %v", in, paramPaths)
 			return ""
@@ -272,14 +312,56 @@ func TestResolveMissingSuggestedPass(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if got, want := count, 5; got != want {
+	if got, want := count, 10; got != want {
 		t.Fatalf("got %d, want %d", got, want)
 	}
-	if got, want := compiled.String(), "create instance count=1 image=ami-1a17137a ip=1.2.3.4 keypair=mykeypair lock=true name=my-instance role=arole securitygroup=@my-sec-group subnet=sub-1234 type=t2.nano userdata=/path/to/my/file"; got != want {
+	if got, want := compiled.String(), "create instance count=1 image=ami-1a17137a ip=1.2.3.4 keypair=mykeypair lock=true name=my-instance role=arole securitygroup=@my-sec-group spot=true spotinterruption=stop spotprice=0.05 subnet=sub-1234 type=t2.nano userdata=/path/to/my/file wait-timeout=60 wait=true"; got != want {
 		t.Fatalf("got \n%s, want \n%s", got, want)
 	}
 }
 
+func TestApplyEntityDefaultsPass(t *testing.T) {
+	tpl := MustParse(`create instance image=ami-1234 name=web subnet=sub-1234
+update instance id=i-1234 lock=true
+create instance distro=debian count=2 name=db subnet=sub-1234 keypair=dbkey
+create volume availabilityzone=eu-west-1a size=10`)
+
+	cenv := NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		return awsspec.MockAWSSessionFactory.Build(strings.Join(tokens, ""))()
+	}).WithParamsMode(env.REQUIRED_PARAMS_ONLY).Build()
+	cenv.Push(env.ENTITY_DEFAULTS, map[string]interface{}{
+		"instance.type":    "t3.micro",
+		"instance.count":   1,
+		"instance.distro":  "amazonlinux",
+		"instance.keypair": "@ops",
+		"volume.size":      20,
+	})
+	cenv.Push(env.FILLERS, map[string]interface{}{"instance.type": "t2.large"}, map[string]interface{}{"instance.count": 1})
+
+	pass := newMultiPass(injectCommandsInNodesPass, applyEntityDefaultsPass, processAndValidateParamsPass)
+	compiled, cenv, err := pass.compile(tpl, cenv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{
+		"create instance count=1 image=ami-1234 keypair=@ops name=web subnet=sub-1234 type={instance.type}",
+		"update instance id=i-1234 lock=true",
+		"create instance count=2 distro=debian keypair=dbkey name=db subnet=sub-1234 type={instance.type}",
+		"create volume availabilityzone=eu-west-1a size=10",
+	}
+	for i, cmd := range compiled.CommandNodesIterator() {
+		if got, want := cmd.String(), exp[i]; got != want {
+			t.Fatalf("%d: got %s, want %s", i, got, want)
+		}
+	}
+
+	expDefaults := []*AppliedDefault{{Key: "instance.count", Value: "1"}, {Key: "instance.keypair", Value: "@ops"}}
+	if got, want := appliedDefaults(cenv.Get(env.APPLIED_DEFAULTS)), expDefaults; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
 func TestResolveAliasPass(t *testing.T) {
 	tpl := MustParse("create instance subnet=@my-subnet ami={instance.ami} count=3")
 
@@ -295,12 +377,20 @@ func TestResolveAliasPass(t *testing.T) {
 
 	pass := newMultiPass(resolveHolesPass, resolveAliasPass)
 
-	tpl, _, err := pass.compile(tpl, cenv)
+	tpl, cenv, err := pass.compile(tpl, cenv)
 	if err != nil {
 		t.Fatal(err)
 	}
 
 	assertCmdParams(t, tpl, map[string]interface{}{"subnet": "sub-12345", "ami": "ami-12345", "count": 3})
+
+	expAliases := []*ResolvedAlias{
+		{Alias: "my-ami", ParamPath: "create.instance.ami", ID: "ami-12345"},
+		{Alias: "my-subnet", ParamPath: "create.instance.subnet", ID: "sub-12345"},
+	}
+	if got, want := resolvedAliases(cenv.Get(env.RESOLVED_ALIASES)), expAliases; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
 }
 
 func TestResolveHolesPass(t *testing.T) {
@@ -321,6 +411,49 @@ func TestResolveHolesPass(t *testing.T) {
 	assertCmdParams(t, tpl, map[string]interface{}{"type": "t2.micro", "count": 3})
 }
 
+func TestResolveBuiltinsPass(t *testing.T) {
+	defer func(clock func() time.Time) { builtinsClock = clock }(builtinsClock)
+	builtinsClock = func() time.Time { return time.Date(2017, 8, 3, 10, 30, 0, 0, time.UTC) }
+
+	t.Run("with builtin func", func(t *testing.T) {
+		tpl := MustParse("create bucket name=backups-{aws.account}-{date.today}\ncreate tag resource=@bucket key=Owner value={aws.user}\ncreate tag resource=@bucket key=Stamp value={date.now}")
+
+		var calls []string
+		cenv := NewEnv().WithBuiltinFunc(func(name string) (string, error) {
+			calls = append(calls, name)
+			return map[string]string{"aws.account": "123456789012", "aws.user": "john"}[name], nil
+		}).Build()
+		cenv.Push(env.FILLERS, map[string]interface{}{"aws.user": "jane"})
+
+		tpl, _, err := newMultiPass(resolveBuiltinsPass, resolveHolesPass).compile(tpl, cenv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := calls, []string{"aws.account"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := tpl.String(), "create bucket name=backups-123456789012-2017-08-03\ncreate tag key=Owner resource=@bucket value=jane\ncreate tag key=Stamp resource=@bucket value=20170803-103000"; got != want {
+			t.Fatalf("got\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("without builtin func", func(t *testing.T) {
+		tpl := MustParse("create bucket name={aws.account}-{date.unix}")
+		tpl, _, err := newMultiPass(resolveBuiltinsPass, resolveHolesPass).compile(tpl, NewEnv().Build())
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertCmdHoles(t, tpl, map[string][]string{"name": {"aws.account"}})
+	})
+
+	t.Run("failing builtin func", func(t *testing.T) {
+		cenv := NewEnv().WithBuiltinFunc(func(name string) (string, error) { return "", errors.New("no credentials") }).Build()
+		if _, _, err := resolveBuiltinsPass(MustParse("create bucket name={aws.account}"), cenv); err == nil || !strings.Contains(err.Error(), "resolving {aws.account}: no credentials") {
+			t.Fatalf("got %v", err)
+		}
+	})
+}
+
 func TestInlineVariableWithValue(t *testing.T) {
 	env := NewEnv().Build()
 	tcases := []struct {
@@ -388,6 +521,123 @@ func TestCmdErr(t *testing.T) {
 	}
 }
 
+type mockCommandWithKeyParam struct{}
+
+func (c *mockCommandWithKeyParam) Run(env.Running, map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+func (c *mockCommandWithKeyParam) ParamsSpec() params.Spec {
+	return params.NewSpec(params.AllOf(params.Key("key"), params.Key("value")))
+}
+
+func TestExtractIdempotencyKeysPass(t *testing.T) {
+	env := NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		if strings.Join(tokens, "") == "createtag" {
+			return &mockCommandWithKeyParam{}
+		}
+		return &mockCommand{strings.Join(tokens, "")}
+	}).Build()
+
+	t.Run("extract keys", func(t *testing.T) {
+		tpl := MustParse("net = create vpc key=main-vpc cidr=10.0.0.0/16\ncreate subnet vpc=$net key=sub\ncreate tag key=Env value=prod\ncreate instance")
+		tpl, _, err := newMultiPass(injectCommandsInNodesPass, extractIdempotencyKeysPass).compile(tpl, env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmds := tpl.CommandNodesIterator()
+		expected := []struct {
+			key, line string
+		}{
+			{"main-vpc", "create vpc cidr=10.0.0.0/16"},
+			{"sub", "create subnet vpc=$net"},
+			{"", "create tag key=Env value=prod"},
+			{"", "create instance"},
+		}
+		for i, exp := range expected {
+			if got, want := cmds[i].IdempotencyKey, exp.key; got != want {
+				t.Fatalf("%d: got %q, want %q", i+1, got, want)
+			}
+			if got, want := cmds[i].String(), exp.line; got != want {
+				t.Fatalf("%d: got %q, want %q", i+1, got, want)
+			}
+		}
+	})
+
+	t.Run("duplicated keys", func(t *testing.T) {
+		tpl := MustParse("create vpc key=main\ncreate subnet key=main")
+		if _, _, err := newMultiPass(injectCommandsInNodesPass, extractIdempotencyKeysPass).compile(tpl, env); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("key referencing variable", func(t *testing.T) {
+		tpl := MustParse("net = create vpc\ncreate subnet key=$net")
+		if _, _, err := newMultiPass(injectCommandsInNodesPass, extractIdempotencyKeysPass).compile(tpl, env); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+type mockCommandWithParamsValidation struct{}
+
+func (c *mockCommandWithParamsValidation) Run(env.Running, map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+func (c *mockCommandWithParamsValidation) ParamsSpec() params.Spec {
+	return params.NewSpec(
+		params.AllOf(params.Key("name"), params.Opt("subnet", "availabilityzone")),
+		params.Validators{"name": params.MinLengthOf(3)},
+	)
+}
+func (c *mockCommandWithParamsValidation) ValidateParams(values map[string]interface{}) error {
+	_, hasSubnet := values["subnet"]
+	_, hasZone := values["availabilityzone"]
+	if hasSubnet && hasZone {
+		return errors.New("either 'subnet' or 'availabilityzone', not both")
+	}
+	return nil
+}
+
+func TestValidateCommandsPass(t *testing.T) {
+	env := NewEnv().WithLookupCommandFunc(func(tokens ...string) interface{} {
+		return &mockCommandWithParamsValidation{}
+	}).Build()
+	tcases := []struct {
+		tpl    string
+		expErr []string
+	}{
+		{tpl: "create instance name=web subnet=sub-1234"},
+		{tpl: "create instance name=web availabilityzone=eu-west-1a"},
+		{tpl: "sub = create subnet name=sub\ncreate instance name=web subnet=$sub availabilityzone=eu-west-1a"},
+		{
+			tpl:    "create instance name=web subnet=sub-1234 availabilityzone=eu-west-1a",
+			expErr: []string{"param validation:", "either 'subnet' or 'availabilityzone', not both"},
+		},
+		{
+			tpl:    "create instance name=w subnet=sub-1234 availabilityzone=eu-west-1a",
+			expErr: []string{"param 'name': expected min length of 3", "either 'subnet' or 'availabilityzone', not both"},
+		},
+	}
+	for i, tcase := range tcases {
+		pass := newMultiPass(injectCommandsInNodesPass, validateCommandsPass)
+		_, _, err := pass.compile(MustParse(tcase.tpl), env)
+		if len(tcase.expErr) == 0 {
+			if err != nil {
+				t.Fatalf("%d: %s", i+1, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Fatalf("%d: expected error", i+1)
+		}
+		for _, exp := range tcase.expErr {
+			if got, want := err.Error(), exp; !strings.Contains(got, want) {
+				t.Fatalf("%d: got %q, want %q", i+1, got, want)
+			}
+		}
+	}
+}
+
 type mockCommand struct{ id string }
 
 func (c *mockCommand) Run(env.Running, map[string]interface{}) (interface{}, error) { return nil, nil }
@@ -0,0 +1,61 @@
+package template
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Events emitted along a run
+const (
+	CompileStartedEvent = "compile_started"
+	HoleNeededEvent     = "hole_needed"
+	CompileDoneEvent    = "compile_done"
+	DryRunDoneEvent     = "dry_run_done"
+	StatementDoneEvent  = "statement_done"
+	RunDoneEvent        = "run_done"
+)
+
+// CanceledOutcome is the outcome of a run not confirmed after its dry run
+const CanceledOutcome = "canceled"
+
+// ProgressEvent reports the progress of a run to machines embedding awless (GUIs, wrappers).
+// Only the fields relevant to the event are set.
+type ProgressEvent struct {
+	Event      string    `json:"event"`
+	Time       time.Time `json:"time"`
+	RunID      string    `json:"runId,omitempty"`
+	Statements int       `json:"statements,omitempty"`
+	Index      *int      `json:"index,omitempty"`
+	Line       string    `json:"line,omitempty"`
+	Result     string    `json:"result,omitempty"`
+	Skipped    bool      `json:"skipped,omitempty"`
+	Hole       string    `json:"hole,omitempty"`
+	ParamPaths []string  `json:"paramPaths,omitempty"`
+	Optional   bool      `json:"optional,omitempty"`
+	Outcome    string    `json:"outcome,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// NewProgressWriter returns a func writing the events to w as newline-delimited JSON.
+// It is safe for concurrent use (statements of parallel blocks).
+func NewProgressWriter(w io.Writer) func(*ProgressEvent) {
+	var mu sync.Mutex
+	enc := json.NewEncoder(w)
+	return func(e *ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if e.Time.IsZero() {
+			e.Time = time.Now().UTC()
+		}
+		enc.Encode(e)
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,104 @@
+package template
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Outcomes of a template execution in its report
+const (
+	SuccessOutcome = "success"
+	PartialOutcome = "partial"
+	FailureOutcome = "failure"
+)
+
+// RunReport is the structured audit record of a template execution: who ran which template
+// (identified by the SHA-256 of its source), where, on which resources and with which outcome
+type RunReport struct {
+	RunID        string              `json:"runId"`
+	Date         time.Time           `json:"date"`
+	Author       string              `json:"author,omitempty"`
+	Operator     string              `json:"operator,omitempty"`
+	Profile      string              `json:"profile,omitempty"`
+	Region       string              `json:"region"`
+	Path         string              `json:"path,omitempty"`
+	Message      string              `json:"message,omitempty"`
+	TemplateHash string              `json:"templateSha256"`
+	Outcome      string              `json:"outcome"`
+	Resources    []*ReportedResource `json:"resources"`
+	Commands     []*ReportedCommand  `json:"commands"`
+}
+
+// ReportedResource is a resource created (id from the result) or acted upon (id param) by a command
+type ReportedResource struct {
+	Action string `json:"action"`
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+}
+
+type ReportedCommand struct {
+	Line    string `json:"line"`
+	Result  string `json:"result,omitempty"`
+	Error   string `json:"error,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"`
+}
+
+// Report returns the audit record of the execution
+func (t *TemplateExecution) Report() *RunReport {
+	hash := sha256.Sum256([]byte(t.Source))
+	report := &RunReport{
+		RunID:        t.ID,
+		Date:         t.Date().UTC(),
+		Author:       t.Author,
+		Operator:     t.Operator,
+		Profile:      t.Profile,
+		Region:       t.Locale,
+		Path:         t.Path,
+		Message:      t.Message,
+		TemplateHash: hex.EncodeToString(hash[:]),
+		Resources:    []*ReportedResource{},
+		Commands:     []*ReportedCommand{},
+	}
+
+	for _, cmd := range t.CommandNodesIterator() {
+		reported := &ReportedCommand{Line: cmd.String(), Skipped: cmd.Skipped}
+		if cmd.CmdErr != nil {
+			reported.Error = cmd.CmdErr.Error()
+		}
+		if result, ok := cmd.CmdResult.(string); ok {
+			reported.Result = result
+		}
+		report.Commands = append(report.Commands, reported)
+
+		if cmd.CmdErr != nil || cmd.Skipped {
+			continue
+		}
+		id := reported.Result
+		if cmd.Action != "create" || id == "" {
+			if v, ok := cmd.ToDriverParams()["id"]; ok {
+				id = fmt.Sprint(v)
+			}
+		}
+		if id != "" {
+			report.Resources = append(report.Resources, &ReportedResource{Action: cmd.Action, Type: cmd.Entity, ID: id})
+		}
+	}
+
+	stats := t.Stats()
+	switch {
+	case stats.KOCount == 0:
+		report.Outcome = SuccessOutcome
+	case stats.AllKO():
+		report.Outcome = FailureOutcome
+	default:
+		report.Outcome = PartialOutcome
+	}
+	return report
+}
+
+func (r *RunReport) JSON() ([]byte, error) {
+	return json.Marshal(r)
+}
@@ -0,0 +1,37 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Retry returns the template of the statements on which the execution stopped with a failure
+// (several in a parallel block), with the references bound during the execution, so that they
+// can be run again once the underlying issue is fixed (ex: a quota raised)
+func (t *TemplateExecution) Retry() (*Template, error) {
+	var lines []string
+	for _, cmd := range t.CommandNodesIterator() {
+		if cmd.CmdErr == nil {
+			continue
+		}
+		if refs := cmd.GetRefs(); len(refs) > 0 {
+			return nil, fmt.Errorf("'%s' has unbound references: %s", cmd, strings.Join(refs, ", "))
+		}
+		line := cmd.String()
+		if cmd.IdempotencyKey != "" {
+			line = fmt.Sprintf("%s %s=%s", line, IdempotencyKeyParam, quoteParamIfNeeded(cmd.IdempotencyKey))
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no failed statement")
+	}
+
+	text := strings.Join(lines, "\n")
+	tpl, err := Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("retry: \n%s\n%s", text, err)
+	}
+	return tpl, nil
+}
@@ -0,0 +1,29 @@
+package template
+
+import "testing"
+
+func TestRetryFailedStatements(t *testing.T) {
+	tplExec := &TemplateExecution{}
+	if err := tplExec.UnmarshalJSON([]byte(`{"id":"01BPZ3QTX7KZ2W1R3J8S7TVAPG","source":"","commands":[
+		{"line":"create vpc cidr=10.0.0.0/16","results":["vpc-1"]},
+		{"line":"create subnet cidr=10.0.0.0/24 vpc=vpc-1","results":["sub-1"]},
+		{"line":"create instance count=20 subnet=sub-1","key":"web","errors":["InstanceLimitExceeded"]}
+	]}`)); err != nil {
+		t.Fatal(err)
+	}
+	retry, err := tplExec.Retry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := retry.String(), "create instance count=20 key=web subnet=sub-1"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	ok := &TemplateExecution{}
+	if err := ok.UnmarshalJSON([]byte(`{"id":"01BPZ3QTX7KZ2W1R3J8S7TVAPG","source":"","commands":[{"line":"create vpc cidr=10.0.0.0/16","results":["vpc-1"]}]}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ok.Retry(); err == nil {
+		t.Fatal("expected error when retrying an execution without failure")
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/template/internal/ast"
 )
 
@@ -53,6 +55,26 @@ func (te *Template) Revert() (*Template, error) {
 				case "mfadevice":
 					params = append(params, fmt.Sprintf("id=%s", cmd.Params["id"].String()))
 					params = append(params, fmt.Sprintf("user=%s", cmd.Params["user"].String()))
+				case "keygrant":
+					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
+					params = append(params, fmt.Sprintf("key=%s", cmd.Params["key"].String()))
+				case "alarm":
+					for k, v := range cmd.Params {
+						if k == "dimensions" {
+							continue
+						}
+						params = append(params, fmt.Sprintf("%s=%v", k, v.String()))
+					}
+					if dims := alarmDimensionsParam(cmd.CmdResult); dims != "" {
+						params = append(params, dims)
+					}
+				case "integration":
+					for k, v := range cmd.Params {
+						if k == "function" {
+							continue
+						}
+						params = append(params, fmt.Sprintf("%s=%v", k, v.String()))
+					}
 				default:
 					for k, v := range cmd.Params {
 						params = append(params, fmt.Sprintf("%s=%v", k, v.String()))
@@ -69,6 +91,16 @@ func (te *Template) Revert() (*Template, error) {
 						}
 						params = append(params, fmt.Sprintf("%s=%v", k, v.String()))
 					}
+				case cmd.Entity == "alarm" && cmd.Action == "detach":
+					for k, v := range cmd.Params {
+						if k == "dimensions" {
+							continue
+						}
+						params = append(params, fmt.Sprintf("%s=%v", k, v.String()))
+					}
+					if dims := alarmDimensionsParam(cmd.CmdResult); dims != "" {
+						params = append(params, dims)
+					}
 				case cmd.Entity == "containertask":
 					params = append(params, fmt.Sprintf("cluster=%s", cmd.Params["cluster"].String()))
 					params = append(params, fmt.Sprintf("type=%s", cmd.Params["type"].String()))
@@ -108,6 +140,12 @@ func (te *Template) Revert() (*Template, error) {
 				case "database":
 					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
 					params = append(params, "skip-snapshot=true")
+				case "instance":
+					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
+					switch strings.ToLower(fmt.Sprint(cmd.ToDriverParams()["spotinterruption"])) {
+					case "stop", "hibernate":
+						params = append(params, "cancel-spot-requests=true")
+					}
 				case "certificate":
 					params = append(params, fmt.Sprintf("arn=%s", quoteParamIfNeeded(cmd.CmdResult)))
 				case "policy":
@@ -134,7 +172,24 @@ func (te *Template) Revert() (*Template, error) {
 					params = append(params, fmt.Sprintf("service-namespace=%s", cmd.Params["service-namespace"].String()))
 				case "loginprofile":
 					params = append(params, fmt.Sprintf("username=%s", cmd.Params["username"].String()))
-				case "bucket", "launchconfiguration", "scalinggroup", "alarm", "dbsubnetgroup", "keypair":
+				case "functionalias":
+					params = append(params, fmt.Sprintf("function=%s", cmd.Params["function"].String()))
+					params = append(params, fmt.Sprintf("name=%s", cmd.Params["name"].String()))
+				case "trafficpolicy":
+					// a traffic policy is created with its first version
+					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
+					params = append(params, "version=1")
+				case "apideployment":
+					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
+					params = append(params, fmt.Sprintf("restapi=%s", cmd.Params["restapi"].String()))
+				case "apiresource":
+					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
+					params = append(params, fmt.Sprintf("restapi=%s", cmd.Params["restapi"].String()))
+				case "apimethod":
+					params = append(params, fmt.Sprintf("method=%s", quoteParamIfNeeded(cmd.CmdResult)))
+					params = append(params, fmt.Sprintf("resource=%s", cmd.Params["resource"].String()))
+					params = append(params, fmt.Sprintf("restapi=%s", cmd.Params["restapi"].String()))
+				case "bucket", "launchconfiguration", "scalinggroup", "alarm", "dbsubnetgroup", "keypair", "table", "alias":
 					params = append(params, fmt.Sprintf("name=%s", quoteParamIfNeeded(cmd.CmdResult)))
 					if cmd.Entity == "scalinggroup" {
 						params = append(params, "force=true")
@@ -184,6 +239,9 @@ func (te *Template) Revert() (*Template, error) {
 				lines = append(lines, fmt.Sprintf("update scalinggroup name=%s max-size=0 min-size=0", quoteParamIfNeeded(cmd.CmdResult)))
 				lines = append(lines, fmt.Sprintf("check scalinggroup count=0 name=%s timeout=600", quoteParamIfNeeded(cmd.CmdResult)))
 			}
+			if cmd.Action == "create" && cmd.Entity == "apideployment" && cmd.Params["stage"] != nil {
+				lines = append(lines, fmt.Sprintf("delete apistage name=%s restapi=%s", cmd.Params["stage"].String(), cmd.Params["restapi"].String()))
+			}
 			if cmd.Action == "start" && cmd.Entity == "instance" {
 				switch vv := cmd.ToDriverParams()["ids"].(type) {
 				case string:
@@ -240,6 +298,109 @@ func (te *Template) Revert() (*Template, error) {
 	return tpl, nil
 }
 
+// RevertStatements reverts only the statements at the given positions, numbered from 1 in
+// the order of the commands of the template (as shown by `awless log REVERTID`). It refuses to
+// delete a resource created by a selected statement still used by an unselected one or, when
+// the local graph is given, by the resources depending on it (see graph.Dependents).
+func (te *Template) RevertStatements(positions []int, g *graph.Graph) (*Template, error) {
+	cmds := te.CommandNodesIterator()
+	selected := make(map[int]bool)
+	for _, pos := range positions {
+		if pos < 1 || pos > len(cmds) {
+			return nil, fmt.Errorf("revert: no statement %d (template has %d statements)", pos, len(cmds))
+		}
+		if !isRevertible(cmds[pos-1]) {
+			return nil, fmt.Errorf("revert: statement %d '%s' is not revertible", pos, cmds[pos-1])
+		}
+		selected[pos-1] = true
+	}
+	if err := checkRevertDependencies(cmds, selected, g); err != nil {
+		return nil, err
+	}
+
+	partial := &Template{ID: te.ID, AST: &ast.AST{}}
+	for i, cmd := range cmds {
+		if selected[i] {
+			partial.Statements = append(partial.Statements, &ast.Statement{Node: cmd})
+		}
+	}
+	return partial.Revert()
+}
+
+// checkRevertDependencies fails when a resource created or copied by a selected statement
+// is referenced in the params of an unselected statement whose effect remains, or has
+// dependents in the graph other than the resources of the template
+func checkRevertDependencies(cmds []*ast.CommandNode, selected map[int]bool, g *graph.Graph) error {
+	ofTemplate := make(map[string]bool)
+	for _, cmd := range cmds {
+		if created, ok := cmd.CmdResult.(string); ok {
+			ofTemplate[created] = true
+		}
+	}
+	var conflicts []string
+	for i, cmd := range cmds {
+		if !selected[i] || (cmd.Action != "create" && cmd.Action != "copy") {
+			continue
+		}
+		created, ok := cmd.CmdResult.(string)
+		if !ok || created == "" {
+			continue
+		}
+		for j, other := range cmds {
+			if selected[j] || j == i || other.CmdErr != nil || other.Skipped || other.Action == "check" {
+				continue
+			}
+			if paramsReference(other.ToDriverParams(), created) {
+				conflicts = append(conflicts, fmt.Sprintf("statement %d '%s' still needs %s created by statement %d", j+1, other, created, i+1))
+			}
+		}
+		if g == nil {
+			continue
+		}
+		resources, err := g.FindWithProperties(map[string]interface{}{properties.ID: created})
+		if err != nil {
+			return err
+		}
+		for _, r := range resources {
+			res, ok := r.(*graph.Resource)
+			if !ok {
+				continue
+			}
+			dependents, err := g.Dependents(res)
+			if err != nil {
+				return err
+			}
+			for _, d := range dependents {
+				if !ofTemplate[d.Id()] {
+					conflicts = append(conflicts, fmt.Sprintf("%s %s (%s) still needs %s created by statement %d", d.Type(), d.Id(), d.Through, created, i+1))
+				}
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("revert: %s (select these statements as well)", strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+func paramsReference(params map[string]interface{}, value string) bool {
+	for _, v := range params {
+		switch vv := v.(type) {
+		case []interface{}:
+			for _, e := range vv {
+				if fmt.Sprint(e) == value {
+					return true
+				}
+			}
+		default:
+			if fmt.Sprint(vv) == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func IsRevertible(t *Template) bool {
 	revertible := false
 	t.visitCommandNodes(func(cmd *ast.CommandNode) {
@@ -251,7 +412,7 @@ func IsRevertible(t *Template) bool {
 }
 
 func isRevertible(cmd *ast.CommandNode) bool {
-	if cmd.CmdErr != nil {
+	if cmd.CmdErr != nil || cmd.Skipped {
 		return false
 	}
 
@@ -259,10 +420,36 @@ func isRevertible(cmd *ast.CommandNode) bool {
 		return false
 	}
 
+	if cmd.Action == "create" && cmd.Entity == "presignedurl" {
+		return false
+	}
+
+	// publishing an unchanged function returns its last version, that may have been published before the run
+	if cmd.Action == "create" && cmd.Entity == "functionversion" {
+		return false
+	}
+
+	// the reused address may have been allocated before the run
+	if reuse, ok := cmd.Params["reuse"]; ok && cmd.Action == "create" && cmd.Entity == "elasticip" && reuse.String() == "true" {
+		return false
+	}
+
 	if cmd.Action == "detach" && cmd.Entity == "routetable" {
 		return false
 	}
 
+	if cmd.Action == "detach" && cmd.Entity == "keygrant" {
+		return false
+	}
+
+	if cmd.Action == "detach" && cmd.Entity == "integration" {
+		return false
+	}
+
+	if _, bulk := cmd.Params["query"]; bulk && cmd.Action == "create" && cmd.Entity == "tag" {
+		return false
+	}
+
 	if cmd.Entity == "record" && (cmd.Action == "create" || cmd.Action == "delete") {
 		return true
 	}
@@ -284,6 +471,10 @@ func isRevertible(cmd *ast.CommandNode) bool {
 		return ok && (t == "service" || t == "task")
 	}
 
+	if cmd.Entity == "statemachine" && cmd.Action == "start" {
+		return false
+	}
+
 	if cmd.Entity == "container" && cmd.Action == "create" {
 		return true
 	}
@@ -293,7 +484,9 @@ func isRevertible(cmd *ast.CommandNode) bool {
 	}
 
 	if cmd.Entity == "securitygroup" && cmd.Action == "update" {
-		return true
+		_, inboundRules := cmd.Params["inbound-rules"]
+		_, outboundRules := cmd.Params["outbound-rules"]
+		return !inboundRules && !outboundRules
 	}
 
 	if cmd.Entity == "appscalingpolicy" && cmd.Action == "create" {
@@ -310,6 +503,20 @@ func isRevertible(cmd *ast.CommandNode) bool {
 		(cmd.Action == "create" && cmd.Entity == "tag") || (cmd.Action == "create" && cmd.Entity == "route")
 }
 
+// alarmDimensionsParam returns the dimensions param restoring the dimensions an alarm had
+// before being attached or detached, given by the command result as 'Name:Value,...'.
+func alarmDimensionsParam(result interface{}) string {
+	previous, ok := result.(string)
+	if !ok || previous == "" {
+		return ""
+	}
+	var dims []string
+	for _, dim := range strings.Split(previous, ",") {
+		dims = append(dims, quoteParamIfNeeded(dim))
+	}
+	return fmt.Sprintf("dimensions=%s", strings.Join(dims, ","))
+}
+
 func quoteParamIfNeeded(param interface{}) string {
 	input := fmt.Sprint(param)
 	if ast.SimpleStringValue.MatchString(input) {
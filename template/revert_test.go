@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
 	"github.com/wallix/awless/template/internal/ast"
 )
 
@@ -202,6 +204,109 @@ attach volume device=/dev/sdh id=vol-12345 instance=i-12345`
 		}
 	})
 
+	t.Run("Revert attach and detach an alarm restores its previous dimensions", func(t *testing.T) {
+		tpl := MustParse("attach alarm name=my-alarm instance=i-12345\nattach alarm name=my-alarm scalinggroup=my-asg\ndetach alarm name=my-alarm scalinggroup=my-asg")
+		results := []string{"", "InstanceId:i-12345", "AutoScalingGroupName:my-asg,Dim:val"}
+		for i, cmd := range tpl.CommandNodesIterator() {
+			cmd.CmdResult = results[i]
+		}
+		reverted, err := tpl.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := `attach alarm dimensions=[AutoScalingGroupName:my-asg,Dim:val] name=my-alarm scalinggroup=my-asg
+detach alarm dimensions=InstanceId:i-12345 name=my-alarm scalinggroup=my-asg
+detach alarm instance=i-12345 name=my-alarm`
+		if got, want := reverted.String(), exp; got != want {
+			t.Fatalf("got: %s\nwant: %s\n", got, want)
+		}
+	})
+
+	t.Run("Revert function versions and aliases", func(t *testing.T) {
+		tpl := MustParse("create functionversion function=my-func\ncreate functionalias function=my-func name=prod version=3")
+		results := []string{"3", "arn:aws:lambda:eu-west-1:123456789012:function:my-func:prod"}
+		for i, cmd := range tpl.CommandNodesIterator() {
+			cmd.CmdResult = results[i]
+		}
+		reverted, err := tpl.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := `delete functionalias function=my-func name=prod`
+		if got, want := reverted.String(), exp; got != want {
+			t.Fatalf("got: %s\nwant: %s\n", got, want)
+		}
+	})
+
+	t.Run("Revert traffic policies and their instances", func(t *testing.T) {
+		tpl := MustParse("create trafficpolicy name=geo-web document-file=./geo-web.json\ncreate trafficpolicyinstance zone=Z1234 name=www.example.com ttl=60 policy=tp-1234 version=1")
+		results := []string{"tp-1234", "tpi-1234"}
+		for i, cmd := range tpl.CommandNodesIterator() {
+			cmd.CmdResult = results[i]
+		}
+		reverted, err := tpl.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := `delete trafficpolicyinstance id=tpi-1234
+delete trafficpolicy id=tp-1234 version=1`
+		if got, want := reverted.String(), exp; got != want {
+			t.Fatalf("got: %s\nwant: %s\n", got, want)
+		}
+	})
+
+	t.Run("Revert KMS key, alias and grant", func(t *testing.T) {
+		tpl := MustParse("create key description=backups\ncreate alias key=key-12345 name=alias/backups\nattach keygrant grantee=arn:aws:iam::123456789012:role/backup key=key-12345 operations=Encrypt,Decrypt")
+		results := []string{"key-12345", "alias/backups", "grant-12345"}
+		for i, cmd := range tpl.CommandNodesIterator() {
+			cmd.CmdResult = results[i]
+		}
+		reverted, err := tpl.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := `detach keygrant id=grant-12345 key=key-12345
+delete alias name=alias/backups
+delete key id=key-12345`
+		if got, want := reverted.String(), exp; got != want {
+			t.Fatalf("got: %s\nwant: %s\n", got, want)
+		}
+
+		if IsRevertible(MustParse("detach keygrant id=grant-12345 key=key-12345")) {
+			t.Fatal("expected revoked grant not to be revertible")
+		}
+	})
+
+	t.Run("Revert API Gateway rest api, integration and deployment", func(t *testing.T) {
+		tpl := MustParse("create restapi name=petstore\ncreate apiresource restapi=api-12345 path=pets\ncreate apimethod restapi=api-12345 resource=res-12345 method=GET\nattach integration restapi=api-12345 resource=res-12345 method=GET function=arn:aws:lambda:us-east-1:123456789012:function:pets\ncreate apideployment restapi=api-12345 stage=prod")
+		results := []string{"api-12345", "res-12345", "GET", "", "dep-12345"}
+		for i, cmd := range tpl.CommandNodesIterator() {
+			cmd.CmdResult = results[i]
+		}
+		reverted, err := tpl.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := `delete apistage name=prod restapi=api-12345
+delete apideployment id=dep-12345 restapi=api-12345
+detach integration method=GET resource=res-12345 restapi=api-12345
+delete apimethod method=GET resource=res-12345 restapi=api-12345
+delete apiresource id=res-12345 restapi=api-12345
+delete restapi id=api-12345`
+		if got, want := reverted.String(), exp; got != want {
+			t.Fatalf("got: %s\nwant: %s\n", got, want)
+		}
+
+		if IsRevertible(MustParse("detach integration restapi=api-12345 resource=res-12345 method=GET")) {
+			t.Fatal("expected detached integration not to be revertible")
+		}
+	})
+
 	t.Run("Revert create route", func(t *testing.T) {
 		tpl := MustParse("create route cidr=0.0.0.0/0 gateway=igw-12345 table=rtb-12345")
 		reverted, err := tpl.Revert()
@@ -387,6 +492,56 @@ stop containertask cluster=cl deployment-name=dpname type=service`
 	})
 }
 
+func TestRevertTemplateExecutionWithAdoptedResources(t *testing.T) {
+	tpl := MustParse("create subnet")
+	for _, cmd := range tpl.CommandNodesIterator() {
+		cmd.CmdResult = "sub-1234"
+	}
+	tplExec := &TemplateExecution{Template: tpl}
+	if !tplExec.Adopt(&AdoptedResource{Type: "instance", ID: "i-54321", Key: "id", Value: "i-54321"}) {
+		t.Fatal("expected adoption")
+	}
+	if !tplExec.Adopt(&AdoptedResource{Type: "user", ID: "AIDA1234", Key: "name", Value: "john"}) {
+		t.Fatal("expected adoption")
+	}
+	if tplExec.Adopt(&AdoptedResource{Type: "user", ID: "AIDA1234", Key: "name", Value: "john"}) {
+		t.Fatal("expected no adoption of already adopted resource")
+	}
+
+	b, err := tplExec.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := &TemplateExecution{}
+	if err = loaded.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(loaded.Adopted), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	reverted, err := loaded.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "delete user name=john\ndelete instance id=i-54321\ncheck instance id=i-54321 state=terminated timeout=180\ndelete subnet id=sub-1234"
+	if got, want := reverted.String(), exp; got != want {
+		t.Fatalf("got: %s\nwant: %s\n", got, want)
+	}
+
+	loaded.Template = MustParse("create subnet")
+	if !loaded.IsRevertible() {
+		t.Fatal("expected revertible template execution")
+	}
+	reverted, err = loaded.Revert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := reverted.String(), "delete user name=john\ndelete instance id=i-54321"; got != want {
+		t.Fatalf("got: %s\nwant: %s\n", got, want)
+	}
+}
+
 func TestCmdNodeIsRevertible(t *testing.T) {
 	tcases := []struct {
 		line, result string
@@ -411,6 +566,14 @@ func TestCmdNodeIsRevertible(t *testing.T) {
 		{line: "stop alarm", revertible: true},
 		{line: "start containertask", params: map[string]ast.CompositeValue{"type": ast.NewInterfaceValue("service")}, revertible: true},
 		{line: "start containertask", params: map[string]ast.CompositeValue{"type": ast.NewInterfaceValue("task")}, revertible: true},
+		{line: "start statemachine", result: "any", revertible: false},
+		{line: "create presignedurl", result: "https://my-bucket.s3.amazonaws.com/key", revertible: false},
+		{line: "create elasticip", result: "eipalloc-1", revertible: true},
+		{line: "create elasticip", result: "eipalloc-1", params: map[string]ast.CompositeValue{"reuse": ast.NewInterfaceValue(true)}, revertible: false},
+		{line: "create tag", params: map[string]ast.CompositeValue{"resource": ast.NewInterfaceValue("arn:aws:s3:::my-bucket")}, revertible: true},
+		{line: "create tag", params: map[string]ast.CompositeValue{"query": ast.NewInterfaceValue("Env=staging")}, revertible: false},
+		{line: "update securitygroup", params: map[string]ast.CompositeValue{"inbound": ast.NewInterfaceValue("authorize")}, revertible: true},
+		{line: "update securitygroup", params: map[string]ast.CompositeValue{"inbound-rules": ast.NewInterfaceValue("tcp:443:0.0.0.0/0")}, revertible: false},
 	}
 
 	for _, tc := range tcases {
@@ -425,3 +588,63 @@ func TestCmdNodeIsRevertible(t *testing.T) {
 		}
 	}
 }
+
+func TestRevertStatements(t *testing.T) {
+	newExecuted := func() *Template {
+		tpl := MustParse("create vpc cidr=10.0.0.0/16\ncreate subnet cidr=10.0.0.0/24 vpc=vpc-1\ncreate instance subnet=sub-1 type=t2.micro\ncreate tag key=Env resource=i-1 value=prod\ncreate keypair name=mykey")
+		results := []string{"vpc-1", "sub-1", "i-1", "", "mykey"}
+		for i, cmd := range tpl.CommandNodesIterator() {
+			cmd.CmdResult = results[i]
+		}
+		return tpl
+	}
+
+	tcases := []struct {
+		only        []int
+		exp, expErr string
+	}{
+		{only: []int{5}, exp: "delete keypair name=mykey"},
+		{only: []int{4}, exp: "delete tag key=Env resource=i-1 value=prod"},
+		{only: []int{3, 4}, exp: "delete tag key=Env resource=i-1 value=prod\ndelete instance id=i-1"},
+		{only: []int{2, 3, 4}, exp: "delete tag key=Env resource=i-1 value=prod\ndelete instance id=i-1\ncheck instance id=i-1 state=terminated timeout=180\ndelete subnet id=sub-1"},
+		{only: []int{3}, expErr: "statement 4 'create tag key=Env resource=i-1 value=prod' still needs i-1 created by statement 3"},
+		{only: []int{1, 3, 4}, expErr: "statement 2 'create subnet cidr=10.0.0.0/24 vpc=vpc-1' still needs vpc-1 created by statement 1"},
+		{only: []int{6}, expErr: "no statement 6"},
+	}
+	for i, tcase := range tcases {
+		reverted, err := newExecuted().RevertStatements(tcase.only, nil)
+		if tcase.expErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tcase.expErr) {
+				t.Fatalf("%d: got %v, want error containing %s", i+1, err, tcase.expErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := reverted.String(), tcase.exp; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+
+	failed := newExecuted()
+	failed.CommandNodesIterator()[3].CmdErr = errors.New("tagging failed")
+	if _, err := failed.RevertStatements([]int{3}, nil); err != nil {
+		t.Fatalf("failed statements do not need resources, got %s", err)
+	}
+	if _, err := failed.RevertStatements([]int{4}, nil); err == nil || !strings.Contains(err.Error(), "not revertible") {
+		t.Fatalf("got %v, want not revertible error", err)
+	}
+
+	g := graph.NewGraph()
+	sub, inst, other := resourcetest.Subnet("sub-1").Build(), resourcetest.Instance("i-1").Build(), resourcetest.Instance("i-2").Build()
+	g.AddResource(sub, inst, other)
+	g.AddParentRelation(sub, inst)
+	if _, err := newExecuted().RevertStatements([]int{2, 3, 4}, g); err != nil {
+		t.Fatalf("resources of the template are reverted with the subnet, got %s", err)
+	}
+	g.AddParentRelation(sub, other)
+	if _, err := newExecuted().RevertStatements([]int{2, 3, 4}, g); err == nil || !strings.Contains(err.Error(), "instance i-2 (child) still needs sub-1 created by statement 2") {
+		t.Fatalf("got %v, want dependent error", err)
+	}
+}
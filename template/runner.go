@@ -14,11 +14,26 @@ type Runner struct {
 	Locale, Profile, Message, TemplatePath string
 	Log                                    *logger.Logger
 	Fillers                                []map[string]interface{}
-	AliasFunc                              func(paramPath, alias string) string
-	MissingHolesFunc                       func(string, []string, bool) string
-	CmdLookuper                            func(tokens ...string) interface{}
-	Validators                             []Validator
-	ParamsSuggested                        int
+	// Defaults of params per entity (ex: instance.type), set when absent from commands
+	Defaults         map[string]interface{}
+	AliasFunc        func(paramPath, alias string) string
+	MissingHolesFunc func(string, []string, bool) string
+	BuiltinFunc      func(string) (string, error)
+	CmdLookuper      func(tokens ...string) interface{}
+	Validators       []Validator
+	ParamsSuggested  int
+	HookScripts      []string
+	UpdateOf         *TemplateExecution
+	// RevertOf is the ID of the run reverted, RevertedStatements the positions of its reverted statements (all when empty)
+	RevertOf           string
+	RevertedStatements []int
+	// SucceededKeys returns the results of the statements per idempotency key
+	// which already succeeded with the profile and region
+	SucceededKeys func(profile, region string) (map[string]*env.Result, error)
+	// Progress receives the events of the run (see NewProgressWriter)
+	Progress func(*ProgressEvent)
+	// ReturnErrors returns an error when statements failed instead of exiting (ex: interactive shell)
+	ReturnErrors bool
 
 	BeforeRun func(*TemplateExecution) (bool, error)
 	AfterRun  func(*TemplateExecution) error
@@ -31,22 +46,79 @@ func (ru *Runner) Run() error {
 		Locale:   ru.Locale,
 		Profile:  ru.Profile,
 		Source:   ru.Template.String(),
+
+		RevertOf:           ru.RevertOf,
+		RevertedStatements: ru.RevertedStatements,
 	}
 	tplExec.SetMessage(ru.Message)
 
-	cenv := NewEnv().WithAliasFunc(ru.AliasFunc).WithMissingHolesFunc(ru.MissingHolesFunc).
+	missingHolesFunc := ru.MissingHolesFunc
+	if ru.Progress != nil && missingHolesFunc != nil {
+		missingHolesFunc = func(hole string, paramPaths []string, optional bool) string {
+			ru.Progress(&ProgressEvent{Event: HoleNeededEvent, Hole: hole, ParamPaths: paramPaths, Optional: optional})
+			return ru.MissingHolesFunc(hole, paramPaths, optional)
+		}
+	}
+	ru.progress(&ProgressEvent{Event: CompileStartedEvent, Statements: len(ru.Template.Statements)})
+
+	cenv := NewEnv().WithAliasFunc(ru.AliasFunc).WithMissingHolesFunc(missingHolesFunc).WithBuiltinFunc(ru.BuiltinFunc).
 		WithLookupCommandFunc(ru.CmdLookuper).WithLog(ru.Log).WithParamsMode(ru.ParamsSuggested).Build()
 	cenv.Push(env.FILLERS, ru.Fillers...)
+	cenv.Push(env.ENTITY_DEFAULTS, ru.Defaults)
 
 	var err error
 	tplExec.Template, cenv, err = Compile(tplExec.Template, cenv, NewRunnerCompileMode)
+	ru.progress(&ProgressEvent{Event: CompileDoneEvent, Statements: len(tplExec.Template.Statements), Error: errorString(err)})
 	if err != nil {
 		return err
 	}
 
 	tplExec.Fillers = cenv.Get(env.PROCESSED_FILLERS)
+	tplExec.Aliases = resolvedAliases(cenv.Get(env.RESOLVED_ALIASES))
+	tplExec.Defaults = appliedDefaults(cenv.Get(env.APPLIED_DEFAULTS))
+
+	if ru.UpdateOf != nil {
+		plan, err := tplExec.Template.UpdateOf(ru.UpdateOf.Template, ru.CmdLookuper)
+		if err == ErrUpToDate {
+			logger.Infof("Nothing to do: resources of run %s are up to date", ru.UpdateOf.ID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		logger.Verbosef("Converging resources of run %s with:\n\n%s\n", ru.UpdateOf.ID, plan)
+		cenv = NewEnv().WithLookupCommandFunc(ru.CmdLookuper).WithLog(ru.Log).Build()
+		if tplExec.Template, cenv, err = Compile(plan, cenv, NewRunnerCompileMode); err != nil {
+			return err
+		}
+		tplExec.Source = tplExec.Template.String()
+	}
 
 	errs := tplExec.Template.Validate(ru.Validators...)
+	if HasLockedResources(errs) {
+		for _, err := range errs {
+			if _, ok := err.(*LockedResourceError); ok {
+				logger.Error(err)
+			}
+		}
+		return errors.New("template targets locked resources")
+	}
+	if HasUnavailableAPIs(errs) {
+		for _, err := range errs {
+			if _, ok := err.(*UnavailableAPIError); ok {
+				logger.Error(err)
+			}
+		}
+		return fmt.Errorf("template uses services not available in region %s", ru.Locale)
+	}
+	if HasDeniedPermissions(errs) {
+		for _, err := range errs {
+			if _, ok := err.(*DeniedPermissionError); ok {
+				logger.Error(err)
+			}
+		}
+		return errors.New("template statements would be denied by the IAM policies of the caller")
+	}
 	if len(errs) > 0 {
 		for _, err := range errs {
 			logger.Warning(err)
@@ -61,7 +133,19 @@ func (ru *Runner) Run() error {
 	}
 
 	renv := NewRunEnv(cenv)
-	if _, err = tplExec.Template.DryRun(renv); err != nil {
+	renv.Metadata().Profile, renv.Metadata().Region = ru.Profile, ru.Locale
+	renv.(*runEnv).hooks = ru.HookScripts
+	renv.(*runEnv).progress = ru.Progress
+	if ru.SucceededKeys != nil && hasIdempotencyKeys(tplExec.Template) {
+		keys, err := ru.SucceededKeys(ru.Profile, ru.Locale)
+		if err != nil {
+			return fmt.Errorf("cannot load idempotency keys of previous runs: %s", err)
+		}
+		renv.(*runEnv).succeededKeys = keys
+	}
+	_, err = tplExec.Template.DryRun(renv)
+	ru.progress(&ProgressEvent{Event: DryRunDoneEvent, Error: errorString(err)})
+	if err != nil {
 		switch t := err.(type) {
 		case *Errors:
 			errs, _ := t.Errors()
@@ -78,20 +162,42 @@ func (ru *Runner) Run() error {
 	if err != nil {
 		return err
 	}
+	if !ok {
+		ru.progress(&ProgressEvent{Event: RunDoneEvent, Outcome: CanceledOutcome})
+	}
 
 	if ok {
 		tplExec.Template, err = tplExec.Template.Run(renv)
 		if err != nil {
 			logger.Errorf("Running template error: %s", err)
 		}
+		ru.progress(&ProgressEvent{Event: RunDoneEvent, RunID: tplExec.ID, Statements: len(tplExec.Template.Statements), Outcome: tplExec.Report().Outcome, Error: errorString(err)})
 		if err := ru.AfterRun(tplExec); err != nil {
 			return err
 		}
 	}
 
-	if tplExec.Stats().KOCount > 0 {
+	if stats := tplExec.Stats(); stats.KOCount > 0 {
+		if ru.ReturnErrors {
+			return fmt.Errorf("%d/%d statement(s) failed", stats.KOCount, stats.CmdCount)
+		}
 		os.Exit(1)
 	}
 
 	return nil
 }
+
+func (ru *Runner) progress(e *ProgressEvent) {
+	if ru.Progress != nil {
+		ru.Progress(e)
+	}
+}
+
+func hasIdempotencyKeys(tpl *Template) bool {
+	for _, cmd := range tpl.CommandNodesIterator() {
+		if cmd.IdempotencyKey != "" {
+			return true
+		}
+	}
+	return false
+}
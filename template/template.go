@@ -19,7 +19,9 @@ package template
 import (
 	"crypto/rand"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/fatih/color"
@@ -62,44 +64,157 @@ func (s *Template) Run(renv env.Running) (*Template, error) {
 	current := &Template{AST: &ast.AST{}}
 	current.ID = ulid.MustNew(ulid.Timestamp(time.Now()), rand.Reader).String()
 
-	for _, sts := range s.Statements {
-		clone := sts.Clone()
-		current.Statements = append(current.Statements, clone)
-		switch n := clone.Node.(type) {
-		case *ast.CommandNode:
-			if stop := processCmdNode(renv, n, vars); stop {
-				return current, nil
+	meta := renv.Metadata()
+	resolved := meta.Variables
+	defer func() { meta.Variables = resolved }()
+	meta.RunID = current.ID
+
+	for i := 0; i < len(s.Statements); {
+		// statements of a parallel block run concurrently (sequentially when dry running)
+		j := i + 1
+		if block := s.Statements[i].Block; block != 0 && !renv.IsDryRun() {
+			for j < len(s.Statements) && s.Statements[j].Block == block {
+				j++
 			}
-		case *ast.DeclarationNode:
-			ident := n.Ident
-			expr := n.Expr
-			switch n := expr.(type) {
-			case *ast.CommandNode:
-				if stop := processCmdNode(renv, n, vars); stop {
-					return current, nil
+		}
+		meta.StatementIndex = i
+		meta.Variables = mergeVariables(resolved, vars)
+
+		var clones []*ast.Statement
+		for _, sts := range s.Statements[i:j] {
+			clone := sts.Clone()
+			current.Statements = append(current.Statements, clone)
+			clones = append(clones, clone)
+		}
+
+		results := make([]*statementResult, len(clones))
+		if len(clones) == 1 {
+			results[0] = runStatement(renv, clones[0], vars)
+		} else {
+			var wg sync.WaitGroup
+			for k, clone := range clones {
+				wg.Add(1)
+				go func(k int, clone *ast.Statement) {
+					defer wg.Done()
+					results[k] = runStatement(renv, clone, vars)
+				}(k, clone)
+			}
+			wg.Wait()
+		}
+
+		var stop bool
+		for k, res := range results {
+			if res.err != nil {
+				return current, res.err
+			}
+			if res.ident != "" {
+				vars[res.ident] = res.value
+				for attr, v := range res.attributes {
+					vars[res.ident+"."+attr] = v
 				}
-				vars[ident] = n.Result()
-			default:
-				return current, fmt.Errorf("unknown type of node: %T", expr)
 			}
-		default:
-			return current, fmt.Errorf("unknown type of node: %T", clone.Node)
+			stop = stop || res.stop
+			meta.StatementIndex = i + k
+			meta.Variables = mergeVariables(resolved, vars)
+			if re, ok := renv.(*runEnv); ok {
+				re.statementDone(i+k, statementCommand(clones[k]))
+			}
+			renv.AfterStatement()
 		}
+		if stop {
+			return current, nil
+		}
+		i = j
 	}
 
 	return current, nil
 }
 
+func statementCommand(st *ast.Statement) *ast.CommandNode {
+	switch n := st.Node.(type) {
+	case *ast.CommandNode:
+		return n
+	case *ast.DeclarationNode:
+		cmd, _ := n.Expr.(*ast.CommandNode)
+		return cmd
+	}
+	return nil
+}
+
+type statementResult struct {
+	stop       bool
+	ident      string
+	value      interface{}
+	attributes map[string]string
+	err        error
+}
+
+// runStatement runs the command of the statement, returning the variable it declares if any.
+// Variables are only read so that statements can run concurrently.
+func runStatement(renv env.Running, st *ast.Statement, vars map[string]interface{}) *statementResult {
+	res := &statementResult{}
+	switch n := st.Node.(type) {
+	case *ast.CommandNode:
+		res.stop = processCmdNode(renv, n, vars)
+	case *ast.DeclarationNode:
+		switch expr := n.Expr.(type) {
+		case *ast.CommandNode:
+			if res.stop = processCmdNode(renv, expr, vars); !res.stop {
+				res.ident, res.value, res.attributes = n.Ident, expr.Result(), expr.CmdResultAttributes
+			}
+		default:
+			res.err = fmt.Errorf("unknown type of node: %T", n.Expr)
+		}
+	default:
+		res.err = fmt.Errorf("unknown type of node: %T", st.Node)
+	}
+	return res
+}
+
+func mergeVariables(maps ...map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, m := range maps {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
 func processCmdNode(renv env.Running, n *ast.CommandNode, vars map[string]interface{}) bool {
 	n.ProcessRefs(vars)
+	if re, ok := renv.(*runEnv); ok {
+		if res, succeeded := re.succeededResult(n.IdempotencyKey); succeeded {
+			n.CmdErr, n.Skipped = nil, true
+			if res.ID != "" {
+				n.CmdResult = res.ID
+			}
+			n.CmdResultAttributes = res.Attrs()
+			if !renv.IsDryRun() {
+				renv.Log().Infof("%s %s %s (key '%s' already succeeded)", color.New(color.FgYellow).Sprint("SKIPPED"), n.Action, n.Entity, n.IdempotencyKey)
+			}
+			return false
+		}
+	}
 	if renv.IsDryRun() {
+		n.ProcessRefs(dryRunAttributes(n.GetRefs(), vars))
 		n.CmdResult, n.CmdErr = n.Command.Run(renv, n.ToDriverParams())
+		if res, ok := n.CmdResult.(*env.Result); ok {
+			n.CmdResult, n.CmdResultAttributes = res.ID, res.Attrs()
+		}
 		n.CmdErr = prefixError(n.CmdErr, fmt.Sprintf("dry run: %s %s", n.Action, n.Entity))
 	} else {
-		n.CmdResult, n.CmdErr = n.Run(renv, n.ToDriverParams())
+		if refs := n.GetRefs(); len(refs) > 0 {
+			n.CmdErr = fmt.Errorf("unresolved reference(s): $%s", strings.Join(refs, ", $"))
+		} else {
+			n.CmdResult, n.CmdErr = n.Run(renv, n.ToDriverParams())
+		}
+		if res, ok := n.CmdResult.(*env.Result); ok {
+			n.CmdResult, n.CmdResultAttributes = res.ID, res.Attrs()
+		}
 		var res, status string
 		if n.CmdResult != nil {
-			res = " (" + color.New(color.FgCyan).Sprint(n.CmdResult) + ") "
+			res = " (" + color.New(color.FgCyan).Sprint(env.Summary(n.CmdResult, n.CmdResultAttributes)) + ") "
 		}
 		if n.CmdErr != nil {
 			status = color.New(color.FgRed).Sprint("KO")
@@ -114,6 +229,20 @@ func processCmdNode(renv env.Running, n *ast.CommandNode, vars map[string]interf
 	return n.CmdErr != nil
 }
 
+// dryRunAttributes returns placeholders for the references to the attributes of the results
+// of previous commands (ex: $inst.privateip), the dry runs returning no attributes
+func dryRunAttributes(refs []string, vars map[string]interface{}) map[string]interface{} {
+	placeholders := make(map[string]interface{})
+	for _, ref := range refs {
+		if variable, isAttr := resultAttributeRef(ref); isAttr {
+			if _, declared := vars[variable]; declared {
+				placeholders[ref] = "dryrun-" + strings.Replace(ref, ".", "-", -1)
+			}
+		}
+	}
+	return placeholders
+}
+
 func prefixError(err error, prefix string) error {
 	if err == nil {
 		return err
@@ -228,6 +357,42 @@ func (s *Template) CommandNodesReverseIterator() (nodes []*ast.CommandNode) {
 	return
 }
 
+// DeclaredResults returns the results of the succeeded commands assigned to variables (ex: inst = create instance ...)
+func (s *Template) DeclaredResults() map[string]interface{} {
+	results := make(map[string]interface{})
+	for _, decl := range s.declarationNodesIterator() {
+		if cmd, ok := decl.Expr.(*ast.CommandNode); ok && cmd.Err() == nil && cmd.Result() != nil {
+			results[decl.Ident] = cmd.Result()
+		}
+	}
+	return results
+}
+
+// WithVariables returns a copy of the template declaring first the values of the variables
+// it does not declare itself, so that its statements can reference them (ex: $inst)
+func (s *Template) WithVariables(vars map[string]interface{}) *Template {
+	declared := make(map[string]bool)
+	for _, decl := range s.declarationNodesIterator() {
+		declared[decl.Ident] = true
+	}
+	var idents []string
+	for ident := range vars {
+		if !declared[ident] {
+			idents = append(idents, ident)
+		}
+	}
+	sort.Strings(idents)
+
+	newTpl := &Template{ID: s.ID, AST: s.AST.Clone()}
+	var statements []*ast.Statement
+	for _, ident := range idents {
+		value := &ast.ValueNode{Value: ast.NewInterfaceValue(vars[ident])}
+		statements = append(statements, &ast.Statement{Node: &ast.DeclarationNode{Ident: ident, Expr: value}})
+	}
+	newTpl.Statements = append(statements, newTpl.Statements...)
+	return newTpl
+}
+
 func (s *Template) declarationNodesIterator() (nodes []*ast.DeclarationNode) {
 	for _, sts := range s.Statements {
 		switch n := sts.Node.(type) {
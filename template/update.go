@@ -0,0 +1,240 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/template/internal/ast"
+	"github.com/wallix/awless/template/params"
+)
+
+// ErrUpToDate is returned when the resources of a previous run already match a template
+var ErrUpToDate = errors.New("resources of the previous run are up to date")
+
+// UpdateOf compares this compiled template to the successful statements of a previous run
+// and returns the statements converging the resources created by the previous run:
+//   - a create matching a previous create (same entity, same name or else same order) with the same params is dropped,
+//     and the references to its declaration and to its result attributes (ex: $inst.privateip) are replaced
+//     by the ones of the resource created previously
+//   - a create with changed params becomes an update when all changed params are accepted by the update of the entity,
+//     otherwise the previous resource is deleted and created again
+//   - the previous resources not created anymore by this template are deleted
+//   - any other statement already run successfully is dropped
+//
+// Deletions come first, in the reverse order of the previous creations.
+//
+// The returned template has to be compiled.
+func (te *Template) UpdateOf(previous *Template, lookup func(...string) interface{}) (*Template, error) {
+	prevCreates := make(map[string][]*ast.CommandNode)
+	prevDone := make(map[string]bool)
+	for _, cmd := range previous.CommandNodesIterator() {
+		if cmd.CmdErr != nil {
+			continue
+		}
+		if res, ok := cmd.CmdResult.(string); cmd.Action == "create" && ok && res != "" {
+			prevCreates[cmd.Entity] = append(prevCreates[cmd.Entity], cmd)
+		} else {
+			prevDone[cmd.String()] = true
+		}
+	}
+	matched, recreated := make(map[*ast.CommandNode]bool), make(map[*ast.CommandNode]bool)
+
+	var lines []string
+	refs := make(map[string]interface{})
+	for _, st := range te.Statements {
+		var ident string
+		var cmd *ast.CommandNode
+		switch n := st.Node.(type) {
+		case *ast.CommandNode:
+			cmd = n
+		case *ast.DeclarationNode:
+			c, ok := n.Expr.(*ast.CommandNode)
+			if !ok {
+				return nil, fmt.Errorf("update: unexpected declaration of %T", n.Expr)
+			}
+			ident, cmd = n.Ident, c
+		default:
+			return nil, fmt.Errorf("update: unexpected statement %T", st.Node)
+		}
+
+		clone := (&ast.Statement{Node: cmd}).Clone().Node.(*ast.CommandNode)
+		clone.ProcessRefs(refs)
+		line := clone.String()
+		if ident != "" {
+			line = fmt.Sprintf("%s = %s", ident, line)
+		}
+
+		if cmd.Action != "create" {
+			if !prevDone[clone.String()] {
+				lines = append(lines, line)
+			}
+			continue
+		}
+
+		prev := matchingCreate(clone, prevCreates[cmd.Entity], matched)
+		if prev == nil {
+			lines = append(lines, line)
+			continue
+		}
+		matched[prev] = true
+
+		changed, removed := diffParams(prev, clone)
+		key, keys, updatable := updateKeys(lookup, cmd.Entity, changed)
+		switch {
+		case len(changed) == 0 && len(removed) == 0:
+			bindPreviousResult(refs, ident, prev)
+		case len(removed) == 0 && updatable:
+			bindPreviousResult(refs, ident, prev)
+			lines = append(lines, updateLine(prev, clone, key, keys))
+		default:
+			if !isRevertible(prev) {
+				return nil, fmt.Errorf("update: cannot delete previous resource to create it again: %s", prev)
+			}
+			recreated[prev] = true
+			lines = append(lines, line)
+		}
+	}
+
+	deletions := &Template{AST: &ast.AST{}}
+	for _, cmd := range previous.CommandNodesIterator() {
+		if cmd.Action != "create" {
+			continue
+		}
+		if recreated[cmd] || (!matched[cmd] && isRevertible(cmd)) {
+			if res, ok := cmd.CmdResult.(string); ok && res != "" {
+				deletions.Statements = append(deletions.Statements, &ast.Statement{Node: cmd})
+			}
+		}
+	}
+	if len(deletions.Statements) > 0 {
+		reverted, err := deletions.Revert()
+		if err != nil {
+			return nil, err
+		}
+		lines = append([]string{reverted.String()}, lines...)
+	}
+
+	if len(lines) == 0 {
+		return nil, ErrUpToDate
+	}
+
+	text := strings.Join(lines, "\n")
+	tpl, err := Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("update: \n%s\n%s", text, err)
+	}
+	return tpl, nil
+}
+
+// bindPreviousResult binds the declaration and the result attributes to the resource created previously
+func bindPreviousResult(refs map[string]interface{}, ident string, prev *ast.CommandNode) {
+	if ident == "" {
+		return
+	}
+	refs[ident] = prev.CmdResult
+	for attr, v := range prev.CmdResultAttributes {
+		refs[ident+"."+attr] = v
+	}
+}
+
+// matchingCreate returns the first previous create not matched yet with the same name,
+// or the first one not matched yet if the create has no name
+func matchingCreate(cmd *ast.CommandNode, creates []*ast.CommandNode, matched map[*ast.CommandNode]bool) *ast.CommandNode {
+	name, hasName := cmd.Params["name"]
+	for _, prev := range creates {
+		if matched[prev] {
+			continue
+		}
+		if !hasName {
+			return prev
+		}
+		if prevName, ok := prev.Params["name"]; ok && prevName.String() == name.String() {
+			return prev
+		}
+	}
+	return nil
+}
+
+func diffParams(prev, next *ast.CommandNode) (changed, removed []string) {
+	for k, v := range next.Params {
+		if prevV, ok := prev.Params[k]; !ok || prevV.String() != v.String() {
+			changed = append(changed, k)
+		}
+	}
+	for k := range prev.Params {
+		if _, ok := next.Params[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return
+}
+
+// updateKeys returns the params of the update of the entity converging the previous create to the new one,
+// if the update accepts the changed params
+func updateKeys(lookup func(...string) interface{}, entity string, changed []string) (string, []string, bool) {
+	if lookup == nil {
+		return "", nil, false
+	}
+	cmd, ok := lookup("update", entity).(ast.Command)
+	if !ok || cmd == nil {
+		return "", nil, false
+	}
+	rule := cmd.ParamsSpec().Rule()
+	required, optionals, _ := params.List(rule)
+	all := append(required, optionals...)
+
+	var key string
+	for _, k := range []string{"id", "name"} {
+		if contains(all, k) {
+			key = k
+			break
+		}
+	}
+	if key == "" {
+		return "", nil, false
+	}
+	keys := []string{key}
+	for _, k := range changed {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	if err := params.Run(rule, keys); err != nil {
+		return "", nil, false
+	}
+	return key, keys[1:], true
+}
+
+// updateLine returns the update of the previous resource, identified by its ID
+// or by the name it was created with
+func updateLine(prev, next *ast.CommandNode, key string, keys []string) string {
+	keyValue := quoteParamIfNeeded(prev.CmdResult)
+	if name, ok := prev.Params["name"]; ok && key == "name" {
+		keyValue = name.String()
+	}
+	params := []string{fmt.Sprintf("%s=%s", key, keyValue)}
+	for _, k := range keys {
+		params = append(params, fmt.Sprintf("%s=%s", k, next.Params[k].String()))
+	}
+	return fmt.Sprintf("update %s %s", next.Entity, strings.Join(params, " "))
+}
@@ -0,0 +1,91 @@
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
+)
+
+type mockUpdateCommand struct{ spec params.Spec }
+
+func (c *mockUpdateCommand) ParamsSpec() params.Spec { return c.spec }
+func (c *mockUpdateCommand) Run(env.Running, map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func TestUpdateOf(t *testing.T) {
+	lookup := func(tokens ...string) interface{} {
+		switch strings.Join(tokens, " ") {
+		case "update subnet":
+			return &mockUpdateCommand{spec: params.NewSpec(params.AllOf(params.Key("id"), params.Opt("public")))}
+		case "update queue":
+			return &mockUpdateCommand{spec: params.NewSpec(params.AllOf(params.Key("name"), params.Opt("delay")))}
+		}
+		return nil
+	}
+	previous := MustParse("create vpc cidr=10.0.0.0/16\ncreate subnet cidr=10.0.0.0/24 vpc=vpc-1\ncreate subnet cidr=10.0.1.0/24 vpc=vpc-1\nattach internetgateway id=igw-1 vpc=vpc-1\ncreate keypair name=old")
+	for i, cmd := range previous.CommandNodesIterator() {
+		cmd.CmdResult = []interface{}{"vpc-1", "subnet-1", "subnet-2", nil, "old"}[i]
+	}
+
+	tcases := []struct {
+		in, exp string
+	}{
+		{
+			in: "vpc = create vpc cidr=10.0.0.0/16\ncreate subnet cidr=10.0.0.0/24 vpc=$vpc\ncreate subnet cidr=10.0.1.0/24 vpc=$vpc\nattach internetgateway id=igw-1 vpc=$vpc\ncreate keypair name=old",
+		},
+		{
+			in:  "vpc = create vpc cidr=10.0.0.0/16\ncreate subnet cidr=10.0.0.0/24 public=true vpc=$vpc\ncreate subnet cidr=10.0.2.0/24 vpc=$vpc\nattach internetgateway id=igw-1 vpc=$vpc\ncreate instance subnet=subnet-1",
+			exp: "delete keypair name=old\ndelete subnet id=subnet-2\nupdate subnet id=subnet-1 public=true\ncreate subnet cidr=10.0.2.0/24 vpc=vpc-1\ncreate instance subnet=subnet-1",
+		},
+		{
+			in:  "vpc = create vpc cidr=10.1.0.0/16\ncreate subnet cidr=10.0.0.0/24 vpc=$vpc\nattach internetgateway id=igw-1 vpc=$vpc\ncreate keypair name=old",
+			exp: "delete subnet id=subnet-2\ndelete subnet id=subnet-1\ndelete vpc id=vpc-1\nvpc = create vpc cidr=10.1.0.0/16\ncreate subnet cidr=10.0.0.0/24 vpc=$vpc\nattach internetgateway id=igw-1 vpc=$vpc",
+		},
+	}
+
+	for i, tcase := range tcases {
+		tpl, err := MustParse(tcase.in).UpdateOf(previous, lookup)
+		if tcase.exp == "" {
+			if got, want := err, ErrUpToDate; got != want {
+				t.Fatalf("%d: got %v, want %v", i+1, got, want)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%d: %s", i+1, err)
+		}
+		if got, want := tpl.String(), tcase.exp; got != want {
+			t.Fatalf("%d: got\n%s\nwant\n%s", i+1, got, want)
+		}
+	}
+
+	t.Run("update by name", func(t *testing.T) {
+		previous := MustParse("create queue name=jobs delay=10")
+		previous.CommandNodesIterator()[0].CmdResult = "https://sqs.eu-west-1.amazonaws.com/0123/jobs"
+
+		tpl, err := MustParse("create queue name=jobs delay=20").UpdateOf(previous, lookup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := tpl.String(), "update queue delay=20 name=jobs"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("result attributes", func(t *testing.T) {
+		previous := MustParse("create instance name=web")
+		cmd := previous.CommandNodesIterator()[0]
+		cmd.CmdResult, cmd.CmdResultAttributes = "inst-1", map[string]string{"privateip": "10.0.0.5"}
+
+		tpl, err := MustParse("inst = create instance name=web\ncreate tag resource=$inst key=ip value=$inst.privateip").UpdateOf(previous, lookup)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := tpl.String(), "create tag key=ip resource=inst-1 value=10.0.0.5"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
 )
 
 type Validator interface {
@@ -60,3 +63,259 @@ func (v *ParamIsSetValidator) Execute(t *Template) (errs []error) {
 	}
 	return
 }
+
+// LockTagKey is the tag protecting a resource from being modified by templates
+const LockTagKey = "awless:locked"
+
+// LockedResourceError is returned when a statement targets a locked resource.
+// Templates with such errors must not be run.
+type LockedResourceError struct {
+	Action, Entity, ID, Reason string
+}
+
+func (e *LockedResourceError) Error() string {
+	return fmt.Sprintf("%s %s: resource '%s' is locked (%s)", e.Action, e.Entity, e.ID, e.Reason)
+}
+
+// LockedResourcesValidator fails the statements targeting locked resources: the resources
+// tagged with `awless:locked` in the graph and the resources of the lock list.
+// Creates only target the resource given as param when creating a tag.
+type LockedResourcesValidator struct {
+	LockedIDs []string
+	Graph     func() cloud.GraphAPI
+}
+
+func (v *LockedResourcesValidator) Execute(t *Template) (errs []error) {
+	var g cloud.GraphAPI
+	for _, cmd := range t.CommandNodesIterator() {
+		if cmd.Action == "create" && cmd.Entity != "tag" {
+			continue
+		}
+		for _, id := range targetedIDs(cmd.ToDriverParams()) {
+			if contains(v.LockedIDs, id) {
+				errs = append(errs, &LockedResourceError{Action: cmd.Action, Entity: cmd.Entity, ID: id, Reason: "in lock list"})
+				continue
+			}
+			if v.Graph == nil {
+				continue
+			}
+			if g == nil {
+				g = v.Graph()
+			}
+			resources, err := g.FindWithProperties(map[string]interface{}{properties.ID: id})
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, r := range resources {
+				if isLockTagged(r) {
+					errs = append(errs, &LockedResourceError{Action: cmd.Action, Entity: cmd.Entity, ID: id, Reason: fmt.Sprintf("tagged %s", LockTagKey)})
+					break
+				}
+			}
+		}
+	}
+	return
+}
+
+// HasLockedResources returns true if one of the errors is a LockedResourceError
+func HasLockedResources(errs []error) bool {
+	for _, err := range errs {
+		if _, ok := err.(*LockedResourceError); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingResourceError is returned when a statement targets a resource absent from the graph
+type MissingResourceError struct {
+	Action, Entity, ID string
+}
+
+func (e *MissingResourceError) Error() string {
+	return fmt.Sprintf("%s %s: resource '%s' not found", e.Action, e.Entity, e.ID)
+}
+
+// ExistingResourcesValidator fails the statements whose id param targets a resource
+// absent from the graph of the entity. Creates, entities without graph and references
+// to resources created by the template are not validated.
+type ExistingResourcesValidator struct {
+	LookupGraph LookupGraphFunc
+}
+
+func (v *ExistingResourcesValidator) Execute(t *Template) (errs []error) {
+	for _, cmd := range t.CommandNodesIterator() {
+		if cmd.Action == "create" {
+			continue
+		}
+		id, ok := cmd.ToDriverParams()["id"]
+		if !ok {
+			continue
+		}
+		g, ok := v.LookupGraph(cmd.Entity)
+		if !ok {
+			continue
+		}
+		for _, id := range targetedIDs(map[string]interface{}{"id": id}) {
+			resources, err := g.FindWithProperties(map[string]interface{}{properties.ID: id})
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if len(resources) == 0 {
+				errs = append(errs, &MissingResourceError{Action: cmd.Action, Entity: cmd.Entity, ID: id})
+			}
+		}
+	}
+	return
+}
+
+// UnavailableAPIError is returned when a statement needs an API not offered in the region of the run.
+// Templates with such errors must not be run.
+type UnavailableAPIError struct {
+	Action, Entity, API, Region string
+}
+
+func (e *UnavailableAPIError) Error() string {
+	return fmt.Sprintf("%s %s: %s is not available in region %s", e.Action, e.Entity, e.API, e.Region)
+}
+
+// UnknownAPIAvailabilityError warns that a statement needs an API whose availability
+// in the region of the run cannot be told (ex: region more recent than the catalog)
+type UnknownAPIAvailabilityError struct {
+	Action, Entity, API, Region string
+}
+
+func (e *UnknownAPIAvailabilityError) Error() string {
+	return fmt.Sprintf("%s %s: cannot tell whether %s is available in region %s", e.Action, e.Entity, e.API, e.Region)
+}
+
+// RegionAPIsValidator fails the statements whose entity is managed by an API
+// known to be not available in the region, and warns when its availability is unknown
+type RegionAPIsValidator struct {
+	Region          string
+	APIPerEntity    map[string]string
+	APIAvailability func(api, region string) (available bool, known bool)
+}
+
+func (v *RegionAPIsValidator) Execute(t *Template) (errs []error) {
+	if v.Region == "" || v.APIAvailability == nil {
+		return
+	}
+	for _, cmd := range t.CommandNodesIterator() {
+		api, ok := v.APIPerEntity[cmd.Entity]
+		if !ok {
+			continue
+		}
+		switch available, known := v.APIAvailability(api, v.Region); {
+		case !known:
+			errs = append(errs, &UnknownAPIAvailabilityError{Action: cmd.Action, Entity: cmd.Entity, API: api, Region: v.Region})
+		case !available:
+			errs = append(errs, &UnavailableAPIError{Action: cmd.Action, Entity: cmd.Entity, API: api, Region: v.Region})
+		}
+	}
+	return
+}
+
+// HasUnavailableAPIs returns true if one of the errors is an UnavailableAPIError
+func HasUnavailableAPIs(errs []error) bool {
+	for _, err := range errs {
+		if _, ok := err.(*UnavailableAPIError); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// DeniedPermissionError is returned when the IAM policies of the caller do not allow
+// the AWS call of a statement. Templates with such errors must not be run.
+type DeniedPermissionError struct {
+	Statement, Action, Decision string
+}
+
+func (e *DeniedPermissionError) Error() string {
+	return fmt.Sprintf("%s: %s is denied (%s)", e.Statement, e.Action, e.Decision)
+}
+
+// PermissionsValidator simulates the IAM policies of the caller for the action
+// of each statement, failing the statements whose action is not allowed
+type PermissionsValidator struct {
+	// IAMAction returns the IAM action of the AWS call of a command (ex: ec2:RunInstances)
+	IAMAction func(cmd interface{}) (string, bool)
+	// Simulate returns the decision (allowed, explicitDeny, implicitDeny) per action
+	Simulate func(actions []string) (map[string]string, error)
+}
+
+func (v *PermissionsValidator) Execute(t *Template) (errs []error) {
+	if v.IAMAction == nil || v.Simulate == nil {
+		return
+	}
+	var actions []string
+	unique := make(map[string]bool)
+	for _, cmd := range t.CommandNodesIterator() {
+		if action, ok := v.IAMAction(cmd.Command); ok && !unique[action] {
+			unique[action] = true
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) == 0 {
+		return
+	}
+	decisions, err := v.Simulate(actions)
+	if err != nil {
+		return []error{fmt.Errorf("cannot simulate permissions: %s", err)}
+	}
+	for _, cmd := range t.CommandNodesIterator() {
+		action, ok := v.IAMAction(cmd.Command)
+		if !ok {
+			continue
+		}
+		if decision := decisions[action]; decision != "allowed" {
+			if decision == "" {
+				decision = "not simulated"
+			}
+			errs = append(errs, &DeniedPermissionError{Statement: cmd.String(), Action: action, Decision: decision})
+		}
+	}
+	return
+}
+
+// HasDeniedPermissions returns true if one of the errors is a DeniedPermissionError
+func HasDeniedPermissions(errs []error) bool {
+	for _, err := range errs {
+		if _, ok := err.(*DeniedPermissionError); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func targetedIDs(params map[string]interface{}) (ids []string) {
+	for _, v := range params {
+		switch vv := v.(type) {
+		case string:
+			ids = append(ids, vv)
+		case []interface{}:
+			for _, e := range vv {
+				if s, ok := e.(string); ok {
+					ids = append(ids, s)
+				}
+			}
+		case []string:
+			ids = append(ids, vv...)
+		}
+	}
+	sort.Strings(ids)
+	return
+}
+
+func isLockTagged(r cloud.Resource) bool {
+	tags, _ := r.Properties()[properties.Tags].([]string)
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, LockTagKey+"=") && strings.TrimPrefix(tag, LockTagKey+"=") != "false" {
+			return true
+		}
+	}
+	return false
+}
@@ -1,12 +1,16 @@
 package template_test
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/graph/resourcetest"
 	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/env"
+	"github.com/wallix/awless/template/params"
 )
 
 func TestValidation(t *testing.T) {
@@ -61,4 +65,150 @@ func TestValidation(t *testing.T) {
 			t.Fatalf("got %d, want %d", got, want)
 		}
 	})
+
+	t.Run("Run locked resources", func(t *testing.T) {
+		g := graph.NewGraph()
+		g.AddResource(
+			resourcetest.Instance("inst_1").Prop("Tags", []string{"awless:locked=true"}).Build(),
+			resourcetest.Instance("inst_2").Prop("Tags", []string{"awless:locked=false"}).Build(),
+			resourcetest.Instance("inst_3").Build(),
+		)
+		tpl := template.MustParse("stop instance id=inst_1\nstop instance id=inst_2\ndelete keypair name=mykey\ncreate tag resource=inst_1 key=env value=prod\ncreate instance subnet=inst_1")
+
+		rule := &template.LockedResourcesValidator{LockedIDs: []string{"mykey"}, Graph: func() cloud.GraphAPI { return g }}
+
+		errs := tpl.Validate(rule)
+		if !template.HasLockedResources(errs) {
+			t.Fatal("expected locked resources")
+		}
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		exp := []string{
+			"stop instance: resource 'inst_1' is locked (tagged awless:locked)",
+			"delete keypair: resource 'mykey' is locked (in lock list)",
+			"create tag: resource 'inst_1' is locked (tagged awless:locked)",
+		}
+		if got, want := msgs, exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("Region APIs", func(t *testing.T) {
+		tpl := template.MustParse("create instance subnet=sub-1\ncreate function name=fn\ndelete function id=fn")
+
+		rule := &template.RegionAPIsValidator{
+			Region:          "cn-northwest-1",
+			APIPerEntity:    map[string]string{"instance": "ec2", "function": "lambda"},
+			APIAvailability: func(api, region string) (bool, bool) { return api != "lambda", true },
+		}
+
+		errs := tpl.Validate(rule)
+		if !template.HasUnavailableAPIs(errs) {
+			t.Fatal("expected unavailable APIs")
+		}
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		exp := []string{
+			"create function: lambda is not available in region cn-northwest-1",
+			"delete function: lambda is not available in region cn-northwest-1",
+		}
+		if got, want := msgs, exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+
+		rule.Region = "eu-north-1"
+		rule.APIAvailability = func(api, region string) (bool, bool) { return api != "lambda", api != "lambda" }
+		errs = tpl.Validate(rule)
+		if template.HasUnavailableAPIs(errs) {
+			t.Fatal("expected unknown availability to only warn")
+		}
+		msgs = nil
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		exp = []string{
+			"create function: cannot tell whether lambda is available in region eu-north-1",
+			"delete function: cannot tell whether lambda is available in region eu-north-1",
+		}
+		if got, want := msgs, exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("Permissions", func(t *testing.T) {
+		tpl := template.MustParse("create instance subnet=sub-1\ncreate function name=fn\ndelete function id=fn\ncreate tag resource=fn key=env value=prod")
+		actions := []iamActionCommand{"ec2:RunInstances", "lambda:CreateFunction", "lambda:DeleteFunction", ""}
+		for i, cmd := range tpl.CommandNodesIterator() {
+			cmd.Command = actions[i]
+		}
+
+		var simulated []string
+		rule := &template.PermissionsValidator{
+			IAMAction: func(cmd interface{}) (string, bool) {
+				action := string(cmd.(iamActionCommand))
+				return action, action != ""
+			},
+			Simulate: func(actions []string) (map[string]string, error) {
+				simulated = actions
+				return map[string]string{"ec2:RunInstances": "allowed", "lambda:CreateFunction": "implicitDeny"}, nil
+			},
+		}
+
+		errs := tpl.Validate(rule)
+		if !template.HasDeniedPermissions(errs) {
+			t.Fatal("expected denied permissions")
+		}
+		if got, want := simulated, []string{"ec2:RunInstances", "lambda:CreateFunction", "lambda:DeleteFunction"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		var msgs []string
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		exp := []string{
+			"create function name=fn: lambda:CreateFunction is denied (implicitDeny)",
+			"delete function id=fn: lambda:DeleteFunction is denied (not simulated)",
+		}
+		if got, want := msgs, exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+
+		rule.Simulate = func(actions []string) (map[string]string, error) { return nil, errors.New("access denied") }
+		errs = tpl.Validate(rule)
+		if template.HasDeniedPermissions(errs) {
+			t.Fatal("expected no denied permissions when the simulation fails")
+		}
+		if got, want := len(errs), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+	t.Run("Existing resources", func(t *testing.T) {
+		tpl := template.MustParse("web = create instance subnet=sub-1 name=web\nstop instance id=[inst_1,inst_2]\nstart instance id=$web\ndelete keypair id=mykey\ndelete volume id=vol-1\ncreate tag resource=inst_3 key=env value=prod")
+
+		g := graph.NewGraph()
+		g.AddResource(resourcetest.Instance("inst_1").Build())
+		rule := &template.ExistingResourcesValidator{LookupGraph: func(key string) (cloud.GraphAPI, bool) { return g, key != "volume" }}
+
+		var msgs []string
+		for _, err := range tpl.Validate(rule) {
+			msgs = append(msgs, err.Error())
+		}
+		exp := []string{
+			"stop instance: resource 'inst_2' not found",
+			"delete keypair: resource 'mykey' not found",
+		}
+		if got, want := msgs, exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+}
+
+// iamActionCommand is a command whose IAM action is its value
+type iamActionCommand string
+
+func (c iamActionCommand) ParamsSpec() params.Spec { return nil }
+func (c iamActionCommand) Run(env.Running, map[string]interface{}) (interface{}, error) {
+	return nil, nil
 }